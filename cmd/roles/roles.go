@@ -51,6 +51,7 @@ import (
 	rocksmqimpl "github.com/milvus-io/milvus/pkg/mq/mqimpl/rocksmq/server"
 	"github.com/milvus-io/milvus/pkg/mq/msgstream/mqwrapper/nmq"
 	"github.com/milvus-io/milvus/pkg/tracer"
+	"github.com/milvus-io/milvus/pkg/util"
 	"github.com/milvus-io/milvus/pkg/util/etcd"
 	"github.com/milvus-io/milvus/pkg/util/expr"
 	"github.com/milvus-io/milvus/pkg/util/gc"
@@ -336,6 +337,16 @@ func (mr *MilvusRoles) handleSignals() func() {
 	}
 }
 
+// checkMetaStore checks whether the meta store could be used in the deploy mode.
+// The sqlite database file can only be accessed by a single process, so it's not permitted in cluster mode.
+func checkMetaStore(local bool) error {
+	metaType := paramtable.Get().MetaStoreCfg.MetaStoreType.GetValue()
+	if metaType == util.MetaStoreTypeSQLite && !local {
+		return fmt.Errorf("meta store %s is only supported in standalone mode", metaType)
+	}
+	return nil
+}
+
 // Run Milvus components.
 func (mr *MilvusRoles) Run() {
 	// start signal handler, defer close func
@@ -393,6 +404,10 @@ func (mr *MilvusRoles) Run() {
 		paramtable.SetRole(mr.ServerType)
 	}
 
+	if err := checkMetaStore(mr.Local); err != nil {
+		panic(err)
+	}
+
 	// Initialize streaming service if enabled.
 	if streamingutil.IsStreamingServiceEnabled() {
 		streaming.Init()
@@ -24,6 +24,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 
+	"github.com/milvus-io/milvus/pkg/util"
 	"github.com/milvus-io/milvus/pkg/util/paramtable"
 )
 
@@ -65,6 +66,17 @@ func TestRoles(t *testing.T) {
 	assert.Equal(t, true, os.IsNotExist(err))
 }
 
+func TestCheckMetaStore(t *testing.T) {
+	paramtable.Init()
+	assert.NoError(t, checkMetaStore(true))
+	assert.NoError(t, checkMetaStore(false))
+
+	paramtable.Get().Save(paramtable.Get().MetaStoreCfg.MetaStoreType.Key, util.MetaStoreTypeSQLite)
+	defer paramtable.Get().Reset(paramtable.Get().MetaStoreCfg.MetaStoreType.Key)
+	assert.NoError(t, checkMetaStore(true))
+	assert.Error(t, checkMetaStore(false))
+}
+
 func TestCleanLocalDir(t *testing.T) {
 	paramtable.Init()
 	rootPath := paramtable.Get().LocalStorageCfg.Path.GetValue()
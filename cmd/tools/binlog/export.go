@@ -0,0 +1,208 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path"
+
+	"github.com/samber/lo"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/schemapb"
+	"github.com/milvus-io/milvus/internal/json"
+	"github.com/milvus-io/milvus/internal/proto/datapb"
+	"github.com/milvus-io/milvus/internal/storage"
+	"github.com/milvus-io/milvus/internal/util/importutilv2/parquet"
+	"github.com/milvus-io/milvus/pkg/util/geo"
+)
+
+const (
+	exportJSON    = "json"
+	exportParquet = "parquet"
+)
+
+// readSegmentRows decodes the insert binlogs of the segment and calls fn for each batch of rows,
+// a batch is the rows of the binlogs with the same index, which are aligned among fields.
+// The deletions are not applied.
+func readSegmentRows(ctx context.Context, cm storage.ChunkManager, segment *datapb.SegmentInfo,
+	pkFieldID int64, fn func(rows []map[int64]any) error,
+) error {
+	batchCount := 0
+	for _, fieldBinlog := range segment.GetBinlogs() {
+		batchCount = len(fieldBinlog.GetBinlogs())
+		break
+	}
+	for idx := 0; idx < batchCount; idx++ {
+		paths := make([]string, 0, len(segment.GetBinlogs()))
+		for _, fieldBinlog := range segment.GetBinlogs() {
+			if idx >= len(fieldBinlog.GetBinlogs()) {
+				return fmt.Errorf("binlogs of field %d are not aligned", fieldBinlog.GetFieldID())
+			}
+			paths = append(paths, fieldBinlog.GetBinlogs()[idx].GetLogPath())
+		}
+		values, err := cm.MultiRead(ctx, paths)
+		if err != nil {
+			return err
+		}
+		blobs := lo.Map(values, func(v []byte, i int) *storage.Blob {
+			return &storage.Blob{Key: paths[i], Value: v}
+		})
+		reader, err := storage.NewBinlogDeserializeReader(blobs, pkFieldID)
+		if err != nil {
+			return err
+		}
+		rows := make([]map[int64]any, 0)
+		for {
+			err = reader.Next()
+			if err != nil {
+				break
+			}
+			// the value is reused by the reader
+			rows = append(rows, lo.Assign(reader.Value().Value.(map[int64]any)))
+		}
+		reader.Close()
+		if err != io.EOF {
+			return err
+		}
+		if err := fn(rows); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// exportSegmentToJSON writes the user fields of the rows as json lines.
+func exportSegmentToJSON(ctx context.Context, cm storage.ChunkManager, schema *schemapb.CollectionSchema,
+	segment *datapb.SegmentInfo, pkFieldID int64, filePath string,
+) error {
+	if err := os.MkdirAll(path.Dir(filePath), os.ModePerm); err != nil {
+		return err
+	}
+	f, err := os.Create(filePath)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	w := bufio.NewWriter(f)
+	encoder := json.NewEncoder(w)
+
+	fields := parquet.ExportFields(schema)
+	exported := 0
+	err = readSegmentRows(ctx, cm, segment, pkFieldID, func(rows []map[int64]any) error {
+		for _, row := range rows {
+			m := make(map[string]any, len(fields))
+			for _, field := range fields {
+				v, err := jsonValue(field, row[field.GetFieldID()])
+				if err != nil {
+					return fmt.Errorf("failed to convert field %s: %w", field.GetName(), err)
+				}
+				m[field.GetName()] = v
+			}
+			if err := encoder.Encode(m); err != nil {
+				return err
+			}
+		}
+		exported += len(rows)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	fmt.Printf("%d rows exported to %s\n", exported, filePath)
+	return nil
+}
+
+// jsonValue converts the value decoded from binlog to the one readable in json.
+func jsonValue(field *schemapb.FieldSchema, v any) (any, error) {
+	if v == nil {
+		return nil, nil
+	}
+	switch field.GetDataType() {
+	case schemapb.DataType_JSON:
+		return json.RawMessage(v.([]byte)), nil
+	case schemapb.DataType_Geometry:
+		return geo.WKBToWKT(v.([]byte))
+	case schemapb.DataType_Array:
+		return arrayValue(v.(*schemapb.ScalarField)), nil
+	default:
+		return v, nil
+	}
+}
+
+func arrayValue(sf *schemapb.ScalarField) any {
+	switch data := sf.GetData().(type) {
+	case *schemapb.ScalarField_BoolData:
+		return data.BoolData.GetData()
+	case *schemapb.ScalarField_IntData:
+		return data.IntData.GetData()
+	case *schemapb.ScalarField_LongData:
+		return data.LongData.GetData()
+	case *schemapb.ScalarField_FloatData:
+		return data.FloatData.GetData()
+	case *schemapb.ScalarField_DoubleData:
+		return data.DoubleData.GetData()
+	case *schemapb.ScalarField_StringData:
+		return data.StringData.GetData()
+	default:
+		return nil
+	}
+}
+
+// exportSegmentToParquet writes the user fields of the rows into a parquet file,
+// a row group for each batch of binlogs.
+func exportSegmentToParquet(ctx context.Context, cm storage.ChunkManager, schema *schemapb.CollectionSchema,
+	segment *datapb.SegmentInfo, pkFieldID int64, outputDir string,
+) error {
+	localCM := storage.NewLocalChunkManager(storage.RootPath(outputDir))
+	filePath := path.Join(outputDir, fmt.Sprintf("%d.parquet", segment.GetID()))
+	writer, err := parquet.NewWriter(ctx, localCM, schema, filePath)
+	if err != nil {
+		return err
+	}
+	exportSchema := &schemapb.CollectionSchema{
+		Fields: parquet.ExportFields(schema),
+	}
+	err = readSegmentRows(ctx, cm, segment, pkFieldID, func(rows []map[int64]any) error {
+		data, err := storage.NewInsertData(exportSchema)
+		if err != nil {
+			return err
+		}
+		for _, row := range rows {
+			for _, field := range exportSchema.GetFields() {
+				if err := data.Data[field.GetFieldID()].AppendRow(row[field.GetFieldID()]); err != nil {
+					return err
+				}
+			}
+		}
+		return writer.Write(data)
+	})
+	if err != nil {
+		return err
+	}
+	if err := writer.Close(); err != nil {
+		return err
+	}
+	fmt.Printf("%d rows exported to %s\n", writer.Rows(), filePath)
+	return nil
+}
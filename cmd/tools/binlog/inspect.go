@@ -0,0 +1,431 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
+	"github.com/milvus-io/milvus-proto/go-api/v2/schemapb"
+	"github.com/milvus-io/milvus/cmd/tools/metakv"
+	"github.com/milvus-io/milvus/internal/metastore/kv/binlog"
+	"github.com/milvus-io/milvus/internal/metastore/model"
+	"github.com/milvus-io/milvus/internal/proto/datapb"
+	"github.com/milvus-io/milvus/internal/storage"
+	"github.com/milvus-io/milvus/pkg/common"
+	"github.com/milvus-io/milvus/pkg/util/metautil"
+	"github.com/milvus-io/milvus/pkg/util/paramtable"
+	"github.com/milvus-io/milvus/pkg/util/typeutil"
+)
+
+// segmentInspector reads the binlogs of a segment through the chunk manager,
+// and checks them against the meta recorded by datacoord.
+type segmentInspector struct {
+	ctx     context.Context
+	cm      storage.ChunkManager
+	segment *datapb.SegmentInfo
+	pkField *schemapb.FieldSchema
+	deep    bool
+
+	w      io.Writer
+	issues int
+}
+
+// inspect is the entry of the `binlog inspect` sub command.
+func inspect(args []string) error {
+	flags := flag.NewFlagSet("inspect", flag.ExitOnError)
+	configPath := flags.String("config", "", "Path to the milvus configuration file")
+	segmentID := flags.Int64("segment", 0, "Segment ID to inspect")
+	deep := flags.Bool("deep", false, "Read the index files fully instead of checking their sizes only")
+	export := flags.String("export", "", "Export the decoded rows, json or parquet")
+	output := flags.String("output", ".", "Directory to write the exported file")
+	flags.Parse(args)
+
+	if *configPath == "" || *segmentID == 0 {
+		flags.Usage()
+		return fmt.Errorf("config file path and segment id are required")
+	}
+	if *export != "" && *export != exportJSON && *export != exportParquet {
+		return fmt.Errorf("unknown export format: %s", *export)
+	}
+
+	paramtable.Get().Init(paramtable.NewBaseTableFromYamlOnly(*configPath))
+	ctx := context.Background()
+
+	metaKV, err := metakv.New(paramtable.Get())
+	if err != nil {
+		return fmt.Errorf("failed to connect to meta store: %w", err)
+	}
+	defer metaKV.Close()
+	cm, err := storage.NewChunkManagerFactoryWithParam(paramtable.Get()).NewPersistentStorageChunkManager(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to connect to object storage: %w", err)
+	}
+
+	dataCoordCatalog := metaKV.DataCoordCatalog(cm.RootPath())
+	segments, err := dataCoordCatalog.ListSegments(ctx)
+	if err != nil {
+		return err
+	}
+	var segment *datapb.SegmentInfo
+	for _, s := range segments {
+		if s.GetID() == *segmentID {
+			segment = proto.Clone(s).(*datapb.SegmentInfo)
+			break
+		}
+	}
+	if segment == nil {
+		return fmt.Errorf("segment %d not found", *segmentID)
+	}
+	if err := decompressBinlogs(cm.RootPath(), segment); err != nil {
+		return err
+	}
+
+	rootCoordCatalog, err := metaKV.RootCoordCatalog()
+	if err != nil {
+		return err
+	}
+	coll, err := metakv.GetCollection(ctx, rootCoordCatalog, segment.GetCollectionID())
+	if err != nil {
+		return err
+	}
+	schema := &schemapb.CollectionSchema{
+		Name:   coll.Name,
+		Fields: model.MarshalFieldModels(coll.Fields),
+	}
+	pkField, err := typeutil.GetPrimaryFieldSchema(schema)
+	if err != nil {
+		return err
+	}
+
+	si := &segmentInspector{
+		ctx:     ctx,
+		cm:      cm,
+		segment: segment,
+		pkField: pkField,
+		deep:    *deep,
+		w:       os.Stdout,
+	}
+	fmt.Fprintf(si.w, "segment %d, collection %d, partition %d, channel %s, state %s, level %s, rows %d\n",
+		segment.GetID(), segment.GetCollectionID(), segment.GetPartitionID(), segment.GetInsertChannel(),
+		segment.GetState(), segment.GetLevel(), segment.GetNumOfRows())
+
+	for _, check := range []func() error{
+		si.checkInsertBinlogs,
+		si.checkDeltaLogs,
+		si.checkPkStats,
+		func() error { return si.checkIndexFiles(dataCoordCatalog.ListSegmentIndexes) },
+	} {
+		if err := check(); err != nil {
+			return err
+		}
+	}
+
+	switch *export {
+	case exportJSON:
+		err = exportSegmentToJSON(ctx, cm, schema, segment, pkField.GetFieldID(),
+			path.Join(*output, fmt.Sprintf("%d.json", segment.GetID())))
+	case exportParquet:
+		err = exportSegmentToParquet(ctx, cm, schema, segment, pkField.GetFieldID(), *output)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to export segment: %w", err)
+	}
+
+	fmt.Fprintf(si.w, "inspect complete, %d issues found\n", si.issues)
+	return nil
+}
+
+func decompressBinlogs(rootPath string, segment *datapb.SegmentInfo) error {
+	for binlogType, fieldBinlogs := range map[storage.BinlogType][]*datapb.FieldBinlog{
+		storage.InsertBinlog: segment.GetBinlogs(),
+		storage.DeleteBinlog: segment.GetDeltalogs(),
+		storage.StatsBinlog:  segment.GetStatslogs(),
+	} {
+		if err := binlog.DecompressBinLogWithRootPath(rootPath, binlogType, segment.GetCollectionID(),
+			segment.GetPartitionID(), segment.GetID(), fieldBinlogs); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (si *segmentInspector) report(format string, args ...any) {
+	si.issues++
+	fmt.Fprintf(si.w, "  [ISSUE] "+format+"\n", args...)
+}
+
+// inspectBinlog reads the binlog and verifies its format, nil is returned if it could not be read.
+func (si *segmentInspector) inspectBinlog(logPath string) *storage.BinlogInspection {
+	data, err := si.cm.Read(si.ctx, logPath)
+	if err != nil {
+		si.report("failed to read %s: %v", logPath, err)
+		return nil
+	}
+	bi := storage.InspectBinlog(data)
+	for _, issue := range bi.Issues {
+		si.report("%s: %s", logPath, issue)
+	}
+	return bi
+}
+
+func (si *segmentInspector) checkInsertBinlogs() error {
+	fmt.Fprintf(si.w, "insert binlogs:\n")
+	segment := si.segment
+	for _, fieldBinlog := range segment.GetBinlogs() {
+		var fieldRows int64
+		for _, l := range fieldBinlog.GetBinlogs() {
+			fieldRows += l.GetEntriesNum()
+			bi := si.inspectBinlog(l.GetLogPath())
+			if bi == nil {
+				continue
+			}
+			fmt.Fprintf(si.w, "  field %d, %s, size %d, rows %d, events %d, ts [%d, %d]\n",
+				fieldBinlog.GetFieldID(), l.GetLogPath(), bi.Size, bi.Rows, len(bi.Events), bi.StartTimestamp, bi.EndTimestamp)
+			if !bi.Valid() {
+				continue
+			}
+			if bi.CollectionID != segment.GetCollectionID() || bi.PartitionID != segment.GetPartitionID() ||
+				bi.SegmentID != segment.GetID() || bi.FieldID != fieldBinlog.GetFieldID() {
+				si.report("%s: ids (%d, %d, %d, %d) in descriptor mismatch the meta", l.GetLogPath(),
+					bi.CollectionID, bi.PartitionID, bi.SegmentID, bi.FieldID)
+			}
+			if int64(bi.Rows) != l.GetEntriesNum() {
+				si.report("%s: %d rows decoded, %d rows in meta", l.GetLogPath(), bi.Rows, l.GetEntriesNum())
+			}
+		}
+		if fieldRows != segment.GetNumOfRows() {
+			si.report("field %d: %d rows in binlogs, %d rows in segment", fieldBinlog.GetFieldID(), fieldRows, segment.GetNumOfRows())
+		}
+	}
+	return nil
+}
+
+func (si *segmentInspector) checkDeltaLogs() error {
+	fmt.Fprintf(si.w, "delta logs:\n")
+	for _, fieldBinlog := range si.segment.GetDeltalogs() {
+		for _, l := range fieldBinlog.GetBinlogs() {
+			bi := si.inspectBinlog(l.GetLogPath())
+			if bi == nil {
+				continue
+			}
+			fmt.Fprintf(si.w, "  %s, size %d, rows %d, ts [%d, %d]\n", l.GetLogPath(), bi.Size, bi.Rows, bi.StartTimestamp, bi.EndTimestamp)
+			if bi.Valid() && int64(bi.Rows) != l.GetEntriesNum() {
+				si.report("%s: %d rows decoded, %d rows in meta", l.GetLogPath(), bi.Rows, l.GetEntriesNum())
+			}
+		}
+	}
+	return nil
+}
+
+// checkPkStats checks that all the primary keys in the insert binlogs are covered by the pk stats.
+func (si *segmentInspector) checkPkStats() error {
+	fmt.Fprintf(si.w, "pk stats:\n")
+	pkFieldID := si.pkField.GetFieldID()
+	var statsPaths []string
+	compound := false
+	for _, fieldBinlog := range si.segment.GetStatslogs() {
+		if fieldBinlog.GetFieldID() != pkFieldID {
+			continue
+		}
+		for _, l := range fieldBinlog.GetBinlogs() {
+			// only the compound stats log is loaded if exists, the same as the datanode does
+			if path.Base(l.GetLogPath()) == storage.CompoundStatsType.LogIdx() {
+				statsPaths, compound = []string{l.GetLogPath()}, true
+				break
+			}
+			statsPaths = append(statsPaths, l.GetLogPath())
+		}
+	}
+	if len(statsPaths) == 0 {
+		// the pk stats of growing segments are kept in memory only
+		if si.segment.GetNumOfRows() > 0 && si.segment.GetState() == commonpb.SegmentState_Flushed {
+			si.report("no pk stats for flushed segment")
+		}
+		return nil
+	}
+
+	values, err := si.cm.MultiRead(si.ctx, statsPaths)
+	if err != nil {
+		si.report("failed to read pk stats: %v", err)
+		return nil
+	}
+	var stats []*storage.PrimaryKeyStats
+	if compound {
+		stats, err = storage.DeserializeStatsList(&storage.Blob{Key: statsPaths[0], Value: values[0]})
+	} else {
+		blobs := make([]*storage.Blob, 0, len(values))
+		for i, v := range values {
+			blobs = append(blobs, &storage.Blob{Key: statsPaths[i], Value: v})
+		}
+		stats, err = storage.DeserializeStats(blobs)
+	}
+	if err != nil {
+		si.report("failed to deserialize pk stats: %v", err)
+		return nil
+	}
+	for _, s := range stats {
+		fmt.Fprintf(si.w, "  min pk %v, max pk %v, filter %s\n", s.MinPk.GetValue(), s.MaxPk.GetValue(), s.BFType.String())
+	}
+
+	var checked, missed int
+	for _, fieldBinlog := range si.segment.GetBinlogs() {
+		if fieldBinlog.GetFieldID() != pkFieldID {
+			continue
+		}
+		for _, l := range fieldBinlog.GetBinlogs() {
+			pks, err := si.readPrimaryKeys(l.GetLogPath())
+			if err != nil {
+				si.report("%s: failed to read primary keys: %v", l.GetLogPath(), err)
+				continue
+			}
+			for _, pk := range pks {
+				checked++
+				if !pkCoveredByStats(pk, stats) {
+					missed++
+					if missed <= 10 {
+						si.report("pk %v not covered by pk stats", pk.GetValue())
+					}
+				}
+			}
+		}
+	}
+	if missed > 10 {
+		si.report("%d pks in total not covered by pk stats", missed)
+	}
+	fmt.Fprintf(si.w, "  %d pks checked\n", checked)
+	return nil
+}
+
+func (si *segmentInspector) readPrimaryKeys(logPath string) ([]storage.PrimaryKey, error) {
+	data, err := si.cm.Read(si.ctx, logPath)
+	if err != nil {
+		return nil, err
+	}
+	reader, err := storage.NewBinlogReader(data)
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+
+	pks := make([]storage.PrimaryKey, 0)
+	for {
+		eventReader, err := reader.NextEventReader()
+		if err != nil {
+			return nil, err
+		}
+		if eventReader == nil {
+			return pks, nil
+		}
+		switch si.pkField.GetDataType() {
+		case schemapb.DataType_Int64:
+			values, _, err := eventReader.GetInt64FromPayload()
+			if err != nil {
+				return nil, err
+			}
+			for _, v := range values {
+				pks = append(pks, storage.NewInt64PrimaryKey(v))
+			}
+		case schemapb.DataType_VarChar:
+			values, _, err := eventReader.GetStringFromPayload()
+			if err != nil {
+				return nil, err
+			}
+			for _, v := range values {
+				pks = append(pks, storage.NewVarCharPrimaryKey(v))
+			}
+		default:
+			return nil, fmt.Errorf("unexpected pk type %s", si.pkField.GetDataType())
+		}
+	}
+}
+
+func pkCoveredByStats(pk storage.PrimaryKey, stats []*storage.PrimaryKeyStats) bool {
+	for _, s := range stats {
+		if s.MinPk != nil && pk.LT(s.MinPk) || s.MaxPk != nil && pk.GT(s.MaxPk) {
+			continue
+		}
+		if s.BF == nil {
+			return true
+		}
+		switch v := pk.GetValue().(type) {
+		case int64:
+			b := make([]byte, 8)
+			common.Endian.PutUint64(b, uint64(v))
+			if s.BF.Test(b) {
+				return true
+			}
+		case string:
+			if s.BF.TestString(v) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (si *segmentInspector) checkIndexFiles(listSegmentIndexes func(context.Context) ([]*model.SegmentIndex, error)) error {
+	fmt.Fprintf(si.w, "index files:\n")
+	segIndexes, err := listSegmentIndexes(si.ctx)
+	if err != nil {
+		return err
+	}
+	rootPath := si.cm.RootPath()
+	for _, segIdx := range segIndexes {
+		if segIdx.SegmentID != si.segment.GetID() || segIdx.IsDeleted {
+			continue
+		}
+		fmt.Fprintf(si.w, "  index %d, build %d, version %d, state %s, rows %d, size %d\n",
+			segIdx.IndexID, segIdx.BuildID, segIdx.IndexVersion, segIdx.IndexState, segIdx.NumRows, segIdx.IndexSize)
+		if segIdx.IndexState != commonpb.IndexState_Finished {
+			continue
+		}
+		if segIdx.NumRows != si.segment.GetNumOfRows() {
+			si.report("index %d: %d rows indexed, %d rows in segment", segIdx.IndexID, segIdx.NumRows, si.segment.GetNumOfRows())
+		}
+		var totalSize uint64
+		for _, fileKey := range segIdx.IndexFileKeys {
+			filePath := metautil.BuildSegmentIndexFilePath(rootPath, segIdx.BuildID, segIdx.IndexVersion,
+				segIdx.PartitionID, segIdx.SegmentID, fileKey)
+			size, err := si.cm.Size(si.ctx, filePath)
+			if err != nil {
+				si.report("index %d: failed to stat %s: %v", segIdx.IndexID, filePath, err)
+				continue
+			}
+			totalSize += uint64(size)
+			if si.deep {
+				data, err := si.cm.Read(si.ctx, filePath)
+				if err != nil {
+					si.report("index %d: failed to read %s: %v", segIdx.IndexID, filePath, err)
+				} else if int64(len(data)) != size {
+					si.report("index %d: %d bytes read from %s, size %d", segIdx.IndexID, len(data), filePath, size)
+				}
+			}
+		}
+		if segIdx.IndexSize != 0 && totalSize != segIdx.IndexSize {
+			si.report("index %d: files size %d, %d in meta", segIdx.IndexID, totalSize, segIdx.IndexSize)
+		}
+	}
+	return nil
+}
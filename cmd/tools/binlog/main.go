@@ -24,8 +24,17 @@ import (
 )
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "inspect" {
+		if err := inspect(os.Args[2:]); err != nil {
+			fmt.Printf("error: %s\n", err.Error())
+			os.Exit(1)
+		}
+		return
+	}
+
 	if len(os.Args) == 1 {
 		fmt.Println("usage: binlog file1 file2 ...")
+		fmt.Println("       binlog inspect -config milvus.yaml -segment <segmentID> [-export json|parquet -output <dir>]")
 	}
 	if err := storage.PrintBinlogFiles(os.Args[1:]); err != nil {
 		fmt.Printf("error: %s\n", err.Error())
@@ -0,0 +1,372 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package inspector cross-checks the rootcoord, datacoord and querycoord catalogs
+// and the object storage offline, and repairs the inconsistencies found.
+package inspector
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"go.uber.org/zap"
+	"golang.org/x/sync/errgroup"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
+	"github.com/milvus-io/milvus-proto/go-api/v2/msgpb"
+	"github.com/milvus-io/milvus/internal/metastore"
+	"github.com/milvus-io/milvus/internal/metastore/kv/binlog"
+	"github.com/milvus-io/milvus/internal/metastore/model"
+	"github.com/milvus-io/milvus/internal/proto/datapb"
+	"github.com/milvus-io/milvus/internal/storage"
+	"github.com/milvus-io/milvus/pkg/common"
+	"github.com/milvus-io/milvus/pkg/log"
+	"github.com/milvus-io/milvus/pkg/util"
+	"github.com/milvus-io/milvus/pkg/util/funcutil"
+	"github.com/milvus-io/milvus/pkg/util/typeutil"
+)
+
+const defaultParallelism = 16
+
+// Inspector loads the catalogs and reports the inconsistencies between them.
+type Inspector struct {
+	rootCoord  metastore.RootCoordCatalog
+	dataCoord  metastore.DataCoordCatalog
+	queryCoord metastore.QueryCoordCatalog
+	// chunkManager is used to check the binlogs, the check is skipped if nil.
+	chunkManager storage.ChunkManager
+
+	collectionID int64
+	parallelism  int
+}
+
+type Option func(*Inspector)
+
+// WithCollection limits the inspection to the collection.
+func WithCollection(collectionID int64) Option {
+	return func(i *Inspector) {
+		i.collectionID = collectionID
+	}
+}
+
+// WithChunkManager enables checking whether the binlogs exist in the object storage.
+func WithChunkManager(chunkManager storage.ChunkManager) Option {
+	return func(i *Inspector) {
+		i.chunkManager = chunkManager
+	}
+}
+
+// WithParallelism sets the number of the concurrent requests to the object storage.
+func WithParallelism(parallelism int) Option {
+	return func(i *Inspector) {
+		if parallelism > 0 {
+			i.parallelism = parallelism
+		}
+	}
+}
+
+func NewInspector(rootCoord metastore.RootCoordCatalog, dataCoord metastore.DataCoordCatalog,
+	queryCoord metastore.QueryCoordCatalog, opts ...Option,
+) *Inspector {
+	i := &Inspector{
+		rootCoord:   rootCoord,
+		dataCoord:   dataCoord,
+		queryCoord:  queryCoord,
+		parallelism: defaultParallelism,
+	}
+	for _, opt := range opts {
+		opt(i)
+	}
+	return i
+}
+
+// Inspect loads all the catalogs and cross-checks them.
+func (i *Inspector) Inspect(ctx context.Context) (*Report, error) {
+	collections, err := i.listCollections(ctx)
+	if err != nil {
+		return nil, err
+	}
+	report := &Report{Collections: len(collections)}
+
+	segments, err := i.dataCoord.ListSegments(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list segments: %w", err)
+	}
+	segments = i.filterSegments(segments)
+	report.Segments = len(segments)
+	i.checkSegments(report, collections, segments)
+
+	indexes, err := i.dataCoord.ListIndexes(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list indexes: %w", err)
+	}
+	i.checkIndexes(report, collections, indexes)
+
+	checkpoints, err := i.dataCoord.ListChannelCheckpoint(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list channel checkpoints: %w", err)
+	}
+	i.checkChannelCheckpoints(report, collections, checkpoints)
+
+	loadInfos, err := i.queryCoord.GetCollections(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list load infos: %w", err)
+	}
+	for _, info := range loadInfos {
+		if !i.match(info.GetCollectionID()) {
+			continue
+		}
+		report.LoadInfos++
+		if _, ok := collections[info.GetCollectionID()]; !ok {
+			collectionID := info.GetCollectionID()
+			report.add(&Issue{
+				Type:         StaleLoadInfo,
+				CollectionID: collectionID,
+				Target:       fmt.Sprintf("collection/%d", collectionID),
+				Reason:       "collection is loaded but not found in rootcoord",
+				repair:       i.releaseCollection(collectionID),
+			})
+		}
+	}
+
+	if i.chunkManager != nil {
+		if err := i.checkBinlogs(ctx, report, segments); err != nil {
+			return nil, err
+		}
+	}
+	return report, nil
+}
+
+// listCollections returns all the collections of all the databases, including the ones being dropped,
+// since their segments and indexes are still valid before the garbage collection.
+func (i *Inspector) listCollections(ctx context.Context) (map[int64]*model.Collection, error) {
+	dbs, err := i.rootCoord.ListDatabases(ctx, typeutil.MaxTimestamp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list databases: %w", err)
+	}
+	// collections created before database supported have no database
+	dbIDs := typeutil.NewSet[int64](util.NonDBID, util.DefaultDBID)
+	for _, db := range dbs {
+		dbIDs.Insert(db.ID)
+	}
+
+	collections := make(map[int64]*model.Collection)
+	for _, dbID := range dbIDs.Collect() {
+		colls, err := i.rootCoord.ListCollections(ctx, dbID, typeutil.MaxTimestamp)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list collections of database %d: %w", dbID, err)
+		}
+		for _, coll := range colls {
+			collections[coll.CollectionID] = coll
+		}
+	}
+	return collections, nil
+}
+
+func (i *Inspector) match(collectionID int64) bool {
+	return i.collectionID <= 0 || i.collectionID == collectionID
+}
+
+func (i *Inspector) filterSegments(segments []*datapb.SegmentInfo) []*datapb.SegmentInfo {
+	ret := make([]*datapb.SegmentInfo, 0, len(segments))
+	for _, segment := range segments {
+		if i.match(segment.GetCollectionID()) {
+			ret = append(ret, segment)
+		}
+	}
+	return ret
+}
+
+func (i *Inspector) checkSegments(report *Report, collections map[int64]*model.Collection, segments []*datapb.SegmentInfo) {
+	for _, segment := range segments {
+		if segment.GetState() == commonpb.SegmentState_Dropped {
+			continue
+		}
+		collection, ok := collections[segment.GetCollectionID()]
+		if !ok {
+			report.add(&Issue{
+				Type:         OrphanSegment,
+				CollectionID: segment.GetCollectionID(),
+				Target:       fmt.Sprintf("segment/%d", segment.GetID()),
+				Reason:       "collection not found in rootcoord",
+				repair:       i.dropSegment(segment),
+			})
+			continue
+		}
+		// the partition id of l0 segments may be AllPartitionsID
+		if segment.GetPartitionID() == common.AllPartitionsID {
+			continue
+		}
+		partitionExists := false
+		for _, partition := range collection.Partitions {
+			if partition.PartitionID == segment.GetPartitionID() {
+				partitionExists = true
+				break
+			}
+		}
+		if !partitionExists {
+			report.add(&Issue{
+				Type:         OrphanSegment,
+				CollectionID: segment.GetCollectionID(),
+				Target:       fmt.Sprintf("segment/%d", segment.GetID()),
+				Reason:       fmt.Sprintf("partition %d not found in rootcoord", segment.GetPartitionID()),
+				repair:       i.dropSegment(segment),
+			})
+		}
+	}
+}
+
+func (i *Inspector) checkIndexes(report *Report, collections map[int64]*model.Collection, indexes []*model.Index) {
+	for _, index := range indexes {
+		if !i.match(index.CollectionID) || index.IsDeleted {
+			continue
+		}
+		report.Indexes++
+		target := fmt.Sprintf("index/%d(%s)", index.IndexID, index.IndexName)
+		collection, ok := collections[index.CollectionID]
+		if !ok {
+			report.add(&Issue{
+				Type:         OrphanIndex,
+				CollectionID: index.CollectionID,
+				Target:       target,
+				Reason:       "collection not found in rootcoord",
+				repair:       i.dropIndex(index),
+			})
+			continue
+		}
+		fieldExists := false
+		for _, field := range collection.Fields {
+			if field.FieldID == index.FieldID {
+				fieldExists = true
+				break
+			}
+		}
+		if !fieldExists {
+			report.add(&Issue{
+				Type:         OrphanIndex,
+				CollectionID: index.CollectionID,
+				Target:       target,
+				Reason:       fmt.Sprintf("field %d not found in collection schema", index.FieldID),
+				repair:       i.dropIndex(index),
+			})
+		}
+	}
+}
+
+func (i *Inspector) checkChannelCheckpoints(report *Report, collections map[int64]*model.Collection, checkpoints map[string]*msgpb.MsgPosition) {
+	channel2Collection := make(map[string]int64)
+	for _, collection := range collections {
+		for _, channel := range collection.VirtualChannelNames {
+			channel2Collection[channel] = collection.CollectionID
+		}
+	}
+
+	for channel := range checkpoints {
+		collectionID, ok := channel2Collection[channel]
+		if !ok {
+			// the collection id is encoded in the vchannel name
+			collectionID = funcutil.GetCollectionIDFromVChannel(channel)
+		}
+		if !i.match(collectionID) {
+			continue
+		}
+		report.Checkpoints++
+		if !ok {
+			report.add(&Issue{
+				Type:         StaleChannelCheckpoint,
+				CollectionID: collectionID,
+				Target:       fmt.Sprintf("channel/%s", channel),
+				Reason:       "channel belongs to no collection in rootcoord",
+				repair:       i.dropChannelCheckpoint(channel),
+			})
+		}
+	}
+}
+
+// checkBinlogs checks whether the binlogs of the segments exist in the object storage.
+// The binlogs of the dropped segments are skipped since they may have been garbage collected.
+func (i *Inspector) checkBinlogs(ctx context.Context, report *Report, segments []*datapb.SegmentInfo) error {
+	type binlogRef struct {
+		collectionID int64
+		segmentID    int64
+		path         string
+	}
+	refs := make([]binlogRef, 0)
+	for _, segment := range segments {
+		if segment.GetState() == commonpb.SegmentState_Dropped {
+			continue
+		}
+		// decompress on a copy, the segments may be written back by the repair actions
+		segment = proto.Clone(segment).(*datapb.SegmentInfo)
+		for binlogType, fieldBinlogs := range map[storage.BinlogType][]*datapb.FieldBinlog{
+			storage.InsertBinlog: segment.GetBinlogs(),
+			storage.StatsBinlog:  segment.GetStatslogs(),
+			storage.DeleteBinlog: segment.GetDeltalogs(),
+			storage.BM25Binlog:   segment.GetBm25Statslogs(),
+		} {
+			err := binlog.DecompressBinLogWithRootPath(i.chunkManager.RootPath(), binlogType,
+				segment.GetCollectionID(), segment.GetPartitionID(), segment.GetID(), fieldBinlogs)
+			if err != nil {
+				return fmt.Errorf("failed to decompress binlogs of segment %d: %w", segment.GetID(), err)
+			}
+		}
+		for _, fieldBinlogs := range [][]*datapb.FieldBinlog{
+			segment.GetBinlogs(), segment.GetStatslogs(), segment.GetDeltalogs(), segment.GetBm25Statslogs(),
+		} {
+			for _, fieldBinlog := range fieldBinlogs {
+				for _, l := range fieldBinlog.GetBinlogs() {
+					refs = append(refs, binlogRef{
+						collectionID: segment.GetCollectionID(),
+						segmentID:    segment.GetID(),
+						path:         l.GetLogPath(),
+					})
+				}
+			}
+		}
+	}
+	report.Binlogs = len(refs)
+
+	var mu sync.Mutex
+	group, ctx := errgroup.WithContext(ctx)
+	group.SetLimit(i.parallelism)
+	for _, ref := range refs {
+		ref := ref
+		group.Go(func() error {
+			exist, err := i.chunkManager.Exist(ctx, ref.path)
+			if err != nil {
+				return fmt.Errorf("failed to check binlog %s: %w", ref.path, err)
+			}
+			if !exist {
+				mu.Lock()
+				defer mu.Unlock()
+				report.add(&Issue{
+					Type:         MissingBinlog,
+					CollectionID: ref.collectionID,
+					Target:       ref.path,
+					Reason:       fmt.Sprintf("binlog of segment %d not found in object storage", ref.segmentID),
+				})
+			}
+			return nil
+		})
+	}
+	if err := group.Wait(); err != nil {
+		return err
+	}
+	log.Info("binlogs checked", zap.Int("binlogs", len(refs)), zap.Int("missing", len(report.IssuesOf(MissingBinlog))))
+	return nil
+}
@@ -0,0 +1,217 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package inspector
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
+	"github.com/milvus-io/milvus-proto/go-api/v2/msgpb"
+	"github.com/milvus-io/milvus/internal/metastore"
+	"github.com/milvus-io/milvus/internal/metastore/mocks"
+	"github.com/milvus-io/milvus/internal/metastore/model"
+	mockstorage "github.com/milvus-io/milvus/internal/mocks"
+	"github.com/milvus-io/milvus/internal/proto/datapb"
+	"github.com/milvus-io/milvus/internal/proto/querypb"
+	"github.com/milvus-io/milvus/pkg/util/metautil"
+	"github.com/milvus-io/milvus/pkg/util/paramtable"
+)
+
+type InspectorSuite struct {
+	suite.Suite
+
+	rootCoord    *mocks.RootCoordCatalog
+	dataCoord    *mocks.DataCoordCatalog
+	queryCoord   *mocks.QueryCoordCatalog
+	chunkManager *mockstorage.ChunkManager
+}
+
+func (s *InspectorSuite) SetupSuite() {
+	paramtable.Init()
+}
+
+func (s *InspectorSuite) SetupTest() {
+	s.rootCoord = mocks.NewRootCoordCatalog(s.T())
+	s.dataCoord = mocks.NewDataCoordCatalog(s.T())
+	s.queryCoord = mocks.NewQueryCoordCatalog(s.T())
+	s.chunkManager = mockstorage.NewChunkManager(s.T())
+
+	s.rootCoord.EXPECT().ListDatabases(mock.Anything, mock.Anything).Return([]*model.Database{{ID: 1, Name: "default"}}, nil)
+	s.rootCoord.EXPECT().ListCollections(mock.Anything, int64(0), mock.Anything).Return(nil, nil)
+	s.rootCoord.EXPECT().ListCollections(mock.Anything, int64(1), mock.Anything).Return([]*model.Collection{
+		{
+			CollectionID:        100,
+			Partitions:          []*model.Partition{{PartitionID: 1000, CollectionID: 100}},
+			Fields:              []*model.Field{{FieldID: 101}, {FieldID: 102}},
+			VirtualChannelNames: []string{"by-dev-rootcoord-dml_0_100v0"},
+		},
+	}, nil)
+
+	s.dataCoord.EXPECT().ListSegments(mock.Anything).Return([]*datapb.SegmentInfo{
+		// healthy
+		{
+			ID: 1, CollectionID: 100, PartitionID: 1000, State: commonpb.SegmentState_Flushed,
+			Binlogs: []*datapb.FieldBinlog{{FieldID: 101, Binlogs: []*datapb.Binlog{{LogID: 10}, {LogID: 11}}}},
+		},
+		// l0 segment
+		{ID: 2, CollectionID: 100, PartitionID: -1, State: commonpb.SegmentState_Flushed},
+		// dropped partition
+		{ID: 3, CollectionID: 100, PartitionID: 1001, State: commonpb.SegmentState_Flushed},
+		// dropped collection
+		{ID: 4, CollectionID: 200, PartitionID: 2000, State: commonpb.SegmentState_Growing},
+		// already dropped
+		{
+			ID: 5, CollectionID: 200, PartitionID: 2000, State: commonpb.SegmentState_Dropped,
+			Binlogs: []*datapb.FieldBinlog{{FieldID: 101, Binlogs: []*datapb.Binlog{{LogID: 12}}}},
+		},
+	}, nil)
+	s.dataCoord.EXPECT().ListIndexes(mock.Anything).Return([]*model.Index{
+		{CollectionID: 100, FieldID: 101, IndexID: 1, IndexName: "healthy"},
+		{CollectionID: 100, FieldID: 103, IndexID: 2, IndexName: "dropped_field"},
+		{CollectionID: 200, FieldID: 101, IndexID: 3, IndexName: "dropped_collection"},
+		{CollectionID: 100, FieldID: 104, IndexID: 4, IndexName: "deleted", IsDeleted: true},
+	}, nil)
+	s.dataCoord.EXPECT().ListChannelCheckpoint(mock.Anything).Return(map[string]*msgpb.MsgPosition{
+		"by-dev-rootcoord-dml_0_100v0": {},
+		"by-dev-rootcoord-dml_1_200v0": {},
+	}, nil)
+	s.queryCoord.EXPECT().GetCollections(mock.Anything).Return([]*querypb.CollectionLoadInfo{
+		{CollectionID: 100},
+		{CollectionID: 200},
+	}, nil)
+}
+
+func (s *InspectorSuite) TestInspect() {
+	s.chunkManager.EXPECT().RootPath().Return("files")
+	missing := metautil.BuildInsertLogPath("files", 100, 1000, 1, 101, 11)
+	s.chunkManager.EXPECT().Exist(mock.Anything, mock.Anything).RunAndReturn(func(ctx context.Context, path string) (bool, error) {
+		return path != missing, nil
+	})
+
+	i := NewInspector(s.rootCoord, s.dataCoord, s.queryCoord, WithChunkManager(s.chunkManager))
+	report, err := i.Inspect(context.Background())
+	s.Require().NoError(err)
+
+	s.Equal(1, report.Collections)
+	s.Equal(5, report.Segments)
+	s.Equal(3, report.Indexes)
+	s.Equal(2, report.Checkpoints)
+	s.Equal(2, report.LoadInfos)
+	s.Equal(2, report.Binlogs)
+
+	orphanSegments := report.IssuesOf(OrphanSegment)
+	s.Require().Len(orphanSegments, 2)
+	s.Equal("segment/3", orphanSegments[0].Target)
+	s.Equal("segment/4", orphanSegments[1].Target)
+
+	orphanIndexes := report.IssuesOf(OrphanIndex)
+	s.Require().Len(orphanIndexes, 2)
+	s.Equal("index/2(dropped_field)", orphanIndexes[0].Target)
+	s.Equal("index/3(dropped_collection)", orphanIndexes[1].Target)
+
+	missingBinlogs := report.IssuesOf(MissingBinlog)
+	s.Require().Len(missingBinlogs, 1)
+	s.Equal(missing, missingBinlogs[0].Target)
+
+	checkpoints := report.IssuesOf(StaleChannelCheckpoint)
+	s.Require().Len(checkpoints, 1)
+	s.Equal(int64(200), checkpoints[0].CollectionID)
+
+	loadInfos := report.IssuesOf(StaleLoadInfo)
+	s.Require().Len(loadInfos, 1)
+	s.Equal(int64(200), loadInfos[0].CollectionID)
+
+	buf := &bytes.Buffer{}
+	report.Print(buf)
+	s.Contains(buf.String(), "orphan-segment: 2")
+	s.Contains(buf.String(), missing)
+}
+
+func (s *InspectorSuite) TestInspectCollection() {
+	i := NewInspector(s.rootCoord, s.dataCoord, s.queryCoord, WithCollection(200))
+	report, err := i.Inspect(context.Background())
+	s.Require().NoError(err)
+
+	s.Equal(2, report.Segments)
+	s.Equal(1, report.Indexes)
+	s.Equal(1, report.Checkpoints)
+	s.Equal(1, report.LoadInfos)
+	s.Equal(0, report.Binlogs)
+	s.Len(report.Issues, 4)
+}
+
+func (s *InspectorSuite) TestRepair() {
+	i := NewInspector(s.rootCoord, s.dataCoord, s.queryCoord)
+	report, err := i.Inspect(context.Background())
+	s.Require().NoError(err)
+
+	s.Run("dry run", func() {
+		buf := &bytes.Buffer{}
+		repaired, err := i.Repair(context.Background(), report, []IssueType{OrphanSegment, OrphanIndex}, true, buf)
+		s.NoError(err)
+		s.Equal(0, repaired)
+		s.Contains(buf.String(), "[dry-run] repair [orphan-segment] collection=200 target=segment/4")
+	})
+
+	s.Run("not repairable", func() {
+		_, err := i.Repair(context.Background(), report, []IssueType{MissingBinlog}, false, &bytes.Buffer{})
+		s.Error(err)
+	})
+
+	s.Run("repair", func() {
+		droppedSegments := make([]int64, 0)
+		s.dataCoord.EXPECT().AlterSegments(mock.Anything, mock.Anything).RunAndReturn(
+			func(ctx context.Context, segments []*datapb.SegmentInfo, _ ...metastore.BinlogsIncrement) error {
+				for _, segment := range segments {
+					s.Equal(commonpb.SegmentState_Dropped, segment.GetState())
+					droppedSegments = append(droppedSegments, segment.GetID())
+				}
+				return nil
+			})
+		s.dataCoord.EXPECT().AlterIndexes(mock.Anything, mock.Anything).RunAndReturn(
+			func(ctx context.Context, indexes []*model.Index) error {
+				for _, index := range indexes {
+					s.True(index.IsDeleted)
+				}
+				return nil
+			})
+		s.dataCoord.EXPECT().DropChannelCheckpoint(mock.Anything, "by-dev-rootcoord-dml_1_200v0").Return(nil)
+		s.queryCoord.EXPECT().ReleaseCollection(mock.Anything, int64(200)).Return(nil)
+		s.queryCoord.EXPECT().ReleaseReplicas(mock.Anything, int64(200)).Return(nil)
+		s.queryCoord.EXPECT().RemoveCollectionTarget(mock.Anything, int64(200)).Return(nil)
+
+		repaired, err := i.Repair(context.Background(), report,
+			[]IssueType{OrphanSegment, OrphanIndex, StaleChannelCheckpoint, StaleLoadInfo}, false, &bytes.Buffer{})
+		s.NoError(err)
+		s.Equal(6, repaired)
+		s.ElementsMatch([]int64{3, 4}, droppedSegments)
+	})
+
+	s.Run("no collection", func() {
+		_, err := i.Repair(context.Background(), &Report{}, []IssueType{OrphanSegment}, false, &bytes.Buffer{})
+		s.ErrorIs(err, ErrNoCollection)
+	})
+}
+
+func TestInspector(t *testing.T) {
+	suite.Run(t, new(InspectorSuite))
+}
@@ -0,0 +1,104 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package inspector
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
+	"github.com/milvus-io/milvus/internal/metastore/model"
+	"github.com/milvus-io/milvus/internal/proto/datapb"
+)
+
+// repairFunc fixes an issue, it only marks the meta as dropped when possible
+// and leaves the cleanup of the data to the garbage collection of the coordinators.
+type repairFunc func(ctx context.Context) error
+
+// ErrNoCollection is returned when repairing with no collection found in rootcoord,
+// which is usually caused by a wrong meta root path, and all the meta would be taken as orphan.
+var ErrNoCollection = errors.New("no collection found in rootcoord, check the meta root path before repairing")
+
+// Repair fixes the issues of the types in the report, and returns the number of issues fixed.
+// Nothing is written in dry run mode, the repair actions are printed to w instead.
+func (i *Inspector) Repair(ctx context.Context, report *Report, issueTypes []IssueType, dryRun bool, w io.Writer) (int, error) {
+	if report.Collections == 0 {
+		return 0, ErrNoCollection
+	}
+
+	repaired := 0
+	for _, issueType := range issueTypes {
+		if !issueType.Repairable() {
+			return repaired, fmt.Errorf("issue type %s is not repairable", issueType)
+		}
+		for _, issue := range report.IssuesOf(issueType) {
+			if issue.repair == nil {
+				continue
+			}
+			if dryRun {
+				fmt.Fprintf(w, "[dry-run] repair %s\n", issue)
+				continue
+			}
+			if err := issue.repair(ctx); err != nil {
+				return repaired, fmt.Errorf("failed to repair %s: %w", issue, err)
+			}
+			fmt.Fprintf(w, "repaired %s\n", issue)
+			repaired++
+		}
+	}
+	return repaired, nil
+}
+
+func (i *Inspector) dropSegment(segment *datapb.SegmentInfo) repairFunc {
+	return func(ctx context.Context) error {
+		dropped := proto.Clone(segment).(*datapb.SegmentInfo)
+		dropped.State = commonpb.SegmentState_Dropped
+		dropped.DroppedAt = uint64(time.Now().UnixNano())
+		return i.dataCoord.AlterSegments(ctx, []*datapb.SegmentInfo{dropped})
+	}
+}
+
+func (i *Inspector) dropIndex(index *model.Index) repairFunc {
+	return func(ctx context.Context) error {
+		deleted := model.CloneIndex(index)
+		deleted.IsDeleted = true
+		return i.dataCoord.AlterIndexes(ctx, []*model.Index{deleted})
+	}
+}
+
+func (i *Inspector) dropChannelCheckpoint(channel string) repairFunc {
+	return func(ctx context.Context) error {
+		return i.dataCoord.DropChannelCheckpoint(ctx, channel)
+	}
+}
+
+func (i *Inspector) releaseCollection(collectionID int64) repairFunc {
+	return func(ctx context.Context) error {
+		if err := i.queryCoord.ReleaseCollection(ctx, collectionID); err != nil {
+			return err
+		}
+		if err := i.queryCoord.ReleaseReplicas(ctx, collectionID); err != nil {
+			return err
+		}
+		return i.queryCoord.RemoveCollectionTarget(ctx, collectionID)
+	}
+}
@@ -0,0 +1,119 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package inspector
+
+import (
+	"fmt"
+	"io"
+	"sort"
+)
+
+// IssueType is the kind of inconsistency found between the catalogs.
+type IssueType string
+
+const (
+	// OrphanSegment is a segment whose collection or partition no longer exists in the rootcoord catalog.
+	OrphanSegment IssueType = "orphan-segment"
+	// OrphanIndex is an index on a dropped collection or field.
+	OrphanIndex IssueType = "orphan-index"
+	// MissingBinlog is a binlog recorded in the datacoord catalog but missing in the object storage.
+	MissingBinlog IssueType = "missing-binlog"
+	// StaleChannelCheckpoint is a checkpoint of a channel that belongs to no collection.
+	StaleChannelCheckpoint IssueType = "stale-checkpoint"
+	// StaleLoadInfo is the querycoord load info of a dropped collection.
+	StaleLoadInfo IssueType = "stale-load-info"
+)
+
+// AllIssueTypes lists the issue types in the order they are checked and reported.
+var AllIssueTypes = []IssueType{
+	OrphanSegment,
+	OrphanIndex,
+	MissingBinlog,
+	StaleChannelCheckpoint,
+	StaleLoadInfo,
+}
+
+// Repairable returns whether the issue type has a repair action,
+// the missing binlogs can only be fixed manually since the data is lost.
+func (t IssueType) Repairable() bool {
+	return t != MissingBinlog
+}
+
+// Issue is an inconsistency found by the inspector.
+type Issue struct {
+	Type         IssueType
+	CollectionID int64
+	// Target identifies the inconsistent meta, e.g. segment id, index id, channel name or binlog path.
+	Target string
+	Reason string
+
+	// repair is the action to fix the issue, nil if not repairable.
+	repair repairFunc
+}
+
+func (i *Issue) String() string {
+	return fmt.Sprintf("[%s] collection=%d target=%s: %s", i.Type, i.CollectionID, i.Target, i.Reason)
+}
+
+// Report is the result of an inspection.
+type Report struct {
+	Collections int
+	Segments    int
+	Indexes     int
+	Checkpoints int
+	LoadInfos   int
+	Binlogs     int
+
+	Issues []*Issue
+}
+
+func (r *Report) add(issue *Issue) {
+	r.Issues = append(r.Issues, issue)
+}
+
+// IssuesOf returns the issues of the type.
+func (r *Report) IssuesOf(issueType IssueType) []*Issue {
+	issues := make([]*Issue, 0)
+	for _, issue := range r.Issues {
+		if issue.Type == issueType {
+			issues = append(issues, issue)
+		}
+	}
+	return issues
+}
+
+// Print writes the human readable report to w.
+func (r *Report) Print(w io.Writer) {
+	fmt.Fprintln(w, "================================================================================")
+	fmt.Fprintf(w, "Collections: %d\tSegments: %d\tIndexes: %d\n", r.Collections, r.Segments, r.Indexes)
+	fmt.Fprintf(w, "Channel Checkpoints: %d\tLoad Infos: %d\tBinlogs Checked: %d\n", r.Checkpoints, r.LoadInfos, r.Binlogs)
+	for _, issueType := range AllIssueTypes {
+		issues := r.IssuesOf(issueType)
+		fmt.Fprintln(w, "--------------------------------------------------------------------------------")
+		fmt.Fprintf(w, "%s: %d\n", issueType, len(issues))
+		sort.SliceStable(issues, func(i, j int) bool {
+			if issues[i].CollectionID != issues[j].CollectionID {
+				return issues[i].CollectionID < issues[j].CollectionID
+			}
+			return issues[i].Target < issues[j].Target
+		})
+		for _, issue := range issues {
+			fmt.Fprintf(w, "  %s\n", issue)
+		}
+	}
+	fmt.Fprintln(w, "================================================================================")
+}
@@ -0,0 +1,149 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// catalog is an offline tool to inspect the consistency of the rootcoord, datacoord and querycoord catalogs,
+// and to repair the inconsistencies found. Stop the coordinators before repairing.
+//
+//	catalog -config milvus.yaml
+//	catalog -config milvus.yaml -repair orphan-segment,stale-checkpoint -dry-run=false
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"github.com/milvus-io/milvus/cmd/tools/catalog/inspector"
+	"github.com/milvus-io/milvus/cmd/tools/metakv"
+	"github.com/milvus-io/milvus/internal/metastore/kv/querycoord"
+	"github.com/milvus-io/milvus/internal/storage"
+	"github.com/milvus-io/milvus/pkg/log"
+	"github.com/milvus-io/milvus/pkg/util/paramtable"
+)
+
+var (
+	configPath   = flag.String("config", "", "Path to the milvus configuration file")
+	collectionID = flag.Int64("collection", 0, "Collection ID to inspect, all collections if not set")
+	checkBinlog  = flag.Bool("checkBinlog", true, "Check whether the binlogs exist in the object storage")
+	parallelism  = flag.Int("parallel", 16, "Number of the concurrent requests to the object storage")
+	repair       = flag.String("repair", "", fmt.Sprintf("Comma separated issue types to repair, or all, available: %v", inspector.AllIssueTypes))
+	dryRun       = flag.Bool("dry-run", true, "Print the repair actions without applying them")
+)
+
+func main() {
+	flag.Parse()
+
+	if *configPath == "" {
+		fmt.Fprintln(os.Stderr, "Config file path is required")
+		flag.Usage()
+		os.Exit(1)
+	}
+	issueTypes, err := parseIssueTypes(*repair)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	paramtable.Get().Init(paramtable.NewBaseTableFromYamlOnly(*configPath))
+	ctx := context.Background()
+
+	metaKV, err := metakv.New(paramtable.Get())
+	if err != nil {
+		log.Fatal("failed to connect to meta store", zap.Error(err))
+	}
+	defer metaKV.Close()
+
+	rootCoordCatalog, err := metaKV.RootCoordCatalog()
+	if err != nil {
+		log.Fatal("failed to create rootcoord catalog", zap.Error(err))
+	}
+	opts := []inspector.Option{
+		inspector.WithCollection(*collectionID),
+		inspector.WithParallelism(*parallelism),
+	}
+	chunkManagerRootPath := ""
+	if *checkBinlog {
+		chunkManager, err := storage.NewChunkManagerFactoryWithParam(paramtable.Get()).NewPersistentStorageChunkManager(ctx)
+		if err != nil {
+			log.Fatal("failed to connect to object storage", zap.Error(err))
+		}
+		chunkManagerRootPath = chunkManager.RootPath()
+		opts = append(opts, inspector.WithChunkManager(chunkManager))
+	}
+
+	i := inspector.NewInspector(
+		rootCoordCatalog,
+		metaKV.DataCoordCatalog(chunkManagerRootPath),
+		querycoord.NewCatalog(metaKV),
+		opts...,
+	)
+	report, err := i.Inspect(ctx)
+	if err != nil {
+		log.Fatal("failed to inspect catalogs", zap.Error(err))
+	}
+	report.Print(os.Stdout)
+
+	if len(issueTypes) == 0 {
+		return
+	}
+	if !*dryRun {
+		fmt.Println("repairing, make sure all the coordinators are stopped")
+	}
+	repaired, err := i.Repair(ctx, report, issueTypes, *dryRun, os.Stdout)
+	fmt.Printf("%d issues repaired\n", repaired)
+	if err != nil {
+		log.Fatal("failed to repair", zap.Error(err))
+	}
+}
+
+func parseIssueTypes(s string) ([]inspector.IssueType, error) {
+	if s == "" {
+		return nil, nil
+	}
+	if s == "all" {
+		issueTypes := make([]inspector.IssueType, 0)
+		for _, issueType := range inspector.AllIssueTypes {
+			if issueType.Repairable() {
+				issueTypes = append(issueTypes, issueType)
+			}
+		}
+		return issueTypes, nil
+	}
+
+	issueTypes := make([]inspector.IssueType, 0)
+	for _, name := range strings.Split(s, ",") {
+		issueType := inspector.IssueType(strings.TrimSpace(name))
+		valid := false
+		for _, t := range inspector.AllIssueTypes {
+			if t == issueType {
+				valid = true
+				break
+			}
+		}
+		if !valid {
+			return nil, fmt.Errorf("unknown issue type: %s", issueType)
+		}
+		if !issueType.Repairable() {
+			return nil, fmt.Errorf("issue type %s is not repairable", issueType)
+		}
+		issueTypes = append(issueTypes, issueType)
+	}
+	return issueTypes, nil
+}
@@ -219,6 +219,13 @@ func WriteYaml(w io.Writer) {
 # Related configuration of tikv, used to store Milvus metadata.
 # Notice that when TiKV is enabled for metastore, you still need to have etcd for service discovery.
 # TiKV is a good option when the metadata size requires better horizontal scalability.`,
+		},
+		{
+			name: "sqlite",
+			header: `
+# Related configuration of sqlite, used to store Milvus metadata in standalone mode.
+# Notice that sqlite can only be accessed by a single process, Milvus fails to start if it's used in cluster mode.
+# Only the metadata is stored in sqlite, you still need to have etcd for sessions and service discovery.`,
 		},
 		{
 			name: "localStorage",
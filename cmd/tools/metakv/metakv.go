@@ -0,0 +1,124 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package metakv connects the offline tools to the meta store configured in milvus.yaml.
+package metakv
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	etcdkv "github.com/milvus-io/milvus/internal/kv/etcd"
+	sqlitekv "github.com/milvus-io/milvus/internal/kv/sqlite"
+	kv_tikv "github.com/milvus-io/milvus/internal/kv/tikv"
+	"github.com/milvus-io/milvus/internal/metastore/kv/datacoord"
+	kvmetestore "github.com/milvus-io/milvus/internal/metastore/kv/rootcoord"
+	"github.com/milvus-io/milvus/internal/metastore/model"
+	"github.com/milvus-io/milvus/pkg/kv"
+	"github.com/milvus-io/milvus/pkg/util"
+	"github.com/milvus-io/milvus/pkg/util/etcd"
+	"github.com/milvus-io/milvus/pkg/util/paramtable"
+	"github.com/milvus-io/milvus/pkg/util/tikv"
+	"github.com/milvus-io/milvus/pkg/util/typeutil"
+)
+
+// MetaKV is the meta kv with the meta root path it is created on.
+type MetaKV struct {
+	kv.MetaKv
+	RootPath string
+}
+
+// New connects to the meta store configured by params.
+func New(params *paramtable.ComponentParam) (*MetaKV, error) {
+	switch params.MetaStoreCfg.MetaStoreType.GetValue() {
+	case util.MetaStoreTypeEtcd:
+		etcdConfig := &params.EtcdCfg
+		etcdCli, err := etcd.CreateEtcdClient(
+			etcdConfig.UseEmbedEtcd.GetAsBool(),
+			etcdConfig.EtcdEnableAuth.GetAsBool(),
+			etcdConfig.EtcdAuthUserName.GetValue(),
+			etcdConfig.EtcdAuthPassword.GetValue(),
+			etcdConfig.EtcdUseSSL.GetAsBool(),
+			etcdConfig.Endpoints.GetAsStrings(),
+			etcdConfig.EtcdTLSCert.GetValue(),
+			etcdConfig.EtcdTLSKey.GetValue(),
+			etcdConfig.EtcdTLSCACert.GetValue(),
+			etcdConfig.EtcdTLSMinVersion.GetValue())
+		if err != nil {
+			return nil, err
+		}
+		rootPath := etcdConfig.MetaRootPath.GetValue()
+		return &MetaKV{
+			MetaKv: etcdkv.NewEtcdKV(etcdCli, rootPath,
+				etcdkv.WithRequestTimeout(etcdConfig.RequestTimeout.GetAsDuration(time.Millisecond))),
+			RootPath: rootPath,
+		}, nil
+	case util.MetaStoreTypeTiKV:
+		tikvCli, err := tikv.GetTiKVClient(&params.TiKVCfg)
+		if err != nil {
+			return nil, err
+		}
+		rootPath := params.TiKVCfg.MetaRootPath.GetValue()
+		return &MetaKV{
+			MetaKv: kv_tikv.NewTiKV(tikvCli, rootPath,
+				kv_tikv.WithRequestTimeout(params.TiKVCfg.RequestTimeout.GetAsDuration(time.Millisecond))),
+			RootPath: rootPath,
+		}, nil
+	case util.MetaStoreTypeSQLite:
+		rootPath := params.SQLiteCfg.MetaRootPath.GetValue()
+		metaKV, err := sqlitekv.NewMetaKvFactory(rootPath, &params.SQLiteCfg)
+		if err != nil {
+			return nil, err
+		}
+		return &MetaKV{MetaKv: metaKV, RootPath: rootPath}, nil
+	default:
+		return nil, fmt.Errorf("not supported meta store: %s", params.MetaStoreCfg.MetaStoreType.GetValue())
+	}
+}
+
+// RootCoordCatalog returns the rootcoord catalog on the meta kv.
+func (m *MetaKV) RootCoordCatalog() (*kvmetestore.Catalog, error) {
+	ss, err := kvmetestore.NewSuffixSnapshot(m.MetaKv, kvmetestore.SnapshotsSep, m.RootPath, kvmetestore.SnapshotPrefix)
+	if err != nil {
+		return nil, err
+	}
+	return &kvmetestore.Catalog{Txn: m.MetaKv, Snapshot: ss}, nil
+}
+
+// DataCoordCatalog returns the datacoord catalog on the meta kv.
+func (m *MetaKV) DataCoordCatalog(chunkManagerRootPath string) *datacoord.Catalog {
+	return datacoord.NewCatalog(m.MetaKv, chunkManagerRootPath, m.RootPath)
+}
+
+// GetCollection finds the collection in all the databases.
+func GetCollection(ctx context.Context, catalog *kvmetestore.Catalog, collectionID int64) (*model.Collection, error) {
+	dbs, err := catalog.ListDatabases(ctx, typeutil.MaxTimestamp)
+	if err != nil {
+		return nil, err
+	}
+	// collections created before database supported have no database
+	dbIDs := []int64{util.NonDBID, util.DefaultDBID}
+	for _, db := range dbs {
+		dbIDs = append(dbIDs, db.ID)
+	}
+	for _, dbID := range dbIDs {
+		if coll, err := catalog.GetCollectionByID(ctx, dbID, typeutil.MaxTimestamp, collectionID); err == nil {
+			return coll, nil
+		}
+	}
+	return nil, fmt.Errorf("collection %d not found", collectionID)
+}
@@ -32,7 +32,8 @@ StringChunkWriter::write(std::shared_ptr<arrow::RecordBatchReader> data) {
     std::vector<std::pair<const uint8_t*, int64_t>> null_bitmaps;
     for (auto batch : *data) {
         auto data = batch.ValueOrDie()->column(0);
-        auto array = std::dynamic_pointer_cast<arrow::StringArray>(data);
+        // geometry columns are binary arrays
+        auto array = std::dynamic_pointer_cast<arrow::BinaryArray>(data);
         for (int i = 0; i < array->length(); i++) {
             auto str = array->GetView(i);
             strs.emplace_back(str);
@@ -375,7 +376,8 @@ create_chunk(const FieldMeta& field_meta,
             break;
         }
         case milvus::DataType::VARCHAR:
-        case milvus::DataType::STRING: {
+        case milvus::DataType::STRING:
+        case milvus::DataType::GEOMETRY: {
             w = std::make_shared<StringChunkWriter>(nullable);
             break;
         }
@@ -470,7 +472,8 @@ create_chunk(const FieldMeta& field_meta,
             break;
         }
         case milvus::DataType::VARCHAR:
-        case milvus::DataType::STRING: {
+        case milvus::DataType::STRING:
+        case milvus::DataType::GEOMETRY: {
             w = std::make_shared<StringChunkWriter>(
                 file, file_offset, nullable);
             break;
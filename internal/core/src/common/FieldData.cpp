@@ -194,6 +194,21 @@ FieldDataImpl<Type, is_type_entire_row>::FillFieldData(
             }
             return FillFieldData(values.data(), element_count);
         }
+        case DataType::GEOMETRY: {
+            AssertInfo(array->type()->id() == arrow::Type::type::BINARY,
+                       "inconsistent data type");
+            auto wkb_array =
+                std::dynamic_pointer_cast<arrow::BinaryArray>(array);
+            std::vector<std::string> values(element_count);
+            for (size_t index = 0; index < element_count; ++index) {
+                values[index] = wkb_array->GetString(index);
+            }
+            if (nullable_) {
+                return FillFieldData(
+                    values.data(), array->null_bitmap_data(), element_count);
+            }
+            return FillFieldData(values.data(), element_count);
+        }
         case DataType::JSON: {
             // The code here is not referenced.
             // A subclass named FieldDataJsonImpl is implemented, which overloads this function.
@@ -310,6 +325,7 @@ InitScalarFieldData(const DataType& type, bool nullable, int64_t cap_rows) {
                 type, nullable, cap_rows);
         case DataType::STRING:
         case DataType::VARCHAR:
+        case DataType::GEOMETRY:
             return std::make_shared<FieldData<std::string>>(
                 type, nullable, cap_rows);
         case DataType::JSON:
@@ -189,6 +189,8 @@ struct SearchResult {
     int64_t total_nq_;
     int64_t unity_topK_;
     int64_t total_data_cnt_;
+    // the number of rows passing the filter
+    int64_t filtered_data_cnt_ = 0;
     void* segment_;
 
     // first fill data during search, and then update data after reducing search results
@@ -225,6 +227,8 @@ struct RetrieveResult {
 
  public:
     int64_t total_data_cnt_;
+    // the number of rows passing the filter
+    int64_t filtered_data_cnt_ = 0;
     void* segment_;
     std::vector<int64_t> result_offsets_;
     std::vector<DataArray> field_data_;
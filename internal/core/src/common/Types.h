@@ -75,6 +75,7 @@ enum class DataType {
     VARCHAR = 21,
     ARRAY = 22,
     JSON = 23,
+    GEOMETRY = 24,
 
     // Some special Data type, start from after 50
     // just for internal use now, may sync proto in future
@@ -180,6 +181,8 @@ GetDataTypeName(DataType data_type) {
             return "array";
         case DataType::JSON:
             return "json";
+        case DataType::GEOMETRY:
+            return "geometry";
         case DataType::VECTOR_FLOAT:
             return "vector_float";
         case DataType::VECTOR_BINARY:
@@ -266,6 +269,12 @@ IsArrayDataType(DataType data_type) {
     return data_type == DataType::ARRAY;
 }
 
+// geometry is stored as WKB bytes, the same way as a varchar
+inline bool
+IsGeometryDataType(DataType data_type) {
+    return data_type == DataType::GEOMETRY;
+}
+
 inline bool
 IsBinaryDataType(DataType data_type) {
     return IsJsonDataType(data_type) || IsArrayDataType(data_type);
@@ -299,6 +308,11 @@ IsArrayType(proto::schema::DataType type) {
     return type == proto::schema::DataType::Array;
 }
 
+inline bool
+IsGeometryType(proto::schema::DataType type) {
+    return type == proto::schema::DataType::Geometry;
+}
+
 inline bool
 IsBinaryVectorDataType(DataType data_type) {
     return data_type == DataType::VECTOR_BINARY;
@@ -336,6 +350,7 @@ IsVectorDataType(DataType data_type) {
 inline bool
 IsVariableDataType(DataType data_type) {
     return IsStringDataType(data_type) || IsBinaryDataType(data_type) ||
+           IsGeometryDataType(data_type) ||
            IsSparseFloatVectorDataType(data_type);
 }
 
@@ -518,6 +533,15 @@ struct TypeTraits<DataType::STRING> : public TypeTraits<DataType::VARCHAR> {
     static constexpr const char* Name = "STRING";
 };
 
+template <>
+struct TypeTraits<DataType::GEOMETRY> {
+    using NativeType = std::string;
+    static constexpr DataType TypeKind = DataType::GEOMETRY;
+    static constexpr bool IsPrimitiveType = false;
+    static constexpr bool IsFixedWidth = false;
+    static constexpr const char* Name = "GEOMETRY";
+};
+
 template <>
 struct TypeTraits<DataType::ARRAY> {
     using NativeType = void;
@@ -606,6 +630,9 @@ struct fmt::formatter<milvus::DataType> : formatter<string_view> {
             case milvus::DataType::JSON:
                 name = "JSON";
                 break;
+            case milvus::DataType::GEOMETRY:
+                name = "GEOMETRY";
+                break;
             case milvus::DataType::ROW:
                 name = "ROW";
                 break;
@@ -43,7 +43,7 @@ class PhyCallExpr : public Expr {
                 const segcore::SegmentInternalInterface* segment,
                 int64_t active_count,
                 int64_t batch_size)
-        : Expr(DataType::BOOL, std::move(input), name),
+        : Expr(expr->type(), std::move(input), name),
           expr_(expr),
           active_count_(active_count),
           segment_(segment),
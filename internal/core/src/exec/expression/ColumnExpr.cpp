@@ -54,7 +54,8 @@ PhyColumnExpr::Eval(EvalCtx& context, VectorPtr& result) {
         case DataType::DOUBLE:
             result = DoEval<double>(input);
             break;
-        case DataType::VARCHAR: {
+        case DataType::VARCHAR:
+        case DataType::GEOMETRY: {
             result = DoEval<std::string>(input);
             break;
         }
@@ -15,7 +15,12 @@
 // limitations under the License.
 
 #include "exec/expression/function/FunctionFactory.h"
+#include <algorithm>
 #include <mutex>
+#include "exec/expression/function/impl/DateFunctions.h"
+#include "exec/expression/function/impl/GeoFunctions.h"
+#include "exec/expression/function/impl/MathFunctions.h"
+#include "exec/expression/function/impl/OperatorFunctions.h"
 #include "exec/expression/function/impl/StringFunctions.h"
 #include "log/Log.h"
 
@@ -23,6 +28,26 @@ namespace milvus {
 namespace exec {
 namespace expression {
 
+namespace {
+
+const std::vector<DataType> kIntegerTypes = {
+    DataType::INT8, DataType::INT16, DataType::INT32, DataType::INT64};
+
+const std::vector<DataType> kNumericTypes = {DataType::INT8,
+                                             DataType::INT16,
+                                             DataType::INT32,
+                                             DataType::INT64,
+                                             DataType::FLOAT,
+                                             DataType::DOUBLE};
+
+bool
+IsIntegerDataType(DataType type) {
+    return std::find(kIntegerTypes.begin(), kIntegerTypes.end(), type) !=
+           kIntegerTypes.end();
+}
+
+}  // namespace
+
 std::string
 FilterFunctionRegisterKey::ToString() const {
     std::ostringstream oss;
@@ -51,21 +76,127 @@ FunctionFactory::Initialize() {
 
 void
 FunctionFactory::RegisterAllFunctions() {
+    RegisterStringFunctions();
+    RegisterMathFunctions();
+    RegisterDateFunctions();
+    RegisterOperatorFunctions();
+    RegisterGeoFunctions();
+    LOG_INFO("{} functions registered", GetFilterFunctionNum());
+}
+
+void
+FunctionFactory::RegisterStringFunctions() {
     RegisterFilterFunction(
         "empty", {DataType::VARCHAR}, function::EmptyVarchar);
     RegisterFilterFunction("starts_with",
                            {DataType::VARCHAR, DataType::VARCHAR},
                            function::StartsWithVarchar);
-    LOG_INFO("{} functions registered", GetFilterFunctionNum());
+    RegisterFilterFunction("lower",
+                           {DataType::VARCHAR},
+                           function::LowerVarchar,
+                           DataType::VARCHAR);
+    RegisterFilterFunction("upper",
+                           {DataType::VARCHAR},
+                           function::UpperVarchar,
+                           DataType::VARCHAR);
+    RegisterFilterFunction("substr",
+                           {DataType::VARCHAR, DataType::INT64},
+                           function::SubstrVarchar,
+                           DataType::VARCHAR);
+    RegisterFilterFunction(
+        "substr",
+        {DataType::VARCHAR, DataType::INT64, DataType::INT64},
+        function::SubstrVarchar,
+        DataType::VARCHAR);
+}
+
+void
+FunctionFactory::RegisterMathFunctions() {
+    for (auto type : kNumericTypes) {
+        auto return_type =
+            IsIntegerDataType(type) ? DataType::INT64 : DataType::DOUBLE;
+        RegisterFilterFunction("abs", {type}, function::Abs, return_type);
+        RegisterFilterFunction("ceil", {type}, function::Ceil, return_type);
+        RegisterFilterFunction("floor", {type}, function::Floor, return_type);
+    }
+}
+
+void
+FunctionFactory::RegisterDateFunctions() {
+    for (auto type : kIntegerTypes) {
+        RegisterFilterFunction("date_trunc",
+                               {DataType::VARCHAR, type},
+                               function::DateTrunc,
+                               DataType::INT64);
+        RegisterFilterFunction("date_part",
+                               {DataType::VARCHAR, type},
+                               function::DatePart,
+                               DataType::INT64);
+    }
+}
+
+void
+FunctionFactory::RegisterOperatorFunctions() {
+    // the parser lowers arithmetic between fields and comparisons on
+    // function results into calls of the following functions.
+    for (auto left : kNumericTypes) {
+        for (auto right : kNumericTypes) {
+            auto both_integer =
+                IsIntegerDataType(left) && IsIntegerDataType(right);
+            auto return_type =
+                both_integer ? DataType::INT64 : DataType::DOUBLE;
+            RegisterFilterFunction(
+                "add", {left, right}, function::Add, return_type);
+            RegisterFilterFunction(
+                "sub", {left, right}, function::Sub, return_type);
+            RegisterFilterFunction(
+                "mul", {left, right}, function::Mul, return_type);
+            RegisterFilterFunction(
+                "div", {left, right}, function::Div, return_type);
+            if (both_integer) {
+                RegisterFilterFunction(
+                    "mod", {left, right}, function::Mod, DataType::INT64);
+            }
+            RegisterFilterFunction("eq", {left, right}, function::Equal);
+            RegisterFilterFunction("ne", {left, right}, function::NotEqual);
+            RegisterFilterFunction("lt", {left, right}, function::LessThan);
+            RegisterFilterFunction("le", {left, right}, function::LessEqual);
+            RegisterFilterFunction("gt", {left, right}, function::GreaterThan);
+            RegisterFilterFunction(
+                "ge", {left, right}, function::GreaterEqual);
+        }
+    }
+    std::vector<DataType> varchar_params = {DataType::VARCHAR,
+                                            DataType::VARCHAR};
+    RegisterFilterFunction("eq", varchar_params, function::Equal);
+    RegisterFilterFunction("ne", varchar_params, function::NotEqual);
+    RegisterFilterFunction("lt", varchar_params, function::LessThan);
+    RegisterFilterFunction("le", varchar_params, function::LessEqual);
+    RegisterFilterFunction("gt", varchar_params, function::GreaterThan);
+    RegisterFilterFunction("ge", varchar_params, function::GreaterEqual);
+}
+
+void
+FunctionFactory::RegisterGeoFunctions() {
+    // the second argument is the WKT constant given in the expression
+    RegisterFilterFunction("st_distance",
+                           {DataType::GEOMETRY, DataType::VARCHAR},
+                           function::StDistance,
+                           DataType::DOUBLE);
+    RegisterFilterFunction("st_within",
+                           {DataType::GEOMETRY, DataType::VARCHAR},
+                           function::StWithin);
 }
 
 void
 FunctionFactory::RegisterFilterFunction(
     std::string func_name,
     std::vector<DataType> func_param_type_list,
-    FilterFunctionPtr func) {
+    FilterFunctionPtr func,
+    DataType return_type) {
     filter_function_map_[FilterFunctionRegisterKey{
-        func_name, func_param_type_list}] = func;
+        func_name, func_param_type_list}] =
+        FilterFunctionInfo{func, return_type};
 }
 
 const FilterFunctionPtr
@@ -73,11 +204,21 @@ FunctionFactory::GetFilterFunction(
     const FilterFunctionRegisterKey& func_sig) const {
     auto iter = filter_function_map_.find(func_sig);
     if (iter != filter_function_map_.end()) {
-        return iter->second;
+        return iter->second.func;
     }
     return nullptr;
 }
 
+DataType
+FunctionFactory::GetFilterFunctionReturnType(
+    const FilterFunctionRegisterKey& func_sig) const {
+    auto iter = filter_function_map_.find(func_sig);
+    if (iter != filter_function_map_.end()) {
+        return iter->second.return_type;
+    }
+    return DataType::NONE;
+}
+
 }  // namespace expression
 }  // namespace exec
 }  // namespace milvus
@@ -65,6 +65,13 @@ using FilterFunctionReturn = VectorPtr;
 using FilterFunctionPtr = void (*)(const RowVector& args,
                                    FilterFunctionReturn& result);
 
+struct FilterFunctionInfo {
+    FilterFunctionPtr func;
+    // scalar functions such as lower() or abs() return a non-bool vector
+    // which can only be consumed by another function.
+    DataType return_type;
+};
+
 class FunctionFactory {
  public:
     static FunctionFactory&
@@ -76,11 +83,17 @@ class FunctionFactory {
     void
     RegisterFilterFunction(std::string func_name,
                            std::vector<DataType> func_param_type_list,
-                           FilterFunctionPtr func);
+                           FilterFunctionPtr func,
+                           DataType return_type = DataType::BOOL);
 
     const FilterFunctionPtr
     GetFilterFunction(const FilterFunctionRegisterKey& func_sig) const;
 
+    // returns DataType::NONE if the function is not registered
+    DataType
+    GetFilterFunctionReturnType(
+        const FilterFunctionRegisterKey& func_sig) const;
+
     size_t
     GetFilterFunctionNum() const {
         return filter_function_map_.size();
@@ -99,8 +112,23 @@ class FunctionFactory {
     void
     RegisterAllFunctions();
 
+    void
+    RegisterStringFunctions();
+
+    void
+    RegisterMathFunctions();
+
+    void
+    RegisterDateFunctions();
+
+    void
+    RegisterOperatorFunctions();
+
+    void
+    RegisterGeoFunctions();
+
     std::unordered_map<FilterFunctionRegisterKey,
-                       FilterFunctionPtr,
+                       FilterFunctionInfo,
                        FilterFunctionRegisterKey::Hash>
         filter_function_map_;
     std::once_flag init_flag_;
@@ -27,4 +27,90 @@ CheckVarcharOrStringType(std::shared_ptr<SimpleVector>& vec) {
     }
 }
 
+void
+CheckNumericType(std::shared_ptr<SimpleVector>& vec) {
+    switch (vec->type()) {
+        case DataType::INT8:
+        case DataType::INT16:
+        case DataType::INT32:
+        case DataType::INT64:
+        case DataType::FLOAT:
+        case DataType::DOUBLE:
+            return;
+        default:
+            PanicInfo(ExprInvalid,
+                      "invalid argument type, expect numeric type, actual {}",
+                      vec->type());
+    }
+}
+
+bool
+IsIntegerVector(std::shared_ptr<SimpleVector>& vec) {
+    switch (vec->type()) {
+        case DataType::INT8:
+        case DataType::INT16:
+        case DataType::INT32:
+        case DataType::INT64:
+            return true;
+        default:
+            return false;
+    }
+}
+
+template <typename T>
+static T
+ValueAt(std::shared_ptr<SimpleVector>& vec, size_t index) {
+    switch (vec->type()) {
+        case DataType::INT8:
+            return static_cast<T>(
+                *reinterpret_cast<int8_t*>(vec->RawValueAt(index, 1)));
+        case DataType::INT16:
+            return static_cast<T>(
+                *reinterpret_cast<int16_t*>(vec->RawValueAt(index, 2)));
+        case DataType::INT32:
+            return static_cast<T>(
+                *reinterpret_cast<int32_t*>(vec->RawValueAt(index, 4)));
+        case DataType::INT64:
+            return static_cast<T>(
+                *reinterpret_cast<int64_t*>(vec->RawValueAt(index, 8)));
+        case DataType::FLOAT:
+            return static_cast<T>(
+                *reinterpret_cast<float*>(vec->RawValueAt(index, 4)));
+        case DataType::DOUBLE:
+            return static_cast<T>(
+                *reinterpret_cast<double*>(vec->RawValueAt(index, 8)));
+        default:
+            PanicInfo(ExprInvalid,
+                      "invalid argument type, expect numeric type, actual {}",
+                      vec->type());
+    }
+}
+
+int64_t
+GetInt64ValueAt(std::shared_ptr<SimpleVector>& vec, size_t index) {
+    return ValueAt<int64_t>(vec, index);
+}
+
+double
+GetDoubleValueAt(std::shared_ptr<SimpleVector>& vec, size_t index) {
+    return ValueAt<double>(vec, index);
+}
+
+std::shared_ptr<SimpleVector>
+GetSimpleVectorArg(const RowVector& args, int index) {
+    auto vec = std::dynamic_pointer_cast<SimpleVector>(args.child(index));
+    Assert(vec != nullptr);
+    return vec;
+}
+
+void
+CheckArgCount(const RowVector& args, size_t expect) {
+    if (args.childrens().size() != expect) {
+        PanicInfo(ExprInvalid,
+                  "invalid argument count, expect {}, actual {}",
+                  expect,
+                  args.childrens().size());
+    }
+}
+
 }  // namespace milvus::exec::expression::function
@@ -22,4 +22,23 @@ namespace milvus::exec::expression::function {
 void
 CheckVarcharOrStringType(std::shared_ptr<SimpleVector>& vec);
 
+void
+CheckNumericType(std::shared_ptr<SimpleVector>& vec);
+
+bool
+IsIntegerVector(std::shared_ptr<SimpleVector>& vec);
+
+// integer vectors are widened to int64, floating vectors are truncated
+int64_t
+GetInt64ValueAt(std::shared_ptr<SimpleVector>& vec, size_t index);
+
+double
+GetDoubleValueAt(std::shared_ptr<SimpleVector>& vec, size_t index);
+
+std::shared_ptr<SimpleVector>
+GetSimpleVectorArg(const RowVector& args, int index);
+
+void
+CheckArgCount(const RowVector& args, size_t expect);
+
 }  // namespace milvus::exec::expression::function
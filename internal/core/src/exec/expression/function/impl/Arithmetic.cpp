@@ -0,0 +1,160 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+#include "exec/expression/function/FunctionImplUtils.h"
+#include "exec/expression/function/impl/OperatorFunctions.h"
+
+#include <algorithm>
+#include <cmath>
+#include "common/EasyAssert.h"
+#include "exec/expression/function/FunctionFactory.h"
+
+namespace milvus {
+namespace exec {
+namespace expression {
+namespace function {
+
+// the functions return false if the result is null, e.g. divided by zero
+template <typename IntegerFunc, typename FloatingFunc>
+static void
+BinaryArithFunction(const RowVector& args,
+                    FilterFunctionReturn& result,
+                    IntegerFunc integer_func,
+                    FloatingFunc floating_func) {
+    CheckArgCount(args, 2);
+    auto left = GetSimpleVectorArg(args, 0);
+    CheckNumericType(left);
+    auto right = GetSimpleVectorArg(args, 1);
+    CheckNumericType(right);
+
+    auto is_integer = IsIntegerVector(left) && IsIntegerVector(right);
+    auto size = std::max(left->size(), right->size());
+    auto res_vec = std::make_shared<ColumnVector>(
+        is_integer ? DataType::INT64 : DataType::DOUBLE, size);
+    TargetBitmapView valid_res(res_vec->GetValidRawData(), size);
+    for (size_t i = 0; i < size; ++i) {
+        if (!left->ValidAt(i) || !right->ValidAt(i)) {
+            valid_res[i] = false;
+            continue;
+        }
+        bool valid;
+        if (is_integer) {
+            valid = integer_func(GetInt64ValueAt(left, i),
+                                 GetInt64ValueAt(right, i),
+                                 res_vec->RawAsValues<int64_t>()[i]);
+        } else {
+            valid = floating_func(GetDoubleValueAt(left, i),
+                                  GetDoubleValueAt(right, i),
+                                  res_vec->RawAsValues<double>()[i]);
+        }
+        if (!valid) {
+            valid_res[i] = false;
+        }
+    }
+    result = res_vec;
+}
+
+void
+Add(const RowVector& args, FilterFunctionReturn& result) {
+    BinaryArithFunction(
+        args,
+        result,
+        [](int64_t l, int64_t r, int64_t& res) {
+            res = l + r;
+            return true;
+        },
+        [](double l, double r, double& res) {
+            res = l + r;
+            return true;
+        });
+}
+
+void
+Sub(const RowVector& args, FilterFunctionReturn& result) {
+    BinaryArithFunction(
+        args,
+        result,
+        [](int64_t l, int64_t r, int64_t& res) {
+            res = l - r;
+            return true;
+        },
+        [](double l, double r, double& res) {
+            res = l - r;
+            return true;
+        });
+}
+
+void
+Mul(const RowVector& args, FilterFunctionReturn& result) {
+    BinaryArithFunction(
+        args,
+        result,
+        [](int64_t l, int64_t r, int64_t& res) {
+            res = l * r;
+            return true;
+        },
+        [](double l, double r, double& res) {
+            res = l * r;
+            return true;
+        });
+}
+
+void
+Div(const RowVector& args, FilterFunctionReturn& result) {
+    BinaryArithFunction(
+        args,
+        result,
+        [](int64_t l, int64_t r, int64_t& res) {
+            if (r == 0) {
+                return false;
+            }
+            res = l / r;
+            return true;
+        },
+        [](double l, double r, double& res) {
+            if (r == 0) {
+                return false;
+            }
+            res = l / r;
+            return true;
+        });
+}
+
+void
+Mod(const RowVector& args, FilterFunctionReturn& result) {
+    BinaryArithFunction(
+        args,
+        result,
+        [](int64_t l, int64_t r, int64_t& res) {
+            if (r == 0) {
+                return false;
+            }
+            res = l % r;
+            return true;
+        },
+        [](double l, double r, double& res) {
+            if (r == 0) {
+                return false;
+            }
+            res = std::fmod(l, r);
+            return true;
+        });
+}
+
+}  // namespace function
+}  // namespace expression
+}  // namespace exec
+}  // namespace milvus
@@ -0,0 +1,76 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+
+#include "exec/expression/function/FunctionImplUtils.h"
+#include "exec/expression/function/impl/StringFunctions.h"
+
+#include <algorithm>
+#include <string>
+#include "common/EasyAssert.h"
+#include "exec/expression/function/FunctionFactory.h"
+
+namespace milvus {
+namespace exec {
+namespace expression {
+namespace function {
+
+template <typename Converter>
+static void
+ConvertVarcharCase(const RowVector& args,
+                   FilterFunctionReturn& result,
+                   Converter converter) {
+    CheckArgCount(args, 1);
+    auto strs = GetSimpleVectorArg(args, 0);
+    CheckVarcharOrStringType(strs);
+
+    auto res_vec =
+        std::make_shared<ColumnVector>(DataType::VARCHAR, strs->size());
+    auto* res_value = res_vec->RawAsValues<std::string>();
+    TargetBitmapView valid_res(res_vec->GetValidRawData(), strs->size());
+    for (size_t i = 0; i < strs->size(); ++i) {
+        if (!strs->ValidAt(i)) {
+            valid_res[i] = false;
+            continue;
+        }
+        auto* str_ptr = reinterpret_cast<std::string*>(
+            strs->RawValueAt(i, sizeof(std::string)));
+        std::string converted(*str_ptr);
+        std::transform(
+            converted.begin(), converted.end(), converted.begin(), converter);
+        res_value[i] = std::move(converted);
+    }
+    result = res_vec;
+}
+
+void
+LowerVarchar(const RowVector& args, FilterFunctionReturn& result) {
+    ConvertVarcharCase(args, result, [](unsigned char c) -> char {
+        return (c >= 'A' && c <= 'Z') ? c - 'A' + 'a' : c;
+    });
+}
+
+void
+UpperVarchar(const RowVector& args, FilterFunctionReturn& result) {
+    ConvertVarcharCase(args, result, [](unsigned char c) -> char {
+        return (c >= 'a' && c <= 'z') ? c - 'a' + 'A' : c;
+    });
+}
+
+}  // namespace function
+}  // namespace expression
+}  // namespace exec
+}  // namespace milvus
@@ -0,0 +1,106 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+#include "exec/expression/function/FunctionImplUtils.h"
+#include "exec/expression/function/impl/OperatorFunctions.h"
+
+#include <algorithm>
+#include <functional>
+#include <string>
+#include "common/EasyAssert.h"
+#include "exec/expression/function/FunctionFactory.h"
+
+namespace milvus {
+namespace exec {
+namespace expression {
+namespace function {
+
+template <template <typename> class Cmp>
+static void
+CompareFunction(const RowVector& args, FilterFunctionReturn& result) {
+    CheckArgCount(args, 2);
+    auto left = GetSimpleVectorArg(args, 0);
+    auto right = GetSimpleVectorArg(args, 1);
+    auto is_string = left->type() == DataType::VARCHAR ||
+                     left->type() == DataType::STRING;
+    if (is_string) {
+        CheckVarcharOrStringType(right);
+    } else {
+        CheckNumericType(left);
+        CheckNumericType(right);
+    }
+    auto is_integer = IsIntegerVector(left) && IsIntegerVector(right);
+
+    auto size = std::max(left->size(), right->size());
+    TargetBitmap bitmap(size, false);
+    TargetBitmap valid_bitmap(size, true);
+    for (size_t i = 0; i < size; ++i) {
+        if (!left->ValidAt(i) || !right->ValidAt(i)) {
+            valid_bitmap[i] = false;
+            continue;
+        }
+        if (is_string) {
+            auto* l = reinterpret_cast<std::string*>(
+                left->RawValueAt(i, sizeof(std::string)));
+            auto* r = reinterpret_cast<std::string*>(
+                right->RawValueAt(i, sizeof(std::string)));
+            bitmap[i] = Cmp<std::string>{}(*l, *r);
+        } else if (is_integer) {
+            bitmap[i] = Cmp<int64_t>{}(GetInt64ValueAt(left, i),
+                                       GetInt64ValueAt(right, i));
+        } else {
+            bitmap[i] = Cmp<double>{}(GetDoubleValueAt(left, i),
+                                      GetDoubleValueAt(right, i));
+        }
+    }
+    result = std::make_shared<ColumnVector>(std::move(bitmap),
+                                            std::move(valid_bitmap));
+}
+
+void
+Equal(const RowVector& args, FilterFunctionReturn& result) {
+    CompareFunction<std::equal_to>(args, result);
+}
+
+void
+NotEqual(const RowVector& args, FilterFunctionReturn& result) {
+    CompareFunction<std::not_equal_to>(args, result);
+}
+
+void
+LessThan(const RowVector& args, FilterFunctionReturn& result) {
+    CompareFunction<std::less>(args, result);
+}
+
+void
+LessEqual(const RowVector& args, FilterFunctionReturn& result) {
+    CompareFunction<std::less_equal>(args, result);
+}
+
+void
+GreaterThan(const RowVector& args, FilterFunctionReturn& result) {
+    CompareFunction<std::greater>(args, result);
+}
+
+void
+GreaterEqual(const RowVector& args, FilterFunctionReturn& result) {
+    CompareFunction<std::greater_equal>(args, result);
+}
+
+}  // namespace function
+}  // namespace expression
+}  // namespace exec
+}  // namespace milvus
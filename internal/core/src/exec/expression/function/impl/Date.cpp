@@ -0,0 +1,194 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+#include "exec/expression/function/FunctionImplUtils.h"
+#include "exec/expression/function/impl/DateFunctions.h"
+
+#include <string>
+#include "common/EasyAssert.h"
+#include "exec/expression/function/FunctionFactory.h"
+
+namespace milvus {
+namespace exec {
+namespace expression {
+namespace function {
+
+namespace {
+
+constexpr int64_t kSecondsPerMinute = 60;
+constexpr int64_t kSecondsPerHour = 60 * kSecondsPerMinute;
+constexpr int64_t kSecondsPerDay = 24 * kSecondsPerHour;
+
+enum class DateUnit {
+    Year,
+    Month,
+    Day,
+    Hour,
+    Minute,
+    Second,
+    DayOfWeek,
+    DayOfYear,
+};
+
+DateUnit
+ParseDateUnit(const std::string& unit, bool allow_part_only_unit) {
+    if (unit == "year") {
+        return DateUnit::Year;
+    } else if (unit == "month") {
+        return DateUnit::Month;
+    } else if (unit == "day") {
+        return DateUnit::Day;
+    } else if (unit == "hour") {
+        return DateUnit::Hour;
+    } else if (unit == "minute") {
+        return DateUnit::Minute;
+    } else if (unit == "second") {
+        return DateUnit::Second;
+    } else if (allow_part_only_unit && unit == "dow") {
+        return DateUnit::DayOfWeek;
+    } else if (allow_part_only_unit && unit == "doy") {
+        return DateUnit::DayOfYear;
+    }
+    PanicInfo(ExprInvalid, "unsupported date unit: {}", unit);
+}
+
+int64_t
+FloorDiv(int64_t a, int64_t b) {
+    auto q = a / b;
+    return (a % b != 0 && ((a < 0) != (b < 0))) ? q - 1 : q;
+}
+
+// http://howardhinnant.github.io/date_algorithms.html
+int64_t
+DaysFromCivil(int64_t y, int64_t m, int64_t d) {
+    y -= m <= 2;
+    auto era = FloorDiv(y, 400);
+    auto yoe = y - era * 400;
+    auto doy = (153 * (m > 2 ? m - 3 : m + 9) + 2) / 5 + d - 1;
+    auto doe = yoe * 365 + yoe / 4 - yoe / 100 + doy;
+    return era * 146097 + doe - 719468;
+}
+
+void
+CivilFromDays(int64_t z, int64_t& y, int64_t& m, int64_t& d) {
+    z += 719468;
+    auto era = FloorDiv(z, 146097);
+    auto doe = z - era * 146097;
+    auto yoe = (doe - doe / 1460 + doe / 36524 - doe / 146096) / 365;
+    auto doy = doe - (365 * yoe + yoe / 4 - yoe / 100);
+    auto mp = (5 * doy + 2) / 153;
+    d = doy - (153 * mp + 2) / 5 + 1;
+    m = mp < 10 ? mp + 3 : mp - 9;
+    y = yoe + era * 400 + (m <= 2);
+}
+
+int64_t
+TruncTimestamp(int64_t ts, DateUnit unit) {
+    auto days = FloorDiv(ts, kSecondsPerDay);
+    int64_t y, m, d;
+    switch (unit) {
+        case DateUnit::Year:
+            CivilFromDays(days, y, m, d);
+            return DaysFromCivil(y, 1, 1) * kSecondsPerDay;
+        case DateUnit::Month:
+            CivilFromDays(days, y, m, d);
+            return DaysFromCivil(y, m, 1) * kSecondsPerDay;
+        case DateUnit::Day:
+            return days * kSecondsPerDay;
+        case DateUnit::Hour:
+            return FloorDiv(ts, kSecondsPerHour) * kSecondsPerHour;
+        case DateUnit::Minute:
+            return FloorDiv(ts, kSecondsPerMinute) * kSecondsPerMinute;
+        default:
+            return ts;
+    }
+}
+
+int64_t
+ExtractTimestampPart(int64_t ts, DateUnit unit) {
+    auto days = FloorDiv(ts, kSecondsPerDay);
+    auto seconds_of_day = ts - days * kSecondsPerDay;
+    int64_t y, m, d;
+    CivilFromDays(days, y, m, d);
+    switch (unit) {
+        case DateUnit::Year:
+            return y;
+        case DateUnit::Month:
+            return m;
+        case DateUnit::Day:
+            return d;
+        case DateUnit::Hour:
+            return seconds_of_day / kSecondsPerHour;
+        case DateUnit::Minute:
+            return seconds_of_day % kSecondsPerHour / kSecondsPerMinute;
+        case DateUnit::Second:
+            return seconds_of_day % kSecondsPerMinute;
+        case DateUnit::DayOfWeek: {
+            // 1970-01-01 is a Thursday, Sunday is 0
+            auto dow = (days + 4) % 7;
+            return dow < 0 ? dow + 7 : dow;
+        }
+        case DateUnit::DayOfYear:
+            return days - DaysFromCivil(y, 1, 1) + 1;
+    }
+    PanicInfo(ExprInvalid, "unsupported date unit");
+}
+
+template <typename Func>
+void
+DateFunction(const RowVector& args,
+             FilterFunctionReturn& result,
+             bool allow_part_only_unit,
+             Func func) {
+    CheckArgCount(args, 2);
+    auto units = GetSimpleVectorArg(args, 0);
+    CheckVarcharOrStringType(units);
+    auto timestamps = GetSimpleVectorArg(args, 1);
+    CheckNumericType(timestamps);
+
+    auto res_vec =
+        std::make_shared<ColumnVector>(DataType::INT64, timestamps->size());
+    auto* res_value = res_vec->RawAsValues<int64_t>();
+    TargetBitmapView valid_res(res_vec->GetValidRawData(), timestamps->size());
+    for (size_t i = 0; i < timestamps->size(); ++i) {
+        if (!units->ValidAt(i) || !timestamps->ValidAt(i)) {
+            valid_res[i] = false;
+            continue;
+        }
+        auto* unit_ptr = reinterpret_cast<std::string*>(
+            units->RawValueAt(i, sizeof(std::string)));
+        auto unit = ParseDateUnit(*unit_ptr, allow_part_only_unit);
+        res_value[i] = func(GetInt64ValueAt(timestamps, i), unit);
+    }
+    result = res_vec;
+}
+
+}  // namespace
+
+void
+DateTrunc(const RowVector& args, FilterFunctionReturn& result) {
+    DateFunction(args, result, false, TruncTimestamp);
+}
+
+void
+DatePart(const RowVector& args, FilterFunctionReturn& result) {
+    DateFunction(args, result, true, ExtractTimestampPart);
+}
+
+}  // namespace function
+}  // namespace expression
+}  // namespace exec
+}  // namespace milvus
@@ -0,0 +1,501 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+#include "exec/expression/function/FunctionImplUtils.h"
+#include "exec/expression/function/impl/GeoFunctions.h"
+
+#include <algorithm>
+#include <cctype>
+#include <cmath>
+#include <cstring>
+#include <limits>
+#include <optional>
+#include <string>
+#include <vector>
+#include "common/EasyAssert.h"
+#include "exec/expression/function/FunctionFactory.h"
+
+namespace milvus {
+namespace exec {
+namespace expression {
+namespace function {
+
+// The geometries are stored as WKB and the constants in the plan are WKT,
+// see also pkg/util/geo, both sides have been validated by the proxy.
+namespace {
+
+// mean earth radius in meters
+constexpr double kEarthRadius = 6371008.8;
+
+enum class GeometryType : uint32_t {
+    Point = 1,
+    LineString = 2,
+    Polygon = 3,
+};
+
+struct Point {
+    double x;
+    double y;
+
+    bool
+    operator==(const Point& other) const {
+        return x == other.x && y == other.y;
+    }
+};
+
+struct Segment {
+    Point a;
+    Point b;
+};
+
+struct Geometry {
+    GeometryType type;
+    // a single point for a point, the points for a line string,
+    // or the exterior ring followed by the holes for a polygon
+    std::vector<std::vector<Point>> rings;
+};
+
+class WkbReader {
+ public:
+    explicit WkbReader(const std::string& data) : data_(data) {
+    }
+
+    Geometry
+    Read() {
+        AssertInfo(data_.size() >= 5, "invalid wkb, too short");
+        AssertInfo(data_[0] == 0 || data_[0] == 1,
+                   "invalid wkb byte order {}",
+                   static_cast<int>(data_[0]));
+        little_endian_ = data_[0] == 1;
+        offset_ = 1;
+        Geometry g;
+        g.type = static_cast<GeometryType>(ReadUint32());
+        switch (g.type) {
+            case GeometryType::Point:
+                g.rings.push_back(ReadPoints(1));
+                break;
+            case GeometryType::LineString:
+                g.rings.push_back(ReadPoints(ReadUint32()));
+                break;
+            case GeometryType::Polygon: {
+                auto num_rings = ReadUint32();
+                for (uint32_t i = 0; i < num_rings; ++i) {
+                    g.rings.push_back(ReadPoints(ReadUint32()));
+                }
+                break;
+            }
+            default:
+                PanicInfo(ExprInvalid,
+                          "unsupported geometry type {}",
+                          static_cast<uint32_t>(g.type));
+        }
+        AssertInfo(offset_ == data_.size(), "invalid wkb, trailing data");
+        return g;
+    }
+
+ private:
+    template <typename T>
+    T
+    ReadValue() {
+        AssertInfo(offset_ + sizeof(T) <= data_.size(),
+                   "invalid wkb, unexpected end of data");
+        unsigned char buf[sizeof(T)];
+        std::memcpy(buf, data_.data() + offset_, sizeof(T));
+        offset_ += sizeof(T);
+        // the wkb byte order differs from the host order (little endian)
+        if (!little_endian_) {
+            std::reverse(buf, buf + sizeof(T));
+        }
+        T value;
+        std::memcpy(&value, buf, sizeof(T));
+        return value;
+    }
+
+    uint32_t
+    ReadUint32() {
+        return ReadValue<uint32_t>();
+    }
+
+    std::vector<Point>
+    ReadPoints(uint32_t n) {
+        AssertInfo(offset_ + static_cast<uint64_t>(n) * 16 <= data_.size(),
+                   "invalid wkb, unexpected end of data");
+        std::vector<Point> points(n);
+        for (auto& p : points) {
+            p.x = ReadValue<double>();
+            p.y = ReadValue<double>();
+        }
+        return points;
+    }
+
+    const std::string& data_;
+    size_t offset_ = 0;
+    bool little_endian_ = true;
+};
+
+class WktParser {
+ public:
+    explicit WktParser(const std::string& input) : input_(input) {
+    }
+
+    Geometry
+    Parse() {
+        SkipSpaces();
+        auto start = pos_;
+        while (pos_ < input_.size() && std::isalpha(input_[pos_])) {
+            pos_++;
+        }
+        auto name = input_.substr(start, pos_ - start);
+        std::transform(name.begin(), name.end(), name.begin(), ::toupper);
+        Geometry g;
+        if (name == "POINT" || name == "LINESTRING") {
+            g.type = name == "POINT" ? GeometryType::Point
+                                     : GeometryType::LineString;
+            g.rings.push_back(Points());
+        } else if (name == "POLYGON") {
+            g.type = GeometryType::Polygon;
+            Expect('(');
+            while (true) {
+                g.rings.push_back(Points());
+                if (!Peek(',')) {
+                    break;
+                }
+                pos_++;
+            }
+            Expect(')');
+        } else {
+            PanicInfo(ExprInvalid, "unsupported geometry type {}", name);
+        }
+        SkipSpaces();
+        AssertInfo(pos_ == input_.size(),
+                   "invalid wkt {}, unexpected character at {}",
+                   input_,
+                   pos_);
+        return g;
+    }
+
+ private:
+    void
+    SkipSpaces() {
+        while (pos_ < input_.size() && std::isspace(input_[pos_])) {
+            pos_++;
+        }
+    }
+
+    void
+    Expect(char c) {
+        SkipSpaces();
+        AssertInfo(pos_ < input_.size() && input_[pos_] == c,
+                   "invalid wkt {}, expect '{}' at {}",
+                   input_,
+                   c,
+                   pos_);
+        pos_++;
+    }
+
+    bool
+    Peek(char c) {
+        SkipSpaces();
+        return pos_ < input_.size() && input_[pos_] == c;
+    }
+
+    double
+    Number() {
+        SkipSpaces();
+        auto start = input_.c_str() + pos_;
+        char* end = nullptr;
+        auto value = std::strtod(start, &end);
+        AssertInfo(end != start,
+                   "invalid wkt {}, expect a number at {}",
+                   input_,
+                   pos_);
+        pos_ += end - start;
+        return value;
+    }
+
+    std::vector<Point>
+    Points() {
+        Expect('(');
+        std::vector<Point> points;
+        while (true) {
+            auto x = Number();
+            auto y = Number();
+            points.push_back({x, y});
+            if (!Peek(',')) {
+                break;
+            }
+            pos_++;
+        }
+        Expect(')');
+        return points;
+    }
+
+    const std::string& input_;
+    size_t pos_ = 0;
+};
+
+std::vector<Point>
+Vertices(const Geometry& g) {
+    std::vector<Point> points;
+    for (auto& ring : g.rings) {
+        points.insert(points.end(), ring.begin(), ring.end());
+    }
+    return points;
+}
+
+// a point is a degenerate segment
+std::vector<Segment>
+Segments(const Geometry& g) {
+    if (g.type == GeometryType::Point) {
+        auto p = g.rings[0][0];
+        return {{p, p}};
+    }
+    std::vector<Segment> segments;
+    for (auto& ring : g.rings) {
+        for (size_t i = 0; i + 1 < ring.size(); ++i) {
+            segments.push_back({ring[i], ring[i + 1]});
+        }
+    }
+    return segments;
+}
+
+double
+ToRadians(double degree) {
+    return degree * M_PI / 180;
+}
+
+double
+Haversine(const Point& p, const Point& q) {
+    auto lat1 = ToRadians(p.y);
+    auto lat2 = ToRadians(q.y);
+    auto d_lat = lat2 - lat1;
+    auto d_lon = ToRadians(q.x - p.x);
+    auto h = std::sin(d_lat / 2) * std::sin(d_lat / 2) +
+             std::cos(lat1) * std::cos(lat2) * std::sin(d_lon / 2) *
+                 std::sin(d_lon / 2);
+    return 2 * kEarthRadius * std::asin(std::min(1.0, std::sqrt(h)));
+}
+
+// the closest point of the segment is found in the equirectangular
+// projection around p, which is accurate for the short segments.
+double
+PointSegmentDistance(const Point& p, const Segment& s) {
+    if (s.a == s.b) {
+        return Haversine(p, s.a);
+    }
+    auto scale = std::cos(ToRadians(p.y));
+    auto ax = (s.a.x - p.x) * scale, ay = s.a.y - p.y;
+    auto bx = (s.b.x - p.x) * scale, by = s.b.y - p.y;
+    auto dx = bx - ax, dy = by - ay;
+    auto t = -(ax * dx + ay * dy) / (dx * dx + dy * dy);
+    t = std::max(0.0, std::min(1.0, t));
+    Point closest{s.a.x + t * (s.b.x - s.a.x), s.a.y + t * (s.b.y - s.a.y)};
+    return Haversine(p, closest);
+}
+
+double
+Cross(const Point& o, const Point& a, const Point& b) {
+    return (a.x - o.x) * (b.y - o.y) - (a.y - o.y) * (b.x - o.x);
+}
+
+bool
+OnSegment(const Point& p, const Segment& s) {
+    return Cross(s.a, s.b, p) == 0 && std::min(s.a.x, s.b.x) <= p.x &&
+           p.x <= std::max(s.a.x, s.b.x) && std::min(s.a.y, s.b.y) <= p.y &&
+           p.y <= std::max(s.a.y, s.b.y);
+}
+
+// whether the segments cross each other at a point interior to both
+bool
+SegmentsCross(const Segment& s1, const Segment& s2) {
+    auto d1 = Cross(s2.a, s2.b, s1.a), d2 = Cross(s2.a, s2.b, s1.b);
+    auto d3 = Cross(s1.a, s1.b, s2.a), d4 = Cross(s1.a, s1.b, s2.b);
+    return ((d1 > 0 && d2 < 0) || (d1 < 0 && d2 > 0)) &&
+           ((d3 > 0 && d4 < 0) || (d3 < 0 && d4 > 0));
+}
+
+// whether the segments share any point
+bool
+SegmentsIntersect(const Segment& s1, const Segment& s2) {
+    return SegmentsCross(s1, s2) || OnSegment(s1.a, s2) ||
+           OnSegment(s1.b, s2) || OnSegment(s2.a, s1) || OnSegment(s2.b, s1);
+}
+
+bool
+PointInRing(const Point& p, const std::vector<Point>& ring) {
+    bool inside = false;
+    for (size_t i = 0, j = ring.size() - 1; i < ring.size(); j = i++) {
+        auto& a = ring[i];
+        auto& b = ring[j];
+        if ((a.y > p.y) != (b.y > p.y) &&
+            p.x < (b.x - a.x) * (p.y - a.y) / (b.y - a.y) + a.x) {
+            inside = !inside;
+        }
+    }
+    return inside;
+}
+
+// whether the point is inside the polygon or on its boundary
+bool
+ContainsPoint(const Geometry& g, const Point& p) {
+    if (g.type != GeometryType::Polygon) {
+        return false;
+    }
+    for (auto& s : Segments(g)) {
+        if (OnSegment(p, s)) {
+            return true;
+        }
+    }
+    if (!PointInRing(p, g.rings[0])) {
+        return false;
+    }
+    for (size_t i = 1; i < g.rings.size(); ++i) {
+        if (PointInRing(p, g.rings[i])) {
+            return false;
+        }
+    }
+    return true;
+}
+
+double
+Distance(const Geometry& a, const Geometry& b) {
+    if (a.type == GeometryType::Point && b.type == GeometryType::Point) {
+        return Haversine(a.rings[0][0], b.rings[0][0]);
+    }
+    if (ContainsPoint(b, a.rings[0][0]) || ContainsPoint(a, b.rings[0][0])) {
+        return 0;
+    }
+    auto segments_a = Segments(a);
+    auto segments_b = Segments(b);
+    for (auto& s1 : segments_a) {
+        for (auto& s2 : segments_b) {
+            if (SegmentsIntersect(s1, s2)) {
+                return 0;
+            }
+        }
+    }
+    auto distance = std::numeric_limits<double>::infinity();
+    for (auto& p : Vertices(a)) {
+        for (auto& s : segments_b) {
+            distance = std::min(distance, PointSegmentDistance(p, s));
+        }
+    }
+    for (auto& p : Vertices(b)) {
+        for (auto& s : segments_a) {
+            distance = std::min(distance, PointSegmentDistance(p, s));
+        }
+    }
+    return distance;
+}
+
+bool
+Within(const Geometry& a, const Geometry& b) {
+    if (b.type != GeometryType::Polygon) {
+        return false;
+    }
+    for (auto& p : Vertices(a)) {
+        if (!ContainsPoint(b, p)) {
+            return false;
+        }
+    }
+    auto segments_b = Segments(b);
+    for (auto& s1 : Segments(a)) {
+        for (auto& s2 : segments_b) {
+            if (SegmentsCross(s1, s2)) {
+                return false;
+            }
+        }
+    }
+    return true;
+}
+
+void
+CheckGeometryType(std::shared_ptr<SimpleVector>& vec) {
+    if (vec->type() != DataType::GEOMETRY) {
+        PanicInfo(ExprInvalid,
+                  "invalid argument type, expect GEOMETRY, actual {}",
+                  vec->type());
+    }
+}
+
+// calls func(row, geometry, constant) for every row where both arguments
+// are valid and on_null(row) for the others, the constant WKT is parsed
+// once as it rarely changes.
+template <typename NullFunc, typename Func>
+void
+ForEachGeometry(const RowVector& args, NullFunc on_null, Func func) {
+    auto geometries = GetSimpleVectorArg(args, 0);
+    CheckGeometryType(geometries);
+    auto wkts = GetSimpleVectorArg(args, 1);
+    CheckVarcharOrStringType(wkts);
+
+    std::optional<std::string> last_wkt;
+    Geometry constant;
+    for (size_t i = 0; i < geometries->size(); ++i) {
+        if (!geometries->ValidAt(i) || !wkts->ValidAt(i)) {
+            on_null(i);
+            continue;
+        }
+        auto* wkb = reinterpret_cast<std::string*>(
+            geometries->RawValueAt(i, sizeof(std::string)));
+        auto* wkt = reinterpret_cast<std::string*>(
+            wkts->RawValueAt(i, sizeof(std::string)));
+        if (!last_wkt.has_value() || last_wkt.value() != *wkt) {
+            constant = WktParser(*wkt).Parse();
+            last_wkt = *wkt;
+        }
+        func(i, WkbReader(*wkb).Read(), constant);
+    }
+}
+
+}  // namespace
+
+void
+StDistance(const RowVector& args, FilterFunctionReturn& result) {
+    CheckArgCount(args, 2);
+    auto size = GetSimpleVectorArg(args, 0)->size();
+    auto res_vec = std::make_shared<ColumnVector>(DataType::DOUBLE, size);
+    TargetBitmapView valid_res(res_vec->GetValidRawData(), size);
+    auto* values = res_vec->RawAsValues<double>();
+    ForEachGeometry(
+        args,
+        [&](size_t i) { valid_res[i] = false; },
+        [&](size_t i, const Geometry& geometry, const Geometry& constant) {
+            values[i] = Distance(geometry, constant);
+        });
+    result = res_vec;
+}
+
+void
+StWithin(const RowVector& args, FilterFunctionReturn& result) {
+    CheckArgCount(args, 2);
+    auto size = GetSimpleVectorArg(args, 0)->size();
+    TargetBitmap bitmap(size, false);
+    TargetBitmap valid_bitmap(size, true);
+    ForEachGeometry(
+        args,
+        [&](size_t i) { valid_bitmap[i] = false; },
+        [&](size_t i, const Geometry& geometry, const Geometry& constant) {
+            bitmap.set(i, Within(geometry, constant));
+        });
+    result = std::make_shared<ColumnVector>(std::move(bitmap),
+                                            std::move(valid_bitmap));
+}
+
+}  // namespace function
+}  // namespace expression
+}  // namespace exec
+}  // namespace milvus
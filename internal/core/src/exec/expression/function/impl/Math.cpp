@@ -0,0 +1,90 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+#include "exec/expression/function/FunctionImplUtils.h"
+#include "exec/expression/function/impl/MathFunctions.h"
+
+#include <cmath>
+#include <cstdlib>
+#include "common/EasyAssert.h"
+#include "exec/expression/function/FunctionFactory.h"
+
+namespace milvus {
+namespace exec {
+namespace expression {
+namespace function {
+
+template <typename IntegerFunc, typename FloatingFunc>
+static void
+UnaryNumericFunction(const RowVector& args,
+                     FilterFunctionReturn& result,
+                     IntegerFunc integer_func,
+                     FloatingFunc floating_func) {
+    CheckArgCount(args, 1);
+    auto nums = GetSimpleVectorArg(args, 0);
+    CheckNumericType(nums);
+
+    auto is_integer = IsIntegerVector(nums);
+    auto res_vec = std::make_shared<ColumnVector>(
+        is_integer ? DataType::INT64 : DataType::DOUBLE, nums->size());
+    TargetBitmapView valid_res(res_vec->GetValidRawData(), nums->size());
+    for (size_t i = 0; i < nums->size(); ++i) {
+        if (!nums->ValidAt(i)) {
+            valid_res[i] = false;
+            continue;
+        }
+        if (is_integer) {
+            res_vec->RawAsValues<int64_t>()[i] =
+                integer_func(GetInt64ValueAt(nums, i));
+        } else {
+            res_vec->RawAsValues<double>()[i] =
+                floating_func(GetDoubleValueAt(nums, i));
+        }
+    }
+    result = res_vec;
+}
+
+void
+Abs(const RowVector& args, FilterFunctionReturn& result) {
+    UnaryNumericFunction(
+        args,
+        result,
+        [](int64_t v) { return std::llabs(v); },
+        [](double v) { return std::fabs(v); });
+}
+
+void
+Ceil(const RowVector& args, FilterFunctionReturn& result) {
+    UnaryNumericFunction(
+        args,
+        result,
+        [](int64_t v) { return v; },
+        [](double v) { return std::ceil(v); });
+}
+
+void
+Floor(const RowVector& args, FilterFunctionReturn& result) {
+    UnaryNumericFunction(
+        args,
+        result,
+        [](int64_t v) { return v; },
+        [](double v) { return std::floor(v); });
+}
+
+}  // namespace function
+}  // namespace expression
+}  // namespace exec
+}  // namespace milvus
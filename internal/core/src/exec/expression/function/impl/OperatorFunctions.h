@@ -0,0 +1,66 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+#pragma once
+
+#include "common/Vector.h"
+#include "exec/expression/function/FunctionFactory.h"
+
+namespace milvus {
+namespace exec {
+namespace expression {
+namespace function {
+
+// arithmetic functions produce INT64 if both arguments are integers,
+// otherwise DOUBLE. Division or modulo by zero produces null.
+void
+Add(const RowVector& args, FilterFunctionReturn& result);
+
+void
+Sub(const RowVector& args, FilterFunctionReturn& result);
+
+void
+Mul(const RowVector& args, FilterFunctionReturn& result);
+
+void
+Div(const RowVector& args, FilterFunctionReturn& result);
+
+void
+Mod(const RowVector& args, FilterFunctionReturn& result);
+
+// comparison functions accept two numeric or two varchar arguments
+void
+Equal(const RowVector& args, FilterFunctionReturn& result);
+
+void
+NotEqual(const RowVector& args, FilterFunctionReturn& result);
+
+void
+LessThan(const RowVector& args, FilterFunctionReturn& result);
+
+void
+LessEqual(const RowVector& args, FilterFunctionReturn& result);
+
+void
+GreaterThan(const RowVector& args, FilterFunctionReturn& result);
+
+void
+GreaterEqual(const RowVector& args, FilterFunctionReturn& result);
+
+}  // namespace function
+}  // namespace expression
+}  // namespace exec
+}  // namespace milvus
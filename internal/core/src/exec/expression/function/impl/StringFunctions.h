@@ -29,6 +29,17 @@ EmptyVarchar(const RowVector& args, FilterFunctionReturn& result);
 void
 StartsWithVarchar(const RowVector& args, FilterFunctionReturn& result);
 
+// lower and upper only convert ASCII letters
+void
+LowerVarchar(const RowVector& args, FilterFunctionReturn& result);
+
+void
+UpperVarchar(const RowVector& args, FilterFunctionReturn& result);
+
+// substr(str, start[, length]) counts UTF-8 code points from 1
+void
+SubstrVarchar(const RowVector& args, FilterFunctionReturn& result);
+
 }  // namespace function
 }  // namespace expression
 }  // namespace exec
@@ -0,0 +1,103 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+
+#include "exec/expression/function/FunctionImplUtils.h"
+#include "exec/expression/function/impl/StringFunctions.h"
+
+#include <algorithm>
+#include <limits>
+#include <string>
+#include <vector>
+#include "common/EasyAssert.h"
+#include "exec/expression/function/FunctionFactory.h"
+
+namespace milvus {
+namespace exec {
+namespace expression {
+namespace function {
+
+// byte offsets of every code point in str, plus str.size() at the end
+static std::vector<size_t>
+CodePointOffsets(const std::string& str) {
+    std::vector<size_t> offsets;
+    offsets.reserve(str.size() + 1);
+    for (size_t i = 0; i < str.size(); ++i) {
+        // skip utf-8 continuation bytes
+        if ((static_cast<unsigned char>(str[i]) & 0xC0) != 0x80) {
+            offsets.push_back(i);
+        }
+    }
+    offsets.push_back(str.size());
+    return offsets;
+}
+
+void
+SubstrVarchar(const RowVector& args, FilterFunctionReturn& result) {
+    if (args.childrens().size() != 2 && args.childrens().size() != 3) {
+        PanicInfo(ExprInvalid,
+                  "invalid argument count, expect 2 or 3, actual {}",
+                  args.childrens().size());
+    }
+    auto strs = GetSimpleVectorArg(args, 0);
+    CheckVarcharOrStringType(strs);
+    auto starts = GetSimpleVectorArg(args, 1);
+    CheckNumericType(starts);
+    std::shared_ptr<SimpleVector> lengths = nullptr;
+    if (args.childrens().size() == 3) {
+        lengths = GetSimpleVectorArg(args, 2);
+        CheckNumericType(lengths);
+    }
+
+    auto res_vec =
+        std::make_shared<ColumnVector>(DataType::VARCHAR, strs->size());
+    auto* res_value = res_vec->RawAsValues<std::string>();
+    TargetBitmapView valid_res(res_vec->GetValidRawData(), strs->size());
+    for (size_t i = 0; i < strs->size(); ++i) {
+        if (!strs->ValidAt(i) || !starts->ValidAt(i) ||
+            (lengths != nullptr && !lengths->ValidAt(i))) {
+            valid_res[i] = false;
+            continue;
+        }
+        auto* str_ptr = reinterpret_cast<std::string*>(
+            strs->RawValueAt(i, sizeof(std::string)));
+        auto offsets = CodePointOffsets(*str_ptr);
+        int64_t num_code_points = offsets.size() - 1;
+
+        // same as SQL, the window [start, start + length) is clipped
+        // to [1, num_code_points].
+        int64_t start = GetInt64ValueAt(starts, i);
+        int64_t end = std::numeric_limits<int64_t>::max();
+        if (lengths != nullptr) {
+            auto length = GetInt64ValueAt(lengths, i);
+            end = length < 0 ? start : start + length;
+        }
+        auto begin = std::max<int64_t>(start, 1);
+        end = std::min<int64_t>(end, num_code_points + 1);
+        if (end <= begin) {
+            res_value[i] = "";
+            continue;
+        }
+        res_value[i] = str_ptr->substr(offsets[begin - 1],
+                                       offsets[end - 1] - offsets[begin - 1]);
+    }
+    result = res_vec;
+}
+
+}  // namespace function
+}  // namespace expression
+}  // namespace exec
+}  // namespace milvus
@@ -631,8 +631,10 @@ class CallExpr : public ITypeFilterExpr {
  public:
     CallExpr(const std::string fun_name,
              const std::vector<TypedExprPtr>& parameters,
-             const exec::expression::FilterFunctionPtr function_ptr)
+             const exec::expression::FilterFunctionPtr function_ptr,
+             DataType return_type = DataType::BOOL)
         : fun_name_(std::move(fun_name)), function_ptr_(function_ptr) {
+        type_ = return_type;
         inputs_.insert(inputs_.end(), parameters.begin(), parameters.end());
     }
 
@@ -655,9 +657,11 @@ class CallExpr : public ITypeFilterExpr {
             parameters += e->ToString();
             parameters += ", ";
         }
-        return fmt::format("CallExpr:[Function Name: {}, Parameters: {}]",
-                           fun_name_,
-                           parameters);
+        return fmt::format(
+            "CallExpr:[Function Name: {}, Parameters: {}, Return Type: {}]",
+            fun_name_,
+            parameters,
+            GetDataTypeName(type_));
     }
 
  private:
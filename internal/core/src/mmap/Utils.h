@@ -57,6 +57,7 @@ PaddingSize(const DataType& type) {
             return simdjson::SIMDJSON_PADDING;
         case DataType::VARCHAR:
         case DataType::STRING:
+        case DataType::GEOMETRY:
             return FILE_STRING_PADDING;
             break;
         case DataType::ARRAY:
@@ -95,7 +96,8 @@ WriteFieldData(File& file,
     if (IsVariableDataType(data_type)) {
         switch (data_type) {
             case DataType::VARCHAR:
-            case DataType::STRING: {
+            case DataType::STRING:
+            case DataType::GEOMETRY: {
                 // write as: |size|data|size|data......
                 for (auto i = 0; i < data->get_num_rows(); ++i) {
                     indices.push_back(total_written);
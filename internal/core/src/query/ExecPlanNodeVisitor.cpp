@@ -189,6 +189,8 @@ ExecPlanNodeVisitor::visit(RetrievePlanNode& node) {
         retrieve_result_opt_ = std::move(query_context->get_retrieve_result());
     } else {
         retrieve_result.total_data_cnt_ = bitset_holder.size();
+        retrieve_result.filtered_data_cnt_ =
+            bitset_holder.size() - bitset_holder.count();
         tracer::AutoSpan _("Find Limit Pk", tracer::GetRootSpan());
         auto results_pair = segment->find_first(node.limit_, bitset_holder);
         retrieve_result.result_offsets_ = std::move(results_pair.first);
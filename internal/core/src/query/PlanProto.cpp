@@ -321,8 +321,18 @@ ProtoParser::ParseCallExprs(const proto::plan::CallExpr& expr_pb) {
         PanicInfo(ExprInvalid,
                   "function " + func_sig.ToString() + " not found. ");
     }
+    auto return_type = factory.GetFilterFunctionReturnType(func_sig);
+    // plans from older proxies do not carry the return type
+    auto expected_type = static_cast<DataType>(expr_pb.return_type());
+    if (expected_type != DataType::NONE && expected_type != return_type) {
+        PanicInfo(ExprInvalid,
+                  "function {} returns {}, but {} is expected",
+                  func_sig.ToString(),
+                  GetDataTypeName(return_type),
+                  GetDataTypeName(expected_type));
+    }
     return std::make_shared<expr::CallExpr>(
-        expr_pb.function_name(), parameters, function);
+        expr_pb.function_name(), parameters, function, return_type);
 }
 
 expr::TypedExprPtr
@@ -366,7 +366,8 @@ ChunkedSegmentSealedImpl::LoadFieldData(FieldId field_id, FieldDataInfo& data) {
             int64_t field_data_size = 0;
             switch (data_type) {
                 case milvus::DataType::STRING:
-                case milvus::DataType::VARCHAR: {
+                case milvus::DataType::VARCHAR:
+                case milvus::DataType::GEOMETRY: {
                     auto var_column =
                         std::make_shared<ChunkedVariableColumn<std::string>>(
                             field_meta);
@@ -569,7 +570,8 @@ ChunkedSegmentSealedImpl::MapFieldData(const FieldId field_id,
     if (IsVariableDataType(data_type)) {
         switch (data_type) {
             case milvus::DataType::STRING:
-            case milvus::DataType::VARCHAR: {
+            case milvus::DataType::VARCHAR:
+            case milvus::DataType::GEOMETRY: {
                 // auto var_column = std::make_shared<VariableColumn<std::string>>(
                 //     file,
                 //     total_written,
@@ -1645,6 +1647,17 @@ ChunkedSegmentSealedImpl::get_raw_data(FieldId field_id,
             break;
         }
 
+        case DataType::GEOMETRY: {
+            bulk_subscript_ptr_impl<std::string>(
+                column.get(),
+                seg_offsets,
+                count,
+                ret->mutable_scalars()
+                    ->mutable_geometry_data()
+                    ->mutable_data());
+            break;
+        }
+
         case DataType::JSON: {
             bulk_subscript_ptr_impl<Json, std::string>(
                 column.get(),
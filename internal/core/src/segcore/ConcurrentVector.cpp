@@ -88,6 +88,12 @@ VectorBase::set_data_raw(ssize_t element_offset,
                                               field_data.end());
             return set_data_raw(element_offset, data_raw.data(), element_count);
         }
+        case DataType::GEOMETRY: {
+            auto& field_data = FIELD_DATA(data, geometry);
+            std::vector<std::string> data_raw(field_data.begin(),
+                                              field_data.end());
+            return set_data_raw(element_offset, data_raw.data(), element_count);
+        }
         case DataType::JSON: {
             auto& json_data = FIELD_DATA(data, json);
             std::vector<Json> data_raw{};
@@ -447,7 +447,8 @@ struct InsertRecord {
                     this->append_data<double>(field_id, size_per_chunk);
                     break;
                 }
-                case DataType::VARCHAR: {
+                case DataType::VARCHAR:
+                case DataType::GEOMETRY: {
                     this->append_data<std::string>(field_id, size_per_chunk);
                     break;
                 }
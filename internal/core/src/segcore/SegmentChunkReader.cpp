@@ -179,7 +179,8 @@ SegmentChunkReader::GetChunkDataAccessor(DataType data_type,
         case DataType::DOUBLE:
             return GetChunkDataAccessor<double>(
                 field_id, index, current_chunk_id, current_chunk_pos);
-        case DataType::VARCHAR: {
+        case DataType::VARCHAR:
+        case DataType::GEOMETRY: {
             return GetChunkDataAccessor<std::string>(
                 field_id, index, current_chunk_id, current_chunk_pos);
         }
@@ -288,7 +289,8 @@ SegmentChunkReader::GetChunkDataAccessor(DataType data_type,
         case DataType::DOUBLE:
             return GetChunkDataAccessor<double>(
                 field_id, chunk_id, data_barrier);
-        case DataType::VARCHAR: {
+        case DataType::VARCHAR:
+        case DataType::GEOMETRY: {
             return GetChunkDataAccessor<std::string>(
                 field_id, chunk_id, data_barrier);
         }
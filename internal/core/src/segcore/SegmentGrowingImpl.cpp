@@ -619,6 +619,15 @@ SegmentGrowingImpl::bulk_subscript(FieldId field_id,
                                                      ->mutable_data());
             break;
         }
+        case DataType::GEOMETRY: {
+            bulk_subscript_ptr_impl<std::string>(vec_ptr,
+                                                 seg_offsets,
+                                                 count,
+                                                 result->mutable_scalars()
+                                                     ->mutable_geometry_data()
+                                                     ->mutable_data());
+            break;
+        }
         case DataType::JSON: {
             bulk_subscript_ptr_impl<Json, std::string>(
                 vec_ptr,
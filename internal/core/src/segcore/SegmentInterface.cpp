@@ -105,8 +105,15 @@ SegmentInternalInterface::Retrieve(tracer::TraceContext* trace_ctx,
     results->set_has_more_result(retrieve_results.has_more_result);
 
     auto result_rows = retrieve_results.result_offsets_.size();
+    auto pk_field_id = plan->schema_.get_primary_field_id();
     int64_t output_data_size = 0;
     for (auto field_id : plan->field_ids_) {
+        // only the pk and system fields are filled if ignore_non_pk,
+        // the other fields are retrieved by offsets later.
+        if (ignore_non_pk && !SystemProperty::Instance().IsSystem(field_id) &&
+            !(pk_field_id.has_value() && pk_field_id.value() == field_id)) {
+            continue;
+        }
         output_data_size += get_field_avg_size(field_id) * result_rows;
     }
     if (output_data_size > limit_size) {
@@ -116,6 +123,7 @@ SegmentInternalInterface::Retrieve(tracer::TraceContext* trace_ctx,
     }
 
     results->set_all_retrieve_count(retrieve_results.total_data_cnt_);
+    results->set_filtered_count(retrieve_results.filtered_data_cnt_);
     if (plan->plan_node_->is_count_) {
         AssertInfo(retrieve_results.field_data_.size() == 1,
                    "count result should only have one column");
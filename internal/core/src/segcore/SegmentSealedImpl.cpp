@@ -387,7 +387,8 @@ SegmentSealedImpl::LoadFieldData(FieldId field_id, FieldDataInfo& data) {
             int64_t field_data_size = 0;
             switch (data_type) {
                 case milvus::DataType::STRING:
-                case milvus::DataType::VARCHAR: {
+                case milvus::DataType::VARCHAR:
+                case milvus::DataType::GEOMETRY: {
                     auto var_column = std::make_shared<
                         SingleChunkVariableColumn<std::string>>(
                         num_rows, field_meta, get_block_size());
@@ -563,7 +564,8 @@ SegmentSealedImpl::MapFieldData(const FieldId field_id, FieldDataInfo& data) {
     if (IsVariableDataType(data_type)) {
         switch (data_type) {
             case milvus::DataType::STRING:
-            case milvus::DataType::VARCHAR: {
+            case milvus::DataType::VARCHAR:
+            case milvus::DataType::GEOMETRY: {
                 auto var_column =
                     std::make_shared<SingleChunkVariableColumn<std::string>>(
                         file,
@@ -1459,6 +1461,17 @@ SegmentSealedImpl::get_raw_data(FieldId field_id,
             break;
         }
 
+        case DataType::GEOMETRY: {
+            bulk_subscript_ptr_impl<std::string>(
+                column.get(),
+                seg_offsets,
+                count,
+                ret->mutable_scalars()
+                    ->mutable_geometry_data()
+                    ->mutable_data());
+            break;
+        }
+
         case DataType::JSON: {
             bulk_subscript_ptr_impl<Json, std::string>(
                 column.get(),
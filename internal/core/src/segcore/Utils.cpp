@@ -146,6 +146,13 @@ GetRawDataSizeOfDataArray(const DataArray* data,
                 }
                 break;
             }
+            case DataType::GEOMETRY: {
+                auto& geometry_data = FIELD_DATA(data, geometry);
+                for (auto& wkb : geometry_data) {
+                    result += wkb.size();
+                }
+                break;
+            }
             case DataType::ARRAY: {
                 auto& array_data = FIELD_DATA(data, array);
                 switch (field_meta.get_element_type()) {
@@ -292,6 +299,14 @@ CreateScalarDataArray(int64_t count, const FieldMeta& field_meta) {
             }
             break;
         }
+        case DataType::GEOMETRY: {
+            auto obj = scalar_array->mutable_geometry_data();
+            obj->mutable_data()->Reserve(count);
+            for (int i = 0; i < count; i++) {
+                *(obj->mutable_data()->Add()) = std::string();
+            }
+            break;
+        }
         case DataType::ARRAY: {
             auto obj = scalar_array->mutable_array_data();
             obj->mutable_data()->Reserve(count);
@@ -440,6 +455,14 @@ CreateScalarDataArrayFrom(const void* data_raw,
             }
             break;
         }
+        case DataType::GEOMETRY: {
+            auto data = reinterpret_cast<const std::string*>(data_raw);
+            auto obj = scalar_array->mutable_geometry_data();
+            for (auto i = 0; i < count; i++) {
+                *(obj->mutable_data()->Add()) = data[i];
+            }
+            break;
+        }
         case DataType::ARRAY: {
             auto data = reinterpret_cast<const ScalarArray*>(data_raw);
             auto obj = scalar_array->mutable_array_data();
@@ -655,6 +678,12 @@ MergeDataArray(std::vector<MergeBase>& merge_bases,
                 *(obj->mutable_data()->Add()) = data[src_offset];
                 break;
             }
+            case DataType::GEOMETRY: {
+                auto& data = FIELD_DATA(src_field_data, geometry);
+                auto obj = scalar_array->mutable_geometry_data();
+                *(obj->mutable_data()->Add()) = data[src_offset];
+                break;
+            }
             case DataType::ARRAY: {
                 auto& data = FIELD_DATA(src_field_data, array);
                 auto obj = scalar_array->mutable_array_data();
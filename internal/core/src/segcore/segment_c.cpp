@@ -106,6 +106,15 @@ DeleteSearchResult(CSearchResult search_result) {
     delete res;
 }
 
+void
+GetSearchResultDataCount(CSearchResult search_result,
+                         int64_t* total_data_cnt,
+                         int64_t* filtered_data_cnt) {
+    auto res = static_cast<milvus::SearchResult*>(search_result);
+    *total_data_cnt = res->total_data_cnt_;
+    *filtered_data_cnt = res->filtered_data_cnt_;
+}
+
 CFuture*  // Future<milvus::SearchResult*>
 AsyncSearch(CTraceContext c_trace,
             CSegmentInterface c_segment,
@@ -45,6 +45,11 @@ ClearSegmentData(CSegmentInterface c_segment);
 void
 DeleteSearchResult(CSearchResult search_result);
 
+void
+GetSearchResultDataCount(CSearchResult search_result,
+                         int64_t* total_data_cnt,
+                         int64_t* filtered_data_cnt);
+
 CFuture*  // Future<CSearchResultBody>
 AsyncSearch(CTraceContext c_trace,
             CSegmentInterface c_segment,
@@ -267,6 +267,17 @@ BaseEventData::Serialize() {
             }
             break;
         }
+        case DataType::GEOMETRY: {
+            for (size_t offset = 0; offset < field_data->get_num_rows();
+                 ++offset) {
+                auto wkb = static_cast<const std::string*>(
+                    field_data->RawValue(offset));
+                auto size = field_data->is_valid(offset) ? wkb->size() : -1;
+                payload_writer->add_one_binary_payload(
+                    reinterpret_cast<const uint8_t*>(wkb->data()), size);
+            }
+            break;
+        }
         case DataType::JSON: {
             for (size_t offset = 0; offset < field_data->get_num_rows();
                  ++offset) {
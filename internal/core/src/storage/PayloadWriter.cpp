@@ -64,6 +64,7 @@ void
 PayloadWriter::add_one_binary_payload(const uint8_t* data, int length) {
     AssertInfo(output_ == nullptr, "payload writer has been finished");
     AssertInfo(milvus::IsBinaryDataType(column_type_) ||
+                   milvus::IsGeometryDataType(column_type_) ||
                    milvus::IsSparseFloatVectorDataType(column_type_),
                "mismatch data type");
     AddOneBinaryToArrowBuilder(builder_, data, length);
@@ -275,7 +275,8 @@ CreateArrowBuilder(DataType data_type) {
             return std::make_shared<arrow::StringBuilder>();
         }
         case DataType::ARRAY:
-        case DataType::JSON: {
+        case DataType::JSON:
+        case DataType::GEOMETRY: {
             return std::make_shared<arrow::BinaryBuilder>();
         }
         // sparse float vector doesn't require a dim
@@ -356,7 +357,8 @@ CreateArrowSchema(DataType data_type, bool nullable) {
                 {arrow::field("val", arrow::utf8(), nullable)});
         }
         case DataType::ARRAY:
-        case DataType::JSON: {
+        case DataType::JSON:
+        case DataType::GEOMETRY: {
             return arrow::schema(
                 {arrow::field("val", arrow::binary(), nullable)});
         }
@@ -787,6 +789,7 @@ CreateFieldData(const DataType& type,
                 type, nullable, total_num_rows);
         case DataType::STRING:
         case DataType::VARCHAR:
+        case DataType::GEOMETRY:
             return std::make_shared<FieldData<std::string>>(
                 type, nullable, total_num_rows);
         case DataType::JSON:
@@ -2301,6 +2301,162 @@ TEST_P(ExprTest, TestCall) {
     }
 }
 
+TEST_P(ExprTest, TestScalarFunctionCall) {
+    milvus::exec::expression::FunctionFactory& factory =
+        milvus::exec::expression::FunctionFactory::Instance();
+    factory.Initialize();
+
+    auto schema = std::make_shared<Schema>();
+    auto vec_fid = schema->AddDebugField("fakevec", data_type, 16, metric_type);
+    auto i32_fid = schema->AddDebugField("age32", DataType::INT32);
+    auto i64_fid = schema->AddDebugField("age64", DataType::INT64);
+    auto varchar_fid = schema->AddDebugField("address", DataType::VARCHAR);
+    schema->set_primary_field_id(varchar_fid);
+
+    auto seg = CreateGrowingSegment(schema, empty_index_meta);
+    int N = 1000;
+    auto raw_data = DataGen(schema, N);
+    auto age32_col = raw_data.get_col<int32_t>(i32_fid);
+    auto age64_col = raw_data.get_col<int64_t>(i64_fid);
+    auto address_col = raw_data.get_col<std::string>(varchar_fid);
+    seg->PreInsert(N);
+    seg->Insert(0,
+                N,
+                raw_data.row_ids_.data(),
+                raw_data.timestamps_.data(),
+                raw_data.raw_);
+    auto seg_promote = dynamic_cast<SegmentGrowingImpl*>(seg.get());
+
+    auto lower = [](std::string s) {
+        std::transform(s.begin(), s.end(), s.begin(), [](unsigned char c) {
+            return (c >= 'A' && c <= 'Z') ? c - 'A' + 'a' : c;
+        });
+        return s;
+    };
+
+    std::tuple<std::string, std::function<bool(int)>> test_cases[] = {
+        // age32 + age64 > 0
+        {R"(call_expr: <
+              function_name: "gt"
+              function_parameters: <
+                call_expr: <
+                  function_name: "add"
+                  function_parameters: <
+                    column_expr: < info: < field_id: 101 data_type: Int32 > >
+                  >
+                  function_parameters: <
+                    column_expr: < info: < field_id: 102 data_type: Int64 > >
+                  >
+                  return_type: Int64
+                >
+              >
+              function_parameters: <
+                value_expr: < value: < int64_val: 0 > >
+              >
+              return_type: Bool
+            >)",
+         [&](int i) {
+             return int64_t(age32_col[i]) + age64_col[i] > 0;
+         }},
+        // abs(age32) < 100
+        {R"(call_expr: <
+              function_name: "lt"
+              function_parameters: <
+                call_expr: <
+                  function_name: "abs"
+                  function_parameters: <
+                    column_expr: < info: < field_id: 101 data_type: Int32 > >
+                  >
+                  return_type: Int64
+                >
+              >
+              function_parameters: <
+                value_expr: < value: < int64_val: 100 > >
+              >
+            >)",
+         [&](int i) { return std::llabs(age32_col[i]) < 100; }},
+        // lower(address) == address
+        {R"(call_expr: <
+              function_name: "eq"
+              function_parameters: <
+                call_expr: <
+                  function_name: "lower"
+                  function_parameters: <
+                    column_expr: < info: < field_id: 103 data_type: VarChar > >
+                  >
+                  return_type: VarChar
+                >
+              >
+              function_parameters: <
+                column_expr: < info: < field_id: 103 data_type: VarChar > >
+              >
+            >)",
+         [&](int i) { return lower(address_col[i]) == address_col[i]; }},
+    };
+
+    for (auto& [predicate, ref_func] : test_cases) {
+        auto raw_plan = fmt::format(R"(vector_anns: <
+                field_id: 100
+                predicates: <
+                  {}
+                >
+                query_info: <
+                  topk: 10
+                  round_decimal: 3
+                  metric_type: "L2"
+                  search_params: "{{\"nprobe\": 10}}"
+                >
+                placeholder_tag: "$0"
+            >)",
+                                    predicate);
+        auto plan_str = translate_text_plan_with_metric_type(raw_plan);
+        auto plan =
+            CreateSearchPlanByExpr(*schema, plan_str.data(), plan_str.size());
+        BitsetType final;
+        final = ExecuteQueryExpr(
+            plan->plan_node_->plannodes_->sources()[0]->sources()[0],
+            seg_promote,
+            N,
+            MAX_TIMESTAMP);
+        EXPECT_EQ(final.size(), N);
+        for (int i = 0; i < N; ++i) {
+            ASSERT_EQ(final[i], ref_func(i)) << predicate << "@" << i;
+        }
+    }
+
+    // the return type from the parser must match the registered one
+    std::string raw_plan = R"(vector_anns: <
+                field_id: 100
+                predicates: <
+                  call_expr: <
+                    function_name: "lt"
+                    function_parameters: <
+                      call_expr: <
+                        function_name: "abs"
+                        function_parameters: <
+                          column_expr: < info: < field_id: 101 data_type: Int32 > >
+                        >
+                        return_type: Double
+                      >
+                    >
+                    function_parameters: <
+                      value_expr: < value: < int64_val: 100 > >
+                    >
+                  >
+                >
+                query_info: <
+                  topk: 10
+                  round_decimal: 3
+                  metric_type: "L2"
+                  search_params: "{\"nprobe\": 10}"
+                >
+                placeholder_tag: "$0"
+            >)";
+    auto plan_str = translate_text_plan_with_metric_type(raw_plan);
+    EXPECT_ANY_THROW(
+        CreateSearchPlanByExpr(*schema, plan_str.data(), plan_str.size()));
+}
+
 TEST_P(ExprTest, TestCompare) {
     std::vector<std::tuple<std::string, std::function<bool(int, int64_t)>>>
         testcases = {
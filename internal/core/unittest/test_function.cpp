@@ -14,6 +14,7 @@
 
 #include "common/Types.h"
 #include "common/Vector.h"
+#include "exec/expression/function/impl/GeoFunctions.h"
 #include "exec/expression/function/impl/StringFunctions.h"
 
 using namespace milvus;
@@ -237,3 +238,84 @@ TEST_F(FunctionTest, StartsWithIncorrectArgs) {
     milvus::RowVector three_args(arg_vec);
     EXPECT_ANY_THROW(StartsWithVarchar(three_args, result));
 }
+
+// little endian WKB of POINT(x y)
+static std::string
+PointWKB(double x, double y) {
+    std::string wkb(1, 1);
+    uint32_t type = 1;
+    wkb.append(reinterpret_cast<const char*>(&type), sizeof(type));
+    wkb.append(reinterpret_cast<const char*>(&x), sizeof(x));
+    wkb.append(reinterpret_cast<const char*>(&y), sizeof(y));
+    return wkb;
+}
+
+TEST_F(FunctionTest, StDistance) {
+    std::vector<milvus::VectorPtr> arg_vec;
+    auto col1 =
+        std::make_shared<milvus::ColumnVector>(milvus::DataType::GEOMETRY, 3);
+    auto* col1_data = col1->RawAsValues<std::string>();
+    col1_data[0] = PointWKB(116.4074, 39.9042);
+    col1_data[1] = PointWKB(121.4737, 31.2304);
+    TargetBitmapView(col1->GetValidRawData(), 3)[2] = false;
+    arg_vec.push_back(col1);
+    arg_vec.push_back(std::make_shared<milvus::ConstantVector<std::string>>(
+        milvus::DataType::VARCHAR, 3, "POINT(116.4074 39.9042)"));
+    milvus::RowVector args(std::move(arg_vec));
+    VectorPtr result;
+    StDistance(args, result);
+
+    auto result_vec = std::dynamic_pointer_cast<milvus::ColumnVector>(result);
+    ASSERT_NE(result_vec, nullptr);
+    auto* distances = result_vec->RawAsValues<double>();
+    EXPECT_TRUE(result_vec->ValidAt(0));
+    EXPECT_EQ(distances[0], 0);
+    EXPECT_TRUE(result_vec->ValidAt(1));
+    // about 1067km between Beijing and Shanghai
+    EXPECT_NEAR(distances[1], 1067e3, 5e3);
+    EXPECT_FALSE(result_vec->ValidAt(2));
+}
+
+TEST_F(FunctionTest, StWithin) {
+    std::vector<milvus::VectorPtr> arg_vec;
+    auto col1 =
+        std::make_shared<milvus::ColumnVector>(milvus::DataType::GEOMETRY, 4);
+    auto* col1_data = col1->RawAsValues<std::string>();
+    col1_data[0] = PointWKB(0.5, 0.5);
+    col1_data[1] = PointWKB(0, 2);
+    col1_data[2] = PointWKB(1.5, 1.5);
+    col1_data[3] = PointWKB(5, 5);
+    arg_vec.push_back(col1);
+    arg_vec.push_back(std::make_shared<milvus::ConstantVector<std::string>>(
+        milvus::DataType::VARCHAR,
+        4,
+        "POLYGON((0 0, 4 0, 4 4, 0 4, 0 0), (1 1, 2 1, 2 2, 1 2, 1 1))"));
+    milvus::RowVector args(std::move(arg_vec));
+    VectorPtr result;
+    StWithin(args, result);
+
+    auto result_vec = std::dynamic_pointer_cast<milvus::ColumnVector>(result);
+    ASSERT_NE(result_vec, nullptr);
+    TargetBitmapView bitmap(result_vec->GetRawData(), result_vec->size());
+    bool expected[4] = {true, true, false, false};
+    for (int i = 0; i < 4; ++i) {
+        EXPECT_TRUE(result_vec->ValidAt(i)) << "i: " << i;
+        EXPECT_EQ(bitmap[i], expected[i]) << "i: " << i;
+    }
+}
+
+TEST_F(FunctionTest, GeoIncorrectArgs) {
+    VectorPtr result;
+    std::vector<milvus::VectorPtr> arg_vec;
+    milvus::RowVector empty_args(arg_vec);
+    EXPECT_ANY_THROW(StDistance(empty_args, result));
+    EXPECT_ANY_THROW(StWithin(empty_args, result));
+
+    // the first argument must be a geometry
+    arg_vec.push_back(std::make_shared<milvus::ColumnVector>(
+        milvus::DataType::VARCHAR, 15));
+    arg_vec.push_back(std::make_shared<milvus::ColumnVector>(
+        milvus::DataType::VARCHAR, 15));
+    milvus::RowVector varchar_args(arg_vec);
+    EXPECT_ANY_THROW(StDistance(varchar_args, result));
+}
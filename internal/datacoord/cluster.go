@@ -47,6 +47,8 @@ type Cluster interface {
 	QueryPreImport(nodeID int64, in *datapb.QueryPreImportRequest) (*datapb.QueryPreImportResponse, error)
 	QueryImport(nodeID int64, in *datapb.QueryImportRequest) (*datapb.QueryImportResponse, error)
 	DropImport(nodeID int64, in *datapb.DropImportRequest) error
+	Export(nodeID int64, in *datapb.ExportTaskRequest) error
+	QueryExport(nodeID int64, in *datapb.QueryExportRequest) (*datapb.QueryExportResponse, error)
 	QuerySlots() map[int64]int64
 	GetSessions() []*session.Session
 	Close()
@@ -180,6 +182,14 @@ func (c *ClusterImpl) DropImport(nodeID int64, in *datapb.DropImportRequest) err
 	return c.sessionManager.DropImport(nodeID, in)
 }
 
+func (c *ClusterImpl) Export(nodeID int64, in *datapb.ExportTaskRequest) error {
+	return c.sessionManager.Export(nodeID, in)
+}
+
+func (c *ClusterImpl) QueryExport(nodeID int64, in *datapb.QueryExportRequest) (*datapb.QueryExportResponse, error) {
+	return c.sessionManager.QueryExport(nodeID, in)
+}
+
 func (c *ClusterImpl) QuerySlots() map[int64]int64 {
 	nodeIDs := c.sessionManager.GetSessionIDs()
 	nodeSlots := make(map[int64]int64)
@@ -28,6 +28,7 @@ import (
 
 	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
 	"github.com/milvus-io/milvus-proto/go-api/v2/msgpb"
+	"github.com/milvus-io/milvus-proto/go-api/v2/schemapb"
 	"github.com/milvus-io/milvus/internal/datacoord/allocator"
 	"github.com/milvus-io/milvus/internal/proto/datapb"
 	"github.com/milvus-io/milvus/pkg/log"
@@ -43,6 +44,8 @@ type compactTime struct {
 	startTime     Timestamp
 	expireTime    Timestamp
 	collectionTTL time.Duration
+	// ttlField declares the expiration time of rows, nil if the collection has none
+	ttlField *schemapb.FieldSchema
 }
 
 // todo: migrate to compaction_trigger_v2
@@ -195,15 +198,16 @@ func getCompactTime(ts Timestamp, coll *collectionInfo) (*compactTime, error) {
 	}
 
 	pts, _ := tsoutil.ParseTS(ts)
+	ttlField := typeutil.GetTTLField(coll.Schema)
 
 	if collectionTTL > 0 {
 		ttexpired := pts.Add(-collectionTTL)
 		ttexpiredLogic := tsoutil.ComposeTS(ttexpired.UnixNano()/int64(time.Millisecond), 0)
-		return &compactTime{ts, ttexpiredLogic, collectionTTL}, nil
+		return &compactTime{ts, ttexpiredLogic, collectionTTL, ttlField}, nil
 	}
 
 	// no expiration time
-	return &compactTime{ts, 0, 0}, nil
+	return &compactTime{ts, 0, 0, ttlField}, nil
 }
 
 // triggerCompaction trigger a compaction if any compaction condition satisfy.
@@ -712,6 +716,21 @@ func (t *compactionTrigger) ShouldDoSingleCompaction(segment *SegmentInfo, compa
 		}
 	}
 
+	// if the collection declares a ttl field, count the rows of binlogs which are all expired
+	if compactTime.ttlField != nil {
+		now := tsoutil.PhysicalTime(compactTime.startTime).Unix()
+		for _, binlogs := range segment.GetBinlogs() {
+			if binlogs.GetFieldID() != compactTime.ttlField.GetFieldID() {
+				continue
+			}
+			for _, l := range binlogs.GetBinlogs() {
+				if l.GetExpireTo() > 0 && l.GetExpireTo() <= now {
+					totalExpiredRows += int(l.GetEntriesNum())
+				}
+			}
+		}
+	}
+
 	if float64(totalExpiredRows)/float64(segment.GetNumOfRows()) >= Params.DataCoordCfg.SingleCompactionRatioThreshold.GetAsFloat() ||
 		totalExpiredSize > Params.DataCoordCfg.SingleCompactionExpiredLogMaxSize.GetAsInt64() {
 		log.Info("total expired entities is too much, trigger compaction", zap.Int64("segmentID", segment.ID),
@@ -18,6 +18,7 @@ package datacoord
 
 import (
 	"context"
+	"math"
 	"sort"
 	satomic "sync/atomic"
 	"testing"
@@ -1958,6 +1959,49 @@ func Test_compactionTrigger_shouldDoSingleCompaction(t *testing.T) {
 	assert.False(t, couldDo)
 }
 
+func Test_compactionTrigger_shouldDoSingleCompactionWithTTLField(t *testing.T) {
+	indexMeta := newSegmentIndexMeta(nil)
+	trigger := newCompactionTrigger(&meta{
+		indexMeta:  indexMeta,
+		channelCPs: newChannelCps(),
+	}, &compactionPlanHandler{}, newMockAllocator(t), newMockHandler(), newIndexEngineVersionManager())
+
+	now := time.Now()
+	ct := &compactTime{
+		startTime: tsoutil.ComposeTSByTime(now, 0),
+		ttlField:  &schemapb.FieldSchema{FieldID: 101, DataType: schemapb.DataType_Int64},
+	}
+	newSegment := func(expireTo ...int64) *SegmentInfo {
+		fieldBinlog := &datapb.FieldBinlog{FieldID: 101}
+		for _, e := range expireTo {
+			fieldBinlog.Binlogs = append(fieldBinlog.Binlogs, &datapb.Binlog{EntriesNum: 50, LogPath: "log1", ExpireTo: e})
+		}
+		return &SegmentInfo{
+			SegmentInfo: &datapb.SegmentInfo{
+				ID:            1,
+				CollectionID:  2,
+				PartitionID:   1,
+				NumOfRows:     int64(50 * len(expireTo)),
+				InsertChannel: "ch1",
+				State:         commonpb.SegmentState_Flushed,
+				Binlogs:       []*datapb.FieldBinlog{fieldBinlog},
+			},
+		}
+	}
+
+	// half of the rows are expired
+	couldDo := trigger.ShouldDoSingleCompaction(newSegment(now.Unix()-10, math.MaxInt64), ct)
+	assert.True(t, couldDo)
+
+	// expiration time unknown or not reached
+	couldDo = trigger.ShouldDoSingleCompaction(newSegment(0, now.Unix()+10, math.MaxInt64), ct)
+	assert.False(t, couldDo)
+
+	// collection has no ttl field
+	couldDo = trigger.ShouldDoSingleCompaction(newSegment(now.Unix()-10, math.MaxInt64), &compactTime{})
+	assert.False(t, couldDo)
+}
+
 func Test_compactionTrigger_new(t *testing.T) {
 	type args struct {
 		meta              *meta
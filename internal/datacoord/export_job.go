@@ -0,0 +1,167 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datacoord
+
+import (
+	"time"
+
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/milvus-io/milvus/internal/proto/datapb"
+	"github.com/milvus-io/milvus/pkg/log"
+	"github.com/milvus-io/milvus/pkg/util/timerecord"
+	"github.com/milvus-io/milvus/pkg/util/tsoutil"
+)
+
+type ExportJob struct {
+	*datapb.ExportJob
+
+	tr *timerecord.TimeRecorder
+}
+
+func (j *ExportJob) GetTR() *timerecord.TimeRecorder {
+	return j.tr
+}
+
+func (j *ExportJob) Clone() *ExportJob {
+	return &ExportJob{
+		ExportJob: proto.Clone(j.ExportJob).(*datapb.ExportJob),
+		tr:        j.tr,
+	}
+}
+
+func (j *ExportJob) IsFinished() bool {
+	return j.GetState() == datapb.ExportJobState_ExportCompleted ||
+		j.GetState() == datapb.ExportJobState_ExportFailed ||
+		j.GetState() == datapb.ExportJobState_ExportCancelled
+}
+
+type ExportTask struct {
+	*datapb.ExportTask
+
+	tr *timerecord.TimeRecorder
+}
+
+func (t *ExportTask) GetTR() *timerecord.TimeRecorder {
+	return t.tr
+}
+
+func (t *ExportTask) Clone() *ExportTask {
+	return &ExportTask{
+		ExportTask: proto.Clone(t.ExportTask).(*datapb.ExportTask),
+		tr:         t.tr,
+	}
+}
+
+func WrapExportTaskLog(task *ExportTask, fields ...zap.Field) []zap.Field {
+	res := []zap.Field{
+		zap.Int64("taskID", task.GetTaskID()),
+		zap.Int64("jobID", task.GetJobID()),
+		zap.Int64("collectionID", task.GetCollectionID()),
+		zap.Int64("nodeID", task.GetNodeID()),
+	}
+	res = append(res, fields...)
+	return res
+}
+
+type ExportJobFilter func(job *ExportJob) bool
+
+func WithExportJobStates(states ...datapb.ExportJobState) ExportJobFilter {
+	return func(job *ExportJob) bool {
+		for _, state := range states {
+			if job.GetState() == state {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+type UpdateExportJobAction func(job *ExportJob)
+
+func UpdateExportJobState(state datapb.ExportJobState) UpdateExportJobAction {
+	return func(job *ExportJob) {
+		job.ExportJob.State = state
+		if job.IsFinished() {
+			job.ExportJob.CompleteTime = time.Now().Format("2006-01-02T15:04:05Z07:00")
+			// set cleanup ts
+			dur := Params.DataCoordCfg.ExportTaskRetention.GetAsDuration(time.Second)
+			cleanupTime := time.Now().Add(dur)
+			cleanupTs := tsoutil.ComposeTSByTime(cleanupTime, 0)
+			job.ExportJob.CleanupTs = cleanupTs
+			log.Info("set export job cleanup ts", zap.Int64("jobID", job.GetJobID()),
+				zap.Time("cleanupTime", cleanupTime), zap.Uint64("cleanupTs", cleanupTs))
+		}
+	}
+}
+
+func UpdateExportJobReason(reason string) UpdateExportJobAction {
+	return func(job *ExportJob) {
+		job.ExportJob.Reason = reason
+	}
+}
+
+type ExportTaskFilter func(task *ExportTask) bool
+
+func WithExportJob(jobID int64) ExportTaskFilter {
+	return func(task *ExportTask) bool {
+		return task.GetJobID() == jobID
+	}
+}
+
+func WithExportTaskStates(states ...datapb.ImportTaskStateV2) ExportTaskFilter {
+	return func(task *ExportTask) bool {
+		for _, state := range states {
+			if task.GetState() == state {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+type UpdateExportTaskAction func(task *ExportTask)
+
+func UpdateExportTaskState(state datapb.ImportTaskStateV2) UpdateExportTaskAction {
+	return func(task *ExportTask) {
+		task.ExportTask.State = state
+		if state == datapb.ImportTaskStateV2_Completed {
+			task.ExportTask.CompleteTime = time.Now().Format("2006-01-02T15:04:05Z07:00")
+		}
+	}
+}
+
+func UpdateExportTaskReason(reason string) UpdateExportTaskAction {
+	return func(task *ExportTask) {
+		task.ExportTask.Reason = reason
+	}
+}
+
+func UpdateExportTaskNodeID(nodeID int64) UpdateExportTaskAction {
+	return func(task *ExportTask) {
+		task.ExportTask.NodeID = nodeID
+	}
+}
+
+func UpdateExportTaskProgress(processedRows, exportedRows int64, files []string) UpdateExportTaskAction {
+	return func(task *ExportTask) {
+		task.ExportTask.ProcessedRows = processedRows
+		task.ExportTask.ExportedRows = exportedRows
+		task.ExportTask.Files = files
+	}
+}
@@ -0,0 +1,383 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datacoord
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/samber/lo"
+	"go.uber.org/zap"
+
+	"github.com/milvus-io/milvus/internal/datacoord/session"
+	"github.com/milvus-io/milvus/internal/proto/datapb"
+	"github.com/milvus-io/milvus/pkg/log"
+	"github.com/milvus-io/milvus/pkg/util/lock"
+	"github.com/milvus-io/milvus/pkg/util/merr"
+	"github.com/milvus-io/milvus/pkg/util/tsoutil"
+)
+
+type ExportScheduler interface {
+	Start()
+	Close()
+}
+
+// exportScheduler dispatches export tasks to datanodes and drives the state of export jobs.
+// Export tasks run in the import executor of datanode, so they compete for the same slots.
+type exportScheduler struct {
+	meta    *meta
+	cluster Cluster
+	emeta   ExportMeta
+
+	closeOnce sync.Once
+	closeChan chan struct{}
+}
+
+func NewExportScheduler(meta *meta, cluster Cluster, emeta ExportMeta) ExportScheduler {
+	return &exportScheduler{
+		meta:      meta,
+		cluster:   cluster,
+		emeta:     emeta,
+		closeChan: make(chan struct{}),
+	}
+}
+
+func (s *exportScheduler) Start() {
+	log.Info("start export scheduler")
+	ticker := time.NewTicker(Params.DataCoordCfg.ExportScheduleInterval.GetAsDuration(time.Second))
+	defer ticker.Stop()
+	for {
+		select {
+		case <-s.closeChan:
+			log.Info("export scheduler exited")
+			return
+		case <-ticker.C:
+			s.process()
+		}
+	}
+}
+
+func (s *exportScheduler) Close() {
+	s.closeOnce.Do(func() {
+		close(s.closeChan)
+	})
+}
+
+func (s *exportScheduler) process() {
+	jobs := s.emeta.GetJobBy(context.TODO())
+	sort.Slice(jobs, func(i, j int) bool {
+		return jobs[i].GetJobID() < jobs[j].GetJobID()
+	})
+	var nodeSlots map[int64]int64
+	for _, job := range jobs {
+		switch job.GetState() {
+		case datapb.ExportJobState_ExportPending, datapb.ExportJobState_ExportInProgress:
+			if nodeSlots == nil {
+				nodeSlots = s.peekSlots()
+			}
+			s.processRunningJob(job, nodeSlots)
+		default:
+			s.processFinishedJob(job)
+		}
+		s.checkGC(s.emeta.GetJob(context.TODO(), job.GetJobID()))
+	}
+}
+
+func (s *exportScheduler) peekSlots() map[int64]int64 {
+	nodeIDs := lo.Map(s.cluster.GetSessions(), func(s *session.Session, _ int) int64 {
+		return s.NodeID()
+	})
+	nodeSlots := make(map[int64]int64)
+	mu := &lock.Mutex{}
+	wg := &sync.WaitGroup{}
+	for _, nodeID := range nodeIDs {
+		wg.Add(1)
+		go func(nodeID int64) {
+			defer wg.Done()
+			resp, err := s.cluster.QueryImport(nodeID, &datapb.QueryImportRequest{QuerySlot: true})
+			if err != nil {
+				log.Warn("query import failed", zap.Error(err))
+				return
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			nodeSlots[nodeID] = resp.GetSlots()
+		}(nodeID)
+	}
+	wg.Wait()
+	return nodeSlots
+}
+
+func (s *exportScheduler) getNodeID(nodeSlots map[int64]int64) int64 {
+	var (
+		nodeID   int64 = NullNodeID
+		maxSlots int64 = 0
+	)
+	for id, slots := range nodeSlots {
+		// find the most idle datanode, an export task takes one slot
+		if slots > maxSlots {
+			nodeID = id
+			maxSlots = slots
+		}
+	}
+	if nodeID != NullNodeID {
+		nodeSlots[nodeID]--
+	}
+	return nodeID
+}
+
+func (s *exportScheduler) processRunningJob(job *ExportJob, nodeSlots map[int64]int64) {
+	tasks := s.emeta.GetTaskBy(context.TODO(), WithExportJob(job.GetJobID()))
+	for _, task := range tasks {
+		var err error
+		switch task.GetState() {
+		case datapb.ImportTaskStateV2_Pending:
+			err = s.processPendingTask(job, task, nodeSlots)
+		case datapb.ImportTaskStateV2_InProgress:
+			err = s.processInProgressTask(task)
+		case datapb.ImportTaskStateV2_Completed:
+			s.dropTaskInDataNode(task)
+		case datapb.ImportTaskStateV2_Failed:
+			err = merr.WrapErrExportFailed(task.GetReason())
+		}
+		if err != nil {
+			s.failJob(job, err.Error())
+			return
+		}
+	}
+
+	tasks = s.emeta.GetTaskBy(context.TODO(), WithExportJob(job.GetJobID()))
+	state := job.GetState()
+	if lo.EveryBy(tasks, func(task *ExportTask) bool {
+		return task.GetState() == datapb.ImportTaskStateV2_Completed
+	}) {
+		state = datapb.ExportJobState_ExportCompleted
+	} else if lo.SomeBy(tasks, func(task *ExportTask) bool {
+		return task.GetState() != datapb.ImportTaskStateV2_Pending
+	}) {
+		state = datapb.ExportJobState_ExportInProgress
+	}
+	if state == job.GetState() {
+		return
+	}
+	err := s.emeta.UpdateJob(context.TODO(), job.GetJobID(), UpdateExportJobState(state))
+	if err != nil {
+		log.Warn("failed to update export job state", zap.Int64("jobID", job.GetJobID()),
+			zap.String("state", state.String()), zap.Error(err))
+		return
+	}
+	if state == datapb.ExportJobState_ExportCompleted {
+		log.Info("export job completed", zap.Int64("jobID", job.GetJobID()),
+			zap.Duration("jobTimeCost/total", job.GetTR().ElapseSpan()))
+	}
+}
+
+func (s *exportScheduler) processPendingTask(job *ExportJob, task *ExportTask, nodeSlots map[int64]int64) error {
+	nodeID := s.getNodeID(nodeSlots)
+	if nodeID == NullNodeID {
+		return nil
+	}
+	req, err := AssembleExportRequest(task, job, s.meta)
+	if err != nil {
+		// segments are gone, the export can never succeed
+		return err
+	}
+	err = s.cluster.Export(nodeID, req)
+	if err != nil {
+		log.Warn("export failed", WrapExportTaskLog(task, zap.Error(err))...)
+		return nil
+	}
+	err = s.emeta.UpdateTask(context.TODO(), task.GetTaskID(),
+		UpdateExportTaskState(datapb.ImportTaskStateV2_InProgress),
+		UpdateExportTaskNodeID(nodeID))
+	if err != nil {
+		log.Warn("update export task failed", WrapExportTaskLog(task, zap.Error(err))...)
+		return nil
+	}
+	log.Info("export task start to execute", WrapExportTaskLog(task, zap.Int64("scheduledNodeID", nodeID),
+		zap.Duration("taskTimeCost/pending", task.GetTR().RecordSpan()))...)
+	return nil
+}
+
+func (s *exportScheduler) processInProgressTask(task *ExportTask) error {
+	req := &datapb.QueryExportRequest{
+		JobID:  task.GetJobID(),
+		TaskID: task.GetTaskID(),
+	}
+	resp, err := s.cluster.QueryExport(task.GetNodeID(), req)
+	if err != nil {
+		// the datanode may be restarted, files written before are overwritten by the retry.
+		updateErr := s.emeta.UpdateTask(context.TODO(), task.GetTaskID(),
+			UpdateExportTaskState(datapb.ImportTaskStateV2_Pending),
+			UpdateExportTaskNodeID(NullNodeID),
+			UpdateExportTaskProgress(0, 0, nil))
+		if updateErr != nil {
+			log.Warn("failed to update export task state to pending", WrapExportTaskLog(task, zap.Error(updateErr))...)
+		}
+		log.Info("reset export task state to pending due to error occurs", WrapExportTaskLog(task, zap.Error(err))...)
+		return nil
+	}
+	if resp.GetState() == datapb.ImportTaskStateV2_Failed {
+		log.Warn("export task failed", WrapExportTaskLog(task, zap.String("reason", resp.GetReason()))...)
+		err = s.emeta.UpdateTask(context.TODO(), task.GetTaskID(),
+			UpdateExportTaskState(datapb.ImportTaskStateV2_Failed),
+			UpdateExportTaskReason(resp.GetReason()))
+		if err != nil {
+			log.Warn("update export task failed", WrapExportTaskLog(task, zap.Error(err))...)
+		}
+		return merr.WrapErrExportFailed(resp.GetReason())
+	}
+	actions := []UpdateExportTaskAction{
+		UpdateExportTaskProgress(resp.GetProcessedRows(), resp.GetExportedRows(), resp.GetFiles()),
+	}
+	if resp.GetState() == datapb.ImportTaskStateV2_Completed {
+		actions = append(actions, UpdateExportTaskState(datapb.ImportTaskStateV2_Completed))
+	}
+	err = s.emeta.UpdateTask(context.TODO(), task.GetTaskID(), actions...)
+	if err != nil {
+		log.Warn("update export task failed", WrapExportTaskLog(task, zap.Error(err))...)
+		return nil
+	}
+	if resp.GetState() == datapb.ImportTaskStateV2_Completed {
+		log.Info("export task done", WrapExportTaskLog(task, zap.Int64("processedRows", resp.GetProcessedRows()),
+			zap.Int64("exportedRows", resp.GetExportedRows()),
+			zap.Duration("taskTimeCost/export", task.GetTR().RecordSpan()))...)
+	}
+	return nil
+}
+
+func (s *exportScheduler) failJob(job *ExportJob, reason string) {
+	err := s.emeta.UpdateJob(context.TODO(), job.GetJobID(),
+		UpdateExportJobState(datapb.ExportJobState_ExportFailed),
+		UpdateExportJobReason(reason))
+	if err != nil {
+		log.Warn("failed to update export job state to Failed", zap.Int64("jobID", job.GetJobID()), zap.Error(err))
+		return
+	}
+	log.Warn("export job failed", zap.Int64("jobID", job.GetJobID()), zap.String("reason", reason))
+}
+
+// processFinishedJob fails the unfinished tasks of a failed or cancelled job,
+// and releases the tasks in datanodes.
+func (s *exportScheduler) processFinishedJob(job *ExportJob) {
+	tasks := s.emeta.GetTaskBy(context.TODO(), WithExportJob(job.GetJobID()))
+	for _, task := range tasks {
+		if task.GetState() == datapb.ImportTaskStateV2_Pending ||
+			task.GetState() == datapb.ImportTaskStateV2_InProgress {
+			err := s.emeta.UpdateTask(context.TODO(), task.GetTaskID(),
+				UpdateExportTaskState(datapb.ImportTaskStateV2_Failed),
+				UpdateExportTaskReason(job.GetReason()))
+			if err != nil {
+				log.Warn("failed to update export task state to failed", WrapExportTaskLog(task, zap.Error(err))...)
+				continue
+			}
+		}
+		s.dropTaskInDataNode(task)
+	}
+}
+
+func (s *exportScheduler) dropTaskInDataNode(task *ExportTask) {
+	if task.GetNodeID() == NullNodeID {
+		return
+	}
+	req := &datapb.DropImportRequest{
+		JobID:  task.GetJobID(),
+		TaskID: task.GetTaskID(),
+	}
+	err := s.cluster.DropImport(task.GetNodeID(), req)
+	if err != nil && !errors.Is(err, merr.ErrNodeNotFound) {
+		log.Warn("drop export task in datanode failed", WrapExportTaskLog(task, zap.Error(err))...)
+		return
+	}
+	err = s.emeta.UpdateTask(context.TODO(), task.GetTaskID(), UpdateExportTaskNodeID(NullNodeID))
+	if err != nil {
+		log.Warn("update export task failed", WrapExportTaskLog(task, zap.Error(err))...)
+		return
+	}
+	log.Info("drop export task in datanode done", WrapExportTaskLog(task)...)
+}
+
+// checkGC removes the meta of finished jobs after the retention, the exported files are kept.
+func (s *exportScheduler) checkGC(job *ExportJob) {
+	if job == nil || !job.IsFinished() {
+		return
+	}
+	cleanupTime := tsoutil.PhysicalTime(job.GetCleanupTs())
+	if time.Now().Before(cleanupTime) {
+		return
+	}
+	log := log.With(zap.Int64("jobID", job.GetJobID()))
+	tasks := s.emeta.GetTaskBy(context.TODO(), WithExportJob(job.GetJobID()))
+	for _, task := range tasks {
+		if task.GetNodeID() != NullNodeID {
+			return
+		}
+	}
+	for _, task := range tasks {
+		err := s.emeta.RemoveTask(context.TODO(), task.GetTaskID())
+		if err != nil {
+			log.Warn("remove export task failed during GC", WrapExportTaskLog(task, zap.Error(err))...)
+			return
+		}
+	}
+	err := s.emeta.RemoveJob(context.TODO(), job.GetJobID())
+	if err != nil {
+		log.Warn("remove export job failed", zap.Error(err))
+		return
+	}
+	log.Info("export job removed", zap.Time("cleanupTime", cleanupTime))
+}
+
+// AssembleExportRequest collects the current binlogs of the segments to export.
+// Segments compacted after the job is created are still readable, since segments of unfinished
+// export jobs are pinned and never garbage collected.
+func AssembleExportRequest(task *ExportTask, job *ExportJob, meta *meta) (*datapb.ExportTaskRequest, error) {
+	segments := make([]*datapb.CompactionSegmentBinlogs, 0, len(task.GetSegmentIDs()))
+	for _, segmentID := range task.GetSegmentIDs() {
+		segment := meta.GetSegment(context.TODO(), segmentID)
+		if segment == nil {
+			return nil, merr.WrapErrSegmentNotFound(segmentID, fmt.Sprintf("segment of export job %d has been recycled", job.GetJobID()))
+		}
+		segments = append(segments, &datapb.CompactionSegmentBinlogs{
+			SegmentID:           segment.GetID(),
+			CollectionID:        segment.GetCollectionID(),
+			PartitionID:         segment.GetPartitionID(),
+			Level:               segment.GetLevel(),
+			InsertChannel:       segment.GetInsertChannel(),
+			FieldBinlogs:        segment.GetBinlogs(),
+			Field2StatslogPaths: segment.GetStatslogs(),
+			Deltalogs:           segment.GetDeltalogs(),
+			IsSorted:            segment.GetIsSorted(),
+		})
+	}
+	return &datapb.ExportTaskRequest{
+		JobID:        task.GetJobID(),
+		TaskID:       task.GetTaskID(),
+		CollectionID: task.GetCollectionID(),
+		Schema:       job.GetSchema(),
+		Expr:         job.GetExpr(),
+		Ts:           job.GetTs(),
+		OutputPath:   job.GetOutputPath(),
+		Segments:     segments,
+
+		SerializedRowFilter: job.GetSerializedRowFilter(),
+	}, nil
+}
@@ -0,0 +1,243 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datacoord
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cockroachdb/errors"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
+	"github.com/milvus-io/milvus/internal/datacoord/session"
+	"github.com/milvus-io/milvus/internal/metastore/mocks"
+	"github.com/milvus-io/milvus/internal/proto/datapb"
+	"github.com/milvus-io/milvus/pkg/util/paramtable"
+	"github.com/milvus-io/milvus/pkg/util/timerecord"
+)
+
+type ExportSchedulerSuite struct {
+	suite.Suite
+
+	collectionID int64
+
+	catalog   *mocks.DataCoordCatalog
+	cluster   *MockCluster
+	meta      *meta
+	emeta     ExportMeta
+	scheduler *exportScheduler
+}
+
+func (s *ExportSchedulerSuite) SetupTest() {
+	var err error
+
+	s.collectionID = 1
+
+	s.catalog = mocks.NewDataCoordCatalog(s.T())
+	s.catalog.EXPECT().ListExportJobs(mock.Anything).Return(nil, nil)
+	s.catalog.EXPECT().ListExportTasks(mock.Anything).Return(nil, nil)
+	s.catalog.EXPECT().ListSegments(mock.Anything).Return(nil, nil)
+	s.catalog.EXPECT().ListChannelCheckpoint(mock.Anything).Return(nil, nil)
+	s.catalog.EXPECT().ListIndexes(mock.Anything).Return(nil, nil)
+	s.catalog.EXPECT().ListSegmentIndexes(mock.Anything).Return(nil, nil)
+	s.catalog.EXPECT().ListAnalyzeTasks(mock.Anything).Return(nil, nil)
+	s.catalog.EXPECT().ListCompactionTask(mock.Anything).Return(nil, nil)
+	s.catalog.EXPECT().ListPartitionStatsInfos(mock.Anything).Return(nil, nil)
+	s.catalog.EXPECT().ListStatsTasks(mock.Anything).Return(nil, nil)
+	s.catalog.EXPECT().ListSnapshots(mock.Anything).Return(nil, nil)
+	s.catalog.EXPECT().SaveExportJob(mock.Anything, mock.Anything).Return(nil)
+	s.catalog.EXPECT().SaveExportTask(mock.Anything, mock.Anything).Return(nil)
+
+	s.cluster = NewMockCluster(s.T())
+	s.meta, err = newMeta(context.TODO(), s.catalog, nil)
+	s.NoError(err)
+	s.meta.AddCollection(&collectionInfo{
+		ID:     s.collectionID,
+		Schema: newTestSchema(),
+	})
+	s.meta.segments.SetSegment(10, NewSegmentInfo(&datapb.SegmentInfo{
+		ID:           10,
+		CollectionID: s.collectionID,
+		State:        commonpb.SegmentState_Flushed,
+		NumOfRows:    100,
+	}))
+	s.emeta, err = NewExportMeta(context.TODO(), s.catalog)
+	s.NoError(err)
+	s.scheduler = NewExportScheduler(s.meta, s.cluster, s.emeta).(*exportScheduler)
+}
+
+func (s *ExportSchedulerSuite) addJob(segmentIDs ...int64) {
+	err := s.emeta.AddTask(context.TODO(), &ExportTask{
+		ExportTask: &datapb.ExportTask{
+			JobID:        1,
+			TaskID:       2,
+			CollectionID: s.collectionID,
+			SegmentIDs:   segmentIDs,
+			NodeID:       NullNodeID,
+			State:        datapb.ImportTaskStateV2_Pending,
+			TotalRows:    100,
+		},
+		tr: timerecord.NewTimeRecorder("export task"),
+	})
+	s.NoError(err)
+	err = s.emeta.AddJob(context.TODO(), &ExportJob{
+		ExportJob: &datapb.ExportJob{
+			JobID:        1,
+			CollectionID: s.collectionID,
+			Schema:       newTestSchema(),
+			OutputPath:   "export/1",
+			State:        datapb.ExportJobState_ExportPending,
+		},
+		tr: timerecord.NewTimeRecorder("export job"),
+	})
+	s.NoError(err)
+}
+
+func (s *ExportSchedulerSuite) mockSlots(nodeID int64, slots int64) {
+	s.cluster.EXPECT().GetSessions().RunAndReturn(func() []*session.Session {
+		sess := session.NewSession(&session.NodeInfo{
+			NodeID: nodeID,
+		}, nil)
+		return []*session.Session{sess}
+	})
+	s.cluster.EXPECT().QueryImport(mock.Anything, mock.Anything).Return(&datapb.QueryImportResponse{
+		Slots: slots,
+	}, nil)
+}
+
+func (s *ExportSchedulerSuite) TestProcessExport() {
+	s.addJob(10)
+
+	// no slot
+	const nodeID = 10
+	s.mockSlots(nodeID, 0)
+	s.scheduler.process()
+	s.Equal(datapb.ImportTaskStateV2_Pending, s.emeta.GetTask(context.TODO(), 2).GetState())
+	s.Equal(datapb.ExportJobState_ExportPending, s.emeta.GetJob(context.TODO(), 1).GetState())
+	// segments of unfinished job are pinned
+	s.True(s.emeta.IsSegmentPinned(10))
+	s.False(s.emeta.IsSegmentPinned(11))
+
+	// pending -> inProgress
+	s.cluster.ExpectedCalls = nil
+	s.mockSlots(nodeID, 1)
+	s.cluster.EXPECT().Export(mock.Anything, mock.Anything).RunAndReturn(func(_ int64, req *datapb.ExportTaskRequest) error {
+		s.Equal(1, len(req.GetSegments()))
+		s.Equal("export/1", req.GetOutputPath())
+		return nil
+	})
+	s.scheduler.process()
+	task := s.emeta.GetTask(context.TODO(), 2)
+	s.Equal(datapb.ImportTaskStateV2_InProgress, task.GetState())
+	s.Equal(int64(nodeID), task.GetNodeID())
+	s.Equal(datapb.ExportJobState_ExportInProgress, s.emeta.GetJob(context.TODO(), 1).GetState())
+
+	// progress
+	s.cluster.EXPECT().QueryExport(mock.Anything, mock.Anything).Return(&datapb.QueryExportResponse{
+		State:         datapb.ImportTaskStateV2_InProgress,
+		ProcessedRows: 50,
+		ExportedRows:  40,
+		Files:         []string{"export/1/10_0.parquet"},
+	}, nil).Once()
+	s.scheduler.process()
+	task = s.emeta.GetTask(context.TODO(), 2)
+	s.Equal(int64(50), task.GetProcessedRows())
+	s.Equal(int64(40), task.GetExportedRows())
+
+	// inProgress -> completed
+	s.cluster.EXPECT().QueryExport(mock.Anything, mock.Anything).Return(&datapb.QueryExportResponse{
+		State:         datapb.ImportTaskStateV2_Completed,
+		ProcessedRows: 100,
+		ExportedRows:  80,
+		Files:         []string{"export/1/10_0.parquet"},
+	}, nil).Once()
+	s.scheduler.process()
+	s.Equal(datapb.ImportTaskStateV2_Completed, s.emeta.GetTask(context.TODO(), 2).GetState())
+	job := s.emeta.GetJob(context.TODO(), 1)
+	s.Equal(datapb.ExportJobState_ExportCompleted, job.GetState())
+	s.NotEmpty(job.GetCompleteTime())
+	s.False(s.emeta.IsSegmentPinned(10))
+
+	// drop task in datanode
+	s.cluster.EXPECT().DropImport(mock.Anything, mock.Anything).Return(nil)
+	s.scheduler.process()
+	s.Equal(int64(NullNodeID), s.emeta.GetTask(context.TODO(), 2).GetNodeID())
+	s.NotNil(s.emeta.GetJob(context.TODO(), 1))
+
+	// gc after retention
+	paramtable.Get().Save(Params.DataCoordCfg.ExportTaskRetention.Key, "0")
+	defer paramtable.Get().Reset(Params.DataCoordCfg.ExportTaskRetention.Key)
+	err := s.emeta.UpdateJob(context.TODO(), 1, UpdateExportJobState(datapb.ExportJobState_ExportCompleted))
+	s.NoError(err)
+	s.catalog.EXPECT().DropExportTask(mock.Anything, mock.Anything).Return(nil)
+	s.catalog.EXPECT().DropExportJob(mock.Anything, mock.Anything).Return(nil)
+	s.scheduler.process()
+	s.Nil(s.emeta.GetJob(context.TODO(), 1))
+	s.Nil(s.emeta.GetTask(context.TODO(), 2))
+}
+
+func (s *ExportSchedulerSuite) TestProcessFailed() {
+	s.addJob(10)
+
+	const nodeID = 10
+	s.mockSlots(nodeID, 1)
+	s.cluster.EXPECT().Export(mock.Anything, mock.Anything).Return(nil)
+	s.scheduler.process()
+
+	// query failed, reset to pending and dispatch again
+	s.cluster.EXPECT().QueryExport(mock.Anything, mock.Anything).Return(nil, errors.New("mock error")).Once()
+	s.scheduler.process()
+	task := s.emeta.GetTask(context.TODO(), 2)
+	s.Equal(datapb.ImportTaskStateV2_Pending, task.GetState())
+	s.Equal(int64(NullNodeID), task.GetNodeID())
+	s.scheduler.process()
+	s.Equal(datapb.ImportTaskStateV2_InProgress, s.emeta.GetTask(context.TODO(), 2).GetState())
+
+	s.cluster.EXPECT().QueryExport(mock.Anything, mock.Anything).Return(&datapb.QueryExportResponse{
+		State:  datapb.ImportTaskStateV2_Failed,
+		Reason: "mock reason",
+	}, nil).Once()
+	s.scheduler.process()
+	job := s.emeta.GetJob(context.TODO(), 1)
+	s.Equal(datapb.ExportJobState_ExportFailed, job.GetState())
+	s.Contains(job.GetReason(), "mock reason")
+
+	// failed job, drop the task in datanode
+	s.cluster.EXPECT().DropImport(mock.Anything, mock.Anything).Return(nil)
+	s.scheduler.process()
+	task = s.emeta.GetTask(context.TODO(), 2)
+	s.Equal(datapb.ImportTaskStateV2_Failed, task.GetState())
+	s.Equal(int64(NullNodeID), task.GetNodeID())
+}
+
+func (s *ExportSchedulerSuite) TestSegmentNotFound() {
+	s.addJob(10, 11)
+
+	s.mockSlots(10, 1)
+	s.scheduler.process()
+	job := s.emeta.GetJob(context.TODO(), 1)
+	s.Equal(datapb.ExportJobState_ExportFailed, job.GetState())
+
+	s.scheduler.process()
+	s.Equal(datapb.ImportTaskStateV2_Failed, s.emeta.GetTask(context.TODO(), 2).GetState())
+}
+
+func TestExportScheduler(t *testing.T) {
+	suite.Run(t, new(ExportSchedulerSuite))
+}
@@ -0,0 +1,312 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datacoord
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"strconv"
+	"time"
+
+	"github.com/samber/lo"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
+	"github.com/milvus-io/milvus/internal/parser/planparserv2"
+	"github.com/milvus-io/milvus/internal/proto/datapb"
+	"github.com/milvus-io/milvus/internal/proto/planpb"
+	"github.com/milvus-io/milvus/internal/util/exprutil"
+	"github.com/milvus-io/milvus/pkg/log"
+	"github.com/milvus-io/milvus/pkg/util/merr"
+	"github.com/milvus-io/milvus/pkg/util/paramtable"
+	"github.com/milvus-io/milvus/pkg/util/timerecord"
+	"github.com/milvus-io/milvus/pkg/util/typeutil"
+)
+
+// Export creates a job dumping the flushed segments of a collection into parquet files.
+// Only rows visible at the allocated ts are exported, data not flushed yet is not included,
+// flush the collection before exporting to include the latest writes.
+func (s *Server) Export(ctx context.Context, req *datapb.ExportRequest) (*datapb.ExportResponse, error) {
+	log := log.Ctx(ctx).With(zap.Int64("collectionID", req.GetCollectionID()),
+		zap.Int64s("partitionIDs", req.GetPartitionIDs()))
+	if err := merr.CheckHealthy(s.GetStateCode()); err != nil {
+		return &datapb.ExportResponse{
+			Status: merr.Status(err),
+		}, nil
+	}
+
+	maxNum := paramtable.Get().DataCoordCfg.MaxExportJobNum.GetAsInt()
+	executingNum := s.exportMeta.CountJobBy(ctx, WithExportJobStates(datapb.ExportJobState_ExportPending, datapb.ExportJobState_ExportInProgress))
+	if executingNum >= maxNum {
+		return &datapb.ExportResponse{
+			Status: merr.Status(merr.WrapErrExportFailed(
+				fmt.Sprintf("the number of export jobs has reached the limit %d, please try again later", maxNum))),
+		}, nil
+	}
+
+	coll, err := s.handler.GetCollection(ctx, req.GetCollectionID())
+	if err != nil {
+		log.Warn("get collection failed", zap.Error(err))
+		return &datapb.ExportResponse{
+			Status: merr.Status(err),
+		}, nil
+	}
+	if coll == nil {
+		return &datapb.ExportResponse{
+			Status: merr.Status(merr.WrapErrCollectionNotFound(req.GetCollectionID())),
+		}, nil
+	}
+
+	if req.GetExpr() != "" {
+		if err := validateExportExpr(coll, req.GetExpr()); err != nil {
+			return &datapb.ExportResponse{
+				Status: merr.Status(err),
+			}, nil
+		}
+	}
+	if len(req.GetSerializedRowFilter()) > 0 {
+		if err := validateExportRowFilter(req.GetSerializedRowFilter()); err != nil {
+			return &datapb.ExportResponse{
+				Status: merr.Status(err),
+			}, nil
+		}
+	}
+
+	jobID, err := s.allocator.AllocID(ctx)
+	if err != nil {
+		log.Warn("alloc export job id failed", zap.Error(err))
+		return &datapb.ExportResponse{
+			Status: merr.Status(err),
+		}, nil
+	}
+
+	segments := s.selectSnapshotSegments(ctx, req.GetCollectionID(), req.GetPartitionIDs(), typeutil.MaxTimestamp)
+	// ts is allocated after segments are selected, so all data of selected segments is before ts.
+	ts, err := s.allocator.AllocTimestamp(ctx)
+	if err != nil {
+		log.Warn("alloc export timestamp failed", zap.Error(err))
+		return &datapb.ExportResponse{
+			Status: merr.Status(err),
+		}, nil
+	}
+
+	outputPath := req.GetOutputPath()
+	if outputPath == "" {
+		outputPath = path.Join(s.meta.chunkManager.RootPath(), "export", strconv.FormatInt(jobID, 10))
+	}
+	job := &ExportJob{
+		ExportJob: &datapb.ExportJob{
+			JobID:          jobID,
+			CollectionID:   req.GetCollectionID(),
+			CollectionName: req.GetCollectionName(),
+			DbName:         coll.DatabaseName,
+			PartitionIDs:   req.GetPartitionIDs(),
+			Schema:         coll.Schema,
+			Expr:           req.GetExpr(),
+			OutputPath:     outputPath,
+			Ts:             ts,
+			State:          datapb.ExportJobState_ExportPending,
+			StartTime:      time.Now().Format("2006-01-02T15:04:05Z07:00"),
+
+			SerializedRowFilter: req.GetSerializedRowFilter(),
+		},
+		tr: timerecord.NewTimeRecorder("export job"),
+	}
+
+	tasks, err := s.newExportTasks(ctx, job, segments)
+	if err != nil {
+		log.Warn("create export tasks failed", zap.Error(err))
+		return &datapb.ExportResponse{
+			Status: merr.Status(err),
+		}, nil
+	}
+	for _, task := range tasks {
+		if err = s.exportMeta.AddTask(ctx, task); err != nil {
+			log.Warn("add export task failed", WrapExportTaskLog(task, zap.Error(err))...)
+			return &datapb.ExportResponse{
+				Status: merr.Status(err),
+			}, nil
+		}
+	}
+	// tasks are saved before the job, dangling tasks of a failed request are never scheduled.
+	if err = s.exportMeta.AddJob(ctx, job); err != nil {
+		log.Warn("add export job failed", zap.Error(err))
+		return &datapb.ExportResponse{
+			Status: merr.Status(err),
+		}, nil
+	}
+
+	log.Info("add export job done", zap.Int64("jobID", jobID), zap.Uint64("ts", ts),
+		zap.String("outputPath", outputPath), zap.Int("segments", len(segments)), zap.Int("tasks", len(tasks)))
+	return &datapb.ExportResponse{
+		Status: merr.Success(),
+		JobID:  jobID,
+	}, nil
+}
+
+func validateExportExpr(coll *collectionInfo, expr string) error {
+	schemaHelper, err := typeutil.CreateSchemaHelper(coll.Schema)
+	if err != nil {
+		return err
+	}
+	plan, err := planparserv2.ParseExpr(schemaHelper, expr, nil)
+	if err != nil {
+		return merr.WrapErrParameterInvalidMsg("invalid export expression %s: %s", expr, err.Error())
+	}
+	_, err = exprutil.NewRowFilter(plan)
+	return err
+}
+
+func validateExportRowFilter(serialized []byte) error {
+	filter := &planpb.Expr{}
+	if err := proto.Unmarshal(serialized, filter); err != nil {
+		return merr.WrapErrParameterInvalidMsg("invalid export row filter: %s", err.Error())
+	}
+	_, err := exprutil.NewRowFilter(filter)
+	return err
+}
+
+// newExportTasks groups the segments by MaxSizeInMBPerExportTask, every task carries all L0 segments
+// since the deletions in them may apply to any segment.
+func (s *Server) newExportTasks(ctx context.Context, job *ExportJob, segments []*SegmentInfo) ([]*ExportTask, error) {
+	l0SegmentIDs := make([]int64, 0)
+	dataSegments := make([]*SegmentInfo, 0, len(segments))
+	for _, segment := range segments {
+		if segment.GetLevel() == datapb.SegmentLevel_L0 {
+			l0SegmentIDs = append(l0SegmentIDs, segment.GetID())
+			continue
+		}
+		dataSegments = append(dataSegments, segment)
+	}
+
+	maxSize := paramtable.Get().DataCoordCfg.MaxSizeInMBPerExportTask.GetAsInt64() * 1024 * 1024
+	groups := make([][]*SegmentInfo, 0)
+	var (
+		group     []*SegmentInfo
+		groupSize int64
+	)
+	for _, segment := range dataSegments {
+		if len(group) > 0 && groupSize+segment.getSegmentSize() > maxSize {
+			groups = append(groups, group)
+			group, groupSize = nil, 0
+		}
+		group = append(group, segment)
+		groupSize += segment.getSegmentSize()
+	}
+	if len(group) > 0 {
+		groups = append(groups, group)
+	}
+
+	if len(groups) == 0 {
+		return nil, nil
+	}
+	taskID, _, err := s.allocator.AllocN(int64(len(groups)))
+	if err != nil {
+		return nil, err
+	}
+	createdTime := time.Now().Format("2006-01-02T15:04:05Z07:00")
+	tasks := make([]*ExportTask, 0, len(groups))
+	for _, group := range groups {
+		segmentIDs := lo.Map(group, func(segment *SegmentInfo, _ int) int64 {
+			return segment.GetID()
+		})
+		tasks = append(tasks, &ExportTask{
+			ExportTask: &datapb.ExportTask{
+				JobID:        job.GetJobID(),
+				TaskID:       taskID,
+				CollectionID: job.GetCollectionID(),
+				SegmentIDs:   append(segmentIDs, l0SegmentIDs...),
+				NodeID:       NullNodeID,
+				State:        datapb.ImportTaskStateV2_Pending,
+				TotalRows: lo.SumBy(group, func(segment *SegmentInfo) int64 {
+					return segment.GetNumOfRows()
+				}),
+				CreatedTime: createdTime,
+			},
+			tr: timerecord.NewTimeRecorder("export task"),
+		})
+		taskID++
+	}
+	return tasks, nil
+}
+
+func (s *Server) GetExportProgress(ctx context.Context, req *datapb.GetExportProgressRequest) (*datapb.GetExportProgressResponse, error) {
+	if err := merr.CheckHealthy(s.GetStateCode()); err != nil {
+		return &datapb.GetExportProgressResponse{
+			Status: merr.Status(err),
+		}, nil
+	}
+
+	job := s.exportMeta.GetJob(ctx, req.GetJobID())
+	if job == nil {
+		return &datapb.GetExportProgressResponse{
+			Status: merr.Status(merr.WrapErrExportFailed(fmt.Sprintf("export job does not exist, jobID=%d", req.GetJobID()))),
+		}, nil
+	}
+	resp := &datapb.GetExportProgressResponse{
+		Status:         merr.Success(),
+		State:          job.GetState(),
+		Reason:         job.GetReason(),
+		CollectionName: job.GetCollectionName(),
+		DbName:         job.GetDbName(),
+		OutputPath:     job.GetOutputPath(),
+		StartTime:      job.GetStartTime(),
+		CompleteTime:   job.GetCompleteTime(),
+	}
+	tasks := s.exportMeta.GetTaskBy(ctx, WithExportJob(req.GetJobID()))
+	for _, task := range tasks {
+		resp.ProcessedRows += task.GetProcessedRows()
+		resp.ExportedRows += task.GetExportedRows()
+		resp.TotalRows += task.GetTotalRows()
+		resp.Files = append(resp.Files, task.GetFiles()...)
+	}
+	switch {
+	case job.GetState() == datapb.ExportJobState_ExportCompleted:
+		resp.Progress = 100
+	case resp.TotalRows > 0:
+		// processed rows may exceed total rows since total rows don't count deletions
+		resp.Progress = min(resp.ProcessedRows*100/resp.TotalRows, 99)
+	}
+	return resp, nil
+}
+
+// CancelExport marks the job as cancelled, the running tasks are stopped by the export scheduler.
+// The files already written are kept.
+func (s *Server) CancelExport(ctx context.Context, req *datapb.CancelExportRequest) (*commonpb.Status, error) {
+	if err := merr.CheckHealthy(s.GetStateCode()); err != nil {
+		return merr.Status(err), nil
+	}
+
+	job := s.exportMeta.GetJob(ctx, req.GetJobID())
+	if job == nil {
+		return merr.Status(merr.WrapErrExportFailed(fmt.Sprintf("export job does not exist, jobID=%d", req.GetJobID()))), nil
+	}
+	if job.IsFinished() {
+		return merr.Status(merr.WrapErrExportFailed(fmt.Sprintf("export job %d has finished with state %s",
+			req.GetJobID(), job.GetState().String()))), nil
+	}
+	err := s.exportMeta.UpdateJob(ctx, req.GetJobID(),
+		UpdateExportJobState(datapb.ExportJobState_ExportCancelled),
+		UpdateExportJobReason("cancelled by user"))
+	if err != nil {
+		return merr.Status(err), nil
+	}
+	log.Ctx(ctx).Info("export job cancelled", zap.Int64("jobID", req.GetJobID()))
+	return merr.Success(), nil
+}
@@ -0,0 +1,182 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datacoord
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cockroachdb/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
+	"github.com/milvus-io/milvus/internal/datacoord/allocator"
+	"github.com/milvus-io/milvus/internal/metastore/mocks"
+	mocks2 "github.com/milvus-io/milvus/internal/mocks"
+	"github.com/milvus-io/milvus/internal/proto/datapb"
+	"github.com/milvus-io/milvus/pkg/util/merr"
+	"github.com/milvus-io/milvus/pkg/util/paramtable"
+)
+
+func TestExportService(t *testing.T) {
+	ctx := context.Background()
+	const collectionID = 1
+
+	catalog := mocks.NewDataCoordCatalog(t)
+	catalog.EXPECT().ListExportJobs(mock.Anything).Return(nil, nil)
+	catalog.EXPECT().ListExportTasks(mock.Anything).Return(nil, nil)
+	catalog.EXPECT().ListSegments(mock.Anything).Return(nil, nil)
+	catalog.EXPECT().ListChannelCheckpoint(mock.Anything).Return(nil, nil)
+	catalog.EXPECT().ListIndexes(mock.Anything).Return(nil, nil)
+	catalog.EXPECT().ListSegmentIndexes(mock.Anything).Return(nil, nil)
+	catalog.EXPECT().ListAnalyzeTasks(mock.Anything).Return(nil, nil)
+	catalog.EXPECT().ListCompactionTask(mock.Anything).Return(nil, nil)
+	catalog.EXPECT().ListPartitionStatsInfos(mock.Anything).Return(nil, nil)
+	catalog.EXPECT().ListStatsTasks(mock.Anything).Return(nil, nil)
+	catalog.EXPECT().ListSnapshots(mock.Anything).Return(nil, nil)
+	catalog.EXPECT().SaveExportJob(mock.Anything, mock.Anything).Return(nil)
+	catalog.EXPECT().SaveExportTask(mock.Anything, mock.Anything).Return(nil)
+
+	cm := mocks2.NewChunkManager(t)
+	cm.EXPECT().RootPath().Return("files").Maybe()
+	meta, err := newMeta(ctx, catalog, cm)
+	require.NoError(t, err)
+	coll := &collectionInfo{
+		ID:           collectionID,
+		Schema:       newTestScalarClusteringKeySchema(),
+		DatabaseName: "db",
+	}
+	meta.AddCollection(coll)
+	segments := []*datapb.SegmentInfo{
+		{ID: 10, CollectionID: collectionID, PartitionID: 2, State: commonpb.SegmentState_Flushed, NumOfRows: 100},
+		{ID: 11, CollectionID: collectionID, PartitionID: 2, State: commonpb.SegmentState_Flushed, NumOfRows: 200},
+		{ID: 12, CollectionID: collectionID, PartitionID: 3, State: commonpb.SegmentState_Flushed, NumOfRows: 300},
+		{ID: 13, CollectionID: collectionID, PartitionID: 2, State: commonpb.SegmentState_Growing, NumOfRows: 400},
+		{ID: 14, CollectionID: collectionID, PartitionID: 2, State: commonpb.SegmentState_Flushed, Level: datapb.SegmentLevel_L0},
+	}
+	for _, segment := range segments {
+		meta.segments.SetSegment(segment.GetID(), NewSegmentInfo(segment))
+	}
+	exportMeta, err := NewExportMeta(ctx, catalog)
+	require.NoError(t, err)
+
+	handler := NewNMockHandler(t)
+	handler.EXPECT().GetCollection(mock.Anything, int64(collectionID)).Return(coll, nil).Maybe()
+	handler.EXPECT().GetCollection(mock.Anything, int64(100)).Return(nil, nil).Maybe()
+	alloc := allocator.NewMockAllocator(t)
+	alloc.EXPECT().AllocID(mock.Anything).Return(1000, nil).Maybe()
+	alloc.EXPECT().AllocTimestamp(mock.Anything).Return(2000, nil).Maybe()
+	alloc.EXPECT().AllocN(mock.Anything).Return(3000, 3010, nil).Maybe()
+
+	s := &Server{
+		meta:       meta,
+		handler:    handler,
+		allocator:  alloc,
+		exportMeta: exportMeta,
+	}
+
+	t.Run("not healthy", func(t *testing.T) {
+		s.stateCode.Store(commonpb.StateCode_Initializing)
+		defer s.stateCode.Store(commonpb.StateCode_Healthy)
+		resp, err := s.Export(ctx, &datapb.ExportRequest{CollectionID: collectionID})
+		assert.NoError(t, err)
+		assert.Error(t, merr.Error(resp.GetStatus()))
+		resp2, err := s.GetExportProgress(ctx, &datapb.GetExportProgressRequest{JobID: 1000})
+		assert.NoError(t, err)
+		assert.Error(t, merr.Error(resp2.GetStatus()))
+		status, err := s.CancelExport(ctx, &datapb.CancelExportRequest{JobID: 1000})
+		assert.NoError(t, err)
+		assert.Error(t, merr.Error(status))
+	})
+	s.stateCode.Store(commonpb.StateCode_Healthy)
+
+	t.Run("invalid request", func(t *testing.T) {
+		resp, err := s.Export(ctx, &datapb.ExportRequest{CollectionID: 100})
+		assert.NoError(t, err)
+		assert.True(t, errors.Is(merr.Error(resp.GetStatus()), merr.ErrCollectionNotFound))
+
+		resp, err = s.Export(ctx, &datapb.ExportRequest{CollectionID: collectionID, Expr: "field1 >"})
+		assert.NoError(t, err)
+		assert.True(t, errors.Is(merr.Error(resp.GetStatus()), merr.ErrParameterInvalid))
+
+		resp, err = s.Export(ctx, &datapb.ExportRequest{CollectionID: collectionID, SerializedRowFilter: []byte("invalid")})
+		assert.NoError(t, err)
+		assert.True(t, errors.Is(merr.Error(resp.GetStatus()), merr.ErrParameterInvalid))
+	})
+
+	t.Run("export", func(t *testing.T) {
+		resp, err := s.Export(ctx, &datapb.ExportRequest{
+			CollectionID:   collectionID,
+			CollectionName: "coll",
+			PartitionIDs:   []int64{2},
+			Expr:           "field1 > 10",
+		})
+		assert.NoError(t, err)
+		assert.NoError(t, merr.Error(resp.GetStatus()))
+		assert.Equal(t, int64(1000), resp.GetJobID())
+
+		job := exportMeta.GetJob(ctx, 1000)
+		assert.Equal(t, "files/export/1000", job.GetOutputPath())
+		assert.Equal(t, uint64(2000), job.GetTs())
+		assert.Equal(t, "db", job.GetDbName())
+		tasks := exportMeta.GetTaskBy(ctx, WithExportJob(1000))
+		assert.Equal(t, 1, len(tasks))
+		assert.ElementsMatch(t, []int64{10, 11, 14}, tasks[0].GetSegmentIDs())
+		assert.Equal(t, int64(300), tasks[0].GetTotalRows())
+
+		err = exportMeta.UpdateTask(ctx, tasks[0].GetTaskID(), UpdateExportTaskProgress(150, 100, []string{"a.parquet"}))
+		assert.NoError(t, err)
+		progress, err := s.GetExportProgress(ctx, &datapb.GetExportProgressRequest{JobID: 1000})
+		assert.NoError(t, err)
+		assert.NoError(t, merr.Error(progress.GetStatus()))
+		assert.Equal(t, int64(50), progress.GetProgress())
+		assert.Equal(t, int64(150), progress.GetProcessedRows())
+		assert.Equal(t, int64(100), progress.GetExportedRows())
+		assert.Equal(t, int64(300), progress.GetTotalRows())
+		assert.Equal(t, []string{"a.parquet"}, progress.GetFiles())
+		assert.Equal(t, "db", progress.GetDbName())
+		assert.Equal(t, "coll", progress.GetCollectionName())
+	})
+
+	t.Run("limit", func(t *testing.T) {
+		paramtable.Get().Save(paramtable.Get().DataCoordCfg.MaxExportJobNum.Key, "1")
+		defer paramtable.Get().Reset(paramtable.Get().DataCoordCfg.MaxExportJobNum.Key)
+		resp, err := s.Export(ctx, &datapb.ExportRequest{CollectionID: collectionID})
+		assert.NoError(t, err)
+		assert.True(t, errors.Is(merr.Error(resp.GetStatus()), merr.ErrExportFailed))
+	})
+
+	t.Run("cancel", func(t *testing.T) {
+		status, err := s.CancelExport(ctx, &datapb.CancelExportRequest{JobID: 1})
+		assert.NoError(t, err)
+		assert.True(t, errors.Is(merr.Error(status), merr.ErrExportFailed))
+
+		status, err = s.CancelExport(ctx, &datapb.CancelExportRequest{JobID: 1000})
+		assert.NoError(t, err)
+		assert.NoError(t, merr.Error(status))
+		progress, err := s.GetExportProgress(ctx, &datapb.GetExportProgressRequest{JobID: 1000})
+		assert.NoError(t, err)
+		assert.Equal(t, datapb.ExportJobState_ExportCancelled, progress.GetState())
+
+		// cancel a finished job
+		status, err = s.CancelExport(ctx, &datapb.CancelExportRequest{JobID: 1000})
+		assert.NoError(t, err)
+		assert.True(t, errors.Is(merr.Error(status), merr.ErrExportFailed))
+	})
+}
@@ -56,6 +56,7 @@ type GcOption struct {
 
 	broker           broker.Broker
 	removeObjectPool *conc.Pool[struct{}]
+	exportMeta       ExportMeta // segments of unfinished exports are pinned
 }
 
 // garbageCollector handles garbage files in object storage
@@ -237,6 +238,14 @@ func (gc *garbageCollector) close() {
 	})
 }
 
+// isSegmentPinned returns whether the segment is pinned by snapshots or unfinished exports.
+func (gc *garbageCollector) isSegmentPinned(segmentID int64) bool {
+	if gc.meta.snapshotMeta.IsSegmentPinned(segmentID) {
+		return true
+	}
+	return gc.option.exportMeta != nil && gc.option.exportMeta.IsSegmentPinned(segmentID)
+}
+
 // recycleUnusedBinlogFiles load meta file info and compares OSS keys
 // if missing found, performs gc cleanup
 func (gc *garbageCollector) recycleUnusedBinlogFiles(ctx context.Context) {
@@ -324,6 +333,13 @@ func (gc *garbageCollector) recycleUnusedBinLogWithChecker(ctx context.Context,
 			return true
 		}
 
+		// files of segments pinned by snapshots or exports are kept even if the segment is gone from meta.
+		if gc.isSegmentPinned(segmentID) {
+			valid++
+			logger.Info("garbageCollector recycleUnusedBinlogFiles skip file since segment is pinned", zap.String("filePath", chunkInfo.FilePath), zap.Int64("segmentID", segmentID))
+			return true
+		}
+
 		segment := gc.meta.GetSegment(ctx, segmentID)
 		if checker(chunkInfo, segment) {
 			valid++
@@ -460,6 +476,11 @@ func (gc *garbageCollector) recycleDroppedSegments(ctx context.Context) {
 		}
 
 		log := log.With(zap.Int64("segmentID", segmentID))
+		if gc.isSegmentPinned(segmentID) {
+			log.WithRateGroup("GC_FAIL_PINNED", 1, 60).
+				RatedInfo(60, "skipping GC when segment is pinned by snapshot or export")
+			continue
+		}
 		segInsertChannel := segment.GetInsertChannel()
 		if !gc.checkDroppedSegmentGC(segment, compactTo[segment.GetID()], indexedSet, channelCPs[segInsertChannel]) {
 			continue
@@ -474,6 +495,7 @@ func (gc *garbageCollector) recycleDroppedSegments(ctx context.Context) {
 			zap.Int("delta_logs", len(segment.GetDeltalogs())),
 			zap.Int("stats_logs", len(segment.GetStatslogs())),
 			zap.Int("bm25_logs", len(segment.GetBm25Statslogs())),
+			zap.Int("field_stats_logs", len(segment.GetFieldStatslogs())),
 			zap.Int("text_logs", len(segment.GetTextStatsLogs())))
 		if err := gc.removeObjectFiles(ctx, logs); err != nil {
 			log.Warn("GC segment remove logs failed", zap.Error(err))
@@ -570,6 +592,11 @@ func getLogs(sinfo *SegmentInfo) map[string]struct{} {
 			logs[l.GetLogPath()] = struct{}{}
 		}
 	}
+	for _, flog := range sinfo.GetFieldStatslogs() {
+		for _, l := range flog.GetBinlogs() {
+			logs[l.GetLogPath()] = struct{}{}
+		}
+	}
 	return logs
 }
 
@@ -644,6 +671,11 @@ func (gc *garbageCollector) recycleUnusedSegIndexes(ctx context.Context) {
 			return
 		}
 
+		// index files pinned by snapshots are kept until the snapshots are dropped.
+		if gc.meta.snapshotMeta.IsBuildPinned(segIdx.BuildID) {
+			continue
+		}
+
 		// 1. segment belongs to is deleted.
 		// 2. index is deleted.
 		if gc.meta.GetSegment(ctx, segIdx.SegmentID) == nil || !gc.meta.indexMeta.IsIndexExist(segIdx.CollectionID, segIdx.IndexID) {
@@ -693,6 +725,10 @@ func (gc *garbageCollector) recycleUnusedIndexFiles(ctx context.Context) {
 			return true
 		}
 		logger = logger.With(zap.Int64("buildID", buildID))
+		if gc.meta.snapshotMeta.IsBuildPinned(buildID) {
+			logger.Info("garbageCollector skip index files since it is pinned by snapshot")
+			return true
+		}
 		logger.Info("garbageCollector will recycle index files")
 		canRecycle, segIdx := gc.meta.indexMeta.CheckCleanSegmentIndex(buildID)
 		if !canRecycle {
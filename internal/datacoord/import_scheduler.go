@@ -321,8 +321,9 @@ func (s *importScheduler) processInProgressImport(task ImportTask) {
 				return
 			}
 			op1 := UpdateBinlogsOperator(info.GetSegmentID(), info.GetBinlogs(), info.GetStatslogs(), info.GetDeltalogs())
-			op2 := UpdateStatusOperator(info.GetSegmentID(), commonpb.SegmentState_Flushed)
-			err = s.meta.UpdateSegmentsInfo(context.TODO(), op1, op2)
+			op2 := AddFieldStatslogsOperator(info.GetSegmentID(), info.GetFieldStatslogs())
+			op3 := UpdateStatusOperator(info.GetSegmentID(), commonpb.SegmentState_Flushed)
+			err = s.meta.UpdateSegmentsInfo(context.TODO(), op1, op2, op3)
 			if err != nil {
 				log.Warn("update import segment binlogs failed", WrapTaskLog(task, zap.Error(err))...)
 				return
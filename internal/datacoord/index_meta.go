@@ -435,6 +435,25 @@ func (m *indexMeta) AddSegmentIndex(ctx context.Context, segIndex *model.Segment
 	return nil
 }
 
+// RestoreSegmentIndex adds a segment index whose index files are already built, e.g. copied from a snapshot.
+func (m *indexMeta) RestoreSegmentIndex(ctx context.Context, segIndex *model.SegmentIndex) error {
+	m.Lock()
+	defer m.Unlock()
+
+	if err := m.catalog.CreateSegmentIndex(ctx, segIndex); err != nil {
+		log.Warn("meta update: restoring segment index failed",
+			zap.Int64("segmentID", segIndex.SegmentID), zap.Int64("indexID", segIndex.IndexID),
+			zap.Int64("buildID", segIndex.BuildID), zap.Error(err))
+		return err
+	}
+	m.updateSegmentIndex(segIndex)
+	log.Info("meta update: restoring segment index success", zap.Int64("collectionID", segIndex.CollectionID),
+		zap.Int64("segmentID", segIndex.SegmentID), zap.Int64("indexID", segIndex.IndexID),
+		zap.Int64("buildID", segIndex.BuildID))
+	m.updateIndexTasksMetrics()
+	return nil
+}
+
 func (m *indexMeta) GetIndexIDByName(collID int64, indexName string) map[int64]uint64 {
 	m.RLock()
 	defer m.RUnlock()
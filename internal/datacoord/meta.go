@@ -90,6 +90,7 @@ type meta struct {
 	partitionStatsMeta *partitionStatsMeta
 	compactionTaskMeta *compactionTaskMeta
 	statsTaskMeta      *statsTaskMeta
+	snapshotMeta       *snapshotMeta
 }
 
 func (m *meta) GetIndexMeta() *indexMeta {
@@ -164,6 +165,11 @@ func newMeta(ctx context.Context, catalog metastore.DataCoordCatalog, chunkManag
 	if err != nil {
 		return nil, err
 	}
+
+	sm, err := newSnapshotMeta(ctx, catalog)
+	if err != nil {
+		return nil, err
+	}
 	mt := &meta{
 		ctx:                ctx,
 		catalog:            catalog,
@@ -176,6 +182,7 @@ func newMeta(ctx context.Context, catalog metastore.DataCoordCatalog, chunkManag
 		partitionStatsMeta: psm,
 		compactionTaskMeta: ctm,
 		statsTaskMeta:      stm,
+		snapshotMeta:       sm,
 	}
 	err = mt.reloadFromKV()
 	if err != nil {
@@ -906,6 +913,24 @@ func AddBinlogsOperator(segmentID int64, binlogs, statslogs, deltalogs, bm25logs
 	}
 }
 
+// AddFieldStatslogsOperator adds the stats logs of the fields with field stats enabled in segmentInfo
+func AddFieldStatslogsOperator(segmentID int64, fieldStatslogs []*datapb.FieldBinlog) UpdateOperator {
+	return func(modPack *updateSegmentPack) bool {
+		if len(fieldStatslogs) == 0 {
+			return false
+		}
+		segment := modPack.Get(segmentID)
+		if segment == nil {
+			log.Warn("meta update: add field stats logs failed - segment not found",
+				zap.Int64("segmentID", segmentID))
+			return false
+		}
+
+		segment.FieldStatslogs = mergeFieldBinlogs(segment.GetFieldStatslogs(), fieldStatslogs)
+		return true
+	}
+}
+
 func UpdateBinlogsOperator(segmentID int64, binlogs, statslogs, deltalogs []*datapb.FieldBinlog) UpdateOperator {
 	return func(modPack *updateSegmentPack) bool {
 		segment := modPack.Get(segmentID)
@@ -1484,6 +1509,7 @@ func (m *meta) completeClusterCompactionMutation(t *datapb.CompactionTask, resul
 			MaxRowNum:           compactFromSegInfos[0].MaxRowNum,
 			Binlogs:             seg.GetInsertLogs(),
 			Statslogs:           seg.GetField2StatslogPaths(),
+			FieldStatslogs:      seg.GetFieldStatslogs(),
 			CreatedByCompaction: true,
 			CompactionFrom:      compactFromSegIDs,
 			LastExpireTime:      tsoutil.ComposeTSByTime(time.Unix(t.GetStartTime(), 0), 0),
@@ -1571,6 +1597,8 @@ func (m *meta) completeMixCompactionMutation(t *datapb.CompactionTask, result *d
 				Deltalogs:     compactToSegment.GetDeltalogs(),
 				Bm25Statslogs: compactToSegment.GetBm25Logs(),
 
+				FieldStatslogs: compactToSegment.GetFieldStatslogs(),
+
 				CreatedByCompaction: true,
 				CompactionFrom:      compactFromSegIDs,
 				LastExpireTime:      tsoutil.ComposeTSByTime(time.Unix(t.GetStartTime(), 0), 0),
@@ -2041,6 +2069,7 @@ func (m *meta) SaveStatsResultSegment(oldSegmentID int64, result *workerpb.Stats
 		Statslogs:                 result.GetStatsLogs(),
 		TextStatsLogs:             result.GetTextStatsLogs(),
 		Bm25Statslogs:             result.GetBm25Logs(),
+		FieldStatslogs:            result.GetFieldStatslogs(),
 		Deltalogs:                 nil,
 		CompactionFrom:            []int64{oldSegmentID},
 		IsSorted:                  true,
@@ -103,6 +103,53 @@ func (_c *MockCluster_DropImport_Call) RunAndReturn(run func(int64, *datapb.Drop
 	return _c
 }
 
+// Export provides a mock function with given fields: nodeID, in
+func (_m *MockCluster) Export(nodeID int64, in *datapb.ExportTaskRequest) error {
+	ret := _m.Called(nodeID, in)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Export")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int64, *datapb.ExportTaskRequest) error); ok {
+		r0 = rf(nodeID, in)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockCluster_Export_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Export'
+type MockCluster_Export_Call struct {
+	*mock.Call
+}
+
+// Export is a helper method to define mock.On call
+//   - nodeID int64
+//   - in *datapb.ExportTaskRequest
+func (_e *MockCluster_Expecter) Export(nodeID interface{}, in interface{}) *MockCluster_Export_Call {
+	return &MockCluster_Export_Call{Call: _e.mock.On("Export", nodeID, in)}
+}
+
+func (_c *MockCluster_Export_Call) Run(run func(nodeID int64, in *datapb.ExportTaskRequest)) *MockCluster_Export_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(int64), args[1].(*datapb.ExportTaskRequest))
+	})
+	return _c
+}
+
+func (_c *MockCluster_Export_Call) Return(_a0 error) *MockCluster_Export_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockCluster_Export_Call) RunAndReturn(run func(int64, *datapb.ExportTaskRequest) error) *MockCluster_Export_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Flush provides a mock function with given fields: ctx, nodeID, channel, segments
 func (_m *MockCluster) Flush(ctx context.Context, nodeID int64, channel string, segments []*datapb.SegmentInfo) error {
 	ret := _m.Called(ctx, nodeID, channel, segments)
@@ -342,6 +389,65 @@ func (_c *MockCluster_PreImport_Call) RunAndReturn(run func(int64, *datapb.PreIm
 	return _c
 }
 
+// QueryExport provides a mock function with given fields: nodeID, in
+func (_m *MockCluster) QueryExport(nodeID int64, in *datapb.QueryExportRequest) (*datapb.QueryExportResponse, error) {
+	ret := _m.Called(nodeID, in)
+
+	if len(ret) == 0 {
+		panic("no return value specified for QueryExport")
+	}
+
+	var r0 *datapb.QueryExportResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int64, *datapb.QueryExportRequest) (*datapb.QueryExportResponse, error)); ok {
+		return rf(nodeID, in)
+	}
+	if rf, ok := ret.Get(0).(func(int64, *datapb.QueryExportRequest) *datapb.QueryExportResponse); ok {
+		r0 = rf(nodeID, in)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*datapb.QueryExportResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int64, *datapb.QueryExportRequest) error); ok {
+		r1 = rf(nodeID, in)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockCluster_QueryExport_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'QueryExport'
+type MockCluster_QueryExport_Call struct {
+	*mock.Call
+}
+
+// QueryExport is a helper method to define mock.On call
+//   - nodeID int64
+//   - in *datapb.QueryExportRequest
+func (_e *MockCluster_Expecter) QueryExport(nodeID interface{}, in interface{}) *MockCluster_QueryExport_Call {
+	return &MockCluster_QueryExport_Call{Call: _e.mock.On("QueryExport", nodeID, in)}
+}
+
+func (_c *MockCluster_QueryExport_Call) Run(run func(nodeID int64, in *datapb.QueryExportRequest)) *MockCluster_QueryExport_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(int64), args[1].(*datapb.QueryExportRequest))
+	})
+	return _c
+}
+
+func (_c *MockCluster_QueryExport_Call) Return(_a0 *datapb.QueryExportResponse, _a1 error) *MockCluster_QueryExport_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockCluster_QueryExport_Call) RunAndReturn(run func(int64, *datapb.QueryExportRequest) (*datapb.QueryExportResponse, error)) *MockCluster_QueryExport_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // QueryImport provides a mock function with given fields: nodeID, in
 func (_m *MockCluster) QueryImport(nodeID int64, in *datapb.QueryImportRequest) (*datapb.QueryImportResponse, error) {
 	ret := _m.Called(nodeID, in)
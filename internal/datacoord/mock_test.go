@@ -291,6 +291,14 @@ func (c *mockDataNodeClient) DropImport(ctx context.Context, req *datapb.DropImp
 	return &commonpb.Status{ErrorCode: commonpb.ErrorCode_Success}, nil
 }
 
+func (c *mockDataNodeClient) Export(ctx context.Context, req *datapb.ExportTaskRequest, opts ...grpc.CallOption) (*commonpb.Status, error) {
+	return merr.Success(), nil
+}
+
+func (c *mockDataNodeClient) QueryExport(ctx context.Context, req *datapb.QueryExportRequest, opts ...grpc.CallOption) (*datapb.QueryExportResponse, error) {
+	return &datapb.QueryExportResponse{Status: merr.Success()}, nil
+}
+
 func (c *mockDataNodeClient) QuerySlot(ctx context.Context, req *datapb.QuerySlotRequest, opts ...grpc.CallOption) (*datapb.QuerySlotResponse, error) {
 	return &datapb.QuerySlotResponse{Status: merr.Success()}, nil
 }
@@ -709,6 +717,22 @@ func (m *mockRootCoordClient) OperatePrivilegeGroup(ctx context.Context, req *mi
 	panic("implement me")
 }
 
+func (m *mockRootCoordClient) OperateRowPolicy(ctx context.Context, req *rootcoordpb.OperateRowPolicyRequest, opts ...grpc.CallOption) (*commonpb.Status, error) {
+	panic("implement me")
+}
+
+func (m *mockRootCoordClient) ListRecycleBin(ctx context.Context, req *proxypb.ListRecycleBinRequest, opts ...grpc.CallOption) (*proxypb.ListRecycleBinResponse, error) {
+	panic("implement me")
+}
+
+func (m *mockRootCoordClient) UndropCollection(ctx context.Context, req *proxypb.UndropCollectionRequest, opts ...grpc.CallOption) (*commonpb.Status, error) {
+	panic("implement me")
+}
+
+func (m *mockRootCoordClient) UndropPartition(ctx context.Context, req *proxypb.UndropPartitionRequest, opts ...grpc.CallOption) (*commonpb.Status, error) {
+	panic("implement me")
+}
+
 type mockHandler struct {
 	meta *meta
 }
@@ -45,6 +45,7 @@ import (
 	datanodeclient "github.com/milvus-io/milvus/internal/distributed/datanode/client"
 	indexnodeclient "github.com/milvus-io/milvus/internal/distributed/indexnode/client"
 	etcdkv "github.com/milvus-io/milvus/internal/kv/etcd"
+	sqlitekv "github.com/milvus-io/milvus/internal/kv/sqlite"
 	"github.com/milvus-io/milvus/internal/kv/tikv"
 	"github.com/milvus-io/milvus/internal/metastore/kv/datacoord"
 	"github.com/milvus-io/milvus/internal/proto/datapb"
@@ -126,6 +127,8 @@ type Server struct {
 	importMeta       ImportMeta
 	importScheduler  ImportScheduler
 	importChecker    ImportChecker
+	exportMeta       ExportMeta
+	exportScheduler  ExportScheduler
 
 	compactionTrigger        trigger
 	compactionHandler        compactionPlanContext
@@ -413,6 +416,12 @@ func (s *Server) initDataCoord() error {
 	}
 	log.Info("init segment manager done")
 
+	s.exportMeta, err = NewExportMeta(s.ctx, s.meta.catalog)
+	if err != nil {
+		return err
+	}
+	s.exportScheduler = NewExportScheduler(s.meta, s.cluster, s.exportMeta)
+
 	s.initGarbageCollection(storageCli)
 
 	s.importMeta, err = NewImportMeta(s.ctx, s.meta.catalog)
@@ -564,6 +573,7 @@ func (s *Server) initGarbageCollection(cli storage.ChunkManager) {
 	s.garbageCollector = newGarbageCollector(s.meta, s.handler, GcOption{
 		cli:              cli,
 		broker:           s.broker,
+		exportMeta:       s.exportMeta,
 		enabled:          Params.DataCoordCfg.EnableGarbageCollection.GetAsBool(),
 		checkInterval:    Params.DataCoordCfg.GCInterval.GetAsDuration(time.Second),
 		scanInterval:     Params.DataCoordCfg.GCScanIntervalInHour.GetAsDuration(time.Hour),
@@ -671,6 +681,14 @@ func (s *Server) initKV() error {
 		s.metaRootPath = Params.EtcdCfg.MetaRootPath.GetValue()
 		s.kv = etcdkv.NewEtcdKV(s.etcdCli, s.metaRootPath,
 			etcdkv.WithRequestTimeout(paramtable.Get().ServiceParam.EtcdCfg.RequestTimeout.GetAsDuration(time.Millisecond)))
+	} else if metaType == util.MetaStoreTypeSQLite {
+		db, err := sqlitekv.GetSharedDB(Params.SQLiteCfg.Path.GetValue())
+		if err != nil {
+			return err
+		}
+		s.metaRootPath = Params.SQLiteCfg.MetaRootPath.GetValue()
+		s.kv = sqlitekv.NewSQLiteKV(db, s.metaRootPath,
+			sqlitekv.WithRequestTimeout(paramtable.Get().ServiceParam.SQLiteCfg.RequestTimeout.GetAsDuration(time.Millisecond)))
 	} else {
 		return retry.Unrecoverable(fmt.Errorf("not supported meta store: %s", metaType))
 	}
@@ -763,6 +781,7 @@ func (s *Server) startServerLoop() {
 	s.startFlushLoop(s.serverLoopCtx)
 	go s.importScheduler.Start()
 	go s.importChecker.Start()
+	go s.exportScheduler.Start()
 	s.garbageCollector.start()
 
 	if !(streamingutil.IsStreamingServiceEnabled() || paramtable.Get().DataNodeCfg.SkipBFStatsLoad.GetAsBool()) {
@@ -1103,6 +1122,7 @@ func (s *Server) Stop() error {
 
 	s.importScheduler.Close()
 	s.importChecker.Close()
+	s.exportScheduler.Close()
 	s.syncSegmentsScheduler.Stop()
 
 	s.stopCompaction()
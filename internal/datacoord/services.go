@@ -552,6 +552,7 @@ func (s *Server) SaveBinlogPaths(ctx context.Context, req *datapb.SaveBinlogPath
 	// save binlogs, start positions and checkpoints
 	operators = append(operators,
 		AddBinlogsOperator(req.GetSegmentID(), req.GetField2BinlogPaths(), req.GetField2StatslogPaths(), req.GetDeltalogs(), req.GetField2Bm25LogPaths()),
+		AddFieldStatslogsOperator(req.GetSegmentID(), req.GetField2FieldStatslogPaths()),
 		UpdateStartPosition(req.GetStartPositions()),
 		UpdateCheckPointOperator(req.GetSegmentID(), req.GetCheckPoints()),
 		UpdateAsDroppedIfEmptyWhenFlushing(req.GetSegmentID()),
@@ -0,0 +1,185 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datacoord
+
+import (
+	"context"
+	"sync"
+
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/milvus-io/milvus/internal/metastore"
+	"github.com/milvus-io/milvus/internal/proto/datapb"
+	"github.com/milvus-io/milvus/pkg/log"
+	"github.com/milvus-io/milvus/pkg/util/merr"
+	"github.com/milvus-io/milvus/pkg/util/timerecord"
+)
+
+// snapshotMeta keeps the snapshots of collections, segments and index builds
+// referenced by any snapshot are pinned and must not be recycled by garbage collector.
+type snapshotMeta struct {
+	sync.RWMutex
+
+	ctx     context.Context
+	catalog metastore.DataCoordCatalog
+
+	// snapshot name -> snapshot
+	snapshots map[string]*datapb.SnapshotInfo
+	// segmentID -> number of snapshots referencing it
+	pinnedSegments map[int64]int
+	// buildID -> number of snapshots referencing it
+	pinnedBuilds map[int64]int
+}
+
+func newSnapshotMeta(ctx context.Context, catalog metastore.DataCoordCatalog) (*snapshotMeta, error) {
+	sm := &snapshotMeta{
+		ctx:            ctx,
+		catalog:        catalog,
+		snapshots:      make(map[string]*datapb.SnapshotInfo),
+		pinnedSegments: make(map[int64]int),
+		pinnedBuilds:   make(map[int64]int),
+	}
+	if err := sm.reloadFromKV(); err != nil {
+		return nil, err
+	}
+	return sm, nil
+}
+
+func (sm *snapshotMeta) reloadFromKV() error {
+	record := timerecord.NewTimeRecorder("snapshotMeta-reloadFromKV")
+	snapshots, err := sm.catalog.ListSnapshots(sm.ctx)
+	if err != nil {
+		log.Error("snapshotMeta reloadFromKV load snapshots failed", zap.Error(err))
+		return err
+	}
+	for _, snapshot := range snapshots {
+		sm.snapshots[snapshot.GetName()] = snapshot
+		sm.pin(snapshot, 1)
+	}
+
+	log.Info("snapshotMeta reloadFromKV done", zap.Int("snapshots", len(snapshots)), zap.Duration("duration", record.ElapseSpan()))
+	return nil
+}
+
+func (sm *snapshotMeta) pin(snapshot *datapb.SnapshotInfo, delta int) {
+	for _, segmentID := range snapshot.GetSegmentIDs() {
+		sm.pinnedSegments[segmentID] += delta
+		if sm.pinnedSegments[segmentID] <= 0 {
+			delete(sm.pinnedSegments, segmentID)
+		}
+	}
+	for _, buildID := range snapshot.GetBuildIDs() {
+		sm.pinnedBuilds[buildID] += delta
+		if sm.pinnedBuilds[buildID] <= 0 {
+			delete(sm.pinnedBuilds, buildID)
+		}
+	}
+}
+
+func (sm *snapshotMeta) AddSnapshot(snapshot *datapb.SnapshotInfo, segments []*datapb.SnapshotSegment) error {
+	sm.Lock()
+	defer sm.Unlock()
+
+	log := log.With(zap.String("name", snapshot.GetName()),
+		zap.Int64("snapshotID", snapshot.GetSnapshotID()),
+		zap.Int64("collectionID", snapshot.GetCollectionID()))
+	if _, ok := sm.snapshots[snapshot.GetName()]; ok {
+		return merr.WrapErrParameterInvalidMsg("snapshot %s already exists", snapshot.GetName())
+	}
+
+	if err := sm.catalog.SaveSnapshot(sm.ctx, snapshot, segments); err != nil {
+		log.Warn("save snapshot failed", zap.Error(err))
+		return err
+	}
+	sm.snapshots[snapshot.GetName()] = snapshot
+	sm.pin(snapshot, 1)
+	log.Info("add snapshot done", zap.Uint64("ts", snapshot.GetTs()),
+		zap.Int("segments", len(snapshot.GetSegmentIDs())), zap.Int("builds", len(snapshot.GetBuildIDs())))
+	return nil
+}
+
+func (sm *snapshotMeta) DropSnapshot(name string) error {
+	sm.Lock()
+	defer sm.Unlock()
+
+	snapshot, ok := sm.snapshots[name]
+	if !ok {
+		return merr.WrapErrParameterInvalidMsg("snapshot %s not found", name)
+	}
+	if err := sm.catalog.DropSnapshot(sm.ctx, snapshot.GetSnapshotID()); err != nil {
+		log.Warn("drop snapshot failed", zap.String("name", name), zap.Error(err))
+		return err
+	}
+	delete(sm.snapshots, name)
+	sm.pin(snapshot, -1)
+	log.Info("drop snapshot done", zap.String("name", name), zap.Int64("snapshotID", snapshot.GetSnapshotID()))
+	return nil
+}
+
+func (sm *snapshotMeta) GetSnapshot(name string) *datapb.SnapshotInfo {
+	sm.RLock()
+	defer sm.RUnlock()
+
+	snapshot, ok := sm.snapshots[name]
+	if !ok {
+		return nil
+	}
+	return proto.Clone(snapshot).(*datapb.SnapshotInfo)
+}
+
+// ListSnapshots returns the snapshots of the collection, or all snapshots if collectionID is zero.
+func (sm *snapshotMeta) ListSnapshots(collectionID int64) []*datapb.SnapshotInfo {
+	sm.RLock()
+	defer sm.RUnlock()
+
+	snapshots := make([]*datapb.SnapshotInfo, 0, len(sm.snapshots))
+	for _, snapshot := range sm.snapshots {
+		if collectionID != 0 && snapshot.GetCollectionID() != collectionID {
+			continue
+		}
+		snapshots = append(snapshots, proto.Clone(snapshot).(*datapb.SnapshotInfo))
+	}
+	return snapshots
+}
+
+// GetSnapshotSegments loads the frozen segments of snapshot from catalog,
+// they are not cached since they are only used by restore.
+func (sm *snapshotMeta) GetSnapshotSegments(snapshotID int64) ([]*datapb.SnapshotSegment, error) {
+	return sm.catalog.ListSnapshotSegments(sm.ctx, snapshotID)
+}
+
+// IsSegmentPinned returns true if the segment is referenced by any snapshot,
+// meta built without snapshotMeta pins nothing.
+func (sm *snapshotMeta) IsSegmentPinned(segmentID int64) bool {
+	if sm == nil {
+		return false
+	}
+	sm.RLock()
+	defer sm.RUnlock()
+	return sm.pinnedSegments[segmentID] > 0
+}
+
+// IsBuildPinned returns true if the index build is referenced by any snapshot.
+func (sm *snapshotMeta) IsBuildPinned(buildID int64) bool {
+	if sm == nil {
+		return false
+	}
+	sm.RLock()
+	defer sm.RUnlock()
+	return sm.pinnedBuilds[buildID] > 0
+}
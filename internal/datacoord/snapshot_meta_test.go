@@ -0,0 +1,122 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datacoord
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/milvus-io/milvus/internal/metastore/mocks"
+	"github.com/milvus-io/milvus/internal/proto/datapb"
+)
+
+type snapshotMetaSuite struct {
+	suite.Suite
+}
+
+func (s *snapshotMetaSuite) TestNewSnapshotMeta() {
+	s.Run("normal case", func() {
+		catalog := mocks.NewDataCoordCatalog(s.T())
+		catalog.EXPECT().ListSnapshots(mock.Anything).Return([]*datapb.SnapshotInfo{
+			{SnapshotID: 1, Name: "s1", CollectionID: 100, SegmentIDs: []int64{1000, 1001}, BuildIDs: []int64{2000}},
+			{SnapshotID: 2, Name: "s2", CollectionID: 100, SegmentIDs: []int64{1001}},
+		}, nil)
+
+		m, err := newSnapshotMeta(context.Background(), catalog)
+		s.NoError(err)
+		s.NotNil(m)
+		s.True(m.IsSegmentPinned(1000))
+		s.True(m.IsSegmentPinned(1001))
+		s.False(m.IsSegmentPinned(1002))
+		s.True(m.IsBuildPinned(2000))
+		s.Equal(2, m.pinnedSegments[1001])
+	})
+
+	s.Run("failed case", func() {
+		catalog := mocks.NewDataCoordCatalog(s.T())
+		catalog.EXPECT().ListSnapshots(mock.Anything).Return(nil, fmt.Errorf("mock error"))
+
+		m, err := newSnapshotMeta(context.Background(), catalog)
+		s.Error(err)
+		s.Nil(m)
+	})
+}
+
+func (s *snapshotMetaSuite) TestAddAndDropSnapshot() {
+	catalog := mocks.NewDataCoordCatalog(s.T())
+	catalog.EXPECT().ListSnapshots(mock.Anything).Return(nil, nil)
+	m, err := newSnapshotMeta(context.Background(), catalog)
+	s.NoError(err)
+
+	s1 := &datapb.SnapshotInfo{SnapshotID: 1, Name: "s1", CollectionID: 100, SegmentIDs: []int64{1000, 1001}, BuildIDs: []int64{2000}}
+	s2 := &datapb.SnapshotInfo{SnapshotID: 2, Name: "s2", CollectionID: 200, SegmentIDs: []int64{1001}}
+
+	s.Run("add failed", func() {
+		catalog.EXPECT().SaveSnapshot(mock.Anything, s1, mock.Anything).Return(fmt.Errorf("mock error")).Once()
+		s.Error(m.AddSnapshot(s1, nil))
+		s.Nil(m.GetSnapshot("s1"))
+		s.False(m.IsSegmentPinned(1000))
+	})
+
+	s.Run("add", func() {
+		catalog.EXPECT().SaveSnapshot(mock.Anything, mock.Anything, mock.Anything).Return(nil).Twice()
+		s.NoError(m.AddSnapshot(s1, nil))
+		s.NoError(m.AddSnapshot(s2, nil))
+
+		// duplicate name
+		s.Error(m.AddSnapshot(&datapb.SnapshotInfo{SnapshotID: 3, Name: "s1"}, nil))
+
+		s.Equal(int64(1), m.GetSnapshot("s1").GetSnapshotID())
+		s.Equal(2, len(m.ListSnapshots(0)))
+		s.Equal(1, len(m.ListSnapshots(100)))
+		s.Equal(0, len(m.ListSnapshots(300)))
+		s.True(m.IsSegmentPinned(1000))
+		s.True(m.IsBuildPinned(2000))
+	})
+
+	s.Run("drop failed", func() {
+		catalog.EXPECT().DropSnapshot(mock.Anything, int64(1)).Return(fmt.Errorf("mock error")).Once()
+		s.Error(m.DropSnapshot("s1"))
+		s.NotNil(m.GetSnapshot("s1"))
+
+		s.Error(m.DropSnapshot("not_exist"))
+	})
+
+	s.Run("drop", func() {
+		catalog.EXPECT().DropSnapshot(mock.Anything, int64(1)).Return(nil).Once()
+		s.NoError(m.DropSnapshot("s1"))
+		s.Nil(m.GetSnapshot("s1"))
+		s.False(m.IsSegmentPinned(1000))
+		s.False(m.IsBuildPinned(2000))
+		// still referenced by s2
+		s.True(m.IsSegmentPinned(1001))
+	})
+}
+
+func (s *snapshotMetaSuite) TestNilSnapshotMeta() {
+	var m *snapshotMeta
+	s.False(m.IsSegmentPinned(1000))
+	s.False(m.IsBuildPinned(2000))
+}
+
+func TestSnapshotMeta(t *testing.T) {
+	suite.Run(t, new(snapshotMetaSuite))
+}
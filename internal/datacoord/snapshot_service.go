@@ -0,0 +1,482 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datacoord
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/samber/lo"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
+	"github.com/milvus-io/milvus-proto/go-api/v2/msgpb"
+	"github.com/milvus-io/milvus/internal/metastore/kv/binlog"
+	"github.com/milvus-io/milvus/internal/metastore/model"
+	"github.com/milvus-io/milvus/internal/proto/datapb"
+	"github.com/milvus-io/milvus/internal/storage"
+	"github.com/milvus-io/milvus/pkg/common"
+	"github.com/milvus-io/milvus/pkg/log"
+	"github.com/milvus-io/milvus/pkg/util/funcutil"
+	"github.com/milvus-io/milvus/pkg/util/merr"
+	"github.com/milvus-io/milvus/pkg/util/metautil"
+	"github.com/milvus-io/milvus/pkg/util/typeutil"
+)
+
+// CreateSnapshot pins the flushed segments of a collection, together with their binlogs and built indexes.
+// The caller must flush the collection first and pass the flush ts, which is used as the snapshot ts.
+func (s *Server) CreateSnapshot(ctx context.Context, req *datapb.CreateSnapshotRequest) (*datapb.CreateSnapshotResponse, error) {
+	log := log.Ctx(ctx).With(zap.String("name", req.GetName()), zap.Int64("collectionID", req.GetCollectionID()))
+	if err := merr.CheckHealthy(s.GetStateCode()); err != nil {
+		return &datapb.CreateSnapshotResponse{
+			Status: merr.Status(err),
+		}, nil
+	}
+
+	if req.GetName() == "" {
+		return &datapb.CreateSnapshotResponse{
+			Status: merr.Status(merr.WrapErrParameterInvalidMsg("snapshot name is empty")),
+		}, nil
+	}
+	if req.GetFlushTs() == 0 {
+		return &datapb.CreateSnapshotResponse{
+			Status: merr.Status(merr.WrapErrParameterInvalidMsg("flush ts of snapshot is not set")),
+		}, nil
+	}
+	if s.meta.snapshotMeta.GetSnapshot(req.GetName()) != nil {
+		return &datapb.CreateSnapshotResponse{
+			Status: merr.Status(merr.WrapErrParameterInvalidMsg("snapshot %s already exists", req.GetName())),
+		}, nil
+	}
+
+	coll, err := s.handler.GetCollection(ctx, req.GetCollectionID())
+	if err != nil {
+		log.Warn("get collection failed", zap.Error(err))
+		return &datapb.CreateSnapshotResponse{
+			Status: merr.Status(err),
+		}, nil
+	}
+	if coll == nil {
+		return &datapb.CreateSnapshotResponse{
+			Status: merr.Status(merr.WrapErrCollectionNotFound(req.GetCollectionID())),
+		}, nil
+	}
+
+	snapshotID, err := s.allocator.AllocID(ctx)
+	if err != nil {
+		log.Warn("alloc snapshot id failed", zap.Error(err))
+		return &datapb.CreateSnapshotResponse{
+			Status: merr.Status(err),
+		}, nil
+	}
+
+	ts := req.GetFlushTs()
+	segments := s.selectSnapshotSegments(ctx, req.GetCollectionID(), req.GetPartitionIDs(), ts)
+
+	snapshot := &datapb.SnapshotInfo{
+		SnapshotID:     snapshotID,
+		Name:           req.GetName(),
+		CollectionID:   req.GetCollectionID(),
+		CollectionName: req.GetCollectionName(),
+		DbName:         coll.DatabaseName,
+		Ts:             ts,
+		CreateTime:     time.Now().Unix(),
+		Schema:         coll.Schema,
+		PartitionIDs:   req.GetPartitionIDs(),
+		PartitionNames: req.GetPartitionNames(),
+		Vchannels:      coll.VChannelNames,
+		Properties:     funcutil.Map2KeyValuePair(coll.Properties),
+	}
+	if len(snapshot.GetPartitionIDs()) == 0 {
+		snapshot.PartitionIDs = coll.Partitions
+	}
+	for _, index := range s.meta.indexMeta.GetIndexesForCollection(req.GetCollectionID(), "") {
+		snapshot.Indexes = append(snapshot.Indexes, model.MarshalIndexModel(index))
+	}
+
+	snapshotSegments := make([]*datapb.SnapshotSegment, 0, len(segments))
+	for _, segment := range segments {
+		snapshotSegment, err := s.freezeSegment(snapshotID, segment)
+		if err != nil {
+			log.Warn("freeze segment failed", zap.Int64("segmentID", segment.GetID()), zap.Error(err))
+			return &datapb.CreateSnapshotResponse{
+				Status: merr.Status(err),
+			}, nil
+		}
+		snapshot.SegmentIDs = append(snapshot.SegmentIDs, segment.GetID())
+		snapshot.NumRows += segment.GetNumOfRows()
+		for _, segIdx := range snapshotSegment.GetSegmentIndexes() {
+			snapshot.BuildIDs = append(snapshot.BuildIDs, segIdx.GetBuildID())
+		}
+		snapshotSegments = append(snapshotSegments, snapshotSegment)
+	}
+
+	if err := s.meta.snapshotMeta.AddSnapshot(snapshot, snapshotSegments); err != nil {
+		return &datapb.CreateSnapshotResponse{
+			Status: merr.Status(err),
+		}, nil
+	}
+	log.Info("create snapshot done", zap.Int64("snapshotID", snapshotID), zap.Uint64("ts", ts),
+		zap.Int("segments", len(snapshotSegments)), zap.Int64("rows", snapshot.GetNumRows()))
+	return &datapb.CreateSnapshotResponse{
+		Status:   merr.Success(),
+		Snapshot: snapshot,
+	}, nil
+}
+
+// selectSnapshotSegments selects the flushed segments of the partitions which start before ts,
+// channel level L0 segments are always selected since they may contain deletions of any partition.
+func (s *Server) selectSnapshotSegments(ctx context.Context, collectionID int64, partitionIDs []int64, ts uint64) []*SegmentInfo {
+	partitions := typeutil.NewSet(partitionIDs...)
+	return s.meta.SelectSegments(ctx, WithCollection(collectionID), SegmentFilterFunc(func(segment *SegmentInfo) bool {
+		if segment.GetState() != commonpb.SegmentState_Flushed || segment.GetIsImporting() {
+			return false
+		}
+		// segments growing after the flush ts contain data newer than the snapshot
+		if segment.GetStartPosition() != nil && segment.GetStartPosition().GetTimestamp() > ts {
+			return false
+		}
+		return partitions.Len() == 0 ||
+			partitions.Contain(segment.GetPartitionID()) ||
+			segment.GetPartitionID() == common.AllPartitionsID
+	}))
+}
+
+// freezeSegment copies the segment meta and the finished segment indexes at snapshot time.
+func (s *Server) freezeSegment(snapshotID int64, segment *SegmentInfo) (*datapb.SnapshotSegment, error) {
+	info := proto.Clone(segment.SegmentInfo).(*datapb.SegmentInfo)
+	if err := binlog.CompressBinLogs(info.GetBinlogs(), info.GetDeltalogs(), info.GetStatslogs(), info.GetBm25Statslogs()); err != nil {
+		return nil, err
+	}
+
+	snapshotSegment := &datapb.SnapshotSegment{
+		SnapshotID: snapshotID,
+		Segment:    info,
+	}
+	for _, segIdx := range s.meta.indexMeta.GetSegmentIndexes(segment.GetCollectionID(), segment.GetID()) {
+		if segIdx.IndexState != commonpb.IndexState_Finished || segIdx.IsDeleted {
+			continue
+		}
+		snapshotSegment.SegmentIndexes = append(snapshotSegment.SegmentIndexes, model.MarshalSegmentIndexModel(segIdx))
+	}
+	return snapshotSegment, nil
+}
+
+func (s *Server) DropSnapshot(ctx context.Context, req *datapb.DropSnapshotRequest) (*commonpb.Status, error) {
+	if err := merr.CheckHealthy(s.GetStateCode()); err != nil {
+		return merr.Status(err), nil
+	}
+
+	if err := s.meta.snapshotMeta.DropSnapshot(req.GetName()); err != nil {
+		log.Ctx(ctx).Warn("drop snapshot failed", zap.String("name", req.GetName()), zap.Error(err))
+		return merr.Status(err), nil
+	}
+	return merr.Success(), nil
+}
+
+func (s *Server) ListSnapshots(ctx context.Context, req *datapb.ListSnapshotsRequest) (*datapb.ListSnapshotsResponse, error) {
+	if err := merr.CheckHealthy(s.GetStateCode()); err != nil {
+		return &datapb.ListSnapshotsResponse{
+			Status: merr.Status(err),
+		}, nil
+	}
+
+	resp := &datapb.ListSnapshotsResponse{
+		Status:    merr.Success(),
+		Snapshots: make([]*datapb.SnapshotInfo, 0),
+	}
+	if req.GetName() != "" {
+		snapshot := s.meta.snapshotMeta.GetSnapshot(req.GetName())
+		if snapshot != nil && (req.GetCollectionID() == 0 || req.GetCollectionID() == snapshot.GetCollectionID()) {
+			resp.Snapshots = append(resp.Snapshots, snapshot)
+		}
+		return resp, nil
+	}
+	resp.Snapshots = s.meta.snapshotMeta.ListSnapshots(req.GetCollectionID())
+	return resp, nil
+}
+
+// RestoreSnapshot restores the snapshot into an empty collection created with the snapshot schema.
+// The binlogs and index files are copied to the target collection, no data is re-imported or re-indexed.
+// If any step fails, the restored segments and indexes are rolled back so that the target collection stays empty.
+func (s *Server) RestoreSnapshot(ctx context.Context, req *datapb.RestoreSnapshotRequest) (*commonpb.Status, error) {
+	log := log.Ctx(ctx).With(zap.String("name", req.GetName()), zap.Int64("targetCollectionID", req.GetTargetCollectionID()))
+	if err := merr.CheckHealthy(s.GetStateCode()); err != nil {
+		return merr.Status(err), nil
+	}
+
+	snapshot := s.meta.snapshotMeta.GetSnapshot(req.GetName())
+	if snapshot == nil {
+		return merr.Status(merr.WrapErrParameterInvalidMsg("snapshot %s not found", req.GetName())), nil
+	}
+	target, err := s.handler.GetCollection(ctx, req.GetTargetCollectionID())
+	if err != nil {
+		log.Warn("get target collection failed", zap.Error(err))
+		return merr.Status(err), nil
+	}
+	if target == nil {
+		return merr.Status(merr.WrapErrCollectionNotFound(req.GetTargetCollectionID())), nil
+	}
+	if len(target.VChannelNames) != len(snapshot.GetVchannels()) {
+		return merr.Status(merr.WrapErrParameterInvalidMsg("target collection has %d shards, but snapshot has %d",
+			len(target.VChannelNames), len(snapshot.GetVchannels()))), nil
+	}
+	if len(s.meta.SelectSegments(ctx, WithCollection(req.GetTargetCollectionID()))) > 0 {
+		return merr.Status(merr.WrapErrParameterInvalidMsg("target collection %d is not empty", req.GetTargetCollectionID())), nil
+	}
+	if len(s.meta.indexMeta.GetIndexesForCollection(req.GetTargetCollectionID(), "")) > 0 {
+		return merr.Status(merr.WrapErrParameterInvalidMsg("target collection %d already has index", req.GetTargetCollectionID())), nil
+	}
+
+	segments, err := s.meta.snapshotMeta.GetSnapshotSegments(snapshot.GetSnapshotID())
+	if err != nil {
+		log.Warn("load snapshot segments failed", zap.Error(err))
+		return merr.Status(err), nil
+	}
+
+	restorer := &snapshotRestorer{
+		s:            s,
+		snapshot:     snapshot,
+		target:       target,
+		partitions:   req.GetPartitionMapping(),
+		indexMapping: make(map[int64]int64),
+	}
+	// create the indexes of snapshot on target collection, source indexID -> target indexID
+	for _, fieldIndex := range snapshot.GetIndexes() {
+		index := model.UnmarshalIndexModel(fieldIndex)
+		if index.IsDeleted {
+			continue
+		}
+		sourceIndexID := index.IndexID
+		indexID, err := s.allocator.AllocID(ctx)
+		if err != nil {
+			restorer.rollback(ctx)
+			return merr.Status(err), nil
+		}
+		index.CollectionID = req.GetTargetCollectionID()
+		index.IndexID = indexID
+		if err := s.meta.indexMeta.CreateIndex(ctx, index); err != nil {
+			log.Warn("create index for target collection failed", zap.String("indexName", index.IndexName), zap.Error(err))
+			restorer.rollback(ctx)
+			return merr.Status(err), nil
+		}
+		restorer.indexMapping[sourceIndexID] = indexID
+	}
+
+	for _, segment := range segments {
+		if err := restorer.restoreSegment(ctx, segment); err != nil {
+			log.Warn("restore segment failed", zap.Int64("segmentID", segment.GetSegment().GetID()), zap.Error(err))
+			restorer.rollback(ctx)
+			return merr.Status(err), nil
+		}
+	}
+	log.Info("restore snapshot done", zap.Int64("snapshotID", snapshot.GetSnapshotID()), zap.Int("segments", len(segments)))
+	return merr.Success(), nil
+}
+
+type snapshotRestorer struct {
+	s            *Server
+	snapshot     *datapb.SnapshotInfo
+	target       *collectionInfo
+	partitions   map[int64]int64
+	indexMapping map[int64]int64
+
+	// restoredSegments are the segments added to target collection
+	restoredSegments []int64
+	// copiedFiles and restoredIndexes belong to the segment being restored, they are reset once the segment is added
+	copiedFiles     []string
+	restoredIndexes []*model.SegmentIndex
+}
+
+// rollback drops the restored segments and indexes, the files of them are recycled by garbage collector.
+// The files and segment indexes of the segment being restored are removed here since the segment is not in meta.
+func (r *snapshotRestorer) rollback(ctx context.Context) {
+	log := log.Ctx(ctx).With(zap.String("snapshot", r.snapshot.GetName()), zap.Int64("targetCollectionID", r.target.ID))
+	if len(r.copiedFiles) > 0 {
+		if err := r.s.meta.chunkManager.MultiRemove(ctx, r.copiedFiles); err != nil {
+			log.Warn("remove copied files failed", zap.Int("files", len(r.copiedFiles)), zap.Error(err))
+		}
+	}
+	for _, segIdx := range r.restoredIndexes {
+		if err := r.s.meta.indexMeta.RemoveSegmentIndex(ctx, segIdx.CollectionID, segIdx.PartitionID, segIdx.SegmentID, segIdx.IndexID, segIdx.BuildID); err != nil {
+			log.Warn("remove restored segment index failed", zap.Int64("buildID", segIdx.BuildID), zap.Error(err))
+		}
+	}
+	for _, segmentID := range r.restoredSegments {
+		if err := r.s.meta.SetState(ctx, segmentID, commonpb.SegmentState_Dropped); err != nil {
+			log.Warn("drop restored segment failed", zap.Int64("segmentID", segmentID), zap.Error(err))
+		}
+	}
+	if len(r.indexMapping) > 0 {
+		indexIDs := lo.Values(r.indexMapping)
+		if err := r.s.meta.indexMeta.MarkIndexAsDeleted(ctx, r.target.ID, indexIDs); err != nil {
+			log.Warn("drop restored indexes failed", zap.Int64s("indexIDs", indexIDs), zap.Error(err))
+		}
+	}
+	log.Info("restore snapshot rolled back", zap.Int("segments", len(r.restoredSegments)))
+}
+
+func (r *snapshotRestorer) targetPartition(partitionID int64) (int64, error) {
+	if partitionID == common.AllPartitionsID {
+		return partitionID, nil
+	}
+	target, ok := r.partitions[partitionID]
+	if !ok {
+		return 0, merr.WrapErrPartitionNotFound(partitionID, "no target partition of snapshot partition")
+	}
+	return target, nil
+}
+
+func (r *snapshotRestorer) targetChannel(channel string) (string, error) {
+	for i, vchannel := range r.snapshot.GetVchannels() {
+		if vchannel == channel {
+			return r.target.VChannelNames[i], nil
+		}
+	}
+	return "", merr.WrapErrChannelNotFound(channel, "channel not in snapshot")
+}
+
+// restoreSegment copies the files of a frozen segment to a new segment of target collection,
+// segment indexes are added before the segment so that no index task is triggered for it.
+func (r *snapshotRestorer) restoreSegment(ctx context.Context, snapshotSegment *datapb.SnapshotSegment) error {
+	source := snapshotSegment.GetSegment()
+	partitionID, err := r.targetPartition(source.GetPartitionID())
+	if err != nil {
+		return err
+	}
+	channel, err := r.targetChannel(source.GetInsertChannel())
+	if err != nil {
+		return err
+	}
+	segmentID, err := r.s.allocator.AllocID(ctx)
+	if err != nil {
+		return err
+	}
+	r.copiedFiles = r.copiedFiles[:0]
+	r.restoredIndexes = r.restoredIndexes[:0]
+
+	restored := proto.Clone(source).(*datapb.SegmentInfo)
+	restored.ID = segmentID
+	restored.CollectionID = r.target.ID
+	restored.PartitionID = partitionID
+	restored.InsertChannel = channel
+	restored.State = commonpb.SegmentState_Flushed
+	restored.CompactionFrom = nil
+	restored.Compacted = false
+	restored.DroppedAt = 0
+	restored.IsImporting = false
+	// text indexes are rebuilt by stats task
+	restored.TextStatsLogs = nil
+	position := &msgpb.MsgPosition{
+		ChannelName: channel,
+		Timestamp:   r.snapshot.GetTs(),
+	}
+	restored.StartPosition = position
+	restored.DmlPosition = position
+
+	copies := []struct {
+		binlogType storage.BinlogType
+		logs       []*datapb.FieldBinlog
+	}{
+		{storage.InsertBinlog, source.GetBinlogs()},
+		{storage.DeleteBinlog, source.GetDeltalogs()},
+		{storage.StatsBinlog, source.GetStatslogs()},
+		{storage.BM25Binlog, source.GetBm25Statslogs()},
+	}
+	rootPath := r.s.meta.chunkManager.RootPath()
+	for _, c := range copies {
+		for _, fieldBinlog := range c.logs {
+			for _, l := range fieldBinlog.GetBinlogs() {
+				src, err := binlog.BuildLogPathWithRootPath(rootPath, c.binlogType, source.GetCollectionID(), source.GetPartitionID(), source.GetID(), fieldBinlog.GetFieldID(), l.GetLogID())
+				if err != nil {
+					return err
+				}
+				dst, err := binlog.BuildLogPathWithRootPath(rootPath, c.binlogType, r.target.ID, partitionID, segmentID, fieldBinlog.GetFieldID(), l.GetLogID())
+				if err != nil {
+					return err
+				}
+				if err := r.copyFile(ctx, src, dst); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	for _, segIdx := range snapshotSegment.GetSegmentIndexes() {
+		indexID, ok := r.indexMapping[segIdx.GetIndexID()]
+		if !ok {
+			continue
+		}
+		buildID, err := r.s.allocator.AllocID(ctx)
+		if err != nil {
+			return err
+		}
+		for _, key := range segIdx.GetIndexFileKeys() {
+			src := metautil.BuildSegmentIndexFilePath(rootPath, segIdx.GetBuildID(), segIdx.GetIndexVersion(), source.GetPartitionID(), source.GetID(), key)
+			dst := metautil.BuildSegmentIndexFilePath(rootPath, buildID, segIdx.GetIndexVersion(), partitionID, segmentID, key)
+			if err := r.copyFile(ctx, src, dst); err != nil {
+				return err
+			}
+		}
+		restoredIdx := model.UnmarshalSegmentIndexModel(segIdx)
+		restoredIdx.CollectionID = r.target.ID
+		restoredIdx.PartitionID = partitionID
+		restoredIdx.SegmentID = segmentID
+		restoredIdx.IndexID = indexID
+		restoredIdx.BuildID = buildID
+		restoredIdx.NodeID = 0
+		if err := r.s.meta.indexMeta.RestoreSegmentIndex(ctx, restoredIdx); err != nil {
+			return err
+		}
+		r.restoredIndexes = append(r.restoredIndexes, restoredIdx)
+	}
+
+	if err := r.s.meta.AddSegment(ctx, NewSegmentInfo(restored)); err != nil {
+		return err
+	}
+	r.restoredSegments = append(r.restoredSegments, segmentID)
+	r.copiedFiles = r.copiedFiles[:0]
+	r.restoredIndexes = r.restoredIndexes[:0]
+	log.Ctx(ctx).Info("restore segment from snapshot done",
+		zap.Int64("sourceSegmentID", source.GetID()),
+		zap.Int64("segmentID", segmentID),
+		zap.Int64("partitionID", partitionID),
+		zap.String("channel", channel),
+		zap.Int64("rows", restored.GetNumOfRows()))
+	return nil
+}
+
+// copyFile streams the content of src to dst, the file is never held in memory as a whole.
+func (r *snapshotRestorer) copyFile(ctx context.Context, src, dst string) error {
+	cm := r.s.meta.chunkManager
+	size, err := cm.Size(ctx, src)
+	if err != nil {
+		return fmt.Errorf("stat %s failed, err=%w", src, err)
+	}
+	reader, err := cm.Reader(ctx, src)
+	if err != nil {
+		return fmt.Errorf("open %s failed, err=%w", src, err)
+	}
+	defer reader.Close()
+	r.copiedFiles = append(r.copiedFiles, dst)
+	if err := cm.WriteFrom(ctx, dst, reader, size); err != nil {
+		return fmt.Errorf("write %s failed, err=%w", dst, err)
+	}
+	return nil
+}
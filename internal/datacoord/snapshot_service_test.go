@@ -0,0 +1,132 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datacoord
+
+import (
+	"context"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
+	"github.com/milvus-io/milvus-proto/go-api/v2/msgpb"
+	"github.com/milvus-io/milvus/internal/metastore/kv/binlog"
+	"github.com/milvus-io/milvus/internal/metastore/model"
+	"github.com/milvus-io/milvus/internal/proto/datapb"
+	"github.com/milvus-io/milvus/internal/storage"
+	"github.com/milvus-io/milvus/pkg/common"
+	"github.com/milvus-io/milvus/pkg/util/merr"
+)
+
+func newSnapshotTestServer(t *testing.T) *Server {
+	meta, err := newMemoryMeta()
+	require.NoError(t, err)
+	meta.chunkManager = storage.NewLocalChunkManager(storage.RootPath(t.TempDir()))
+
+	s := &Server{
+		meta:      meta,
+		handler:   newMockHandlerWithMeta(meta),
+		allocator: newMockAllocator(t),
+	}
+	s.stateCode.Store(commonpb.StateCode_Healthy)
+	return s
+}
+
+func addSnapshotTestSegment(t *testing.T, s *Server, segmentID int64, startTs uint64, fieldIDs []int64, writeFields []int64) {
+	ctx := context.Background()
+	segment := &datapb.SegmentInfo{
+		ID:            segmentID,
+		CollectionID:  1,
+		PartitionID:   10,
+		InsertChannel: "ch-1",
+		State:         commonpb.SegmentState_Flushed,
+		NumOfRows:     100,
+		StartPosition: &msgpb.MsgPosition{ChannelName: "ch-1", Timestamp: startTs},
+	}
+	for _, fieldID := range fieldIDs {
+		segment.Binlogs = append(segment.Binlogs, &datapb.FieldBinlog{
+			FieldID: fieldID,
+			Binlogs: []*datapb.Binlog{{LogID: segmentID*100 + fieldID, EntriesNum: 100}},
+		})
+	}
+	for _, fieldID := range writeFields {
+		filePath, err := binlog.BuildLogPathWithRootPath(s.meta.chunkManager.RootPath(), storage.InsertBinlog, 1, 10, segmentID, fieldID, segmentID*100+fieldID)
+		require.NoError(t, err)
+		require.NoError(t, s.meta.chunkManager.Write(ctx, filePath, []byte("binlog")))
+	}
+	s.meta.segments.SetSegment(segmentID, NewSegmentInfo(segment))
+}
+
+func TestCreateSnapshot(t *testing.T) {
+	ctx := context.Background()
+	s := newSnapshotTestServer(t)
+	s.meta.AddCollection(&collectionInfo{ID: 1, Partitions: []int64{10}, VChannelNames: []string{"ch-1"}})
+	addSnapshotTestSegment(t, s, 100, 50, []int64{common.RowIDField}, nil)
+	addSnapshotTestSegment(t, s, 101, 300, []int64{common.RowIDField}, nil)
+
+	t.Run("no flush ts", func(t *testing.T) {
+		resp, err := s.CreateSnapshot(ctx, &datapb.CreateSnapshotRequest{Name: "s0", CollectionID: 1})
+		assert.NoError(t, err)
+		assert.ErrorIs(t, merr.Error(resp.GetStatus()), merr.ErrParameterInvalid)
+	})
+
+	t.Run("segments after flush ts are excluded", func(t *testing.T) {
+		resp, err := s.CreateSnapshot(ctx, &datapb.CreateSnapshotRequest{Name: "s1", CollectionID: 1, FlushTs: 200})
+		assert.NoError(t, err)
+		assert.NoError(t, merr.Error(resp.GetStatus()))
+		assert.Equal(t, uint64(200), resp.GetSnapshot().GetTs())
+		assert.ElementsMatch(t, []int64{100}, resp.GetSnapshot().GetSegmentIDs())
+	})
+}
+
+func TestRestoreSnapshotRollback(t *testing.T) {
+	ctx := context.Background()
+	s := newSnapshotTestServer(t)
+	s.meta.AddCollection(&collectionInfo{ID: 1, Partitions: []int64{10}, VChannelNames: []string{"ch-1"}})
+	s.meta.AddCollection(&collectionInfo{ID: 2, Partitions: []int64{20}, VChannelNames: []string{"ch-2"}})
+	require.NoError(t, s.meta.indexMeta.CreateIndex(ctx, &model.Index{CollectionID: 1, FieldID: 101, IndexID: 1000, IndexName: "idx"}))
+	addSnapshotTestSegment(t, s, 100, 50, []int64{101}, []int64{101})
+	// the binlog of field 102 is missing, copying fails after the binlog of field 101 is copied
+	addSnapshotTestSegment(t, s, 101, 50, []int64{101, 102}, []int64{101})
+
+	resp, err := s.CreateSnapshot(ctx, &datapb.CreateSnapshotRequest{Name: "s1", CollectionID: 1, FlushTs: 200})
+	require.NoError(t, err)
+	require.NoError(t, merr.Error(resp.GetStatus()))
+
+	status, err := s.RestoreSnapshot(ctx, &datapb.RestoreSnapshotRequest{
+		Name:               "s1",
+		TargetCollectionID: 2,
+		PartitionMapping:   map[int64]int64{10: 20},
+	})
+	assert.NoError(t, err)
+	assert.Error(t, merr.Error(status))
+
+	// restored segments are dropped and the indexes are deleted
+	restored := s.meta.SelectSegments(ctx, WithCollection(2))
+	for _, segment := range restored {
+		assert.Equal(t, commonpb.SegmentState_Dropped, segment.GetState())
+	}
+	assert.Empty(t, s.meta.indexMeta.GetIndexesForCollection(2, ""))
+
+	// only the files of dropped segments are left for garbage collector
+	files, _, err := storage.ListAllChunkWithPrefix(ctx, s.meta.chunkManager,
+		path.Join(s.meta.chunkManager.RootPath(), common.SegmentInsertLogPath, "2"), true)
+	assert.NoError(t, err)
+	assert.Len(t, files, len(restored))
+}
@@ -21,14 +21,17 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/samber/lo"
 	"go.uber.org/zap"
 
+	"github.com/milvus-io/milvus-proto/go-api/v2/schemapb"
 	"github.com/milvus-io/milvus/internal/proto/indexpb"
 	"github.com/milvus-io/milvus/internal/proto/workerpb"
 	"github.com/milvus-io/milvus/internal/types"
 	"github.com/milvus-io/milvus/pkg/log"
 	"github.com/milvus-io/milvus/pkg/util/merr"
 	"github.com/milvus-io/milvus/pkg/util/tsoutil"
+	"github.com/milvus-io/milvus/pkg/util/typeutil"
 )
 
 type statsTask struct {
@@ -179,8 +182,12 @@ func (st *statsTask) PreCheck(ctx context.Context, dependency *taskScheduler) bo
 	}
 
 	binlogNum := (segment.getSegmentSize()/Params.DataNodeCfg.BinLogMaxSize.GetAsInt64() + 1) * int64(len(collInfo.Schema.GetFields())) * 100
-	// binlogNum + BM25logNum + statslogNum
-	start, end, err := dependency.allocator.AllocN(binlogNum + int64(len(collInfo.Schema.GetFunctions())) + 1)
+	fieldStatslogNum := lo.CountBy(collInfo.Schema.GetFields(), func(field *schemapb.FieldSchema) bool {
+		_, ok := typeutil.CreateFieldSchemaHelper(field).GetFieldStatsType()
+		return ok
+	})
+	// binlogNum + BM25logNum + statslogNum + fieldStatslogNum
+	start, end, err := dependency.allocator.AllocN(binlogNum + int64(len(collInfo.Schema.GetFunctions())) + 1 + int64(fieldStatslogNum))
 	if err != nil {
 		log.Warn("stats task alloc logID failed", zap.Int64("collectionID", segment.GetCollectionID()), zap.Error(err))
 		st.SetState(indexpb.JobState_JobStateInit, err.Error())
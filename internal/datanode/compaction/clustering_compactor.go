@@ -124,6 +124,8 @@ type ClusterBuffer struct {
 	flushedBinlogs map[typeutil.UniqueID]map[typeutil.UniqueID]*datapb.FieldBinlog
 	// segID -> fieldID -> binlogs
 	flushedBM25stats map[typeutil.UniqueID]map[int64]*storage.BM25Stats
+	// segID -> fieldID -> field stats of each flushed batch
+	flushedFieldStats map[typeutil.UniqueID]map[int64][]*storage.FieldStats
 
 	uploadedSegments     []*datapb.CompactionSegment
 	uploadedSegmentStats map[typeutil.UniqueID]storage.SegmentStats
@@ -322,6 +324,7 @@ func (t *clusteringCompactionTask) getScalarAnalyzeResult(ctx context.Context) e
 			id:                      id,
 			flushedRowNum:           map[typeutil.UniqueID]atomic.Int64{},
 			flushedBinlogs:          make(map[typeutil.UniqueID]map[typeutil.UniqueID]*datapb.FieldBinlog, 0),
+			flushedFieldStats:       make(map[typeutil.UniqueID]map[int64][]*storage.FieldStats, 0),
 			flushedBM25stats:        make(map[int64]map[int64]*storage.BM25Stats, 0),
 			uploadedSegments:        make([]*datapb.CompactionSegment, 0),
 			uploadedSegmentStats:    make(map[typeutil.UniqueID]storage.SegmentStats, 0),
@@ -345,6 +348,7 @@ func (t *clusteringCompactionTask) getScalarAnalyzeResult(ctx context.Context) e
 			id:                      len(buckets),
 			flushedRowNum:           map[typeutil.UniqueID]atomic.Int64{},
 			flushedBinlogs:          make(map[typeutil.UniqueID]map[typeutil.UniqueID]*datapb.FieldBinlog, 0),
+			flushedFieldStats:       make(map[typeutil.UniqueID]map[int64][]*storage.FieldStats, 0),
 			uploadedSegments:        make([]*datapb.CompactionSegment, 0),
 			uploadedSegmentStats:    make(map[typeutil.UniqueID]storage.SegmentStats, 0),
 			clusteringKeyFieldStats: fieldStats, // null stats
@@ -404,6 +408,7 @@ func (t *clusteringCompactionTask) generatedVectorPlan(bufferNum int, centroids
 			id:                      id,
 			flushedRowNum:           map[typeutil.UniqueID]atomic.Int64{},
 			flushedBinlogs:          make(map[typeutil.UniqueID]map[typeutil.UniqueID]*datapb.FieldBinlog, 0),
+			flushedFieldStats:       make(map[typeutil.UniqueID]map[int64][]*storage.FieldStats, 0),
 			uploadedSegments:        make([]*datapb.CompactionSegment, 0),
 			uploadedSegmentStats:    make(map[typeutil.UniqueID]storage.SegmentStats, 0),
 			clusteringKeyFieldStats: fieldStats,
@@ -968,6 +973,14 @@ func (t *clusteringCompactionTask) packBufferToSegment(ctx context.Context, buff
 		seg.Bm25Logs = bm25Logs
 	}
 
+	if len(buffer.flushedFieldStats[segmentID]) > 0 {
+		fieldStatsLogs, err := t.generateFieldStats(ctx, segmentID, numRows.Load(), buffer.flushedFieldStats[segmentID])
+		if err != nil {
+			return err
+		}
+		seg.FieldStatslogs = fieldStatsLogs
+	}
+
 	buffer.uploadedSegments = append(buffer.uploadedSegments, seg)
 	segmentStats := storage.SegmentStats{
 		FieldStats: []storage.FieldStats{buffer.clusteringKeyFieldStats.Clone()},
@@ -994,6 +1007,7 @@ func (t *clusteringCompactionTask) packBufferToSegment(ctx context.Context, buff
 	if len(t.bm25FieldIds) > 0 {
 		delete(buffer.flushedBM25stats, segmentID)
 	}
+	delete(buffer.flushedFieldStats, segmentID)
 	return nil
 }
 
@@ -1063,6 +1077,16 @@ func (t *clusteringCompactionTask) flushBinlog(ctx context.Context, buffer *Clus
 		}
 	}
 
+	// cache the field stats of the batch, the stats of a segment are a list of batch stats
+	for fieldID, stats := range writer.GetFieldStats() {
+		statsMap, ok := buffer.flushedFieldStats[segmentID]
+		if !ok {
+			statsMap = make(map[int64][]*storage.FieldStats)
+			buffer.flushedFieldStats[segmentID] = statsMap
+		}
+		statsMap[fieldID] = append(statsMap[fieldID], stats)
+	}
+
 	for fID, path := range partialBinlogs {
 		tmpBinlog, ok := buffer.flushedBinlogs[segmentID][fID]
 		if !ok {
@@ -1428,10 +1452,52 @@ func (t *clusteringCompactionTask) generateBM25Stats(ctx context.Context, segmen
 	return binlogs, nil
 }
 
+func (t *clusteringCompactionTask) generateFieldStats(ctx context.Context, segmentID int64, numRows int64, statsMap map[int64][]*storage.FieldStats) ([]*datapb.FieldBinlog, error) {
+	binlogs := []*datapb.FieldBinlog{}
+	kvs := map[string][]byte{}
+	logID, _, err := t.logIDAlloc.Alloc(uint32(len(statsMap)))
+	if err != nil {
+		return nil, err
+	}
+
+	for fieldID, stats := range statsMap {
+		key, _ := binlog.BuildLogPath(storage.FieldStatsBinlog, t.collectionID, t.partitionID, segmentID, fieldID, logID)
+		writer := &storage.FieldStatsWriter{}
+		if err := writer.GenerateList(stats); err != nil {
+			log.Warn("failed to seralize field stats", zap.Int64("collection", t.collectionID),
+				zap.Int64("partition", t.partitionID), zap.Int64("segment", segmentID), zap.Error(err))
+			return nil, err
+		}
+		bytes := writer.GetBuffer()
+		kvs[key] = bytes
+
+		binlogs = append(binlogs, &datapb.FieldBinlog{
+			FieldID: fieldID,
+			Binlogs: []*datapb.Binlog{{
+				LogSize:    int64(len(bytes)),
+				MemorySize: int64(len(bytes)),
+				LogPath:    key,
+				EntriesNum: numRows,
+			}},
+		})
+		logID++
+	}
+
+	if err := t.binlogIO.Upload(ctx, kvs); err != nil {
+		log.Warn("failed to upload field stats log",
+			zap.Int64("collection", t.collectionID),
+			zap.Int64("partition", t.partitionID),
+			zap.Int64("segment", segmentID),
+			zap.Error(err))
+		return nil, err
+	}
+	return binlogs, nil
+}
+
 func (t *clusteringCompactionTask) generatePkStats(ctx context.Context, segmentID int64,
 	numRows int64, binlogPaths [][]string,
 ) (*datapb.FieldBinlog, error) {
-	stats, err := storage.NewPrimaryKeyStats(t.primaryKeyField.GetFieldID(), int64(t.primaryKeyField.GetDataType()), numRows)
+	stats, err := storage.NewSealedPrimaryKeyStats(t.primaryKeyField.GetFieldID(), int64(t.primaryKeyField.GetDataType()), numRows)
 	if err != nil {
 		return nil, err
 	}
@@ -49,6 +49,18 @@ func isExpiredEntity(ttl int64, now, ts typeutil.Timestamp) bool {
 	return expireTime.Before(pnow)
 }
 
+// isRowExpired returns whether the row is expired at now, by the expiration time
+// in unix seconds it holds in the ttl field.
+func isRowExpired(expireAt int64, now typeutil.Timestamp) bool {
+	// the row never expires if expireAt <= 0
+	if expireAt <= 0 {
+		return false
+	}
+
+	pnow, _ := tsoutil.ParseTS(now)
+	return expireAt <= pnow.Unix()
+}
+
 func mergeDeltalogs(ctx context.Context, io io.BinlogIO, paths []string) (map[interface{}]typeutil.Timestamp, error) {
 	pk2ts := make(map[interface{}]typeutil.Timestamp)
 
@@ -148,6 +160,7 @@ func serializeWrite(ctx context.Context, allocator allocator.Interface, writer *
 	_, span := otel.Tracer(typeutil.DataNodeRole).Start(ctx, "serializeWrite")
 	defer span.End()
 
+	ttlFieldID, expireTo := writer.GetExpireTo()
 	blobs, tr, err := writer.SerializeYield()
 	startID, _, err := allocator.Alloc(uint32(len(blobs)))
 	if err != nil {
@@ -175,6 +188,9 @@ func serializeWrite(ctx context.Context, allocator allocator.Interface, writer *
 				},
 			},
 		}
+		if fID == ttlFieldID {
+			fieldBinlogs[fID].Binlogs[0].ExpireTo = expireTo
+		}
 	}
 
 	return
@@ -270,3 +286,47 @@ func bm25SerializeWrite(ctx context.Context, io io.BinlogIO, allocator allocator
 
 	return binlogs, nil
 }
+
+func fieldStatsSerializeWrite(ctx context.Context, io io.BinlogIO, allocator allocator.Interface, writer *SegmentWriter) ([]*datapb.FieldBinlog, error) {
+	ctx, span := otel.Tracer(typeutil.DataNodeRole).Start(ctx, "field stats log serializeWrite")
+	defer span.End()
+
+	if len(writer.GetFieldStats()) == 0 || writer.GetRowNum() == 0 {
+		return nil, nil
+	}
+	stats, err := writer.GetFieldStatsBlob()
+	if err != nil {
+		return nil, err
+	}
+
+	logID, _, err := allocator.Alloc(uint32(len(stats)))
+	if err != nil {
+		return nil, err
+	}
+
+	kvs := make(map[string][]byte)
+	binlogs := []*datapb.FieldBinlog{}
+	for fieldID, blob := range stats {
+		key, _ := binlog.BuildLogPath(storage.FieldStatsBinlog, writer.GetCollectionID(), writer.GetPartitionID(), writer.GetSegmentID(), fieldID, logID)
+		kvs[key] = blob.GetValue()
+		binlogs = append(binlogs, &datapb.FieldBinlog{
+			FieldID: fieldID,
+			Binlogs: []*datapb.Binlog{
+				{
+					LogSize:    int64(len(blob.GetValue())),
+					MemorySize: blob.MemorySize,
+					LogPath:    key,
+					EntriesNum: writer.GetRowNum(),
+				},
+			},
+		})
+		logID++
+	}
+
+	if err := io.Upload(ctx, kvs); err != nil {
+		log.Warn("failed to upload field stats log", zap.Error(err))
+		return nil, err
+	}
+
+	return binlogs, nil
+}
@@ -52,6 +52,11 @@ func mergeSortMultipleSegments(ctx context.Context,
 		return nil, err
 	}
 
+	var ttlFieldID int64 = -1
+	if ttlField := typeutil.GetTTLField(plan.GetSchema()); ttlField != nil {
+		ttlFieldID = ttlField.GetFieldID()
+	}
+
 	// SegmentDeserializeReaderTest(binlogPaths, t.binlogIO, writer.GetPkID())
 	segmentReaders := make([]*SegmentDeserializeReader, len(binlogs))
 	segmentDelta := make([]map[interface{}]storage.Timestamp, len(binlogs))
@@ -111,6 +116,13 @@ func mergeSortMultipleSegments(ctx context.Context,
 				expiredRowCount++
 				continue
 			}
+			if ttlFieldID != -1 {
+				row := v.Value.(map[typeutil.UniqueID]interface{})
+				if expireAt, ok := row[ttlFieldID].(int64); ok && isRowExpired(expireAt, currentTs) {
+					expiredRowCount++
+					continue
+				}
+			}
 			return v, nil
 		}
 	}
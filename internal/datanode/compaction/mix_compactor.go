@@ -202,7 +202,7 @@ func (t *mixCompactionTask) writeSegment(ctx context.Context,
 		return
 	}
 
-	isValueDeleted := func(pk any, ts typeutil.Timestamp) bool {
+	isValueDeleted := func(pk any, ts typeutil.Timestamp, expireAt int64) bool {
 		oldts, ok := delta[pk]
 		// insert task and delete task has the same ts when upsert
 		// here should be < instead of <=
@@ -212,7 +212,8 @@ func (t *mixCompactionTask) writeSegment(ctx context.Context,
 			return true
 		}
 		// Filtering expired entity
-		if isExpiredEntity(t.plan.GetCollectionTtl(), t.currentTs, typeutil.Timestamp(ts)) {
+		if isExpiredEntity(t.plan.GetCollectionTtl(), t.currentTs, typeutil.Timestamp(ts)) ||
+			isRowExpired(expireAt, t.currentTs) {
 			expiredRowCount++
 			return true
 		}
@@ -226,6 +227,8 @@ func (t *mixCompactionTask) writeSegment(ctx context.Context,
 	}
 	defer reader.Close()
 
+	ttlField := typeutil.GetTTLField(t.plan.GetSchema())
+
 	writeSlice := func(r storage.Record, start, end int) error {
 		sliced := r.Slice(start, end)
 		defer sliced.Release()
@@ -250,6 +253,10 @@ func (t *mixCompactionTask) writeSegment(ctx context.Context,
 		r := reader.Record()
 		pkArray := r.Column(pkField.FieldID)
 		tsArray := r.Column(common.TimeStampField).(*array.Int64)
+		var ttlArray *array.Int64
+		if ttlField != nil {
+			ttlArray = r.Column(ttlField.GetFieldID()).(*array.Int64)
+		}
 
 		sliceStart := -1
 		rows := r.Len()
@@ -265,7 +272,11 @@ func (t *mixCompactionTask) writeSegment(ctx context.Context,
 				panic("invalid data type")
 			}
 			ts := typeutil.Timestamp(tsArray.Value(i))
-			if isValueDeleted(pk, ts) {
+			var expireAt int64
+			if ttlArray != nil {
+				expireAt = ttlArray.Value(i)
+			}
+			if isValueDeleted(pk, ts, expireAt) {
 				if sliceStart != -1 {
 					err = writeSlice(r, sliceStart, i)
 					if err != nil {
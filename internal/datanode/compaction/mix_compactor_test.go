@@ -368,6 +368,56 @@ func (s *MixCompactionTaskSuite) TestSplitMergeEntityExpired() {
 	s.Empty(compactionSegments[0].GetField2StatslogPaths())
 }
 
+func (s *MixCompactionTaskSuite) TestSplitMergeRowExpired() {
+	schema := typeutil.Clone(s.meta.GetSchema())
+	schema.Fields = append(schema.Fields, &schemapb.FieldSchema{
+		FieldID:    ExpireAtField,
+		Name:       "field_expire_at",
+		DataType:   schemapb.DataType_Int64,
+		TypeParams: []*commonpb.KeyValuePair{{Key: common.TTLFieldKey, Value: "true"}},
+	})
+	s.task.plan.Schema = schema
+
+	now := getMilvusBirthday().Add(time.Hour)
+	s.task.currentTs = tsoutil.ComposeTSByTime(now, 0)
+	segWriter, err := NewSegmentWriter(schema, 100, compactionBatchSize, 1, PartitionID, CollectionID, []int64{})
+	s.Require().NoError(err)
+	// rows never expiring or expiring after now are kept
+	for i, expireAt := range []int64{0, now.Unix() - 1, now.Unix(), now.Unix() + 1} {
+		row := getRow(int64(i))
+		row[ExpireAtField] = expireAt
+		err = segWriter.Write(&storage.Value{
+			PK:        storage.NewInt64PrimaryKey(int64(i)),
+			Timestamp: int64(tsoutil.ComposeTSByTime(getMilvusBirthday(), 0)),
+			Value:     row,
+		})
+		s.Require().NoError(err)
+	}
+	segWriter.FlushAndIsFull()
+
+	alloc := allocator.NewLocalAllocator(888888, math.MaxInt64)
+	kvs, fieldBinlogs, err := serializeWrite(context.TODO(), alloc, segWriter)
+	s.Require().NoError(err)
+	// the row of expireAt 0 never expires
+	s.EqualValues(math.MaxInt64, fieldBinlogs[ExpireAtField].GetBinlogs()[0].GetExpireTo())
+	s.EqualValues(0, fieldBinlogs[Int64Field].GetBinlogs()[0].GetExpireTo())
+	s.mockBinlogIO.EXPECT().Download(mock.Anything, mock.Anything).RunAndReturn(
+		func(ctx context.Context, paths []string) ([][]byte, error) {
+			s.Require().Equal(len(paths), len(kvs))
+			return lo.Values(kvs), nil
+		})
+	s.mockBinlogIO.EXPECT().Upload(mock.Anything, mock.Anything).Return(nil).Maybe()
+
+	s.task.collectionID = CollectionID
+	s.task.partitionID = PartitionID
+	s.task.maxRows = 1000
+
+	compactionSegments, err := s.task.mergeSplit(s.task.ctx, map[int64][]string{segWriter.segmentID: lo.Keys(kvs)}, nil)
+	s.NoError(err)
+	s.Equal(1, len(compactionSegments))
+	s.EqualValues(2, compactionSegments[0].GetNumOfRows())
+}
+
 func (s *MixCompactionTaskSuite) TestMergeNoExpiration() {
 	s.initSegBuffer(1, 4)
 	deleteTs := tsoutil.ComposeTSByTime(getMilvusBirthday().Add(10*time.Second), 0)
@@ -624,6 +674,17 @@ func (s *MixCompactionTaskSuite) TestIsExpiredEntity() {
 	}
 }
 
+func (s *MixCompactionTaskSuite) TestIsRowExpired() {
+	now := tsoutil.ComposeTSByTime(getMilvusBirthday(), 0)
+	birthday := getMilvusBirthday().Unix()
+
+	s.False(isRowExpired(0, now))
+	s.False(isRowExpired(-1, now))
+	s.False(isRowExpired(birthday+1, now))
+	s.True(isRowExpired(birthday, now))
+	s.True(isRowExpired(birthday-1, now))
+}
+
 func getRow(magic int64) map[int64]interface{} {
 	ts := tsoutil.ComposeTSByTime(getMilvusBirthday(), 0)
 	return map[int64]interface{}{
@@ -725,6 +786,7 @@ const (
 	BFloat16VectorField    = 113
 	SparseFloatVectorField = 114
 	VarCharField           = 115
+	ExpireAtField          = 116
 )
 
 func getInt64DeltaBlobs(segID int64, pks []int64, tss []uint64) (*storage.Blob, error) {
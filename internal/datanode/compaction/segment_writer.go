@@ -150,6 +150,13 @@ func (w *MultiSegmentWriter) finishCurrent() error {
 		result.Bm25Logs = bmBinlogs
 	}
 
+	fieldStatsBinlogs, err := fieldStatsSerializeWrite(context.TODO(), w.binlogIO, w.allocator.getLogIDAllocator(), writer)
+	if err != nil {
+		log.Warn("compact wrong, failed to serialize write segment field stats", zap.Error(err))
+		return err
+	}
+	result.FieldStatslogs = fieldStatsBinlogs
+
 	w.res = append(w.res, result)
 
 	log.Info("Segment writer flushed a segment",
@@ -353,8 +360,14 @@ type SegmentWriter struct {
 	tsFrom  typeutil.Timestamp
 	tsTo    typeutil.Timestamp
 
+	// ttlFieldID is -1 if the collection has no ttl field
+	ttlFieldID int64
+	expireTo   int64
+
 	pkstats   *storage.PrimaryKeyStats
 	bm25Stats map[int64]*storage.BM25Stats
+	// fieldStats are the stats of the fields with field stats enabled
+	fieldStats map[int64]*storage.FieldStats
 
 	segmentID    int64
 	partitionID  int64
@@ -393,6 +406,10 @@ func (w *SegmentWriter) WrittenMemorySize() uint64 {
 
 func (w *SegmentWriter) WriteRecord(r storage.Record) error {
 	tsArray := r.Column(common.TimeStampField).(*array.Int64)
+	var ttlArray *array.Int64
+	if w.ttlFieldID != -1 {
+		ttlArray = r.Column(w.ttlFieldID).(*array.Int64)
+	}
 	rows := r.Len()
 	for i := 0; i < rows; i++ {
 		ts := typeutil.Timestamp(tsArray.Value(i))
@@ -402,6 +419,9 @@ func (w *SegmentWriter) WriteRecord(r storage.Record) error {
 		if ts > w.tsTo {
 			w.tsTo = ts
 		}
+		if ttlArray != nil {
+			w.updateExpireTo(ttlArray.Value(i))
+		}
 
 		switch schemapb.DataType(w.pkstats.PkType) {
 		case schemapb.DataType_Int64:
@@ -428,6 +448,10 @@ func (w *SegmentWriter) WriteRecord(r storage.Record) error {
 			stats.AppendBytes(field.Value(i))
 		}
 
+		for fieldID, stats := range w.fieldStats {
+			stats.UpdateByArray(r.Column(fieldID), i)
+		}
+
 		w.rowCount.Inc()
 	}
 	return w.writer.WriteRecord(r)
@@ -441,6 +465,11 @@ func (w *SegmentWriter) Write(v *storage.Value) error {
 	if ts > w.tsTo {
 		w.tsTo = ts
 	}
+	if w.ttlFieldID != -1 {
+		if expireAt, ok := v.Value.(map[storage.FieldID]interface{})[w.ttlFieldID].(int64); ok {
+			w.updateExpireTo(expireAt)
+		}
+	}
 
 	w.pkstats.Update(v.PK)
 	for fieldID, stats := range w.bm25Stats {
@@ -455,11 +484,28 @@ func (w *SegmentWriter) Write(v *storage.Value) error {
 		}
 		stats.AppendBytes(bytes)
 	}
+	for fieldID, stats := range w.fieldStats {
+		stats.UpdateByValue(v.Value.(map[storage.FieldID]interface{})[fieldID])
+	}
 
 	w.rowCount.Inc()
 	return w.writer.Write(v)
 }
 
+func (w *SegmentWriter) updateExpireTo(expireAt int64) {
+	// the row never expires
+	if expireAt <= 0 {
+		expireAt = math.MaxInt64
+	}
+	w.expireTo = max(w.expireTo, expireAt)
+}
+
+// GetExpireTo returns the ttl field and the max expiration time of the rows written since last yield,
+// the field is -1 if the collection has no ttl field.
+func (w *SegmentWriter) GetExpireTo() (int64, int64) {
+	return w.ttlFieldID, w.expireTo
+}
+
 func (w *SegmentWriter) Finish() (*storage.Blob, error) {
 	w.writer.Flush()
 	codec := storage.NewInsertCodecWithSchema(&etcdpb.CollectionMeta{ID: w.collectionID, Schema: w.sch})
@@ -488,6 +534,28 @@ func (w *SegmentWriter) GetBm25StatsBlob() (map[int64]*storage.Blob, error) {
 	return result, nil
 }
 
+func (w *SegmentWriter) GetFieldStats() map[int64]*storage.FieldStats {
+	return w.fieldStats
+}
+
+// GetFieldStatsBlob serializes the stats of the fields with field stats enabled, one blob per field.
+func (w *SegmentWriter) GetFieldStatsBlob() (map[int64]*storage.Blob, error) {
+	result := make(map[int64]*storage.Blob)
+	for fieldID, stats := range w.fieldStats {
+		writer := &storage.FieldStatsWriter{}
+		if err := writer.GenerateList([]*storage.FieldStats{stats}); err != nil {
+			return nil, err
+		}
+		result[fieldID] = &storage.Blob{
+			Key:        fmt.Sprintf("%d", fieldID),
+			Value:      writer.GetBuffer(),
+			RowNum:     w.GetRowNum(),
+			MemorySize: int64(len(writer.GetBuffer())),
+		}
+	}
+	return result, nil
+}
+
 func (w *SegmentWriter) IsFull() bool {
 	return w.writer.WrittenMemorySize() > w.maxBinlogSize
 }
@@ -545,6 +613,7 @@ func (w *SegmentWriter) clear() {
 	w.closers = closers
 	w.tsFrom = math.MaxUint64
 	w.tsTo = 0
+	w.expireTo = 0
 }
 
 func NewSegmentWriter(sch *schemapb.CollectionSchema, maxCount int64, batchSize int, segID, partID, collID int64, Bm25Fields []int64) (*SegmentWriter, error) {
@@ -559,7 +628,7 @@ func NewSegmentWriter(sch *schemapb.CollectionSchema, maxCount int64, batchSize
 		return nil, err
 	}
 
-	stats, err := storage.NewPrimaryKeyStats(pkField.GetFieldID(), int64(pkField.GetDataType()), maxCount)
+	stats, err := storage.NewSealedPrimaryKeyStats(pkField.GetFieldID(), int64(pkField.GetDataType()), maxCount)
 	if err != nil {
 		return nil, err
 	}
@@ -570,8 +639,11 @@ func NewSegmentWriter(sch *schemapb.CollectionSchema, maxCount int64, batchSize
 		tsFrom:  math.MaxUint64,
 		tsTo:    0,
 
+		ttlFieldID: -1,
+
 		pkstats:      stats,
 		bm25Stats:    make(map[int64]*storage.BM25Stats),
+		fieldStats:   make(map[int64]*storage.FieldStats),
 		sch:          sch,
 		segmentID:    segID,
 		partitionID:  partID,
@@ -586,6 +658,14 @@ func NewSegmentWriter(sch *schemapb.CollectionSchema, maxCount int64, batchSize
 	for _, fieldID := range Bm25Fields {
 		segWriter.bm25Stats[fieldID] = storage.NewBM25Stats()
 	}
+	for _, field := range sch.GetFields() {
+		if stats, ok := storage.NewFieldStatsOfField(field, maxCount); ok {
+			segWriter.fieldStats[field.GetFieldID()] = stats
+		}
+	}
+	if ttlField := typeutil.GetTTLField(sch); ttlField != nil {
+		segWriter.ttlFieldID = ttlField.GetFieldID()
+	}
 	return &segWriter, nil
 }
 
@@ -17,11 +17,14 @@
 package compaction
 
 import (
+	"fmt"
 	"testing"
 
 	"github.com/stretchr/testify/suite"
 
+	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
 	"github.com/milvus-io/milvus/internal/storage"
+	"github.com/milvus-io/milvus/pkg/common"
 	"github.com/milvus-io/milvus/pkg/util/tsoutil"
 )
 
@@ -71,3 +74,37 @@ func (s *SegmentWriteSuite) TestWriteFailed() {
 		s.Error(err)
 	})
 }
+
+func (s *SegmentWriteSuite) TestFieldStats() {
+	schema := genCollectionSchemaWithBM25()
+	for _, field := range schema.GetFields() {
+		if field.GetFieldID() == 101 {
+			field.TypeParams = append(field.TypeParams, &commonpb.KeyValuePair{Key: common.FieldStatsKey, Value: common.FieldStatsBloom})
+		}
+	}
+	writer, err := NewSegmentWriter(schema, 1024, compactionBatchSize, 1, s.parititonID, s.collectionID, nil)
+	s.Require().NoError(err)
+
+	for i := int64(0); i < 2; i++ {
+		row := genRowWithBM25(i)
+		row[101] = fmt.Sprintf("text-%d", i)
+		err = writer.Write(&storage.Value{
+			PK:        storage.NewInt64PrimaryKey(i),
+			Timestamp: int64(tsoutil.ComposeTSByTime(getMilvusBirthday(), 0)),
+			Value:     row,
+		})
+		s.Require().NoError(err)
+	}
+
+	blobs, err := writer.GetFieldStatsBlob()
+	s.Require().NoError(err)
+	s.Require().Len(blobs, 1)
+	s.EqualValues(2, blobs[101].RowNum)
+
+	stats, err := storage.DeserializeFieldStats(blobs[101])
+	s.Require().NoError(err)
+	s.Require().Len(stats, 1)
+	s.Equal("text-0", stats[0].Min.GetValue())
+	s.Equal("text-1", stats[0].Max.GetValue())
+	s.True(stats[0].BF.TestString("text-1"))
+}
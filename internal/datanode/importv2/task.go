@@ -17,6 +17,8 @@
 package importv2
 
 import (
+	"slices"
+
 	"github.com/samber/lo"
 	"go.uber.org/zap"
 
@@ -32,6 +34,7 @@ const (
 	ImportTaskType      TaskType = 1
 	L0PreImportTaskType TaskType = 2
 	L0ImportTaskType    TaskType = 3
+	ExportTaskType      TaskType = 4
 )
 
 var ImportTaskTypeName = map[TaskType]string{
@@ -39,6 +42,7 @@ var ImportTaskTypeName = map[TaskType]string{
 	1: "ImportTask",
 	2: "L0PreImportTaskType",
 	3: "L0ImportTaskType",
+	4: "ExportTask",
 }
 
 func (t TaskType) String() string {
@@ -77,6 +81,8 @@ func UpdateState(state datapb.ImportTaskStateV2) UpdateAction {
 			t.(*L0PreImportTask).PreImportTask.State = state
 		case L0ImportTaskType:
 			t.(*L0ImportTask).ImportTaskV2.State = state
+		case ExportTaskType:
+			t.(*ExportTask).ImportTaskV2.State = state
 		}
 	}
 }
@@ -92,6 +98,8 @@ func UpdateReason(reason string) UpdateAction {
 			t.(*L0PreImportTask).PreImportTask.Reason = reason
 		case L0ImportTaskType:
 			t.(*L0ImportTask).ImportTaskV2.Reason = reason
+		case ExportTaskType:
+			t.(*ExportTask).ImportTaskV2.Reason = reason
 		}
 	}
 }
@@ -143,6 +151,7 @@ func UpdateSegmentInfo(info *datapb.ImportSegmentInfo) UpdateAction {
 				segmentsInfo[segment].Binlogs = mergeFn(segmentsInfo[segment].Binlogs, info.GetBinlogs())
 				segmentsInfo[segment].Statslogs = mergeFn(segmentsInfo[segment].Statslogs, info.GetStatslogs())
 				segmentsInfo[segment].Deltalogs = mergeFn(segmentsInfo[segment].Deltalogs, info.GetDeltalogs())
+				segmentsInfo[segment].FieldStatslogs = mergeFn(segmentsInfo[segment].FieldStatslogs, info.GetFieldStatslogs())
 				return
 			}
 			segmentsInfo[segment] = info
@@ -150,6 +159,16 @@ func UpdateSegmentInfo(info *datapb.ImportSegmentInfo) UpdateAction {
 	}
 }
 
+func UpdateExportProgress(processedRows, exportedRows int64, files []string) UpdateAction {
+	return func(task Task) {
+		if t, ok := task.(*ExportTask); ok {
+			t.processedRows = processedRows
+			t.exportedRows = exportedRows
+			t.files = slices.Clone(files)
+		}
+	}
+}
+
 type Task interface {
 	Execute() []*conc.Future[any]
 	GetJobID() int64
@@ -0,0 +1,400 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package importv2
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"path"
+	"slices"
+	"time"
+
+	"github.com/samber/lo"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/schemapb"
+	"github.com/milvus-io/milvus/internal/metastore/kv/binlog"
+	"github.com/milvus-io/milvus/internal/parser/planparserv2"
+	"github.com/milvus-io/milvus/internal/proto/datapb"
+	"github.com/milvus-io/milvus/internal/proto/planpb"
+	"github.com/milvus-io/milvus/internal/storage"
+	"github.com/milvus-io/milvus/internal/util/exprutil"
+	"github.com/milvus-io/milvus/internal/util/importutilv2/parquet"
+	"github.com/milvus-io/milvus/pkg/log"
+	"github.com/milvus-io/milvus/pkg/util/conc"
+	"github.com/milvus-io/milvus/pkg/util/merr"
+	"github.com/milvus-io/milvus/pkg/util/paramtable"
+	"github.com/milvus-io/milvus/pkg/util/typeutil"
+)
+
+// ExportTask dumps the rows of flushed segments visible at req.Ts into parquet files.
+type ExportTask struct {
+	*datapb.ImportTaskV2
+	ctx    context.Context
+	cancel context.CancelFunc
+	req    *datapb.ExportTaskRequest
+
+	processedRows int64
+	exportedRows  int64
+	files         []string
+
+	manager TaskManager
+	cm      storage.ChunkManager
+}
+
+func NewExportTask(req *datapb.ExportTaskRequest,
+	manager TaskManager,
+	cm storage.ChunkManager,
+) Task {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &ExportTask{
+		ImportTaskV2: &datapb.ImportTaskV2{
+			JobID:        req.GetJobID(),
+			TaskID:       req.GetTaskID(),
+			CollectionID: req.GetCollectionID(),
+			State:        datapb.ImportTaskStateV2_Pending,
+		},
+		ctx:     ctx,
+		cancel:  cancel,
+		req:     req,
+		manager: manager,
+		cm:      cm,
+	}
+}
+
+func (t *ExportTask) GetType() TaskType {
+	return ExportTaskType
+}
+
+func (t *ExportTask) GetPartitionIDs() []int64 {
+	return nil
+}
+
+func (t *ExportTask) GetVchannels() []string {
+	return nil
+}
+
+func (t *ExportTask) GetSchema() *schemapb.CollectionSchema {
+	return t.req.GetSchema()
+}
+
+func (t *ExportTask) GetSlots() int64 {
+	return 1
+}
+
+func (t *ExportTask) Cancel() {
+	t.cancel()
+}
+
+func (t *ExportTask) GetProcessedRows() int64 {
+	return t.processedRows
+}
+
+func (t *ExportTask) GetExportedRows() int64 {
+	return t.exportedRows
+}
+
+func (t *ExportTask) GetFiles() []string {
+	return t.files
+}
+
+func (t *ExportTask) Clone() Task {
+	// The clones share the context, so that removing the task from
+	// the manager stops the running execution.
+	return &ExportTask{
+		ImportTaskV2:  typeutil.Clone(t.ImportTaskV2),
+		ctx:           t.ctx,
+		cancel:        t.cancel,
+		req:           t.req,
+		processedRows: t.processedRows,
+		exportedRows:  t.exportedRows,
+		files:         slices.Clone(t.files),
+		manager:       t.manager,
+		cm:            t.cm,
+	}
+}
+
+func (t *ExportTask) Execute() []*conc.Future[any] {
+	log.Info("start to export", WrapLogFields(t,
+		zap.Int("segments", len(t.req.GetSegments())),
+		zap.String("expr", t.req.GetExpr()),
+		zap.String("outputPath", t.req.GetOutputPath()))...)
+	t.manager.Update(t.GetTaskID(), UpdateState(datapb.ImportTaskStateV2_InProgress))
+
+	fn := func() (err error) {
+		defer func() {
+			if err != nil {
+				log.Warn("export task execute failed", WrapLogFields(t, zap.Error(err))...)
+				t.manager.Update(t.GetTaskID(), UpdateState(datapb.ImportTaskStateV2_Failed), UpdateReason(err.Error()))
+			}
+		}()
+
+		start := time.Now()
+		if err = binlog.DecompressCompactionBinlogs(t.req.GetSegments()); err != nil {
+			return
+		}
+		var pkField *schemapb.FieldSchema
+		pkField, err = typeutil.GetPrimaryFieldSchema(t.GetSchema())
+		if err != nil {
+			return
+		}
+		var filter exprutil.RowFilter
+		filter, err = t.newRowFilter()
+		if err != nil {
+			return
+		}
+		var deletes map[any]uint64
+		deletes, err = t.readDeletes()
+		if err != nil {
+			return
+		}
+		for _, segment := range t.req.GetSegments() {
+			if segment.GetLevel() == datapb.SegmentLevel_L0 {
+				continue
+			}
+			err = t.exportSegment(segment, pkField.GetFieldID(), deletes, filter)
+			if err != nil {
+				return
+			}
+		}
+		log.Info("export done", WrapLogFields(t,
+			zap.Int64("processedRows", t.processedRows),
+			zap.Int64("exportedRows", t.exportedRows),
+			zap.Strings("files", t.files),
+			zap.Duration("dur", time.Since(start)))...)
+		return nil
+	}
+
+	f := GetExecPool().Submit(func() (any, error) {
+		err := fn()
+		return err, err
+	})
+	return []*conc.Future[any]{f}
+}
+
+// newRowFilter compiles the expr and the row policy filter of the job, the rows must match both of them.
+func (t *ExportTask) newRowFilter() (exprutil.RowFilter, error) {
+	var expr *planpb.Expr
+	if t.req.GetExpr() != "" {
+		schemaHelper, err := typeutil.CreateSchemaHelper(t.GetSchema())
+		if err != nil {
+			return nil, err
+		}
+		expr, err = planparserv2.ParseExpr(schemaHelper, t.req.GetExpr(), nil)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if len(t.req.GetSerializedRowFilter()) > 0 {
+		rowFilter := &planpb.Expr{}
+		if err := proto.Unmarshal(t.req.GetSerializedRowFilter(), rowFilter); err != nil {
+			return nil, err
+		}
+		if expr == nil {
+			expr = rowFilter
+		} else {
+			expr = &planpb.Expr{
+				Expr: &planpb.Expr_BinaryExpr{
+					BinaryExpr: &planpb.BinaryExpr{
+						Op:    planpb.BinaryExpr_LogicalAnd,
+						Left:  expr,
+						Right: rowFilter,
+					},
+				},
+			}
+		}
+	}
+	if expr == nil {
+		return nil, nil
+	}
+	return exprutil.NewRowFilter(expr)
+}
+
+// readDeletes merges the deltalogs of all segments into pk -> max delete ts,
+// deletions after req.Ts are ignored.
+func (t *ExportTask) readDeletes() (map[any]uint64, error) {
+	deletes := make(map[any]uint64)
+	for _, segment := range t.req.GetSegments() {
+		paths := make([]string, 0)
+		for _, fieldBinlog := range segment.GetDeltalogs() {
+			for _, l := range fieldBinlog.GetBinlogs() {
+				paths = append(paths, l.GetLogPath())
+			}
+		}
+		if len(paths) == 0 {
+			continue
+		}
+		values, err := t.cm.MultiRead(t.ctx, paths)
+		if err != nil {
+			return nil, err
+		}
+		blobs := lo.Map(values, func(v []byte, i int) *storage.Blob {
+			return &storage.Blob{Key: paths[i], Value: v}
+		})
+		reader, err := storage.CreateDeltalogReader(blobs)
+		if err != nil {
+			return nil, err
+		}
+		for {
+			err = reader.Next()
+			if err != nil {
+				break
+			}
+			dl := reader.Value()
+			if dl.Ts > t.req.GetTs() {
+				continue
+			}
+			if ts, ok := deletes[dl.Pk.GetValue()]; !ok || ts < dl.Ts {
+				deletes[dl.Pk.GetValue()] = dl.Ts
+			}
+		}
+		reader.Close()
+		if err != io.EOF {
+			return nil, err
+		}
+	}
+	return deletes, nil
+}
+
+func (t *ExportTask) exportSegment(segment *datapb.CompactionSegmentBinlogs, pkFieldID int64,
+	deletes map[any]uint64, filter exprutil.RowFilter,
+) error {
+	fileSize := paramtable.Get().DataNodeCfg.ExportFileSizeInMB.GetAsInt() * 1024 * 1024
+	rowGroupSize := paramtable.Get().DataNodeCfg.ExportRowGroupSizeInMB.GetAsInt() * 1024 * 1024
+	exportSchema := &schemapb.CollectionSchema{
+		Fields: parquet.ExportFields(t.GetSchema()),
+	}
+
+	var (
+		writer  *parquet.Writer
+		fileIdx int
+	)
+	// Files are named by segment, a retried task overwrites the files written before.
+	closeWriter := func() error {
+		if writer == nil || writer.Rows() == 0 {
+			return nil
+		}
+		if err := writer.Close(); err != nil {
+			return err
+		}
+		t.files = append(t.files, writer.Path())
+		writer = nil
+		return nil
+	}
+	flush := func(data *storage.InsertData, processed int64) error {
+		t.processedRows += processed
+		if data.GetRowNum() > 0 {
+			if writer == nil {
+				filePath := path.Join(t.req.GetOutputPath(), fmt.Sprintf("%d_%d.parquet", segment.GetSegmentID(), fileIdx))
+				var err error
+				writer, err = parquet.NewWriter(t.ctx, t.cm, t.GetSchema(), filePath)
+				if err != nil {
+					return err
+				}
+				fileIdx++
+			}
+			if err := writer.Write(data); err != nil {
+				return err
+			}
+			t.exportedRows += int64(data.GetRowNum())
+			if writer.Size() >= fileSize {
+				if err := closeWriter(); err != nil {
+					return err
+				}
+			}
+		}
+		t.manager.Update(t.GetTaskID(), UpdateExportProgress(t.processedRows, t.exportedRows, t.files))
+		return nil
+	}
+
+	// binlogs of different fields are aligned by index
+	batchCount := 0
+	for _, fieldBinlog := range segment.GetFieldBinlogs() {
+		batchCount = len(fieldBinlog.GetBinlogs())
+		break
+	}
+	buffer, err := storage.NewInsertData(exportSchema)
+	if err != nil {
+		return err
+	}
+	var processed int64
+	for idx := 0; idx < batchCount; idx++ {
+		if err = t.ctx.Err(); err != nil {
+			return merr.WrapErrExportFailed(fmt.Sprintf("export task cancelled: %s", err.Error()))
+		}
+		paths := make([]string, 0, len(segment.GetFieldBinlogs()))
+		for _, fieldBinlog := range segment.GetFieldBinlogs() {
+			paths = append(paths, fieldBinlog.GetBinlogs()[idx].GetLogPath())
+		}
+		values, err := t.cm.MultiRead(t.ctx, paths)
+		if err != nil {
+			return err
+		}
+		blobs := lo.Map(values, func(v []byte, i int) *storage.Blob {
+			return &storage.Blob{Key: paths[i], Value: v}
+		})
+		reader, err := storage.NewBinlogDeserializeReader(blobs, pkFieldID)
+		if err != nil {
+			return err
+		}
+		for {
+			err = reader.Next()
+			if err != nil {
+				break
+			}
+			v := reader.Value()
+			processed++
+			if uint64(v.Timestamp) > t.req.GetTs() {
+				continue
+			}
+			if ts, ok := deletes[v.PK.GetValue()]; ok && uint64(v.Timestamp) < ts {
+				continue
+			}
+			row := v.Value.(map[int64]any)
+			if filter != nil && !filter(row) {
+				continue
+			}
+			for _, field := range exportSchema.GetFields() {
+				if err = buffer.Data[field.GetFieldID()].AppendRow(row[field.GetFieldID()]); err != nil {
+					reader.Close()
+					return err
+				}
+			}
+			if buffer.GetMemorySize() >= rowGroupSize {
+				if err = flush(buffer, processed); err != nil {
+					reader.Close()
+					return err
+				}
+				processed = 0
+				if buffer, err = storage.NewInsertData(exportSchema); err != nil {
+					reader.Close()
+					return err
+				}
+			}
+		}
+		reader.Close()
+		if err != io.EOF {
+			return err
+		}
+	}
+	if err = flush(buffer, processed); err != nil {
+		return err
+	}
+	return closeWriter()
+}
@@ -0,0 +1,223 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package importv2
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
+	"github.com/milvus-io/milvus-proto/go-api/v2/schemapb"
+	"github.com/milvus-io/milvus/internal/parser/planparserv2"
+	"github.com/milvus-io/milvus/internal/proto/datapb"
+	"github.com/milvus-io/milvus/internal/proto/etcdpb"
+	"github.com/milvus-io/milvus/internal/storage"
+	"github.com/milvus-io/milvus/pkg/common"
+	"github.com/milvus-io/milvus/pkg/util/conc"
+	"github.com/milvus-io/milvus/pkg/util/metautil"
+	"github.com/milvus-io/milvus/pkg/util/paramtable"
+	"github.com/milvus-io/milvus/pkg/util/typeutil"
+)
+
+type ExportTaskSuite struct {
+	suite.Suite
+
+	collectionID int64
+	partitionID  int64
+	segmentID    int64
+
+	schema  *schemapb.CollectionSchema
+	cm      storage.ChunkManager
+	manager TaskManager
+}
+
+func (s *ExportTaskSuite) SetupSuite() {
+	paramtable.Init()
+}
+
+func (s *ExportTaskSuite) SetupTest() {
+	s.collectionID = 1
+	s.partitionID = 2
+	s.segmentID = 3
+
+	s.schema = &schemapb.CollectionSchema{
+		Fields: []*schemapb.FieldSchema{
+			{FieldID: common.RowIDField, Name: common.RowIDFieldName, DataType: schemapb.DataType_Int64},
+			{FieldID: common.TimeStampField, Name: common.TimeStampFieldName, DataType: schemapb.DataType_Int64},
+			{FieldID: 100, Name: "pk", IsPrimaryKey: true, DataType: schemapb.DataType_Int64},
+			{
+				FieldID:  101,
+				Name:     "vec",
+				DataType: schemapb.DataType_FloatVector,
+				TypeParams: []*commonpb.KeyValuePair{
+					{Key: common.DimKey, Value: "4"},
+				},
+			},
+		},
+	}
+	s.cm = storage.NewLocalChunkManager(storage.RootPath(s.T().TempDir()))
+	s.manager = NewTaskManager()
+}
+
+// writeSegment writes 10 rows with pk i and ts i+1, pk 9 is inserted after the export ts.
+func (s *ExportTaskSuite) writeSegment() *datapb.CompactionSegmentBinlogs {
+	const rows = 10
+	insertData, err := storage.NewInsertData(s.schema)
+	s.NoError(err)
+	for i := 0; i < rows; i++ {
+		ts := int64(i + 1)
+		if i == rows-1 {
+			ts = 70
+		}
+		err = insertData.Append(map[int64]any{
+			common.RowIDField:     int64(i),
+			common.TimeStampField: ts,
+			100:                   int64(i),
+			101:                   []float32{float32(i), 1, 2, 3},
+		})
+		s.NoError(err)
+	}
+	codec := storage.NewInsertCodecWithSchema(&etcdpb.CollectionMeta{ID: s.collectionID, Schema: s.schema})
+	blobs, err := codec.Serialize(s.partitionID, s.segmentID, insertData)
+	s.NoError(err)
+
+	segment := &datapb.CompactionSegmentBinlogs{
+		SegmentID:    s.segmentID,
+		CollectionID: s.collectionID,
+		PartitionID:  s.partitionID,
+		Level:        datapb.SegmentLevel_L1,
+	}
+	for i, blob := range blobs {
+		fieldID, err := strconv.ParseInt(blob.GetKey(), 10, 64)
+		s.NoError(err)
+		logPath := metautil.BuildInsertLogPath(s.cm.RootPath(), s.collectionID, s.partitionID, s.segmentID, fieldID, int64(i+1))
+		s.NoError(s.cm.Write(context.Background(), logPath, blob.GetValue()))
+		segment.FieldBinlogs = append(segment.FieldBinlogs, &datapb.FieldBinlog{
+			FieldID: fieldID,
+			Binlogs: []*datapb.Binlog{{LogPath: logPath, EntriesNum: rows}},
+		})
+	}
+	return segment
+}
+
+// writeL0Segment deletes pk 2 before the export ts and pk 4 after it.
+func (s *ExportTaskSuite) writeL0Segment() *datapb.CompactionSegmentBinlogs {
+	deleteData := storage.NewDeleteData(
+		[]storage.PrimaryKey{storage.NewInt64PrimaryKey(2), storage.NewInt64PrimaryKey(4)},
+		[]uint64{20, 60})
+	blob, err := storage.NewDeleteCodec().Serialize(s.collectionID, s.partitionID, 4, deleteData)
+	s.NoError(err)
+	logPath := metautil.BuildDeltaLogPath(s.cm.RootPath(), s.collectionID, s.partitionID, 4, 1)
+	s.NoError(s.cm.Write(context.Background(), logPath, blob.GetValue()))
+	return &datapb.CompactionSegmentBinlogs{
+		SegmentID:    4,
+		CollectionID: s.collectionID,
+		PartitionID:  s.partitionID,
+		Level:        datapb.SegmentLevel_L0,
+		Deltalogs: []*datapb.FieldBinlog{{
+			Binlogs: []*datapb.Binlog{{LogPath: logPath}},
+		}},
+	}
+}
+
+func (s *ExportTaskSuite) TestExport() {
+	req := &datapb.ExportTaskRequest{
+		JobID:        1,
+		TaskID:       2,
+		CollectionID: s.collectionID,
+		Schema:       s.schema,
+		Expr:         "pk >= 1",
+		Ts:           50,
+		OutputPath:   s.cm.RootPath() + "/export/1",
+		Segments:     []*datapb.CompactionSegmentBinlogs{s.writeSegment(), s.writeL0Segment()},
+	}
+	task := NewExportTask(req, s.manager, s.cm)
+	s.manager.Add(task)
+	fu := task.Execute()
+	err := conc.AwaitAll(fu...)
+	s.NoError(err)
+
+	task = s.manager.Get(task.GetTaskID())
+	s.Equal(datapb.ImportTaskStateV2_InProgress, task.GetState())
+	exportTask := task.(*ExportTask)
+	s.Equal(int64(10), exportTask.GetProcessedRows())
+	// pk 0 is filtered, pk 2 is deleted and pk 9 is inserted after ts
+	s.Equal(int64(7), exportTask.GetExportedRows())
+	s.Equal([]string{s.cm.RootPath() + "/export/1/3_0.parquet"}, exportTask.GetFiles())
+	exist, err := s.cm.Exist(context.Background(), exportTask.GetFiles()[0])
+	s.NoError(err)
+	s.True(exist)
+}
+
+func (s *ExportTaskSuite) TestExportWithRowFilter() {
+	schemaHelper, err := typeutil.CreateSchemaHelper(s.schema)
+	s.NoError(err)
+	rowFilter, err := planparserv2.ParseExpr(schemaHelper, "pk < 5", nil)
+	s.NoError(err)
+	serialized, err := proto.Marshal(rowFilter)
+	s.NoError(err)
+
+	req := &datapb.ExportTaskRequest{
+		JobID:        1,
+		TaskID:       2,
+		CollectionID: s.collectionID,
+		Schema:       s.schema,
+		Expr:         "pk >= 1",
+		Ts:           50,
+		OutputPath:   s.cm.RootPath() + "/export/1",
+		Segments:     []*datapb.CompactionSegmentBinlogs{s.writeSegment(), s.writeL0Segment()},
+
+		SerializedRowFilter: serialized,
+	}
+	task := NewExportTask(req, s.manager, s.cm)
+	s.manager.Add(task)
+	fu := task.Execute()
+	err = conc.AwaitAll(fu...)
+	s.NoError(err)
+
+	exportTask := s.manager.Get(task.GetTaskID()).(*ExportTask)
+	s.Equal(int64(10), exportTask.GetProcessedRows())
+	// only pk 1, 3 and 4 match both the expr and the row filter, pk 2 is deleted
+	s.Equal(int64(3), exportTask.GetExportedRows())
+}
+
+func (s *ExportTaskSuite) TestCancel() {
+	req := &datapb.ExportTaskRequest{
+		JobID:        1,
+		TaskID:       2,
+		CollectionID: s.collectionID,
+		Schema:       s.schema,
+		Ts:           50,
+		OutputPath:   s.cm.RootPath() + "/export/1",
+		Segments:     []*datapb.CompactionSegmentBinlogs{s.writeSegment()},
+	}
+	task := NewExportTask(req, s.manager, s.cm)
+	s.manager.Add(task)
+	s.manager.Get(task.GetTaskID()).Clone().Cancel()
+	fu := task.Execute()
+	err := conc.AwaitAll(fu...)
+	s.Error(err)
+	s.Equal(datapb.ImportTaskStateV2_Failed, s.manager.Get(task.GetTaskID()).GetState())
+}
+
+func TestExportTask(t *testing.T) {
+	suite.Run(t, new(ExportTaskSuite))
+}
@@ -106,6 +106,7 @@ func NewSyncTask(ctx context.Context,
 func NewImportSegmentInfo(syncTask syncmgr.Task, metaCaches map[string]metacache.MetaCache) (*datapb.ImportSegmentInfo, error) {
 	segmentID := syncTask.SegmentID()
 	insertBinlogs, statsBinlog, deltaLog := syncTask.(*syncmgr.SyncTask).Binlogs()
+	fieldStatsBinlogs := syncTask.(*syncmgr.SyncTask).FieldStatsBinlogs()
 	metaCache := metaCaches[syncTask.ChannelName()]
 	segment, ok := metaCache.GetSegmentByID(segmentID)
 	if !ok {
@@ -121,6 +122,8 @@ func NewImportSegmentInfo(syncTask syncmgr.Task, metaCaches map[string]metacache
 		Binlogs:      lo.Values(insertBinlogs),
 		Statslogs:    lo.Values(statsBinlog),
 		Deltalogs:    deltaLogs,
+
+		FieldStatslogs: lo.Values(fieldStatsBinlogs),
 	}, nil
 }
 
@@ -551,6 +551,54 @@ func (node *DataNode) DropImport(ctx context.Context, req *datapb.DropImportRequ
 	return merr.Success(), nil
 }
 
+// Export adds an export task into the import task manager, it shares the slots with import tasks,
+// and is dropped by DropImport as well.
+func (node *DataNode) Export(ctx context.Context, req *datapb.ExportTaskRequest) (*commonpb.Status, error) {
+	log := log.Ctx(ctx).With(zap.Int64("taskID", req.GetTaskID()),
+		zap.Int64("jobID", req.GetJobID()),
+		zap.Int64("collectionID", req.GetCollectionID()),
+		zap.Int("segments", len(req.GetSegments())),
+		zap.String("outputPath", req.GetOutputPath()))
+
+	log.Info("datanode receive export request")
+
+	if err := merr.CheckHealthy(node.GetStateCode()); err != nil {
+		return merr.Status(err), nil
+	}
+	task := importv2.NewExportTask(req, node.importTaskMgr, node.chunkManager)
+	node.importTaskMgr.Add(task)
+
+	log.Info("datanode added export task")
+	return merr.Success(), nil
+}
+
+func (node *DataNode) QueryExport(ctx context.Context, req *datapb.QueryExportRequest) (*datapb.QueryExportResponse, error) {
+	log := log.Ctx(ctx).With(zap.Int64("taskID", req.GetTaskID()),
+		zap.Int64("jobID", req.GetJobID()))
+
+	if err := merr.CheckHealthy(node.GetStateCode()); err != nil {
+		return &datapb.QueryExportResponse{Status: merr.Status(err)}, nil
+	}
+
+	task, ok := node.importTaskMgr.Get(req.GetTaskID()).(*importv2.ExportTask)
+	if !ok {
+		return &datapb.QueryExportResponse{
+			Status: merr.Status(importv2.WrapTaskNotFoundError(req.GetTaskID())),
+		}, nil
+	}
+	log.RatedInfo(10, "datanode query export", zap.String("state", task.GetState().String()),
+		zap.String("reason", task.GetReason()))
+	return &datapb.QueryExportResponse{
+		Status:        merr.Success(),
+		TaskID:        task.GetTaskID(),
+		State:         task.GetState(),
+		Reason:        task.GetReason(),
+		ProcessedRows: task.GetProcessedRows(),
+		ExportedRows:  task.GetExportedRows(),
+		Files:         task.GetFiles(),
+	}, nil
+}
+
 func (node *DataNode) QuerySlot(ctx context.Context, req *datapb.QuerySlotRequest) (*datapb.QuerySlotResponse, error) {
 	if err := merr.CheckHealthy(node.GetStateCode()); err != nil {
 		return &datapb.QuerySlotResponse{
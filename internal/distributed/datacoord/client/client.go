@@ -779,3 +779,45 @@ func (c *Client) ListIndexes(ctx context.Context, in *indexpb.ListIndexesRequest
 		return client.ListIndexes(ctx, in)
 	})
 }
+
+func (c *Client) CreateSnapshot(ctx context.Context, in *datapb.CreateSnapshotRequest, opts ...grpc.CallOption) (*datapb.CreateSnapshotResponse, error) {
+	return wrapGrpcCall(ctx, c, func(client datapb.DataCoordClient) (*datapb.CreateSnapshotResponse, error) {
+		return client.CreateSnapshot(ctx, in)
+	})
+}
+
+func (c *Client) DropSnapshot(ctx context.Context, in *datapb.DropSnapshotRequest, opts ...grpc.CallOption) (*commonpb.Status, error) {
+	return wrapGrpcCall(ctx, c, func(client datapb.DataCoordClient) (*commonpb.Status, error) {
+		return client.DropSnapshot(ctx, in)
+	})
+}
+
+func (c *Client) ListSnapshots(ctx context.Context, in *datapb.ListSnapshotsRequest, opts ...grpc.CallOption) (*datapb.ListSnapshotsResponse, error) {
+	return wrapGrpcCall(ctx, c, func(client datapb.DataCoordClient) (*datapb.ListSnapshotsResponse, error) {
+		return client.ListSnapshots(ctx, in)
+	})
+}
+
+func (c *Client) RestoreSnapshot(ctx context.Context, in *datapb.RestoreSnapshotRequest, opts ...grpc.CallOption) (*commonpb.Status, error) {
+	return wrapGrpcCall(ctx, c, func(client datapb.DataCoordClient) (*commonpb.Status, error) {
+		return client.RestoreSnapshot(ctx, in)
+	})
+}
+
+func (c *Client) Export(ctx context.Context, in *datapb.ExportRequest, opts ...grpc.CallOption) (*datapb.ExportResponse, error) {
+	return wrapGrpcCall(ctx, c, func(client datapb.DataCoordClient) (*datapb.ExportResponse, error) {
+		return client.Export(ctx, in)
+	})
+}
+
+func (c *Client) GetExportProgress(ctx context.Context, in *datapb.GetExportProgressRequest, opts ...grpc.CallOption) (*datapb.GetExportProgressResponse, error) {
+	return wrapGrpcCall(ctx, c, func(client datapb.DataCoordClient) (*datapb.GetExportProgressResponse, error) {
+		return client.GetExportProgress(ctx, in)
+	})
+}
+
+func (c *Client) CancelExport(ctx context.Context, in *datapb.CancelExportRequest, opts ...grpc.CallOption) (*commonpb.Status, error) {
+	return wrapGrpcCall(ctx, c, func(client datapb.DataCoordClient) (*commonpb.Status, error) {
+		return client.CancelExport(ctx, in)
+	})
+}
@@ -521,3 +521,31 @@ func (s *Server) ListImports(ctx context.Context, in *internalpb.ListImportsRequ
 func (s *Server) ListIndexes(ctx context.Context, in *indexpb.ListIndexesRequest) (*indexpb.ListIndexesResponse, error) {
 	return s.dataCoord.ListIndexes(ctx, in)
 }
+
+func (s *Server) CreateSnapshot(ctx context.Context, in *datapb.CreateSnapshotRequest) (*datapb.CreateSnapshotResponse, error) {
+	return s.dataCoord.CreateSnapshot(ctx, in)
+}
+
+func (s *Server) DropSnapshot(ctx context.Context, in *datapb.DropSnapshotRequest) (*commonpb.Status, error) {
+	return s.dataCoord.DropSnapshot(ctx, in)
+}
+
+func (s *Server) ListSnapshots(ctx context.Context, in *datapb.ListSnapshotsRequest) (*datapb.ListSnapshotsResponse, error) {
+	return s.dataCoord.ListSnapshots(ctx, in)
+}
+
+func (s *Server) RestoreSnapshot(ctx context.Context, in *datapb.RestoreSnapshotRequest) (*commonpb.Status, error) {
+	return s.dataCoord.RestoreSnapshot(ctx, in)
+}
+
+func (s *Server) Export(ctx context.Context, in *datapb.ExportRequest) (*datapb.ExportResponse, error) {
+	return s.dataCoord.Export(ctx, in)
+}
+
+func (s *Server) GetExportProgress(ctx context.Context, in *datapb.GetExportProgressRequest) (*datapb.GetExportProgressResponse, error) {
+	return s.dataCoord.GetExportProgress(ctx, in)
+}
+
+func (s *Server) CancelExport(ctx context.Context, in *datapb.CancelExportRequest) (*commonpb.Status, error) {
+	return s.dataCoord.CancelExport(ctx, in)
+}
@@ -267,6 +267,18 @@ func (c *Client) DropImport(ctx context.Context, req *datapb.DropImportRequest,
 	})
 }
 
+func (c *Client) Export(ctx context.Context, req *datapb.ExportTaskRequest, opts ...grpc.CallOption) (*commonpb.Status, error) {
+	return wrapGrpcCall(ctx, c, func(client datapb.DataNodeClient) (*commonpb.Status, error) {
+		return client.Export(ctx, req)
+	})
+}
+
+func (c *Client) QueryExport(ctx context.Context, req *datapb.QueryExportRequest, opts ...grpc.CallOption) (*datapb.QueryExportResponse, error) {
+	return wrapGrpcCall(ctx, c, func(client datapb.DataNodeClient) (*datapb.QueryExportResponse, error) {
+		return client.QueryExport(ctx, req)
+	})
+}
+
 func (c *Client) QuerySlot(ctx context.Context, req *datapb.QuerySlotRequest, opts ...grpc.CallOption) (*datapb.QuerySlotResponse, error) {
 	return wrapGrpcCall(ctx, c, func(client datapb.DataNodeClient) (*datapb.QuerySlotResponse, error) {
 		return client.QuerySlot(ctx, req)
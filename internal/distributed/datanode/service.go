@@ -404,6 +404,14 @@ func (s *Server) DropImport(ctx context.Context, req *datapb.DropImportRequest)
 	return s.datanode.DropImport(ctx, req)
 }
 
+func (s *Server) Export(ctx context.Context, req *datapb.ExportTaskRequest) (*commonpb.Status, error) {
+	return s.datanode.Export(ctx, req)
+}
+
+func (s *Server) QueryExport(ctx context.Context, req *datapb.QueryExportRequest) (*datapb.QueryExportResponse, error) {
+	return s.datanode.QueryExport(ctx, req)
+}
+
 func (s *Server) QuerySlot(ctx context.Context, req *datapb.QuerySlotRequest) (*datapb.QuerySlotResponse, error) {
 	return s.datanode.QuerySlot(ctx, req)
 }
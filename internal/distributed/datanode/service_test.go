@@ -177,6 +177,14 @@ func (m *MockDataNode) DropImport(ctx context.Context, req *datapb.DropImportReq
 	return m.status, m.err
 }
 
+func (m *MockDataNode) Export(ctx context.Context, req *datapb.ExportTaskRequest) (*commonpb.Status, error) {
+	return m.status, m.err
+}
+
+func (m *MockDataNode) QueryExport(ctx context.Context, req *datapb.QueryExportRequest) (*datapb.QueryExportResponse, error) {
+	return &datapb.QueryExportResponse{}, m.err
+}
+
 func (m *MockDataNode) QuerySlot(ctx context.Context, req *datapb.QuerySlotRequest) (*datapb.QuerySlotResponse, error) {
 	return &datapb.QuerySlotResponse{}, m.err
 }
@@ -35,6 +35,12 @@ const (
 	AliasCategory          = "/aliases/"
 	ImportJobCategory      = "/jobs/import/"
 	PrivilegeGroupCategory = "/privilege_groups/"
+	TransactionCategory    = "/transactions/"
+	RecycleBinCategory     = "/recycle_bin/"
+	ChangeStreamCategory   = "/change_stream/"
+	SnapshotCategory       = "/snapshots/"
+	ExportJobCategory      = "/jobs/export/"
+	RowPolicyCategory      = "/row_policies/"
 
 	ListAction           = "list"
 	HasAction            = "has"
@@ -66,11 +72,19 @@ const (
 	GetProgressAction               = "get_progress" // deprecated, keep it for compatibility, use `/v2/vectordb/jobs/import/describe` instead
 	AddPrivilegesToGroupAction      = "add_privileges_to_group"
 	RemovePrivilegesFromGroupAction = "remove_privileges_from_group"
+	BeginAction                     = "begin"
+	CommitAction                    = "commit"
+	RollbackAction                  = "rollback"
+	UndropAction                    = "undrop"
+	SubscribeAction                 = "subscribe"
+	RestoreAction                   = "restore"
+	CancelAction                    = "cancel"
 )
 
 const (
 	ContextRequest                = "request"
 	ContextUsername               = "username"
+	ContextJWTRoles               = "jwt_roles"
 	VectorCollectionsPath         = "/vector/collections"
 	VectorCollectionsCreatePath   = "/vector/collections/create"
 	VectorCollectionsDescribePath = "/vector/collections/describe"
@@ -94,6 +108,7 @@ const (
 	HTTPDbID                 = "dbID"
 	HTTPProperties           = "properties"
 	HTTPPartitionName        = "partitionName"
+	HTTPPartitionID          = "partitionID"
 	HTTPPartitionNames       = "partitionNames"
 	HTTPUserName             = "userName"
 	HTTPRoleName             = "roleName"
@@ -109,6 +124,7 @@ const (
 	HTTPHeaderAllowInt64     = "Accept-Type-Allow-Int64"
 	HTTPHeaderDBName         = "DB-Name"
 	HTTPHeaderRequestTimeout = "Request-Timeout"
+	HTTPHeaderTxnID          = "Txn-Id"
 	HTTPDefaultTimeout       = 30 * time.Second
 	HTTPReturnCode           = "code"
 	HTTPReturnMessage        = "message"
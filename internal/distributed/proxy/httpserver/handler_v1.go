@@ -211,7 +211,7 @@ func (h *HandlersV1) listCollections(c *gin.Context) {
 	}
 	c.Set(ContextRequest, req)
 	username, _ := c.Get(ContextUsername)
-	ctx := proxy.NewContextWithMetadata(c, username.(string), req.DbName)
+	ctx := newContextWithMetadata(c, c, username.(string), req.DbName)
 
 	resp, err := h.executeRestRequestInterceptor(ctx, c, req, func(reqCtx context.Context, req any) (any, error) {
 		return h.proxy.ShowCollections(reqCtx, req.(*milvuspb.ShowCollectionsRequest))
@@ -305,7 +305,7 @@ func (h *HandlersV1) createCollection(c *gin.Context) {
 	}
 	req.Schema = schema
 	username, _ := c.Get(ContextUsername)
-	ctx := proxy.NewContextWithMetadata(c, username.(string), req.DbName)
+	ctx := newContextWithMetadata(c, c, username.(string), req.DbName)
 	response, err := h.executeRestRequestInterceptor(ctx, c, req, func(reqCtx context.Context, req any) (any, error) {
 		return h.proxy.CreateCollection(reqCtx, req.(*milvuspb.CreateCollectionRequest))
 	})
@@ -360,7 +360,7 @@ func (h *HandlersV1) getCollectionDetails(c *gin.Context) {
 	}
 	dbName := c.DefaultQuery(HTTPDbName, DefaultDbName)
 	username, _ := c.Get(ContextUsername)
-	ctx := proxy.NewContextWithMetadata(c, username.(string), dbName)
+	ctx := newContextWithMetadata(c, c, username.(string), dbName)
 
 	req := &milvuspb.DescribeCollectionRequest{
 		DbName:         dbName,
@@ -465,7 +465,7 @@ func (h *HandlersV1) dropCollection(c *gin.Context) {
 	}
 	c.Set(ContextRequest, req)
 	username, _ := c.Get(ContextUsername)
-	ctx := proxy.NewContextWithMetadata(c, username.(string), req.DbName)
+	ctx := newContextWithMetadata(c, c, username.(string), req.DbName)
 	response, err := h.executeRestRequestInterceptor(ctx, c, req, func(reqCtx context.Context, req any) (any, error) {
 		has, err := h.hasCollection(ctx, c, httpReq.DbName, httpReq.CollectionName)
 		if err != nil {
@@ -531,7 +531,7 @@ func (h *HandlersV1) query(c *gin.Context) {
 		req.QueryParams = append(req.QueryParams, &commonpb.KeyValuePair{Key: ParamLimit, Value: strconv.FormatInt(int64(httpReq.Limit), 10)})
 	}
 	username, _ := c.Get(ContextUsername)
-	ctx := proxy.NewContextWithMetadata(c, username.(string), req.DbName)
+	ctx := newContextWithMetadata(c, c, username.(string), req.DbName)
 	response, err := h.executeRestRequestInterceptor(ctx, c, req, func(reqCtx context.Context, req any) (any, error) {
 		if _, err := CheckLimiter(ctx, &req, h.proxy); err != nil {
 			c.AbortWithStatusJSON(http.StatusOK, gin.H{
@@ -595,7 +595,7 @@ func (h *HandlersV1) get(c *gin.Context) {
 	}
 	c.Set(ContextRequest, req)
 	username, _ := c.Get(ContextUsername)
-	ctx := proxy.NewContextWithMetadata(c, username.(string), req.DbName)
+	ctx := newContextWithMetadata(c, c, username.(string), req.DbName)
 	response, err := h.executeRestRequestInterceptor(ctx, c, req, func(reqCtx context.Context, req any) (any, error) {
 		collSchema, err := h.describeCollection(ctx, c, httpReq.DbName, httpReq.CollectionName)
 		if err != nil || collSchema == nil {
@@ -671,7 +671,7 @@ func (h *HandlersV1) delete(c *gin.Context) {
 	}
 	c.Set(ContextRequest, req)
 	username, _ := c.Get(ContextUsername)
-	ctx := proxy.NewContextWithMetadata(c, username.(string), req.DbName)
+	ctx := newContextWithMetadata(c, c, username.(string), req.DbName)
 	response, err := h.executeRestRequestInterceptor(ctx, c, req, func(reqCtx context.Context, req any) (any, error) {
 		collSchema, err := h.describeCollection(ctx, c, httpReq.DbName, httpReq.CollectionName)
 		if err != nil || collSchema == nil {
@@ -748,7 +748,7 @@ func (h *HandlersV1) insert(c *gin.Context) {
 	}
 	c.Set(ContextRequest, req)
 	username, _ := c.Get(ContextUsername)
-	ctx := proxy.NewContextWithMetadata(c, username.(string), req.DbName)
+	ctx := newContextWithMetadata(c, c, username.(string), req.DbName)
 	response, err := h.executeRestRequestInterceptor(ctx, c, req, func(reqCtx context.Context, req any) (any, error) {
 		collSchema, err := h.describeCollection(ctx, c, httpReq.DbName, httpReq.CollectionName)
 		if err != nil || collSchema == nil {
@@ -847,7 +847,7 @@ func (h *HandlersV1) upsert(c *gin.Context) {
 	}
 	c.Set(ContextRequest, req)
 	username, _ := c.Get(ContextUsername)
-	ctx := proxy.NewContextWithMetadata(c, username.(string), req.DbName)
+	ctx := newContextWithMetadata(c, c, username.(string), req.DbName)
 	response, err := h.executeRestRequestInterceptor(ctx, c, req, func(reqCtx context.Context, req any) (any, error) {
 		collSchema, err := h.describeCollection(ctx, c, httpReq.DbName, httpReq.CollectionName)
 		if err != nil || collSchema == nil {
@@ -981,7 +981,7 @@ func (h *HandlersV1) search(c *gin.Context) {
 	}
 
 	username, _ := c.Get(ContextUsername)
-	ctx := proxy.NewContextWithMetadata(c, username.(string), req.DbName)
+	ctx := newContextWithMetadata(c, c, username.(string), req.DbName)
 	response, err := h.executeRestRequestInterceptor(ctx, c, req, func(reqCtx context.Context, req any) (any, error) {
 		if _, err := CheckLimiter(ctx, &req, h.proxy); err != nil {
 			c.AbortWithStatusJSON(http.StatusOK, gin.H{
@@ -42,12 +42,15 @@ import (
 	"github.com/milvus-io/milvus-proto/go-api/v2/schemapb"
 	"github.com/milvus-io/milvus/internal/json"
 	"github.com/milvus-io/milvus/internal/proto/internalpb"
+	"github.com/milvus-io/milvus/internal/proto/proxypb"
 	"github.com/milvus-io/milvus/internal/proxy"
 	"github.com/milvus-io/milvus/internal/types"
 	"github.com/milvus-io/milvus/internal/util/hookutil"
 	"github.com/milvus-io/milvus/pkg/common"
 	"github.com/milvus-io/milvus/pkg/log"
 	"github.com/milvus-io/milvus/pkg/metrics"
+	"github.com/milvus-io/milvus/pkg/util"
+	"github.com/milvus-io/milvus/pkg/util/contextutil"
 	"github.com/milvus-io/milvus/pkg/util/crypto"
 	"github.com/milvus-io/milvus/pkg/util/funcutil"
 	"github.com/milvus-io/milvus/pkg/util/merr"
@@ -76,6 +79,7 @@ func (h *HandlersV2) RegisterRoutesToV2(router gin.IRouter) {
 	router.POST(CollectionCategory+LoadStateAction, timeoutMiddleware(wrapperPost(func() any { return &CollectionNameReq{} }, wrapperTraceLog(h.getCollectionLoadState))))
 	router.POST(CollectionCategory+CreateAction, timeoutMiddleware(wrapperPost(func() any { return &CollectionReq{AutoID: DisableAutoID} }, wrapperTraceLog(h.createCollection))))
 	router.POST(CollectionCategory+DropAction, timeoutMiddleware(wrapperPost(func() any { return &CollectionNameReq{} }, wrapperTraceLog(h.dropCollection))))
+	router.POST(CollectionCategory+UndropAction, timeoutMiddleware(wrapperPost(func() any { return &UndropCollectionReq{} }, wrapperTraceLog(h.undropCollection))))
 	router.POST(CollectionCategory+RenameAction, timeoutMiddleware(wrapperPost(func() any { return &RenameCollectionReq{} }, wrapperTraceLog(h.renameCollection))))
 	router.POST(CollectionCategory+LoadAction, timeoutMiddleware(wrapperPost(func() any { return &CollectionNameReq{} }, wrapperTraceLog(h.loadCollection))))
 	router.POST(CollectionCategory+ReleaseAction, timeoutMiddleware(wrapperPost(func() any { return &CollectionNameReq{} }, wrapperTraceLog(h.releaseCollection))))
@@ -135,6 +139,7 @@ func (h *HandlersV2) RegisterRoutesToV2(router gin.IRouter) {
 
 	router.POST(PartitionCategory+CreateAction, timeoutMiddleware(wrapperPost(func() any { return &PartitionReq{} }, wrapperTraceLog(h.createPartition))))
 	router.POST(PartitionCategory+DropAction, timeoutMiddleware(wrapperPost(func() any { return &PartitionReq{} }, wrapperTraceLog(h.dropPartition))))
+	router.POST(PartitionCategory+UndropAction, timeoutMiddleware(wrapperPost(func() any { return &UndropPartitionReq{} }, wrapperTraceLog(h.undropPartition))))
 	router.POST(PartitionCategory+LoadAction, timeoutMiddleware(wrapperPost(func() any { return &PartitionsReq{} }, wrapperTraceLog(h.loadPartitions))))
 	router.POST(PartitionCategory+ReleaseAction, timeoutMiddleware(wrapperPost(func() any { return &PartitionsReq{} }, wrapperTraceLog(h.releasePartitions))))
 
@@ -182,6 +187,28 @@ func (h *HandlersV2) RegisterRoutesToV2(router gin.IRouter) {
 	router.POST(ImportJobCategory+CreateAction, timeoutMiddleware(wrapperPost(func() any { return &ImportReq{} }, wrapperTraceLog(h.createImportJob))))
 	router.POST(ImportJobCategory+GetProgressAction, timeoutMiddleware(wrapperPost(func() any { return &JobIDReq{} }, wrapperTraceLog(h.getImportJobProcess))))
 	router.POST(ImportJobCategory+DescribeAction, timeoutMiddleware(wrapperPost(func() any { return &JobIDReq{} }, wrapperTraceLog(h.getImportJobProcess))))
+
+	router.POST(RecycleBinCategory+ListAction, timeoutMiddleware(wrapperPost(func() any { return &DatabaseReq{} }, wrapperTraceLog(h.listRecycleBin))))
+	// the subscription is kept until the client disconnects, so it has no timeout
+	router.POST(ChangeStreamCategory+SubscribeAction, wrapperPost(func() any { return &SubscribeChangesReq{} }, wrapperTraceLog(h.subscribeChanges)))
+
+	router.POST(SnapshotCategory+CreateAction, timeoutMiddleware(wrapperPost(func() any { return &CreateSnapshotReq{} }, wrapperTraceLog(h.createSnapshot))))
+	router.POST(SnapshotCategory+DropAction, timeoutMiddleware(wrapperPost(func() any { return &SnapshotReq{} }, wrapperTraceLog(h.dropSnapshot))))
+	router.POST(SnapshotCategory+ListAction, timeoutMiddleware(wrapperPost(func() any { return &ListSnapshotsReq{} }, wrapperTraceLog(h.listSnapshots))))
+	// the snapshot is restored into a new collection named by collectionName
+	router.POST(SnapshotCategory+RestoreAction, timeoutMiddleware(wrapperPost(func() any { return &SnapshotReq{} }, wrapperTraceLog(h.restoreSnapshot))))
+
+	router.POST(ExportJobCategory+CreateAction, timeoutMiddleware(wrapperPost(func() any { return &ExportReq{} }, wrapperTraceLog(h.createExportJob))))
+	router.POST(ExportJobCategory+DescribeAction, timeoutMiddleware(wrapperPost(func() any { return &JobIDReq{} }, wrapperTraceLog(h.describeExportJob))))
+	router.POST(ExportJobCategory+CancelAction, timeoutMiddleware(wrapperPost(func() any { return &JobIDReq{} }, wrapperTraceLog(h.cancelExportJob))))
+
+	router.POST(RowPolicyCategory+CreateAction, timeoutMiddleware(wrapperPost(func() any { return &SetRowPolicyReq{} }, wrapperTraceLog(h.setRowPolicy))))
+	router.POST(RowPolicyCategory+DropAction, timeoutMiddleware(wrapperPost(func() any { return &RowPolicyReq{} }, wrapperTraceLog(h.dropRowPolicy))))
+	router.POST(RowPolicyCategory+ListAction, timeoutMiddleware(wrapperPost(func() any { return &ListRowPoliciesReq{} }, wrapperTraceLog(h.listRowPolicies))))
+
+	router.POST(TransactionCategory+BeginAction, timeoutMiddleware(wrapperPost(func() any { return &BeginTransactionReq{} }, wrapperTraceLog(h.beginTransaction))))
+	router.POST(TransactionCategory+CommitAction, timeoutMiddleware(wrapperPost(func() any { return &TxnIDReq{} }, wrapperTraceLog(h.commitTransaction))))
+	router.POST(TransactionCategory+RollbackAction, timeoutMiddleware(wrapperPost(func() any { return &TxnIDReq{} }, wrapperTraceLog(h.rollbackTransaction))))
 }
 
 type (
@@ -228,7 +255,11 @@ func wrapperPost(newReq newReqFunc, v2 handlerFuncV2) gin.HandlerFunc {
 		username, _ := c.Get(ContextUsername)
 		ctx, span := otel.Tracer(typeutil.ProxyRole).Start(getCtx(c), c.Request.URL.Path)
 		defer span.End()
-		ctx = proxy.NewContextWithMetadata(ctx, username.(string), dbName)
+		ctx = newContextWithMetadata(ctx, c, username.(string), dbName)
+		// the dml request joins the transaction by the header
+		if txnID := c.Request.Header.Get(HTTPHeaderTxnID); txnID != "" {
+			ctx = contextutil.AppendToIncomingContext(ctx, strings.ToLower(util.HeaderTxnID), txnID)
+		}
 		traceID := span.SpanContext().TraceID().String()
 		ctx = log.WithTraceID(ctx, traceID)
 		c.Keys["traceID"] = traceID
@@ -654,6 +685,9 @@ func (h *HandlersV2) query(ctx context.Context, c *gin.Context, anyReq any, dbNa
 	if httpReq.Limit > 0 && !matchCountRule(httpReq.OutputFields) {
 		req.QueryParams = append(req.QueryParams, &commonpb.KeyValuePair{Key: ParamLimit, Value: strconv.FormatInt(int64(httpReq.Limit), 10)})
 	}
+	if httpReq.Profile {
+		req.QueryParams = append(req.QueryParams, &commonpb.KeyValuePair{Key: proxy.ProfileKey, Value: "true"})
+	}
 	resp, err := wrapperProxyWithLimit(ctx, c, req, h.checkAuth, false, "/milvus.proto.milvus.MilvusService/Query", true, h.proxy, func(reqCtx context.Context, req any) (interface{}, error) {
 		return h.proxy.Query(reqCtx, req.(*milvuspb.QueryRequest))
 	})
@@ -953,6 +987,12 @@ func generatePlaceholderGroup(ctx context.Context, body string, collSchema *sche
 					dataType = schemapb.DataType_VarChar
 				}
 			}
+			if function.Type == schemapb.FunctionType_TextEmbedding {
+				// text embedding output field accepts both raw text and vectors.
+				if function.OutputFieldNames[0] == vectorField.Name && gjson.Get(body, HTTPRequestData+".0").Type == gjson.String {
+					dataType = schemapb.DataType_VarChar
+				}
+			}
 		}
 	}
 
@@ -1020,6 +1060,9 @@ func (h *HandlersV2) search(ctx context.Context, c *gin.Context, anyReq any, dbN
 		searchParams = append(searchParams, &commonpb.KeyValuePair{Key: ParamStrictGroupSize, Value: strconv.FormatBool(httpReq.StrictGroupSize)})
 	}
 	searchParams = append(searchParams, &commonpb.KeyValuePair{Key: proxy.AnnsFieldKey, Value: httpReq.AnnsField})
+	if httpReq.Profile {
+		searchParams = append(searchParams, &commonpb.KeyValuePair{Key: proxy.ProfileKey, Value: "true"})
+	}
 	body, _ := c.Get(gin.BodyBytesKey)
 	placeholderGroup, err := generatePlaceholderGroup(ctx, string(body.([]byte)), collSchema, httpReq.AnnsField)
 	if err != nil {
@@ -1126,6 +1169,9 @@ func (h *HandlersV2) advancedSearch(ctx context.Context, c *gin.Context, anyReq
 		req.RankParams = append(req.RankParams, &commonpb.KeyValuePair{Key: ParamGroupSize, Value: strconv.FormatInt(int64(httpReq.GroupSize), 10)})
 		req.RankParams = append(req.RankParams, &commonpb.KeyValuePair{Key: ParamStrictGroupSize, Value: strconv.FormatBool(httpReq.StrictGroupSize)})
 	}
+	if httpReq.Profile {
+		req.RankParams = append(req.RankParams, &commonpb.KeyValuePair{Key: proxy.ProfileKey, Value: "true"})
+	}
 	resp, err := wrapperProxyWithLimit(ctx, c, req, h.checkAuth, false, "/milvus.proto.milvus.MilvusService/HybridSearch", true, h.proxy, func(reqCtx context.Context, req any) (interface{}, error) {
 		return h.proxy.HybridSearch(reqCtx, req.(*milvuspb.HybridSearchRequest))
 	})
@@ -2380,6 +2426,442 @@ func (h *HandlersV2) getImportJobProcess(ctx context.Context, c *gin.Context, an
 	return resp, err
 }
 
+func (h *HandlersV2) beginTransaction(ctx context.Context, c *gin.Context, anyReq any, dbName string) (interface{}, error) {
+	httpReq := anyReq.(*BeginTransactionReq)
+	req := &proxypb.BeginTransactionRequest{
+		DbName:         dbName,
+		CollectionName: httpReq.CollectionName,
+		KeepaliveMs:    httpReq.KeepaliveMs,
+	}
+	c.Set(ContextRequest, req)
+	resp, err := wrapperProxy(ctx, c, req, h.checkAuth, false, "/milvus.proto.proxy.Transaction/BeginTransaction", func(reqCtx context.Context, req any) (interface{}, error) {
+		return h.proxy.BeginTransaction(reqCtx, req.(*proxypb.BeginTransactionRequest))
+	})
+	if err == nil {
+		response := resp.(*proxypb.BeginTransactionResponse)
+		HTTPReturn(c, http.StatusOK, gin.H{HTTPReturnCode: merr.Code(nil), HTTPReturnData: gin.H{
+			"txnId":       strconv.FormatInt(response.GetTxnID(), 10),
+			"keepaliveMs": response.GetKeepaliveMs(),
+		}})
+	}
+	return resp, err
+}
+
+func (h *HandlersV2) commitTransaction(ctx context.Context, c *gin.Context, anyReq any, dbName string) (interface{}, error) {
+	txnID, err := strconv.ParseInt(anyReq.(*TxnIDReq).GetTxnID(), 10, 64)
+	if err != nil {
+		HTTPAbortReturn(c, http.StatusOK, gin.H{
+			HTTPReturnCode:    merr.Code(merr.ErrIncorrectParameterFormat),
+			HTTPReturnMessage: merr.ErrIncorrectParameterFormat.Error() + ", error: " + err.Error(),
+		})
+		return nil, err
+	}
+	req := &proxypb.CommitTransactionRequest{TxnID: txnID}
+	c.Set(ContextRequest, req)
+	resp, err := wrapperProxy(ctx, c, req, h.checkAuth, false, "/milvus.proto.proxy.Transaction/CommitTransaction", func(reqCtx context.Context, req any) (interface{}, error) {
+		return h.proxy.CommitTransaction(reqCtx, req.(*proxypb.CommitTransactionRequest))
+	})
+	if err == nil {
+		HTTPReturn(c, http.StatusOK, gin.H{HTTPReturnCode: merr.Code(nil), HTTPReturnData: gin.H{
+			"timestamp": resp.(*proxypb.CommitTransactionResponse).GetTimestamp(),
+		}})
+	}
+	return resp, err
+}
+
+func (h *HandlersV2) rollbackTransaction(ctx context.Context, c *gin.Context, anyReq any, dbName string) (interface{}, error) {
+	txnID, err := strconv.ParseInt(anyReq.(*TxnIDReq).GetTxnID(), 10, 64)
+	if err != nil {
+		HTTPAbortReturn(c, http.StatusOK, gin.H{
+			HTTPReturnCode:    merr.Code(merr.ErrIncorrectParameterFormat),
+			HTTPReturnMessage: merr.ErrIncorrectParameterFormat.Error() + ", error: " + err.Error(),
+		})
+		return nil, err
+	}
+	req := &proxypb.RollbackTransactionRequest{TxnID: txnID}
+	c.Set(ContextRequest, req)
+	resp, err := wrapperProxy(ctx, c, req, h.checkAuth, false, "/milvus.proto.proxy.Transaction/RollbackTransaction", func(reqCtx context.Context, req any) (interface{}, error) {
+		return h.proxy.RollbackTransaction(reqCtx, req.(*proxypb.RollbackTransactionRequest))
+	})
+	if err == nil {
+		HTTPReturn(c, http.StatusOK, gin.H{HTTPReturnCode: merr.Code(nil), HTTPReturnData: gin.H{}})
+	}
+	return resp, err
+}
+
+func (h *HandlersV2) listRecycleBin(ctx context.Context, c *gin.Context, anyReq any, dbName string) (interface{}, error) {
+	req := &proxypb.ListRecycleBinRequest{
+		DbName: dbName,
+	}
+	c.Set(ContextRequest, req)
+	resp, err := wrapperProxy(ctx, c, req, h.checkAuth, false, "/milvus.proto.proxy.RecycleBin/ListRecycleBin", func(reqCtx context.Context, req any) (interface{}, error) {
+		return h.proxy.ListRecycleBin(reqCtx, req.(*proxypb.ListRecycleBinRequest))
+	})
+	if err == nil {
+		response := resp.(*proxypb.ListRecycleBinResponse)
+		collections := make([]gin.H, 0, len(response.GetCollections()))
+		for _, coll := range response.GetCollections() {
+			collections = append(collections, gin.H{
+				HTTPCollectionName: coll.GetCollectionName(),
+				HTTPCollectionID:   coll.GetCollectionID(),
+				"droppedTime":      coll.GetDroppedTime(),
+				"expireTime":       coll.GetExpireTime(),
+			})
+		}
+		partitions := make([]gin.H, 0, len(response.GetPartitions()))
+		for _, partition := range response.GetPartitions() {
+			partitions = append(partitions, gin.H{
+				HTTPCollectionName: partition.GetCollectionName(),
+				HTTPCollectionID:   partition.GetCollectionID(),
+				HTTPPartitionName:  partition.GetPartitionName(),
+				HTTPPartitionID:    partition.GetPartitionID(),
+				"droppedTime":      partition.GetDroppedTime(),
+				"expireTime":       partition.GetExpireTime(),
+			})
+		}
+		HTTPReturn(c, http.StatusOK, gin.H{HTTPReturnCode: merr.Code(nil), HTTPReturnData: gin.H{
+			"collections": collections,
+			"partitions":  partitions,
+		}})
+	}
+	return resp, err
+}
+
+func (h *HandlersV2) undropCollection(ctx context.Context, c *gin.Context, anyReq any, dbName string) (interface{}, error) {
+	httpReq := anyReq.(*UndropCollectionReq)
+	req := &proxypb.UndropCollectionRequest{
+		DbName:         dbName,
+		CollectionName: httpReq.CollectionName,
+		CollectionID:   httpReq.CollectionID,
+	}
+	c.Set(ContextRequest, req)
+	resp, err := wrapperProxy(ctx, c, req, h.checkAuth, false, "/milvus.proto.proxy.RecycleBin/UndropCollection", func(reqCtx context.Context, req any) (interface{}, error) {
+		return h.proxy.UndropCollection(reqCtx, req.(*proxypb.UndropCollectionRequest))
+	})
+	if err == nil {
+		HTTPReturn(c, http.StatusOK, wrapperReturnDefault())
+	}
+	return resp, err
+}
+
+func (h *HandlersV2) undropPartition(ctx context.Context, c *gin.Context, anyReq any, dbName string) (interface{}, error) {
+	httpReq := anyReq.(*UndropPartitionReq)
+	req := &proxypb.UndropPartitionRequest{
+		DbName:         dbName,
+		CollectionName: httpReq.CollectionName,
+		PartitionName:  httpReq.PartitionName,
+		PartitionID:    httpReq.PartitionID,
+	}
+	c.Set(ContextRequest, req)
+	resp, err := wrapperProxy(ctx, c, req, h.checkAuth, false, "/milvus.proto.proxy.RecycleBin/UndropPartition", func(reqCtx context.Context, req any) (interface{}, error) {
+		return h.proxy.UndropPartition(reqCtx, req.(*proxypb.UndropPartitionRequest))
+	})
+	if err == nil {
+		HTTPReturn(c, http.StatusOK, wrapperReturnDefault())
+	}
+	return resp, err
+}
+
+func (h *HandlersV2) createSnapshot(ctx context.Context, c *gin.Context, anyReq any, dbName string) (interface{}, error) {
+	httpReq := anyReq.(*CreateSnapshotReq)
+	req := &proxypb.CreateSnapshotRequest{
+		DbName:         dbName,
+		CollectionName: httpReq.CollectionName,
+		SnapshotName:   httpReq.SnapshotName,
+		PartitionNames: httpReq.PartitionNames,
+	}
+	c.Set(ContextRequest, req)
+	resp, err := wrapperProxy(ctx, c, req, h.checkAuth, false, "/milvus.proto.proxy.Snapshot/CreateSnapshot", func(reqCtx context.Context, req any) (interface{}, error) {
+		return h.proxy.CreateSnapshot(reqCtx, req.(*proxypb.CreateSnapshotRequest))
+	})
+	if err == nil {
+		response := resp.(*proxypb.CreateSnapshotResponse)
+		HTTPReturn(c, http.StatusOK, gin.H{HTTPReturnCode: merr.Code(nil), HTTPReturnData: gin.H{
+			"snapshotID": response.GetSnapshotID(),
+			"ts":         response.GetTs(),
+		}})
+	}
+	return resp, err
+}
+
+func (h *HandlersV2) dropSnapshot(ctx context.Context, c *gin.Context, anyReq any, dbName string) (interface{}, error) {
+	httpReq := anyReq.(*SnapshotReq)
+	req := &proxypb.DropSnapshotRequest{
+		DbName:         dbName,
+		CollectionName: httpReq.CollectionName,
+		SnapshotName:   httpReq.SnapshotName,
+	}
+	c.Set(ContextRequest, req)
+	resp, err := wrapperProxy(ctx, c, req, h.checkAuth, false, "/milvus.proto.proxy.Snapshot/DropSnapshot", func(reqCtx context.Context, req any) (interface{}, error) {
+		return h.proxy.DropSnapshot(reqCtx, req.(*proxypb.DropSnapshotRequest))
+	})
+	if err == nil {
+		HTTPReturn(c, http.StatusOK, wrapperReturnDefault())
+	}
+	return resp, err
+}
+
+func (h *HandlersV2) listSnapshots(ctx context.Context, c *gin.Context, anyReq any, dbName string) (interface{}, error) {
+	httpReq := anyReq.(*ListSnapshotsReq)
+	req := &proxypb.ListSnapshotsRequest{
+		DbName:         dbName,
+		CollectionName: httpReq.CollectionName,
+		SnapshotName:   httpReq.SnapshotName,
+	}
+	c.Set(ContextRequest, req)
+	resp, err := wrapperProxy(ctx, c, req, h.checkAuth, false, "/milvus.proto.proxy.Snapshot/ListSnapshots", func(reqCtx context.Context, req any) (interface{}, error) {
+		return h.proxy.ListSnapshots(reqCtx, req.(*proxypb.ListSnapshotsRequest))
+	})
+	if err == nil {
+		response := resp.(*proxypb.ListSnapshotsResponse)
+		snapshots := make([]gin.H, 0, len(response.GetSnapshots()))
+		for _, snapshot := range response.GetSnapshots() {
+			snapshots = append(snapshots, gin.H{
+				"snapshotID":       snapshot.GetSnapshotID(),
+				"snapshotName":     snapshot.GetName(),
+				HTTPCollectionName: snapshot.GetCollectionName(),
+				HTTPCollectionID:   snapshot.GetCollectionID(),
+				HTTPPartitionNames: snapshot.GetPartitionNames(),
+				"ts":               snapshot.GetTs(),
+				"createTime":       snapshot.GetCreateTime(),
+				"numSegments":      snapshot.GetNumSegments(),
+				"numRows":          snapshot.GetNumRows(),
+			})
+		}
+		HTTPReturn(c, http.StatusOK, gin.H{HTTPReturnCode: merr.Code(nil), HTTPReturnData: snapshots})
+	}
+	return resp, err
+}
+
+func (h *HandlersV2) restoreSnapshot(ctx context.Context, c *gin.Context, anyReq any, dbName string) (interface{}, error) {
+	httpReq := anyReq.(*SnapshotReq)
+	req := &proxypb.RestoreSnapshotRequest{
+		DbName:         dbName,
+		CollectionName: httpReq.CollectionName,
+		SnapshotName:   httpReq.SnapshotName,
+	}
+	c.Set(ContextRequest, req)
+	resp, err := wrapperProxy(ctx, c, req, h.checkAuth, false, "/milvus.proto.proxy.Snapshot/RestoreSnapshot", func(reqCtx context.Context, req any) (interface{}, error) {
+		return h.proxy.RestoreSnapshot(reqCtx, req.(*proxypb.RestoreSnapshotRequest))
+	})
+	if err == nil {
+		HTTPReturn(c, http.StatusOK, wrapperReturnDefault())
+	}
+	return resp, err
+}
+
+func (h *HandlersV2) createExportJob(ctx context.Context, c *gin.Context, anyReq any, dbName string) (interface{}, error) {
+	httpReq := anyReq.(*ExportReq)
+	req := &proxypb.ExportRequest{
+		DbName:         dbName,
+		CollectionName: httpReq.CollectionName,
+		PartitionNames: httpReq.PartitionNames,
+		Expr:           httpReq.Filter,
+		OutputPath:     httpReq.OutputPath,
+	}
+	c.Set(ContextRequest, req)
+	resp, err := wrapperProxy(ctx, c, req, h.checkAuth, false, "/milvus.proto.proxy.Export/Export", func(reqCtx context.Context, req any) (interface{}, error) {
+		return h.proxy.Export(reqCtx, req.(*proxypb.ExportRequest))
+	})
+	if err == nil {
+		response := resp.(*proxypb.ExportResponse)
+		HTTPReturn(c, http.StatusOK, gin.H{HTTPReturnCode: merr.Code(nil), HTTPReturnData: gin.H{"jobId": response.GetJobID()}})
+	}
+	return resp, err
+}
+
+// parseExportJobID parses the job id, which is a string in the requests like the one of import jobs.
+func parseExportJobID(c *gin.Context, jobID string) (int64, error) {
+	id, err := strconv.ParseInt(jobID, 10, 64)
+	if err != nil {
+		HTTPAbortReturn(c, http.StatusOK, gin.H{
+			HTTPReturnCode:    merr.Code(merr.ErrIncorrectParameterFormat),
+			HTTPReturnMessage: merr.ErrIncorrectParameterFormat.Error() + ", error: " + err.Error(),
+		})
+		return 0, err
+	}
+	return id, nil
+}
+
+func (h *HandlersV2) describeExportJob(ctx context.Context, c *gin.Context, anyReq any, dbName string) (interface{}, error) {
+	jobID, err := parseExportJobID(c, anyReq.(JobIDGetter).GetJobID())
+	if err != nil {
+		return nil, err
+	}
+	req := &proxypb.GetExportProgressRequest{
+		JobID: jobID,
+	}
+	c.Set(ContextRequest, req)
+	resp, err := wrapperProxy(ctx, c, req, h.checkAuth, false, "/milvus.proto.proxy.Export/GetExportProgress", func(reqCtx context.Context, req any) (interface{}, error) {
+		return h.proxy.GetExportProgress(reqCtx, req.(*proxypb.GetExportProgressRequest))
+	})
+	if err == nil {
+		response := resp.(*proxypb.GetExportProgressResponse)
+		HTTPReturn(c, http.StatusOK, gin.H{HTTPReturnCode: merr.Code(nil), HTTPReturnData: gin.H{
+			"jobId":            response.GetJobID(),
+			HTTPDbName:         response.GetDbName(),
+			HTTPCollectionName: response.GetCollectionName(),
+			"state":            response.GetState(),
+			"reason":           response.GetReason(),
+			"progress":         response.GetProgress(),
+			"processedRows":    response.GetProcessedRows(),
+			"exportedRows":     response.GetExportedRows(),
+			"totalRows":        response.GetTotalRows(),
+			"outputPath":       response.GetOutputPath(),
+			"files":            response.GetFiles(),
+			"startTime":        response.GetStartTime(),
+			"completeTime":     response.GetCompleteTime(),
+		}})
+	}
+	return resp, err
+}
+
+func (h *HandlersV2) cancelExportJob(ctx context.Context, c *gin.Context, anyReq any, dbName string) (interface{}, error) {
+	jobID, err := parseExportJobID(c, anyReq.(JobIDGetter).GetJobID())
+	if err != nil {
+		return nil, err
+	}
+	req := &proxypb.CancelExportRequest{
+		JobID: jobID,
+	}
+	c.Set(ContextRequest, req)
+	resp, err := wrapperProxy(ctx, c, req, h.checkAuth, false, "/milvus.proto.proxy.Export/CancelExport", func(reqCtx context.Context, req any) (interface{}, error) {
+		return h.proxy.CancelExport(reqCtx, req.(*proxypb.CancelExportRequest))
+	})
+	if err == nil {
+		HTTPReturn(c, http.StatusOK, wrapperReturnDefault())
+	}
+	return resp, err
+}
+
+func (h *HandlersV2) setRowPolicy(ctx context.Context, c *gin.Context, anyReq any, dbName string) (interface{}, error) {
+	httpReq := anyReq.(*SetRowPolicyReq)
+	req := &proxypb.SetRowPolicyRequest{
+		DbName:         dbName,
+		RoleName:       httpReq.RoleName,
+		CollectionName: httpReq.CollectionName,
+		Expr:           httpReq.Filter,
+	}
+	c.Set(ContextRequest, req)
+	resp, err := wrapperProxy(ctx, c, req, h.checkAuth, false, "/milvus.proto.proxy.RowPolicy/SetRowPolicy", func(reqCtx context.Context, req any) (interface{}, error) {
+		return h.proxy.SetRowPolicy(reqCtx, req.(*proxypb.SetRowPolicyRequest))
+	})
+	if err == nil {
+		HTTPReturn(c, http.StatusOK, wrapperReturnDefault())
+	}
+	return resp, err
+}
+
+func (h *HandlersV2) dropRowPolicy(ctx context.Context, c *gin.Context, anyReq any, dbName string) (interface{}, error) {
+	httpReq := anyReq.(*RowPolicyReq)
+	req := &proxypb.DropRowPolicyRequest{
+		DbName:         dbName,
+		RoleName:       httpReq.RoleName,
+		CollectionName: httpReq.CollectionName,
+	}
+	c.Set(ContextRequest, req)
+	resp, err := wrapperProxy(ctx, c, req, h.checkAuth, false, "/milvus.proto.proxy.RowPolicy/DropRowPolicy", func(reqCtx context.Context, req any) (interface{}, error) {
+		return h.proxy.DropRowPolicy(reqCtx, req.(*proxypb.DropRowPolicyRequest))
+	})
+	if err == nil {
+		HTTPReturn(c, http.StatusOK, wrapperReturnDefault())
+	}
+	return resp, err
+}
+
+func (h *HandlersV2) listRowPolicies(ctx context.Context, c *gin.Context, anyReq any, dbName string) (interface{}, error) {
+	httpReq := anyReq.(*ListRowPoliciesReq)
+	req := &proxypb.ListRowPoliciesRequest{
+		DbName:         dbName,
+		RoleName:       httpReq.RoleName,
+		CollectionName: httpReq.CollectionName,
+	}
+	c.Set(ContextRequest, req)
+	resp, err := wrapperProxy(ctx, c, req, h.checkAuth, false, "/milvus.proto.proxy.RowPolicy/ListRowPolicies", func(reqCtx context.Context, req any) (interface{}, error) {
+		return h.proxy.ListRowPolicies(reqCtx, req.(*proxypb.ListRowPoliciesRequest))
+	})
+	if err == nil {
+		response := resp.(*proxypb.ListRowPoliciesResponse)
+		policies := make([]gin.H, 0, len(response.GetPolicies()))
+		for _, policy := range response.GetPolicies() {
+			policies = append(policies, gin.H{
+				HTTPRoleName:       policy.GetRole(),
+				HTTPDbName:         policy.GetDbName(),
+				HTTPCollectionName: policy.GetCollectionName(),
+				"filter":           policy.GetExpr(),
+			})
+		}
+		HTTPReturn(c, http.StatusOK, gin.H{HTTPReturnCode: merr.Code(nil), HTTPReturnData: policies})
+	}
+	return resp, err
+}
+
+// changeEventsWriter sends the change events as server-sent events.
+type changeEventsWriter struct {
+	grpc.ServerStream
+
+	ctx     context.Context
+	c       *gin.Context
+	allowJS bool
+}
+
+func (w *changeEventsWriter) Context() context.Context {
+	return w.ctx
+}
+
+func (w *changeEventsWriter) Send(resp *proxypb.ChangeEvents) error {
+	if err := merr.Error(resp.GetStatus()); err != nil {
+		w.c.SSEvent("error", gin.H{HTTPReturnCode: merr.Code(err), HTTPReturnMessage: err.Error()})
+		w.c.Writer.Flush()
+		return nil
+	}
+	events := make([]gin.H, 0, len(resp.GetEvents()))
+	for _, event := range resp.GetEvents() {
+		rows, err := buildQueryResp(0, nil, event.GetFieldsData(), event.GetPrimaryKey(), nil, w.allowJS)
+		if err != nil {
+			return err
+		}
+		events = append(events, gin.H{
+			"type":            strings.ToLower(strings.TrimPrefix(event.GetType().String(), "Change")),
+			"timestamp":       event.GetTimestamp(),
+			HTTPPartitionName: event.GetPartitionName(),
+			"row":             rows[0],
+		})
+	}
+	w.c.SSEvent("changes", gin.H{"events": events, "resumeToken": resp.GetResumeToken()})
+	w.c.Writer.Flush()
+	return nil
+}
+
+func (h *HandlersV2) subscribeChanges(ctx context.Context, c *gin.Context, anyReq any, dbName string) (interface{}, error) {
+	httpReq := anyReq.(*SubscribeChangesReq)
+	req := &proxypb.SubscribeChangesRequest{
+		DbName:         dbName,
+		CollectionName: httpReq.CollectionName,
+		OutputFields:   httpReq.OutputFields,
+		StartTs:        httpReq.StartTs,
+		ResumeToken:    httpReq.ResumeToken,
+	}
+	c.Set(ContextRequest, req)
+	if h.checkAuth {
+		if err := checkAuthorizationV2(ctx, c, false, req); err != nil {
+			return nil, err
+		}
+	}
+	// stop the subscription once the client disconnects
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	stop := context.AfterFunc(c.Request.Context(), cancel)
+	defer stop()
+
+	allowJS, _ := strconv.ParseBool(c.Request.Header.Get(HTTPHeaderAllowInt64))
+	err := h.proxy.SubscribeChanges(req, &changeEventsWriter{ctx: ctx, c: c, allowJS: allowJS})
+	if err != nil {
+		log.Ctx(ctx).Warn("high level restful api, change stream failed", zap.Error(err))
+	}
+	return nil, err
+}
+
 func (h *HandlersV2) GetCollectionSchema(ctx context.Context, c *gin.Context, dbName, collectionName string) (*schemapb.CollectionSchema, error) {
 	collSchema, err := proxy.GetCachedCollectionSchema(ctx, dbName, collectionName)
 	if err == nil {
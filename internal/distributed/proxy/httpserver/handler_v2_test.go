@@ -36,6 +36,7 @@ import (
 	"github.com/milvus-io/milvus/internal/json"
 	"github.com/milvus-io/milvus/internal/mocks"
 	"github.com/milvus-io/milvus/internal/proto/internalpb"
+	"github.com/milvus-io/milvus/internal/proto/proxypb"
 	"github.com/milvus-io/milvus/internal/proxy"
 	"github.com/milvus-io/milvus/internal/types"
 	"github.com/milvus-io/milvus/pkg/util"
@@ -1521,6 +1522,40 @@ func TestMethodPost(t *testing.T) {
 		Reason:   "",
 		Progress: 100,
 	}, nil).Twice()
+	mp.EXPECT().BeginTransaction(mock.Anything, mock.Anything).Return(&proxypb.BeginTransactionResponse{
+		Status: commonSuccessStatus, TxnID: 1234567890, KeepaliveMs: 10000,
+	}, nil).Once()
+	mp.EXPECT().CommitTransaction(mock.Anything, mock.Anything).Return(&proxypb.CommitTransactionResponse{
+		Status: commonSuccessStatus, Timestamp: 100,
+	}, nil).Once()
+	mp.EXPECT().RollbackTransaction(mock.Anything, mock.Anything).Return(commonSuccessStatus, nil).Once()
+	mp.EXPECT().ListRecycleBin(mock.Anything, mock.Anything).Return(&proxypb.ListRecycleBinResponse{
+		Status:      commonSuccessStatus,
+		Collections: []*proxypb.TrashedCollection{{CollectionID: 1, CollectionName: DefaultCollectionName, DroppedTime: 100, ExpireTime: 200}},
+		Partitions:  []*proxypb.TrashedPartition{{CollectionID: 2, CollectionName: DefaultCollectionName, PartitionID: 3, PartitionName: DefaultPartitionName}},
+	}, nil).Once()
+	mp.EXPECT().UndropCollection(mock.Anything, mock.Anything).Return(commonSuccessStatus, nil).Once()
+	mp.EXPECT().UndropPartition(mock.Anything, mock.Anything).Return(commonSuccessStatus, nil).Once()
+	mp.EXPECT().CreateSnapshot(mock.Anything, mock.Anything).Return(&proxypb.CreateSnapshotResponse{
+		Status: commonSuccessStatus, SnapshotID: 1, Ts: 100,
+	}, nil).Once()
+	mp.EXPECT().DropSnapshot(mock.Anything, mock.Anything).Return(commonSuccessStatus, nil).Once()
+	mp.EXPECT().ListSnapshots(mock.Anything, mock.Anything).Return(&proxypb.ListSnapshotsResponse{
+		Status:    commonSuccessStatus,
+		Snapshots: []*proxypb.SnapshotSummary{{SnapshotID: 1, Name: "snapshot1", CollectionName: DefaultCollectionName, NumSegments: 2, NumRows: 100}},
+	}, nil).Once()
+	mp.EXPECT().RestoreSnapshot(mock.Anything, mock.Anything).Return(commonSuccessStatus, nil).Once()
+	mp.EXPECT().Export(mock.Anything, mock.Anything).Return(&proxypb.ExportResponse{Status: commonSuccessStatus, JobID: 1}, nil).Once()
+	mp.EXPECT().GetExportProgress(mock.Anything, mock.Anything).Return(&proxypb.GetExportProgressResponse{
+		Status: commonSuccessStatus, JobID: 1234567890, CollectionName: DefaultCollectionName, State: "ExportCompleted", Progress: 100,
+	}, nil).Once()
+	mp.EXPECT().CancelExport(mock.Anything, mock.Anything).Return(commonSuccessStatus, nil).Once()
+	mp.EXPECT().SetRowPolicy(mock.Anything, mock.Anything).Return(commonSuccessStatus, nil).Once()
+	mp.EXPECT().DropRowPolicy(mock.Anything, mock.Anything).Return(commonSuccessStatus, nil).Once()
+	mp.EXPECT().ListRowPolicies(mock.Anything, mock.Anything).Return(&proxypb.ListRowPoliciesResponse{
+		Status:   commonSuccessStatus,
+		Policies: []*internalpb.RowPolicy{{Role: util.RoleAdmin, DbName: util.DefaultDBName, CollectionName: DefaultCollectionName, Expr: "book_id > 0"}},
+	}, nil).Once()
 	testEngine := initHTTPServerV2(mp, false)
 	queryTestCases := []rawTestCase{}
 	queryTestCases = append(queryTestCases, rawTestCase{
@@ -1606,6 +1641,54 @@ func TestMethodPost(t *testing.T) {
 	queryTestCases = append(queryTestCases, rawTestCase{
 		path: versionalV2(PrivilegeGroupCategory, RemovePrivilegesFromGroupAction),
 	})
+	queryTestCases = append(queryTestCases, rawTestCase{
+		path: versionalV2(TransactionCategory, BeginAction),
+	})
+	queryTestCases = append(queryTestCases, rawTestCase{
+		path: versionalV2(TransactionCategory, CommitAction),
+	})
+	queryTestCases = append(queryTestCases, rawTestCase{
+		path: versionalV2(TransactionCategory, RollbackAction),
+	})
+	queryTestCases = append(queryTestCases, rawTestCase{
+		path: versionalV2(RecycleBinCategory, ListAction),
+	})
+	queryTestCases = append(queryTestCases, rawTestCase{
+		path: versionalV2(CollectionCategory, UndropAction),
+	})
+	queryTestCases = append(queryTestCases, rawTestCase{
+		path: versionalV2(PartitionCategory, UndropAction),
+	})
+	queryTestCases = append(queryTestCases, rawTestCase{
+		path: versionalV2(SnapshotCategory, CreateAction),
+	})
+	queryTestCases = append(queryTestCases, rawTestCase{
+		path: versionalV2(SnapshotCategory, DropAction),
+	})
+	queryTestCases = append(queryTestCases, rawTestCase{
+		path: versionalV2(SnapshotCategory, ListAction),
+	})
+	queryTestCases = append(queryTestCases, rawTestCase{
+		path: versionalV2(SnapshotCategory, RestoreAction),
+	})
+	queryTestCases = append(queryTestCases, rawTestCase{
+		path: versionalV2(ExportJobCategory, CreateAction),
+	})
+	queryTestCases = append(queryTestCases, rawTestCase{
+		path: versionalV2(ExportJobCategory, DescribeAction),
+	})
+	queryTestCases = append(queryTestCases, rawTestCase{
+		path: versionalV2(ExportJobCategory, CancelAction),
+	})
+	queryTestCases = append(queryTestCases, rawTestCase{
+		path: versionalV2(RowPolicyCategory, CreateAction),
+	})
+	queryTestCases = append(queryTestCases, rawTestCase{
+		path: versionalV2(RowPolicyCategory, DropAction),
+	})
+	queryTestCases = append(queryTestCases, rawTestCase{
+		path: versionalV2(RowPolicyCategory, ListAction),
+	})
 
 	for _, testcase := range queryTestCases {
 		t.Run(testcase.path, func(t *testing.T) {
@@ -1618,7 +1701,7 @@ func TestMethodPost(t *testing.T) {
 				`"roleName": "` + util.RoleAdmin + `", "objectType": "Global", "objectName": "*", "privilege": "*",` +
 				`"privilegeGroupName": "pg", "privileges": ["create", "drop"],` +
 				`"aliasName": "` + DefaultAliasName + `",` +
-				`"jobId": "1234567890",` +
+				`"jobId": "1234567890", "txnId": "1234567890", "snapshotName": "snapshot1",` +
 				`"files": [["book.json"]]` +
 				`}`))
 			req := httptest.NewRequest(http.MethodPost, testcase.path, bodyReader)
@@ -1806,6 +1889,67 @@ func TestAllowInt64(t *testing.T) {
 	validateTestCases(t, testEngine, queryTestCases, true)
 }
 
+func TestSubscribeChanges(t *testing.T) {
+	paramtable.Init()
+	mp := mocks.NewMockProxy(t)
+	testEngine := initHTTPServerV2(mp, false)
+	path := versionalV2(ChangeStreamCategory, SubscribeAction)
+
+	t.Run("changes", func(t *testing.T) {
+		mp.EXPECT().SubscribeChanges(mock.Anything, mock.Anything).RunAndReturn(func(req *proxypb.SubscribeChangesRequest, server proxypb.ChangeStream_SubscribeChangesServer) error {
+			assert.Equal(t, "book", req.GetCollectionName())
+			assert.Equal(t, []string{"word_count"}, req.GetOutputFields())
+			assert.Equal(t, "token0", req.GetResumeToken())
+			return server.Send(&proxypb.ChangeEvents{
+				Status: merr.Success(),
+				Events: []*proxypb.ChangeEvent{
+					{
+						Type:       proxypb.ChangeEventType_ChangeInsert,
+						Timestamp:  100,
+						PrimaryKey: &schemapb.IDs{IdField: &schemapb.IDs_IntId{IntId: &schemapb.LongArray{Data: []int64{1}}}},
+						FieldsData: []*schemapb.FieldData{{
+							Type: schemapb.DataType_Int64, FieldName: "word_count",
+							Field: &schemapb.FieldData_Scalars{Scalars: &schemapb.ScalarField{
+								Data: &schemapb.ScalarField_LongData{LongData: &schemapb.LongArray{Data: []int64{10}}},
+							}},
+						}},
+					},
+					{
+						Type:       proxypb.ChangeEventType_ChangeDelete,
+						Timestamp:  101,
+						PrimaryKey: &schemapb.IDs{IdField: &schemapb.IDs_IntId{IntId: &schemapb.LongArray{Data: []int64{2}}}},
+					},
+				},
+				ResumeToken: "token1",
+			})
+		}).Once()
+		req := httptest.NewRequest(http.MethodPost, path, bytes.NewReader([]byte(`{"collectionName": "book", "outputFields": ["word_count"], "resumeToken": "token0"}`)))
+		req.Header.Set(HTTPHeaderAllowInt64, "true")
+		w := httptest.NewRecorder()
+		testEngine.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "text/event-stream", w.Header().Get("Content-Type"))
+		body := w.Body.String()
+		assert.Contains(t, body, "event:changes")
+		assert.Contains(t, body, `"resumeToken":"token1"`)
+		assert.Contains(t, body, `"type":"insert"`)
+		assert.Contains(t, body, `"word_count":10`)
+		assert.Contains(t, body, `"id":2`)
+		assert.Contains(t, body, `"type":"delete"`)
+	})
+
+	t.Run("failure", func(t *testing.T) {
+		mp.EXPECT().SubscribeChanges(mock.Anything, mock.Anything).RunAndReturn(func(req *proxypb.SubscribeChangesRequest, server proxypb.ChangeStream_SubscribeChangesServer) error {
+			return server.Send(&proxypb.ChangeEvents{Status: merr.Status(merr.WrapErrCollectionNotFound("book"))})
+		}).Once()
+		req := httptest.NewRequest(http.MethodPost, path, bytes.NewReader([]byte(`{"collectionName": "book"}`)))
+		w := httptest.NewRecorder()
+		testEngine.ServeHTTP(w, req)
+		assert.Contains(t, w.Body.String(), "event:error")
+		assert.Contains(t, w.Body.String(), "collection not found")
+	})
+}
+
 func generateCollectionSchemaWithVectorFields() *schemapb.CollectionSchema {
 	collSchema := generateCollectionSchema(schemapb.DataType_Int64, false, true)
 	binaryVectorField := generateVectorFieldSchema(schemapb.DataType_BinaryVector)
@@ -133,6 +133,129 @@ type JobIDReq struct {
 
 func (req *JobIDReq) GetJobID() string { return req.JobID }
 
+type BeginTransactionReq struct {
+	DbName         string `json:"dbName"`
+	CollectionName string `json:"collectionName" binding:"required"`
+	KeepaliveMs    int64  `json:"keepaliveMs"`
+}
+
+func (req *BeginTransactionReq) GetDbName() string { return req.DbName }
+
+func (req *BeginTransactionReq) GetCollectionName() string { return req.CollectionName }
+
+type TxnIDReq struct {
+	TxnID string `json:"txnId" binding:"required"`
+}
+
+func (req *TxnIDReq) GetTxnID() string { return req.TxnID }
+
+type UndropCollectionReq struct {
+	DbName         string `json:"dbName"`
+	CollectionName string `json:"collectionName" binding:"required"`
+	CollectionID   int64  `json:"collectionID"` // required if multiple dropped collections have the same name
+}
+
+func (req *UndropCollectionReq) GetDbName() string { return req.DbName }
+
+func (req *UndropCollectionReq) GetCollectionName() string { return req.CollectionName }
+
+type UndropPartitionReq struct {
+	DbName         string `json:"dbName"`
+	CollectionName string `json:"collectionName" binding:"required"`
+	PartitionName  string `json:"partitionName" binding:"required"`
+	PartitionID    int64  `json:"partitionID"` // required if multiple dropped partitions have the same name
+}
+
+func (req *UndropPartitionReq) GetDbName() string { return req.DbName }
+
+func (req *UndropPartitionReq) GetCollectionName() string { return req.CollectionName }
+
+func (req *UndropPartitionReq) GetPartitionName() string { return req.PartitionName }
+
+type CreateSnapshotReq struct {
+	DbName         string   `json:"dbName"`
+	CollectionName string   `json:"collectionName" binding:"required"`
+	SnapshotName   string   `json:"snapshotName" binding:"required"`
+	PartitionNames []string `json:"partitionNames"` // all the partitions if empty
+}
+
+func (req *CreateSnapshotReq) GetDbName() string { return req.DbName }
+
+func (req *CreateSnapshotReq) GetCollectionName() string { return req.CollectionName }
+
+type SnapshotReq struct {
+	DbName         string `json:"dbName"`
+	CollectionName string `json:"collectionName" binding:"required"`
+	SnapshotName   string `json:"snapshotName" binding:"required"`
+}
+
+func (req *SnapshotReq) GetDbName() string { return req.DbName }
+
+func (req *SnapshotReq) GetCollectionName() string { return req.CollectionName }
+
+type ListSnapshotsReq struct {
+	DbName         string `json:"dbName"`
+	CollectionName string `json:"collectionName" binding:"required"`
+	SnapshotName   string `json:"snapshotName"`
+}
+
+func (req *ListSnapshotsReq) GetDbName() string { return req.DbName }
+
+func (req *ListSnapshotsReq) GetCollectionName() string { return req.CollectionName }
+
+type ExportReq struct {
+	DbName         string   `json:"dbName"`
+	CollectionName string   `json:"collectionName" binding:"required"`
+	PartitionNames []string `json:"partitionNames"` // all the partitions if empty
+	Filter         string   `json:"filter"`
+	OutputPath     string   `json:"outputPath"`
+}
+
+func (req *ExportReq) GetDbName() string { return req.DbName }
+
+func (req *ExportReq) GetCollectionName() string { return req.CollectionName }
+
+type SetRowPolicyReq struct {
+	DbName         string `json:"dbName"`
+	RoleName       string `json:"roleName" binding:"required"`
+	CollectionName string `json:"collectionName" binding:"required"`
+	Filter         string `json:"filter"`
+}
+
+func (req *SetRowPolicyReq) GetDbName() string { return req.DbName }
+
+func (req *SetRowPolicyReq) GetCollectionName() string { return req.CollectionName }
+
+type RowPolicyReq struct {
+	DbName         string `json:"dbName"`
+	RoleName       string `json:"roleName" binding:"required"`
+	CollectionName string `json:"collectionName" binding:"required"`
+}
+
+func (req *RowPolicyReq) GetDbName() string { return req.DbName }
+
+func (req *RowPolicyReq) GetCollectionName() string { return req.CollectionName }
+
+type ListRowPoliciesReq struct {
+	DbName         string `json:"dbName"`
+	RoleName       string `json:"roleName"`
+	CollectionName string `json:"collectionName"`
+}
+
+func (req *ListRowPoliciesReq) GetDbName() string { return req.DbName }
+
+type SubscribeChangesReq struct {
+	DbName         string   `json:"dbName"`
+	CollectionName string   `json:"collectionName" binding:"required"`
+	OutputFields   []string `json:"outputFields"`
+	StartTs        uint64   `json:"startTs"`
+	ResumeToken    string   `json:"resumeToken"`
+}
+
+func (req *SubscribeChangesReq) GetDbName() string { return req.DbName }
+
+func (req *SubscribeChangesReq) GetCollectionName() string { return req.CollectionName }
+
 type QueryReqV2 struct {
 	DbName         string                 `json:"dbName"`
 	CollectionName string                 `json:"collectionName" binding:"required"`
@@ -142,6 +265,7 @@ type QueryReqV2 struct {
 	Limit          int32                  `json:"limit"`
 	Offset         int32                  `json:"offset"`
 	ExprParams     map[string]interface{} `json:"exprParams"`
+	Profile        bool                   `json:"profile"`
 }
 
 func (req *QueryReqV2) GetDbName() string { return req.DbName }
@@ -200,6 +324,7 @@ type SearchReqV2 struct {
 	SearchParams     searchParams           `json:"searchParams"`
 	ConsistencyLevel string                 `json:"consistencyLevel"`
 	ExprParams       map[string]interface{} `json:"exprParams"`
+	Profile          bool                   `json:"profile"`
 	// not use Params any more, just for compatibility
 	Params map[string]float64 `json:"params"`
 }
@@ -235,6 +360,7 @@ type HybridSearchReq struct {
 	StrictGroupSize  bool           `json:"strictGroupSize"`
 	OutputFields     []string       `json:"outputFields"`
 	ConsistencyLevel string         `json:"consistencyLevel"`
+	Profile          bool           `json:"profile"`
 }
 
 func (req *HybridSearchReq) GetDbName() string { return req.DbName }
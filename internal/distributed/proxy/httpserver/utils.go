@@ -45,6 +45,7 @@ import (
 	"github.com/milvus-io/milvus/pkg/metrics"
 	"github.com/milvus-io/milvus/pkg/util"
 	"github.com/milvus-io/milvus/pkg/util/funcutil"
+	"github.com/milvus-io/milvus/pkg/util/geo"
 	"github.com/milvus-io/milvus/pkg/util/merr"
 	"github.com/milvus-io/milvus/pkg/util/parameterutil"
 	"github.com/milvus-io/milvus/pkg/util/paramtable"
@@ -98,6 +99,16 @@ func GetAuthorization(c *gin.Context) string {
 	return strings.TrimPrefix(auth, "Bearer ")
 }
 
+// newContextWithMetadata sets the user and the database of the request to ctx,
+// together with the roles mapped from the jwt of the request if any.
+func newContextWithMetadata(ctx context.Context, c *gin.Context, username string, dbName string) context.Context {
+	ctx = proxy.NewContextWithMetadata(ctx, username, dbName)
+	if roles, ok := c.Get(ContextJWTRoles); ok {
+		ctx = proxy.NewContextWithJWTRoles(ctx, roles.([]string))
+	}
+	return ctx
+}
+
 // find the primary field of collection
 func getPrimaryField(schema *schemapb.CollectionSchema) (*schemapb.FieldSchema, bool) {
 	for _, field := range schema.Fields {
@@ -544,6 +555,12 @@ func checkAndSetData(body string, collSchema *schemapb.CollectionSchema) (error,
 					}
 				case schemapb.DataType_JSON:
 					reallyData[fieldName] = []byte(dataString)
+				case schemapb.DataType_Geometry:
+					wkb, err := geo.WKTToWKB(dataString)
+					if err != nil {
+						return merr.WrapErrParameterInvalid(schemapb.DataType_name[int32(fieldType)], dataString, err.Error()), reallyDataArray, validDataMap
+					}
+					reallyData[fieldName] = wkb
 				case schemapb.DataType_Float:
 					result, err := cast.ToFloat32E(dataString)
 					if err != nil {
@@ -751,6 +768,8 @@ func anyToColumns(rows []map[string]interface{}, validDataMap map[string][]bool,
 			data = make([]*schemapb.ScalarField, 0, rowsLen)
 		case schemapb.DataType_JSON:
 			data = make([][]byte, 0, rowsLen)
+		case schemapb.DataType_Geometry:
+			data = make([][]byte, 0, rowsLen)
 		case schemapb.DataType_FloatVector:
 			data = make([][]float32, 0, rowsLen)
 			dim, _ := getDim(field)
@@ -840,6 +859,8 @@ func anyToColumns(rows []map[string]interface{}, validDataMap map[string][]bool,
 				nameColumns[field.Name] = append(nameColumns[field.Name].([]*schemapb.ScalarField), candi.v.Interface().(*schemapb.ScalarField))
 			case schemapb.DataType_JSON:
 				nameColumns[field.Name] = append(nameColumns[field.Name].([][]byte), candi.v.Interface().([]byte))
+			case schemapb.DataType_Geometry:
+				nameColumns[field.Name] = append(nameColumns[field.Name].([][]byte), candi.v.Interface().([]byte))
 			case schemapb.DataType_FloatVector:
 				nameColumns[field.Name] = append(nameColumns[field.Name].([][]float32), candi.v.Interface().([]float32))
 			case schemapb.DataType_BinaryVector:
@@ -1004,6 +1025,16 @@ func anyToColumns(rows []map[string]interface{}, validDataMap map[string][]bool,
 					},
 				},
 			}
+		case schemapb.DataType_Geometry:
+			colData.Field = &schemapb.FieldData_Scalars{
+				Scalars: &schemapb.ScalarField{
+					Data: &schemapb.ScalarField_GeometryData{
+						GeometryData: &schemapb.GeometryArray{
+							Data: column.([][]byte),
+						},
+					},
+				},
+			}
 		case schemapb.DataType_FloatVector:
 			dim := nameDims[name]
 			arr, err := convertFloatVectorToArray(column.([][]float32), dim)
@@ -1270,6 +1301,8 @@ func buildQueryResp(rowsNum int64, needFields []string, fieldDataList []*schemap
 				rowsNum = int64(len(fieldDataList[0].GetScalars().GetArrayData().Data))
 			case schemapb.DataType_JSON:
 				rowsNum = int64(len(fieldDataList[0].GetScalars().GetJsonData().Data))
+			case schemapb.DataType_Geometry:
+				rowsNum = int64(len(fieldDataList[0].GetScalars().GetGeometryData().Data))
 			case schemapb.DataType_BinaryVector:
 				rowsNum = int64(len(fieldDataList[0].GetVectors().GetBinaryVector())*8) / fieldDataList[0].GetVectors().GetDim()
 			case schemapb.DataType_FloatVector:
@@ -1380,6 +1413,17 @@ func buildQueryResp(rowsNum int64, needFields []string, fieldDataList []*schemap
 						continue
 					}
 					row[fieldDataList[j].FieldName] = fieldDataList[j].GetScalars().GetArrayData().Data[i]
+				case schemapb.DataType_Geometry:
+					if len(fieldDataList[j].ValidData) != 0 && !fieldDataList[j].ValidData[i] {
+						row[fieldDataList[j].FieldName] = nil
+						continue
+					}
+					wkt, err := geo.WKBToWKT(fieldDataList[j].GetScalars().GetGeometryData().Data[i])
+					if err != nil {
+						log.Error(fmt.Sprintf("[BuildQueryResp] convert geometry to wkt error %s", err.Error()))
+						return nil, err
+					}
+					row[fieldDataList[j].FieldName] = wkt
 				case schemapb.DataType_JSON:
 					if len(fieldDataList[j].ValidData) != 0 && !fieldDataList[j].ValidData[i] {
 						row[fieldDataList[j].FieldName] = nil
@@ -32,6 +32,7 @@ import (
 	"github.com/milvus-io/milvus-proto/go-api/v2/schemapb"
 	"github.com/milvus-io/milvus/internal/json"
 	"github.com/milvus-io/milvus/pkg/common"
+	"github.com/milvus-io/milvus/pkg/util/geo"
 	"github.com/milvus-io/milvus/pkg/util/typeutil"
 )
 
@@ -2041,6 +2042,40 @@ func TestArray(t *testing.T) {
 	assert.Equal(t, len(collectionSchema.Fields), len(data))
 }
 
+func TestGeometry(t *testing.T) {
+	collectionSchema := generateCollectionSchema(schemapb.DataType_Int64, false, false)
+	collectionSchema.Fields = append(collectionSchema.Fields, &schemapb.FieldSchema{
+		FieldID:  common.StartOfUserFieldID + 3,
+		Name:     "location",
+		DataType: schemapb.DataType_Geometry,
+	})
+	body := `{"data": [{"book_id": 1, "word_count": 2, "book_intro": [0.1, 0.2], "location": "POINT(116.4 39.9)"},
+		{"book_id": 2, "word_count": 3, "book_intro": [0.3, 0.4], "location": "POLYGON((0 0, 1 0, 1 1, 0 0))"}]}`
+	err, rows, validRows := checkAndSetData(body, collectionSchema)
+	assert.NoError(t, err)
+	assert.Equal(t, geo.NewPoint(116.4, 39.9).WKB(), rows[0]["location"])
+	data, err := anyToColumns(rows, validRows, collectionSchema, true)
+	assert.NoError(t, err)
+
+	var geometryData *schemapb.FieldData
+	for _, fieldData := range data {
+		if fieldData.GetFieldName() == "location" {
+			geometryData = fieldData
+		}
+	}
+	assert.Equal(t, schemapb.DataType_Geometry, geometryData.GetType())
+	assert.Len(t, geometryData.GetScalars().GetGeometryData().GetData(), 2)
+
+	queryRows, err := buildQueryResp(0, []string{"location"}, []*schemapb.FieldData{geometryData}, nil, nil, true)
+	assert.NoError(t, err)
+	assert.Equal(t, "POINT(116.4 39.9)", queryRows[0]["location"])
+	assert.Equal(t, "POLYGON((0 0, 1 0, 1 1, 0 0))", queryRows[1]["location"])
+
+	body = `{"data": [{"book_id": 1, "word_count": 2, "book_intro": [0.1, 0.2], "location": "POINT(200 0)"}]}`
+	err, _, _ = checkAndSetData(body, collectionSchema)
+	assert.Error(t, err)
+}
+
 func TestVector(t *testing.T) {
 	floatVector := "vector-float"
 	binaryVector := "vector-binary"
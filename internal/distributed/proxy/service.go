@@ -137,6 +137,15 @@ func authenticate(c *gin.Context) {
 	}
 	rawToken := httpserver.GetAuthorization(c)
 	if rawToken != "" && !strings.Contains(rawToken, util.CredentialSeperator) {
+		if proxy.Params.CommonCfg.JWTEnabled.GetAsBool() && strings.Count(rawToken, ".") == 2 {
+			user, roles, err := proxy.VerifyJWT(rawToken)
+			if err == nil {
+				c.Set(httpserver.ContextUsername, user)
+				c.Set(httpserver.ContextJWTRoles, roles)
+				return
+			}
+			log.Warn("fail to verify jwt", zap.Error(err))
+		}
 		user, err := proxy.VerifyAPIKey(rawToken)
 		if err == nil {
 			c.Set(httpserver.ContextUsername, user)
@@ -236,8 +245,13 @@ func (s *Server) startExternalGrpc(errChan chan error) {
 	}
 	log.Debug("Get proxy rate limiter done")
 
-	var unaryServerOption grpc.ServerOption
+	var unaryServerOption, streamServerOption grpc.ServerOption
 	if enableCustomInterceptor {
+		streamServerOption = grpc.StreamInterceptor(grpc_middleware.ChainStreamServer(
+			grpc_auth.StreamServerInterceptor(proxy.AuthenticationInterceptor),
+			proxy.DatabaseStreamInterceptor(),
+			proxy.StreamServerInterceptor(proxy.PrivilegeInterceptor),
+		))
 		unaryServerOption = grpc.UnaryInterceptor(grpc_middleware.ChainUnaryServer(
 			accesslog.UnaryAccessLogInterceptor,
 			grpc_auth.UnaryServerInterceptor(proxy.AuthenticationInterceptor),
@@ -252,6 +266,7 @@ func (s *Server) startExternalGrpc(errChan chan error) {
 		))
 	} else {
 		unaryServerOption = grpc.EmptyServerOption{}
+		streamServerOption = grpc.EmptyServerOption{}
 	}
 
 	grpcOpts := []grpc.ServerOption{
@@ -260,6 +275,7 @@ func (s *Server) startExternalGrpc(errChan chan error) {
 		grpc.MaxRecvMsgSize(Params.ServerMaxRecvSize.GetAsInt()),
 		grpc.MaxSendMsgSize(Params.ServerMaxSendSize.GetAsInt()),
 		unaryServerOption,
+		streamServerOption,
 		grpc.StatsHandler(tracer.GetDynamicOtelGrpcServerStatsHandler()),
 		grpc.StatsHandler(metrics.NewGRPCSizeStatsHandler().
 			// both inbound and outbound
@@ -318,6 +334,12 @@ func (s *Server) startExternalGrpc(errChan chan error) {
 	}
 
 	milvuspb.RegisterMilvusServiceServer(s.grpcExternalServer, s)
+	proxypb.RegisterTransactionServer(s.grpcExternalServer, s)
+	proxypb.RegisterRecycleBinServer(s.grpcExternalServer, s)
+	proxypb.RegisterChangeStreamServer(s.grpcExternalServer, s)
+	proxypb.RegisterSnapshotServer(s.grpcExternalServer, s)
+	proxypb.RegisterExportServer(s.grpcExternalServer, s)
+	proxypb.RegisterRowPolicyServer(s.grpcExternalServer, s)
 	grpc_health_v1.RegisterHealthServer(s.grpcExternalServer, s)
 	errChan <- nil
 
@@ -1141,6 +1163,74 @@ func (s *Server) ListImports(ctx context.Context, req *internalpb.ListImportsReq
 	return s.proxy.ListImports(ctx, req)
 }
 
+func (s *Server) BeginTransaction(ctx context.Context, req *proxypb.BeginTransactionRequest) (*proxypb.BeginTransactionResponse, error) {
+	return s.proxy.BeginTransaction(ctx, req)
+}
+
+func (s *Server) CommitTransaction(ctx context.Context, req *proxypb.CommitTransactionRequest) (*proxypb.CommitTransactionResponse, error) {
+	return s.proxy.CommitTransaction(ctx, req)
+}
+
+func (s *Server) RollbackTransaction(ctx context.Context, req *proxypb.RollbackTransactionRequest) (*commonpb.Status, error) {
+	return s.proxy.RollbackTransaction(ctx, req)
+}
+
+func (s *Server) ListRecycleBin(ctx context.Context, req *proxypb.ListRecycleBinRequest) (*proxypb.ListRecycleBinResponse, error) {
+	return s.proxy.ListRecycleBin(ctx, req)
+}
+
+func (s *Server) UndropCollection(ctx context.Context, req *proxypb.UndropCollectionRequest) (*commonpb.Status, error) {
+	return s.proxy.UndropCollection(ctx, req)
+}
+
+func (s *Server) UndropPartition(ctx context.Context, req *proxypb.UndropPartitionRequest) (*commonpb.Status, error) {
+	return s.proxy.UndropPartition(ctx, req)
+}
+
+func (s *Server) SubscribeChanges(req *proxypb.SubscribeChangesRequest, server proxypb.ChangeStream_SubscribeChangesServer) error {
+	return s.proxy.SubscribeChanges(req, server)
+}
+
+func (s *Server) CreateSnapshot(ctx context.Context, req *proxypb.CreateSnapshotRequest) (*proxypb.CreateSnapshotResponse, error) {
+	return s.proxy.CreateSnapshot(ctx, req)
+}
+
+func (s *Server) DropSnapshot(ctx context.Context, req *proxypb.DropSnapshotRequest) (*commonpb.Status, error) {
+	return s.proxy.DropSnapshot(ctx, req)
+}
+
+func (s *Server) ListSnapshots(ctx context.Context, req *proxypb.ListSnapshotsRequest) (*proxypb.ListSnapshotsResponse, error) {
+	return s.proxy.ListSnapshots(ctx, req)
+}
+
+func (s *Server) RestoreSnapshot(ctx context.Context, req *proxypb.RestoreSnapshotRequest) (*commonpb.Status, error) {
+	return s.proxy.RestoreSnapshot(ctx, req)
+}
+
+func (s *Server) Export(ctx context.Context, req *proxypb.ExportRequest) (*proxypb.ExportResponse, error) {
+	return s.proxy.Export(ctx, req)
+}
+
+func (s *Server) GetExportProgress(ctx context.Context, req *proxypb.GetExportProgressRequest) (*proxypb.GetExportProgressResponse, error) {
+	return s.proxy.GetExportProgress(ctx, req)
+}
+
+func (s *Server) CancelExport(ctx context.Context, req *proxypb.CancelExportRequest) (*commonpb.Status, error) {
+	return s.proxy.CancelExport(ctx, req)
+}
+
+func (s *Server) SetRowPolicy(ctx context.Context, req *proxypb.SetRowPolicyRequest) (*commonpb.Status, error) {
+	return s.proxy.SetRowPolicy(ctx, req)
+}
+
+func (s *Server) DropRowPolicy(ctx context.Context, req *proxypb.DropRowPolicyRequest) (*commonpb.Status, error) {
+	return s.proxy.DropRowPolicy(ctx, req)
+}
+
+func (s *Server) ListRowPolicies(ctx context.Context, req *proxypb.ListRowPoliciesRequest) (*proxypb.ListRowPoliciesResponse, error) {
+	return s.proxy.ListRowPolicies(ctx, req)
+}
+
 func (s *Server) AlterDatabase(ctx context.Context, req *milvuspb.AlterDatabaseRequest) (*commonpb.Status, error) {
 	return s.proxy.AlterDatabase(ctx, req)
 }
@@ -776,3 +776,51 @@ func (c *Client) OperatePrivilegeGroup(ctx context.Context, in *milvuspb.Operate
 		return client.OperatePrivilegeGroup(ctx, in)
 	})
 }
+
+func (c *Client) OperateRowPolicy(ctx context.Context, in *rootcoordpb.OperateRowPolicyRequest, opts ...grpc.CallOption) (*commonpb.Status, error) {
+	in = typeutil.Clone(in)
+	commonpbutil.UpdateMsgBase(
+		in.GetBase(),
+		commonpbutil.FillMsgBaseFromClient(paramtable.GetNodeID(), commonpbutil.WithTargetID(c.sess.ServerID)),
+	)
+
+	return wrapGrpcCall(ctx, c, func(client rootcoordpb.RootCoordClient) (*commonpb.Status, error) {
+		return client.OperateRowPolicy(ctx, in)
+	})
+}
+
+func (c *Client) ListRecycleBin(ctx context.Context, in *proxypb.ListRecycleBinRequest, opts ...grpc.CallOption) (*proxypb.ListRecycleBinResponse, error) {
+	in = typeutil.Clone(in)
+	commonpbutil.UpdateMsgBase(
+		in.GetBase(),
+		commonpbutil.FillMsgBaseFromClient(paramtable.GetNodeID(), commonpbutil.WithTargetID(c.sess.ServerID)),
+	)
+
+	return wrapGrpcCall(ctx, c, func(client rootcoordpb.RootCoordClient) (*proxypb.ListRecycleBinResponse, error) {
+		return client.ListRecycleBin(ctx, in)
+	})
+}
+
+func (c *Client) UndropCollection(ctx context.Context, in *proxypb.UndropCollectionRequest, opts ...grpc.CallOption) (*commonpb.Status, error) {
+	in = typeutil.Clone(in)
+	commonpbutil.UpdateMsgBase(
+		in.GetBase(),
+		commonpbutil.FillMsgBaseFromClient(paramtable.GetNodeID(), commonpbutil.WithTargetID(c.sess.ServerID)),
+	)
+
+	return wrapGrpcCall(ctx, c, func(client rootcoordpb.RootCoordClient) (*commonpb.Status, error) {
+		return client.UndropCollection(ctx, in)
+	})
+}
+
+func (c *Client) UndropPartition(ctx context.Context, in *proxypb.UndropPartitionRequest, opts ...grpc.CallOption) (*commonpb.Status, error) {
+	in = typeutil.Clone(in)
+	commonpbutil.UpdateMsgBase(
+		in.GetBase(),
+		commonpbutil.FillMsgBaseFromClient(paramtable.GetNodeID(), commonpbutil.WithTargetID(c.sess.ServerID)),
+	)
+
+	return wrapGrpcCall(ctx, c, func(client rootcoordpb.RootCoordClient) (*commonpb.Status, error) {
+		return client.UndropPartition(ctx, in)
+	})
+}
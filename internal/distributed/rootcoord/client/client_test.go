@@ -272,6 +272,22 @@ func Test_NewClient(t *testing.T) {
 			r, err := client.OperatePrivilegeGroup(ctx, nil)
 			retCheck(retNotNil, r, err)
 		}
+		{
+			r, err := client.OperateRowPolicy(ctx, nil)
+			retCheck(retNotNil, r, err)
+		}
+		{
+			r, err := client.ListRecycleBin(ctx, nil)
+			retCheck(retNotNil, r, err)
+		}
+		{
+			r, err := client.UndropCollection(ctx, nil)
+			retCheck(retNotNil, r, err)
+		}
+		{
+			r, err := client.UndropPartition(ctx, nil)
+			retCheck(retNotNil, r, err)
+		}
 	}
 
 	client.(*Client).grpcClient = &mock.GRPCClientBase[rootcoordpb.RootCoordClient]{
@@ -561,3 +561,19 @@ func (s *Server) ListPrivilegeGroups(ctx context.Context, request *milvuspb.List
 func (s *Server) OperatePrivilegeGroup(ctx context.Context, request *milvuspb.OperatePrivilegeGroupRequest) (*commonpb.Status, error) {
 	return s.rootCoord.OperatePrivilegeGroup(ctx, request)
 }
+
+func (s *Server) OperateRowPolicy(ctx context.Context, request *rootcoordpb.OperateRowPolicyRequest) (*commonpb.Status, error) {
+	return s.rootCoord.OperateRowPolicy(ctx, request)
+}
+
+func (s *Server) ListRecycleBin(ctx context.Context, request *proxypb.ListRecycleBinRequest) (*proxypb.ListRecycleBinResponse, error) {
+	return s.rootCoord.ListRecycleBin(ctx, request)
+}
+
+func (s *Server) UndropCollection(ctx context.Context, request *proxypb.UndropCollectionRequest) (*commonpb.Status, error) {
+	return s.rootCoord.UndropCollection(ctx, request)
+}
+
+func (s *Server) UndropPartition(ctx context.Context, request *proxypb.UndropPartitionRequest) (*commonpb.Status, error) {
+	return s.rootCoord.UndropPartition(ctx, request)
+}
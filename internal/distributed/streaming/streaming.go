@@ -104,6 +104,10 @@ type WALAccesser interface {
 
 // Txn is the interface for writing transaction into the wal.
 type Txn interface {
+	// TxnContext returns the context of the transaction assigned by the wal,
+	// including the transaction id and the keepalive of the session.
+	TxnContext() message.TxnContext
+
 	// Append writes a record to the log.
 	Append(ctx context.Context, msg message.MutableMessage, opts ...AppendOption) error
 
@@ -21,6 +21,11 @@ type txnImpl struct {
 	*walAccesserImpl
 }
 
+// TxnContext returns the context of the transaction.
+func (t *txnImpl) TxnContext() message.TxnContext {
+	return *t.txnCtx
+}
+
 // Append writes records to the log.
 func (t *txnImpl) Append(ctx context.Context, msg message.MutableMessage, opts ...AppendOption) error {
 	assertValidMessage(msg)
@@ -36,6 +36,7 @@ import (
 	dcc "github.com/milvus-io/milvus/internal/distributed/datacoord/client"
 	rcc "github.com/milvus-io/milvus/internal/distributed/rootcoord/client"
 	etcdkv "github.com/milvus-io/milvus/internal/kv/etcd"
+	sqlitekv "github.com/milvus-io/milvus/internal/kv/sqlite"
 	tikvkv "github.com/milvus-io/milvus/internal/kv/tikv"
 	"github.com/milvus-io/milvus/internal/storage"
 	streamingnodeserver "github.com/milvus-io/milvus/internal/streamingnode/server"
@@ -289,6 +290,15 @@ func (s *Server) initMeta() error {
 		metaRootPath = params.EtcdCfg.MetaRootPath.GetValue()
 		s.metaKV = etcdkv.NewEtcdKV(s.etcdCli, metaRootPath,
 			etcdkv.WithRequestTimeout(paramtable.Get().ServiceParam.EtcdCfg.RequestTimeout.GetAsDuration(time.Millisecond)))
+	} else if metaType == util.MetaStoreTypeSQLite {
+		db, err := sqlitekv.GetSharedDB(params.SQLiteCfg.Path.GetValue())
+		if err != nil {
+			log.Warn("Streamingnode open sqlite failed", zap.Error(err))
+			return err
+		}
+		metaRootPath = params.SQLiteCfg.MetaRootPath.GetValue()
+		s.metaKV = sqlitekv.NewSQLiteKV(db, metaRootPath,
+			sqlitekv.WithRequestTimeout(paramtable.Get().ServiceParam.SQLiteCfg.RequestTimeout.GetAsDuration(time.Millisecond)))
 	}
 	return nil
 }
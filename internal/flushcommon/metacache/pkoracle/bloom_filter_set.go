@@ -31,6 +31,8 @@ var _ PkStat = (*BloomFilterSet)(nil)
 // BloomFilterSet is a struct with multiple `storage.PkStatstics`.
 // it maintains bloom filter generated from segment primary keys.
 // it may be updated with new insert FieldData when serving growing segments.
+// the current entry always uses the mutable `common.bloomFilterType`, while the history entries
+// are rolled from synced stats, which use `common.sealedBloomFilterType` and could be static filters.
 type BloomFilterSet struct {
 	mut       sync.RWMutex
 	batchSize uint
@@ -24,6 +24,7 @@ import (
 
 	"github.com/milvus-io/milvus-proto/go-api/v2/schemapb"
 	"github.com/milvus-io/milvus/internal/storage"
+	"github.com/milvus-io/milvus/internal/util/bloomfilter"
 	"github.com/milvus-io/milvus/pkg/util/paramtable"
 )
 
@@ -129,6 +130,33 @@ func (s *BloomFilterSetSuite) TestRoll() {
 	s.Equal(1, len(history), "history shall have one entry after empty roll")
 }
 
+func (s *BloomFilterSetSuite) TestRollSealedStats() {
+	paramtable.Get().Save(paramtable.Get().CommonCfg.SealedBloomFilterType.Key, bloomfilter.XorBFName)
+	defer paramtable.Get().Reset(paramtable.Get().CommonCfg.SealedBloomFilterType.Key)
+
+	ids := []int64{1, 2, 3, 4, 5}
+	err := s.bfs.UpdatePKRange(s.GetFieldData(ids))
+	s.NoError(err)
+
+	stats, err := storage.NewSealedPrimaryKeyStats(101, int64(schemapb.DataType_Int64), int64(len(ids)))
+	s.Require().NoError(err)
+	stats.UpdateByMsgs(s.GetFieldData(ids))
+	s.bfs.Roll(stats)
+
+	history := s.bfs.GetHistory()
+	s.Require().Equal(1, len(history))
+	s.Equal(bloomfilter.XorBF, history[0].PkFilter.Type())
+	for _, id := range ids {
+		s.True(s.bfs.PkExists(storage.NewLocationsCache(storage.NewInt64PrimaryKey(id))), "pk shall exist after roll")
+	}
+	s.False(s.bfs.PkExists(storage.NewLocationsCache(storage.NewInt64PrimaryKey(6))))
+
+	// growing data keeps the mutable bloom filter
+	err = s.bfs.UpdatePKRange(s.GetFieldData([]int64{6}))
+	s.NoError(err)
+	s.True(s.bfs.PkExists(storage.NewLocationsCache(storage.NewInt64PrimaryKey(6))))
+}
+
 func TestBloomFilterSet(t *testing.T) {
 	suite.Run(t, new(BloomFilterSetSuite))
 }
@@ -41,6 +41,8 @@ type embeddingNode struct {
 
 	// embeddingType EmbeddingType
 	functionRunners map[int64]function.FunctionRunner
+	// the output fields of the text embedding functions, filled by proxy.
+	textEmbeddingOutputs []int64
 }
 
 func newEmbeddingNode(channelName string, schema *schemapb.CollectionSchema) (*embeddingNode, error) {
@@ -63,6 +65,12 @@ func newEmbeddingNode(channelName string, schema *schemapb.CollectionSchema) (*e
 	}
 
 	for _, tf := range schema.GetFunctions() {
+		// the text is embedded by proxy before the insert is sent,
+		// the flowgraph never calls the embedding service.
+		if tf.GetType() == schemapb.FunctionType_TextEmbedding {
+			node.textEmbeddingOutputs = append(node.textEmbeddingOutputs, tf.GetOutputFieldIds()[0])
+			continue
+		}
 		functionRunner, err := function.NewFunctionRunner(schema, tf)
 		if err != nil {
 			return nil, err
@@ -101,9 +109,22 @@ func (eNode *embeddingNode) bm25Embedding(runner function.FunctionRunner, inputF
 	return nil
 }
 
+// checkTextEmbedding checks the outputs of the text embedding functions are filled by proxy.
+func (eNode *embeddingNode) checkTextEmbedding(data *storage.InsertData) error {
+	for _, outputFieldID := range eNode.textEmbeddingOutputs {
+		if _, ok := data.Data[outputFieldID]; !ok {
+			return fmt.Errorf("text embedding failed: output field %d is not filled by proxy", outputFieldID)
+		}
+	}
+	return nil
+}
+
 func (eNode *embeddingNode) embedding(datas []*storage.InsertData) (map[int64]*storage.BM25Stats, error) {
 	meta := make(map[int64]*storage.BM25Stats)
 	for _, data := range datas {
+		if err := eNode.checkTextEmbedding(data); err != nil {
+			return nil, err
+		}
 		for _, functionRunner := range eNode.functionRunners {
 			functionSchema := functionRunner.GetSchema()
 			switch functionSchema.GetType() {
@@ -180,3 +180,88 @@ func TestEmbeddingNode_BM25_Operator(t *testing.T) {
 		})
 	})
 }
+
+func TestEmbeddingNode_TextEmbedding(t *testing.T) {
+	collSchema := &schemapb.CollectionSchema{
+		Fields: []*schemapb.FieldSchema{
+			{
+				FieldID:  common.TimeStampField,
+				Name:     common.TimeStampFieldName,
+				DataType: schemapb.DataType_Int64,
+			}, {
+				Name:         "pk",
+				FieldID:      100,
+				IsPrimaryKey: true,
+				DataType:     schemapb.DataType_Int64,
+			}, {
+				Name:     "text",
+				FieldID:  101,
+				DataType: schemapb.DataType_VarChar,
+			}, {
+				Name:             "dense",
+				FieldID:          102,
+				DataType:         schemapb.DataType_FloatVector,
+				TypeParams:       []*commonpb.KeyValuePair{{Key: common.DimKey, Value: "2"}},
+				IsFunctionOutput: true,
+			},
+		},
+		Functions: []*schemapb.FunctionSchema{{
+			Name:           "text_embedding",
+			Type:           schemapb.FunctionType_TextEmbedding,
+			InputFieldIds:  []int64{101},
+			OutputFieldIds: []int64{102},
+		}},
+	}
+	newMsg := func(fieldsData ...*schemapb.FieldData) *FlowGraphMsg {
+		return &FlowGraphMsg{
+			BaseMsg: flowgraph.NewBaseMsg(false),
+			InsertMessages: []*msgstream.InsertMsg{{
+				BaseMsg: msgstream.BaseMsg{},
+				InsertRequest: &msgpb.InsertRequest{
+					SegmentID:  1,
+					Version:    msgpb.InsertDataVersion_ColumnBased,
+					Timestamps: []uint64{1, 1},
+					FieldsData: append([]*schemapb.FieldData{
+						{
+							FieldId: 100,
+							Field: &schemapb.FieldData_Scalars{
+								Scalars: &schemapb.ScalarField{Data: &schemapb.ScalarField_LongData{LongData: &schemapb.LongArray{Data: []int64{1, 2}}}},
+							},
+						}, {
+							FieldId: 101,
+							Field: &schemapb.FieldData_Scalars{
+								Scalars: &schemapb.ScalarField{Data: &schemapb.ScalarField_StringData{StringData: &schemapb.StringArray{Data: []string{"test1", "test2"}}}},
+							},
+						},
+					}, fieldsData...),
+				},
+			}},
+		}
+	}
+
+	// no embedding service is configured, the node must not create the text embedding runner
+	node, err := newEmbeddingNode("test-channel", collSchema)
+	assert.NoError(t, err)
+	assert.Empty(t, node.functionRunners)
+
+	t.Run("output filled by proxy", func(t *testing.T) {
+		var output []Msg
+		assert.NotPanics(t, func() {
+			output = node.Operate([]Msg{newMsg(&schemapb.FieldData{
+				FieldId: 102,
+				Field: &schemapb.FieldData_Vectors{
+					Vectors: &schemapb.VectorField{Dim: 2, Data: &schemapb.VectorField_FloatVector{FloatVector: &schemapb.FloatArray{Data: []float32{1, 2, 3, 4}}}},
+				},
+			})})
+		})
+		msg := output[0].(*FlowGraphMsg)
+		assert.Len(t, msg.InsertData, 1)
+		assert.Equal(t, 2, msg.InsertData[0].GetDatas()[0].Data[102].RowNum())
+	})
+
+	t.Run("output missing", func(t *testing.T) {
+		assert.Panics(t, func() {
+			node.Operate([]Msg{newMsg()})
+		})
+	})
+}
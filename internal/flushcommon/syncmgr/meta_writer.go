@@ -42,6 +42,7 @@ func (b *brokerMetaWriter) UpdateSync(ctx context.Context, pack *SyncTask) error
 		checkPoints                                 = []*datapb.CheckPoint{}
 		deltaFieldBinlogs                           = []*datapb.FieldBinlog{}
 		deltaBm25StatsBinlogs []*datapb.FieldBinlog = nil
+		fieldStatsBinlogs     []*datapb.FieldBinlog = nil
 	)
 
 	insertFieldBinlogs := lo.MapToSlice(pack.insertBinlogs, func(_ int64, fieldBinlog *datapb.FieldBinlog) *datapb.FieldBinlog { return fieldBinlog })
@@ -53,6 +54,10 @@ func (b *brokerMetaWriter) UpdateSync(ctx context.Context, pack *SyncTask) error
 	if len(pack.bm25Binlogs) > 0 {
 		deltaBm25StatsBinlogs = lo.MapToSlice(pack.bm25Binlogs, func(_ int64, fieldBinlog *datapb.FieldBinlog) *datapb.FieldBinlog { return fieldBinlog })
 	}
+
+	if len(pack.fieldStatsBinlogs) > 0 {
+		fieldStatsBinlogs = lo.MapToSlice(pack.fieldStatsBinlogs, func(_ int64, fieldBinlog *datapb.FieldBinlog) *datapb.FieldBinlog { return fieldBinlog })
+	}
 	// only current segment checkpoint info
 	segment, ok := pack.metacache.GetSegmentByID(pack.segmentID)
 	if !ok {
@@ -82,6 +87,7 @@ func (b *brokerMetaWriter) UpdateSync(ctx context.Context, pack *SyncTask) error
 		zap.Int("statslogNum", lo.SumBy(statsFieldBinlogs, getBinlogNum)),
 		zap.Int("deltalogNum", lo.SumBy(deltaFieldBinlogs, getBinlogNum)),
 		zap.Int("bm25logNum", lo.SumBy(deltaBm25StatsBinlogs, getBinlogNum)),
+		zap.Int("fieldStatslogNum", lo.SumBy(fieldStatsBinlogs, getBinlogNum)),
 		zap.String("vChannelName", pack.channelName),
 	)
 
@@ -99,6 +105,8 @@ func (b *brokerMetaWriter) UpdateSync(ctx context.Context, pack *SyncTask) error
 		Field2Bm25LogPaths:  deltaBm25StatsBinlogs,
 		Deltalogs:           deltaFieldBinlogs,
 
+		Field2FieldStatslogPaths: fieldStatsBinlogs,
+
 		CheckPoints: checkPoints,
 
 		StartPositions: startPos,
@@ -15,13 +15,14 @@ import (
 
 func NewSyncTask() *SyncTask {
 	return &SyncTask{
-		isFlush:       false,
-		insertBinlogs: make(map[int64]*datapb.FieldBinlog),
-		statsBinlogs:  make(map[int64]*datapb.FieldBinlog),
-		deltaBinlog:   &datapb.FieldBinlog{},
-		bm25Binlogs:   make(map[int64]*datapb.FieldBinlog),
-		segmentData:   make(map[string][]byte),
-		binlogBlobs:   make(map[int64]*storage.Blob),
+		isFlush:           false,
+		insertBinlogs:     make(map[int64]*datapb.FieldBinlog),
+		statsBinlogs:      make(map[int64]*datapb.FieldBinlog),
+		deltaBinlog:       &datapb.FieldBinlog{},
+		bm25Binlogs:       make(map[int64]*datapb.FieldBinlog),
+		fieldStatsBinlogs: make(map[int64]*datapb.FieldBinlog),
+		segmentData:       make(map[string][]byte),
+		binlogBlobs:       make(map[int64]*storage.Blob),
 	}
 }
 
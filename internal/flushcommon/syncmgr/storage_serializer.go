@@ -19,6 +19,7 @@ package syncmgr
 import (
 	"context"
 	"fmt"
+	"math"
 	"strconv"
 
 	"github.com/samber/lo"
@@ -35,6 +36,7 @@ import (
 	"github.com/milvus-io/milvus/pkg/util/merr"
 	"github.com/milvus-io/milvus/pkg/util/paramtable"
 	"github.com/milvus-io/milvus/pkg/util/timerecord"
+	"github.com/milvus-io/milvus/pkg/util/typeutil"
 )
 
 type storageV1Serializer struct {
@@ -91,6 +93,9 @@ func (s *storageV1Serializer) EncodeBuffer(ctx context.Context, pack *SyncPack)
 			}
 		}
 		task.binlogMemsize = memSize
+		if ttlField := typeutil.GetTTLField(s.schema); ttlField != nil {
+			task.binlogExpire = map[int64]int64{ttlField.GetFieldID(): getExpireTo(pack.insertData, ttlField.GetFieldID())}
+		}
 
 		binlogBlobs, err := s.serializeBinlog(ctx, pack)
 		if err != nil {
@@ -119,6 +124,13 @@ func (s *storageV1Serializer) EncodeBuffer(ctx context.Context, pack *SyncPack)
 			actions = append(actions, metacache.MergeBm25Stats(pack.bm25Stats))
 		}
 
+		fieldStatsBlobs, err := s.serializeFieldStats(pack)
+		if err != nil {
+			log.Warn("failed to serialize field stats", zap.Error(err))
+			return nil, err
+		}
+		task.fieldStatsBlobs = fieldStatsBlobs
+
 		s.metacache.UpdateSegments(metacache.MergeSegmentAction(actions...), metacache.WithSegmentIDs(pack.segmentID))
 	}
 
@@ -218,6 +230,48 @@ func (s *storageV1Serializer) serializeBM25Stats(pack *SyncPack) (map[int64]*sto
 	return blobs, nil
 }
 
+// serializeFieldStats builds the min/max and filter of the fields with field stats enabled
+// for the rows of this sync batch.
+func (s *storageV1Serializer) serializeFieldStats(pack *SyncPack) (map[int64]*storage.Blob, error) {
+	var blobs map[int64]*storage.Blob
+	for _, field := range s.schema.GetFields() {
+		if _, ok := typeutil.CreateFieldSchemaHelper(field).GetFieldStatsType(); !ok {
+			continue
+		}
+
+		var rowNum int64
+		var fieldData []storage.FieldData
+		for _, chunk := range pack.insertData {
+			if data, ok := chunk.Data[field.GetFieldID()]; ok {
+				fieldData = append(fieldData, data)
+				rowNum += int64(data.RowNum())
+			}
+		}
+		if rowNum == 0 {
+			continue
+		}
+
+		stats, _ := storage.NewFieldStatsOfField(field, rowNum)
+		for _, data := range fieldData {
+			stats.UpdateByMsgs(data)
+		}
+
+		writer := &storage.FieldStatsWriter{}
+		if err := writer.GenerateList([]*storage.FieldStats{stats}); err != nil {
+			return nil, err
+		}
+		if blobs == nil {
+			blobs = make(map[int64]*storage.Blob)
+		}
+		blobs[field.GetFieldID()] = &storage.Blob{
+			Value:      writer.GetBuffer(),
+			MemorySize: int64(len(writer.GetBuffer())),
+			RowNum:     rowNum,
+		}
+	}
+	return blobs, nil
+}
+
 func (s *storageV1Serializer) serializeStatslog(pack *SyncPack) (*storage.PrimaryKeyStats, *storage.Blob, error) {
 	var rowNum int64
 	var pkFieldData []storage.FieldData
@@ -227,7 +281,7 @@ func (s *storageV1Serializer) serializeStatslog(pack *SyncPack) (*storage.Primar
 		rowNum += int64(chunkPKData.RowNum())
 	}
 
-	stats, err := storage.NewPrimaryKeyStats(s.pkField.GetFieldID(), int64(s.pkField.GetDataType()), rowNum)
+	stats, err := storage.NewSealedPrimaryKeyStats(s.pkField.GetFieldID(), int64(s.pkField.GetDataType()), rowNum)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -326,3 +380,22 @@ func hasBM25Function(schema *schemapb.CollectionSchema) bool {
 	}
 	return false
 }
+
+// getExpireTo returns the max expiration time of the rows in the ttl field,
+// math.MaxInt64 if any row never expires.
+func getExpireTo(insertData []*storage.InsertData, ttlFieldID int64) int64 {
+	var expireTo int64
+	for _, chunk := range insertData {
+		fieldData, ok := chunk.Data[ttlFieldID].(*storage.Int64FieldData)
+		if !ok {
+			continue
+		}
+		for _, expireAt := range fieldData.Data {
+			if expireAt <= 0 {
+				return math.MaxInt64
+			}
+			expireTo = max(expireTo, expireAt)
+		}
+	}
+	return expireTo
+}
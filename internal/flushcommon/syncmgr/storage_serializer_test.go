@@ -19,6 +19,7 @@ package syncmgr
 import (
 	"context"
 	"fmt"
+	"math"
 	"math/rand"
 	"testing"
 	"time"
@@ -35,6 +36,7 @@ import (
 	"github.com/milvus-io/milvus/internal/flushcommon/metacache/pkoracle"
 	"github.com/milvus-io/milvus/internal/proto/datapb"
 	"github.com/milvus-io/milvus/internal/storage"
+	"github.com/milvus-io/milvus/internal/util/bloomfilter"
 	"github.com/milvus-io/milvus/pkg/common"
 	"github.com/milvus-io/milvus/pkg/util/paramtable"
 	"github.com/milvus-io/milvus/pkg/util/tsoutil"
@@ -307,6 +309,63 @@ func (s *StorageV1SerializerSuite) TestBadSchema() {
 	s.Error(err)
 }
 
+func (s *StorageV1SerializerSuite) TestGetExpireTo() {
+	const ttlFieldID = 101
+	newInsertData := func(values ...int64) *storage.InsertData {
+		return &storage.InsertData{
+			Data: map[int64]storage.FieldData{
+				ttlFieldID: &storage.Int64FieldData{Data: values},
+			},
+		}
+	}
+
+	s.EqualValues(0, getExpireTo(nil, ttlFieldID))
+	s.EqualValues(300, getExpireTo([]*storage.InsertData{newInsertData(100, 300), newInsertData(200)}, ttlFieldID))
+	s.EqualValues(math.MaxInt64, getExpireTo([]*storage.InsertData{newInsertData(100), newInsertData(0, 200)}, ttlFieldID))
+}
+
+func (s *StorageV1SerializerSuite) TestSerializeFieldStats() {
+	const tenantFieldID = 102
+	schema := &schemapb.CollectionSchema{
+		Fields: append(s.schema.GetFields(), &schemapb.FieldSchema{
+			FieldID:  tenantFieldID,
+			Name:     "tenant_id",
+			DataType: schemapb.DataType_VarChar,
+			TypeParams: []*commonpb.KeyValuePair{
+				{Key: common.FieldStatsKey, Value: common.FieldStatsXor},
+			},
+		}),
+	}
+	serializer := &storageV1Serializer{schema: schema}
+	newInsertData := func(values ...string) *storage.InsertData {
+		return &storage.InsertData{
+			Data: map[int64]storage.FieldData{
+				tenantFieldID: &storage.StringFieldData{Data: values, DataType: schemapb.DataType_VarChar},
+			},
+		}
+	}
+
+	pack := &SyncPack{}
+	pack.WithInsertData([]*storage.InsertData{newInsertData("b", "d"), newInsertData("c")})
+	blobs, err := serializer.serializeFieldStats(pack)
+	s.Require().NoError(err)
+	s.Require().Len(blobs, 1)
+	s.EqualValues(3, blobs[tenantFieldID].RowNum)
+
+	stats, err := storage.DeserializeFieldStats(blobs[tenantFieldID])
+	s.Require().NoError(err)
+	s.Require().Len(stats, 1)
+	s.Equal("b", stats[0].Min.GetValue())
+	s.Equal("d", stats[0].Max.GetValue())
+	s.Equal(bloomfilter.XorBF, stats[0].BFType)
+	s.True(stats[0].BF.TestString("c"))
+
+	// no stats for empty batch
+	blobs, err = serializer.serializeFieldStats(&SyncPack{})
+	s.NoError(err)
+	s.Empty(blobs)
+}
+
 func TestStorageV1Serializer(t *testing.T) {
 	suite.Run(t, new(StorageV1SerializerSuite))
 }
@@ -77,14 +77,19 @@ type SyncTask struct {
 	insertBinlogs map[int64]*datapb.FieldBinlog // map[int64]*datapb.Binlog
 	statsBinlogs  map[int64]*datapb.FieldBinlog // map[int64]*datapb.Binlog
 	bm25Binlogs   map[int64]*datapb.FieldBinlog
-	deltaBinlog   *datapb.FieldBinlog
+	// fieldStatsBinlogs are the stats logs of the fields with field stats enabled
+	fieldStatsBinlogs map[int64]*datapb.FieldBinlog
+	deltaBinlog       *datapb.FieldBinlog
 
 	binlogBlobs   map[int64]*storage.Blob // fieldID => blob
 	binlogMemsize map[int64]int64         // memory size
+	binlogExpire  map[int64]int64         // fieldID => max expiration time of ttl field
 
 	bm25Blobs      map[int64]*storage.Blob
 	mergedBm25Blob map[int64]*storage.Blob
 
+	fieldStatsBlobs map[int64]*storage.Blob // fieldID => blob of batch field stats
+
 	batchStatsBlob  *storage.Blob
 	mergedStatsBlob *storage.Blob
 
@@ -163,6 +168,10 @@ func (t *SyncTask) Run(ctx context.Context) (err error) {
 		t.processBM25StastBlob()
 	}
 
+	if len(t.fieldStatsBlobs) > 0 {
+		t.processFieldStatsBlob()
+	}
+
 	err = t.writeLogs(ctx)
 	if err != nil {
 		log.Warn("failed to save serialized data into storage", zap.Error(err))
@@ -231,6 +240,7 @@ func (t *SyncTask) prefetchIDs() error {
 	if t.bm25Blobs != nil {
 		totalIDCount += len(t.bm25Blobs)
 	}
+	totalIDCount += len(t.fieldStatsBlobs)
 
 	start, _, err := t.allocator.Alloc(uint32(totalIDCount))
 	if err != nil {
@@ -261,6 +271,7 @@ func (t *SyncTask) processInsertBlobs() {
 			LogPath:       key,
 			LogSize:       int64(len(blob.GetValue())),
 			MemorySize:    t.binlogMemsize[fieldID],
+			ExpireTo:      t.binlogExpire[fieldID],
 		})
 	}
 }
@@ -295,6 +306,22 @@ func (t *SyncTask) processBM25StastBlob() {
 	}
 }
 
+func (t *SyncTask) processFieldStatsBlob() {
+	for fieldID, blob := range t.fieldStatsBlobs {
+		k := metautil.JoinIDPath(t.collectionID, t.partitionID, t.segmentID, fieldID, t.nextID())
+		key := path.Join(t.chunkManager.RootPath(), common.SegmentFieldStatsLogPath, k)
+		t.segmentData[key] = blob.GetValue()
+		t.appendFieldStatslog(fieldID, &datapb.Binlog{
+			EntriesNum:    blob.RowNum,
+			TimestampFrom: t.tsFrom,
+			TimestampTo:   t.tsTo,
+			LogPath:       key,
+			LogSize:       int64(len(blob.GetValue())),
+			MemorySize:    blob.MemorySize,
+		})
+	}
+}
+
 func (t *SyncTask) processStatsBlob() {
 	if t.batchStatsBlob != nil {
 		t.convertBlob2StatsBinlog(t.batchStatsBlob, t.pkField.GetFieldID(), t.nextID(), t.batchRows)
@@ -363,6 +390,17 @@ func (t *SyncTask) appendBM25Statslog(fieldID int64, log *datapb.Binlog) {
 	fieldBinlog.Binlogs = append(fieldBinlog.Binlogs, log)
 }
 
+func (t *SyncTask) appendFieldStatslog(fieldID int64, log *datapb.Binlog) {
+	fieldBinlog, ok := t.fieldStatsBinlogs[fieldID]
+	if !ok {
+		fieldBinlog = &datapb.FieldBinlog{
+			FieldID: fieldID,
+		}
+		t.fieldStatsBinlogs[fieldID] = fieldBinlog
+	}
+	fieldBinlog.Binlogs = append(fieldBinlog.Binlogs, log)
+}
+
 func (t *SyncTask) appendStatslog(fieldID int64, statlog *datapb.Binlog) {
 	fieldBinlog, ok := t.statsBinlogs[fieldID]
 	if !ok {
@@ -418,6 +456,11 @@ func (t *SyncTask) Binlogs() (map[int64]*datapb.FieldBinlog, map[int64]*datapb.F
 	return t.insertBinlogs, t.statsBinlogs, t.deltaBinlog
 }
 
+// FieldStatsBinlogs returns the stats logs of the fields with field stats enabled written by the task.
+func (t *SyncTask) FieldStatsBinlogs() map[int64]*datapb.FieldBinlog {
+	return t.fieldStatsBinlogs
+}
+
 func (t *SyncTask) MarshalJSON() ([]byte, error) {
 	return json.Marshal(&metricsinfo.SyncTask{
 		SegmentID:     t.segmentID,
@@ -77,6 +77,8 @@ const (
 	HookConfigsPath = "/_hook/configs"
 	// SlowQueryPath is the path to get slow queries metrics
 	SlowQueryPath = "/_cluster/slow_query"
+	// QueryProfilePath is the path to get the execution profiles of search/query requests
+	QueryProfilePath = "/_cluster/query_profile"
 
 	// QCDistPath is the path to get QueryCoord distribution.
 	QCDistPath = "/_qc/dist"
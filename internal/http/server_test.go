@@ -266,6 +266,7 @@ func TestRegisterWebUIHandler(t *testing.T) {
 		{"/webui/", http.StatusOK, "<!doctype html>"},
 		{"/webui/index.html", http.StatusOK, "<!doctype html>"},
 		{"/webui/unknown", http.StatusOK, "<!doctype html>"},
+		{"/webui/profile.html", http.StatusOK, "Query Profiles"},
 	}
 
 	for _, tt := range tests {
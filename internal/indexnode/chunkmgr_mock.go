@@ -3,6 +3,7 @@ package indexnode
 import (
 	"context"
 	"fmt"
+	"io"
 	"math/rand"
 	"sync"
 	"time"
@@ -112,6 +113,14 @@ func (c *mockChunkmgr) Write(ctx context.Context, filePath string, content []byt
 	return nil
 }
 
+func (c *mockChunkmgr) WriteFrom(ctx context.Context, filePath string, reader io.Reader, size int64) error {
+	content, err := io.ReadAll(reader)
+	if err != nil {
+		return err
+	}
+	return c.Write(ctx, filePath, content)
+}
+
 func (c *mockChunkmgr) MultiWrite(ctx context.Context, contents map[string][]byte) error {
 	// TODO
 	return errNotImplErr
@@ -518,6 +518,8 @@ func (i *IndexNode) QueryJobsV2(ctx context.Context, req *workerpb.QueryJobsV2Re
 					TextStatsLogs: info.textStatsLogs,
 					Bm25Logs:      info.bm25Logs,
 					NumRows:       info.numRows,
+
+					FieldStatslogs: info.fieldStatsLogs,
 				})
 			}
 		}
@@ -268,6 +268,13 @@ func (st *statsTask) sortSegment(ctx context.Context) ([]*datapb.FieldBinlog, er
 		}
 	}
 
+	binlogNums, fieldStatsLogs, err := fieldStatsSerializeWrite(ctx, st.binlogIO, st.req.GetStartLogID()+st.logIDOffset, writer, int64(len(values)))
+	if err != nil {
+		log.Warn("stats wrong, failed to serialize write segment field stats", zap.Error(err))
+		return nil, err
+	}
+	st.logIDOffset += binlogNums
+
 	totalElapse := st.tr.RecordSpan()
 
 	insertLogs := lo.Values(allBinlogs)
@@ -286,7 +293,7 @@ func (st *statsTask) sortSegment(ctx context.Context) ([]*datapb.FieldBinlog, er
 		st.req.GetPartitionID(),
 		st.req.GetTargetSegmentID(),
 		st.req.GetInsertChannel(),
-		int64(len(values)), insertLogs, statsLogs, bm25StatsLogs)
+		int64(len(values)), insertLogs, statsLogs, bm25StatsLogs, fieldStatsLogs)
 
 	log.Info("sort segment end",
 		zap.String("clusterID", st.req.GetClusterID()),
@@ -514,6 +521,7 @@ func serializeWrite(ctx context.Context, startID int64, writer *compaction.Segme
 	_, span := otel.Tracer(typeutil.DataNodeRole).Start(ctx, "serializeWrite")
 	defer span.End()
 
+	ttlFieldID, expireTo := writer.GetExpireTo()
 	blobs, tr, err := writer.SerializeYield()
 	if err != nil {
 		return 0, nil, nil, err
@@ -541,6 +549,9 @@ func serializeWrite(ctx context.Context, startID int64, writer *compaction.Segme
 				},
 			},
 		}
+		if fID == ttlFieldID {
+			fieldBinlogs[fID].Binlogs[0].ExpireTo = expireTo
+		}
 	}
 
 	return
@@ -614,6 +625,45 @@ func bm25SerializeWrite(ctx context.Context, io io.BinlogIO, startID int64, writ
 	return cnt, binlogs, nil
 }
 
+func fieldStatsSerializeWrite(ctx context.Context, io io.BinlogIO, startID int64, writer *compaction.SegmentWriter, finalRowCount int64) (int64, []*datapb.FieldBinlog, error) {
+	ctx, span := otel.Tracer(typeutil.DataNodeRole).Start(ctx, "field stats log serializeWrite")
+	defer span.End()
+	if finalRowCount == 0 {
+		return 0, nil, nil
+	}
+	stats, err := writer.GetFieldStatsBlob()
+	if err != nil {
+		return 0, nil, err
+	}
+
+	kvs := make(map[string][]byte)
+	binlogs := []*datapb.FieldBinlog{}
+	cnt := int64(0)
+	for fieldID, blob := range stats {
+		key, _ := binlog.BuildLogPath(storage.FieldStatsBinlog, writer.GetCollectionID(), writer.GetPartitionID(), writer.GetSegmentID(), fieldID, startID+cnt)
+		kvs[key] = blob.GetValue()
+		binlogs = append(binlogs, &datapb.FieldBinlog{
+			FieldID: fieldID,
+			Binlogs: []*datapb.Binlog{
+				{
+					LogSize:    int64(len(blob.GetValue())),
+					MemorySize: int64(len(blob.GetValue())),
+					LogPath:    key,
+					EntriesNum: finalRowCount,
+				},
+			},
+		})
+		cnt++
+	}
+
+	if err := io.Upload(ctx, kvs); err != nil {
+		log.Warn("failed to upload field stats log", zap.Error(err))
+		return 0, nil, err
+	}
+
+	return cnt, binlogs, nil
+}
+
 func buildTextLogPrefix(rootPath string, collID, partID, segID, fieldID, version int64) string {
 	return fmt.Sprintf("%s/%s/%d/%d/%d/%d/%d", rootPath, common.TextIndexPath, collID, partID, segID, fieldID, version)
 }
@@ -324,6 +324,8 @@ type statsTaskInfo struct {
 	statsLogs     []*datapb.FieldBinlog
 	textStatsLogs map[int64]*datapb.TextIndexStats
 	bm25Logs      []*datapb.FieldBinlog
+	// fieldStatsLogs are the stats logs of the fields with field stats enabled
+	fieldStatsLogs []*datapb.FieldBinlog
 }
 
 func (i *IndexNode) loadOrStoreStatsTask(clusterID string, taskID UniqueID, info *statsTaskInfo) *statsTaskInfo {
@@ -372,6 +374,7 @@ func (i *IndexNode) storePKSortStatsResult(
 	insertLogs []*datapb.FieldBinlog,
 	statsLogs []*datapb.FieldBinlog,
 	bm25Logs []*datapb.FieldBinlog,
+	fieldStatsLogs []*datapb.FieldBinlog,
 ) {
 	key := taskKey{ClusterID: ClusterID, TaskID: taskID}
 	i.stateLock.Lock()
@@ -385,6 +388,7 @@ func (i *IndexNode) storePKSortStatsResult(
 		info.insertLogs = insertLogs
 		info.statsLogs = statsLogs
 		info.bm25Logs = bm25Logs
+		info.fieldStatsLogs = fieldStatsLogs
 		return
 	}
 }
@@ -428,6 +432,8 @@ func (i *IndexNode) getStatsTaskInfo(clusterID string, taskID UniqueID) *statsTa
 			statsLogs:     info.statsLogs,
 			textStatsLogs: info.textStatsLogs,
 			bm25Logs:      info.bm25Logs,
+
+			fieldStatsLogs: info.fieldStatsLogs,
 		}
 	}
 	return nil
@@ -0,0 +1,547 @@
+//go:build test
+// +build test
+
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kvtest
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"sort"
+
+	"github.com/cockroachdb/errors"
+	"github.com/stretchr/testify/suite"
+	"go.etcd.io/etcd/api/v3/mvccpb"
+	"golang.org/x/exp/maps"
+
+	"github.com/milvus-io/milvus/pkg/kv"
+	"github.com/milvus-io/milvus/pkg/kv/predicates"
+	"github.com/milvus-io/milvus/pkg/util/funcutil"
+	"github.com/milvus-io/milvus/pkg/util/merr"
+)
+
+// revisionLoader is implemented by the WatchKVs supporting to watch from a loaded revision.
+type revisionLoader interface {
+	LoadBytesWithRevision(ctx context.Context, key string) ([]string, [][]byte, int64, error)
+}
+
+// WatchKVSuite is the behavior tests shared by the kv.WatchKV implementations,
+// run it with the implementation specific NewKV, e.g.
+//
+//	suite.Run(t, &kvtest.WatchKVSuite{NewKV: func(rootPath string) kv.WatchKV { ... }})
+type WatchKVSuite struct {
+	suite.Suite
+
+	// NewKV returns the kv to test under the rootPath.
+	NewKV func(rootPath string) kv.WatchKV
+
+	rootPath string
+	kv       kv.WatchKV
+}
+
+func (s *WatchKVSuite) SetupTest() {
+	s.rootPath = path.Join("unittest/kvtest", funcutil.RandomString(8))
+	s.kv = s.NewKV(s.rootPath)
+}
+
+func (s *WatchKVSuite) TearDownTest() {
+	s.kv.RemoveWithPrefix(context.TODO(), "")
+	s.kv.Close()
+}
+
+func (s *WatchKVSuite) TestSaveLoad() {
+	kv := s.kv
+	saveAndLoadTests := []struct {
+		key   string
+		value string
+	}{
+		{"test1", "value1"},
+		{"test2", "value2"},
+		{"test1/a", "value_a"},
+		{"test1/b", "value_b"},
+		{"empty", ""},
+	}
+
+	for _, test := range saveAndLoadTests {
+		err := kv.Save(context.TODO(), test.key, test.value)
+		s.Require().NoError(err)
+
+		val, err := kv.Load(context.TODO(), test.key)
+		s.Require().NoError(err)
+		s.Equal(test.value, val)
+	}
+	s.Require().NoError(kv.Remove(context.TODO(), "empty"))
+
+	invalidLoadTests := []struct {
+		invalidKey string
+	}{
+		{"t"},
+		{"a"},
+		{"test1a"},
+	}
+
+	for _, test := range invalidLoadTests {
+		val, err := kv.Load(context.TODO(), test.invalidKey)
+		s.ErrorIs(err, merr.ErrIoKeyNotFound)
+		s.Zero(val)
+	}
+
+	loadPrefixTests := []struct {
+		prefix string
+
+		expectedKeys   []string
+		expectedValues []string
+	}{
+		{"test", []string{
+			kv.GetPath("test1"),
+			kv.GetPath("test2"),
+			kv.GetPath("test1/a"),
+			kv.GetPath("test1/b"),
+		}, []string{"value1", "value2", "value_a", "value_b"}},
+		{"test1", []string{
+			kv.GetPath("test1"),
+			kv.GetPath("test1/a"),
+			kv.GetPath("test1/b"),
+		}, []string{"value1", "value_a", "value_b"}},
+		{"test2", []string{kv.GetPath("test2")}, []string{"value2"}},
+		{"", []string{
+			kv.GetPath("test1"),
+			kv.GetPath("test2"),
+			kv.GetPath("test1/a"),
+			kv.GetPath("test1/b"),
+		}, []string{"value1", "value2", "value_a", "value_b"}},
+		{"test1/a", []string{kv.GetPath("test1/a")}, []string{"value_a"}},
+		{"a", []string{}, []string{}},
+		{"root", []string{}, []string{}},
+		{"/etcd/test/root", []string{}, []string{}},
+	}
+
+	for _, test := range loadPrefixTests {
+		actualKeys, actualValues, err := kv.LoadWithPrefix(context.TODO(), test.prefix)
+		s.NoError(err)
+		s.ElementsMatch(test.expectedKeys, actualKeys)
+		s.ElementsMatch(test.expectedValues, actualValues)
+	}
+
+	removeTests := []struct {
+		validKey   string
+		invalidKey string
+	}{
+		{"test1", "abc"},
+		{"test1/a", "test1/lskfjal"},
+		{"test1/b", "test1/b"},
+		{"test2", "-"},
+	}
+
+	for _, test := range removeTests {
+		err := kv.Remove(context.TODO(), test.validKey)
+		s.NoError(err)
+
+		_, err = kv.Load(context.TODO(), test.validKey)
+		s.Error(err)
+
+		err = kv.Remove(context.TODO(), test.validKey)
+		s.NoError(err)
+		err = kv.Remove(context.TODO(), test.invalidKey)
+		s.NoError(err)
+	}
+}
+
+func (s *WatchKVSuite) TestMultiSaveAndMultiLoad() {
+	kv := s.kv
+	multiSaveTests := map[string]string{
+		"key_1":      "value_1",
+		"key_2":      "value_2",
+		"key_3/a":    "value_3a",
+		"multikey_1": "multivalue_1",
+		"multikey_2": "multivalue_2",
+		"_":          "other",
+	}
+
+	err := kv.MultiSave(context.TODO(), multiSaveTests)
+	s.Require().NoError(err)
+	for k, v := range multiSaveTests {
+		actualV, err := kv.Load(context.TODO(), k)
+		s.NoError(err)
+		s.Equal(v, actualV)
+	}
+
+	multiLoadTests := []struct {
+		inputKeys      []string
+		expectedValues []string
+	}{
+		{[]string{"key_1"}, []string{"value_1"}},
+		{[]string{"key_1", "key_2", "key_3/a"}, []string{"value_1", "value_2", "value_3a"}},
+		{[]string{"multikey_1", "multikey_2"}, []string{"multivalue_1", "multivalue_2"}},
+		{[]string{"_"}, []string{"other"}},
+	}
+
+	for _, test := range multiLoadTests {
+		vs, err := kv.MultiLoad(context.TODO(), test.inputKeys)
+		s.NoError(err)
+		s.Equal(test.expectedValues, vs)
+	}
+
+	invalidMultiLoad := []struct {
+		invalidKeys    []string
+		expectedValues []string
+	}{
+		{[]string{"a", "key_1"}, []string{"", "value_1"}},
+		{[]string{".....", "key_1"}, []string{"", "value_1"}},
+		{[]string{"*********"}, []string{""}},
+		{[]string{"key_1", "1"}, []string{"value_1", ""}},
+	}
+
+	for _, test := range invalidMultiLoad {
+		vs, err := kv.MultiLoad(context.TODO(), test.invalidKeys)
+		s.Error(err)
+		s.Equal(test.expectedValues, vs)
+	}
+
+	removeWithPrefixTests := []string{
+		"key_1",
+		"multi",
+	}
+
+	for _, k := range removeWithPrefixTests {
+		err = kv.RemoveWithPrefix(context.TODO(), k)
+		s.NoError(err)
+
+		ks, vs, err := kv.LoadWithPrefix(context.TODO(), k)
+		s.Empty(ks)
+		s.Empty(vs)
+		s.NoError(err)
+	}
+
+	multiRemoveTests := []string{
+		"key_2",
+		"key_3/a",
+		"multikey_2",
+		"_",
+	}
+
+	err = kv.MultiRemove(context.TODO(), multiRemoveTests)
+	s.NoError(err)
+
+	ks, vs, err := kv.LoadWithPrefix(context.TODO(), "")
+	s.NoError(err)
+	s.Empty(ks)
+	s.Empty(vs)
+
+	multiSaveAndRemoveTests := []struct {
+		multiSaves   map[string]string
+		multiRemoves []string
+	}{
+		{map[string]string{"key_1": "value_1"}, []string{}},
+		{map[string]string{"key_2": "value_2"}, []string{"key_1"}},
+		{map[string]string{"key_3/a": "value_3a"}, []string{"key_2"}},
+		{map[string]string{"multikey_1": "multivalue_1"}, []string{}},
+		{map[string]string{"multikey_2": "multivalue_2"}, []string{"multikey_1", "key_3/a"}},
+		{make(map[string]string), []string{"multikey_2"}},
+	}
+	for _, test := range multiSaveAndRemoveTests {
+		err = kv.MultiSaveAndRemove(context.TODO(), test.multiSaves, test.multiRemoves)
+		s.NoError(err)
+	}
+
+	ks, vs, err = kv.LoadWithPrefix(context.TODO(), "")
+	s.NoError(err)
+	s.Empty(ks)
+	s.Empty(vs)
+}
+
+func (s *WatchKVSuite) TestTxnWithPredicates() {
+	kv := s.kv
+
+	prepareKV := map[string]string{
+		"lease1": "1",
+		"lease2": "2",
+	}
+
+	err := kv.MultiSave(context.TODO(), prepareKV)
+	s.Require().NoError(err)
+
+	badPredicate := predicates.NewMockPredicate(s.T())
+	badPredicate.EXPECT().Type().Return(0)
+	badPredicate.EXPECT().Target().Return(predicates.PredTargetValue)
+
+	multiSaveAndRemovePredTests := []struct {
+		tag           string
+		multiSave     map[string]string
+		preds         []predicates.Predicate
+		expectSuccess bool
+	}{
+		{"predicate_ok", map[string]string{"a": "b"}, []predicates.Predicate{predicates.ValueEqual("lease1", "1")}, true},
+		{"predicate_fail", map[string]string{"a": "b"}, []predicates.Predicate{predicates.ValueEqual("lease1", "2")}, false},
+		{"predicate_missing_key", map[string]string{"a": "b"}, []predicates.Predicate{predicates.ValueEqual("lease3", "1")}, false},
+		{"bad_predicate", map[string]string{"a": "b"}, []predicates.Predicate{badPredicate}, false},
+	}
+
+	for _, test := range multiSaveAndRemovePredTests {
+		s.Run(test.tag, func() {
+			s.Require().NoError(kv.Remove(context.TODO(), "a"))
+			err := kv.MultiSaveAndRemove(context.TODO(), test.multiSave, nil, test.preds...)
+			if test.expectSuccess {
+				s.NoError(err)
+			} else {
+				s.Error(err)
+			}
+			// nothing shall be applied if the predicates are not met
+			has, err := kv.Has(context.TODO(), "a")
+			s.NoError(err)
+			s.Equal(test.expectSuccess, has)
+
+			err = kv.MultiSaveAndRemoveWithPrefix(context.TODO(), test.multiSave, nil, test.preds...)
+			if test.expectSuccess {
+				s.NoError(err)
+			} else {
+				s.Error(err)
+			}
+		})
+	}
+}
+
+func (s *WatchKVSuite) TestMultiSaveAndRemoveWithPrefix() {
+	kv := s.kv
+
+	prepareTests := map[string]string{
+		"x/abc/1": "1",
+		"x/abc/2": "2",
+		"x/def/1": "10",
+		"x/def/2": "20",
+		"x/den/1": "100",
+		"x/den/2": "200",
+	}
+
+	err := kv.MultiSave(context.TODO(), prepareTests)
+	s.Require().NoError(err)
+	multiSaveAndRemoveWithPrefixTests := []struct {
+		multiSave map[string]string
+		prefix    []string
+
+		loadPrefix         string
+		lengthBeforeRemove int
+		lengthAfterRemove  int
+	}{
+		{map[string]string{}, []string{"x/abc", "x/def", "x/den"}, "x", 6, 0},
+		{map[string]string{"y/a": "vvv", "y/b": "vvv"}, []string{}, "y", 0, 2},
+		{map[string]string{"y/c": "vvv"}, []string{}, "y", 2, 3},
+		{map[string]string{"p/a": "vvv"}, []string{"y/a", "y"}, "y", 3, 0},
+		{map[string]string{}, []string{"p"}, "p", 1, 0},
+	}
+
+	for _, test := range multiSaveAndRemoveWithPrefixTests {
+		k, _, err := kv.LoadWithPrefix(context.TODO(), test.loadPrefix)
+		s.NoError(err)
+		s.Equal(test.lengthBeforeRemove, len(k))
+
+		err = kv.MultiSaveAndRemoveWithPrefix(context.TODO(), test.multiSave, test.prefix)
+		s.NoError(err)
+
+		k, _, err = kv.LoadWithPrefix(context.TODO(), test.loadPrefix)
+		s.NoError(err)
+		s.Equal(test.lengthAfterRemove, len(k))
+	}
+}
+
+func (s *WatchKVSuite) TestHas() {
+	kv := s.kv
+
+	has, err := kv.Has(context.TODO(), "key1")
+	s.NoError(err)
+	s.False(has)
+	has, err = kv.HasPrefix(context.TODO(), "key")
+	s.NoError(err)
+	s.False(has)
+
+	err = kv.Save(context.TODO(), "key1", "value1")
+	s.NoError(err)
+
+	has, err = kv.Has(context.TODO(), "key1")
+	s.NoError(err)
+	s.True(has)
+	has, err = kv.HasPrefix(context.TODO(), "key")
+	s.NoError(err)
+	s.True(has)
+
+	err = kv.Remove(context.TODO(), "key1")
+	s.NoError(err)
+
+	has, err = kv.Has(context.TODO(), "key1")
+	s.NoError(err)
+	s.False(has)
+	has, err = kv.HasPrefix(context.TODO(), "key")
+	s.NoError(err)
+	s.False(has)
+}
+
+func (s *WatchKVSuite) TestWalkWithPagination() {
+	kv := s.kv
+
+	kvs := map[string]string{
+		"A/100":    "v1",
+		"AA/100":   "v2",
+		"AB/100":   "v3",
+		"AB/2/100": "v4",
+		"B/100":    "v5",
+	}
+	err := kv.MultiSave(context.TODO(), kvs)
+	s.Require().NoError(err)
+
+	err = kv.WalkWithPrefix(context.TODO(), "A", 5, func(key []byte, value []byte) error {
+		return errors.New("error")
+	})
+	s.Error(err)
+
+	err = kv.WalkWithPrefix(context.TODO(), "non-exist-prefix", 5, func(key []byte, value []byte) error {
+		return nil
+	})
+	s.NoError(err)
+
+	expected := map[string]string{
+		"A/100":    "v1",
+		"AA/100":   "v2",
+		"AB/100":   "v3",
+		"AB/2/100": "v4",
+	}
+	expectedSortedKey := maps.Keys(expected)
+	sort.Strings(expectedSortedKey)
+
+	for _, pagination := range []int{1, 4, 5, 100} {
+		ret := make(map[string]string)
+		actualSortedKey := make([]string, 0)
+
+		err = kv.WalkWithPrefix(context.TODO(), "A", pagination, func(key []byte, value []byte) error {
+			k := string(key)[len(s.rootPath)+1:]
+			ret[k] = string(value)
+			actualSortedKey = append(actualSortedKey, k)
+			return nil
+		})
+
+		s.NoError(err)
+		s.Equal(expected, ret, fmt.Errorf("pagination: %d", pagination))
+		s.Equal(expectedSortedKey, actualSortedKey, fmt.Errorf("pagination: %d", pagination))
+	}
+}
+
+func (s *WatchKVSuite) TestCompareVersionAndSwap() {
+	kv := s.kv
+
+	success, err := kv.CompareVersionAndSwap(context.TODO(), "a/b/c", 0, "1")
+	s.NoError(err)
+	s.True(success)
+
+	value, err := kv.Load(context.TODO(), "a/b/c")
+	s.NoError(err)
+	s.Equal("1", value)
+
+	success, err = kv.CompareVersionAndSwap(context.TODO(), "a/b/c", 0, "2")
+	s.NoError(err)
+	s.False(success)
+
+	success, err = kv.CompareVersionAndSwap(context.TODO(), "a/b/c", 1, "2")
+	s.NoError(err)
+	s.True(success)
+
+	value, err = kv.Load(context.TODO(), "a/b/c")
+	s.NoError(err)
+	s.Equal("2", value)
+}
+
+func (s *WatchKVSuite) TestWatch() {
+	kv := s.kv
+
+	ch := kv.Watch(context.TODO(), "x")
+	resp := <-ch
+	s.True(resp.Created)
+
+	prefixCh := kv.WatchWithPrefix(context.TODO(), "x")
+	resp = <-prefixCh
+	s.True(resp.Created)
+
+	s.Require().NoError(kv.MultiSave(context.TODO(), map[string]string{"x": "1", "x/a": "2", "y": "3"}))
+	s.Require().NoError(kv.RemoveWithPrefix(context.TODO(), "x"))
+
+	resp = <-ch
+	s.Require().Equal(1, len(resp.Events))
+	s.Equal(mvccpb.PUT, resp.Events[0].Type)
+	s.Equal(kv.GetPath("x"), string(resp.Events[0].Kv.Key))
+	s.Equal("1", string(resp.Events[0].Kv.Value))
+	s.Nil(resp.Events[0].PrevKv)
+	resp = <-ch
+	s.Require().Equal(1, len(resp.Events))
+	s.Equal(mvccpb.DELETE, resp.Events[0].Type)
+
+	// the events of a transaction are delivered in one response
+	resp = <-prefixCh
+	s.Equal(2, len(resp.Events))
+	resp = <-prefixCh
+	s.Equal(2, len(resp.Events))
+	for _, event := range resp.Events {
+		s.Equal(mvccpb.DELETE, event.Type)
+	}
+}
+
+func (s *WatchKVSuite) TestWatchWithRevision() {
+	loader, ok := s.kv.(revisionLoader)
+	if !ok {
+		s.T().Skip("the kv doesn't support to load with revision")
+	}
+	kv := s.kv
+
+	revisionTests := []struct {
+		inKey       string
+		fistValue   string
+		secondValue string
+	}{
+		{"a", "v1", "v11"},
+		{"y", "v2", "v22"},
+		{"z", "v3", "v33"},
+	}
+
+	for _, test := range revisionTests {
+		err := kv.Save(context.TODO(), test.inKey, test.fistValue)
+		s.Require().NoError(err)
+
+		_, _, revision, err := loader.LoadBytesWithRevision(context.TODO(), test.inKey)
+		s.Require().NoError(err)
+		ch := kv.WatchWithRevision(context.TODO(), test.inKey, revision+1)
+
+		err = kv.Save(context.TODO(), test.inKey, test.secondValue)
+		s.Require().NoError(err)
+
+		resp := <-ch
+		s.Equal(1, len(resp.Events))
+		s.Equal(test.secondValue, string(resp.Events[0].Kv.Value))
+		s.Equal(test.fistValue, string(resp.Events[0].PrevKv.Value))
+		s.Equal(revision+1, resp.Header.Revision)
+
+		// replay the history, the events of the history may be delivered in one response
+		ch = kv.WatchWithRevision(context.TODO(), test.inKey, revision)
+		values := make([]string, 0, 2)
+		for len(values) < 2 {
+			resp = <-ch
+			s.Require().NoError(resp.Err())
+			for _, event := range resp.Events {
+				values = append(values, string(event.Kv.Value))
+			}
+		}
+		s.Equal([]string{test.fistValue, test.secondValue}, values)
+	}
+}
@@ -0,0 +1,292 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlitekv
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/cockroachdb/errors"
+	_ "github.com/mattn/go-sqlite3"
+	"go.etcd.io/etcd/api/v3/mvccpb"
+	clientv3 "go.etcd.io/etcd/client/v3"
+	"go.uber.org/zap"
+
+	"github.com/milvus-io/milvus/pkg/log"
+)
+
+const (
+	driverName = "sqlite3"
+
+	// busyTimeout is the milliseconds to wait for the lock held by other connections.
+	busyTimeout = 5000
+
+	schema = `
+CREATE TABLE IF NOT EXISTS kv (
+	key             TEXT PRIMARY KEY,
+	value           BLOB NOT NULL,
+	create_revision INTEGER NOT NULL,
+	mod_revision    INTEGER NOT NULL,
+	version         INTEGER NOT NULL
+) WITHOUT ROWID;
+CREATE TABLE IF NOT EXISTS revision (
+	id       INTEGER PRIMARY KEY CHECK (id = 0),
+	revision INTEGER NOT NULL
+);
+INSERT OR IGNORE INTO revision (id, revision) VALUES (0, 1);`
+)
+
+// DB is a sqlite database storing the kv pairs in the etcd data model.
+// Each write transaction bumps the revision by one, and every key keeps its create revision,
+// mod revision and version, so that the watch events could be emulated the same as etcd.
+//
+// The database shall be accessed by a single process, the write transactions are serialized
+// by DB itself and the readers are never blocked thanks to the WAL journal mode.
+type DB struct {
+	path string
+	db   *sql.DB
+
+	// mu serializes the write transactions and the dispatch of their watch events.
+	mu  sync.Mutex
+	hub *watchHub
+}
+
+// Open opens the sqlite database at path, the database file is created if not exist.
+func Open(path string) (*DB, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, errors.Wrapf(err, "failed to create directory for sqlite database %s", path)
+	}
+	dsn := fmt.Sprintf("file:%s?_journal_mode=WAL&_synchronous=NORMAL&_busy_timeout=%d", path, busyTimeout)
+	db, err := sql.Open(driverName, dsn)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to open sqlite database %s", path)
+	}
+	if _, err := db.Exec(schema); err != nil {
+		db.Close()
+		return nil, errors.Wrapf(err, "failed to init schema of sqlite database %s", path)
+	}
+
+	var revision int64
+	if err := db.QueryRow(`SELECT revision FROM revision WHERE id = 0`).Scan(&revision); err != nil {
+		db.Close()
+		return nil, errors.Wrapf(err, "failed to load revision of sqlite database %s", path)
+	}
+	log.Info("sqlite database opened", zap.String("path", path), zap.Int64("revision", revision))
+	return &DB{
+		path: path,
+		db:   db,
+		hub:  newWatchHub(revision),
+	}, nil
+}
+
+// Close closes the database and all the watch channels.
+func (db *DB) Close() error {
+	db.hub.close()
+	return db.db.Close()
+}
+
+// Revision returns the current revision of the database.
+func (db *DB) Revision() int64 {
+	return db.hub.currentRevision()
+}
+
+// view runs fn in a read only transaction.
+func (db *DB) view(ctx context.Context, fn func(tx *sql.Tx) error) error {
+	tx, err := db.db.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	return fn(tx)
+}
+
+// update runs fn in a write transaction. The revision is bumped and the watch events are
+// dispatched only if the transaction is committed with any changes.
+func (db *DB) update(ctx context.Context, fn func(txn *writeTxn) error) error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	tx, err := db.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	txn := &writeTxn{
+		ctx:      ctx,
+		tx:       tx,
+		revision: db.hub.currentRevision() + 1,
+	}
+	if err := fn(txn); err != nil {
+		return err
+	}
+	if len(txn.events) == 0 {
+		return tx.Commit()
+	}
+	if _, err := tx.ExecContext(ctx, `UPDATE revision SET revision = ? WHERE id = 0`, txn.revision); err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	db.hub.notify(txn.revision, txn.events)
+	return nil
+}
+
+// writeTxn applies the changes within a write transaction and records the watch events.
+type writeTxn struct {
+	ctx      context.Context
+	tx       *sql.Tx
+	revision int64
+	events   []*clientv3.Event
+}
+
+func (txn *writeTxn) get(key string) (*mvccpb.KeyValue, error) {
+	return getKeyValue(txn.ctx, txn.tx, key)
+}
+
+func (txn *writeTxn) put(key string, value []byte) error {
+	prev, err := txn.get(key)
+	if err != nil {
+		return err
+	}
+	kv := &mvccpb.KeyValue{
+		Key:            []byte(key),
+		Value:          value,
+		CreateRevision: txn.revision,
+		ModRevision:    txn.revision,
+		Version:        1,
+	}
+	if prev != nil {
+		kv.CreateRevision = prev.CreateRevision
+		kv.Version = prev.Version + 1
+	}
+	_, err = txn.tx.ExecContext(txn.ctx,
+		`INSERT OR REPLACE INTO kv (key, value, create_revision, mod_revision, version) VALUES (?, ?, ?, ?, ?)`,
+		key, value, kv.CreateRevision, kv.ModRevision, kv.Version)
+	if err != nil {
+		return err
+	}
+	txn.events = append(txn.events, &clientv3.Event{Type: mvccpb.PUT, Kv: kv, PrevKv: prev})
+	return nil
+}
+
+func (txn *writeTxn) delete(key string) error {
+	prev, err := txn.get(key)
+	if err != nil || prev == nil {
+		return err
+	}
+	if _, err := txn.tx.ExecContext(txn.ctx, `DELETE FROM kv WHERE key = ?`, key); err != nil {
+		return err
+	}
+	txn.events = append(txn.events, &clientv3.Event{
+		Type:   mvccpb.DELETE,
+		Kv:     &mvccpb.KeyValue{Key: []byte(key), ModRevision: txn.revision},
+		PrevKv: prev,
+	})
+	return nil
+}
+
+func (txn *writeTxn) deletePrefix(prefix string) error {
+	prevs, err := rangeKeyValues(txn.ctx, txn.tx, prefix, 0)
+	if err != nil {
+		return err
+	}
+	for _, prev := range prevs {
+		if _, err := txn.tx.ExecContext(txn.ctx, `DELETE FROM kv WHERE key = ?`, string(prev.Key)); err != nil {
+			return err
+		}
+		txn.events = append(txn.events, &clientv3.Event{
+			Type:   mvccpb.DELETE,
+			Kv:     &mvccpb.KeyValue{Key: prev.Key, ModRevision: txn.revision},
+			PrevKv: prev,
+		})
+	}
+	return nil
+}
+
+type queryer interface {
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+func getKeyValue(ctx context.Context, q queryer, key string) (*mvccpb.KeyValue, error) {
+	kv := &mvccpb.KeyValue{}
+	err := q.QueryRowContext(ctx, `SELECT key, value, create_revision, mod_revision, version FROM kv WHERE key = ?`, key).
+		Scan(&kv.Key, &kv.Value, &kv.CreateRevision, &kv.ModRevision, &kv.Version)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return kv, nil
+}
+
+// rangeKeyValues returns the key values with the given prefix in ascending key order,
+// at most limit ones are returned if limit is positive.
+func rangeKeyValues(ctx context.Context, q queryer, prefix string, limit int) ([]*mvccpb.KeyValue, error) {
+	return rangeKeyValuesFrom(ctx, q, prefix, prefix, limit)
+}
+
+// rangeKeyValuesFrom is the same as rangeKeyValues but starts from the given key.
+func rangeKeyValuesFrom(ctx context.Context, q queryer, prefix string, from string, limit int) ([]*mvccpb.KeyValue, error) {
+	query := `SELECT key, value, create_revision, mod_revision, version FROM kv WHERE key >= ?`
+	args := []any{from}
+	if end, ok := prefixEnd(prefix); ok {
+		query += ` AND key < ?`
+		args = append(args, end)
+	}
+	query += ` ORDER BY key`
+	if limit > 0 {
+		query += ` LIMIT ?`
+		args = append(args, limit)
+	}
+
+	rows, err := q.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var kvs []*mvccpb.KeyValue
+	for rows.Next() {
+		kv := &mvccpb.KeyValue{}
+		if err := rows.Scan(&kv.Key, &kv.Value, &kv.CreateRevision, &kv.ModRevision, &kv.Version); err != nil {
+			return nil, err
+		}
+		kvs = append(kvs, kv)
+	}
+	return kvs, rows.Err()
+}
+
+// prefixEnd returns the smallest key larger than all the keys with the given prefix,
+// returns false if there is no such key, which means all keys from the prefix.
+func prefixEnd(prefix string) (string, bool) {
+	end := []byte(prefix)
+	for i := len(end) - 1; i >= 0; i-- {
+		if end[i] < 0xff {
+			end[i]++
+			return string(end[:i+1]), true
+		}
+	}
+	return "", false
+}
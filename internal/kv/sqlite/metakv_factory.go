@@ -0,0 +1,68 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlitekv
+
+import (
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/milvus-io/milvus/pkg/kv"
+	"github.com/milvus-io/milvus/pkg/log"
+	"github.com/milvus-io/milvus/pkg/util/paramtable"
+)
+
+var (
+	sharedDBMu sync.Mutex
+	sharedDBs  = make(map[string]*DB)
+)
+
+// GetSharedDB returns the DB opened at path, which is shared by all the components in the process,
+// so that the coordinators in standalone mode see the same revision and watch events.
+func GetSharedDB(path string) (*DB, error) {
+	sharedDBMu.Lock()
+	defer sharedDBMu.Unlock()
+
+	if db, ok := sharedDBs[path]; ok {
+		return db, nil
+	}
+	db, err := Open(path)
+	if err != nil {
+		return nil, err
+	}
+	sharedDBs[path] = db
+	return db, nil
+}
+
+// NewWatchKVFactory returns an object that implements the kv.WatchKV interface using the shared sqlite database.
+func NewWatchKVFactory(rootPath string, sqliteCfg *paramtable.SQLiteConfig) (kv.WatchKV, error) {
+	log.Info("start sqlite with rootPath",
+		zap.String("rootpath", rootPath),
+		zap.String("path", sqliteCfg.Path.GetValue()))
+	db, err := GetSharedDB(sqliteCfg.Path.GetValue())
+	if err != nil {
+		return nil, err
+	}
+	return NewSQLiteKV(db, rootPath,
+		WithRequestTimeout(sqliteCfg.RequestTimeout.GetAsDuration(time.Millisecond))), nil
+}
+
+// NewMetaKvFactory returns an object that implements the kv.MetaKv interface using the shared sqlite database.
+func NewMetaKvFactory(rootPath string, sqliteCfg *paramtable.SQLiteConfig) (kv.MetaKv, error) {
+	return NewWatchKVFactory(rootPath, sqliteCfg)
+}
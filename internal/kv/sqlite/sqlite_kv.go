@@ -0,0 +1,401 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlitekv
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"path"
+	"time"
+
+	"go.etcd.io/etcd/api/v3/mvccpb"
+	clientv3 "go.etcd.io/etcd/client/v3"
+	"go.uber.org/zap"
+
+	"github.com/milvus-io/milvus/pkg/kv"
+	"github.com/milvus-io/milvus/pkg/kv/predicates"
+	"github.com/milvus-io/milvus/pkg/log"
+	"github.com/milvus-io/milvus/pkg/metrics"
+	"github.com/milvus-io/milvus/pkg/util/merr"
+)
+
+const (
+	// defaultRequestTimeout is default timeout for sqlite request.
+	defaultRequestTimeout = 10 * time.Second
+)
+
+// implementation assertion
+var _ kv.WatchKV = (*sqliteKV)(nil)
+
+// sqliteKV implements WatchKV interface on a sqlite database, it supports to process multiple kvs in a transaction.
+// All the sqliteKVs on the same DB share the revision and watch events, like the etcdKVs on the same etcd.
+type sqliteKV struct {
+	db       *DB
+	rootPath string
+
+	requestTimeout time.Duration
+}
+
+// NewSQLiteKV creates a new sqlite kv.
+func NewSQLiteKV(db *DB, rootPath string, options ...Option) *sqliteKV {
+	opt := defaultOption()
+	for _, option := range options {
+		option(opt)
+	}
+	return &sqliteKV{
+		db:       db,
+		rootPath: rootPath,
+
+		requestTimeout: opt.requestTimeout,
+	}
+}
+
+// Close does nothing as the DB may be shared by other kvs.
+func (kv *sqliteKV) Close() {
+	log.Debug("sqlite kv closed", zap.String("path", kv.rootPath))
+}
+
+// GetPath returns the path of the key.
+func (kv *sqliteKV) GetPath(key string) string {
+	return path.Join(kv.rootPath, key)
+}
+
+// Load returns value of the key.
+func (kv *sqliteKV) Load(ctx context.Context, key string) (string, error) {
+	key = path.Join(kv.rootPath, key)
+	ctx, cancel := context.WithTimeout(ctx, kv.requestTimeout)
+	defer cancel()
+
+	var result *mvccpb.KeyValue
+	err := kv.read(ctx, func(tx *sql.Tx) error {
+		var err error
+		result, err = getKeyValue(ctx, tx, key)
+		return err
+	})
+	if err != nil {
+		return "", err
+	}
+	if result == nil {
+		return "", merr.WrapErrIoKeyNotFound(key)
+	}
+	return string(result.Value), nil
+}
+
+// MultiLoad gets the values of the keys in a transaction.
+func (kv *sqliteKV) MultiLoad(ctx context.Context, keys []string) ([]string, error) {
+	ctx, cancel := context.WithTimeout(ctx, kv.requestTimeout)
+	defer cancel()
+
+	result := make([]string, 0, len(keys))
+	invalid := make([]string, 0, len(keys))
+	err := kv.read(ctx, func(tx *sql.Tx) error {
+		for _, key := range keys {
+			value, err := getKeyValue(ctx, tx, path.Join(kv.rootPath, key))
+			if err != nil {
+				return err
+			}
+			if value == nil {
+				invalid = append(invalid, key)
+				result = append(result, "")
+				continue
+			}
+			result = append(result, string(value.Value))
+		}
+		return nil
+	})
+	if err != nil {
+		return []string{}, err
+	}
+	if len(invalid) != 0 {
+		log.Warn("MultiLoad: there are invalid keys", zap.Strings("keys", invalid))
+		return result, fmt.Errorf("there are invalid keys: %s", invalid)
+	}
+	return result, nil
+}
+
+// LoadWithPrefix returns all the keys and values with the given key prefix.
+func (kv *sqliteKV) LoadWithPrefix(ctx context.Context, key string) ([]string, []string, error) {
+	keys, values, _, err := kv.LoadBytesWithRevision(ctx, key)
+	if err != nil {
+		return nil, nil, err
+	}
+	strValues := make([]string, 0, len(values))
+	for _, value := range values {
+		strValues = append(strValues, string(value))
+	}
+	return keys, strValues, nil
+}
+
+// LoadBytesWithRevision returns keys, values and revision with given key prefix.
+func (kv *sqliteKV) LoadBytesWithRevision(ctx context.Context, key string) ([]string, [][]byte, int64, error) {
+	key = path.Join(kv.rootPath, key)
+	ctx, cancel := context.WithTimeout(ctx, kv.requestTimeout)
+	defer cancel()
+
+	var revision int64
+	var kvs []*mvccpb.KeyValue
+	err := kv.read(ctx, func(tx *sql.Tx) error {
+		if err := tx.QueryRowContext(ctx, `SELECT revision FROM revision WHERE id = 0`).Scan(&revision); err != nil {
+			return err
+		}
+		var err error
+		kvs, err = rangeKeyValues(ctx, tx, key, 0)
+		return err
+	})
+	if err != nil {
+		return nil, nil, 0, err
+	}
+	keys := make([]string, 0, len(kvs))
+	values := make([][]byte, 0, len(kvs))
+	for _, kv := range kvs {
+		keys = append(keys, string(kv.Key))
+		values = append(values, kv.Value)
+	}
+	return keys, values, revision, nil
+}
+
+// WalkWithPrefix visits each kv with input prefix in key order and applies given fn to it.
+func (kv *sqliteKV) WalkWithPrefix(ctx context.Context, prefix string, paginationSize int, fn func([]byte, []byte) error) error {
+	prefix = path.Join(kv.rootPath, prefix)
+
+	from := prefix
+	for {
+		var kvs []*mvccpb.KeyValue
+		err := kv.read(ctx, func(tx *sql.Tx) error {
+			var err error
+			kvs, err = rangeKeyValuesFrom(ctx, tx, prefix, from, paginationSize)
+			return err
+		})
+		if err != nil {
+			return err
+		}
+		for _, kv := range kvs {
+			if err := fn(kv.Key, kv.Value); err != nil {
+				return err
+			}
+		}
+		if paginationSize <= 0 || len(kvs) < paginationSize {
+			return nil
+		}
+		// move to next key
+		from = string(kvs[len(kvs)-1].Key) + "\x00"
+	}
+}
+
+// Has returns whether the key exists.
+func (kv *sqliteKV) Has(ctx context.Context, key string) (bool, error) {
+	key = path.Join(kv.rootPath, key)
+	ctx, cancel := context.WithTimeout(ctx, kv.requestTimeout)
+	defer cancel()
+
+	var result *mvccpb.KeyValue
+	err := kv.read(ctx, func(tx *sql.Tx) error {
+		var err error
+		result, err = getKeyValue(ctx, tx, key)
+		return err
+	})
+	if err != nil {
+		return false, err
+	}
+	return result != nil, nil
+}
+
+// HasPrefix returns whether any key with the given prefix exists.
+func (kv *sqliteKV) HasPrefix(ctx context.Context, prefix string) (bool, error) {
+	prefix = path.Join(kv.rootPath, prefix)
+	ctx, cancel := context.WithTimeout(ctx, kv.requestTimeout)
+	defer cancel()
+
+	var kvs []*mvccpb.KeyValue
+	err := kv.read(ctx, func(tx *sql.Tx) error {
+		var err error
+		kvs, err = rangeKeyValues(ctx, tx, prefix, 1)
+		return err
+	})
+	if err != nil {
+		return false, err
+	}
+	return len(kvs) != 0, nil
+}
+
+// Save saves the key-value pair.
+func (kv *sqliteKV) Save(ctx context.Context, key, value string) error {
+	return kv.MultiSaveAndRemove(ctx, map[string]string{key: value}, nil)
+}
+
+// MultiSave saves the key-value pairs in a transaction.
+func (kv *sqliteKV) MultiSave(ctx context.Context, kvs map[string]string) error {
+	return kv.MultiSaveAndRemove(ctx, kvs, nil)
+}
+
+// Remove removes the key.
+func (kv *sqliteKV) Remove(ctx context.Context, key string) error {
+	return kv.MultiSaveAndRemove(ctx, nil, []string{key})
+}
+
+// MultiRemove removes the keys in a transaction.
+func (kv *sqliteKV) MultiRemove(ctx context.Context, keys []string) error {
+	return kv.MultiSaveAndRemove(ctx, nil, keys)
+}
+
+// RemoveWithPrefix removes the keys with given prefix.
+func (kv *sqliteKV) RemoveWithPrefix(ctx context.Context, prefix string) error {
+	return kv.MultiSaveAndRemoveWithPrefix(ctx, nil, []string{prefix})
+}
+
+// MultiSaveAndRemove saves the key-value pairs and removes the keys in a transaction.
+func (kv *sqliteKV) MultiSaveAndRemove(ctx context.Context, saves map[string]string, removals []string, preds ...predicates.Predicate) error {
+	return kv.multiSaveAndRemove(ctx, saves, removals, false, preds...)
+}
+
+// MultiSaveAndRemoveWithPrefix saves kv in @saves and removes the keys with given prefix in @removals.
+func (kv *sqliteKV) MultiSaveAndRemoveWithPrefix(ctx context.Context, saves map[string]string, removals []string, preds ...predicates.Predicate) error {
+	return kv.multiSaveAndRemove(ctx, saves, removals, true, preds...)
+}
+
+func (kv *sqliteKV) multiSaveAndRemove(ctx context.Context, saves map[string]string, removals []string, removeWithPrefix bool, preds ...predicates.Predicate) error {
+	if err := checkPredicates(preds...); err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, kv.requestTimeout)
+	defer cancel()
+
+	err := kv.write(ctx, func(txn *writeTxn) error {
+		for _, pred := range preds {
+			target, err := txn.get(path.Join(kv.rootPath, pred.Key()))
+			if err != nil {
+				return err
+			}
+			if target == nil || !pred.IsTrue(target.Value) {
+				return merr.WrapErrIoFailedReason("failed to execute transaction",
+					fmt.Sprintf("predicate not met, key=%s, value=%v", pred.Key(), pred.TargetValue()))
+			}
+		}
+		for key, value := range saves {
+			if err := txn.put(path.Join(kv.rootPath, key), []byte(value)); err != nil {
+				return err
+			}
+		}
+		for _, key := range removals {
+			var err error
+			if removeWithPrefix {
+				err = txn.deletePrefix(path.Join(kv.rootPath, key))
+			} else {
+				err = txn.delete(path.Join(kv.rootPath, key))
+			}
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		log.Warn("SQLite MultiSaveAndRemove error",
+			zap.Int("saveLength", len(saves)),
+			zap.Strings("removes", removals),
+			zap.Bool("withPrefix", removeWithPrefix),
+			zap.Error(err))
+	}
+	return err
+}
+
+// CompareVersionAndSwap compares the existing key-value's version with version, and if
+// they are equal, the target is stored in sqlite.
+func (kv *sqliteKV) CompareVersionAndSwap(ctx context.Context, key string, version int64, target string) (bool, error) {
+	key = path.Join(kv.rootPath, key)
+	ctx, cancel := context.WithTimeout(ctx, kv.requestTimeout)
+	defer cancel()
+
+	succeeded := false
+	err := kv.write(ctx, func(txn *writeTxn) error {
+		current, err := txn.get(key)
+		if err != nil {
+			return err
+		}
+		var currentVersion int64
+		if current != nil {
+			currentVersion = current.Version
+		}
+		if currentVersion != version {
+			return nil
+		}
+		succeeded = true
+		return txn.put(key, []byte(target))
+	})
+	if err != nil {
+		return false, err
+	}
+	return succeeded, nil
+}
+
+// Watch starts watching a key, returns a watch channel.
+func (kv *sqliteKV) Watch(ctx context.Context, key string) clientv3.WatchChan {
+	key = path.Join(kv.rootPath, key)
+	return kv.db.hub.watch(ctx, key, false, 0, false, true)
+}
+
+// WatchWithPrefix starts watching a key with prefix, returns a watch channel.
+func (kv *sqliteKV) WatchWithPrefix(ctx context.Context, key string) clientv3.WatchChan {
+	key = path.Join(kv.rootPath, key)
+	return kv.db.hub.watch(ctx, key, true, 0, false, true)
+}
+
+// WatchWithRevision starts watching a key with prefix from revision, returns a watch channel.
+// The watch is canceled with CompactRevision set if the revision is too old to replay.
+func (kv *sqliteKV) WatchWithRevision(ctx context.Context, key string, revision int64) clientv3.WatchChan {
+	key = path.Join(kv.rootPath, key)
+	return kv.db.hub.watch(ctx, key, true, revision, true, false)
+}
+
+func (kv *sqliteKV) read(ctx context.Context, fn func(tx *sql.Tx) error) error {
+	start := time.Now()
+	err := kv.db.view(ctx, fn)
+	observe(metrics.MetaGetLabel, start, err)
+	return err
+}
+
+func (kv *sqliteKV) write(ctx context.Context, fn func(txn *writeTxn) error) error {
+	start := time.Now()
+	err := kv.db.update(ctx, fn)
+	observe(metrics.MetaTxnLabel, start, err)
+	return err
+}
+
+func observe(label string, start time.Time, err error) {
+	metrics.MetaOpCounter.WithLabelValues(label, metrics.TotalLabel).Inc()
+	if err != nil {
+		metrics.MetaOpCounter.WithLabelValues(label, metrics.FailLabel).Inc()
+		return
+	}
+	metrics.MetaRequestLatency.WithLabelValues(label).Observe(float64(time.Since(start).Milliseconds()))
+	metrics.MetaOpCounter.WithLabelValues(label, metrics.SuccessLabel).Inc()
+}
+
+// checkPredicates checks whether the predicates are supported, same as the etcd kv.
+func checkPredicates(preds ...predicates.Predicate) error {
+	for _, pred := range preds {
+		if pred.Target() != predicates.PredTargetValue {
+			return merr.WrapErrParameterInvalid("valid predicate target", fmt.Sprintf("%d", pred.Target()))
+		}
+		if pred.Type() != predicates.PredTypeEqual {
+			return merr.WrapErrParameterInvalid("valid predicate type", fmt.Sprintf("%d", pred.Type()))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,152 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlitekv
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/milvus-io/milvus/internal/kv/kvtest"
+	"github.com/milvus-io/milvus/pkg/kv"
+	"github.com/milvus-io/milvus/pkg/util/paramtable"
+)
+
+func TestMain(m *testing.M) {
+	paramtable.Init()
+	code := m.Run()
+	os.Exit(code)
+}
+
+func TestSQLiteKV(t *testing.T) {
+	db, err := Open(filepath.Join(t.TempDir(), "meta.db"))
+	require.NoError(t, err)
+	defer db.Close()
+
+	suite.Run(t, &kvtest.WatchKVSuite{NewKV: func(rootPath string) kv.WatchKV {
+		return NewSQLiteKV(db, rootPath)
+	}})
+}
+
+func TestLoadBytesWithRevision(t *testing.T) {
+	db, err := Open(filepath.Join(t.TempDir(), "meta.db"))
+	require.NoError(t, err)
+	defer db.Close()
+	kv := NewSQLiteKV(db, "root")
+
+	require.NoError(t, kv.MultiSave(context.TODO(), map[string]string{"a": "a_version1", "a/suba": "a_version2", "b": "b_version3"}))
+	require.NoError(t, kv.Save(context.TODO(), "a", "a_version4"))
+
+	keys, values, revision, err := kv.LoadBytesWithRevision(context.TODO(), "a")
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []string{kv.GetPath("a"), kv.GetPath("a/suba")}, keys)
+	assert.ElementsMatch(t, [][]byte{[]byte("a_version4"), []byte("a_version2")}, values)
+	assert.Equal(t, db.Revision(), revision)
+}
+
+func TestWalkWithNonPositivePagination(t *testing.T) {
+	db, err := Open(filepath.Join(t.TempDir(), "meta.db"))
+	require.NoError(t, err)
+	defer db.Close()
+	kv := NewSQLiteKV(db, "root")
+
+	require.NoError(t, kv.MultiSave(context.TODO(), map[string]string{"A/1": "v1", "A/2": "v2", "B/1": "v3"}))
+	for _, pagination := range []int{-100, -1, 0} {
+		keys := make([]string, 0)
+		err = kv.WalkWithPrefix(context.TODO(), "A", pagination, func(key []byte, value []byte) error {
+			keys = append(keys, string(key))
+			return nil
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{kv.GetPath("A/1"), kv.GetPath("A/2")}, keys)
+	}
+}
+
+func TestWatchCanceled(t *testing.T) {
+	db, err := Open(filepath.Join(t.TempDir(), "meta.db"))
+	require.NoError(t, err)
+	defer db.Close()
+	kv := NewSQLiteKV(db, "root")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	ch := kv.Watch(ctx, "x")
+	resp := <-ch
+	assert.True(t, resp.Created)
+
+	// the channel is closed once the context is done
+	cancel()
+	assert.Eventually(t, func() bool {
+		_, ok := <-ch
+		return !ok
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestReopen(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "meta.db")
+	db, err := Open(dbPath)
+	require.NoError(t, err)
+
+	kv := NewSQLiteKV(db, "root")
+	require.NoError(t, kv.Save(context.TODO(), "key", "value"))
+	revision := db.Revision()
+	require.NoError(t, db.Close())
+
+	// the channel is closed if the database is closed
+	ch := kv.Watch(context.TODO(), "key")
+	resp, ok := <-ch
+	assert.True(t, ok)
+	assert.True(t, resp.Created)
+	_, ok = <-ch
+	assert.False(t, ok)
+
+	db, err = Open(dbPath)
+	require.NoError(t, err)
+	defer db.Close()
+	assert.Equal(t, revision, db.Revision())
+
+	kv = NewSQLiteKV(db, "root")
+	value, err := kv.Load(context.TODO(), "key")
+	assert.NoError(t, err)
+	assert.Equal(t, "value", value)
+
+	// the history before reopen is not available
+	resp = <-kv.WatchWithRevision(context.TODO(), "key", revision)
+	assert.True(t, resp.Canceled)
+	assert.Equal(t, revision+1, resp.CompactRevision)
+	assert.Error(t, resp.Err())
+}
+
+func TestPrefixEnd(t *testing.T) {
+	end, ok := prefixEnd("abc")
+	assert.True(t, ok)
+	assert.Equal(t, "abd", end)
+
+	end, ok = prefixEnd("a\xff")
+	assert.True(t, ok)
+	assert.Equal(t, "b", end)
+
+	_, ok = prefixEnd("\xff\xff")
+	assert.False(t, ok)
+	_, ok = prefixEnd("")
+	assert.False(t, ok)
+}
@@ -0,0 +1,260 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlitekv
+
+import (
+	"context"
+	"strings"
+	"sync"
+
+	"go.etcd.io/etcd/api/v3/etcdserverpb"
+	clientv3 "go.etcd.io/etcd/client/v3"
+)
+
+// defaultWatchHistorySize is the number of recent revisions kept in memory for watching with revision.
+const defaultWatchHistorySize = 4096
+
+type revisionEvents struct {
+	revision int64
+	events   []*clientv3.Event
+}
+
+// watchHub emulates the etcd watch within a single process.
+// The events of each committed write transaction are dispatched to the watchers,
+// and the recent ones are kept to serve the watches starting from a history revision.
+// It serves the watches on the metadata only, there is no lease, so the sessions and
+// service discovery are not backed by sqlite and still rely on etcd.
+type watchHub struct {
+	mu        sync.Mutex
+	revision  int64
+	watchers  map[*watcher]struct{}
+	history   []revisionEvents
+	compacted int64
+	closed    chan struct{}
+}
+
+func newWatchHub(revision int64) *watchHub {
+	return &watchHub{
+		revision: revision,
+		watchers: make(map[*watcher]struct{}),
+		// the history before the database opened is not available
+		compacted: revision,
+		closed:    make(chan struct{}),
+	}
+}
+
+func (h *watchHub) currentRevision() int64 {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.revision
+}
+
+// watch starts watching the key, or all the keys with the key as prefix if withPrefix.
+// The events from startRevision are replayed if startRevision is positive.
+func (h *watchHub) watch(ctx context.Context, key string, withPrefix bool, startRevision int64, prevKV bool, createdNotify bool) clientv3.WatchChan {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	w := &watcher{
+		key:           key,
+		withPrefix:    withPrefix,
+		startRevision: startRevision,
+		prevKV:        prevKV,
+		ch:            make(chan clientv3.WatchResponse),
+		notify:        make(chan struct{}, 1),
+	}
+	if startRevision <= 0 {
+		w.startRevision = h.revision + 1
+	}
+	if createdNotify {
+		w.enqueue(clientv3.WatchResponse{
+			Header:  etcdserverpb.ResponseHeader{Revision: h.revision},
+			Created: true,
+		})
+	}
+
+	select {
+	case <-h.closed:
+		w.cancel()
+		go w.run(ctx, h)
+		return w.ch
+	default:
+	}
+
+	if w.startRevision <= h.compacted {
+		// same as etcd, the watch is canceled if the required revision has been compacted
+		w.enqueue(clientv3.WatchResponse{
+			Header:          etcdserverpb.ResponseHeader{Revision: h.revision},
+			CompactRevision: h.compacted + 1,
+			Canceled:        true,
+		})
+		w.cancel()
+		go w.run(ctx, h)
+		return w.ch
+	}
+
+	for _, history := range h.history {
+		if history.revision >= w.startRevision {
+			w.dispatch(history.revision, history.events)
+		}
+	}
+	h.watchers[w] = struct{}{}
+	go w.run(ctx, h)
+	return w.ch
+}
+
+// notify dispatches the events of a committed revision to the watchers.
+func (h *watchHub) notify(revision int64, events []*clientv3.Event) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.revision = revision
+	h.history = append(h.history, revisionEvents{revision: revision, events: events})
+	if len(h.history) > defaultWatchHistorySize {
+		h.compacted = h.history[0].revision
+		h.history = h.history[1:]
+	}
+	for w := range h.watchers {
+		w.dispatch(revision, events)
+	}
+}
+
+func (h *watchHub) remove(w *watcher) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	delete(h.watchers, w)
+}
+
+// close cancels all the watchers, the watch channels are closed after the pending responses consumed.
+func (h *watchHub) close() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	select {
+	case <-h.closed:
+		return
+	default:
+	}
+	close(h.closed)
+	for w := range h.watchers {
+		w.cancel()
+	}
+	h.watchers = make(map[*watcher]struct{})
+}
+
+type watcher struct {
+	key           string
+	withPrefix    bool
+	startRevision int64
+	prevKV        bool
+
+	ch     chan clientv3.WatchResponse
+	notify chan struct{}
+
+	mu       sync.Mutex
+	pending  []clientv3.WatchResponse
+	canceled bool
+}
+
+func (w *watcher) match(key []byte) bool {
+	if w.withPrefix {
+		return strings.HasPrefix(string(key), w.key)
+	}
+	return string(key) == w.key
+}
+
+// dispatch enqueues the events matching the watcher as one response.
+func (w *watcher) dispatch(revision int64, events []*clientv3.Event) {
+	if revision < w.startRevision {
+		return
+	}
+	var matched []*clientv3.Event
+	for _, event := range events {
+		if !w.match(event.Kv.Key) {
+			continue
+		}
+		if !w.prevKV && event.PrevKv != nil {
+			event = &clientv3.Event{Type: event.Type, Kv: event.Kv}
+		}
+		matched = append(matched, event)
+	}
+	if len(matched) == 0 {
+		return
+	}
+	w.enqueue(clientv3.WatchResponse{
+		Header: etcdserverpb.ResponseHeader{Revision: revision},
+		Events: matched,
+	})
+}
+
+// enqueue never blocks the writers, the responses are sent to the channel by the watcher goroutine.
+func (w *watcher) enqueue(resp clientv3.WatchResponse) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.pending = append(w.pending, resp)
+	w.signal()
+}
+
+// cancel marks the watcher as canceled, the channel is closed after all the pending responses sent.
+func (w *watcher) cancel() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.canceled = true
+	w.signal()
+}
+
+func (w *watcher) signal() {
+	select {
+	case w.notify <- struct{}{}:
+	default:
+	}
+}
+
+func (w *watcher) run(ctx context.Context, h *watchHub) {
+	defer func() {
+		h.remove(w)
+		close(w.ch)
+	}()
+
+	for {
+		w.mu.Lock()
+		pending := w.pending
+		w.pending = nil
+		canceled := w.canceled
+		w.mu.Unlock()
+
+		for _, resp := range pending {
+			select {
+			case w.ch <- resp:
+			case <-ctx.Done():
+				return
+			}
+		}
+		if len(pending) > 0 {
+			continue
+		}
+		if canceled {
+			return
+		}
+
+		select {
+		case <-w.notify:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
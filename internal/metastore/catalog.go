@@ -8,6 +8,7 @@ import (
 	"github.com/milvus-io/milvus/internal/metastore/model"
 	"github.com/milvus-io/milvus/internal/proto/datapb"
 	"github.com/milvus-io/milvus/internal/proto/indexpb"
+	"github.com/milvus-io/milvus/internal/proto/internalpb"
 	"github.com/milvus-io/milvus/internal/proto/querypb"
 	"github.com/milvus-io/milvus/pkg/streaming/proto/streamingpb"
 	"github.com/milvus-io/milvus/pkg/util/typeutil"
@@ -91,6 +92,13 @@ type RootCoordCatalog interface {
 	SavePrivilegeGroup(ctx context.Context, data *milvuspb.PrivilegeGroupInfo) error
 	ListPrivilegeGroups(ctx context.Context) ([]*milvuspb.PrivilegeGroupInfo, error)
 
+	// SaveRowPolicy saves the row policy of a role on a collection, the existing one is overwritten.
+	SaveRowPolicy(ctx context.Context, tenant string, policy *internalpb.RowPolicy) error
+	DropRowPolicy(ctx context.Context, tenant string, role string, dbName string, collectionName string) error
+	// DeleteRowPolicies deletes all the row policies of a role.
+	DeleteRowPolicies(ctx context.Context, tenant string, role string) error
+	ListRowPolicies(ctx context.Context, tenant string) ([]*internalpb.RowPolicy, error)
+
 	Close()
 }
 
@@ -179,6 +187,18 @@ type DataCoordCatalog interface {
 	ListStatsTasks(ctx context.Context) ([]*indexpb.StatsTask, error)
 	SaveStatsTask(ctx context.Context, task *indexpb.StatsTask) error
 	DropStatsTask(ctx context.Context, taskID typeutil.UniqueID) error
+
+	ListSnapshots(ctx context.Context) ([]*datapb.SnapshotInfo, error)
+	ListSnapshotSegments(ctx context.Context, snapshotID typeutil.UniqueID) ([]*datapb.SnapshotSegment, error)
+	SaveSnapshot(ctx context.Context, snapshot *datapb.SnapshotInfo, segments []*datapb.SnapshotSegment) error
+	DropSnapshot(ctx context.Context, snapshotID typeutil.UniqueID) error
+
+	SaveExportJob(ctx context.Context, job *datapb.ExportJob) error
+	ListExportJobs(ctx context.Context) ([]*datapb.ExportJob, error)
+	DropExportJob(ctx context.Context, jobID int64) error
+	SaveExportTask(ctx context.Context, task *datapb.ExportTask) error
+	ListExportTasks(ctx context.Context) ([]*datapb.ExportTask, error)
+	DropExportTask(ctx context.Context, taskID int64) error
 }
 
 type QueryCoordCatalog interface {
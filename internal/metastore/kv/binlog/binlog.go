@@ -204,6 +204,8 @@ func BuildLogPathWithRootPath(chunkManagerRootPath string, binlogType storage.Bi
 		return metautil.BuildStatsLogPath(chunkManagerRootPath, collectionID, partitionID, segmentID, fieldID, logID), nil
 	case storage.BM25Binlog:
 		return metautil.BuildBm25LogPath(chunkManagerRootPath, collectionID, partitionID, segmentID, fieldID, logID), nil
+	case storage.FieldStatsBinlog:
+		return metautil.BuildFieldStatsLogPath(chunkManagerRootPath, collectionID, partitionID, segmentID, fieldID, logID), nil
 	}
 	// should not happen
 	return "", merr.WrapErrParameterInvalidMsg("invalid binlog type")
@@ -33,6 +33,10 @@ const (
 	PartitionStatsInfoPrefix           = MetaPrefix + "/partition-stats"
 	PartitionStatsCurrentVersionPrefix = MetaPrefix + "/current-partition-stats-version"
 	StatsTaskPrefix                    = MetaPrefix + "/stats-task"
+	SnapshotPrefix                     = MetaPrefix + "/snapshot"
+	SnapshotSegmentPrefix              = MetaPrefix + "/snapshot-segment"
+	ExportJobPrefix                    = MetaPrefix + "/export-job"
+	ExportTaskPrefix                   = MetaPrefix + "/export-task"
 
 	NonRemoveFlagTomestone = "non-removed"
 	RemoveFlagTomestone    = "removed"
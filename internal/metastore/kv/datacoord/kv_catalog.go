@@ -973,3 +973,140 @@ func (kc *Catalog) DropStatsTask(ctx context.Context, taskID typeutil.UniqueID)
 	key := buildStatsTaskKey(taskID)
 	return kc.MetaKv.Remove(ctx, key)
 }
+
+func (kc *Catalog) ListSnapshots(ctx context.Context) ([]*datapb.SnapshotInfo, error) {
+	snapshots := make([]*datapb.SnapshotInfo, 0)
+
+	applyFn := func(key []byte, value []byte) error {
+		snapshot := &datapb.SnapshotInfo{}
+		err := proto.Unmarshal(value, snapshot)
+		if err != nil {
+			return err
+		}
+		snapshots = append(snapshots, snapshot)
+		return nil
+	}
+
+	// SnapshotPrefix is also the prefix of SnapshotSegmentPrefix, walk with the trailing slash.
+	err := kc.MetaKv.WalkWithPrefix(ctx, SnapshotPrefix+"/", paginationSize, applyFn)
+	if err != nil {
+		return nil, err
+	}
+	return snapshots, nil
+}
+
+func (kc *Catalog) ListSnapshotSegments(ctx context.Context, snapshotID typeutil.UniqueID) ([]*datapb.SnapshotSegment, error) {
+	segments := make([]*datapb.SnapshotSegment, 0)
+
+	applyFn := func(key []byte, value []byte) error {
+		segment := &datapb.SnapshotSegment{}
+		err := proto.Unmarshal(value, segment)
+		if err != nil {
+			return err
+		}
+		segments = append(segments, segment)
+		return nil
+	}
+
+	err := kc.MetaKv.WalkWithPrefix(ctx, buildSnapshotSegmentPrefix(snapshotID), paginationSize, applyFn)
+	if err != nil {
+		return nil, err
+	}
+	return segments, nil
+}
+
+// SaveSnapshot saves the frozen segments first and the snapshot info at last,
+// segments without snapshot info are invisible.
+func (kc *Catalog) SaveSnapshot(ctx context.Context, snapshot *datapb.SnapshotInfo, segments []*datapb.SnapshotSegment) error {
+	kvs := make(map[string]string, len(segments))
+	for _, segment := range segments {
+		value, err := proto.Marshal(segment)
+		if err != nil {
+			return fmt.Errorf("failed to marshal snapshot segment: %d, err: %w", segment.GetSegment().GetID(), err)
+		}
+		kvs[buildSnapshotSegmentKey(snapshot.GetSnapshotID(), segment.GetSegment().GetID())] = string(value)
+	}
+	if err := kc.SaveByBatch(ctx, kvs); err != nil {
+		return err
+	}
+
+	value, err := proto.Marshal(snapshot)
+	if err != nil {
+		return err
+	}
+	return kc.MetaKv.Save(ctx, buildSnapshotKey(snapshot.GetSnapshotID()), string(value))
+}
+
+// DropSnapshot removes the snapshot info first, so a snapshot is never visible with part of its segments.
+func (kc *Catalog) DropSnapshot(ctx context.Context, snapshotID typeutil.UniqueID) error {
+	if err := kc.MetaKv.Remove(ctx, buildSnapshotKey(snapshotID)); err != nil {
+		return err
+	}
+	return kc.MetaKv.RemoveWithPrefix(ctx, buildSnapshotSegmentPrefix(snapshotID))
+}
+
+func (kc *Catalog) SaveExportJob(ctx context.Context, job *datapb.ExportJob) error {
+	key := buildExportJobKey(job.GetJobID())
+	value, err := proto.Marshal(job)
+	if err != nil {
+		return err
+	}
+	return kc.MetaKv.Save(ctx, key, string(value))
+}
+
+func (kc *Catalog) ListExportJobs(ctx context.Context) ([]*datapb.ExportJob, error) {
+	jobs := make([]*datapb.ExportJob, 0)
+	applyFn := func(key []byte, value []byte) error {
+		job := &datapb.ExportJob{}
+		err := proto.Unmarshal(value, job)
+		if err != nil {
+			return err
+		}
+		jobs = append(jobs, job)
+		return nil
+	}
+
+	err := kc.MetaKv.WalkWithPrefix(ctx, ExportJobPrefix, paginationSize, applyFn)
+	if err != nil {
+		return nil, err
+	}
+	return jobs, nil
+}
+
+func (kc *Catalog) DropExportJob(ctx context.Context, jobID int64) error {
+	key := buildExportJobKey(jobID)
+	return kc.MetaKv.Remove(ctx, key)
+}
+
+func (kc *Catalog) SaveExportTask(ctx context.Context, task *datapb.ExportTask) error {
+	key := buildExportTaskKey(task.GetTaskID())
+	value, err := proto.Marshal(task)
+	if err != nil {
+		return err
+	}
+	return kc.MetaKv.Save(ctx, key, string(value))
+}
+
+func (kc *Catalog) ListExportTasks(ctx context.Context) ([]*datapb.ExportTask, error) {
+	tasks := make([]*datapb.ExportTask, 0)
+	applyFn := func(key []byte, value []byte) error {
+		task := &datapb.ExportTask{}
+		err := proto.Unmarshal(value, task)
+		if err != nil {
+			return err
+		}
+		tasks = append(tasks, task)
+		return nil
+	}
+
+	err := kc.MetaKv.WalkWithPrefix(ctx, ExportTaskPrefix, paginationSize, applyFn)
+	if err != nil {
+		return nil, err
+	}
+	return tasks, nil
+}
+
+func (kc *Catalog) DropExportTask(ctx context.Context, taskID int64) error {
+	key := buildExportTaskKey(taskID)
+	return kc.MetaKv.Remove(ctx, key)
+}
@@ -1891,3 +1891,213 @@ func Test_StatsTasks(t *testing.T) {
 		assert.NoError(t, err)
 	})
 }
+
+func Test_Snapshots(t *testing.T) {
+	kc := &Catalog{}
+	mockErr := errors.New("mock error")
+
+	snapshot := &datapb.SnapshotInfo{
+		SnapshotID:   1,
+		Name:         "snapshot1",
+		CollectionID: 2,
+		SegmentIDs:   []int64{3},
+	}
+	segments := []*datapb.SnapshotSegment{
+		{
+			SnapshotID: 1,
+			Segment:    &datapb.SegmentInfo{ID: 3, CollectionID: 2, PartitionID: 4, NumOfRows: 100},
+		},
+	}
+
+	t.Run("ListSnapshots", func(t *testing.T) {
+		txn := mocks.NewMetaKv(t)
+		txn.EXPECT().WalkWithPrefix(mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(mockErr)
+		kc.MetaKv = txn
+
+		snapshots, err := kc.ListSnapshots(context.Background())
+		assert.Error(t, err)
+		assert.Nil(t, snapshots)
+
+		value, err := proto.Marshal(snapshot)
+		assert.NoError(t, err)
+
+		txn = mocks.NewMetaKv(t)
+		txn.EXPECT().WalkWithPrefix(mock.Anything, SnapshotPrefix+"/", mock.Anything, mock.Anything).RunAndReturn(func(_ context.Context, _ string, _ int, f func([]byte, []byte) error) error {
+			return f([]byte("key1"), value)
+		})
+		kc.MetaKv = txn
+
+		snapshots, err = kc.ListSnapshots(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, 1, len(snapshots))
+		assert.Equal(t, "snapshot1", snapshots[0].GetName())
+
+		txn = mocks.NewMetaKv(t)
+		txn.EXPECT().WalkWithPrefix(mock.Anything, mock.Anything, mock.Anything, mock.Anything).RunAndReturn(func(_ context.Context, _ string, _ int, f func([]byte, []byte) error) error {
+			return f([]byte("key1"), []byte("1234"))
+		})
+		kc.MetaKv = txn
+
+		snapshots, err = kc.ListSnapshots(context.Background())
+		assert.Error(t, err)
+		assert.Nil(t, snapshots)
+	})
+
+	t.Run("ListSnapshotSegments", func(t *testing.T) {
+		value, err := proto.Marshal(segments[0])
+		assert.NoError(t, err)
+
+		txn := mocks.NewMetaKv(t)
+		txn.EXPECT().WalkWithPrefix(mock.Anything, buildSnapshotSegmentPrefix(1), mock.Anything, mock.Anything).RunAndReturn(func(_ context.Context, _ string, _ int, f func([]byte, []byte) error) error {
+			return f([]byte("key1"), value)
+		})
+		kc.MetaKv = txn
+
+		result, err := kc.ListSnapshotSegments(context.Background(), 1)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, len(result))
+		assert.Equal(t, int64(100), result[0].GetSegment().GetNumOfRows())
+
+		txn = mocks.NewMetaKv(t)
+		txn.EXPECT().WalkWithPrefix(mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(mockErr)
+		kc.MetaKv = txn
+
+		result, err = kc.ListSnapshotSegments(context.Background(), 1)
+		assert.Error(t, err)
+		assert.Nil(t, result)
+	})
+
+	t.Run("SaveSnapshot", func(t *testing.T) {
+		txn := mocks.NewMetaKv(t)
+		txn.EXPECT().MultiSave(mock.Anything, mock.Anything).Return(mockErr)
+		kc.MetaKv = txn
+
+		err := kc.SaveSnapshot(context.Background(), snapshot, segments)
+		assert.Error(t, err)
+
+		txn = mocks.NewMetaKv(t)
+		txn.EXPECT().MultiSave(mock.Anything, mock.Anything).RunAndReturn(func(_ context.Context, kvs map[string]string) error {
+			assert.Contains(t, kvs, buildSnapshotSegmentKey(1, 3))
+			return nil
+		})
+		txn.EXPECT().Save(mock.Anything, buildSnapshotKey(1), mock.Anything).Return(nil)
+		kc.MetaKv = txn
+
+		err = kc.SaveSnapshot(context.Background(), snapshot, segments)
+		assert.NoError(t, err)
+	})
+
+	t.Run("DropSnapshot", func(t *testing.T) {
+		txn := mocks.NewMetaKv(t)
+		txn.EXPECT().Remove(mock.Anything, mock.Anything).Return(mockErr)
+		kc.MetaKv = txn
+
+		err := kc.DropSnapshot(context.Background(), 1)
+		assert.Error(t, err)
+
+		txn = mocks.NewMetaKv(t)
+		txn.EXPECT().Remove(mock.Anything, buildSnapshotKey(1)).Return(nil)
+		txn.EXPECT().RemoveWithPrefix(mock.Anything, buildSnapshotSegmentPrefix(1)).Return(nil)
+		kc.MetaKv = txn
+
+		err = kc.DropSnapshot(context.Background(), 1)
+		assert.NoError(t, err)
+	})
+}
+
+func TestCatalog_Export(t *testing.T) {
+	kc := &Catalog{}
+	mockErr := errors.New("mock error")
+
+	job := &datapb.ExportJob{
+		JobID: 1,
+	}
+	task := &datapb.ExportTask{
+		JobID:  1,
+		TaskID: 2,
+	}
+
+	t.Run("SaveExportJob", func(t *testing.T) {
+		txn := mocks.NewMetaKv(t)
+		txn.EXPECT().Save(mock.Anything, buildExportJobKey(1), mock.Anything).Return(nil)
+		kc.MetaKv = txn
+		err := kc.SaveExportJob(context.TODO(), job)
+		assert.NoError(t, err)
+
+		txn = mocks.NewMetaKv(t)
+		txn.EXPECT().Save(mock.Anything, mock.Anything, mock.Anything).Return(mockErr)
+		kc.MetaKv = txn
+		err = kc.SaveExportJob(context.TODO(), job)
+		assert.Error(t, err)
+	})
+
+	t.Run("ListExportJobs", func(t *testing.T) {
+		txn := mocks.NewMetaKv(t)
+		value, err := proto.Marshal(job)
+		assert.NoError(t, err)
+		txn.EXPECT().WalkWithPrefix(mock.Anything, ExportJobPrefix, mock.Anything, mock.Anything).RunAndReturn(func(_ context.Context, _ string, _ int, f func([]byte, []byte) error) error {
+			return f(nil, value)
+		})
+		kc.MetaKv = txn
+		jobs, err := kc.ListExportJobs(context.TODO())
+		assert.NoError(t, err)
+		assert.Equal(t, 1, len(jobs))
+
+		txn = mocks.NewMetaKv(t)
+		txn.EXPECT().WalkWithPrefix(mock.Anything, mock.Anything, mock.Anything, mock.Anything).RunAndReturn(func(_ context.Context, _ string, _ int, f func([]byte, []byte) error) error {
+			return f(nil, []byte("@#%#^#"))
+		})
+		kc.MetaKv = txn
+		_, err = kc.ListExportJobs(context.TODO())
+		assert.Error(t, err)
+
+		txn = mocks.NewMetaKv(t)
+		txn.EXPECT().WalkWithPrefix(mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(mockErr)
+		kc.MetaKv = txn
+		_, err = kc.ListExportJobs(context.TODO())
+		assert.Error(t, err)
+	})
+
+	t.Run("DropExportJob", func(t *testing.T) {
+		txn := mocks.NewMetaKv(t)
+		txn.EXPECT().Remove(mock.Anything, buildExportJobKey(1)).Return(nil)
+		kc.MetaKv = txn
+		err := kc.DropExportJob(context.TODO(), job.GetJobID())
+		assert.NoError(t, err)
+	})
+
+	t.Run("SaveExportTask", func(t *testing.T) {
+		txn := mocks.NewMetaKv(t)
+		txn.EXPECT().Save(mock.Anything, buildExportTaskKey(2), mock.Anything).Return(nil)
+		kc.MetaKv = txn
+		err := kc.SaveExportTask(context.TODO(), task)
+		assert.NoError(t, err)
+	})
+
+	t.Run("ListExportTasks", func(t *testing.T) {
+		txn := mocks.NewMetaKv(t)
+		value, err := proto.Marshal(task)
+		assert.NoError(t, err)
+		txn.EXPECT().WalkWithPrefix(mock.Anything, ExportTaskPrefix, mock.Anything, mock.Anything).RunAndReturn(func(_ context.Context, _ string, _ int, f func([]byte, []byte) error) error {
+			return f(nil, value)
+		})
+		kc.MetaKv = txn
+		tasks, err := kc.ListExportTasks(context.TODO())
+		assert.NoError(t, err)
+		assert.Equal(t, 1, len(tasks))
+
+		txn = mocks.NewMetaKv(t)
+		txn.EXPECT().WalkWithPrefix(mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(mockErr)
+		kc.MetaKv = txn
+		_, err = kc.ListExportTasks(context.TODO())
+		assert.Error(t, err)
+	})
+
+	t.Run("DropExportTask", func(t *testing.T) {
+		txn := mocks.NewMetaKv(t)
+		txn.EXPECT().Remove(mock.Anything, buildExportTaskKey(2)).Return(mockErr)
+		kc.MetaKv = txn
+		err := kc.DropExportTask(context.TODO(), task.GetTaskID())
+		assert.Error(t, err)
+	})
+}
@@ -352,3 +352,23 @@ func buildAnalyzeTaskKey(taskID int64) string {
 func buildStatsTaskKey(taskID int64) string {
 	return fmt.Sprintf("%s/%d", StatsTaskPrefix, taskID)
 }
+
+func buildSnapshotKey(snapshotID int64) string {
+	return fmt.Sprintf("%s/%d", SnapshotPrefix, snapshotID)
+}
+
+func buildSnapshotSegmentPrefix(snapshotID int64) string {
+	return fmt.Sprintf("%s/%d/", SnapshotSegmentPrefix, snapshotID)
+}
+
+func buildSnapshotSegmentKey(snapshotID int64, segmentID int64) string {
+	return fmt.Sprintf("%s/%d/%d", SnapshotSegmentPrefix, snapshotID, segmentID)
+}
+
+func buildExportJobKey(jobID int64) string {
+	return fmt.Sprintf("%s/%d", ExportJobPrefix, jobID)
+}
+
+func buildExportTaskKey(taskID int64) string {
+	return fmt.Sprintf("%s/%d", ExportTaskPrefix, taskID)
+}
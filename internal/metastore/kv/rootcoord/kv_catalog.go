@@ -638,6 +638,7 @@ func (kc *Catalog) alterModifyCollection(ctx context.Context, oldColl *model.Col
 	oldCollClone.ConsistencyLevel = newColl.ConsistencyLevel
 	oldCollClone.State = newColl.State
 	oldCollClone.Properties = newColl.Properties
+	oldCollClone.DroppedTime = newColl.DroppedTime
 
 	oldKey := BuildCollectionKey(oldColl.DBID, oldColl.CollectionID)
 	newKey := BuildCollectionKey(newColl.DBID, oldColl.CollectionID)
@@ -668,6 +669,7 @@ func (kc *Catalog) alterModifyPartition(ctx context.Context, oldPart *model.Part
 	oldPartClone.PartitionName = newPartClone.PartitionName
 	oldPartClone.PartitionCreatedTimestamp = newPartClone.PartitionCreatedTimestamp
 	oldPartClone.State = newPartClone.State
+	oldPartClone.DroppedTime = newPartClone.DroppedTime
 	key := BuildPartitionKey(oldPart.CollectionID, oldPart.PartitionID)
 	value, err := proto.Marshal(model.MarshalPartitionModel(oldPartClone))
 	if err != nil {
@@ -1678,6 +1680,58 @@ func (kc *Catalog) ListPrivilegeGroups(ctx context.Context) ([]*milvuspb.Privile
 	return privGroups, nil
 }
 
+func (kc *Catalog) SaveRowPolicy(ctx context.Context, tenant string, policy *internalpb.RowPolicy) error {
+	k := funcutil.HandleTenantForEtcdKey(RowPolicyPrefix, tenant,
+		fmt.Sprintf("%s/%s/%s", policy.GetRole(), policy.GetDbName(), policy.GetCollectionName()))
+	v, err := proto.Marshal(policy)
+	if err != nil {
+		log.Error("failed to marshal row policy", zap.Error(err))
+		return err
+	}
+	if err = kc.Txn.Save(ctx, k, string(v)); err != nil {
+		log.Warn("fail to put row policy", zap.String("key", k), zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+func (kc *Catalog) DropRowPolicy(ctx context.Context, tenant string, role string, dbName string, collectionName string) error {
+	k := funcutil.HandleTenantForEtcdKey(RowPolicyPrefix, tenant, fmt.Sprintf("%s/%s/%s", role, dbName, collectionName))
+	if err := kc.Txn.Remove(ctx, k); err != nil {
+		log.Warn("fail to drop row policy", zap.String("key", k), zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+func (kc *Catalog) DeleteRowPolicies(ctx context.Context, tenant string, role string) error {
+	k := funcutil.HandleTenantForEtcdKey(RowPolicyPrefix, tenant, role+"/")
+	if err := kc.Txn.MultiSaveAndRemoveWithPrefix(ctx, nil, []string{k}); err != nil {
+		log.Warn("fail to delete row policies of role", zap.String("key", k), zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+func (kc *Catalog) ListRowPolicies(ctx context.Context, tenant string) ([]*internalpb.RowPolicy, error) {
+	k := funcutil.HandleTenantForEtcdKey(RowPolicyPrefix, tenant, "")
+	_, vals, err := kc.Txn.LoadWithPrefix(ctx, k+"/")
+	if err != nil {
+		log.Error("failed to list row policies", zap.String("prefix", k), zap.Error(err))
+		return nil, err
+	}
+	policies := make([]*internalpb.RowPolicy, 0, len(vals))
+	for _, val := range vals {
+		policy := &internalpb.RowPolicy{}
+		if err = proto.Unmarshal([]byte(val), policy); err != nil {
+			log.Error("failed to unmarshal row policy", zap.Error(err))
+			return nil, err
+		}
+		policies = append(policies, policy)
+	}
+	return policies, nil
+}
+
 func (kc *Catalog) Close() {
 	// do nothing
 }
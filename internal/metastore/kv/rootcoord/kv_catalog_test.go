@@ -1057,6 +1057,28 @@ func TestCatalog_AlterCollection(t *testing.T) {
 		assert.Equal(t, pb.CollectionState_CollectionCreated, got.State)
 	})
 
+	t.Run("modify, trashed", func(t *testing.T) {
+		snapshot := kv.NewMockSnapshotKV()
+		kvs := map[string]string{}
+		snapshot.SaveFunc = func(ctx context.Context, key string, value string, ts typeutil.Timestamp) error {
+			kvs[key] = value
+			return nil
+		}
+		kc := &Catalog{Snapshot: snapshot}
+		ctx := context.Background()
+		var collectionID int64 = 1
+		oldC := &model.Collection{CollectionID: collectionID, State: pb.CollectionState_CollectionCreated}
+		newC := &model.Collection{CollectionID: collectionID, State: pb.CollectionState_CollectionTrashed, DroppedTime: 100}
+		err := kc.AlterCollection(ctx, oldC, newC, metastore.MODIFY, 0)
+		assert.NoError(t, err)
+		var collPb pb.CollectionInfo
+		err = proto.Unmarshal([]byte(kvs[BuildCollectionKey(0, collectionID)]), &collPb)
+		assert.NoError(t, err)
+		got := model.UnmarshalCollectionModel(&collPb)
+		assert.True(t, got.Trashed())
+		assert.Equal(t, uint64(100), got.DroppedTime)
+	})
+
 	t.Run("modify, tenant id changed", func(t *testing.T) {
 		kc := &Catalog{}
 		ctx := context.Background()
@@ -1126,6 +1148,29 @@ func TestCatalog_AlterPartition(t *testing.T) {
 		assert.Equal(t, pb.PartitionState_PartitionCreated, got.State)
 	})
 
+	t.Run("modify, trashed", func(t *testing.T) {
+		snapshot := kv.NewMockSnapshotKV()
+		kvs := map[string]string{}
+		snapshot.SaveFunc = func(ctx context.Context, key string, value string, ts typeutil.Timestamp) error {
+			kvs[key] = value
+			return nil
+		}
+		kc := &Catalog{Snapshot: snapshot}
+		ctx := context.Background()
+		var collectionID int64 = 1
+		var partitionID int64 = 2
+		oldP := &model.Partition{PartitionID: partitionID, CollectionID: collectionID, State: pb.PartitionState_PartitionCreated}
+		newP := &model.Partition{PartitionID: partitionID, CollectionID: collectionID, State: pb.PartitionState_PartitionTrashed, DroppedTime: 100}
+		err := kc.AlterPartition(ctx, testDb, oldP, newP, metastore.MODIFY, 0)
+		assert.NoError(t, err)
+		var partPb pb.PartitionInfo
+		err = proto.Unmarshal([]byte(kvs[BuildPartitionKey(collectionID, partitionID)]), &partPb)
+		assert.NoError(t, err)
+		got := model.UnmarshalPartitionModel(&partPb)
+		assert.True(t, got.Trashed())
+		assert.Equal(t, uint64(100), got.DroppedTime)
+	})
+
 	t.Run("modify, tenant id changed", func(t *testing.T) {
 		kc := &Catalog{}
 		ctx := context.Background()
@@ -3024,6 +3069,50 @@ func getPrivilegeNames(privileges []*milvuspb.PrivilegeEntity) []string {
 	})
 }
 
+func TestRBAC_RowPolicy(t *testing.T) {
+	ctx := context.TODO()
+	tenant := util.DefaultTenant
+	policy := &internalpb.RowPolicy{Role: "role1", DbName: "default", CollectionName: "coll1", Expr: "tenant_id == 1"}
+	key := funcutil.HandleTenantForEtcdKey(RowPolicyPrefix, tenant, "role1/default/coll1")
+	v, _ := proto.Marshal(policy)
+
+	t.Run("save", func(t *testing.T) {
+		kvmock := mocks.NewTxnKV(t)
+		c := &Catalog{Txn: kvmock}
+		kvmock.EXPECT().Save(mock.Anything, key, mock.Anything).Return(nil).Once()
+		assert.NoError(t, c.SaveRowPolicy(ctx, tenant, policy))
+
+		kvmock.EXPECT().Save(mock.Anything, key, mock.Anything).Return(errors.New("mock save failure")).Once()
+		assert.Error(t, c.SaveRowPolicy(ctx, tenant, policy))
+	})
+
+	t.Run("drop", func(t *testing.T) {
+		kvmock := mocks.NewTxnKV(t)
+		c := &Catalog{Txn: kvmock}
+		kvmock.EXPECT().Remove(mock.Anything, key).Return(nil).Once()
+		assert.NoError(t, c.DropRowPolicy(ctx, tenant, "role1", "default", "coll1"))
+
+		kvmock.EXPECT().MultiSaveAndRemoveWithPrefix(mock.Anything, mock.Anything,
+			[]string{funcutil.HandleTenantForEtcdKey(RowPolicyPrefix, tenant, "role1/")}).Return(nil).Once()
+		assert.NoError(t, c.DeleteRowPolicies(ctx, tenant, "role1"))
+	})
+
+	t.Run("list", func(t *testing.T) {
+		kvmock := mocks.NewTxnKV(t)
+		c := &Catalog{Txn: kvmock}
+		prefix := funcutil.HandleTenantForEtcdKey(RowPolicyPrefix, tenant, "") + "/"
+		kvmock.EXPECT().LoadWithPrefix(mock.Anything, prefix).Return([]string{key}, []string{string(v)}, nil).Once()
+		policies, err := c.ListRowPolicies(ctx, tenant)
+		assert.NoError(t, err)
+		assert.Len(t, policies, 1)
+		assert.True(t, proto.Equal(policy, policies[0]))
+
+		kvmock.EXPECT().LoadWithPrefix(mock.Anything, prefix).Return([]string{key}, []string{"invalid"}, nil).Once()
+		_, err = c.ListRowPolicies(ctx, tenant)
+		assert.Error(t, err)
+	})
+}
+
 func TestCatalog_AlterDatabase(t *testing.T) {
 	kvmock := mocks.NewSnapShotKV(t)
 	c := &Catalog{Snapshot: kvmock}
@@ -51,6 +51,9 @@ const (
 	// GranteeIDPrefix prefix for mapping among privilege and grantor
 	GranteeIDPrefix = ComponentPrefix + CommonCredentialPrefix + "/grantee-id"
 
+	// RowPolicyPrefix prefix for row policy of role on collection
+	RowPolicyPrefix = ComponentPrefix + CommonCredentialPrefix + "/row-policy"
+
 	// PrivilegeGroupPrefix prefix for privilege group
 	PrivilegeGroupPrefix = ComponentPrefix + "/privilege-group"
 )
@@ -944,6 +944,53 @@ func (_c *DataCoordCatalog_DropSegmentIndex_Call) RunAndReturn(run func(context.
 	return _c
 }
 
+// DropSnapshot provides a mock function with given fields: ctx, snapshotID
+func (_m *DataCoordCatalog) DropSnapshot(ctx context.Context, snapshotID int64) error {
+	ret := _m.Called(ctx, snapshotID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DropSnapshot")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+		r0 = rf(ctx, snapshotID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DataCoordCatalog_DropSnapshot_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DropSnapshot'
+type DataCoordCatalog_DropSnapshot_Call struct {
+	*mock.Call
+}
+
+// DropSnapshot is a helper method to define mock.On call
+//   - ctx context.Context
+//   - snapshotID int64
+func (_e *DataCoordCatalog_Expecter) DropSnapshot(ctx interface{}, snapshotID interface{}) *DataCoordCatalog_DropSnapshot_Call {
+	return &DataCoordCatalog_DropSnapshot_Call{Call: _e.mock.On("DropSnapshot", ctx, snapshotID)}
+}
+
+func (_c *DataCoordCatalog_DropSnapshot_Call) Run(run func(ctx context.Context, snapshotID int64)) *DataCoordCatalog_DropSnapshot_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *DataCoordCatalog_DropSnapshot_Call) Return(_a0 error) *DataCoordCatalog_DropSnapshot_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *DataCoordCatalog_DropSnapshot_Call) RunAndReturn(run func(context.Context, int64) error) *DataCoordCatalog_DropSnapshot_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // DropStatsTask provides a mock function with given fields: ctx, taskID
 func (_m *DataCoordCatalog) DropStatsTask(ctx context.Context, taskID int64) error {
 	ret := _m.Called(ctx, taskID)
@@ -1678,6 +1725,123 @@ func (_c *DataCoordCatalog_ListSegments_Call) RunAndReturn(run func(context.Cont
 	return _c
 }
 
+// ListSnapshotSegments provides a mock function with given fields: ctx, snapshotID
+func (_m *DataCoordCatalog) ListSnapshotSegments(ctx context.Context, snapshotID int64) ([]*datapb.SnapshotSegment, error) {
+	ret := _m.Called(ctx, snapshotID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListSnapshotSegments")
+	}
+
+	var r0 []*datapb.SnapshotSegment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) ([]*datapb.SnapshotSegment, error)); ok {
+		return rf(ctx, snapshotID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) []*datapb.SnapshotSegment); ok {
+		r0 = rf(ctx, snapshotID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*datapb.SnapshotSegment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, snapshotID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DataCoordCatalog_ListSnapshotSegments_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListSnapshotSegments'
+type DataCoordCatalog_ListSnapshotSegments_Call struct {
+	*mock.Call
+}
+
+// ListSnapshotSegments is a helper method to define mock.On call
+//   - ctx context.Context
+//   - snapshotID int64
+func (_e *DataCoordCatalog_Expecter) ListSnapshotSegments(ctx interface{}, snapshotID interface{}) *DataCoordCatalog_ListSnapshotSegments_Call {
+	return &DataCoordCatalog_ListSnapshotSegments_Call{Call: _e.mock.On("ListSnapshotSegments", ctx, snapshotID)}
+}
+
+func (_c *DataCoordCatalog_ListSnapshotSegments_Call) Run(run func(ctx context.Context, snapshotID int64)) *DataCoordCatalog_ListSnapshotSegments_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *DataCoordCatalog_ListSnapshotSegments_Call) Return(_a0 []*datapb.SnapshotSegment, _a1 error) *DataCoordCatalog_ListSnapshotSegments_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *DataCoordCatalog_ListSnapshotSegments_Call) RunAndReturn(run func(context.Context, int64) ([]*datapb.SnapshotSegment, error)) *DataCoordCatalog_ListSnapshotSegments_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListSnapshots provides a mock function with given fields: ctx
+func (_m *DataCoordCatalog) ListSnapshots(ctx context.Context) ([]*datapb.SnapshotInfo, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListSnapshots")
+	}
+
+	var r0 []*datapb.SnapshotInfo
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]*datapb.SnapshotInfo, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []*datapb.SnapshotInfo); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*datapb.SnapshotInfo)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DataCoordCatalog_ListSnapshots_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListSnapshots'
+type DataCoordCatalog_ListSnapshots_Call struct {
+	*mock.Call
+}
+
+// ListSnapshots is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *DataCoordCatalog_Expecter) ListSnapshots(ctx interface{}) *DataCoordCatalog_ListSnapshots_Call {
+	return &DataCoordCatalog_ListSnapshots_Call{Call: _e.mock.On("ListSnapshots", ctx)}
+}
+
+func (_c *DataCoordCatalog_ListSnapshots_Call) Run(run func(ctx context.Context)) *DataCoordCatalog_ListSnapshots_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *DataCoordCatalog_ListSnapshots_Call) Return(_a0 []*datapb.SnapshotInfo, _a1 error) *DataCoordCatalog_ListSnapshots_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *DataCoordCatalog_ListSnapshots_Call) RunAndReturn(run func(context.Context) ([]*datapb.SnapshotInfo, error)) *DataCoordCatalog_ListSnapshots_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // ListStatsTasks provides a mock function with given fields: ctx
 func (_m *DataCoordCatalog) ListStatsTasks(ctx context.Context) ([]*indexpb.StatsTask, error) {
 	ret := _m.Called(ctx)
@@ -2304,6 +2468,54 @@ func (_c *DataCoordCatalog_SavePreImportTask_Call) RunAndReturn(run func(context
 	return _c
 }
 
+// SaveSnapshot provides a mock function with given fields: ctx, snapshot, segments
+func (_m *DataCoordCatalog) SaveSnapshot(ctx context.Context, snapshot *datapb.SnapshotInfo, segments []*datapb.SnapshotSegment) error {
+	ret := _m.Called(ctx, snapshot, segments)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveSnapshot")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *datapb.SnapshotInfo, []*datapb.SnapshotSegment) error); ok {
+		r0 = rf(ctx, snapshot, segments)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DataCoordCatalog_SaveSnapshot_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SaveSnapshot'
+type DataCoordCatalog_SaveSnapshot_Call struct {
+	*mock.Call
+}
+
+// SaveSnapshot is a helper method to define mock.On call
+//   - ctx context.Context
+//   - snapshot *datapb.SnapshotInfo
+//   - segments []*datapb.SnapshotSegment
+func (_e *DataCoordCatalog_Expecter) SaveSnapshot(ctx interface{}, snapshot interface{}, segments interface{}) *DataCoordCatalog_SaveSnapshot_Call {
+	return &DataCoordCatalog_SaveSnapshot_Call{Call: _e.mock.On("SaveSnapshot", ctx, snapshot, segments)}
+}
+
+func (_c *DataCoordCatalog_SaveSnapshot_Call) Run(run func(ctx context.Context, snapshot *datapb.SnapshotInfo, segments []*datapb.SnapshotSegment)) *DataCoordCatalog_SaveSnapshot_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*datapb.SnapshotInfo), args[2].([]*datapb.SnapshotSegment))
+	})
+	return _c
+}
+
+func (_c *DataCoordCatalog_SaveSnapshot_Call) Return(_a0 error) *DataCoordCatalog_SaveSnapshot_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *DataCoordCatalog_SaveSnapshot_Call) RunAndReturn(run func(context.Context, *datapb.SnapshotInfo, []*datapb.SnapshotSegment) error) *DataCoordCatalog_SaveSnapshot_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // SaveStatsTask provides a mock function with given fields: ctx, task
 func (_m *DataCoordCatalog) SaveStatsTask(ctx context.Context, task *indexpb.StatsTask) error {
 	ret := _m.Called(ctx, task)
@@ -2398,6 +2610,310 @@ func (_c *DataCoordCatalog_ShouldDropChannel_Call) RunAndReturn(run func(context
 	return _c
 }
 
+// DropExportJob provides a mock function with given fields: ctx, jobID
+func (_m *DataCoordCatalog) DropExportJob(ctx context.Context, jobID int64) error {
+	ret := _m.Called(ctx, jobID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DropExportJob")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+		r0 = rf(ctx, jobID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DataCoordCatalog_DropExportJob_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DropExportJob'
+type DataCoordCatalog_DropExportJob_Call struct {
+	*mock.Call
+}
+
+// DropExportJob is a helper method to define mock.On call
+//   - ctx context.Context
+//   - jobID int64
+func (_e *DataCoordCatalog_Expecter) DropExportJob(ctx interface{}, jobID interface{}) *DataCoordCatalog_DropExportJob_Call {
+	return &DataCoordCatalog_DropExportJob_Call{Call: _e.mock.On("DropExportJob", ctx, jobID)}
+}
+
+func (_c *DataCoordCatalog_DropExportJob_Call) Run(run func(ctx context.Context, jobID int64)) *DataCoordCatalog_DropExportJob_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *DataCoordCatalog_DropExportJob_Call) Return(_a0 error) *DataCoordCatalog_DropExportJob_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *DataCoordCatalog_DropExportJob_Call) RunAndReturn(run func(context.Context, int64) error) *DataCoordCatalog_DropExportJob_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DropExportTask provides a mock function with given fields: ctx, taskID
+func (_m *DataCoordCatalog) DropExportTask(ctx context.Context, taskID int64) error {
+	ret := _m.Called(ctx, taskID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DropExportTask")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+		r0 = rf(ctx, taskID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DataCoordCatalog_DropExportTask_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DropExportTask'
+type DataCoordCatalog_DropExportTask_Call struct {
+	*mock.Call
+}
+
+// DropExportTask is a helper method to define mock.On call
+//   - ctx context.Context
+//   - taskID int64
+func (_e *DataCoordCatalog_Expecter) DropExportTask(ctx interface{}, taskID interface{}) *DataCoordCatalog_DropExportTask_Call {
+	return &DataCoordCatalog_DropExportTask_Call{Call: _e.mock.On("DropExportTask", ctx, taskID)}
+}
+
+func (_c *DataCoordCatalog_DropExportTask_Call) Run(run func(ctx context.Context, taskID int64)) *DataCoordCatalog_DropExportTask_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *DataCoordCatalog_DropExportTask_Call) Return(_a0 error) *DataCoordCatalog_DropExportTask_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *DataCoordCatalog_DropExportTask_Call) RunAndReturn(run func(context.Context, int64) error) *DataCoordCatalog_DropExportTask_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListExportJobs provides a mock function with given fields: ctx
+func (_m *DataCoordCatalog) ListExportJobs(ctx context.Context) ([]*datapb.ExportJob, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListExportJobs")
+	}
+
+	var r0 []*datapb.ExportJob
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]*datapb.ExportJob, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []*datapb.ExportJob); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*datapb.ExportJob)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DataCoordCatalog_ListExportJobs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListExportJobs'
+type DataCoordCatalog_ListExportJobs_Call struct {
+	*mock.Call
+}
+
+// ListExportJobs is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *DataCoordCatalog_Expecter) ListExportJobs(ctx interface{}) *DataCoordCatalog_ListExportJobs_Call {
+	return &DataCoordCatalog_ListExportJobs_Call{Call: _e.mock.On("ListExportJobs", ctx)}
+}
+
+func (_c *DataCoordCatalog_ListExportJobs_Call) Run(run func(ctx context.Context)) *DataCoordCatalog_ListExportJobs_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *DataCoordCatalog_ListExportJobs_Call) Return(_a0 []*datapb.ExportJob, _a1 error) *DataCoordCatalog_ListExportJobs_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *DataCoordCatalog_ListExportJobs_Call) RunAndReturn(run func(context.Context) ([]*datapb.ExportJob, error)) *DataCoordCatalog_ListExportJobs_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListExportTasks provides a mock function with given fields: ctx
+func (_m *DataCoordCatalog) ListExportTasks(ctx context.Context) ([]*datapb.ExportTask, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListExportTasks")
+	}
+
+	var r0 []*datapb.ExportTask
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]*datapb.ExportTask, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []*datapb.ExportTask); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*datapb.ExportTask)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DataCoordCatalog_ListExportTasks_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListExportTasks'
+type DataCoordCatalog_ListExportTasks_Call struct {
+	*mock.Call
+}
+
+// ListExportTasks is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *DataCoordCatalog_Expecter) ListExportTasks(ctx interface{}) *DataCoordCatalog_ListExportTasks_Call {
+	return &DataCoordCatalog_ListExportTasks_Call{Call: _e.mock.On("ListExportTasks", ctx)}
+}
+
+func (_c *DataCoordCatalog_ListExportTasks_Call) Run(run func(ctx context.Context)) *DataCoordCatalog_ListExportTasks_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *DataCoordCatalog_ListExportTasks_Call) Return(_a0 []*datapb.ExportTask, _a1 error) *DataCoordCatalog_ListExportTasks_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *DataCoordCatalog_ListExportTasks_Call) RunAndReturn(run func(context.Context) ([]*datapb.ExportTask, error)) *DataCoordCatalog_ListExportTasks_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SaveExportJob provides a mock function with given fields: ctx, job
+func (_m *DataCoordCatalog) SaveExportJob(ctx context.Context, job *datapb.ExportJob) error {
+	ret := _m.Called(ctx, job)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveExportJob")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *datapb.ExportJob) error); ok {
+		r0 = rf(ctx, job)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DataCoordCatalog_SaveExportJob_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SaveExportJob'
+type DataCoordCatalog_SaveExportJob_Call struct {
+	*mock.Call
+}
+
+// SaveExportJob is a helper method to define mock.On call
+//   - ctx context.Context
+//   - job *datapb.ExportJob
+func (_e *DataCoordCatalog_Expecter) SaveExportJob(ctx interface{}, job interface{}) *DataCoordCatalog_SaveExportJob_Call {
+	return &DataCoordCatalog_SaveExportJob_Call{Call: _e.mock.On("SaveExportJob", ctx, job)}
+}
+
+func (_c *DataCoordCatalog_SaveExportJob_Call) Run(run func(ctx context.Context, job *datapb.ExportJob)) *DataCoordCatalog_SaveExportJob_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*datapb.ExportJob))
+	})
+	return _c
+}
+
+func (_c *DataCoordCatalog_SaveExportJob_Call) Return(_a0 error) *DataCoordCatalog_SaveExportJob_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *DataCoordCatalog_SaveExportJob_Call) RunAndReturn(run func(context.Context, *datapb.ExportJob) error) *DataCoordCatalog_SaveExportJob_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SaveExportTask provides a mock function with given fields: ctx, task
+func (_m *DataCoordCatalog) SaveExportTask(ctx context.Context, task *datapb.ExportTask) error {
+	ret := _m.Called(ctx, task)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveExportTask")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *datapb.ExportTask) error); ok {
+		r0 = rf(ctx, task)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DataCoordCatalog_SaveExportTask_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SaveExportTask'
+type DataCoordCatalog_SaveExportTask_Call struct {
+	*mock.Call
+}
+
+// SaveExportTask is a helper method to define mock.On call
+//   - ctx context.Context
+//   - task *datapb.ExportTask
+func (_e *DataCoordCatalog_Expecter) SaveExportTask(ctx interface{}, task interface{}) *DataCoordCatalog_SaveExportTask_Call {
+	return &DataCoordCatalog_SaveExportTask_Call{Call: _e.mock.On("SaveExportTask", ctx, task)}
+}
+
+func (_c *DataCoordCatalog_SaveExportTask_Call) Run(run func(ctx context.Context, task *datapb.ExportTask)) *DataCoordCatalog_SaveExportTask_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*datapb.ExportTask))
+	})
+	return _c
+}
+
+func (_c *DataCoordCatalog_SaveExportTask_Call) Return(_a0 error) *DataCoordCatalog_SaveExportTask_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *DataCoordCatalog_SaveExportTask_Call) RunAndReturn(run func(context.Context, *datapb.ExportTask) error) *DataCoordCatalog_SaveExportTask_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewDataCoordCatalog creates a new instance of DataCoordCatalog. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewDataCoordCatalog(t interface {
@@ -5,6 +5,8 @@ package mocks
 import (
 	context "context"
 
+	internalpb "github.com/milvus-io/milvus/internal/proto/internalpb"
+
 	milvuspb "github.com/milvus-io/milvus-proto/go-api/v2/milvuspb"
 	metastore "github.com/milvus-io/milvus/internal/metastore"
 
@@ -781,6 +783,54 @@ func (_c *RootCoordCatalog_DeleteGrant_Call) RunAndReturn(run func(context.Conte
 	return _c
 }
 
+// DeleteRowPolicies provides a mock function with given fields: ctx, tenant, role
+func (_m *RootCoordCatalog) DeleteRowPolicies(ctx context.Context, tenant string, role string) error {
+	ret := _m.Called(ctx, tenant, role)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteRowPolicies")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, tenant, role)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RootCoordCatalog_DeleteRowPolicies_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteRowPolicies'
+type RootCoordCatalog_DeleteRowPolicies_Call struct {
+	*mock.Call
+}
+
+// DeleteRowPolicies is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tenant string
+//   - role string
+func (_e *RootCoordCatalog_Expecter) DeleteRowPolicies(ctx interface{}, tenant interface{}, role interface{}) *RootCoordCatalog_DeleteRowPolicies_Call {
+	return &RootCoordCatalog_DeleteRowPolicies_Call{Call: _e.mock.On("DeleteRowPolicies", ctx, tenant, role)}
+}
+
+func (_c *RootCoordCatalog_DeleteRowPolicies_Call) Run(run func(ctx context.Context, tenant string, role string)) *RootCoordCatalog_DeleteRowPolicies_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *RootCoordCatalog_DeleteRowPolicies_Call) Return(_a0 error) *RootCoordCatalog_DeleteRowPolicies_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *RootCoordCatalog_DeleteRowPolicies_Call) RunAndReturn(run func(context.Context, string, string) error) *RootCoordCatalog_DeleteRowPolicies_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // DropAlias provides a mock function with given fields: ctx, dbID, alias, ts
 func (_m *RootCoordCatalog) DropAlias(ctx context.Context, dbID int64, alias string, ts uint64) error {
 	ret := _m.Called(ctx, dbID, alias, ts)
@@ -1047,6 +1097,56 @@ func (_c *RootCoordCatalog_DropRole_Call) RunAndReturn(run func(context.Context,
 	return _c
 }
 
+// DropRowPolicy provides a mock function with given fields: ctx, tenant, role, dbName, collectionName
+func (_m *RootCoordCatalog) DropRowPolicy(ctx context.Context, tenant string, role string, dbName string, collectionName string) error {
+	ret := _m.Called(ctx, tenant, role, dbName, collectionName)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DropRowPolicy")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, string) error); ok {
+		r0 = rf(ctx, tenant, role, dbName, collectionName)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RootCoordCatalog_DropRowPolicy_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DropRowPolicy'
+type RootCoordCatalog_DropRowPolicy_Call struct {
+	*mock.Call
+}
+
+// DropRowPolicy is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tenant string
+//   - role string
+//   - dbName string
+//   - collectionName string
+func (_e *RootCoordCatalog_Expecter) DropRowPolicy(ctx interface{}, tenant interface{}, role interface{}, dbName interface{}, collectionName interface{}) *RootCoordCatalog_DropRowPolicy_Call {
+	return &RootCoordCatalog_DropRowPolicy_Call{Call: _e.mock.On("DropRowPolicy", ctx, tenant, role, dbName, collectionName)}
+}
+
+func (_c *RootCoordCatalog_DropRowPolicy_Call) Run(run func(ctx context.Context, tenant string, role string, dbName string, collectionName string)) *RootCoordCatalog_DropRowPolicy_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(string), args[4].(string))
+	})
+	return _c
+}
+
+func (_c *RootCoordCatalog_DropRowPolicy_Call) Return(_a0 error) *RootCoordCatalog_DropRowPolicy_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *RootCoordCatalog_DropRowPolicy_Call) RunAndReturn(run func(context.Context, string, string, string, string) error) *RootCoordCatalog_DropRowPolicy_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetCollectionByID provides a mock function with given fields: ctx, dbID, ts, collectionID
 func (_m *RootCoordCatalog) GetCollectionByID(ctx context.Context, dbID int64, ts uint64, collectionID int64) (*model.Collection, error) {
 	ret := _m.Called(ctx, dbID, ts, collectionID)
@@ -1659,6 +1759,65 @@ func (_c *RootCoordCatalog_ListRole_Call) RunAndReturn(run func(context.Context,
 	return _c
 }
 
+// ListRowPolicies provides a mock function with given fields: ctx, tenant
+func (_m *RootCoordCatalog) ListRowPolicies(ctx context.Context, tenant string) ([]*internalpb.RowPolicy, error) {
+	ret := _m.Called(ctx, tenant)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListRowPolicies")
+	}
+
+	var r0 []*internalpb.RowPolicy
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]*internalpb.RowPolicy, error)); ok {
+		return rf(ctx, tenant)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []*internalpb.RowPolicy); ok {
+		r0 = rf(ctx, tenant)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*internalpb.RowPolicy)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, tenant)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RootCoordCatalog_ListRowPolicies_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListRowPolicies'
+type RootCoordCatalog_ListRowPolicies_Call struct {
+	*mock.Call
+}
+
+// ListRowPolicies is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tenant string
+func (_e *RootCoordCatalog_Expecter) ListRowPolicies(ctx interface{}, tenant interface{}) *RootCoordCatalog_ListRowPolicies_Call {
+	return &RootCoordCatalog_ListRowPolicies_Call{Call: _e.mock.On("ListRowPolicies", ctx, tenant)}
+}
+
+func (_c *RootCoordCatalog_ListRowPolicies_Call) Run(run func(ctx context.Context, tenant string)) *RootCoordCatalog_ListRowPolicies_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *RootCoordCatalog_ListRowPolicies_Call) Return(_a0 []*internalpb.RowPolicy, _a1 error) *RootCoordCatalog_ListRowPolicies_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *RootCoordCatalog_ListRowPolicies_Call) RunAndReturn(run func(context.Context, string) ([]*internalpb.RowPolicy, error)) *RootCoordCatalog_ListRowPolicies_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // ListUser provides a mock function with given fields: ctx, tenant, entity, includeRoleInfo
 func (_m *RootCoordCatalog) ListUser(ctx context.Context, tenant string, entity *milvuspb.UserEntity, includeRoleInfo bool) ([]*milvuspb.UserResult, error) {
 	ret := _m.Called(ctx, tenant, entity, includeRoleInfo)
@@ -1815,6 +1974,54 @@ func (_c *RootCoordCatalog_RestoreRBAC_Call) RunAndReturn(run func(context.Conte
 	return _c
 }
 
+// SaveRowPolicy provides a mock function with given fields: ctx, tenant, policy
+func (_m *RootCoordCatalog) SaveRowPolicy(ctx context.Context, tenant string, policy *internalpb.RowPolicy) error {
+	ret := _m.Called(ctx, tenant, policy)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveRowPolicy")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *internalpb.RowPolicy) error); ok {
+		r0 = rf(ctx, tenant, policy)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RootCoordCatalog_SaveRowPolicy_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SaveRowPolicy'
+type RootCoordCatalog_SaveRowPolicy_Call struct {
+	*mock.Call
+}
+
+// SaveRowPolicy is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tenant string
+//   - policy *internalpb.RowPolicy
+func (_e *RootCoordCatalog_Expecter) SaveRowPolicy(ctx interface{}, tenant interface{}, policy interface{}) *RootCoordCatalog_SaveRowPolicy_Call {
+	return &RootCoordCatalog_SaveRowPolicy_Call{Call: _e.mock.On("SaveRowPolicy", ctx, tenant, policy)}
+}
+
+func (_c *RootCoordCatalog_SaveRowPolicy_Call) Run(run func(ctx context.Context, tenant string, policy *internalpb.RowPolicy)) *RootCoordCatalog_SaveRowPolicy_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(*internalpb.RowPolicy))
+	})
+	return _c
+}
+
+func (_c *RootCoordCatalog_SaveRowPolicy_Call) Return(_a0 error) *RootCoordCatalog_SaveRowPolicy_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *RootCoordCatalog_SaveRowPolicy_Call) RunAndReturn(run func(context.Context, string, *internalpb.RowPolicy) error) *RootCoordCatalog_SaveRowPolicy_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewRootCoordCatalog creates a new instance of RootCoordCatalog. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewRootCoordCatalog(t interface {
@@ -29,12 +29,18 @@ type Collection struct {
 	Properties           []*commonpb.KeyValuePair
 	State                pb.CollectionState
 	EnableDynamicField   bool
+	DroppedTime          uint64 // the ts when the collection is moved into the recycle bin.
 }
 
 func (c *Collection) Available() bool {
 	return c.State == pb.CollectionState_CollectionCreated
 }
 
+// Trashed returns whether the collection is in the recycle bin.
+func (c *Collection) Trashed() bool {
+	return c.State == pb.CollectionState_CollectionTrashed
+}
+
 func (c *Collection) Clone() *Collection {
 	return &Collection{
 		TenantID:             c.TenantID,
@@ -56,6 +62,7 @@ func (c *Collection) Clone() *Collection {
 		State:                c.State,
 		EnableDynamicField:   c.EnableDynamicField,
 		Functions:            CloneFunctions(c.Functions),
+		DroppedTime:          c.DroppedTime,
 	}
 }
 
@@ -112,6 +119,7 @@ func UnmarshalCollectionModel(coll *pb.CollectionInfo) *Collection {
 		State:                coll.State,
 		Properties:           coll.Properties,
 		EnableDynamicField:   coll.Schema.EnableDynamicField,
+		DroppedTime:          coll.DroppedTime,
 	}
 }
 
@@ -173,6 +181,7 @@ func marshalCollectionModelWithConfig(coll *Collection, c *config) *pb.Collectio
 		StartPositions:       coll.StartPositions,
 		State:                coll.State,
 		Properties:           coll.Properties,
+		DroppedTime:          coll.DroppedTime,
 	}
 
 	if c.withPartitions {
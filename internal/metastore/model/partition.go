@@ -12,12 +12,18 @@ type Partition struct {
 	Extra                     map[string]string // deprecated.
 	CollectionID              int64
 	State                     pb.PartitionState
+	DroppedTime               uint64 // the ts when the partition is moved into the recycle bin.
 }
 
 func (p *Partition) Available() bool {
 	return p.State == pb.PartitionState_PartitionCreated
 }
 
+// Trashed returns whether the partition is in the recycle bin.
+func (p *Partition) Trashed() bool {
+	return p.State == pb.PartitionState_PartitionTrashed
+}
+
 func (p *Partition) Clone() *Partition {
 	return &Partition{
 		PartitionID:               p.PartitionID,
@@ -26,6 +32,7 @@ func (p *Partition) Clone() *Partition {
 		Extra:                     common.CloneStr2Str(p.Extra),
 		CollectionID:              p.CollectionID,
 		State:                     p.State,
+		DroppedTime:               p.DroppedTime,
 	}
 }
 
@@ -61,6 +68,7 @@ func MarshalPartitionModel(partition *Partition) *pb.PartitionInfo {
 		PartitionCreatedTimestamp: partition.PartitionCreatedTimestamp,
 		CollectionId:              partition.CollectionID,
 		State:                     partition.State,
+		DroppedTime:               partition.DroppedTime,
 	}
 }
 
@@ -71,5 +79,6 @@ func UnmarshalPartitionModel(info *pb.PartitionInfo) *Partition {
 		PartitionCreatedTimestamp: info.GetPartitionCreatedTimestamp(),
 		CollectionID:              info.GetCollectionId(),
 		State:                     info.GetState(),
+		DroppedTime:               info.GetDroppedTime(),
 	}
 }
@@ -268,6 +268,65 @@ func (_c *MockDataNode_DropImport_Call) RunAndReturn(run func(context.Context, *
 	return _c
 }
 
+// Export provides a mock function with given fields: _a0, _a1
+func (_m *MockDataNode) Export(_a0 context.Context, _a1 *datapb.ExportTaskRequest) (*commonpb.Status, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Export")
+	}
+
+	var r0 *commonpb.Status
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *datapb.ExportTaskRequest) (*commonpb.Status, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *datapb.ExportTaskRequest) *commonpb.Status); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*commonpb.Status)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *datapb.ExportTaskRequest) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockDataNode_Export_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Export'
+type MockDataNode_Export_Call struct {
+	*mock.Call
+}
+
+// Export is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 *datapb.ExportTaskRequest
+func (_e *MockDataNode_Expecter) Export(_a0 interface{}, _a1 interface{}) *MockDataNode_Export_Call {
+	return &MockDataNode_Export_Call{Call: _e.mock.On("Export", _a0, _a1)}
+}
+
+func (_c *MockDataNode_Export_Call) Run(run func(_a0 context.Context, _a1 *datapb.ExportTaskRequest)) *MockDataNode_Export_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*datapb.ExportTaskRequest))
+	})
+	return _c
+}
+
+func (_c *MockDataNode_Export_Call) Return(_a0 *commonpb.Status, _a1 error) *MockDataNode_Export_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockDataNode_Export_Call) RunAndReturn(run func(context.Context, *datapb.ExportTaskRequest) (*commonpb.Status, error)) *MockDataNode_Export_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // FlushChannels provides a mock function with given fields: _a0, _a1
 func (_m *MockDataNode) FlushChannels(_a0 context.Context, _a1 *datapb.FlushChannelsRequest) (*commonpb.Status, error) {
 	ret := _m.Called(_a0, _a1)
@@ -979,6 +1038,65 @@ func (_c *MockDataNode_PreImport_Call) RunAndReturn(run func(context.Context, *d
 	return _c
 }
 
+// QueryExport provides a mock function with given fields: _a0, _a1
+func (_m *MockDataNode) QueryExport(_a0 context.Context, _a1 *datapb.QueryExportRequest) (*datapb.QueryExportResponse, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for QueryExport")
+	}
+
+	var r0 *datapb.QueryExportResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *datapb.QueryExportRequest) (*datapb.QueryExportResponse, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *datapb.QueryExportRequest) *datapb.QueryExportResponse); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*datapb.QueryExportResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *datapb.QueryExportRequest) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockDataNode_QueryExport_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'QueryExport'
+type MockDataNode_QueryExport_Call struct {
+	*mock.Call
+}
+
+// QueryExport is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 *datapb.QueryExportRequest
+func (_e *MockDataNode_Expecter) QueryExport(_a0 interface{}, _a1 interface{}) *MockDataNode_QueryExport_Call {
+	return &MockDataNode_QueryExport_Call{Call: _e.mock.On("QueryExport", _a0, _a1)}
+}
+
+func (_c *MockDataNode_QueryExport_Call) Run(run func(_a0 context.Context, _a1 *datapb.QueryExportRequest)) *MockDataNode_QueryExport_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*datapb.QueryExportRequest))
+	})
+	return _c
+}
+
+func (_c *MockDataNode_QueryExport_Call) Return(_a0 *datapb.QueryExportResponse, _a1 error) *MockDataNode_QueryExport_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockDataNode_QueryExport_Call) RunAndReturn(run func(context.Context, *datapb.QueryExportRequest) (*datapb.QueryExportResponse, error)) *MockDataNode_QueryExport_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // QueryImport provides a mock function with given fields: _a0, _a1
 func (_m *MockDataNode) QueryImport(_a0 context.Context, _a1 *datapb.QueryImportRequest) (*datapb.QueryImportResponse, error) {
 	ret := _m.Called(_a0, _a1)
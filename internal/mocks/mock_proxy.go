@@ -447,6 +447,65 @@ func (_c *MockProxy_BackupRBAC_Call) RunAndReturn(run func(context.Context, *mil
 	return _c
 }
 
+// BeginTransaction provides a mock function with given fields: _a0, _a1
+func (_m *MockProxy) BeginTransaction(_a0 context.Context, _a1 *proxypb.BeginTransactionRequest) (*proxypb.BeginTransactionResponse, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for BeginTransaction")
+	}
+
+	var r0 *proxypb.BeginTransactionResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *proxypb.BeginTransactionRequest) (*proxypb.BeginTransactionResponse, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *proxypb.BeginTransactionRequest) *proxypb.BeginTransactionResponse); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*proxypb.BeginTransactionResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *proxypb.BeginTransactionRequest) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockProxy_BeginTransaction_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'BeginTransaction'
+type MockProxy_BeginTransaction_Call struct {
+	*mock.Call
+}
+
+// BeginTransaction is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 *proxypb.BeginTransactionRequest
+func (_e *MockProxy_Expecter) BeginTransaction(_a0 interface{}, _a1 interface{}) *MockProxy_BeginTransaction_Call {
+	return &MockProxy_BeginTransaction_Call{Call: _e.mock.On("BeginTransaction", _a0, _a1)}
+}
+
+func (_c *MockProxy_BeginTransaction_Call) Run(run func(_a0 context.Context, _a1 *proxypb.BeginTransactionRequest)) *MockProxy_BeginTransaction_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*proxypb.BeginTransactionRequest))
+	})
+	return _c
+}
+
+func (_c *MockProxy_BeginTransaction_Call) Return(_a0 *proxypb.BeginTransactionResponse, _a1 error) *MockProxy_BeginTransaction_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockProxy_BeginTransaction_Call) RunAndReturn(run func(context.Context, *proxypb.BeginTransactionRequest) (*proxypb.BeginTransactionResponse, error)) *MockProxy_BeginTransaction_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // CalcDistance provides a mock function with given fields: _a0, _a1
 func (_m *MockProxy) CalcDistance(_a0 context.Context, _a1 *milvuspb.CalcDistanceRequest) (*milvuspb.CalcDistanceResults, error) {
 	ret := _m.Called(_a0, _a1)
@@ -506,6 +565,65 @@ func (_c *MockProxy_CalcDistance_Call) RunAndReturn(run func(context.Context, *m
 	return _c
 }
 
+// CancelExport provides a mock function with given fields: _a0, _a1
+func (_m *MockProxy) CancelExport(_a0 context.Context, _a1 *proxypb.CancelExportRequest) (*commonpb.Status, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CancelExport")
+	}
+
+	var r0 *commonpb.Status
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *proxypb.CancelExportRequest) (*commonpb.Status, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *proxypb.CancelExportRequest) *commonpb.Status); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*commonpb.Status)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *proxypb.CancelExportRequest) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockProxy_CancelExport_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CancelExport'
+type MockProxy_CancelExport_Call struct {
+	*mock.Call
+}
+
+// CancelExport is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 *proxypb.CancelExportRequest
+func (_e *MockProxy_Expecter) CancelExport(_a0 interface{}, _a1 interface{}) *MockProxy_CancelExport_Call {
+	return &MockProxy_CancelExport_Call{Call: _e.mock.On("CancelExport", _a0, _a1)}
+}
+
+func (_c *MockProxy_CancelExport_Call) Run(run func(_a0 context.Context, _a1 *proxypb.CancelExportRequest)) *MockProxy_CancelExport_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*proxypb.CancelExportRequest))
+	})
+	return _c
+}
+
+func (_c *MockProxy_CancelExport_Call) Return(_a0 *commonpb.Status, _a1 error) *MockProxy_CancelExport_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockProxy_CancelExport_Call) RunAndReturn(run func(context.Context, *proxypb.CancelExportRequest) (*commonpb.Status, error)) *MockProxy_CancelExport_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // CheckHealth provides a mock function with given fields: _a0, _a1
 func (_m *MockProxy) CheckHealth(_a0 context.Context, _a1 *milvuspb.CheckHealthRequest) (*milvuspb.CheckHealthResponse, error) {
 	ret := _m.Called(_a0, _a1)
@@ -565,6 +683,65 @@ func (_c *MockProxy_CheckHealth_Call) RunAndReturn(run func(context.Context, *mi
 	return _c
 }
 
+// CommitTransaction provides a mock function with given fields: _a0, _a1
+func (_m *MockProxy) CommitTransaction(_a0 context.Context, _a1 *proxypb.CommitTransactionRequest) (*proxypb.CommitTransactionResponse, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CommitTransaction")
+	}
+
+	var r0 *proxypb.CommitTransactionResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *proxypb.CommitTransactionRequest) (*proxypb.CommitTransactionResponse, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *proxypb.CommitTransactionRequest) *proxypb.CommitTransactionResponse); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*proxypb.CommitTransactionResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *proxypb.CommitTransactionRequest) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockProxy_CommitTransaction_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CommitTransaction'
+type MockProxy_CommitTransaction_Call struct {
+	*mock.Call
+}
+
+// CommitTransaction is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 *proxypb.CommitTransactionRequest
+func (_e *MockProxy_Expecter) CommitTransaction(_a0 interface{}, _a1 interface{}) *MockProxy_CommitTransaction_Call {
+	return &MockProxy_CommitTransaction_Call{Call: _e.mock.On("CommitTransaction", _a0, _a1)}
+}
+
+func (_c *MockProxy_CommitTransaction_Call) Run(run func(_a0 context.Context, _a1 *proxypb.CommitTransactionRequest)) *MockProxy_CommitTransaction_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*proxypb.CommitTransactionRequest))
+	})
+	return _c
+}
+
+func (_c *MockProxy_CommitTransaction_Call) Return(_a0 *proxypb.CommitTransactionResponse, _a1 error) *MockProxy_CommitTransaction_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockProxy_CommitTransaction_Call) RunAndReturn(run func(context.Context, *proxypb.CommitTransactionRequest) (*proxypb.CommitTransactionResponse, error)) *MockProxy_CommitTransaction_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Connect provides a mock function with given fields: _a0, _a1
 func (_m *MockProxy) Connect(_a0 context.Context, _a1 *milvuspb.ConnectRequest) (*milvuspb.ConnectResponse, error) {
 	ret := _m.Called(_a0, _a1)
@@ -1155,6 +1332,65 @@ func (_c *MockProxy_CreateRole_Call) RunAndReturn(run func(context.Context, *mil
 	return _c
 }
 
+// CreateSnapshot provides a mock function with given fields: _a0, _a1
+func (_m *MockProxy) CreateSnapshot(_a0 context.Context, _a1 *proxypb.CreateSnapshotRequest) (*proxypb.CreateSnapshotResponse, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateSnapshot")
+	}
+
+	var r0 *proxypb.CreateSnapshotResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *proxypb.CreateSnapshotRequest) (*proxypb.CreateSnapshotResponse, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *proxypb.CreateSnapshotRequest) *proxypb.CreateSnapshotResponse); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*proxypb.CreateSnapshotResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *proxypb.CreateSnapshotRequest) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockProxy_CreateSnapshot_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateSnapshot'
+type MockProxy_CreateSnapshot_Call struct {
+	*mock.Call
+}
+
+// CreateSnapshot is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 *proxypb.CreateSnapshotRequest
+func (_e *MockProxy_Expecter) CreateSnapshot(_a0 interface{}, _a1 interface{}) *MockProxy_CreateSnapshot_Call {
+	return &MockProxy_CreateSnapshot_Call{Call: _e.mock.On("CreateSnapshot", _a0, _a1)}
+}
+
+func (_c *MockProxy_CreateSnapshot_Call) Run(run func(_a0 context.Context, _a1 *proxypb.CreateSnapshotRequest)) *MockProxy_CreateSnapshot_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*proxypb.CreateSnapshotRequest))
+	})
+	return _c
+}
+
+func (_c *MockProxy_CreateSnapshot_Call) Return(_a0 *proxypb.CreateSnapshotResponse, _a1 error) *MockProxy_CreateSnapshot_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockProxy_CreateSnapshot_Call) RunAndReturn(run func(context.Context, *proxypb.CreateSnapshotRequest) (*proxypb.CreateSnapshotResponse, error)) *MockProxy_CreateSnapshot_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Delete provides a mock function with given fields: _a0, _a1
 func (_m *MockProxy) Delete(_a0 context.Context, _a1 *milvuspb.DeleteRequest) (*milvuspb.MutationResult, error) {
 	ret := _m.Called(_a0, _a1)
@@ -2099,6 +2335,124 @@ func (_c *MockProxy_DropRole_Call) RunAndReturn(run func(context.Context, *milvu
 	return _c
 }
 
+// DropRowPolicy provides a mock function with given fields: _a0, _a1
+func (_m *MockProxy) DropRowPolicy(_a0 context.Context, _a1 *proxypb.DropRowPolicyRequest) (*commonpb.Status, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DropRowPolicy")
+	}
+
+	var r0 *commonpb.Status
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *proxypb.DropRowPolicyRequest) (*commonpb.Status, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *proxypb.DropRowPolicyRequest) *commonpb.Status); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*commonpb.Status)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *proxypb.DropRowPolicyRequest) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockProxy_DropRowPolicy_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DropRowPolicy'
+type MockProxy_DropRowPolicy_Call struct {
+	*mock.Call
+}
+
+// DropRowPolicy is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 *proxypb.DropRowPolicyRequest
+func (_e *MockProxy_Expecter) DropRowPolicy(_a0 interface{}, _a1 interface{}) *MockProxy_DropRowPolicy_Call {
+	return &MockProxy_DropRowPolicy_Call{Call: _e.mock.On("DropRowPolicy", _a0, _a1)}
+}
+
+func (_c *MockProxy_DropRowPolicy_Call) Run(run func(_a0 context.Context, _a1 *proxypb.DropRowPolicyRequest)) *MockProxy_DropRowPolicy_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*proxypb.DropRowPolicyRequest))
+	})
+	return _c
+}
+
+func (_c *MockProxy_DropRowPolicy_Call) Return(_a0 *commonpb.Status, _a1 error) *MockProxy_DropRowPolicy_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockProxy_DropRowPolicy_Call) RunAndReturn(run func(context.Context, *proxypb.DropRowPolicyRequest) (*commonpb.Status, error)) *MockProxy_DropRowPolicy_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DropSnapshot provides a mock function with given fields: _a0, _a1
+func (_m *MockProxy) DropSnapshot(_a0 context.Context, _a1 *proxypb.DropSnapshotRequest) (*commonpb.Status, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DropSnapshot")
+	}
+
+	var r0 *commonpb.Status
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *proxypb.DropSnapshotRequest) (*commonpb.Status, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *proxypb.DropSnapshotRequest) *commonpb.Status); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*commonpb.Status)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *proxypb.DropSnapshotRequest) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockProxy_DropSnapshot_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DropSnapshot'
+type MockProxy_DropSnapshot_Call struct {
+	*mock.Call
+}
+
+// DropSnapshot is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 *proxypb.DropSnapshotRequest
+func (_e *MockProxy_Expecter) DropSnapshot(_a0 interface{}, _a1 interface{}) *MockProxy_DropSnapshot_Call {
+	return &MockProxy_DropSnapshot_Call{Call: _e.mock.On("DropSnapshot", _a0, _a1)}
+}
+
+func (_c *MockProxy_DropSnapshot_Call) Run(run func(_a0 context.Context, _a1 *proxypb.DropSnapshotRequest)) *MockProxy_DropSnapshot_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*proxypb.DropSnapshotRequest))
+	})
+	return _c
+}
+
+func (_c *MockProxy_DropSnapshot_Call) Return(_a0 *commonpb.Status, _a1 error) *MockProxy_DropSnapshot_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockProxy_DropSnapshot_Call) RunAndReturn(run func(context.Context, *proxypb.DropSnapshotRequest) (*commonpb.Status, error)) *MockProxy_DropSnapshot_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Dummy provides a mock function with given fields: _a0, _a1
 func (_m *MockProxy) Dummy(_a0 context.Context, _a1 *milvuspb.DummyRequest) (*milvuspb.DummyResponse, error) {
 	ret := _m.Called(_a0, _a1)
@@ -2158,6 +2512,65 @@ func (_c *MockProxy_Dummy_Call) RunAndReturn(run func(context.Context, *milvuspb
 	return _c
 }
 
+// Export provides a mock function with given fields: _a0, _a1
+func (_m *MockProxy) Export(_a0 context.Context, _a1 *proxypb.ExportRequest) (*proxypb.ExportResponse, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Export")
+	}
+
+	var r0 *proxypb.ExportResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *proxypb.ExportRequest) (*proxypb.ExportResponse, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *proxypb.ExportRequest) *proxypb.ExportResponse); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*proxypb.ExportResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *proxypb.ExportRequest) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockProxy_Export_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Export'
+type MockProxy_Export_Call struct {
+	*mock.Call
+}
+
+// Export is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 *proxypb.ExportRequest
+func (_e *MockProxy_Expecter) Export(_a0 interface{}, _a1 interface{}) *MockProxy_Export_Call {
+	return &MockProxy_Export_Call{Call: _e.mock.On("Export", _a0, _a1)}
+}
+
+func (_c *MockProxy_Export_Call) Run(run func(_a0 context.Context, _a1 *proxypb.ExportRequest)) *MockProxy_Export_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*proxypb.ExportRequest))
+	})
+	return _c
+}
+
+func (_c *MockProxy_Export_Call) Return(_a0 *proxypb.ExportResponse, _a1 error) *MockProxy_Export_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockProxy_Export_Call) RunAndReturn(run func(context.Context, *proxypb.ExportRequest) (*proxypb.ExportResponse, error)) *MockProxy_Export_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Flush provides a mock function with given fields: _a0, _a1
 func (_m *MockProxy) Flush(_a0 context.Context, _a1 *milvuspb.FlushRequest) (*milvuspb.FlushResponse, error) {
 	ret := _m.Called(_a0, _a1)
@@ -2616,6 +3029,65 @@ func (_c *MockProxy_GetDdChannel_Call) RunAndReturn(run func(context.Context, *i
 	return _c
 }
 
+// GetExportProgress provides a mock function with given fields: _a0, _a1
+func (_m *MockProxy) GetExportProgress(_a0 context.Context, _a1 *proxypb.GetExportProgressRequest) (*proxypb.GetExportProgressResponse, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetExportProgress")
+	}
+
+	var r0 *proxypb.GetExportProgressResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *proxypb.GetExportProgressRequest) (*proxypb.GetExportProgressResponse, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *proxypb.GetExportProgressRequest) *proxypb.GetExportProgressResponse); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*proxypb.GetExportProgressResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *proxypb.GetExportProgressRequest) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockProxy_GetExportProgress_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetExportProgress'
+type MockProxy_GetExportProgress_Call struct {
+	*mock.Call
+}
+
+// GetExportProgress is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 *proxypb.GetExportProgressRequest
+func (_e *MockProxy_Expecter) GetExportProgress(_a0 interface{}, _a1 interface{}) *MockProxy_GetExportProgress_Call {
+	return &MockProxy_GetExportProgress_Call{Call: _e.mock.On("GetExportProgress", _a0, _a1)}
+}
+
+func (_c *MockProxy_GetExportProgress_Call) Run(run func(_a0 context.Context, _a1 *proxypb.GetExportProgressRequest)) *MockProxy_GetExportProgress_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*proxypb.GetExportProgressRequest))
+	})
+	return _c
+}
+
+func (_c *MockProxy_GetExportProgress_Call) Return(_a0 *proxypb.GetExportProgressResponse, _a1 error) *MockProxy_GetExportProgress_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockProxy_GetExportProgress_Call) RunAndReturn(run func(context.Context, *proxypb.GetExportProgressRequest) (*proxypb.GetExportProgressResponse, error)) *MockProxy_GetExportProgress_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetFlushAllState provides a mock function with given fields: _a0, _a1
 func (_m *MockProxy) GetFlushAllState(_a0 context.Context, _a1 *milvuspb.GetFlushAllStateRequest) (*milvuspb.GetFlushAllStateResponse, error) {
 	ret := _m.Called(_a0, _a1)
@@ -4724,6 +5196,65 @@ func (_c *MockProxy_ListPrivilegeGroups_Call) RunAndReturn(run func(context.Cont
 	return _c
 }
 
+// ListRecycleBin provides a mock function with given fields: _a0, _a1
+func (_m *MockProxy) ListRecycleBin(_a0 context.Context, _a1 *proxypb.ListRecycleBinRequest) (*proxypb.ListRecycleBinResponse, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListRecycleBin")
+	}
+
+	var r0 *proxypb.ListRecycleBinResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *proxypb.ListRecycleBinRequest) (*proxypb.ListRecycleBinResponse, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *proxypb.ListRecycleBinRequest) *proxypb.ListRecycleBinResponse); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*proxypb.ListRecycleBinResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *proxypb.ListRecycleBinRequest) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockProxy_ListRecycleBin_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListRecycleBin'
+type MockProxy_ListRecycleBin_Call struct {
+	*mock.Call
+}
+
+// ListRecycleBin is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 *proxypb.ListRecycleBinRequest
+func (_e *MockProxy_Expecter) ListRecycleBin(_a0 interface{}, _a1 interface{}) *MockProxy_ListRecycleBin_Call {
+	return &MockProxy_ListRecycleBin_Call{Call: _e.mock.On("ListRecycleBin", _a0, _a1)}
+}
+
+func (_c *MockProxy_ListRecycleBin_Call) Run(run func(_a0 context.Context, _a1 *proxypb.ListRecycleBinRequest)) *MockProxy_ListRecycleBin_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*proxypb.ListRecycleBinRequest))
+	})
+	return _c
+}
+
+func (_c *MockProxy_ListRecycleBin_Call) Return(_a0 *proxypb.ListRecycleBinResponse, _a1 error) *MockProxy_ListRecycleBin_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockProxy_ListRecycleBin_Call) RunAndReturn(run func(context.Context, *proxypb.ListRecycleBinRequest) (*proxypb.ListRecycleBinResponse, error)) *MockProxy_ListRecycleBin_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // ListResourceGroups provides a mock function with given fields: _a0, _a1
 func (_m *MockProxy) ListResourceGroups(_a0 context.Context, _a1 *milvuspb.ListResourceGroupsRequest) (*milvuspb.ListResourceGroupsResponse, error) {
 	ret := _m.Called(_a0, _a1)
@@ -4759,26 +5290,144 @@ type MockProxy_ListResourceGroups_Call struct {
 	*mock.Call
 }
 
-// ListResourceGroups is a helper method to define mock.On call
+// ListResourceGroups is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 *milvuspb.ListResourceGroupsRequest
+func (_e *MockProxy_Expecter) ListResourceGroups(_a0 interface{}, _a1 interface{}) *MockProxy_ListResourceGroups_Call {
+	return &MockProxy_ListResourceGroups_Call{Call: _e.mock.On("ListResourceGroups", _a0, _a1)}
+}
+
+func (_c *MockProxy_ListResourceGroups_Call) Run(run func(_a0 context.Context, _a1 *milvuspb.ListResourceGroupsRequest)) *MockProxy_ListResourceGroups_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*milvuspb.ListResourceGroupsRequest))
+	})
+	return _c
+}
+
+func (_c *MockProxy_ListResourceGroups_Call) Return(_a0 *milvuspb.ListResourceGroupsResponse, _a1 error) *MockProxy_ListResourceGroups_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockProxy_ListResourceGroups_Call) RunAndReturn(run func(context.Context, *milvuspb.ListResourceGroupsRequest) (*milvuspb.ListResourceGroupsResponse, error)) *MockProxy_ListResourceGroups_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListRowPolicies provides a mock function with given fields: _a0, _a1
+func (_m *MockProxy) ListRowPolicies(_a0 context.Context, _a1 *proxypb.ListRowPoliciesRequest) (*proxypb.ListRowPoliciesResponse, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListRowPolicies")
+	}
+
+	var r0 *proxypb.ListRowPoliciesResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *proxypb.ListRowPoliciesRequest) (*proxypb.ListRowPoliciesResponse, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *proxypb.ListRowPoliciesRequest) *proxypb.ListRowPoliciesResponse); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*proxypb.ListRowPoliciesResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *proxypb.ListRowPoliciesRequest) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockProxy_ListRowPolicies_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListRowPolicies'
+type MockProxy_ListRowPolicies_Call struct {
+	*mock.Call
+}
+
+// ListRowPolicies is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 *proxypb.ListRowPoliciesRequest
+func (_e *MockProxy_Expecter) ListRowPolicies(_a0 interface{}, _a1 interface{}) *MockProxy_ListRowPolicies_Call {
+	return &MockProxy_ListRowPolicies_Call{Call: _e.mock.On("ListRowPolicies", _a0, _a1)}
+}
+
+func (_c *MockProxy_ListRowPolicies_Call) Run(run func(_a0 context.Context, _a1 *proxypb.ListRowPoliciesRequest)) *MockProxy_ListRowPolicies_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*proxypb.ListRowPoliciesRequest))
+	})
+	return _c
+}
+
+func (_c *MockProxy_ListRowPolicies_Call) Return(_a0 *proxypb.ListRowPoliciesResponse, _a1 error) *MockProxy_ListRowPolicies_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockProxy_ListRowPolicies_Call) RunAndReturn(run func(context.Context, *proxypb.ListRowPoliciesRequest) (*proxypb.ListRowPoliciesResponse, error)) *MockProxy_ListRowPolicies_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListSnapshots provides a mock function with given fields: _a0, _a1
+func (_m *MockProxy) ListSnapshots(_a0 context.Context, _a1 *proxypb.ListSnapshotsRequest) (*proxypb.ListSnapshotsResponse, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListSnapshots")
+	}
+
+	var r0 *proxypb.ListSnapshotsResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *proxypb.ListSnapshotsRequest) (*proxypb.ListSnapshotsResponse, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *proxypb.ListSnapshotsRequest) *proxypb.ListSnapshotsResponse); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*proxypb.ListSnapshotsResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *proxypb.ListSnapshotsRequest) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockProxy_ListSnapshots_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListSnapshots'
+type MockProxy_ListSnapshots_Call struct {
+	*mock.Call
+}
+
+// ListSnapshots is a helper method to define mock.On call
 //   - _a0 context.Context
-//   - _a1 *milvuspb.ListResourceGroupsRequest
-func (_e *MockProxy_Expecter) ListResourceGroups(_a0 interface{}, _a1 interface{}) *MockProxy_ListResourceGroups_Call {
-	return &MockProxy_ListResourceGroups_Call{Call: _e.mock.On("ListResourceGroups", _a0, _a1)}
+//   - _a1 *proxypb.ListSnapshotsRequest
+func (_e *MockProxy_Expecter) ListSnapshots(_a0 interface{}, _a1 interface{}) *MockProxy_ListSnapshots_Call {
+	return &MockProxy_ListSnapshots_Call{Call: _e.mock.On("ListSnapshots", _a0, _a1)}
 }
 
-func (_c *MockProxy_ListResourceGroups_Call) Run(run func(_a0 context.Context, _a1 *milvuspb.ListResourceGroupsRequest)) *MockProxy_ListResourceGroups_Call {
+func (_c *MockProxy_ListSnapshots_Call) Run(run func(_a0 context.Context, _a1 *proxypb.ListSnapshotsRequest)) *MockProxy_ListSnapshots_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(*milvuspb.ListResourceGroupsRequest))
+		run(args[0].(context.Context), args[1].(*proxypb.ListSnapshotsRequest))
 	})
 	return _c
 }
 
-func (_c *MockProxy_ListResourceGroups_Call) Return(_a0 *milvuspb.ListResourceGroupsResponse, _a1 error) *MockProxy_ListResourceGroups_Call {
+func (_c *MockProxy_ListSnapshots_Call) Return(_a0 *proxypb.ListSnapshotsResponse, _a1 error) *MockProxy_ListSnapshots_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockProxy_ListResourceGroups_Call) RunAndReturn(run func(context.Context, *milvuspb.ListResourceGroupsRequest) (*milvuspb.ListResourceGroupsResponse, error)) *MockProxy_ListResourceGroups_Call {
+func (_c *MockProxy_ListSnapshots_Call) RunAndReturn(run func(context.Context, *proxypb.ListSnapshotsRequest) (*proxypb.ListSnapshotsResponse, error)) *MockProxy_ListSnapshots_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -5772,6 +6421,124 @@ func (_c *MockProxy_RestoreRBAC_Call) RunAndReturn(run func(context.Context, *mi
 	return _c
 }
 
+// RestoreSnapshot provides a mock function with given fields: _a0, _a1
+func (_m *MockProxy) RestoreSnapshot(_a0 context.Context, _a1 *proxypb.RestoreSnapshotRequest) (*commonpb.Status, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RestoreSnapshot")
+	}
+
+	var r0 *commonpb.Status
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *proxypb.RestoreSnapshotRequest) (*commonpb.Status, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *proxypb.RestoreSnapshotRequest) *commonpb.Status); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*commonpb.Status)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *proxypb.RestoreSnapshotRequest) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockProxy_RestoreSnapshot_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RestoreSnapshot'
+type MockProxy_RestoreSnapshot_Call struct {
+	*mock.Call
+}
+
+// RestoreSnapshot is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 *proxypb.RestoreSnapshotRequest
+func (_e *MockProxy_Expecter) RestoreSnapshot(_a0 interface{}, _a1 interface{}) *MockProxy_RestoreSnapshot_Call {
+	return &MockProxy_RestoreSnapshot_Call{Call: _e.mock.On("RestoreSnapshot", _a0, _a1)}
+}
+
+func (_c *MockProxy_RestoreSnapshot_Call) Run(run func(_a0 context.Context, _a1 *proxypb.RestoreSnapshotRequest)) *MockProxy_RestoreSnapshot_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*proxypb.RestoreSnapshotRequest))
+	})
+	return _c
+}
+
+func (_c *MockProxy_RestoreSnapshot_Call) Return(_a0 *commonpb.Status, _a1 error) *MockProxy_RestoreSnapshot_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockProxy_RestoreSnapshot_Call) RunAndReturn(run func(context.Context, *proxypb.RestoreSnapshotRequest) (*commonpb.Status, error)) *MockProxy_RestoreSnapshot_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RollbackTransaction provides a mock function with given fields: _a0, _a1
+func (_m *MockProxy) RollbackTransaction(_a0 context.Context, _a1 *proxypb.RollbackTransactionRequest) (*commonpb.Status, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RollbackTransaction")
+	}
+
+	var r0 *commonpb.Status
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *proxypb.RollbackTransactionRequest) (*commonpb.Status, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *proxypb.RollbackTransactionRequest) *commonpb.Status); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*commonpb.Status)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *proxypb.RollbackTransactionRequest) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockProxy_RollbackTransaction_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RollbackTransaction'
+type MockProxy_RollbackTransaction_Call struct {
+	*mock.Call
+}
+
+// RollbackTransaction is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 *proxypb.RollbackTransactionRequest
+func (_e *MockProxy_Expecter) RollbackTransaction(_a0 interface{}, _a1 interface{}) *MockProxy_RollbackTransaction_Call {
+	return &MockProxy_RollbackTransaction_Call{Call: _e.mock.On("RollbackTransaction", _a0, _a1)}
+}
+
+func (_c *MockProxy_RollbackTransaction_Call) Run(run func(_a0 context.Context, _a1 *proxypb.RollbackTransactionRequest)) *MockProxy_RollbackTransaction_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*proxypb.RollbackTransactionRequest))
+	})
+	return _c
+}
+
+func (_c *MockProxy_RollbackTransaction_Call) Return(_a0 *commonpb.Status, _a1 error) *MockProxy_RollbackTransaction_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockProxy_RollbackTransaction_Call) RunAndReturn(run func(context.Context, *proxypb.RollbackTransactionRequest) (*commonpb.Status, error)) *MockProxy_RollbackTransaction_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Search provides a mock function with given fields: _a0, _a1
 func (_m *MockProxy) Search(_a0 context.Context, _a1 *milvuspb.SearchRequest) (*milvuspb.SearchResults, error) {
 	ret := _m.Called(_a0, _a1)
@@ -6265,6 +7032,65 @@ func (_c *MockProxy_SetRootCoordClient_Call) RunAndReturn(run func(types.RootCoo
 	return _c
 }
 
+// SetRowPolicy provides a mock function with given fields: _a0, _a1
+func (_m *MockProxy) SetRowPolicy(_a0 context.Context, _a1 *proxypb.SetRowPolicyRequest) (*commonpb.Status, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetRowPolicy")
+	}
+
+	var r0 *commonpb.Status
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *proxypb.SetRowPolicyRequest) (*commonpb.Status, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *proxypb.SetRowPolicyRequest) *commonpb.Status); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*commonpb.Status)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *proxypb.SetRowPolicyRequest) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockProxy_SetRowPolicy_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetRowPolicy'
+type MockProxy_SetRowPolicy_Call struct {
+	*mock.Call
+}
+
+// SetRowPolicy is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 *proxypb.SetRowPolicyRequest
+func (_e *MockProxy_Expecter) SetRowPolicy(_a0 interface{}, _a1 interface{}) *MockProxy_SetRowPolicy_Call {
+	return &MockProxy_SetRowPolicy_Call{Call: _e.mock.On("SetRowPolicy", _a0, _a1)}
+}
+
+func (_c *MockProxy_SetRowPolicy_Call) Run(run func(_a0 context.Context, _a1 *proxypb.SetRowPolicyRequest)) *MockProxy_SetRowPolicy_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*proxypb.SetRowPolicyRequest))
+	})
+	return _c
+}
+
+func (_c *MockProxy_SetRowPolicy_Call) Return(_a0 *commonpb.Status, _a1 error) *MockProxy_SetRowPolicy_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockProxy_SetRowPolicy_Call) RunAndReturn(run func(context.Context, *proxypb.SetRowPolicyRequest) (*commonpb.Status, error)) *MockProxy_SetRowPolicy_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // ShowCollections provides a mock function with given fields: _a0, _a1
 func (_m *MockProxy) ShowCollections(_a0 context.Context, _a1 *milvuspb.ShowCollectionsRequest) (*milvuspb.ShowCollectionsResponse, error) {
 	ret := _m.Called(_a0, _a1)
@@ -6473,6 +7299,53 @@ func (_c *MockProxy_Stop_Call) RunAndReturn(run func() error) *MockProxy_Stop_Ca
 	return _c
 }
 
+// SubscribeChanges provides a mock function with given fields: _a0, _a1
+func (_m *MockProxy) SubscribeChanges(_a0 *proxypb.SubscribeChangesRequest, _a1 proxypb.ChangeStream_SubscribeChangesServer) error {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SubscribeChanges")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*proxypb.SubscribeChangesRequest, proxypb.ChangeStream_SubscribeChangesServer) error); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockProxy_SubscribeChanges_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SubscribeChanges'
+type MockProxy_SubscribeChanges_Call struct {
+	*mock.Call
+}
+
+// SubscribeChanges is a helper method to define mock.On call
+//   - _a0 *proxypb.SubscribeChangesRequest
+//   - _a1 proxypb.ChangeStream_SubscribeChangesServer
+func (_e *MockProxy_Expecter) SubscribeChanges(_a0 interface{}, _a1 interface{}) *MockProxy_SubscribeChanges_Call {
+	return &MockProxy_SubscribeChanges_Call{Call: _e.mock.On("SubscribeChanges", _a0, _a1)}
+}
+
+func (_c *MockProxy_SubscribeChanges_Call) Run(run func(_a0 *proxypb.SubscribeChangesRequest, _a1 proxypb.ChangeStream_SubscribeChangesServer)) *MockProxy_SubscribeChanges_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*proxypb.SubscribeChangesRequest), args[1].(proxypb.ChangeStream_SubscribeChangesServer))
+	})
+	return _c
+}
+
+func (_c *MockProxy_SubscribeChanges_Call) Return(_a0 error) *MockProxy_SubscribeChanges_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockProxy_SubscribeChanges_Call) RunAndReturn(run func(*proxypb.SubscribeChangesRequest, proxypb.ChangeStream_SubscribeChangesServer) error) *MockProxy_SubscribeChanges_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // TransferNode provides a mock function with given fields: _a0, _a1
 func (_m *MockProxy) TransferNode(_a0 context.Context, _a1 *milvuspb.TransferNodeRequest) (*commonpb.Status, error) {
 	ret := _m.Called(_a0, _a1)
@@ -6591,6 +7464,124 @@ func (_c *MockProxy_TransferReplica_Call) RunAndReturn(run func(context.Context,
 	return _c
 }
 
+// UndropCollection provides a mock function with given fields: _a0, _a1
+func (_m *MockProxy) UndropCollection(_a0 context.Context, _a1 *proxypb.UndropCollectionRequest) (*commonpb.Status, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UndropCollection")
+	}
+
+	var r0 *commonpb.Status
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *proxypb.UndropCollectionRequest) (*commonpb.Status, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *proxypb.UndropCollectionRequest) *commonpb.Status); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*commonpb.Status)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *proxypb.UndropCollectionRequest) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockProxy_UndropCollection_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UndropCollection'
+type MockProxy_UndropCollection_Call struct {
+	*mock.Call
+}
+
+// UndropCollection is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 *proxypb.UndropCollectionRequest
+func (_e *MockProxy_Expecter) UndropCollection(_a0 interface{}, _a1 interface{}) *MockProxy_UndropCollection_Call {
+	return &MockProxy_UndropCollection_Call{Call: _e.mock.On("UndropCollection", _a0, _a1)}
+}
+
+func (_c *MockProxy_UndropCollection_Call) Run(run func(_a0 context.Context, _a1 *proxypb.UndropCollectionRequest)) *MockProxy_UndropCollection_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*proxypb.UndropCollectionRequest))
+	})
+	return _c
+}
+
+func (_c *MockProxy_UndropCollection_Call) Return(_a0 *commonpb.Status, _a1 error) *MockProxy_UndropCollection_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockProxy_UndropCollection_Call) RunAndReturn(run func(context.Context, *proxypb.UndropCollectionRequest) (*commonpb.Status, error)) *MockProxy_UndropCollection_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UndropPartition provides a mock function with given fields: _a0, _a1
+func (_m *MockProxy) UndropPartition(_a0 context.Context, _a1 *proxypb.UndropPartitionRequest) (*commonpb.Status, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UndropPartition")
+	}
+
+	var r0 *commonpb.Status
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *proxypb.UndropPartitionRequest) (*commonpb.Status, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *proxypb.UndropPartitionRequest) *commonpb.Status); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*commonpb.Status)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *proxypb.UndropPartitionRequest) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockProxy_UndropPartition_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UndropPartition'
+type MockProxy_UndropPartition_Call struct {
+	*mock.Call
+}
+
+// UndropPartition is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 *proxypb.UndropPartitionRequest
+func (_e *MockProxy_Expecter) UndropPartition(_a0 interface{}, _a1 interface{}) *MockProxy_UndropPartition_Call {
+	return &MockProxy_UndropPartition_Call{Call: _e.mock.On("UndropPartition", _a0, _a1)}
+}
+
+func (_c *MockProxy_UndropPartition_Call) Run(run func(_a0 context.Context, _a1 *proxypb.UndropPartitionRequest)) *MockProxy_UndropPartition_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*proxypb.UndropPartitionRequest))
+	})
+	return _c
+}
+
+func (_c *MockProxy_UndropPartition_Call) Return(_a0 *commonpb.Status, _a1 error) *MockProxy_UndropPartition_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockProxy_UndropPartition_Call) RunAndReturn(run func(context.Context, *proxypb.UndropPartitionRequest) (*commonpb.Status, error)) *MockProxy_UndropPartition_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // UpdateCredential provides a mock function with given fields: _a0, _a1
 func (_m *MockProxy) UpdateCredential(_a0 context.Context, _a1 *milvuspb.UpdateCredentialRequest) (*commonpb.Status, error) {
 	ret := _m.Called(_a0, _a1)
@@ -2441,6 +2441,65 @@ func (_c *RootCoord_ListPrivilegeGroups_Call) RunAndReturn(run func(context.Cont
 	return _c
 }
 
+// ListRecycleBin provides a mock function with given fields: _a0, _a1
+func (_m *RootCoord) ListRecycleBin(_a0 context.Context, _a1 *proxypb.ListRecycleBinRequest) (*proxypb.ListRecycleBinResponse, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListRecycleBin")
+	}
+
+	var r0 *proxypb.ListRecycleBinResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *proxypb.ListRecycleBinRequest) (*proxypb.ListRecycleBinResponse, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *proxypb.ListRecycleBinRequest) *proxypb.ListRecycleBinResponse); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*proxypb.ListRecycleBinResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *proxypb.ListRecycleBinRequest) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RootCoord_ListRecycleBin_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListRecycleBin'
+type RootCoord_ListRecycleBin_Call struct {
+	*mock.Call
+}
+
+// ListRecycleBin is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 *proxypb.ListRecycleBinRequest
+func (_e *RootCoord_Expecter) ListRecycleBin(_a0 interface{}, _a1 interface{}) *RootCoord_ListRecycleBin_Call {
+	return &RootCoord_ListRecycleBin_Call{Call: _e.mock.On("ListRecycleBin", _a0, _a1)}
+}
+
+func (_c *RootCoord_ListRecycleBin_Call) Run(run func(_a0 context.Context, _a1 *proxypb.ListRecycleBinRequest)) *RootCoord_ListRecycleBin_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*proxypb.ListRecycleBinRequest))
+	})
+	return _c
+}
+
+func (_c *RootCoord_ListRecycleBin_Call) Return(_a0 *proxypb.ListRecycleBinResponse, _a1 error) *RootCoord_ListRecycleBin_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *RootCoord_ListRecycleBin_Call) RunAndReturn(run func(context.Context, *proxypb.ListRecycleBinRequest) (*proxypb.ListRecycleBinResponse, error)) *RootCoord_ListRecycleBin_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // OperatePrivilege provides a mock function with given fields: _a0, _a1
 func (_m *RootCoord) OperatePrivilege(_a0 context.Context, _a1 *milvuspb.OperatePrivilegeRequest) (*commonpb.Status, error) {
 	ret := _m.Called(_a0, _a1)
@@ -2559,6 +2618,65 @@ func (_c *RootCoord_OperatePrivilegeGroup_Call) RunAndReturn(run func(context.Co
 	return _c
 }
 
+// OperateRowPolicy provides a mock function with given fields: _a0, _a1
+func (_m *RootCoord) OperateRowPolicy(_a0 context.Context, _a1 *rootcoordpb.OperateRowPolicyRequest) (*commonpb.Status, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for OperateRowPolicy")
+	}
+
+	var r0 *commonpb.Status
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *rootcoordpb.OperateRowPolicyRequest) (*commonpb.Status, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *rootcoordpb.OperateRowPolicyRequest) *commonpb.Status); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*commonpb.Status)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *rootcoordpb.OperateRowPolicyRequest) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RootCoord_OperateRowPolicy_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'OperateRowPolicy'
+type RootCoord_OperateRowPolicy_Call struct {
+	*mock.Call
+}
+
+// OperateRowPolicy is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 *rootcoordpb.OperateRowPolicyRequest
+func (_e *RootCoord_Expecter) OperateRowPolicy(_a0 interface{}, _a1 interface{}) *RootCoord_OperateRowPolicy_Call {
+	return &RootCoord_OperateRowPolicy_Call{Call: _e.mock.On("OperateRowPolicy", _a0, _a1)}
+}
+
+func (_c *RootCoord_OperateRowPolicy_Call) Run(run func(_a0 context.Context, _a1 *rootcoordpb.OperateRowPolicyRequest)) *RootCoord_OperateRowPolicy_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*rootcoordpb.OperateRowPolicyRequest))
+	})
+	return _c
+}
+
+func (_c *RootCoord_OperateRowPolicy_Call) Return(_a0 *commonpb.Status, _a1 error) *RootCoord_OperateRowPolicy_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *RootCoord_OperateRowPolicy_Call) RunAndReturn(run func(context.Context, *rootcoordpb.OperateRowPolicyRequest) (*commonpb.Status, error)) *RootCoord_OperateRowPolicy_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // OperateUserRole provides a mock function with given fields: _a0, _a1
 func (_m *RootCoord) OperateUserRole(_a0 context.Context, _a1 *milvuspb.OperateUserRoleRequest) (*commonpb.Status, error) {
 	ret := _m.Called(_a0, _a1)
@@ -3567,6 +3685,124 @@ func (_c *RootCoord_Stop_Call) RunAndReturn(run func() error) *RootCoord_Stop_Ca
 	return _c
 }
 
+// UndropCollection provides a mock function with given fields: _a0, _a1
+func (_m *RootCoord) UndropCollection(_a0 context.Context, _a1 *proxypb.UndropCollectionRequest) (*commonpb.Status, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UndropCollection")
+	}
+
+	var r0 *commonpb.Status
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *proxypb.UndropCollectionRequest) (*commonpb.Status, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *proxypb.UndropCollectionRequest) *commonpb.Status); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*commonpb.Status)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *proxypb.UndropCollectionRequest) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RootCoord_UndropCollection_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UndropCollection'
+type RootCoord_UndropCollection_Call struct {
+	*mock.Call
+}
+
+// UndropCollection is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 *proxypb.UndropCollectionRequest
+func (_e *RootCoord_Expecter) UndropCollection(_a0 interface{}, _a1 interface{}) *RootCoord_UndropCollection_Call {
+	return &RootCoord_UndropCollection_Call{Call: _e.mock.On("UndropCollection", _a0, _a1)}
+}
+
+func (_c *RootCoord_UndropCollection_Call) Run(run func(_a0 context.Context, _a1 *proxypb.UndropCollectionRequest)) *RootCoord_UndropCollection_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*proxypb.UndropCollectionRequest))
+	})
+	return _c
+}
+
+func (_c *RootCoord_UndropCollection_Call) Return(_a0 *commonpb.Status, _a1 error) *RootCoord_UndropCollection_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *RootCoord_UndropCollection_Call) RunAndReturn(run func(context.Context, *proxypb.UndropCollectionRequest) (*commonpb.Status, error)) *RootCoord_UndropCollection_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UndropPartition provides a mock function with given fields: _a0, _a1
+func (_m *RootCoord) UndropPartition(_a0 context.Context, _a1 *proxypb.UndropPartitionRequest) (*commonpb.Status, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UndropPartition")
+	}
+
+	var r0 *commonpb.Status
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *proxypb.UndropPartitionRequest) (*commonpb.Status, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *proxypb.UndropPartitionRequest) *commonpb.Status); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*commonpb.Status)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *proxypb.UndropPartitionRequest) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RootCoord_UndropPartition_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UndropPartition'
+type RootCoord_UndropPartition_Call struct {
+	*mock.Call
+}
+
+// UndropPartition is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 *proxypb.UndropPartitionRequest
+func (_e *RootCoord_Expecter) UndropPartition(_a0 interface{}, _a1 interface{}) *RootCoord_UndropPartition_Call {
+	return &RootCoord_UndropPartition_Call{Call: _e.mock.On("UndropPartition", _a0, _a1)}
+}
+
+func (_c *RootCoord_UndropPartition_Call) Run(run func(_a0 context.Context, _a1 *proxypb.UndropPartitionRequest)) *RootCoord_UndropPartition_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*proxypb.UndropPartitionRequest))
+	})
+	return _c
+}
+
+func (_c *RootCoord_UndropPartition_Call) Return(_a0 *commonpb.Status, _a1 error) *RootCoord_UndropPartition_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *RootCoord_UndropPartition_Call) RunAndReturn(run func(context.Context, *proxypb.UndropPartitionRequest) (*commonpb.Status, error)) *RootCoord_UndropPartition_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // UpdateChannelTimeTick provides a mock function with given fields: _a0, _a1
 func (_m *RootCoord) UpdateChannelTimeTick(_a0 context.Context, _a1 *internalpb.ChannelTimeTickMsg) (*commonpb.Status, error) {
 	ret := _m.Called(_a0, _a1)
@@ -3038,6 +3038,80 @@ func (_c *MockRootCoordClient_ListPrivilegeGroups_Call) RunAndReturn(run func(co
 	return _c
 }
 
+// ListRecycleBin provides a mock function with given fields: ctx, in, opts
+func (_m *MockRootCoordClient) ListRecycleBin(ctx context.Context, in *proxypb.ListRecycleBinRequest, opts ...grpc.CallOption) (*proxypb.ListRecycleBinResponse, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListRecycleBin")
+	}
+
+	var r0 *proxypb.ListRecycleBinResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *proxypb.ListRecycleBinRequest, ...grpc.CallOption) (*proxypb.ListRecycleBinResponse, error)); ok {
+		return rf(ctx, in, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *proxypb.ListRecycleBinRequest, ...grpc.CallOption) *proxypb.ListRecycleBinResponse); ok {
+		r0 = rf(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*proxypb.ListRecycleBinResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *proxypb.ListRecycleBinRequest, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRootCoordClient_ListRecycleBin_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListRecycleBin'
+type MockRootCoordClient_ListRecycleBin_Call struct {
+	*mock.Call
+}
+
+// ListRecycleBin is a helper method to define mock.On call
+//   - ctx context.Context
+//   - in *proxypb.ListRecycleBinRequest
+//   - opts ...grpc.CallOption
+func (_e *MockRootCoordClient_Expecter) ListRecycleBin(ctx interface{}, in interface{}, opts ...interface{}) *MockRootCoordClient_ListRecycleBin_Call {
+	return &MockRootCoordClient_ListRecycleBin_Call{Call: _e.mock.On("ListRecycleBin",
+		append([]interface{}{ctx, in}, opts...)...)}
+}
+
+func (_c *MockRootCoordClient_ListRecycleBin_Call) Run(run func(ctx context.Context, in *proxypb.ListRecycleBinRequest, opts ...grpc.CallOption)) *MockRootCoordClient_ListRecycleBin_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]grpc.CallOption, len(args)-2)
+		for i, a := range args[2:] {
+			if a != nil {
+				variadicArgs[i] = a.(grpc.CallOption)
+			}
+		}
+		run(args[0].(context.Context), args[1].(*proxypb.ListRecycleBinRequest), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *MockRootCoordClient_ListRecycleBin_Call) Return(_a0 *proxypb.ListRecycleBinResponse, _a1 error) *MockRootCoordClient_ListRecycleBin_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRootCoordClient_ListRecycleBin_Call) RunAndReturn(run func(context.Context, *proxypb.ListRecycleBinRequest, ...grpc.CallOption) (*proxypb.ListRecycleBinResponse, error)) *MockRootCoordClient_ListRecycleBin_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // OperatePrivilege provides a mock function with given fields: ctx, in, opts
 func (_m *MockRootCoordClient) OperatePrivilege(ctx context.Context, in *milvuspb.OperatePrivilegeRequest, opts ...grpc.CallOption) (*commonpb.Status, error) {
 	_va := make([]interface{}, len(opts))
@@ -3186,6 +3260,80 @@ func (_c *MockRootCoordClient_OperatePrivilegeGroup_Call) RunAndReturn(run func(
 	return _c
 }
 
+// OperateRowPolicy provides a mock function with given fields: ctx, in, opts
+func (_m *MockRootCoordClient) OperateRowPolicy(ctx context.Context, in *rootcoordpb.OperateRowPolicyRequest, opts ...grpc.CallOption) (*commonpb.Status, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for OperateRowPolicy")
+	}
+
+	var r0 *commonpb.Status
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *rootcoordpb.OperateRowPolicyRequest, ...grpc.CallOption) (*commonpb.Status, error)); ok {
+		return rf(ctx, in, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *rootcoordpb.OperateRowPolicyRequest, ...grpc.CallOption) *commonpb.Status); ok {
+		r0 = rf(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*commonpb.Status)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *rootcoordpb.OperateRowPolicyRequest, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRootCoordClient_OperateRowPolicy_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'OperateRowPolicy'
+type MockRootCoordClient_OperateRowPolicy_Call struct {
+	*mock.Call
+}
+
+// OperateRowPolicy is a helper method to define mock.On call
+//   - ctx context.Context
+//   - in *rootcoordpb.OperateRowPolicyRequest
+//   - opts ...grpc.CallOption
+func (_e *MockRootCoordClient_Expecter) OperateRowPolicy(ctx interface{}, in interface{}, opts ...interface{}) *MockRootCoordClient_OperateRowPolicy_Call {
+	return &MockRootCoordClient_OperateRowPolicy_Call{Call: _e.mock.On("OperateRowPolicy",
+		append([]interface{}{ctx, in}, opts...)...)}
+}
+
+func (_c *MockRootCoordClient_OperateRowPolicy_Call) Run(run func(ctx context.Context, in *rootcoordpb.OperateRowPolicyRequest, opts ...grpc.CallOption)) *MockRootCoordClient_OperateRowPolicy_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]grpc.CallOption, len(args)-2)
+		for i, a := range args[2:] {
+			if a != nil {
+				variadicArgs[i] = a.(grpc.CallOption)
+			}
+		}
+		run(args[0].(context.Context), args[1].(*rootcoordpb.OperateRowPolicyRequest), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *MockRootCoordClient_OperateRowPolicy_Call) Return(_a0 *commonpb.Status, _a1 error) *MockRootCoordClient_OperateRowPolicy_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRootCoordClient_OperateRowPolicy_Call) RunAndReturn(run func(context.Context, *rootcoordpb.OperateRowPolicyRequest, ...grpc.CallOption) (*commonpb.Status, error)) *MockRootCoordClient_OperateRowPolicy_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // OperateUserRole provides a mock function with given fields: ctx, in, opts
 func (_m *MockRootCoordClient) OperateUserRole(ctx context.Context, in *milvuspb.OperateUserRoleRequest, opts ...grpc.CallOption) (*commonpb.Status, error) {
 	_va := make([]interface{}, len(opts))
@@ -4000,6 +4148,154 @@ func (_c *MockRootCoordClient_ShowSegments_Call) RunAndReturn(run func(context.C
 	return _c
 }
 
+// UndropCollection provides a mock function with given fields: ctx, in, opts
+func (_m *MockRootCoordClient) UndropCollection(ctx context.Context, in *proxypb.UndropCollectionRequest, opts ...grpc.CallOption) (*commonpb.Status, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UndropCollection")
+	}
+
+	var r0 *commonpb.Status
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *proxypb.UndropCollectionRequest, ...grpc.CallOption) (*commonpb.Status, error)); ok {
+		return rf(ctx, in, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *proxypb.UndropCollectionRequest, ...grpc.CallOption) *commonpb.Status); ok {
+		r0 = rf(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*commonpb.Status)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *proxypb.UndropCollectionRequest, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRootCoordClient_UndropCollection_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UndropCollection'
+type MockRootCoordClient_UndropCollection_Call struct {
+	*mock.Call
+}
+
+// UndropCollection is a helper method to define mock.On call
+//   - ctx context.Context
+//   - in *proxypb.UndropCollectionRequest
+//   - opts ...grpc.CallOption
+func (_e *MockRootCoordClient_Expecter) UndropCollection(ctx interface{}, in interface{}, opts ...interface{}) *MockRootCoordClient_UndropCollection_Call {
+	return &MockRootCoordClient_UndropCollection_Call{Call: _e.mock.On("UndropCollection",
+		append([]interface{}{ctx, in}, opts...)...)}
+}
+
+func (_c *MockRootCoordClient_UndropCollection_Call) Run(run func(ctx context.Context, in *proxypb.UndropCollectionRequest, opts ...grpc.CallOption)) *MockRootCoordClient_UndropCollection_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]grpc.CallOption, len(args)-2)
+		for i, a := range args[2:] {
+			if a != nil {
+				variadicArgs[i] = a.(grpc.CallOption)
+			}
+		}
+		run(args[0].(context.Context), args[1].(*proxypb.UndropCollectionRequest), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *MockRootCoordClient_UndropCollection_Call) Return(_a0 *commonpb.Status, _a1 error) *MockRootCoordClient_UndropCollection_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRootCoordClient_UndropCollection_Call) RunAndReturn(run func(context.Context, *proxypb.UndropCollectionRequest, ...grpc.CallOption) (*commonpb.Status, error)) *MockRootCoordClient_UndropCollection_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UndropPartition provides a mock function with given fields: ctx, in, opts
+func (_m *MockRootCoordClient) UndropPartition(ctx context.Context, in *proxypb.UndropPartitionRequest, opts ...grpc.CallOption) (*commonpb.Status, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UndropPartition")
+	}
+
+	var r0 *commonpb.Status
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *proxypb.UndropPartitionRequest, ...grpc.CallOption) (*commonpb.Status, error)); ok {
+		return rf(ctx, in, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *proxypb.UndropPartitionRequest, ...grpc.CallOption) *commonpb.Status); ok {
+		r0 = rf(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*commonpb.Status)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *proxypb.UndropPartitionRequest, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRootCoordClient_UndropPartition_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UndropPartition'
+type MockRootCoordClient_UndropPartition_Call struct {
+	*mock.Call
+}
+
+// UndropPartition is a helper method to define mock.On call
+//   - ctx context.Context
+//   - in *proxypb.UndropPartitionRequest
+//   - opts ...grpc.CallOption
+func (_e *MockRootCoordClient_Expecter) UndropPartition(ctx interface{}, in interface{}, opts ...interface{}) *MockRootCoordClient_UndropPartition_Call {
+	return &MockRootCoordClient_UndropPartition_Call{Call: _e.mock.On("UndropPartition",
+		append([]interface{}{ctx, in}, opts...)...)}
+}
+
+func (_c *MockRootCoordClient_UndropPartition_Call) Run(run func(ctx context.Context, in *proxypb.UndropPartitionRequest, opts ...grpc.CallOption)) *MockRootCoordClient_UndropPartition_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]grpc.CallOption, len(args)-2)
+		for i, a := range args[2:] {
+			if a != nil {
+				variadicArgs[i] = a.(grpc.CallOption)
+			}
+		}
+		run(args[0].(context.Context), args[1].(*proxypb.UndropPartitionRequest), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *MockRootCoordClient_UndropPartition_Call) Return(_a0 *commonpb.Status, _a1 error) *MockRootCoordClient_UndropPartition_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRootCoordClient_UndropPartition_Call) RunAndReturn(run func(context.Context, *proxypb.UndropPartitionRequest, ...grpc.CallOption) (*commonpb.Status, error)) *MockRootCoordClient_UndropPartition_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // UpdateChannelTimeTick provides a mock function with given fields: ctx, in, opts
 func (_m *MockRootCoordClient) UpdateChannelTimeTick(ctx context.Context, in *internalpb.ChannelTimeTickMsg, opts ...grpc.CallOption) (*commonpb.Status, error) {
 	_va := make([]interface{}, len(opts))
@@ -36,6 +36,8 @@ func FillExpressionValue(expr *planpb.Expr, templateValues map[string]*planpb.Ge
 		return FillExpressionValue(e.BinaryArithExpr.GetRight(), templateValues)
 	case *planpb.Expr_JsonContainsExpr:
 		return FillJSONContainsExpressionValue(e.JsonContainsExpr, templateValues)
+	case *planpb.Expr_CallExpr:
+		return FillCallExpressionValue(e.CallExpr, templateValues)
 	default:
 		return fmt.Errorf("this expression no need to fill placeholder with expr type: %T", e)
 	}
@@ -229,3 +231,26 @@ func FillJSONContainsExpressionValue(expr *planpb.JSONContainsExpr, templateValu
 	}
 	return nil
 }
+
+func FillCallExpressionValue(expr *planpb.CallExpr, templateValues map[string]*planpb.GenericValue) error {
+	for _, param := range expr.GetFunctionParameters() {
+		valueExpr := param.GetValueExpr()
+		if !isTemplateExpr(valueExpr) {
+			if err := FillExpressionValue(param, templateValues); err != nil {
+				return err
+			}
+			continue
+		}
+		value, ok := templateValues[valueExpr.GetTemplateVariableName()]
+		if !ok {
+			return fmt.Errorf("the value of expression template variable name {%s} is not found", valueExpr.GetTemplateVariableName())
+		}
+		valueExpr.Value = value
+		valueExpr.TemplateVariableName = ""
+	}
+
+	if err := checkCallExprParams(expr); err != nil {
+		return fmt.Errorf("invalid template value of function %s: %w", expr.GetFunctionName(), err)
+	}
+	return nil
+}
@@ -496,3 +496,49 @@ func (s *FillExpressionValueSuite) TestBinaryExpression() {
 		}
 	})
 }
+
+func (s *FillExpressionValueSuite) TestCallExpression() {
+	s.Run("normal case", func() {
+		testcases := []testcase{
+			{`lower(VarCharField) == {str}`, map[string]*schemapb.TemplateValue{
+				"str": generateTemplateValue(schemapb.DataType_String, "abc"),
+			}},
+			{`{target} > abs(Int32Field)`, map[string]*schemapb.TemplateValue{
+				"target": generateTemplateValue(schemapb.DataType_Double, 3.5),
+			}},
+			{`substr(VarCharField, {start}, {length}) != "abc"`, map[string]*schemapb.TemplateValue{
+				"start":  generateTemplateValue(schemapb.DataType_Int64, int64(2)),
+				"length": generateTemplateValue(schemapb.DataType_Int64, int64(3)),
+			}},
+			{`date_part({unit}, Int64Field) == 2024`, map[string]*schemapb.TemplateValue{
+				"unit": generateTemplateValue(schemapb.DataType_String, "year"),
+			}},
+		}
+		schemaH := newTestSchemaHelper(s.T())
+		for _, c := range testcases {
+			s.assertValidExpr(schemaH, c.expr, c.values)
+		}
+	})
+
+	s.Run("failed case", func() {
+		testcases := []testcase{
+			{`lower(VarCharField) == {str}`, map[string]*schemapb.TemplateValue{
+				"str": generateTemplateValue(schemapb.DataType_Int64, int64(1)),
+			}},
+			{`Int64Field + Int32Field > {target}`, map[string]*schemapb.TemplateValue{
+				"target": generateTemplateValue(schemapb.DataType_String, "abc"),
+			}},
+			{`substr(VarCharField, {start}) == "abc"`, map[string]*schemapb.TemplateValue{
+				"start": generateTemplateValue(schemapb.DataType_String, "abc"),
+			}},
+			{`date_trunc({unit}, Int64Field) == 0`, map[string]*schemapb.TemplateValue{
+				"unit": generateTemplateValue(schemapb.DataType_String, "dow"),
+			}},
+			{`abs(Int32Field) > {target}`, map[string]*schemapb.TemplateValue{}},
+		}
+		schemaH := newTestSchemaHelper(s.T())
+		for _, c := range testcases {
+			s.assertInvalidExpr(schemaH, c.expr, c.values)
+		}
+	})
+}
@@ -0,0 +1,562 @@
+package planparserv2
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/samber/lo"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/schemapb"
+	"github.com/milvus-io/milvus/internal/proto/planpb"
+	"github.com/milvus-io/milvus/pkg/util/geo"
+	"github.com/milvus-io/milvus/pkg/util/typeutil"
+)
+
+// scalarFunction is a builtin function, e.g. lower(VarCharField). The non-boolean result can be compared
+// with constants, fields or other function calls, the boolean result is used as a predicate.
+type scalarFunction struct {
+	minArgs int
+	maxArgs int
+	// checkArgs checks the arguments and returns the return type of the call.
+	checkArgs func(name string, args []*ExprWithType) (schemapb.DataType, error)
+	// eval folds the call when all the arguments are constants, nil if the call always references a field.
+	eval func(args []*planpb.GenericValue) (*planpb.GenericValue, error)
+}
+
+var scalarFunctions = map[string]*scalarFunction{
+	"lower":      {minArgs: 1, maxArgs: 1, checkArgs: checkStringFunctionArgs, eval: evalLower},
+	"upper":      {minArgs: 1, maxArgs: 1, checkArgs: checkStringFunctionArgs, eval: evalUpper},
+	"substr":     {minArgs: 2, maxArgs: 3, checkArgs: checkSubstrArgs, eval: evalSubstr},
+	"abs":        {minArgs: 1, maxArgs: 1, checkArgs: checkNumericFunctionArgs, eval: evalAbs},
+	"ceil":       {minArgs: 1, maxArgs: 1, checkArgs: checkNumericFunctionArgs, eval: evalCeil},
+	"floor":      {minArgs: 1, maxArgs: 1, checkArgs: checkNumericFunctionArgs, eval: evalFloor},
+	"date_trunc": {minArgs: 2, maxArgs: 2, checkArgs: checkDateTruncArgs, eval: evalDateTrunc},
+	"date_part":  {minArgs: 2, maxArgs: 2, checkArgs: checkDatePartArgs, eval: evalDatePart},
+	// the geometry functions take a Geometry field and a constant wkt, the distance is in meters.
+	"st_distance": {minArgs: 2, maxArgs: 2, checkArgs: checkGeoDistanceArgs},
+	"st_within":   {minArgs: 2, maxArgs: 2, checkArgs: checkGeoWithinArgs},
+}
+
+// operatorFunctions are the functions that arithmetic between fields and comparisons on function results
+// are lowered to, they are not exposed to users directly.
+var operatorFunctions = map[string]func(name string, args []*ExprWithType) (schemapb.DataType, error){
+	"add": checkArithmeticArgs,
+	"sub": checkArithmeticArgs,
+	"mul": checkArithmeticArgs,
+	"div": checkArithmeticArgs,
+	"mod": checkArithmeticArgs,
+	"eq":  checkCompareArgs,
+	"ne":  checkCompareArgs,
+	"lt":  checkCompareArgs,
+	"le":  checkCompareArgs,
+	"gt":  checkCompareArgs,
+	"ge":  checkCompareArgs,
+}
+
+var arithFunctionNames = map[planpb.ArithOpType]string{
+	planpb.ArithOpType_Add: "add",
+	planpb.ArithOpType_Sub: "sub",
+	planpb.ArithOpType_Mul: "mul",
+	planpb.ArithOpType_Div: "div",
+	planpb.ArithOpType_Mod: "mod",
+}
+
+var cmpFunctionNames = map[planpb.OpType]string{
+	planpb.OpType_Equal:        "eq",
+	planpb.OpType_NotEqual:     "ne",
+	planpb.OpType_LessThan:     "lt",
+	planpb.OpType_LessEqual:    "le",
+	planpb.OpType_GreaterThan:  "gt",
+	planpb.OpType_GreaterEqual: "ge",
+}
+
+var (
+	dateTruncUnits = []string{"year", "month", "day", "hour", "minute", "second"}
+	datePartUnits  = []string{"year", "month", "day", "hour", "minute", "second", "dow", "doy"}
+)
+
+func isTemplateArg(arg *ExprWithType) bool {
+	return isTemplateExpr(arg.expr.GetValueExpr())
+}
+
+// isScalarCallExpr returns true if expr is a call which produces a non-boolean value.
+func isScalarCallExpr(expr *ExprWithType) bool {
+	return expr != nil && expr.expr.GetCallExpr() != nil &&
+		expr.expr.GetCallExpr().GetReturnType() != schemapb.DataType_None &&
+		expr.expr.GetCallExpr().GetReturnType() != schemapb.DataType_Bool
+}
+
+func isColumnValueArith(left, right *ExprWithType) bool {
+	return (left.expr.GetColumnExpr() != nil && right.expr.GetValueExpr() != nil) ||
+		(left.expr.GetValueExpr() != nil && right.expr.GetColumnExpr() != nil)
+}
+
+func checkArgNotTemplate(name string, pos int, arg *ExprWithType) error {
+	if isTemplateArg(arg) {
+		return fmt.Errorf("placeholder is not supported as argument %d of function %s", pos+1, name)
+	}
+	return nil
+}
+
+func checkStringArg(name string, pos int, arg *ExprWithType) error {
+	if err := checkArgNotTemplate(name, pos, arg); err != nil {
+		return err
+	}
+	if !typeutil.IsStringType(arg.dataType) || len(toColumnInfo(arg).GetNestedPath()) != 0 {
+		return fmt.Errorf("function %s expects a string as argument %d, but got %s", name, pos+1, getDataType(arg))
+	}
+	return nil
+}
+
+func checkNumericArg(name string, pos int, arg *ExprWithType) error {
+	if err := checkArgNotTemplate(name, pos, arg); err != nil {
+		return err
+	}
+	if !typeutil.IsArithmetic(arg.dataType) || len(toColumnInfo(arg).GetNestedPath()) != 0 {
+		return fmt.Errorf("function %s expects a number as argument %d, but got %s", name, pos+1, getDataType(arg))
+	}
+	return nil
+}
+
+// checkConstantArg checks that the argument is a constant of the expected type,
+// template arguments are checked after the template values are filled.
+func checkConstantArg(name string, pos int, arg *ExprWithType, expected schemapb.DataType) error {
+	valueExpr := arg.expr.GetValueExpr()
+	if valueExpr == nil {
+		return fmt.Errorf("function %s expects a constant as argument %d", name, pos+1)
+	}
+	if isTemplateExpr(valueExpr) && valueExpr.GetValue() == nil {
+		return nil
+	}
+	if (typeutil.IsStringType(expected) && !IsString(valueExpr.GetValue())) ||
+		(typeutil.IsIntegerType(expected) && !IsInteger(valueExpr.GetValue())) {
+		return fmt.Errorf("function %s expects a constant %s as argument %d, but got %s",
+			name, expected, pos+1, valueExpr.GetValue())
+	}
+	return nil
+}
+
+func checkStringFunctionArgs(name string, args []*ExprWithType) (schemapb.DataType, error) {
+	if err := checkStringArg(name, 0, args[0]); err != nil {
+		return schemapb.DataType_None, err
+	}
+	return schemapb.DataType_VarChar, nil
+}
+
+func checkSubstrArgs(name string, args []*ExprWithType) (schemapb.DataType, error) {
+	if err := checkStringArg(name, 0, args[0]); err != nil {
+		return schemapb.DataType_None, err
+	}
+	for i := 1; i < len(args); i++ {
+		if err := checkConstantArg(name, i, args[i], schemapb.DataType_Int64); err != nil {
+			return schemapb.DataType_None, err
+		}
+	}
+	if len(args) == 3 {
+		if length := args[2].expr.GetValueExpr().GetValue(); length != nil && length.GetInt64Val() < 0 {
+			return schemapb.DataType_None, fmt.Errorf("function %s expects a non-negative length, but got %d", name, length.GetInt64Val())
+		}
+	}
+	return schemapb.DataType_VarChar, nil
+}
+
+func checkNumericFunctionArgs(name string, args []*ExprWithType) (schemapb.DataType, error) {
+	if err := checkNumericArg(name, 0, args[0]); err != nil {
+		return schemapb.DataType_None, err
+	}
+	if typeutil.IsIntegerType(args[0].dataType) {
+		return schemapb.DataType_Int64, nil
+	}
+	return schemapb.DataType_Double, nil
+}
+
+func checkDateArgs(name string, args []*ExprWithType, units []string) (schemapb.DataType, error) {
+	if err := checkConstantArg(name, 0, args[0], schemapb.DataType_VarChar); err != nil {
+		return schemapb.DataType_None, err
+	}
+	if unit := args[0].expr.GetValueExpr().GetValue(); unit != nil {
+		if !lo.Contains(units, unit.GetStringVal()) {
+			return schemapb.DataType_None, fmt.Errorf("function %s expects one of %v as unit, but got %s",
+				name, units, unit.GetStringVal())
+		}
+	}
+	if err := checkNumericArg(name, 1, args[1]); err != nil {
+		return schemapb.DataType_None, err
+	}
+	if !typeutil.IsIntegerType(args[1].dataType) {
+		return schemapb.DataType_None, fmt.Errorf("function %s expects an integer unix timestamp in seconds as argument 2, but got %s",
+			name, getDataType(args[1]))
+	}
+	return schemapb.DataType_Int64, nil
+}
+
+func checkDateTruncArgs(name string, args []*ExprWithType) (schemapb.DataType, error) {
+	return checkDateArgs(name, args, dateTruncUnits)
+}
+
+func checkDatePartArgs(name string, args []*ExprWithType) (schemapb.DataType, error) {
+	return checkDateArgs(name, args, datePartUnits)
+}
+
+func checkGeoArgs(name string, args []*ExprWithType) error {
+	if err := checkArgNotTemplate(name, 0, args[0]); err != nil {
+		return err
+	}
+	if args[0].expr.GetColumnExpr() == nil || !typeutil.IsGeometryType(args[0].dataType) {
+		return fmt.Errorf("function %s expects a geometry field as argument 1, but got %s", name, getDataType(args[0]))
+	}
+	if err := checkConstantArg(name, 1, args[1], schemapb.DataType_VarChar); err != nil {
+		return err
+	}
+	if wkt := args[1].expr.GetValueExpr().GetValue(); wkt != nil {
+		if _, err := geo.ParseWKT(wkt.GetStringVal()); err != nil {
+			return fmt.Errorf("function %s expects a wkt as argument 2: %w", name, err)
+		}
+	}
+	return nil
+}
+
+func checkGeoDistanceArgs(name string, args []*ExprWithType) (schemapb.DataType, error) {
+	if err := checkGeoArgs(name, args); err != nil {
+		return schemapb.DataType_None, err
+	}
+	return schemapb.DataType_Double, nil
+}
+
+func checkGeoWithinArgs(name string, args []*ExprWithType) (schemapb.DataType, error) {
+	if err := checkGeoArgs(name, args); err != nil {
+		return schemapb.DataType_None, err
+	}
+	if wkt := args[1].expr.GetValueExpr().GetValue(); wkt != nil {
+		if g, _ := geo.ParseWKT(wkt.GetStringVal()); g.Type != geo.TypePolygon {
+			return schemapb.DataType_None, fmt.Errorf("function %s expects a polygon as argument 2, but got %s", name, g.Type)
+		}
+	}
+	return schemapb.DataType_Bool, nil
+}
+
+func checkArithmeticArgs(name string, args []*ExprWithType) (schemapb.DataType, error) {
+	if len(args) != 2 {
+		return schemapb.DataType_None, fmt.Errorf("'%s' expects 2 operands, but got %d", name, len(args))
+	}
+	for i, arg := range args {
+		if err := checkNumericArg(name, i, arg); err != nil {
+			return schemapb.DataType_None, err
+		}
+	}
+	bothInteger := typeutil.IsIntegerType(args[0].dataType) && typeutil.IsIntegerType(args[1].dataType)
+	if name == arithFunctionNames[planpb.ArithOpType_Mod] && !bothInteger {
+		return schemapb.DataType_None, fmt.Errorf("modulo can only apply on integer types")
+	}
+	if bothInteger {
+		return schemapb.DataType_Int64, nil
+	}
+	return schemapb.DataType_Double, nil
+}
+
+func checkCompareArgs(name string, args []*ExprWithType) (schemapb.DataType, error) {
+	if len(args) != 2 {
+		return schemapb.DataType_None, fmt.Errorf("'%s' expects 2 operands, but got %d", name, len(args))
+	}
+	left, right := args[0], args[1]
+	// the template value will be checked after filled.
+	if isTemplateArg(left) || isTemplateArg(right) {
+		return schemapb.DataType_Bool, nil
+	}
+	for _, arg := range args {
+		if len(toColumnInfo(arg).GetNestedPath()) != 0 ||
+			(!typeutil.IsArithmetic(arg.dataType) && !typeutil.IsStringType(arg.dataType)) {
+			return schemapb.DataType_None, fmt.Errorf("comparisons between %s and %s are not supported",
+				getDataType(left), getDataType(right))
+		}
+	}
+	if typeutil.IsStringType(left.dataType) != typeutil.IsStringType(right.dataType) {
+		return schemapb.DataType_None, fmt.Errorf("comparisons between %s and %s are not supported",
+			getDataType(left), getDataType(right))
+	}
+	return schemapb.DataType_Bool, nil
+}
+
+func newCallExpr(name string, args []*ExprWithType, returnType schemapb.DataType) *ExprWithType {
+	params := make([]*planpb.Expr, 0, len(args))
+	isTemplate := false
+	for _, arg := range args {
+		params = append(params, arg.expr)
+		isTemplate = isTemplate || arg.expr.GetIsTemplate()
+	}
+	return &ExprWithType{
+		expr: &planpb.Expr{
+			Expr: &planpb.Expr_CallExpr{
+				CallExpr: &planpb.CallExpr{
+					FunctionName:       name,
+					FunctionParameters: params,
+					ReturnType:         returnType,
+				},
+			},
+			IsTemplate: isTemplate,
+		},
+		dataType: returnType,
+		// only the comparison result can be used as a predicate.
+		nodeDependent: returnType != schemapb.DataType_Bool,
+	}
+}
+
+// buildScalarFunctionCall checks the arguments of a builtin function and folds it if possible.
+func buildScalarFunctionCall(name string, function *scalarFunction, args []*ExprWithType) (*ExprWithType, error) {
+	if len(args) < function.minArgs || len(args) > function.maxArgs {
+		if function.minArgs == function.maxArgs {
+			return nil, fmt.Errorf("function %s expects %d arguments, but got %d", name, function.minArgs, len(args))
+		}
+		return nil, fmt.Errorf("function %s expects %d to %d arguments, but got %d", name, function.minArgs, function.maxArgs, len(args))
+	}
+	returnType, err := function.checkArgs(name, args)
+	if err != nil {
+		return nil, err
+	}
+	if function.eval == nil {
+		return newCallExpr(name, args, returnType), nil
+	}
+
+	values := make([]*planpb.GenericValue, 0, len(args))
+	for _, arg := range args {
+		valueExpr := arg.expr.GetValueExpr()
+		if valueExpr == nil || isTemplateExpr(valueExpr) {
+			return newCallExpr(name, args, returnType), nil
+		}
+		values = append(values, valueExpr.GetValue())
+	}
+	value, err := function.eval(values)
+	if err != nil {
+		return nil, err
+	}
+	ret := toValueExpr(value)
+	ret.nodeDependent = true
+	return ret, nil
+}
+
+// toScalarOperand converts the expression to an operand of the operator functions.
+func toScalarOperand(expr *ExprWithType) (*ExprWithType, error) {
+	switch e := expr.expr.GetExpr().(type) {
+	case *planpb.Expr_ColumnExpr, *planpb.Expr_ValueExpr:
+		return expr, nil
+	case *planpb.Expr_CallExpr:
+		if !isScalarCallExpr(expr) {
+			return nil, fmt.Errorf("function %s can not be used as an operand", e.CallExpr.GetFunctionName())
+		}
+		return expr, nil
+	case *planpb.Expr_BinaryArithExpr:
+		name, ok := arithFunctionNames[e.BinaryArithExpr.GetOp()]
+		if !ok {
+			return nil, fmt.Errorf("%s can not be used with other arithmetic operations", e.BinaryArithExpr.GetOp())
+		}
+		left, right := e.BinaryArithExpr.GetLeft(), e.BinaryArithExpr.GetRight()
+		args := make([]*ExprWithType, 0, 2)
+		for _, operand := range []*planpb.Expr{left, right} {
+			var arg *ExprWithType
+			if operand.GetColumnExpr() != nil {
+				arg = toColumnExpr(operand.GetColumnExpr().GetInfo())
+			} else if valueExpr := operand.GetValueExpr(); valueExpr != nil && !isTemplateExpr(valueExpr) {
+				arg = toValueExpr(valueExpr.GetValue())
+			} else {
+				return nil, fmt.Errorf("placeholder is not supported in arithmetic operations between multiple fields")
+			}
+			args = append(args, arg)
+		}
+		return buildOperatorCall(name, args)
+	default:
+		return nil, fmt.Errorf("unsupported operand of arithmetic or comparison operations: %T", e)
+	}
+}
+
+func buildOperatorCall(name string, operands []*ExprWithType) (*ExprWithType, error) {
+	args := make([]*ExprWithType, 0, len(operands))
+	for _, operand := range operands {
+		arg, err := toScalarOperand(operand)
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, arg)
+	}
+	returnType, err := operatorFunctions[name](name, args)
+	if err != nil {
+		return nil, err
+	}
+	return newCallExpr(name, args, returnType), nil
+}
+
+// buildArithmeticCall lowers arithmetic operations which can not be evaluated as BinaryArithOpEvalRangeExpr,
+// e.g. Int64Field + Int32Field, into calls.
+func buildArithmeticCall(op planpb.ArithOpType, left, right *ExprWithType) (*ExprWithType, error) {
+	if isTemplateArg(left) || isTemplateArg(right) {
+		return nil, fmt.Errorf("placeholder is not supported in arithmetic operations between multiple fields")
+	}
+	return buildOperatorCall(arithFunctionNames[op], []*ExprWithType{left, right})
+}
+
+// buildCompareCall lowers comparisons on function results into calls.
+func buildCompareCall(op planpb.OpType, left, right *ExprWithType) (*planpb.Expr, error) {
+	name, ok := cmpFunctionNames[op]
+	if !ok {
+		return nil, fmt.Errorf("unsupported op type: %s", op)
+	}
+	ret, err := buildOperatorCall(name, []*ExprWithType{left, right})
+	if err != nil {
+		return nil, err
+	}
+	return ret.expr, nil
+}
+
+// callParamsWithType restores the types of call parameters from the plan.
+func callParamsWithType(expr *planpb.CallExpr) []*ExprWithType {
+	args := make([]*ExprWithType, 0, len(expr.GetFunctionParameters()))
+	for _, param := range expr.GetFunctionParameters() {
+		var arg *ExprWithType
+		switch e := param.GetExpr().(type) {
+		case *planpb.Expr_ColumnExpr:
+			arg = toColumnExpr(e.ColumnExpr.GetInfo())
+		case *planpb.Expr_ValueExpr:
+			arg = toValueExpr(e.ValueExpr.GetValue())
+		case *planpb.Expr_CallExpr:
+			arg = &ExprWithType{dataType: e.CallExpr.GetReturnType()}
+		}
+		if arg == nil {
+			arg = &ExprWithType{}
+		}
+		arg.expr = param
+		args = append(args, arg)
+	}
+	return args
+}
+
+// checkCallExprParams checks the parameters of a builtin call again after the template values are filled.
+func checkCallExprParams(expr *planpb.CallExpr) error {
+	if expr.GetReturnType() == schemapb.DataType_None {
+		return nil
+	}
+	args := callParamsWithType(expr)
+	name := expr.GetFunctionName()
+	var err error
+	if function, ok := scalarFunctions[name]; ok {
+		_, err = function.checkArgs(name, args)
+	} else if checkArgs, ok := operatorFunctions[name]; ok {
+		_, err = checkArgs(name, args)
+	}
+	return err
+}
+
+func toASCIILower(s string) string {
+	return strings.Map(func(r rune) rune {
+		if r >= 'A' && r <= 'Z' {
+			return r - 'A' + 'a'
+		}
+		return r
+	}, s)
+}
+
+func toASCIIUpper(s string) string {
+	return strings.Map(func(r rune) rune {
+		if r >= 'a' && r <= 'z' {
+			return r - 'a' + 'A'
+		}
+		return r
+	}, s)
+}
+
+func evalLower(args []*planpb.GenericValue) (*planpb.GenericValue, error) {
+	return NewString(toASCIILower(args[0].GetStringVal())), nil
+}
+
+func evalUpper(args []*planpb.GenericValue) (*planpb.GenericValue, error) {
+	return NewString(toASCIIUpper(args[0].GetStringVal())), nil
+}
+
+// evalSubstr follows the sql semantics, positions are counted from 1 and
+// the window [start, start + length) is clipped to the string.
+func evalSubstr(args []*planpb.GenericValue) (*planpb.GenericValue, error) {
+	runes := []rune(args[0].GetStringVal())
+	start := args[1].GetInt64Val()
+	end := int64(math.MaxInt64)
+	if len(args) == 3 {
+		end = start + args[2].GetInt64Val()
+	}
+	begin := max(start, 1)
+	end = min(end, int64(len(runes))+1)
+	if end <= begin {
+		return NewString(""), nil
+	}
+	return NewString(string(runes[begin-1 : end-1])), nil
+}
+
+func evalUnaryNumeric(arg *planpb.GenericValue, intFunc func(int64) int64, floatFunc func(float64) float64) (*planpb.GenericValue, error) {
+	if IsInteger(arg) {
+		return NewInt(intFunc(arg.GetInt64Val())), nil
+	}
+	return NewFloat(floatFunc(arg.GetFloatVal())), nil
+}
+
+func evalAbs(args []*planpb.GenericValue) (*planpb.GenericValue, error) {
+	return evalUnaryNumeric(args[0], func(v int64) int64 {
+		if v < 0 {
+			return -v
+		}
+		return v
+	}, math.Abs)
+}
+
+func evalCeil(args []*planpb.GenericValue) (*planpb.GenericValue, error) {
+	return evalUnaryNumeric(args[0], func(v int64) int64 { return v }, math.Ceil)
+}
+
+func evalFloor(args []*planpb.GenericValue) (*planpb.GenericValue, error) {
+	return evalUnaryNumeric(args[0], func(v int64) int64 { return v }, math.Floor)
+}
+
+func evalDateTrunc(args []*planpb.GenericValue) (*planpb.GenericValue, error) {
+	t := time.Unix(args[1].GetInt64Val(), 0).UTC()
+	var truncated time.Time
+	switch args[0].GetStringVal() {
+	case "year":
+		truncated = time.Date(t.Year(), time.January, 1, 0, 0, 0, 0, time.UTC)
+	case "month":
+		truncated = time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+	case "day":
+		truncated = time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	case "hour":
+		truncated = t.Truncate(time.Hour)
+	case "minute":
+		truncated = t.Truncate(time.Minute)
+	case "second":
+		truncated = t
+	default:
+		return nil, fmt.Errorf("unsupported unit of date_trunc: %s", args[0].GetStringVal())
+	}
+	return NewInt(truncated.Unix()), nil
+}
+
+func evalDatePart(args []*planpb.GenericValue) (*planpb.GenericValue, error) {
+	t := time.Unix(args[1].GetInt64Val(), 0).UTC()
+	var part int
+	switch args[0].GetStringVal() {
+	case "year":
+		part = t.Year()
+	case "month":
+		part = int(t.Month())
+	case "day":
+		part = t.Day()
+	case "hour":
+		part = t.Hour()
+	case "minute":
+		part = t.Minute()
+	case "second":
+		part = t.Second()
+	case "dow":
+		part = int(t.Weekday())
+	case "doy":
+		part = t.YearDay()
+	default:
+		return nil, fmt.Errorf("unsupported unit of date_part: %s", args[0].GetStringVal())
+	}
+	return NewInt(int64(part)), nil
+}
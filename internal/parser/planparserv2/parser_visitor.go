@@ -195,6 +195,15 @@ func (v *ParserVisitor) VisitAddSub(ctx *parser.AddSubContext) interface{} {
 		return fmt.Errorf("invalid arithmetic expression, left: %s, op: %s, right: %s", ctx.Expr(0).GetText(), ctx.GetOp(), ctx.Expr(1).GetText())
 	}
 
+	if !isColumnValueArith(leftExpr, rightExpr) {
+		// a + b, lower(a) + 1, (a + 1) * b
+		expr, err := buildArithmeticCall(arithExprMap[ctx.GetOp().GetTokenType()], leftExpr, rightExpr)
+		if err != nil {
+			return err
+		}
+		return expr
+	}
+
 	if err = checkDirectComparisonBinaryField(toColumnInfo(leftExpr)); err != nil {
 		return err
 	}
@@ -284,6 +293,15 @@ func (v *ParserVisitor) VisitMulDivMod(ctx *parser.MulDivModContext) interface{}
 		return fmt.Errorf("invalid arithmetic expression, left: %s, op: %s, right: %s", ctx.Expr(0).GetText(), ctx.GetOp(), ctx.Expr(1).GetText())
 	}
 
+	if !isColumnValueArith(leftExpr, rightExpr) {
+		// a + b, lower(a) + 1, (a + 1) * b
+		expr, err := buildArithmeticCall(arithExprMap[ctx.GetOp().GetTokenType()], leftExpr, rightExpr)
+		if err != nil {
+			return err
+		}
+		return expr
+	}
+
 	if err := checkDirectComparisonBinaryField(toColumnInfo(leftExpr)); err != nil {
 		return err
 	}
@@ -606,10 +624,28 @@ func (v *ParserVisitor) VisitCall(ctx *parser.CallContext) interface{} {
 	functionName := strings.ToLower(ctx.Identifier().GetText())
 	numParams := len(ctx.AllExpr())
 	funcParameters := make([]*planpb.Expr, 0, numParams)
+	args := make([]*ExprWithType, 0, numParams)
 	for _, param := range ctx.AllExpr() {
-		paramExpr := getExpr(param.Accept(v))
+		ret := param.Accept(v)
+		if err := getError(ret); err != nil {
+			return err
+		}
+		paramExpr := getExpr(ret)
+		if paramExpr == nil {
+			return fmt.Errorf("invalid parameter of function %s: %s", functionName, param.GetText())
+		}
 		funcParameters = append(funcParameters, paramExpr.expr)
+		args = append(args, paramExpr)
 	}
+
+	if function, ok := scalarFunctions[functionName]; ok {
+		expr, err := buildScalarFunctionCall(functionName, function, args)
+		if err != nil {
+			return err
+		}
+		return expr
+	}
+
 	return &ExprWithType{
 		expr: &planpb.Expr{
 			Expr: &planpb.Expr_CallExpr{
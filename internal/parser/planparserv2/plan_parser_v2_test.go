@@ -141,6 +141,148 @@ func TestExpr_Call(t *testing.T) {
 	assert.Equal(t, int64(20), expr.GetCallExpr().GetFunctionParameters()[2].GetCallExpr().GetFunctionParameters()[0].GetValueExpr().GetValue().GetInt64Val())
 }
 
+func TestExpr_ScalarFunctions(t *testing.T) {
+	schema := newTestSchema()
+	helper, err := typeutil.CreateSchemaHelper(schema)
+	assert.NoError(t, err)
+
+	exprStrs := []string{
+		`lower(VarCharField) == "abc"`,
+		`"ABC" != upper(VarCharField)`,
+		`substr(VarCharField, 2) == "bc"`,
+		`substr(VarCharField, 1, 2) >= lower(StringField)`,
+		`abs(Int32Field) < 10`,
+		`ceil(FloatField) == 3`,
+		`floor(DoubleField) > Int64Field`,
+		`date_trunc("day", Int64Field) == 1700000000`,
+		`date_part("year", Int64Field) == 2024 && date_part("dow", Int64Field) != 0`,
+		`Int64Field + Int32Field > Int16Field`,
+		`Int8Field * Int16Field <= 100.5`,
+		`(Int64Field + 1) * Int32Field == 10`,
+		`Int64Field % Int32Field == 1`,
+		`abs(Int64Field - Int32Field) < 3`,
+		`not (Int64Field - Int32Field > 0)`,
+	}
+	for _, exprStr := range exprStrs {
+		assertValidExpr(t, helper, exprStr)
+	}
+
+	expr, err := ParseExpr(helper, `lower(VarCharField) == "abc"`, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "eq", expr.GetCallExpr().GetFunctionName())
+	assert.Equal(t, schemapb.DataType_Bool, expr.GetCallExpr().GetReturnType())
+	lower := expr.GetCallExpr().GetFunctionParameters()[0].GetCallExpr()
+	assert.Equal(t, "lower", lower.GetFunctionName())
+	assert.Equal(t, schemapb.DataType_VarChar, lower.GetReturnType())
+
+	expr, err = ParseExpr(helper, `Int64Field + Int32Field > FloatField`, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "gt", expr.GetCallExpr().GetFunctionName())
+	add := expr.GetCallExpr().GetFunctionParameters()[0].GetCallExpr()
+	assert.Equal(t, "add", add.GetFunctionName())
+	assert.Equal(t, schemapb.DataType_Int64, add.GetReturnType())
+
+	// column with constant is still evaluated as BinaryArithOpEvalRangeExpr.
+	expr, err = ParseExpr(helper, `Int64Field + 1 > 3`, nil)
+	assert.NoError(t, err)
+	assert.NotNil(t, expr.GetBinaryArithOpEvalRangeExpr())
+
+	// constant arguments are folded.
+	expr, err = ParseExpr(helper, `VarCharField == lower("ABC")`, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "abc", expr.GetUnaryRangeExpr().GetValue().GetStringVal())
+	expr, err = ParseExpr(helper, `Int64Field > date_trunc("month", 1700000000)`, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1698796800), expr.GetUnaryRangeExpr().GetValue().GetInt64Val())
+	expr, err = ParseExpr(helper, `VarCharField == substr("héllo", 2, 3)`, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "éll", expr.GetUnaryRangeExpr().GetValue().GetStringVal())
+
+	invalidExprs := []string{
+		`lower(VarCharField)`,
+		`lower(Int64Field) == "abc"`,
+		`lower(VarCharField) == 1`,
+		`lower(VarCharField, StringField) == "abc"`,
+		`lower(JSONField["A"]) == "abc"`,
+		`abs(VarCharField) > 1`,
+		`abs(BoolField) > 1`,
+		`abs(ArrayField) > 1`,
+		`substr(VarCharField, Int64Field) == "a"`,
+		`substr(VarCharField, 1, -1) == "a"`,
+		`substr(VarCharField, "1") == "a"`,
+		`date_trunc("week", Int64Field) == 0`,
+		`date_trunc(VarCharField, Int64Field) == 0`,
+		`date_part("year", FloatField) == 2024`,
+		`Int64Field + VarCharField > 1`,
+		`Int64Field + JSONField["A"] > 1`,
+		`FloatField % Int64Field == 1`,
+		`Int64Field + Int32Field`,
+		`Int64Field + Int32Field > "abc"`,
+		`Int64Field * {x} + Int32Field > 1`,
+		`array_length(ArrayField) + Int64Field == 1`,
+	}
+	for _, exprStr := range invalidExprs {
+		assertInvalidExpr(t, helper, exprStr)
+	}
+}
+
+func TestExpr_GeoFunctions(t *testing.T) {
+	schema := newTestSchema()
+	helper, err := typeutil.CreateSchemaHelper(schema)
+	assert.NoError(t, err)
+
+	exprStrs := []string{
+		`st_distance(GeometryField, "POINT(116.4 39.9)") < 1000`,
+		`st_distance(GeometryField, 'LINESTRING(0 0, 1 1)') <= Int64Field`,
+		`st_within(GeometryField, "POLYGON((0 0, 1 0, 1 1, 0 1, 0 0))")`,
+		`not st_within(GeometryField, "POLYGON((0 0, 1 0, 1 1, 0 0))") and Int64Field > 1`,
+	}
+	for _, exprStr := range exprStrs {
+		assertValidExpr(t, helper, exprStr)
+	}
+
+	expr, err := ParseExpr(helper, `st_distance(GeometryField, "POINT(1 2)") < 1000`, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "lt", expr.GetCallExpr().GetFunctionName())
+	distance := expr.GetCallExpr().GetFunctionParameters()[0].GetCallExpr()
+	assert.Equal(t, "st_distance", distance.GetFunctionName())
+	assert.Equal(t, schemapb.DataType_Double, distance.GetReturnType())
+	assert.Equal(t, "POINT(1 2)", distance.GetFunctionParameters()[1].GetValueExpr().GetValue().GetStringVal())
+
+	expr, err = ParseExpr(helper, `st_within(GeometryField, "POLYGON((0 0, 1 0, 1 1, 0 0))")`, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "st_within", expr.GetCallExpr().GetFunctionName())
+	assert.Equal(t, schemapb.DataType_Bool, expr.GetCallExpr().GetReturnType())
+
+	expr, err = ParseExpr(helper, `st_within(GeometryField, {polygon})`, map[string]*schemapb.TemplateValue{
+		"polygon": generateTemplateValue(schemapb.DataType_VarChar, "POLYGON((0 0, 1 0, 1 1, 0 0))"),
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "POLYGON((0 0, 1 0, 1 1, 0 0))", expr.GetCallExpr().GetFunctionParameters()[1].GetValueExpr().GetValue().GetStringVal())
+	_, err = ParseExpr(helper, `st_within(GeometryField, {polygon})`, map[string]*schemapb.TemplateValue{
+		"polygon": generateTemplateValue(schemapb.DataType_VarChar, "POINT(1 2)"),
+	})
+	assert.Error(t, err)
+
+	invalidExprs := []string{
+		`st_distance(GeometryField, "POINT(1 2)")`,
+		`st_distance(GeometryField, "POINT(1 2)") < "a"`,
+		`st_distance(GeometryField, "POINT(200 2)") < 1`,
+		`st_distance(GeometryField, "CIRCLE(1 2)") < 1`,
+		`st_distance(GeometryField, 1) < 1`,
+		`st_distance(GeometryField, VarCharField) < 1`,
+		`st_distance(VarCharField, "POINT(1 2)") < 1`,
+		`st_distance("POINT(1 2)", "POINT(1 2)") < 1`,
+		`st_distance(GeometryField) < 1`,
+		`st_within(GeometryField, "POINT(1 2)")`,
+		`st_within(GeometryField, "POLYGON((0 0, 1 0, 1 1, 0 0))") == true`,
+		`GeometryField == "POINT(1 2)"`,
+	}
+	for _, exprStr := range invalidExprs {
+		assertInvalidExpr(t, helper, exprStr)
+	}
+}
+
 func TestExpr_Compare(t *testing.T) {
 	schema := newTestSchema()
 	helper, err := typeutil.CreateSchemaHelper(schema)
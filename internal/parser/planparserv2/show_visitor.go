@@ -3,6 +3,7 @@ package planparserv2
 import (
 	"go.uber.org/zap"
 
+	"github.com/milvus-io/milvus-proto/go-api/v2/schemapb"
 	"github.com/milvus-io/milvus/internal/json"
 	"github.com/milvus-io/milvus/internal/proto/planpb"
 	"github.com/milvus-io/milvus/pkg/log"
@@ -106,6 +107,9 @@ func (v *ShowExprVisitor) VisitCallExpr(expr *planpb.CallExpr) interface{} {
 		params = append(params, v.VisitExpr(p))
 	}
 	js["func_parameters"] = params
+	if expr.GetReturnType() != schemapb.DataType_None {
+		js["return_type"] = expr.GetReturnType().String()
+	}
 	return js
 }
 
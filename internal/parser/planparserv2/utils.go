@@ -468,6 +468,9 @@ func HandleCompare(op int, left, right *ExprWithType) (*planpb.Expr, error) {
 	}
 
 	cmpOp := cmpOpMap[op]
+	if isScalarCallExpr(left) || isScalarCallExpr(right) {
+		return buildCompareCall(cmpOp, left, right)
+	}
 	if valueExpr := left.expr.GetValueExpr(); valueExpr != nil {
 		op, err := reverseOrder(cmpOp)
 		if err != nil {
@@ -30,7 +30,9 @@ import (
 	"google.golang.org/grpc/peer"
 	"google.golang.org/grpc/status"
 
+	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
 	"github.com/milvus-io/milvus-proto/go-api/v2/milvuspb"
+	"github.com/milvus-io/milvus/internal/json"
 	"github.com/milvus-io/milvus/internal/proxy/accesslog/info"
 	"github.com/milvus-io/milvus/pkg/tracer"
 	"github.com/milvus-io/milvus/pkg/util"
@@ -181,10 +183,70 @@ func (s *LogFormatterSuite) TestFormatMethodResult() {
 	}
 }
 
+func (s *LogFormatterSuite) TestFormatJSON() {
+	formatter := NewJSONFormatter()
+
+	i := info.NewGrpcAccessInfo(s.ctx, s.serverinfo, &milvuspb.SearchRequest{
+		DbName:           "test-db",
+		CollectionName:   "test-collection",
+		Dsl:              "pk > 10",
+		Nq:               2,
+		ConsistencyLevel: commonpb.ConsistencyLevel_Bounded,
+		SearchParams: []*commonpb.KeyValuePair{
+			{Key: "topk", Value: "10"},
+		},
+	})
+	i.UpdateCtx(s.ctx)
+	i.SetResult(&milvuspb.SearchResults{Status: merr.Status(nil)}, nil)
+
+	fs := formatter.Format(i)
+	s.True(strings.HasSuffix(fs, "\n"))
+
+	record := &JSONRecord{}
+	s.Require().NoError(json.Unmarshal([]byte(fs), record))
+	s.Equal("test", record.Method)
+	s.Equal("Successful", record.Status)
+	s.Equal(0, record.ErrorCode)
+	s.Equal("mockUser", record.User)
+	s.Equal("test-db", record.Database)
+	s.Equal("test-collection", record.Collection)
+	s.Equal("pk > 10", record.Expression)
+	s.Equal(int64(2), record.NQ)
+	s.Equal(int64(10), record.TopK)
+	s.Equal(commonpb.ConsistencyLevel_Bounded.String(), record.ConsistencyLevel)
+	s.GreaterOrEqual(record.LatencyMs, float64(0))
+
+	// unknown values are omitted
+	i = info.NewGrpcAccessInfo(context.Background(), &grpc.UnaryServerInfo{}, nil)
+	fs = formatter.Format(i)
+	s.False(strings.Contains(fs, info.Unknown))
+	s.False(strings.Contains(fs, "collection"))
+}
+
+func (s *LogFormatterSuite) TestFormatterManager() {
+	manager := NewFormatterManger()
+	manager.Add(BaseFormatterKey, "$method_name")
+	manager.AddFormatter("json", NewJSONFormatter())
+	manager.SetMethod("json", "Search")
+
+	formatter, ok := manager.GetByMethod("Search")
+	s.True(ok)
+	s.IsType(&JSONFormatter{}, formatter)
+
+	formatter, ok = manager.GetByMethod("Query")
+	s.True(ok)
+	s.IsType(&Formatter{}, formatter)
+}
+
 func (s *LogFormatterSuite) TestParseConfigKeyFailed() {
 	configKey := ".testf.invalidSub"
 	_, _, err := parseConfigKey(configKey)
 	s.Error(err)
+
+	name, option, err := parseConfigKey("testf.type")
+	s.NoError(err)
+	s.Equal("testf", name)
+	s.Equal(typeKey, option)
 }
 
 func TestLogFormatter(t *testing.T) {
@@ -27,20 +27,31 @@ import (
 const (
 	fomaterkey = "format"
 	methodKey  = "methods"
+	typeKey    = "type"
+)
+
+const (
+	TextFormatterType = "text"
+	JSONFormatterType = "json"
 )
 
 var BaseFormatterKey = "base"
 
+// LogFormatter formats the access info of a request into one log line.
+type LogFormatter interface {
+	Format(i info.AccessInfo) string
+}
+
 // Formaater manager not concurrent safe
 // make sure init with Add and SetMethod before use Get
 type FormatterManger struct {
-	formatters map[string]*Formatter
+	formatters map[string]LogFormatter
 	methodMap  map[string]string
 }
 
 func NewFormatterManger() *FormatterManger {
 	return &FormatterManger{
-		formatters: make(map[string]*Formatter),
+		formatters: make(map[string]LogFormatter),
 		methodMap:  make(map[string]string),
 	}
 }
@@ -49,13 +60,17 @@ func (m *FormatterManger) Add(name, fmt string) {
 	m.formatters[name] = NewFormatter(fmt)
 }
 
+func (m *FormatterManger) AddFormatter(name string, formatter LogFormatter) {
+	m.formatters[name] = formatter
+}
+
 func (m *FormatterManger) SetMethod(name string, methods ...string) {
 	for _, method := range methods {
 		m.methodMap[method] = name
 	}
 }
 
-func (m *FormatterManger) GetByMethod(method string) (*Formatter, bool) {
+func (m *FormatterManger) GetByMethod(method string) (LogFormatter, bool) {
 	formatterName, ok := m.methodMap[method]
 	if !ok {
 		formatterName = BaseFormatterKey
@@ -126,8 +141,8 @@ func (f *Formatter) Format(i info.AccessInfo) string {
 
 func parseConfigKey(k string) (string, string, error) {
 	fields := strings.Split(k, ".")
-	if len(fields) != 2 || (fields[1] != fomaterkey && fields[1] != methodKey) {
-		return "", "", merr.WrapErrParameterInvalid("<FormatterName>.(format|methods)", k, "parse accsslog formatter config key failed")
+	if len(fields) != 2 || (fields[1] != fomaterkey && fields[1] != methodKey && fields[1] != typeKey) {
+		return "", "", merr.WrapErrParameterInvalid("<FormatterName>.(format|methods|type)", k, "parse accsslog formatter config key failed")
 	}
 	return fields[0], fields[1], nil
 }
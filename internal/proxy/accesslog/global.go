@@ -28,6 +28,7 @@ import (
 	"github.com/milvus-io/milvus/internal/proxy/accesslog/info"
 	configEvent "github.com/milvus-io/milvus/pkg/config"
 	"github.com/milvus-io/milvus/pkg/log"
+	"github.com/milvus-io/milvus/pkg/util/merr"
 	"github.com/milvus-io/milvus/pkg/util/paramtable"
 )
 
@@ -94,7 +95,10 @@ func (l *AccessLogger) SetEnable(enable bool) error {
 		}
 	} else {
 		log.Info("start close access log")
-		if write, ok := l.writer.(*RotateWriter); ok {
+		switch write := l.writer.(type) {
+		case *RotateWriter:
+			write.Close()
+		case *HTTPWriter:
 			write.Close()
 		}
 	}
@@ -150,6 +154,7 @@ func InitAccessLogger(params *paramtable.ComponentParam) {
 func initFormatter(logCfg *paramtable.AccessLogConfig) (*FormatterManger, error) {
 	formatterManger := NewFormatterManger()
 	formatMap := make(map[string]string)   // fommatter name -> formatter format
+	typeMap := make(map[string]string)     // fommatter name -> formatter type
 	methodMap := make(map[string][]string) // fommatter name -> formatter owner method
 	for key, value := range logCfg.Formatter.GetValue() {
 		formatterName, option, err := parseConfigKey(key)
@@ -157,18 +162,38 @@ func initFormatter(logCfg *paramtable.AccessLogConfig) (*FormatterManger, error)
 			return nil, err
 		}
 
-		if option == fomaterkey {
+		switch option {
+		case fomaterkey:
 			formatMap[formatterName] = value
-		} else if option == methodKey {
+		case typeKey:
+			typeMap[formatterName] = value
+		case methodKey:
 			methodMap[formatterName] = paramtable.ParseAsStings(value)
 		}
 	}
 
+	for name, formatterType := range typeMap {
+		switch formatterType {
+		case JSONFormatterType:
+			formatterManger.AddFormatter(name, NewJSONFormatter())
+		case TextFormatterType:
+			if _, ok := formatMap[name]; !ok {
+				return nil, merr.WrapErrParameterMissing(name, "format of text access log formatter is required")
+			}
+		default:
+			return nil, merr.WrapErrParameterInvalid("text or json", formatterType, "invalid access log formatter type")
+		}
+	}
+
 	for name, format := range formatMap {
-		formatterManger.Add(name, format)
-		if methods, ok := methodMap[name]; ok {
-			formatterManger.SetMethod(name, methods...)
+		if typeMap[name] == JSONFormatterType {
+			continue
 		}
+		formatterManger.Add(name, format)
+	}
+
+	for name, methods := range methodMap {
+		formatterManger.SetMethod(name, methods...)
 	}
 
 	return formatterManger, nil
@@ -176,6 +201,10 @@ func initFormatter(logCfg *paramtable.AccessLogConfig) (*FormatterManger, error)
 
 // initAccessLogger initializes a zap access logger for proxy
 func initWriter(logCfg *paramtable.AccessLogConfig, minioCfg *paramtable.MinioConfig) (io.Writer, error) {
+	if len(logCfg.HTTPEndpoint.GetValue()) > 0 {
+		return NewHTTPWriter(logCfg)
+	}
+
 	if len(logCfg.Filename.GetValue()) > 0 {
 		lg, err := NewRotateWriter(logCfg, minioCfg)
 		if err != nil {
@@ -19,6 +19,7 @@ package accesslog
 import (
 	"context"
 	"net"
+	"net/http/httptest"
 	"os"
 	"sync"
 	"testing"
@@ -31,6 +32,7 @@ import (
 
 	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
 	"github.com/milvus-io/milvus-proto/go-api/v2/milvuspb"
+	"github.com/milvus-io/milvus/internal/json"
 	"github.com/milvus-io/milvus/internal/proxy/accesslog/info"
 	"github.com/milvus-io/milvus/pkg/util/etcd"
 	"github.com/milvus-io/milvus/pkg/util/paramtable"
@@ -228,6 +230,65 @@ func TestAccessLogger_Stdout(t *testing.T) {
 	assert.True(t, ok)
 }
 
+func TestAccessLogger_HTTPSink(t *testing.T) {
+	once = sync.Once{}
+	collector := &mockCollector{}
+	server := httptest.NewServer(collector)
+	defer server.Close()
+
+	var Params paramtable.ComponentParam
+	Params.Init(paramtable.NewBaseTable(paramtable.SkipRemote(true)))
+	Params.Save(Params.ProxyCfg.AccessLog.Enable.Key, "true")
+	Params.Save(Params.ProxyCfg.AccessLog.HTTPEndpoint.Key, server.URL)
+	Params.SaveGroup(map[string]string{
+		Params.ProxyCfg.AccessLog.Formatter.KeyPrefix + "json.type":    JSONFormatterType,
+		Params.ProxyCfg.AccessLog.Formatter.KeyPrefix + "json.methods": "Upsert",
+	})
+
+	InitAccessLogger(&Params)
+
+	req := &milvuspb.UpsertRequest{
+		DbName:         "test-db",
+		CollectionName: "test-collection",
+	}
+	rpcInfo := &grpc.UnaryServerInfo{Server: nil, FullMethod: "/milvus.proto.milvus.MilvusService/Upsert"}
+	accessInfo := info.NewGrpcAccessInfo(context.Background(), rpcInfo, req)
+	accessInfo.SetResult(&milvuspb.MutationResult{}, nil)
+	assert.True(t, _globalL.Write(accessInfo))
+
+	// close writer and send the remaining lines
+	assert.NoError(t, _globalL.SetEnable(false))
+	bodies := collector.received()
+	assert.Equal(t, 1, len(bodies))
+	record := &JSONRecord{}
+	assert.NoError(t, json.Unmarshal([]byte(bodies[0]), record))
+	assert.Equal(t, "Upsert", record.Method)
+	assert.Equal(t, "test-collection", record.Collection)
+}
+
+func TestInitFormatter(t *testing.T) {
+	var Params paramtable.ComponentParam
+	Params.Init(paramtable.NewBaseTable(paramtable.SkipRemote(true)))
+
+	Params.SaveGroup(map[string]string{Params.ProxyCfg.AccessLog.Formatter.KeyPrefix + "testf.type": "xml"})
+	_, err := initFormatter(&Params.ProxyCfg.AccessLog)
+	assert.Error(t, err)
+
+	Params.SaveGroup(map[string]string{Params.ProxyCfg.AccessLog.Formatter.KeyPrefix + "testf.type": TextFormatterType})
+	_, err = initFormatter(&Params.ProxyCfg.AccessLog)
+	assert.Error(t, err)
+
+	Params.SaveGroup(map[string]string{
+		Params.ProxyCfg.AccessLog.Formatter.KeyPrefix + "testf.format":  "$method_name",
+		Params.ProxyCfg.AccessLog.Formatter.KeyPrefix + "testf.methods": "Insert",
+	})
+	manager, err := initFormatter(&Params.ProxyCfg.AccessLog)
+	assert.NoError(t, err)
+	formatter, ok := manager.GetByMethod("Insert")
+	assert.True(t, ok)
+	assert.IsType(t, &Formatter{}, formatter)
+}
+
 func TestAccessLogger_WithMinio(t *testing.T) {
 	once = sync.Once{}
 	var Params paramtable.ComponentParam
@@ -0,0 +1,276 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package accesslog
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/milvus-io/milvus/internal/json"
+	"github.com/milvus-io/milvus/pkg/log"
+	"github.com/milvus-io/milvus/pkg/util/merr"
+	"github.com/milvus-io/milvus/pkg/util/paramtable"
+)
+
+const (
+	JSONLinesProtocol = "jsonl"
+	OTLPProtocol      = "otlp"
+
+	// max pending lines = batchSize * maxPendingBatches,
+	// lines are dropped when the collector can't keep up.
+	maxPendingBatches = 10
+	otlpServiceName   = "milvus"
+	otlpScopeName     = "milvus.proxy.accesslog"
+)
+
+// HTTPWriter sends access log lines to a log collector in batches.
+// Write never blocks on network, batches are sent by a background goroutine
+// when batch size is reached or flush interval elapsed.
+type HTTPWriter struct {
+	endpoint      string
+	protocol      string
+	batchSize     int
+	flushInterval time.Duration
+	client        *http.Client
+
+	mu      sync.Mutex
+	pending [][]byte
+	dropped int
+
+	flushCh   chan struct{}
+	closed    bool
+	closeOnce sync.Once
+	closeCh   chan struct{}
+	closeWg   sync.WaitGroup
+}
+
+func NewHTTPWriter(logCfg *paramtable.AccessLogConfig) (*HTTPWriter, error) {
+	endpoint := logCfg.HTTPEndpoint.GetValue()
+	if _, err := url.ParseRequestURI(endpoint); err != nil {
+		return nil, merr.WrapErrParameterInvalid("valid url", endpoint, "invalid access log http endpoint")
+	}
+	protocol := logCfg.HTTPProtocol.GetValue()
+	if protocol != JSONLinesProtocol && protocol != OTLPProtocol {
+		return nil, merr.WrapErrParameterInvalid("jsonl or otlp", protocol, "invalid access log http protocol")
+	}
+	batchSize := logCfg.HTTPBatchSize.GetAsInt()
+	if batchSize <= 0 {
+		return nil, merr.WrapErrParameterInvalidRange(1, math.MaxInt, batchSize, "invalid access log http batch size")
+	}
+
+	w := &HTTPWriter{
+		endpoint:      endpoint,
+		protocol:      protocol,
+		batchSize:     batchSize,
+		flushInterval: logCfg.HTTPFlushInterval.GetAsDuration(time.Second),
+		client:        &http.Client{Timeout: logCfg.HTTPTimeout.GetAsDuration(time.Second)},
+		flushCh:       make(chan struct{}, 1),
+		closeCh:       make(chan struct{}),
+	}
+	w.Start()
+	return w, nil
+}
+
+func (w *HTTPWriter) Write(p []byte) (n int, err error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.closed {
+		return 0, fmt.Errorf("write to closed writer")
+	}
+
+	if len(w.pending) >= w.batchSize*maxPendingBatches {
+		w.dropped++
+		return len(p), nil
+	}
+	// the caller may reuse p
+	w.pending = append(w.pending, bytes.TrimRight(bytes.Clone(p), "\n"))
+	if len(w.pending) >= w.batchSize {
+		select {
+		case w.flushCh <- struct{}{}:
+		default:
+		}
+	}
+	return len(p), nil
+}
+
+// Flush sends all pending lines to the collector.
+func (w *HTTPWriter) Flush() error {
+	w.mu.Lock()
+	lines := w.pending
+	dropped := w.dropped
+	w.pending = nil
+	w.dropped = 0
+	w.mu.Unlock()
+
+	if dropped > 0 {
+		log.Warn("access log http sink is too slow, lines dropped", zap.Int("dropped", dropped))
+	}
+
+	for len(lines) > 0 {
+		size := w.batchSize
+		if size > len(lines) {
+			size = len(lines)
+		}
+		if err := w.send(lines[:size]); err != nil {
+			log.Warn("send access log to http sink failed", zap.String("endpoint", w.endpoint), zap.Int("lines", size), zap.Error(err))
+			return err
+		}
+		lines = lines[size:]
+	}
+	return nil
+}
+
+func (w *HTTPWriter) Start() {
+	w.closeWg.Add(1)
+	go func() {
+		defer w.closeWg.Done()
+		var tickerCh <-chan time.Time
+		if w.flushInterval > 0 {
+			ticker := time.NewTicker(w.flushInterval)
+			defer ticker.Stop()
+			tickerCh = ticker.C
+		}
+
+		for {
+			select {
+			case <-tickerCh:
+				w.Flush()
+			case <-w.flushCh:
+				w.Flush()
+			case <-w.closeCh:
+				return
+			}
+		}
+	}()
+}
+
+func (w *HTTPWriter) Close() {
+	w.closeOnce.Do(func() {
+		close(w.closeCh)
+		w.closeWg.Wait()
+
+		w.mu.Lock()
+		w.closed = true
+		w.mu.Unlock()
+
+		// send remaining lines
+		w.Flush()
+	})
+}
+
+func (w *HTTPWriter) send(lines [][]byte) error {
+	var body []byte
+	var contentType string
+	switch w.protocol {
+	case OTLPProtocol:
+		payload, err := json.Marshal(newOTLPLogs(lines))
+		if err != nil {
+			return err
+		}
+		body = payload
+		contentType = "application/json"
+	default:
+		body = append(bytes.Join(lines, []byte("\n")), '\n')
+		contentType = "application/x-ndjson"
+	}
+
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodPost, w.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", contentType)
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// json encoding of OTLP/HTTP ExportLogsServiceRequest.
+type otlpLogs struct {
+	ResourceLogs []otlpResourceLogs `json:"resourceLogs"`
+}
+
+type otlpResourceLogs struct {
+	Resource  otlpResource    `json:"resource"`
+	ScopeLogs []otlpScopeLogs `json:"scopeLogs"`
+}
+
+type otlpResource struct {
+	Attributes []otlpKeyValue `json:"attributes"`
+}
+
+type otlpScopeLogs struct {
+	Scope      otlpScope       `json:"scope"`
+	LogRecords []otlpLogRecord `json:"logRecords"`
+}
+
+type otlpScope struct {
+	Name string `json:"name"`
+}
+
+type otlpLogRecord struct {
+	TimeUnixNano string       `json:"timeUnixNano"`
+	Body         otlpAnyValue `json:"body"`
+}
+
+type otlpKeyValue struct {
+	Key   string       `json:"key"`
+	Value otlpAnyValue `json:"value"`
+}
+
+type otlpAnyValue struct {
+	StringValue string `json:"stringValue"`
+}
+
+func newOTLPLogs(lines [][]byte) *otlpLogs {
+	now := strconv.FormatInt(time.Now().UnixNano(), 10)
+	records := make([]otlpLogRecord, 0, len(lines))
+	for _, line := range lines {
+		records = append(records, otlpLogRecord{
+			TimeUnixNano: now,
+			Body:         otlpAnyValue{StringValue: string(line)},
+		})
+	}
+	return &otlpLogs{
+		ResourceLogs: []otlpResourceLogs{{
+			Resource: otlpResource{
+				Attributes: []otlpKeyValue{{Key: "service.name", Value: otlpAnyValue{StringValue: otlpServiceName}}},
+			},
+			ScopeLogs: []otlpScopeLogs{{
+				Scope:      otlpScope{Name: otlpScopeName},
+				LogRecords: records,
+			}},
+		}},
+	}
+}
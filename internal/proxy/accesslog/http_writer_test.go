@@ -0,0 +1,164 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package accesslog
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/milvus-io/milvus/internal/json"
+	"github.com/milvus-io/milvus/pkg/util/paramtable"
+)
+
+type mockCollector struct {
+	mu          sync.Mutex
+	bodies      []string
+	contentType string
+	statusCode  int
+}
+
+func (c *mockCollector) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.bodies = append(c.bodies, string(body))
+	c.contentType = r.Header.Get("Content-Type")
+	if c.statusCode != 0 {
+		w.WriteHeader(c.statusCode)
+	}
+}
+
+func (c *mockCollector) received() []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]string{}, c.bodies...)
+}
+
+func newHTTPSinkParams(endpoint string) *paramtable.ComponentParam {
+	var params paramtable.ComponentParam
+	params.Init(paramtable.NewBaseTable(paramtable.SkipRemote(true)))
+	params.Save(params.ProxyCfg.AccessLog.HTTPEndpoint.Key, endpoint)
+	params.Save(params.ProxyCfg.AccessLog.HTTPBatchSize.Key, "2")
+	params.Save(params.ProxyCfg.AccessLog.HTTPFlushInterval.Key, "0")
+	return &params
+}
+
+func TestHTTPWriter_JSONLines(t *testing.T) {
+	collector := &mockCollector{}
+	server := httptest.NewServer(collector)
+	defer server.Close()
+
+	params := newHTTPSinkParams(server.URL)
+	writer, err := NewHTTPWriter(&params.ProxyCfg.AccessLog)
+	require.NoError(t, err)
+
+	// reach batch size, sent by background goroutine
+	_, err = writer.Write([]byte("{\"method\":\"Search\"}\n"))
+	assert.NoError(t, err)
+	_, err = writer.Write([]byte("{\"method\":\"Query\"}\n"))
+	assert.NoError(t, err)
+	assert.Eventually(t, func() bool {
+		return len(collector.received()) == 1
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.Equal(t, "{\"method\":\"Search\"}\n{\"method\":\"Query\"}\n", collector.received()[0])
+	assert.Equal(t, "application/x-ndjson", collector.contentType)
+
+	// remaining lines are sent when close
+	_, err = writer.Write([]byte("{\"method\":\"Insert\"}\n"))
+	assert.NoError(t, err)
+	writer.Close()
+	assert.Equal(t, 2, len(collector.received()))
+
+	_, err = writer.Write([]byte("{}\n"))
+	assert.Error(t, err)
+}
+
+func TestHTTPWriter_OTLP(t *testing.T) {
+	collector := &mockCollector{}
+	server := httptest.NewServer(collector)
+	defer server.Close()
+
+	params := newHTTPSinkParams(server.URL)
+	params.Save(params.ProxyCfg.AccessLog.HTTPProtocol.Key, OTLPProtocol)
+	writer, err := NewHTTPWriter(&params.ProxyCfg.AccessLog)
+	require.NoError(t, err)
+
+	_, err = writer.Write([]byte("line1\n"))
+	assert.NoError(t, err)
+	assert.NoError(t, writer.Flush())
+	writer.Close()
+
+	require.Equal(t, 1, len(collector.received()))
+	assert.Equal(t, "application/json", collector.contentType)
+	logs := &otlpLogs{}
+	require.NoError(t, json.Unmarshal([]byte(collector.received()[0]), logs))
+	require.Equal(t, 1, len(logs.ResourceLogs))
+	records := logs.ResourceLogs[0].ScopeLogs[0].LogRecords
+	require.Equal(t, 1, len(records))
+	assert.Equal(t, "line1", records[0].Body.StringValue)
+	assert.NotEmpty(t, records[0].TimeUnixNano)
+}
+
+func TestHTTPWriter_Failed(t *testing.T) {
+	collector := &mockCollector{statusCode: http.StatusInternalServerError}
+	server := httptest.NewServer(collector)
+	defer server.Close()
+
+	params := newHTTPSinkParams(server.URL)
+	writer, err := NewHTTPWriter(&params.ProxyCfg.AccessLog)
+	require.NoError(t, err)
+	defer writer.Close()
+
+	_, err = writer.Write([]byte("line1\n"))
+	assert.NoError(t, err)
+	err = writer.Flush()
+	assert.Error(t, err)
+	assert.True(t, strings.Contains(err.Error(), "500"))
+
+	// lines are dropped when too many pending
+	for i := 0; i < writer.batchSize*maxPendingBatches+1; i++ {
+		_, err = writer.Write([]byte("line\n"))
+		assert.NoError(t, err)
+	}
+	writer.mu.Lock()
+	assert.LessOrEqual(t, len(writer.pending), writer.batchSize*maxPendingBatches)
+	writer.mu.Unlock()
+}
+
+func TestHTTPWriter_InvalidConfig(t *testing.T) {
+	params := newHTTPSinkParams("not a url")
+	_, err := NewHTTPWriter(&params.ProxyCfg.AccessLog)
+	assert.Error(t, err)
+
+	params = newHTTPSinkParams("http://localhost:4318/v1/logs")
+	params.Save(params.ProxyCfg.AccessLog.HTTPProtocol.Key, "grpc")
+	_, err = NewHTTPWriter(&params.ProxyCfg.AccessLog)
+	assert.Error(t, err)
+
+	params = newHTTPSinkParams("http://localhost:4318/v1/logs")
+	params.Save(params.ProxyCfg.AccessLog.HTTPBatchSize.Key, "0")
+	_, err = NewHTTPWriter(&params.ProxyCfg.AccessLog)
+	assert.Error(t, err)
+}
@@ -299,3 +299,15 @@ func (i *GrpcAccessInfo) ConsistencyLevel() string {
 	}
 	return Unknown
 }
+
+func (i *GrpcAccessInfo) NQ() string {
+	nq, ok := requestutil.GetNQFromRequest(i.req)
+	if ok {
+		return fmt.Sprint(nq.(int64))
+	}
+	return Unknown
+}
+
+func (i *GrpcAccessInfo) TopK() string {
+	return getTopKFromRequest(i.req)
+}
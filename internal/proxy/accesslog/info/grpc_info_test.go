@@ -222,6 +222,31 @@ func (s *GrpcAccessInfoSuite) TestConsistencyLevel() {
 	s.Equal(commonpb.ConsistencyLevel_Bounded.String(), result[0])
 }
 
+func (s *GrpcAccessInfoSuite) TestNQAndTopK() {
+	result := Get(s.info, "$nq", "$topk")
+	s.Equal(Unknown, result[0])
+	s.Equal(Unknown, result[1])
+
+	s.info.req = &milvuspb.SearchRequest{
+		Nq: 2,
+		SearchParams: []*commonpb.KeyValuePair{
+			{Key: "topk", Value: "10"},
+		},
+	}
+	result = Get(s.info, "$nq", "$topk")
+	s.Equal("2", result[0])
+	s.Equal("10", result[1])
+
+	s.info.req = &milvuspb.QueryRequest{
+		QueryParams: []*commonpb.KeyValuePair{
+			{Key: "limit", Value: "100"},
+		},
+	}
+	result = Get(s.info, "$nq", "$topk")
+	s.Equal(Unknown, result[0])
+	s.Equal("100", result[1])
+}
+
 func (s *GrpcAccessInfoSuite) TestClusterPrefix() {
 	cluster := "instance-test"
 	paramtable.Init()
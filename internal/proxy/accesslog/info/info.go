@@ -47,6 +47,8 @@ var MetricFuncMap = map[string]getMetricFunc{
 	"$sdk_version":       getSdkVersion,
 	"$cluster_prefix":    getClusterPrefix,
 	"$consistency_level": getConsistencyLevel,
+	"$nq":                getNQ,
+	"$topk":              getTopK,
 }
 
 type AccessInfo interface {
@@ -70,6 +72,8 @@ type AccessInfo interface {
 	OutputFields() string
 	SdkVersion() string
 	ConsistencyLevel() string
+	NQ() string
+	TopK() string
 }
 
 func Get(i AccessInfo, keys ...string) []any {
@@ -165,6 +169,14 @@ func getConsistencyLevel(i AccessInfo) string {
 	return i.ConsistencyLevel()
 }
 
+func getNQ(i AccessInfo) string {
+	return i.NQ()
+}
+
+func getTopK(i AccessInfo) string {
+	return i.TopK()
+}
+
 func getClusterPrefix(i AccessInfo) string {
 	return ClusterPrefix.Load()
 }
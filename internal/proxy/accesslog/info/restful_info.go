@@ -208,3 +208,15 @@ func (i *RestfulInfo) ConsistencyLevel() string {
 	}
 	return Unknown
 }
+
+func (i *RestfulInfo) NQ() string {
+	nq, ok := requestutil.GetNQFromRequest(i.req)
+	if ok {
+		return fmt.Sprint(nq.(int64))
+	}
+	return Unknown
+}
+
+func (i *RestfulInfo) TopK() string {
+	return getTopKFromRequest(i.req)
+}
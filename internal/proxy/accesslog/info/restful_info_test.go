@@ -195,6 +195,23 @@ func (s *RestfulAccessInfoSuite) TestConsistencyLevel() {
 	s.Equal(commonpb.ConsistencyLevel_Bounded.String(), result[0])
 }
 
+func (s *RestfulAccessInfoSuite) TestNQAndTopK() {
+	result := Get(s.info, "$nq", "$topk")
+	s.Equal(Unknown, result[0])
+	s.Equal(Unknown, result[1])
+
+	s.info.params.Keys[ContextRequest] = &milvuspb.SearchRequest{
+		Nq: 3,
+		SearchParams: []*commonpb.KeyValuePair{
+			{Key: "limit", Value: "5"},
+		},
+	}
+	s.info.InitReq()
+	result = Get(s.info, "$nq", "$topk")
+	s.Equal("3", result[0])
+	s.Equal("5", result[1])
+}
+
 func (s *RestfulAccessInfoSuite) TestClusterPrefix() {
 	cluster := "instance-test"
 	paramtable.Init()
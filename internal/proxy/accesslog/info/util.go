@@ -24,8 +24,10 @@ import (
 	"go.uber.org/atomic"
 	"google.golang.org/grpc/metadata"
 
+	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
 	"github.com/milvus-io/milvus/pkg/util"
 	"github.com/milvus-io/milvus/pkg/util/crypto"
+	"github.com/milvus-io/milvus/pkg/util/requestutil"
 )
 
 var ClusterPrefix atomic.String
@@ -89,3 +91,23 @@ func getSdkTypeByUserAgent(userAgents []string) (string, bool) {
 		return "", false
 	}
 }
+
+// getTopKFromRequest returns the topk of search request or the limit of query request.
+func getTopKFromRequest(req interface{}) string {
+	if params, ok := requestutil.GetSearchParamsFromRequest(req); ok {
+		for _, kv := range params.([]*commonpb.KeyValuePair) {
+			if kv.GetKey() == "topk" || kv.GetKey() == "limit" {
+				return kv.GetValue()
+			}
+		}
+	}
+
+	if params, ok := requestutil.GetQueryParamsFromRequest(req); ok {
+		for _, kv := range params.([]*commonpb.KeyValuePair) {
+			if kv.GetKey() == "limit" {
+				return kv.GetValue()
+			}
+		}
+	}
+	return Unknown
+}
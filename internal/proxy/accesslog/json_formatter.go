@@ -0,0 +1,111 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package accesslog
+
+import (
+	"strconv"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/milvus-io/milvus/internal/json"
+	"github.com/milvus-io/milvus/internal/proxy/accesslog/info"
+	"github.com/milvus-io/milvus/pkg/log"
+)
+
+// JSONRecord is one access log entry of JSONFormatter,
+// unknown values are omitted.
+type JSONRecord struct {
+	Time             string  `json:"time,omitempty"`
+	Cluster          string  `json:"cluster,omitempty"`
+	Method           string  `json:"method,omitempty"`
+	Status           string  `json:"status,omitempty"`
+	ErrorCode        int     `json:"error_code"`
+	ErrorMsg         string  `json:"error_msg,omitempty"`
+	ErrorType        string  `json:"error_type,omitempty"`
+	User             string  `json:"user,omitempty"`
+	Address          string  `json:"address,omitempty"`
+	Database         string  `json:"db,omitempty"`
+	Collection       string  `json:"collection,omitempty"`
+	Partitions       string  `json:"partitions,omitempty"`
+	LatencyMs        float64 `json:"latency_ms"`
+	NQ               int64   `json:"nq,omitempty"`
+	TopK             int64   `json:"topk,omitempty"`
+	ConsistencyLevel string  `json:"consistency_level,omitempty"`
+	TraceID          string  `json:"trace_id,omitempty"`
+	Expression       string  `json:"expr,omitempty"`
+	OutputFields     string  `json:"output_fields,omitempty"`
+	ResponseSize     int64   `json:"response_size,omitempty"`
+	SdkVersion       string  `json:"sdk,omitempty"`
+}
+
+// JSONFormatter formats access info as one JSON object per line.
+type JSONFormatter struct{}
+
+func NewJSONFormatter() *JSONFormatter {
+	return &JSONFormatter{}
+}
+
+func (f *JSONFormatter) Format(i info.AccessInfo) string {
+	record := &JSONRecord{
+		Time:             knownString(i.TimeStart()),
+		Cluster:          info.ClusterPrefix.Load(),
+		Method:           knownString(i.MethodName()),
+		Status:           knownString(i.MethodStatus()),
+		ErrorCode:        int(knownInt(i.ErrorCode())),
+		ErrorMsg:         knownString(i.ErrorMsg()),
+		ErrorType:        knownString(i.ErrorType()),
+		User:             knownString(i.UserName()),
+		Address:          knownString(i.Address()),
+		Database:         knownString(i.DbName()),
+		Collection:       knownString(i.CollectionName()),
+		Partitions:       knownString(i.PartitionName()),
+		NQ:               knownInt(i.NQ()),
+		TopK:             knownInt(i.TopK()),
+		ConsistencyLevel: knownString(i.ConsistencyLevel()),
+		TraceID:          knownString(i.TraceID()),
+		Expression:       knownString(i.Expression()),
+		OutputFields:     knownString(i.OutputFields()),
+		ResponseSize:     knownInt(i.ResponseSize()),
+		SdkVersion:       knownString(i.SdkVersion()),
+	}
+	if cost, err := time.ParseDuration(i.TimeCost()); err == nil {
+		record.LatencyMs = float64(cost) / float64(time.Millisecond)
+	}
+
+	bytes, err := json.Marshal(record)
+	if err != nil {
+		log.Warn("marshal json access log failed", zap.Error(err))
+		return ""
+	}
+	return string(bytes) + "\n"
+}
+
+func knownString(value string) string {
+	if value == info.Unknown {
+		return ""
+	}
+	return value
+}
+
+func knownInt(value string) int64 {
+	result, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return 0
+	}
+	return result
+}
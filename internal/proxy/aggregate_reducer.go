@@ -0,0 +1,42 @@
+package proxy
+
+import (
+	"github.com/milvus-io/milvus-proto/go-api/v2/milvuspb"
+	"github.com/milvus-io/milvus-proto/go-api/v2/schemapb"
+	"github.com/milvus-io/milvus/internal/proto/internalpb"
+	"github.com/milvus-io/milvus/internal/util/aggregate"
+	"github.com/milvus-io/milvus/pkg/util/merr"
+	"github.com/milvus-io/milvus/pkg/util/typeutil"
+)
+
+// aggReducer merges the partial aggregation results of all shards and
+// applies offset and limit on the groups.
+type aggReducer struct {
+	params         *queryParams
+	req            *internalpb.RetrieveRequest
+	schema         *schemapb.CollectionSchema
+	collectionName string
+}
+
+func (r *aggReducer) Reduce(results []*internalpb.RetrieveResults) (*milvuspb.QueryResults, error) {
+	agg, err := aggregate.NewAggregator(r.req.GetGroupByFieldsId(), r.req.GetAggregates(), r.schema)
+	if err != nil {
+		return nil, err
+	}
+	for _, res := range results {
+		if err := agg.AddPartial(res.GetFieldsData()); err != nil {
+			return nil, err
+		}
+	}
+
+	offset, limit := int64(0), typeutil.Unlimited
+	if r.params != nil {
+		offset, limit = r.params.offset, r.params.limit
+	}
+	return &milvuspb.QueryResults{
+		Status:         merr.Success(),
+		FieldsData:     agg.FinalResult(offset, limit),
+		CollectionName: r.collectionName,
+		OutputFields:   agg.OutputNames(),
+	}, nil
+}
@@ -0,0 +1,84 @@
+package proxy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/schemapb"
+	"github.com/milvus-io/milvus/internal/proto/internalpb"
+	"github.com/milvus-io/milvus/pkg/util/typeutil"
+)
+
+func Test_aggReducer_Reduce(t *testing.T) {
+	schema := &schemapb.CollectionSchema{
+		Fields: []*schemapb.FieldSchema{
+			{FieldID: 100, Name: "pk", DataType: schemapb.DataType_Int64, IsPrimaryKey: true},
+			{FieldID: 101, Name: "tag", DataType: schemapb.DataType_Int64},
+		},
+	}
+	req := &internalpb.RetrieveRequest{
+		GroupByFieldsId: []int64{101},
+		Aggregates:      []*internalpb.Aggregate{{Op: internalpb.AggregateOp_Count}},
+	}
+	partial := func(tags []int64, counts []int64) *internalpb.RetrieveResults {
+		return &internalpb.RetrieveResults{
+			FieldsData: []*schemapb.FieldData{
+				{
+					Type:    schemapb.DataType_Int64,
+					FieldId: 101,
+					Field: &schemapb.FieldData_Scalars{Scalars: &schemapb.ScalarField{
+						Data: &schemapb.ScalarField_LongData{LongData: &schemapb.LongArray{Data: tags}},
+					}},
+				},
+				{
+					Type: schemapb.DataType_Int64,
+					Field: &schemapb.FieldData_Scalars{Scalars: &schemapb.ScalarField{
+						Data: &schemapb.ScalarField_LongData{LongData: &schemapb.LongArray{Data: counts}},
+					}},
+				},
+			},
+		}
+	}
+
+	t.Run("normal case", func(t *testing.T) {
+		r := &aggReducer{
+			params:         &queryParams{limit: typeutil.Unlimited},
+			req:            req,
+			schema:         schema,
+			collectionName: "test",
+		}
+		res, err := r.Reduce([]*internalpb.RetrieveResults{
+			partial([]int64{3, 1}, []int64{1, 2}),
+			partial([]int64{1, 2}, []int64{4, 8}),
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "test", res.GetCollectionName())
+		assert.Equal(t, []string{"tag", "count(*)"}, res.GetOutputFields())
+		assert.Equal(t, []int64{1, 2, 3}, res.GetFieldsData()[0].GetScalars().GetLongData().GetData())
+		assert.Equal(t, []int64{6, 8, 1}, res.GetFieldsData()[1].GetScalars().GetLongData().GetData())
+	})
+
+	t.Run("offset and limit", func(t *testing.T) {
+		r := &aggReducer{
+			params: &queryParams{offset: 1, limit: 1},
+			req:    req,
+			schema: schema,
+		}
+		res, err := r.Reduce([]*internalpb.RetrieveResults{
+			partial([]int64{3, 1, 2}, []int64{1, 2, 3}),
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []int64{2}, res.GetFieldsData()[0].GetScalars().GetLongData().GetData())
+		assert.Equal(t, []int64{3}, res.GetFieldsData()[1].GetScalars().GetLongData().GetData())
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		r := &aggReducer{req: req, schema: schema}
+		_, err := r.Reduce([]*internalpb.RetrieveResults{
+			{FieldsData: []*schemapb.FieldData{nil}},
+		})
+		assert.Error(t, err)
+	})
+}
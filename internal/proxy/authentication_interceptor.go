@@ -88,9 +88,18 @@ func AuthenticationInterceptor(ctx context.Context) (context.Context, error) {
 				return nil, status.Error(codes.Unauthenticated, "missing authorization in header")
 			}
 
-			// token format: base64<username:password>
-			// token := strings.TrimPrefix(authorization[0], "Bearer ")
+			// token format: base64<username:password>, or Bearer <jwt>
 			token := authStrArr[0]
+			if jwtToken, ok := strings.CutPrefix(token, bearerPrefix); ok {
+				user, roles, err := VerifyJWT(jwtToken)
+				if err != nil {
+					return nil, status.Error(codes.Unauthenticated, "auth check failure, please check the bearer token is valid")
+				}
+				metrics.UserRPCCounter.WithLabelValues(user).Inc()
+				userToken := fmt.Sprintf("%s%s%s", user, util.CredentialSeperator, util.PasswordHolder)
+				md[strings.ToLower(util.HeaderAuthorize)] = []string{crypto.Base64Encode(userToken)}
+				return NewContextWithJWTRoles(metadata.NewIncomingContext(ctx, md), roles), nil
+			}
 			rawToken, err := crypto.Base64Decode(token)
 			if err != nil {
 				log.Warn("fail to decode the token", zap.Error(err))
@@ -0,0 +1,379 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"math/rand"
+	"sort"
+	"time"
+
+	"github.com/samber/lo"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
+	"github.com/milvus-io/milvus-proto/go-api/v2/milvuspb"
+	"github.com/milvus-io/milvus-proto/go-api/v2/msgpb"
+	"github.com/milvus-io/milvus-proto/go-api/v2/schemapb"
+	"github.com/milvus-io/milvus/internal/proto/datapb"
+	"github.com/milvus-io/milvus/internal/proto/proxypb"
+	"github.com/milvus-io/milvus/pkg/common"
+	"github.com/milvus-io/milvus/pkg/log"
+	"github.com/milvus-io/milvus/pkg/mq/msgstream"
+	"github.com/milvus-io/milvus/pkg/util/commonpbutil"
+	"github.com/milvus-io/milvus/pkg/util/funcutil"
+	"github.com/milvus-io/milvus/pkg/util/merr"
+	"github.com/milvus-io/milvus/pkg/util/paramtable"
+	"github.com/milvus-io/milvus/pkg/util/typeutil"
+)
+
+// changeStream tails the dml channels of a collection and converts the inserts and deletes into change events.
+type changeStream struct {
+	collectionID int64
+	pkField      *schemapb.FieldSchema
+	// the output fields including the primary key, all the fields are returned if it's nil.
+	outputFields typeutil.Set[string]
+	// pchannel -> vchannel of the collection.
+	vchannels map[string]string
+	// the changes at or before the timestamp are skipped.
+	startTs uint64
+
+	subName   string
+	stream    msgstream.MsgStream
+	disposer  func([]string, string) error
+	positions map[string]*msgpb.MsgPosition // vchannel -> consumed position
+}
+
+// newChangeStream creates the change stream of the collection, the masked fields of the subscriber are never returned,
+// field id -> field name as returned by getMaskedFields.
+func newChangeStream(collectionID int64, schema *schemaInfo, vchannels []string, outputFields []string, masked map[int64]string) (*changeStream, error) {
+	if schema.pkField == nil {
+		return nil, merr.WrapErrServiceInternal(fmt.Sprintf("primary key field of collection %d not found", collectionID))
+	}
+	// the primary key is sent with every event
+	if err := checkMaskedFieldIDs(masked, schema.pkField.GetFieldID()); err != nil {
+		return nil, err
+	}
+	if err := checkMaskedOutputFields(masked, outputFields); err != nil {
+		return nil, err
+	}
+	cs := &changeStream{
+		collectionID: collectionID,
+		pkField:      schema.pkField,
+		vchannels:    make(map[string]string, len(vchannels)),
+		subName: fmt.Sprintf("%s-change-stream-%d-%d-%d", Params.CommonCfg.ClusterPrefix.GetValue(),
+			paramtable.GetNodeID(), collectionID, rand.Int()),
+		positions: make(map[string]*msgpb.MsgPosition, len(vchannels)),
+	}
+	for _, vchannel := range vchannels {
+		cs.vchannels[funcutil.ToPhysicalChannel(vchannel)] = vchannel
+	}
+	if lo.Contains(outputFields, "*") {
+		if len(masked) == 0 {
+			return cs, nil
+		}
+		outputFields = lo.Map(schema.GetFields(), func(field *schemapb.FieldSchema, _ int) string {
+			return field.GetName()
+		})
+	}
+	cs.outputFields = typeutil.NewSet(schema.pkField.GetName())
+	for _, name := range removeMaskedOutputFields(masked, outputFields) {
+		if _, err := schema.schemaHelper.GetFieldFromName(name); err != nil {
+			return nil, merr.WrapErrFieldNotFound(name)
+		}
+		cs.outputFields.Insert(name)
+	}
+	return cs, nil
+}
+
+// subscribe consumes the pchannels from the positions, or from the latest if positions are empty.
+func (cs *changeStream) subscribe(ctx context.Context, factory msgstream.Factory, positions []*msgpb.MsgPosition) error {
+	stream, err := factory.NewTtMsgStream(ctx)
+	if err != nil {
+		return err
+	}
+	cs.stream = stream
+	cs.disposer = factory.NewMsgStreamDisposer(ctx)
+
+	pchannels := lo.Keys(cs.vchannels)
+	if len(positions) == 0 {
+		return stream.AsConsumer(ctx, pchannels, cs.subName, common.SubscriptionPositionLatest)
+	}
+	if err := stream.AsConsumer(ctx, pchannels, cs.subName, common.SubscriptionPositionUnknown); err != nil {
+		return err
+	}
+	return stream.Seek(ctx, positions, false)
+}
+
+func (cs *changeStream) close() {
+	if cs.stream == nil {
+		return
+	}
+	cs.stream.Close()
+	if err := cs.disposer(lo.Keys(cs.vchannels), cs.subName); err != nil {
+		log.Warn("failed to remove the subscription of change stream", zap.String("subName", cs.subName), zap.Error(err))
+	}
+}
+
+// run sends the changes of every time tick to the subscriber until the context is done or any error occurs,
+// the latest resume token is sent at the heartbeat interval if there are no changes.
+func (cs *changeStream) run(ctx context.Context, send func(*proxypb.ChangeEvents) error) error {
+	lastSent := time.Now()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case pack, ok := <-cs.stream.Chan():
+			if !ok {
+				return merr.WrapErrServiceInternal("change stream is closed")
+			}
+			if pack == nil {
+				continue
+			}
+			events, err := cs.handlePack(pack)
+			if err != nil {
+				return err
+			}
+			heartbeat := Params.ProxyCfg.ChangeStreamHeartbeatInterval.GetAsDuration(time.Second)
+			if len(events) == 0 && time.Since(lastSent) < heartbeat {
+				continue
+			}
+			token, err := cs.resumeToken()
+			if err != nil {
+				return err
+			}
+			if err := send(&proxypb.ChangeEvents{
+				Status:      merr.Success(),
+				Events:      events,
+				ResumeToken: token,
+			}); err != nil {
+				return err
+			}
+			lastSent = time.Now()
+		}
+	}
+}
+
+// changeKey identifies the delete and the insert of an upsert, they have the same primary key and timestamp.
+type changeKey struct {
+	pk any
+	ts uint64
+}
+
+// handlePack converts the inserts and deletes of the collection in the pack into change events ordered by timestamp.
+func (cs *changeStream) handlePack(pack *msgstream.MsgPack) ([]*proxypb.ChangeEvent, error) {
+	for _, pos := range pack.EndPositions {
+		if vchannel, ok := cs.vchannels[pos.GetChannelName()]; ok {
+			cs.positions[vchannel] = &msgpb.MsgPosition{
+				ChannelName: vchannel,
+				MsgID:       pos.GetMsgID(),
+				Timestamp:   pos.GetTimestamp(),
+			}
+		}
+	}
+
+	inserts := make([]*msgstream.InsertMsg, 0)
+	deletes := make([]*msgstream.DeleteMsg, 0)
+	for _, msg := range pack.Msgs {
+		switch msg := msg.(type) {
+		case *msgstream.InsertMsg:
+			if cs.accept(msg.GetCollectionID(), msg.GetShardName()) {
+				inserts = append(inserts, msg)
+			}
+		case *msgstream.DeleteMsg:
+			if cs.accept(msg.GetCollectionID(), msg.GetShardName()) {
+				deletes = append(deletes, msg)
+			}
+		case *msgstream.DropCollectionMsg:
+			if msg.GetCollectionID() == cs.collectionID {
+				return nil, merr.WrapErrCollectionNotFound(cs.collectionID, "collection is dropped")
+			}
+		}
+	}
+
+	deleted := make(map[changeKey]struct{})
+	for _, msg := range deletes {
+		for i, ts := range msg.GetTimestamps() {
+			deleted[changeKey{pk: typeutil.GetPK(msg.GetPrimaryKeys(), int64(i)), ts: ts}] = struct{}{}
+		}
+	}
+
+	events := make([]*proxypb.ChangeEvent, 0)
+	for _, msg := range inserts {
+		pkData, err := typeutil.GetPrimaryFieldData(msg.GetFieldsData(), cs.pkField)
+		if err != nil {
+			return nil, err
+		}
+		pks, err := parsePrimaryFieldData2IDs(pkData)
+		if err != nil {
+			return nil, err
+		}
+		fieldsData := lo.Filter(msg.GetFieldsData(), func(field *schemapb.FieldData, _ int) bool {
+			return cs.outputFields == nil || cs.outputFields.Contain(field.GetFieldName())
+		})
+		for i, ts := range msg.GetTimestamps() {
+			if ts <= cs.startTs {
+				continue
+			}
+			event := &proxypb.ChangeEvent{
+				Type:          proxypb.ChangeEventType_ChangeInsert,
+				Timestamp:     ts,
+				PartitionName: msg.GetPartitionName(),
+				PrimaryKey:    &schemapb.IDs{},
+				FieldsData:    make([]*schemapb.FieldData, len(fieldsData)),
+			}
+			typeutil.AppendIDs(event.PrimaryKey, pks, i)
+			typeutil.AppendFieldData(event.FieldsData, fieldsData, int64(i))
+			key := changeKey{pk: typeutil.GetPK(pks, int64(i)), ts: ts}
+			if _, ok := deleted[key]; ok {
+				event.Type = proxypb.ChangeEventType_ChangeUpsert
+				delete(deleted, key)
+			}
+			events = append(events, event)
+		}
+	}
+	for _, msg := range deletes {
+		for i, ts := range msg.GetTimestamps() {
+			key := changeKey{pk: typeutil.GetPK(msg.GetPrimaryKeys(), int64(i)), ts: ts}
+			if _, ok := deleted[key]; !ok || ts <= cs.startTs {
+				continue
+			}
+			event := &proxypb.ChangeEvent{
+				Type:          proxypb.ChangeEventType_ChangeDelete,
+				Timestamp:     ts,
+				PartitionName: msg.GetPartitionName(),
+				PrimaryKey:    &schemapb.IDs{},
+			}
+			typeutil.AppendIDs(event.PrimaryKey, msg.GetPrimaryKeys(), i)
+			events = append(events, event)
+		}
+	}
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].GetTimestamp() < events[j].GetTimestamp()
+	})
+	return events, nil
+}
+
+func (cs *changeStream) accept(collectionID int64, vchannel string) bool {
+	if collectionID != cs.collectionID {
+		return false
+	}
+	return cs.vchannels[funcutil.ToPhysicalChannel(vchannel)] == vchannel
+}
+
+// resumeToken encodes the consumed positions of the vchannels.
+func (cs *changeStream) resumeToken() (string, error) {
+	checkpoint := &proxypb.ChangeStreamCheckpoint{
+		CollectionID: cs.collectionID,
+		Positions:    lo.Values(cs.positions),
+	}
+	sort.Slice(checkpoint.Positions, func(i, j int) bool {
+		return checkpoint.Positions[i].GetChannelName() < checkpoint.Positions[j].GetChannelName()
+	})
+	bytes, err := proto.Marshal(checkpoint)
+	if err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(bytes), nil
+}
+
+// positionsFromToken returns the positions of the pchannels to resume the subscription.
+func (cs *changeStream) positionsFromToken(token string) ([]*msgpb.MsgPosition, error) {
+	bytes, err := base64.StdEncoding.DecodeString(token)
+	if err != nil {
+		return nil, merr.WrapErrParameterInvalidMsg("invalid resume token: %s", err.Error())
+	}
+	checkpoint := &proxypb.ChangeStreamCheckpoint{}
+	if err := proto.Unmarshal(bytes, checkpoint); err != nil {
+		return nil, merr.WrapErrParameterInvalidMsg("invalid resume token: %s", err.Error())
+	}
+	if checkpoint.GetCollectionID() != cs.collectionID {
+		return nil, merr.WrapErrParameterInvalidMsg("resume token of collection %d can't be used for collection %d",
+			checkpoint.GetCollectionID(), cs.collectionID)
+	}
+	positions := make([]*msgpb.MsgPosition, 0, len(checkpoint.GetPositions()))
+	for _, pos := range checkpoint.GetPositions() {
+		pchannel := funcutil.ToPhysicalChannel(pos.GetChannelName())
+		if cs.vchannels[pchannel] != pos.GetChannelName() {
+			return nil, merr.WrapErrParameterInvalidMsg("resume token has unknown channel %s", pos.GetChannelName())
+		}
+		cs.positions[pos.GetChannelName()] = pos
+		positions = append(positions, &msgpb.MsgPosition{
+			ChannelName: pchannel,
+			MsgID:       pos.GetMsgID(),
+			Timestamp:   pos.GetTimestamp(),
+		})
+	}
+	if len(positions) != len(cs.vchannels) {
+		return nil, merr.WrapErrParameterInvalidMsg("resume token doesn't have the positions of all the channels")
+	}
+	return positions, nil
+}
+
+// changeStreamPositionsFromTs returns the positions of the pchannels to consume the changes after the ts.
+// The channel checkpoints are used if they're not later than the ts, otherwise the changes are replayed
+// from the start positions of the collection.
+func (node *Proxy) changeStreamPositionsFromTs(ctx context.Context, cs *changeStream, ts uint64) ([]*msgpb.MsgPosition, error) {
+	positions := make([]*msgpb.MsgPosition, 0, len(cs.vchannels))
+	for pchannel, vchannel := range cs.vchannels {
+		resp, err := node.dataCoord.GetChannelRecoveryInfo(ctx, &datapb.GetChannelRecoveryInfoRequest{
+			Vchannel: vchannel,
+		})
+		if err := merr.CheckRPCCall(resp, err); err != nil {
+			return nil, err
+		}
+		pos := resp.GetInfo().GetSeekPosition()
+		if pos.GetTimestamp() > ts {
+			return node.collectionStartPositions(ctx, cs)
+		}
+		positions = append(positions, &msgpb.MsgPosition{
+			ChannelName: pchannel,
+			MsgID:       pos.GetMsgID(),
+			Timestamp:   pos.GetTimestamp(),
+		})
+	}
+	return positions, nil
+}
+
+func (node *Proxy) collectionStartPositions(ctx context.Context, cs *changeStream) ([]*msgpb.MsgPosition, error) {
+	resp, err := node.rootCoord.DescribeCollection(ctx, &milvuspb.DescribeCollectionRequest{
+		Base: commonpbutil.NewMsgBase(
+			commonpbutil.WithMsgType(commonpb.MsgType_DescribeCollection),
+		),
+		CollectionID: cs.collectionID,
+	})
+	if err := merr.CheckRPCCall(resp, err); err != nil {
+		return nil, err
+	}
+	positions := make([]*msgpb.MsgPosition, 0, len(resp.GetStartPositions()))
+	for _, pair := range resp.GetStartPositions() {
+		if _, ok := cs.vchannels[pair.GetKey()]; !ok {
+			continue
+		}
+		positions = append(positions, &msgpb.MsgPosition{
+			ChannelName: pair.GetKey(),
+			MsgID:       pair.GetData(),
+		})
+	}
+	if len(positions) != len(cs.vchannels) {
+		return nil, merr.WrapErrServiceInternal(fmt.Sprintf("start positions of collection %d not found", cs.collectionID))
+	}
+	return positions, nil
+}
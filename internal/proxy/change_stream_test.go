@@ -0,0 +1,361 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
+	"github.com/milvus-io/milvus-proto/go-api/v2/msgpb"
+	"github.com/milvus-io/milvus-proto/go-api/v2/schemapb"
+	"github.com/milvus-io/milvus/internal/proto/proxypb"
+	"github.com/milvus-io/milvus/pkg/common"
+	"github.com/milvus-io/milvus/pkg/mq/msgstream"
+	"github.com/milvus-io/milvus/pkg/util/merr"
+	"github.com/milvus-io/milvus/pkg/util/paramtable"
+)
+
+func newChangeStreamTestSchema() *schemaInfo {
+	return newSchemaInfo(&schemapb.CollectionSchema{
+		Name: "coll",
+		Fields: []*schemapb.FieldSchema{
+			{FieldID: 100, Name: "pk", DataType: schemapb.DataType_Int64, IsPrimaryKey: true},
+			{FieldID: 101, Name: "value", DataType: schemapb.DataType_Int64},
+			{FieldID: 102, Name: "tag", DataType: schemapb.DataType_VarChar},
+		},
+	})
+}
+
+func newChangeStreamInsertMsg(collectionID int64, vchannel string, pks []int64, values []int64, tags []string, ts uint64) *msgstream.InsertMsg {
+	timestamps := make([]uint64, len(pks))
+	for i := range timestamps {
+		timestamps[i] = ts
+	}
+	return &msgstream.InsertMsg{
+		InsertRequest: &msgpb.InsertRequest{
+			CollectionID:  collectionID,
+			ShardName:     vchannel,
+			PartitionName: "_default",
+			Timestamps:    timestamps,
+			NumRows:       uint64(len(pks)),
+			Version:       msgpb.InsertDataVersion_ColumnBased,
+			FieldsData: []*schemapb.FieldData{
+				{
+					Type: schemapb.DataType_Int64, FieldName: "pk", FieldId: 100,
+					Field: &schemapb.FieldData_Scalars{Scalars: &schemapb.ScalarField{
+						Data: &schemapb.ScalarField_LongData{LongData: &schemapb.LongArray{Data: pks}},
+					}},
+				},
+				{
+					Type: schemapb.DataType_Int64, FieldName: "value", FieldId: 101,
+					Field: &schemapb.FieldData_Scalars{Scalars: &schemapb.ScalarField{
+						Data: &schemapb.ScalarField_LongData{LongData: &schemapb.LongArray{Data: values}},
+					}},
+				},
+				{
+					Type: schemapb.DataType_VarChar, FieldName: "tag", FieldId: 102,
+					Field: &schemapb.FieldData_Scalars{Scalars: &schemapb.ScalarField{
+						Data: &schemapb.ScalarField_StringData{StringData: &schemapb.StringArray{Data: tags}},
+					}},
+				},
+			},
+		},
+	}
+}
+
+func newChangeStreamDeleteMsg(collectionID int64, vchannel string, pks []int64, ts uint64) *msgstream.DeleteMsg {
+	timestamps := make([]uint64, len(pks))
+	for i := range timestamps {
+		timestamps[i] = ts
+	}
+	return &msgstream.DeleteMsg{
+		DeleteRequest: &msgpb.DeleteRequest{
+			CollectionID: collectionID,
+			ShardName:    vchannel,
+			PartitionID:  common.AllPartitionsID,
+			Timestamps:   timestamps,
+			NumRows:      int64(len(pks)),
+			PrimaryKeys:  &schemapb.IDs{IdField: &schemapb.IDs_IntId{IntId: &schemapb.LongArray{Data: pks}}},
+		},
+	}
+}
+
+func TestChangeStream_NewChangeStream(t *testing.T) {
+	paramtable.Init()
+	schema := newChangeStreamTestSchema()
+
+	cs, err := newChangeStream(1, schema, []string{"by-dev-rootcoord-dml_0_1v0"}, []string{"value"}, nil)
+	assert.NoError(t, err)
+	assert.True(t, cs.outputFields.Contain("pk"))
+	assert.True(t, cs.outputFields.Contain("value"))
+	assert.False(t, cs.outputFields.Contain("tag"))
+	assert.Equal(t, "by-dev-rootcoord-dml_0_1v0", cs.vchannels["by-dev-rootcoord-dml_0"])
+
+	cs, err = newChangeStream(1, schema, []string{"by-dev-rootcoord-dml_0_1v0"}, []string{"*"}, nil)
+	assert.NoError(t, err)
+	assert.Nil(t, cs.outputFields)
+
+	_, err = newChangeStream(1, schema, []string{"by-dev-rootcoord-dml_0_1v0"}, []string{"not_exist"}, nil)
+	assert.ErrorIs(t, err, merr.ErrFieldNotFound)
+}
+
+func TestChangeStream_MaskedFields(t *testing.T) {
+	paramtable.Init()
+	schema := newChangeStreamTestSchema()
+	tag, err := schema.schemaHelper.GetFieldFromName("tag")
+	assert.NoError(t, err)
+	masked := map[int64]string{tag.GetFieldID(): "tag"}
+
+	cs, err := newChangeStream(1, schema, []string{"by-dev-rootcoord-dml_0_1v0"}, []string{"*"}, masked)
+	assert.NoError(t, err)
+	assert.True(t, cs.outputFields.Contain("pk"))
+	assert.True(t, cs.outputFields.Contain("value"))
+	assert.False(t, cs.outputFields.Contain("tag"))
+
+	cs, err = newChangeStream(1, schema, []string{"by-dev-rootcoord-dml_0_1v0"}, []string{"value", "tag"}, masked)
+	assert.NoError(t, err)
+	assert.True(t, cs.outputFields.Contain("value"))
+	assert.False(t, cs.outputFields.Contain("tag"))
+
+	paramtable.Get().Save(Params.ProxyCfg.RejectMaskedOutputFields.Key, "true")
+	defer paramtable.Get().Reset(Params.ProxyCfg.RejectMaskedOutputFields.Key)
+	_, err = newChangeStream(1, schema, []string{"by-dev-rootcoord-dml_0_1v0"}, []string{"value", "tag"}, masked)
+	assert.ErrorIs(t, err, merr.ErrPrivilegeNotPermitted)
+
+	_, err = newChangeStream(1, schema, []string{"by-dev-rootcoord-dml_0_1v0"}, nil, map[int64]string{schema.pkField.GetFieldID(): "pk"})
+	assert.ErrorIs(t, err, merr.ErrPrivilegeNotPermitted)
+}
+
+func TestChangeStream_HandlePack(t *testing.T) {
+	paramtable.Init()
+	vchannel := "by-dev-rootcoord-dml_0_1v0"
+	cs, err := newChangeStream(1, newChangeStreamTestSchema(), []string{vchannel}, []string{"value"}, nil)
+	assert.NoError(t, err)
+	cs.startTs = 10
+
+	pack := &msgstream.MsgPack{
+		Msgs: []msgstream.TsMsg{
+			// an upsert is a delete and an insert with the same timestamp
+			newChangeStreamInsertMsg(1, vchannel, []int64{1, 2}, []int64{10, 20}, []string{"a", "b"}, 30),
+			newChangeStreamDeleteMsg(1, vchannel, []int64{2}, 30),
+			newChangeStreamDeleteMsg(1, vchannel, []int64{3}, 20),
+			// skipped by the start ts
+			newChangeStreamInsertMsg(1, vchannel, []int64{4}, []int64{40}, []string{"d"}, 10),
+			// other collection and other vchannel
+			newChangeStreamInsertMsg(2, vchannel, []int64{5}, []int64{50}, []string{"e"}, 30),
+			newChangeStreamInsertMsg(1, "by-dev-rootcoord-dml_1_1v1", []int64{6}, []int64{60}, []string{"f"}, 30),
+		},
+		EndPositions: []*msgpb.MsgPosition{
+			{ChannelName: "by-dev-rootcoord-dml_0", MsgID: []byte{1}, Timestamp: 40},
+			{ChannelName: "by-dev-rootcoord-dml_1", MsgID: []byte{2}, Timestamp: 40},
+		},
+	}
+	events, err := cs.handlePack(pack)
+	assert.NoError(t, err)
+	assert.Len(t, events, 3)
+
+	assert.Equal(t, proxypb.ChangeEventType_ChangeDelete, events[0].GetType())
+	assert.Equal(t, uint64(20), events[0].GetTimestamp())
+	assert.Equal(t, []int64{3}, events[0].GetPrimaryKey().GetIntId().GetData())
+	assert.Empty(t, events[0].GetFieldsData())
+
+	assert.Equal(t, proxypb.ChangeEventType_ChangeInsert, events[1].GetType())
+	assert.Equal(t, []int64{1}, events[1].GetPrimaryKey().GetIntId().GetData())
+	assert.Len(t, events[1].GetFieldsData(), 2)
+	assert.Equal(t, "value", events[1].GetFieldsData()[1].GetFieldName())
+	assert.Equal(t, []int64{10}, events[1].GetFieldsData()[1].GetScalars().GetLongData().GetData())
+
+	assert.Equal(t, proxypb.ChangeEventType_ChangeUpsert, events[2].GetType())
+	assert.Equal(t, []int64{2}, events[2].GetPrimaryKey().GetIntId().GetData())
+	assert.Equal(t, []int64{20}, events[2].GetFieldsData()[1].GetScalars().GetLongData().GetData())
+
+	assert.Len(t, cs.positions, 1)
+	assert.Equal(t, vchannel, cs.positions[vchannel].GetChannelName())
+	assert.Equal(t, uint64(40), cs.positions[vchannel].GetTimestamp())
+
+	_, err = cs.handlePack(&msgstream.MsgPack{
+		Msgs: []msgstream.TsMsg{&msgstream.DropCollectionMsg{DropCollectionRequest: &msgpb.DropCollectionRequest{CollectionID: 1}}},
+	})
+	assert.ErrorIs(t, err, merr.ErrCollectionNotFound)
+}
+
+func TestChangeStream_ResumeToken(t *testing.T) {
+	paramtable.Init()
+	vchannels := []string{"by-dev-rootcoord-dml_0_1v0", "by-dev-rootcoord-dml_1_1v1"}
+	cs, err := newChangeStream(1, newChangeStreamTestSchema(), vchannels, nil, nil)
+	assert.NoError(t, err)
+	_, err = cs.handlePack(&msgstream.MsgPack{
+		EndPositions: []*msgpb.MsgPosition{
+			{ChannelName: "by-dev-rootcoord-dml_0", MsgID: []byte{1}, Timestamp: 40},
+			{ChannelName: "by-dev-rootcoord-dml_1", MsgID: []byte{2}, Timestamp: 40},
+		},
+	})
+	assert.NoError(t, err)
+	token, err := cs.resumeToken()
+	assert.NoError(t, err)
+
+	resumed, err := newChangeStream(1, newChangeStreamTestSchema(), vchannels, nil, nil)
+	assert.NoError(t, err)
+	positions, err := resumed.positionsFromToken(token)
+	assert.NoError(t, err)
+	assert.Len(t, positions, 2)
+	assert.Equal(t, "by-dev-rootcoord-dml_0", positions[0].GetChannelName())
+	assert.Equal(t, []byte{1}, positions[0].GetMsgID())
+	assert.Equal(t, "by-dev-rootcoord-dml_1", positions[1].GetChannelName())
+	assert.Equal(t, []byte{2}, positions[1].GetMsgID())
+	resumedToken, err := resumed.resumeToken()
+	assert.NoError(t, err)
+	assert.Equal(t, token, resumedToken)
+
+	_, err = resumed.positionsFromToken("invalid token")
+	assert.ErrorIs(t, err, merr.ErrParameterInvalid)
+
+	other, err := newChangeStream(2, newChangeStreamTestSchema(), []string{"by-dev-rootcoord-dml_0_2v0"}, nil, nil)
+	assert.NoError(t, err)
+	_, err = other.positionsFromToken(token)
+	assert.ErrorIs(t, err, merr.ErrParameterInvalid)
+}
+
+func TestChangeStream_Run(t *testing.T) {
+	paramtable.Init()
+	vchannel := "by-dev-rootcoord-dml_0_1v0"
+	cs, err := newChangeStream(1, newChangeStreamTestSchema(), []string{vchannel}, nil, nil)
+	assert.NoError(t, err)
+
+	ch := make(chan *msgstream.MsgPack, 3)
+	stream := msgstream.NewMockMsgStream(t)
+	stream.EXPECT().AsConsumer(mock.Anything, []string{"by-dev-rootcoord-dml_0"}, cs.subName, common.SubscriptionPositionUnknown).Return(nil)
+	stream.EXPECT().Seek(mock.Anything, mock.Anything, false).Return(nil)
+	stream.EXPECT().Chan().Return(ch)
+	stream.EXPECT().Close().Return()
+	factory := msgstream.NewMockFactory(t)
+	factory.EXPECT().NewTtMsgStream(mock.Anything).Return(stream, nil)
+	disposed := false
+	factory.EXPECT().NewMsgStreamDisposer(mock.Anything).Return(func(channels []string, subName string) error {
+		disposed = true
+		return nil
+	})
+
+	err = cs.subscribe(context.Background(), factory, []*msgpb.MsgPosition{{ChannelName: "by-dev-rootcoord-dml_0", MsgID: []byte{1}}})
+	assert.NoError(t, err)
+	defer func() {
+		cs.close()
+		assert.True(t, disposed)
+	}()
+
+	paramtable.Get().Save(paramtable.Get().ProxyCfg.ChangeStreamHeartbeatInterval.Key, "0.2")
+	defer paramtable.Get().Reset(paramtable.Get().ProxyCfg.ChangeStreamHeartbeatInterval.Key)
+
+	endPositions := []*msgpb.MsgPosition{{ChannelName: "by-dev-rootcoord-dml_0", MsgID: []byte{1}, Timestamp: 40}}
+	ch <- &msgstream.MsgPack{
+		Msgs:         []msgstream.TsMsg{newChangeStreamInsertMsg(1, vchannel, []int64{1}, []int64{10}, []string{"a"}, 30)},
+		EndPositions: endPositions,
+	}
+	// no changes within the heartbeat interval, skipped
+	ch <- &msgstream.MsgPack{EndPositions: endPositions}
+	go func() {
+		time.Sleep(300 * time.Millisecond)
+		ch <- &msgstream.MsgPack{EndPositions: endPositions}
+	}()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	sent := make([]*proxypb.ChangeEvents, 0)
+	err = cs.run(ctx, func(events *proxypb.ChangeEvents) error {
+		sent = append(sent, events)
+		if len(sent) == 2 {
+			cancel()
+		}
+		return nil
+	})
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Len(t, sent, 2)
+	assert.Len(t, sent[0].GetEvents(), 1)
+	assert.NotEmpty(t, sent[0].GetResumeToken())
+	// heartbeat
+	assert.Empty(t, sent[1].GetEvents())
+	assert.Equal(t, sent[0].GetResumeToken(), sent[1].GetResumeToken())
+}
+
+type mockChangeStreamServer struct {
+	proxypb.ChangeStream_SubscribeChangesServer
+	ctx  context.Context
+	sent []*proxypb.ChangeEvents
+}
+
+func (s *mockChangeStreamServer) Context() context.Context {
+	return s.ctx
+}
+
+func (s *mockChangeStreamServer) Send(events *proxypb.ChangeEvents) error {
+	s.sent = append(s.sent, events)
+	return nil
+}
+
+func TestProxy_SubscribeChanges(t *testing.T) {
+	paramtable.Init()
+
+	t.Run("unhealthy", func(t *testing.T) {
+		node := &Proxy{}
+		node.UpdateStateCode(commonpb.StateCode_Abnormal)
+		server := &mockChangeStreamServer{ctx: context.Background()}
+		err := node.SubscribeChanges(&proxypb.SubscribeChangesRequest{CollectionName: "coll"}, server)
+		assert.NoError(t, err)
+		assert.Len(t, server.sent, 1)
+		assert.ErrorIs(t, merr.Error(server.sent[0].GetStatus()), merr.ErrServiceNotReady)
+	})
+
+	t.Run("too many subscriptions", func(t *testing.T) {
+		paramtable.Get().Save(paramtable.Get().ProxyCfg.MaxChangeStreamNum.Key, "0")
+		defer paramtable.Get().Reset(paramtable.Get().ProxyCfg.MaxChangeStreamNum.Key)
+
+		node := &Proxy{}
+		node.UpdateStateCode(commonpb.StateCode_Healthy)
+		server := &mockChangeStreamServer{ctx: context.Background()}
+		err := node.SubscribeChanges(&proxypb.SubscribeChangesRequest{CollectionName: "coll"}, server)
+		assert.NoError(t, err)
+		assert.Len(t, server.sent, 1)
+		assert.ErrorIs(t, merr.Error(server.sent[0].GetStatus()), merr.ErrServiceQuotaExceeded)
+		assert.Equal(t, int32(0), node.changeStreamNum.Load())
+	})
+
+	t.Run("row policy", func(t *testing.T) {
+		paramtable.Get().Save(Params.CommonCfg.AuthorizationEnabled.Key, "true")
+		defer paramtable.Get().Reset(Params.CommonCfg.AuthorizationEnabled.Key)
+
+		cacheBak := globalMetaCache
+		defer func() { globalMetaCache = cacheBak }()
+		cache := NewMockCache(t)
+		cache.EXPECT().GetCollectionID(mock.Anything, mock.Anything, "coll").Return(1, nil)
+		cache.EXPECT().GetCollectionSchema(mock.Anything, mock.Anything, "coll").Return(newChangeStreamTestSchema(), nil)
+		cache.EXPECT().GetUserRole("alice").Return([]string{"role1"})
+		cache.EXPECT().GetRowPolicies(mock.Anything, "default", "coll").Return([]string{"value > 1"})
+		globalMetaCache = cache
+
+		node := &Proxy{}
+		node.UpdateStateCode(commonpb.StateCode_Healthy)
+		server := &mockChangeStreamServer{ctx: GetContext(context.Background(), "alice:123456")}
+		err := node.SubscribeChanges(&proxypb.SubscribeChangesRequest{CollectionName: "coll"}, server)
+		assert.NoError(t, err)
+		assert.Len(t, server.sent, 1)
+		assert.ErrorIs(t, merr.Error(server.sent[0].GetStatus()), merr.ErrPrivilegeNotPermitted)
+	})
+}
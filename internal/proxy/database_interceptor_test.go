@@ -11,6 +11,7 @@ import (
 	"google.golang.org/protobuf/proto"
 
 	"github.com/milvus-io/milvus-proto/go-api/v2/milvuspb"
+	"github.com/milvus-io/milvus/internal/proto/proxypb"
 	"github.com/milvus-io/milvus/pkg/util"
 )
 
@@ -95,6 +96,18 @@ func TestDatabaseInterceptor(t *testing.T) {
 			&milvuspb.OperatePrivilegeRequest{Entity: &milvuspb.GrantEntity{}},
 			&milvuspb.SelectGrantRequest{Entity: &milvuspb.GrantEntity{}},
 			&milvuspb.ManualCompactionRequest{},
+			&proxypb.ListRecycleBinRequest{},
+			&proxypb.UndropCollectionRequest{},
+			&proxypb.UndropPartitionRequest{},
+			&proxypb.SubscribeChangesRequest{},
+			&proxypb.CreateSnapshotRequest{},
+			&proxypb.DropSnapshotRequest{},
+			&proxypb.ListSnapshotsRequest{},
+			&proxypb.RestoreSnapshotRequest{},
+			&proxypb.ExportRequest{},
+			&proxypb.SetRowPolicyRequest{},
+			&proxypb.DropRowPolicyRequest{},
+			&proxypb.ListRowPoliciesRequest{},
 		}
 
 		md := metadata.Pairs(util.HeaderDBName, "db")
@@ -139,3 +152,34 @@ func TestDatabaseInterceptor(t *testing.T) {
 		}
 	})
 }
+
+// mockRecvServerStream is the server stream receiving the request once.
+type mockRecvServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+	req proto.Message
+}
+
+func (s *mockRecvServerStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *mockRecvServerStream) RecvMsg(m any) error {
+	proto.Merge(m.(proto.Message), s.req)
+	return nil
+}
+
+func TestDatabaseStreamInterceptor(t *testing.T) {
+	interceptor := DatabaseStreamInterceptor()
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(util.HeaderDBName, "db"))
+	stream := &mockRecvServerStream{ctx: ctx, req: &proxypb.SubscribeChangesRequest{CollectionName: "coll"}}
+
+	err := interceptor(nil, stream, &grpc.StreamServerInfo{}, func(srv any, ss grpc.ServerStream) error {
+		req := &proxypb.SubscribeChangesRequest{}
+		assert.NoError(t, ss.RecvMsg(req))
+		assert.Equal(t, "db", req.GetDbName())
+		assert.Equal(t, "coll", req.GetCollectionName())
+		return nil
+	})
+	assert.NoError(t, err)
+}
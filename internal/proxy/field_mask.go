@@ -0,0 +1,144 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"context"
+	"strings"
+
+	"github.com/samber/lo"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
+	"github.com/milvus-io/milvus-proto/go-api/v2/schemapb"
+	"github.com/milvus-io/milvus/internal/parser/planparserv2"
+	"github.com/milvus-io/milvus/internal/proto/planpb"
+	"github.com/milvus-io/milvus/pkg/util"
+	"github.com/milvus-io/milvus/pkg/util/funcutil"
+	"github.com/milvus-io/milvus/pkg/util/merr"
+)
+
+// getMaskedFields returns the fields of the collection masked for the current user, field id -> field name.
+// A field is masked if any role of the user is granted the MaskField privilege on it.
+func getMaskedFields(ctx context.Context, dbName, collectionName string, schema *schemaInfo) (map[int64]string, error) {
+	roles, err := getRestrictedRoles(ctx)
+	if err != nil || len(roles) == 0 {
+		return nil, err
+	}
+	if dbName == "" {
+		dbName = util.DefaultDBName
+	}
+	names := globalMetaCache.GetMaskedFields(roles, dbName, collectionName)
+	masked := make(map[int64]string, len(names))
+	for _, name := range names {
+		field, err := schema.schemaHelper.GetFieldFromName(name)
+		if err != nil {
+			// the grant is kept after the field is dropped
+			continue
+		}
+		masked[field.GetFieldID()] = name
+	}
+	return masked, nil
+}
+
+// checkMaskedFieldIDs refuses the request using the masked fields, in filter, group by or aggregation.
+func checkMaskedFieldIDs(masked map[int64]string, fieldIDs ...int64) error {
+	for _, fieldID := range fieldIDs {
+		if name, ok := masked[fieldID]; ok {
+			return merr.WrapErrPrivilegeNotPermitted("field %s is masked for the current user", name)
+		}
+	}
+	return nil
+}
+
+// checkMaskedFieldsInExpr refuses the filter expr referencing the masked fields.
+func checkMaskedFieldsInExpr(schema *schemaInfo, masked map[int64]string, expr string, templateValues map[string]*schemapb.TemplateValue) error {
+	if len(masked) == 0 || strings.TrimSpace(expr) == "" {
+		return nil
+	}
+	parsed, err := planparserv2.ParseExpr(schema.schemaHelper, expr, templateValues)
+	if err != nil {
+		// the invalid expr is reported when creating the plan
+		return nil
+	}
+	fieldIDs := make([]int64, 0)
+	collectColumnFieldIDs(parsed.ProtoReflect(), &fieldIDs)
+	return checkMaskedFieldIDs(masked, fieldIDs...)
+}
+
+// collectColumnFieldIDs collects the ids of all the columns referenced by the plan message.
+func collectColumnFieldIDs(msg protoreflect.Message, fieldIDs *[]int64) {
+	if info, ok := msg.Interface().(*planpb.ColumnInfo); ok {
+		*fieldIDs = append(*fieldIDs, info.GetFieldId())
+	}
+	msg.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		switch {
+		case fd.IsMap() || fd.Message() == nil:
+		case fd.IsList():
+			list := v.List()
+			for i := 0; i < list.Len(); i++ {
+				collectColumnFieldIDs(list.Get(i).Message(), fieldIDs)
+			}
+		default:
+			collectColumnFieldIDs(v.Message(), fieldIDs)
+		}
+		return true
+	})
+}
+
+// checkMaskedOutputFields refuses the request explicitly outputting the masked fields if proxy.rejectMaskedOutputFields is set,
+// the masked fields are dropped from the output fields by removeMaskedOutputFields otherwise.
+func checkMaskedOutputFields(masked map[int64]string, outputFields []string) error {
+	if len(masked) == 0 || !Params.ProxyCfg.RejectMaskedOutputFields.GetAsBool() {
+		return nil
+	}
+	for _, name := range masked {
+		if lo.Contains(outputFields, name) {
+			return merr.WrapErrPrivilegeNotPermitted("field %s is masked for the current user", name)
+		}
+	}
+	return nil
+}
+
+func removeMaskedOutputFields(masked map[int64]string, outputFields []string) []string {
+	if len(masked) == 0 {
+		return outputFields
+	}
+	names := lo.Values(masked)
+	return lo.Filter(outputFields, func(name string, _ int) bool {
+		return !lo.Contains(names, name)
+	})
+}
+
+// validateFieldPrivilegeObject checks the object of the field privileges is a field of the collection, named <collection>$<field>.
+func validateFieldPrivilegeObject(privilege, objectType, objectName string) error {
+	if privilege != util.MetaStore2API(util.PrivilegeMaskField) {
+		return nil
+	}
+	if objectType != commonpb.ObjectType_Collection.String() {
+		return merr.WrapErrParameterInvalidMsg("the object type of the %s privilege must be %s", privilege, commonpb.ObjectType_Collection.String())
+	}
+	collectionName, fieldName, ok := funcutil.SplitFieldObjectName(objectName)
+	if !ok || util.IsAnyWord(collectionName) {
+		return merr.WrapErrParameterInvalidMsg("the object name of the %s privilege must be <collection>%s<field>, got %s",
+			privilege, util.FieldObjectNameSeparator, objectName)
+	}
+	if err := ValidateCollectionName(collectionName); err != nil {
+		return err
+	}
+	return validateFieldName(fieldName)
+}
@@ -0,0 +1,135 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
+	"github.com/milvus-io/milvus-proto/go-api/v2/schemapb"
+	"github.com/milvus-io/milvus/internal/mocks"
+	"github.com/milvus-io/milvus/internal/proto/internalpb"
+	"github.com/milvus-io/milvus/pkg/common"
+	"github.com/milvus-io/milvus/pkg/util"
+	"github.com/milvus-io/milvus/pkg/util/funcutil"
+	"github.com/milvus-io/milvus/pkg/util/merr"
+	"github.com/milvus-io/milvus/pkg/util/paramtable"
+)
+
+func newFieldMaskSchema() *schemaInfo {
+	return newSchemaInfo(&schemapb.CollectionSchema{
+		Name: "coll",
+		Fields: []*schemapb.FieldSchema{
+			{FieldID: 100, Name: "pk", IsPrimaryKey: true, DataType: schemapb.DataType_Int64},
+			{FieldID: 101, Name: "email", DataType: schemapb.DataType_VarChar, TypeParams: []*commonpb.KeyValuePair{{Key: common.MaxLengthKey, Value: "128"}}},
+			{FieldID: 102, Name: "age", DataType: schemapb.DataType_Int64},
+			{FieldID: 103, Name: "vec", DataType: schemapb.DataType_FloatVector, TypeParams: []*commonpb.KeyValuePair{{Key: common.DimKey, Value: "2"}}},
+		},
+	})
+}
+
+func TestGetMaskedFields(t *testing.T) {
+	paramtable.Init()
+	client := &MockRootCoordClientInterface{}
+	client.listPolicy = func(ctx context.Context, in *internalpb.ListPolicyRequest) (*internalpb.ListPolicyResponse, error) {
+		return &internalpb.ListPolicyResponse{
+			Status: merr.Success(),
+			PolicyInfos: []string{
+				funcutil.PolicyForPrivilege("role1", commonpb.ObjectType_Collection.String(), funcutil.CombineFieldObjectName("coll", "email"), util.PrivilegeMaskField, "default"),
+				funcutil.PolicyForPrivilege("role1", commonpb.ObjectType_Collection.String(), funcutil.CombineFieldObjectName("coll", "dropped"), util.PrivilegeMaskField, "default"),
+				funcutil.PolicyForPrivilege("role2", commonpb.ObjectType_Collection.String(), funcutil.CombineFieldObjectName("coll", "age"), util.PrivilegeMaskField, "default"),
+				funcutil.PolicyForPrivilege("role1", commonpb.ObjectType_Collection.String(), "coll", commonpb.ObjectPrivilege_PrivilegeQuery.String(), "default"),
+			},
+			UserRoles: []string{funcutil.EncodeUserRoleCache("alice", "role1")},
+		}, nil
+	}
+	err := InitMetaCache(context.Background(), client, &mocks.MockQueryCoordClient{}, newShardClientMgr())
+	assert.NoError(t, err)
+	schema := newFieldMaskSchema()
+
+	masked, err := getMaskedFields(GetContext(context.Background(), "alice:123456"), "", "coll", schema)
+	assert.NoError(t, err)
+	assert.Empty(t, masked)
+
+	paramtable.Get().Save(Params.CommonCfg.AuthorizationEnabled.Key, "true")
+	defer paramtable.Get().Reset(Params.CommonCfg.AuthorizationEnabled.Key)
+
+	masked, err = getMaskedFields(GetContext(context.Background(), "alice:123456"), "", "coll", schema)
+	assert.NoError(t, err)
+	assert.Equal(t, map[int64]string{101: "email"}, masked)
+
+	masked, err = getMaskedFields(GetContext(context.Background(), "alice:123456"), "db2", "coll", schema)
+	assert.NoError(t, err)
+	assert.Empty(t, masked)
+
+	masked, err = getMaskedFields(GetContext(context.Background(), "root:123456"), "", "coll", schema)
+	assert.NoError(t, err)
+	assert.Empty(t, masked)
+}
+
+func TestCheckMaskedFieldsInExpr(t *testing.T) {
+	schema := newFieldMaskSchema()
+	masked := map[int64]string{101: "email"}
+
+	assert.NoError(t, checkMaskedFieldsInExpr(schema, nil, `email == "a"`, nil))
+	assert.NoError(t, checkMaskedFieldsInExpr(schema, masked, "", nil))
+	assert.NoError(t, checkMaskedFieldsInExpr(schema, masked, "age > 10 and pk in [1, 2]", nil))
+
+	for _, expr := range []string{
+		`email == "a"`,
+		`age > 10 and (pk < 5 or email like "a%")`,
+		`not (email in ["a", "b"])`,
+		`email in {emails}`,
+	} {
+		err := checkMaskedFieldsInExpr(schema, masked, expr, map[string]*schemapb.TemplateValue{
+			"emails": {Val: &schemapb.TemplateValue_ArrayVal{ArrayVal: &schemapb.TemplateArrayValue{
+				Data: &schemapb.TemplateArrayValue_StringData{StringData: &schemapb.StringArray{Data: []string{"a"}}},
+			}}},
+		})
+		assert.ErrorIs(t, err, merr.ErrPrivilegeNotPermitted, expr)
+	}
+}
+
+func TestMaskedOutputFields(t *testing.T) {
+	paramtable.Init()
+	masked := map[int64]string{101: "email"}
+
+	assert.NoError(t, checkMaskedOutputFields(masked, []string{"email", "age"}))
+	assert.Equal(t, []string{"pk", "age"}, removeMaskedOutputFields(masked, []string{"pk", "email", "age"}))
+	assert.Equal(t, []string{"pk", "email"}, removeMaskedOutputFields(nil, []string{"pk", "email"}))
+
+	paramtable.Get().Save(Params.ProxyCfg.RejectMaskedOutputFields.Key, "true")
+	defer paramtable.Get().Reset(Params.ProxyCfg.RejectMaskedOutputFields.Key)
+	assert.ErrorIs(t, checkMaskedOutputFields(masked, []string{"email", "age"}), merr.ErrPrivilegeNotPermitted)
+	assert.NoError(t, checkMaskedOutputFields(masked, []string{"*"}))
+}
+
+func TestValidateFieldPrivilegeObject(t *testing.T) {
+	paramtable.Init()
+	maskField := util.MetaStore2API(util.PrivilegeMaskField)
+	collection := commonpb.ObjectType_Collection.String()
+
+	assert.NoError(t, validateFieldPrivilegeObject("Query", collection, "coll"))
+	assert.NoError(t, validateFieldPrivilegeObject(maskField, collection, "coll$email"))
+	assert.Error(t, validateFieldPrivilegeObject(maskField, commonpb.ObjectType_Global.String(), "coll$email"))
+	assert.Error(t, validateFieldPrivilegeObject(maskField, collection, "coll"))
+	assert.Error(t, validateFieldPrivilegeObject(maskField, collection, "*$email"))
+	assert.Error(t, validateFieldPrivilegeObject(maskField, collection, "coll$1email"))
+}
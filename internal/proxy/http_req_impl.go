@@ -147,6 +147,29 @@ func getSlowQuery(node *Proxy) gin.HandlerFunc {
 	}
 }
 
+// getQueryProfiles returns the latest profiles of search/query requests, newest first,
+// filtered by the trace_id parameter if given.
+func getQueryProfiles(node *Proxy) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		traceID := c.Query("trace_id")
+		profiles := node.queryProfiles.Values()
+		ret := make([]*metricsinfo.QueryProfile, 0, len(profiles))
+		for i := len(profiles) - 1; i >= 0; i-- {
+			if traceID == "" || profiles[i].TraceID == traceID {
+				ret = append(ret, profiles[i])
+			}
+		}
+		bs, err := json.Marshal(ret)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{
+				mhttp.HTTPReturnMessage: err.Error(),
+			})
+			return
+		}
+		c.Data(http.StatusOK, contentType, bs)
+	}
+}
+
 // buildReqParams fetch all parameters from query parameter of URL, add them into a map data structure.
 // put key and value from query parameter into map, concatenate values with separator if values size is greater than 1
 func buildReqParams(c *gin.Context, metricsType string) map[string]interface{} {
@@ -4,6 +4,7 @@ import (
 	"context"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/cockroachdb/errors"
@@ -19,6 +20,7 @@ import (
 	"github.com/milvus-io/milvus/internal/proxy/connection"
 	"github.com/milvus-io/milvus/pkg/util/metricsinfo"
 	"github.com/milvus-io/milvus/pkg/util/paramtable"
+	"github.com/milvus-io/milvus/pkg/util/typeutil"
 )
 
 func TestGetConfigs(t *testing.T) {
@@ -77,6 +79,28 @@ func TestGetDependencies(t *testing.T) {
 	assert.Contains(t, w.Body.String(), "metastore")
 }
 
+func TestGetQueryProfiles(t *testing.T) {
+	node := &Proxy{queryProfiles: typeutil.NewRingBuffer[*metricsinfo.QueryProfile](10)}
+	node.queryProfiles.Add(&metricsinfo.QueryProfile{SlowQuery: metricsinfo.SlowQuery{TraceID: "trace1", Collection: "c1"}})
+	node.queryProfiles.Add(&metricsinfo.QueryProfile{SlowQuery: metricsinfo.SlowQuery{TraceID: "trace2", Collection: "c2"}})
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodGet, "/?trace_id=trace2", nil)
+	getQueryProfiles(node)(c)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), "c2")
+	assert.NotContains(t, w.Body.String(), "c1")
+
+	w = httptest.NewRecorder()
+	c, _ = gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodGet, "/", nil)
+	getQueryProfiles(node)(c)
+	assert.Equal(t, http.StatusOK, w.Code)
+	body := w.Body.String()
+	assert.Less(t, strings.Index(body, "c2"), strings.Index(body, "c1"))
+}
+
 func TestBuildReqParams(t *testing.T) {
 	w := httptest.NewRecorder()
 	c, _ := gin.CreateTestContext(w)
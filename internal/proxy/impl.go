@@ -45,6 +45,7 @@ import (
 	"github.com/milvus-io/milvus/internal/proto/internalpb"
 	"github.com/milvus-io/milvus/internal/proto/proxypb"
 	"github.com/milvus-io/milvus/internal/proto/querypb"
+	"github.com/milvus-io/milvus/internal/proto/rootcoordpb"
 	"github.com/milvus-io/milvus/internal/proxy/connection"
 	"github.com/milvus-io/milvus/internal/types"
 	"github.com/milvus-io/milvus/internal/util/hookutil"
@@ -2594,6 +2595,12 @@ func (node *Proxy) Insert(ctx context.Context, request *milvuspb.InsertRequest)
 			Status: merr.Status(err),
 		}, nil
 	}
+	ctx, err := node.withTransaction(ctx, request.GetDbName(), request.GetCollectionName())
+	if err != nil {
+		return &milvuspb.MutationResult{
+			Status: merr.Status(err),
+		}, nil
+	}
 	log := log.Ctx(ctx).With(
 		zap.String("role", typeutil.ProxyRole),
 		zap.String("db", request.DbName),
@@ -2750,6 +2757,12 @@ func (node *Proxy) Delete(ctx context.Context, request *milvuspb.DeleteRequest)
 			Status: merr.Status(err),
 		}, nil
 	}
+	ctx, err := node.withTransaction(ctx, request.GetDbName(), request.GetCollectionName())
+	if err != nil {
+		return &milvuspb.MutationResult{
+			Status: merr.Status(err),
+		}, nil
+	}
 
 	tr := timerecord.NewTimeRecorder(method)
 
@@ -2845,6 +2858,12 @@ func (node *Proxy) Upsert(ctx context.Context, request *milvuspb.UpsertRequest)
 			Status: merr.Status(err),
 		}, nil
 	}
+	ctx, err := node.withTransaction(ctx, request.GetDbName(), request.GetCollectionName())
+	if err != nil {
+		return &milvuspb.MutationResult{
+			Status: merr.Status(err),
+		}, nil
+	}
 	method := "Upsert"
 	tr := timerecord.NewTimeRecorder(method)
 
@@ -2878,6 +2897,7 @@ func (node *Proxy) Upsert(ctx context.Context, request *milvuspb.UpsertRequest)
 		segIDAssigner: node.segAssigner,
 		chMgr:         node.chMgr,
 		chTicker:      node.chTicker,
+		node:          node,
 	}
 	var enqueuedTask task = it
 	if streamingutil.IsStreamingServiceEnabled() {
@@ -3110,6 +3130,7 @@ func (node *Proxy) search(ctx context.Context, request *milvuspb.SearchRequest,
 		lb:                     node.lbPolicy,
 		enableMaterializedView: node.enableMaterializedView,
 		mustUsePartitionKey:    Params.ProxyCfg.MustUsePartitionKey.GetAsBool(),
+		profiler:               newQueryProfiler(isProfileRequested(request.GetSearchParams())),
 	}
 
 	log := log.Ctx(ctx).With( // TODO: it might cause some cpu consumption
@@ -3127,22 +3148,28 @@ func (node *Proxy) search(ctx context.Context, request *milvuspb.SearchRequest,
 
 	defer func() {
 		span := tr.ElapseSpan()
-		if span >= paramtable.Get().ProxyCfg.SlowQuerySpanInSeconds.GetAsDuration(time.Second) {
+		slow := span >= paramtable.Get().ProxyCfg.SlowQuerySpanInSeconds.GetAsDuration(time.Second)
+		if slow {
 			log.Info(rpcSlow(method), zap.Uint64("guarantee_timestamp", qt.GetGuaranteeTimestamp()),
 				zap.Int64("nq", qt.SearchRequest.GetNq()), zap.Duration("duration", span))
 			metrics.ProxySlowQueryCount.WithLabelValues(
 				strconv.FormatInt(paramtable.GetNodeID(), 10),
 				metrics.SearchLabel,
 			).Inc()
-			user, _ := GetCurUserFromContext(ctx)
-			traceID := ""
-			if sp != nil {
-				traceID = sp.SpanContext().TraceID().String()
-			}
-			if node.slowQueries != nil {
-				node.slowQueries.Add(qt.BeginTs(), metricsinfo.NewSlowQueryWithSearchRequest(request, user, span, traceID))
-			}
 		}
+		if !slow && qt.profiler == nil {
+			return
+		}
+		user, _ := GetCurUserFromContext(ctx)
+		traceID := ""
+		if sp != nil {
+			traceID = sp.SpanContext().TraceID().String()
+		}
+		slowQuery := metricsinfo.NewSlowQueryWithSearchRequest(request, user, span, traceID)
+		if slow && node.slowQueries != nil {
+			node.slowQueries.Add(qt.BeginTs(), slowQuery)
+		}
+		node.addQueryProfile(qt.profiler, slowQuery, slow)
 	}()
 
 	log.Debug(rpcReceived(method))
@@ -3330,6 +3357,7 @@ func (node *Proxy) hybridSearch(ctx context.Context, request *milvuspb.HybridSea
 		node:                node,
 		lb:                  node.lbPolicy,
 		mustUsePartitionKey: Params.ProxyCfg.MustUsePartitionKey.GetAsBool(),
+		profiler:            newQueryProfiler(isProfileRequested(newSearchReq.GetSearchParams())),
 	}
 
 	log := log.Ctx(ctx).With(
@@ -3344,21 +3372,27 @@ func (node *Proxy) hybridSearch(ctx context.Context, request *milvuspb.HybridSea
 
 	defer func() {
 		span := tr.ElapseSpan()
-		if span >= paramtable.Get().ProxyCfg.SlowQuerySpanInSeconds.GetAsDuration(time.Second) {
+		slow := span >= paramtable.Get().ProxyCfg.SlowQuerySpanInSeconds.GetAsDuration(time.Second)
+		if slow {
 			log.Info(rpcSlow(method), zap.Uint64("guarantee_timestamp", qt.GetGuaranteeTimestamp()), zap.Duration("duration", span))
 			metrics.ProxySlowQueryCount.WithLabelValues(
 				strconv.FormatInt(paramtable.GetNodeID(), 10),
 				metrics.HybridSearchLabel,
 			).Inc()
-			user, _ := GetCurUserFromContext(ctx)
-			traceID := ""
-			if sp != nil {
-				traceID = sp.SpanContext().TraceID().String()
-			}
-			if node.slowQueries != nil {
-				node.slowQueries.Add(qt.BeginTs(), metricsinfo.NewSlowQueryWithSearchRequest(newSearchReq, user, span, traceID))
-			}
 		}
+		if !slow && qt.profiler == nil {
+			return
+		}
+		user, _ := GetCurUserFromContext(ctx)
+		traceID := ""
+		if sp != nil {
+			traceID = sp.SpanContext().TraceID().String()
+		}
+		slowQuery := metricsinfo.NewSlowQueryWithSearchRequest(newSearchReq, user, span, traceID)
+		if slow && node.slowQueries != nil {
+			node.slowQueries.Add(qt.BeginTs(), slowQuery)
+		}
+		node.addQueryProfile(qt.profiler, slowQuery, slow)
 	}()
 
 	log.Debug(rpcReceived(method))
@@ -3629,7 +3663,8 @@ func (node *Proxy) query(ctx context.Context, qt *queryTask, sp trace.Span) (*mi
 
 	defer func() {
 		span := tr.ElapseSpan()
-		if span >= paramtable.Get().ProxyCfg.SlowQuerySpanInSeconds.GetAsDuration(time.Second) {
+		slow := span >= paramtable.Get().ProxyCfg.SlowQuerySpanInSeconds.GetAsDuration(time.Second)
+		if slow {
 			log.Info(
 				rpcSlow(method),
 				zap.String("expr", request.Expr),
@@ -3641,16 +3676,21 @@ func (node *Proxy) query(ctx context.Context, qt *queryTask, sp trace.Span) (*mi
 				strconv.FormatInt(paramtable.GetNodeID(), 10),
 				metrics.QueryLabel,
 			).Inc()
-			user, _ := GetCurUserFromContext(ctx)
-			traceID := ""
-			if sp != nil {
-				traceID = sp.SpanContext().TraceID().String()
-			}
+		}
+		if !slow && qt.profiler == nil {
+			return
+		}
+		user, _ := GetCurUserFromContext(ctx)
+		traceID := ""
+		if sp != nil {
+			traceID = sp.SpanContext().TraceID().String()
+		}
 
-			if node.slowQueries != nil {
-				node.slowQueries.Add(qt.BeginTs(), metricsinfo.NewSlowQueryWithQueryRequest(request, user, span, traceID))
-			}
+		slowQuery := metricsinfo.NewSlowQueryWithQueryRequest(request, user, span, traceID)
+		if slow && node.slowQueries != nil {
+			node.slowQueries.Add(qt.BeginTs(), slowQuery)
 		}
+		node.addQueryProfile(qt.profiler, slowQuery, slow)
 	}()
 
 	if err := node.sched.dqQueue.Enqueue(qt); err != nil {
@@ -3732,6 +3772,7 @@ func (node *Proxy) Query(ctx context.Context, request *milvuspb.QueryRequest) (*
 		qc:                  node.queryCoord,
 		lb:                  node.lbPolicy,
 		mustUsePartitionKey: Params.ProxyCfg.MustUsePartitionKey.GetAsBool(),
+		profiler:            newQueryProfiler(isProfileRequested(request.GetQueryParams())),
 	}
 
 	subLabel := GetCollectionRateSubLabel(request)
@@ -5422,6 +5463,9 @@ func (node *Proxy) validPrivilegeParams(req *milvuspb.OperatePrivilegeRequest) e
 	if err := ValidateObjectName(req.Entity.ObjectName); err != nil {
 		return err
 	}
+	if err := validateFieldPrivilegeObject(req.Entity.Grantor.Privilege.Name, req.Entity.Object.Name, req.Entity.ObjectName); err != nil {
+		return err
+	}
 	if req.Entity.Role == nil {
 		return fmt.Errorf("the object entity in the grant entity is nil")
 	}
@@ -5457,6 +5501,9 @@ func (node *Proxy) validateOperatePrivilegeV2Params(req *milvuspb.OperatePrivile
 	if err := ValidateCollectionName(req.CollectionName); err != nil {
 		return err
 	}
+	if err := validateFieldPrivilegeObject(req.Grantor.Privilege.Name, commonpb.ObjectType_Collection.String(), req.CollectionName); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -6702,6 +6749,1010 @@ func (node *Proxy) ListImports(ctx context.Context, req *internalpb.ListImportsR
 	return resp, nil
 }
 
+// BeginTransaction begins a transaction on the collection, the inserts, deletes and upserts
+// carrying the transaction id in the metadata are not visible until the transaction is committed.
+func (node *Proxy) BeginTransaction(ctx context.Context, req *proxypb.BeginTransactionRequest) (*proxypb.BeginTransactionResponse, error) {
+	if err := merr.CheckHealthy(node.GetStateCode()); err != nil {
+		return &proxypb.BeginTransactionResponse{
+			Status: merr.Status(err),
+		}, nil
+	}
+	dbName := req.GetDbName()
+	if dbName == "" {
+		dbName = GetCurDBNameFromContextOrDefault(ctx)
+	}
+	log := log.Ctx(ctx).With(
+		zap.String("dbName", dbName),
+		zap.String("collectionName", req.GetCollectionName()),
+	)
+	method := "BeginTransaction"
+	log.Info(rpcReceived(method))
+
+	nodeID := fmt.Sprint(paramtable.GetNodeID())
+	metrics.ProxyFunctionCall.WithLabelValues(nodeID, method, metrics.TotalLabel, dbName, req.GetCollectionName()).Inc()
+	if req.GetKeepaliveMs() < 0 {
+		err := merr.WrapErrParameterInvalidMsg("invalid keepalive %dms", req.GetKeepaliveMs())
+		metrics.ProxyFunctionCall.WithLabelValues(nodeID, method, metrics.FailLabel, dbName, req.GetCollectionName()).Inc()
+		return &proxypb.BeginTransactionResponse{Status: merr.Status(err)}, nil
+	}
+
+	txn, err := func() (*transaction, error) {
+		collectionID, err := globalMetaCache.GetCollectionID(ctx, dbName, req.GetCollectionName())
+		if err != nil {
+			return nil, err
+		}
+		vchannels, err := node.chMgr.getVChannels(collectionID)
+		if err != nil {
+			return nil, err
+		}
+		return node.txnManager.begin(ctx, GetCurUserFromContextOrDefault(ctx), dbName, req.GetCollectionName(), collectionID, vchannels,
+			time.Duration(req.GetKeepaliveMs())*time.Millisecond)
+	}()
+	if err != nil {
+		log.Warn(rpcFailedToWaitToFinish(method), zap.Error(err))
+		metrics.ProxyFunctionCall.WithLabelValues(nodeID, method, metrics.FailLabel, dbName, req.GetCollectionName()).Inc()
+		return &proxypb.BeginTransactionResponse{Status: merr.Status(err)}, nil
+	}
+	log.Info(rpcDone(method), zap.Int64("txnID", txn.id), zap.Duration("keepalive", txn.keepalive))
+	metrics.ProxyFunctionCall.WithLabelValues(nodeID, method, metrics.SuccessLabel, dbName, req.GetCollectionName()).Inc()
+	return &proxypb.BeginTransactionResponse{
+		Status:      merr.Success(),
+		TxnID:       txn.id,
+		KeepaliveMs: txn.keepalive.Milliseconds(),
+	}, nil
+}
+
+// CommitTransaction commits the transaction, the written data become visible together.
+func (node *Proxy) CommitTransaction(ctx context.Context, req *proxypb.CommitTransactionRequest) (*proxypb.CommitTransactionResponse, error) {
+	if err := merr.CheckHealthy(node.GetStateCode()); err != nil {
+		return &proxypb.CommitTransactionResponse{
+			Status: merr.Status(err),
+		}, nil
+	}
+	log := log.Ctx(ctx).With(zap.Int64("txnID", req.GetTxnID()))
+	method := "CommitTransaction"
+	log.Info(rpcReceived(method))
+
+	nodeID := fmt.Sprint(paramtable.GetNodeID())
+	metrics.ProxyFunctionCall.WithLabelValues(nodeID, method, metrics.TotalLabel, "", "").Inc()
+	ts, err := node.txnManager.commit(ctx, req.GetTxnID(), GetCurUserFromContextOrDefault(ctx))
+	if err != nil {
+		log.Warn(rpcFailedToWaitToFinish(method), zap.Error(err))
+		metrics.ProxyFunctionCall.WithLabelValues(nodeID, method, metrics.FailLabel, "", "").Inc()
+		return &proxypb.CommitTransactionResponse{Status: merr.Status(err)}, nil
+	}
+	log.Info(rpcDone(method), zap.Uint64("timestamp", ts))
+	metrics.ProxyFunctionCall.WithLabelValues(nodeID, method, metrics.SuccessLabel, "", "").Inc()
+	return &proxypb.CommitTransactionResponse{
+		Status:    merr.Success(),
+		Timestamp: ts,
+	}, nil
+}
+
+// RollbackTransaction rollbacks the transaction, the written data are discarded.
+func (node *Proxy) RollbackTransaction(ctx context.Context, req *proxypb.RollbackTransactionRequest) (*commonpb.Status, error) {
+	if err := merr.CheckHealthy(node.GetStateCode()); err != nil {
+		return merr.Status(err), nil
+	}
+	log := log.Ctx(ctx).With(zap.Int64("txnID", req.GetTxnID()))
+	method := "RollbackTransaction"
+	log.Info(rpcReceived(method))
+
+	nodeID := fmt.Sprint(paramtable.GetNodeID())
+	metrics.ProxyFunctionCall.WithLabelValues(nodeID, method, metrics.TotalLabel, "", "").Inc()
+	if err := node.txnManager.rollback(ctx, req.GetTxnID(), GetCurUserFromContextOrDefault(ctx)); err != nil {
+		log.Warn(rpcFailedToWaitToFinish(method), zap.Error(err))
+		metrics.ProxyFunctionCall.WithLabelValues(nodeID, method, metrics.FailLabel, "", "").Inc()
+		return merr.Status(err), nil
+	}
+	log.Info(rpcDone(method))
+	metrics.ProxyFunctionCall.WithLabelValues(nodeID, method, metrics.SuccessLabel, "", "").Inc()
+	return merr.Success(), nil
+}
+
+// ListRecycleBin lists the dropped collections and partitions of the database which can be restored.
+func (node *Proxy) ListRecycleBin(ctx context.Context, req *proxypb.ListRecycleBinRequest) (*proxypb.ListRecycleBinResponse, error) {
+	if err := merr.CheckHealthy(node.GetStateCode()); err != nil {
+		return &proxypb.ListRecycleBinResponse{
+			Status: merr.Status(err),
+		}, nil
+	}
+	if req.GetDbName() == "" {
+		req.DbName = GetCurDBNameFromContextOrDefault(ctx)
+	}
+	log := log.Ctx(ctx).With(zap.String("dbName", req.GetDbName()))
+	method := "ListRecycleBin"
+	log.Debug(rpcReceived(method))
+
+	nodeID := fmt.Sprint(paramtable.GetNodeID())
+	metrics.ProxyFunctionCall.WithLabelValues(nodeID, method, metrics.TotalLabel, req.GetDbName(), "").Inc()
+	resp, err := node.rootCoord.ListRecycleBin(ctx, req)
+	if err = merr.CheckRPCCall(resp, err); err != nil {
+		log.Warn(rpcFailedToWaitToFinish(method), zap.Error(err))
+		metrics.ProxyFunctionCall.WithLabelValues(nodeID, method, metrics.FailLabel, req.GetDbName(), "").Inc()
+		return &proxypb.ListRecycleBinResponse{Status: merr.Status(err)}, nil
+	}
+	log.Debug(rpcDone(method), zap.Int("collections", len(resp.GetCollections())), zap.Int("partitions", len(resp.GetPartitions())))
+	metrics.ProxyFunctionCall.WithLabelValues(nodeID, method, metrics.SuccessLabel, req.GetDbName(), "").Inc()
+	return resp, nil
+}
+
+// UndropCollection restores the dropped collection from the recycle bin, it must be loaded again to be searched.
+func (node *Proxy) UndropCollection(ctx context.Context, req *proxypb.UndropCollectionRequest) (*commonpb.Status, error) {
+	if err := merr.CheckHealthy(node.GetStateCode()); err != nil {
+		return merr.Status(err), nil
+	}
+	if req.GetDbName() == "" {
+		req.DbName = GetCurDBNameFromContextOrDefault(ctx)
+	}
+	log := log.Ctx(ctx).With(
+		zap.String("dbName", req.GetDbName()),
+		zap.String("collectionName", req.GetCollectionName()),
+		zap.Int64("collectionID", req.GetCollectionID()),
+	)
+	method := "UndropCollection"
+	log.Info(rpcReceived(method))
+
+	nodeID := fmt.Sprint(paramtable.GetNodeID())
+	metrics.ProxyFunctionCall.WithLabelValues(nodeID, method, metrics.TotalLabel, req.GetDbName(), req.GetCollectionName()).Inc()
+	if err := validateCollectionName(req.GetCollectionName()); err != nil {
+		metrics.ProxyFunctionCall.WithLabelValues(nodeID, method, metrics.FailLabel, req.GetDbName(), req.GetCollectionName()).Inc()
+		return merr.Status(err), nil
+	}
+	status, err := node.rootCoord.UndropCollection(ctx, req)
+	if err = merr.CheckRPCCall(status, err); err != nil {
+		log.Warn(rpcFailedToWaitToFinish(method), zap.Error(err))
+		metrics.ProxyFunctionCall.WithLabelValues(nodeID, method, metrics.FailLabel, req.GetDbName(), req.GetCollectionName()).Inc()
+		return merr.Status(err), nil
+	}
+	log.Info(rpcDone(method))
+	metrics.ProxyFunctionCall.WithLabelValues(nodeID, method, metrics.SuccessLabel, req.GetDbName(), req.GetCollectionName()).Inc()
+	return merr.Success(), nil
+}
+
+// UndropPartition restores the dropped partition from the recycle bin, it must be loaded again to be searched.
+func (node *Proxy) UndropPartition(ctx context.Context, req *proxypb.UndropPartitionRequest) (*commonpb.Status, error) {
+	if err := merr.CheckHealthy(node.GetStateCode()); err != nil {
+		return merr.Status(err), nil
+	}
+	if req.GetDbName() == "" {
+		req.DbName = GetCurDBNameFromContextOrDefault(ctx)
+	}
+	log := log.Ctx(ctx).With(
+		zap.String("dbName", req.GetDbName()),
+		zap.String("collectionName", req.GetCollectionName()),
+		zap.String("partitionName", req.GetPartitionName()),
+		zap.Int64("partitionID", req.GetPartitionID()),
+	)
+	method := "UndropPartition"
+	log.Info(rpcReceived(method))
+
+	nodeID := fmt.Sprint(paramtable.GetNodeID())
+	metrics.ProxyFunctionCall.WithLabelValues(nodeID, method, metrics.TotalLabel, req.GetDbName(), req.GetCollectionName()).Inc()
+	if err := validateCollectionName(req.GetCollectionName()); err != nil {
+		metrics.ProxyFunctionCall.WithLabelValues(nodeID, method, metrics.FailLabel, req.GetDbName(), req.GetCollectionName()).Inc()
+		return merr.Status(err), nil
+	}
+	if err := validatePartitionTag(req.GetPartitionName(), true); err != nil {
+		metrics.ProxyFunctionCall.WithLabelValues(nodeID, method, metrics.FailLabel, req.GetDbName(), req.GetCollectionName()).Inc()
+		return merr.Status(err), nil
+	}
+	status, err := node.rootCoord.UndropPartition(ctx, req)
+	if err = merr.CheckRPCCall(status, err); err != nil {
+		log.Warn(rpcFailedToWaitToFinish(method), zap.Error(err))
+		metrics.ProxyFunctionCall.WithLabelValues(nodeID, method, metrics.FailLabel, req.GetDbName(), req.GetCollectionName()).Inc()
+		return merr.Status(err), nil
+	}
+	log.Info(rpcDone(method))
+	metrics.ProxyFunctionCall.WithLabelValues(nodeID, method, metrics.SuccessLabel, req.GetDbName(), req.GetCollectionName()).Inc()
+	return merr.Success(), nil
+}
+
+// SubscribeChanges sends the inserts, upserts and deletes of the collection until the subscriber cancels,
+// the failure is sent as the status of the last events.
+func (node *Proxy) SubscribeChanges(req *proxypb.SubscribeChangesRequest, server proxypb.ChangeStream_SubscribeChangesServer) error {
+	ctx := server.Context()
+	if err := merr.CheckHealthy(node.GetStateCode()); err != nil {
+		return server.Send(&proxypb.ChangeEvents{Status: merr.Status(err)})
+	}
+	dbName := req.GetDbName()
+	if dbName == "" {
+		dbName = GetCurDBNameFromContextOrDefault(ctx)
+	}
+	log := log.Ctx(ctx).With(
+		zap.String("dbName", dbName),
+		zap.String("collectionName", req.GetCollectionName()),
+		zap.Uint64("startTs", req.GetStartTs()),
+		zap.Bool("resume", req.GetResumeToken() != ""),
+	)
+	method := "SubscribeChanges"
+	log.Info(rpcReceived(method))
+
+	nodeID := fmt.Sprint(paramtable.GetNodeID())
+	metrics.ProxyFunctionCall.WithLabelValues(nodeID, method, metrics.TotalLabel, dbName, req.GetCollectionName()).Inc()
+	fail := func(err error) error {
+		log.Warn(rpcFailedToWaitToFinish(method), zap.Error(err))
+		metrics.ProxyFunctionCall.WithLabelValues(nodeID, method, metrics.FailLabel, dbName, req.GetCollectionName()).Inc()
+		return server.Send(&proxypb.ChangeEvents{Status: merr.Status(err)})
+	}
+	if streamingutil.IsStreamingServiceEnabled() {
+		return fail(merr.WrapErrServiceUnavailable("change stream is not supported with the streaming service enabled"))
+	}
+	if num := node.changeStreamNum.Inc(); num > Params.ProxyCfg.MaxChangeStreamNum.GetAsInt32() {
+		node.changeStreamNum.Dec()
+		return fail(merr.WrapErrServiceQuotaExceeded(fmt.Sprintf("too many change stream subscriptions on the proxy, limit %d",
+			Params.ProxyCfg.MaxChangeStreamNum.GetAsInt())))
+	}
+	defer node.changeStreamNum.Dec()
+
+	cs, err := func() (*changeStream, error) {
+		collectionID, err := globalMetaCache.GetCollectionID(ctx, dbName, req.GetCollectionName())
+		if err != nil {
+			return nil, err
+		}
+		schema, err := globalMetaCache.GetCollectionSchema(ctx, dbName, req.GetCollectionName())
+		if err != nil {
+			return nil, err
+		}
+		// the deletes carry only the primary keys, so the changes can't be filtered by the row policy
+		rowPolicyFilter, err := getRowPolicyFilter(ctx, dbName, req.GetCollectionName(), schema.schemaHelper)
+		if err != nil {
+			return nil, err
+		}
+		if rowPolicyFilter != nil {
+			return nil, merr.WrapErrPrivilegeNotPermitted("change stream is not permitted on collection %s with row policy for the current user",
+				req.GetCollectionName())
+		}
+		masked, err := getMaskedFields(ctx, dbName, req.GetCollectionName(), schema)
+		if err != nil {
+			return nil, err
+		}
+		vchannels, err := node.chMgr.getVChannels(collectionID)
+		if err != nil {
+			return nil, err
+		}
+		return newChangeStream(collectionID, schema, vchannels, req.GetOutputFields(), masked)
+	}()
+	if err != nil {
+		return fail(err)
+	}
+	defer cs.close()
+
+	var positions []*msgpb.MsgPosition
+	if req.GetResumeToken() != "" {
+		positions, err = cs.positionsFromToken(req.GetResumeToken())
+	} else if req.GetStartTs() > 0 {
+		cs.startTs = req.GetStartTs()
+		positions, err = node.changeStreamPositionsFromTs(ctx, cs, req.GetStartTs())
+	}
+	if err == nil {
+		err = cs.subscribe(ctx, node.factory, positions)
+	}
+	if err != nil {
+		return fail(err)
+	}
+	log.Info("change stream subscribed", zap.String("subName", cs.subName))
+
+	err = cs.run(ctx, server.Send)
+	if ctx.Err() != nil {
+		log.Info(rpcDone(method))
+		metrics.ProxyFunctionCall.WithLabelValues(nodeID, method, metrics.SuccessLabel, dbName, req.GetCollectionName()).Inc()
+		return nil
+	}
+	return fail(err)
+}
+
+// snapshotFlushCheckInterval is the interval to check the flush state before creating snapshot
+var snapshotFlushCheckInterval = 200 * time.Millisecond
+
+// CreateSnapshot flushes the collection and pins the flushed segments by the snapshot.
+func (node *Proxy) CreateSnapshot(ctx context.Context, req *proxypb.CreateSnapshotRequest) (*proxypb.CreateSnapshotResponse, error) {
+	if err := merr.CheckHealthy(node.GetStateCode()); err != nil {
+		return &proxypb.CreateSnapshotResponse{
+			Status: merr.Status(err),
+		}, nil
+	}
+	if req.GetDbName() == "" {
+		req.DbName = GetCurDBNameFromContextOrDefault(ctx)
+	}
+	log := log.Ctx(ctx).With(
+		zap.String("dbName", req.GetDbName()),
+		zap.String("collectionName", req.GetCollectionName()),
+		zap.String("snapshotName", req.GetSnapshotName()),
+	)
+	method := "CreateSnapshot"
+	log.Info(rpcReceived(method))
+
+	nodeID := fmt.Sprint(paramtable.GetNodeID())
+	metrics.ProxyFunctionCall.WithLabelValues(nodeID, method, metrics.TotalLabel, req.GetDbName(), req.GetCollectionName()).Inc()
+	snapshot, err := node.createSnapshot(ctx, req)
+	if err != nil {
+		log.Warn(rpcFailedToWaitToFinish(method), zap.Error(err))
+		metrics.ProxyFunctionCall.WithLabelValues(nodeID, method, metrics.FailLabel, req.GetDbName(), req.GetCollectionName()).Inc()
+		return &proxypb.CreateSnapshotResponse{Status: merr.Status(err)}, nil
+	}
+	log.Info(rpcDone(method), zap.Int64("snapshotID", snapshot.GetSnapshotID()), zap.Uint64("ts", snapshot.GetTs()))
+	metrics.ProxyFunctionCall.WithLabelValues(nodeID, method, metrics.SuccessLabel, req.GetDbName(), req.GetCollectionName()).Inc()
+	return &proxypb.CreateSnapshotResponse{
+		Status:     merr.Success(),
+		SnapshotID: snapshot.GetSnapshotID(),
+		Ts:         snapshot.GetTs(),
+	}, nil
+}
+
+func (node *Proxy) createSnapshot(ctx context.Context, req *proxypb.CreateSnapshotRequest) (*datapb.SnapshotInfo, error) {
+	if err := validateCollectionName(req.GetCollectionName()); err != nil {
+		return nil, err
+	}
+	if req.GetSnapshotName() == "" {
+		return nil, merr.WrapErrParameterInvalidMsg("snapshot name is empty")
+	}
+	collectionID, err := globalMetaCache.GetCollectionID(ctx, req.GetDbName(), req.GetCollectionName())
+	if err != nil {
+		return nil, err
+	}
+	partitions, err := globalMetaCache.GetPartitions(ctx, req.GetDbName(), req.GetCollectionName())
+	if err != nil {
+		return nil, err
+	}
+	partitionNames := lo.Keys(partitions)
+	if len(req.GetPartitionNames()) > 0 {
+		partitionNames = req.GetPartitionNames()
+	}
+	partitionIDs := make([]int64, 0, len(partitionNames))
+	for _, name := range partitionNames {
+		partitionID, ok := partitions[name]
+		if !ok {
+			return nil, merr.WrapErrPartitionNotFound(name)
+		}
+		partitionIDs = append(partitionIDs, partitionID)
+	}
+
+	flushTs, err := node.flushForSnapshot(ctx, req.GetDbName(), req.GetCollectionName())
+	if err != nil {
+		return nil, err
+	}
+	resp, err := node.dataCoord.CreateSnapshot(ctx, &datapb.CreateSnapshotRequest{
+		Base:           commonpbutil.NewMsgBase(),
+		Name:           req.GetSnapshotName(),
+		CollectionID:   collectionID,
+		CollectionName: req.GetCollectionName(),
+		DbName:         req.GetDbName(),
+		PartitionIDs:   partitionIDs,
+		PartitionNames: partitionNames,
+		FlushTs:        flushTs,
+	})
+	if err = merr.CheckRPCCall(resp, err); err != nil {
+		return nil, err
+	}
+	return resp.GetSnapshot(), nil
+}
+
+// flushForSnapshot flushes the collection and waits until all data before the flush ts is persisted.
+func (node *Proxy) flushForSnapshot(ctx context.Context, dbName, collectionName string) (uint64, error) {
+	flushResp, err := node.Flush(ctx, &milvuspb.FlushRequest{
+		DbName:          dbName,
+		CollectionNames: []string{collectionName},
+	})
+	if err := merr.CheckRPCCall(flushResp, err); err != nil {
+		return 0, err
+	}
+	flushTs := flushResp.GetCollFlushTs()[collectionName]
+	segmentIDs := flushResp.GetCollSegIDs()[collectionName].GetData()
+
+	ticker := time.NewTicker(snapshotFlushCheckInterval)
+	defer ticker.Stop()
+	for {
+		stateResp, err := node.GetFlushState(ctx, &milvuspb.GetFlushStateRequest{
+			SegmentIDs:     segmentIDs,
+			FlushTs:        flushTs,
+			DbName:         dbName,
+			CollectionName: collectionName,
+		})
+		if err := merr.CheckRPCCall(stateResp, err); err != nil {
+			return 0, err
+		}
+		if stateResp.GetFlushed() {
+			return flushTs, nil
+		}
+		select {
+		case <-ctx.Done():
+			return 0, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// getSnapshot returns the snapshot of the name, the snapshots are matched by the name of collection and database
+// rather than collection id, so that the snapshots of the dropped collections can be still accessed.
+func (node *Proxy) getSnapshot(ctx context.Context, name string) (*datapb.SnapshotInfo, error) {
+	resp, err := node.dataCoord.ListSnapshots(ctx, &datapb.ListSnapshotsRequest{
+		Base: commonpbutil.NewMsgBase(),
+		Name: name,
+	})
+	if err = merr.CheckRPCCall(resp, err); err != nil {
+		return nil, err
+	}
+	if len(resp.GetSnapshots()) == 0 {
+		return nil, merr.WrapErrParameterInvalidMsg("snapshot %s not found", name)
+	}
+	return resp.GetSnapshots()[0], nil
+}
+
+// DropSnapshot drops the snapshot taken from the collection and releases the pinned segments.
+func (node *Proxy) DropSnapshot(ctx context.Context, req *proxypb.DropSnapshotRequest) (*commonpb.Status, error) {
+	if err := merr.CheckHealthy(node.GetStateCode()); err != nil {
+		return merr.Status(err), nil
+	}
+	if req.GetDbName() == "" {
+		req.DbName = GetCurDBNameFromContextOrDefault(ctx)
+	}
+	log := log.Ctx(ctx).With(
+		zap.String("dbName", req.GetDbName()),
+		zap.String("collectionName", req.GetCollectionName()),
+		zap.String("snapshotName", req.GetSnapshotName()),
+	)
+	method := "DropSnapshot"
+	log.Info(rpcReceived(method))
+
+	nodeID := fmt.Sprint(paramtable.GetNodeID())
+	metrics.ProxyFunctionCall.WithLabelValues(nodeID, method, metrics.TotalLabel, req.GetDbName(), req.GetCollectionName()).Inc()
+	err := func() error {
+		if err := validateCollectionName(req.GetCollectionName()); err != nil {
+			return err
+		}
+		snapshot, err := node.getSnapshot(ctx, req.GetSnapshotName())
+		if err != nil {
+			return err
+		}
+		if snapshot.GetDbName() != req.GetDbName() || snapshot.GetCollectionName() != req.GetCollectionName() {
+			return merr.WrapErrParameterInvalidMsg("snapshot %s not found in collection %s", req.GetSnapshotName(), req.GetCollectionName())
+		}
+		status, err := node.dataCoord.DropSnapshot(ctx, &datapb.DropSnapshotRequest{
+			Base: commonpbutil.NewMsgBase(),
+			Name: req.GetSnapshotName(),
+		})
+		return merr.CheckRPCCall(status, err)
+	}()
+	if err != nil {
+		log.Warn(rpcFailedToWaitToFinish(method), zap.Error(err))
+		metrics.ProxyFunctionCall.WithLabelValues(nodeID, method, metrics.FailLabel, req.GetDbName(), req.GetCollectionName()).Inc()
+		return merr.Status(err), nil
+	}
+	log.Info(rpcDone(method))
+	metrics.ProxyFunctionCall.WithLabelValues(nodeID, method, metrics.SuccessLabel, req.GetDbName(), req.GetCollectionName()).Inc()
+	return merr.Success(), nil
+}
+
+// ListSnapshots lists the snapshots taken from the collection, segments and schema are omitted.
+func (node *Proxy) ListSnapshots(ctx context.Context, req *proxypb.ListSnapshotsRequest) (*proxypb.ListSnapshotsResponse, error) {
+	if err := merr.CheckHealthy(node.GetStateCode()); err != nil {
+		return &proxypb.ListSnapshotsResponse{
+			Status: merr.Status(err),
+		}, nil
+	}
+	if req.GetDbName() == "" {
+		req.DbName = GetCurDBNameFromContextOrDefault(ctx)
+	}
+	log := log.Ctx(ctx).With(
+		zap.String("dbName", req.GetDbName()),
+		zap.String("collectionName", req.GetCollectionName()),
+		zap.String("snapshotName", req.GetSnapshotName()),
+	)
+	method := "ListSnapshots"
+	log.Debug(rpcReceived(method))
+
+	nodeID := fmt.Sprint(paramtable.GetNodeID())
+	metrics.ProxyFunctionCall.WithLabelValues(nodeID, method, metrics.TotalLabel, req.GetDbName(), req.GetCollectionName()).Inc()
+	if err := validateCollectionName(req.GetCollectionName()); err != nil {
+		metrics.ProxyFunctionCall.WithLabelValues(nodeID, method, metrics.FailLabel, req.GetDbName(), req.GetCollectionName()).Inc()
+		return &proxypb.ListSnapshotsResponse{Status: merr.Status(err)}, nil
+	}
+	resp, err := node.dataCoord.ListSnapshots(ctx, &datapb.ListSnapshotsRequest{
+		Base: commonpbutil.NewMsgBase(),
+		Name: req.GetSnapshotName(),
+	})
+	if err = merr.CheckRPCCall(resp, err); err != nil {
+		log.Warn(rpcFailedToWaitToFinish(method), zap.Error(err))
+		metrics.ProxyFunctionCall.WithLabelValues(nodeID, method, metrics.FailLabel, req.GetDbName(), req.GetCollectionName()).Inc()
+		return &proxypb.ListSnapshotsResponse{Status: merr.Status(err)}, nil
+	}
+	snapshots := lo.FilterMap(resp.GetSnapshots(), func(snapshot *datapb.SnapshotInfo, _ int) (*proxypb.SnapshotSummary, bool) {
+		if snapshot.GetDbName() != req.GetDbName() || snapshot.GetCollectionName() != req.GetCollectionName() {
+			return nil, false
+		}
+		return &proxypb.SnapshotSummary{
+			SnapshotID:     snapshot.GetSnapshotID(),
+			Name:           snapshot.GetName(),
+			DbName:         snapshot.GetDbName(),
+			CollectionID:   snapshot.GetCollectionID(),
+			CollectionName: snapshot.GetCollectionName(),
+			PartitionNames: snapshot.GetPartitionNames(),
+			Ts:             snapshot.GetTs(),
+			CreateTime:     snapshot.GetCreateTime(),
+			NumSegments:    int64(len(snapshot.GetSegmentIDs())),
+			NumRows:        snapshot.GetNumRows(),
+		}, true
+	})
+	log.Debug(rpcDone(method), zap.Int("snapshots", len(snapshots)))
+	metrics.ProxyFunctionCall.WithLabelValues(nodeID, method, metrics.SuccessLabel, req.GetDbName(), req.GetCollectionName()).Inc()
+	return &proxypb.ListSnapshotsResponse{
+		Status:    merr.Success(),
+		Snapshots: snapshots,
+	}, nil
+}
+
+// RestoreSnapshot creates a new collection with the schema of snapshot, and restores the snapshot data into it.
+// The caller needs the privilege to create collections in the target database and to query the source collection,
+// the new collection is dropped if restore failed.
+func (node *Proxy) RestoreSnapshot(ctx context.Context, req *proxypb.RestoreSnapshotRequest) (*commonpb.Status, error) {
+	if err := merr.CheckHealthy(node.GetStateCode()); err != nil {
+		return merr.Status(err), nil
+	}
+	if req.GetDbName() == "" {
+		req.DbName = GetCurDBNameFromContextOrDefault(ctx)
+	}
+	log := log.Ctx(ctx).With(
+		zap.String("dbName", req.GetDbName()),
+		zap.String("collectionName", req.GetCollectionName()),
+		zap.String("snapshotName", req.GetSnapshotName()),
+	)
+	method := "RestoreSnapshot"
+	log.Info(rpcReceived(method))
+
+	nodeID := fmt.Sprint(paramtable.GetNodeID())
+	metrics.ProxyFunctionCall.WithLabelValues(nodeID, method, metrics.TotalLabel, req.GetDbName(), req.GetCollectionName()).Inc()
+	err := func() error {
+		if err := validateCollectionName(req.GetCollectionName()); err != nil {
+			return err
+		}
+		// the privilege interceptor checks the database of context, which may differ from the target one.
+		if err := checkPrivilegeInDatabase(ctx, req.GetDbName(), req); err != nil {
+			return err
+		}
+		snapshot, err := node.getSnapshot(ctx, req.GetSnapshotName())
+		if err != nil {
+			return err
+		}
+		if err := checkPrivilegeInDatabase(ctx, snapshot.GetDbName(), &milvuspb.QueryRequest{
+			DbName:         snapshot.GetDbName(),
+			CollectionName: snapshot.GetCollectionName(),
+		}); err != nil {
+			return err
+		}
+		return node.restoreSnapshot(ctx, snapshot, req.GetDbName(), req.GetCollectionName())
+	}()
+	if err != nil {
+		log.Warn(rpcFailedToWaitToFinish(method), zap.Error(err))
+		metrics.ProxyFunctionCall.WithLabelValues(nodeID, method, metrics.FailLabel, req.GetDbName(), req.GetCollectionName()).Inc()
+		return merr.Status(err), nil
+	}
+	log.Info(rpcDone(method))
+	metrics.ProxyFunctionCall.WithLabelValues(nodeID, method, metrics.SuccessLabel, req.GetDbName(), req.GetCollectionName()).Inc()
+	return merr.Success(), nil
+}
+
+func (node *Proxy) restoreSnapshot(ctx context.Context, snapshot *datapb.SnapshotInfo, dbName, collectionName string) error {
+	log := log.Ctx(ctx).With(zap.String("snapshot", snapshot.GetName()),
+		zap.String("db", dbName), zap.String("collection", collectionName))
+
+	// system fields are added by rootcoord, user fields get the same field ids since they are assigned in order.
+	schema := proto.Clone(snapshot.GetSchema()).(*schemapb.CollectionSchema)
+	schema.Name = collectionName
+	schema.Fields = lo.Filter(schema.GetFields(), func(field *schemapb.FieldSchema, _ int) bool {
+		return field.GetFieldID() >= common.StartOfUserFieldID
+	})
+	schemaBytes, err := proto.Marshal(schema)
+	if err != nil {
+		return err
+	}
+	createReq := &milvuspb.CreateCollectionRequest{
+		Base:           commonpbutil.NewMsgBase(commonpbutil.WithMsgType(commonpb.MsgType_CreateCollection)),
+		DbName:         dbName,
+		CollectionName: collectionName,
+		Schema:         schemaBytes,
+		ShardsNum:      int32(len(snapshot.GetVchannels())),
+		Properties:     snapshot.GetProperties(),
+	}
+	if typeutil.HasPartitionKey(schema) {
+		createReq.NumPartitions = int64(len(snapshot.GetPartitionNames()))
+	}
+	status, err := node.rootCoord.CreateCollection(ctx, createReq)
+	if err = merr.CheckRPCCall(status, err); err != nil {
+		return err
+	}
+
+	err = node.restoreSnapshotInto(ctx, snapshot, dbName, collectionName)
+	if err != nil {
+		log.Warn("restore snapshot failed, drop the target collection", zap.Error(err))
+		status, dropErr := node.rootCoord.DropCollection(ctx, &milvuspb.DropCollectionRequest{
+			Base:           commonpbutil.NewMsgBase(commonpbutil.WithMsgType(commonpb.MsgType_DropCollection)),
+			DbName:         dbName,
+			CollectionName: collectionName,
+		})
+		if dropErr = merr.CheckRPCCall(status, dropErr); dropErr != nil {
+			log.Warn("drop target collection failed", zap.Error(dropErr))
+		}
+		return err
+	}
+	log.Info("restore snapshot done")
+	return nil
+}
+
+func (node *Proxy) restoreSnapshotInto(ctx context.Context, snapshot *datapb.SnapshotInfo, dbName, collectionName string) error {
+	describeResp, err := node.rootCoord.DescribeCollection(ctx, &milvuspb.DescribeCollectionRequest{
+		Base:           commonpbutil.NewMsgBase(commonpbutil.WithMsgType(commonpb.MsgType_DescribeCollection)),
+		DbName:         dbName,
+		CollectionName: collectionName,
+	})
+	if err = merr.CheckRPCCall(describeResp, err); err != nil {
+		return err
+	}
+	fieldIDs := lo.SliceToMap(describeResp.GetSchema().GetFields(), func(field *schemapb.FieldSchema) (string, int64) {
+		return field.GetName(), field.GetFieldID()
+	})
+	for _, field := range snapshot.GetSchema().GetFields() {
+		if field.GetFieldID() >= common.StartOfUserFieldID && fieldIDs[field.GetName()] != field.GetFieldID() {
+			return merr.WrapErrServiceInternal(fmt.Sprintf("field %s has id %d in snapshot but %d in target collection",
+				field.GetName(), field.GetFieldID(), fieldIDs[field.GetName()]))
+		}
+	}
+
+	// create the partitions not created with collection
+	showPartitions := func() (map[string]int64, error) {
+		resp, err := node.rootCoord.ShowPartitions(ctx, &milvuspb.ShowPartitionsRequest{
+			Base:           commonpbutil.NewMsgBase(commonpbutil.WithMsgType(commonpb.MsgType_ShowPartitions)),
+			DbName:         dbName,
+			CollectionName: collectionName,
+		})
+		if err = merr.CheckRPCCall(resp, err); err != nil {
+			return nil, err
+		}
+		partitions := make(map[string]int64, len(resp.GetPartitionNames()))
+		for i, name := range resp.GetPartitionNames() {
+			partitions[name] = resp.GetPartitionIDs()[i]
+		}
+		return partitions, nil
+	}
+	partitions, err := showPartitions()
+	if err != nil {
+		return err
+	}
+	created := false
+	for _, name := range snapshot.GetPartitionNames() {
+		if _, ok := partitions[name]; ok {
+			continue
+		}
+		status, err := node.rootCoord.CreatePartition(ctx, &milvuspb.CreatePartitionRequest{
+			Base:           commonpbutil.NewMsgBase(commonpbutil.WithMsgType(commonpb.MsgType_CreatePartition)),
+			DbName:         dbName,
+			CollectionName: collectionName,
+			PartitionName:  name,
+		})
+		if err = merr.CheckRPCCall(status, err); err != nil {
+			return err
+		}
+		created = true
+	}
+	if created {
+		if partitions, err = showPartitions(); err != nil {
+			return err
+		}
+	}
+
+	partitionMapping := make(map[int64]int64)
+	for i, name := range snapshot.GetPartitionNames() {
+		if i < len(snapshot.GetPartitionIDs()) {
+			partitionMapping[snapshot.GetPartitionIDs()[i]] = partitions[name]
+		}
+	}
+	status, err := node.dataCoord.RestoreSnapshot(ctx, &datapb.RestoreSnapshotRequest{
+		Base:               commonpbutil.NewMsgBase(),
+		Name:               snapshot.GetName(),
+		TargetCollectionID: describeResp.GetCollectionID(),
+		PartitionMapping:   partitionMapping,
+	})
+	return merr.CheckRPCCall(status, err)
+}
+
+// Export creates a job dumping the flushed data of the collection into parquet files. The rows are filtered by
+// the row policies of the caller besides the expr, and the export is refused if any field is masked for the caller.
+func (node *Proxy) Export(ctx context.Context, req *proxypb.ExportRequest) (*proxypb.ExportResponse, error) {
+	if err := merr.CheckHealthy(node.GetStateCode()); err != nil {
+		return &proxypb.ExportResponse{
+			Status: merr.Status(err),
+		}, nil
+	}
+	if req.GetDbName() == "" {
+		req.DbName = GetCurDBNameFromContextOrDefault(ctx)
+	}
+	log := log.Ctx(ctx).With(
+		zap.String("dbName", req.GetDbName()),
+		zap.String("collectionName", req.GetCollectionName()),
+		zap.Strings("partitionNames", req.GetPartitionNames()),
+		zap.String("expr", req.GetExpr()),
+	)
+	method := "Export"
+	log.Info(rpcReceived(method))
+
+	nodeID := fmt.Sprint(paramtable.GetNodeID())
+	metrics.ProxyFunctionCall.WithLabelValues(nodeID, method, metrics.TotalLabel, req.GetDbName(), req.GetCollectionName()).Inc()
+	jobID, err := node.export(ctx, req)
+	if err != nil {
+		log.Warn(rpcFailedToWaitToFinish(method), zap.Error(err))
+		metrics.ProxyFunctionCall.WithLabelValues(nodeID, method, metrics.FailLabel, req.GetDbName(), req.GetCollectionName()).Inc()
+		return &proxypb.ExportResponse{Status: merr.Status(err)}, nil
+	}
+	log.Info(rpcDone(method), zap.Int64("jobID", jobID))
+	metrics.ProxyFunctionCall.WithLabelValues(nodeID, method, metrics.SuccessLabel, req.GetDbName(), req.GetCollectionName()).Inc()
+	return &proxypb.ExportResponse{
+		Status: merr.Success(),
+		JobID:  jobID,
+	}, nil
+}
+
+func (node *Proxy) export(ctx context.Context, req *proxypb.ExportRequest) (int64, error) {
+	if err := validateCollectionName(req.GetCollectionName()); err != nil {
+		return 0, err
+	}
+	collectionID, err := globalMetaCache.GetCollectionID(ctx, req.GetDbName(), req.GetCollectionName())
+	if err != nil {
+		return 0, err
+	}
+	schema, err := globalMetaCache.GetCollectionSchema(ctx, req.GetDbName(), req.GetCollectionName())
+	if err != nil {
+		return 0, err
+	}
+	// all the fields are exported, so the masked ones can't be left out
+	masked, err := getMaskedFields(ctx, req.GetDbName(), req.GetCollectionName(), schema)
+	if err != nil {
+		return 0, err
+	}
+	if len(masked) > 0 {
+		return 0, merr.WrapErrPrivilegeNotPermitted("export is not permitted since fields %v of collection %s are masked",
+			lo.Values(masked), req.GetCollectionName())
+	}
+	filter, err := getRowPolicyFilter(ctx, req.GetDbName(), req.GetCollectionName(), schema.schemaHelper)
+	if err != nil {
+		return 0, err
+	}
+	var serializedFilter []byte
+	if filter != nil {
+		if serializedFilter, err = proto.Marshal(filter); err != nil {
+			return 0, err
+		}
+	}
+
+	var partitionIDs []int64
+	if len(req.GetPartitionNames()) > 0 {
+		partitions, err := globalMetaCache.GetPartitions(ctx, req.GetDbName(), req.GetCollectionName())
+		if err != nil {
+			return 0, err
+		}
+		for _, name := range req.GetPartitionNames() {
+			partitionID, ok := partitions[name]
+			if !ok {
+				return 0, merr.WrapErrPartitionNotFound(name)
+			}
+			partitionIDs = append(partitionIDs, partitionID)
+		}
+	}
+
+	resp, err := node.dataCoord.Export(ctx, &datapb.ExportRequest{
+		Base:                commonpbutil.NewMsgBase(),
+		DbName:              req.GetDbName(),
+		CollectionName:      req.GetCollectionName(),
+		CollectionID:        collectionID,
+		PartitionIDs:        partitionIDs,
+		Expr:                req.GetExpr(),
+		OutputPath:          req.GetOutputPath(),
+		SerializedRowFilter: serializedFilter,
+	})
+	if err = merr.CheckRPCCall(resp, err); err != nil {
+		return 0, err
+	}
+	return resp.GetJobID(), nil
+}
+
+// getExportJob returns the progress of the export job, the caller needs the query privilege of the exported collection.
+func (node *Proxy) getExportJob(ctx context.Context, jobID int64) (*datapb.GetExportProgressResponse, error) {
+	resp, err := node.dataCoord.GetExportProgress(ctx, &datapb.GetExportProgressRequest{
+		Base:  commonpbutil.NewMsgBase(),
+		JobID: jobID,
+	})
+	if err = merr.CheckRPCCall(resp, err); err != nil {
+		return nil, err
+	}
+	if err := checkPrivilegeInDatabase(ctx, resp.GetDbName(), &milvuspb.QueryRequest{
+		DbName:         resp.GetDbName(),
+		CollectionName: resp.GetCollectionName(),
+	}); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// GetExportProgress returns the state and the exported files of the export job.
+func (node *Proxy) GetExportProgress(ctx context.Context, req *proxypb.GetExportProgressRequest) (*proxypb.GetExportProgressResponse, error) {
+	if err := merr.CheckHealthy(node.GetStateCode()); err != nil {
+		return &proxypb.GetExportProgressResponse{
+			Status: merr.Status(err),
+		}, nil
+	}
+	log := log.Ctx(ctx).With(zap.Int64("jobID", req.GetJobID()))
+	method := "GetExportProgress"
+	log.Debug(rpcReceived(method))
+
+	nodeID := fmt.Sprint(paramtable.GetNodeID())
+	metrics.ProxyFunctionCall.WithLabelValues(nodeID, method, metrics.TotalLabel, "", "").Inc()
+	resp, err := node.getExportJob(ctx, req.GetJobID())
+	if err != nil {
+		log.Warn(rpcFailedToWaitToFinish(method), zap.Error(err))
+		metrics.ProxyFunctionCall.WithLabelValues(nodeID, method, metrics.FailLabel, "", "").Inc()
+		return &proxypb.GetExportProgressResponse{Status: merr.Status(err)}, nil
+	}
+	log.Debug(rpcDone(method), zap.String("state", resp.GetState().String()))
+	metrics.ProxyFunctionCall.WithLabelValues(nodeID, method, metrics.SuccessLabel, resp.GetDbName(), resp.GetCollectionName()).Inc()
+	return &proxypb.GetExportProgressResponse{
+		Status:         merr.Success(),
+		JobID:          req.GetJobID(),
+		DbName:         resp.GetDbName(),
+		CollectionName: resp.GetCollectionName(),
+		State:          resp.GetState().String(),
+		Reason:         resp.GetReason(),
+		Progress:       resp.GetProgress(),
+		ProcessedRows:  resp.GetProcessedRows(),
+		ExportedRows:   resp.GetExportedRows(),
+		TotalRows:      resp.GetTotalRows(),
+		OutputPath:     resp.GetOutputPath(),
+		Files:          resp.GetFiles(),
+		StartTime:      resp.GetStartTime(),
+		CompleteTime:   resp.GetCompleteTime(),
+	}, nil
+}
+
+// CancelExport cancels the export job, the files already written are kept.
+func (node *Proxy) CancelExport(ctx context.Context, req *proxypb.CancelExportRequest) (*commonpb.Status, error) {
+	if err := merr.CheckHealthy(node.GetStateCode()); err != nil {
+		return merr.Status(err), nil
+	}
+	log := log.Ctx(ctx).With(zap.Int64("jobID", req.GetJobID()))
+	method := "CancelExport"
+	log.Info(rpcReceived(method))
+
+	nodeID := fmt.Sprint(paramtable.GetNodeID())
+	metrics.ProxyFunctionCall.WithLabelValues(nodeID, method, metrics.TotalLabel, "", "").Inc()
+	err := func() error {
+		if _, err := node.getExportJob(ctx, req.GetJobID()); err != nil {
+			return err
+		}
+		status, err := node.dataCoord.CancelExport(ctx, &datapb.CancelExportRequest{
+			Base:  commonpbutil.NewMsgBase(),
+			JobID: req.GetJobID(),
+		})
+		return merr.CheckRPCCall(status, err)
+	}()
+	if err != nil {
+		log.Warn(rpcFailedToWaitToFinish(method), zap.Error(err))
+		metrics.ProxyFunctionCall.WithLabelValues(nodeID, method, metrics.FailLabel, "", "").Inc()
+		return merr.Status(err), nil
+	}
+	log.Info(rpcDone(method))
+	metrics.ProxyFunctionCall.WithLabelValues(nodeID, method, metrics.SuccessLabel, "", "").Inc()
+	return merr.Success(), nil
+}
+
+// SetRowPolicy restricts the rows of the collection the role can access to the ones matching the expr.
+func (node *Proxy) SetRowPolicy(ctx context.Context, req *proxypb.SetRowPolicyRequest) (*commonpb.Status, error) {
+	return node.operateRowPolicy(ctx, "SetRowPolicy", rootcoordpb.OperateRowPolicyType_SetRowPolicy, &internalpb.RowPolicy{
+		Role:           req.GetRoleName(),
+		DbName:         req.GetDbName(),
+		CollectionName: req.GetCollectionName(),
+		Expr:           req.GetExpr(),
+	}), nil
+}
+
+// DropRowPolicy removes the row policy of the role on the collection.
+func (node *Proxy) DropRowPolicy(ctx context.Context, req *proxypb.DropRowPolicyRequest) (*commonpb.Status, error) {
+	return node.operateRowPolicy(ctx, "DropRowPolicy", rootcoordpb.OperateRowPolicyType_DropRowPolicy, &internalpb.RowPolicy{
+		Role:           req.GetRoleName(),
+		DbName:         req.GetDbName(),
+		CollectionName: req.GetCollectionName(),
+	}), nil
+}
+
+func (node *Proxy) operateRowPolicy(ctx context.Context, method string, opType rootcoordpb.OperateRowPolicyType, policy *internalpb.RowPolicy) *commonpb.Status {
+	if err := merr.CheckHealthy(node.GetStateCode()); err != nil {
+		return merr.Status(err)
+	}
+	if policy.GetDbName() == "" {
+		policy.DbName = GetCurDBNameFromContextOrDefault(ctx)
+	}
+	log := log.Ctx(ctx).With(
+		zap.String("role", policy.GetRole()),
+		zap.String("dbName", policy.GetDbName()),
+		zap.String("collectionName", policy.GetCollectionName()),
+		zap.String("expr", policy.GetExpr()),
+	)
+	log.Info(rpcReceived(method))
+
+	nodeID := fmt.Sprint(paramtable.GetNodeID())
+	metrics.ProxyFunctionCall.WithLabelValues(nodeID, method, metrics.TotalLabel, policy.GetDbName(), policy.GetCollectionName()).Inc()
+	err := func() error {
+		if err := ValidateRoleName(policy.GetRole()); err != nil {
+			return err
+		}
+		if err := validateCollectionName(policy.GetCollectionName()); err != nil {
+			return err
+		}
+		if opType == rootcoordpb.OperateRowPolicyType_SetRowPolicy {
+			// the expr is checked by the row filter as well, since it is used to check the inserted and upserted rows
+			schema, err := globalMetaCache.GetCollectionSchema(ctx, policy.GetDbName(), policy.GetCollectionName())
+			if err != nil {
+				return err
+			}
+			if _, err := newRowPolicyChecker(schema.schemaHelper, policy.GetExpr()); err != nil {
+				return merr.WrapErrParameterInvalidMsg("invalid row policy expr %s: %v", policy.GetExpr(), err)
+			}
+		}
+		status, err := node.rootCoord.OperateRowPolicy(ctx, &rootcoordpb.OperateRowPolicyRequest{
+			Base:   commonpbutil.NewMsgBase(),
+			Type:   opType,
+			Policy: policy,
+		})
+		return merr.CheckRPCCall(status, err)
+	}()
+	if err != nil {
+		log.Warn(rpcFailedToWaitToFinish(method), zap.Error(err))
+		metrics.ProxyFunctionCall.WithLabelValues(nodeID, method, metrics.FailLabel, policy.GetDbName(), policy.GetCollectionName()).Inc()
+		return merr.Status(err)
+	}
+	log.Info(rpcDone(method))
+	metrics.ProxyFunctionCall.WithLabelValues(nodeID, method, metrics.SuccessLabel, policy.GetDbName(), policy.GetCollectionName()).Inc()
+	return merr.Success()
+}
+
+// ListRowPolicies lists the row policies of the database, filtered by the role and collection if they're set.
+func (node *Proxy) ListRowPolicies(ctx context.Context, req *proxypb.ListRowPoliciesRequest) (*proxypb.ListRowPoliciesResponse, error) {
+	if err := merr.CheckHealthy(node.GetStateCode()); err != nil {
+		return &proxypb.ListRowPoliciesResponse{
+			Status: merr.Status(err),
+		}, nil
+	}
+	if req.GetDbName() == "" {
+		req.DbName = GetCurDBNameFromContextOrDefault(ctx)
+	}
+	log := log.Ctx(ctx).With(
+		zap.String("role", req.GetRoleName()),
+		zap.String("dbName", req.GetDbName()),
+		zap.String("collectionName", req.GetCollectionName()),
+	)
+	method := "ListRowPolicies"
+	log.Debug(rpcReceived(method))
+
+	nodeID := fmt.Sprint(paramtable.GetNodeID())
+	metrics.ProxyFunctionCall.WithLabelValues(nodeID, method, metrics.TotalLabel, req.GetDbName(), req.GetCollectionName()).Inc()
+	resp, err := node.rootCoord.ListPolicy(ctx, &internalpb.ListPolicyRequest{
+		Base: commonpbutil.NewMsgBase(),
+	})
+	if err = merr.CheckRPCCall(resp, err); err != nil {
+		log.Warn(rpcFailedToWaitToFinish(method), zap.Error(err))
+		metrics.ProxyFunctionCall.WithLabelValues(nodeID, method, metrics.FailLabel, req.GetDbName(), req.GetCollectionName()).Inc()
+		return &proxypb.ListRowPoliciesResponse{Status: merr.Status(err)}, nil
+	}
+	policies := lo.Filter(resp.GetRowPolicies(), func(policy *internalpb.RowPolicy, _ int) bool {
+		return policy.GetDbName() == req.GetDbName() &&
+			(req.GetRoleName() == "" || policy.GetRole() == req.GetRoleName()) &&
+			(req.GetCollectionName() == "" || policy.GetCollectionName() == req.GetCollectionName())
+	})
+	log.Debug(rpcDone(method), zap.Int("num", len(policies)))
+	metrics.ProxyFunctionCall.WithLabelValues(nodeID, method, metrics.SuccessLabel, req.GetDbName(), req.GetCollectionName()).Inc()
+	return &proxypb.ListRowPoliciesResponse{
+		Status:   merr.Success(),
+		Policies: policies,
+	}, nil
+}
+
 // DeregisterSubLabel must add the sub-labels here if using other labels for the sub-labels
 func DeregisterSubLabel(subLabel string) {
 	rateCol.DeregisterSubLabel(internalpb.RateType_DQLQuery.String(), subLabel)
@@ -6721,6 +7772,7 @@ func (node *Proxy) RegisterRestRouter(router gin.IRouter) {
 
 	// Slow query request that executed by proxy
 	router.GET(http.SlowQueryPath, getSlowQuery(node))
+	router.GET(http.QueryProfilePath, getQueryProfiles(node))
 
 	// QueryCoord requests that are forwarded from proxy
 	router.GET(http.QCTargetPath, getQueryComponentMetrics(node, metricsinfo.TargetKey))
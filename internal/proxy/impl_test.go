@@ -43,10 +43,12 @@ import (
 	"github.com/milvus-io/milvus/internal/mocks"
 	"github.com/milvus-io/milvus/internal/proto/datapb"
 	"github.com/milvus-io/milvus/internal/proto/internalpb"
+	"github.com/milvus-io/milvus/internal/proto/planpb"
 	"github.com/milvus-io/milvus/internal/proto/proxypb"
 	"github.com/milvus-io/milvus/internal/proto/querypb"
 	"github.com/milvus-io/milvus/internal/proto/rootcoordpb"
 	"github.com/milvus-io/milvus/internal/util/dependency"
+	"github.com/milvus-io/milvus/internal/util/exprutil"
 	"github.com/milvus-io/milvus/internal/util/sessionutil"
 	"github.com/milvus-io/milvus/pkg/common"
 	"github.com/milvus-io/milvus/pkg/log"
@@ -1863,3 +1865,522 @@ func TestRegisterRestRouter(t *testing.T) {
 		})
 	}
 }
+
+func TestProxy_Snapshot(t *testing.T) {
+	ctx := context.Background()
+	paramtable.Init()
+
+	snapshot := &datapb.SnapshotInfo{
+		SnapshotID:     1,
+		Name:           "snapshot1",
+		DbName:         "default",
+		CollectionName: "coll",
+		Schema: &schemapb.CollectionSchema{
+			Name: "coll",
+			Fields: []*schemapb.FieldSchema{
+				{FieldID: 0, Name: "RowID", DataType: schemapb.DataType_Int64},
+				{FieldID: 100, Name: "pk", DataType: schemapb.DataType_Int64, IsPrimaryKey: true},
+				{FieldID: 101, Name: "vec", DataType: schemapb.DataType_FloatVector},
+			},
+		},
+		PartitionIDs:   []int64{10, 11},
+		PartitionNames: []string{"_default", "p1"},
+		Vchannels:      []string{"ch-0", "ch-1"},
+		SegmentIDs:     []int64{1, 2},
+		NumRows:        100,
+	}
+	targetSchema := &schemapb.CollectionSchema{
+		Name: "restored",
+		Fields: []*schemapb.FieldSchema{
+			{FieldID: 100, Name: "pk", DataType: schemapb.DataType_Int64, IsPrimaryKey: true},
+			{FieldID: 101, Name: "vec", DataType: schemapb.DataType_FloatVector},
+		},
+	}
+	newProxy := func(t *testing.T) (*Proxy, *mocks.MockDataCoordClient, *mocks.MockRootCoordClient) {
+		dc := mocks.NewMockDataCoordClient(t)
+		rc := mocks.NewMockRootCoordClient(t)
+		node := &Proxy{dataCoord: dc, rootCoord: rc}
+		node.UpdateStateCode(commonpb.StateCode_Healthy)
+		return node, dc, rc
+	}
+	listResp := func(snapshots ...*datapb.SnapshotInfo) *datapb.ListSnapshotsResponse {
+		return &datapb.ListSnapshotsResponse{Status: merr.Success(), Snapshots: snapshots}
+	}
+
+	t.Run("unhealthy", func(t *testing.T) {
+		node := &Proxy{}
+		node.UpdateStateCode(commonpb.StateCode_Abnormal)
+		createResp, err := node.CreateSnapshot(ctx, &proxypb.CreateSnapshotRequest{})
+		assert.NoError(t, err)
+		assert.False(t, merr.Ok(createResp.GetStatus()))
+		status, err := node.DropSnapshot(ctx, &proxypb.DropSnapshotRequest{})
+		assert.NoError(t, err)
+		assert.False(t, merr.Ok(status))
+		listResp, err := node.ListSnapshots(ctx, &proxypb.ListSnapshotsRequest{})
+		assert.NoError(t, err)
+		assert.False(t, merr.Ok(listResp.GetStatus()))
+		status, err = node.RestoreSnapshot(ctx, &proxypb.RestoreSnapshotRequest{})
+		assert.NoError(t, err)
+		assert.False(t, merr.Ok(status))
+	})
+
+	t.Run("create partition not found", func(t *testing.T) {
+		cacheBak := globalMetaCache
+		defer func() { globalMetaCache = cacheBak }()
+		cache := NewMockCache(t)
+		cache.EXPECT().GetCollectionID(mock.Anything, mock.Anything, mock.Anything).Return(1, nil)
+		cache.EXPECT().GetPartitions(mock.Anything, mock.Anything, mock.Anything).Return(map[string]int64{"_default": 10}, nil)
+		globalMetaCache = cache
+
+		node, _, _ := newProxy(t)
+		resp, err := node.CreateSnapshot(ctx, &proxypb.CreateSnapshotRequest{
+			CollectionName: "coll",
+			SnapshotName:   "snapshot1",
+			PartitionNames: []string{"p1"},
+		})
+		assert.NoError(t, err)
+		assert.ErrorIs(t, merr.Error(resp.GetStatus()), merr.ErrPartitionNotFound)
+	})
+
+	t.Run("drop", func(t *testing.T) {
+		node, dc, _ := newProxy(t)
+		dc.EXPECT().ListSnapshots(mock.Anything, mock.Anything).Return(listResp(snapshot), nil)
+		dc.EXPECT().DropSnapshot(mock.Anything, mock.Anything).RunAndReturn(func(ctx context.Context, req *datapb.DropSnapshotRequest, options ...grpc.CallOption) (*commonpb.Status, error) {
+			assert.Equal(t, "snapshot1", req.GetName())
+			return merr.Success(), nil
+		})
+		status, err := node.DropSnapshot(ctx, &proxypb.DropSnapshotRequest{CollectionName: "coll", SnapshotName: "snapshot1"})
+		assert.NoError(t, err)
+		assert.True(t, merr.Ok(status))
+	})
+
+	t.Run("drop snapshot of other collection", func(t *testing.T) {
+		node, dc, _ := newProxy(t)
+		dc.EXPECT().ListSnapshots(mock.Anything, mock.Anything).Return(listResp(snapshot), nil)
+		status, err := node.DropSnapshot(ctx, &proxypb.DropSnapshotRequest{CollectionName: "other", SnapshotName: "snapshot1"})
+		assert.NoError(t, err)
+		assert.False(t, merr.Ok(status))
+	})
+
+	t.Run("drop failed", func(t *testing.T) {
+		node, dc, _ := newProxy(t)
+		dc.EXPECT().ListSnapshots(mock.Anything, mock.Anything).Return(listResp(snapshot), nil)
+		dc.EXPECT().DropSnapshot(mock.Anything, mock.Anything).Return(merr.Status(merr.WrapErrServiceInternal("mock")), nil)
+		status, err := node.DropSnapshot(ctx, &proxypb.DropSnapshotRequest{CollectionName: "coll", SnapshotName: "snapshot1"})
+		assert.NoError(t, err)
+		assert.False(t, merr.Ok(status))
+	})
+
+	t.Run("list", func(t *testing.T) {
+		node, dc, _ := newProxy(t)
+		other := &datapb.SnapshotInfo{SnapshotID: 2, Name: "snapshot2", DbName: "default", CollectionName: "other"}
+		dc.EXPECT().ListSnapshots(mock.Anything, mock.Anything).Return(listResp(snapshot, other), nil)
+		resp, err := node.ListSnapshots(ctx, &proxypb.ListSnapshotsRequest{CollectionName: "coll"})
+		assert.NoError(t, err)
+		assert.True(t, merr.Ok(resp.GetStatus()))
+		assert.Len(t, resp.GetSnapshots(), 1)
+		assert.Equal(t, "snapshot1", resp.GetSnapshots()[0].GetName())
+		assert.EqualValues(t, 2, resp.GetSnapshots()[0].GetNumSegments())
+		assert.EqualValues(t, 100, resp.GetSnapshots()[0].GetNumRows())
+	})
+
+	t.Run("list failed", func(t *testing.T) {
+		node, dc, _ := newProxy(t)
+		dc.EXPECT().ListSnapshots(mock.Anything, mock.Anything).Return(nil, errors.New("mock"))
+		resp, err := node.ListSnapshots(ctx, &proxypb.ListSnapshotsRequest{CollectionName: "coll"})
+		assert.NoError(t, err)
+		assert.False(t, merr.Ok(resp.GetStatus()))
+	})
+
+	restoreReq := &proxypb.RestoreSnapshotRequest{CollectionName: "restored", SnapshotName: "snapshot1"}
+
+	t.Run("restore", func(t *testing.T) {
+		node, dc, rc := newProxy(t)
+		dc.EXPECT().ListSnapshots(mock.Anything, mock.Anything).Return(listResp(snapshot), nil)
+		rc.EXPECT().CreateCollection(mock.Anything, mock.Anything).RunAndReturn(func(ctx context.Context, req *milvuspb.CreateCollectionRequest, options ...grpc.CallOption) (*commonpb.Status, error) {
+			assert.Equal(t, "restored", req.GetCollectionName())
+			assert.EqualValues(t, 2, req.GetShardsNum())
+			schema := &schemapb.CollectionSchema{}
+			assert.NoError(t, proto.Unmarshal(req.GetSchema(), schema))
+			assert.Equal(t, "restored", schema.GetName())
+			assert.Equal(t, 2, len(schema.GetFields()))
+			return merr.Success(), nil
+		})
+		rc.EXPECT().DescribeCollection(mock.Anything, mock.Anything).Return(&milvuspb.DescribeCollectionResponse{
+			Status:       merr.Success(),
+			CollectionID: 1000,
+			Schema:       targetSchema,
+		}, nil)
+		rc.EXPECT().ShowPartitions(mock.Anything, mock.Anything).Return(&milvuspb.ShowPartitionsResponse{
+			Status:         merr.Success(),
+			PartitionNames: []string{"_default"},
+			PartitionIDs:   []int64{20},
+		}, nil).Once()
+		rc.EXPECT().CreatePartition(mock.Anything, mock.Anything).RunAndReturn(func(ctx context.Context, req *milvuspb.CreatePartitionRequest, options ...grpc.CallOption) (*commonpb.Status, error) {
+			assert.Equal(t, "p1", req.GetPartitionName())
+			return merr.Success(), nil
+		})
+		rc.EXPECT().ShowPartitions(mock.Anything, mock.Anything).Return(&milvuspb.ShowPartitionsResponse{
+			Status:         merr.Success(),
+			PartitionNames: []string{"_default", "p1"},
+			PartitionIDs:   []int64{20, 21},
+		}, nil).Once()
+		dc.EXPECT().RestoreSnapshot(mock.Anything, mock.Anything).RunAndReturn(func(ctx context.Context, req *datapb.RestoreSnapshotRequest, options ...grpc.CallOption) (*commonpb.Status, error) {
+			assert.EqualValues(t, 1000, req.GetTargetCollectionID())
+			assert.Equal(t, map[int64]int64{10: 20, 11: 21}, req.GetPartitionMapping())
+			return merr.Success(), nil
+		})
+
+		status, err := node.RestoreSnapshot(ctx, proto.Clone(restoreReq).(*proxypb.RestoreSnapshotRequest))
+		assert.NoError(t, err)
+		assert.True(t, merr.Ok(status))
+	})
+
+	t.Run("restore failed", func(t *testing.T) {
+		node, dc, rc := newProxy(t)
+		dc.EXPECT().ListSnapshots(mock.Anything, mock.Anything).Return(listResp(snapshot), nil)
+		rc.EXPECT().CreateCollection(mock.Anything, mock.Anything).Return(merr.Success(), nil)
+		rc.EXPECT().DescribeCollection(mock.Anything, mock.Anything).Return(&milvuspb.DescribeCollectionResponse{
+			Status:       merr.Success(),
+			CollectionID: 1000,
+			Schema:       targetSchema,
+		}, nil)
+		rc.EXPECT().ShowPartitions(mock.Anything, mock.Anything).Return(&milvuspb.ShowPartitionsResponse{
+			Status:         merr.Success(),
+			PartitionNames: []string{"_default", "p1"},
+			PartitionIDs:   []int64{20, 21},
+		}, nil)
+		dc.EXPECT().RestoreSnapshot(mock.Anything, mock.Anything).Return(merr.Status(merr.WrapErrServiceInternal("mock")), nil)
+		// target collection is dropped when restore failed
+		rc.EXPECT().DropCollection(mock.Anything, mock.Anything).RunAndReturn(func(ctx context.Context, req *milvuspb.DropCollectionRequest, options ...grpc.CallOption) (*commonpb.Status, error) {
+			assert.Equal(t, "restored", req.GetCollectionName())
+			return merr.Success(), nil
+		})
+
+		status, err := node.RestoreSnapshot(ctx, proto.Clone(restoreReq).(*proxypb.RestoreSnapshotRequest))
+		assert.NoError(t, err)
+		assert.False(t, merr.Ok(status))
+	})
+
+	t.Run("restore field id mismatch", func(t *testing.T) {
+		node, dc, rc := newProxy(t)
+		dc.EXPECT().ListSnapshots(mock.Anything, mock.Anything).Return(listResp(snapshot), nil)
+		rc.EXPECT().CreateCollection(mock.Anything, mock.Anything).Return(merr.Success(), nil)
+		rc.EXPECT().DescribeCollection(mock.Anything, mock.Anything).Return(&milvuspb.DescribeCollectionResponse{
+			Status:       merr.Success(),
+			CollectionID: 1000,
+			Schema: &schemapb.CollectionSchema{
+				Fields: []*schemapb.FieldSchema{
+					{FieldID: 100, Name: "vec", DataType: schemapb.DataType_FloatVector},
+					{FieldID: 101, Name: "pk", DataType: schemapb.DataType_Int64, IsPrimaryKey: true},
+				},
+			},
+		}, nil)
+		rc.EXPECT().DropCollection(mock.Anything, mock.Anything).Return(merr.Success(), nil)
+
+		status, err := node.RestoreSnapshot(ctx, proto.Clone(restoreReq).(*proxypb.RestoreSnapshotRequest))
+		assert.NoError(t, err)
+		assert.False(t, merr.Ok(status))
+	})
+
+	t.Run("restore snapshot not found", func(t *testing.T) {
+		node, dc, _ := newProxy(t)
+		dc.EXPECT().ListSnapshots(mock.Anything, mock.Anything).Return(listResp(), nil)
+
+		status, err := node.RestoreSnapshot(ctx, proto.Clone(restoreReq).(*proxypb.RestoreSnapshotRequest))
+		assert.NoError(t, err)
+		assert.ErrorIs(t, merr.Error(status), merr.ErrParameterInvalid)
+	})
+}
+
+func TestProxy_Export(t *testing.T) {
+	ctx := context.Background()
+	paramtable.Init()
+
+	schema := newSchemaInfo(&schemapb.CollectionSchema{
+		Name: "coll",
+		Fields: []*schemapb.FieldSchema{
+			{FieldID: 100, Name: "pk", DataType: schemapb.DataType_Int64, IsPrimaryKey: true},
+			{FieldID: 101, Name: "tenant", DataType: schemapb.DataType_Int64},
+			{FieldID: 102, Name: "vec", DataType: schemapb.DataType_FloatVector},
+		},
+	})
+	newProxy := func(t *testing.T) (*Proxy, *mocks.MockDataCoordClient) {
+		dc := mocks.NewMockDataCoordClient(t)
+		node := &Proxy{dataCoord: dc}
+		node.UpdateStateCode(commonpb.StateCode_Healthy)
+		return node, dc
+	}
+	mockCache := func(t *testing.T) *MockCache {
+		cacheBak := globalMetaCache
+		t.Cleanup(func() { globalMetaCache = cacheBak })
+		cache := NewMockCache(t)
+		cache.EXPECT().GetCollectionID(mock.Anything, "default", "coll").Return(1, nil).Maybe()
+		cache.EXPECT().GetCollectionSchema(mock.Anything, "default", "coll").Return(schema, nil).Maybe()
+		globalMetaCache = cache
+		return cache
+	}
+
+	t.Run("unhealthy", func(t *testing.T) {
+		node := &Proxy{}
+		node.UpdateStateCode(commonpb.StateCode_Abnormal)
+		resp, err := node.Export(ctx, &proxypb.ExportRequest{})
+		assert.NoError(t, err)
+		assert.False(t, merr.Ok(resp.GetStatus()))
+		progress, err := node.GetExportProgress(ctx, &proxypb.GetExportProgressRequest{})
+		assert.NoError(t, err)
+		assert.False(t, merr.Ok(progress.GetStatus()))
+		status, err := node.CancelExport(ctx, &proxypb.CancelExportRequest{})
+		assert.NoError(t, err)
+		assert.False(t, merr.Ok(status))
+	})
+
+	t.Run("normal", func(t *testing.T) {
+		cache := mockCache(t)
+		cache.EXPECT().GetPartitions(mock.Anything, "default", "coll").Return(map[string]int64{"_default": 10, "p1": 11}, nil)
+		node, dc := newProxy(t)
+		dc.EXPECT().Export(mock.Anything, mock.Anything).RunAndReturn(func(ctx context.Context, req *datapb.ExportRequest, options ...grpc.CallOption) (*datapb.ExportResponse, error) {
+			assert.Equal(t, int64(1), req.GetCollectionID())
+			assert.Equal(t, []int64{11}, req.GetPartitionIDs())
+			assert.Equal(t, "pk > 10", req.GetExpr())
+			assert.Empty(t, req.GetSerializedRowFilter())
+			return &datapb.ExportResponse{Status: merr.Success(), JobID: 100}, nil
+		})
+		resp, err := node.Export(ctx, &proxypb.ExportRequest{
+			CollectionName: "coll",
+			PartitionNames: []string{"p1"},
+			Expr:           "pk > 10",
+		})
+		assert.NoError(t, err)
+		assert.True(t, merr.Ok(resp.GetStatus()))
+		assert.Equal(t, int64(100), resp.GetJobID())
+	})
+
+	t.Run("partition not found", func(t *testing.T) {
+		cache := mockCache(t)
+		cache.EXPECT().GetPartitions(mock.Anything, "default", "coll").Return(map[string]int64{"_default": 10}, nil)
+		node, _ := newProxy(t)
+		resp, err := node.Export(ctx, &proxypb.ExportRequest{
+			CollectionName: "coll",
+			PartitionNames: []string{"p1"},
+		})
+		assert.NoError(t, err)
+		assert.ErrorIs(t, merr.Error(resp.GetStatus()), merr.ErrPartitionNotFound)
+	})
+
+	t.Run("restricted user", func(t *testing.T) {
+		paramtable.Get().Save(Params.CommonCfg.AuthorizationEnabled.Key, "true")
+		defer paramtable.Get().Reset(Params.CommonCfg.AuthorizationEnabled.Key)
+		userCtx := GetContext(context.Background(), "alice:123456")
+
+		t.Run("masked fields", func(t *testing.T) {
+			cache := mockCache(t)
+			cache.EXPECT().GetUserRole("alice").Return([]string{"role1"})
+			cache.EXPECT().GetMaskedFields(mock.Anything, "default", "coll").Return([]string{"tenant"})
+			node, _ := newProxy(t)
+			resp, err := node.Export(userCtx, &proxypb.ExportRequest{CollectionName: "coll"})
+			assert.NoError(t, err)
+			assert.ErrorIs(t, merr.Error(resp.GetStatus()), merr.ErrPrivilegeNotPermitted)
+		})
+
+		t.Run("row policies", func(t *testing.T) {
+			cache := mockCache(t)
+			cache.EXPECT().GetUserRole("alice").Return([]string{"role1"})
+			cache.EXPECT().GetMaskedFields(mock.Anything, "default", "coll").Return(nil)
+			cache.EXPECT().GetRowPolicies(mock.Anything, "default", "coll").Return([]string{"tenant == 1"})
+			node, dc := newProxy(t)
+			dc.EXPECT().Export(mock.Anything, mock.Anything).RunAndReturn(func(ctx context.Context, req *datapb.ExportRequest, options ...grpc.CallOption) (*datapb.ExportResponse, error) {
+				// the row policies are carried apart from the expr of the caller
+				assert.Equal(t, "pk > 10", req.GetExpr())
+				filter := &planpb.Expr{}
+				assert.NoError(t, proto.Unmarshal(req.GetSerializedRowFilter(), filter))
+				check, err := exprutil.NewRowFilter(filter)
+				assert.NoError(t, err)
+				assert.True(t, check(map[int64]any{101: int64(1)}))
+				assert.False(t, check(map[int64]any{101: int64(2)}))
+				return &datapb.ExportResponse{Status: merr.Success(), JobID: 100}, nil
+			})
+			resp, err := node.Export(userCtx, &proxypb.ExportRequest{CollectionName: "coll", Expr: "pk > 10"})
+			assert.NoError(t, err)
+			assert.True(t, merr.Ok(resp.GetStatus()))
+		})
+	})
+
+	t.Run("export failed", func(t *testing.T) {
+		mockCache(t)
+		node, dc := newProxy(t)
+		dc.EXPECT().Export(mock.Anything, mock.Anything).Return(&datapb.ExportResponse{
+			Status: merr.Status(merr.WrapErrExportFailed("mock")),
+		}, nil)
+		resp, err := node.Export(ctx, &proxypb.ExportRequest{CollectionName: "coll"})
+		assert.NoError(t, err)
+		assert.False(t, merr.Ok(resp.GetStatus()))
+	})
+
+	t.Run("progress", func(t *testing.T) {
+		node, dc := newProxy(t)
+		dc.EXPECT().GetExportProgress(mock.Anything, mock.Anything).RunAndReturn(func(ctx context.Context, req *datapb.GetExportProgressRequest, options ...grpc.CallOption) (*datapb.GetExportProgressResponse, error) {
+			assert.Equal(t, int64(100), req.GetJobID())
+			return &datapb.GetExportProgressResponse{
+				Status:         merr.Success(),
+				DbName:         "default",
+				CollectionName: "coll",
+				State:          datapb.ExportJobState_ExportInProgress,
+				Progress:       50,
+				Files:          []string{"export/100/1_0.parquet"},
+			}, nil
+		})
+		resp, err := node.GetExportProgress(ctx, &proxypb.GetExportProgressRequest{JobID: 100})
+		assert.NoError(t, err)
+		assert.True(t, merr.Ok(resp.GetStatus()))
+		assert.Equal(t, "ExportInProgress", resp.GetState())
+		assert.Equal(t, int64(50), resp.GetProgress())
+		assert.Equal(t, "coll", resp.GetCollectionName())
+		assert.Equal(t, []string{"export/100/1_0.parquet"}, resp.GetFiles())
+	})
+
+	t.Run("progress failed", func(t *testing.T) {
+		node, dc := newProxy(t)
+		dc.EXPECT().GetExportProgress(mock.Anything, mock.Anything).Return(nil, errors.New("mock"))
+		resp, err := node.GetExportProgress(ctx, &proxypb.GetExportProgressRequest{JobID: 100})
+		assert.NoError(t, err)
+		assert.False(t, merr.Ok(resp.GetStatus()))
+
+		// the job is looked up before it is canceled
+		status, err := node.CancelExport(ctx, &proxypb.CancelExportRequest{JobID: 100})
+		assert.NoError(t, err)
+		assert.False(t, merr.Ok(status))
+	})
+
+	t.Run("cancel", func(t *testing.T) {
+		node, dc := newProxy(t)
+		dc.EXPECT().GetExportProgress(mock.Anything, mock.Anything).Return(&datapb.GetExportProgressResponse{
+			Status:         merr.Success(),
+			DbName:         "default",
+			CollectionName: "coll",
+		}, nil)
+		dc.EXPECT().CancelExport(mock.Anything, mock.Anything).RunAndReturn(func(ctx context.Context, req *datapb.CancelExportRequest, options ...grpc.CallOption) (*commonpb.Status, error) {
+			assert.Equal(t, int64(100), req.GetJobID())
+			return merr.Success(), nil
+		})
+		status, err := node.CancelExport(ctx, &proxypb.CancelExportRequest{JobID: 100})
+		assert.NoError(t, err)
+		assert.True(t, merr.Ok(status))
+	})
+}
+
+func TestProxy_RowPolicy(t *testing.T) {
+	ctx := context.Background()
+	paramtable.Init()
+
+	newProxy := func(t *testing.T) (*Proxy, *mocks.MockRootCoordClient) {
+		rc := mocks.NewMockRootCoordClient(t)
+		node := &Proxy{rootCoord: rc}
+		node.UpdateStateCode(commonpb.StateCode_Healthy)
+		return node, rc
+	}
+	mockCache := func(t *testing.T) {
+		cacheBak := globalMetaCache
+		t.Cleanup(func() { globalMetaCache = cacheBak })
+		cache := NewMockCache(t)
+		cache.EXPECT().GetCollectionSchema(mock.Anything, "default", "coll").Return(newSchemaInfo(&schemapb.CollectionSchema{
+			Name: "coll",
+			Fields: []*schemapb.FieldSchema{
+				{FieldID: 100, Name: "pk", DataType: schemapb.DataType_Int64, IsPrimaryKey: true},
+				{FieldID: 101, Name: "tenant", DataType: schemapb.DataType_Int64},
+			},
+		}), nil)
+		globalMetaCache = cache
+	}
+
+	t.Run("unhealthy", func(t *testing.T) {
+		node := &Proxy{}
+		node.UpdateStateCode(commonpb.StateCode_Abnormal)
+		status, err := node.SetRowPolicy(ctx, &proxypb.SetRowPolicyRequest{})
+		assert.NoError(t, err)
+		assert.False(t, merr.Ok(status))
+		status, err = node.DropRowPolicy(ctx, &proxypb.DropRowPolicyRequest{})
+		assert.NoError(t, err)
+		assert.False(t, merr.Ok(status))
+		resp, err := node.ListRowPolicies(ctx, &proxypb.ListRowPoliciesRequest{})
+		assert.NoError(t, err)
+		assert.False(t, merr.Ok(resp.GetStatus()))
+	})
+
+	t.Run("set", func(t *testing.T) {
+		mockCache(t)
+		node, rc := newProxy(t)
+		rc.EXPECT().OperateRowPolicy(mock.Anything, mock.Anything).RunAndReturn(func(ctx context.Context, req *rootcoordpb.OperateRowPolicyRequest, options ...grpc.CallOption) (*commonpb.Status, error) {
+			assert.Equal(t, rootcoordpb.OperateRowPolicyType_SetRowPolicy, req.GetType())
+			assert.Equal(t, "role1", req.GetPolicy().GetRole())
+			assert.Equal(t, "default", req.GetPolicy().GetDbName())
+			assert.Equal(t, "coll", req.GetPolicy().GetCollectionName())
+			assert.Equal(t, "tenant == 1", req.GetPolicy().GetExpr())
+			return merr.Success(), nil
+		})
+		status, err := node.SetRowPolicy(ctx, &proxypb.SetRowPolicyRequest{
+			RoleName:       "role1",
+			CollectionName: "coll",
+			Expr:           "tenant == 1",
+		})
+		assert.NoError(t, err)
+		assert.True(t, merr.Ok(status))
+	})
+
+	t.Run("set invalid expr", func(t *testing.T) {
+		mockCache(t)
+		node, _ := newProxy(t)
+		status, err := node.SetRowPolicy(ctx, &proxypb.SetRowPolicyRequest{
+			RoleName:       "role1",
+			CollectionName: "coll",
+			Expr:           "unknown == 1",
+		})
+		assert.NoError(t, err)
+		assert.ErrorIs(t, merr.Error(status), merr.ErrParameterInvalid)
+	})
+
+	t.Run("invalid role", func(t *testing.T) {
+		node, _ := newProxy(t)
+		status, err := node.DropRowPolicy(ctx, &proxypb.DropRowPolicyRequest{CollectionName: "coll"})
+		assert.NoError(t, err)
+		assert.False(t, merr.Ok(status))
+	})
+
+	t.Run("drop", func(t *testing.T) {
+		node, rc := newProxy(t)
+		rc.EXPECT().OperateRowPolicy(mock.Anything, mock.Anything).RunAndReturn(func(ctx context.Context, req *rootcoordpb.OperateRowPolicyRequest, options ...grpc.CallOption) (*commonpb.Status, error) {
+			assert.Equal(t, rootcoordpb.OperateRowPolicyType_DropRowPolicy, req.GetType())
+			assert.Equal(t, "db1", req.GetPolicy().GetDbName())
+			return merr.Status(merr.WrapErrServiceInternal("mock")), nil
+		})
+		status, err := node.DropRowPolicy(ctx, &proxypb.DropRowPolicyRequest{
+			RoleName:       "role1",
+			DbName:         "db1",
+			CollectionName: "coll",
+		})
+		assert.NoError(t, err)
+		assert.False(t, merr.Ok(status))
+	})
+
+	t.Run("list", func(t *testing.T) {
+		node, rc := newProxy(t)
+		rc.EXPECT().ListPolicy(mock.Anything, mock.Anything).Return(&internalpb.ListPolicyResponse{
+			Status: merr.Success(),
+			RowPolicies: []*internalpb.RowPolicy{
+				{Role: "role1", DbName: "default", CollectionName: "coll", Expr: "tenant == 1"},
+				{Role: "role2", DbName: "default", CollectionName: "coll", Expr: "tenant == 2"},
+				{Role: "role1", DbName: "default", CollectionName: "coll2", Expr: "tenant == 1"},
+				{Role: "role1", DbName: "db1", CollectionName: "coll", Expr: "tenant == 1"},
+			},
+		}, nil)
+		resp, err := node.ListRowPolicies(ctx, &proxypb.ListRowPoliciesRequest{})
+		assert.NoError(t, err)
+		assert.True(t, merr.Ok(resp.GetStatus()))
+		assert.Len(t, resp.GetPolicies(), 3)
+
+		resp, err = node.ListRowPolicies(ctx, &proxypb.ListRowPoliciesRequest{RoleName: "role1", CollectionName: "coll"})
+		assert.NoError(t, err)
+		assert.Len(t, resp.GetPolicies(), 1)
+		assert.Equal(t, "tenant == 1", resp.GetPolicies()[0].GetExpr())
+	})
+}
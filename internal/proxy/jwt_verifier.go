@@ -0,0 +1,294 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/golang-jwt/jwt/v5"
+	"go.uber.org/zap"
+
+	"github.com/milvus-io/milvus/pkg/log"
+	"github.com/milvus-io/milvus/pkg/util"
+	"github.com/milvus-io/milvus/pkg/util/typeutil"
+)
+
+const bearerPrefix = "Bearer "
+
+// globalJWTVerifier is nil if jwt authentication is disabled.
+var globalJWTVerifier *jwtVerifier
+
+var jwtSigningMethods = []string{"RS256", "RS384", "RS512", "PS256", "PS384", "PS512", "ES256", "ES384", "ES512"}
+
+type jwk struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+type jwtRolesKey struct{}
+
+// NewContextWithJWTRoles attaches the milvus roles mapped from the verified token of the request,
+// the roles are granted to the user within the request only.
+func NewContextWithJWTRoles(ctx context.Context, roles []string) context.Context {
+	return context.WithValue(ctx, jwtRolesKey{}, roles)
+}
+
+func getJWTRoles(ctx context.Context) []string {
+	roles, _ := ctx.Value(jwtRolesKey{}).([]string)
+	return roles
+}
+
+// jwtVerifier validates bearer tokens against the signing keys of the configured JWKS.
+type jwtVerifier struct {
+	jwksURL string
+	client  *http.Client
+
+	mu   sync.RWMutex
+	keys map[string]any // kid -> public key
+}
+
+// checkJWTConfig checks the required configs of jwt authentication.
+func checkJWTConfig() error {
+	if Params.CommonCfg.JWTJWKSURL.GetValue() == "" {
+		return fmt.Errorf("%s is required if jwt authentication is enabled", Params.CommonCfg.JWTJWKSURL.Key)
+	}
+	if Params.CommonCfg.JWTIssuer.GetValue() == "" {
+		return fmt.Errorf("%s is required if jwt authentication is enabled", Params.CommonCfg.JWTIssuer.Key)
+	}
+	if Params.CommonCfg.JWTAudience.GetValue() == "" {
+		return fmt.Errorf("%s is required if jwt authentication is enabled", Params.CommonCfg.JWTAudience.Key)
+	}
+	return nil
+}
+
+func newJWTVerifier(jwksURL string) *jwtVerifier {
+	return &jwtVerifier{
+		jwksURL: jwksURL,
+		client:  &http.Client{Timeout: 10 * time.Second},
+		keys:    make(map[string]any),
+	}
+}
+
+// refresh fetches the JWKS and replaces the signing keys.
+func (v *jwtVerifier) refresh(ctx context.Context) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, v.jwksURL, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := v.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("fetch jwks failed, status: %s", resp.Status)
+	}
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	keys, err := parseJWKS(body)
+	if err != nil {
+		return err
+	}
+
+	v.mu.Lock()
+	v.keys = keys
+	v.mu.Unlock()
+	log.Info("jwks refreshed", zap.String("url", v.jwksURL), zap.Int("keys", len(keys)))
+	return nil
+}
+
+func (v *jwtVerifier) keyFunc(token *jwt.Token) (any, error) {
+	kid, _ := token.Header["kid"].(string)
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+	if kid == "" && len(v.keys) == 1 {
+		for _, key := range v.keys {
+			return key, nil
+		}
+	}
+	key, ok := v.keys[kid]
+	if !ok {
+		return nil, fmt.Errorf("signing key %s not found in jwks", kid)
+	}
+	return key, nil
+}
+
+// Verify checks the signature, issuer, audience and expiry of the token,
+// and returns the user and the milvus roles it is mapped to.
+func (v *jwtVerifier) Verify(token string) (string, []string, error) {
+	if err := checkJWTConfig(); err != nil {
+		return "", nil, err
+	}
+	claims := jwt.MapClaims{}
+	_, err := jwt.ParseWithClaims(token, claims, v.keyFunc,
+		jwt.WithValidMethods(jwtSigningMethods),
+		jwt.WithExpirationRequired(),
+		jwt.WithIssuer(Params.CommonCfg.JWTIssuer.GetValue()),
+		jwt.WithAudience(Params.CommonCfg.JWTAudience.GetValue()))
+	if err != nil {
+		return "", nil, err
+	}
+
+	user, _ := getClaim(claims, Params.CommonCfg.JWTUserClaim.GetValue()).(string)
+	if user == "" {
+		return "", nil, fmt.Errorf("claim %s not found in token", Params.CommonCfg.JWTUserClaim.GetValue())
+	}
+	// the root user is reserved for the credential stored in milvus
+	if user == util.UserRoot {
+		return "", nil, errors.New("token cannot be mapped to the root user")
+	}
+	roles := mapJWTRoles(getClaimStrings(getClaim(claims, Params.CommonCfg.JWTRoleClaim.GetValue())))
+	return user, roles, nil
+}
+
+// mapJWTRoles maps the roles in the token to milvus roles by the configured role mapping,
+// the roles not in the mapping are ignored.
+func mapJWTRoles(claimRoles []string) []string {
+	mapping := Params.CommonCfg.JWTRoleMapping.GetAsJSONMap()
+	roles := typeutil.NewSet[string]()
+	for _, claimRole := range claimRoles {
+		if role, ok := mapping[claimRole]; ok && role != "" {
+			roles.Insert(role)
+		}
+	}
+	return roles.Collect()
+}
+
+// getClaim gets the claim by its dot separated path, like `realm_access.roles`.
+func getClaim(claims map[string]any, path string) any {
+	var value any = claims
+	for _, key := range strings.Split(path, ".") {
+		m, ok := value.(map[string]any)
+		if !ok {
+			return nil
+		}
+		value = m[key]
+	}
+	return value
+}
+
+// getClaimStrings accepts a string array or a comma separated string.
+func getClaimStrings(value any) []string {
+	switch v := value.(type) {
+	case string:
+		res := make([]string, 0)
+		for _, s := range strings.Split(v, ",") {
+			if s = strings.TrimSpace(s); s != "" {
+				res = append(res, s)
+			}
+		}
+		return res
+	case []any:
+		res := make([]string, 0, len(v))
+		for _, e := range v {
+			if s, ok := e.(string); ok && s != "" {
+				res = append(res, s)
+			}
+		}
+		return res
+	default:
+		return nil
+	}
+}
+
+func parseJWKS(body []byte) (map[string]any, error) {
+	set := struct {
+		Keys []jwk `json:"keys"`
+	}{}
+	if err := json.Unmarshal(body, &set); err != nil {
+		return nil, err
+	}
+	keys := make(map[string]any)
+	for _, k := range set.Keys {
+		if k.Use != "" && k.Use != "sig" {
+			continue
+		}
+		key, err := k.publicKey()
+		if err != nil {
+			log.Warn("skip invalid jwk", zap.String("kid", k.Kid), zap.Error(err))
+			continue
+		}
+		keys[k.Kid] = key
+	}
+	return keys, nil
+}
+
+func (k *jwk) publicKey() (any, error) {
+	decode := func(s string) (*big.Int, error) {
+		b, err := base64.RawURLEncoding.DecodeString(s)
+		if err != nil {
+			return nil, err
+		}
+		return new(big.Int).SetBytes(b), nil
+	}
+	switch k.Kty {
+	case "RSA":
+		n, err := decode(k.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := decode(k.E)
+		if err != nil {
+			return nil, err
+		}
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+	case "EC":
+		var curve elliptic.Curve
+		switch k.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("unsupported curve %s", k.Crv)
+		}
+		x, err := decode(k.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := decode(k.Y)
+		if err != nil {
+			return nil, err
+		}
+		return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, nil
+	default:
+		return nil, fmt.Errorf("unsupported key type %s", k.Kty)
+	}
+}
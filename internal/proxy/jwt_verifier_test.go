@@ -0,0 +1,237 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/suite"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/milvus-io/milvus/internal/mocks"
+	"github.com/milvus-io/milvus/pkg/util"
+	"github.com/milvus-io/milvus/pkg/util/crypto"
+	"github.com/milvus-io/milvus/pkg/util/paramtable"
+)
+
+type JWTVerifierSuite struct {
+	suite.Suite
+
+	key      *rsa.PrivateKey
+	server   *httptest.Server
+	verifier *jwtVerifier
+}
+
+func (s *JWTVerifierSuite) SetupSuite() {
+	paramtable.Init()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	s.Require().NoError(err)
+	s.key = key
+
+	jwks, err := json.Marshal(map[string]any{
+		"keys": []map[string]string{
+			{
+				"kty": "RSA",
+				"kid": "key1",
+				"use": "sig",
+				"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+				"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+			},
+		},
+	})
+	s.Require().NoError(err)
+	s.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write(jwks)
+	}))
+}
+
+func (s *JWTVerifierSuite) TearDownSuite() {
+	s.server.Close()
+}
+
+func (s *JWTVerifierSuite) SetupTest() {
+	paramtable.Get().Save(Params.CommonCfg.JWTIssuer.Key, "https://idp.example.com")
+	paramtable.Get().Save(Params.CommonCfg.JWTAudience.Key, "milvus")
+	paramtable.Get().Save(Params.CommonCfg.JWTJWKSURL.Key, s.server.URL)
+	paramtable.Get().Save(Params.CommonCfg.JWTRoleMapping.Key, `{"reader": "db_ro", "writer": "db_rw"}`)
+	s.verifier = newJWTVerifier(s.server.URL)
+	s.Require().NoError(s.verifier.refresh(context.Background()))
+}
+
+func (s *JWTVerifierSuite) TearDownTest() {
+	paramtable.Get().Reset(Params.CommonCfg.JWTIssuer.Key)
+	paramtable.Get().Reset(Params.CommonCfg.JWTAudience.Key)
+	paramtable.Get().Reset(Params.CommonCfg.JWTRoleClaim.Key)
+	paramtable.Get().Reset(Params.CommonCfg.JWTJWKSURL.Key)
+	paramtable.Get().Reset(Params.CommonCfg.JWTRoleMapping.Key)
+}
+
+func (s *JWTVerifierSuite) sign(claims jwt.MapClaims) string {
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["kid"] = "key1"
+	signed, err := token.SignedString(s.key)
+	s.Require().NoError(err)
+	return signed
+}
+
+func (s *JWTVerifierSuite) validClaims() jwt.MapClaims {
+	return jwt.MapClaims{
+		"sub":   "alice",
+		"iss":   "https://idp.example.com",
+		"aud":   "milvus",
+		"exp":   time.Now().Add(time.Hour).Unix(),
+		"roles": []string{"reader", "writer"},
+	}
+}
+
+func (s *JWTVerifierSuite) TestVerify() {
+	user, roles, err := s.verifier.Verify(s.sign(s.validClaims()))
+	s.NoError(err)
+	s.Equal("alice", user)
+	s.ElementsMatch([]string{"db_ro", "db_rw"}, roles)
+}
+
+func (s *JWTVerifierSuite) TestNestedRoleClaim() {
+	paramtable.Get().Save(Params.CommonCfg.JWTRoleClaim.Key, "realm_access.roles")
+	claims := s.validClaims()
+	claims["realm_access"] = map[string]any{"roles": "admin, reader"}
+	_, roles, err := s.verifier.Verify(s.sign(claims))
+	s.NoError(err)
+	// admin is not in the role mapping
+	s.ElementsMatch([]string{"db_ro"}, roles)
+}
+
+func (s *JWTVerifierSuite) TestRoleMapping() {
+	claims := s.validClaims()
+	claims["roles"] = []string{"idp-admin", util.RoleAdmin}
+	_, roles, err := s.verifier.Verify(s.sign(claims))
+	s.NoError(err)
+	s.Empty(roles)
+
+	paramtable.Get().Save(Params.CommonCfg.JWTRoleMapping.Key, "{}")
+	_, roles, err = s.verifier.Verify(s.sign(s.validClaims()))
+	s.NoError(err)
+	s.Empty(roles)
+}
+
+func (s *JWTVerifierSuite) TestRequiredConfig() {
+	for _, key := range []string{Params.CommonCfg.JWTIssuer.Key, Params.CommonCfg.JWTAudience.Key, Params.CommonCfg.JWTJWKSURL.Key} {
+		s.Run(key, func() {
+			paramtable.Get().Save(key, "")
+			defer s.SetupTest()
+			s.Error(checkJWTConfig())
+			_, _, err := s.verifier.Verify(s.sign(s.validClaims()))
+			s.Error(err)
+		})
+	}
+	s.NoError(checkJWTConfig())
+}
+
+func (s *JWTVerifierSuite) TestInvalidToken() {
+	cases := map[string]func(jwt.MapClaims){
+		"expired":      func(c jwt.MapClaims) { c["exp"] = time.Now().Add(-time.Hour).Unix() },
+		"no expiry":    func(c jwt.MapClaims) { delete(c, "exp") },
+		"wrong issuer": func(c jwt.MapClaims) { c["iss"] = "https://evil.example.com" },
+		"wrong aud":    func(c jwt.MapClaims) { c["aud"] = "other" },
+		"no user":      func(c jwt.MapClaims) { delete(c, "sub") },
+		"root user":    func(c jwt.MapClaims) { c["sub"] = util.UserRoot },
+	}
+	for name, modify := range cases {
+		s.Run(name, func() {
+			claims := s.validClaims()
+			modify(claims)
+			_, _, err := s.verifier.Verify(s.sign(claims))
+			s.Error(err)
+		})
+	}
+
+	s.Run("unknown key", func() {
+		otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+		s.Require().NoError(err)
+		token := jwt.NewWithClaims(jwt.SigningMethodRS256, s.validClaims())
+		token.Header["kid"] = "key1"
+		signed, err := token.SignedString(otherKey)
+		s.Require().NoError(err)
+		_, _, err = s.verifier.Verify(signed)
+		s.Error(err)
+	})
+
+	s.Run("hmac", func() {
+		token := jwt.NewWithClaims(jwt.SigningMethodHS256, s.validClaims())
+		signed, err := token.SignedString([]byte("secret"))
+		s.Require().NoError(err)
+		_, _, err = s.verifier.Verify(signed)
+		s.Error(err)
+	})
+}
+
+func (s *JWTVerifierSuite) TestAuthenticationInterceptor() {
+	paramtable.Get().Save(Params.CommonCfg.AuthorizationEnabled.Key, "true")
+	defer paramtable.Get().Reset(Params.CommonCfg.AuthorizationEnabled.Key)
+	ctx := context.Background()
+	err := InitMetaCache(ctx, &MockRootCoordClientInterface{}, &mocks.MockQueryCoordClient{}, newShardClientMgr())
+	s.Require().NoError(err)
+
+	globalJWTVerifier = s.verifier
+	defer func() {
+		globalJWTVerifier = nil
+	}()
+
+	md := metadata.Pairs(util.HeaderAuthorize, bearerPrefix+s.sign(s.validClaims()))
+	authCtx, err := AuthenticationInterceptor(metadata.NewIncomingContext(ctx, md))
+	s.NoError(err)
+	user, err := GetCurUserFromContext(authCtx)
+	s.NoError(err)
+	s.Equal("alice", user)
+	roles, err := getUserRoles(authCtx, user)
+	s.NoError(err)
+	s.Subset(roles, []string{"db_ro", "db_rw"})
+	// the roles of the token are not granted to the other requests of the user
+	roles, err = getUserRoles(ctx, user)
+	s.NoError(err)
+	s.NotContains(roles, "db_ro")
+	s.NotContains(roles, "db_rw")
+
+	md = metadata.Pairs(util.HeaderAuthorize, bearerPrefix+"invalid")
+	_, err = AuthenticationInterceptor(metadata.NewIncomingContext(ctx, md))
+	s.Error(err)
+
+	// bearer tokens are rejected if jwt is disabled
+	globalJWTVerifier = nil
+	md = metadata.Pairs(util.HeaderAuthorize, bearerPrefix+s.sign(s.validClaims()))
+	_, err = AuthenticationInterceptor(metadata.NewIncomingContext(ctx, md))
+	s.Error(err)
+
+	// username/password still works
+	md = metadata.Pairs(util.HeaderAuthorize, crypto.Base64Encode("mockUser:mockPass"))
+	_, err = AuthenticationInterceptor(metadata.NewIncomingContext(ctx, md))
+	s.NoError(err)
+}
+
+func TestJWTVerifier(t *testing.T) {
+	suite.Run(t, new(JWTVerifierSuite))
+}
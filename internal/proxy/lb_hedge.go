@@ -0,0 +1,116 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"context"
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/samber/lo"
+	"go.uber.org/atomic"
+
+	"github.com/milvus-io/milvus/pkg/util/typeutil"
+)
+
+const (
+	// the number of the recent latencies kept for each node
+	hedgeLatencyWindow = 100
+	// the node is not hedged until it has enough latencies to compute the percentile
+	hedgeMinLatencySamples = 20
+	// the max number of hedged requests could be sent in a burst
+	hedgeBudgetBurst = 10
+)
+
+// errShardResultClaimed is returned by the hedged execution whose result is dropped,
+// since the other execution of the same shard has returned the result.
+var errShardResultClaimed = errors.New("the result of the shard is returned by another hedged request")
+
+type shardResultClaimKey struct{}
+
+// claimShardResult must be called by the executeFunc of a hedgeable workload before it records the result.
+// Only one of the hedged executions of a shard could claim the result, the others must drop theirs.
+func claimShardResult(ctx context.Context) bool {
+	claimed, ok := ctx.Value(shardResultClaimKey{}).(*atomic.Bool)
+	if !ok {
+		return true
+	}
+	return claimed.CompareAndSwap(false, true)
+}
+
+// shardResultClaimed returns whether the result of the shard has been returned by another hedged execution,
+// the failure of the execution is expected then since it's canceled.
+func shardResultClaimed(ctx context.Context) bool {
+	claimed, ok := ctx.Value(shardResultClaimKey{}).(*atomic.Bool)
+	return ok && claimed.Load()
+}
+
+// latencyTracker keeps the recent latencies of the shard requests on each node.
+type latencyTracker struct {
+	latencies *typeutil.ConcurrentMap[int64, *typeutil.RingBuffer[time.Duration]]
+}
+
+func newLatencyTracker() *latencyTracker {
+	return &latencyTracker{
+		latencies: typeutil.NewConcurrentMap[int64, *typeutil.RingBuffer[time.Duration]](),
+	}
+}
+
+func (t *latencyTracker) record(node int64, latency time.Duration) {
+	latencies, _ := t.latencies.GetOrInsert(node, typeutil.NewRingBuffer[time.Duration](hedgeLatencyWindow))
+	latencies.Add(latency)
+}
+
+// percentile returns the percentile of the recent latencies of the node,
+// false if there are not enough latencies.
+func (t *latencyTracker) percentile(node int64, p float64) (time.Duration, bool) {
+	latencies, ok := t.latencies.Get(node)
+	if !ok || latencies.Len() < hedgeMinLatencySamples {
+		return 0, false
+	}
+	values := latencies.Values()
+	sort.Slice(values, func(i, j int) bool { return values[i] < values[j] })
+	idx := int(math.Ceil(p*float64(len(values)))) - 1
+	idx = lo.Clamp(idx, 0, len(values)-1)
+	return values[idx], true
+}
+
+// hedgeBudget limits the hedged requests to a ratio of all the shard requests.
+// Each shard request earns ratio token, and each hedged request costs one token.
+type hedgeBudget struct {
+	mu     sync.Mutex
+	tokens float64
+}
+
+func (b *hedgeBudget) deposit(ratio float64) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.tokens = math.Min(b.tokens+ratio, hedgeBudgetBurst)
+}
+
+func (b *hedgeBudget) withdraw() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.tokens < 1 {
+		return false
+	}
+	b.tokens--
+	return true
+}
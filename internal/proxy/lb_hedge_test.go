@@ -0,0 +1,91 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/atomic"
+)
+
+func TestLatencyTracker(t *testing.T) {
+	tracker := newLatencyTracker()
+	_, ok := tracker.percentile(1, 0.95)
+	assert.False(t, ok)
+
+	for i := 1; i < hedgeMinLatencySamples; i++ {
+		tracker.record(1, time.Duration(i)*time.Millisecond)
+	}
+	_, ok = tracker.percentile(1, 0.95)
+	assert.False(t, ok)
+
+	for i := hedgeMinLatencySamples; i <= hedgeLatencyWindow; i++ {
+		tracker.record(1, time.Duration(i)*time.Millisecond)
+	}
+	latency, ok := tracker.percentile(1, 0.95)
+	assert.True(t, ok)
+	assert.Equal(t, 95*time.Millisecond, latency)
+	latency, ok = tracker.percentile(1, 1)
+	assert.True(t, ok)
+	assert.Equal(t, 100*time.Millisecond, latency)
+
+	// only the recent latencies are kept
+	for i := 0; i < hedgeLatencyWindow; i++ {
+		tracker.record(1, time.Second)
+	}
+	latency, ok = tracker.percentile(1, 0.5)
+	assert.True(t, ok)
+	assert.Equal(t, time.Second, latency)
+}
+
+func TestHedgeBudget(t *testing.T) {
+	budget := &hedgeBudget{}
+	assert.False(t, budget.withdraw())
+
+	// 10% of the requests could be hedged
+	for i := 0; i < 15; i++ {
+		budget.deposit(0.1)
+	}
+	assert.True(t, budget.withdraw())
+	assert.False(t, budget.withdraw())
+
+	// the burst is limited
+	for i := 0; i < 1000; i++ {
+		budget.deposit(0.1)
+	}
+	for i := 0; i < hedgeBudgetBurst; i++ {
+		assert.True(t, budget.withdraw())
+	}
+	assert.False(t, budget.withdraw())
+}
+
+func TestClaimShardResult(t *testing.T) {
+	// the result is always claimed if not hedged
+	ctx := context.Background()
+	assert.False(t, shardResultClaimed(ctx))
+	assert.True(t, claimShardResult(ctx))
+	assert.True(t, claimShardResult(ctx))
+
+	ctx = context.WithValue(ctx, shardResultClaimKey{}, atomic.NewBool(false))
+	assert.False(t, shardResultClaimed(ctx))
+	assert.True(t, claimShardResult(ctx))
+	assert.True(t, shardResultClaimed(ctx))
+	assert.False(t, claimShardResult(ctx))
+}
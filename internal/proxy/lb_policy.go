@@ -17,9 +17,12 @@ package proxy
 
 import (
 	"context"
+	"strconv"
+	"time"
 
 	"github.com/cockroachdb/errors"
 	"github.com/samber/lo"
+	"go.uber.org/atomic"
 	"go.uber.org/zap"
 	"golang.org/x/sync/errgroup"
 
@@ -27,7 +30,9 @@ import (
 	"github.com/milvus-io/milvus/internal/querycoordv2/params"
 	"github.com/milvus-io/milvus/internal/types"
 	"github.com/milvus-io/milvus/pkg/log"
+	"github.com/milvus-io/milvus/pkg/metrics"
 	"github.com/milvus-io/milvus/pkg/util/merr"
+	"github.com/milvus-io/milvus/pkg/util/paramtable"
 	"github.com/milvus-io/milvus/pkg/util/retry"
 	"github.com/milvus-io/milvus/pkg/util/typeutil"
 )
@@ -43,6 +48,9 @@ type ChannelWorkload struct {
 	nq             int64
 	exec           executeFunc
 	retryTimes     uint
+	// hedgeable is true if the exec is read only and claims the result by claimShardResult,
+	// so the workload could be executed on another replica at the same time.
+	hedgeable bool
 }
 
 type CollectionWorkLoad struct {
@@ -51,6 +59,7 @@ type CollectionWorkLoad struct {
 	collectionID   int64
 	nq             int64
 	exec           executeFunc
+	hedgeable      bool
 }
 
 type LBPolicy interface {
@@ -71,6 +80,10 @@ type LBPolicyImpl struct {
 	clientMgr      shardClientMgr
 	balancerMap    map[string]LBBalancer
 	retryOnReplica int
+
+	// for hedging the slow shard requests to another replica
+	latencies   *latencyTracker
+	hedgeBudget *hedgeBudget
 }
 
 func NewLBPolicyImpl(clientMgr shardClientMgr) *LBPolicyImpl {
@@ -93,6 +106,8 @@ func NewLBPolicyImpl(clientMgr shardClientMgr) *LBPolicyImpl {
 		clientMgr:      clientMgr,
 		balancerMap:    balancerMap,
 		retryOnReplica: retryOnReplica,
+		latencies:      newLatencyTracker(),
+		hedgeBudget:    &hedgeBudget{},
 	}
 }
 
@@ -207,7 +222,7 @@ func (lb *LBPolicyImpl) ExecuteWithRetry(ctx context.Context, workload ChannelWo
 			return lastErr
 		}
 
-		err = workload.exec(ctx, targetNode.nodeID, client, workload.channel)
+		err = lb.exec(ctx, balancer, workload, targetNode, client, excludeNodes)
 		if err != nil {
 			log.Warn("search/query channel failed",
 				zap.Int64("collectionID", workload.collectionID),
@@ -225,6 +240,141 @@ func (lb *LBPolicyImpl) ExecuteWithRetry(ctx context.Context, workload ChannelWo
 	return err
 }
 
+// hedgeDelay returns how long to wait for the target node before hedging the workload to another replica,
+// false if the workload shouldn't be hedged.
+func (lb *LBPolicyImpl) hedgeDelay(workload ChannelWorkload, targetNode int64, excludeNodes typeutil.UniqueSet) (time.Duration, bool) {
+	if !workload.hedgeable || !Params.ProxyCfg.HedgingEnabled.GetAsBool() {
+		return 0, false
+	}
+	lb.hedgeBudget.deposit(Params.ProxyCfg.HedgingBudgetRatio.GetAsFloat())
+	hasOtherReplica := lo.ContainsBy(workload.shardLeaders, func(node nodeInfo) bool {
+		return node.nodeID != targetNode && !excludeNodes.Contain(node.nodeID)
+	})
+	if !hasOtherReplica {
+		return 0, false
+	}
+	delay, ok := lb.latencies.percentile(targetNode, Params.ProxyCfg.HedgingLatencyPercentile.GetAsFloat())
+	if !ok {
+		return 0, false
+	}
+	minDelay := Params.ProxyCfg.HedgingMinDelay.GetAsDuration(time.Millisecond)
+	if delay < minDelay {
+		delay = minDelay
+	}
+	return delay, true
+}
+
+// exec executes the workload on the target node. If the target node doesn't return in time,
+// the workload is hedged to a node of another replica, and the result which arrives first is taken.
+func (lb *LBPolicyImpl) exec(ctx context.Context, balancer LBBalancer, workload ChannelWorkload, targetNode nodeInfo, client types.QueryNodeClient, excludeNodes typeutil.UniqueSet) error {
+	type execResult struct {
+		nodeID int64
+		err    error
+	}
+	execOn := func(ctx context.Context, node nodeInfo, client types.QueryNodeClient) error {
+		start := time.Now()
+		err := workload.exec(ctx, node.nodeID, client, workload.channel)
+		if err == nil {
+			lb.latencies.record(node.nodeID, time.Since(start))
+		}
+		return err
+	}
+
+	delay, ok := lb.hedgeDelay(workload, targetNode.nodeID, excludeNodes)
+	if !ok {
+		return execOn(ctx, targetNode, client)
+	}
+
+	ctx = context.WithValue(ctx, shardResultClaimKey{}, atomic.NewBool(false))
+	results := make(chan execResult, 2)
+	primaryCtx, cancelPrimary := context.WithCancel(ctx)
+	defer cancelPrimary()
+	primaryStart := time.Now()
+	go func() {
+		results <- execResult{nodeID: targetNode.nodeID, err: execOn(primaryCtx, targetNode, client)}
+	}()
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case result := <-results:
+		return result.err
+	case <-timer.C:
+	}
+
+	hedgeNode, hedgeClient, ok := lb.selectHedgeNode(ctx, balancer, workload, targetNode, excludeNodes)
+	if !ok {
+		return (<-results).err
+	}
+	// cancel work load which assign to the hedge node
+	defer balancer.CancelWorkload(hedgeNode.nodeID, workload.nq)
+	hedgeCtx, cancelHedge := context.WithCancel(ctx)
+	defer cancelHedge()
+	go func() {
+		results <- execResult{nodeID: hedgeNode.nodeID, err: execOn(hedgeCtx, hedgeNode, hedgeClient)}
+	}()
+
+	nodeID := strconv.FormatInt(paramtable.GetNodeID(), 10)
+	var primaryErr error
+	primaryDone := false
+	for i := 0; i < 2; i++ {
+		result := <-results
+		if result.err == nil {
+			// cancel the one still running, its result would be dropped
+			if result.nodeID == hedgeNode.nodeID {
+				cancelPrimary()
+				// the latency of the canceled primary is not known but no less than the elapsed time,
+				// record the lower bound, otherwise only the fast requests of the slow node are recorded
+				if !primaryDone {
+					lb.latencies.record(targetNode.nodeID, time.Since(primaryStart))
+				}
+				metrics.ProxyHedgedRequestCount.WithLabelValues(nodeID, metrics.SuccessLabel).Inc()
+			} else {
+				cancelHedge()
+				metrics.ProxyHedgedRequestCount.WithLabelValues(nodeID, metrics.PrimaryWonLabel).Inc()
+			}
+			return nil
+		}
+		if result.nodeID == targetNode.nodeID {
+			primaryErr = result.err
+			primaryDone = true
+		} else if !errors.Is(result.err, errShardResultClaimed) {
+			log.Ctx(ctx).Warn("hedged search/query channel failed",
+				zap.Int64("collectionID", workload.collectionID),
+				zap.String("channelName", workload.channel),
+				zap.Int64("nodeID", hedgeNode.nodeID),
+				zap.Error(result.err))
+			excludeNodes.Insert(hedgeNode.nodeID)
+		}
+	}
+	metrics.ProxyHedgedRequestCount.WithLabelValues(nodeID, metrics.FailLabel).Inc()
+	return primaryErr
+}
+
+// selectHedgeNode selects a node of another replica to hedge the workload, false if there is none or no budget.
+func (lb *LBPolicyImpl) selectHedgeNode(ctx context.Context, balancer LBBalancer, workload ChannelWorkload, targetNode nodeInfo, excludeNodes typeutil.UniqueSet) (nodeInfo, types.QueryNodeClient, bool) {
+	if !lb.hedgeBudget.withdraw() {
+		return nodeInfo{}, nil, false
+	}
+	exclude := typeutil.NewUniqueSet(excludeNodes.Collect()...)
+	exclude.Insert(targetNode.nodeID)
+	hedgeNode, err := lb.selectNode(ctx, balancer, workload, exclude)
+	if err != nil {
+		return nodeInfo{}, nil, false
+	}
+	client, err := lb.clientMgr.GetClient(ctx, hedgeNode)
+	if err != nil {
+		balancer.CancelWorkload(hedgeNode.nodeID, workload.nq)
+		return nodeInfo{}, nil, false
+	}
+	log.Ctx(ctx).Debug("hedge search/query channel to another replica",
+		zap.Int64("collectionID", workload.collectionID),
+		zap.String("channelName", workload.channel),
+		zap.Int64("nodeID", targetNode.nodeID),
+		zap.Int64("hedgeNodeID", hedgeNode.nodeID))
+	return hedgeNode, client, true
+}
+
 // Execute will execute collection workload in parallel
 func (lb *LBPolicyImpl) Execute(ctx context.Context, workload CollectionWorkLoad) error {
 	dml2leaders, err := lb.GetShardLeaders(ctx, workload.db, workload.collectionName, workload.collectionID, true)
@@ -252,6 +402,7 @@ func (lb *LBPolicyImpl) Execute(ctx context.Context, workload CollectionWorkLoad
 				nq:             workload.nq,
 				exec:           workload.exec,
 				retryTimes:     uint(channelRetryTimes),
+				hedgeable:      workload.hedgeable,
 			})
 		})
 	}
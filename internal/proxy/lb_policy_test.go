@@ -18,10 +18,14 @@ package proxy
 import (
 	"context"
 	"reflect"
+	"strconv"
 	"testing"
+	"time"
 
 	"github.com/cockroachdb/errors"
 	"github.com/pingcap/log"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/samber/lo"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
 	"go.uber.org/atomic"
@@ -37,6 +41,7 @@ import (
 	"github.com/milvus-io/milvus/internal/proto/querypb"
 	"github.com/milvus-io/milvus/internal/types"
 	"github.com/milvus-io/milvus/pkg/common"
+	"github.com/milvus-io/milvus/pkg/metrics"
 	"github.com/milvus-io/milvus/pkg/util/merr"
 	"github.com/milvus-io/milvus/pkg/util/paramtable"
 	"github.com/milvus-io/milvus/pkg/util/typeutil"
@@ -378,6 +383,161 @@ func (s *LBPolicySuite) TestExecuteWithRetry() {
 	s.True(merr.IsCanceledOrTimeout(err))
 }
 
+func (s *LBPolicySuite) TestExecuteWithHedging() {
+	ctx := context.Background()
+	paramtable.Get().Save(Params.ProxyCfg.HedgingEnabled.Key, "true")
+	defer paramtable.Get().Reset(Params.ProxyCfg.HedgingEnabled.Key)
+	paramtable.Get().Save(Params.ProxyCfg.HedgingBudgetRatio.Key, "1")
+	defer paramtable.Get().Reset(Params.ProxyCfg.HedgingBudgetRatio.Key)
+	for i := 0; i < hedgeMinLatencySamples; i++ {
+		s.lbPolicy.latencies.record(1, time.Millisecond)
+	}
+
+	s.lbBalancer.ExpectedCalls = nil
+	s.mgr.EXPECT().GetClient(mock.Anything, mock.Anything).Return(s.qn, nil)
+	s.lbBalancer.EXPECT().RegisterNodeInfo(mock.Anything)
+	s.lbBalancer.EXPECT().SelectNode(mock.Anything, mock.Anything, mock.Anything).RunAndReturn(
+		func(ctx context.Context, availableNodes []int64, nq int64) (int64, error) {
+			return lo.Min(availableNodes), nil
+		})
+	s.lbBalancer.EXPECT().CancelWorkload(mock.Anything, mock.Anything)
+	proxyID := strconv.FormatInt(paramtable.GetNodeID(), 10)
+	hedgedCount := func(status string) float64 {
+		return testutil.ToFloat64(metrics.ProxyHedgedRequestCount.WithLabelValues(proxyID, status))
+	}
+	hedgeWon, primaryWon, failed := hedgedCount(metrics.SuccessLabel), hedgedCount(metrics.PrimaryWonLabel), hedgedCount(metrics.FailLabel)
+
+	// node 1 is slow, the result of the hedged node 2 is taken and node 1 is canceled
+	results := typeutil.NewConcurrentSet[int64]()
+	canceled := atomic.NewBool(false)
+	err := s.lbPolicy.ExecuteWithRetry(ctx, ChannelWorkload{
+		db:             dbName,
+		collectionName: s.collectionName,
+		collectionID:   s.collectionID,
+		channel:        s.channels[0],
+		shardLeaders:   s.nodes,
+		nq:             1,
+		exec: func(ctx context.Context, nodeID UniqueID, qn types.QueryNodeClient, channel string) error {
+			if nodeID == 1 {
+				<-ctx.Done()
+				canceled.Store(true)
+				if shardResultClaimed(ctx) {
+					return errShardResultClaimed
+				}
+				return ctx.Err()
+			}
+			if !claimShardResult(ctx) {
+				return errShardResultClaimed
+			}
+			results.Insert(nodeID)
+			return nil
+		},
+		retryTimes: 1,
+		hedgeable:  true,
+	})
+	s.NoError(err)
+	s.ElementsMatch([]int64{2}, results.Collect())
+	s.Eventually(canceled.Load, time.Second, 10*time.Millisecond)
+	s.Equal(hedgeWon+1, hedgedCount(metrics.SuccessLabel))
+	// the elapsed time of the canceled primary is recorded as the lower bound of its latency
+	latency, ok := s.lbPolicy.latencies.percentile(1, 1)
+	s.True(ok)
+	s.GreaterOrEqual(latency, Params.ProxyCfg.HedgingMinDelay.GetAsDuration(time.Millisecond))
+
+	// node 1 returns after hedged but before node 2
+	results = typeutil.NewConcurrentSet[int64]()
+	err = s.lbPolicy.ExecuteWithRetry(ctx, ChannelWorkload{
+		db:             dbName,
+		collectionName: s.collectionName,
+		collectionID:   s.collectionID,
+		channel:        s.channels[0],
+		shardLeaders:   s.nodes,
+		nq:             1,
+		exec: func(ctx context.Context, nodeID UniqueID, qn types.QueryNodeClient, channel string) error {
+			if nodeID == 2 {
+				<-ctx.Done()
+				return errShardResultClaimed
+			}
+			time.Sleep(50 * time.Millisecond)
+			if !claimShardResult(ctx) {
+				return errShardResultClaimed
+			}
+			results.Insert(nodeID)
+			return nil
+		},
+		retryTimes: 1,
+		hedgeable:  true,
+	})
+	s.NoError(err)
+	s.ElementsMatch([]int64{1}, results.Collect())
+	s.Equal(primaryWon+1, hedgedCount(metrics.PrimaryWonLabel))
+
+	// both node 1 and the hedged node 2 fail
+	mockErr := errors.New("mock error")
+	err = s.lbPolicy.ExecuteWithRetry(ctx, ChannelWorkload{
+		db:             dbName,
+		collectionName: s.collectionName,
+		collectionID:   s.collectionID,
+		channel:        s.channels[0],
+		shardLeaders:   s.nodes,
+		nq:             1,
+		exec: func(ctx context.Context, nodeID UniqueID, qn types.QueryNodeClient, channel string) error {
+			if nodeID == 1 {
+				time.Sleep(50 * time.Millisecond)
+			}
+			return mockErr
+		},
+		retryTimes: 1,
+		hedgeable:  true,
+	})
+	s.ErrorIs(err, mockErr)
+	s.Equal(failed+1, hedgedCount(metrics.FailLabel))
+
+	// the workload not hedgeable is never hedged
+	results = typeutil.NewConcurrentSet[int64]()
+	err = s.lbPolicy.ExecuteWithRetry(ctx, ChannelWorkload{
+		db:             dbName,
+		collectionName: s.collectionName,
+		collectionID:   s.collectionID,
+		channel:        s.channels[0],
+		shardLeaders:   s.nodes,
+		nq:             1,
+		exec: func(ctx context.Context, nodeID UniqueID, qn types.QueryNodeClient, channel string) error {
+			time.Sleep(50 * time.Millisecond)
+			results.Insert(nodeID)
+			return nil
+		},
+		retryTimes: 1,
+	})
+	s.NoError(err)
+	s.ElementsMatch([]int64{1}, results.Collect())
+
+	// no hedging without budget
+	paramtable.Get().Save(Params.ProxyCfg.HedgingBudgetRatio.Key, "0")
+	s.lbPolicy.hedgeBudget = &hedgeBudget{}
+	results = typeutil.NewConcurrentSet[int64]()
+	err = s.lbPolicy.ExecuteWithRetry(ctx, ChannelWorkload{
+		db:             dbName,
+		collectionName: s.collectionName,
+		collectionID:   s.collectionID,
+		channel:        s.channels[0],
+		shardLeaders:   s.nodes,
+		nq:             1,
+		exec: func(ctx context.Context, nodeID UniqueID, qn types.QueryNodeClient, channel string) error {
+			time.Sleep(50 * time.Millisecond)
+			if !claimShardResult(ctx) {
+				return errShardResultClaimed
+			}
+			results.Insert(nodeID)
+			return nil
+		},
+		retryTimes: 1,
+		hedgeable:  true,
+	})
+	s.NoError(err)
+	s.ElementsMatch([]int64{1}, results.Collect())
+}
+
 func (s *LBPolicySuite) TestExecute() {
 	ctx := context.Background()
 	mockErr := errors.New("mock error")
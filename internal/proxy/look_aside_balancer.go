@@ -22,12 +22,14 @@ import (
 	"sync"
 	"time"
 
+	"github.com/samber/lo"
 	"go.uber.org/atomic"
 	"go.uber.org/zap"
 
 	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
 	"github.com/milvus-io/milvus-proto/go-api/v2/milvuspb"
 	"github.com/milvus-io/milvus/internal/proto/internalpb"
+	"github.com/milvus-io/milvus/internal/util/sessionutil"
 	"github.com/milvus-io/milvus/pkg/log"
 	"github.com/milvus-io/milvus/pkg/util/conc"
 	"github.com/milvus-io/milvus/pkg/util/merr"
@@ -52,6 +54,9 @@ type LookAsideBalancer struct {
 	// idx for round_robin
 	idx atomic.Int64
 
+	// the availability zone of the proxy, the nodes in the same zone are preferred
+	zone string
+
 	closeCh   chan struct{}
 	closeOnce sync.Once
 	wg        sync.WaitGroup
@@ -69,6 +74,7 @@ func NewLookAsideBalancer(clientMgr shardClientMgr) *LookAsideBalancer {
 		metricsMap:             typeutil.NewConcurrentMap[int64, *CostMetrics](),
 		failedHeartBeatCounter: typeutil.NewConcurrentMap[int64, *atomic.Int64](),
 		closeCh:                make(chan struct{}),
+		zone:                   sessionutil.GetZoneFromEnv(typeutil.ProxyRole),
 	}
 
 	balancer.metricExpireInterval = Params.ProxyCfg.CostMetricsExpireTime.GetAsInt64()
@@ -97,6 +103,7 @@ func (b *LookAsideBalancer) RegisterNodeInfo(nodeInfos []nodeInfo) {
 }
 
 func (b *LookAsideBalancer) SelectNode(ctx context.Context, availableNodes []int64, nq int64) (int64, error) {
+	availableNodes = b.preferLocalZone(availableNodes)
 	targetNode := int64(-1)
 	defer func() {
 		if targetNode != -1 {
@@ -167,6 +174,26 @@ func (b *LookAsideBalancer) SelectNode(ctx context.Context, availableNodes []int
 	return targetNode, nil
 }
 
+// preferLocalZone returns the available nodes in the same zone as the proxy to avoid the cross zone traffic.
+// All the nodes are returned if the zone is unknown or none of the nodes in the zone is reachable.
+func (b *LookAsideBalancer) preferLocalZone(availableNodes []int64) []int64 {
+	if b.zone == "" {
+		return availableNodes
+	}
+	localNodes := lo.Filter(availableNodes, func(node int64, _ int) bool {
+		info, ok := b.knownNodeInfos.Get(node)
+		if !ok || info.zone != b.zone {
+			return false
+		}
+		metrics, ok := b.metricsMap.Get(node)
+		return !ok || !metrics.unavailable.Load()
+	})
+	if len(localNodes) == 0 {
+		return availableNodes
+	}
+	return localNodes
+}
+
 // when task canceled, should reduce executing total nq cost
 func (b *LookAsideBalancer) CancelWorkload(node int64, nq int64) {
 	metrics, ok := b.metricsMap.Get(node)
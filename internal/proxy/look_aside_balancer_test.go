@@ -32,6 +32,7 @@ import (
 	"github.com/milvus-io/milvus/internal/proto/internalpb"
 	"github.com/milvus-io/milvus/internal/types"
 	"github.com/milvus-io/milvus/pkg/util/merr"
+	"github.com/milvus-io/milvus/pkg/util/typeutil"
 )
 
 type LookAsideBalancerSuite struct {
@@ -288,6 +289,43 @@ func (suite *LookAsideBalancerSuite) TestSelectNode() {
 	}
 }
 
+func (suite *LookAsideBalancerSuite) TestSelectNodePreferLocalZone() {
+	ctx := context.Background()
+	suite.balancer.zone = "az-1"
+	suite.balancer.RegisterNodeInfo([]nodeInfo{
+		{nodeID: 1, zone: "az-1"},
+		{nodeID: 2, zone: "az-2"},
+		{nodeID: 3, zone: "az-2"},
+	})
+
+	// the node in the same zone is always selected
+	for i := 0; i < 20; i++ {
+		node, err := suite.balancer.SelectNode(ctx, []int64{1, 2, 3}, 1)
+		suite.NoError(err)
+		suite.Equal(int64(1), node)
+	}
+
+	// fall back to the nodes of other zones if the local one is unreachable
+	metrics, _ := suite.balancer.metricsMap.Get(1)
+	metrics.unavailable.Store(true)
+	for i := 0; i < 20; i++ {
+		node, err := suite.balancer.SelectNode(ctx, []int64{1, 2, 3}, 1)
+		suite.NoError(err)
+		suite.Contains([]int64{2, 3}, node)
+	}
+
+	// all the nodes are candidates if the zone of the proxy is unknown
+	metrics.unavailable.Store(false)
+	suite.balancer.zone = ""
+	selected := typeutil.NewUniqueSet()
+	for i := 0; i < 20; i++ {
+		node, err := suite.balancer.SelectNode(ctx, []int64{1, 2, 3}, 1)
+		suite.NoError(err)
+		selected.Insert(node)
+	}
+	suite.Greater(selected.Len(), 1)
+}
+
 func (suite *LookAsideBalancerSuite) TestCancelWorkload() {
 	node, err := suite.balancer.SelectNode(context.TODO(), []int64{1, 2, 3}, 10)
 	suite.NoError(err)
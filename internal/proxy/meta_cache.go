@@ -32,10 +32,12 @@ import (
 	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
 	"github.com/milvus-io/milvus-proto/go-api/v2/milvuspb"
 	"github.com/milvus-io/milvus-proto/go-api/v2/schemapb"
+	"github.com/milvus-io/milvus/internal/json"
 	"github.com/milvus-io/milvus/internal/proto/internalpb"
 	"github.com/milvus-io/milvus/internal/proto/querypb"
 	"github.com/milvus-io/milvus/internal/proto/rootcoordpb"
 	"github.com/milvus-io/milvus/internal/types"
+	"github.com/milvus-io/milvus/internal/util/function"
 	"github.com/milvus-io/milvus/pkg/common"
 	"github.com/milvus-io/milvus/pkg/log"
 	"github.com/milvus-io/milvus/pkg/metrics"
@@ -85,7 +87,11 @@ type Cache interface {
 	GetPrivilegeInfo(ctx context.Context) []string
 	GetUserRole(username string) []string
 	RefreshPolicyInfo(op typeutil.CacheOp) error
-	InitPolicyInfo(info []string, userRoles []string)
+	InitPolicyInfo(info []string, userRoles []string, rowPolicies []*internalpb.RowPolicy)
+	// GetRowPolicies returns the row filter exprs of the roles on the collection.
+	GetRowPolicies(roles []string, database, collectionName string) []string
+	// GetMaskedFields returns the names of the fields of the collection masked for any of the roles.
+	GetMaskedFields(roles []string, database, collectionName string) []string
 
 	RemoveDatabase(ctx context.Context, database string)
 	HasDatabase(ctx context.Context, database string) bool
@@ -117,6 +123,11 @@ type schemaInfo struct {
 	hasPartitionKeyField bool
 	pkField              *schemapb.FieldSchema
 	schemaHelper         *typeutil.SchemaHelper
+
+	// the executor of the non-BM25 functions, built on first use.
+	// schemaInfo is recreated when the schema changes, so the executor is cached per schema version.
+	functionExecutorMu sync.Mutex
+	functionExecutor   *function.FunctionExecutor
 }
 
 func newSchemaInfoWithLoadFields(schema *schemapb.CollectionSchema, loadFields []int64) *schemaInfo {
@@ -147,6 +158,21 @@ func newSchemaInfo(schema *schemapb.CollectionSchema) *schemaInfo {
 	return newSchemaInfoWithLoadFields(schema, nil)
 }
 
+// GetFunctionExecutor returns the cached function executor of the schema, builds it if absent.
+func (s *schemaInfo) GetFunctionExecutor() (*function.FunctionExecutor, error) {
+	s.functionExecutorMu.Lock()
+	defer s.functionExecutorMu.Unlock()
+	if s.functionExecutor != nil {
+		return s.functionExecutor, nil
+	}
+	executor, err := function.NewFunctionExecutor(s.CollectionSchema)
+	if err != nil {
+		return nil, err
+	}
+	s.functionExecutor = executor
+	return executor, nil
+}
+
 func (s *schemaInfo) MapFieldID(name string) (int64, bool) {
 	return s.fieldMap.Get(name)
 }
@@ -329,6 +355,7 @@ type MetaCache struct {
 	credMap        map[string]*internalpb.CredentialInfo // cache for credential, lazy load
 	privilegeInfos map[string]struct{}                   // privileges cache
 	userToRoles    map[string]map[string]struct{}        // user to role cache
+	rowPolicies    map[string]map[string]string          // role -> database/collectionName -> row filter expr
 	mu             sync.RWMutex
 	credMut        sync.RWMutex
 	leaderMut      sync.RWMutex
@@ -362,7 +389,7 @@ func InitMetaCache(ctx context.Context, rootCoord types.RootCoordClient, queryCo
 		log.Error("fail to init meta cache", zap.Error(err))
 		return err
 	}
-	globalMetaCache.InitPolicyInfo(resp.PolicyInfos, resp.UserRoles)
+	globalMetaCache.InitPolicyInfo(resp.PolicyInfos, resp.UserRoles, resp.RowPolicies)
 	log.Info("success to init meta cache", zap.Strings("policy_infos", resp.PolicyInfos))
 	return nil
 }
@@ -379,6 +406,7 @@ func NewMetaCache(rootCoord types.RootCoordClient, queryCoord types.QueryCoordCl
 		shardMgr:               shardMgr,
 		privilegeInfos:         map[string]struct{}{},
 		userToRoles:            map[string]map[string]struct{}{},
+		rowPolicies:            map[string]map[string]string{},
 		collectionCacheVersion: make(map[UniqueID]uint64),
 	}, nil
 }
@@ -1007,7 +1035,11 @@ func parseShardLeaderList2QueryNode(shardsLeaders []*querypb.ShardLeadersList) m
 		qns := make([]nodeInfo, len(leaders.GetNodeIds()))
 
 		for j := range qns {
-			qns[j] = nodeInfo{leaders.GetNodeIds()[j], leaders.GetNodeAddrs()[j]}
+			qns[j] = nodeInfo{nodeID: leaders.GetNodeIds()[j], address: leaders.GetNodeAddrs()[j]}
+			// the zones are absent if the querycoord is of an old version
+			if j < len(leaders.GetNodeZones()) {
+				qns[j].zone = leaders.GetNodeZones()[j]
+			}
 		}
 
 		shard2QueryNodes[leaders.GetChannelName()] = qns
@@ -1066,7 +1098,7 @@ func (m *MetaCache) InvalidateShardLeaderCache(collections []int64) {
 	}
 }
 
-func (m *MetaCache) InitPolicyInfo(info []string, userRoles []string) {
+func (m *MetaCache) InitPolicyInfo(info []string, userRoles []string, rowPolicies []*internalpb.RowPolicy) {
 	defer func() {
 		err := getEnforcer().LoadPolicy()
 		if err != nil {
@@ -1075,10 +1107,10 @@ func (m *MetaCache) InitPolicyInfo(info []string, userRoles []string) {
 	}()
 	m.mu.Lock()
 	defer m.mu.Unlock()
-	m.unsafeInitPolicyInfo(info, userRoles)
+	m.unsafeInitPolicyInfo(info, userRoles, rowPolicies)
 }
 
-func (m *MetaCache) unsafeInitPolicyInfo(info []string, userRoles []string) {
+func (m *MetaCache) unsafeInitPolicyInfo(info []string, userRoles []string, rowPolicies []*internalpb.RowPolicy) {
 	m.privilegeInfos = util.StringSet(info)
 	for _, userRole := range userRoles {
 		user, role, err := funcutil.DecodeUserRoleCache(userRole)
@@ -1091,6 +1123,67 @@ func (m *MetaCache) unsafeInitPolicyInfo(info []string, userRoles []string) {
 		}
 		m.userToRoles[user][role] = struct{}{}
 	}
+	for _, policy := range rowPolicies {
+		m.unsafeSetRowPolicy(policy.GetRole(), policy.GetDbName(), policy.GetCollectionName(), policy.GetExpr())
+	}
+}
+
+func rowPolicyKey(database, collectionName string) string {
+	return database + "/" + collectionName
+}
+
+func (m *MetaCache) unsafeSetRowPolicy(role, database, collectionName, expr string) {
+	if m.rowPolicies == nil {
+		m.rowPolicies = make(map[string]map[string]string)
+	}
+	if m.rowPolicies[role] == nil {
+		m.rowPolicies[role] = make(map[string]string)
+	}
+	m.rowPolicies[role][rowPolicyKey(database, collectionName)] = expr
+}
+
+func (m *MetaCache) GetRowPolicies(roles []string, database, collectionName string) []string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	exprs := make([]string, 0)
+	for _, role := range roles {
+		if expr, ok := m.rowPolicies[role][rowPolicyKey(database, collectionName)]; ok {
+			exprs = append(exprs, expr)
+		}
+	}
+	return exprs
+}
+
+func (m *MetaCache) GetMaskedFields(roles []string, database, collectionName string) []string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	// the masked fields are granted as the PrivilegeMaskField privilege on the collection object named <collection>$<field>
+	resourcePrefix := funcutil.PolicyForResource(database, commonpb.ObjectType_Collection.String(),
+		funcutil.CombineFieldObjectName(collectionName, ""))
+	fields := typeutil.NewSet[string]()
+	for policy := range m.privilegeInfos {
+		if !strings.Contains(policy, util.PrivilegeMaskField) {
+			continue
+		}
+		rule := struct {
+			V0 string
+			V1 string
+			V2 string
+		}{}
+		if err := json.Unmarshal([]byte(policy), &rule); err != nil {
+			continue
+		}
+		if rule.V2 != util.PrivilegeMaskField || !strings.HasPrefix(rule.V1, resourcePrefix) || !lo.Contains(roles, rule.V0) {
+			continue
+		}
+		// field names never contain the separator, so it is a field of another collection otherwise
+		if field := strings.TrimPrefix(rule.V1, resourcePrefix); !strings.Contains(field, util.FieldObjectNameSeparator) {
+			fields.Insert(field)
+		}
+	}
+	return fields.Collect()
 }
 
 func (m *MetaCache) GetPrivilegeInfo(ctx context.Context) []string {
@@ -1159,12 +1252,25 @@ func (m *MetaCache) RefreshPolicyInfo(op typeutil.CacheOp) (err error) {
 		for user := range m.userToRoles {
 			delete(m.userToRoles[user], op.OpKey)
 		}
+		delete(m.rowPolicies, op.OpKey)
 
 		for policy := range m.privilegeInfos {
 			if funcutil.PolicyCheckerWithRole(policy, op.OpKey) {
 				delete(m.privilegeInfos, policy)
 			}
 		}
+	case typeutil.CacheSetRowPolicy:
+		role, database, collectionName, expr, err := funcutil.DecodeRowPolicyCache(op.OpKey)
+		if err != nil {
+			return fmt.Errorf("invalid opKey, fail to decode, op_type: %d, op_key: %s", int(op.OpType), op.OpKey)
+		}
+		m.unsafeSetRowPolicy(role, database, collectionName, expr)
+	case typeutil.CacheDropRowPolicy:
+		role, database, collectionName, _, err := funcutil.DecodeRowPolicyCache(op.OpKey)
+		if err != nil {
+			return fmt.Errorf("invalid opKey, fail to decode, op_type: %d, op_key: %s", int(op.OpType), op.OpKey)
+		}
+		delete(m.rowPolicies[role], rowPolicyKey(database, collectionName))
 	case typeutil.CacheRefresh:
 		resp, err := m.rootCoord.ListPolicy(context.Background(), &internalpb.ListPolicyRequest{})
 		if err != nil {
@@ -1183,7 +1289,8 @@ func (m *MetaCache) RefreshPolicyInfo(op typeutil.CacheOp) (err error) {
 		defer m.mu.Unlock()
 		m.userToRoles = make(map[string]map[string]struct{})
 		m.privilegeInfos = make(map[string]struct{})
-		m.unsafeInitPolicyInfo(resp.PolicyInfos, resp.UserRoles)
+		m.rowPolicies = make(map[string]map[string]string)
+		m.unsafeInitPolicyInfo(resp.PolicyInfos, resp.UserRoles, resp.RowPolicies)
 	default:
 		return fmt.Errorf("invalid opType, op_type: %d, op_key: %s", int(op.OpType), op.OpKey)
 	}
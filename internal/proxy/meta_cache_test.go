@@ -763,6 +763,45 @@ func TestMetaCache_PolicyInfo(t *testing.T) {
 	})
 }
 
+func TestMetaCache_RowPolicy(t *testing.T) {
+	client := &MockRootCoordClientInterface{}
+	qc := &mocks.MockQueryCoordClient{}
+	mgr := newShardClientMgr()
+
+	client.listPolicy = func(ctx context.Context, in *internalpb.ListPolicyRequest) (*internalpb.ListPolicyResponse, error) {
+		return &internalpb.ListPolicyResponse{
+			Status:    merr.Success(),
+			UserRoles: []string{funcutil.EncodeUserRoleCache("foo", "role1"), funcutil.EncodeUserRoleCache("foo", "role2")},
+			RowPolicies: []*internalpb.RowPolicy{
+				{Role: "role1", DbName: "default", CollectionName: "coll", Expr: "tenant == 1"},
+			},
+		}, nil
+	}
+	err := InitMetaCache(context.Background(), client, qc, mgr)
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []string{"tenant == 1"}, globalMetaCache.GetRowPolicies([]string{"role1", "role2"}, "default", "coll"))
+	assert.Empty(t, globalMetaCache.GetRowPolicies([]string{"role1"}, "default", "coll2"))
+
+	err = globalMetaCache.RefreshPolicyInfo(typeutil.CacheOp{OpType: typeutil.CacheSetRowPolicy, OpKey: funcutil.EncodeRowPolicyCache("role2", "default", "coll", "tenant == 2")})
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []string{"tenant == 1", "tenant == 2"}, globalMetaCache.GetRowPolicies([]string{"role1", "role2"}, "default", "coll"))
+
+	err = globalMetaCache.RefreshPolicyInfo(typeutil.CacheOp{OpType: typeutil.CacheDropRowPolicy, OpKey: funcutil.EncodeRowPolicyCache("role1", "default", "coll", "")})
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []string{"tenant == 2"}, globalMetaCache.GetRowPolicies([]string{"role1", "role2"}, "default", "coll"))
+
+	err = globalMetaCache.RefreshPolicyInfo(typeutil.CacheOp{OpType: typeutil.CacheDropRole, OpKey: "role2"})
+	assert.NoError(t, err)
+	assert.Empty(t, globalMetaCache.GetRowPolicies([]string{"role1", "role2"}, "default", "coll"))
+
+	err = globalMetaCache.RefreshPolicyInfo(typeutil.CacheOp{OpType: typeutil.CacheRefresh})
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []string{"tenant == 1"}, globalMetaCache.GetRowPolicies([]string{"role1", "role2"}, "default", "coll"))
+
+	err = globalMetaCache.RefreshPolicyInfo(typeutil.CacheOp{OpType: typeutil.CacheSetRowPolicy, OpKey: "invalid"})
+	assert.Error(t, err)
+}
+
 func TestMetaCache_RemoveCollection(t *testing.T) {
 	ctx := context.Background()
 	rootCoord := &MockRootCoordClientInterface{}
@@ -1263,6 +1302,42 @@ func TestSchemaInfo_GetLoadFieldIDs(t *testing.T) {
 	}
 }
 
+func TestSchemaInfo_GetFunctionExecutor(t *testing.T) {
+	info := newSchemaInfo(&schemapb.CollectionSchema{
+		Fields: []*schemapb.FieldSchema{
+			{FieldID: 100, Name: "pk", DataType: schemapb.DataType_Int64, IsPrimaryKey: true},
+			{FieldID: 101, Name: "text", DataType: schemapb.DataType_VarChar},
+			{
+				FieldID: 102, Name: "vector", DataType: schemapb.DataType_FloatVector,
+				TypeParams: []*commonpb.KeyValuePair{{Key: common.DimKey, Value: "4"}},
+			},
+		},
+		Functions: []*schemapb.FunctionSchema{{
+			Name:             "embedding",
+			Type:             schemapb.FunctionType_TextEmbedding,
+			InputFieldNames:  []string{"text"},
+			InputFieldIds:    []int64{101},
+			OutputFieldNames: []string{"vector"},
+			OutputFieldIds:   []int64{102},
+			Params: []*commonpb.KeyValuePair{
+				{Key: "provider", Value: "tei"},
+				{Key: "url", Value: "http://localhost"},
+			},
+		}},
+	})
+
+	executor, err := info.GetFunctionExecutor()
+	assert.NoError(t, err)
+	cached, err := info.GetFunctionExecutor()
+	assert.NoError(t, err)
+	assert.Same(t, executor, cached)
+
+	// the executor is rebuilt for a new schema version
+	another, err := newSchemaInfo(info.CollectionSchema).GetFunctionExecutor()
+	assert.NoError(t, err)
+	assert.NotSame(t, executor, another)
+}
+
 func TestMetaCache_Parallel(t *testing.T) {
 	ctx := context.Background()
 	rootCoord := mocks.NewMockRootCoordClient(t)
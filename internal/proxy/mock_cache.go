@@ -469,6 +469,56 @@ func (_c *MockCache_GetDatabaseInfo_Call) RunAndReturn(run func(context.Context,
 	return _c
 }
 
+// GetMaskedFields provides a mock function with given fields: roles, database, collectionName
+func (_m *MockCache) GetMaskedFields(roles []string, database string, collectionName string) []string {
+	ret := _m.Called(roles, database, collectionName)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetMaskedFields")
+	}
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func([]string, string, string) []string); ok {
+		r0 = rf(roles, database, collectionName)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	return r0
+}
+
+// MockCache_GetMaskedFields_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetMaskedFields'
+type MockCache_GetMaskedFields_Call struct {
+	*mock.Call
+}
+
+// GetMaskedFields is a helper method to define mock.On call
+//   - roles []string
+//   - database string
+//   - collectionName string
+func (_e *MockCache_Expecter) GetMaskedFields(roles interface{}, database interface{}, collectionName interface{}) *MockCache_GetMaskedFields_Call {
+	return &MockCache_GetMaskedFields_Call{Call: _e.mock.On("GetMaskedFields", roles, database, collectionName)}
+}
+
+func (_c *MockCache_GetMaskedFields_Call) Run(run func(roles []string, database string, collectionName string)) *MockCache_GetMaskedFields_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].([]string), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockCache_GetMaskedFields_Call) Return(_a0 []string) *MockCache_GetMaskedFields_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockCache_GetMaskedFields_Call) RunAndReturn(run func([]string, string, string) []string) *MockCache_GetMaskedFields_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetPartitionID provides a mock function with given fields: ctx, database, collectionName, partitionName
 func (_m *MockCache) GetPartitionID(ctx context.Context, database string, collectionName string, partitionName string) (int64, error) {
 	ret := _m.Called(ctx, database, collectionName, partitionName)
@@ -757,6 +807,56 @@ func (_c *MockCache_GetPrivilegeInfo_Call) RunAndReturn(run func(context.Context
 	return _c
 }
 
+// GetRowPolicies provides a mock function with given fields: roles, database, collectionName
+func (_m *MockCache) GetRowPolicies(roles []string, database string, collectionName string) []string {
+	ret := _m.Called(roles, database, collectionName)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRowPolicies")
+	}
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func([]string, string, string) []string); ok {
+		r0 = rf(roles, database, collectionName)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	return r0
+}
+
+// MockCache_GetRowPolicies_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetRowPolicies'
+type MockCache_GetRowPolicies_Call struct {
+	*mock.Call
+}
+
+// GetRowPolicies is a helper method to define mock.On call
+//   - roles []string
+//   - database string
+//   - collectionName string
+func (_e *MockCache_Expecter) GetRowPolicies(roles interface{}, database interface{}, collectionName interface{}) *MockCache_GetRowPolicies_Call {
+	return &MockCache_GetRowPolicies_Call{Call: _e.mock.On("GetRowPolicies", roles, database, collectionName)}
+}
+
+func (_c *MockCache_GetRowPolicies_Call) Run(run func(roles []string, database string, collectionName string)) *MockCache_GetRowPolicies_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].([]string), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockCache_GetRowPolicies_Call) Return(_a0 []string) *MockCache_GetRowPolicies_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockCache_GetRowPolicies_Call) RunAndReturn(run func([]string, string, string) []string) *MockCache_GetRowPolicies_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetShards provides a mock function with given fields: ctx, withCache, database, collectionName, collectionID
 func (_m *MockCache) GetShards(ctx context.Context, withCache bool, database string, collectionName string, collectionID int64) (map[string][]nodeInfo, error) {
 	ret := _m.Called(ctx, withCache, database, collectionName, collectionID)
@@ -914,9 +1014,9 @@ func (_c *MockCache_HasDatabase_Call) RunAndReturn(run func(context.Context, str
 	return _c
 }
 
-// InitPolicyInfo provides a mock function with given fields: info, userRoles
-func (_m *MockCache) InitPolicyInfo(info []string, userRoles []string) {
-	_m.Called(info, userRoles)
+// InitPolicyInfo provides a mock function with given fields: info, userRoles, rowPolicies
+func (_m *MockCache) InitPolicyInfo(info []string, userRoles []string, rowPolicies []*internalpb.RowPolicy) {
+	_m.Called(info, userRoles, rowPolicies)
 }
 
 // MockCache_InitPolicyInfo_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'InitPolicyInfo'
@@ -927,13 +1027,14 @@ type MockCache_InitPolicyInfo_Call struct {
 // InitPolicyInfo is a helper method to define mock.On call
 //   - info []string
 //   - userRoles []string
-func (_e *MockCache_Expecter) InitPolicyInfo(info interface{}, userRoles interface{}) *MockCache_InitPolicyInfo_Call {
-	return &MockCache_InitPolicyInfo_Call{Call: _e.mock.On("InitPolicyInfo", info, userRoles)}
+//   - rowPolicies []*internalpb.RowPolicy
+func (_e *MockCache_Expecter) InitPolicyInfo(info interface{}, userRoles interface{}, rowPolicies interface{}) *MockCache_InitPolicyInfo_Call {
+	return &MockCache_InitPolicyInfo_Call{Call: _e.mock.On("InitPolicyInfo", info, userRoles, rowPolicies)}
 }
 
-func (_c *MockCache_InitPolicyInfo_Call) Run(run func(info []string, userRoles []string)) *MockCache_InitPolicyInfo_Call {
+func (_c *MockCache_InitPolicyInfo_Call) Run(run func(info []string, userRoles []string, rowPolicies []*internalpb.RowPolicy)) *MockCache_InitPolicyInfo_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].([]string), args[1].([]string))
+		run(args[0].([]string), args[1].([]string), args[2].([]*internalpb.RowPolicy))
 	})
 	return _c
 }
@@ -943,7 +1044,7 @@ func (_c *MockCache_InitPolicyInfo_Call) Return() *MockCache_InitPolicyInfo_Call
 	return _c
 }
 
-func (_c *MockCache_InitPolicyInfo_Call) RunAndReturn(run func([]string, []string)) *MockCache_InitPolicyInfo_Call {
+func (_c *MockCache_InitPolicyInfo_Call) RunAndReturn(run func([]string, []string, []*internalpb.RowPolicy)) *MockCache_InitPolicyInfo_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -13,6 +13,7 @@ import (
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 
 	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
@@ -113,6 +114,33 @@ func UnaryServerInterceptor(privilegeFunc PrivilegeFunc) grpc.UnaryServerInterce
 	}
 }
 
+// StreamServerInterceptor returns a new stream server interceptors that performs privilege access on the received requests.
+func StreamServerInterceptor(privilegeFunc PrivilegeFunc) grpc.StreamServerInterceptor {
+	initPrivilegeGroups()
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return handler(srv, &recvHookServerStream{
+			ServerStream: ss,
+			hook: func(req any) error {
+				_, err := privilegeFunc(ss.Context(), req)
+				return err
+			},
+		})
+	}
+}
+
+// recvHookServerStream calls the hook on every request received from the stream, the error of the hook fails the receiving.
+type recvHookServerStream struct {
+	grpc.ServerStream
+	hook func(req any) error
+}
+
+func (s *recvHookServerStream) RecvMsg(m any) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+	return s.hook(m)
+}
+
 func PrivilegeInterceptor(ctx context.Context, req interface{}) (context.Context, error) {
 	if !Params.CommonCfg.AuthorizationEnabled.GetAsBool() {
 		return ctx, nil
@@ -132,7 +160,7 @@ func PrivilegeInterceptor(ctx context.Context, req interface{}) (context.Context
 	if username == util.UserRoot {
 		return ctx, nil
 	}
-	roleNames, err := GetRole(username)
+	roleNames, err := getUserRoles(ctx, username)
 	if err != nil {
 		log.Warn("GetRole fail", zap.String("username", username), zap.Error(err))
 		return ctx, err
@@ -218,6 +246,16 @@ func PrivilegeInterceptor(ctx context.Context, req interface{}) (context.Context
 		fmt.Sprintf("%s: permission deny to %s in the `%s` database", objectPrivilege, username, dbName))
 }
 
+// checkPrivilegeInDatabase checks the privilege of req in the database dbName instead of the one of the context,
+// it's used by the apis which refer to the resources of other databases.
+func checkPrivilegeInDatabase(ctx context.Context, dbName string, req interface{}) error {
+	md, _ := metadata.FromIncomingContext(ctx)
+	md = md.Copy()
+	md.Set(strings.ToLower(util.HeaderDBName), dbName)
+	_, err := PrivilegeInterceptor(metadata.NewIncomingContext(ctx, md), req)
+	return err
+}
+
 // isCurUserObject Determine whether it is an Object of type User that operates on its own user information,
 // like updating password or viewing your own role information.
 // make users operate their own user information when the related privileges are not granted.
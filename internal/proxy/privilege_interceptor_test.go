@@ -7,11 +7,15 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/grpc"
 
 	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
 	"github.com/milvus-io/milvus-proto/go-api/v2/milvuspb"
 	"github.com/milvus-io/milvus/internal/mocks"
+	"github.com/milvus-io/milvus/internal/proto/datapb"
 	"github.com/milvus-io/milvus/internal/proto/internalpb"
+	"github.com/milvus-io/milvus/internal/proto/proxypb"
 	"github.com/milvus-io/milvus/pkg/util"
 	"github.com/milvus-io/milvus/pkg/util/funcutil"
 	"github.com/milvus-io/milvus/pkg/util/merr"
@@ -232,6 +236,280 @@ func TestResourceGroupPrivilege(t *testing.T) {
 	})
 }
 
+func TestRecycleBinPrivilege(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Recycle Bin Privilege", func(t *testing.T) {
+		paramtable.Get().Save(Params.CommonCfg.AuthorizationEnabled.Key, "true")
+
+		_, err := PrivilegeInterceptor(ctx, &proxypb.ListRecycleBinRequest{})
+		assert.Error(t, err)
+
+		ctx = GetContext(context.Background(), "fooo:123456")
+		client := &MockRootCoordClientInterface{}
+		queryCoord := &mocks.MockQueryCoordClient{}
+		mgr := newShardClientMgr()
+
+		client.listPolicy = func(ctx context.Context, in *internalpb.ListPolicyRequest) (*internalpb.ListPolicyResponse, error) {
+			return &internalpb.ListPolicyResponse{
+				Status: merr.Success(),
+				PolicyInfos: []string{
+					funcutil.PolicyForPrivilege("role1", commonpb.ObjectType_Global.String(), "*", commonpb.ObjectPrivilege_PrivilegeCreateCollection.String(), "default"),
+					funcutil.PolicyForPrivilege("role1", commonpb.ObjectType_Collection.String(), "col1", commonpb.ObjectPrivilege_PrivilegeCreatePartition.String(), "default"),
+				},
+				UserRoles: []string{
+					funcutil.EncodeUserRoleCache("fooo", "role1"),
+				},
+			}, nil
+		}
+		InitMetaCache(ctx, client, queryCoord, mgr)
+
+		_, err = PrivilegeInterceptor(GetContext(context.Background(), "fooo:123456"), &proxypb.ListRecycleBinRequest{})
+		assert.NoError(t, err)
+
+		_, err = PrivilegeInterceptor(GetContext(context.Background(), "fooo:123456"), &proxypb.UndropCollectionRequest{
+			CollectionName: "col1",
+		})
+		assert.NoError(t, err)
+
+		_, err = PrivilegeInterceptor(GetContext(context.Background(), "fooo:123456"), &proxypb.UndropPartitionRequest{
+			CollectionName: "col1",
+			PartitionName:  "p1",
+		})
+		assert.NoError(t, err)
+
+		_, err = PrivilegeInterceptor(GetContext(context.Background(), "fooo:123456"), &proxypb.UndropPartitionRequest{
+			CollectionName: "col2",
+			PartitionName:  "p1",
+		})
+		assert.Error(t, err)
+
+		// the user without any grant can't list or restore the dropped collections and partitions.
+		_, err = PrivilegeInterceptor(GetContext(context.Background(), "bar:123456"), &proxypb.ListRecycleBinRequest{})
+		assert.Error(t, err)
+
+		_, err = PrivilegeInterceptor(GetContext(context.Background(), "bar:123456"), &proxypb.UndropCollectionRequest{
+			CollectionName: "col1",
+		})
+		assert.Error(t, err)
+
+		_, err = PrivilegeInterceptor(GetContext(context.Background(), "bar:123456"), &proxypb.UndropPartitionRequest{
+			CollectionName: "col1",
+			PartitionName:  "p1",
+		})
+		assert.Error(t, err)
+
+		_, err = PrivilegeInterceptor(GetContext(context.Background(), "root:123456"), &proxypb.UndropCollectionRequest{
+			CollectionName: "col1",
+		})
+		assert.NoError(t, err)
+	})
+}
+
+func TestSnapshotPrivilege(t *testing.T) {
+	paramtable.Get().Save(Params.CommonCfg.AuthorizationEnabled.Key, "true")
+	defer paramtable.Get().Reset(Params.CommonCfg.AuthorizationEnabled.Key)
+
+	ctx := GetContext(context.Background(), "fooo:123456")
+	client := &MockRootCoordClientInterface{}
+	queryCoord := &mocks.MockQueryCoordClient{}
+	mgr := newShardClientMgr()
+
+	client.listPolicy = func(ctx context.Context, in *internalpb.ListPolicyRequest) (*internalpb.ListPolicyResponse, error) {
+		return &internalpb.ListPolicyResponse{
+			Status: merr.Success(),
+			PolicyInfos: []string{
+				funcutil.PolicyForPrivilege("role1", commonpb.ObjectType_Global.String(), "*", commonpb.ObjectPrivilege_PrivilegeCreateCollection.String(), "default"),
+				funcutil.PolicyForPrivilege("role1", commonpb.ObjectType_Collection.String(), "col1", commonpb.ObjectPrivilege_PrivilegeFlush.String(), "default"),
+				funcutil.PolicyForPrivilege("role1", commonpb.ObjectType_Collection.String(), "col1", commonpb.ObjectPrivilege_PrivilegeQuery.String(), "db1"),
+			},
+			UserRoles: []string{
+				funcutil.EncodeUserRoleCache("fooo", "role1"),
+			},
+		}, nil
+	}
+	InitMetaCache(ctx, client, queryCoord, mgr)
+	CleanPrivilegeCache()
+	defer CleanPrivilegeCache()
+
+	_, err := PrivilegeInterceptor(ctx, &proxypb.CreateSnapshotRequest{CollectionName: "col1"})
+	assert.NoError(t, err)
+	_, err = PrivilegeInterceptor(ctx, &proxypb.CreateSnapshotRequest{CollectionName: "col2"})
+	assert.Error(t, err)
+	_, err = PrivilegeInterceptor(ctx, &proxypb.DropSnapshotRequest{CollectionName: "col1"})
+	assert.Error(t, err)
+	_, err = PrivilegeInterceptor(ctx, &proxypb.ListSnapshotsRequest{CollectionName: "col1"})
+	assert.Error(t, err)
+	_, err = PrivilegeInterceptor(ctx, &proxypb.RestoreSnapshotRequest{CollectionName: "restored"})
+	assert.NoError(t, err)
+
+	// the privileges are checked in the database of the argument rather than the one of context.
+	assert.NoError(t, checkPrivilegeInDatabase(ctx, "db1", &milvuspb.QueryRequest{CollectionName: "col1"}))
+	assert.Error(t, checkPrivilegeInDatabase(ctx, "default", &milvuspb.QueryRequest{CollectionName: "col1"}))
+	assert.Error(t, checkPrivilegeInDatabase(ctx, "db1", &proxypb.RestoreSnapshotRequest{CollectionName: "restored"}))
+
+	t.Run("restore", func(t *testing.T) {
+		dc := mocks.NewMockDataCoordClient(t)
+		node := &Proxy{dataCoord: dc}
+		node.UpdateStateCode(commonpb.StateCode_Healthy)
+
+		// no privilege to create collection in the target database
+		status, err := node.RestoreSnapshot(ctx, &proxypb.RestoreSnapshotRequest{
+			DbName:         "db1",
+			CollectionName: "restored",
+			SnapshotName:   "snapshot1",
+		})
+		assert.NoError(t, err)
+		assert.False(t, merr.Ok(status))
+
+		// no privilege to query the source collection
+		dc.EXPECT().ListSnapshots(mock.Anything, mock.Anything).Return(&datapb.ListSnapshotsResponse{
+			Status: merr.Success(),
+			Snapshots: []*datapb.SnapshotInfo{
+				{SnapshotID: 1, Name: "snapshot2", DbName: "db1", CollectionName: "col2"},
+			},
+		}, nil)
+		status, err = node.RestoreSnapshot(ctx, &proxypb.RestoreSnapshotRequest{
+			DbName:         "default",
+			CollectionName: "restored",
+			SnapshotName:   "snapshot2",
+		})
+		assert.NoError(t, err)
+		assert.False(t, merr.Ok(status))
+	})
+}
+
+func TestExportPrivilege(t *testing.T) {
+	paramtable.Get().Save(Params.CommonCfg.AuthorizationEnabled.Key, "true")
+	defer paramtable.Get().Reset(Params.CommonCfg.AuthorizationEnabled.Key)
+
+	ctx := GetContext(context.Background(), "fooo:123456")
+	client := &MockRootCoordClientInterface{}
+	queryCoord := &mocks.MockQueryCoordClient{}
+	mgr := newShardClientMgr()
+
+	client.listPolicy = func(ctx context.Context, in *internalpb.ListPolicyRequest) (*internalpb.ListPolicyResponse, error) {
+		return &internalpb.ListPolicyResponse{
+			Status: merr.Success(),
+			PolicyInfos: []string{
+				funcutil.PolicyForPrivilege("role1", commonpb.ObjectType_Collection.String(), "col1", commonpb.ObjectPrivilege_PrivilegeQuery.String(), "db1"),
+			},
+			UserRoles: []string{
+				funcutil.EncodeUserRoleCache("fooo", "role1"),
+			},
+		}, nil
+	}
+	InitMetaCache(ctx, client, queryCoord, mgr)
+	CleanPrivilegeCache()
+	defer CleanPrivilegeCache()
+
+	_, err := PrivilegeInterceptor(ctx, &proxypb.ExportRequest{DbName: "db1", CollectionName: "col1"})
+	assert.NoError(t, err)
+	_, err = PrivilegeInterceptor(ctx, &proxypb.ExportRequest{DbName: "db1", CollectionName: "col2"})
+	assert.Error(t, err)
+
+	dc := mocks.NewMockDataCoordClient(t)
+	node := &Proxy{dataCoord: dc}
+	node.UpdateStateCode(commonpb.StateCode_Healthy)
+	dc.EXPECT().GetExportProgress(mock.Anything, mock.Anything).RunAndReturn(func(ctx context.Context, req *datapb.GetExportProgressRequest, options ...grpc.CallOption) (*datapb.GetExportProgressResponse, error) {
+		if req.GetJobID() == 1 {
+			return &datapb.GetExportProgressResponse{Status: merr.Success(), DbName: "db1", CollectionName: "col1"}, nil
+		}
+		return &datapb.GetExportProgressResponse{Status: merr.Success(), DbName: "db1", CollectionName: "col2"}, nil
+	})
+
+	// the privileges are checked on the collection of the job
+	resp, err := node.GetExportProgress(ctx, &proxypb.GetExportProgressRequest{JobID: 1})
+	assert.NoError(t, err)
+	assert.True(t, merr.Ok(resp.GetStatus()))
+	resp, err = node.GetExportProgress(ctx, &proxypb.GetExportProgressRequest{JobID: 2})
+	assert.NoError(t, err)
+	assert.False(t, merr.Ok(resp.GetStatus()))
+
+	// the job is not canceled without the privilege
+	status, err := node.CancelExport(ctx, &proxypb.CancelExportRequest{JobID: 2})
+	assert.NoError(t, err)
+	assert.False(t, merr.Ok(status))
+}
+
+func TestRowPolicyPrivilege(t *testing.T) {
+	paramtable.Get().Save(Params.CommonCfg.AuthorizationEnabled.Key, "true")
+	defer paramtable.Get().Reset(Params.CommonCfg.AuthorizationEnabled.Key)
+
+	client := &MockRootCoordClientInterface{}
+	queryCoord := &mocks.MockQueryCoordClient{}
+	mgr := newShardClientMgr()
+
+	client.listPolicy = func(ctx context.Context, in *internalpb.ListPolicyRequest) (*internalpb.ListPolicyResponse, error) {
+		return &internalpb.ListPolicyResponse{
+			Status: merr.Success(),
+			PolicyInfos: []string{
+				funcutil.PolicyForPrivilege("role1", commonpb.ObjectType_Global.String(), "*", commonpb.ObjectPrivilege_PrivilegeSelectOwnership.String(), "default"),
+				funcutil.PolicyForPrivilege("role2", commonpb.ObjectType_Global.String(), "*", commonpb.ObjectPrivilege_PrivilegeManageOwnership.String(), "default"),
+			},
+			UserRoles: []string{
+				funcutil.EncodeUserRoleCache("fooo", "role1"),
+				funcutil.EncodeUserRoleCache("bar", "role2"),
+			},
+		}, nil
+	}
+	InitMetaCache(context.Background(), client, queryCoord, mgr)
+	CleanPrivilegeCache()
+	defer CleanPrivilegeCache()
+
+	// the row policies are managed by the owners of the grants
+	ctx := GetContext(context.Background(), "fooo:123456")
+	_, err := PrivilegeInterceptor(ctx, &proxypb.ListRowPoliciesRequest{})
+	assert.NoError(t, err)
+	_, err = PrivilegeInterceptor(ctx, &proxypb.SetRowPolicyRequest{RoleName: "role1", CollectionName: "col1"})
+	assert.Error(t, err)
+	_, err = PrivilegeInterceptor(ctx, &proxypb.DropRowPolicyRequest{RoleName: "role1", CollectionName: "col1"})
+	assert.Error(t, err)
+
+	ctx = GetContext(context.Background(), "bar:123456")
+	_, err = PrivilegeInterceptor(ctx, &proxypb.SetRowPolicyRequest{RoleName: "role1", CollectionName: "col1"})
+	assert.NoError(t, err)
+	_, err = PrivilegeInterceptor(ctx, &proxypb.DropRowPolicyRequest{RoleName: "role1", CollectionName: "col1"})
+	assert.NoError(t, err)
+}
+
+func TestStreamServerInterceptor(t *testing.T) {
+	ctx := context.Background()
+	paramtable.Get().Save(Params.CommonCfg.AuthorizationEnabled.Key, "true")
+
+	client := &MockRootCoordClientInterface{}
+	queryCoord := &mocks.MockQueryCoordClient{}
+	mgr := newShardClientMgr()
+	client.listPolicy = func(ctx context.Context, in *internalpb.ListPolicyRequest) (*internalpb.ListPolicyResponse, error) {
+		return &internalpb.ListPolicyResponse{
+			Status: merr.Success(),
+			PolicyInfos: []string{
+				funcutil.PolicyForPrivilege("role1", commonpb.ObjectType_Collection.String(), "col1", commonpb.ObjectPrivilege_PrivilegeQuery.String(), "default"),
+			},
+			UserRoles: []string{
+				funcutil.EncodeUserRoleCache("fooo", "role1"),
+			},
+		}, nil
+	}
+	InitMetaCache(ctx, client, queryCoord, mgr)
+
+	interceptor := StreamServerInterceptor(PrivilegeInterceptor)
+	subscribe := func(user string, collectionName string) error {
+		stream := &mockRecvServerStream{
+			ctx: GetContext(context.Background(), user+":123456"),
+			req: &proxypb.SubscribeChangesRequest{CollectionName: collectionName},
+		}
+		return interceptor(nil, stream, &grpc.StreamServerInfo{}, func(srv any, ss grpc.ServerStream) error {
+			return ss.RecvMsg(&proxypb.SubscribeChangesRequest{})
+		})
+	}
+
+	assert.NoError(t, subscribe("fooo", "col1"))
+	assert.Error(t, subscribe("fooo", "col2"))
+	assert.Error(t, subscribe("bar", "col1"))
+	assert.NoError(t, subscribe("root", "col1"))
+}
+
 func TestPrivilegeGroup(t *testing.T) {
 	ctx := context.Background()
 
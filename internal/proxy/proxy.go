@@ -133,6 +133,13 @@ type Proxy struct {
 	enableComplexDeleteLimit bool
 
 	slowQueries *expirable.LRU[Timestamp, *metricsinfo.SlowQuery]
+	// the latest profiles of search/query requests
+	queryProfiles *typeutil.RingBuffer[*metricsinfo.QueryProfile]
+
+	// the client-visible transactions begun on this proxy
+	txnManager *transactionManager
+	// the number of the change stream subscriptions served by this proxy
+	changeStreamNum atomic.Int32
 }
 
 // NewProxy returns a Proxy struct.
@@ -156,6 +163,8 @@ func NewProxy(ctx context.Context, factory dependency.Factory) (*Proxy, error) {
 		resourceManager:        resourceManager,
 		replicateStreamManager: replicateStreamManager,
 		slowQueries:            expirable.NewLRU[Timestamp, *metricsinfo.SlowQuery](20, nil, time.Minute*15),
+		queryProfiles:          typeutil.NewRingBuffer[*metricsinfo.QueryProfile](Params.ProxyCfg.QueryProfileBufferSize.GetAsInt()),
+		txnManager:             newTransactionManager(),
 	}
 	node.UpdateStateCode(commonpb.StateCode_Abnormal)
 	expr.Register("proxy", node)
@@ -301,12 +310,47 @@ func (node *Proxy) Init() error {
 	}
 	log.Debug("init meta cache done", zap.String("role", typeutil.ProxyRole))
 
+	if Params.CommonCfg.AuthorizationEnabled.GetAsBool() && Params.CommonCfg.JWTEnabled.GetAsBool() {
+		if err := checkJWTConfig(); err != nil {
+			log.Warn("invalid jwt config", zap.String("role", typeutil.ProxyRole), zap.Error(err))
+			return err
+		}
+		globalJWTVerifier = newJWTVerifier(Params.CommonCfg.JWTJWKSURL.GetValue())
+		if err := globalJWTVerifier.refresh(node.ctx); err != nil {
+			// keys are fetched again by the refresh loop
+			log.Warn("failed to fetch jwks", zap.String("role", typeutil.ProxyRole), zap.Error(err))
+		}
+		log.Debug("init jwt verifier done", zap.String("role", typeutil.ProxyRole))
+	}
+
 	node.enableMaterializedView = Params.CommonCfg.EnableMaterializedView.GetAsBool()
 
 	log.Info("init proxy done", zap.Int64("nodeID", paramtable.GetNodeID()), zap.String("Address", node.address))
 	return nil
 }
 
+// refreshJWKSLoop starts a goroutine that refreshes the signing keys of jwt periodically.
+func (node *Proxy) refreshJWKSLoop() {
+	node.wg.Add(1)
+	go func() {
+		defer node.wg.Done()
+
+		ticker := time.NewTicker(Params.CommonCfg.JWTJWKSRefreshInterval.GetAsDuration(time.Second))
+		defer ticker.Stop()
+		for {
+			select {
+			case <-node.ctx.Done():
+				log.Info("refresh jwks loop exit")
+				return
+			case <-ticker.C:
+				if err := globalJWTVerifier.refresh(node.ctx); err != nil {
+					log.Warn("failed to refresh jwks", zap.Error(err))
+				}
+			}
+		}
+	}()
+}
+
 // sendChannelsTimeTickLoop starts a goroutine that synchronizes the time tick information.
 func (node *Proxy) sendChannelsTimeTickLoop() {
 	node.wg.Add(1)
@@ -418,6 +462,13 @@ func (node *Proxy) Start() error {
 		log.Debug("start channels time ticker done", zap.String("role", typeutil.ProxyRole))
 
 		node.sendChannelsTimeTickLoop()
+	} else {
+		node.txnManager.start()
+		log.Debug("start transaction manager done", zap.String("role", typeutil.ProxyRole))
+	}
+
+	if globalJWTVerifier != nil {
+		node.refreshJWKSLoop()
 	}
 
 	// Start callbacks
@@ -451,6 +502,11 @@ func (node *Proxy) Stop() error {
 		log.Info("close scheduler", zap.String("role", typeutil.ProxyRole))
 	}
 
+	if node.txnManager != nil {
+		node.txnManager.close()
+		log.Info("close transaction manager", zap.String("role", typeutil.ProxyRole))
+	}
+
 	if !streamingutil.IsStreamingServiceEnabled() {
 		if node.segAssigner != nil {
 			node.segAssigner.Close()
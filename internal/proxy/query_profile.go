@@ -0,0 +1,152 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
+	"github.com/milvus-io/milvus/internal/proto/internalpb"
+	"github.com/milvus-io/milvus/pkg/util/funcutil"
+	"github.com/milvus-io/milvus/pkg/util/metricsinfo"
+	"github.com/milvus-io/milvus/pkg/util/paramtable"
+)
+
+// Names of the phases of a search or query request on proxy.
+const (
+	profilePhaseParse        = "parse"
+	profilePhasePlan         = "plan"
+	profilePhaseShardRouting = "shard routing"
+	profilePhaseReduce       = "reduce"
+	profilePhaseRequery      = "requery"
+)
+
+// isProfileRequested returns whether the request asks for the execution profile by the params.
+func isProfileRequested(params []*commonpb.KeyValuePair) bool {
+	value, err := funcutil.GetAttrByKeyFromRepeatedKV(ProfileKey, params)
+	if err != nil {
+		return false
+	}
+	profile, _ := strconv.ParseBool(value)
+	return profile
+}
+
+// queryProfiler records the execution profile of a search or query request.
+// All the methods are no-op on a nil profiler, so the request not profiled costs nothing.
+type queryProfiler struct {
+	// requested is false if the request is profiled only in case it turns out slow.
+	requested bool
+
+	mu         sync.Mutex
+	last       time.Time
+	phases     []*metricsinfo.ProfilePhase
+	delegators []*metricsinfo.DelegatorProfile
+}
+
+// newQueryProfiler returns a profiler if the request asks for profile,
+// or the profiles of slow queries are kept, otherwise nil.
+func newQueryProfiler(requested bool) *queryProfiler {
+	if !requested && !paramtable.Get().ProxyCfg.ProfileSlowQuery.GetAsBool() {
+		return nil
+	}
+	return &queryProfiler{
+		requested: requested,
+		last:      time.Now(),
+	}
+}
+
+// start resets the beginning of the next phase, the time before it is not recorded.
+func (p *queryProfiler) start() {
+	if p == nil {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.last = time.Now()
+}
+
+// recordPhase records the time since the last phase as the phase of the name.
+func (p *queryProfiler) recordPhase(name string) {
+	if p == nil {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	now := time.Now()
+	p.phases = append(p.phases, &metricsinfo.ProfilePhase{
+		Name:     name,
+		Duration: now.Sub(p.last).String(),
+	})
+	p.last = now
+}
+
+// addDelegatorProfiles collects the profiles returned by the shard delegators.
+func (p *queryProfiler) addDelegatorProfiles(profiles []*internalpb.DelegatorProfile) {
+	if p == nil {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for _, profile := range profiles {
+		delegator := &metricsinfo.DelegatorProfile{
+			NodeID:         profile.GetNodeID(),
+			Channel:        profile.GetChannel(),
+			Duration:       time.Duration(profile.GetDurationUs() * int64(time.Microsecond)).String(),
+			ReduceDuration: time.Duration(profile.GetReduceDurationUs() * int64(time.Microsecond)).String(),
+		}
+		for _, segment := range profile.GetSegments() {
+			delegator.Segments = append(delegator.Segments, &metricsinfo.SegmentProfile{
+				SegmentID:    segment.GetSegmentID(),
+				NodeID:       segment.GetNodeID(),
+				IsGrowing:    segment.GetIsGrowing(),
+				Duration:     time.Duration(segment.GetDurationUs() * int64(time.Microsecond)).String(),
+				TotalRows:    segment.GetTotalRows(),
+				FilteredRows: segment.GetFilteredRows(),
+				IndexType:    segment.GetIndexType(),
+			})
+		}
+		p.delegators = append(p.delegators, delegator)
+	}
+}
+
+// finish returns the profile of the request described by request,
+// nil if the request didn't ask for profile and it's not slow.
+func (p *queryProfiler) finish(request *metricsinfo.SlowQuery, slow bool) *metricsinfo.QueryProfile {
+	if p == nil || !p.requested && !slow {
+		return nil
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return &metricsinfo.QueryProfile{
+		SlowQuery:  *request,
+		Slow:       slow,
+		Phases:     p.phases,
+		Delegators: p.delegators,
+	}
+}
+
+// addQueryProfile keeps the profile of a finished request.
+func (node *Proxy) addQueryProfile(profiler *queryProfiler, request *metricsinfo.SlowQuery, slow bool) {
+	if node.queryProfiles == nil {
+		return
+	}
+	if profile := profiler.finish(request, slow); profile != nil {
+		node.queryProfiles.Add(profile)
+	}
+}
@@ -0,0 +1,99 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
+	"github.com/milvus-io/milvus/internal/proto/internalpb"
+	"github.com/milvus-io/milvus/pkg/util/metricsinfo"
+	"github.com/milvus-io/milvus/pkg/util/paramtable"
+	"github.com/milvus-io/milvus/pkg/util/typeutil"
+)
+
+func TestIsProfileRequested(t *testing.T) {
+	assert.False(t, isProfileRequested(nil))
+	assert.False(t, isProfileRequested([]*commonpb.KeyValuePair{{Key: ProfileKey, Value: "false"}}))
+	assert.False(t, isProfileRequested([]*commonpb.KeyValuePair{{Key: ProfileKey, Value: "invalid"}}))
+	assert.True(t, isProfileRequested([]*commonpb.KeyValuePair{{Key: TopKKey, Value: "10"}, {Key: ProfileKey, Value: "true"}}))
+}
+
+func TestQueryProfiler(t *testing.T) {
+	paramtable.Init()
+
+	t.Run("not profiled", func(t *testing.T) {
+		profiler := newQueryProfiler(false)
+		assert.Nil(t, profiler)
+		// no-op on nil profiler
+		profiler.start()
+		profiler.recordPhase(profilePhaseParse)
+		profiler.addDelegatorProfiles([]*internalpb.DelegatorProfile{{NodeID: 1}})
+		assert.Nil(t, profiler.finish(&metricsinfo.SlowQuery{}, true))
+	})
+
+	t.Run("requested", func(t *testing.T) {
+		profiler := newQueryProfiler(true)
+		assert.NotNil(t, profiler)
+		profiler.recordPhase(profilePhaseParse)
+		profiler.recordPhase(profilePhasePlan)
+		profiler.addDelegatorProfiles([]*internalpb.DelegatorProfile{{
+			NodeID:           1,
+			Channel:          "ch1",
+			DurationUs:       1500,
+			ReduceDurationUs: 100,
+			Segments: []*internalpb.SegmentProfile{{
+				SegmentID:    100,
+				NodeID:       2,
+				DurationUs:   1000,
+				TotalRows:    1000,
+				FilteredRows: 10,
+				IndexType:    "HNSW",
+			}},
+		}})
+
+		profile := profiler.finish(&metricsinfo.SlowQuery{TraceID: "trace"}, false)
+		assert.NotNil(t, profile)
+		assert.Equal(t, "trace", profile.TraceID)
+		assert.False(t, profile.Slow)
+		assert.Len(t, profile.Phases, 2)
+		assert.Equal(t, profilePhaseParse, profile.Phases[0].Name)
+		assert.Equal(t, profilePhasePlan, profile.Phases[1].Name)
+		assert.Len(t, profile.Delegators, 1)
+		assert.Equal(t, "1.5ms", profile.Delegators[0].Duration)
+		assert.Len(t, profile.Delegators[0].Segments, 1)
+		assert.EqualValues(t, 10, profile.Delegators[0].Segments[0].FilteredRows)
+		assert.Equal(t, "HNSW", profile.Delegators[0].Segments[0].IndexType)
+	})
+
+	t.Run("slow query", func(t *testing.T) {
+		params := paramtable.Get()
+		params.Save(params.ProxyCfg.ProfileSlowQuery.Key, "true")
+		defer params.Reset(params.ProxyCfg.ProfileSlowQuery.Key)
+
+		node := &Proxy{queryProfiles: typeutil.NewRingBuffer[*metricsinfo.QueryProfile](10)}
+		profiler := newQueryProfiler(false)
+		assert.NotNil(t, profiler)
+		node.addQueryProfile(profiler, &metricsinfo.SlowQuery{}, false)
+		assert.Equal(t, 0, node.queryProfiles.Len())
+		node.addQueryProfile(profiler, &metricsinfo.SlowQuery{}, true)
+		assert.Equal(t, 1, node.queryProfiles.Len())
+		assert.True(t, node.queryProfiles.Values()[0].Slow)
+	})
+}
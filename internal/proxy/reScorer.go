@@ -145,6 +145,19 @@ func NewReScorers(reqCnt int, rankParams []*commonpb.KeyValuePair) ([]reScorer,
 		return res, nil
 	}
 
+	if isRerankerStrategy(rankTypeStr) {
+		// the sub results are fused by rrf, then the fused candidates are reordered by the reranker.
+		for i := 0; i < reqCnt; i++ {
+			res[i] = &rrfScorer{
+				baseScorer: baseScorer{
+					scorerName: "rrf",
+				},
+				k: float32(defaultRRFParamsValue),
+			}
+		}
+		return res, nil
+	}
+
 	if _, ok := rankTypeMap[rankTypeStr]; !ok {
 		return nil, errors.Errorf("unsupported rank type %s", rankTypeStr)
 	}
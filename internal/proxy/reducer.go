@@ -7,6 +7,7 @@ import (
 	"github.com/milvus-io/milvus-proto/go-api/v2/schemapb"
 	"github.com/milvus-io/milvus/internal/proto/internalpb"
 	"github.com/milvus-io/milvus/internal/proto/planpb"
+	"github.com/milvus-io/milvus/internal/util/aggregate"
 )
 
 type milvusReducer interface {
@@ -19,5 +20,13 @@ func createMilvusReducer(ctx context.Context, params *queryParams, req *internal
 			collectionName: collectionName,
 		}
 	}
+	if aggregate.IsAggregation(req) {
+		return &aggReducer{
+			params:         params,
+			req:            req,
+			schema:         schema,
+			collectionName: collectionName,
+		}
+	}
 	return newDefaultLimitReducer(ctx, params, req, schema, collectionName)
 }
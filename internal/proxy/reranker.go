@@ -0,0 +1,170 @@
+package proxy
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/cockroachdb/errors"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
+	"github.com/milvus-io/milvus-proto/go-api/v2/schemapb"
+	"github.com/milvus-io/milvus/internal/json"
+	"github.com/milvus-io/milvus/pkg/util/funcutil"
+	"github.com/milvus-io/milvus/pkg/util/typeutil"
+)
+
+// reranker reorders the fused result of hybrid search.
+// It runs after the reScorers have fused all the sub search results,
+// and the fields it relies on are fetched by requery.
+type reranker interface {
+	name() string
+	// inputFields returns the name of fields required by the reranker.
+	inputFields() []string
+	// rerank computes the new scores of all the results, the results are reordered by the new scores later.
+	rerank(ctx context.Context, result *schemapb.SearchResultData) ([]float32, error)
+}
+
+type rerankerBuilder func(schema *schemapb.CollectionSchema, nq int64, params map[string]interface{}) (reranker, error)
+
+// rerankerBuilders is the registry of rerankers, the key is the value of `reranker` in rank params.
+var rerankerBuilders = map[string]rerankerBuilder{
+	modelRerankerName: newModelReranker,
+	decayRerankerName: newDecayReranker,
+}
+
+// newReranker creates the reranker selected by the rank strategy, nil is returned if the strategy is not a reranker.
+func newReranker(schema *schemapb.CollectionSchema, nq int64, rankParams []*commonpb.KeyValuePair) (reranker, error) {
+	rankTypeStr, err := funcutil.GetAttrByKeyFromRepeatedKV(RankTypeKey, rankParams)
+	if err != nil {
+		return nil, nil
+	}
+	builder, ok := rerankerBuilders[rankTypeStr]
+	if !ok {
+		return nil, nil
+	}
+
+	paramStr, err := funcutil.GetAttrByKeyFromRepeatedKV(RankParamsKey, rankParams)
+	if err != nil {
+		return nil, errors.New(RankParamsKey + " not found in rank_params")
+	}
+	var params map[string]interface{}
+	if err := json.Unmarshal([]byte(paramStr), &params); err != nil {
+		return nil, err
+	}
+	return builder(schema, nq, params)
+}
+
+// isRerankerStrategy returns whether the rank strategy is served by a reranker.
+func isRerankerStrategy(rankTypeStr string) bool {
+	_, ok := rerankerBuilders[rankTypeStr]
+	return ok
+}
+
+// rerankSearchResultData reranks the result and reorders the rows of every query by the new scores in descending order.
+// The result holds the top offset+limit candidates of each query, only the rows in [offset, offset+limit) are kept after rerank.
+func rerankSearchResultData(ctx context.Context, r reranker, result *schemapb.SearchResultData, offset, limit int64) error {
+	if typeutil.GetSizeOfIDs(result.GetIds()) == 0 {
+		return nil
+	}
+	scores, err := r.rerank(ctx, result)
+	if err != nil {
+		return err
+	}
+	if len(scores) != len(result.GetScores()) {
+		return fmt.Errorf("reranker %s returned %d scores for %d results", r.name(), len(scores), len(result.GetScores()))
+	}
+
+	ids := &schemapb.IDs{}
+	newScores := make([]float32, 0, len(scores))
+	newTopks := make([]int64, 0, len(result.GetTopks()))
+	realTopK := int64(0)
+	fieldsData := typeutil.PrepareResultFieldData(result.GetFieldsData(), int64(len(scores)))
+	start := int64(0)
+	for _, topk := range result.GetTopks() {
+		order := make([]int64, 0, topk)
+		for i := start; i < start+topk; i++ {
+			order = append(order, i)
+		}
+		sort.SliceStable(order, func(i, j int) bool {
+			return scores[order[i]] > scores[order[j]]
+		})
+		order = order[min(offset, topk):min(offset+limit, topk)]
+		for _, idx := range order {
+			typeutil.AppendPKs(ids, typeutil.GetPK(result.GetIds(), idx))
+			newScores = append(newScores, scores[idx])
+			typeutil.AppendFieldData(fieldsData, result.GetFieldsData(), idx)
+		}
+		newTopks = append(newTopks, int64(len(order)))
+		realTopK = max(realTopK, int64(len(order)))
+		start += topk
+	}
+	result.Ids = ids
+	result.Scores = newScores
+	result.Topks = newTopks
+	result.TopK = realTopK
+	result.FieldsData = fieldsData
+	return nil
+}
+
+// getRerankInputField returns the field data with the name from the result.
+func getRerankInputField(result *schemapb.SearchResultData, name string) (*schemapb.FieldData, error) {
+	for _, fieldData := range result.GetFieldsData() {
+		if fieldData.GetFieldName() == name {
+			return fieldData, nil
+		}
+	}
+	return nil, fmt.Errorf("rerank input field %s not found in search result", name)
+}
+
+// isRerankInputValid returns whether the value at idx of field data is not null.
+func isRerankInputValid(fieldData *schemapb.FieldData, idx int) bool {
+	validData := fieldData.GetValidData()
+	return len(validData) == 0 || validData[idx]
+}
+
+// getNumericValue returns the value at idx of a numeric field data as float64.
+func getNumericValue(fieldData *schemapb.FieldData, idx int) (float64, error) {
+	scalars := fieldData.GetScalars()
+	switch fieldData.GetType() {
+	case schemapb.DataType_Int8, schemapb.DataType_Int16, schemapb.DataType_Int32:
+		return float64(scalars.GetIntData().GetData()[idx]), nil
+	case schemapb.DataType_Int64:
+		return float64(scalars.GetLongData().GetData()[idx]), nil
+	case schemapb.DataType_Float:
+		return float64(scalars.GetFloatData().GetData()[idx]), nil
+	case schemapb.DataType_Double:
+		return scalars.GetDoubleData().GetData()[idx], nil
+	default:
+		return 0, fmt.Errorf("field %s with type %s is not numeric", fieldData.GetFieldName(), fieldData.GetType().String())
+	}
+}
+
+// getRerankStringParam returns the string param of reranker.
+func getRerankStringParam(params map[string]interface{}, key string) (string, error) {
+	v, ok := params[key]
+	if !ok {
+		return "", fmt.Errorf("reranker param %s not found", key)
+	}
+	s, ok := v.(string)
+	if !ok {
+		return "", fmt.Errorf("the type of reranker param %s should be string", key)
+	}
+	return s, nil
+}
+
+// getRerankFloatParam returns the float param of reranker, defaultValue is returned if the param is not set.
+func getRerankFloatParam(params map[string]interface{}, key string, defaultValue *float64) (float64, error) {
+	v, ok := params[key]
+	if !ok {
+		if defaultValue == nil {
+			return 0, fmt.Errorf("reranker param %s not found", key)
+		}
+		return *defaultValue, nil
+	}
+	f, ok := v.(float64)
+	if !ok {
+		return 0, fmt.Errorf("the type of reranker param %s should be float", key)
+	}
+	return f, nil
+}
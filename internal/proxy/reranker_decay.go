@@ -0,0 +1,195 @@
+package proxy
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/schemapb"
+	"github.com/milvus-io/milvus/pkg/util/typeutil"
+)
+
+const (
+	decayRerankerName = "decay"
+
+	decayFunctionKey = "function"
+	decayOriginKey   = "origin"
+	decayScaleKey    = "scale"
+	decayOffsetKey   = "offset"
+	decayDecayKey    = "decay"
+
+	gaussDecayFunction  = "gauss"
+	expDecayFunction    = "exp"
+	linearDecayFunction = "linear"
+
+	// earthRadius is the mean radius of earth in meters, used by haversine distance.
+	earthRadius = 6371008.8
+)
+
+// decayReranker multiplies the score of each result by a decay factor,
+// which decreases with the distance between the value of a numeric field and the origin.
+// The input field can be a single numeric field (e.g. a timestamp),
+// or a pair of numeric fields as [latitude, longitude] in degrees, then the haversine distance in meters is used.
+// Results with null input are not decayed.
+type decayReranker struct {
+	function string
+	fields   []string
+	origin   []float64
+	scale    float64
+	offset   float64
+	decay    float64
+}
+
+func newDecayReranker(schema *schemapb.CollectionSchema, nq int64, params map[string]interface{}) (reranker, error) {
+	dr := &decayReranker{}
+	var err error
+	dr.function, err = getRerankStringParam(params, decayFunctionKey)
+	if err != nil {
+		return nil, err
+	}
+	if dr.function != gaussDecayFunction && dr.function != expDecayFunction && dr.function != linearDecayFunction {
+		return nil, fmt.Errorf("unsupported decay function %s, only %s, %s and %s are supported",
+			dr.function, gaussDecayFunction, expDecayFunction, linearDecayFunction)
+	}
+
+	switch v := params[rerankInputFieldKey].(type) {
+	case string:
+		dr.fields = []string{v}
+	case []interface{}:
+		for _, f := range v {
+			name, ok := f.(string)
+			if !ok {
+				return nil, fmt.Errorf("decay reranker param %s should be a field name or an array of field names", rerankInputFieldKey)
+			}
+			dr.fields = append(dr.fields, name)
+		}
+	default:
+		return nil, fmt.Errorf("decay reranker param %s should be a field name or an array of field names", rerankInputFieldKey)
+	}
+	if len(dr.fields) != 1 && len(dr.fields) != 2 {
+		return nil, fmt.Errorf("decay reranker accepts one numeric field or two fields as [latitude, longitude], but got %d fields", len(dr.fields))
+	}
+	for _, name := range dr.fields {
+		field := typeutil.GetFieldByName(schema, name)
+		if field == nil {
+			return nil, fmt.Errorf("decay reranker input field %s not found in schema", name)
+		}
+		if !typeutil.IsArithmetic(field.GetDataType()) {
+			return nil, fmt.Errorf("decay reranker input field must be numeric, but field %s is %s", name, field.GetDataType().String())
+		}
+	}
+
+	switch v := params[decayOriginKey].(type) {
+	case float64:
+		dr.origin = []float64{v}
+	case []interface{}:
+		for _, o := range v {
+			f, ok := o.(float64)
+			if !ok {
+				return nil, fmt.Errorf("the type of decay reranker param %s should be float or an array of float", decayOriginKey)
+			}
+			dr.origin = append(dr.origin, f)
+		}
+	default:
+		return nil, fmt.Errorf("the type of decay reranker param %s should be float or an array of float", decayOriginKey)
+	}
+	if len(dr.origin) != len(dr.fields) {
+		return nil, fmt.Errorf("decay reranker param %s should have %d values, but got %d", decayOriginKey, len(dr.fields), len(dr.origin))
+	}
+
+	if dr.scale, err = getRerankFloatParam(params, decayScaleKey, nil); err != nil {
+		return nil, err
+	}
+	if dr.scale <= 0 {
+		return nil, fmt.Errorf("decay reranker param %s should be positive", decayScaleKey)
+	}
+	defaultOffset, defaultDecay := 0.0, 0.5
+	if dr.offset, err = getRerankFloatParam(params, decayOffsetKey, &defaultOffset); err != nil {
+		return nil, err
+	}
+	if dr.offset < 0 {
+		return nil, fmt.Errorf("decay reranker param %s should not be negative", decayOffsetKey)
+	}
+	if dr.decay, err = getRerankFloatParam(params, decayDecayKey, &defaultDecay); err != nil {
+		return nil, err
+	}
+	if dr.decay <= 0 || dr.decay >= 1 {
+		return nil, fmt.Errorf("decay reranker param %s should be in range (0, 1)", decayDecayKey)
+	}
+	return dr, nil
+}
+
+func (dr *decayReranker) name() string {
+	return decayRerankerName
+}
+
+func (dr *decayReranker) inputFields() []string {
+	return dr.fields
+}
+
+func (dr *decayReranker) rerank(ctx context.Context, result *schemapb.SearchResultData) ([]float32, error) {
+	fieldsData := make([]*schemapb.FieldData, 0, len(dr.fields))
+	for _, name := range dr.fields {
+		fieldData, err := getRerankInputField(result, name)
+		if err != nil {
+			return nil, err
+		}
+		fieldsData = append(fieldsData, fieldData)
+	}
+
+	scores := make([]float32, len(result.GetScores()))
+	for i, score := range result.GetScores() {
+		scores[i] = score
+		values := make([]float64, 0, len(fieldsData))
+		for _, fieldData := range fieldsData {
+			if !isRerankInputValid(fieldData, i) {
+				break
+			}
+			v, err := getNumericValue(fieldData, i)
+			if err != nil {
+				return nil, err
+			}
+			values = append(values, v)
+		}
+		if len(values) != len(fieldsData) {
+			continue
+		}
+		scores[i] = score * float32(dr.factor(dr.distance(values)))
+	}
+	return scores, nil
+}
+
+// distance returns the distance between values and origin.
+func (dr *decayReranker) distance(values []float64) float64 {
+	if len(values) == 1 {
+		return math.Abs(values[0] - dr.origin[0])
+	}
+	return haversineDistance(values[0], values[1], dr.origin[0], dr.origin[1])
+}
+
+// factor returns the decay factor of the distance,
+// it's 1 if distance is within offset, and it's exactly `decay` at `offset + scale`.
+func (dr *decayReranker) factor(distance float64) float64 {
+	d := math.Max(0, distance-dr.offset)
+	switch dr.function {
+	case gaussDecayFunction:
+		sigmaSquare := -dr.scale * dr.scale / (2 * math.Log(dr.decay))
+		return math.Exp(-d * d / (2 * sigmaSquare))
+	case expDecayFunction:
+		lambda := math.Log(dr.decay) / dr.scale
+		return math.Exp(lambda * d)
+	default:
+		s := dr.scale / (1 - dr.decay)
+		return math.Max(0, (s-d)/s)
+	}
+}
+
+// haversineDistance returns the great-circle distance in meters between two points given in degrees.
+func haversineDistance(lat1, lon1, lat2, lon2 float64) float64 {
+	toRadians := func(degree float64) float64 { return degree * math.Pi / 180 }
+	dLat := toRadians(lat2 - lat1)
+	dLon := toRadians(lon2 - lon1)
+	a := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(toRadians(lat1))*math.Cos(toRadians(lat2))*math.Sin(dLon/2)*math.Sin(dLon/2)
+	return 2 * earthRadius * math.Asin(math.Min(1, math.Sqrt(a)))
+}
@@ -0,0 +1,114 @@
+package proxy
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
+	"github.com/milvus-io/milvus-proto/go-api/v2/schemapb"
+	"github.com/milvus-io/milvus/internal/util/function"
+	"github.com/milvus-io/milvus/pkg/util/typeutil"
+)
+
+const (
+	modelRerankerName = "model"
+
+	rerankQueriesKey    = "queries"
+	rerankInputFieldKey = "input_field"
+)
+
+// modelReranker scores the results by an external cross-encoder service,
+// with the query text and the text field of each result.
+type modelReranker struct {
+	client     *function.RerankClient
+	queries    []string
+	inputField string
+}
+
+func newModelReranker(schema *schemapb.CollectionSchema, nq int64, params map[string]interface{}) (reranker, error) {
+	inputField, err := getRerankStringParam(params, rerankInputFieldKey)
+	if err != nil {
+		return nil, err
+	}
+	field := typeutil.GetFieldByName(schema, inputField)
+	if field == nil {
+		return nil, fmt.Errorf("model reranker input field %s not found in schema", inputField)
+	}
+	if field.GetDataType() != schemapb.DataType_VarChar {
+		return nil, fmt.Errorf("model reranker input field must be a VARCHAR field, but got %s", field.GetDataType().String())
+	}
+
+	rawQueries, ok := params[rerankQueriesKey].([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("model reranker param %s should be an array of string", rerankQueriesKey)
+	}
+	if int64(len(rawQueries)) != nq {
+		return nil, fmt.Errorf("the length of model reranker param %s %d mismatch with nq %d", rerankQueriesKey, len(rawQueries), nq)
+	}
+	queries := make([]string, 0, len(rawQueries))
+	for _, q := range rawQueries {
+		query, ok := q.(string)
+		if !ok {
+			return nil, fmt.Errorf("model reranker param %s should be an array of string", rerankQueriesKey)
+		}
+		queries = append(queries, query)
+	}
+
+	clientParams := make([]*commonpb.KeyValuePair, 0, len(params))
+	for key, value := range params {
+		if key == rerankQueriesKey || key == rerankInputFieldKey {
+			continue
+		}
+		clientParams = append(clientParams, &commonpb.KeyValuePair{Key: key, Value: formatRerankParam(value)})
+	}
+	client, err := function.NewRerankClient(clientParams)
+	if err != nil {
+		return nil, err
+	}
+	return &modelReranker{
+		client:     client,
+		queries:    queries,
+		inputField: inputField,
+	}, nil
+}
+
+// formatRerankParam formats the json value of param as the client param,
+// the numbers are formatted without exponent, e.g. 1000000 instead of 1e+06.
+func formatRerankParam(value interface{}) string {
+	if v, ok := value.(float64); ok {
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	}
+	return fmt.Sprint(value)
+}
+
+func (mr *modelReranker) name() string {
+	return modelRerankerName
+}
+
+func (mr *modelReranker) inputFields() []string {
+	return []string{mr.inputField}
+}
+
+func (mr *modelReranker) rerank(ctx context.Context, result *schemapb.SearchResultData) ([]float32, error) {
+	fieldData, err := getRerankInputField(result, mr.inputField)
+	if err != nil {
+		return nil, err
+	}
+	texts := fieldData.GetScalars().GetStringData().GetData()
+	if len(texts) != len(result.GetScores()) {
+		return nil, fmt.Errorf("model reranker got %d texts for %d results", len(texts), len(result.GetScores()))
+	}
+
+	scores := make([]float32, 0, len(texts))
+	offset := int64(0)
+	for i, topk := range result.GetTopks() {
+		queryScores, err := mr.client.Rerank(ctx, mr.queries[i], texts[offset:offset+topk])
+		if err != nil {
+			return nil, err
+		}
+		scores = append(scores, queryScores...)
+		offset += topk
+	}
+	return scores, nil
+}
@@ -0,0 +1,259 @@
+package proxy
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
+	"github.com/milvus-io/milvus-proto/go-api/v2/schemapb"
+	"github.com/milvus-io/milvus/internal/json"
+)
+
+func newRerankTestSchema() *schemapb.CollectionSchema {
+	return &schemapb.CollectionSchema{
+		Fields: []*schemapb.FieldSchema{
+			{FieldID: 100, Name: "pk", DataType: schemapb.DataType_Int64, IsPrimaryKey: true},
+			{FieldID: 101, Name: "text", DataType: schemapb.DataType_VarChar},
+			{FieldID: 102, Name: "ts", DataType: schemapb.DataType_Int64},
+			{FieldID: 103, Name: "lat", DataType: schemapb.DataType_Double},
+			{FieldID: 104, Name: "lon", DataType: schemapb.DataType_Double},
+		},
+	}
+}
+
+// newRerankTestResult returns a result of 2 queries with 2 results each.
+func newRerankTestResult() *schemapb.SearchResultData {
+	return &schemapb.SearchResultData{
+		NumQueries: 2,
+		TopK:       2,
+		Topks:      []int64{2, 2},
+		Scores:     []float32{1, 0.5, 1, 0.5},
+		Ids:        &schemapb.IDs{IdField: &schemapb.IDs_IntId{IntId: &schemapb.LongArray{Data: []int64{1, 2, 3, 4}}}},
+		FieldsData: []*schemapb.FieldData{
+			{
+				Type: schemapb.DataType_VarChar, FieldName: "text", FieldId: 101,
+				Field: &schemapb.FieldData_Scalars{Scalars: &schemapb.ScalarField{
+					Data: &schemapb.ScalarField_StringData{StringData: &schemapb.StringArray{Data: []string{"a", "bbb", "ccc", "d"}}},
+				}},
+			},
+			{
+				Type: schemapb.DataType_Int64, FieldName: "ts", FieldId: 102,
+				Field: &schemapb.FieldData_Scalars{Scalars: &schemapb.ScalarField{
+					Data: &schemapb.ScalarField_LongData{LongData: &schemapb.LongArray{Data: []int64{0, 100, 100, 0}}},
+				}},
+			},
+		},
+	}
+}
+
+func rerankParams(name string, params map[string]any) []*commonpb.KeyValuePair {
+	b, _ := json.Marshal(params)
+	return []*commonpb.KeyValuePair{
+		{Key: RankTypeKey, Value: name},
+		{Key: RankParamsKey, Value: string(b)},
+	}
+}
+
+func TestNewReranker(t *testing.T) {
+	schema := newRerankTestSchema()
+
+	r, err := newReranker(schema, 2, nil)
+	assert.NoError(t, err)
+	assert.Nil(t, r)
+
+	// the strategies served by reScorers
+	r, err = newReranker(schema, 2, []*commonpb.KeyValuePair{{Key: RankTypeKey, Value: "rrf"}})
+	assert.NoError(t, err)
+	assert.Nil(t, r)
+
+	_, err = newReranker(schema, 2, []*commonpb.KeyValuePair{{Key: RankTypeKey, Value: decayRerankerName}})
+	assert.Error(t, err)
+
+	_, err = newReranker(schema, 2, []*commonpb.KeyValuePair{
+		{Key: RankTypeKey, Value: decayRerankerName},
+		{Key: RankParamsKey, Value: "{"},
+	})
+	assert.Error(t, err)
+
+	// the sub results are fused by rrf before rerank
+	for _, name := range []string{decayRerankerName, modelRerankerName} {
+		reScorers, err := NewReScorers(2, rerankParams(name, map[string]any{}))
+		assert.NoError(t, err)
+		assert.Len(t, reScorers, 2)
+		assert.Equal(t, rrfRankType, reScorers[0].scorerType())
+	}
+}
+
+func TestRerankSearchResultDataWithOffset(t *testing.T) {
+	schema := newRerankTestSchema()
+	r, err := newReranker(schema, 2, rerankParams(decayRerankerName, map[string]any{
+		"function": gaussDecayFunction, "input_field": "ts", "origin": 100, "scale": 10,
+	}))
+	assert.NoError(t, err)
+
+	// the candidates are reranked before the offset is applied.
+	result := newRerankTestResult()
+	assert.NoError(t, rerankSearchResultData(context.Background(), r, result, 1, 1))
+	assert.Equal(t, []int64{1, 1}, result.GetTopks())
+	assert.Equal(t, int64(1), result.GetTopK())
+	assert.Equal(t, []int64{1, 4}, result.GetIds().GetIntId().GetData())
+	assert.Equal(t, []string{"a", "d"}, result.GetFieldsData()[0].GetScalars().GetStringData().GetData())
+
+	result = newRerankTestResult()
+	assert.NoError(t, rerankSearchResultData(context.Background(), r, result, 2, 1))
+	assert.Equal(t, []int64{0, 0}, result.GetTopks())
+	assert.Empty(t, result.GetScores())
+}
+
+func TestFormatRerankParam(t *testing.T) {
+	var params map[string]any
+	assert.NoError(t, json.Unmarshal([]byte(`{"timeout_ms": 1000000, "truncate": true, "score": 0.5, "url": "http://localhost"}`), &params))
+	assert.Equal(t, "1000000", formatRerankParam(params["timeout_ms"]))
+	assert.Equal(t, "true", formatRerankParam(params["truncate"]))
+	assert.Equal(t, "0.5", formatRerankParam(params["score"]))
+	assert.Equal(t, "http://localhost", formatRerankParam(params["url"]))
+}
+
+func TestDecayReranker(t *testing.T) {
+	schema := newRerankTestSchema()
+
+	t.Run("invalid params", func(t *testing.T) {
+		for _, params := range []map[string]any{
+			{"function": "unknown", "input_field": "ts", "origin": 0, "scale": 100},
+			{"function": "gauss", "input_field": "not_exist", "origin": 0, "scale": 100},
+			{"function": "gauss", "input_field": "text", "origin": 0, "scale": 100},
+			{"function": "gauss", "input_field": "ts", "origin": []float64{0, 1}, "scale": 100},
+			{"function": "gauss", "input_field": "ts", "origin": 0, "scale": 0},
+			{"function": "gauss", "input_field": "ts", "origin": 0},
+			{"function": "gauss", "input_field": "ts", "origin": 0, "scale": 100, "decay": 1},
+			{"function": "gauss", "input_field": "ts", "origin": 0, "scale": 100, "offset": -1},
+			{"function": "gauss", "input_field": []string{"lat", "lon", "ts"}, "origin": []float64{0, 0, 0}, "scale": 100},
+		} {
+			_, err := newReranker(schema, 2, rerankParams(decayRerankerName, params))
+			assert.Error(t, err, params)
+		}
+	})
+
+	t.Run("decay functions", func(t *testing.T) {
+		for _, function := range []string{gaussDecayFunction, expDecayFunction, linearDecayFunction} {
+			r, err := newReranker(schema, 2, rerankParams(decayRerankerName, map[string]any{
+				"function": function, "input_field": "ts", "origin": 0, "scale": 100, "offset": 10, "decay": 0.5,
+			}))
+			assert.NoError(t, err)
+			dr := r.(*decayReranker)
+			assert.Equal(t, 1.0, dr.factor(5))
+			assert.InDelta(t, 0.5, dr.factor(110), 1e-6)
+			assert.Less(t, dr.factor(200), 0.5)
+		}
+	})
+
+	t.Run("rerank", func(t *testing.T) {
+		r, err := newReranker(schema, 2, rerankParams(decayRerankerName, map[string]any{
+			"function": gaussDecayFunction, "input_field": "ts", "origin": 100, "scale": 10,
+		}))
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"ts"}, r.inputFields())
+
+		result := newRerankTestResult()
+		assert.NoError(t, rerankSearchResultData(context.Background(), r, result, 0, 2))
+		// the first query is reordered as ts 100 is the origin.
+		assert.Equal(t, []int64{2, 1, 3, 4}, result.GetIds().GetIntId().GetData())
+		assert.Equal(t, []string{"bbb", "a", "ccc", "d"}, result.GetFieldsData()[0].GetScalars().GetStringData().GetData())
+		assert.Equal(t, []int64{100, 0, 100, 0}, result.GetFieldsData()[1].GetScalars().GetLongData().GetData())
+		assert.Equal(t, float32(0.5), result.GetScores()[0])
+	})
+
+	t.Run("geo distance", func(t *testing.T) {
+		// Paris to London
+		assert.InDelta(t, 343556, haversineDistance(48.8566, 2.3522, 51.5074, -0.1278), 1000)
+
+		r, err := newReranker(schema, 1, rerankParams(decayRerankerName, map[string]any{
+			"function": linearDecayFunction, "input_field": []string{"lat", "lon"}, "origin": []float64{0, 0}, "scale": 5000,
+		}))
+		assert.NoError(t, err)
+		result := &schemapb.SearchResultData{
+			Topks:  []int64{2},
+			Scores: []float32{1, 0.9},
+			Ids:    &schemapb.IDs{IdField: &schemapb.IDs_StrId{StrId: &schemapb.StringArray{Data: []string{"far", "near"}}}},
+			FieldsData: []*schemapb.FieldData{
+				{
+					Type: schemapb.DataType_Double, FieldName: "lat",
+					Field: &schemapb.FieldData_Scalars{Scalars: &schemapb.ScalarField{
+						Data: &schemapb.ScalarField_DoubleData{DoubleData: &schemapb.DoubleArray{Data: []float64{1, 0}}},
+					}},
+				},
+				{
+					Type: schemapb.DataType_Double, FieldName: "lon",
+					Field: &schemapb.FieldData_Scalars{Scalars: &schemapb.ScalarField{
+						Data: &schemapb.ScalarField_DoubleData{DoubleData: &schemapb.DoubleArray{Data: []float64{1, 0}}},
+					}},
+				},
+			},
+		}
+		assert.NoError(t, rerankSearchResultData(context.Background(), r, result, 0, 2))
+		assert.Equal(t, []string{"near", "far"}, result.GetIds().GetStrId().GetData())
+		assert.Equal(t, float32(0.9), result.GetScores()[0])
+		assert.Equal(t, float32(0), result.GetScores()[1])
+	})
+}
+
+func TestModelReranker(t *testing.T) {
+	schema := newRerankTestSchema()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req := struct {
+			Query string   `json:"query"`
+			Texts []string `json:"texts"`
+		}{}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		// score by the length of text, the texts matching query get the highest score.
+		resp := make([]map[string]any, 0, len(req.Texts))
+		for i, text := range req.Texts {
+			score := float64(len(text))
+			if strings.Contains(text, req.Query) {
+				score = math.MaxInt32
+			}
+			resp = append(resp, map[string]any{"index": i, "score": score})
+		}
+		assert.NoError(t, json.NewEncoder(w).Encode(resp))
+	}))
+	defer server.Close()
+
+	t.Run("invalid params", func(t *testing.T) {
+		for _, params := range []map[string]any{
+			{"provider": "tei", "url": server.URL, "input_field": "ts", "queries": []string{"a", "d"}},
+			{"provider": "tei", "url": server.URL, "input_field": "not_exist", "queries": []string{"a", "d"}},
+			{"provider": "tei", "url": server.URL, "input_field": "text", "queries": []string{"a"}},
+			{"provider": "tei", "url": server.URL, "input_field": "text", "queries": "a"},
+			{"provider": "tei", "input_field": "text", "queries": []string{"a", "d"}},
+			{"provider": "unknown", "url": server.URL, "input_field": "text", "queries": []string{"a", "d"}},
+		} {
+			_, err := newReranker(schema, 2, rerankParams(modelRerankerName, params))
+			assert.Error(t, err, params)
+		}
+	})
+
+	t.Run("rerank", func(t *testing.T) {
+		r, err := newReranker(schema, 2, rerankParams(modelRerankerName, map[string]any{
+			"provider": "tei", "url": server.URL, "input_field": "text", "queries": []string{"x", "d"},
+		}))
+		assert.NoError(t, err)
+		assert.Equal(t, modelRerankerName, r.name())
+		assert.Equal(t, []string{"text"}, r.inputFields())
+
+		result := newRerankTestResult()
+		assert.NoError(t, rerankSearchResultData(context.Background(), r, result, 0, 2))
+		assert.Equal(t, []int64{2, 1, 4, 3}, result.GetIds().GetIntId().GetData())
+		assert.Equal(t, []float32{3, 1, math.MaxInt32, 3}, result.GetScores())
+
+		// missing input field
+		result = newRerankTestResult()
+		result.FieldsData = result.FieldsData[1:]
+		assert.Error(t, rerankSearchResultData(context.Background(), r, result, 0, 2))
+	})
+}
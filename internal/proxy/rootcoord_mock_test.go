@@ -1154,6 +1154,22 @@ func (coord *RootCoordMock) OperatePrivilegeGroup(ctx context.Context, req *milv
 	return &commonpb.Status{}, nil
 }
 
+func (coord *RootCoordMock) OperateRowPolicy(ctx context.Context, req *rootcoordpb.OperateRowPolicyRequest, opts ...grpc.CallOption) (*commonpb.Status, error) {
+	return &commonpb.Status{}, nil
+}
+
+func (coord *RootCoordMock) ListRecycleBin(ctx context.Context, req *proxypb.ListRecycleBinRequest, opts ...grpc.CallOption) (*proxypb.ListRecycleBinResponse, error) {
+	return &proxypb.ListRecycleBinResponse{}, nil
+}
+
+func (coord *RootCoordMock) UndropCollection(ctx context.Context, req *proxypb.UndropCollectionRequest, opts ...grpc.CallOption) (*commonpb.Status, error) {
+	return &commonpb.Status{}, nil
+}
+
+func (coord *RootCoordMock) UndropPartition(ctx context.Context, req *proxypb.UndropPartitionRequest, opts ...grpc.CallOption) (*commonpb.Status, error) {
+	return &commonpb.Status{}, nil
+}
+
 type DescribeCollectionFunc func(ctx context.Context, request *milvuspb.DescribeCollectionRequest, opts ...grpc.CallOption) (*milvuspb.DescribeCollectionResponse, error)
 
 type ShowPartitionsFunc func(ctx context.Context, request *milvuspb.ShowPartitionsRequest, opts ...grpc.CallOption) (*milvuspb.ShowPartitionsResponse, error)
@@ -0,0 +1,172 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"context"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/schemapb"
+	"github.com/milvus-io/milvus/internal/parser/planparserv2"
+	"github.com/milvus-io/milvus/internal/proto/planpb"
+	"github.com/milvus-io/milvus/internal/util/exprutil"
+	"github.com/milvus-io/milvus/pkg/util"
+	"github.com/milvus-io/milvus/pkg/util/merr"
+	"github.com/milvus-io/milvus/pkg/util/typeutil"
+)
+
+// getRowPolicyFilter returns the filter expr restricting the rows the current user can access in the collection,
+// nil if the user is not restricted. A user with several restricted roles can access the rows permitted by
+// any of them, the roles without row policy on the collection don't lift the restriction. Each policy is parsed
+// on its own and the policies are combined as plan nodes, so that neither of them could change the meaning of
+// the others or of the request expr.
+func getRowPolicyFilter(ctx context.Context, dbName, collectionName string, schemaHelper *typeutil.SchemaHelper) (*planpb.Expr, error) {
+	roles, err := getRestrictedRoles(ctx)
+	if err != nil || len(roles) == 0 {
+		return nil, err
+	}
+	if dbName == "" {
+		dbName = util.DefaultDBName
+	}
+	var filter *planpb.Expr
+	for _, expr := range globalMetaCache.GetRowPolicies(roles, dbName, collectionName) {
+		parsed, err := planparserv2.ParseExpr(schemaHelper, expr, nil)
+		if err != nil {
+			return nil, merr.WrapErrParameterInvalidMsg("invalid row policy %s on collection %s: %v", expr, collectionName, err)
+		}
+		if filter == nil {
+			filter = parsed
+			continue
+		}
+		filter = &planpb.Expr{
+			Expr: &planpb.Expr_BinaryExpr{
+				BinaryExpr: &planpb.BinaryExpr{
+					Op:    planpb.BinaryExpr_LogicalOr,
+					Left:  filter,
+					Right: parsed,
+				},
+			},
+		}
+	}
+	return filter, nil
+}
+
+// getRestrictedRoles returns the roles of the current user which the row policies and the masked fields apply to,
+// empty if the authorization is disabled or the user is root.
+func getRestrictedRoles(ctx context.Context) ([]string, error) {
+	if !Params.CommonCfg.AuthorizationEnabled.GetAsBool() {
+		return nil, nil
+	}
+	username, err := GetCurUserFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if username == util.UserRoot {
+		return nil, nil
+	}
+	roles, err := getUserRoles(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+	return append(roles, util.RolePublic), nil
+}
+
+// andRowPolicyFilter ands the row policy filter into the predicates of the plan.
+func andRowPolicyFilter(plan *planpb.PlanNode, filter *planpb.Expr) error {
+	if filter == nil {
+		return nil
+	}
+	and := func(predicates *planpb.Expr) *planpb.Expr {
+		if predicates == nil {
+			return filter
+		}
+		return &planpb.Expr{
+			Expr: &planpb.Expr_BinaryExpr{
+				BinaryExpr: &planpb.BinaryExpr{
+					Op:    planpb.BinaryExpr_LogicalAnd,
+					Left:  predicates,
+					Right: filter,
+				},
+			},
+		}
+	}
+
+	switch node := plan.GetNode().(type) {
+	case *planpb.PlanNode_VectorAnns:
+		node.VectorAnns.Predicates = and(node.VectorAnns.GetPredicates())
+	case *planpb.PlanNode_Query:
+		node.Query.Predicates = and(node.Query.GetPredicates())
+	case *planpb.PlanNode_Predicates:
+		node.Predicates = and(node.Predicates)
+	default:
+		return merr.WrapErrParameterInvalidMsg("row policy is not supported on plan node %T", plan.GetNode())
+	}
+	return nil
+}
+
+// newRowPolicyChecker compiles the row policy expr into a row filter, used to check the written rows.
+func newRowPolicyChecker(schemaHelper *typeutil.SchemaHelper, expr string) (exprutil.RowFilter, error) {
+	parsed, err := planparserv2.ParseExpr(schemaHelper, expr, nil)
+	if err != nil {
+		return nil, err
+	}
+	return exprutil.NewRowFilter(parsed)
+}
+
+// checkRowPolicy checks that all the written rows are permitted by the row policy filter of the current user.
+func checkRowPolicy(filter *planpb.Expr, collectionName string, schema *schemaInfo, fieldsData []*schemapb.FieldData, numRows int) error {
+	check, err := exprutil.NewRowFilter(filter)
+	if err != nil {
+		return err
+	}
+
+	columns := make(map[int64]*schemapb.FieldData, len(fieldsData))
+	for _, fieldData := range fieldsData {
+		field, err := schema.schemaHelper.GetFieldFromName(fieldData.GetFieldName())
+		if err != nil {
+			return err
+		}
+		if typeutil.IsVectorType(field.GetDataType()) {
+			continue
+		}
+		columns[field.GetFieldID()] = fieldData
+	}
+	row := make(map[int64]any, len(columns))
+	for i := 0; i < numRows; i++ {
+		for fieldID, column := range columns {
+			row[fieldID] = getRowPolicyValue(column, i)
+		}
+		if !check(row) {
+			return merr.WrapErrPrivilegeNotPermitted("row %d is not permitted by the row policy on collection %s", i, collectionName)
+		}
+	}
+	return nil
+}
+
+func getRowPolicyValue(fieldData *schemapb.FieldData, idx int) any {
+	if validData := fieldData.GetValidData(); len(validData) > 0 && !validData[idx] {
+		return nil
+	}
+	switch fieldData.GetType() {
+	case schemapb.DataType_JSON:
+		return fieldData.GetScalars().GetJsonData().GetData()[idx]
+	case schemapb.DataType_Bool, schemapb.DataType_Int8, schemapb.DataType_Int16, schemapb.DataType_Int32,
+		schemapb.DataType_Int64, schemapb.DataType_Float, schemapb.DataType_Double, schemapb.DataType_VarChar:
+		return typeutil.GetData(fieldData, idx)
+	default:
+		return nil
+	}
+}
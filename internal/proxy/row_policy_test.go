@@ -0,0 +1,210 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
+	"github.com/milvus-io/milvus-proto/go-api/v2/schemapb"
+	"github.com/milvus-io/milvus/internal/mocks"
+	"github.com/milvus-io/milvus/internal/parser/planparserv2"
+	"github.com/milvus-io/milvus/internal/proto/internalpb"
+	"github.com/milvus-io/milvus/internal/proto/planpb"
+	"github.com/milvus-io/milvus/internal/util/exprutil"
+	"github.com/milvus-io/milvus/pkg/common"
+	"github.com/milvus-io/milvus/pkg/util/funcutil"
+	"github.com/milvus-io/milvus/pkg/util/merr"
+	"github.com/milvus-io/milvus/pkg/util/paramtable"
+	"github.com/milvus-io/milvus/pkg/util/testutils"
+)
+
+func initRowPolicyMetaCache(t *testing.T) {
+	client := &MockRootCoordClientInterface{}
+	client.listPolicy = func(ctx context.Context, in *internalpb.ListPolicyRequest) (*internalpb.ListPolicyResponse, error) {
+		return &internalpb.ListPolicyResponse{
+			Status: merr.Success(),
+			UserRoles: []string{
+				funcutil.EncodeUserRoleCache("alice", "role1"),
+				funcutil.EncodeUserRoleCache("alice", "role2"),
+				funcutil.EncodeUserRoleCache("bob", "role3"),
+			},
+			RowPolicies: []*internalpb.RowPolicy{
+				{Role: "role1", DbName: "default", CollectionName: "coll", Expr: "tenant == 1"},
+				{Role: "role2", DbName: "default", CollectionName: "coll", Expr: "tenant == 2"},
+			},
+		}, nil
+	}
+	err := InitMetaCache(context.Background(), client, &mocks.MockQueryCoordClient{}, newShardClientMgr())
+	assert.NoError(t, err)
+}
+
+func newRowPolicyTestSchema() *schemaInfo {
+	return newSchemaInfo(&schemapb.CollectionSchema{
+		Name: "coll",
+		Fields: []*schemapb.FieldSchema{
+			{FieldID: 100, Name: "pk", IsPrimaryKey: true, DataType: schemapb.DataType_Int64},
+			{FieldID: 101, Name: "tenant", DataType: schemapb.DataType_Int64},
+			{
+				FieldID: 102, Name: "vec", DataType: schemapb.DataType_FloatVector,
+				TypeParams: []*commonpb.KeyValuePair{{Key: common.DimKey, Value: "2"}},
+			},
+		},
+	})
+}
+
+func TestGetRowPolicyFilter(t *testing.T) {
+	paramtable.Init()
+	initRowPolicyMetaCache(t)
+	schema := newRowPolicyTestSchema()
+
+	t.Run("authorization disabled", func(t *testing.T) {
+		filter, err := getRowPolicyFilter(GetContext(context.Background(), "alice:123456"), "", "coll", schema.schemaHelper)
+		assert.NoError(t, err)
+		assert.Nil(t, filter)
+	})
+
+	paramtable.Get().Save(Params.CommonCfg.AuthorizationEnabled.Key, "true")
+	defer paramtable.Get().Reset(Params.CommonCfg.AuthorizationEnabled.Key)
+
+	t.Run("restricted user", func(t *testing.T) {
+		filter, err := getRowPolicyFilter(GetContext(context.Background(), "alice:123456"), "", "coll", schema.schemaHelper)
+		assert.NoError(t, err)
+		// the policies of the roles are ored
+		assert.Equal(t, planpb.BinaryExpr_LogicalOr, filter.GetBinaryExpr().GetOp())
+		check, err := exprutil.NewRowFilter(filter)
+		assert.NoError(t, err)
+		assert.True(t, check(map[int64]any{101: int64(1)}))
+		assert.True(t, check(map[int64]any{101: int64(2)}))
+		assert.False(t, check(map[int64]any{101: int64(3)}))
+
+		filter, err = getRowPolicyFilter(GetContext(context.Background(), "alice:123456"), "default", "coll2", schema.schemaHelper)
+		assert.NoError(t, err)
+		assert.Nil(t, filter)
+	})
+
+	t.Run("unrestricted user", func(t *testing.T) {
+		filter, err := getRowPolicyFilter(GetContext(context.Background(), "bob:123456"), "default", "coll", schema.schemaHelper)
+		assert.NoError(t, err)
+		assert.Nil(t, filter)
+
+		filter, err = getRowPolicyFilter(GetContext(context.Background(), "root:123456"), "default", "coll", schema.schemaHelper)
+		assert.NoError(t, err)
+		assert.Nil(t, filter)
+	})
+
+	t.Run("no user", func(t *testing.T) {
+		_, err := getRowPolicyFilter(context.Background(), "default", "coll", schema.schemaHelper)
+		assert.Error(t, err)
+	})
+}
+
+func TestAndRowPolicyFilter(t *testing.T) {
+	paramtable.Init()
+	initRowPolicyMetaCache(t)
+	paramtable.Get().Save(Params.CommonCfg.AuthorizationEnabled.Key, "true")
+	defer paramtable.Get().Reset(Params.CommonCfg.AuthorizationEnabled.Key)
+
+	schema := newRowPolicyTestSchema()
+	filter, err := getRowPolicyFilter(GetContext(context.Background(), "alice:123456"), "default", "coll", schema.schemaHelper)
+	assert.NoError(t, err)
+
+	matches := func(t *testing.T, plan *planpb.PlanNode, tenant int64) bool {
+		expr, err := exprutil.ParseExprFromPlan(plan)
+		assert.NoError(t, err)
+		check, err := exprutil.NewRowFilter(expr)
+		assert.NoError(t, err)
+		return check(map[int64]any{101: tenant})
+	}
+
+	t.Run("query", func(t *testing.T) {
+		// the or of the request doesn't widen the policies
+		plan, err := planparserv2.CreateRetrievePlan(schema.schemaHelper, "tenant == 3 or tenant == 1", nil)
+		assert.NoError(t, err)
+		assert.NoError(t, andRowPolicyFilter(plan, filter))
+		assert.True(t, matches(t, plan, 1))
+		assert.False(t, matches(t, plan, 2))
+		assert.False(t, matches(t, plan, 3))
+	})
+
+	t.Run("empty expr", func(t *testing.T) {
+		plan, err := planparserv2.CreateRetrievePlan(schema.schemaHelper, "", nil)
+		assert.NoError(t, err)
+		assert.NoError(t, andRowPolicyFilter(plan, filter))
+		assert.True(t, matches(t, plan, 2))
+		assert.False(t, matches(t, plan, 3))
+	})
+
+	t.Run("search", func(t *testing.T) {
+		plan, err := planparserv2.CreateSearchPlan(schema.schemaHelper, "tenant >= 2", "vec", &planpb.QueryInfo{Topk: 10, MetricType: "L2"}, nil)
+		assert.NoError(t, err)
+		assert.NoError(t, andRowPolicyFilter(plan, filter))
+		assert.True(t, matches(t, plan, 2))
+		assert.False(t, matches(t, plan, 1))
+		assert.False(t, matches(t, plan, 3))
+	})
+
+	t.Run("unbalanced parentheses", func(t *testing.T) {
+		// the expr can't close the parentheses around the policies any more, it is rejected by the parser
+		_, err := planparserv2.CreateRetrievePlan(schema.schemaHelper, "tenant == 3) or (tenant == 3", nil)
+		assert.Error(t, err)
+		_, err = planparserv2.CreateRetrievePlan(schema.schemaHelper, "tenant == 3)) or ((tenant > 0", nil)
+		assert.Error(t, err)
+	})
+
+	t.Run("no filter", func(t *testing.T) {
+		plan, err := planparserv2.CreateRetrievePlan(schema.schemaHelper, "tenant == 3", nil)
+		assert.NoError(t, err)
+		assert.NoError(t, andRowPolicyFilter(plan, nil))
+		assert.True(t, matches(t, plan, 3))
+	})
+}
+
+func TestCheckRowPolicy(t *testing.T) {
+	paramtable.Init()
+	initRowPolicyMetaCache(t)
+	paramtable.Get().Save(Params.CommonCfg.AuthorizationEnabled.Key, "true")
+	defer paramtable.Get().Reset(Params.CommonCfg.AuthorizationEnabled.Key)
+
+	schema := newRowPolicyTestSchema()
+	fieldsData := func(tenants ...int64) []*schemapb.FieldData {
+		return []*schemapb.FieldData{
+			testutils.GenerateScalarFieldData(schemapb.DataType_Int64, "pk", len(tenants)),
+			{
+				Type:      schemapb.DataType_Int64,
+				FieldName: "tenant",
+				Field: &schemapb.FieldData_Scalars{
+					Scalars: &schemapb.ScalarField{
+						Data: &schemapb.ScalarField_LongData{LongData: &schemapb.LongArray{Data: tenants}},
+					},
+				},
+			},
+			testutils.GenerateVectorFieldData(schemapb.DataType_FloatVector, "vec", len(tenants), 2),
+		}
+	}
+
+	filter, err := getRowPolicyFilter(GetContext(context.Background(), "alice:123456"), "default", "coll", schema.schemaHelper)
+	assert.NoError(t, err)
+	err = checkRowPolicy(filter, "coll", schema, fieldsData(1, 2, 1), 3)
+	assert.NoError(t, err)
+
+	err = checkRowPolicy(filter, "coll", schema, fieldsData(1, 3), 2)
+	assert.ErrorIs(t, err, merr.ErrPrivilegeNotPermitted)
+}
@@ -22,6 +22,8 @@ type queryNodeCreatorFunc func(ctx context.Context, addr string, nodeID int64) (
 type nodeInfo struct {
 	nodeID  UniqueID
 	address string
+	// the availability zone of the node, empty if it's unknown
+	zone string
 }
 
 func (n nodeInfo) String() string {
@@ -54,6 +54,7 @@ const (
 	ReduceStopForBestKey = "reduce_stop_for_best"
 	IteratorField        = "iterator"
 	GroupByFieldKey      = "group_by_field"
+	GroupByFieldsKey     = "group_by_fields"
 	GroupSizeKey         = "group_size"
 	StrictGroupSize      = "strict_group_size"
 	RankGroupScorer      = "rank_group_scorer"
@@ -66,6 +67,7 @@ const (
 	RoundDecimalKey      = "round_decimal"
 	OffsetKey            = "offset"
 	LimitKey             = "limit"
+	ProfileKey           = "profile"
 
 	InsertTaskName                = "InsertTask"
 	CreateCollectionTaskName      = "CreateCollectionTask"
@@ -296,6 +298,57 @@ func (t *createCollectionTask) validateClusteringKey() error {
 	return nil
 }
 
+func (t *createCollectionTask) validateTTLField() error {
+	var ttlField *schemapb.FieldSchema
+	for _, field := range t.schema.Fields {
+		if _, err := funcutil.GetAttrByKeyFromRepeatedKV(common.TTLFieldKey, field.GetTypeParams()); err != nil {
+			continue
+		}
+		if !typeutil.CreateFieldSchemaHelper(field).IsTTLField() {
+			return merr.WrapErrCollectionIllegalSchema(t.CollectionName,
+				fmt.Sprintf("ttl field must be of int64 type with %s set to true, field name = %s", common.TTLFieldKey, field.Name))
+		}
+		if field.GetIsPrimaryKey() || field.GetNullable() {
+			return merr.WrapErrCollectionIllegalSchema(t.CollectionName,
+				fmt.Sprintf("ttl field cannot be primary key or nullable, field name = %s", field.Name))
+		}
+		if ttlField != nil {
+			return merr.WrapErrCollectionIllegalSchema(t.CollectionName,
+				fmt.Sprintf("there are more than one ttl field, field name = %s, %s", ttlField.Name, field.Name))
+		}
+		ttlField = field
+	}
+
+	if ttlField != nil {
+		log.Info("create collection with ttl field",
+			zap.String("collectionName", t.CollectionName),
+			zap.String("ttlField", ttlField.Name))
+	}
+	return nil
+}
+
+func (t *createCollectionTask) validateFieldStats() error {
+	for _, field := range t.schema.Fields {
+		bfType, ok := typeutil.CreateFieldSchemaHelper(field).GetFieldStatsType()
+		if !ok {
+			continue
+		}
+		if bfType != common.FieldStatsBloom && bfType != common.FieldStatsXor {
+			return merr.WrapErrCollectionIllegalSchema(t.CollectionName,
+				fmt.Sprintf("%s must be %s or %s, field name = %s", common.FieldStatsKey, common.FieldStatsBloom, common.FieldStatsXor, field.Name))
+		}
+		if !typeutil.IsIntegerType(field.GetDataType()) && !typeutil.IsFloatingType(field.GetDataType()) && field.GetDataType() != schemapb.DataType_VarChar {
+			return merr.WrapErrCollectionIllegalSchema(t.CollectionName,
+				fmt.Sprintf("field stats are only supported on integer, floating and varchar fields, field name = %s", field.Name))
+		}
+		if field.GetIsPrimaryKey() {
+			return merr.WrapErrCollectionIllegalSchema(t.CollectionName,
+				fmt.Sprintf("primary key has stats already, field name = %s", field.Name))
+		}
+	}
+	return nil
+}
+
 func (t *createCollectionTask) PreExecute(ctx context.Context) error {
 	t.Base.MsgType = commonpb.MsgType_CreateCollection
 	t.Base.SourceID = paramtable.GetNodeID()
@@ -373,6 +426,16 @@ func (t *createCollectionTask) PreExecute(ctx context.Context) error {
 		return err
 	}
 
+	// validate ttl field
+	if err := t.validateTTLField(); err != nil {
+		return err
+	}
+
+	// validate field stats
+	if err := t.validateFieldStats(); err != nil {
+		return err
+	}
+
 	for _, field := range t.schema.Fields {
 		// validate field name
 		if err := validateFieldName(field.Name); err != nil {
@@ -301,6 +301,8 @@ type deleteRunner struct {
 	collectionID     UniqueID
 	partitionID      UniqueID
 	partitionKeyMode bool
+	// rows not permitted by the row policy of the user are not deleted
+	rowPolicyFilter *planpb.Expr
 
 	// for query
 	msgID int64
@@ -366,6 +368,11 @@ func (dr *deleteRunner) Init(ctx context.Context) error {
 	}
 	dr.vChannels = channelNames
 
+	dr.rowPolicyFilter, err = getRowPolicyFilter(ctx, dr.req.GetDbName(), collName, dr.schema.schemaHelper)
+	if err != nil {
+		return ErrWithLog(log, "Failed to get row policy", err)
+	}
+
 	dr.result = &milvuspb.MutationResult{
 		Status: merr.Success(),
 		IDs: &schemapb.IDs{
@@ -385,6 +392,10 @@ func (dr *deleteRunner) Run(ctx context.Context) error {
 		return merr.WrapErrAsInputError(merr.WrapErrParameterInvalidMsg("delete plan can't be empty or always true : %s", dr.req.GetExpr()))
 	}
 
+	if err := andRowPolicyFilter(plan, dr.rowPolicyFilter); err != nil {
+		return err
+	}
+
 	isSimple, pk, numRow := getPrimaryKeysFromPlan(dr.schema.CollectionSchema, plan)
 	if isSimple {
 		// if could get delete.primaryKeys from delete expr
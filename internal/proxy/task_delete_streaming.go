@@ -7,7 +7,6 @@ import (
 	"go.opentelemetry.io/otel"
 	"go.uber.org/zap"
 
-	"github.com/milvus-io/milvus/internal/distributed/streaming"
 	"github.com/milvus-io/milvus/pkg/log"
 	"github.com/milvus-io/milvus/pkg/streaming/util/message"
 	"github.com/milvus-io/milvus/pkg/util/merr"
@@ -67,8 +66,8 @@ func (dt *deleteTaskByStreamingService) Execute(ctx context.Context) (err error)
 		zap.Int64("taskID", dt.ID()),
 		zap.Duration("prepare duration", dt.tr.RecordSpan()))
 
-	resp := streaming.WAL().AppendMessages(ctx, msgs...)
-	if resp.UnwrapFirstError(); err != nil {
+	resp := appendMessagesToWAL(ctx, msgs...)
+	if err := resp.UnwrapFirstError(); err != nil {
 		log.Warn("append messages to wal failed", zap.Error(err))
 		return err
 	}
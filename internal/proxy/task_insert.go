@@ -12,6 +12,7 @@ import (
 	"github.com/milvus-io/milvus-proto/go-api/v2/milvuspb"
 	"github.com/milvus-io/milvus-proto/go-api/v2/schemapb"
 	"github.com/milvus-io/milvus/internal/allocator"
+	"github.com/milvus-io/milvus/internal/util/function"
 	"github.com/milvus-io/milvus/pkg/log"
 	"github.com/milvus-io/milvus/pkg/metrics"
 	"github.com/milvus-io/milvus/pkg/mq/msgstream"
@@ -185,6 +186,17 @@ func (it *insertTask) PreExecute(ctx context.Context) error {
 		return err
 	}
 
+	if function.HasNonBM25Functions(schema.CollectionSchema.GetFunctions()) {
+		exec, err := schema.GetFunctionExecutor()
+		if err != nil {
+			return err
+		}
+		if err := exec.ProcessInsert(ctx, it.insertMsg); err != nil {
+			log.Warn("execute functions on insert data failed", zap.Error(err))
+			return err
+		}
+	}
+
 	partitionKeyMode, err := isPartitionKeyMode(ctx, it.insertMsg.GetDbName(), collectionName)
 	if err != nil {
 		log.Warn("check partition key mode failed", zap.String("collectionName", collectionName), zap.Error(err))
@@ -222,6 +234,19 @@ func (it *insertTask) PreExecute(ctx context.Context) error {
 		return merr.WrapErrAsInputError(err)
 	}
 
+	rowPolicyFilter, err := getRowPolicyFilter(ctx, it.insertMsg.GetDbName(), collectionName, schema.schemaHelper)
+	if err != nil {
+		log.Warn("get row policy failed", zap.Error(err))
+		return err
+	}
+	if rowPolicyFilter != nil {
+		err = checkRowPolicy(rowPolicyFilter, collectionName, schema, it.insertMsg.GetFieldsData(), int(it.insertMsg.NRows()))
+		if err != nil {
+			log.Warn("Fail to check row policy", zap.Error(err))
+			return err
+		}
+	}
+
 	log.Debug("Proxy Insert PreExecute done")
 
 	return nil
@@ -9,7 +9,6 @@ import (
 
 	"github.com/milvus-io/milvus-proto/go-api/v2/milvuspb"
 	"github.com/milvus-io/milvus-proto/go-api/v2/schemapb"
-	"github.com/milvus-io/milvus/internal/distributed/streaming"
 	"github.com/milvus-io/milvus/pkg/log"
 	"github.com/milvus-io/milvus/pkg/mq/msgstream"
 	"github.com/milvus-io/milvus/pkg/streaming/util/message"
@@ -66,7 +65,7 @@ func (it *insertTaskByStreamingService) Execute(ctx context.Context) error {
 		it.result.Status = merr.Status(err)
 		return err
 	}
-	resp := streaming.WAL().AppendMessages(ctx, msgs...)
+	resp := appendMessagesToWAL(ctx, msgs...)
 	if err := resp.UnwrapFirstError(); err != nil {
 		log.Warn("append messages to wal failed", zap.Error(err))
 		it.result.Status = merr.Status(err)
@@ -10,6 +10,8 @@ import (
 	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
 	"github.com/milvus-io/milvus-proto/go-api/v2/msgpb"
 	"github.com/milvus-io/milvus-proto/go-api/v2/schemapb"
+	"github.com/milvus-io/milvus/internal/allocator"
+	"github.com/milvus-io/milvus/internal/mocks"
 	"github.com/milvus-io/milvus/pkg/mq/msgstream"
 	"github.com/milvus-io/milvus/pkg/util/merr"
 	"github.com/milvus-io/milvus/pkg/util/paramtable"
@@ -308,3 +310,72 @@ func TestMaxInsertSize(t *testing.T) {
 		assert.ErrorIs(t, err, merr.ErrParameterTooLarge)
 	})
 }
+
+func TestInsertTask_RowPolicy(t *testing.T) {
+	paramtable.Init()
+	paramtable.Get().Save(Params.CommonCfg.AuthorizationEnabled.Key, "true")
+	defer paramtable.Get().Reset(Params.CommonCfg.AuthorizationEnabled.Key)
+
+	cacheBak := globalMetaCache
+	defer func() { globalMetaCache = cacheBak }()
+	schema := newRowPolicyTestSchema()
+	cache := NewMockCache(t)
+	cache.EXPECT().GetCollectionSchema(mock.Anything, mock.Anything, "coll").Return(schema, nil)
+	cache.EXPECT().GetPartitionInfo(mock.Anything, mock.Anything, "coll", "").Return(&partitionInfo{name: "_default"}, nil)
+	cache.EXPECT().GetUserRole("alice").Return([]string{"role1"})
+	cache.EXPECT().GetRowPolicies(mock.Anything, "default", "coll").Return([]string{"tenant == 1"})
+	globalMetaCache = cache
+
+	ctx := GetContext(context.Background(), "alice:123456")
+	idAllocator, err := allocator.NewIDAllocator(ctx, mocks.NewMockRootCoordClient(t), paramtable.GetNodeID())
+	assert.NoError(t, err)
+	idAllocator.Close()
+
+	newTask := func(tenants ...int64) *insertTask {
+		numRows := len(tenants)
+		return &insertTask{
+			ctx:         ctx,
+			idAllocator: idAllocator,
+			insertMsg: &BaseInsertTask{
+				InsertRequest: &msgpb.InsertRequest{
+					Base:           &commonpb.MsgBase{MsgType: commonpb.MsgType_Insert},
+					DbName:         "default",
+					CollectionName: "coll",
+					Version:        msgpb.InsertDataVersion_ColumnBased,
+					NumRows:        uint64(numRows),
+					FieldsData: []*schemapb.FieldData{
+						{
+							FieldName: "pk",
+							Type:      schemapb.DataType_Int64,
+							Field: &schemapb.FieldData_Scalars{Scalars: &schemapb.ScalarField{
+								Data: &schemapb.ScalarField_LongData{LongData: &schemapb.LongArray{Data: testutils.GenerateInt64Array(numRows)}},
+							}},
+						},
+						{
+							FieldName: "tenant",
+							Type:      schemapb.DataType_Int64,
+							Field: &schemapb.FieldData_Scalars{Scalars: &schemapb.ScalarField{
+								Data: &schemapb.ScalarField_LongData{LongData: &schemapb.LongArray{Data: tenants}},
+							}},
+						},
+						newFloatVectorFieldData("vec", numRows, 2),
+					},
+				},
+			},
+		}
+	}
+
+	t.Run("permitted", func(t *testing.T) {
+		assert.NoError(t, newTask(1, 1).PreExecute(ctx))
+	})
+
+	t.Run("outside the policy", func(t *testing.T) {
+		err := newTask(1, 2).PreExecute(ctx)
+		assert.ErrorIs(t, err, merr.ErrPrivilegeNotPermitted)
+
+		// the streaming service shares the checks of insert task
+		it := &insertTaskByStreamingService{insertTask: newTask(2)}
+		err = it.PreExecute(ctx)
+		assert.ErrorIs(t, err, merr.ErrPrivilegeNotPermitted)
+	})
+}
@@ -19,6 +19,7 @@ import (
 	"github.com/milvus-io/milvus/internal/proto/planpb"
 	"github.com/milvus-io/milvus/internal/proto/querypb"
 	"github.com/milvus-io/milvus/internal/types"
+	"github.com/milvus-io/milvus/internal/util/aggregate"
 	"github.com/milvus-io/milvus/internal/util/exprutil"
 	"github.com/milvus-io/milvus/internal/util/reduce"
 	typeutil2 "github.com/milvus-io/milvus/internal/util/typeutil"
@@ -60,6 +61,7 @@ type queryTask struct {
 
 	userOutputFields  []string
 	userDynamicFields []string
+	maskedFields      map[int64]string
 
 	resultBuf *typeutil.ConcurrentSet[*internalpb.RetrieveResults]
 
@@ -73,6 +75,9 @@ type queryTask struct {
 	allQueryCnt          int64
 	totalRelatedDataSize int64
 	mustUsePartitionKey  bool
+
+	// profiler is nil if the request is not profiled
+	profiler *queryProfiler
 }
 
 type queryParams struct {
@@ -233,10 +238,109 @@ func createCntPlan(expr string, schemaHelper *typeutil.SchemaHelper, exprTemplat
 	return plan, nil
 }
 
+func hasGroupByFields(queryParamsPair []*commonpb.KeyValuePair) bool {
+	_, err := funcutil.GetAttrByKeyFromRepeatedKV(GroupByFieldsKey, queryParamsPair)
+	return err == nil
+}
+
+// translateAggregation translates the group by fields from query params and the aggregate
+// expressions from output fields, returns nothing if the query is not an aggregation.
+func translateAggregation(outputFields []string, queryParamsPair []*commonpb.KeyValuePair, schemaHelper *typeutil.SchemaHelper) ([]int64, []*internalpb.Aggregate, error) {
+	var groupByFieldIDs []int64
+	groupByFieldNames := typeutil.NewSet[string]()
+	groupByStr, err := funcutil.GetAttrByKeyFromRepeatedKV(GroupByFieldsKey, queryParamsPair)
+	// if group_by_fields is provided
+	if err == nil {
+		for _, name := range strings.Split(groupByStr, ",") {
+			name = strings.TrimSpace(name)
+			field, err := schemaHelper.GetFieldFromName(name)
+			if err != nil {
+				return nil, nil, err
+			}
+			if groupByFieldNames.Contain(name) {
+				return nil, nil, fmt.Errorf("duplicated group by field %s", name)
+			}
+			groupByFieldNames.Insert(name)
+			groupByFieldIDs = append(groupByFieldIDs, field.GetFieldID())
+		}
+	}
+
+	var aggregates []*internalpb.Aggregate
+	plainFields := make([]string, 0, len(outputFields))
+	for _, outputField := range outputFields {
+		op, fieldName, ok, err := aggregate.ParseOutputField(outputField)
+		if err != nil {
+			return nil, nil, err
+		}
+		if !ok {
+			plainFields = append(plainFields, strings.TrimSpace(outputField))
+			continue
+		}
+		var fieldID int64
+		if fieldName != "" {
+			field, err := schemaHelper.GetFieldFromName(fieldName)
+			if err != nil {
+				return nil, nil, err
+			}
+			fieldID = field.GetFieldID()
+		}
+		aggregates = append(aggregates, &internalpb.Aggregate{Op: op, FieldId: fieldID})
+	}
+
+	if len(groupByFieldIDs) == 0 && len(aggregates) == 0 {
+		return nil, nil, nil
+	}
+	for _, name := range plainFields {
+		if !groupByFieldNames.Contain(name) {
+			return nil, nil, fmt.Errorf("output field %s is neither a group by field nor an aggregate", name)
+		}
+	}
+	return groupByFieldIDs, aggregates, nil
+}
+
+func (t *queryTask) createAggregationPlan(groupByFieldIDs []int64, aggregates []*internalpb.Aggregate) error {
+	schema := t.schema
+
+	agg, err := aggregate.NewAggregator(groupByFieldIDs, aggregates, schema.CollectionSchema)
+	if err != nil {
+		return merr.WrapErrAsInputError(merr.WrapErrParameterInvalidMsg("invalid aggregation: %v", err))
+	}
+	if t.plan == nil {
+		t.plan, err = planparserv2.CreateRetrievePlan(schema.schemaHelper, t.request.Expr, t.request.GetExprTemplateValues())
+		if err != nil {
+			return merr.WrapErrAsInputError(merr.WrapErrParameterInvalidMsg("failed to create query plan: %v", err))
+		}
+	}
+	t.RetrieveRequest.GroupByFieldsId = groupByFieldIDs
+	t.RetrieveRequest.Aggregates = aggregates
+	t.userOutputFields = agg.OutputNames()
+
+	// only the group by fields and the aggregated fields are retrieved from segments
+	outputFieldIDs := make([]int64, 0, len(groupByFieldIDs)+len(aggregates)+2)
+	outputFieldIDs = append(outputFieldIDs, groupByFieldIDs...)
+	for _, a := range aggregates {
+		if a.GetFieldId() != 0 && !lo.Contains(outputFieldIDs, a.GetFieldId()) {
+			outputFieldIDs = append(outputFieldIDs, a.GetFieldId())
+		}
+	}
+	pkField, err := typeutil.GetPrimaryFieldSchema(schema.CollectionSchema)
+	if err != nil {
+		return err
+	}
+	if !lo.Contains(outputFieldIDs, pkField.GetFieldID()) {
+		outputFieldIDs = append(outputFieldIDs, pkField.GetFieldID())
+	}
+	outputFieldIDs = append(outputFieldIDs, common.TimeStampField)
+	t.RetrieveRequest.OutputFieldsId = outputFieldIDs
+	t.plan.OutputFieldIds = outputFieldIDs
+	return nil
+}
+
 func (t *queryTask) createPlan(ctx context.Context) error {
 	schema := t.schema
 
-	cntMatch := matchCountRule(t.request.GetOutputFields())
+	// count(*) with group by fields is handled as an aggregation
+	cntMatch := matchCountRule(t.request.GetOutputFields()) && !hasGroupByFields(t.request.GetQueryParams())
 	if cntMatch {
 		var err error
 		t.plan, err = createCntPlan(t.request.GetExpr(), schema.schemaHelper, t.request.GetExprTemplateValues())
@@ -244,7 +348,21 @@ func (t *queryTask) createPlan(ctx context.Context) error {
 		return err
 	}
 
-	var err error
+	groupByFieldIDs, aggregates, err := translateAggregation(t.request.GetOutputFields(), t.request.GetQueryParams(), schema.schemaHelper)
+	if err != nil {
+		return merr.WrapErrAsInputError(merr.WrapErrParameterInvalidMsg("invalid aggregation: %v", err))
+	}
+	if len(groupByFieldIDs) > 0 || len(aggregates) > 0 {
+		if err := checkMaskedFieldIDs(t.maskedFields, groupByFieldIDs...); err != nil {
+			return err
+		}
+		aggregateFieldIDs := lo.Map(aggregates, func(a *internalpb.Aggregate, _ int) int64 { return a.GetFieldId() })
+		if err := checkMaskedFieldIDs(t.maskedFields, aggregateFieldIDs...); err != nil {
+			return err
+		}
+		return t.createAggregationPlan(groupByFieldIDs, aggregates)
+	}
+
 	if t.plan == nil {
 		t.plan, err = planparserv2.CreateRetrievePlan(schema.schemaHelper, t.request.Expr, t.request.GetExprTemplateValues())
 		if err != nil {
@@ -256,6 +374,8 @@ func (t *queryTask) createPlan(ctx context.Context) error {
 	if err != nil {
 		return err
 	}
+	t.request.OutputFields = removeMaskedOutputFields(t.maskedFields, t.request.GetOutputFields())
+	t.userOutputFields = removeMaskedOutputFields(t.maskedFields, t.userOutputFields)
 
 	outputFieldIDs, err := translateToOutputFieldIDs(t.request.GetOutputFields(), schema.CollectionSchema)
 	if err != nil {
@@ -301,8 +421,10 @@ func (t *queryTask) CanSkipAllocTimestamp() bool {
 }
 
 func (t *queryTask) PreExecute(ctx context.Context) error {
+	t.profiler.start()
 	t.Base.MsgType = commonpb.MsgType_Retrieve
 	t.Base.SourceID = paramtable.GetNodeID()
+	t.RetrieveRequest.Profile = t.profiler != nil
 
 	collectionName := t.request.CollectionName
 	t.collectionName = collectionName
@@ -379,6 +501,18 @@ func (t *queryTask) PreExecute(ctx context.Context) error {
 	}
 	t.schema = schema
 
+	t.maskedFields, err = getMaskedFields(ctx, t.request.GetDbName(), collectionName, schema)
+	if err != nil {
+		log.Warn("get masked fields failed", zap.Error(err))
+		return err
+	}
+	if err := checkMaskedFieldsInExpr(schema, t.maskedFields, t.request.GetExpr(), t.request.GetExprTemplateValues()); err != nil {
+		return err
+	}
+	if err := checkMaskedOutputFields(t.maskedFields, t.request.GetOutputFields()); err != nil {
+		return err
+	}
+
 	if t.ids != nil {
 		pkField := ""
 		for _, field := range schema.Fields {
@@ -389,15 +523,37 @@ func (t *queryTask) PreExecute(ctx context.Context) error {
 		t.request.Expr = IDs2Expr(pkField, t.ids)
 	}
 
+	t.profiler.recordPhase(profilePhaseParse)
+
 	if err := t.createPlan(ctx); err != nil {
 		return err
 	}
+	isAggregation := aggregate.IsAggregation(t.RetrieveRequest)
+	if isAggregation {
+		if t.queryParams.isIterator {
+			return merr.WrapErrAsInputError(merr.WrapErrParameterInvalidMsg("aggregation is not supported by query iterator"))
+		}
+		// all the matched rows are aggregated, offset and limit are applied on groups by the proxy reducer
+		t.RetrieveRequest.Limit = typeutil.Unlimited
+	}
 	t.plan.Node.(*planpb.PlanNode_Query).Query.Limit = t.RetrieveRequest.Limit
 
-	if planparserv2.IsAlwaysTruePlan(t.plan) && t.RetrieveRequest.Limit == typeutil.Unlimited {
+	if planparserv2.IsAlwaysTruePlan(t.plan) && t.RetrieveRequest.Limit == typeutil.Unlimited && !isAggregation {
 		return merr.WrapErrAsInputError(merr.WrapErrParameterInvalidMsg("empty expression should be used with limit"))
 	}
 
+	// the plan of requery is built by the proxy, the row policy is applied by the caller
+	if !t.reQuery {
+		rowPolicyFilter, err := getRowPolicyFilter(ctx, t.request.GetDbName(), collectionName, schema.schemaHelper)
+		if err != nil {
+			log.Warn("get row policy failed", zap.Error(err))
+			return err
+		}
+		if err := andRowPolicyFilter(t.plan, rowPolicyFilter); err != nil {
+			return err
+		}
+	}
+
 	// convert partition names only when requery is false
 	if !t.reQuery {
 		partitionNames := t.request.GetPartitionNames()
@@ -430,6 +586,7 @@ func (t *queryTask) PreExecute(ctx context.Context) error {
 	if err != nil {
 		return err
 	}
+	t.profiler.recordPhase(profilePhasePlan)
 
 	// Set username for this query request,
 	if username, _ := GetCurUserFromContext(ctx); username != "" {
@@ -488,17 +645,20 @@ func (t *queryTask) Execute(ctx context.Context) error {
 		zap.String("requestType", "query"))
 
 	t.resultBuf = typeutil.NewConcurrentSet[*internalpb.RetrieveResults]()
+	t.profiler.start()
 	err := t.lb.Execute(ctx, CollectionWorkLoad{
 		db:             t.request.GetDbName(),
 		collectionID:   t.CollectionID,
 		collectionName: t.collectionName,
 		nq:             1,
 		exec:           t.queryShard,
+		hedgeable:      true,
 	})
 	if err != nil {
 		log.Warn("fail to execute query", zap.Error(err))
 		return errors.Wrap(err, "failed to query")
 	}
+	t.profiler.recordPhase(profilePhaseShardRouting)
 
 	log.Debug("Query Execute done.")
 	return nil
@@ -529,6 +689,7 @@ func (t *queryTask) PostExecute(ctx context.Context) error {
 			toReduceResults = append(toReduceResults, res)
 			t.allQueryCnt += res.GetAllRetrieveCount()
 			t.totalRelatedDataSize += res.GetCostAggregation().GetTotalRelatedDataSize()
+			t.profiler.addDelegatorProfiles(res.GetDelegatorProfiles())
 			log.Debug("proxy receives one query result", zap.Int64("sourceID", res.GetBase().GetSourceID()))
 			return true
 		})
@@ -536,6 +697,7 @@ func (t *queryTask) PostExecute(ctx context.Context) error {
 
 	metrics.ProxyDecodeResultLatency.WithLabelValues(strconv.FormatInt(paramtable.GetNodeID(), 10), metrics.QueryLabel).Observe(0.0)
 	tr.CtxRecord(ctx, "reduceResultStart")
+	t.profiler.start()
 
 	reducer := createMilvusReducer(ctx, t.queryParams, t.RetrieveRequest, t.schema.CollectionSchema, t.plan, t.collectionName)
 
@@ -545,6 +707,7 @@ func (t *queryTask) PostExecute(ctx context.Context) error {
 		return err
 	}
 	t.result.OutputFields = t.userOutputFields
+	t.profiler.recordPhase(profilePhaseReduce)
 	metrics.ProxyReduceResultLatency.WithLabelValues(strconv.FormatInt(paramtable.GetNodeID(), 10), metrics.QueryLabel).Observe(float64(tr.RecordSpan().Milliseconds()))
 
 	if t.queryParams.isIterator && t.request.GetGuaranteeTimestamp() == 0 {
@@ -584,6 +747,9 @@ func (t *queryTask) queryShard(ctx context.Context, nodeID int64, qn types.Query
 		zap.String("channel", channel))
 
 	result, err := qn.Query(ctx, req)
+	if shardResultClaimed(ctx) {
+		return errShardResultClaimed
+	}
 	if err != nil {
 		log.Warn("QueryNode query return error", zap.Error(err))
 		globalMetaCache.DeprecateShardCache(t.request.GetDbName(), t.collectionName)
@@ -599,6 +765,9 @@ func (t *queryTask) queryShard(ctx context.Context, nodeID int64, qn types.Query
 		return errors.Wrapf(merr.Error(result.GetStatus()), "fail to Query on QueryNode %d", nodeID)
 	}
 
+	if !claimShardResult(ctx) {
+		return errShardResultClaimed
+	}
 	log.Debug("get query result")
 	t.resultBuf.Insert(result)
 	t.lb.UpdateCostMetrics(nodeID, result.CostAggregation)
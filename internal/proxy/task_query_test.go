@@ -32,6 +32,7 @@ import (
 	"github.com/milvus-io/milvus-proto/go-api/v2/milvuspb"
 	"github.com/milvus-io/milvus-proto/go-api/v2/schemapb"
 	"github.com/milvus-io/milvus/internal/mocks"
+	"github.com/milvus-io/milvus/internal/parser/planparserv2"
 	"github.com/milvus-io/milvus/internal/proto/internalpb"
 	"github.com/milvus-io/milvus/internal/proto/querypb"
 	"github.com/milvus-io/milvus/internal/util/reduce"
@@ -1125,6 +1126,122 @@ func Test_queryTask_createPlan(t *testing.T) {
 	})
 }
 
+func Test_translateAggregation(t *testing.T) {
+	schema := &schemapb.CollectionSchema{
+		Fields: []*schemapb.FieldSchema{
+			{FieldID: 100, Name: "pk", IsPrimaryKey: true, DataType: schemapb.DataType_Int64},
+			{FieldID: 101, Name: "tag", DataType: schemapb.DataType_VarChar},
+			{FieldID: 102, Name: "price", DataType: schemapb.DataType_Double},
+		},
+	}
+	schemaHelper, err := typeutil.CreateSchemaHelper(schema)
+	require.NoError(t, err)
+	groupBy := func(fields string) []*commonpb.KeyValuePair {
+		return []*commonpb.KeyValuePair{{Key: GroupByFieldsKey, Value: fields}}
+	}
+
+	t.Run("not aggregation", func(t *testing.T) {
+		groupByFieldIDs, aggregates, err := translateAggregation([]string{"tag", "price"}, nil, schemaHelper)
+		assert.NoError(t, err)
+		assert.Empty(t, groupByFieldIDs)
+		assert.Empty(t, aggregates)
+	})
+
+	t.Run("group by", func(t *testing.T) {
+		groupByFieldIDs, aggregates, err := translateAggregation(
+			[]string{"tag", "count(*)", "sum(price)", "count(distinct price)"}, groupBy(" tag "), schemaHelper)
+		assert.NoError(t, err)
+		assert.Equal(t, []int64{101}, groupByFieldIDs)
+		assert.Equal(t, 3, len(aggregates))
+		assert.Equal(t, internalpb.AggregateOp_Count, aggregates[0].GetOp())
+		assert.Equal(t, int64(0), aggregates[0].GetFieldId())
+		assert.Equal(t, internalpb.AggregateOp_Sum, aggregates[1].GetOp())
+		assert.Equal(t, int64(102), aggregates[1].GetFieldId())
+		assert.Equal(t, internalpb.AggregateOp_CountDistinct, aggregates[2].GetOp())
+	})
+
+	t.Run("aggregate without group by", func(t *testing.T) {
+		groupByFieldIDs, aggregates, err := translateAggregation([]string{"max(price)", "avg(price)"}, nil, schemaHelper)
+		assert.NoError(t, err)
+		assert.Empty(t, groupByFieldIDs)
+		assert.Equal(t, 2, len(aggregates))
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		_, _, err := translateAggregation([]string{"count(*)"}, groupBy("tag,tag"), schemaHelper)
+		assert.Error(t, err)
+
+		_, _, err = translateAggregation([]string{"count(*)"}, groupBy("not_exist"), schemaHelper)
+		assert.Error(t, err)
+
+		_, _, err = translateAggregation([]string{"sum(not_exist)"}, nil, schemaHelper)
+		assert.Error(t, err)
+
+		_, _, err = translateAggregation([]string{"sum(*)"}, nil, schemaHelper)
+		assert.Error(t, err)
+
+		_, _, err = translateAggregation([]string{"price", "count(*)"}, groupBy("tag"), schemaHelper)
+		assert.Error(t, err)
+	})
+}
+
+func Test_queryTask_createAggregationPlan(t *testing.T) {
+	collSchema := &schemapb.CollectionSchema{
+		Fields: []*schemapb.FieldSchema{
+			{FieldID: 100, Name: "pk", IsPrimaryKey: true, DataType: schemapb.DataType_Int64},
+			{FieldID: 101, Name: "tag", DataType: schemapb.DataType_VarChar},
+			{FieldID: 102, Name: "price", DataType: schemapb.DataType_Double},
+		},
+	}
+
+	t.Run("count with group by", func(t *testing.T) {
+		tsk := &queryTask{
+			RetrieveRequest: &internalpb.RetrieveRequest{},
+			request: &milvuspb.QueryRequest{
+				OutputFields: []string{"count(*)"},
+				QueryParams:  []*commonpb.KeyValuePair{{Key: GroupByFieldsKey, Value: "tag"}},
+				Expr:         "price > 1",
+			},
+			schema: newSchemaInfo(collSchema),
+		}
+		err := tsk.createPlan(context.TODO())
+		assert.NoError(t, err)
+		assert.False(t, tsk.plan.GetQuery().GetIsCount())
+		assert.NotNil(t, tsk.plan.GetQuery().GetPredicates())
+		assert.Equal(t, []string{"tag", "count(*)"}, tsk.userOutputFields)
+		assert.Equal(t, []int64{101}, tsk.RetrieveRequest.GetGroupByFieldsId())
+		assert.Equal(t, []int64{101, 100, common.TimeStampField}, tsk.RetrieveRequest.GetOutputFieldsId())
+		assert.Equal(t, tsk.RetrieveRequest.GetOutputFieldsId(), tsk.plan.GetOutputFieldIds())
+	})
+
+	t.Run("aggregate without expression", func(t *testing.T) {
+		tsk := &queryTask{
+			RetrieveRequest: &internalpb.RetrieveRequest{},
+			request: &milvuspb.QueryRequest{
+				OutputFields: []string{"min(price)", "max(price)"},
+			},
+			schema: newSchemaInfo(collSchema),
+		}
+		err := tsk.createPlan(context.TODO())
+		assert.NoError(t, err)
+		assert.True(t, planparserv2.IsAlwaysTruePlan(tsk.plan))
+		assert.Equal(t, []string{"min(price)", "max(price)"}, tsk.userOutputFields)
+		assert.Equal(t, []int64{102, 100, common.TimeStampField}, tsk.RetrieveRequest.GetOutputFieldsId())
+	})
+
+	t.Run("unsupported aggregate", func(t *testing.T) {
+		tsk := &queryTask{
+			RetrieveRequest: &internalpb.RetrieveRequest{},
+			request: &milvuspb.QueryRequest{
+				OutputFields: []string{"sum(tag)"},
+			},
+			schema: newSchemaInfo(collSchema),
+		}
+		err := tsk.createPlan(context.TODO())
+		assert.Error(t, err)
+	})
+}
+
 func TestQueryTask_IDs2Expr(t *testing.T) {
 	fieldName := "pk"
 	intIDs := &schemapb.IDs{
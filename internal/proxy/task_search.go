@@ -22,6 +22,7 @@ import (
 	"github.com/milvus-io/milvus/internal/proto/querypb"
 	"github.com/milvus-io/milvus/internal/types"
 	"github.com/milvus-io/milvus/internal/util/exprutil"
+	"github.com/milvus-io/milvus/internal/util/function"
 	"github.com/milvus-io/milvus/internal/util/reduce"
 	"github.com/milvus-io/milvus/pkg/log"
 	"github.com/milvus-io/milvus/pkg/metrics"
@@ -82,10 +83,17 @@ type searchTask struct {
 	relatedDataSize int64
 
 	reScorers   []reScorer
+	reranker    reranker
 	rankParams  *rankParams
 	groupScorer func(group *Group) error
 
 	isIterator bool
+
+	// rowPolicyFilter is anded into the plan of each search, nil if the user is not restricted
+	rowPolicyFilter *planpb.Expr
+
+	// profiler is nil if the request is not profiled
+	profiler *queryProfiler
 }
 
 func (t *searchTask) CanSkipAllocTimestamp() bool {
@@ -119,7 +127,9 @@ func (t *searchTask) CanSkipAllocTimestamp() bool {
 func (t *searchTask) PreExecute(ctx context.Context) error {
 	ctx, sp := otel.Tracer(typeutil.ProxyRole).Start(ctx, "Proxy-Search-PreExecute")
 	defer sp.End()
+	t.profiler.start()
 	t.SearchRequest.IsAdvanced = len(t.request.GetSubReqs()) > 0
+	t.SearchRequest.Profile = t.profiler != nil
 	t.Base.MsgType = commonpb.MsgType_Search
 	t.Base.SourceID = paramtable.GetNodeID()
 
@@ -161,11 +171,22 @@ func (t *searchTask) PreExecute(ctx context.Context) error {
 		}
 	}
 
+	maskedFields, err := getMaskedFields(ctx, t.request.GetDbName(), collectionName, t.schema)
+	if err != nil {
+		log.Warn("get masked fields failed", zap.Error(err))
+		return err
+	}
+	if err := t.checkMaskedFields(maskedFields); err != nil {
+		return err
+	}
+
 	t.request.OutputFields, t.userOutputFields, t.userDynamicFields, err = translateOutputFields(t.request.OutputFields, t.schema, false)
 	if err != nil {
 		log.Warn("translate output fields failed", zap.Error(err))
 		return err
 	}
+	t.request.OutputFields = removeMaskedOutputFields(maskedFields, t.request.GetOutputFields())
+	t.userOutputFields = removeMaskedOutputFields(maskedFields, t.userOutputFields)
 	log.Debug("translate output fields",
 		zap.Strings("output fields", t.request.GetOutputFields()))
 
@@ -218,8 +239,23 @@ func (t *searchTask) PreExecute(ctx context.Context) error {
 		return lo.Contains(t.request.GetOutputFields(), field.GetName()) && typeutil.IsVectorType(field.GetDataType())
 	})
 
+	t.rowPolicyFilter, err = getRowPolicyFilter(ctx, t.request.GetDbName(), collectionName, t.schema.schemaHelper)
+	if err != nil {
+		log.Warn("get row policy failed", zap.Error(err))
+		return err
+	}
+
+	t.profiler.recordPhase(profilePhaseParse)
 	if t.SearchRequest.GetIsAdvanced() {
-		t.requery = len(t.request.OutputFields) > 0
+		t.reranker, err = newReranker(t.schema.CollectionSchema, t.SearchRequest.GetNq(), t.request.GetSearchParams())
+		if err != nil {
+			log.Info("generate reranker failed", zap.Any("params", t.request.GetSearchParams()), zap.Error(err))
+			return err
+		}
+		if t.reranker != nil && t.rankParams.GetGroupByFieldId() > 0 {
+			return merr.WrapErrParameterInvalidMsg("reranker is not supported with group by")
+		}
+		t.requery = len(t.request.OutputFields) > 0 || t.reranker != nil
 		err = t.initAdvancedSearchRequest(ctx)
 	} else {
 		t.requery = len(vectorOutputFields) > 0
@@ -229,6 +265,18 @@ func (t *searchTask) PreExecute(ctx context.Context) error {
 		log.Debug("init search request failed", zap.Error(err))
 		return err
 	}
+	t.profiler.recordPhase(profilePhasePlan)
+
+	if function.HasNonBM25Functions(t.schema.CollectionSchema.GetFunctions()) {
+		exec, err := t.schema.GetFunctionExecutor()
+		if err != nil {
+			return err
+		}
+		if err := exec.ProcessSearch(ctx, t.SearchRequest); err != nil {
+			log.Warn("execute functions on search request failed", zap.Error(err))
+			return err
+		}
+	}
 
 	collectionInfo, err2 := globalMetaCache.GetCollectionInfo(ctx, t.request.GetDbName(), collectionName, t.CollectionID)
 	if err2 != nil {
@@ -350,6 +398,38 @@ func setQueryInfoIfMvEnable(queryInfo *planpb.QueryInfo, t *searchTask, plan *pl
 	return nil
 }
 
+// checkMaskedFields refuses the search filtering or grouping by the masked fields, or outputting them explicitly.
+func (t *searchTask) checkMaskedFields(maskedFields map[int64]string) error {
+	if len(maskedFields) == 0 {
+		return nil
+	}
+	if err := checkMaskedOutputFields(maskedFields, t.request.GetOutputFields()); err != nil {
+		return err
+	}
+	if err := checkMaskedFieldsInExpr(t.schema, maskedFields, t.request.GetDsl(), t.request.GetExprTemplateValues()); err != nil {
+		return err
+	}
+	searchParams := [][]*commonpb.KeyValuePair{t.request.GetSearchParams()}
+	for _, subReq := range t.request.GetSubReqs() {
+		if err := checkMaskedFieldsInExpr(t.schema, maskedFields, subReq.GetDsl(), subReq.GetExprTemplateValues()); err != nil {
+			return err
+		}
+		searchParams = append(searchParams, subReq.GetSearchParams())
+	}
+	for _, params := range searchParams {
+		groupByFieldName, err := funcutil.GetAttrByKeyFromRepeatedKV(GroupByFieldKey, params)
+		if err != nil {
+			continue
+		}
+		if field, err := t.schema.schemaHelper.GetFieldFromName(groupByFieldName); err == nil {
+			if err := checkMaskedFieldIDs(maskedFields, field.GetFieldID()); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
 func (t *searchTask) initAdvancedSearchRequest(ctx context.Context) error {
 	ctx, sp := otel.Tracer(typeutil.ProxyRole).Start(ctx, "init advanced search request")
 	defer sp.End()
@@ -532,6 +612,9 @@ func (t *searchTask) tryGeneratePlan(params []*commonpb.KeyValuePair, dsl string
 			zap.String("anns field", annsFieldName), zap.Any("query info", searchInfo.planInfo))
 		return nil, nil, 0, false, merr.WrapErrParameterInvalidMsg("failed to create query plan: %v", planErr)
 	}
+	if err := andRowPolicyFilter(plan, t.rowPolicyFilter); err != nil {
+		return nil, nil, 0, false, err
+	}
 	log.Debug("create query plan",
 		zap.String("dsl", t.request.Dsl), // may be very large if large term passed.
 		zap.String("anns field", annsFieldName), zap.Any("query info", searchInfo.planInfo))
@@ -571,17 +654,20 @@ func (t *searchTask) Execute(ctx context.Context) error {
 	tr := timerecord.NewTimeRecorder(fmt.Sprintf("proxy execute search %d", t.ID()))
 	defer tr.CtxElapse(ctx, "done")
 
+	t.profiler.start()
 	err := t.lb.Execute(ctx, CollectionWorkLoad{
 		db:             t.request.GetDbName(),
 		collectionID:   t.SearchRequest.CollectionID,
 		collectionName: t.collectionName,
 		nq:             t.Nq,
 		exec:           t.searchShard,
+		hedgeable:      true,
 	})
 	if err != nil {
 		log.Warn("search execute failed", zap.Error(err))
 		return errors.Wrap(err, "failed to search")
 	}
+	t.profiler.recordPhase(profilePhaseShardRouting)
 
 	log.Debug("Search Execute done.",
 		zap.Int64("collection", t.GetCollectionID()),
@@ -644,6 +730,7 @@ func (t *searchTask) PostExecute(ctx context.Context) error {
 		log.Warn("failed to collect search results", zap.Error(err))
 		return err
 	}
+	t.profiler.start()
 
 	t.queryChannelsTs = make(map[string]uint64)
 	t.relatedDataSize = 0
@@ -660,6 +747,7 @@ func (t *searchTask) PostExecute(ctx context.Context) error {
 		for ch, ts := range r.GetChannelsMvcc() {
 			t.queryChannelsTs[ch] = ts
 		}
+		t.profiler.addDelegatorProfiles(r.GetDelegatorProfiles())
 	}
 
 	primaryFieldSchema, err := t.schema.GetPkField()
@@ -707,8 +795,16 @@ func (t *searchTask) PostExecute(ctx context.Context) error {
 			t.reScorers[index].reScore(result)
 			multipleMilvusResults[index] = result
 		}
+		fusionParams := t.rankParams
+		if t.reranker != nil {
+			// the reranker reorders the top offset+limit candidates of fusion, the offset is applied after rerank.
+			candidateParams := *t.rankParams
+			candidateParams.limit += candidateParams.offset
+			candidateParams.offset = 0
+			fusionParams = &candidateParams
+		}
 		t.result, err = rankSearchResultData(ctx, t.SearchRequest.GetNq(),
-			t.rankParams,
+			fusionParams,
 			primaryFieldSchema.GetDataType(),
 			multipleMilvusResults,
 			t.SearchRequest.GetGroupByFieldId(),
@@ -726,19 +822,11 @@ func (t *searchTask) PostExecute(ctx context.Context) error {
 	}
 
 	// reduce done, get final result
-	limit := t.SearchRequest.GetTopk() - t.SearchRequest.GetOffset()
-	resultSizeInsufficient := false
-	for _, topk := range t.result.Results.Topks {
-		if topk < limit {
-			resultSizeInsufficient = true
-			break
-		}
-	}
-	t.resultSizeInsufficient = resultSizeInsufficient
 	t.isTopkReduce = isTopkReduce
 	t.isRecallEvaluation = isRecallEvaluation
 	t.result.CollectionName = t.collectionName
 	t.fillInFieldInfo()
+	t.profiler.recordPhase(profilePhaseReduce)
 
 	if t.requery {
 		err = t.Requery(sp)
@@ -746,7 +834,27 @@ func (t *searchTask) PostExecute(ctx context.Context) error {
 			log.Warn("failed to requery", zap.Error(err))
 			return err
 		}
+		t.profiler.recordPhase(profilePhaseRequery)
 	}
+	if t.reranker != nil {
+		if err := rerankSearchResultData(ctx, t.reranker, t.result.GetResults(), t.rankParams.GetOffset(), t.rankParams.GetLimit()); err != nil {
+			log.Warn("failed to rerank", zap.String("reranker", t.reranker.name()), zap.Error(err))
+			return err
+		}
+		// drop the fields only fetched for reranker.
+		t.result.Results.FieldsData = lo.Filter(t.result.Results.FieldsData, func(fieldData *schemapb.FieldData, i int) bool {
+			return lo.Contains(t.request.GetOutputFields(), fieldData.GetFieldName())
+		})
+	}
+	limit := t.SearchRequest.GetTopk() - t.SearchRequest.GetOffset()
+	resultSizeInsufficient := false
+	for _, topk := range t.result.Results.Topks {
+		if topk < limit {
+			resultSizeInsufficient = true
+			break
+		}
+	}
+	t.resultSizeInsufficient = resultSizeInsufficient
 	t.result.Results.OutputFields = t.userOutputFields
 	t.result.CollectionName = t.request.GetCollectionName()
 	if t.isIterator && t.request.GetGuaranteeTimestamp() == 0 {
@@ -781,6 +889,9 @@ func (t *searchTask) searchShard(ctx context.Context, nodeID int64, qn types.Que
 	var err error
 
 	result, err = qn.Search(ctx, req)
+	if shardResultClaimed(ctx) {
+		return errShardResultClaimed
+	}
 	if err != nil {
 		log.Warn("QueryNode search return error", zap.Error(err))
 		globalMetaCache.DeprecateShardCache(t.request.GetDbName(), t.collectionName)
@@ -796,6 +907,9 @@ func (t *searchTask) searchShard(ctx context.Context, nodeID int64, qn types.Que
 			zap.String("reason", result.GetStatus().GetReason()))
 		return errors.Wrapf(merr.Error(result.GetStatus()), "fail to search on QueryNode %d", nodeID)
 	}
+	if !claimShardResult(ctx) {
+		return errShardResultClaimed
+	}
 	if t.resultBuf != nil {
 		t.resultBuf.Insert(result)
 	}
@@ -837,7 +951,7 @@ func (t *searchTask) Requery(span trace.Span) error {
 		ConsistencyLevel:      t.SearchRequest.GetConsistencyLevel(),
 		NotReturnAllMeta:      t.request.GetNotReturnAllMeta(),
 		Expr:                  "",
-		OutputFields:          t.request.GetOutputFields(),
+		OutputFields:          t.requeryOutputFields(),
 		PartitionNames:        t.request.GetPartitionNames(),
 		UseDefaultConsistency: false,
 		GuaranteeTimestamp:    t.SearchRequest.GuaranteeTimestamp,
@@ -915,12 +1029,21 @@ func (t *searchTask) Requery(span trace.Span) error {
 		typeutil.AppendFieldData(t.result.Results.FieldsData, queryResult.GetFieldsData(), int64(offsets[id]))
 	}
 
+	outputFields := t.requeryOutputFields()
 	t.result.Results.FieldsData = lo.Filter(t.result.Results.FieldsData, func(fieldData *schemapb.FieldData, i int) bool {
-		return lo.Contains(t.request.GetOutputFields(), fieldData.GetFieldName())
+		return lo.Contains(outputFields, fieldData.GetFieldName())
 	})
 	return nil
 }
 
+// requeryOutputFields returns the output fields of requery, which includes the input fields of reranker.
+func (t *searchTask) requeryOutputFields() []string {
+	if t.reranker == nil {
+		return t.request.GetOutputFields()
+	}
+	return lo.Union(t.request.GetOutputFields(), t.reranker.inputFields())
+}
+
 func (t *searchTask) fillInFieldInfo() {
 	if len(t.request.OutputFields) != 0 && len(t.result.Results.FieldsData) != 0 {
 		for i, name := range t.request.OutputFields {
@@ -265,6 +265,7 @@ func (g *getStatisticsTask) getStatisticsFromQueryNode(ctx context.Context) erro
 		collectionName: g.collectionName,
 		nq:             1,
 		exec:           g.getStatisticsShard,
+		hedgeable:      true,
 	})
 	if err != nil {
 		return errors.Wrap(err, "failed to statistic")
@@ -282,6 +283,9 @@ func (g *getStatisticsTask) getStatisticsShard(ctx context.Context, nodeID int64
 		Scope:       querypb.DataScope_All,
 	}
 	result, err := qn.GetStatistics(ctx, req)
+	if shardResultClaimed(ctx) {
+		return errShardResultClaimed
+	}
 	if err != nil {
 		log.Warn("QueryNode statistic return error",
 			zap.Int64("nodeID", nodeID),
@@ -303,6 +307,9 @@ func (g *getStatisticsTask) getStatisticsShard(ctx context.Context, nodeID int64
 			zap.String("reason", result.GetStatus().GetReason()))
 		return errors.Wrapf(merr.Error(result.GetStatus()), "fail to get statistic on QueryNode ID=%d", nodeID)
 	}
+	if !claimShardResult(ctx) {
+		return errShardResultClaimed
+	}
 	g.resultBuf.Insert(result)
 
 	return nil
@@ -4010,6 +4010,148 @@ func TestClusteringKey(t *testing.T) {
 	})
 }
 
+func TestTTLField(t *testing.T) {
+	ctx := context.Background()
+	collectionName := "TestTTLField" + funcutil.GenRandomStr()
+
+	newTask := func(fields ...*schemapb.FieldSchema) *createCollectionTask {
+		fieldName2Type := make(map[string]schemapb.DataType)
+		fieldName2Type["int64_field"] = schemapb.DataType_Int64
+		schema := constructCollectionSchemaByDataType(collectionName, fieldName2Type, "int64_field", false)
+		schema.Fields = append(schema.Fields, &schemapb.FieldSchema{
+			Name:     "fvec_field",
+			DataType: schemapb.DataType_FloatVector,
+			TypeParams: []*commonpb.KeyValuePair{
+				{
+					Key:   common.DimKey,
+					Value: strconv.Itoa(testVecDim),
+				},
+			},
+		})
+		schema.Fields = append(schema.Fields, fields...)
+		marshaledSchema, err := proto.Marshal(schema)
+		assert.NoError(t, err)
+
+		return &createCollectionTask{
+			Condition: NewTaskCondition(ctx),
+			CreateCollectionRequest: &milvuspb.CreateCollectionRequest{
+				Base: &commonpb.MsgBase{
+					MsgID:     UniqueID(uniquegenerator.GetUniqueIntGeneratorIns().GetInt()),
+					Timestamp: Timestamp(time.Now().UnixNano()),
+				},
+				CollectionName: collectionName,
+				Schema:         marshaledSchema,
+				ShardsNum:      common.DefaultShardsNum,
+			},
+			ctx: ctx,
+		}
+	}
+	ttlField := func(name string, dataType schemapb.DataType) *schemapb.FieldSchema {
+		return &schemapb.FieldSchema{
+			Name:       name,
+			DataType:   dataType,
+			TypeParams: []*commonpb.KeyValuePair{{Key: common.TTLFieldKey, Value: "true"}},
+		}
+	}
+
+	t.Run("normal", func(t *testing.T) {
+		task := newTask(ttlField("expire_at", schemapb.DataType_Int64))
+		err := task.PreExecute(ctx)
+		assert.NoError(t, err)
+	})
+
+	t.Run("not int64", func(t *testing.T) {
+		task := newTask(ttlField("expire_at", schemapb.DataType_Float))
+		err := task.PreExecute(ctx)
+		assert.ErrorIs(t, err, merr.ErrCollectionIllegalSchema)
+	})
+
+	t.Run("nullable", func(t *testing.T) {
+		field := ttlField("expire_at", schemapb.DataType_Int64)
+		field.Nullable = true
+		task := newTask(field)
+		err := task.PreExecute(ctx)
+		assert.ErrorIs(t, err, merr.ErrCollectionIllegalSchema)
+	})
+
+	t.Run("more than one ttl field", func(t *testing.T) {
+		task := newTask(ttlField("expire_at", schemapb.DataType_Int64), ttlField("expire_at2", schemapb.DataType_Int64))
+		err := task.PreExecute(ctx)
+		assert.ErrorIs(t, err, merr.ErrCollectionIllegalSchema)
+	})
+}
+
+func TestFieldStats(t *testing.T) {
+	ctx := context.Background()
+	collectionName := "TestFieldStats" + funcutil.GenRandomStr()
+
+	newTask := func(field *schemapb.FieldSchema) *createCollectionTask {
+		fieldName2Type := make(map[string]schemapb.DataType)
+		fieldName2Type["int64_field"] = schemapb.DataType_Int64
+		schema := constructCollectionSchemaByDataType(collectionName, fieldName2Type, "int64_field", false)
+		schema.Fields = append(schema.Fields, &schemapb.FieldSchema{
+			Name:     "fvec_field",
+			DataType: schemapb.DataType_FloatVector,
+			TypeParams: []*commonpb.KeyValuePair{
+				{
+					Key:   common.DimKey,
+					Value: strconv.Itoa(testVecDim),
+				},
+			},
+		}, field)
+		marshaledSchema, err := proto.Marshal(schema)
+		assert.NoError(t, err)
+
+		return &createCollectionTask{
+			Condition: NewTaskCondition(ctx),
+			CreateCollectionRequest: &milvuspb.CreateCollectionRequest{
+				Base: &commonpb.MsgBase{
+					MsgID:     UniqueID(uniquegenerator.GetUniqueIntGeneratorIns().GetInt()),
+					Timestamp: Timestamp(time.Now().UnixNano()),
+				},
+				CollectionName: collectionName,
+				Schema:         marshaledSchema,
+				ShardsNum:      common.DefaultShardsNum,
+			},
+			ctx: ctx,
+		}
+	}
+	statsField := func(dataType schemapb.DataType, bfType string) *schemapb.FieldSchema {
+		return &schemapb.FieldSchema{
+			Name:     "tenant_id",
+			DataType: dataType,
+			TypeParams: []*commonpb.KeyValuePair{
+				{Key: common.FieldStatsKey, Value: bfType},
+				{Key: common.MaxLengthKey, Value: "64"},
+			},
+		}
+	}
+
+	t.Run("normal", func(t *testing.T) {
+		for _, field := range []*schemapb.FieldSchema{
+			statsField(schemapb.DataType_VarChar, common.FieldStatsBloom),
+			statsField(schemapb.DataType_Int64, common.FieldStatsXor),
+			statsField(schemapb.DataType_Double, common.FieldStatsBloom),
+		} {
+			task := newTask(field)
+			err := task.PreExecute(ctx)
+			assert.NoError(t, err)
+		}
+	})
+
+	t.Run("unknown filter", func(t *testing.T) {
+		task := newTask(statsField(schemapb.DataType_VarChar, "cuckoo"))
+		err := task.PreExecute(ctx)
+		assert.ErrorIs(t, err, merr.ErrCollectionIllegalSchema)
+	})
+
+	t.Run("unsupported type", func(t *testing.T) {
+		task := newTask(statsField(schemapb.DataType_JSON, common.FieldStatsBloom))
+		err := task.PreExecute(ctx)
+		assert.ErrorIs(t, err, merr.ErrCollectionIllegalSchema)
+	})
+}
+
 func TestAlterCollectionCheckLoaded(t *testing.T) {
 	rc := NewRootCoordMock()
 	rc.state.Store(commonpb.StateCode_Healthy)
@@ -22,6 +22,7 @@ import (
 
 	"github.com/cockroachdb/errors"
 	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 
 	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
@@ -29,6 +30,11 @@ import (
 	"github.com/milvus-io/milvus-proto/go-api/v2/msgpb"
 	"github.com/milvus-io/milvus-proto/go-api/v2/schemapb"
 	"github.com/milvus-io/milvus/internal/allocator"
+	"github.com/milvus-io/milvus/internal/parser/planparserv2"
+	"github.com/milvus-io/milvus/internal/proto/internalpb"
+	"github.com/milvus-io/milvus/internal/proto/planpb"
+	"github.com/milvus-io/milvus/internal/types"
+	"github.com/milvus-io/milvus/internal/util/function"
 	"github.com/milvus-io/milvus/pkg/common"
 	"github.com/milvus-io/milvus/pkg/log"
 	"github.com/milvus-io/milvus/pkg/metrics"
@@ -58,6 +64,7 @@ type upsertTask struct {
 	collectionID     UniqueID
 	chMgr            channelsMgr
 	chTicker         channelsTimeTicker
+	node             types.ProxyComponent
 	vChannels        []vChan
 	pChannels        []pChan
 	schema           *schemaInfo
@@ -205,6 +212,17 @@ func (it *upsertTask) insertPreExecute(ctx context.Context) error {
 		return merr.WrapErrAsInputErrorWhen(err, merr.ErrParameterInvalid)
 	}
 
+	if function.HasNonBM25Functions(it.schema.CollectionSchema.GetFunctions()) {
+		exec, err := it.schema.GetFunctionExecutor()
+		if err != nil {
+			return err
+		}
+		if err := exec.ProcessInsert(ctx, it.upsertMsg.InsertMsg); err != nil {
+			log.Warn("execute functions on upsert data failed", zap.Error(err))
+			return err
+		}
+	}
+
 	if it.partitionKeyMode {
 		fieldSchema, _ := typeutil.GetPartitionKeyFieldSchema(it.schema.CollectionSchema)
 		it.partitionKeys, err = getPartitionKeyFieldData(fieldSchema, it.upsertMsg.InsertMsg)
@@ -277,6 +295,86 @@ func (it *upsertTask) deletePreExecute(ctx context.Context) error {
 	return nil
 }
 
+// checkReplacedRows checks that the existing rows replaced by the upsert are permitted by the row policy filter,
+// the upsert can't overwrite the rows the user can't see.
+func (it *upsertTask) checkReplacedRows(ctx context.Context, rowPolicyFilter *planpb.Expr) error {
+	pkField, err := typeutil.GetPrimaryFieldSchema(it.schema.CollectionSchema)
+	if err != nil {
+		return err
+	}
+	existing, err := it.queryReplacedRows(ctx, pkField, nil)
+	if err != nil {
+		return err
+	}
+	if len(existing) == 0 {
+		return nil
+	}
+	visible, err := it.queryReplacedRows(ctx, pkField, rowPolicyFilter)
+	if err != nil {
+		return err
+	}
+	for pk := range existing {
+		if _, ok := visible[pk]; !ok {
+			return merr.WrapErrPrivilegeNotPermitted("row with primary key %v is not permitted by the row policy on collection %s", pk, it.req.GetCollectionName())
+		}
+	}
+	return nil
+}
+
+// queryReplacedRows returns the primary keys of the existing rows replaced by the upsert which match the filter.
+func (it *upsertTask) queryReplacedRows(ctx context.Context, pkField *schemapb.FieldSchema, filter *planpb.Expr) (map[any]struct{}, error) {
+	plan := planparserv2.CreateRequeryPlan(pkField, it.oldIds)
+	if err := andRowPolicyFilter(plan, filter); err != nil {
+		return nil, err
+	}
+	qt := &queryTask{
+		ctx:       ctx,
+		Condition: NewTaskCondition(ctx),
+		RetrieveRequest: &internalpb.RetrieveRequest{
+			Base: commonpbutil.NewMsgBase(
+				commonpbutil.WithMsgType(commonpb.MsgType_Retrieve),
+				commonpbutil.WithSourceID(paramtable.GetNodeID()),
+			),
+			ReqID: paramtable.GetNodeID(),
+		},
+		request: &milvuspb.QueryRequest{
+			Base: &commonpb.MsgBase{
+				MsgType: commonpb.MsgType_Retrieve,
+			},
+			DbName:                it.req.GetDbName(),
+			CollectionName:        it.req.GetCollectionName(),
+			ConsistencyLevel:      commonpb.ConsistencyLevel_Strong,
+			OutputFields:          []string{pkField.GetName()},
+			UseDefaultConsistency: false,
+		},
+		plan: plan,
+		qc:   it.node.(*Proxy).queryCoord,
+		lb:   it.node.(*Proxy).lbPolicy,
+		// the plan is built by the proxy, the row policy is applied by the caller
+		reQuery: true,
+	}
+	sp := trace.SpanFromContext(ctx)
+	queryResult, err := it.node.(*Proxy).query(ctx, qt, sp)
+	if err != nil {
+		return nil, err
+	}
+	if err := merr.Error(queryResult.GetStatus()); err != nil {
+		return nil, err
+	}
+	pks := make(map[any]struct{})
+	if len(queryResult.GetFieldsData()) == 0 {
+		return pks, nil
+	}
+	pkFieldData, err := typeutil.GetPrimaryFieldData(queryResult.GetFieldsData(), pkField)
+	if err != nil {
+		return nil, err
+	}
+	for i := 0; i < typeutil.GetPKSize(pkFieldData); i++ {
+		pks[typeutil.GetData(pkFieldData, i)] = struct{}{}
+	}
+	return pks, nil
+}
+
 func (it *upsertTask) PreExecute(ctx context.Context) error {
 	ctx, sp := otel.Tracer(typeutil.ProxyRole).Start(ctx, "Proxy-Upsert-PreExecute")
 	defer sp.End()
@@ -361,6 +459,24 @@ func (it *upsertTask) PreExecute(ctx context.Context) error {
 		return err
 	}
 
+	rowPolicyFilter, err := getRowPolicyFilter(ctx, it.req.GetDbName(), collectionName, it.schema.schemaHelper)
+	if err != nil {
+		log.Warn("get row policy failed", zap.Error(err))
+		return err
+	}
+	if rowPolicyFilter != nil {
+		err = checkRowPolicy(rowPolicyFilter, collectionName, it.schema, it.upsertMsg.InsertMsg.GetFieldsData(), int(it.upsertMsg.InsertMsg.GetNumRows()))
+		if err != nil {
+			log.Warn("Fail to check row policy", zap.Error(err))
+			return err
+		}
+		err = it.checkReplacedRows(ctx, rowPolicyFilter)
+		if err != nil {
+			log.Warn("Fail to check row policy of the replaced rows", zap.Error(err))
+			return err
+		}
+	}
+
 	err = it.deletePreExecute(ctx)
 	if err != nil {
 		log.Warn("Fail to deletePreExecute", zap.Error(err))
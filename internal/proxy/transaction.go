@@ -0,0 +1,340 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/milvus-io/milvus/internal/distributed/streaming"
+	"github.com/milvus-io/milvus/internal/util/streamingutil"
+	"github.com/milvus-io/milvus/pkg/log"
+	"github.com/milvus-io/milvus/pkg/streaming/util/message"
+	"github.com/milvus-io/milvus/pkg/util"
+	"github.com/milvus-io/milvus/pkg/util/merr"
+)
+
+// the interval to rollback the expired transactions.
+const txnExpireCheckInterval = time.Second
+
+type transactionKey struct{}
+
+// transaction is a client-visible transaction over the wal of one vchannel.
+// The dml messages appended into it are not visible until it's committed.
+type transaction struct {
+	id             int64
+	username       string
+	dbName         string
+	collectionName string
+	collectionID   int64
+	vchannel       string
+	keepalive      time.Duration
+
+	// mu serializes the appending and the committing, streaming.Txn is not safe for them to be concurrent.
+	mu         sync.Mutex
+	txn        streaming.Txn
+	lastActive time.Time
+	done       bool
+}
+
+// expired returns whether the transaction session has been expired at the wal.
+func (t *transaction) expired(now time.Time) bool {
+	return now.Sub(t.lastActive) > t.keepalive
+}
+
+// append appends the dml messages into the transaction.
+func (t *transaction) append(ctx context.Context, msgs ...message.MutableMessage) streaming.AppendResponses {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	resp := streaming.AppendResponses{Responses: make([]streaming.AppendResponse, len(msgs))}
+	fillError := func(err error) streaming.AppendResponses {
+		for i := range resp.Responses {
+			resp.Responses[i].Error = err
+		}
+		return resp
+	}
+	if t.done || t.expired(time.Now()) {
+		return fillError(merr.WrapErrTxnNotFound(t.id, "transaction is finished or expired"))
+	}
+	for _, msg := range msgs {
+		if msg.VChannel() != t.vchannel {
+			return fillError(merr.WrapErrParameterInvalidMsg("the data is routed to vchannel %s, but transaction %d is on vchannel %s",
+				msg.VChannel(), t.id, t.vchannel))
+		}
+	}
+	for i, msg := range msgs {
+		if err := t.txn.Append(ctx, msg); err != nil {
+			resp.Responses[i].Error = err
+			return resp
+		}
+		t.lastActive = time.Now()
+	}
+	return resp
+}
+
+// transactionManager keeps the open transactions begun on this proxy.
+type transactionManager struct {
+	mu   sync.Mutex
+	txns map[int64]*transaction
+
+	closeOnce sync.Once
+	closed    chan struct{}
+	wg        sync.WaitGroup
+}
+
+func newTransactionManager() *transactionManager {
+	return &transactionManager{
+		txns:   make(map[int64]*transaction),
+		closed: make(chan struct{}),
+	}
+}
+
+// start starts the background rollback of the expired transactions.
+func (m *transactionManager) start() {
+	m.wg.Add(1)
+	go func() {
+		defer m.wg.Done()
+		ticker := time.NewTicker(txnExpireCheckInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-m.closed:
+				return
+			case <-ticker.C:
+				m.rollbackExpired()
+			}
+		}
+	}()
+}
+
+// close stops the manager and rollbacks all the open transactions.
+func (m *transactionManager) close() {
+	m.closeOnce.Do(func() {
+		close(m.closed)
+		m.wg.Wait()
+
+		m.mu.Lock()
+		txns := m.txns
+		m.txns = make(map[int64]*transaction)
+		m.mu.Unlock()
+		for _, txn := range txns {
+			m.rollbackTxn(context.Background(), txn)
+		}
+	})
+}
+
+// begin begins a transaction on the only vchannel of the collection.
+func (m *transactionManager) begin(ctx context.Context, username, dbName, collectionName string, collectionID int64, vchannels []string, keepalive time.Duration) (*transaction, error) {
+	if !streamingutil.IsStreamingServiceEnabled() {
+		return nil, merr.WrapErrServiceUnavailable("transaction is only supported with the streaming service enabled")
+	}
+	if len(vchannels) != 1 {
+		return nil, merr.WrapErrParameterInvalidMsg("transaction is only supported on collection with one shard, collection %s has %d shards",
+			collectionName, len(vchannels))
+	}
+	maxNum := Params.ProxyCfg.MaxTxnNum.GetAsInt()
+	m.mu.Lock()
+	num := len(m.txns)
+	m.mu.Unlock()
+	if num >= maxNum {
+		return nil, merr.WrapErrServiceQuotaExceeded(fmt.Sprintf("too many open transactions on the proxy, limit %d", maxNum))
+	}
+
+	txn, err := streaming.WAL().Txn(ctx, streaming.TxnOption{
+		VChannel:  vchannels[0],
+		Keepalive: keepalive,
+	})
+	if err != nil {
+		return nil, err
+	}
+	txnCtx := txn.TxnContext()
+	t := &transaction{
+		id:             int64(txnCtx.TxnID),
+		username:       username,
+		dbName:         dbName,
+		collectionName: collectionName,
+		collectionID:   collectionID,
+		vchannel:       vchannels[0],
+		keepalive:      txnCtx.Keepalive,
+		txn:            txn,
+		lastActive:     time.Now(),
+	}
+	m.mu.Lock()
+	m.txns[t.id] = t
+	m.mu.Unlock()
+	return t, nil
+}
+
+// get returns the open transaction of the id, it must be begun by the user.
+func (m *transactionManager) get(txnID int64, username string) (*transaction, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	t, ok := m.txns[txnID]
+	if !ok {
+		return nil, merr.WrapErrTxnNotFound(txnID)
+	}
+	if t.username != username {
+		return nil, merr.WrapErrPrivilegeNotPermitted("transaction %d is not begun by user %s", txnID, username)
+	}
+	return t, nil
+}
+
+// remove takes the transaction out of the manager, so it could be finished only once.
+func (m *transactionManager) remove(txnID int64, username string) (*transaction, error) {
+	t, err := m.get(txnID, username)
+	if err != nil {
+		return nil, err
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.txns[txnID]; !ok {
+		return nil, merr.WrapErrTxnNotFound(txnID)
+	}
+	delete(m.txns, txnID)
+	return t, nil
+}
+
+// commit commits the transaction, returns the timetick of the commit.
+func (m *transactionManager) commit(ctx context.Context, txnID int64, username string) (uint64, error) {
+	t, err := m.remove(txnID, username)
+	if err != nil {
+		return 0, err
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.done = true
+	if t.expired(time.Now()) {
+		t.txn.Rollback(ctx)
+		return 0, merr.WrapErrTxnNotFound(txnID, "transaction is expired")
+	}
+	result, err := t.txn.Commit(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return result.TimeTick, nil
+}
+
+// rollback rollbacks the transaction.
+func (m *transactionManager) rollback(ctx context.Context, txnID int64, username string) error {
+	t, err := m.remove(txnID, username)
+	if err != nil {
+		return err
+	}
+	return m.rollbackTxn(ctx, t)
+}
+
+func (m *transactionManager) rollbackTxn(ctx context.Context, t *transaction) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.done {
+		return nil
+	}
+	t.done = true
+	err := t.txn.Rollback(ctx)
+	// the session of the expired transaction is already rolled back by the wal.
+	if err != nil && !t.expired(time.Now()) {
+		log.Ctx(ctx).Warn("failed to rollback transaction", zap.Int64("txnID", t.id), zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+func (m *transactionManager) rollbackExpired() {
+	now := time.Now()
+	expired := make([]*transaction, 0)
+	m.mu.Lock()
+	for id, t := range m.txns {
+		t.mu.Lock()
+		if t.expired(now) {
+			expired = append(expired, t)
+			delete(m.txns, id)
+		}
+		t.mu.Unlock()
+	}
+	m.mu.Unlock()
+
+	for _, t := range expired {
+		log.Info("rollback expired transaction", zap.Int64("txnID", t.id), zap.String("collection", t.collectionName))
+		// the session at the wal is already expired, the rollback only releases the resources.
+		m.rollbackTxn(context.Background(), t)
+	}
+}
+
+// getTxnIDFromContext returns the id of the transaction which the request joins, 0 if none.
+func getTxnIDFromContext(ctx context.Context) (int64, error) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return 0, nil
+	}
+	values := md[strings.ToLower(util.HeaderTxnID)]
+	if len(values) < 1 || values[0] == "" {
+		return 0, nil
+	}
+	txnID, err := strconv.ParseInt(values[0], 10, 64)
+	if err != nil {
+		return 0, merr.WrapErrParameterInvalidMsg("invalid transaction id %s", values[0])
+	}
+	return txnID, nil
+}
+
+// withTransaction returns the context carrying the transaction the dml request joins,
+// the context is returned as it is if the request doesn't join a transaction.
+func (node *Proxy) withTransaction(ctx context.Context, dbName, collectionName string) (context.Context, error) {
+	txnID, err := getTxnIDFromContext(ctx)
+	if err != nil || txnID == 0 {
+		return ctx, err
+	}
+	t, err := node.txnManager.get(txnID, GetCurUserFromContextOrDefault(ctx))
+	if err != nil {
+		return ctx, err
+	}
+	if dbName == "" {
+		dbName = GetCurDBNameFromContextOrDefault(ctx)
+	}
+	// compare by the id since the collection may be accessed by the alias
+	collectionID, err := globalMetaCache.GetCollectionID(ctx, dbName, collectionName)
+	if err != nil {
+		return ctx, err
+	}
+	if collectionID != t.collectionID {
+		return ctx, merr.WrapErrParameterInvalidMsg("transaction %d is on collection %s.%s, but the request is on %s.%s",
+			txnID, t.dbName, t.collectionName, dbName, collectionName)
+	}
+	return context.WithValue(ctx, transactionKey{}, t), nil
+}
+
+func transactionFromContext(ctx context.Context) *transaction {
+	t, _ := ctx.Value(transactionKey{}).(*transaction)
+	return t
+}
+
+// appendMessagesToWAL appends the dml messages into the transaction the request joins,
+// or into the wal directly if none.
+func appendMessagesToWAL(ctx context.Context, msgs ...message.MutableMessage) streaming.AppendResponses {
+	if t := transactionFromContext(ctx); t != nil {
+		return t.append(ctx, msgs...)
+	}
+	return streaming.WAL().AppendMessages(ctx, msgs...)
+}
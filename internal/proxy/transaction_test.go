@@ -0,0 +1,222 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/milvus-io/milvus/internal/distributed/streaming"
+	"github.com/milvus-io/milvus/internal/mocks/distributed/mock_streaming"
+	"github.com/milvus-io/milvus/internal/util/streamingutil"
+	"github.com/milvus-io/milvus/pkg/mocks/streaming/util/mock_message"
+	"github.com/milvus-io/milvus/pkg/streaming/util/message"
+	"github.com/milvus-io/milvus/pkg/streaming/util/types"
+	"github.com/milvus-io/milvus/pkg/util"
+	"github.com/milvus-io/milvus/pkg/util/merr"
+	"github.com/milvus-io/milvus/pkg/util/paramtable"
+)
+
+type fakeTxn struct {
+	txnCtx     message.TxnContext
+	appended   []message.MutableMessage
+	committed  bool
+	rollbacked bool
+}
+
+func (t *fakeTxn) TxnContext() message.TxnContext {
+	return t.txnCtx
+}
+
+func (t *fakeTxn) Append(ctx context.Context, msg message.MutableMessage, opts ...streaming.AppendOption) error {
+	t.appended = append(t.appended, msg)
+	return nil
+}
+
+func (t *fakeTxn) Commit(ctx context.Context) (*types.AppendResult, error) {
+	t.committed = true
+	return &types.AppendResult{TimeTick: 100}, nil
+}
+
+func (t *fakeTxn) Rollback(ctx context.Context) error {
+	t.rollbacked = true
+	return nil
+}
+
+func newTestMutableMessage(t *testing.T, vchannel string) message.MutableMessage {
+	msg := mock_message.NewMockMutableMessage(t)
+	msg.EXPECT().VChannel().Return(vchannel).Maybe()
+	return msg
+}
+
+func setupTxnWAL(t *testing.T, keepalive time.Duration) {
+	streamingutil.SetStreamingServiceEnabled()
+	t.Cleanup(streamingutil.UnsetStreamingServiceEnabled)
+
+	txnID := 0
+	wal := mock_streaming.NewMockWALAccesser(t)
+	wal.EXPECT().Txn(mock.Anything, mock.Anything).RunAndReturn(func(ctx context.Context, opts streaming.TxnOption) (streaming.Txn, error) {
+		txnID++
+		return &fakeTxn{txnCtx: message.TxnContext{TxnID: message.TxnID(txnID), Keepalive: keepalive}}, nil
+	}).Maybe()
+	streaming.SetWALForTest(wal)
+}
+
+func TestTransactionManager_BeginAndCommit(t *testing.T) {
+	paramtable.Init()
+	setupTxnWAL(t, time.Minute)
+	ctx := context.Background()
+	m := newTransactionManager()
+	defer m.close()
+
+	_, err := m.begin(ctx, "alice", "default", "coll", 1, []string{"ch-0", "ch-1"}, 0)
+	assert.ErrorIs(t, err, merr.ErrParameterInvalid)
+
+	txn, err := m.begin(ctx, "alice", "default", "coll", 1, []string{"ch-0"}, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), txn.id)
+	assert.Equal(t, time.Minute, txn.keepalive)
+
+	// only the user began the transaction could use it
+	_, err = m.get(txn.id, "bob")
+	assert.ErrorIs(t, err, merr.ErrPrivilegeNotPermitted)
+	_, err = m.get(2, "alice")
+	assert.ErrorIs(t, err, merr.ErrTxnNotFound)
+
+	resp := txn.append(ctx, newTestMutableMessage(t, "ch-0"))
+	assert.NoError(t, resp.UnwrapFirstError())
+	resp = txn.append(ctx, newTestMutableMessage(t, "ch-1"))
+	assert.ErrorIs(t, resp.UnwrapFirstError(), merr.ErrParameterInvalid)
+	assert.Len(t, txn.txn.(*fakeTxn).appended, 1)
+
+	ts, err := m.commit(ctx, txn.id, "alice")
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(100), ts)
+	assert.True(t, txn.txn.(*fakeTxn).committed)
+
+	// the transaction could be finished only once
+	_, err = m.commit(ctx, txn.id, "alice")
+	assert.ErrorIs(t, err, merr.ErrTxnNotFound)
+	resp = txn.append(ctx, newTestMutableMessage(t, "ch-0"))
+	assert.ErrorIs(t, resp.UnwrapFirstError(), merr.ErrTxnNotFound)
+}
+
+func TestTransactionManager_Rollback(t *testing.T) {
+	paramtable.Init()
+	setupTxnWAL(t, time.Minute)
+	ctx := context.Background()
+	m := newTransactionManager()
+
+	txn1, err := m.begin(ctx, "alice", "default", "coll", 1, []string{"ch-0"}, 0)
+	assert.NoError(t, err)
+	txn2, err := m.begin(ctx, "alice", "default", "coll", 1, []string{"ch-0"}, 0)
+	assert.NoError(t, err)
+
+	err = m.rollback(ctx, txn1.id, "bob")
+	assert.ErrorIs(t, err, merr.ErrPrivilegeNotPermitted)
+	err = m.rollback(ctx, txn1.id, "alice")
+	assert.NoError(t, err)
+	assert.True(t, txn1.txn.(*fakeTxn).rollbacked)
+
+	// the open transactions are rolled back at close
+	m.close()
+	assert.True(t, txn2.txn.(*fakeTxn).rollbacked)
+}
+
+func TestTransactionManager_Limit(t *testing.T) {
+	paramtable.Init()
+	setupTxnWAL(t, time.Minute)
+	paramtable.Get().Save(Params.ProxyCfg.MaxTxnNum.Key, "1")
+	defer paramtable.Get().Reset(Params.ProxyCfg.MaxTxnNum.Key)
+	ctx := context.Background()
+	m := newTransactionManager()
+	defer m.close()
+
+	_, err := m.begin(ctx, "alice", "default", "coll", 1, []string{"ch-0"}, 0)
+	assert.NoError(t, err)
+	_, err = m.begin(ctx, "alice", "default", "coll", 1, []string{"ch-0"}, 0)
+	assert.ErrorIs(t, err, merr.ErrServiceQuotaExceeded)
+}
+
+func TestTransactionManager_Expire(t *testing.T) {
+	paramtable.Init()
+	setupTxnWAL(t, 50*time.Millisecond)
+	ctx := context.Background()
+	m := newTransactionManager()
+	defer m.close()
+
+	txn, err := m.begin(ctx, "alice", "default", "coll", 1, []string{"ch-0"}, 0)
+	assert.NoError(t, err)
+	time.Sleep(100 * time.Millisecond)
+
+	resp := txn.append(ctx, newTestMutableMessage(t, "ch-0"))
+	assert.ErrorIs(t, resp.UnwrapFirstError(), merr.ErrTxnNotFound)
+
+	m.rollbackExpired()
+	_, err = m.get(txn.id, "alice")
+	assert.ErrorIs(t, err, merr.ErrTxnNotFound)
+	assert.True(t, txn.txn.(*fakeTxn).rollbacked)
+}
+
+func TestTransactionManager_StreamingDisabled(t *testing.T) {
+	paramtable.Init()
+	m := newTransactionManager()
+	defer m.close()
+
+	_, err := m.begin(context.Background(), "alice", "default", "coll", 1, []string{"ch-0"}, 0)
+	assert.ErrorIs(t, err, merr.ErrServiceUnavailable)
+}
+
+func TestGetTxnIDFromContext(t *testing.T) {
+	txnID, err := getTxnIDFromContext(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), txnID)
+
+	key := strings.ToLower(util.HeaderTxnID)
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(key, "123"))
+	txnID, err = getTxnIDFromContext(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(123), txnID)
+
+	ctx = metadata.NewIncomingContext(context.Background(), metadata.Pairs(key, "abc"))
+	_, err = getTxnIDFromContext(ctx)
+	assert.ErrorIs(t, err, merr.ErrParameterInvalid)
+}
+
+func TestAppendMessagesToWAL(t *testing.T) {
+	paramtable.Init()
+	setupTxnWAL(t, time.Minute)
+	ctx := context.Background()
+	m := newTransactionManager()
+	defer m.close()
+
+	txn, err := m.begin(ctx, "alice", "default", "coll", 1, []string{"ch-0"}, 0)
+	assert.NoError(t, err)
+	assert.Nil(t, transactionFromContext(ctx))
+
+	ctx = context.WithValue(ctx, transactionKey{}, txn)
+	assert.Equal(t, txn, transactionFromContext(ctx))
+	resp := appendMessagesToWAL(ctx, newTestMutableMessage(t, "ch-0"), newTestMutableMessage(t, "ch-0"))
+	assert.NoError(t, resp.UnwrapFirstError())
+	assert.Len(t, txn.txn.(*fakeTxn).appended, 2)
+}
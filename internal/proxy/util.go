@@ -39,6 +39,7 @@ import (
 	"github.com/milvus-io/milvus/internal/proto/planpb"
 	"github.com/milvus-io/milvus/internal/proto/querypb"
 	"github.com/milvus-io/milvus/internal/types"
+	functionutil "github.com/milvus-io/milvus/internal/util/function"
 	"github.com/milvus-io/milvus/internal/util/hookutil"
 	"github.com/milvus-io/milvus/internal/util/indexparamcheck"
 	typeutil2 "github.com/milvus-io/milvus/internal/util/typeutil"
@@ -718,6 +719,14 @@ func checkFunctionOutputField(function *schemapb.FunctionSchema, fields []*schem
 		if !typeutil.IsSparseFloatVectorType(fields[0].GetDataType()) {
 			return fmt.Errorf("BM25 function output field must be a SparseFloatVector field, but got %s", fields[0].DataType.String())
 		}
+	case schemapb.FunctionType_TextEmbedding:
+		if len(fields) != 1 {
+			return fmt.Errorf("TextEmbedding function only need 1 output field, but got %d", len(fields))
+		}
+
+		if fields[0].GetDataType() != schemapb.DataType_FloatVector {
+			return fmt.Errorf("TextEmbedding function output field must be a FloatVector field, but got %s", fields[0].DataType.String())
+		}
 	default:
 		return fmt.Errorf("check output field for unknown function type")
 	}
@@ -736,14 +745,23 @@ func wasBm25FunctionInputField(coll *schemapb.CollectionSchema, field *schemapb.
 func checkFunctionInputField(function *schemapb.FunctionSchema, fields []*schemapb.FieldSchema) error {
 	switch function.GetType() {
 	case schemapb.FunctionType_BM25:
-		if len(fields) != 1 || fields[0].DataType != schemapb.DataType_VarChar {
-			return fmt.Errorf("BM25 function input field must be a VARCHAR field, got %d field with type %s",
-				len(fields), fields[0].DataType.String())
+		if len(fields) != 1 {
+			return fmt.Errorf("BM25 function must have exactly one input field, got %d", len(fields))
+		}
+		if fields[0].DataType != schemapb.DataType_VarChar {
+			return fmt.Errorf("BM25 function input field must be a VARCHAR field, got %s", fields[0].DataType.String())
 		}
 		h := typeutil.CreateFieldSchemaHelper(fields[0])
 		if !h.EnableAnalyzer() {
 			return fmt.Errorf("BM25 function input field must set enable_analyzer to true")
 		}
+	case schemapb.FunctionType_TextEmbedding:
+		if len(fields) != 1 {
+			return fmt.Errorf("TextEmbedding function must have exactly one input field, got %d", len(fields))
+		}
+		if fields[0].DataType != schemapb.DataType_VarChar {
+			return fmt.Errorf("TextEmbedding function input field must be a VARCHAR field, got %s", fields[0].DataType.String())
+		}
 
 	default:
 		return fmt.Errorf("check input field with unknown function type")
@@ -786,6 +804,10 @@ func checkFunctionBasicParams(function *schemapb.FunctionSchema) error {
 		if len(function.GetParams()) != 0 {
 			return fmt.Errorf("BM25 function accepts no params")
 		}
+	case schemapb.FunctionType_TextEmbedding:
+		if err := functionutil.ValidateTextEmbeddingParams(function.GetParams()); err != nil {
+			return err
+		}
 	default:
 		return fmt.Errorf("check function params with unknown function type")
 	}
@@ -1199,6 +1221,19 @@ func GetRole(username string) ([]string, error) {
 	return globalMetaCache.GetUserRole(username), nil
 }
 
+// getUserRoles returns the roles granted to the user, together with the roles mapped from
+// the jwt presented by the request.
+func getUserRoles(ctx context.Context, username string) ([]string, error) {
+	roles, err := GetRole(username)
+	if err != nil {
+		return nil, err
+	}
+	if jwtRoles := getJWTRoles(ctx); len(jwtRoles) > 0 {
+		roles = typeutil.NewSet(roles...).Union(typeutil.NewSet(jwtRoles...)).Collect()
+	}
+	return roles, nil
+}
+
 func PasswordVerify(ctx context.Context, username, rawPwd string) bool {
 	return passwordVerify(ctx, username, rawPwd, globalMetaCache)
 }
@@ -1213,6 +1248,20 @@ func VerifyAPIKey(rawToken string) (string, error) {
 	return user, nil
 }
 
+// VerifyJWT verifies the bearer token and returns the user and the milvus roles it is mapped to,
+// the roles should be attached to the request context by NewContextWithJWTRoles.
+func VerifyJWT(token string) (string, []string, error) {
+	if globalJWTVerifier == nil {
+		return "", nil, merr.WrapErrParameterInvalidMsg("jwt authentication is not enabled")
+	}
+	user, roles, err := globalJWTVerifier.Verify(token)
+	if err != nil {
+		log.Warn("fail to verify jwt", zap.Error(err))
+		return "", nil, merr.WrapErrParameterInvalidMsg("invalid jwt: %s", err.Error())
+	}
+	return user, roles, nil
+}
+
 // PasswordVerify verify password
 func passwordVerify(ctx context.Context, username, rawPwd string, globalMetaCache Cache) bool {
 	// it represents the cache miss if Sha256Password is empty within credInfo, which shall be updated first connection.
@@ -2908,6 +2908,13 @@ func TestValidateFunctionInputField(t *testing.T) {
 		assert.Error(t, err)
 	})
 
+	t.Run("Invalid function input - no field", func(t *testing.T) {
+		for _, fnType := range []schemapb.FunctionType{schemapb.FunctionType_BM25, schemapb.FunctionType_TextEmbedding} {
+			err := checkFunctionInputField(&schemapb.FunctionSchema{Type: fnType}, nil)
+			assert.Error(t, err)
+		}
+	})
+
 	t.Run("Unknown function type", func(t *testing.T) {
 		function := &schemapb.FunctionSchema{
 			Type: schemapb.FunctionType_Unknown,
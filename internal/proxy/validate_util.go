@@ -12,6 +12,7 @@ import (
 	"github.com/milvus-io/milvus/pkg/common"
 	"github.com/milvus-io/milvus/pkg/log"
 	"github.com/milvus-io/milvus/pkg/util/funcutil"
+	"github.com/milvus-io/milvus/pkg/util/geo"
 	"github.com/milvus-io/milvus/pkg/util/merr"
 	"github.com/milvus-io/milvus/pkg/util/parameterutil"
 	"github.com/milvus-io/milvus/pkg/util/paramtable"
@@ -96,6 +97,10 @@ func (v *validateUtil) Validate(data []*schemapb.FieldData, helper *typeutil.Sch
 			if err := v.checkJSONFieldData(field, fieldSchema); err != nil {
 				return err
 			}
+		case schemapb.DataType_Geometry:
+			if err := v.checkGeometryFieldData(field, fieldSchema); err != nil {
+				return err
+			}
 		case schemapb.DataType_Int8, schemapb.DataType_Int16, schemapb.DataType_Int32:
 			if err := v.checkIntegerFieldData(field, fieldSchema); err != nil {
 				return err
@@ -368,6 +373,14 @@ func (v *validateUtil) fillWithNullValue(field *schemapb.FieldData, fieldSchema
 				}
 			}
 
+		case *schemapb.ScalarField_GeometryData:
+			if fieldSchema.GetNullable() {
+				sd.GeometryData.Data, err = fillWithNullValueImpl(sd.GeometryData.Data, field.GetValidData())
+				if err != nil {
+					return err
+				}
+			}
+
 		default:
 			return merr.WrapErrParameterInvalidMsg(fmt.Sprintf("undefined data type:%s", field.Type.String()))
 		}
@@ -456,6 +469,10 @@ func (v *validateUtil) fillWithDefaultValue(field *schemapb.FieldData, fieldSche
 			log.Error("array type not support default value", zap.String("fieldSchemaName", field.GetFieldName()))
 			return merr.WrapErrParameterInvalid("not set default value", "", "array type not support default value")
 
+		case *schemapb.ScalarField_GeometryData:
+			log.Error("geometry type not support default value", zap.String("fieldSchemaName", field.GetFieldName()))
+			return merr.WrapErrParameterInvalid("not set default value", "", "geometry type not support default value")
+
 		case *schemapb.ScalarField_JsonData:
 			if len(field.GetValidData()) != numRows {
 				msg := fmt.Sprintf("the length of valid_data of field(%s) is wrong", field.GetFieldName())
@@ -660,6 +677,23 @@ func (v *validateUtil) checkJSONFieldData(field *schemapb.FieldData, fieldSchema
 	return nil
 }
 
+// checkGeometryFieldData checks the geometries are valid wkb, the null rows are not in the data before filled.
+func (v *validateUtil) checkGeometryFieldData(field *schemapb.FieldData, fieldSchema *schemapb.FieldSchema) error {
+	geometries := field.GetScalars().GetGeometryData().GetData()
+	if geometries == nil && !fieldSchema.GetNullable() {
+		msg := fmt.Sprintf("geometry field '%v' is illegal, array type mismatch", field.GetFieldName())
+		return merr.WrapErrParameterInvalid("need geometry array", "got nil", msg)
+	}
+
+	for i, wkb := range geometries {
+		if _, err := geo.ParseWKB(wkb); err != nil {
+			return merr.WrapErrParameterInvalidMsg("invalid geometry of field %s, row number: %d, %s",
+				fieldSchema.GetName(), i, err.Error())
+		}
+	}
+	return nil
+}
+
 func (v *validateUtil) checkIntegerFieldData(field *schemapb.FieldData, fieldSchema *schemapb.FieldSchema) error {
 	data := field.GetScalars().GetIntData().GetData()
 	if data == nil && fieldSchema.GetDefaultValue() == nil && !fieldSchema.GetNullable() {
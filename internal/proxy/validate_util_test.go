@@ -14,6 +14,7 @@ import (
 	"github.com/milvus-io/milvus-proto/go-api/v2/schemapb"
 	"github.com/milvus-io/milvus/internal/json"
 	"github.com/milvus-io/milvus/pkg/common"
+	"github.com/milvus-io/milvus/pkg/util/geo"
 	"github.com/milvus-io/milvus/pkg/util/merr"
 	"github.com/milvus-io/milvus/pkg/util/paramtable"
 	"github.com/milvus-io/milvus/pkg/util/testutils"
@@ -6600,3 +6601,36 @@ func TestCheckArrayElementNilData(t *testing.T) {
 	err := v.checkArrayElement(data, fieldSchema)
 	assert.True(t, merr.ErrParameterInvalid.Is(err))
 }
+
+func Test_validateUtil_checkGeometryData(t *testing.T) {
+	v := newValidateUtil()
+	f := &schemapb.FieldSchema{
+		Name:     "geo",
+		DataType: schemapb.DataType_Geometry,
+	}
+	newData := func(data [][]byte) *schemapb.FieldData {
+		return &schemapb.FieldData{
+			FieldName: "geo",
+			Field: &schemapb.FieldData_Scalars{
+				Scalars: &schemapb.ScalarField{
+					Data: &schemapb.ScalarField_GeometryData{
+						GeometryData: &schemapb.GeometryArray{Data: data},
+					},
+				},
+			},
+		}
+	}
+
+	err := v.checkGeometryFieldData(newData(nil), f)
+	assert.Error(t, err)
+
+	err = v.checkGeometryFieldData(newData(testutils.GenerateGeometryArray(10)), f)
+	assert.NoError(t, err)
+
+	err = v.checkGeometryFieldData(newData([][]byte{geo.NewPoint(1, 2).WKB(), []byte("POINT(1 2)")}), f)
+	assert.ErrorIs(t, err, merr.ErrParameterInvalid)
+
+	f.Nullable = true
+	err = v.checkGeometryFieldData(newData(nil), f)
+	assert.NoError(t, err)
+}
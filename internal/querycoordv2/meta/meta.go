@@ -34,7 +34,7 @@ func NewMeta(
 ) *Meta {
 	return &Meta{
 		NewCollectionManager(catalog),
-		NewReplicaManager(idAllocator, catalog),
+		NewReplicaManager(idAllocator, catalog, nodeMgr),
 		NewResourceManager(catalog, nodeMgr),
 	}
 }
@@ -28,6 +28,7 @@ import (
 	"github.com/milvus-io/milvus/internal/json"
 	"github.com/milvus-io/milvus/internal/metastore"
 	"github.com/milvus-io/milvus/internal/proto/querypb"
+	"github.com/milvus-io/milvus/internal/querycoordv2/session"
 	"github.com/milvus-io/milvus/pkg/log"
 	"github.com/milvus-io/milvus/pkg/util/merr"
 	"github.com/milvus-io/milvus/pkg/util/metricsinfo"
@@ -42,6 +43,7 @@ type ReplicaManager struct {
 	replicas      map[typeutil.UniqueID]*Replica
 	coll2Replicas map[typeutil.UniqueID]*collectionReplicas // typeutil.UniqueSet
 	catalog       metastore.QueryCoordCatalog
+	nodeMgr       *session.NodeManager // used to get the availability zone of nodes, may be nil.
 }
 
 // collectionReplicas maintains collection secondary index mapping
@@ -69,12 +71,13 @@ func newCollectionReplicas() *collectionReplicas {
 	}
 }
 
-func NewReplicaManager(idAllocator func() (int64, error), catalog metastore.QueryCoordCatalog) *ReplicaManager {
+func NewReplicaManager(idAllocator func() (int64, error), catalog metastore.QueryCoordCatalog, nodeMgr *session.NodeManager) *ReplicaManager {
 	return &ReplicaManager{
 		idAllocator:   idAllocator,
 		replicas:      make(map[int64]*Replica),
 		coll2Replicas: make(map[int64]*collectionReplicas),
 		catalog:       catalog,
+		nodeMgr:       nodeMgr,
 	}
 }
 
@@ -387,6 +390,7 @@ func (m *ReplicaManager) GetByResourceGroup(ctx context.Context, rgName string)
 // 1. Move the rw nodes to ro nodes if they are not in related resource group.
 // 2. Add new incoming nodes into the replica if they are not in-used by other replicas of same collection.
 // 3. replicas in same resource group will shared the nodes in resource group fairly.
+// 4. replicas in same resource group are spread across the availability zones, and prefer the nodes in their own zone.
 func (m *ReplicaManager) RecoverNodesInCollection(ctx context.Context, collectionID typeutil.UniqueID, rgs map[string]typeutil.UniqueSet) error {
 	if err := m.validateResourceGroups(rgs); err != nil {
 		return err
@@ -404,13 +408,22 @@ func (m *ReplicaManager) RecoverNodesInCollection(ctx context.Context, collectio
 	modifiedReplicas := make([]*Replica, 0)
 	// recover node by resource group.
 	helper.RangeOverResourceGroup(func(replicaHelper *replicasInSameRGAssignmentHelper) {
+		roNodes := make(map[typeutil.UniqueID][]int64)
+		recoverableNodes := make(map[typeutil.UniqueID][]int64)
+		incomingNodeCounts := make(map[typeutil.UniqueID]int)
 		replicaHelper.RangeOverReplicas(func(assignment *replicaAssignmentInfo) {
-			roNodes := assignment.GetNewRONodes()
-			recoverableNodes, incomingNodeCount := assignment.GetRecoverNodesAndIncomingNodeCount()
-			// There may be not enough incoming nodes for current replica,
-			// Even we filtering the nodes that are used by other replica of same collection in other resource group,
-			// current replica's expected node may be still used by other replica of same collection in same resource group.
-			incomingNode := replicaHelper.AllocateIncomingNodes(incomingNodeCount)
+			replicaID := assignment.GetReplicaID()
+			roNodes[replicaID] = assignment.GetNewRONodes()
+			recoverableNodes[replicaID], incomingNodeCounts[replicaID] = assignment.GetRecoverNodesAndIncomingNodeCount()
+		})
+		// There may be not enough incoming nodes for current replica,
+		// Even we filtering the nodes that are used by other replica of same collection in other resource group,
+		// current replica's expected node may be still used by other replica of same collection in same resource group.
+		incomingNodes := replicaHelper.AllocateIncomingNodes(incomingNodeCounts)
+		replicaHelper.RangeOverReplicas(func(assignment *replicaAssignmentInfo) {
+			roNodes := roNodes[assignment.GetReplicaID()]
+			recoverableNodes := recoverableNodes[assignment.GetReplicaID()]
+			incomingNode := incomingNodes[assignment.GetReplicaID()]
 			if len(roNodes) == 0 && len(recoverableNodes) == 0 && len(incomingNode) == 0 {
 				// nothing to do.
 				return
@@ -422,6 +435,7 @@ func (m *ReplicaManager) RecoverNodesInCollection(ctx context.Context, collectio
 			log.Info(
 				"new replica recovery found",
 				zap.Int64("replicaID", assignment.GetReplicaID()),
+				zap.String("zone", assignment.zone),
 				zap.Int64s("newRONodes", roNodes),
 				zap.Int64s("roToRWNodes", recoverableNodes),
 				zap.Int64s("newIncomingNodes", incomingNode))
@@ -465,7 +479,19 @@ func (m *ReplicaManager) getCollectionAssignmentHelper(collectionID typeutil.Uni
 		}
 		rgToReplicas[rgName] = append(rgToReplicas[rgName], replica)
 	}
-	return newCollectionAssignmentHelper(collectionID, rgToReplicas, rgs), nil
+	return newCollectionAssignmentHelper(collectionID, rgToReplicas, rgs, m.getNodeZone), nil
+}
+
+// getNodeZone returns the availability zone of the node, empty if it's unknown.
+func (m *ReplicaManager) getNodeZone(nodeID int64) string {
+	if m.nodeMgr == nil {
+		return ""
+	}
+	node := m.nodeMgr.Get(nodeID)
+	if node == nil {
+		return ""
+	}
+	return node.Zone()
 }
 
 // RemoveNode removes the node from all replicas of given collection.
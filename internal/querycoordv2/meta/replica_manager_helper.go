@@ -3,6 +3,8 @@ package meta
 import (
 	"sort"
 
+	"github.com/samber/lo"
+
 	"github.com/milvus-io/milvus/pkg/util/typeutil"
 )
 
@@ -13,14 +15,16 @@ type collectionAssignmentHelper struct {
 }
 
 // newCollectionAssignmentHelper creates a new collectionAssignmentHelper.
+// nodeZone returns the availability zone of the node, empty if it's unknown.
 func newCollectionAssignmentHelper(
 	collectionID typeutil.UniqueID,
 	rgToReplicas map[string][]*Replica,
 	rgs map[string]typeutil.UniqueSet,
+	nodeZone func(nodeID int64) string,
 ) *collectionAssignmentHelper {
 	resourceGroupToReplicas := make(map[string]*replicasInSameRGAssignmentHelper)
 	for rgName, replicas := range rgToReplicas {
-		resourceGroupToReplicas[rgName] = newReplicaAssignmentHelper(rgName, replicas, rgs[rgName])
+		resourceGroupToReplicas[rgName] = newReplicaAssignmentHelper(rgName, replicas, rgs[rgName], nodeZone)
 	}
 
 	helper := &collectionAssignmentHelper{
@@ -54,6 +58,7 @@ func (h *collectionAssignmentHelper) updateIncomingNodesAndExpectedNode() {
 		})
 		helper.incomingNodes = newIncomingNodes
 		helper.updateExpectedNodeCountForReplicas(currentUsedNodeCount)
+		helper.updateZoneForReplicas()
 	}
 }
 
@@ -74,15 +79,19 @@ func (h *collectionAssignmentHelper) RangeOverReplicas(f func(rgName string, ass
 }
 
 // newReplicaAssignmentHelper creates a new replicaAssignmentHelper.
-func newReplicaAssignmentHelper(rgName string, replicas []*Replica, nodeInRG typeutil.UniqueSet) *replicasInSameRGAssignmentHelper {
+func newReplicaAssignmentHelper(rgName string, replicas []*Replica, nodeInRG typeutil.UniqueSet, nodeZone func(nodeID int64) string) *replicasInSameRGAssignmentHelper {
+	if nodeZone == nil {
+		nodeZone = func(int64) string { return "" }
+	}
 	assignmentInfos := make([]*replicaAssignmentInfo, 0, len(replicas))
 	for _, replica := range replicas {
-		assignmentInfos = append(assignmentInfos, newReplicaAssignmentInfo(replica, nodeInRG))
+		assignmentInfos = append(assignmentInfos, newReplicaAssignmentInfo(replica, nodeInRG, nodeZone))
 	}
 	h := &replicasInSameRGAssignmentHelper{
 		rgName:    rgName,
 		nodesInRG: nodeInRG,
 		replicas:  assignmentInfos,
+		nodeZone:  nodeZone,
 	}
 	return h
 }
@@ -93,21 +102,88 @@ type replicasInSameRGAssignmentHelper struct {
 	nodesInRG     typeutil.UniqueSet
 	incomingNodes typeutil.UniqueSet // nodes that not used by current replicas in resource group.
 	replicas      []*replicaAssignmentInfo
+	nodeZone      func(nodeID int64) string
 }
 
-func (h *replicasInSameRGAssignmentHelper) AllocateIncomingNodes(n int) []int64 {
-	nodeIDs := make([]int64, 0, n)
-	h.incomingNodes.Range(func(nodeID int64) bool {
-		if n > 0 {
-			nodeIDs = append(nodeIDs, nodeID)
-			n--
-		} else {
-			return false
+// AllocateIncomingNodes allocates the incoming nodes to the replicas, counts is the incoming node count of each replica.
+// The nodes in the zone of the replica are allocated first, then the nodes in other zones if there are not enough.
+func (h *replicasInSameRGAssignmentHelper) AllocateIncomingNodes(counts map[typeutil.UniqueID]int) map[typeutil.UniqueID][]int64 {
+	// allocate in stable order to avoid unnecessary node transfer.
+	incomingNodes := h.incomingNodes.Collect()
+	sort.Slice(incomingNodes, func(i, j int) bool { return incomingNodes[i] < incomingNodes[j] })
+	replicas := make([]*replicaAssignmentInfo, len(h.replicas))
+	copy(replicas, h.replicas)
+	sort.Slice(replicas, func(i, j int) bool { return replicas[i].replicaID < replicas[j].replicaID })
+
+	result := make(map[typeutil.UniqueID][]int64, len(counts))
+	allocate := func(sameZone bool) {
+		for _, info := range replicas {
+			for _, nodeID := range incomingNodes {
+				if len(result[info.replicaID]) >= counts[info.replicaID] {
+					break
+				}
+				if !h.incomingNodes.Contain(nodeID) || (sameZone && h.nodeZone(nodeID) != info.zone) {
+					continue
+				}
+				result[info.replicaID] = append(result[info.replicaID], nodeID)
+				h.incomingNodes.Remove(nodeID)
+			}
 		}
-		return true
-	})
-	h.incomingNodes.Remove(nodeIDs...)
-	return nodeIDs
+	}
+	allocate(true)
+	allocate(false)
+	return result
+}
+
+// updateZoneForReplicas decides the availability zone of each replica in the resource group.
+// The replicas are spread across the zones of the nodes evenly, so each replica is served by the nodes in one zone
+// as much as possible, and losing a zone doesn't lose all the replicas.
+func (h *replicasInSameRGAssignmentHelper) updateZoneForReplicas() {
+	nodeCountInZone := make(map[string]int)
+	for nodeID := range h.nodesInRG {
+		if zone := h.nodeZone(nodeID); zone != "" {
+			nodeCountInZone[zone]++
+		}
+	}
+	if len(nodeCountInZone) == 0 {
+		return
+	}
+	zones := lo.Keys(nodeCountInZone)
+	sort.Strings(zones)
+	maxReplicaInZone := (len(h.replicas) + len(zones) - 1) / len(zones)
+
+	// the replica keeps the zone where most of its nodes are, unless there are too many replicas in the zone.
+	// the replicas with more nodes in their zones are considered first.
+	sorter := make(replicaAssignmentInfoSorter, len(h.replicas))
+	copy(sorter, h.replicas)
+	sort.Sort(sort.Reverse(replicaAssignmentInfoSortByNodesInZone{sorter}))
+	replicaCountInZone := make(map[string]int)
+	unassigned := make([]*replicaAssignmentInfo, 0)
+	for _, info := range sorter {
+		info.zone = ""
+		for _, zone := range info.zonesByNodeCount() {
+			if zone != "" && replicaCountInZone[zone] < maxReplicaInZone {
+				info.zone = zone
+				replicaCountInZone[zone]++
+				break
+			}
+		}
+		if info.zone == "" {
+			unassigned = append(unassigned, info)
+		}
+	}
+	// the replica without zone is put into the zone with least replicas, then the most nodes.
+	for _, info := range unassigned {
+		target := ""
+		for _, zone := range zones {
+			if target == "" || replicaCountInZone[zone] < replicaCountInZone[target] ||
+				(replicaCountInZone[zone] == replicaCountInZone[target] && nodeCountInZone[zone] > nodeCountInZone[target]) {
+				target = zone
+			}
+		}
+		info.zone = target
+		replicaCountInZone[target]++
+	}
 }
 
 // RangeOverReplicas iterate replicas.
@@ -145,7 +221,7 @@ func (h *replicasInSameRGAssignmentHelper) updateExpectedNodeCountForReplicas(cu
 }
 
 // newReplicaAssignmentInfo creates a new replicaAssignmentInfo.
-func newReplicaAssignmentInfo(replica *Replica, nodeInRG typeutil.UniqueSet) *replicaAssignmentInfo {
+func newReplicaAssignmentInfo(replica *Replica, nodeInRG typeutil.UniqueSet, nodeZone func(nodeID int64) string) *replicaAssignmentInfo {
 	// node in replica can be split into 3 part.
 	rwNodes := make(typeutil.UniqueSet, replica.RWNodesCount())
 	newRONodes := make(typeutil.UniqueSet, replica.RONodesCount())
@@ -176,6 +252,7 @@ func newReplicaAssignmentInfo(replica *Replica, nodeInRG typeutil.UniqueSet) *re
 		newRONodes:           newRONodes,
 		recoverableRONodes:   recoverableRONodes,
 		unrecoverableRONodes: unrecoverableRONodes,
+		nodeZone:             nodeZone,
 	}
 }
 
@@ -186,6 +263,8 @@ type replicaAssignmentInfo struct {
 	newRONodes           typeutil.UniqueSet // new ro nodes for these replica. (rw -> ro)
 	recoverableRONodes   typeutil.UniqueSet // recoverable ro nodes for these replica (ro node can be put back to rw node if it's in current resource group). (may ro -> rw)
 	unrecoverableRONodes typeutil.UniqueSet // unrecoverable ro nodes for these replica (ro node can't be put back to rw node if it's not in current resource group). (ro -> ro)
+	zone                 string             // the availability zone of the replica, empty if the zones of nodes are unknown.
+	nodeZone             func(nodeID int64) string
 }
 
 // GetReplicaID returns the replica id for these replica.
@@ -202,36 +281,76 @@ func (s *replicaAssignmentInfo) GetNewRONodes() []int64 {
 	}
 
 	// too much node is occupied by current replica, then set some node to ro.
+	// the nodes out of the zone of replica are set to ro first.
 	if s.rwNodes.Len() > s.expectedNodeCount {
 		cnt := s.rwNodes.Len() - s.expectedNodeCount
-		s.rwNodes.Range(func(node int64) bool {
-			if cnt > 0 {
-				newRONodes = append(newRONodes, node)
-				cnt--
-			} else {
-				return false
-			}
-			return true
-		})
+		newRONodes = append(newRONodes, s.sortNodesByZone(s.rwNodes, false)[:cnt]...)
 	}
 	return newRONodes
 }
 
+// sortNodesByZone sorts the nodes by whether they are in the zone of replica, then by node id.
+func (s *replicaAssignmentInfo) sortNodesByZone(nodes typeutil.UniqueSet, inZoneFirst bool) []int64 {
+	sorted := nodes.Collect()
+	sort.Slice(sorted, func(i, j int) bool {
+		iInZone, jInZone := s.nodeZone(sorted[i]) == s.zone, s.nodeZone(sorted[j]) == s.zone
+		if iInZone != jInZone {
+			return iInZone == inZoneFirst
+		}
+		return sorted[i] < sorted[j]
+	})
+	return sorted
+}
+
+// zonesByNodeCount returns the zones of the nodes in replica, sorted by the node count in the zone.
+func (s *replicaAssignmentInfo) zonesByNodeCount() []string {
+	nodeCountInZone := make(map[string]int)
+	for _, nodes := range []typeutil.UniqueSet{s.rwNodes, s.recoverableRONodes} {
+		for nodeID := range nodes {
+			nodeCountInZone[s.nodeZone(nodeID)]++
+		}
+	}
+	zones := lo.Keys(nodeCountInZone)
+	sort.Slice(zones, func(i, j int) bool {
+		if nodeCountInZone[zones[i]] != nodeCountInZone[zones[j]] {
+			return nodeCountInZone[zones[i]] > nodeCountInZone[zones[j]]
+		}
+		return zones[i] < zones[j]
+	})
+	return zones
+}
+
+// nodeCountInZone returns the count of the nodes in the zone of replica.
+func (s *replicaAssignmentInfo) nodeCountInZone() int {
+	zones := s.zonesByNodeCount()
+	if len(zones) == 0 || zones[0] == "" {
+		return 0
+	}
+	count := 0
+	for _, nodes := range []typeutil.UniqueSet{s.rwNodes, s.recoverableRONodes} {
+		for nodeID := range nodes {
+			if s.nodeZone(nodeID) == zones[0] {
+				count++
+			}
+		}
+	}
+	return count
+}
+
 // GetRecoverNodesAndIncomingNodeCount returns the recoverable ro nodes and incoming node count for these replica.
 func (s *replicaAssignmentInfo) GetRecoverNodesAndIncomingNodeCount() (recoverNodes []int64, incomingNodeCount int) {
 	recoverNodes = make([]int64, 0, s.recoverableRONodes.Len())
 	incomingNodeCount = 0
 	if s.rwNodes.Len() < s.expectedNodeCount {
 		incomingNodeCount = s.expectedNodeCount - s.rwNodes.Len()
-		s.recoverableRONodes.Range(func(node int64) bool {
-			if incomingNodeCount > 0 {
-				recoverNodes = append(recoverNodes, node)
-				incomingNodeCount--
-			} else {
-				return false
+		// the nodes in the zone of replica are recovered first.
+		for _, node := range s.sortNodesByZone(s.recoverableRONodes, true) {
+			if incomingNodeCount == 0 {
+				break
 			}
-			return true
-		})
+			recoverNodes = append(recoverNodes, node)
+			incomingNodeCount--
+		}
 	}
 	return recoverNodes, incomingNodeCount
 }
@@ -270,3 +389,13 @@ func (s replicaAssignmentInfoSortByAvailableAndRecoverable) Less(i, j int) bool
 	// Otherwise unstable assignment may cause unnecessary node transfer.
 	return left < right || (left == right && s.replicaAssignmentInfoSorter[i].replicaID < s.replicaAssignmentInfoSorter[j].replicaID)
 }
+
+type replicaAssignmentInfoSortByNodesInZone struct {
+	replicaAssignmentInfoSorter
+}
+
+func (s replicaAssignmentInfoSortByNodesInZone) Less(i, j int) bool {
+	left := s.replicaAssignmentInfoSorter[i].nodeCountInZone()
+	right := s.replicaAssignmentInfoSorter[j].nodeCountInZone()
+	return left < right || (left == right && s.replicaAssignmentInfoSorter[i].replicaID > s.replicaAssignmentInfoSorter[j].replicaID)
+}
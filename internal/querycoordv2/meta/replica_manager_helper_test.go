@@ -21,6 +21,7 @@ type testCase struct {
 	rgs                      map[string]typeutil.UniqueSet             // from resource group to nodes
 	expectedPlan             map[typeutil.UniqueID]expectedReplicaPlan // from replica id to expected plan
 	expectedNewIncomingNodes map[string]typeutil.UniqueSet             // from resource group to incoming nodes
+	nodeZones                map[int64]string                          // from node to availability zone
 }
 
 type CollectionAssignmentHelperSuite struct {
@@ -457,8 +458,87 @@ func (s *CollectionAssignmentHelperSuite) TestMixRecoverNode() {
 	})
 }
 
+func (s *CollectionAssignmentHelperSuite) TestZoneAwareCase() {
+	c := testCase{
+		collectionID: 1,
+		rgToReplicas: map[string][]*Replica{
+			"rg1": {
+				newReplica(&querypb.Replica{
+					ID:           1,
+					CollectionID: 1,
+					Nodes:        []int64{1, 4},
+					RoNodes:      []int64{},
+				}),
+				newReplica(&querypb.Replica{
+					ID:           2,
+					CollectionID: 1,
+					Nodes:        []int64{2},
+					RoNodes:      []int64{},
+				}),
+			},
+		},
+		rgs: map[string]typeutil.UniqueSet{
+			"rg1": typeutil.NewUniqueSet(1, 2, 3, 4, 5, 6),
+		},
+		expectedPlan: map[typeutil.UniqueID]expectedReplicaPlan{
+			1: {
+				newRONodes:        0,
+				recoverNodes:      0,
+				incomingNodeCount: 1,
+				expectedNodeCount: 3,
+			},
+			2: {
+				newRONodes:        0,
+				recoverNodes:      0,
+				incomingNodeCount: 2,
+				expectedNodeCount: 3,
+			},
+		},
+		expectedNewIncomingNodes: map[string]typeutil.UniqueSet{
+			"rg1": typeutil.NewUniqueSet(3, 5, 6),
+		},
+		nodeZones: map[int64]string{
+			1: "az1", 2: "az1", 3: "az1",
+			4: "az2", 5: "az2", 6: "az2",
+		},
+	}
+	s.runCase(c)
+
+	cHelper := newCollectionAssignmentHelper(c.collectionID, c.rgToReplicas, c.rgs, func(nodeID int64) string {
+		return c.nodeZones[nodeID]
+	})
+	cHelper.RangeOverResourceGroup(func(rHelper *replicasInSameRGAssignmentHelper) {
+		// the replicas are spread across the zones.
+		zones := make(map[typeutil.UniqueID]string)
+		rHelper.RangeOverReplicas(func(assignment *replicaAssignmentInfo) {
+			zones[assignment.GetReplicaID()] = assignment.zone
+		})
+		s.Equal(map[typeutil.UniqueID]string{1: "az1", 2: "az2"}, zones)
+
+		// the incoming nodes in the zone of replica are allocated first.
+		incomingNodes := rHelper.AllocateIncomingNodes(map[typeutil.UniqueID]int{1: 1, 2: 2})
+		s.ElementsMatch([]int64{3}, incomingNodes[1])
+		s.ElementsMatch([]int64{5, 6}, incomingNodes[2])
+		s.Equal(0, rHelper.incomingNodes.Len())
+	})
+
+	// the nodes out of the zone of replica are set to ro first.
+	assignment := newReplicaAssignmentInfo(newReplica(&querypb.Replica{
+		ID:           3,
+		CollectionID: 1,
+		Nodes:        []int64{1, 4, 5},
+	}), typeutil.NewUniqueSet(1, 4, 5), func(nodeID int64) string {
+		return c.nodeZones[nodeID]
+	})
+	assignment.zone = "az2"
+	assignment.expectedNodeCount = 2
+	s.Equal([]int64{1}, assignment.GetNewRONodes())
+}
+
 func (s *CollectionAssignmentHelperSuite) runCase(c testCase) {
-	cHelper := newCollectionAssignmentHelper(c.collectionID, c.rgToReplicas, c.rgs)
+	cHelper := newCollectionAssignmentHelper(c.collectionID, c.rgToReplicas, c.rgs, func(nodeID int64) string {
+		return c.nodeZones[nodeID]
+	})
 	cHelper.RangeOverResourceGroup(func(rHelper *replicasInSameRGAssignmentHelper) {
 		s.ElementsMatch(c.expectedNewIncomingNodes[rHelper.rgName].Collect(), rHelper.incomingNodes.Collect())
 		rHelper.RangeOverReplicas(func(assignment *replicaAssignmentInfo) {
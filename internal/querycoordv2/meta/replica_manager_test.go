@@ -107,7 +107,7 @@ func (suite *ReplicaManagerSuite) SetupTest() {
 	suite.catalog = querycoord.NewCatalog(suite.kv)
 
 	suite.idAllocator = RandomIncrementIDAllocator()
-	suite.mgr = NewReplicaManager(suite.idAllocator, suite.catalog)
+	suite.mgr = NewReplicaManager(suite.idAllocator, suite.catalog, nil)
 	suite.spawnAll()
 }
 
@@ -306,7 +306,7 @@ func (suite *ReplicaManagerSuite) spawnAll() {
 }
 
 func (suite *ReplicaManagerSuite) TestResourceGroup() {
-	mgr := NewReplicaManager(suite.idAllocator, suite.catalog)
+	mgr := NewReplicaManager(suite.idAllocator, suite.catalog, nil)
 	ctx := suite.ctx
 	replicas1, err := mgr.Spawn(ctx, int64(1000), map[string]int{DefaultResourceGroupName: 1}, nil)
 	suite.NoError(err)
@@ -386,7 +386,7 @@ func (suite *ReplicaManagerV2Suite) SetupSuite() {
 	suite.catalog = querycoord.NewCatalog(suite.kv)
 
 	idAllocator := RandomIncrementIDAllocator()
-	suite.mgr = NewReplicaManager(idAllocator, suite.catalog)
+	suite.mgr = NewReplicaManager(idAllocator, suite.catalog, nil)
 	suite.ctx = context.Background()
 }
 
@@ -522,7 +522,7 @@ func TestGetReplicasJSON(t *testing.T) {
 	catalog := mocks.NewQueryCoordCatalog(t)
 	catalog.EXPECT().SaveReplica(mock.Anything, mock.Anything).Return(nil)
 	idAllocator := RandomIncrementIDAllocator()
-	replicaManager := NewReplicaManager(idAllocator, catalog)
+	replicaManager := NewReplicaManager(idAllocator, catalog, nil)
 	ctx := context.Background()
 
 	// Add some replicas to the ReplicaManager
@@ -39,6 +39,7 @@ import (
 	"github.com/milvus-io/milvus-proto/go-api/v2/milvuspb"
 	"github.com/milvus-io/milvus/internal/allocator"
 	etcdkv "github.com/milvus-io/milvus/internal/kv/etcd"
+	sqlitekv "github.com/milvus-io/milvus/internal/kv/sqlite"
 	"github.com/milvus-io/milvus/internal/kv/tikv"
 	"github.com/milvus-io/milvus/internal/metastore"
 	"github.com/milvus-io/milvus/internal/metastore/kv/querycoord"
@@ -307,6 +308,15 @@ func (s *Server) initQueryCoord() error {
 		s.kv = etcdkv.NewEtcdKV(s.etcdCli, Params.EtcdCfg.MetaRootPath.GetValue(),
 			etcdkv.WithRequestTimeout(paramtable.Get().ServiceParam.EtcdCfg.RequestTimeout.GetAsDuration(time.Millisecond)))
 		idAllocatorKV = tsoutil.NewTSOKVBase(s.etcdCli, Params.EtcdCfg.KvRootPath.GetValue(), "querycoord-id-allocator")
+	} else if metaType == util.MetaStoreTypeSQLite {
+		db, err := sqlitekv.GetSharedDB(Params.SQLiteCfg.Path.GetValue())
+		if err != nil {
+			log.Error("query coordinator open sqlite failed", zap.Error(err))
+			return err
+		}
+		s.kv = sqlitekv.NewSQLiteKV(db, Params.SQLiteCfg.MetaRootPath.GetValue(),
+			sqlitekv.WithRequestTimeout(paramtable.Get().ServiceParam.SQLiteCfg.RequestTimeout.GetAsDuration(time.Millisecond)))
+		idAllocatorKV = tsoutil.NewTSOSQLiteBase(db, Params.SQLiteCfg.KvRootPath.GetValue(), "querycoord-id-allocator")
 	} else {
 		return fmt.Errorf("not supported meta store: %s", metaType)
 	}
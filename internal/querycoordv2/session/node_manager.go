@@ -24,6 +24,7 @@ import (
 	"github.com/blang/semver/v4"
 	"go.uber.org/atomic"
 
+	"github.com/milvus-io/milvus/internal/util/sessionutil"
 	"github.com/milvus-io/milvus/pkg/metrics"
 )
 
@@ -152,6 +153,11 @@ func (n *NodeInfo) Labels() map[string]string {
 	return n.immutableInfo.Labels
 }
 
+// Zone returns the availability zone of the node, empty if it's unknown.
+func (n *NodeInfo) Zone() string {
+	return n.immutableInfo.Labels[sessionutil.LabelZone]
+}
+
 func (n *NodeInfo) SegmentCnt() int {
 	n.mu.RLock()
 	defer n.mu.RUnlock()
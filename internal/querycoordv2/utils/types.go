@@ -90,6 +90,7 @@ func PackSegmentLoadInfo(segment *datapb.SegmentInfo, channelCheckpoint *msgpb.M
 		StorageVersion: segment.GetStorageVersion(),
 		IsSorted:       segment.GetIsSorted(),
 		TextStatsLogs:  segment.GetTextStatsLogs(),
+		FieldStatslogs: segment.GetFieldStatslogs(),
 	}
 	return loadInfo
 }
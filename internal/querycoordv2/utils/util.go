@@ -140,11 +140,13 @@ func GetShardLeadersWithChannels(ctx context.Context, m *meta.Meta, targetMgr me
 		readableLeaders = filterDupLeaders(ctx, m.ReplicaManager, readableLeaders)
 		ids := make([]int64, 0, len(leaders))
 		addrs := make([]string, 0, len(leaders))
+		zones := make([]string, 0, len(leaders))
 		for _, leader := range readableLeaders {
 			info := nodeMgr.Get(leader.ID)
 			if info != nil {
 				ids = append(ids, info.ID())
 				addrs = append(addrs, info.Addr())
+				zones = append(zones, info.Zone())
 			}
 		}
 
@@ -163,6 +165,7 @@ func GetShardLeadersWithChannels(ctx context.Context, m *meta.Meta, targetMgr me
 			ChannelName: channel.GetChannelName(),
 			NodeIds:     ids,
 			NodeAddrs:   addrs,
+			NodeZones:   zones,
 		})
 	}
 
@@ -140,6 +140,8 @@ type shardDelegator struct {
 	// in order to make add/remove growing be atomic, need lock before modify these meta info
 	growingSegmentLock sync.RWMutex
 	partitionStatsMut  sync.RWMutex
+	// segmentID -> field stats of the sealed segment, for the fields with field stats enabled
+	fieldStats *typeutil.ConcurrentMap[UniqueID, segmentFieldStats]
 
 	// fieldId -> functionRunner map for search function field
 	functionRunners map[UniqueID]function.FunctionRunner
@@ -268,6 +270,16 @@ func (sd *shardDelegator) modifyQueryRequest(req *querypb.QueryRequest, scope qu
 	return nodeReq
 }
 
+// applyTTLFilter hides the rows expired at the mvcc timestamp of the request
+// if the collection declares a ttl field.
+func (sd *shardDelegator) applyTTLFilter(serializedPlan []byte, mvccTs uint64) ([]byte, error) {
+	ttlField := typeutil.GetTTLField(sd.collection.Schema())
+	if ttlField == nil || len(serializedPlan) == 0 {
+		return serializedPlan, nil
+	}
+	return SetTTLFilter(serializedPlan, ttlField, tsoutil.PhysicalTime(mvccTs).Unix())
+}
+
 // Search preforms search operation on shard.
 func (sd *shardDelegator) search(ctx context.Context, req *querypb.SearchRequest, sealed []SnapshotItem, growing []SegmentEntry) ([]*internalpb.SearchResults, error) {
 	log := sd.getLogger(ctx)
@@ -282,6 +294,7 @@ func (sd *shardDelegator) search(ctx context.Context, req *querypb.SearchRequest
 			PruneSegments(ctx, sd.partitionStats, req.GetReq(), nil, sd.collection.Schema(), sealed,
 				PruneInfo{filterRatio: paramtable.Get().QueryNodeCfg.DefaultSegmentFilterRatio.GetAsFloat()})
 		}()
+		sd.pruneSegmentsByFieldStats(ctx, req.GetReq().GetSerializedExprPlan(), sealed)
 	}
 
 	searchAgainstBM25Field := sd.isBM25Field[req.GetReq().GetFieldId()]
@@ -302,6 +315,13 @@ func (sd *shardDelegator) search(ctx context.Context, req *querypb.SearchRequest
 		}
 	}
 
+	plan, err := sd.applyTTLFilter(req.GetReq().GetSerializedExprPlan(), req.GetReq().GetMvccTimestamp())
+	if err != nil {
+		log.Warn("failed to apply ttl filter", zap.Error(err))
+		return nil, err
+	}
+	req.Req.SerializedExprPlan = plan
+
 	// get final sealedNum after possible segment prune
 	sealedNum := lo.SumBy(sealed, func(item SnapshotItem) int { return len(item.Segments) })
 	log.Debug("search segments...",
@@ -309,7 +329,7 @@ func (sd *shardDelegator) search(ctx context.Context, req *querypb.SearchRequest
 		zap.Int("growingNum", len(growing)),
 	)
 
-	req, err := optimizers.OptimizeSearchParams(ctx, req, sd.queryHook, sealedNum)
+	req, err = optimizers.OptimizeSearchParams(ctx, req, sd.queryHook, sealedNum)
 	if err != nil {
 		log.Warn("failed to optimize search params", zap.Error(err))
 		return nil, err
@@ -469,6 +489,13 @@ func (sd *shardDelegator) QueryStream(ctx context.Context, req *querypb.QueryReq
 		fmt.Sprint(paramtable.GetNodeID()), metrics.QueryLabel).
 		Observe(float64(waitTr.ElapseSpan().Milliseconds()))
 
+	plan, err := sd.applyTTLFilter(req.GetReq().GetSerializedExprPlan(), req.GetReq().GetMvccTimestamp())
+	if err != nil {
+		log.Warn("failed to apply ttl filter", zap.Error(err))
+		return err
+	}
+	req.Req.SerializedExprPlan = plan
+
 	sealed, growing, version, err := sd.distribution.PinReadableSegments(req.GetReq().GetPartitionIDs()...)
 	if err != nil {
 		log.Warn("delegator failed to query, current distribution is not serviceable", zap.Error(err))
@@ -532,6 +559,13 @@ func (sd *shardDelegator) Query(ctx context.Context, req *querypb.QueryRequest)
 		fmt.Sprint(paramtable.GetNodeID()), metrics.QueryLabel).
 		Observe(float64(waitTr.ElapseSpan().Milliseconds()))
 
+	plan, err := sd.applyTTLFilter(req.GetReq().GetSerializedExprPlan(), req.GetReq().GetMvccTimestamp())
+	if err != nil {
+		log.Warn("failed to apply ttl filter", zap.Error(err))
+		return nil, err
+	}
+	req.Req.SerializedExprPlan = plan
+
 	sealed, growing, version, err := sd.distribution.PinReadableSegments(req.GetReq().GetPartitionIDs()...)
 	if err != nil {
 		log.Warn("delegator failed to query, current distribution is not serviceable", zap.Error(err))
@@ -549,6 +583,7 @@ func (sd *shardDelegator) Query(ctx context.Context, req *querypb.QueryRequest)
 			defer sd.partitionStatsMut.RUnlock()
 			PruneSegments(ctx, sd.partitionStats, nil, req.GetReq(), sd.collection.Schema(), sealed, PruneInfo{paramtable.Get().QueryNodeCfg.DefaultSegmentFilterRatio.GetAsFloat()})
 		}()
+		sd.pruneSegmentsByFieldStats(ctx, req.GetReq().GetSerializedExprPlan(), sealed)
 	}
 
 	sealedNum := lo.SumBy(sealed, func(item SnapshotItem) int { return len(item.Segments) })
@@ -931,6 +966,7 @@ func NewShardDelegator(ctx context.Context, collectionID UniqueID, replicaID Uni
 		queryHook:        queryHook,
 		chunkManager:     chunkManager,
 		partitionStats:   make(map[UniqueID]*storage.PartitionStatsSnapshot),
+		fieldStats:       typeutil.NewConcurrentMap[UniqueID, segmentFieldStats](),
 		excludedSegments: excludedSegments,
 		functionRunners:  make(map[int64]function.FunctionRunner),
 		isBM25Field:      make(map[int64]bool),
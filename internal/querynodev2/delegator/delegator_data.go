@@ -509,6 +509,8 @@ func (sd *shardDelegator) LoadSegments(ctx context.Context, req *querypb.LoadSeg
 			log.Warn("load stream delete failed", zap.Error(err))
 			return err
 		}
+
+		sd.loadFieldStats(ctx, infos)
 	}
 
 	// alter distribution
@@ -938,6 +940,9 @@ func (sd *shardDelegator) ReleaseSegments(ctx context.Context, req *querypb.Rele
 	if hasLevel0 {
 		sd.RefreshLevel0DeletionStats()
 	}
+	if len(sealed) > 0 {
+		sd.removeFieldStats(lo.Map(sealed, func(entry SegmentEntry, _ int) int64 { return entry.SegmentID })...)
+	}
 	partitionsToReload := make([]UniqueID, 0)
 	lo.ForEach(req.GetSegmentIDs(), func(segmentID int64, _ int) {
 		segment := sd.segmentManager.Get(segmentID)
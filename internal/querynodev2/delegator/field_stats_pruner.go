@@ -0,0 +1,339 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package delegator
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/samber/lo"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/schemapb"
+	"github.com/milvus-io/milvus/internal/proto/datapb"
+	"github.com/milvus-io/milvus/internal/proto/planpb"
+	"github.com/milvus-io/milvus/internal/proto/querypb"
+	"github.com/milvus-io/milvus/internal/storage"
+	"github.com/milvus-io/milvus/internal/util/exprutil"
+	"github.com/milvus-io/milvus/pkg/metrics"
+	"github.com/milvus-io/milvus/pkg/util/paramtable"
+)
+
+// segmentFieldStats is the field stats of a sealed segment, fieldID => stats of each sync batch.
+type segmentFieldStats map[int64][]*storage.FieldStats
+
+// As field stats are an optimization like partition stats, loading them is a try-best process,
+// the segments without complete field stats are never pruned.
+func (sd *shardDelegator) loadFieldStats(ctx context.Context, infos []*querypb.SegmentLoadInfo) {
+	log := sd.getLogger(ctx)
+	for _, info := range infos {
+		if len(info.GetFieldStatslogs()) == 0 {
+			continue
+		}
+		stats, err := sd.readFieldStats(ctx, info)
+		if err != nil {
+			log.Warn("failed to load field stats, skip the segment", zap.Int64("segmentID", info.GetSegmentID()), zap.Error(err))
+			continue
+		}
+		sd.fieldStats.Insert(info.GetSegmentID(), stats)
+	}
+}
+
+// readFieldStats reads the field stats of the segment, it fails unless the stats of every field cover all the rows
+// of the segment, e.g. the stats logs of some syncs are missing.
+func (sd *shardDelegator) readFieldStats(ctx context.Context, info *querypb.SegmentLoadInfo) (segmentFieldStats, error) {
+	stats := make(segmentFieldStats)
+	for _, fieldBinlog := range info.GetFieldStatslogs() {
+		rows := lo.SumBy(fieldBinlog.GetBinlogs(), func(binlog *datapb.Binlog) int64 { return binlog.GetEntriesNum() })
+		if rows < info.GetNumOfRows() {
+			return nil, fmt.Errorf("field stats of field %d cover %d rows of %d", fieldBinlog.GetFieldID(), rows, info.GetNumOfRows())
+		}
+		paths := lo.Map(fieldBinlog.GetBinlogs(), func(binlog *datapb.Binlog, _ int) string { return binlog.GetLogPath() })
+		values, err := sd.chunkManager.MultiRead(ctx, paths)
+		if err != nil {
+			return nil, err
+		}
+		fieldStats := make([]*storage.FieldStats, 0, len(values))
+		for _, value := range values {
+			batchStats, err := storage.DeserializeFieldStats(&storage.Blob{Value: value})
+			if err != nil {
+				return nil, err
+			}
+			fieldStats = append(fieldStats, batchStats...)
+		}
+		stats[fieldBinlog.GetFieldID()] = fieldStats
+	}
+	return stats, nil
+}
+
+// removeFieldStats removes the field stats of the segments which are not in the distribution anymore.
+func (sd *shardDelegator) removeFieldStats(segmentIDs ...int64) {
+	sealed, _ := sd.distribution.PeekSegments(false)
+	online := make(map[int64]struct{})
+	for _, item := range sealed {
+		for _, segment := range item.Segments {
+			online[segment.SegmentID] = struct{}{}
+		}
+	}
+	for _, segmentID := range segmentIDs {
+		if _, ok := online[segmentID]; !ok {
+			sd.fieldStats.Remove(segmentID)
+		}
+	}
+}
+
+// pruneSegmentsByFieldStats removes the sealed segments which cannot match the filter of the request
+// according to the min/max and bloom filter of the fields with field stats enabled.
+func (sd *shardDelegator) pruneSegmentsByFieldStats(ctx context.Context, serializedPlan []byte, sealed []SnapshotItem) {
+	if sd.fieldStats.Len() == 0 || len(serializedPlan) == 0 {
+		return
+	}
+	plan := planpb.PlanNode{}
+	if err := proto.Unmarshal(serializedPlan, &plan); err != nil {
+		return
+	}
+	expr, err := exprutil.ParseExprFromPlan(&plan)
+	if err != nil || expr == nil {
+		return
+	}
+
+	total, pruned := PruneSegmentsByFieldStats(expr, sealed, func(segmentID int64) (segmentFieldStats, bool) {
+		return sd.fieldStats.Get(segmentID)
+	})
+	if total == 0 {
+		return
+	}
+	metrics.QueryNodeSegmentPruneRatio.
+		WithLabelValues(fmt.Sprint(paramtable.GetNodeID()), fmt.Sprint(sd.collectionID), "field_stats").
+		Set(float64(pruned) / float64(total))
+	sd.getLogger(ctx).Debug("Pruned segment by field stats",
+		zap.Int("filtered_segment_num", pruned),
+		zap.Int("total_segment_num", total))
+}
+
+// PruneSegmentsByFieldStats removes the segments which cannot match the expr from sealed,
+// returns the total number of sealed segments and the number of the removed ones.
+func PruneSegmentsByFieldStats(expr *planpb.Expr, sealed []SnapshotItem, getStats func(segmentID int64) (segmentFieldStats, bool)) (int, int) {
+	total, pruned := 0, 0
+	for idx, item := range sealed {
+		total += len(item.Segments)
+		newSegments := make([]SegmentEntry, 0, len(item.Segments))
+		for _, segment := range item.Segments {
+			stats, ok := getStats(segment.SegmentID)
+			if ok && !mayMatch(expr, stats) {
+				pruned++
+				continue
+			}
+			newSegments = append(newSegments, segment)
+		}
+		item.Segments = newSegments
+		sealed[idx] = item
+	}
+	return total, pruned
+}
+
+// mayMatch returns false only if no row of the segment matches the expr.
+func mayMatch(expr *planpb.Expr, stats segmentFieldStats) bool {
+	switch e := expr.GetExpr().(type) {
+	case *planpb.Expr_BinaryExpr:
+		switch e.BinaryExpr.GetOp() {
+		case planpb.BinaryExpr_LogicalAnd:
+			return mayMatch(e.BinaryExpr.GetLeft(), stats) && mayMatch(e.BinaryExpr.GetRight(), stats)
+		case planpb.BinaryExpr_LogicalOr:
+			return mayMatch(e.BinaryExpr.GetLeft(), stats) || mayMatch(e.BinaryExpr.GetRight(), stats)
+		}
+	case *planpb.Expr_UnaryRangeExpr:
+		return anyBatchMayMatch(e.UnaryRangeExpr.GetColumnInfo(), stats, func(s *storage.FieldStats) bool {
+			return unaryRangeMayMatch(s, e.UnaryRangeExpr.GetOp(), e.UnaryRangeExpr.GetValue())
+		})
+	case *planpb.Expr_BinaryRangeExpr:
+		br := e.BinaryRangeExpr
+		return anyBatchMayMatch(br.GetColumnInfo(), stats, func(s *storage.FieldStats) bool {
+			lowerOp, upperOp := planpb.OpType_GreaterThan, planpb.OpType_LessThan
+			if br.GetLowerInclusive() {
+				lowerOp = planpb.OpType_GreaterEqual
+			}
+			if br.GetUpperInclusive() {
+				upperOp = planpb.OpType_LessEqual
+			}
+			return unaryRangeMayMatch(s, lowerOp, br.GetLowerValue()) && unaryRangeMayMatch(s, upperOp, br.GetUpperValue())
+		})
+	case *planpb.Expr_TermExpr:
+		if e.TermExpr.GetIsInField() {
+			return true
+		}
+		return anyBatchMayMatch(e.TermExpr.GetColumnInfo(), stats, func(s *storage.FieldStats) bool {
+			return lo.ContainsBy(e.TermExpr.GetValues(), func(value *planpb.GenericValue) bool {
+				return unaryRangeMayMatch(s, planpb.OpType_Equal, value)
+			})
+		})
+	}
+	return true
+}
+
+func anyBatchMayMatch(column *planpb.ColumnInfo, stats segmentFieldStats, fn func(s *storage.FieldStats) bool) bool {
+	if len(column.GetNestedPath()) > 0 {
+		return true
+	}
+	fieldStats, ok := stats[column.GetFieldId()]
+	if !ok {
+		return true
+	}
+	return lo.ContainsBy(fieldStats, fn)
+}
+
+func unaryRangeMayMatch(s *storage.FieldStats, op planpb.OpType, value *planpb.GenericValue) bool {
+	if value == nil || s.Min == nil || s.Max == nil {
+		return true
+	}
+	switch op {
+	case planpb.OpType_Equal:
+		minCmp, ok1 := compareFieldValue(s.Type, s.Min, value)
+		maxCmp, ok2 := compareFieldValue(s.Type, s.Max, value)
+		if !ok1 || !ok2 {
+			return true
+		}
+		if minCmp > 0 || maxCmp < 0 {
+			return false
+		}
+		fieldValue, ok := toFieldValue(s.Type, value)
+		return !ok || s.MayContain(fieldValue)
+	case planpb.OpType_GreaterThan, planpb.OpType_GreaterEqual:
+		order, ok := compareFieldValue(s.Type, s.Max, value)
+		return !ok || order > 0 || (order == 0 && op == planpb.OpType_GreaterEqual)
+	case planpb.OpType_LessThan, planpb.OpType_LessEqual:
+		order, ok := compareFieldValue(s.Type, s.Min, value)
+		return !ok || order < 0 || (order == 0 && op == planpb.OpType_LessEqual)
+	case planpb.OpType_PrefixMatch:
+		prefix, ok := value.GetVal().(*planpb.GenericValue_StringVal)
+		minValue, ok1 := s.Min.GetValue().(string)
+		maxValue, ok2 := s.Max.GetValue().(string)
+		if !ok || !ok1 || !ok2 {
+			return true
+		}
+		// the strings with the prefix are in [prefix, prefix+"\xff\xff...")
+		p := prefix.StringVal
+		return maxValue >= p && (minValue <= p || strings.HasPrefix(minValue, p))
+	}
+	return true
+}
+
+// compareFieldValue compares the field value with the value of the expr, returns false if they are not comparable.
+func compareFieldValue(dataType schemapb.DataType, fieldValue storage.ScalarFieldValue, value *planpb.GenericValue) (int, bool) {
+	switch v := fieldValue.GetValue().(type) {
+	case int8, int16, int32, int64:
+		i := toInt64(v)
+		switch val := value.GetVal().(type) {
+		case *planpb.GenericValue_Int64Val:
+			return cmp.Compare(i, val.Int64Val), true
+		case *planpb.GenericValue_FloatVal:
+			return cmp.Compare(float64(i), val.FloatVal), true
+		}
+	case float32, float64:
+		var f float64
+		if fv, ok := v.(float32); ok {
+			f = float64(fv)
+		} else {
+			f = v.(float64)
+		}
+		var target float64
+		switch val := value.GetVal().(type) {
+		case *planpb.GenericValue_Int64Val:
+			target = float64(val.Int64Val)
+		case *planpb.GenericValue_FloatVal:
+			target = val.FloatVal
+		default:
+			return 0, false
+		}
+		// the float field is compared in float32 precision by segcore
+		if dataType == schemapb.DataType_Float {
+			target = float64(float32(target))
+		}
+		if math.IsNaN(f) || math.IsNaN(target) {
+			return 0, false
+		}
+		return cmp.Compare(f, target), true
+	case string:
+		if val, ok := value.GetVal().(*planpb.GenericValue_StringVal); ok {
+			return strings.Compare(v, val.StringVal), true
+		}
+	}
+	return 0, false
+}
+
+// toFieldValue converts the value of the expr into the field type to test the bloom filter.
+func toFieldValue(dataType schemapb.DataType, value *planpb.GenericValue) (storage.ScalarFieldValue, bool) {
+	switch dataType {
+	case schemapb.DataType_Int8, schemapb.DataType_Int16, schemapb.DataType_Int32, schemapb.DataType_Int64:
+		val, ok := value.GetVal().(*planpb.GenericValue_Int64Val)
+		if !ok {
+			return nil, false
+		}
+		// the value is in range of the type since it is between min and max
+		switch dataType {
+		case schemapb.DataType_Int8:
+			return storage.NewInt8FieldValue(int8(val.Int64Val)), true
+		case schemapb.DataType_Int16:
+			return storage.NewInt16FieldValue(int16(val.Int64Val)), true
+		case schemapb.DataType_Int32:
+			return storage.NewInt32FieldValue(int32(val.Int64Val)), true
+		default:
+			return storage.NewInt64FieldValue(val.Int64Val), true
+		}
+	case schemapb.DataType_Float, schemapb.DataType_Double:
+		var f float64
+		switch val := value.GetVal().(type) {
+		case *planpb.GenericValue_Int64Val:
+			f = float64(val.Int64Val)
+		case *planpb.GenericValue_FloatVal:
+			f = val.FloatVal
+		default:
+			return nil, false
+		}
+		if dataType == schemapb.DataType_Float {
+			return storage.NewFloatFieldValue(float32(f)), true
+		}
+		return storage.NewDoubleFieldValue(f), true
+	case schemapb.DataType_VarChar, schemapb.DataType_String:
+		val, ok := value.GetVal().(*planpb.GenericValue_StringVal)
+		if !ok {
+			return nil, false
+		}
+		if dataType == schemapb.DataType_String {
+			return storage.NewStringFieldValue(val.StringVal), true
+		}
+		return storage.NewVarCharFieldValue(val.StringVal), true
+	}
+	return nil, false
+}
+
+func toInt64(v any) int64 {
+	switch i := v.(type) {
+	case int8:
+		return int64(i)
+	case int16:
+		return int64(i)
+	case int32:
+		return int64(i)
+	default:
+		return i.(int64)
+	}
+}
@@ -0,0 +1,210 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package delegator
+
+import (
+	"context"
+	"path"
+	"testing"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
+	"github.com/milvus-io/milvus-proto/go-api/v2/schemapb"
+	"github.com/milvus-io/milvus/internal/parser/planparserv2"
+	"github.com/milvus-io/milvus/internal/proto/datapb"
+	"github.com/milvus-io/milvus/internal/proto/querypb"
+	"github.com/milvus-io/milvus/internal/storage"
+	"github.com/milvus-io/milvus/internal/util/exprutil"
+	"github.com/milvus-io/milvus/pkg/common"
+	"github.com/milvus-io/milvus/pkg/util/paramtable"
+	"github.com/milvus-io/milvus/pkg/util/typeutil"
+)
+
+const (
+	tenantFieldID = 101
+	ageFieldID    = 102
+	scoreFieldID  = 103
+)
+
+type FieldStatsPrunerSuite struct {
+	suite.Suite
+	schema     *schemapb.CollectionSchema
+	fieldStats map[int64]segmentFieldStats
+}
+
+func (s *FieldStatsPrunerSuite) SetupSuite() {
+	paramtable.Init()
+	fieldStatsParams := []*commonpb.KeyValuePair{{Key: common.FieldStatsKey, Value: common.FieldStatsBloom}}
+	s.schema = &schemapb.CollectionSchema{
+		Name: "test_field_stats_prune",
+		Fields: []*schemapb.FieldSchema{
+			{FieldID: 100, Name: "pk", DataType: schemapb.DataType_Int64, IsPrimaryKey: true},
+			{
+				FieldID: tenantFieldID, Name: "tenant_id", DataType: schemapb.DataType_VarChar,
+				TypeParams: append([]*commonpb.KeyValuePair{{Key: common.MaxLengthKey, Value: "64"}}, fieldStatsParams...),
+			},
+			{FieldID: ageFieldID, Name: "age", DataType: schemapb.DataType_Int64, TypeParams: fieldStatsParams},
+			{FieldID: scoreFieldID, Name: "score", DataType: schemapb.DataType_Float, TypeParams: fieldStatsParams},
+			{
+				FieldID: 104, Name: "vec", DataType: schemapb.DataType_FloatVector,
+				TypeParams: []*commonpb.KeyValuePair{{Key: common.DimKey, Value: "8"}},
+			},
+		},
+	}
+
+	newStats := func(fieldID int64, dataType schemapb.DataType, values ...storage.ScalarFieldValue) *storage.FieldStats {
+		stats, err := storage.NewFieldStats(fieldID, dataType, int64(len(values)))
+		s.Require().NoError(err)
+		for _, value := range values {
+			stats.Update(value)
+		}
+		return stats
+	}
+	s.fieldStats = map[int64]segmentFieldStats{
+		1: {
+			tenantFieldID: {
+				newStats(tenantFieldID, schemapb.DataType_VarChar, storage.NewVarCharFieldValue("t1")),
+				newStats(tenantFieldID, schemapb.DataType_VarChar, storage.NewVarCharFieldValue("t3")),
+			},
+			ageFieldID:   {newStats(ageFieldID, schemapb.DataType_Int64, storage.NewInt64FieldValue(1), storage.NewInt64FieldValue(10))},
+			scoreFieldID: {newStats(scoreFieldID, schemapb.DataType_Float, storage.NewFloatFieldValue(0.1), storage.NewFloatFieldValue(0.5))},
+		},
+		2: {
+			tenantFieldID: {newStats(tenantFieldID, schemapb.DataType_VarChar, storage.NewVarCharFieldValue("u5"))},
+			ageFieldID:    {newStats(ageFieldID, schemapb.DataType_Int64, storage.NewInt64FieldValue(20), storage.NewInt64FieldValue(30))},
+		},
+		// segment 3 has no field stats
+	}
+}
+
+func (s *FieldStatsPrunerSuite) prune(exprStr string) []int64 {
+	schemaHelper, err := typeutil.CreateSchemaHelper(s.schema)
+	s.Require().NoError(err)
+	plan, err := planparserv2.CreateRetrievePlan(schemaHelper, exprStr, nil)
+	s.Require().NoError(err)
+	expr, err := exprutil.ParseExprFromPlan(plan)
+	s.Require().NoError(err)
+
+	sealed := []SnapshotItem{
+		{NodeID: 1, Segments: []SegmentEntry{{SegmentID: 1}, {SegmentID: 2}}},
+		{NodeID: 2, Segments: []SegmentEntry{{SegmentID: 3}}},
+	}
+	total, pruned := PruneSegmentsByFieldStats(expr, sealed, func(segmentID int64) (segmentFieldStats, bool) {
+		stats, ok := s.fieldStats[segmentID]
+		return stats, ok
+	})
+	s.Equal(3, total)
+
+	segmentIDs := lo.FlatMap(sealed, func(item SnapshotItem, _ int) []int64 {
+		return lo.Map(item.Segments, func(segment SegmentEntry, _ int) int64 { return segment.SegmentID })
+	})
+	s.Equal(total-pruned, len(segmentIDs))
+	return segmentIDs
+}
+
+func (s *FieldStatsPrunerSuite) TestPrune() {
+	cases := []struct {
+		expr     string
+		expected []int64
+	}{
+		{`tenant_id == "t1"`, []int64{1, 3}},
+		{`tenant_id == "v1"`, []int64{3}},
+		{`tenant_id in ["a", "u5"]`, []int64{2, 3}},
+		{`tenant_id like "u%"`, []int64{2, 3}},
+		{`tenant_id like "t%"`, []int64{1, 3}},
+		{`tenant_id like "%1"`, []int64{1, 2, 3}},
+		{`tenant_id != "t1"`, []int64{1, 2, 3}},
+		{`age > 10`, []int64{2, 3}},
+		{`age >= 10`, []int64{1, 2, 3}},
+		{`age < 1`, []int64{3}},
+		{`age <= 1`, []int64{1, 3}},
+		{`10 < age < 20`, []int64{3}},
+		{`10 <= age < 20`, []int64{1, 3}},
+		{`age == 30`, []int64{2, 3}},
+		{`score > 0.5`, []int64{2, 3}},
+		{`score == 0.1`, []int64{1, 2, 3}},
+		{`tenant_id == "t1" or age == 30`, []int64{1, 2, 3}},
+		{`tenant_id == "t1" and age == 30`, []int64{3}},
+		{`tenant_id == "t1" and pk > 100`, []int64{1, 3}},
+		{`not (tenant_id == "t1")`, []int64{1, 2, 3}},
+	}
+	for _, c := range cases {
+		s.Run(c.expr, func() {
+			s.ElementsMatch(c.expected, s.prune(c.expr))
+		})
+	}
+}
+
+func (s *FieldStatsPrunerSuite) TestLoadFieldStats() {
+	ctx := context.Background()
+	cm := storage.NewLocalChunkManager(storage.RootPath(s.T().TempDir()))
+	sd := &shardDelegator{
+		chunkManager: cm,
+		fieldStats:   typeutil.NewConcurrentMap[UniqueID, segmentFieldStats](),
+	}
+	statsPath := func(name string) string { return path.Join(cm.RootPath(), "stats", name) }
+	writeStats := func(filePath string, stats *storage.FieldStats) {
+		writer := &storage.FieldStatsWriter{}
+		s.Require().NoError(writer.GenerateList([]*storage.FieldStats{stats}))
+		s.Require().NoError(cm.Write(ctx, filePath, writer.GetBuffer()))
+	}
+	writeStats(statsPath("1"), s.fieldStats[1][ageFieldID][0])
+	writeStats(statsPath("2"), s.fieldStats[2][ageFieldID][0])
+	writeStats(statsPath("3"), s.fieldStats[2][tenantFieldID][0])
+
+	infos := []*querypb.SegmentLoadInfo{
+		{
+			SegmentID: 1,
+			NumOfRows: 100,
+			FieldStatslogs: []*datapb.FieldBinlog{
+				{FieldID: ageFieldID, Binlogs: []*datapb.Binlog{{LogPath: statsPath("1"), EntriesNum: 60}, {LogPath: statsPath("2"), EntriesNum: 40}}},
+				{FieldID: tenantFieldID, Binlogs: []*datapb.Binlog{{LogPath: statsPath("3"), EntriesNum: 100}}},
+			},
+		},
+		{
+			// the stats of tenant don't cover all the rows
+			SegmentID: 2,
+			NumOfRows: 100,
+			FieldStatslogs: []*datapb.FieldBinlog{
+				{FieldID: ageFieldID, Binlogs: []*datapb.Binlog{{LogPath: statsPath("2"), EntriesNum: 100}}},
+				{FieldID: tenantFieldID, Binlogs: []*datapb.Binlog{{LogPath: statsPath("3"), EntriesNum: 60}}},
+			},
+		},
+		{
+			// the stats log is missing
+			SegmentID: 3,
+			NumOfRows: 100,
+			FieldStatslogs: []*datapb.FieldBinlog{
+				{FieldID: ageFieldID, Binlogs: []*datapb.Binlog{{LogPath: statsPath("4"), EntriesNum: 100}}},
+			},
+		},
+		{SegmentID: 4, NumOfRows: 100},
+	}
+	sd.loadFieldStats(ctx, infos)
+
+	s.Equal([]int64{1}, sd.fieldStats.Keys())
+	stats, ok := sd.fieldStats.Get(1)
+	s.Require().True(ok)
+	s.Len(stats[ageFieldID], 2)
+	s.Len(stats[tenantFieldID], 1)
+}
+
+func TestFieldStatsPruner(t *testing.T) {
+	suite.Run(t, new(FieldStatsPrunerSuite))
+}
@@ -62,3 +62,77 @@ func SetBM25Params(req *internalpb.SearchRequest, avgdl float64) error {
 	}
 	return nil
 }
+
+// SetTTLFilter appends `ttlField <= 0 || ttlField > now` to the predicates of the serialized plan,
+// so that rows expired before now are invisible.
+func SetTTLFilter(serializedPlan []byte, ttlField *schemapb.FieldSchema, now int64) ([]byte, error) {
+	plan := planpb.PlanNode{}
+	err := proto.Unmarshal(serializedPlan, &plan)
+	if err != nil {
+		log.Warn("failed to unmarshal plan", zap.Error(err))
+		return nil, merr.WrapErrParameterInvalid("valid serialized plan", "no unmarshalable one", err.Error())
+	}
+
+	columnInfo := &planpb.ColumnInfo{
+		FieldId:  ttlField.GetFieldID(),
+		DataType: ttlField.GetDataType(),
+	}
+	ttlExpr := &planpb.Expr{
+		Expr: &planpb.Expr_BinaryExpr{
+			BinaryExpr: &planpb.BinaryExpr{
+				Op: planpb.BinaryExpr_LogicalOr,
+				Left: &planpb.Expr{
+					Expr: &planpb.Expr_UnaryRangeExpr{
+						UnaryRangeExpr: &planpb.UnaryRangeExpr{
+							ColumnInfo: columnInfo,
+							Op:         planpb.OpType_LessEqual,
+							Value:      &planpb.GenericValue{Val: &planpb.GenericValue_Int64Val{Int64Val: 0}},
+						},
+					},
+				},
+				Right: &planpb.Expr{
+					Expr: &planpb.Expr_UnaryRangeExpr{
+						UnaryRangeExpr: &planpb.UnaryRangeExpr{
+							ColumnInfo: columnInfo,
+							Op:         planpb.OpType_GreaterThan,
+							Value:      &planpb.GenericValue{Val: &planpb.GenericValue_Int64Val{Int64Val: now}},
+						},
+					},
+				},
+			},
+		},
+	}
+	and := func(predicates *planpb.Expr) *planpb.Expr {
+		if predicates == nil {
+			return ttlExpr
+		}
+		return &planpb.Expr{
+			Expr: &planpb.Expr_BinaryExpr{
+				BinaryExpr: &planpb.BinaryExpr{
+					Op:    planpb.BinaryExpr_LogicalAnd,
+					Left:  predicates,
+					Right: ttlExpr,
+				},
+			},
+		}
+	}
+
+	switch node := plan.GetNode().(type) {
+	case *planpb.PlanNode_VectorAnns:
+		node.VectorAnns.Predicates = and(node.VectorAnns.GetPredicates())
+	case *planpb.PlanNode_Query:
+		node.Query.Predicates = and(node.Query.GetPredicates())
+	case *planpb.PlanNode_Predicates:
+		node.Predicates = and(node.Predicates)
+	default:
+		log.Warn("not supported node type", zap.String("nodeType", fmt.Sprintf("%T", plan.GetNode())))
+		return serializedPlan, nil
+	}
+
+	serializedPlan, err = proto.Marshal(&plan)
+	if err != nil {
+		log.Warn("failed to marshal plan with ttl filter", zap.Error(err))
+		return nil, merr.WrapErrParameterInvalid("marshalable plan", "plan with marshal error", err.Error())
+	}
+	return serializedPlan, nil
+}
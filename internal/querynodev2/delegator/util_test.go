@@ -0,0 +1,84 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package delegator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/schemapb"
+	"github.com/milvus-io/milvus/internal/proto/planpb"
+)
+
+func TestSetTTLFilter(t *testing.T) {
+	ttlField := &schemapb.FieldSchema{FieldID: 101, DataType: schemapb.DataType_Int64}
+	predicates := &planpb.Expr{
+		Expr: &planpb.Expr_UnaryRangeExpr{
+			UnaryRangeExpr: &planpb.UnaryRangeExpr{
+				ColumnInfo: &planpb.ColumnInfo{FieldId: 100, DataType: schemapb.DataType_Int64},
+				Op:         planpb.OpType_Equal,
+				Value:      &planpb.GenericValue{Val: &planpb.GenericValue_Int64Val{Int64Val: 1}},
+			},
+		},
+	}
+	checkTTLExpr := func(expr *planpb.Expr) {
+		or := expr.GetBinaryExpr()
+		assert.Equal(t, planpb.BinaryExpr_LogicalOr, or.GetOp())
+		assert.Equal(t, int64(101), or.GetLeft().GetUnaryRangeExpr().GetColumnInfo().GetFieldId())
+		assert.Equal(t, planpb.OpType_LessEqual, or.GetLeft().GetUnaryRangeExpr().GetOp())
+		assert.Equal(t, int64(0), or.GetLeft().GetUnaryRangeExpr().GetValue().GetInt64Val())
+		assert.Equal(t, planpb.OpType_GreaterThan, or.GetRight().GetUnaryRangeExpr().GetOp())
+		assert.Equal(t, int64(1000), or.GetRight().GetUnaryRangeExpr().GetValue().GetInt64Val())
+	}
+
+	t.Run("search with predicates", func(t *testing.T) {
+		serialized, err := proto.Marshal(&planpb.PlanNode{
+			Node: &planpb.PlanNode_VectorAnns{VectorAnns: &planpb.VectorANNS{Predicates: predicates}},
+		})
+		assert.NoError(t, err)
+		serialized, err = SetTTLFilter(serialized, ttlField, 1000)
+		assert.NoError(t, err)
+
+		plan := &planpb.PlanNode{}
+		assert.NoError(t, proto.Unmarshal(serialized, plan))
+		and := plan.GetVectorAnns().GetPredicates().GetBinaryExpr()
+		assert.Equal(t, planpb.BinaryExpr_LogicalAnd, and.GetOp())
+		assert.True(t, proto.Equal(predicates, and.GetLeft()))
+		checkTTLExpr(and.GetRight())
+	})
+
+	t.Run("query without predicates", func(t *testing.T) {
+		serialized, err := proto.Marshal(&planpb.PlanNode{
+			Node: &planpb.PlanNode_Query{Query: &planpb.QueryPlanNode{IsCount: true}},
+		})
+		assert.NoError(t, err)
+		serialized, err = SetTTLFilter(serialized, ttlField, 1000)
+		assert.NoError(t, err)
+
+		plan := &planpb.PlanNode{}
+		assert.NoError(t, proto.Unmarshal(serialized, plan))
+		assert.True(t, plan.GetQuery().GetIsCount())
+		checkTTLExpr(plan.GetQuery().GetPredicates())
+	})
+
+	t.Run("invalid plan", func(t *testing.T) {
+		_, err := SetTTLFilter([]byte{1, 2, 3}, ttlField, 1000)
+		assert.Error(t, err)
+	})
+}
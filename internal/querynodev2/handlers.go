@@ -20,6 +20,7 @@ import (
 	"context"
 	"fmt"
 	"strconv"
+	"time"
 
 	"github.com/samber/lo"
 	"go.opentelemetry.io/otel/trace"
@@ -244,10 +245,12 @@ func (node *QueryNode) queryChannel(ctx context.Context, req *querypb.QueryReque
 
 	reducer := segments.CreateInternalReducer(req, collection.Schema())
 
+	beforeReduce := time.Now()
 	resp, err := reducer.Reduce(ctx, results)
 	if err != nil {
 		return nil, err
 	}
+	reduceLatency := time.Since(beforeReduce)
 
 	tr.CtxElapse(ctx, fmt.Sprintf("do query with channel done , vChannel = %s, segmentIDs = %v",
 		channel,
@@ -257,6 +260,10 @@ func (node *QueryNode) queryChannel(ctx context.Context, req *querypb.QueryReque
 	latency := tr.ElapseSpan()
 	metrics.QueryNodeSQReqLatency.WithLabelValues(fmt.Sprint(node.GetNodeID()), metrics.QueryLabel, metrics.Leader).Observe(float64(latency.Milliseconds()))
 	metrics.QueryNodeSQCount.WithLabelValues(fmt.Sprint(node.GetNodeID()), metrics.QueryLabel, metrics.SuccessLabel, metrics.Leader, fmt.Sprint(req.GetReq().GetCollectionID())).Inc()
+	if req.GetReq().GetProfile() {
+		resp.SegmentProfiles = nil
+		resp.DelegatorProfiles = []*internalpb.DelegatorProfile{newDelegatorProfile(node.GetNodeID(), channel, latency, reduceLatency, results)}
+	}
 	return resp, nil
 }
 
@@ -393,6 +400,7 @@ func (node *QueryNode) searchChannel(ctx context.Context, req *querypb.SearchReq
 		req.GetSegmentIDs(),
 	))
 
+	beforeReduce := time.Now()
 	resp, err := segments.ReduceSearchOnQueryNode(ctx, results,
 		reduce.NewReduceSearchResultInfo(req.GetReq().GetNq(),
 			req.GetReq().GetTopk()).WithMetricType(req.GetReq().GetMetricType()).WithGroupByField(req.GetReq().GetGroupByFieldId()).
@@ -400,6 +408,7 @@ func (node *QueryNode) searchChannel(ctx context.Context, req *querypb.SearchReq
 	if err != nil {
 		return nil, err
 	}
+	reduceLatency := time.Since(beforeReduce)
 
 	tr.CtxElapse(ctx, fmt.Sprintf("do search with channel done , vChannel = %s, segmentIDs = %v",
 		channel,
@@ -412,9 +421,30 @@ func (node *QueryNode) searchChannel(ctx context.Context, req *querypb.SearchReq
 	metrics.QueryNodeSQCount.WithLabelValues(fmt.Sprint(node.GetNodeID()), metrics.SearchLabel, metrics.SuccessLabel, metrics.Leader, fmt.Sprint(req.GetReq().GetCollectionID())).Inc()
 	metrics.QueryNodeSearchNQ.WithLabelValues(fmt.Sprint(node.GetNodeID())).Observe(float64(req.Req.GetNq()))
 	metrics.QueryNodeSearchTopK.WithLabelValues(fmt.Sprint(node.GetNodeID())).Observe(float64(req.Req.GetTopk()))
+	if req.GetReq().GetProfile() {
+		resp.SegmentProfiles = nil
+		resp.DelegatorProfiles = []*internalpb.DelegatorProfile{newDelegatorProfile(node.GetNodeID(), channel, latency, reduceLatency, results)}
+	}
 	return resp, nil
 }
 
+// newDelegatorProfile wraps the segment profiles returned by the workers into the profile of the delegator.
+func newDelegatorProfile[R interface {
+	GetSegmentProfiles() []*internalpb.SegmentProfile
+}](nodeID int64, channel string, cost, reduceCost time.Duration, results []R,
+) *internalpb.DelegatorProfile {
+	profile := &internalpb.DelegatorProfile{
+		NodeID:           nodeID,
+		Channel:          channel,
+		DurationUs:       cost.Microseconds(),
+		ReduceDurationUs: reduceCost.Microseconds(),
+	}
+	for _, result := range results {
+		profile.Segments = append(profile.Segments, result.GetSegmentProfiles()...)
+	}
+	return profile
+}
+
 func (node *QueryNode) getChannelStatistics(ctx context.Context, req *querypb.GetStatisticsRequest, channel string) (*internalpb.GetStatisticsResponse, error) {
 	log := log.Ctx(ctx).With(
 		zap.Int64("collectionID", req.Req.GetCollectionID()),
@@ -19,6 +19,7 @@ package pipeline
 import (
 	"fmt"
 
+	"github.com/samber/lo"
 	"go.uber.org/zap"
 
 	"github.com/milvus-io/milvus-proto/go-api/v2/msgpb"
@@ -43,6 +44,8 @@ type embeddingNode struct {
 	manager *DataManager
 
 	functionRunners []function.FunctionRunner
+	// the output fields of the text embedding functions, filled by proxy.
+	textEmbeddingOutputs []int64
 }
 
 func newEmbeddingNode(collectionID int64, channelName string, manager *DataManager, maxQueueLength int32) (*embeddingNode, error) {
@@ -65,6 +68,12 @@ func newEmbeddingNode(collectionID int64, channelName string, manager *DataManag
 	}
 
 	for _, tf := range collection.Schema().GetFunctions() {
+		// the text is embedded by proxy before the insert is sent,
+		// the pipeline never calls the embedding service.
+		if tf.GetType() == schemapb.FunctionType_TextEmbedding {
+			node.textEmbeddingOutputs = append(node.textEmbeddingOutputs, tf.GetOutputFieldIds()[0])
+			continue
+		}
 		functionRunner, err := function.NewFunctionRunner(collection.Schema(), tf)
 		if err != nil {
 			return nil, err
@@ -163,7 +172,22 @@ func (eNode *embeddingNode) bm25Embedding(runner function.FunctionRunner, msg *m
 	return nil
 }
 
+// checkTextEmbedding checks the outputs of the text embedding functions are filled by proxy.
+func (eNode *embeddingNode) checkTextEmbedding(msg *msgstream.InsertMsg) error {
+	for _, outputFieldID := range eNode.textEmbeddingOutputs {
+		if !lo.ContainsBy(msg.GetFieldsData(), func(fieldData *schemapb.FieldData) bool {
+			return fieldData.GetFieldId() == outputFieldID
+		}) {
+			return fmt.Errorf("text embedding failed: output field %d is not filled by proxy", outputFieldID)
+		}
+	}
+	return nil
+}
+
 func (eNode *embeddingNode) embedding(msg *msgstream.InsertMsg, stats map[int64]*storage.BM25Stats) error {
+	if err := eNode.checkTextEmbedding(msg); err != nil {
+		return err
+	}
 	for _, functionRunner := range eNode.functionRunners {
 		functionSchema := functionRunner.GetSchema()
 		switch functionSchema.GetType() {
@@ -29,6 +29,7 @@ import (
 	"github.com/milvus-io/milvus-proto/go-api/v2/schemapb"
 	"github.com/milvus-io/milvus/internal/querynodev2/delegator"
 	"github.com/milvus-io/milvus/internal/querynodev2/segments"
+	"github.com/milvus-io/milvus/internal/storage"
 	"github.com/milvus-io/milvus/internal/util/function"
 	"github.com/milvus-io/milvus/pkg/common"
 	"github.com/milvus-io/milvus/pkg/mq/msgstream"
@@ -276,6 +277,49 @@ func (suite *EmbeddingNodeSuite) TestBM25Embedding() {
 	})
 }
 
+func (suite *EmbeddingNodeSuite) TestTextEmbedding() {
+	schema := proto.Clone(suite.collectionSchema).(*schemapb.CollectionSchema)
+	schema.Fields = append(schema.Fields, &schemapb.FieldSchema{
+		Name:             "dense",
+		FieldID:          103,
+		DataType:         schemapb.DataType_FloatVector,
+		TypeParams:       []*commonpb.KeyValuePair{{Key: common.DimKey, Value: "2"}},
+		IsFunctionOutput: true,
+	})
+	schema.Functions = append(schema.Functions, &schemapb.FunctionSchema{
+		Name:           "text_embedding",
+		Type:           schemapb.FunctionType_TextEmbedding,
+		InputFieldIds:  []int64{101},
+		OutputFieldIds: []int64{103},
+	})
+	collection := segments.NewCollectionWithoutSegcoreForTest(suite.collectionID, schema)
+	suite.colManager.EXPECT().Get(suite.collectionID).Return(collection)
+
+	// no embedding service is configured, the node must not create the text embedding runner
+	node, err := newEmbeddingNode(suite.collectionID, suite.channel, suite.manager, 128)
+	suite.Require().NoError(err)
+	suite.Len(node.functionRunners, 1)
+
+	suite.Run("output missing", func() {
+		msg := proto.Clone(suite.msgs[0].InsertRequest).(*msgpb.InsertRequest)
+		err := node.embedding(&msgstream.InsertMsg{InsertRequest: msg}, make(map[int64]*storage.BM25Stats))
+		suite.ErrorContains(err, "not filled by proxy")
+	})
+
+	suite.Run("output filled by proxy", func() {
+		msg := proto.Clone(suite.msgs[0].InsertRequest).(*msgpb.InsertRequest)
+		msg.FieldsData = append(msg.FieldsData, &schemapb.FieldData{
+			FieldId: 103,
+			Type:    schemapb.DataType_FloatVector,
+			Field: &schemapb.FieldData_Vectors{
+				Vectors: &schemapb.VectorField{Dim: 2, Data: &schemapb.VectorField_FloatVector{FloatVector: &schemapb.FloatArray{Data: []float32{1, 2, 3, 4, 5, 6}}}},
+			},
+		})
+		err := node.embedding(&msgstream.InsertMsg{InsertRequest: msg}, make(map[int64]*storage.BM25Stats))
+		suite.NoError(err)
+	})
+}
+
 func TestEmbeddingNode(t *testing.T) {
 	suite.Run(t, new(EmbeddingNodeSuite))
 }
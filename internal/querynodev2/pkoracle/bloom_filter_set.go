@@ -90,6 +90,8 @@ func (s *BloomFilterSet) Type() commonpb.SegmentState {
 }
 
 // UpdateBloomFilter updates currentStats with provided pks.
+// currentStats always uses the mutable `common.bloomFilterType`, the historical stats loaded
+// from statslog may be static ones built with `common.sealedBloomFilterType`.
 func (s *BloomFilterSet) UpdateBloomFilter(pks []storage.PrimaryKey) {
 	s.statsMutex.Lock()
 	defer s.statsMutex.Unlock()
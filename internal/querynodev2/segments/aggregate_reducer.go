@@ -0,0 +1,102 @@
+package segments
+
+import (
+	"context"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/schemapb"
+	"github.com/milvus-io/milvus/internal/proto/internalpb"
+	"github.com/milvus-io/milvus/internal/proto/querypb"
+	"github.com/milvus-io/milvus/internal/proto/segcorepb"
+	"github.com/milvus-io/milvus/internal/util/aggregate"
+	"github.com/milvus-io/milvus/internal/util/segcore"
+	"github.com/milvus-io/milvus/pkg/util/merr"
+	"github.com/milvus-io/milvus/pkg/util/typeutil"
+)
+
+// aggReducer merges the partial aggregation results of shards and workers.
+type aggReducer struct {
+	req    *querypb.QueryRequest
+	schema *schemapb.CollectionSchema
+}
+
+func (r *aggReducer) Reduce(ctx context.Context, results []*internalpb.RetrieveResults) (*internalpb.RetrieveResults, error) {
+	agg, err := aggregate.NewAggregator(r.req.GetReq().GetGroupByFieldsId(), r.req.GetReq().GetAggregates(), r.schema)
+	if err != nil {
+		return nil, merr.WrapErrParameterInvalidMsg(err.Error())
+	}
+	allRetrieveCount := int64(0)
+	relatedDataSize := int64(0)
+	for _, res := range results {
+		allRetrieveCount += res.GetAllRetrieveCount()
+		relatedDataSize += res.GetCostAggregation().GetTotalRelatedDataSize()
+		if err := agg.AddPartial(res.GetFieldsData()); err != nil {
+			return nil, err
+		}
+	}
+	return &internalpb.RetrieveResults{
+		Status:           merr.Success(),
+		FieldsData:       agg.PartialResult(),
+		AllRetrieveCount: allRetrieveCount,
+		CostAggregation: &internalpb.CostAggregation{
+			TotalRelatedDataSize: relatedDataSize,
+		},
+	}, nil
+}
+
+// aggregateBatchSize is the max row count retrieved by offsets at once when aggregating a segment.
+var aggregateBatchSize = 4096
+
+// aggReducerSegCore aggregates the rows retrieved from segments into a partial result.
+// If the plan ignores non-pk fields, the segments only return the offsets of matched rows,
+// the rows are then retrieved by offsets and aggregated in batches, so the rows of a segment are never materialized at once.
+type aggReducerSegCore struct {
+	req     *querypb.QueryRequest
+	schema  *schemapb.CollectionSchema
+	manager *Manager
+}
+
+func (r *aggReducerSegCore) Reduce(ctx context.Context, results []*segcorepb.RetrieveResults, segments []Segment, plan *segcore.RetrievePlan) (*segcorepb.RetrieveResults, error) {
+	agg, err := aggregate.NewAggregator(r.req.GetReq().GetGroupByFieldsId(), r.req.GetReq().GetAggregates(), r.schema)
+	if err != nil {
+		return nil, merr.WrapErrParameterInvalidMsg(err.Error())
+	}
+	allRetrieveCount := int64(0)
+	for i, res := range results {
+		allRetrieveCount += res.GetAllRetrieveCount()
+		if plan == nil || !plan.IsIgnoreNonPk() {
+			if err := agg.AddRows(res.GetFieldsData(), typeutil.GetSizeOfIDs(res.GetIds())); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		if err := r.aggregateByOffsets(ctx, agg, segments[i], plan, res.GetOffset()); err != nil {
+			return nil, err
+		}
+	}
+	return &segcorepb.RetrieveResults{
+		FieldsData:       agg.PartialResult(),
+		AllRetrieveCount: allRetrieveCount,
+	}, nil
+}
+
+func (r *aggReducerSegCore) aggregateByOffsets(ctx context.Context, agg *aggregate.Aggregator, segment Segment, plan *segcore.RetrievePlan, offsets []int64) error {
+	for start := 0; start < len(offsets); start += aggregateBatchSize {
+		end := min(start+aggregateBatchSize, len(offsets))
+		var result *segcorepb.RetrieveResults
+		err := doOnSegment(ctx, r.manager, segment, func(ctx context.Context, segment Segment) error {
+			var err error
+			result, err = segment.RetrieveByOffsets(ctx, &segcore.RetrievePlanWithOffsets{
+				RetrievePlan: plan,
+				Offsets:      offsets[start:end],
+			})
+			return err
+		})
+		if err != nil {
+			return err
+		}
+		if err := agg.AddRows(result.GetFieldsData(), end-start); err != nil {
+			return err
+		}
+	}
+	return nil
+}
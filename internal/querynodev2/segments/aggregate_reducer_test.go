@@ -0,0 +1,172 @@
+package segments
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/schemapb"
+	"github.com/milvus-io/milvus/internal/proto/internalpb"
+	"github.com/milvus-io/milvus/internal/proto/querypb"
+	"github.com/milvus-io/milvus/internal/proto/segcorepb"
+	"github.com/milvus-io/milvus/internal/util/segcore"
+	"github.com/milvus-io/milvus/pkg/util/paramtable"
+)
+
+type AggReducerSuite struct {
+	suite.Suite
+	schema *schemapb.CollectionSchema
+	req    *querypb.QueryRequest
+}
+
+func (suite *AggReducerSuite) SetupTest() {
+	suite.schema = &schemapb.CollectionSchema{
+		Fields: []*schemapb.FieldSchema{
+			{FieldID: 100, Name: "pk", DataType: schemapb.DataType_Int64, IsPrimaryKey: true},
+			{FieldID: 101, Name: "tag", DataType: schemapb.DataType_VarChar},
+			{FieldID: 102, Name: "price", DataType: schemapb.DataType_Int64},
+		},
+	}
+	suite.req = &querypb.QueryRequest{
+		Req: &internalpb.RetrieveRequest{
+			GroupByFieldsId: []int64{101},
+			Aggregates: []*internalpb.Aggregate{
+				{Op: internalpb.AggregateOp_Count},
+				{Op: internalpb.AggregateOp_Sum, FieldId: 102},
+			},
+		},
+	}
+}
+
+func TestAggReducerSuite(t *testing.T) {
+	paramtable.Init()
+	suite.Run(t, new(AggReducerSuite))
+}
+
+func (suite *AggReducerSuite) segcoreResult(pks []int64, tags []string, prices []int64) *segcorepb.RetrieveResults {
+	return &segcorepb.RetrieveResults{
+		Ids: &schemapb.IDs{IdField: &schemapb.IDs_IntId{IntId: &schemapb.LongArray{Data: pks}}},
+		FieldsData: []*schemapb.FieldData{
+			{
+				Type:    schemapb.DataType_VarChar,
+				FieldId: 101,
+				Field: &schemapb.FieldData_Scalars{Scalars: &schemapb.ScalarField{
+					Data: &schemapb.ScalarField_StringData{StringData: &schemapb.StringArray{Data: tags}},
+				}},
+			},
+			{
+				Type:    schemapb.DataType_Int64,
+				FieldId: 102,
+				Field: &schemapb.FieldData_Scalars{Scalars: &schemapb.ScalarField{
+					Data: &schemapb.ScalarField_LongData{LongData: &schemapb.LongArray{Data: prices}},
+				}},
+			},
+		},
+		AllRetrieveCount: int64(len(pks)),
+	}
+}
+
+func (suite *AggReducerSuite) TestNormalCase() {
+	sr := &aggReducerSegCore{req: suite.req, schema: suite.schema}
+	partial1, err := sr.Reduce(context.TODO(), []*segcorepb.RetrieveResults{
+		suite.segcoreResult([]int64{1, 2, 3}, []string{"a", "b", "a"}, []int64{1, 2, 3}),
+		// empty segment carries no column
+		{},
+	}, nil, nil)
+	suite.Require().NoError(err)
+	suite.Equal(int64(3), partial1.GetAllRetrieveCount())
+
+	partial2, err := sr.Reduce(context.TODO(), []*segcorepb.RetrieveResults{
+		suite.segcoreResult([]int64{4}, []string{"c"}, []int64{4}),
+	}, nil, nil)
+	suite.Require().NoError(err)
+
+	ir := &aggReducer{req: suite.req, schema: suite.schema}
+	res, err := ir.Reduce(context.TODO(), []*internalpb.RetrieveResults{
+		{FieldsData: partial1.GetFieldsData(), AllRetrieveCount: partial1.GetAllRetrieveCount()},
+		{FieldsData: partial2.GetFieldsData(), AllRetrieveCount: partial2.GetAllRetrieveCount()},
+	})
+	suite.Require().NoError(err)
+	suite.Equal(int64(4), res.GetAllRetrieveCount())
+	suite.Equal(3, len(res.GetFieldsData()))
+	suite.Equal([]string{"a", "b", "c"}, res.GetFieldsData()[0].GetScalars().GetStringData().GetData())
+	suite.Equal([]int64{2, 1, 1}, res.GetFieldsData()[1].GetScalars().GetLongData().GetData())
+	suite.Equal([]int64{4, 2, 4}, res.GetFieldsData()[2].GetScalars().GetLongData().GetData())
+}
+
+func (suite *AggReducerSuite) TestInvalid() {
+	sr := &aggReducerSegCore{req: suite.req, schema: suite.schema}
+	_, err := sr.Reduce(context.TODO(), []*segcorepb.RetrieveResults{
+		{
+			Ids:        &schemapb.IDs{IdField: &schemapb.IDs_IntId{IntId: &schemapb.LongArray{Data: []int64{1}}}},
+			FieldsData: []*schemapb.FieldData{},
+		},
+	}, nil, nil)
+	suite.Error(err)
+
+	ir := &aggReducer{req: suite.req, schema: suite.schema}
+	_, err = ir.Reduce(context.TODO(), []*internalpb.RetrieveResults{
+		{FieldsData: []*schemapb.FieldData{{Type: schemapb.DataType_Int64}}},
+	})
+	suite.Error(err)
+}
+
+func (suite *AggReducerSuite) TestAggregateByOffsets() {
+	// the rows of the segment are far beyond the output size limit, but each batch is small.
+	paramtable.Get().Save(paramtable.Get().QuotaConfig.MaxOutputSize.Key, "1024")
+	defer paramtable.Get().Reset(paramtable.Get().QuotaConfig.MaxOutputSize.Key)
+	oldBatchSize := aggregateBatchSize
+	aggregateBatchSize = 16
+	defer func() { aggregateBatchSize = oldBatchSize }()
+
+	rowNum := 1000
+	offsets := make([]int64, 0, rowNum)
+	pks := make([]int64, 0, rowNum)
+	for i := 0; i < rowNum; i++ {
+		offsets = append(offsets, int64(i))
+		pks = append(pks, int64(i))
+	}
+
+	batches := 0
+	segment := NewMockSegment(suite.T())
+	segment.EXPECT().IsLazyLoad().Return(false)
+	segment.EXPECT().DatabaseName().Return("default")
+	segment.EXPECT().ResourceGroup().Return("default")
+	segment.EXPECT().RetrieveByOffsets(mock.Anything, mock.Anything).RunAndReturn(
+		func(ctx context.Context, plan *segcore.RetrievePlanWithOffsets) (*segcorepb.RetrieveResults, error) {
+			suite.LessOrEqual(len(plan.Offsets), aggregateBatchSize)
+			batches++
+			tags := make([]string, 0, len(plan.Offsets))
+			prices := make([]int64, 0, len(plan.Offsets))
+			for _, offset := range plan.Offsets {
+				tags = append(tags, fmt.Sprintf("tag_%d", offset%2))
+				prices = append(prices, offset)
+			}
+			return suite.segcoreResult(plan.Offsets, tags, prices), nil
+		})
+
+	plan := &segcore.RetrievePlan{}
+	plan.SetIgnoreNonPk(true)
+	sr := &aggReducerSegCore{req: suite.req, schema: suite.schema}
+	partial, err := sr.Reduce(context.TODO(), []*segcorepb.RetrieveResults{
+		{
+			Ids:              &schemapb.IDs{IdField: &schemapb.IDs_IntId{IntId: &schemapb.LongArray{Data: pks}}},
+			Offset:           offsets,
+			AllRetrieveCount: int64(rowNum),
+		},
+	}, []Segment{segment}, plan)
+	suite.Require().NoError(err)
+	suite.Equal((rowNum+aggregateBatchSize-1)/aggregateBatchSize, batches)
+
+	ir := &aggReducer{req: suite.req, schema: suite.schema}
+	res, err := ir.Reduce(context.TODO(), []*internalpb.RetrieveResults{
+		{FieldsData: partial.GetFieldsData(), AllRetrieveCount: partial.GetAllRetrieveCount()},
+	})
+	suite.Require().NoError(err)
+	suite.Equal([]string{"tag_0", "tag_1"}, res.GetFieldsData()[0].GetScalars().GetStringData().GetData())
+	suite.Equal([]int64{500, 500}, res.GetFieldsData()[1].GetScalars().GetLongData().GetData())
+	suite.Equal([]int64{249500, 250000}, res.GetFieldsData()[2].GetScalars().GetLongData().GetData())
+}
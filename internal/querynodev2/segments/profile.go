@@ -0,0 +1,82 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package segments
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
+	"github.com/milvus-io/milvus/internal/proto/internalpb"
+	"github.com/milvus-io/milvus/pkg/common"
+	"github.com/milvus-io/milvus/pkg/util/funcutil"
+	"github.com/milvus-io/milvus/pkg/util/paramtable"
+)
+
+type segmentProfilerKey struct{}
+
+// SegmentProfiler collects the execution profiles on segments of a request.
+// It's carried by the context, so nothing is recorded if the request is not profiled.
+type SegmentProfiler struct {
+	mu       sync.Mutex
+	profiles []*internalpb.SegmentProfile
+}
+
+// WithSegmentProfiler returns a context carrying a new segment profiler.
+func WithSegmentProfiler(ctx context.Context) (context.Context, *SegmentProfiler) {
+	profiler := &SegmentProfiler{}
+	return context.WithValue(ctx, segmentProfilerKey{}, profiler), profiler
+}
+
+func segmentProfilerFromContext(ctx context.Context) *SegmentProfiler {
+	profiler, _ := ctx.Value(segmentProfilerKey{}).(*SegmentProfiler)
+	return profiler
+}
+
+// record adds the profile of the segment, indexFieldID is the field whose index is used, 0 if none.
+func (p *SegmentProfiler) record(seg Segment, indexFieldID int64, cost time.Duration, total, filtered int64) {
+	if p == nil {
+		return
+	}
+	profile := &internalpb.SegmentProfile{
+		SegmentID:    seg.ID(),
+		NodeID:       paramtable.GetNodeID(),
+		IsGrowing:    seg.Type() == commonpb.SegmentState_Growing,
+		DurationUs:   cost.Microseconds(),
+		TotalRows:    total,
+		FilteredRows: filtered,
+	}
+	if indexFieldID > 0 {
+		if info := seg.GetIndex(indexFieldID); info != nil && info.IsLoaded {
+			profile.IndexType, _ = funcutil.GetAttrByKeyFromRepeatedKV(common.IndexTypeKey, info.IndexInfo.GetIndexParams())
+		}
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.profiles = append(p.profiles, profile)
+}
+
+// Profiles returns the collected profiles.
+func (p *SegmentProfiler) Profiles() []*internalpb.SegmentProfile {
+	if p == nil {
+		return nil
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.profiles
+}
@@ -0,0 +1,70 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package segments
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
+	"github.com/milvus-io/milvus/internal/proto/querypb"
+	"github.com/milvus-io/milvus/pkg/common"
+	"github.com/milvus-io/milvus/pkg/util/paramtable"
+)
+
+func TestSegmentProfiler(t *testing.T) {
+	paramtable.Init()
+
+	// not profiled
+	profiler := segmentProfilerFromContext(context.Background())
+	assert.Nil(t, profiler)
+	profiler.record(NewMockSegment(t), 0, time.Millisecond, 10, 5)
+	assert.Nil(t, profiler.Profiles())
+
+	ctx, profiler := WithSegmentProfiler(context.Background())
+	assert.Same(t, profiler, segmentProfilerFromContext(ctx))
+
+	sealed := NewMockSegment(t)
+	sealed.EXPECT().ID().Return(1)
+	sealed.EXPECT().Type().Return(commonpb.SegmentState_Sealed)
+	sealed.EXPECT().GetIndex(int64(100)).Return(&IndexedFieldInfo{
+		IndexInfo: &querypb.FieldIndexInfo{
+			IndexParams: []*commonpb.KeyValuePair{{Key: common.IndexTypeKey, Value: "HNSW"}},
+		},
+		IsLoaded: true,
+	})
+	growing := NewMockSegment(t)
+	growing.EXPECT().ID().Return(2)
+	growing.EXPECT().Type().Return(commonpb.SegmentState_Growing)
+
+	profiler.record(sealed, 100, time.Millisecond, 1000, 10)
+	profiler.record(growing, 0, 2*time.Millisecond, 100, 1)
+
+	profiles := profiler.Profiles()
+	assert.Len(t, profiles, 2)
+	assert.EqualValues(t, 1, profiles[0].GetSegmentID())
+	assert.False(t, profiles[0].GetIsGrowing())
+	assert.EqualValues(t, 1000, profiles[0].GetDurationUs())
+	assert.EqualValues(t, 10, profiles[0].GetFilteredRows())
+	assert.Equal(t, "HNSW", profiles[0].GetIndexType())
+	assert.EqualValues(t, 2, profiles[1].GetSegmentID())
+	assert.True(t, profiles[1].GetIsGrowing())
+	assert.Empty(t, profiles[1].GetIndexType())
+}
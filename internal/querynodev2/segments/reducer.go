@@ -9,6 +9,7 @@ import (
 	"github.com/milvus-io/milvus/internal/proto/internalpb"
 	"github.com/milvus-io/milvus/internal/proto/querypb"
 	"github.com/milvus-io/milvus/internal/proto/segcorepb"
+	"github.com/milvus-io/milvus/internal/util/aggregate"
 	"github.com/milvus-io/milvus/internal/util/segcore"
 	"github.com/milvus-io/milvus/pkg/common"
 	"github.com/milvus-io/milvus/pkg/util/merr"
@@ -23,6 +24,9 @@ func CreateInternalReducer(req *querypb.QueryRequest, schema *schemapb.Collectio
 	if req.GetReq().GetIsCount() {
 		return &cntReducer{}
 	}
+	if aggregate.IsAggregation(req.GetReq()) {
+		return &aggReducer{req: req, schema: schema}
+	}
 	return newDefaultLimitReducer(req, schema)
 }
 
@@ -34,6 +38,9 @@ func CreateSegCoreReducer(req *querypb.QueryRequest, schema *schemapb.Collection
 	if req.GetReq().GetIsCount() {
 		return &cntReducerSegCore{}
 	}
+	if aggregate.IsAggregation(req.GetReq()) {
+		return &aggReducerSegCore{req: req, schema: schema, manager: manager}
+	}
 	return newDefaultLimitReducerSegcore(req, schema, manager)
 }
 
@@ -40,6 +40,12 @@ func (suite *ReducerFactorySuite) TestCreateInternalReducer() {
 	suite.ir = CreateInternalReducer(req, nil)
 	_, suite.ok = suite.ir.(*cntReducer)
 	suite.True(suite.ok)
+
+	req.Req.IsCount = false
+	req.Req.GroupByFieldsId = []int64{101}
+	suite.ir = CreateInternalReducer(req, nil)
+	_, suite.ok = suite.ir.(*aggReducer)
+	suite.True(suite.ok)
 }
 
 func (suite *ReducerFactorySuite) TestCreateSegCoreReducer() {
@@ -57,4 +63,10 @@ func (suite *ReducerFactorySuite) TestCreateSegCoreReducer() {
 	suite.sr = CreateSegCoreReducer(req, nil, nil)
 	_, suite.ok = suite.sr.(*cntReducerSegCore)
 	suite.True(suite.ok)
+
+	req.Req.IsCount = false
+	req.Req.Aggregates = []*internalpb.Aggregate{{Op: internalpb.AggregateOp_Count}}
+	suite.sr = CreateSegCoreReducer(req, nil, nil)
+	_, suite.ok = suite.sr.(*aggReducerSegCore)
+	suite.True(suite.ok)
 }
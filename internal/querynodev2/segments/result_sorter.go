@@ -64,6 +64,9 @@ func swapFieldData(field *schemapb.FieldData, i int, j int) {
 		case *schemapb.ScalarField_JsonData:
 			data := sd.JsonData.Data
 			data[i], data[j] = data[j], data[i]
+		case *schemapb.ScalarField_GeometryData:
+			data := sd.GeometryData.Data
+			data[i], data[j] = data[j], data[i]
 		case *schemapb.ScalarField_ArrayData:
 			data := sd.ArrayData.Data
 			data[i], data[j] = data[j], data[i]
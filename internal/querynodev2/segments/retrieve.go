@@ -27,6 +27,7 @@ import (
 	"github.com/milvus-io/milvus/internal/proto/internalpb"
 	"github.com/milvus-io/milvus/internal/proto/querypb"
 	"github.com/milvus-io/milvus/internal/proto/segcorepb"
+	"github.com/milvus-io/milvus/internal/util/aggregate"
 	"github.com/milvus-io/milvus/internal/util/streamrpc"
 	"github.com/milvus-io/milvus/pkg/log"
 	"github.com/milvus-io/milvus/pkg/metrics"
@@ -54,19 +55,24 @@ func retrieveOnSegments(ctx context.Context, mgr *Manager, segments []Segment, s
 		}
 		return false
 	}()
-	plan.SetIgnoreNonPk(!anySegIsLazyLoad && len(segments) > 1 && req.GetReq().GetLimit() != typeutil.Unlimited && plan.ShouldIgnoreNonPk())
+	// the rows of aggregation are retrieved by offsets in batches while reducing, instead of all at once.
+	isAggregation := aggregate.IsAggregation(req.GetReq())
+	plan.SetIgnoreNonPk(!anySegIsLazyLoad && plan.ShouldIgnoreNonPk() &&
+		(isAggregation || len(segments) > 1 && req.GetReq().GetLimit() != typeutil.Unlimited))
 
 	label := metrics.SealedSegmentLabel
 	if segType == commonpb.SegmentState_Growing {
 		label = metrics.GrowingSegmentLabel
 	}
 
+	profiler := segmentProfilerFromContext(ctx)
 	retriever := func(ctx context.Context, s Segment) error {
 		tr := timerecord.NewTimeRecorder("retrieveOnSegments")
 		result, err := s.Retrieve(ctx, plan)
 		if err != nil {
 			return err
 		}
+		profiler.record(s, 0, tr.ElapseSpan(), result.GetAllRetrieveCount(), result.GetFilteredCount())
 		resultCh <- RetrieveSegmentResult{
 			result,
 			s,
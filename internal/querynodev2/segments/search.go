@@ -42,6 +42,7 @@ func searchSegments(ctx context.Context, mgr *Manager, segments []Segment, segTy
 	}
 
 	resultCh := make(chan *SearchResult, len(segments))
+	profiler := segmentProfilerFromContext(ctx)
 	searcher := func(ctx context.Context, s Segment) error {
 		// record search time
 		tr := timerecord.NewTimeRecorder("searchOnSegments")
@@ -50,6 +51,10 @@ func searchSegments(ctx context.Context, mgr *Manager, segments []Segment, segTy
 			return err
 		}
 		resultCh <- searchResult
+		if profiler != nil {
+			total, filtered := searchResult.DataCount()
+			profiler.record(s, searchReq.SearchFieldID(), tr.ElapseSpan(), total, filtered)
+		}
 		// update metrics
 		elapsed := tr.ElapseSpan().Milliseconds()
 		metrics.QueryNodeSQSegmentLatency.WithLabelValues(fmt.Sprint(paramtable.GetNodeID()),
@@ -127,14 +132,20 @@ func searchSegmentsStreamly(ctx context.Context,
 	searchResultsToClear := make([]*SearchResult, 0)
 	var reduceMutex sync.Mutex
 	var sumReduceDuration atomic.Duration
+	profiler := segmentProfilerFromContext(ctx)
 	searcher := func(ctx context.Context, seg Segment) error {
 		// record search time
 		tr := timerecord.NewTimeRecorder("searchOnSegments")
 		searchResult, searchErr := seg.Search(ctx, searchReq)
-		searchDuration := tr.RecordSpan().Milliseconds()
+		searchSpan := tr.RecordSpan()
+		searchDuration := searchSpan.Milliseconds()
 		if searchErr != nil {
 			return searchErr
 		}
+		if profiler != nil {
+			total, filtered := searchResult.DataCount()
+			profiler.record(seg, searchReq.SearchFieldID(), searchSpan, total, filtered)
+		}
 		reduceMutex.Lock()
 		searchResultsToClear = append(searchResultsToClear, searchResult)
 		reducedErr := streamReduce(searchResult)
@@ -1564,7 +1564,8 @@ func getResourceUsageEstimateOfSegment(schema *schemapb.CollectionSchema, loadIn
 func DoubleMemoryDataType(dataType schemapb.DataType) bool {
 	return dataType == schemapb.DataType_String ||
 		dataType == schemapb.DataType_VarChar ||
-		dataType == schemapb.DataType_JSON
+		dataType == schemapb.DataType_JSON ||
+		dataType == schemapb.DataType_Geometry
 }
 
 func DoubleMemorySystemField(fieldID int64) bool {
@@ -50,6 +50,16 @@ func (t *QueryStreamTask) Username() string {
 	return t.req.Req.GetUsername()
 }
 
+// Return the database name which task is belong to.
+func (t *QueryStreamTask) DBName() string {
+	return t.collection.GetDBName()
+}
+
+// Return the resource group which task is running in.
+func (t *QueryStreamTask) ResourceGroup() string {
+	return t.collection.GetResourceGroup()
+}
+
 func (t *QueryStreamTask) IsGpuIndex() bool {
 	return false
 }
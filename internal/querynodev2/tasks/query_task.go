@@ -60,6 +60,16 @@ func (t *QueryTask) Username() string {
 	return t.req.Req.GetUsername()
 }
 
+// Return the database name which task is belong to.
+func (t *QueryTask) DBName() string {
+	return t.collection.GetDBName()
+}
+
+// Return the resource group which task is running in.
+func (t *QueryTask) ResourceGroup() string {
+	return t.collection.GetResourceGroup()
+}
+
 func (t *QueryTask) IsGpuIndex() bool {
 	return false
 }
@@ -111,7 +121,12 @@ func (t *QueryTask) Execute() error {
 		return err
 	}
 	defer retrievePlan.Delete()
-	results, pinnedSegments, err := segments.Retrieve(t.ctx, t.segmentManager, retrievePlan, t.req)
+	ctx := t.ctx
+	var profiler *segments.SegmentProfiler
+	if t.req.GetReq().GetProfile() {
+		ctx, profiler = segments.WithSegmentProfiler(ctx)
+	}
+	results, pinnedSegments, err := segments.Retrieve(ctx, t.segmentManager, retrievePlan, t.req)
 	defer t.segmentManager.Segment.Unpin(pinnedSegments)
 	if err != nil {
 		return err
@@ -158,6 +173,7 @@ func (t *QueryTask) Execute() error {
 		},
 		AllRetrieveCount: reducedResult.GetAllRetrieveCount(),
 		HasMoreResult:    reducedResult.HasMoreResult,
+		SegmentProfiles:  profiler.Profiles(),
 	}
 	return nil
 }
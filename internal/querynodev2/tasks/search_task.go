@@ -89,6 +89,16 @@ func (t *SearchTask) Username() string {
 	return t.req.Req.GetUsername()
 }
 
+// Return the database name which task is belong to.
+func (t *SearchTask) DBName() string {
+	return t.collection.GetDBName()
+}
+
+// Return the resource group which task is running in.
+func (t *SearchTask) ResourceGroup() string {
+	return t.collection.GetResourceGroup()
+}
+
 func (t *SearchTask) GetNodeID() int64 {
 	return t.serverID
 }
@@ -156,9 +166,10 @@ func (t *SearchTask) Execute() error {
 		results          []*segments.SearchResult
 		searchedSegments []segments.Segment
 	)
+	ctx, profiler := t.profileContext()
 	if req.GetScope() == querypb.DataScope_Historical {
 		results, searchedSegments, err = segments.SearchHistorical(
-			t.ctx,
+			ctx,
 			t.segmentManager,
 			searchReq,
 			req.GetReq().GetCollectionID(),
@@ -167,7 +178,7 @@ func (t *SearchTask) Execute() error {
 		)
 	} else if req.GetScope() == querypb.DataScope_Streaming {
 		results, searchedSegments, err = segments.SearchStreaming(
-			t.ctx,
+			ctx,
 			t.segmentManager,
 			searchReq,
 			req.GetReq().GetCollectionID(),
@@ -267,6 +278,7 @@ func (t *SearchTask) Execute() error {
 				ServiceTime:          tr.ElapseSpan().Milliseconds(),
 				TotalRelatedDataSize: relatedDataSize,
 			},
+			SegmentProfiles: profiler.Profiles(),
 		}
 	}
 
@@ -297,7 +309,9 @@ func (t *SearchTask) Merge(other *SearchTask) bool {
 		diffTopk && ratio > paramtable.Get().QueryNodeCfg.TopKMergeRatio.GetAsFloat() ||
 		!funcutil.SliceSetEqual(t.req.GetReq().GetPartitionIDs(), other.req.GetReq().GetPartitionIDs()) ||
 		!funcutil.SliceSetEqual(t.req.GetSegmentIDs(), other.req.GetSegmentIDs()) ||
-		!bytes.Equal(t.req.GetReq().GetSerializedExprPlan(), other.req.GetReq().GetSerializedExprPlan()) {
+		!bytes.Equal(t.req.GetReq().GetSerializedExprPlan(), other.req.GetReq().GetSerializedExprPlan()) ||
+		// the profiles of merged tasks could not be told apart
+		t.req.GetReq().GetProfile() || other.req.GetReq().GetProfile() {
 		return false
 	}
 
@@ -313,6 +327,15 @@ func (t *SearchTask) Merge(other *SearchTask) bool {
 	return true
 }
 
+// profileContext returns the context to execute the task on segments,
+// which carries a segment profiler if the request asks for profile.
+func (t *SearchTask) profileContext() (context.Context, *segments.SegmentProfiler) {
+	if !t.req.GetReq().GetProfile() {
+		return t.ctx, nil
+	}
+	return segments.WithSegmentProfiler(t.ctx)
+}
+
 func (t *SearchTask) Done(err error) {
 	if !t.merged {
 		metrics.QueryNodeSearchGroupSize.WithLabelValues(fmt.Sprint(t.GetNodeID())).Observe(float64(t.groupSize))
@@ -443,13 +466,14 @@ func (t *StreamingSearchTask) Execute() error {
 	// 1. search&&reduce or streaming-search&&streaming-reduce
 	metricType := searchReq.Plan().GetMetricType()
 	var relatedDataSize int64
+	ctx, profiler := t.profileContext()
 	if req.GetScope() == querypb.DataScope_Historical {
 		streamReduceFunc := func(result *segments.SearchResult) error {
 			reduceErr := t.streamReduce(t.ctx, searchReq.Plan(), result, t.originNqs, t.originTopks)
 			return reduceErr
 		}
 		pinnedSegments, err := segments.SearchHistoricalStreamly(
-			t.ctx,
+			ctx,
 			t.segmentManager,
 			searchReq,
 			req.GetReq().GetCollectionID(),
@@ -473,7 +497,7 @@ func (t *StreamingSearchTask) Execute() error {
 		}, 0)
 	} else if req.GetScope() == querypb.DataScope_Streaming {
 		results, pinnedSegments, err := segments.SearchStreaming(
-			t.ctx,
+			ctx,
 			t.segmentManager,
 			searchReq,
 			req.GetReq().GetCollectionID(),
@@ -546,6 +570,7 @@ func (t *StreamingSearchTask) Execute() error {
 				ServiceTime:          tr.ElapseSpan().Milliseconds(),
 				TotalRelatedDataSize: relatedDataSize,
 			},
+			SegmentProfiles: profiler.Profiles(),
 		}
 	}
 
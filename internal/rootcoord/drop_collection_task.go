@@ -19,12 +19,14 @@ package rootcoord
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/cockroachdb/errors"
 	"go.uber.org/zap"
 
 	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
 	"github.com/milvus-io/milvus-proto/go-api/v2/milvuspb"
+	"github.com/milvus-io/milvus/internal/metastore/model"
 	pb "github.com/milvus-io/milvus/internal/proto/etcdpb"
 	"github.com/milvus-io/milvus/internal/util/proxyutil"
 	"github.com/milvus-io/milvus/pkg/log"
@@ -35,6 +37,8 @@ import (
 type dropCollectionTask struct {
 	baseTask
 	Req *milvuspb.DropCollectionRequest
+	// how long the collection is kept in the recycle bin, zero means dropping immediately.
+	retention time.Duration
 }
 
 func (t *dropCollectionTask) validate(ctx context.Context) error {
@@ -48,7 +52,14 @@ func (t *dropCollectionTask) validate(ctx context.Context) error {
 }
 
 func (t *dropCollectionTask) Prepare(ctx context.Context) error {
-	return t.validate(ctx)
+	if err := t.validate(ctx); err != nil {
+		return err
+	}
+	// the replicated request is applied immediately, since the source cluster has its own recycle bin.
+	if !t.Req.GetBase().GetReplicateInfo().GetIsReplicate() {
+		t.retention = t.core.getRecycleRetention(ctx, t.Req.GetDbName())
+	}
+	return nil
 }
 
 func (t *dropCollectionTask) Execute(ctx context.Context) error {
@@ -82,42 +93,61 @@ func (t *dropCollectionTask) Execute(ctx context.Context) error {
 		ts:              ts,
 		opts:            []proxyutil.ExpireCacheOpt{proxyutil.SetMsgType(commonpb.MsgType_DropCollection)},
 	})
+	// the collection is moved into the recycle bin if the retention is set, it's released but the data is kept.
+	if t.retention > 0 {
+		redoTask.AddSyncStep(&changeCollectionStateStep{
+			baseStep:     baseStep{core: t.core},
+			collectionID: collMeta.CollectionID,
+			state:        pb.CollectionState_CollectionTrashed,
+			ts:           ts,
+		})
+		redoTask.AddAsyncStep(&releaseCollectionStep{
+			baseStep:     baseStep{core: t.core},
+			collectionID: collMeta.CollectionID,
+		})
+		return redoTask.Execute(ctx)
+	}
+
 	redoTask.AddSyncStep(&changeCollectionStateStep{
 		baseStep:     baseStep{core: t.core},
 		collectionID: collMeta.CollectionID,
 		state:        pb.CollectionState_CollectionDropping,
 		ts:           ts,
 	})
+	addDropCollectionAsyncSteps(redoTask, t.core, collMeta, ts, t.Req.GetBase().GetReplicateInfo().GetIsReplicate())
 
+	return redoTask.Execute(ctx)
+}
+
+// addDropCollectionAsyncSteps adds the steps to release the collection and remove its data and meta.
+func addDropCollectionAsyncSteps(redoTask *baseRedoTask, core *Core, collMeta *model.Collection, ts Timestamp, skipDeleteData bool) {
 	redoTask.AddAsyncStep(&releaseCollectionStep{
-		baseStep:     baseStep{core: t.core},
+		baseStep:     baseStep{core: core},
 		collectionID: collMeta.CollectionID,
 	})
 	redoTask.AddAsyncStep(&dropIndexStep{
-		baseStep: baseStep{core: t.core},
+		baseStep: baseStep{core: core},
 		collID:   collMeta.CollectionID,
 		partIDs:  nil,
 	})
 	redoTask.AddAsyncStep(&deleteCollectionDataStep{
-		baseStep: baseStep{core: t.core},
+		baseStep: baseStep{core: core},
 		coll:     collMeta,
-		isSkip:   t.Req.GetBase().GetReplicateInfo().GetIsReplicate(),
+		isSkip:   skipDeleteData,
 	})
 	redoTask.AddAsyncStep(&removeDmlChannelsStep{
-		baseStep:  baseStep{core: t.core},
+		baseStep:  baseStep{core: core},
 		pChannels: collMeta.PhysicalChannelNames,
 	})
-	redoTask.AddAsyncStep(newConfirmGCStep(t.core, collMeta.CollectionID, allPartition))
+	redoTask.AddAsyncStep(newConfirmGCStep(core, collMeta.CollectionID, allPartition))
 	redoTask.AddAsyncStep(&deleteCollectionMetaStep{
-		baseStep:     baseStep{core: t.core},
+		baseStep:     baseStep{core: core},
 		collectionID: collMeta.CollectionID,
 		// This ts is less than the ts when we notify data nodes to drop collection, but it's OK since we have already
 		// marked this collection as deleted. If we want to make this ts greater than the notification's ts, we should
 		// wrap a step who will have these three children and connect them with ts.
 		ts: ts,
 	})
-
-	return redoTask.Execute(ctx)
 }
 
 func (t *dropCollectionTask) GetLockerKey() LockerKey {
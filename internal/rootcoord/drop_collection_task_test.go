@@ -28,7 +28,9 @@ import (
 	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
 	"github.com/milvus-io/milvus-proto/go-api/v2/milvuspb"
 	"github.com/milvus-io/milvus/internal/metastore/model"
+	pb "github.com/milvus-io/milvus/internal/proto/etcdpb"
 	mockrootcoord "github.com/milvus-io/milvus/internal/rootcoord/mocks"
+	"github.com/milvus-io/milvus/pkg/common"
 	"github.com/milvus-io/milvus/pkg/util/funcutil"
 	"github.com/milvus-io/milvus/pkg/util/merr"
 )
@@ -75,6 +77,8 @@ func Test_dropCollectionTask_Prepare(t *testing.T) {
 			mock.Anything,
 			mock.Anything,
 		).Return(false)
+		meta.EXPECT().GetDatabaseByName(mock.Anything, mock.Anything, mock.Anything).
+			Return(model.NewDefaultDatabase([]*commonpb.KeyValuePair{{Key: common.DatabaseRecycleRetentionKey, Value: "3600"}}), nil)
 
 		core := newTestCore(withMeta(meta))
 		task := &dropCollectionTask{
@@ -86,6 +90,31 @@ func Test_dropCollectionTask_Prepare(t *testing.T) {
 		}
 		err := task.Prepare(context.Background())
 		assert.NoError(t, err)
+		assert.Equal(t, time.Hour, task.retention)
+	})
+
+	t.Run("replicated request", func(t *testing.T) {
+		meta := mockrootcoord.NewIMetaTable(t)
+		meta.On("IsAlias",
+			mock.Anything,
+			mock.Anything,
+			mock.Anything,
+		).Return(false)
+
+		core := newTestCore(withMeta(meta))
+		task := &dropCollectionTask{
+			baseTask: newBaseTask(context.Background(), core),
+			Req: &milvuspb.DropCollectionRequest{
+				Base: &commonpb.MsgBase{
+					MsgType:       commonpb.MsgType_DropCollection,
+					ReplicateInfo: &commonpb.ReplicateInfo{IsReplicate: true},
+				},
+				CollectionName: funcutil.GenRandomStr(),
+			},
+		}
+		err := task.Prepare(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, time.Duration(0), task.retention)
 	})
 }
 
@@ -285,4 +314,35 @@ func Test_dropCollectionTask_Execute(t *testing.T) {
 		<-removeCollectionMetaChan
 		assert.True(t, removeCollectionMetaCalled)
 	})
+
+	t.Run("move into recycle bin", func(t *testing.T) {
+		collectionName := funcutil.GenRandomStr()
+		coll := &model.Collection{Name: collectionName, CollectionID: 100}
+
+		meta := mockrootcoord.NewIMetaTable(t)
+		meta.EXPECT().GetCollectionByName(mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(coll.Clone(), nil)
+		meta.EXPECT().ListAliasesByID(mock.Anything, mock.Anything).Return([]string{})
+		meta.EXPECT().ChangeCollectionState(mock.Anything, int64(100), pb.CollectionState_CollectionTrashed, mock.Anything).Return(nil)
+
+		broker := newMockBroker()
+		releaseCollectionChan := make(chan struct{}, 1)
+		broker.ReleaseCollectionFunc = func(ctx context.Context, collectionID UniqueID) error {
+			releaseCollectionChan <- struct{}{}
+			return nil
+		}
+
+		core := newTestCore(withValidProxyManager(), withMeta(meta), withBroker(broker))
+		task := &dropCollectionTask{
+			baseTask: newBaseTask(context.Background(), core),
+			Req: &milvuspb.DropCollectionRequest{
+				Base:           &commonpb.MsgBase{MsgType: commonpb.MsgType_DropCollection},
+				CollectionName: collectionName,
+			},
+			retention: time.Hour,
+		}
+		err := task.Execute(context.Background())
+		assert.NoError(t, err)
+		// the collection is released, but its data and meta are kept.
+		<-releaseCollectionChan
+	})
 }
@@ -20,10 +20,16 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/cockroachdb/errors"
+	"github.com/samber/lo"
+
 	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
 	"github.com/milvus-io/milvus-proto/go-api/v2/milvuspb"
+	"github.com/milvus-io/milvus/internal/metastore/model"
 	"github.com/milvus-io/milvus/internal/util/proxyutil"
 	"github.com/milvus-io/milvus/pkg/util"
+	"github.com/milvus-io/milvus/pkg/util/merr"
+	"github.com/milvus-io/milvus/pkg/util/typeutil"
 )
 
 type dropDatabaseTask struct {
@@ -42,6 +48,22 @@ func (t *dropDatabaseTask) Execute(ctx context.Context) error {
 	redoTask := newBaseRedoTask(t.core.stepExecutor)
 	dbName := t.Req.GetDbName()
 	ts := t.GetTs()
+
+	// the collections in the recycle bin are purged with the database.
+	colls, err := t.core.meta.ListCollections(ctx, dbName, typeutil.MaxTimestamp, false)
+	if err != nil && !errors.Is(err, merr.ErrDatabaseNotFound) {
+		return err
+	}
+	// check the database is empty before purging, the trashed collections are kept if it's not dropped.
+	if lo.ContainsBy(colls, func(coll *model.Collection) bool { return coll.Available() }) {
+		return fmt.Errorf("database:%s not empty, must drop all collections before drop database", dbName)
+	}
+	for _, coll := range colls {
+		if coll.Trashed() {
+			addPurgeCollectionSteps(redoTask, t.core, coll, ts)
+		}
+	}
+
 	redoTask.AddSyncStep(&deleteDatabaseMetaStep{
 		baseStep:     baseStep{core: t.core},
 		databaseName: dbName,
@@ -19,6 +19,7 @@ package rootcoord
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/cockroachdb/errors"
 	"github.com/stretchr/testify/assert"
@@ -26,6 +27,8 @@ import (
 
 	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
 	"github.com/milvus-io/milvus-proto/go-api/v2/milvuspb"
+	"github.com/milvus-io/milvus/internal/metastore/model"
+	pb "github.com/milvus-io/milvus/internal/proto/etcdpb"
 	mockrootcoord "github.com/milvus-io/milvus/internal/rootcoord/mocks"
 	"github.com/milvus-io/milvus/pkg/util"
 )
@@ -38,6 +41,7 @@ func Test_DropDBTask(t *testing.T) {
 			mock.Anything,
 			mock.Anything).
 			Return(nil)
+		meta.EXPECT().ListCollections(mock.Anything, mock.Anything, mock.Anything, false).Return(nil, nil)
 
 		core := newTestCore(withMeta(meta), withValidProxyManager())
 		task := &dropDatabaseTask{
@@ -80,6 +84,7 @@ func Test_DropDBTask(t *testing.T) {
 			mock.Anything,
 			mock.Anything).
 			Return(errors.New("mock drop db error"))
+		meta.EXPECT().ListCollections(mock.Anything, mock.Anything, mock.Anything, false).Return(nil, nil)
 
 		core := newTestCore(withMeta(meta))
 		task := &dropDatabaseTask{
@@ -95,4 +100,70 @@ func Test_DropDBTask(t *testing.T) {
 		err := task.Execute(context.Background())
 		assert.Error(t, err)
 	})
+
+	t.Run("purge collections in recycle bin", func(t *testing.T) {
+		defer cleanTestEnv()
+
+		confirmGCInterval = time.Millisecond
+		defer restoreConfirmGCInterval()
+
+		coll := &model.Collection{Name: "coll", CollectionID: 100, State: pb.CollectionState_CollectionTrashed}
+		meta := mockrootcoord.NewIMetaTable(t)
+		meta.EXPECT().ListCollections(mock.Anything, mock.Anything, mock.Anything, false).
+			Return([]*model.Collection{coll, {Name: "dropping", CollectionID: 101, State: pb.CollectionState_CollectionDropping}}, nil)
+		meta.EXPECT().ChangeCollectionState(mock.Anything, int64(100), pb.CollectionState_CollectionDropping, mock.Anything).Return(nil).Once()
+		meta.EXPECT().DropDatabase(mock.Anything, mock.Anything, mock.Anything).Return(nil)
+		removeCollectionChan := make(chan struct{}, 1)
+		meta.EXPECT().RemoveCollection(mock.Anything, int64(100), mock.Anything).RunAndReturn(func(ctx context.Context, collectionID int64, ts uint64) error {
+			removeCollectionChan <- struct{}{}
+			return nil
+		})
+
+		broker := newMockBroker()
+		broker.ReleaseCollectionFunc = func(ctx context.Context, collectionID UniqueID) error {
+			return nil
+		}
+		broker.DropCollectionIndexFunc = func(ctx context.Context, collID UniqueID, partIDs []UniqueID) error {
+			return nil
+		}
+		broker.GCConfirmFunc = func(ctx context.Context, collectionID, partitionID UniqueID) bool {
+			return true
+		}
+		gc := mockrootcoord.NewGarbageCollector(t)
+		gc.EXPECT().GcCollectionData(mock.Anything, mock.Anything).Return(0, nil)
+
+		core := newTestCore(withMeta(meta), withValidProxyManager(), withBroker(broker), withGarbageCollector(gc),
+			withTtSynchronizer(newRocksMqTtSynchronizer()))
+		task := &dropDatabaseTask{
+			baseTask: newBaseTask(context.TODO(), core),
+			Req: &milvuspb.DropDatabaseRequest{
+				Base:   &commonpb.MsgBase{MsgType: commonpb.MsgType_DropDatabase},
+				DbName: "db",
+			},
+		}
+		err := task.Execute(context.Background())
+		assert.NoError(t, err)
+		<-removeCollectionChan
+	})
+
+	t.Run("keep the recycle bin of the database not empty", func(t *testing.T) {
+		meta := mockrootcoord.NewIMetaTable(t)
+		meta.EXPECT().ListCollections(mock.Anything, mock.Anything, mock.Anything, false).
+			Return([]*model.Collection{
+				{Name: "trashed", CollectionID: 100, State: pb.CollectionState_CollectionTrashed},
+				{Name: "coll", CollectionID: 101, State: pb.CollectionState_CollectionCreated},
+			}, nil)
+
+		core := newTestCore(withMeta(meta))
+		task := &dropDatabaseTask{
+			baseTask: newBaseTask(context.TODO(), core),
+			Req: &milvuspb.DropDatabaseRequest{
+				Base:   &commonpb.MsgBase{MsgType: commonpb.MsgType_DropDatabase},
+				DbName: "db",
+			},
+		}
+		// neither the trashed collection is purged nor the database is dropped
+		err := task.Execute(context.Background())
+		assert.Error(t, err)
+	})
 }
@@ -19,6 +19,7 @@ package rootcoord
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"go.uber.org/zap"
 
@@ -35,6 +36,8 @@ type dropPartitionTask struct {
 	baseTask
 	Req      *milvuspb.DropPartitionRequest
 	collMeta *model.Collection
+	// how long the partition is kept in the recycle bin, zero means dropping immediately.
+	retention time.Duration
 }
 
 func (t *dropPartitionTask) Prepare(ctx context.Context) error {
@@ -50,6 +53,10 @@ func (t *dropPartitionTask) Prepare(ctx context.Context) error {
 		return err
 	}
 	t.collMeta = collMeta
+	// the replicated request is applied immediately, since the source cluster has its own recycle bin.
+	if !t.Req.GetBase().GetReplicateInfo().GetIsReplicate() {
+		t.retention = t.core.getRecycleRetention(ctx, t.Req.GetDbName())
+	}
 	return nil
 }
 
@@ -78,6 +85,18 @@ func (t *dropPartitionTask) Execute(ctx context.Context) error {
 		ts:              t.GetTs(),
 		opts:            []proxyutil.ExpireCacheOpt{proxyutil.SetMsgType(commonpb.MsgType_DropPartition)},
 	})
+	// the partition is moved into the recycle bin if the retention is set, the data is kept.
+	if t.retention > 0 {
+		redoTask.AddSyncStep(&changePartitionStateStep{
+			baseStep:     baseStep{core: t.core},
+			collectionID: t.collMeta.CollectionID,
+			partitionID:  partID,
+			state:        pb.PartitionState_PartitionTrashed,
+			ts:           t.GetTs(),
+		})
+		return redoTask.Execute(ctx)
+	}
+
 	redoTask.AddSyncStep(&changePartitionStateStep{
 		baseStep:     baseStep{core: t.core},
 		collectionID: t.collMeta.CollectionID,
@@ -85,31 +104,35 @@ func (t *dropPartitionTask) Execute(ctx context.Context) error {
 		state:        pb.PartitionState_PartitionDropping,
 		ts:           t.GetTs(),
 	})
+	addDropPartitionAsyncSteps(redoTask, t.core, t.collMeta, &model.Partition{
+		PartitionID:   partID,
+		PartitionName: t.Req.GetPartitionName(),
+		CollectionID:  t.collMeta.CollectionID,
+	}, t.GetTs(), t.Req.GetBase().GetReplicateInfo().GetIsReplicate())
+
+	return redoTask.Execute(ctx)
+}
 
+// addDropPartitionAsyncSteps adds the steps to remove the data and meta of the partition.
+func addDropPartitionAsyncSteps(redoTask *baseRedoTask, core *Core, collMeta *model.Collection, partition *model.Partition, ts Timestamp, skipDeleteData bool) {
 	redoTask.AddAsyncStep(&deletePartitionDataStep{
-		baseStep: baseStep{core: t.core},
-		pchans:   t.collMeta.PhysicalChannelNames,
-		vchans:   t.collMeta.VirtualChannelNames,
-		partition: &model.Partition{
-			PartitionID:   partID,
-			PartitionName: t.Req.GetPartitionName(),
-			CollectionID:  t.collMeta.CollectionID,
-		},
-		isSkip: t.Req.GetBase().GetReplicateInfo().GetIsReplicate(),
+		baseStep:  baseStep{core: core},
+		pchans:    collMeta.PhysicalChannelNames,
+		vchans:    collMeta.VirtualChannelNames,
+		partition: partition,
+		isSkip:    skipDeleteData,
 	})
-	redoTask.AddAsyncStep(newConfirmGCStep(t.core, t.collMeta.CollectionID, partID))
+	redoTask.AddAsyncStep(newConfirmGCStep(core, collMeta.CollectionID, partition.PartitionID))
 	redoTask.AddAsyncStep(&removePartitionMetaStep{
-		baseStep:     baseStep{core: t.core},
-		dbID:         t.collMeta.DBID,
-		collectionID: t.collMeta.CollectionID,
-		partitionID:  partID,
+		baseStep:     baseStep{core: core},
+		dbID:         collMeta.DBID,
+		collectionID: collMeta.CollectionID,
+		partitionID:  partition.PartitionID,
 		// This ts is less than the ts when we notify data nodes to drop partition, but it's OK since we have already
 		// marked this partition as deleted. If we want to make this ts greater than the notification's ts, we should
 		// wrap a step who will have these children and connect them with ts.
-		ts: t.GetTs(),
+		ts: ts,
 	})
-
-	return redoTask.Execute(ctx)
 }
 
 func (t *dropPartitionTask) GetLockerKey() LockerKey {
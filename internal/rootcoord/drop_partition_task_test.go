@@ -27,6 +27,7 @@ import (
 	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
 	"github.com/milvus-io/milvus-proto/go-api/v2/milvuspb"
 	"github.com/milvus-io/milvus/internal/metastore/model"
+	pb "github.com/milvus-io/milvus/internal/proto/etcdpb"
 	mockrootcoord "github.com/milvus-io/milvus/internal/rootcoord/mocks"
 	"github.com/milvus-io/milvus/pkg/util/funcutil"
 )
@@ -76,6 +77,8 @@ func Test_dropPartitionTask_Prepare(t *testing.T) {
 			mock.Anything,
 			mock.Anything,
 		).Return(coll.Clone(), nil)
+		meta.EXPECT().GetDatabaseByName(mock.Anything, mock.Anything, mock.Anything).
+			Return(model.NewDefaultDatabase(nil), nil)
 
 		core := newTestCore(withMeta(meta))
 		task := &dropPartitionTask{
@@ -218,4 +221,27 @@ func Test_dropPartitionTask_Execute(t *testing.T) {
 		<-deletePartitionChan
 		assert.True(t, deletePartitionCalled)
 	})
+
+	t.Run("move into recycle bin", func(t *testing.T) {
+		collectionName := funcutil.GenRandomStr()
+		partitionName := funcutil.GenRandomStr()
+		coll := &model.Collection{Name: collectionName, CollectionID: 100, Partitions: []*model.Partition{{PartitionName: partitionName, PartitionID: 500}}}
+
+		meta := mockrootcoord.NewIMetaTable(t)
+		meta.EXPECT().ChangePartitionState(mock.Anything, int64(100), int64(500), pb.PartitionState_PartitionTrashed, mock.Anything).Return(nil)
+
+		core := newTestCore(withValidProxyManager(), withMeta(meta))
+		task := &dropPartitionTask{
+			baseTask: newBaseTask(context.Background(), core),
+			Req: &milvuspb.DropPartitionRequest{
+				Base:           &commonpb.MsgBase{MsgType: commonpb.MsgType_DropPartition},
+				CollectionName: collectionName,
+				PartitionName:  partitionName,
+			},
+			collMeta:  coll.Clone(),
+			retention: time.Hour,
+		}
+		err := task.Execute(context.Background())
+		assert.NoError(t, err)
+	})
 }
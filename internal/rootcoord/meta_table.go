@@ -55,6 +55,8 @@ type IMetaTable interface {
 
 	AddCollection(ctx context.Context, coll *model.Collection) error
 	ChangeCollectionState(ctx context.Context, collectionID UniqueID, state pb.CollectionState, ts Timestamp) error
+	// RestoreCollection moves the collection out of the recycle bin.
+	RestoreCollection(ctx context.Context, collectionID UniqueID, ts Timestamp) error
 	RemoveCollection(ctx context.Context, collectionID UniqueID, ts Timestamp) error
 	// GetCollectionID retrieves the corresponding collectionID based on the collectionName.
 	// If the collection does not exist, it will return InvalidCollectionID.
@@ -70,6 +72,8 @@ type IMetaTable interface {
 	GetPChannelInfo(ctx context.Context, pchannel string) *rootcoordpb.GetPChannelInfoResponse
 	AddPartition(ctx context.Context, partition *model.Partition) error
 	ChangePartitionState(ctx context.Context, collectionID UniqueID, partitionID UniqueID, state pb.PartitionState, ts Timestamp) error
+	// RestorePartition moves the partition out of the recycle bin.
+	RestorePartition(ctx context.Context, collectionID UniqueID, partitionID UniqueID, ts Timestamp) error
 	RemovePartition(ctx context.Context, dbID int64, collectionID UniqueID, partitionID UniqueID, ts Timestamp) error
 	CreateAlias(ctx context.Context, dbName string, alias string, collectionName string, ts Timestamp) error
 	DropAlias(ctx context.Context, dbName string, alias string, ts Timestamp) error
@@ -108,6 +112,9 @@ type IMetaTable interface {
 	ListPrivilegeGroups(ctx context.Context) ([]*milvuspb.PrivilegeGroupInfo, error)
 	OperatePrivilegeGroup(ctx context.Context, groupName string, privileges []*milvuspb.PrivilegeEntity, operateType milvuspb.OperatePrivilegeGroupType) error
 	GetPrivilegeGroupRoles(ctx context.Context, groupName string) ([]*milvuspb.RoleEntity, error)
+	OperateRowPolicy(ctx context.Context, tenant string, policy *internalpb.RowPolicy, operateType rootcoordpb.OperateRowPolicyType) error
+	DropRowPolicies(ctx context.Context, tenant string, role string) error
+	ListRowPolicies(ctx context.Context, tenant string) ([]*internalpb.RowPolicy, error)
 }
 
 // MetaTable is a persistent meta set of all databases, collections and partitions.
@@ -446,6 +453,9 @@ func (mt *MetaTable) ChangeCollectionState(ctx context.Context, collectionID Uni
 	}
 	clone := coll.Clone()
 	clone.State = state
+	if state == pb.CollectionState_CollectionTrashed {
+		clone.DroppedTime = ts
+	}
 	ctx1 := contextutil.WithTenantID(ctx, Params.CommonCfg.ClusterName.GetValue())
 	if err := mt.catalog.AlterCollection(ctx1, coll, clone, metastore.MODIFY, ts); err != nil {
 		return err
@@ -457,10 +467,12 @@ func (mt *MetaTable) ChangeCollectionState(ctx context.Context, collectionID Uni
 		return fmt.Errorf("dbID not found for collection:%d", collectionID)
 	}
 
-	switch state {
-	case pb.CollectionState_CollectionCreated:
+	switch {
+	case state == pb.CollectionState_CollectionCreated:
 		metrics.RootCoordNumOfCollections.WithLabelValues(db.Name).Inc()
 		metrics.RootCoordNumOfPartitions.WithLabelValues().Add(float64(coll.GetPartitionNum(true)))
+	case coll.Trashed():
+		// the collection has been removed from the metrics when it's moved into the recycle bin.
 	default:
 		metrics.RootCoordNumOfCollections.WithLabelValues(db.Name).Dec()
 		metrics.RootCoordNumOfPartitions.WithLabelValues().Sub(float64(coll.GetPartitionNum(true)))
@@ -472,6 +484,46 @@ func (mt *MetaTable) ChangeCollectionState(ctx context.Context, collectionID Uni
 	return nil
 }
 
+func (mt *MetaTable) RestoreCollection(ctx context.Context, collectionID UniqueID, ts Timestamp) error {
+	mt.ddLock.Lock()
+	defer mt.ddLock.Unlock()
+
+	coll, ok := mt.collID2Meta[collectionID]
+	if !ok || !coll.Trashed() {
+		return merr.WrapErrCollectionNotFound(collectionID, "collection is not in the recycle bin")
+	}
+	db, err := mt.getDatabaseByIDInternal(ctx, coll.DBID, typeutil.MaxTimestamp)
+	if err != nil {
+		return err
+	}
+	// a new collection with the same name may be created after the collection is dropped.
+	if id, ok := mt.names.get(db.Name, coll.Name); ok && id != collectionID {
+		if other, ok := mt.collID2Meta[id]; ok && other.Available() {
+			return merr.WrapErrParameterInvalidMsg("collection name %s is used by another collection, it must be dropped or renamed first", coll.Name)
+		}
+	}
+	if _, ok := mt.aliases.get(db.Name, coll.Name); ok {
+		return merr.WrapErrAliasCollectionNameConflict(db.Name, coll.Name)
+	}
+
+	clone := coll.Clone()
+	clone.State = pb.CollectionState_CollectionCreated
+	clone.DroppedTime = 0
+	ctx1 := contextutil.WithTenantID(ctx, Params.CommonCfg.ClusterName.GetValue())
+	if err := mt.catalog.AlterCollection(ctx1, coll, clone, metastore.MODIFY, ts); err != nil {
+		return err
+	}
+	mt.collID2Meta[collectionID] = clone
+	mt.names.insert(db.Name, coll.Name, collectionID)
+
+	metrics.RootCoordNumOfCollections.WithLabelValues(db.Name).Inc()
+	metrics.RootCoordNumOfPartitions.WithLabelValues().Add(float64(clone.GetPartitionNum(true)))
+
+	log.Ctx(ctx).Info("restore collection from recycle bin", zap.String("db", db.Name),
+		zap.String("collection", coll.Name), zap.Int64("collectionID", collectionID), zap.Uint64("ts", ts))
+	return nil
+}
+
 func (mt *MetaTable) removeIfNameMatchedInternal(collectionID UniqueID, name string) {
 	mt.names.removeIf(func(db string, collection string, id UniqueID) bool {
 		return collectionID == id
@@ -958,16 +1010,21 @@ func (mt *MetaTable) ChangePartitionState(ctx context.Context, collectionID Uniq
 		if part.PartitionID == partitionID {
 			clone := part.Clone()
 			clone.State = state
+			if state == pb.PartitionState_PartitionTrashed {
+				clone.DroppedTime = ts
+			}
 			ctx1 := contextutil.WithTenantID(ctx, Params.CommonCfg.ClusterName.GetValue())
 			if err := mt.catalog.AlterPartition(ctx1, coll.DBID, part, clone, metastore.MODIFY, ts); err != nil {
 				return err
 			}
 			mt.collID2Meta[collectionID].Partitions[idx] = clone
 
-			switch state {
-			case pb.PartitionState_PartitionCreated:
+			switch {
+			case state == pb.PartitionState_PartitionCreated:
 				// support Dynamic load/release partitions
 				metrics.RootCoordNumOfPartitions.WithLabelValues().Inc()
+			case part.Trashed():
+				// the partition has been removed from the metrics when it's moved into the recycle bin.
 			default:
 				metrics.RootCoordNumOfPartitions.WithLabelValues().Dec()
 			}
@@ -982,6 +1039,46 @@ func (mt *MetaTable) ChangePartitionState(ctx context.Context, collectionID Uniq
 	return fmt.Errorf("partition not exist, collection: %d, partition: %d", collectionID, partitionID)
 }
 
+func (mt *MetaTable) RestorePartition(ctx context.Context, collectionID UniqueID, partitionID UniqueID, ts Timestamp) error {
+	mt.ddLock.Lock()
+	defer mt.ddLock.Unlock()
+
+	coll, ok := mt.collID2Meta[collectionID]
+	if !ok || !coll.Available() {
+		return merr.WrapErrCollectionNotFound(collectionID)
+	}
+	part, idx, ok := lo.FindIndexOf(coll.Partitions, func(part *model.Partition) bool {
+		return part.PartitionID == partitionID && part.Trashed()
+	})
+	if !ok {
+		return merr.WrapErrPartitionNotFound(partitionID, "partition is not in the recycle bin")
+	}
+	// a new partition with the same name may be created after the partition is dropped.
+	if lo.ContainsBy(coll.Partitions, func(other *model.Partition) bool {
+		return other.Available() && other.PartitionName == part.PartitionName
+	}) {
+		return merr.WrapErrParameterInvalidMsg("partition name %s is used by another partition, it must be dropped first", part.PartitionName)
+	}
+	if maxPartitionNum := Params.RootCoordCfg.MaxPartitionNum.GetAsInt(); coll.GetPartitionNum(true) >= maxPartitionNum {
+		return merr.WrapErrParameterInvalidMsg("partition number (%d) exceeds max configuration (%d), collection: %s",
+			coll.GetPartitionNum(true), maxPartitionNum, coll.Name)
+	}
+
+	clone := part.Clone()
+	clone.State = pb.PartitionState_PartitionCreated
+	clone.DroppedTime = 0
+	ctx1 := contextutil.WithTenantID(ctx, Params.CommonCfg.ClusterName.GetValue())
+	if err := mt.catalog.AlterPartition(ctx1, coll.DBID, part, clone, metastore.MODIFY, ts); err != nil {
+		return err
+	}
+	coll.Partitions[idx] = clone
+
+	metrics.RootCoordNumOfPartitions.WithLabelValues().Inc()
+	log.Ctx(ctx).Info("restore partition from recycle bin", zap.Int64("collectionID", collectionID),
+		zap.String("partition", part.PartitionName), zap.Int64("partitionID", partitionID), zap.Uint64("ts", ts))
+	return nil
+}
+
 func (mt *MetaTable) RemovePartition(ctx context.Context, dbID int64, collectionID UniqueID, partitionID UniqueID, ts Timestamp) error {
 	mt.ddLock.Lock()
 	defer mt.ddLock.Unlock()
@@ -1705,3 +1802,50 @@ func (mt *MetaTable) GetPrivilegeGroupRoles(ctx context.Context, groupName strin
 	}
 	return lo.Keys(rolesMap), nil
 }
+
+func (mt *MetaTable) OperateRowPolicy(ctx context.Context, tenant string, policy *internalpb.RowPolicy, operateType rootcoordpb.OperateRowPolicyType) error {
+	if funcutil.IsEmptyString(policy.GetRole()) {
+		return fmt.Errorf("the role name in the row policy is empty")
+	}
+	if funcutil.IsEmptyString(policy.GetCollectionName()) {
+		return fmt.Errorf("the collection name in the row policy is empty")
+	}
+	if policy.GetDbName() == "" {
+		policy.DbName = util.DefaultDBName
+	}
+
+	mt.permissionLock.Lock()
+	defer mt.permissionLock.Unlock()
+
+	switch operateType {
+	case rootcoordpb.OperateRowPolicyType_SetRowPolicy:
+		if funcutil.IsEmptyString(policy.GetExpr()) {
+			return fmt.Errorf("the expr in the row policy is empty")
+		}
+		if _, err := mt.catalog.ListRole(ctx, tenant, &milvuspb.RoleEntity{Name: policy.GetRole()}, false); err != nil {
+			return err
+		}
+		return mt.catalog.SaveRowPolicy(ctx, tenant, policy)
+	case rootcoordpb.OperateRowPolicyType_DropRowPolicy:
+		return mt.catalog.DropRowPolicy(ctx, tenant, policy.GetRole(), policy.GetDbName(), policy.GetCollectionName())
+	default:
+		return fmt.Errorf("invalid operate type for row policy: %s", operateType.String())
+	}
+}
+
+func (mt *MetaTable) DropRowPolicies(ctx context.Context, tenant string, role string) error {
+	if funcutil.IsEmptyString(role) {
+		return fmt.Errorf("the role name is empty when dropping the row policies")
+	}
+	mt.permissionLock.Lock()
+	defer mt.permissionLock.Unlock()
+
+	return mt.catalog.DeleteRowPolicies(ctx, tenant, role)
+}
+
+func (mt *MetaTable) ListRowPolicies(ctx context.Context, tenant string) ([]*internalpb.RowPolicy, error) {
+	mt.permissionLock.RLock()
+	defer mt.permissionLock.RUnlock()
+
+	return mt.catalog.ListRowPolicies(ctx, tenant)
+}
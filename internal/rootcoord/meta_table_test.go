@@ -29,11 +29,13 @@ import (
 	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
 	"github.com/milvus-io/milvus-proto/go-api/v2/milvuspb"
 	memkv "github.com/milvus-io/milvus/internal/kv/mem"
+	"github.com/milvus-io/milvus/internal/metastore"
 	"github.com/milvus-io/milvus/internal/metastore/kv/rootcoord"
 	"github.com/milvus-io/milvus/internal/metastore/mocks"
 	"github.com/milvus-io/milvus/internal/metastore/model"
 	pb "github.com/milvus-io/milvus/internal/proto/etcdpb"
 	"github.com/milvus-io/milvus/internal/proto/internalpb"
+	"github.com/milvus-io/milvus/internal/proto/rootcoordpb"
 	mocktso "github.com/milvus-io/milvus/internal/tso/mocks"
 	"github.com/milvus-io/milvus/pkg/common"
 	"github.com/milvus-io/milvus/pkg/util"
@@ -1756,6 +1758,132 @@ func TestMetaTable_ChangePartitionState(t *testing.T) {
 	})
 }
 
+func TestMetaTable_RestoreCollection(t *testing.T) {
+	newMeta := func(catalog *mocks.RootCoordCatalog) *MetaTable {
+		meta := &MetaTable{
+			catalog: catalog,
+			dbName2Meta: map[string]*model.Database{
+				util.DefaultDBName: {Name: util.DefaultDBName, ID: util.DefaultDBID},
+			},
+			collID2Meta: map[typeutil.UniqueID]*model.Collection{
+				100: {Name: "test", CollectionID: 100, DBID: util.DefaultDBID, State: pb.CollectionState_CollectionTrashed, DroppedTime: 1000},
+			},
+			names:   newNameDb(),
+			aliases: newNameDb(),
+		}
+		meta.names.insert(util.DefaultDBName, "test", 100)
+		return meta
+	}
+
+	t.Run("not in recycle bin", func(t *testing.T) {
+		meta := newMeta(nil)
+		meta.collID2Meta[100].State = pb.CollectionState_CollectionCreated
+		err := meta.RestoreCollection(context.TODO(), 100, 2000)
+		assert.ErrorIs(t, err, merr.ErrCollectionNotFound)
+		err = meta.RestoreCollection(context.TODO(), 101, 2000)
+		assert.ErrorIs(t, err, merr.ErrCollectionNotFound)
+	})
+
+	t.Run("name is used", func(t *testing.T) {
+		meta := newMeta(nil)
+		meta.collID2Meta[101] = &model.Collection{Name: "test", CollectionID: 101, DBID: util.DefaultDBID, State: pb.CollectionState_CollectionCreated}
+		meta.names.insert(util.DefaultDBName, "test", 101)
+		err := meta.RestoreCollection(context.TODO(), 100, 2000)
+		assert.ErrorIs(t, err, merr.ErrParameterInvalid)
+	})
+
+	t.Run("name is used by alias", func(t *testing.T) {
+		meta := newMeta(nil)
+		meta.aliases.insert(util.DefaultDBName, "test", 101)
+		err := meta.RestoreCollection(context.TODO(), 100, 2000)
+		assert.Error(t, err)
+	})
+
+	t.Run("failed to alter collection", func(t *testing.T) {
+		catalog := mocks.NewRootCoordCatalog(t)
+		catalog.EXPECT().AlterCollection(mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+			Return(errors.New("error mock AlterCollection"))
+		meta := newMeta(catalog)
+		err := meta.RestoreCollection(context.TODO(), 100, 2000)
+		assert.Error(t, err)
+		assert.True(t, meta.collID2Meta[100].Trashed())
+	})
+
+	t.Run("normal case", func(t *testing.T) {
+		catalog := mocks.NewRootCoordCatalog(t)
+		catalog.EXPECT().AlterCollection(mock.Anything, mock.Anything, mock.Anything, metastore.MODIFY, mock.Anything).
+			Return(nil)
+		meta := newMeta(catalog)
+		// a collection with the same name has been created and dropped.
+		meta.names.insert(util.DefaultDBName, "test", 101)
+		err := meta.RestoreCollection(context.TODO(), 100, 2000)
+		assert.NoError(t, err)
+		coll, err := meta.GetCollectionByName(context.TODO(), util.DefaultDBName, "test", typeutil.MaxTimestamp)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(100), coll.CollectionID)
+		assert.Equal(t, uint64(0), coll.DroppedTime)
+	})
+}
+
+func TestMetaTable_RestorePartition(t *testing.T) {
+	newMeta := func(catalog *mocks.RootCoordCatalog) *MetaTable {
+		return &MetaTable{
+			catalog: catalog,
+			collID2Meta: map[typeutil.UniqueID]*model.Collection{
+				100: {
+					Name: "test", CollectionID: 100, State: pb.CollectionState_CollectionCreated,
+					Partitions: []*model.Partition{
+						{CollectionID: 100, PartitionID: 500, PartitionName: "p", State: pb.PartitionState_PartitionTrashed, DroppedTime: 1000},
+					},
+				},
+			},
+		}
+	}
+
+	t.Run("collection not available", func(t *testing.T) {
+		meta := newMeta(nil)
+		meta.collID2Meta[100].State = pb.CollectionState_CollectionTrashed
+		err := meta.RestorePartition(context.TODO(), 100, 500, 2000)
+		assert.ErrorIs(t, err, merr.ErrCollectionNotFound)
+	})
+
+	t.Run("not in recycle bin", func(t *testing.T) {
+		meta := newMeta(nil)
+		err := meta.RestorePartition(context.TODO(), 100, 501, 2000)
+		assert.ErrorIs(t, err, merr.ErrPartitionNotFound)
+	})
+
+	t.Run("name is used", func(t *testing.T) {
+		meta := newMeta(nil)
+		coll := meta.collID2Meta[100]
+		coll.Partitions = append(coll.Partitions, &model.Partition{CollectionID: 100, PartitionID: 501, PartitionName: "p", State: pb.PartitionState_PartitionCreated})
+		err := meta.RestorePartition(context.TODO(), 100, 500, 2000)
+		assert.ErrorIs(t, err, merr.ErrParameterInvalid)
+	})
+
+	t.Run("exceed max partition number", func(t *testing.T) {
+		paramtable.Get().Save(Params.RootCoordCfg.MaxPartitionNum.Key, "1")
+		defer paramtable.Get().Reset(Params.RootCoordCfg.MaxPartitionNum.Key)
+		meta := newMeta(nil)
+		coll := meta.collID2Meta[100]
+		coll.Partitions = append(coll.Partitions, &model.Partition{CollectionID: 100, PartitionID: 501, PartitionName: "p2", State: pb.PartitionState_PartitionCreated})
+		err := meta.RestorePartition(context.TODO(), 100, 500, 2000)
+		assert.ErrorIs(t, err, merr.ErrParameterInvalid)
+	})
+
+	t.Run("normal case", func(t *testing.T) {
+		catalog := mocks.NewRootCoordCatalog(t)
+		catalog.EXPECT().AlterPartition(mock.Anything, mock.Anything, mock.Anything, mock.Anything, metastore.MODIFY, mock.Anything).
+			Return(nil)
+		meta := newMeta(catalog)
+		err := meta.RestorePartition(context.TODO(), 100, 500, 2000)
+		assert.NoError(t, err)
+		partition := meta.collID2Meta[100].Partitions[0]
+		assert.True(t, partition.Available())
+		assert.Equal(t, uint64(0), partition.DroppedTime)
+	})
+}
+
 func TestMetaTable_CreateDatabase(t *testing.T) {
 	db := model.NewDatabase(1, "exist", pb.DatabaseState_DatabaseCreated, nil)
 	t.Run("database already exist", func(t *testing.T) {
@@ -2148,3 +2276,42 @@ func TestMetaTable_PrivilegeGroup(t *testing.T) {
 	_, err = mt.ListPrivilegeGroups(context.TODO())
 	assert.NoError(t, err)
 }
+
+func TestMetaTable_RowPolicy(t *testing.T) {
+	catalog := mocks.NewRootCoordCatalog(t)
+	mt := &MetaTable{catalog: catalog}
+	ctx := context.TODO()
+
+	policy := &internalpb.RowPolicy{Role: "role1", CollectionName: "coll1", Expr: "tenant_id == 1"}
+	catalog.EXPECT().ListRole(mock.Anything, util.DefaultTenant, &milvuspb.RoleEntity{Name: "role1"}, false).Return(nil, nil).Once()
+	catalog.EXPECT().SaveRowPolicy(mock.Anything, util.DefaultTenant, policy).Return(nil).Once()
+	err := mt.OperateRowPolicy(ctx, util.DefaultTenant, policy, rootcoordpb.OperateRowPolicyType_SetRowPolicy)
+	assert.NoError(t, err)
+	assert.Equal(t, util.DefaultDBName, policy.GetDbName())
+
+	catalog.EXPECT().ListRole(mock.Anything, util.DefaultTenant, mock.Anything, false).Return(nil, errors.New("role not found")).Once()
+	err = mt.OperateRowPolicy(ctx, util.DefaultTenant, policy, rootcoordpb.OperateRowPolicyType_SetRowPolicy)
+	assert.Error(t, err)
+
+	err = mt.OperateRowPolicy(ctx, util.DefaultTenant, &internalpb.RowPolicy{Role: "role1", CollectionName: "coll1"}, rootcoordpb.OperateRowPolicyType_SetRowPolicy)
+	assert.Error(t, err)
+	err = mt.OperateRowPolicy(ctx, util.DefaultTenant, &internalpb.RowPolicy{CollectionName: "coll1"}, rootcoordpb.OperateRowPolicyType_DropRowPolicy)
+	assert.Error(t, err)
+	err = mt.OperateRowPolicy(ctx, util.DefaultTenant, &internalpb.RowPolicy{Role: "role1"}, rootcoordpb.OperateRowPolicyType_DropRowPolicy)
+	assert.Error(t, err)
+
+	catalog.EXPECT().DropRowPolicy(mock.Anything, util.DefaultTenant, "role1", util.DefaultDBName, "coll1").Return(nil).Once()
+	err = mt.OperateRowPolicy(ctx, util.DefaultTenant, &internalpb.RowPolicy{Role: "role1", CollectionName: "coll1"}, rootcoordpb.OperateRowPolicyType_DropRowPolicy)
+	assert.NoError(t, err)
+
+	err = mt.DropRowPolicies(ctx, util.DefaultTenant, "")
+	assert.Error(t, err)
+	catalog.EXPECT().DeleteRowPolicies(mock.Anything, util.DefaultTenant, "role1").Return(nil).Once()
+	err = mt.DropRowPolicies(ctx, util.DefaultTenant, "role1")
+	assert.NoError(t, err)
+
+	catalog.EXPECT().ListRowPolicies(mock.Anything, util.DefaultTenant).Return([]*internalpb.RowPolicy{policy}, nil).Once()
+	policies, err := mt.ListRowPolicies(ctx, util.DefaultTenant)
+	assert.NoError(t, err)
+	assert.Len(t, policies, 1)
+}
@@ -1063,6 +1063,54 @@ func (_c *IMetaTable_DropRole_Call) RunAndReturn(run func(context.Context, strin
 	return _c
 }
 
+// DropRowPolicies provides a mock function with given fields: ctx, tenant, role
+func (_m *IMetaTable) DropRowPolicies(ctx context.Context, tenant string, role string) error {
+	ret := _m.Called(ctx, tenant, role)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DropRowPolicies")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, tenant, role)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// IMetaTable_DropRowPolicies_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DropRowPolicies'
+type IMetaTable_DropRowPolicies_Call struct {
+	*mock.Call
+}
+
+// DropRowPolicies is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tenant string
+//   - role string
+func (_e *IMetaTable_Expecter) DropRowPolicies(ctx interface{}, tenant interface{}, role interface{}) *IMetaTable_DropRowPolicies_Call {
+	return &IMetaTable_DropRowPolicies_Call{Call: _e.mock.On("DropRowPolicies", ctx, tenant, role)}
+}
+
+func (_c *IMetaTable_DropRowPolicies_Call) Run(run func(ctx context.Context, tenant string, role string)) *IMetaTable_DropRowPolicies_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *IMetaTable_DropRowPolicies_Call) Return(_a0 error) *IMetaTable_DropRowPolicies_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *IMetaTable_DropRowPolicies_Call) RunAndReturn(run func(context.Context, string, string) error) *IMetaTable_DropRowPolicies_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetCollectionByID provides a mock function with given fields: ctx, dbName, collectionID, ts, allowUnavailable
 func (_m *IMetaTable) GetCollectionByID(ctx context.Context, dbName string, collectionID int64, ts uint64, allowUnavailable bool) (*model.Collection, error) {
 	ret := _m.Called(ctx, dbName, collectionID, ts, allowUnavailable)
@@ -2281,6 +2329,65 @@ func (_c *IMetaTable_ListPrivilegeGroups_Call) RunAndReturn(run func(context.Con
 	return _c
 }
 
+// ListRowPolicies provides a mock function with given fields: ctx, tenant
+func (_m *IMetaTable) ListRowPolicies(ctx context.Context, tenant string) ([]*internalpb.RowPolicy, error) {
+	ret := _m.Called(ctx, tenant)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListRowPolicies")
+	}
+
+	var r0 []*internalpb.RowPolicy
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]*internalpb.RowPolicy, error)); ok {
+		return rf(ctx, tenant)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []*internalpb.RowPolicy); ok {
+		r0 = rf(ctx, tenant)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*internalpb.RowPolicy)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, tenant)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IMetaTable_ListRowPolicies_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListRowPolicies'
+type IMetaTable_ListRowPolicies_Call struct {
+	*mock.Call
+}
+
+// ListRowPolicies is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tenant string
+func (_e *IMetaTable_Expecter) ListRowPolicies(ctx interface{}, tenant interface{}) *IMetaTable_ListRowPolicies_Call {
+	return &IMetaTable_ListRowPolicies_Call{Call: _e.mock.On("ListRowPolicies", ctx, tenant)}
+}
+
+func (_c *IMetaTable_ListRowPolicies_Call) Run(run func(ctx context.Context, tenant string)) *IMetaTable_ListRowPolicies_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *IMetaTable_ListRowPolicies_Call) Return(_a0 []*internalpb.RowPolicy, _a1 error) *IMetaTable_ListRowPolicies_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *IMetaTable_ListRowPolicies_Call) RunAndReturn(run func(context.Context, string) ([]*internalpb.RowPolicy, error)) *IMetaTable_ListRowPolicies_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // ListUserRole provides a mock function with given fields: ctx, tenant
 func (_m *IMetaTable) ListUserRole(ctx context.Context, tenant string) ([]string, error) {
 	ret := _m.Called(ctx, tenant)
@@ -2438,6 +2545,55 @@ func (_c *IMetaTable_OperatePrivilegeGroup_Call) RunAndReturn(run func(context.C
 	return _c
 }
 
+// OperateRowPolicy provides a mock function with given fields: ctx, tenant, policy, operateType
+func (_m *IMetaTable) OperateRowPolicy(ctx context.Context, tenant string, policy *internalpb.RowPolicy, operateType rootcoordpb.OperateRowPolicyType) error {
+	ret := _m.Called(ctx, tenant, policy, operateType)
+
+	if len(ret) == 0 {
+		panic("no return value specified for OperateRowPolicy")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *internalpb.RowPolicy, rootcoordpb.OperateRowPolicyType) error); ok {
+		r0 = rf(ctx, tenant, policy, operateType)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// IMetaTable_OperateRowPolicy_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'OperateRowPolicy'
+type IMetaTable_OperateRowPolicy_Call struct {
+	*mock.Call
+}
+
+// OperateRowPolicy is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tenant string
+//   - policy *internalpb.RowPolicy
+//   - operateType rootcoordpb.OperateRowPolicyType
+func (_e *IMetaTable_Expecter) OperateRowPolicy(ctx interface{}, tenant interface{}, policy interface{}, operateType interface{}) *IMetaTable_OperateRowPolicy_Call {
+	return &IMetaTable_OperateRowPolicy_Call{Call: _e.mock.On("OperateRowPolicy", ctx, tenant, policy, operateType)}
+}
+
+func (_c *IMetaTable_OperateRowPolicy_Call) Run(run func(ctx context.Context, tenant string, policy *internalpb.RowPolicy, operateType rootcoordpb.OperateRowPolicyType)) *IMetaTable_OperateRowPolicy_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(*internalpb.RowPolicy), args[3].(rootcoordpb.OperateRowPolicyType))
+	})
+	return _c
+}
+
+func (_c *IMetaTable_OperateRowPolicy_Call) Return(_a0 error) *IMetaTable_OperateRowPolicy_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *IMetaTable_OperateRowPolicy_Call) RunAndReturn(run func(context.Context, string, *internalpb.RowPolicy, rootcoordpb.OperateRowPolicyType) error) *IMetaTable_OperateRowPolicy_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // OperateUserRole provides a mock function with given fields: ctx, tenant, userEntity, roleEntity, operateType
 func (_m *IMetaTable) OperateUserRole(ctx context.Context, tenant string, userEntity *milvuspb.UserEntity, roleEntity *milvuspb.RoleEntity, operateType milvuspb.OperateUserRoleType) error {
 	ret := _m.Called(ctx, tenant, userEntity, roleEntity, operateType)
@@ -2637,6 +2793,103 @@ func (_c *IMetaTable_RenameCollection_Call) RunAndReturn(run func(context.Contex
 	return _c
 }
 
+// RestoreCollection provides a mock function with given fields: ctx, collectionID, ts
+func (_m *IMetaTable) RestoreCollection(ctx context.Context, collectionID int64, ts uint64) error {
+	ret := _m.Called(ctx, collectionID, ts)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RestoreCollection")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, uint64) error); ok {
+		r0 = rf(ctx, collectionID, ts)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// IMetaTable_RestoreCollection_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RestoreCollection'
+type IMetaTable_RestoreCollection_Call struct {
+	*mock.Call
+}
+
+// RestoreCollection is a helper method to define mock.On call
+//   - ctx context.Context
+//   - collectionID int64
+//   - ts uint64
+func (_e *IMetaTable_Expecter) RestoreCollection(ctx interface{}, collectionID interface{}, ts interface{}) *IMetaTable_RestoreCollection_Call {
+	return &IMetaTable_RestoreCollection_Call{Call: _e.mock.On("RestoreCollection", ctx, collectionID, ts)}
+}
+
+func (_c *IMetaTable_RestoreCollection_Call) Run(run func(ctx context.Context, collectionID int64, ts uint64)) *IMetaTable_RestoreCollection_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(uint64))
+	})
+	return _c
+}
+
+func (_c *IMetaTable_RestoreCollection_Call) Return(_a0 error) *IMetaTable_RestoreCollection_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *IMetaTable_RestoreCollection_Call) RunAndReturn(run func(context.Context, int64, uint64) error) *IMetaTable_RestoreCollection_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RestorePartition provides a mock function with given fields: ctx, collectionID, partitionID, ts
+func (_m *IMetaTable) RestorePartition(ctx context.Context, collectionID int64, partitionID int64, ts uint64) error {
+	ret := _m.Called(ctx, collectionID, partitionID, ts)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RestorePartition")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64, uint64) error); ok {
+		r0 = rf(ctx, collectionID, partitionID, ts)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// IMetaTable_RestorePartition_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RestorePartition'
+type IMetaTable_RestorePartition_Call struct {
+	*mock.Call
+}
+
+// RestorePartition is a helper method to define mock.On call
+//   - ctx context.Context
+//   - collectionID int64
+//   - partitionID int64
+//   - ts uint64
+func (_e *IMetaTable_Expecter) RestorePartition(ctx interface{}, collectionID interface{}, partitionID interface{}, ts interface{}) *IMetaTable_RestorePartition_Call {
+	return &IMetaTable_RestorePartition_Call{Call: _e.mock.On("RestorePartition", ctx, collectionID, partitionID, ts)}
+}
+
+func (_c *IMetaTable_RestorePartition_Call) Run(run func(ctx context.Context, collectionID int64, partitionID int64, ts uint64)) *IMetaTable_RestorePartition_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(int64), args[3].(uint64))
+	})
+	return _c
+}
+
+func (_c *IMetaTable_RestorePartition_Call) Return(_a0 error) *IMetaTable_RestorePartition_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *IMetaTable_RestorePartition_Call) RunAndReturn(run func(context.Context, int64, int64, uint64) error) *IMetaTable_RestorePartition_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // RestoreRBAC provides a mock function with given fields: ctx, tenant, meta
 func (_m *IMetaTable) RestoreRBAC(ctx context.Context, tenant string, meta *milvuspb.RBACMeta) error {
 	ret := _m.Called(ctx, tenant, meta)
@@ -0,0 +1,280 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rootcoord
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/samber/lo"
+	"go.uber.org/zap"
+
+	"github.com/milvus-io/milvus/internal/metastore/model"
+	pb "github.com/milvus-io/milvus/internal/proto/etcdpb"
+	"github.com/milvus-io/milvus/internal/proto/proxypb"
+	"github.com/milvus-io/milvus/pkg/common"
+	"github.com/milvus-io/milvus/pkg/log"
+	"github.com/milvus-io/milvus/pkg/util"
+	"github.com/milvus-io/milvus/pkg/util/merr"
+	"github.com/milvus-io/milvus/pkg/util/tsoutil"
+	"github.com/milvus-io/milvus/pkg/util/typeutil"
+)
+
+// getRecycleRetention returns how long the dropped collections and partitions of the database are kept
+// in the recycle bin, the database property overrides the global config. Zero means dropping immediately.
+func (c *Core) getRecycleRetention(ctx context.Context, dbName string) time.Duration {
+	if dbName == "" {
+		dbName = util.DefaultDBName
+	}
+	retention := Params.RootCoordCfg.RecycleBinRetention.GetAsDuration(time.Second)
+	db, err := c.meta.GetDatabaseByName(ctx, dbName, typeutil.MaxTimestamp)
+	if err != nil {
+		return retention
+	}
+	seconds, err := common.DatabaseLevelRecycleRetention(db.Properties)
+	if err != nil {
+		return retention
+	}
+	return time.Duration(seconds) * time.Second
+}
+
+// recycleBinExpired returns whether the item dropped at the ts should be purged.
+func recycleBinExpired(droppedTime Timestamp, retention time.Duration, now time.Time) bool {
+	return tsoutil.PhysicalTime(droppedTime).Add(retention).Before(now)
+}
+
+func (c *Core) recycleBinLoop() {
+	defer c.wg.Done()
+	ticker := time.NewTicker(Params.RootCoordCfg.RecycleBinCheckInterval.GetAsDuration(time.Second))
+	defer ticker.Stop()
+	for {
+		select {
+		case <-c.ctx.Done():
+			log.Info("recycle bin loop exit")
+			return
+		case <-ticker.C:
+			c.purgeRecycleBin(c.ctx)
+		}
+	}
+}
+
+// purgeRecycleBin drops the collections and partitions whose retention has expired.
+func (c *Core) purgeRecycleBin(ctx context.Context) {
+	dbs, err := c.meta.ListDatabases(ctx, typeutil.MaxTimestamp)
+	if err != nil {
+		log.Warn("failed to list databases for purging recycle bin", zap.Error(err))
+		return
+	}
+	now := time.Now()
+	for _, db := range dbs {
+		colls, err := c.meta.ListCollections(ctx, db.Name, typeutil.MaxTimestamp, false)
+		if err != nil {
+			log.Warn("failed to list collections for purging recycle bin", zap.String("db", db.Name), zap.Error(err))
+			continue
+		}
+		retention := c.getRecycleRetention(ctx, db.Name)
+		for _, coll := range colls {
+			if coll.Trashed() && recycleBinExpired(coll.DroppedTime, retention, now) {
+				c.purgeTrashedCollection(ctx, db.Name, coll)
+				continue
+			}
+			if !coll.Available() {
+				continue
+			}
+			for _, partition := range coll.Partitions {
+				if partition.Trashed() && recycleBinExpired(partition.DroppedTime, retention, now) {
+					c.purgeTrashedPartition(ctx, db.Name, coll, partition)
+				}
+			}
+		}
+	}
+}
+
+func (c *Core) purgeTrashedCollection(ctx context.Context, dbName string, coll *model.Collection) {
+	log := log.Ctx(ctx).With(zap.String("db", dbName), zap.String("collection", coll.Name),
+		zap.Int64("collectionID", coll.CollectionID))
+	t := &purgeCollectionTask{
+		baseTask:       newBaseTask(ctx, c),
+		dbName:         dbName,
+		collectionName: coll.Name,
+		collectionID:   coll.CollectionID,
+	}
+	if err := c.scheduler.AddTask(t); err != nil {
+		log.Warn("failed to enqueue task to purge collection", zap.Error(err))
+		return
+	}
+	if err := t.WaitToFinish(); err != nil {
+		log.Warn("failed to purge collection", zap.Error(err))
+		return
+	}
+	log.Info("purge expired collection from recycle bin", zap.Uint64("droppedTime", coll.DroppedTime))
+}
+
+func (c *Core) purgeTrashedPartition(ctx context.Context, dbName string, coll *model.Collection, partition *model.Partition) {
+	log := log.Ctx(ctx).With(zap.String("db", dbName), zap.String("collection", coll.Name),
+		zap.Int64("collectionID", coll.CollectionID), zap.String("partition", partition.PartitionName),
+		zap.Int64("partitionID", partition.PartitionID))
+	t := &purgePartitionTask{
+		baseTask:     newBaseTask(ctx, c),
+		dbName:       dbName,
+		collectionID: coll.CollectionID,
+		partitionID:  partition.PartitionID,
+	}
+	if err := c.scheduler.AddTask(t); err != nil {
+		log.Warn("failed to enqueue task to purge partition", zap.Error(err))
+		return
+	}
+	if err := t.WaitToFinish(); err != nil {
+		log.Warn("failed to purge partition", zap.Error(err))
+		return
+	}
+	log.Info("purge expired partition from recycle bin", zap.Uint64("droppedTime", partition.DroppedTime))
+}
+
+// purgeCollectionTask drops a collection in the recycle bin permanently.
+type purgeCollectionTask struct {
+	baseTask
+	dbName         string
+	collectionName string
+	collectionID   UniqueID
+}
+
+func (t *purgeCollectionTask) Execute(ctx context.Context) error {
+	collMeta, err := t.core.meta.GetCollectionByID(ctx, t.dbName, t.collectionID, typeutil.MaxTimestamp, true)
+	if errors.Is(err, merr.ErrCollectionNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	// the collection may be restored or purged by others before the task is scheduled.
+	if !collMeta.Trashed() {
+		return nil
+	}
+	redoTask := newBaseRedoTask(t.core.stepExecutor)
+	addPurgeCollectionSteps(redoTask, t.core, collMeta, t.GetTs())
+	return redoTask.Execute(ctx)
+}
+
+func (t *purgeCollectionTask) GetLockerKey() LockerKey {
+	return NewLockerKeyChain(
+		NewClusterLockerKey(false),
+		NewDatabaseLockerKey(t.dbName, false),
+		NewCollectionLockerKey(t.collectionName, true),
+	)
+}
+
+// addPurgeCollectionSteps adds the steps to drop a trashed collection, the collection has been released
+// when it was moved into the recycle bin.
+func addPurgeCollectionSteps(redoTask *baseRedoTask, core *Core, collMeta *model.Collection, ts Timestamp) {
+	redoTask.AddSyncStep(&changeCollectionStateStep{
+		baseStep:     baseStep{core: core},
+		collectionID: collMeta.CollectionID,
+		state:        pb.CollectionState_CollectionDropping,
+		ts:           ts,
+	})
+	addDropCollectionAsyncSteps(redoTask, core, collMeta, ts, !Params.CommonCfg.TTMsgEnabled.GetAsBool())
+}
+
+// purgePartitionTask drops a partition in the recycle bin permanently.
+type purgePartitionTask struct {
+	baseTask
+	dbName       string
+	collectionID UniqueID
+	partitionID  UniqueID
+}
+
+func (t *purgePartitionTask) Execute(ctx context.Context) error {
+	coll, err := t.core.meta.GetCollectionByID(ctx, t.dbName, t.collectionID, typeutil.MaxTimestamp, true)
+	if errors.Is(err, merr.ErrCollectionNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	// the partition may be restored or purged by others before the task is scheduled.
+	partition, ok := lo.Find(coll.Partitions, func(p *model.Partition) bool {
+		return p.PartitionID == t.partitionID && p.Trashed()
+	})
+	if !ok {
+		return nil
+	}
+
+	redoTask := newBaseRedoTask(t.core.stepExecutor)
+	redoTask.AddSyncStep(&changePartitionStateStep{
+		baseStep:     baseStep{core: t.core},
+		collectionID: t.collectionID,
+		partitionID:  t.partitionID,
+		state:        pb.PartitionState_PartitionDropping,
+		ts:           t.GetTs(),
+	})
+	addDropPartitionAsyncSteps(redoTask, t.core, coll, partition, t.GetTs(), !Params.CommonCfg.TTMsgEnabled.GetAsBool())
+	return redoTask.Execute(ctx)
+}
+
+func (t *purgePartitionTask) GetLockerKey() LockerKey {
+	return NewLockerKeyChain(
+		NewClusterLockerKey(false),
+		NewDatabaseLockerKey(t.dbName, false),
+		NewCollectionLockerKey(strconv.FormatInt(t.collectionID, 10), true),
+	)
+}
+
+// listRecycleBin lists the collections and partitions of the database which are in the recycle bin.
+func (c *Core) listRecycleBin(ctx context.Context, dbName string) ([]*proxypb.TrashedCollection, []*proxypb.TrashedPartition, error) {
+	colls, err := c.meta.ListCollections(ctx, dbName, typeutil.MaxTimestamp, false)
+	if err != nil {
+		return nil, nil, err
+	}
+	retention := c.getRecycleRetention(ctx, dbName)
+	expireTime := func(droppedTime Timestamp) int64 {
+		return tsoutil.PhysicalTime(droppedTime).Add(retention).Unix()
+	}
+
+	collections := make([]*proxypb.TrashedCollection, 0)
+	partitions := make([]*proxypb.TrashedPartition, 0)
+	for _, coll := range colls {
+		if coll.Trashed() {
+			collections = append(collections, &proxypb.TrashedCollection{
+				CollectionID:   coll.CollectionID,
+				CollectionName: coll.Name,
+				DroppedTime:    tsoutil.PhysicalTime(coll.DroppedTime).Unix(),
+				ExpireTime:     expireTime(coll.DroppedTime),
+			})
+			continue
+		}
+		if !coll.Available() {
+			continue
+		}
+		for _, partition := range coll.Partitions {
+			if !partition.Trashed() {
+				continue
+			}
+			partitions = append(partitions, &proxypb.TrashedPartition{
+				CollectionID:   coll.CollectionID,
+				CollectionName: coll.Name,
+				PartitionID:    partition.PartitionID,
+				PartitionName:  partition.PartitionName,
+				DroppedTime:    tsoutil.PhysicalTime(partition.DroppedTime).Unix(),
+				ExpireTime:     expireTime(partition.DroppedTime),
+			})
+		}
+	}
+	return collections, partitions, nil
+}
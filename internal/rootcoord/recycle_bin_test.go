@@ -0,0 +1,162 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rootcoord
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
+	"github.com/milvus-io/milvus/internal/metastore/model"
+	pb "github.com/milvus-io/milvus/internal/proto/etcdpb"
+	mockrootcoord "github.com/milvus-io/milvus/internal/rootcoord/mocks"
+	"github.com/milvus-io/milvus/pkg/common"
+	"github.com/milvus-io/milvus/pkg/util"
+	"github.com/milvus-io/milvus/pkg/util/merr"
+	"github.com/milvus-io/milvus/pkg/util/paramtable"
+	"github.com/milvus-io/milvus/pkg/util/tsoutil"
+)
+
+func TestCore_getRecycleRetention(t *testing.T) {
+	paramtable.Get().Save(Params.RootCoordCfg.RecycleBinRetention.Key, "60")
+	defer paramtable.Get().Reset(Params.RootCoordCfg.RecycleBinRetention.Key)
+
+	meta := mockrootcoord.NewIMetaTable(t)
+	meta.EXPECT().GetDatabaseByName(mock.Anything, util.DefaultDBName, mock.Anything).Return(model.NewDefaultDatabase(nil), nil)
+	meta.EXPECT().GetDatabaseByName(mock.Anything, "db", mock.Anything).
+		Return(model.NewDatabase(1, "db", pb.DatabaseState_DatabaseCreated, []*commonpb.KeyValuePair{{Key: common.DatabaseRecycleRetentionKey, Value: "0"}}), nil)
+	meta.EXPECT().GetDatabaseByName(mock.Anything, "not_exist", mock.Anything).Return(nil, merr.WrapErrDatabaseNotFound("not_exist"))
+	core := newTestCore(withMeta(meta))
+
+	assert.Equal(t, time.Minute, core.getRecycleRetention(context.Background(), ""))
+	assert.Equal(t, time.Duration(0), core.getRecycleRetention(context.Background(), "db"))
+	assert.Equal(t, time.Minute, core.getRecycleRetention(context.Background(), "not_exist"))
+}
+
+func Test_recycleBinExpired(t *testing.T) {
+	now := time.Now()
+	droppedTime := tsoutil.ComposeTSByTime(now.Add(-time.Hour), 0)
+	assert.True(t, recycleBinExpired(droppedTime, time.Minute, now))
+	assert.False(t, recycleBinExpired(droppedTime, 2*time.Hour, now))
+}
+
+func TestCore_purgeRecycleBin(t *testing.T) {
+	expired := tsoutil.ComposeTSByTime(time.Now().Add(-2*time.Hour), 0)
+	notExpired := tsoutil.ComposeTSByTime(time.Now(), 0)
+
+	meta := mockrootcoord.NewIMetaTable(t)
+	meta.EXPECT().ListDatabases(mock.Anything, mock.Anything).Return([]*model.Database{model.NewDefaultDatabase(nil)}, nil)
+	meta.EXPECT().GetDatabaseByName(mock.Anything, util.DefaultDBName, mock.Anything).
+		Return(model.NewDefaultDatabase([]*commonpb.KeyValuePair{{Key: common.DatabaseRecycleRetentionKey, Value: "3600"}}), nil)
+	meta.EXPECT().ListCollections(mock.Anything, util.DefaultDBName, mock.Anything, false).Return([]*model.Collection{
+		{Name: "expired", CollectionID: 100, State: pb.CollectionState_CollectionTrashed, DroppedTime: expired},
+		{Name: "not_expired", CollectionID: 101, State: pb.CollectionState_CollectionTrashed, DroppedTime: notExpired},
+		{
+			Name: "coll", CollectionID: 102, State: pb.CollectionState_CollectionCreated,
+			Partitions: []*model.Partition{
+				{PartitionID: 500, State: pb.PartitionState_PartitionTrashed, DroppedTime: expired},
+				{PartitionID: 501, State: pb.PartitionState_PartitionTrashed, DroppedTime: notExpired},
+				{PartitionID: 502, State: pb.PartitionState_PartitionCreated},
+			},
+		},
+	}, nil)
+
+	purged := make([]task, 0)
+	sched := newMockScheduler()
+	sched.AddTaskFunc = func(t task) error {
+		purged = append(purged, t)
+		t.NotifyDone(nil)
+		return nil
+	}
+	core := newTestCore(withMeta(meta), withScheduler(sched))
+	core.purgeRecycleBin(context.Background())
+
+	assert.Len(t, purged, 2)
+	collTask, ok := purged[0].(*purgeCollectionTask)
+	assert.True(t, ok)
+	assert.Equal(t, int64(100), collTask.collectionID)
+	assert.Equal(t, "expired", collTask.collectionName)
+	partTask, ok := purged[1].(*purgePartitionTask)
+	assert.True(t, ok)
+	assert.Equal(t, int64(102), partTask.collectionID)
+	assert.Equal(t, int64(500), partTask.partitionID)
+}
+
+func Test_purgeCollectionTask_Execute(t *testing.T) {
+	t.Run("collection not found", func(t *testing.T) {
+		meta := mockrootcoord.NewIMetaTable(t)
+		meta.EXPECT().GetCollectionByID(mock.Anything, mock.Anything, int64(100), mock.Anything, true).
+			Return(nil, merr.WrapErrCollectionNotFound(100))
+		core := newTestCore(withMeta(meta))
+		task := &purgeCollectionTask{baseTask: newBaseTask(context.Background(), core), collectionID: 100}
+		assert.NoError(t, task.Execute(context.Background()))
+	})
+
+	t.Run("collection restored", func(t *testing.T) {
+		meta := mockrootcoord.NewIMetaTable(t)
+		meta.EXPECT().GetCollectionByID(mock.Anything, mock.Anything, int64(100), mock.Anything, true).
+			Return(&model.Collection{CollectionID: 100, State: pb.CollectionState_CollectionCreated}, nil)
+		core := newTestCore(withMeta(meta))
+		task := &purgeCollectionTask{baseTask: newBaseTask(context.Background(), core), collectionID: 100}
+		assert.NoError(t, task.Execute(context.Background()))
+	})
+
+	t.Run("failed to change collection state", func(t *testing.T) {
+		meta := mockrootcoord.NewIMetaTable(t)
+		meta.EXPECT().GetCollectionByID(mock.Anything, mock.Anything, int64(100), mock.Anything, true).
+			Return(&model.Collection{CollectionID: 100, State: pb.CollectionState_CollectionTrashed}, nil)
+		meta.EXPECT().ChangeCollectionState(mock.Anything, int64(100), pb.CollectionState_CollectionDropping, mock.Anything).
+			Return(errors.New("mock"))
+		core := newTestCore(withMeta(meta))
+		task := &purgeCollectionTask{baseTask: newBaseTask(context.Background(), core), collectionID: 100}
+		assert.Error(t, task.Execute(context.Background()))
+	})
+}
+
+func Test_purgePartitionTask_Execute(t *testing.T) {
+	coll := &model.Collection{
+		CollectionID: 100,
+		State:        pb.CollectionState_CollectionCreated,
+		Partitions: []*model.Partition{
+			{PartitionID: 500, State: pb.PartitionState_PartitionTrashed},
+			{PartitionID: 501, State: pb.PartitionState_PartitionCreated},
+		},
+	}
+
+	t.Run("partition restored", func(t *testing.T) {
+		meta := mockrootcoord.NewIMetaTable(t)
+		meta.EXPECT().GetCollectionByID(mock.Anything, mock.Anything, int64(100), mock.Anything, true).Return(coll.Clone(), nil)
+		core := newTestCore(withMeta(meta))
+		task := &purgePartitionTask{baseTask: newBaseTask(context.Background(), core), collectionID: 100, partitionID: 501}
+		assert.NoError(t, task.Execute(context.Background()))
+	})
+
+	t.Run("failed to change partition state", func(t *testing.T) {
+		meta := mockrootcoord.NewIMetaTable(t)
+		meta.EXPECT().GetCollectionByID(mock.Anything, mock.Anything, int64(100), mock.Anything, true).Return(coll.Clone(), nil)
+		meta.EXPECT().ChangePartitionState(mock.Anything, int64(100), int64(500), pb.PartitionState_PartitionDropping, mock.Anything).
+			Return(errors.New("mock"))
+		core := newTestCore(withMeta(meta))
+		task := &purgePartitionTask{baseTask: newBaseTask(context.Background(), core), collectionID: 100, partitionID: 500}
+		assert.Error(t, task.Execute(context.Background()))
+	})
+}
@@ -39,6 +39,7 @@ import (
 	"github.com/milvus-io/milvus-proto/go-api/v2/schemapb"
 	"github.com/milvus-io/milvus/internal/allocator"
 	etcdkv "github.com/milvus-io/milvus/internal/kv/etcd"
+	sqlitekv "github.com/milvus-io/milvus/internal/kv/sqlite"
 	"github.com/milvus-io/milvus/internal/kv/tikv"
 	"github.com/milvus-io/milvus/internal/metastore"
 	kvmetestore "github.com/milvus-io/milvus/internal/metastore/kv/rootcoord"
@@ -328,6 +329,10 @@ func (c *Core) initKVCreator() {
 				return tikv.NewTiKV(c.tikvCli, Params.TiKVCfg.MetaRootPath.GetValue(),
 					tikv.WithRequestTimeout(paramtable.Get().ServiceParam.TiKVCfg.RequestTimeout.GetAsDuration(time.Millisecond))), nil
 			}
+		} else if Params.MetaStoreCfg.MetaStoreType.GetValue() == util.MetaStoreTypeSQLite {
+			c.metaKVCreator = func() (kv.MetaKv, error) {
+				return sqlitekv.NewMetaKvFactory(Params.SQLiteCfg.MetaRootPath.GetValue(), &Params.SQLiteCfg)
+			}
 		} else {
 			c.metaKVCreator = func() (kv.MetaKv, error) {
 				return etcdkv.NewEtcdKV(c.etcdCli, Params.EtcdCfg.MetaRootPath.GetValue(),
@@ -371,6 +376,20 @@ func (c *Core) initMetaTable() error {
 				return err
 			}
 			catalog = &kvmetestore.Catalog{Txn: metaKV, Snapshot: ss}
+		case util.MetaStoreTypeSQLite:
+			log.Info("Using sqlite as meta storage.")
+			var metaKV kv.MetaKv
+			var ss *kvmetestore.SuffixSnapshot
+			var err error
+
+			if metaKV, err = c.metaKVCreator(); err != nil {
+				return err
+			}
+
+			if ss, err = kvmetestore.NewSuffixSnapshot(metaKV, kvmetestore.SnapshotsSep, Params.SQLiteCfg.MetaRootPath.GetValue(), kvmetestore.SnapshotPrefix); err != nil {
+				return err
+			}
+			catalog = &kvmetestore.Catalog{Txn: metaKV, Snapshot: ss}
 		default:
 			return retry.Unrecoverable(fmt.Errorf("not supported meta store: %s", Params.MetaStoreCfg.MetaStoreType.GetValue()))
 		}
@@ -391,6 +410,13 @@ func (c *Core) initIDAllocator() error {
 	if Params.MetaStoreCfg.MetaStoreType.GetValue() == util.MetaStoreTypeTiKV {
 		kvPath = Params.TiKVCfg.KvRootPath.GetValue()
 		tsoKV = tsoutil2.NewTSOTiKVBase(c.tikvCli, kvPath, globalIDAllocatorSubPath)
+	} else if Params.MetaStoreCfg.MetaStoreType.GetValue() == util.MetaStoreTypeSQLite {
+		db, err := sqlitekv.GetSharedDB(Params.SQLiteCfg.Path.GetValue())
+		if err != nil {
+			return err
+		}
+		kvPath = Params.SQLiteCfg.KvRootPath.GetValue()
+		tsoKV = tsoutil2.NewTSOSQLiteBase(db, kvPath, globalIDAllocatorSubPath)
 	} else {
 		kvPath = Params.EtcdCfg.KvRootPath.GetValue()
 		tsoKV = tsoutil2.NewTSOKVBase(c.etcdCli, kvPath, globalIDAllocatorSubPath)
@@ -415,6 +441,13 @@ func (c *Core) initTSOAllocator() error {
 	if Params.MetaStoreCfg.MetaStoreType.GetValue() == util.MetaStoreTypeTiKV {
 		kvPath = Params.TiKVCfg.KvRootPath.GetValue()
 		tsoKV = tsoutil2.NewTSOTiKVBase(c.tikvCli, Params.TiKVCfg.KvRootPath.GetValue(), globalIDAllocatorSubPath)
+	} else if Params.MetaStoreCfg.MetaStoreType.GetValue() == util.MetaStoreTypeSQLite {
+		db, err := sqlitekv.GetSharedDB(Params.SQLiteCfg.Path.GetValue())
+		if err != nil {
+			return err
+		}
+		kvPath = Params.SQLiteCfg.KvRootPath.GetValue()
+		tsoKV = tsoutil2.NewTSOSQLiteBase(db, kvPath, globalIDAllocatorSubPath)
 	} else {
 		kvPath = Params.EtcdCfg.KvRootPath.GetValue()
 		tsoKV = tsoutil2.NewTSOKVBase(c.etcdCli, Params.EtcdCfg.KvRootPath.GetValue(), globalIDAllocatorSubPath)
@@ -793,8 +826,9 @@ func (c *Core) startInternal() error {
 }
 
 func (c *Core) startServerLoop() {
-	c.wg.Add(1)
+	c.wg.Add(2)
 	go c.tsLoop()
+	go c.recycleBinLoop()
 	if !streamingutil.IsStreamingServiceEnabled() {
 		c.wg.Add(2)
 		go c.startTimeTickLoop()
@@ -1711,6 +1745,121 @@ func (c *Core) ShowPartitionsInternal(ctx context.Context, in *milvuspb.ShowPart
 	return c.showPartitionsImpl(ctx, in, true)
 }
 
+// ListRecycleBin lists the dropped collections and partitions which can be restored.
+func (c *Core) ListRecycleBin(ctx context.Context, in *proxypb.ListRecycleBinRequest) (*proxypb.ListRecycleBinResponse, error) {
+	if err := merr.CheckHealthy(c.GetStateCode()); err != nil {
+		return &proxypb.ListRecycleBinResponse{
+			Status: merr.Status(err),
+		}, nil
+	}
+
+	metrics.RootCoordDDLReqCounter.WithLabelValues("ListRecycleBin", metrics.TotalLabel).Inc()
+	tr := timerecord.NewTimeRecorder("ListRecycleBin")
+
+	log := log.Ctx(ctx).With(zap.String("role", typeutil.RootCoordRole), zap.String("dbName", in.GetDbName()))
+	log.Debug("received request to list recycle bin")
+
+	collections, partitions, err := c.listRecycleBin(ctx, in.GetDbName())
+	if err != nil {
+		log.Info("failed to list recycle bin", zap.Error(err))
+		metrics.RootCoordDDLReqCounter.WithLabelValues("ListRecycleBin", metrics.FailLabel).Inc()
+		return &proxypb.ListRecycleBinResponse{
+			Status: merr.Status(err),
+		}, nil
+	}
+
+	metrics.RootCoordDDLReqCounter.WithLabelValues("ListRecycleBin", metrics.SuccessLabel).Inc()
+	metrics.RootCoordDDLReqLatency.WithLabelValues("ListRecycleBin").Observe(float64(tr.ElapseSpan().Milliseconds()))
+
+	log.Debug("done to list recycle bin", zap.Int("collections", len(collections)), zap.Int("partitions", len(partitions)))
+	return &proxypb.ListRecycleBinResponse{
+		Status:      merr.Success(),
+		Collections: collections,
+		Partitions:  partitions,
+	}, nil
+}
+
+// UndropCollection restores a dropped collection from the recycle bin.
+func (c *Core) UndropCollection(ctx context.Context, in *proxypb.UndropCollectionRequest) (*commonpb.Status, error) {
+	if err := merr.CheckHealthy(c.GetStateCode()); err != nil {
+		return merr.Status(err), nil
+	}
+
+	metrics.RootCoordDDLReqCounter.WithLabelValues("UndropCollection", metrics.TotalLabel).Inc()
+	tr := timerecord.NewTimeRecorder("UndropCollection")
+
+	log := log.Ctx(ctx).With(zap.String("role", typeutil.RootCoordRole),
+		zap.String("dbName", in.GetDbName()),
+		zap.String("name", in.GetCollectionName()),
+		zap.Int64("collectionID", in.GetCollectionID()))
+	log.Info("received request to undrop collection")
+
+	t := &undropCollectionTask{
+		baseTask: newBaseTask(ctx, c),
+		Req:      in,
+	}
+
+	if err := c.scheduler.AddTask(t); err != nil {
+		log.Info("failed to enqueue request to undrop collection", zap.Error(err))
+		metrics.RootCoordDDLReqCounter.WithLabelValues("UndropCollection", metrics.FailLabel).Inc()
+		return merr.Status(err), nil
+	}
+
+	if err := t.WaitToFinish(); err != nil {
+		log.Info("failed to undrop collection", zap.Error(err), zap.Uint64("ts", t.GetTs()))
+		metrics.RootCoordDDLReqCounter.WithLabelValues("UndropCollection", metrics.FailLabel).Inc()
+		return merr.Status(err), nil
+	}
+
+	metrics.RootCoordDDLReqCounter.WithLabelValues("UndropCollection", metrics.SuccessLabel).Inc()
+	metrics.RootCoordDDLReqLatency.WithLabelValues("UndropCollection").Observe(float64(tr.ElapseSpan().Milliseconds()))
+	metrics.RootCoordDDLReqLatencyInQueue.WithLabelValues("UndropCollection").Observe(float64(t.queueDur.Milliseconds()))
+
+	log.Info("done to undrop collection", zap.Uint64("ts", t.GetTs()))
+	return merr.Success(), nil
+}
+
+// UndropPartition restores a dropped partition from the recycle bin.
+func (c *Core) UndropPartition(ctx context.Context, in *proxypb.UndropPartitionRequest) (*commonpb.Status, error) {
+	if err := merr.CheckHealthy(c.GetStateCode()); err != nil {
+		return merr.Status(err), nil
+	}
+
+	metrics.RootCoordDDLReqCounter.WithLabelValues("UndropPartition", metrics.TotalLabel).Inc()
+	tr := timerecord.NewTimeRecorder("UndropPartition")
+
+	log := log.Ctx(ctx).With(zap.String("role", typeutil.RootCoordRole),
+		zap.String("dbName", in.GetDbName()),
+		zap.String("collection", in.GetCollectionName()),
+		zap.String("partition", in.GetPartitionName()),
+		zap.Int64("partitionID", in.GetPartitionID()))
+	log.Info("received request to undrop partition")
+
+	t := &undropPartitionTask{
+		baseTask: newBaseTask(ctx, c),
+		Req:      in,
+	}
+
+	if err := c.scheduler.AddTask(t); err != nil {
+		log.Info("failed to enqueue request to undrop partition", zap.Error(err))
+		metrics.RootCoordDDLReqCounter.WithLabelValues("UndropPartition", metrics.FailLabel).Inc()
+		return merr.Status(err), nil
+	}
+
+	if err := t.WaitToFinish(); err != nil {
+		log.Info("failed to undrop partition", zap.Error(err), zap.Uint64("ts", t.GetTs()))
+		metrics.RootCoordDDLReqCounter.WithLabelValues("UndropPartition", metrics.FailLabel).Inc()
+		return merr.Status(err), nil
+	}
+
+	metrics.RootCoordDDLReqCounter.WithLabelValues("UndropPartition", metrics.SuccessLabel).Inc()
+	metrics.RootCoordDDLReqLatency.WithLabelValues("UndropPartition").Observe(float64(tr.ElapseSpan().Milliseconds()))
+	metrics.RootCoordDDLReqLatencyInQueue.WithLabelValues("UndropPartition").Observe(float64(t.queueDur.Milliseconds()))
+
+	log.Info("done to undrop partition", zap.Uint64("ts", t.GetTs()))
+	return merr.Success(), nil
+}
+
 // ShowSegments list all segments
 func (c *Core) ShowSegments(ctx context.Context, in *milvuspb.ShowSegmentsRequest) (*milvuspb.ShowSegmentsResponse, error) {
 	// ShowSegments Only used in GetPersistentSegmentInfo, it's already deprecated for a long time.
@@ -2399,6 +2548,13 @@ func (c *Core) DropRole(ctx context.Context, in *milvuspb.DropRoleRequest) (*com
 		}
 		return nil, err
 	}))
+	redoTask.AddAsyncStep(NewSimpleStep("drop the row policies of this role", func(ctx context.Context) ([]nestedStep, error) {
+		err := c.meta.DropRowPolicies(ctx, util.DefaultTenant, in.RoleName)
+		if err != nil {
+			ctxLog.Warn("drop the row policies failed for the role", zap.Error(err))
+		}
+		return nil, err
+	}))
 	redoTask.AddAsyncStep(NewSimpleStep("drop role cache", func(ctx context.Context) ([]nestedStep, error) {
 		err := c.proxyClientManager.RefreshPolicyInfoCache(ctx, &proxypb.RefreshPolicyInfoCacheRequest{
 			OpType: int32(typeutil.CacheDropRole),
@@ -2919,6 +3075,14 @@ func (c *Core) ListPolicy(ctx context.Context, in *internalpb.ListPolicyRequest)
 			Status: merr.StatusWithErrorCode(errors.New(errMsg), commonpb.ErrorCode_ListPolicyFailure),
 		}, nil
 	}
+	rowPolicies, err := c.meta.ListRowPolicies(ctx, util.DefaultTenant)
+	if err != nil {
+		errMsg := "fail to list row policies"
+		ctxLog.Warn(errMsg, zap.Error(err))
+		return &internalpb.ListPolicyResponse{
+			Status: merr.StatusWithErrorCode(errors.New(errMsg), commonpb.ErrorCode_ListPolicyFailure),
+		}, nil
+	}
 
 	ctxLog.Debug(method + " success")
 	metrics.RootCoordDDLReqCounter.WithLabelValues(method, metrics.SuccessLabel).Inc()
@@ -2928,6 +3092,7 @@ func (c *Core) ListPolicy(ctx context.Context, in *internalpb.ListPolicyRequest)
 		PolicyInfos:     policies,
 		UserRoles:       userRoles,
 		PrivilegeGroups: privGroups,
+		RowPolicies:     rowPolicies,
 	}, nil
 }
 
@@ -3361,6 +3526,63 @@ func (c *Core) OperatePrivilegeGroup(ctx context.Context, in *milvuspb.OperatePr
 	return merr.Success(), nil
 }
 
+// OperateRowPolicy sets or drops the row policy of a role on a collection.
+// The expr is validated against the collection schema by proxy.
+func (c *Core) OperateRowPolicy(ctx context.Context, in *rootcoordpb.OperateRowPolicyRequest) (*commonpb.Status, error) {
+	method := "OperateRowPolicy-" + in.GetType().String()
+	metrics.RootCoordDDLReqCounter.WithLabelValues(method, metrics.TotalLabel).Inc()
+	tr := timerecord.NewTimeRecorder(method)
+	ctxLog := log.Ctx(ctx).With(zap.String("role", typeutil.RootCoordRole), zap.Any("in", in))
+	ctxLog.Debug(method)
+
+	if err := merr.CheckHealthy(c.GetStateCode()); err != nil {
+		return merr.Status(err), nil
+	}
+	policy := in.GetPolicy()
+	if policy == nil {
+		return merr.Status(merr.WrapErrParameterMissing("policy")), nil
+	}
+	if policy.GetDbName() == "" {
+		policy.DbName = util.DefaultDBName
+	}
+
+	redoTask := newBaseRedoTask(c.stepExecutor)
+	redoTask.AddSyncStep(NewSimpleStep("operate row policy meta data", func(ctx context.Context) ([]nestedStep, error) {
+		err := c.meta.OperateRowPolicy(ctx, util.DefaultTenant, policy, in.GetType())
+		if err != nil && !common.IsIgnorableError(err) {
+			ctxLog.Warn("fail to operate the row policy", zap.Error(err))
+			return nil, err
+		}
+		return nil, nil
+	}))
+	redoTask.AddAsyncStep(NewSimpleStep("operate row policy cache", func(ctx context.Context) ([]nestedStep, error) {
+		opType := int32(typeutil.CacheSetRowPolicy)
+		if in.GetType() == rootcoordpb.OperateRowPolicyType_DropRowPolicy {
+			opType = int32(typeutil.CacheDropRowPolicy)
+		}
+		if err := c.proxyClientManager.RefreshPolicyInfoCache(ctx, &proxypb.RefreshPolicyInfoCacheRequest{
+			OpType: opType,
+			OpKey:  funcutil.EncodeRowPolicyCache(policy.GetRole(), policy.GetDbName(), policy.GetCollectionName(), policy.GetExpr()),
+		}); err != nil {
+			ctxLog.Warn("fail to refresh policy info cache", zap.Error(err))
+			return nil, err
+		}
+		return nil, nil
+	}))
+
+	err := redoTask.Execute(ctx)
+	if err != nil {
+		errMsg := "fail to execute task when operating the row policy"
+		ctxLog.Warn(errMsg, zap.Error(err))
+		return merr.StatusWithErrorCode(err, commonpb.ErrorCode_OperatePrivilegeFailure), nil
+	}
+
+	ctxLog.Debug(method + " success")
+	metrics.RootCoordDDLReqCounter.WithLabelValues(method, metrics.SuccessLabel).Inc()
+	metrics.RootCoordDDLReqLatency.WithLabelValues(method).Observe(float64(tr.ElapseSpan().Milliseconds()))
+	return merr.Success(), nil
+}
+
 func (c *Core) expandPrivilegeGroups(ctx context.Context, grants []*milvuspb.GrantEntity, groups map[string][]*milvuspb.PrivilegeEntity) ([]*milvuspb.GrantEntity, error) {
 	newGrants := []*milvuspb.GrantEntity{}
 	createGrantEntity := func(grant *milvuspb.GrantEntity, privilegeName string) (*milvuspb.GrantEntity, error) {
@@ -42,6 +42,7 @@ import (
 	kvfactory "github.com/milvus-io/milvus/internal/util/dependency/kv"
 	"github.com/milvus-io/milvus/internal/util/proxyutil"
 	"github.com/milvus-io/milvus/internal/util/sessionutil"
+	"github.com/milvus-io/milvus/pkg/common"
 	"github.com/milvus-io/milvus/pkg/util"
 	"github.com/milvus-io/milvus/pkg/util/etcd"
 	"github.com/milvus-io/milvus/pkg/util/funcutil"
@@ -301,6 +302,112 @@ func TestRootCoord_DropCollection(t *testing.T) {
 	})
 }
 
+func TestRootCoord_ListRecycleBin(t *testing.T) {
+	t.Run("not healthy", func(t *testing.T) {
+		c := newTestCore(withAbnormalCode())
+		resp, err := c.ListRecycleBin(context.Background(), &proxypb.ListRecycleBinRequest{})
+		assert.NoError(t, err)
+		assert.Error(t, merr.Error(resp.GetStatus()))
+	})
+
+	t.Run("failed to list collections", func(t *testing.T) {
+		meta := mockrootcoord.NewIMetaTable(t)
+		meta.EXPECT().ListCollections(mock.Anything, "db", mock.Anything, false).Return(nil, merr.WrapErrDatabaseNotFound("db"))
+		c := newTestCore(withHealthyCode(), withMeta(meta))
+		resp, err := c.ListRecycleBin(context.Background(), &proxypb.ListRecycleBinRequest{DbName: "db"})
+		assert.NoError(t, err)
+		assert.ErrorIs(t, merr.Error(resp.GetStatus()), merr.ErrDatabaseNotFound)
+	})
+
+	t.Run("normal case", func(t *testing.T) {
+		meta := mockrootcoord.NewIMetaTable(t)
+		meta.EXPECT().ListCollections(mock.Anything, "db", mock.Anything, false).Return([]*model.Collection{
+			{Name: "trashed", CollectionID: 100, State: etcdpb.CollectionState_CollectionTrashed, DroppedTime: tsoutil.ComposeTS(1000000, 0)},
+			{
+				Name: "coll", CollectionID: 101, State: etcdpb.CollectionState_CollectionCreated,
+				Partitions: []*model.Partition{
+					{PartitionName: "p", PartitionID: 500, State: etcdpb.PartitionState_PartitionTrashed, DroppedTime: tsoutil.ComposeTS(2000000, 0)},
+					{PartitionName: "p2", PartitionID: 501, State: etcdpb.PartitionState_PartitionCreated},
+				},
+			},
+		}, nil)
+		meta.EXPECT().GetDatabaseByName(mock.Anything, "db", mock.Anything).
+			Return(model.NewDatabase(1, "db", etcdpb.DatabaseState_DatabaseCreated, []*commonpb.KeyValuePair{{Key: common.DatabaseRecycleRetentionKey, Value: "100"}}), nil)
+		c := newTestCore(withHealthyCode(), withMeta(meta))
+		resp, err := c.ListRecycleBin(context.Background(), &proxypb.ListRecycleBinRequest{DbName: "db"})
+		assert.NoError(t, err)
+		assert.NoError(t, merr.Error(resp.GetStatus()))
+		assert.Len(t, resp.GetCollections(), 1)
+		assert.Equal(t, int64(100), resp.GetCollections()[0].GetCollectionID())
+		assert.Equal(t, int64(1000), resp.GetCollections()[0].GetDroppedTime())
+		assert.Equal(t, int64(1100), resp.GetCollections()[0].GetExpireTime())
+		assert.Len(t, resp.GetPartitions(), 1)
+		assert.Equal(t, int64(500), resp.GetPartitions()[0].GetPartitionID())
+		assert.Equal(t, "coll", resp.GetPartitions()[0].GetCollectionName())
+		assert.Equal(t, int64(2100), resp.GetPartitions()[0].GetExpireTime())
+	})
+}
+
+func TestRootCoord_UndropCollection(t *testing.T) {
+	t.Run("not healthy", func(t *testing.T) {
+		c := newTestCore(withAbnormalCode())
+		resp, err := c.UndropCollection(context.Background(), &proxypb.UndropCollectionRequest{})
+		assert.NoError(t, err)
+		assert.Error(t, merr.Error(resp))
+	})
+
+	t.Run("failed to add task", func(t *testing.T) {
+		c := newTestCore(withHealthyCode(), withInvalidScheduler())
+		resp, err := c.UndropCollection(context.Background(), &proxypb.UndropCollectionRequest{})
+		assert.NoError(t, err)
+		assert.Error(t, merr.Error(resp))
+	})
+
+	t.Run("failed to execute", func(t *testing.T) {
+		c := newTestCore(withHealthyCode(), withTaskFailScheduler())
+		resp, err := c.UndropCollection(context.Background(), &proxypb.UndropCollectionRequest{})
+		assert.NoError(t, err)
+		assert.Error(t, merr.Error(resp))
+	})
+
+	t.Run("normal case", func(t *testing.T) {
+		c := newTestCore(withHealthyCode(), withValidScheduler())
+		resp, err := c.UndropCollection(context.Background(), &proxypb.UndropCollectionRequest{})
+		assert.NoError(t, err)
+		assert.NoError(t, merr.Error(resp))
+	})
+}
+
+func TestRootCoord_UndropPartition(t *testing.T) {
+	t.Run("not healthy", func(t *testing.T) {
+		c := newTestCore(withAbnormalCode())
+		resp, err := c.UndropPartition(context.Background(), &proxypb.UndropPartitionRequest{})
+		assert.NoError(t, err)
+		assert.Error(t, merr.Error(resp))
+	})
+
+	t.Run("failed to add task", func(t *testing.T) {
+		c := newTestCore(withHealthyCode(), withInvalidScheduler())
+		resp, err := c.UndropPartition(context.Background(), &proxypb.UndropPartitionRequest{})
+		assert.NoError(t, err)
+		assert.Error(t, merr.Error(resp))
+	})
+
+	t.Run("failed to execute", func(t *testing.T) {
+		c := newTestCore(withHealthyCode(), withTaskFailScheduler())
+		resp, err := c.UndropPartition(context.Background(), &proxypb.UndropPartitionRequest{})
+		assert.NoError(t, err)
+		assert.Error(t, merr.Error(resp))
+	})
+
+	t.Run("normal case", func(t *testing.T) {
+		c := newTestCore(withHealthyCode(), withValidScheduler())
+		resp, err := c.UndropPartition(context.Background(), &proxypb.UndropPartitionRequest{})
+		assert.NoError(t, err)
+		assert.NoError(t, merr.Error(resp))
+	})
+}
+
 func TestRootCoord_CreatePartition(t *testing.T) {
 	t.Run("not healthy", func(t *testing.T) {
 		c := newTestCore(withAbnormalCode())
@@ -2071,6 +2178,45 @@ func TestCore_RestoreRBAC(t *testing.T) {
 	assert.False(t, merr.Ok(resp))
 }
 
+func TestCore_OperateRowPolicy(t *testing.T) {
+	meta := mockrootcoord.NewIMetaTable(t)
+	c := newTestCore(withHealthyCode(), withMeta(meta))
+	mockProxyClientManager := proxyutil.NewMockProxyClientManager(t)
+	c.proxyClientManager = mockProxyClientManager
+
+	policy := &internalpb.RowPolicy{Role: "role1", CollectionName: "coll1", Expr: "tenant_id == 1"}
+	meta.EXPECT().OperateRowPolicy(mock.Anything, util.DefaultTenant, policy, rootcoordpb.OperateRowPolicyType_SetRowPolicy).Return(nil).Once()
+	mockProxyClientManager.EXPECT().RefreshPolicyInfoCache(mock.Anything, mock.Anything).RunAndReturn(
+		func(ctx context.Context, req *proxypb.RefreshPolicyInfoCacheRequest) error {
+			assert.Equal(t, int32(typeutil.CacheSetRowPolicy), req.GetOpType())
+			assert.Equal(t, "role1/default/coll1/tenant_id == 1", req.GetOpKey())
+			return nil
+		}).Once()
+	resp, err := c.OperateRowPolicy(context.Background(), &rootcoordpb.OperateRowPolicyRequest{
+		Type:   rootcoordpb.OperateRowPolicyType_SetRowPolicy,
+		Policy: policy,
+	})
+	assert.NoError(t, err)
+	assert.True(t, merr.Ok(resp))
+
+	meta.EXPECT().OperateRowPolicy(mock.Anything, util.DefaultTenant, mock.Anything, rootcoordpb.OperateRowPolicyType_SetRowPolicy).Return(errors.New("mock error")).Once()
+	resp, err = c.OperateRowPolicy(context.Background(), &rootcoordpb.OperateRowPolicyRequest{
+		Type:   rootcoordpb.OperateRowPolicyType_SetRowPolicy,
+		Policy: policy,
+	})
+	assert.NoError(t, err)
+	assert.False(t, merr.Ok(resp))
+
+	resp, err = c.OperateRowPolicy(context.Background(), &rootcoordpb.OperateRowPolicyRequest{})
+	assert.NoError(t, err)
+	assert.False(t, merr.Ok(resp))
+
+	c = newTestCore(withAbnormalCode())
+	resp, err = c.OperateRowPolicy(context.Background(), &rootcoordpb.OperateRowPolicyRequest{Policy: policy})
+	assert.NoError(t, err)
+	assert.False(t, merr.Ok(resp))
+}
+
 type RootCoordSuite struct {
 	suite.Suite
 }
@@ -185,6 +185,21 @@ func (s *changeCollectionStateStep) Desc() string {
 		s.collectionID, s.ts, s.state.String())
 }
 
+type restoreCollectionStep struct {
+	baseStep
+	collectionID UniqueID
+	ts           Timestamp
+}
+
+func (s *restoreCollectionStep) Execute(ctx context.Context) ([]nestedStep, error) {
+	err := s.core.meta.RestoreCollection(ctx, s.collectionID, s.ts)
+	return nil, err
+}
+
+func (s *restoreCollectionStep) Desc() string {
+	return fmt.Sprintf("restore collection from recycle bin, collection: %d, ts: %d", s.collectionID, s.ts)
+}
+
 type expireCacheStep struct {
 	baseStep
 	dbName          string
@@ -423,6 +438,23 @@ func (s *changePartitionStateStep) Desc() string {
 		s.collectionID, s.partitionID, s.state.String(), s.ts)
 }
 
+type restorePartitionStep struct {
+	baseStep
+	collectionID UniqueID
+	partitionID  UniqueID
+	ts           Timestamp
+}
+
+func (s *restorePartitionStep) Execute(ctx context.Context) ([]nestedStep, error) {
+	err := s.core.meta.RestorePartition(ctx, s.collectionID, s.partitionID, s.ts)
+	return nil, err
+}
+
+func (s *restorePartitionStep) Desc() string {
+	return fmt.Sprintf("restore partition from recycle bin, collection: %d, partition: %d, ts: %d",
+		s.collectionID, s.partitionID, s.ts)
+}
+
 type removePartitionMetaStep struct {
 	baseStep
 	dbID         UniqueID
@@ -0,0 +1,85 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rootcoord
+
+import (
+	"context"
+
+	"github.com/samber/lo"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
+	"github.com/milvus-io/milvus/internal/metastore/model"
+	"github.com/milvus-io/milvus/internal/proto/proxypb"
+	"github.com/milvus-io/milvus/internal/util/proxyutil"
+	"github.com/milvus-io/milvus/pkg/util/merr"
+	"github.com/milvus-io/milvus/pkg/util/typeutil"
+)
+
+// undropCollectionTask restores a collection from the recycle bin, the collection must be loaded again to be searched.
+type undropCollectionTask struct {
+	baseTask
+	Req *proxypb.UndropCollectionRequest
+}
+
+func (t *undropCollectionTask) Prepare(ctx context.Context) error {
+	if t.Req.GetCollectionName() == "" {
+		return merr.WrapErrParameterInvalidMsg("collection name should not be empty")
+	}
+	return nil
+}
+
+func (t *undropCollectionTask) Execute(ctx context.Context) error {
+	colls, err := t.core.meta.ListCollections(ctx, t.Req.GetDbName(), typeutil.MaxTimestamp, false)
+	if err != nil {
+		return err
+	}
+	candidates := lo.Filter(colls, func(coll *model.Collection, _ int) bool {
+		return coll.Trashed() && coll.Name == t.Req.GetCollectionName() &&
+			(t.Req.GetCollectionID() == 0 || coll.CollectionID == t.Req.GetCollectionID())
+	})
+	if len(candidates) == 0 {
+		return merr.WrapErrCollectionNotFoundWithDB(t.Req.GetDbName(), t.Req.GetCollectionName(), "collection is not in the recycle bin")
+	}
+	if len(candidates) > 1 {
+		return merr.WrapErrParameterInvalidMsg("more than one collection named %s in the recycle bin, collection id must be specified", t.Req.GetCollectionName())
+	}
+	collMeta := candidates[0]
+
+	redoTask := newBaseRedoTask(t.core.stepExecutor)
+	redoTask.AddSyncStep(&restoreCollectionStep{
+		baseStep:     baseStep{core: t.core},
+		collectionID: collMeta.CollectionID,
+		ts:           t.GetTs(),
+	})
+	redoTask.AddSyncStep(&expireCacheStep{
+		baseStep:        baseStep{core: t.core},
+		dbName:          t.Req.GetDbName(),
+		collectionNames: []string{collMeta.Name},
+		collectionID:    collMeta.CollectionID,
+		ts:              t.GetTs(),
+		opts:            []proxyutil.ExpireCacheOpt{proxyutil.SetMsgType(commonpb.MsgType_CreateCollection)},
+	})
+	return redoTask.Execute(ctx)
+}
+
+func (t *undropCollectionTask) GetLockerKey() LockerKey {
+	return NewLockerKeyChain(
+		NewClusterLockerKey(false),
+		NewDatabaseLockerKey(t.Req.GetDbName(), false),
+		NewCollectionLockerKey(t.Req.GetCollectionName(), true),
+	)
+}
@@ -0,0 +1,110 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rootcoord
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cockroachdb/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/milvus-io/milvus/internal/metastore/model"
+	pb "github.com/milvus-io/milvus/internal/proto/etcdpb"
+	"github.com/milvus-io/milvus/internal/proto/proxypb"
+	mockrootcoord "github.com/milvus-io/milvus/internal/rootcoord/mocks"
+	"github.com/milvus-io/milvus/pkg/util/merr"
+)
+
+func Test_undropCollectionTask_Prepare(t *testing.T) {
+	task := &undropCollectionTask{
+		Req: &proxypb.UndropCollectionRequest{},
+	}
+	err := task.Prepare(context.Background())
+	assert.ErrorIs(t, err, merr.ErrParameterInvalid)
+}
+
+func Test_undropCollectionTask_Execute(t *testing.T) {
+	colls := []*model.Collection{
+		{Name: "coll", CollectionID: 100, State: pb.CollectionState_CollectionTrashed},
+		{Name: "coll", CollectionID: 101, State: pb.CollectionState_CollectionTrashed},
+		{Name: "coll2", CollectionID: 102, State: pb.CollectionState_CollectionCreated},
+	}
+
+	t.Run("failed to list collections", func(t *testing.T) {
+		meta := mockrootcoord.NewIMetaTable(t)
+		meta.EXPECT().ListCollections(mock.Anything, mock.Anything, mock.Anything, false).Return(nil, errors.New("mock"))
+		core := newTestCore(withMeta(meta))
+		task := &undropCollectionTask{
+			baseTask: newBaseTask(context.Background(), core),
+			Req:      &proxypb.UndropCollectionRequest{CollectionName: "coll"},
+		}
+		err := task.Execute(context.Background())
+		assert.Error(t, err)
+	})
+
+	t.Run("not in recycle bin", func(t *testing.T) {
+		meta := mockrootcoord.NewIMetaTable(t)
+		meta.EXPECT().ListCollections(mock.Anything, mock.Anything, mock.Anything, false).Return(colls, nil)
+		core := newTestCore(withMeta(meta))
+		task := &undropCollectionTask{
+			baseTask: newBaseTask(context.Background(), core),
+			Req:      &proxypb.UndropCollectionRequest{CollectionName: "coll2"},
+		}
+		err := task.Execute(context.Background())
+		assert.ErrorIs(t, err, merr.ErrCollectionNotFound)
+	})
+
+	t.Run("ambiguous name", func(t *testing.T) {
+		meta := mockrootcoord.NewIMetaTable(t)
+		meta.EXPECT().ListCollections(mock.Anything, mock.Anything, mock.Anything, false).Return(colls, nil)
+		core := newTestCore(withMeta(meta))
+		task := &undropCollectionTask{
+			baseTask: newBaseTask(context.Background(), core),
+			Req:      &proxypb.UndropCollectionRequest{CollectionName: "coll"},
+		}
+		err := task.Execute(context.Background())
+		assert.ErrorIs(t, err, merr.ErrParameterInvalid)
+	})
+
+	t.Run("failed to restore", func(t *testing.T) {
+		meta := mockrootcoord.NewIMetaTable(t)
+		meta.EXPECT().ListCollections(mock.Anything, mock.Anything, mock.Anything, false).Return(colls, nil)
+		meta.EXPECT().RestoreCollection(mock.Anything, int64(101), mock.Anything).Return(errors.New("mock"))
+		core := newTestCore(withMeta(meta))
+		task := &undropCollectionTask{
+			baseTask: newBaseTask(context.Background(), core),
+			Req:      &proxypb.UndropCollectionRequest{CollectionName: "coll", CollectionID: 101},
+		}
+		err := task.Execute(context.Background())
+		assert.Error(t, err)
+	})
+
+	t.Run("normal case", func(t *testing.T) {
+		meta := mockrootcoord.NewIMetaTable(t)
+		meta.EXPECT().ListCollections(mock.Anything, mock.Anything, mock.Anything, false).Return(colls, nil)
+		meta.EXPECT().RestoreCollection(mock.Anything, int64(101), mock.Anything).Return(nil)
+		core := newTestCore(withMeta(meta), withValidProxyManager())
+		task := &undropCollectionTask{
+			baseTask: newBaseTask(context.Background(), core),
+			Req:      &proxypb.UndropCollectionRequest{CollectionName: "coll", CollectionID: 101},
+		}
+		err := task.Execute(context.Background())
+		assert.NoError(t, err)
+	})
+}
@@ -0,0 +1,93 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rootcoord
+
+import (
+	"context"
+
+	"github.com/samber/lo"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
+	"github.com/milvus-io/milvus/internal/metastore/model"
+	"github.com/milvus-io/milvus/internal/proto/proxypb"
+	"github.com/milvus-io/milvus/internal/util/proxyutil"
+	"github.com/milvus-io/milvus/pkg/util/merr"
+	"github.com/milvus-io/milvus/pkg/util/typeutil"
+)
+
+// undropPartitionTask restores a partition from the recycle bin, the partition must be loaded again to be searched.
+type undropPartitionTask struct {
+	baseTask
+	Req *proxypb.UndropPartitionRequest
+}
+
+func (t *undropPartitionTask) Prepare(ctx context.Context) error {
+	if t.Req.GetCollectionName() == "" || t.Req.GetPartitionName() == "" {
+		return merr.WrapErrParameterInvalidMsg("collection name and partition name should not be empty")
+	}
+	return nil
+}
+
+func (t *undropPartitionTask) Execute(ctx context.Context) error {
+	coll, err := t.core.meta.GetCollectionByName(ctx, t.Req.GetDbName(), t.Req.GetCollectionName(), typeutil.MaxTimestamp)
+	if err != nil {
+		return err
+	}
+	// the trashed partitions are filtered out by GetCollectionByName.
+	collMeta, err := t.core.meta.GetCollectionByID(ctx, t.Req.GetDbName(), coll.CollectionID, typeutil.MaxTimestamp, true)
+	if err != nil {
+		return err
+	}
+	candidates := lo.Filter(collMeta.Partitions, func(partition *model.Partition, _ int) bool {
+		return partition.Trashed() && partition.PartitionName == t.Req.GetPartitionName() &&
+			(t.Req.GetPartitionID() == 0 || partition.PartitionID == t.Req.GetPartitionID())
+	})
+	if len(candidates) == 0 {
+		return merr.WrapErrPartitionNotFound(t.Req.GetPartitionName(), "partition is not in the recycle bin")
+	}
+	if len(candidates) > 1 {
+		return merr.WrapErrParameterInvalidMsg("more than one partition named %s in the recycle bin, partition id must be specified", t.Req.GetPartitionName())
+	}
+	partition := candidates[0]
+
+	redoTask := newBaseRedoTask(t.core.stepExecutor)
+	redoTask.AddSyncStep(&restorePartitionStep{
+		baseStep:     baseStep{core: t.core},
+		collectionID: collMeta.CollectionID,
+		partitionID:  partition.PartitionID,
+		ts:           t.GetTs(),
+	})
+	redoTask.AddSyncStep(&expireCacheStep{
+		baseStep:        baseStep{core: t.core},
+		dbName:          t.Req.GetDbName(),
+		collectionNames: []string{collMeta.Name},
+		collectionID:    collMeta.CollectionID,
+		partitionName:   partition.PartitionName,
+		ts:              t.GetTs(),
+		opts:            []proxyutil.ExpireCacheOpt{proxyutil.SetMsgType(commonpb.MsgType_CreatePartition)},
+	})
+	return redoTask.Execute(ctx)
+}
+
+func (t *undropPartitionTask) GetLockerKey() LockerKey {
+	collection := t.core.getCollectionIDStr(t.ctx, t.Req.GetDbName(), t.Req.GetCollectionName(), 0)
+	return NewLockerKeyChain(
+		NewClusterLockerKey(false),
+		NewDatabaseLockerKey(t.Req.GetDbName(), false),
+		NewCollectionLockerKey(collection, true),
+	)
+}
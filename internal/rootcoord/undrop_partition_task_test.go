@@ -0,0 +1,103 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rootcoord
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/milvus-io/milvus/internal/metastore/model"
+	pb "github.com/milvus-io/milvus/internal/proto/etcdpb"
+	"github.com/milvus-io/milvus/internal/proto/proxypb"
+	mockrootcoord "github.com/milvus-io/milvus/internal/rootcoord/mocks"
+	"github.com/milvus-io/milvus/pkg/util/merr"
+)
+
+func Test_undropPartitionTask_Prepare(t *testing.T) {
+	task := &undropPartitionTask{
+		Req: &proxypb.UndropPartitionRequest{CollectionName: "coll"},
+	}
+	err := task.Prepare(context.Background())
+	assert.ErrorIs(t, err, merr.ErrParameterInvalid)
+}
+
+func Test_undropPartitionTask_Execute(t *testing.T) {
+	coll := &model.Collection{
+		Name:         "coll",
+		CollectionID: 100,
+		State:        pb.CollectionState_CollectionCreated,
+		Partitions: []*model.Partition{
+			{PartitionName: "p", PartitionID: 500, State: pb.PartitionState_PartitionTrashed},
+			{PartitionName: "p", PartitionID: 501, State: pb.PartitionState_PartitionTrashed},
+			{PartitionName: "p2", PartitionID: 502, State: pb.PartitionState_PartitionCreated},
+		},
+	}
+	newMeta := func(t *testing.T) *mockrootcoord.IMetaTable {
+		meta := mockrootcoord.NewIMetaTable(t)
+		meta.EXPECT().GetCollectionByName(mock.Anything, mock.Anything, "coll", mock.Anything).Return(coll.Clone(), nil)
+		meta.EXPECT().GetCollectionByID(mock.Anything, mock.Anything, int64(100), mock.Anything, true).Return(coll.Clone(), nil)
+		return meta
+	}
+
+	t.Run("collection not found", func(t *testing.T) {
+		meta := mockrootcoord.NewIMetaTable(t)
+		meta.EXPECT().GetCollectionByName(mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+			Return(nil, merr.WrapErrCollectionNotFound("coll"))
+		core := newTestCore(withMeta(meta))
+		task := &undropPartitionTask{
+			baseTask: newBaseTask(context.Background(), core),
+			Req:      &proxypb.UndropPartitionRequest{CollectionName: "coll", PartitionName: "p"},
+		}
+		err := task.Execute(context.Background())
+		assert.ErrorIs(t, err, merr.ErrCollectionNotFound)
+	})
+
+	t.Run("not in recycle bin", func(t *testing.T) {
+		core := newTestCore(withMeta(newMeta(t)))
+		task := &undropPartitionTask{
+			baseTask: newBaseTask(context.Background(), core),
+			Req:      &proxypb.UndropPartitionRequest{CollectionName: "coll", PartitionName: "p2"},
+		}
+		err := task.Execute(context.Background())
+		assert.ErrorIs(t, err, merr.ErrPartitionNotFound)
+	})
+
+	t.Run("ambiguous name", func(t *testing.T) {
+		core := newTestCore(withMeta(newMeta(t)))
+		task := &undropPartitionTask{
+			baseTask: newBaseTask(context.Background(), core),
+			Req:      &proxypb.UndropPartitionRequest{CollectionName: "coll", PartitionName: "p"},
+		}
+		err := task.Execute(context.Background())
+		assert.ErrorIs(t, err, merr.ErrParameterInvalid)
+	})
+
+	t.Run("normal case", func(t *testing.T) {
+		meta := newMeta(t)
+		meta.EXPECT().RestorePartition(mock.Anything, int64(100), int64(500), mock.Anything).Return(nil)
+		core := newTestCore(withMeta(meta), withValidProxyManager())
+		task := &undropPartitionTask{
+			baseTask: newBaseTask(context.Background(), core),
+			Req:      &proxypb.UndropPartitionRequest{CollectionName: "coll", PartitionName: "p", PartitionID: 500},
+		}
+		err := task.Execute(context.Background())
+		assert.NoError(t, err)
+	})
+}
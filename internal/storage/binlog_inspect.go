@@ -0,0 +1,219 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+
+	"github.com/milvus-io/milvus/pkg/common"
+	"github.com/milvus-io/milvus/pkg/util/typeutil"
+)
+
+// BinlogInspection is the result of inspecting a binlog file.
+type BinlogInspection struct {
+	Size int
+	DescriptorEventDataFixPart
+	DescriptorTimestamp typeutil.Timestamp
+	Extras              map[string]interface{}
+	Events              []*EventInspection
+	// Rows is the total number of rows of the payloads that could be decoded.
+	Rows int
+	// Issues are the integrity problems found, the binlog is valid if empty.
+	Issues []string
+}
+
+// EventInspection is the summary of an event in the binlog.
+type EventInspection struct {
+	Offset         int32
+	TypeCode       EventTypeCode
+	Timestamp      typeutil.Timestamp
+	EventLength    int32
+	StartTimestamp typeutil.Timestamp
+	EndTimestamp   typeutil.Timestamp
+	Rows           int
+}
+
+func (bi *BinlogInspection) Valid() bool {
+	return len(bi.Issues) == 0
+}
+
+func (bi *BinlogInspection) addIssue(format string, args ...any) {
+	bi.Issues = append(bi.Issues, fmt.Sprintf(format, args...))
+}
+
+// InspectBinlog walks through all the events of the binlog file and verifies its integrity.
+// The binlog format carries no checksum, so the integrity is verified by the magic number,
+// the lengths and positions recorded in the event headers, the event timestamps,
+// and that the payload of each event could be decoded.
+// Unlike BinlogReader, it never panics on corrupted data and reports as many issues as possible.
+func InspectBinlog(data []byte) (bi *BinlogInspection) {
+	bi = &BinlogInspection{Size: len(data)}
+	defer func() {
+		if r := recover(); r != nil {
+			bi.addIssue("corrupted binlog: %v", r)
+		}
+	}()
+
+	if _, err := readMagicNumber(bytes.NewReader(data)); err != nil {
+		bi.addIssue("invalid magic number: %v", err)
+		return bi
+	}
+	offset := int32(binary.Size(MagicNumber))
+
+	descriptor, ok := bi.inspectDescriptorEvent(data, offset)
+	if !ok {
+		return bi
+	}
+	offset += descriptor.EventLength
+	nullable, err := descriptor.GetNullable()
+	if err != nil {
+		bi.addIssue("invalid descriptor extras: %v", err)
+	}
+
+	headerSize := (&baseEventHeader{}).GetMemoryUsageInBytes()
+	for int(offset) < len(data) {
+		if len(data)-int(offset) < int(headerSize) {
+			bi.addIssue("truncated event header at offset %d", offset)
+			return bi
+		}
+		header, err := readEventHeader(bytes.NewReader(data[offset:]))
+		if err != nil {
+			bi.addIssue("failed to read event header at offset %d: %v", offset, err)
+			return bi
+		}
+		fixPartSize := getEventFixPartSize(header.TypeCode)
+		if fixPartSize < 0 || header.TypeCode == DescriptorEventType {
+			bi.addIssue("unexpected event type %d at offset %d", header.TypeCode, offset)
+			return bi
+		}
+		if header.EventLength < headerSize+fixPartSize || int(offset)+int(header.EventLength) > len(data) {
+			bi.addIssue("invalid event length %d at offset %d, file size %d", header.EventLength, offset, len(data))
+			return bi
+		}
+		if header.NextPosition != offset+header.EventLength {
+			bi.addIssue("next position %d of event at offset %d mismatches the event length %d",
+				header.NextPosition, offset, header.EventLength)
+		}
+
+		event := &EventInspection{
+			Offset:      offset,
+			TypeCode:    header.TypeCode,
+			Timestamp:   header.Timestamp,
+			EventLength: header.EventLength,
+		}
+		// the fixed parts of all the event types are the start and end timestamps
+		fixPart := &insertEventData{}
+		if err := binary.Read(bytes.NewReader(data[offset+headerSize:]), common.Endian, fixPart); err != nil {
+			bi.addIssue("failed to read event data at offset %d: %v", offset, err)
+			return bi
+		}
+		event.StartTimestamp, event.EndTimestamp = fixPart.StartTimestamp, fixPart.EndTimestamp
+		bi.checkEventTimestamps(event)
+
+		payload := data[offset+headerSize+fixPartSize : offset+header.EventLength]
+		rows, err := readPayloadLength(descriptor, payload, nullable)
+		if err != nil {
+			bi.addIssue("failed to decode payload of event at offset %d: %v", offset, err)
+		} else {
+			event.Rows = rows
+			bi.Rows += rows
+		}
+		bi.Events = append(bi.Events, event)
+		offset += header.EventLength
+	}
+
+	if len(bi.Events) == 0 {
+		bi.addIssue("no event in binlog")
+	}
+	return bi
+}
+
+func (bi *BinlogInspection) inspectDescriptorEvent(data []byte, offset int32) (*descriptorEvent, bool) {
+	header, err := readDescriptorEventHeader(bytes.NewReader(data[offset:]))
+	if err != nil {
+		bi.addIssue("failed to read descriptor event header: %v", err)
+		return nil, false
+	}
+	if header.TypeCode != DescriptorEventType {
+		bi.addIssue("unexpected descriptor event type %d", header.TypeCode)
+		return nil, false
+	}
+	headerSize := header.GetMemoryUsageInBytes()
+	if header.EventLength <= headerSize || int(offset)+int(header.EventLength) > len(data) {
+		bi.addIssue("invalid descriptor event length %d, file size %d", header.EventLength, len(data))
+		return nil, false
+	}
+	if header.NextPosition != offset+header.EventLength {
+		bi.addIssue("next position %d of descriptor event mismatches the event length %d", header.NextPosition, header.EventLength)
+	}
+	bi.DescriptorTimestamp = header.Timestamp
+
+	data = data[offset+headerSize : offset+header.EventLength]
+	// check the extra length before reading, a corrupted one may lead to a huge allocation
+	extraLengthOffset := binary.Size(DescriptorEventDataFixPart{}) + len(newDescriptorEventData().PostHeaderLengths)
+	if len(data) < extraLengthOffset+4 {
+		bi.addIssue("truncated descriptor event data")
+		return nil, false
+	}
+	extraLength := int32(common.Endian.Uint32(data[extraLengthOffset:]))
+	if extraLength < 0 || extraLengthOffset+4+int(extraLength) != len(data) {
+		bi.addIssue("invalid descriptor extra length %d", extraLength)
+		return nil, false
+	}
+	eventData, err := readDescriptorEventData(bytes.NewReader(data))
+	if err != nil {
+		bi.addIssue("failed to read descriptor event data: %v", err)
+		return nil, false
+	}
+	bi.DescriptorEventDataFixPart = eventData.DescriptorEventDataFixPart
+	bi.Extras = eventData.Extras
+	if _, ok := eventData.Extras[originalSizeKey]; !ok {
+		bi.addIssue("%s not in descriptor extras", originalSizeKey)
+	}
+	if eventData.StartTimestamp > eventData.EndTimestamp {
+		bi.addIssue("descriptor start timestamp %d is larger than end timestamp %d", eventData.StartTimestamp, eventData.EndTimestamp)
+	}
+	return &descriptorEvent{
+		descriptorEventHeader: *header,
+		descriptorEventData:   *eventData,
+	}, true
+}
+
+func (bi *BinlogInspection) checkEventTimestamps(event *EventInspection) {
+	if event.TypeCode != InsertEventType && event.TypeCode != DeleteEventType {
+		return
+	}
+	if event.StartTimestamp > event.EndTimestamp {
+		bi.addIssue("start timestamp %d of event at offset %d is larger than end timestamp %d",
+			event.StartTimestamp, event.Offset, event.EndTimestamp)
+	}
+	if event.StartTimestamp < bi.StartTimestamp || event.EndTimestamp > bi.EndTimestamp {
+		bi.addIssue("timestamp range [%d, %d] of event at offset %d is out of the descriptor range [%d, %d]",
+			event.StartTimestamp, event.EndTimestamp, event.Offset, bi.StartTimestamp, bi.EndTimestamp)
+	}
+}
+
+func readPayloadLength(descriptor *descriptorEvent, payload []byte, nullable bool) (int, error) {
+	reader, err := NewPayloadReader(descriptor.PayloadDataType, payload, nullable)
+	if err != nil {
+		return 0, err
+	}
+	defer reader.Close()
+	return reader.GetPayloadLengthFromReader()
+}
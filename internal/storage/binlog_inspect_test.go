@@ -0,0 +1,123 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"encoding/binary"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/schemapb"
+	"github.com/milvus-io/milvus/pkg/common"
+)
+
+func buildInspectTestBinlog(t *testing.T) []byte {
+	binlogWriter := NewInsertBinlogWriter(schemapb.DataType_Int64, 10, 20, 30, 40, false)
+	defer binlogWriter.Close()
+	binlogWriter.SetEventTimeStamp(1000, 3000)
+	binlogWriter.descriptorEventData.AddExtra(originalSizeKey, fmt.Sprintf("%v", 48))
+
+	eventWriter, err := binlogWriter.NextInsertEventWriter()
+	require.NoError(t, err)
+	require.NoError(t, eventWriter.AddInt64ToPayload([]int64{1, 2, 3}, nil))
+	eventWriter.SetEventTimestamp(1000, 2000)
+
+	eventWriter, err = binlogWriter.NextInsertEventWriter()
+	require.NoError(t, err)
+	require.NoError(t, eventWriter.AddInt64ToPayload([]int64{4, 5, 6}, nil))
+	eventWriter.SetEventTimestamp(2000, 3000)
+
+	require.NoError(t, binlogWriter.Finish())
+	buffer, err := binlogWriter.GetBuffer()
+	require.NoError(t, err)
+	return buffer
+}
+
+func TestInspectBinlog(t *testing.T) {
+	data := buildInspectTestBinlog(t)
+
+	t.Run("valid", func(t *testing.T) {
+		bi := InspectBinlog(data)
+		assert.True(t, bi.Valid(), bi.Issues)
+		assert.Equal(t, len(data), bi.Size)
+		assert.EqualValues(t, 10, bi.CollectionID)
+		assert.EqualValues(t, 20, bi.PartitionID)
+		assert.EqualValues(t, 30, bi.SegmentID)
+		assert.EqualValues(t, 40, bi.FieldID)
+		assert.Equal(t, schemapb.DataType_Int64, bi.PayloadDataType)
+		assert.Equal(t, "48", bi.Extras[originalSizeKey])
+		assert.Equal(t, 6, bi.Rows)
+		require.Len(t, bi.Events, 2)
+		assert.Equal(t, InsertEventType, bi.Events[0].TypeCode)
+		assert.Equal(t, 3, bi.Events[0].Rows)
+		assert.EqualValues(t, 2000, bi.Events[1].StartTimestamp)
+		assert.EqualValues(t, 3000, bi.Events[1].EndTimestamp)
+		assert.EqualValues(t, bi.Events[0].Offset+bi.Events[0].EventLength, bi.Events[1].Offset)
+	})
+
+	t.Run("invalid magic number", func(t *testing.T) {
+		corrupted := append([]byte{}, data...)
+		corrupted[0] ^= 0xff
+		bi := InspectBinlog(corrupted)
+		assert.False(t, bi.Valid())
+		assert.Empty(t, bi.Events)
+	})
+
+	t.Run("truncated", func(t *testing.T) {
+		for _, size := range []int{2, 10, 100, len(data) - 1} {
+			bi := InspectBinlog(data[:size])
+			assert.False(t, bi.Valid(), size)
+		}
+	})
+
+	t.Run("corrupted extra length", func(t *testing.T) {
+		corrupted := append([]byte{}, data...)
+		offset := binary.Size(MagicNumber) + binary.Size(baseEventHeader{}) +
+			binary.Size(DescriptorEventDataFixPart{}) + len(newDescriptorEventData().PostHeaderLengths)
+		common.Endian.PutUint32(corrupted[offset:], 0x7fffffff)
+		bi := InspectBinlog(corrupted)
+		assert.False(t, bi.Valid())
+	})
+
+	t.Run("corrupted next position", func(t *testing.T) {
+		bi := InspectBinlog(data)
+		corrupted := append([]byte{}, data...)
+		// the next position is the last field of the event header
+		offset := bi.Events[0].Offset + int32(binary.Size(baseEventHeader{})) - 4
+		common.Endian.PutUint32(corrupted[offset:], 1)
+		bi = InspectBinlog(corrupted)
+		assert.False(t, bi.Valid())
+		assert.Equal(t, 6, bi.Rows)
+		assert.Len(t, bi.Issues, 1)
+	})
+
+	t.Run("corrupted payload", func(t *testing.T) {
+		bi := InspectBinlog(data)
+		corrupted := append([]byte{}, data...)
+		payloadOffset := int(bi.Events[1].Offset) + binary.Size(baseEventHeader{}) + binary.Size(insertEventData{})
+		for i := payloadOffset; i < len(corrupted); i++ {
+			corrupted[i] = 0
+		}
+		bi = InspectBinlog(corrupted)
+		assert.False(t, bi.Valid())
+		assert.Equal(t, 3, bi.Rows)
+		assert.Len(t, bi.Events, 2)
+	})
+}
@@ -41,6 +41,8 @@ const (
 	StatsBinlog
 	// BM25 BinlogType for bm25 stats data
 	BM25Binlog
+	// FieldStatsBinlog BinlogType for the stats of the fields with field stats enabled
+	FieldStatsBinlog
 )
 
 const (
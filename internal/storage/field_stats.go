@@ -19,6 +19,7 @@ package storage
 import (
 	"fmt"
 
+	"github.com/apache/arrow/go/v12/arrow"
 	"go.uber.org/zap"
 
 	"github.com/milvus-io/milvus-proto/go-api/v2/schemapb"
@@ -28,6 +29,7 @@ import (
 	"github.com/milvus-io/milvus/pkg/log"
 	"github.com/milvus-io/milvus/pkg/util/merr"
 	"github.com/milvus-io/milvus/pkg/util/paramtable"
+	"github.com/milvus-io/milvus/pkg/util/typeutil"
 )
 
 // FieldStats contains statistics data for any column
@@ -374,6 +376,41 @@ func (stats *FieldStats) UpdateMinMax(pk ScalarFieldValue) {
 	}
 }
 
+// MayContain checks whether the value may exist in the field by min/max and bloom filter,
+// the value must be of the same type as the field.
+func (stats *FieldStats) MayContain(value ScalarFieldValue) bool {
+	if stats.Min == nil || stats.Max == nil {
+		return true
+	}
+	if stats.Min.GT(value) || stats.Max.LT(value) {
+		return false
+	}
+	if stats.BF == nil {
+		return true
+	}
+	// encode the same as Update
+	b := make([]byte, 8)
+	switch stats.Type {
+	case schemapb.DataType_Int8:
+		common.Endian.PutUint64(b, uint64(value.GetValue().(int8)))
+	case schemapb.DataType_Int16:
+		common.Endian.PutUint64(b, uint64(value.GetValue().(int16)))
+	case schemapb.DataType_Int32:
+		common.Endian.PutUint64(b, uint64(value.GetValue().(int32)))
+	case schemapb.DataType_Int64:
+		common.Endian.PutUint64(b, uint64(value.GetValue().(int64)))
+	case schemapb.DataType_Float:
+		common.Endian.PutUint64(b, uint64(value.GetValue().(float32)))
+	case schemapb.DataType_Double:
+		common.Endian.PutUint64(b, uint64(value.GetValue().(float64)))
+	case schemapb.DataType_String, schemapb.DataType_VarChar:
+		return stats.BF.TestString(value.GetValue().(string))
+	default:
+		return true
+	}
+	return stats.BF.Test(b)
+}
+
 // SetVectorCentroids update centroids value
 func (stats *FieldStats) SetVectorCentroids(centroids ...VectorFieldValue) {
 	stats.Centroids = centroids
@@ -398,6 +435,45 @@ func NewFieldStats(fieldID int64, pkType schemapb.DataType, rowNum int64) (*Fiel
 	}, nil
 }
 
+// NewFieldStatsOfField creates the stats of a field with field stats enabled, the filter type follows
+// the field_stats type param of the field. It returns false if the field stats are not enabled.
+func NewFieldStatsOfField(field *schemapb.FieldSchema, rowNum int64) (*FieldStats, bool) {
+	statsType, ok := typeutil.CreateFieldSchemaHelper(field).GetFieldStatsType()
+	if !ok {
+		return nil, false
+	}
+	bfType := bloomfilter.BlockBFName
+	if statsType == common.FieldStatsXor {
+		bfType = bloomfilter.XorBFName
+	}
+	return &FieldStats{
+		FieldID: field.GetFieldID(),
+		Type:    field.GetDataType(),
+		BFType:  bloomfilter.BFTypeFromString(bfType),
+		BF: bloomfilter.NewBloomFilterWithType(uint(rowNum),
+			paramtable.Get().CommonCfg.MaxBloomFalsePositive.GetAsFloat(), bfType),
+	}, true
+}
+
+// UpdateByValue updates the stats by a deserialized value of the field, null is skipped.
+func (stats *FieldStats) UpdateByValue(value any) {
+	if value == nil {
+		return
+	}
+	stats.Update(NewScalarFieldValue(stats.Type, value))
+}
+
+// UpdateByArray updates the stats by the i-th value of the arrow array of the field, null is skipped.
+func (stats *FieldStats) UpdateByArray(a arrow.Array, i int) {
+	entry, ok := serdeMap[stats.Type]
+	if !ok {
+		return
+	}
+	if value, ok := entry.deserialize(a, i); ok {
+		stats.UpdateByValue(value)
+	}
+}
+
 // FieldStatsWriter writes stats to buffer
 type FieldStatsWriter struct {
 	buffer []byte
@@ -19,8 +19,11 @@ package storage
 import (
 	"testing"
 
+	"github.com/apache/arrow/go/v12/arrow/array"
+	"github.com/apache/arrow/go/v12/arrow/memory"
 	"github.com/stretchr/testify/assert"
 
+	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
 	"github.com/milvus-io/milvus-proto/go-api/v2/schemapb"
 	"github.com/milvus-io/milvus/internal/json"
 	"github.com/milvus-io/milvus/internal/util/bloomfilter"
@@ -87,6 +90,29 @@ func TestFieldStatsUpdate(t *testing.T) {
 	assert.Equal(t, "a", fieldStat8.Min.GetValue())
 }
 
+func TestFieldStatsMayContain(t *testing.T) {
+	intStats, err := NewFieldStats(1, schemapb.DataType_Int32, 2)
+	assert.NoError(t, err)
+	intStats.Update(NewInt32FieldValue(99))
+	intStats.Update(NewInt32FieldValue(201))
+	assert.True(t, intStats.MayContain(NewInt32FieldValue(99)))
+	assert.True(t, intStats.MayContain(NewInt32FieldValue(201)))
+	assert.False(t, intStats.MayContain(NewInt32FieldValue(98)))
+	assert.False(t, intStats.MayContain(NewInt32FieldValue(202)))
+
+	strStats, err := NewFieldStats(2, schemapb.DataType_VarChar, 2)
+	assert.NoError(t, err)
+	strStats.Update(NewVarCharFieldValue("a"))
+	strStats.Update(NewVarCharFieldValue("z"))
+	assert.True(t, strStats.MayContain(NewVarCharFieldValue("a")))
+	assert.False(t, strStats.MayContain(NewVarCharFieldValue("zz")))
+
+	// no stats
+	emptyStats, err := NewFieldStats(2, schemapb.DataType_VarChar, 0)
+	assert.NoError(t, err)
+	assert.True(t, emptyStats.MayContain(NewVarCharFieldValue("a")))
+}
+
 func TestFieldStatsWriter_Int8FieldValue(t *testing.T) {
 	data := &Int8FieldData{
 		Data: []int8{1, 2, 3, 4, 5, 6, 7, 8, 9},
@@ -721,3 +747,32 @@ func TestFindMaxVersion(t *testing.T) {
 	assert.Equal(t, int64(-1), version2)
 	assert.Equal(t, "", path2)
 }
+
+func TestNewFieldStatsOfField(t *testing.T) {
+	paramtable.Init()
+	_, ok := NewFieldStatsOfField(&schemapb.FieldSchema{FieldID: 100, DataType: schemapb.DataType_Int64}, 10)
+	assert.False(t, ok)
+
+	stats, ok := NewFieldStatsOfField(&schemapb.FieldSchema{
+		FieldID:    101,
+		DataType:   schemapb.DataType_Int64,
+		TypeParams: []*commonpb.KeyValuePair{{Key: common.FieldStatsKey, Value: common.FieldStatsXor}},
+	}, 10)
+	assert.True(t, ok)
+	assert.Equal(t, bloomfilter.XorBF, stats.BFType)
+
+	stats.UpdateByValue(int64(3))
+	stats.UpdateByValue(nil)
+	builder := array.NewInt64Builder(memory.DefaultAllocator)
+	builder.AppendValues([]int64{1, 5}, nil)
+	builder.AppendNull()
+	arr := builder.NewArray()
+	defer arr.Release()
+	for i := 0; i < arr.Len(); i++ {
+		stats.UpdateByArray(arr, i)
+	}
+	assert.Equal(t, int64(1), stats.Min.GetValue())
+	assert.Equal(t, int64(5), stats.Max.GetValue())
+	assert.True(t, stats.MayContain(NewInt64FieldValue(3)))
+	assert.False(t, stats.MayContain(NewInt64FieldValue(6)))
+}
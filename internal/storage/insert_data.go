@@ -309,6 +309,15 @@ func NewFieldData(dataType schemapb.DataType, fieldSchema *schemapb.FieldSchema,
 			data.ValidData = make([]bool, 0, cap)
 		}
 		return data, nil
+	case schemapb.DataType_Geometry:
+		data := &GeometryFieldData{
+			Data:     make([][]byte, 0, cap),
+			Nullable: fieldSchema.GetNullable(),
+		}
+		if fieldSchema.GetNullable() {
+			data.ValidData = make([]bool, 0, cap)
+		}
+		return data, nil
 	case schemapb.DataType_Array:
 		data := &ArrayFieldData{
 			Data:        make([]*schemapb.ScalarField, 0, cap),
@@ -386,6 +395,13 @@ type JSONFieldData struct {
 	ValidData []bool
 	Nullable  bool
 }
+
+// GeometryFieldData holds the geometries as wkb.
+type GeometryFieldData struct {
+	Data      [][]byte
+	ValidData []bool
+	Nullable  bool
+}
 type BinaryVectorFieldData struct {
 	Data []byte
 	Dim  int
@@ -428,6 +444,7 @@ func (data *DoubleFieldData) RowNum() int        { return len(data.Data) }
 func (data *StringFieldData) RowNum() int        { return len(data.Data) }
 func (data *ArrayFieldData) RowNum() int         { return len(data.Data) }
 func (data *JSONFieldData) RowNum() int          { return len(data.Data) }
+func (data *GeometryFieldData) RowNum() int      { return len(data.Data) }
 func (data *BinaryVectorFieldData) RowNum() int  { return len(data.Data) * 8 / data.Dim }
 func (data *FloatVectorFieldData) RowNum() int   { return len(data.Data) / data.Dim }
 func (data *Float16VectorFieldData) RowNum() int { return len(data.Data) / 2 / data.Dim }
@@ -507,6 +524,13 @@ func (data *JSONFieldData) GetRow(i int) any {
 	return data.Data[i]
 }
 
+func (data *GeometryFieldData) GetRow(i int) any {
+	if data.GetNullable() && !data.ValidData[i] {
+		return nil
+	}
+	return data.Data[i]
+}
+
 func (data *BinaryVectorFieldData) GetRow(i int) any {
 	return data.Data[i*data.Dim/8 : (i+1)*data.Dim/8]
 }
@@ -537,6 +561,7 @@ func (data *DoubleFieldData) GetDataRows() any            { return data.Data }
 func (data *StringFieldData) GetDataRows() any            { return data.Data }
 func (data *ArrayFieldData) GetDataRows() any             { return data.Data }
 func (data *JSONFieldData) GetDataRows() any              { return data.Data }
+func (data *GeometryFieldData) GetDataRows() any          { return data.Data }
 func (data *BinaryVectorFieldData) GetDataRows() any      { return data.Data }
 func (data *FloatVectorFieldData) GetDataRows() any       { return data.Data }
 func (data *Float16VectorFieldData) GetDataRows() any     { return data.Data }
@@ -714,6 +739,23 @@ func (data *JSONFieldData) AppendRow(row interface{}) error {
 	return nil
 }
 
+func (data *GeometryFieldData) AppendRow(row interface{}) error {
+	if data.GetNullable() && row == nil {
+		data.Data = append(data.Data, make([][]byte, 1)...)
+		data.ValidData = append(data.ValidData, false)
+		return nil
+	}
+	v, ok := row.([]byte)
+	if !ok {
+		return merr.WrapErrParameterInvalid("[]byte", row, "Wrong row type")
+	}
+	if data.GetNullable() {
+		data.ValidData = append(data.ValidData, true)
+	}
+	data.Data = append(data.Data, v)
+	return nil
+}
+
 func (data *BinaryVectorFieldData) AppendRow(row interface{}) error {
 	v, ok := row.([]byte)
 	if !ok || len(v) != data.Dim/8 {
@@ -846,6 +888,14 @@ func (data *JSONFieldData) AppendRows(dataRows interface{}, validDataRows interf
 	return data.AppendValidDataRows(validDataRows)
 }
 
+func (data *GeometryFieldData) AppendRows(dataRows interface{}, validDataRows interface{}) error {
+	err := data.AppendDataRows(dataRows)
+	if err != nil {
+		return err
+	}
+	return data.AppendValidDataRows(validDataRows)
+}
+
 // AppendDataRows appends FLATTEN vectors to field data.
 func (data *BinaryVectorFieldData) AppendRows(dataRows interface{}, validDataRows interface{}) error {
 	err := data.AppendDataRows(dataRows)
@@ -980,6 +1030,15 @@ func (data *JSONFieldData) AppendDataRows(rows interface{}) error {
 	return nil
 }
 
+func (data *GeometryFieldData) AppendDataRows(rows interface{}) error {
+	v, ok := rows.([][]byte)
+	if !ok {
+		return merr.WrapErrParameterInvalid("[][]byte", rows, "Wrong rows type")
+	}
+	data.Data = append(data.Data, v...)
+	return nil
+}
+
 // AppendDataRows appends FLATTEN vectors to field data.
 func (data *BinaryVectorFieldData) AppendDataRows(rows interface{}) error {
 	v, ok := rows.([]byte)
@@ -1164,6 +1223,18 @@ func (data *JSONFieldData) AppendValidDataRows(rows interface{}) error {
 	return nil
 }
 
+func (data *GeometryFieldData) AppendValidDataRows(rows interface{}) error {
+	if rows == nil {
+		return nil
+	}
+	v, ok := rows.([]bool)
+	if !ok {
+		return merr.WrapErrParameterInvalid("[]bool", rows, "Wrong rows type")
+	}
+	data.ValidData = append(data.ValidData, v...)
+	return nil
+}
+
 // AppendValidDataRows appends FLATTEN vectors to field data.
 func (data *BinaryVectorFieldData) AppendValidDataRows(rows interface{}) error {
 	if rows != nil {
@@ -1282,6 +1353,10 @@ func (data *DoubleFieldData) GetDataType() schemapb.DataType { return schemapb.D
 func (data *StringFieldData) GetDataType() schemapb.DataType { return data.DataType }
 func (data *ArrayFieldData) GetDataType() schemapb.DataType  { return schemapb.DataType_Array }
 func (data *JSONFieldData) GetDataType() schemapb.DataType   { return schemapb.DataType_JSON }
+func (data *GeometryFieldData) GetDataType() schemapb.DataType {
+	return schemapb.DataType_Geometry
+}
+
 func (data *BinaryVectorFieldData) GetDataType() schemapb.DataType {
 	return schemapb.DataType_BinaryVector
 }
@@ -1347,6 +1422,14 @@ func (data *JSONFieldData) GetMemorySize() int {
 	return size + binary.Size(data.ValidData) + binary.Size(data.Nullable)
 }
 
+func (data *GeometryFieldData) GetMemorySize() int {
+	var size int
+	for _, val := range data.Data {
+		size += len(val) + 16
+	}
+	return size + binary.Size(data.ValidData) + binary.Size(data.Nullable)
+}
+
 func (data *BoolFieldData) GetRowSize(i int) int           { return 1 }
 func (data *Int8FieldData) GetRowSize(i int) int           { return 1 }
 func (data *Int16FieldData) GetRowSize(i int) int          { return 2 }
@@ -1360,6 +1443,7 @@ func (data *Float16VectorFieldData) GetRowSize(i int) int  { return data.Dim * 2
 func (data *BFloat16VectorFieldData) GetRowSize(i int) int { return data.Dim * 2 }
 func (data *StringFieldData) GetRowSize(i int) int         { return len(data.Data[i]) + 16 }
 func (data *JSONFieldData) GetRowSize(i int) int           { return len(data.Data[i]) + 16 }
+func (data *GeometryFieldData) GetRowSize(i int) int       { return len(data.Data[i]) + 16 }
 func (data *ArrayFieldData) GetRowSize(i int) int {
 	switch data.ElementType {
 	case schemapb.DataType_Bool:
@@ -1445,3 +1529,7 @@ func (data *ArrayFieldData) GetNullable() bool {
 func (data *JSONFieldData) GetNullable() bool {
 	return data.Nullable
 }
+
+func (data *GeometryFieldData) GetNullable() bool {
+	return data.Nullable
+}
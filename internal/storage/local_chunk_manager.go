@@ -90,6 +90,27 @@ func (lcm *LocalChunkManager) Write(ctx context.Context, filePath string, conten
 	return WriteFile(filePath, content, os.ModePerm)
 }
 
+// WriteFrom writes the data read from reader to local storage.
+func (lcm *LocalChunkManager) WriteFrom(ctx context.Context, filePath string, reader io.Reader, size int64) error {
+	dir := path.Dir(filePath)
+	if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+		return merr.WrapErrIoFailed(filePath, err)
+	}
+	file, err := os.Create(filePath)
+	if err != nil {
+		return merr.WrapErrIoFailed(filePath, err)
+	}
+	defer file.Close()
+	n, err := io.Copy(file, reader)
+	if err != nil {
+		return merr.WrapErrIoFailed(filePath, err)
+	}
+	if n != size {
+		return merr.WrapErrIoFailed(filePath, errors.Newf("expected %d bytes, but wrote %d bytes", size, n))
+	}
+	return nil
+}
+
 // MultiWrite writes the data to local storage.
 func (lcm *LocalChunkManager) MultiWrite(ctx context.Context, contents map[string][]byte) error {
 	var el error
@@ -20,6 +20,7 @@ import (
 	"context"
 	"path"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -174,6 +175,24 @@ func TestLocalCM(t *testing.T) {
 		assert.Error(t, err)
 	})
 
+	t.Run("test WriteFrom", func(t *testing.T) {
+		testWriteFromRoot := "test_write_from"
+
+		testCM := NewLocalChunkManager(RootPath(localPath))
+		defer testCM.RemoveWithPrefix(ctx, testCM.RootPath())
+
+		key := path.Join(localPath, testWriteFromRoot, "key_1")
+		err := testCM.WriteFrom(ctx, key, strings.NewReader("111"), 3)
+		assert.NoError(t, err)
+		val, err := testCM.Read(ctx, key)
+		assert.NoError(t, err)
+		assert.Equal(t, []byte("111"), val)
+
+		// size mismatch
+		err = testCM.WriteFrom(ctx, key, strings.NewReader("111"), 4)
+		assert.Error(t, err)
+	})
+
 	t.Run("test MultiSave", func(t *testing.T) {
 		testMultiSaveRoot := "test_multisave"
 
@@ -98,6 +98,9 @@ func (r *PayloadReader) GetDataFromPayload() (interface{}, []bool, int, error) {
 	case schemapb.DataType_JSON:
 		val, validData, err := r.GetJSONFromPayload()
 		return val, validData, 0, err
+	case schemapb.DataType_Geometry:
+		val, validData, err := r.GetGeometryFromPayload()
+		return val, validData, 0, err
 	default:
 		return nil, nil, 0, merr.WrapErrParameterInvalidMsg("unknown type")
 	}
@@ -432,6 +435,25 @@ func (r *PayloadReader) GetJSONFromPayload() ([][]byte, []bool, error) {
 	return value, nil, nil
 }
 
+func (r *PayloadReader) GetGeometryFromPayload() ([][]byte, []bool, error) {
+	if r.colType != schemapb.DataType_Geometry {
+		return nil, nil, merr.WrapErrParameterInvalidMsg(fmt.Sprintf("failed to get geometry from datatype %v", r.colType.String()))
+	}
+
+	if r.nullable {
+		return readNullableByteAndConvert(r, func(bytes []byte) []byte {
+			return bytes
+		})
+	}
+	value, err := readByteAndConvert(r, func(bytes parquet.ByteArray) []byte {
+		return bytes
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return value, nil, nil
+}
+
 func (r *PayloadReader) GetByteArrayDataSet() (*DataSet[parquet.ByteArray, *file.ByteArrayColumnChunkReader], error) {
 	if r.colType != schemapb.DataType_String && r.colType != schemapb.DataType_VarChar {
 		return nil, fmt.Errorf("failed to get string from datatype %v", r.colType.String())
@@ -182,8 +182,8 @@ func (lc *LocationsCache) Locations(k uint, bfType bloomfilter.BFType) []uint64
 			lc.basicBFLocations = Locations(lc.pk, k, bfType)
 		}
 		return lc.basicBFLocations[:k]
-	case bloomfilter.BlockedBF:
-		// for block bf, we only need cache the hash result, which is a uint and only compute once for any k value
+	case bloomfilter.BlockedBF, bloomfilter.XorBF:
+		// for block bf and xor filter, we only need cache the hash result, which is a uint and only compute once for any k value
 		if len(lc.blockBFLocations) != 1 {
 			lc.blockBFLocations = Locations(lc.pk, 1, bfType)
 		}
@@ -229,8 +229,8 @@ func (lc *BatchLocationsCache) Locations(k uint, bfType bloomfilter.BFType) [][]
 		}
 
 		return lo.Map(lc.basicLocations, func(locations []uint64, _ int) []uint64 { return locations[:k] })
-	case bloomfilter.BlockedBF:
-		// for block bf, we only need cache the hash result, which is a uint and only compute once for any k value
+	case bloomfilter.BlockedBF, bloomfilter.XorBF:
+		// for block bf and xor filter, we only need cache the hash result, which is a uint and only compute once for any k value
 		if len(lc.blockLocations) != len(lc.pks) {
 			lc.blockLocations = lo.Map(lc.pks, func(pk PrimaryKey, _ int) []uint64 {
 				return Locations(pk, lc.k, bfType)
@@ -27,6 +27,7 @@ import (
 	"github.com/milvus-io/milvus-proto/go-api/v2/msgpb"
 	"github.com/milvus-io/milvus-proto/go-api/v2/schemapb"
 	"github.com/milvus-io/milvus/internal/json"
+	"github.com/milvus-io/milvus/pkg/util/geo"
 	"github.com/milvus-io/milvus/pkg/util/tsoutil"
 )
 
@@ -368,6 +369,21 @@ func printPayloadValues(colType schemapb.DataType, reader PayloadReaderInterface
 		for i, v := range valids {
 			fmt.Printf("\t\t%d : %v\n", i, v)
 		}
+	case schemapb.DataType_Geometry:
+		val, valids, err := reader.GetGeometryFromPayload()
+		if err != nil {
+			return err
+		}
+		for i, v := range val {
+			wkt, err := geo.WKBToWKT(v)
+			if err != nil {
+				wkt = fmt.Sprintf("%x", v)
+			}
+			fmt.Printf("\t\t%d : %s\n", i, wkt)
+		}
+		for i, v := range valids {
+			fmt.Printf("\t\t%d : %v\n", i, v)
+		}
 	case schemapb.DataType_SparseFloatVector:
 		sparseData, _, err := reader.GetSparseFloatVectorFromPayload()
 		if err != nil {
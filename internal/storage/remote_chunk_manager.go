@@ -162,6 +162,18 @@ func (mcm *RemoteChunkManager) Write(ctx context.Context, filePath string, conte
 	return nil
 }
 
+// WriteFrom writes the data read from reader to minio storage.
+func (mcm *RemoteChunkManager) WriteFrom(ctx context.Context, filePath string, reader io.Reader, size int64) error {
+	err := mcm.putObject(ctx, mcm.bucketName, filePath, reader, size)
+	if err != nil {
+		log.Warn("failed to put object", zap.String("bucket", mcm.bucketName), zap.String("path", filePath), zap.Error(err))
+		return err
+	}
+
+	metrics.PersistentDataKvSize.WithLabelValues(metrics.DataPutLabel).Observe(float64(size))
+	return nil
+}
+
 // MultiWrite saves multiple objects, the path is the key of @kvs.
 // The object value is the value of @kvs.
 func (mcm *RemoteChunkManager) MultiWrite(ctx context.Context, kvs map[string][]byte) error {
@@ -208,6 +208,10 @@ func NewPrimaryKeyStats(fieldID, pkType, rowNum int64) (*PrimaryKeyStats, error)
 	}
 
 	bfType := paramtable.Get().CommonCfg.BloomFilterType.GetValue()
+	// the stats may be updated after tested, which is not permitted by the static xor filter
+	if bloomfilter.BFTypeFromString(bfType) == bloomfilter.XorBF {
+		bfType = bloomfilter.BlockBFName
+	}
 	return &PrimaryKeyStats{
 		FieldID: fieldID,
 		PkType:  pkType,
@@ -219,6 +223,35 @@ func NewPrimaryKeyStats(fieldID, pkType, rowNum int64) (*PrimaryKeyStats, error)
 	}, nil
 }
 
+// NewSealedPrimaryKeyStats returns a PrimaryKeyStats whose bloom filter type is
+// `common.sealedBloomFilterType`, shall be used for stats that won't be updated after serialized,
+// such as the stats of flushed batches and compacted segments.
+func NewSealedPrimaryKeyStats(fieldID, pkType, rowNum int64) (*PrimaryKeyStats, error) {
+	if rowNum <= 0 {
+		return nil, merr.WrapErrParameterInvalidMsg("zero or negative row num", rowNum)
+	}
+
+	bfType := SealedBloomFilterType()
+	return &PrimaryKeyStats{
+		FieldID: fieldID,
+		PkType:  pkType,
+		BFType:  bloomfilter.BFTypeFromString(bfType),
+		BF: bloomfilter.NewBloomFilterWithType(
+			uint(rowNum),
+			paramtable.Get().CommonCfg.MaxBloomFalsePositive.GetAsFloat(),
+			bfType),
+	}, nil
+}
+
+// SealedBloomFilterType returns the bloom filter type for sealed segments,
+// falls back to `common.bloomFilterType` if not set.
+func SealedBloomFilterType() string {
+	if bfType := paramtable.Get().CommonCfg.SealedBloomFilterType.GetValue(); bfType != "" {
+		return bfType
+	}
+	return paramtable.Get().CommonCfg.BloomFilterType.GetValue()
+}
+
 // StatsWriter writes stats to buffer
 type StatsWriter struct {
 	buffer []byte
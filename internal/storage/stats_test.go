@@ -204,3 +204,63 @@ func TestMarshalStats(t *testing.T) {
 		assert.True(t, stat1[0].BF.Test(b))
 	}
 }
+
+func TestPrimaryKeyStats_XorFallback(t *testing.T) {
+	paramtable.Init()
+	paramtable.Get().Save(paramtable.Get().CommonCfg.BloomFilterType.Key, bloomfilter.XorBFName)
+	defer paramtable.Get().Reset(paramtable.Get().CommonCfg.BloomFilterType.Key)
+
+	stat, err := NewPrimaryKeyStats(1, int64(schemapb.DataType_Int64), 100)
+	assert.NoError(t, err)
+	assert.Equal(t, bloomfilter.BlockedBF, stat.BFType)
+	stat.Update(NewInt64PrimaryKey(1))
+	assert.True(t, stat.BF.TestLocations(Locations(NewInt64PrimaryKey(1), stat.BF.K(), stat.BFType)))
+	stat.Update(NewInt64PrimaryKey(2))
+	assert.True(t, stat.BF.TestLocations(Locations(NewInt64PrimaryKey(2), stat.BF.K(), stat.BFType)))
+}
+
+func TestSealedPrimaryKeyStats(t *testing.T) {
+	paramtable.Init()
+	assert.Equal(t, paramtable.Get().CommonCfg.BloomFilterType.GetValue(), SealedBloomFilterType())
+
+	paramtable.Get().Save(paramtable.Get().CommonCfg.SealedBloomFilterType.Key, bloomfilter.XorBFName)
+	defer paramtable.Get().Reset(paramtable.Get().CommonCfg.SealedBloomFilterType.Key)
+	assert.Equal(t, bloomfilter.XorBFName, SealedBloomFilterType())
+
+	_, err := NewSealedPrimaryKeyStats(1, int64(schemapb.DataType_Int64), 0)
+	assert.Error(t, err)
+
+	stat, err := NewSealedPrimaryKeyStats(1, int64(schemapb.DataType_Int64), 10000)
+	assert.NoError(t, err)
+	assert.Equal(t, bloomfilter.XorBF, stat.BFType)
+	for i := 0; i < 10000; i++ {
+		stat.Update(NewInt64PrimaryKey(int64(i)))
+	}
+
+	sw := &StatsWriter{}
+	assert.NoError(t, sw.GenerateList([]*PrimaryKeyStats{stat}))
+	sr := &StatsReader{}
+	sr.SetBuffer(sw.GetBuffer())
+	stats, err := sr.GetPrimaryKeyStatsList()
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(stats))
+	assert.Equal(t, bloomfilter.XorBF, stats[0].BFType)
+
+	pkStats := &PkStatistics{
+		PkFilter: stats[0].BF,
+		MinPK:    stats[0].MinPk,
+		MaxPK:    stats[0].MaxPk,
+	}
+	pks := make([]PrimaryKey, 0, 10000)
+	for i := 0; i < 10000; i++ {
+		pk := NewInt64PrimaryKey(int64(i))
+		pks = append(pks, pk)
+		assert.True(t, pkStats.TestLocationCache(NewLocationsCache(pk)))
+	}
+	assert.False(t, pkStats.TestLocationCache(NewLocationsCache(NewInt64PrimaryKey(10001))))
+
+	hits := pkStats.BatchPkExist(NewBatchLocationsCache(pks), make([]bool, len(pks)))
+	for _, hit := range hits {
+		assert.True(t, hit)
+	}
+}
@@ -60,6 +60,8 @@ type ChunkManager interface {
 	Write(ctx context.Context, filePath string, content []byte) error
 	// MultiWrite writes multi @content to @filePath.
 	MultiWrite(ctx context.Context, contents map[string][]byte) error
+	// WriteFrom writes @size bytes read from @reader to @filePath, the content is not buffered as a whole.
+	WriteFrom(ctx context.Context, filePath string, reader io.Reader, size int64) error
 	// Exist returns true if @filePath exists.
 	Exist(ctx context.Context, filePath string) (bool, error)
 	// Read reads @filePath and returns content.
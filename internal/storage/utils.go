@@ -503,7 +503,8 @@ func ColumnBasedInsertMsgToInsertData(msg *msgstream.InsertMsg, collSchema *sche
 	}
 	length := 0
 	for _, field := range collSchema.Fields {
-		if field.GetIsFunctionOutput() {
+		// the outputs embedded by proxy are sent with the message, the others are computed by the consumers.
+		if _, ok := srcFields[field.GetFieldID()]; field.GetIsFunctionOutput() && !ok {
 			continue
 		}
 
@@ -674,6 +675,15 @@ func ColumnBasedInsertMsgToInsertData(msg *msgstream.InsertMsg, collSchema *sche
 				ValidData: lo.Map(validData, func(v bool, _ int) bool { return v }),
 			}
 
+		case schemapb.DataType_Geometry:
+			srcData := srcField.GetScalars().GetGeometryData().GetData()
+			validData := srcField.GetValidData()
+
+			fieldData = &GeometryFieldData{
+				Data:      lo.Map(srcData, func(v []byte, _ int) []byte { return v }),
+				ValidData: lo.Map(validData, func(v bool, _ int) bool { return v }),
+			}
+
 		default:
 			return nil, merr.WrapErrServiceInternal("data type not handled", field.GetDataType().String())
 		}
@@ -832,6 +842,19 @@ func mergeJSONField(data *InsertData, fid FieldID, field *JSONFieldData) {
 	fieldData.ValidData = append(fieldData.ValidData, field.ValidData...)
 }
 
+func mergeGeometryField(data *InsertData, fid FieldID, field *GeometryFieldData) {
+	if _, ok := data.Data[fid]; !ok {
+		fieldData := &GeometryFieldData{
+			Data:      nil,
+			ValidData: nil,
+		}
+		data.Data[fid] = fieldData
+	}
+	fieldData := data.Data[fid].(*GeometryFieldData)
+	fieldData.Data = append(fieldData.Data, field.Data...)
+	fieldData.ValidData = append(fieldData.ValidData, field.ValidData...)
+}
+
 func mergeBinaryVectorField(data *InsertData, fid FieldID, field *BinaryVectorFieldData) {
 	if _, ok := data.Data[fid]; !ok {
 		fieldData := &BinaryVectorFieldData{
@@ -914,6 +937,8 @@ func MergeFieldData(data *InsertData, fid FieldID, field FieldData) {
 		mergeArrayField(data, fid, field)
 	case *JSONFieldData:
 		mergeJSONField(data, fid, field)
+	case *GeometryFieldData:
+		mergeGeometryField(data, fid, field)
 	case *BinaryVectorFieldData:
 		mergeBinaryVectorField(data, fid, field)
 	case *FloatVectorFieldData:
@@ -1193,6 +1218,21 @@ func TransferInsertDataToInsertRecord(insertData *InsertData) (*segcorepb.Insert
 				},
 				ValidData: rawData.ValidData,
 			}
+		case *GeometryFieldData:
+			fieldData = &schemapb.FieldData{
+				Type:    schemapb.DataType_Geometry,
+				FieldId: fieldID,
+				Field: &schemapb.FieldData_Scalars{
+					Scalars: &schemapb.ScalarField{
+						Data: &schemapb.ScalarField_GeometryData{
+							GeometryData: &schemapb.GeometryArray{
+								Data: rawData.Data,
+							},
+						},
+					},
+				},
+				ValidData: rawData.ValidData,
+			}
 		case *FloatVectorFieldData:
 			fieldData = &schemapb.FieldData{
 				Type:    schemapb.DataType_FloatVector,
@@ -1161,6 +1161,53 @@ func TestColumnBasedInsertMsgToInsertData(t *testing.T) {
 	}
 }
 
+func TestColumnBasedInsertMsgToInsertDataFunctionOutput(t *testing.T) {
+	schema := &schemapb.CollectionSchema{
+		Fields: []*schemapb.FieldSchema{
+			{FieldID: common.RowIDField, Name: common.RowIDFieldName, DataType: schemapb.DataType_Int64},
+			{FieldID: common.TimeStampField, Name: common.TimeStampFieldName, DataType: schemapb.DataType_Int64},
+			{FieldID: 100, Name: "pk", IsPrimaryKey: true, DataType: schemapb.DataType_Int64},
+			{
+				FieldID: 101, Name: "dense", DataType: schemapb.DataType_FloatVector, IsFunctionOutput: true,
+				TypeParams: []*commonpb.KeyValuePair{{Key: common.DimKey, Value: "2"}},
+			},
+			{FieldID: 102, Name: "sparse", DataType: schemapb.DataType_SparseFloatVector, IsFunctionOutput: true},
+		},
+	}
+	msg := &msgstream.InsertMsg{
+		InsertRequest: &msgpb.InsertRequest{
+			Version:    msgpb.InsertDataVersion_ColumnBased,
+			NumRows:    2,
+			RowIDs:     []int64{1, 2},
+			Timestamps: []uint64{1, 1},
+			FieldsData: []*schemapb.FieldData{
+				{
+					FieldId: 100,
+					Type:    schemapb.DataType_Int64,
+					Field: &schemapb.FieldData_Scalars{
+						Scalars: &schemapb.ScalarField{Data: &schemapb.ScalarField_LongData{LongData: &schemapb.LongArray{Data: []int64{1, 2}}}},
+					},
+				},
+				{
+					FieldId: 101,
+					Type:    schemapb.DataType_FloatVector,
+					Field: &schemapb.FieldData_Vectors{
+						Vectors: &schemapb.VectorField{Dim: 2, Data: &schemapb.VectorField_FloatVector{FloatVector: &schemapb.FloatArray{Data: []float32{1, 2, 3, 4}}}},
+					},
+				},
+			},
+		},
+	}
+
+	idata, err := ColumnBasedInsertMsgToInsertData(msg, schema)
+	assert.NoError(t, err)
+	// the output sent with the message is kept
+	assert.Equal(t, []float32{1, 2, 3, 4}, idata.Data[101].(*FloatVectorFieldData).Data)
+	// the output computed by the consumers is skipped
+	_, ok := idata.Data[102]
+	assert.False(t, ok)
+}
+
 func TestColumnBasedInsertMsgToInsertDataNullable(t *testing.T) {
 	numRows, fVecDim, bVecDim, f16VecDim, bf16VecDim := 2, 2, 8, 2, 2
 	schema, _, fieldIDs := genAllFieldsSchemaNullable(fVecDim, bVecDim, f16VecDim, bf16VecDim, true)
@@ -225,6 +225,12 @@ type ProxyClient interface {
 type Proxy interface {
 	Component
 	proxypb.ProxyServer
+	proxypb.TransactionServer
+	proxypb.RecycleBinServer
+	proxypb.ChangeStreamServer
+	proxypb.SnapshotServer
+	proxypb.ExportServer
+	proxypb.RowPolicyServer
 	milvuspb.MilvusServiceServer
 
 	ImportV2(context.Context, *internalpb.ImportRequest) (*internalpb.ImportResponse, error)
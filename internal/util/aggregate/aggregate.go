@@ -0,0 +1,70 @@
+package aggregate
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/milvus-io/milvus/internal/proto/internalpb"
+)
+
+// CountStar is the output name of count(*), which counts rows instead of values.
+const CountStar = "count(*)"
+
+var (
+	aggregateRegex = regexp.MustCompile(`(?i)^\s*(count|sum|min|max|avg)\s*\(\s*(distinct\s+)?([A-Za-z_][A-Za-z0-9_]*|\*)\s*\)\s*$`)
+
+	aggregateOps = map[string]internalpb.AggregateOp{
+		"count": internalpb.AggregateOp_Count,
+		"sum":   internalpb.AggregateOp_Sum,
+		"min":   internalpb.AggregateOp_Min,
+		"max":   internalpb.AggregateOp_Max,
+		"avg":   internalpb.AggregateOp_Avg,
+	}
+)
+
+// IsAggregation returns whether the retrieve request asks for aggregated results.
+func IsAggregation(req *internalpb.RetrieveRequest) bool {
+	return len(req.GetGroupByFieldsId()) > 0 || len(req.GetAggregates()) > 0
+}
+
+// ParseOutputField parses an output field like `sum(price)` or `count(distinct tag)`.
+// ok is false if the output field is not an aggregate expression, fieldName is empty for count(*).
+func ParseOutputField(outputField string) (op internalpb.AggregateOp, fieldName string, ok bool, err error) {
+	matches := aggregateRegex.FindStringSubmatch(outputField)
+	if matches == nil {
+		return internalpb.AggregateOp_UnknownAggregate, "", false, nil
+	}
+	op = aggregateOps[strings.ToLower(matches[1])]
+	distinct := matches[2] != ""
+	fieldName = matches[3]
+
+	if fieldName == "*" {
+		if op != internalpb.AggregateOp_Count || distinct {
+			return op, "", true, fmt.Errorf("invalid aggregate expression %s, only count supports *", outputField)
+		}
+		return op, "", true, nil
+	}
+	if distinct {
+		if op != internalpb.AggregateOp_Count {
+			return op, fieldName, true, fmt.Errorf("invalid aggregate expression %s, only count supports distinct", outputField)
+		}
+		op = internalpb.AggregateOp_CountDistinct
+	}
+	return op, fieldName, true, nil
+}
+
+// Name returns the output name of an aggregate, fieldName is ignored for count(*).
+func Name(op internalpb.AggregateOp, fieldName string) string {
+	switch op {
+	case internalpb.AggregateOp_Count:
+		if fieldName == "" {
+			return CountStar
+		}
+		return fmt.Sprintf("count(%s)", fieldName)
+	case internalpb.AggregateOp_CountDistinct:
+		return fmt.Sprintf("count(distinct %s)", fieldName)
+	default:
+		return fmt.Sprintf("%s(%s)", strings.ToLower(op.String()), fieldName)
+	}
+}
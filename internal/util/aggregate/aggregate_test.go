@@ -0,0 +1,225 @@
+package aggregate
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/schemapb"
+	"github.com/milvus-io/milvus/internal/proto/internalpb"
+	"github.com/milvus-io/milvus/pkg/util/typeutil"
+)
+
+const (
+	pkFieldID    = 100
+	tagFieldID   = 101
+	priceFieldID = 102
+	stockFieldID = 103
+	nameFieldID  = 104
+)
+
+func testSchema() *schemapb.CollectionSchema {
+	return &schemapb.CollectionSchema{
+		Name: "test",
+		Fields: []*schemapb.FieldSchema{
+			{FieldID: pkFieldID, Name: "pk", DataType: schemapb.DataType_Int64, IsPrimaryKey: true},
+			{FieldID: tagFieldID, Name: "tag", DataType: schemapb.DataType_VarChar, Nullable: true},
+			{FieldID: priceFieldID, Name: "price", DataType: schemapb.DataType_Double},
+			{FieldID: stockFieldID, Name: "stock", DataType: schemapb.DataType_Int32},
+			{FieldID: nameFieldID, Name: "name", DataType: schemapb.DataType_VarChar},
+			{FieldID: 105, Name: "vec", DataType: schemapb.DataType_FloatVector},
+		},
+	}
+}
+
+func testRows(tags []any, prices []float64, stocks []int32, names []string) []*schemapb.FieldData {
+	priceValues := make([]any, len(prices))
+	for i, price := range prices {
+		priceValues[i] = price
+	}
+	stockValues := make([]any, len(stocks))
+	for i, stock := range stocks {
+		stockValues[i] = int64(stock)
+	}
+	nameValues := make([]any, len(names))
+	for i, name := range names {
+		nameValues[i] = name
+	}
+	return []*schemapb.FieldData{
+		newColumn("tag", tagFieldID, schemapb.DataType_VarChar, tags),
+		newColumn("price", priceFieldID, schemapb.DataType_Double, priceValues),
+		newColumn("stock", stockFieldID, schemapb.DataType_Int32, stockValues),
+		newColumn("name", nameFieldID, schemapb.DataType_VarChar, nameValues),
+	}
+}
+
+func TestParseOutputField(t *testing.T) {
+	cases := []struct {
+		outputField string
+		op          internalpb.AggregateOp
+		fieldName   string
+		ok          bool
+		hasErr      bool
+	}{
+		{"tag", internalpb.AggregateOp_UnknownAggregate, "", false, false},
+		{"count(*)", internalpb.AggregateOp_Count, "", true, false},
+		{" COUNT ( * ) ", internalpb.AggregateOp_Count, "", true, false},
+		{"count(tag)", internalpb.AggregateOp_Count, "tag", true, false},
+		{"count(distinct tag)", internalpb.AggregateOp_CountDistinct, "tag", true, false},
+		{"sum(price)", internalpb.AggregateOp_Sum, "price", true, false},
+		{"Min(price)", internalpb.AggregateOp_Min, "price", true, false},
+		{"max(price)", internalpb.AggregateOp_Max, "price", true, false},
+		{"avg(price)", internalpb.AggregateOp_Avg, "price", true, false},
+		{"sum(*)", internalpb.AggregateOp_Sum, "", true, true},
+		{"sum(distinct price)", internalpb.AggregateOp_Sum, "price", true, true},
+		{"median(price)", internalpb.AggregateOp_UnknownAggregate, "", false, false},
+	}
+	for _, c := range cases {
+		op, fieldName, ok, err := ParseOutputField(c.outputField)
+		assert.Equal(t, c.ok, ok, c.outputField)
+		if c.hasErr {
+			assert.Error(t, err, c.outputField)
+			continue
+		}
+		assert.NoError(t, err, c.outputField)
+		assert.Equal(t, c.op, op, c.outputField)
+		assert.Equal(t, c.fieldName, fieldName, c.outputField)
+	}
+
+	assert.Equal(t, "count(*)", Name(internalpb.AggregateOp_Count, ""))
+	assert.Equal(t, "count(tag)", Name(internalpb.AggregateOp_Count, "tag"))
+	assert.Equal(t, "count(distinct tag)", Name(internalpb.AggregateOp_CountDistinct, "tag"))
+	assert.Equal(t, "avg(price)", Name(internalpb.AggregateOp_Avg, "price"))
+}
+
+func TestNewAggregator(t *testing.T) {
+	schema := testSchema()
+
+	_, err := NewAggregator(nil, nil, schema)
+	assert.Error(t, err)
+
+	_, err = NewAggregator([]int64{999}, nil, schema)
+	assert.Error(t, err)
+
+	_, err = NewAggregator([]int64{priceFieldID}, nil, schema)
+	assert.Error(t, err)
+
+	_, err = NewAggregator(nil, []*internalpb.Aggregate{{Op: internalpb.AggregateOp_Sum, FieldId: nameFieldID}}, schema)
+	assert.Error(t, err)
+
+	_, err = NewAggregator(nil, []*internalpb.Aggregate{{Op: internalpb.AggregateOp_Max, FieldId: 105}}, schema)
+	assert.Error(t, err)
+
+	agg, err := NewAggregator([]int64{tagFieldID}, []*internalpb.Aggregate{
+		{Op: internalpb.AggregateOp_Count},
+		{Op: internalpb.AggregateOp_Max, FieldId: nameFieldID},
+		{Op: internalpb.AggregateOp_CountDistinct, FieldId: stockFieldID},
+	}, schema)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"tag", "count(*)", "max(name)", "count(distinct stock)"}, agg.OutputNames())
+}
+
+func TestAggregator(t *testing.T) {
+	schema := testSchema()
+	groupBy := []int64{tagFieldID}
+	aggregates := []*internalpb.Aggregate{
+		{Op: internalpb.AggregateOp_Count},
+		{Op: internalpb.AggregateOp_Sum, FieldId: priceFieldID},
+		{Op: internalpb.AggregateOp_Sum, FieldId: stockFieldID},
+		{Op: internalpb.AggregateOp_Min, FieldId: stockFieldID},
+		{Op: internalpb.AggregateOp_Max, FieldId: nameFieldID},
+		{Op: internalpb.AggregateOp_Avg, FieldId: priceFieldID},
+		{Op: internalpb.AggregateOp_CountDistinct, FieldId: nameFieldID},
+	}
+
+	// two query nodes, each of them has two segments
+	partials := make([][]*schemapb.FieldData, 0, 2)
+	for _, segments := range [][][]*schemapb.FieldData{
+		{
+			testRows([]any{"a", "b", nil}, []float64{1, 2, 3}, []int32{10, 20, 30}, []string{"x", "y", "z"}),
+			testRows([]any{"a", "a"}, []float64{4, 5}, []int32{5, 15}, []string{"x", "w"}),
+		},
+		{
+			testRows([]any{"b"}, []float64{6}, []int32{1}, []string{"y"}),
+			testRows([]any{}, []float64{}, []int32{}, []string{}),
+		},
+	} {
+		agg, err := NewAggregator(groupBy, aggregates, schema)
+		require.NoError(t, err)
+		for _, rows := range segments {
+			require.NoError(t, agg.AddRows(rows, columnLen(rows[0])))
+		}
+		partials = append(partials, agg.PartialResult())
+	}
+
+	// merge on the proxy
+	agg, err := NewAggregator(groupBy, aggregates, schema)
+	require.NoError(t, err)
+	for _, partial := range partials {
+		require.NoError(t, agg.AddPartial(partial))
+	}
+	result := agg.FinalResult(0, typeutil.Unlimited)
+	require.Equal(t, 8, len(result))
+
+	// groups are ordered by key, null first
+	assert.Equal(t, []string{"", "a", "b"}, result[0].GetScalars().GetStringData().GetData())
+	assert.Equal(t, []bool{false, true, true}, result[0].GetValidData())
+	assert.Equal(t, []int64{1, 3, 2}, result[1].GetScalars().GetLongData().GetData())
+	assert.Equal(t, schemapb.DataType_Double, result[2].GetType())
+	assert.Equal(t, []float64{3, 10, 8}, result[2].GetScalars().GetDoubleData().GetData())
+	assert.Equal(t, schemapb.DataType_Int64, result[3].GetType())
+	assert.Equal(t, []int64{30, 30, 21}, result[3].GetScalars().GetLongData().GetData())
+	assert.Equal(t, schemapb.DataType_Int32, result[4].GetType())
+	assert.Equal(t, []int32{30, 5, 1}, result[4].GetScalars().GetIntData().GetData())
+	assert.Equal(t, []string{"z", "x", "y"}, result[5].GetScalars().GetStringData().GetData())
+	assert.Equal(t, []float64{3, 10.0 / 3, 4}, result[6].GetScalars().GetDoubleData().GetData())
+	assert.Equal(t, []int64{1, 2, 1}, result[7].GetScalars().GetLongData().GetData())
+	assert.Equal(t, "count(distinct name)", result[7].GetFieldName())
+
+	// offset and limit are applied on groups
+	result = agg.FinalResult(1, 1)
+	assert.Equal(t, []string{"a"}, result[0].GetScalars().GetStringData().GetData())
+	result = agg.FinalResult(5, typeutil.Unlimited)
+	assert.Equal(t, 0, columnLen(result[0]))
+
+	// malformed partial results
+	assert.Error(t, agg.AddPartial(partials[0][:2]))
+	assert.Error(t, agg.AddRows(partials[0][:1], 1))
+}
+
+func TestAggregatorWithoutGroupBy(t *testing.T) {
+	schema := testSchema()
+	aggregates := []*internalpb.Aggregate{
+		{Op: internalpb.AggregateOp_Count, FieldId: tagFieldID},
+		{Op: internalpb.AggregateOp_Sum, FieldId: priceFieldID},
+		{Op: internalpb.AggregateOp_Avg, FieldId: stockFieldID},
+	}
+
+	// no rows at all, still one row of result
+	agg, err := NewAggregator(nil, aggregates, schema)
+	require.NoError(t, err)
+	emptyPartial := agg.PartialResult()
+	agg, err = NewAggregator(nil, aggregates, schema)
+	require.NoError(t, err)
+	require.NoError(t, agg.AddPartial(emptyPartial))
+	result := agg.FinalResult(0, typeutil.Unlimited)
+	assert.Equal(t, []int64{0}, result[0].GetScalars().GetLongData().GetData())
+	assert.Equal(t, []bool{false}, result[1].GetValidData())
+	assert.Equal(t, []bool{false}, result[2].GetValidData())
+
+	agg, err = NewAggregator(nil, aggregates, schema)
+	require.NoError(t, err)
+	rows := testRows([]any{"a", nil, "b"}, []float64{1.5, 2.5, 3}, []int32{1, 2, 4}, []string{"x", "y", "z"})
+	require.NoError(t, agg.AddRows(rows, 3))
+	partial := agg.PartialResult()
+
+	agg, err = NewAggregator(nil, aggregates, schema)
+	require.NoError(t, err)
+	require.NoError(t, agg.AddPartial(partial))
+	require.NoError(t, agg.AddPartial(emptyPartial))
+	result = agg.FinalResult(0, typeutil.Unlimited)
+	assert.Equal(t, []int64{2}, result[0].GetScalars().GetLongData().GetData())
+	assert.Equal(t, []float64{7}, result[1].GetScalars().GetDoubleData().GetData())
+	assert.Equal(t, []float64{7.0 / 3}, result[2].GetScalars().GetDoubleData().GetData())
+}
@@ -0,0 +1,503 @@
+package aggregate
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/schemapb"
+	"github.com/milvus-io/milvus/internal/proto/internalpb"
+	"github.com/milvus-io/milvus/pkg/util/typeutil"
+)
+
+// Aggregator groups rows by the group by fields and accumulates the aggregates of each group.
+//
+// Aggregation is done in two phases. Query nodes feed the raw rows retrieved from segments
+// with AddRows and ship the PartialResult, then the partial results are merged with AddPartial
+// until the proxy produces the FinalResult. A partial result has the group by columns first,
+// followed by the state columns of each aggregate:
+//
+//	count          -> count (Int64)
+//	sum            -> sum (Int64 for integer fields, Double otherwise), null if no value
+//	min, max       -> value (same type as the field), null if no value
+//	avg            -> sum (Double), count (Int64)
+//	count distinct -> distinct values (Array)
+type Aggregator struct {
+	groupBy    []*schemapb.FieldSchema
+	aggregates []*internalpb.Aggregate
+	// aggFields holds the field of each aggregate, nil for count(*)
+	aggFields []*schemapb.FieldSchema
+	groups    map[string]*group
+}
+
+type group struct {
+	keys []any
+	accs []*accumulator
+}
+
+type accumulator struct {
+	count    int64
+	intSum   int64
+	floatSum float64
+	value    any
+	distinct map[any]struct{}
+}
+
+// NewAggregator creates an Aggregator, returns error if a field is missing or not supported.
+func NewAggregator(groupByFieldIDs []int64, aggregates []*internalpb.Aggregate, schema *schemapb.CollectionSchema) (*Aggregator, error) {
+	helper, err := typeutil.CreateSchemaHelper(schema)
+	if err != nil {
+		return nil, err
+	}
+	if len(groupByFieldIDs) == 0 && len(aggregates) == 0 {
+		return nil, fmt.Errorf("neither group by fields nor aggregates are specified")
+	}
+
+	groupBy := make([]*schemapb.FieldSchema, 0, len(groupByFieldIDs))
+	for _, fieldID := range groupByFieldIDs {
+		field, err := helper.GetFieldFromID(fieldID)
+		if err != nil {
+			return nil, err
+		}
+		if !isGroupableType(field.GetDataType()) {
+			return nil, fmt.Errorf("group by field %s of type %s is not supported", field.GetName(), field.GetDataType().String())
+		}
+		groupBy = append(groupBy, field)
+	}
+
+	aggFields := make([]*schemapb.FieldSchema, len(aggregates))
+	for i, agg := range aggregates {
+		if agg.GetOp() == internalpb.AggregateOp_Count && agg.GetFieldId() == 0 {
+			continue
+		}
+		field, err := helper.GetFieldFromID(agg.GetFieldId())
+		if err != nil {
+			return nil, err
+		}
+		if err := checkAggregateType(agg.GetOp(), field); err != nil {
+			return nil, err
+		}
+		aggFields[i] = field
+	}
+
+	return &Aggregator{
+		groupBy:    groupBy,
+		aggregates: aggregates,
+		aggFields:  aggFields,
+		groups:     make(map[string]*group),
+	}, nil
+}
+
+func isGroupableType(dataType schemapb.DataType) bool {
+	return typeutil.IsBoolType(dataType) || typeutil.IsIntegerType(dataType) || typeutil.IsStringType(dataType)
+}
+
+func checkAggregateType(op internalpb.AggregateOp, field *schemapb.FieldSchema) error {
+	dataType := field.GetDataType()
+	var supported bool
+	switch op {
+	case internalpb.AggregateOp_Count, internalpb.AggregateOp_CountDistinct:
+		supported = isGroupableType(dataType) || typeutil.IsFloatingType(dataType)
+	case internalpb.AggregateOp_Sum, internalpb.AggregateOp_Avg:
+		supported = typeutil.IsArithmetic(dataType)
+	case internalpb.AggregateOp_Min, internalpb.AggregateOp_Max:
+		supported = typeutil.IsArithmetic(dataType) || typeutil.IsStringType(dataType)
+	default:
+		return fmt.Errorf("unknown aggregate op %s", op.String())
+	}
+	if !supported {
+		return fmt.Errorf("%s is not supported on field %s of type %s",
+			strings.ToLower(op.String()), field.GetName(), dataType.String())
+	}
+	return nil
+}
+
+// OutputNames returns the names of the columns in the final result.
+func (a *Aggregator) OutputNames() []string {
+	names := make([]string, 0, len(a.groupBy)+len(a.aggregates))
+	for _, field := range a.groupBy {
+		names = append(names, field.GetName())
+	}
+	for i, agg := range a.aggregates {
+		names = append(names, Name(agg.GetOp(), a.aggFields[i].GetName()))
+	}
+	return names
+}
+
+// AddRows accumulates rowCount raw rows, the columns are looked up by field id.
+func (a *Aggregator) AddRows(fieldsData []*schemapb.FieldData, rowCount int) error {
+	// empty results may not carry any column
+	if rowCount == 0 {
+		return nil
+	}
+	columns := make(map[int64]*schemapb.FieldData, len(fieldsData))
+	for _, field := range fieldsData {
+		columns[field.GetFieldId()] = field
+	}
+	lookup := func(field *schemapb.FieldSchema) (*schemapb.FieldData, error) {
+		column, ok := columns[field.GetFieldID()]
+		if !ok {
+			return nil, fmt.Errorf("field %s not found in retrieve results", field.GetName())
+		}
+		if column.GetType() != field.GetDataType() {
+			return nil, fmt.Errorf("unexpected data type %s of field %s", column.GetType().String(), field.GetName())
+		}
+		return column, nil
+	}
+
+	groupColumns := make([]*schemapb.FieldData, len(a.groupBy))
+	for i, field := range a.groupBy {
+		column, err := lookup(field)
+		if err != nil {
+			return err
+		}
+		groupColumns[i] = column
+	}
+	aggColumns := make([]*schemapb.FieldData, len(a.aggregates))
+	for i, field := range a.aggFields {
+		if field == nil {
+			continue
+		}
+		column, err := lookup(field)
+		if err != nil {
+			return err
+		}
+		aggColumns[i] = column
+	}
+
+	for row := 0; row < rowCount; row++ {
+		g, err := a.groupOf(groupColumns, row)
+		if err != nil {
+			return err
+		}
+		for i, agg := range a.aggregates {
+			var value any
+			if aggColumns[i] != nil {
+				if value, err = valueAt(aggColumns[i], row); err != nil {
+					return err
+				}
+			}
+			g.accs[i].update(agg.GetOp(), aggColumns[i] == nil, value)
+		}
+	}
+	return nil
+}
+
+// AddPartial merges a partial result produced by PartialResult.
+func (a *Aggregator) AddPartial(fieldsData []*schemapb.FieldData) error {
+	if len(fieldsData) == 0 {
+		return nil
+	}
+	width := len(a.groupBy)
+	for _, agg := range a.aggregates {
+		width += stateWidth(agg.GetOp())
+	}
+	if len(fieldsData) != width {
+		return fmt.Errorf("partial aggregation result should have %d columns, but got %d", width, len(fieldsData))
+	}
+
+	rowCount := columnLen(fieldsData[0])
+	for _, column := range fieldsData {
+		if columnLen(column) != rowCount {
+			return fmt.Errorf("columns of partial aggregation result have different row counts")
+		}
+	}
+	groupColumns := fieldsData[:len(a.groupBy)]
+	for row := 0; row < rowCount; row++ {
+		g, err := a.groupOf(groupColumns, row)
+		if err != nil {
+			return err
+		}
+		pos := len(a.groupBy)
+		for i, agg := range a.aggregates {
+			next := pos + stateWidth(agg.GetOp())
+			if err := g.accs[i].merge(agg.GetOp(), fieldsData[pos:next], row); err != nil {
+				return err
+			}
+			pos = next
+		}
+	}
+	return nil
+}
+
+// PartialResult returns the accumulated states which could be merged by AddPartial.
+func (a *Aggregator) PartialResult() []*schemapb.FieldData {
+	groups := a.sortedGroups()
+	result := a.groupColumns(groups)
+	for i, agg := range a.aggregates {
+		field := a.aggFields[i]
+		name := Name(agg.GetOp(), field.GetName())
+		switch agg.GetOp() {
+		case internalpb.AggregateOp_Count:
+			result = append(result, newColumn(name, field.GetFieldID(), schemapb.DataType_Int64, collect(groups, i, func(acc *accumulator) any {
+				return acc.count
+			})))
+		case internalpb.AggregateOp_Sum:
+			result = append(result, newColumn(name, field.GetFieldID(), sumType(field), collect(groups, i, func(acc *accumulator) any {
+				return acc.sum(sumType(field))
+			})))
+		case internalpb.AggregateOp_Min, internalpb.AggregateOp_Max:
+			result = append(result, newColumn(name, field.GetFieldID(), field.GetDataType(), collect(groups, i, func(acc *accumulator) any {
+				return acc.value
+			})))
+		case internalpb.AggregateOp_Avg:
+			result = append(result,
+				newColumn(name, field.GetFieldID(), schemapb.DataType_Double, collect(groups, i, func(acc *accumulator) any {
+					return acc.floatSum
+				})),
+				newColumn(name, field.GetFieldID(), schemapb.DataType_Int64, collect(groups, i, func(acc *accumulator) any {
+					return acc.count
+				})))
+		case internalpb.AggregateOp_CountDistinct:
+			rows := make([][]any, len(groups))
+			for j, g := range groups {
+				values := make([]any, 0, len(g.accs[i].distinct))
+				for value := range g.accs[i].distinct {
+					values = append(values, value)
+				}
+				rows[j] = values
+			}
+			result = append(result, newArrayColumn(name, field.GetFieldID(), field.GetDataType(), rows))
+		}
+	}
+	return result
+}
+
+// FinalResult returns the groups ordered by the group by fields, skipping offset groups
+// and returning at most limit groups.
+func (a *Aggregator) FinalResult(offset int64, limit int64) []*schemapb.FieldData {
+	groups := a.sortedGroups()
+	if offset >= int64(len(groups)) {
+		groups = nil
+	} else {
+		groups = groups[offset:]
+	}
+	if limit != typeutil.Unlimited && limit < int64(len(groups)) {
+		groups = groups[:limit]
+	}
+
+	result := a.groupColumns(groups)
+	for i, agg := range a.aggregates {
+		field := a.aggFields[i]
+		name := Name(agg.GetOp(), field.GetName())
+		switch agg.GetOp() {
+		case internalpb.AggregateOp_Count:
+			result = append(result, newColumn(name, field.GetFieldID(), schemapb.DataType_Int64, collect(groups, i, func(acc *accumulator) any {
+				return acc.count
+			})))
+		case internalpb.AggregateOp_Sum:
+			result = append(result, newColumn(name, field.GetFieldID(), sumType(field), collect(groups, i, func(acc *accumulator) any {
+				return acc.sum(sumType(field))
+			})))
+		case internalpb.AggregateOp_Min, internalpb.AggregateOp_Max:
+			result = append(result, newColumn(name, field.GetFieldID(), field.GetDataType(), collect(groups, i, func(acc *accumulator) any {
+				return acc.value
+			})))
+		case internalpb.AggregateOp_Avg:
+			result = append(result, newColumn(name, field.GetFieldID(), schemapb.DataType_Double, collect(groups, i, func(acc *accumulator) any {
+				if acc.count == 0 {
+					return nil
+				}
+				return acc.floatSum / float64(acc.count)
+			})))
+		case internalpb.AggregateOp_CountDistinct:
+			result = append(result, newColumn(name, field.GetFieldID(), schemapb.DataType_Int64, collect(groups, i, func(acc *accumulator) any {
+				return int64(len(acc.distinct))
+			})))
+		}
+	}
+	return result
+}
+
+func (a *Aggregator) groupOf(groupColumns []*schemapb.FieldData, row int) (*group, error) {
+	keys := make([]any, len(groupColumns))
+	for i, column := range groupColumns {
+		value, err := valueAt(column, row)
+		if err != nil {
+			return nil, err
+		}
+		keys[i] = value
+	}
+	return a.getOrCreateGroup(keys), nil
+}
+
+func (a *Aggregator) getOrCreateGroup(keys []any) *group {
+	key := encodeKeys(keys)
+	g, ok := a.groups[key]
+	if !ok {
+		g = &group{
+			keys: keys,
+			accs: make([]*accumulator, len(a.aggregates)),
+		}
+		for i, agg := range a.aggregates {
+			g.accs[i] = &accumulator{}
+			if agg.GetOp() == internalpb.AggregateOp_CountDistinct {
+				g.accs[i].distinct = make(map[any]struct{})
+			}
+		}
+		a.groups[key] = g
+	}
+	return g
+}
+
+// sortedGroups returns the groups ordered by keys. Without group by fields,
+// there is always exactly one group even if there is no row.
+func (a *Aggregator) sortedGroups() []*group {
+	if len(a.groupBy) == 0 {
+		return []*group{a.getOrCreateGroup(nil)}
+	}
+	groups := make([]*group, 0, len(a.groups))
+	for _, g := range a.groups {
+		groups = append(groups, g)
+	}
+	sort.Slice(groups, func(i, j int) bool {
+		for k := range a.groupBy {
+			if c := compareValues(groups[i].keys[k], groups[j].keys[k]); c != 0 {
+				return c < 0
+			}
+		}
+		return false
+	})
+	return groups
+}
+
+func (a *Aggregator) groupColumns(groups []*group) []*schemapb.FieldData {
+	columns := make([]*schemapb.FieldData, 0, len(a.groupBy))
+	for i, field := range a.groupBy {
+		values := make([]any, len(groups))
+		for j, g := range groups {
+			values[j] = g.keys[i]
+		}
+		columns = append(columns, newColumn(field.GetName(), field.GetFieldID(), field.GetDataType(), values))
+	}
+	return columns
+}
+
+func (acc *accumulator) update(op internalpb.AggregateOp, countRows bool, value any) {
+	if value == nil {
+		if countRows {
+			acc.count++
+		}
+		return
+	}
+	switch op {
+	case internalpb.AggregateOp_Count:
+		acc.count++
+	case internalpb.AggregateOp_Sum, internalpb.AggregateOp_Avg:
+		acc.count++
+		switch v := value.(type) {
+		case int64:
+			acc.intSum += v
+			acc.floatSum += float64(v)
+		case float64:
+			acc.floatSum += v
+		}
+	case internalpb.AggregateOp_Min:
+		if acc.value == nil || compareValues(value, acc.value) < 0 {
+			acc.value = value
+		}
+	case internalpb.AggregateOp_Max:
+		if acc.value == nil || compareValues(value, acc.value) > 0 {
+			acc.value = value
+		}
+	case internalpb.AggregateOp_CountDistinct:
+		acc.distinct[value] = struct{}{}
+	}
+}
+
+func (acc *accumulator) merge(op internalpb.AggregateOp, states []*schemapb.FieldData, row int) error {
+	switch op {
+	case internalpb.AggregateOp_Count:
+		acc.count += states[0].GetScalars().GetLongData().GetData()[row]
+	case internalpb.AggregateOp_Sum:
+		value, err := valueAt(states[0], row)
+		if err != nil || value == nil {
+			return err
+		}
+		acc.update(op, false, value)
+	case internalpb.AggregateOp_Min, internalpb.AggregateOp_Max:
+		value, err := valueAt(states[0], row)
+		if err != nil || value == nil {
+			return err
+		}
+		acc.update(op, false, value)
+	case internalpb.AggregateOp_Avg:
+		acc.floatSum += states[0].GetScalars().GetDoubleData().GetData()[row]
+		acc.count += states[1].GetScalars().GetLongData().GetData()[row]
+	case internalpb.AggregateOp_CountDistinct:
+		arrayData := states[0].GetScalars().GetArrayData()
+		values := arrayData.GetData()[row]
+		for i := 0; i < scalarLen(arrayData.GetElementType(), values); i++ {
+			value, err := scalarValueAt(arrayData.GetElementType(), values, i)
+			if err != nil {
+				return err
+			}
+			acc.distinct[value] = struct{}{}
+		}
+	default:
+		return fmt.Errorf("unknown aggregate op %s", op.String())
+	}
+	return nil
+}
+
+// sum returns the sum in dataType, nil if no value is accumulated.
+func (acc *accumulator) sum(dataType schemapb.DataType) any {
+	if acc.count == 0 {
+		return nil
+	}
+	if dataType == schemapb.DataType_Int64 {
+		return acc.intSum
+	}
+	return acc.floatSum
+}
+
+func stateWidth(op internalpb.AggregateOp) int {
+	if op == internalpb.AggregateOp_Avg {
+		return 2
+	}
+	return 1
+}
+
+func sumType(field *schemapb.FieldSchema) schemapb.DataType {
+	if typeutil.IsIntegerType(field.GetDataType()) {
+		return schemapb.DataType_Int64
+	}
+	return schemapb.DataType_Double
+}
+
+func collect(groups []*group, i int, getter func(*accumulator) any) []any {
+	values := make([]any, len(groups))
+	for j, g := range groups {
+		values[j] = getter(g.accs[i])
+	}
+	return values
+}
+
+// encodeKeys encodes group keys into a map key, strings are length prefixed
+// so that different keys never collide.
+func encodeKeys(keys []any) string {
+	var sb strings.Builder
+	for _, key := range keys {
+		switch v := key.(type) {
+		case nil:
+			sb.WriteByte('n')
+		case bool:
+			sb.WriteByte('b')
+			sb.WriteString(strconv.FormatBool(v))
+		case int64:
+			sb.WriteByte('i')
+			sb.WriteString(strconv.FormatInt(v, 10))
+		case float64:
+			sb.WriteByte('f')
+			sb.WriteString(strconv.FormatFloat(v, 'g', -1, 64))
+		case string:
+			sb.WriteByte('s')
+			sb.WriteString(strconv.Itoa(len(v)))
+			sb.WriteByte(':')
+			sb.WriteString(v)
+		}
+		sb.WriteByte(';')
+	}
+	return sb.String()
+}
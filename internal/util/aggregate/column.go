@@ -0,0 +1,216 @@
+package aggregate
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/schemapb"
+)
+
+// valueAt returns the idx-th value of a scalar column, normalized to
+// bool, int64, float64 or string. Nil is returned for null values.
+func valueAt(field *schemapb.FieldData, idx int) (any, error) {
+	if validData := field.GetValidData(); len(validData) != 0 && !validData[idx] {
+		return nil, nil
+	}
+	return scalarValueAt(field.GetType(), field.GetScalars(), idx)
+}
+
+func scalarValueAt(dataType schemapb.DataType, scalars *schemapb.ScalarField, idx int) (any, error) {
+	switch dataType {
+	case schemapb.DataType_Bool:
+		return scalars.GetBoolData().GetData()[idx], nil
+	case schemapb.DataType_Int8, schemapb.DataType_Int16, schemapb.DataType_Int32:
+		return int64(scalars.GetIntData().GetData()[idx]), nil
+	case schemapb.DataType_Int64:
+		return scalars.GetLongData().GetData()[idx], nil
+	case schemapb.DataType_Float:
+		return float64(scalars.GetFloatData().GetData()[idx]), nil
+	case schemapb.DataType_Double:
+		return scalars.GetDoubleData().GetData()[idx], nil
+	case schemapb.DataType_VarChar, schemapb.DataType_String:
+		return scalars.GetStringData().GetData()[idx], nil
+	default:
+		return nil, fmt.Errorf("unsupported data type %s for aggregation", dataType.String())
+	}
+}
+
+func scalarLen(dataType schemapb.DataType, scalars *schemapb.ScalarField) int {
+	switch dataType {
+	case schemapb.DataType_Bool:
+		return len(scalars.GetBoolData().GetData())
+	case schemapb.DataType_Int8, schemapb.DataType_Int16, schemapb.DataType_Int32:
+		return len(scalars.GetIntData().GetData())
+	case schemapb.DataType_Int64:
+		return len(scalars.GetLongData().GetData())
+	case schemapb.DataType_Float:
+		return len(scalars.GetFloatData().GetData())
+	case schemapb.DataType_Double:
+		return len(scalars.GetDoubleData().GetData())
+	case schemapb.DataType_VarChar, schemapb.DataType_String:
+		return len(scalars.GetStringData().GetData())
+	default:
+		return 0
+	}
+}
+
+// newScalarField builds a ScalarField of dataType from normalized values,
+// nil values are stored as zero values.
+func newScalarField(dataType schemapb.DataType, values []any) *schemapb.ScalarField {
+	switch dataType {
+	case schemapb.DataType_Bool:
+		data := make([]bool, len(values))
+		for i, v := range values {
+			if v != nil {
+				data[i] = v.(bool)
+			}
+		}
+		return &schemapb.ScalarField{Data: &schemapb.ScalarField_BoolData{BoolData: &schemapb.BoolArray{Data: data}}}
+	case schemapb.DataType_Int8, schemapb.DataType_Int16, schemapb.DataType_Int32:
+		data := make([]int32, len(values))
+		for i, v := range values {
+			if v != nil {
+				data[i] = int32(v.(int64))
+			}
+		}
+		return &schemapb.ScalarField{Data: &schemapb.ScalarField_IntData{IntData: &schemapb.IntArray{Data: data}}}
+	case schemapb.DataType_Int64:
+		data := make([]int64, len(values))
+		for i, v := range values {
+			if v != nil {
+				data[i] = v.(int64)
+			}
+		}
+		return &schemapb.ScalarField{Data: &schemapb.ScalarField_LongData{LongData: &schemapb.LongArray{Data: data}}}
+	case schemapb.DataType_Float:
+		data := make([]float32, len(values))
+		for i, v := range values {
+			if v != nil {
+				data[i] = float32(v.(float64))
+			}
+		}
+		return &schemapb.ScalarField{Data: &schemapb.ScalarField_FloatData{FloatData: &schemapb.FloatArray{Data: data}}}
+	case schemapb.DataType_Double:
+		data := make([]float64, len(values))
+		for i, v := range values {
+			if v != nil {
+				data[i] = v.(float64)
+			}
+		}
+		return &schemapb.ScalarField{Data: &schemapb.ScalarField_DoubleData{DoubleData: &schemapb.DoubleArray{Data: data}}}
+	default:
+		data := make([]string, len(values))
+		for i, v := range values {
+			if v != nil {
+				data[i] = v.(string)
+			}
+		}
+		return &schemapb.ScalarField{Data: &schemapb.ScalarField_StringData{StringData: &schemapb.StringArray{Data: data}}}
+	}
+}
+
+// newColumn builds a scalar FieldData from normalized values, ValidData is
+// only set when there is a null value.
+func newColumn(name string, fieldID int64, dataType schemapb.DataType, values []any) *schemapb.FieldData {
+	var validData []bool
+	for i, v := range values {
+		if v == nil {
+			if validData == nil {
+				validData = make([]bool, len(values))
+				for j := 0; j < i; j++ {
+					validData[j] = true
+				}
+			}
+			continue
+		}
+		if validData != nil {
+			validData[i] = true
+		}
+	}
+	return &schemapb.FieldData{
+		Type:      dataType,
+		FieldName: name,
+		FieldId:   fieldID,
+		Field: &schemapb.FieldData_Scalars{
+			Scalars: newScalarField(dataType, values),
+		},
+		ValidData: validData,
+	}
+}
+
+// newArrayColumn builds an Array FieldData, each row holds a set of values.
+func newArrayColumn(name string, fieldID int64, elementType schemapb.DataType, rows [][]any) *schemapb.FieldData {
+	data := make([]*schemapb.ScalarField, len(rows))
+	for i, row := range rows {
+		data[i] = newScalarField(elementType, row)
+	}
+	return &schemapb.FieldData{
+		Type:      schemapb.DataType_Array,
+		FieldName: name,
+		FieldId:   fieldID,
+		Field: &schemapb.FieldData_Scalars{
+			Scalars: &schemapb.ScalarField{
+				Data: &schemapb.ScalarField_ArrayData{
+					ArrayData: &schemapb.ArrayArray{
+						Data:        data,
+						ElementType: elementType,
+					},
+				},
+			},
+		},
+	}
+}
+
+// columnLen returns the row count of a column produced by newColumn or newArrayColumn.
+func columnLen(field *schemapb.FieldData) int {
+	if field.GetType() == schemapb.DataType_Array {
+		return len(field.GetScalars().GetArrayData().GetData())
+	}
+	return scalarLen(field.GetType(), field.GetScalars())
+}
+
+// compareValues orders two normalized values of the same type, nil is the smallest.
+func compareValues(a, b any) int {
+	switch {
+	case a == nil && b == nil:
+		return 0
+	case a == nil:
+		return -1
+	case b == nil:
+		return 1
+	}
+	switch av := a.(type) {
+	case bool:
+		bv := b.(bool)
+		switch {
+		case av == bv:
+			return 0
+		case !av:
+			return -1
+		default:
+			return 1
+		}
+	case int64:
+		bv := b.(int64)
+		switch {
+		case av < bv:
+			return -1
+		case av > bv:
+			return 1
+		default:
+			return 0
+		}
+	case float64:
+		bv := b.(float64)
+		switch {
+		case av < bv:
+			return -1
+		case av > bv:
+			return 1
+		default:
+			return 0
+		}
+	default:
+		return strings.Compare(a.(string), b.(string))
+	}
+}
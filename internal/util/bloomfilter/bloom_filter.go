@@ -35,6 +35,7 @@ const (
 	BlockBFName       = "BlockedBloomFilter"
 	BasicBFName       = "BasicBloomFilter"
 	AlwaysTrueBFName  = "AlwaysTrueBloomFilter"
+	XorBFName         = "XorFilter"
 )
 
 const (
@@ -42,12 +43,14 @@ const (
 	AlwaysTrueBF         // empty bloom filter
 	BasicBF
 	BlockedBF
+	XorBF // static xor filter, see xorFilter
 )
 
 var bfNames = map[BFType]string{
 	BasicBF:       BlockBFName,
 	BlockedBF:     BasicBFName,
 	AlwaysTrueBF:  AlwaysTrueBFName,
+	XorBF:         XorBFName,
 	UnsupportedBF: UnsupportedBFName,
 }
 
@@ -63,6 +66,8 @@ func BFTypeFromString(name string) BFType {
 		return BlockedBF
 	case AlwaysTrueBFName:
 		return AlwaysTrueBF
+	case XorBFName:
+		return XorBF
 	default:
 		return UnsupportedBF
 	}
@@ -295,6 +300,8 @@ func NewBloomFilterWithType(capacity uint, fp float64, typeName string) BloomFil
 		return newBlockedBloomFilter(capacity, fp)
 	case BasicBF:
 		return newBasicBloomFilter(capacity, fp)
+	case XorBF:
+		return newXorFilter(capacity, fp)
 	default:
 		log.Info("unsupported bloom filter type, using block bloom filter", zap.String("type", typeName))
 		return newBlockedBloomFilter(capacity, fp)
@@ -317,6 +324,13 @@ func UnmarshalJSON(data []byte, bfType BFType) (BloomFilterInterface, error) {
 			return nil, errors.Wrap(err, "failed to unmarshal blocked bloom filter")
 		}
 		return bf, nil
+	case XorBF:
+		bf := &xorFilter{}
+		err := json.Unmarshal(data, bf)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to unmarshal xor filter")
+		}
+		return bf, nil
 	case AlwaysTrueBF:
 		return AlwaysTrueBloomFilter, nil
 	default:
@@ -328,7 +342,7 @@ func Locations(data []byte, k uint, bfType BFType) []uint64 {
 	switch bfType {
 	case BasicBF:
 		return bloom.Locations(data, k)
-	case BlockedBF:
+	case BlockedBF, XorBF:
 		return []uint64{xxh3.Hash(data)}
 	case AlwaysTrueBF:
 		return nil
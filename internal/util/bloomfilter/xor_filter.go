@@ -0,0 +1,349 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bloomfilter
+
+import (
+	"encoding/binary"
+	"math/bits"
+	"slices"
+	"sync"
+
+	"github.com/cockroachdb/errors"
+	"github.com/zeebo/xxh3"
+
+	"github.com/milvus-io/milvus/internal/json"
+)
+
+// xorFilter is a static xor filter (Graf and Lemire, "Xor Filters: Faster and Smaller Than Bloom
+// and Cuckoo Filters"). The 8-bit filter takes about 9.84 bits per key with a false positive rate of
+// about 0.39%, the 16-bit one is used for lower rates and takes about 19.68 bits per key.
+//
+// Xor filters cannot be updated once built, so the xxh3 hashes of the added keys are buffered and the
+// filter is built on the first test or marshal, the buffered keys are released then. Adding keys to a
+// built or deserialized filter panics.
+type xorFilter struct {
+	mu    sync.RWMutex
+	keys  []uint64
+	built bool
+
+	seed            uint64
+	blockLength     uint32
+	fingerprintBits uint8
+	// fingerprints of 16 bits are stored in little endian
+	fingerprints []byte
+}
+
+type xorFilterJSON struct {
+	Seed            uint64 `json:"seed"`
+	BlockLength     uint32 `json:"block_length"`
+	FingerprintBits uint8  `json:"fingerprint_bits,omitempty"`
+	Fingerprints    []byte `json:"fingerprints"`
+}
+
+// xorFilter8FalsePositive is the false positive rate of the 8-bit xor filter, 1/256
+const xorFilter8FalsePositive = 0.0039
+
+func newXorFilter(capacity uint, fp float64) *xorFilter {
+	fingerprintBits := uint8(8)
+	if fp < xorFilter8FalsePositive {
+		fingerprintBits = 16
+	}
+	return &xorFilter{
+		keys:            make([]uint64, 0, capacity),
+		fingerprintBits: fingerprintBits,
+	}
+}
+
+func (b *xorFilter) Type() BFType {
+	return XorBF
+}
+
+func (b *xorFilter) Cap() uint {
+	b.build()
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return uint(len(b.fingerprints)) * 8
+}
+
+func (b *xorFilter) K() uint {
+	return 1
+}
+
+func (b *xorFilter) Add(data []byte) {
+	b.addHash(xxh3.Hash(data))
+}
+
+func (b *xorFilter) AddString(data string) {
+	b.addHash(xxh3.HashString(data))
+}
+
+func (b *xorFilter) addHash(h uint64) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.built {
+		panic("xor filter is immutable once built")
+	}
+	b.keys = append(b.keys, h)
+}
+
+func (b *xorFilter) Test(data []byte) bool {
+	return b.contains(xxh3.Hash(data))
+}
+
+func (b *xorFilter) TestString(data string) bool {
+	return b.contains(xxh3.HashString(data))
+}
+
+func (b *xorFilter) TestLocations(locs []uint64) bool {
+	// same as block bf, the location is the xxh3 hash of the key
+	if len(locs) != 1 {
+		return true
+	}
+	return b.contains(locs[0])
+}
+
+func (b *xorFilter) BatchTestLocations(locs [][]uint64, hits []bool) []bool {
+	ret := make([]bool, len(locs))
+	for i := range hits {
+		if !hits[i] {
+			if len(locs[i]) != 1 {
+				ret[i] = true
+				continue
+			}
+			ret[i] = b.contains(locs[i][0])
+		}
+	}
+	return ret
+}
+
+func (b *xorFilter) MarshalJSON() ([]byte, error) {
+	b.build()
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return json.Marshal(&xorFilterJSON{
+		Seed:            b.seed,
+		BlockLength:     b.blockLength,
+		FingerprintBits: b.fingerprintBits,
+		Fingerprints:    b.fingerprints,
+	})
+}
+
+func (b *xorFilter) UnmarshalJSON(data []byte) error {
+	inner := &xorFilterJSON{}
+	if err := json.Unmarshal(data, inner); err != nil {
+		return err
+	}
+	// filters serialized before the 16-bit fingerprints were introduced are 8-bit
+	fingerprintBits := inner.FingerprintBits
+	if fingerprintBits == 0 {
+		fingerprintBits = 8
+	}
+	if fingerprintBits != 8 && fingerprintBits != 16 {
+		return errors.Errorf("invalid xor filter fingerprint bits %d", fingerprintBits)
+	}
+	if len(inner.Fingerprints) != int(inner.BlockLength)*3*int(fingerprintBits/8) {
+		return errors.Errorf("xor filter fingerprints size %d mismatches block length %d", len(inner.Fingerprints), inner.BlockLength)
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.keys = nil
+	b.seed = inner.Seed
+	b.blockLength = inner.BlockLength
+	b.fingerprintBits = fingerprintBits
+	b.fingerprints = inner.Fingerprints
+	b.built = true
+	return nil
+}
+
+func (b *xorFilter) contains(key uint64) bool {
+	b.build()
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	if b.blockLength == 0 {
+		return false
+	}
+	h := mixsplit(key, b.seed)
+	h0, h1, h2 := b.positions(h)
+	return b.fingerprintOf(h) == b.fingerprintAt(h0)^b.fingerprintAt(h1)^b.fingerprintAt(h2)
+}
+
+func (b *xorFilter) fingerprintOf(h uint64) uint16 {
+	f := fingerprint(h)
+	if b.fingerprintBits == 16 {
+		return uint16(f)
+	}
+	return uint16(uint8(f))
+}
+
+func (b *xorFilter) fingerprintAt(i uint32) uint16 {
+	if b.fingerprintBits == 16 {
+		return binary.LittleEndian.Uint16(b.fingerprints[2*i:])
+	}
+	return uint16(b.fingerprints[i])
+}
+
+func (b *xorFilter) setFingerprint(i uint32, f uint16) {
+	if b.fingerprintBits == 16 {
+		binary.LittleEndian.PutUint16(b.fingerprints[2*i:], f)
+		return
+	}
+	b.fingerprints[i] = uint8(f)
+}
+
+func (b *xorFilter) positions(h uint64) (uint32, uint32, uint32) {
+	h0 := reduce(uint32(h), b.blockLength)
+	h1 := reduce(uint32(bits.RotateLeft64(h, 21)), b.blockLength) + b.blockLength
+	h2 := reduce(uint32(bits.RotateLeft64(h, 42)), b.blockLength) + 2*b.blockLength
+	return h0, h1, h2
+}
+
+func (b *xorFilter) build() {
+	b.mu.RLock()
+	built := b.built
+	b.mu.RUnlock()
+	if built {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.built {
+		return
+	}
+	// the buffered keys are useless once the filter is built
+	defer func() {
+		b.keys = nil
+		b.built = true
+	}()
+	if b.fingerprintBits == 0 {
+		b.fingerprintBits = 8
+	}
+
+	// the construction fails on duplicated keys
+	slices.Sort(b.keys)
+	b.keys = slices.Compact(b.keys)
+
+	size := len(b.keys)
+	if size == 0 {
+		b.blockLength = 0
+		b.fingerprints = nil
+		return
+	}
+	capacity := 32 + uint32(1.23*float64(size))
+	capacity = capacity / 3 * 3
+	b.blockLength = capacity / 3
+	b.fingerprints = make([]byte, capacity*uint32(b.fingerprintBits/8))
+
+	type xorSet struct {
+		xorMask uint64
+		count   uint32
+	}
+	type keyIndex struct {
+		hash  uint64
+		index uint32
+	}
+	sets := make([]xorSet, capacity)
+	queue := make([]keyIndex, 0, capacity)
+	stack := make([]keyIndex, 0, size)
+
+	rngCounter := uint64(1)
+	for {
+		b.seed = splitmix64(&rngCounter)
+		clear(sets)
+		for _, key := range b.keys {
+			h := mixsplit(key, b.seed)
+			h0, h1, h2 := b.positions(h)
+			for _, idx := range []uint32{h0, h1, h2} {
+				sets[idx].xorMask ^= h
+				sets[idx].count++
+			}
+		}
+
+		// peel the sets holding a single key until no one left
+		queue = queue[:0]
+		stack = stack[:0]
+		for i := range sets {
+			if sets[i].count == 1 {
+				queue = append(queue, keyIndex{hash: sets[i].xorMask, index: uint32(i)})
+			}
+		}
+		for len(queue) > 0 {
+			ki := queue[len(queue)-1]
+			queue = queue[:len(queue)-1]
+			if sets[ki.index].count == 0 {
+				continue
+			}
+			stack = append(stack, ki)
+			h0, h1, h2 := b.positions(ki.hash)
+			for _, idx := range []uint32{h0, h1, h2} {
+				sets[idx].xorMask ^= ki.hash
+				sets[idx].count--
+				if sets[idx].count == 1 {
+					queue = append(queue, keyIndex{hash: sets[idx].xorMask, index: idx})
+				}
+			}
+		}
+		if len(stack) == size {
+			break
+		}
+	}
+
+	// assign fingerprints in the reverse order of peeling
+	for i := len(stack) - 1; i >= 0; i-- {
+		ki := stack[i]
+		h0, h1, h2 := b.positions(ki.hash)
+		f := b.fingerprintOf(ki.hash)
+		switch ki.index {
+		case h0:
+			f ^= b.fingerprintAt(h1) ^ b.fingerprintAt(h2)
+		case h1:
+			f ^= b.fingerprintAt(h0) ^ b.fingerprintAt(h2)
+		default:
+			f ^= b.fingerprintAt(h0) ^ b.fingerprintAt(h1)
+		}
+		b.setFingerprint(ki.index, f)
+	}
+}
+
+func murmur64(h uint64) uint64 {
+	h ^= h >> 33
+	h *= 0xff51afd7ed558ccd
+	h ^= h >> 33
+	h *= 0xc4ceb9fe1a85ec53
+	h ^= h >> 33
+	return h
+}
+
+func splitmix64(seed *uint64) uint64 {
+	*seed += 0x9e3779b97f4a7c15
+	z := *seed
+	z = (z ^ (z >> 30)) * 0xbf58476d1ce4e5b9
+	z = (z ^ (z >> 27)) * 0x94d049bb133111eb
+	return z ^ (z >> 31)
+}
+
+func mixsplit(key, seed uint64) uint64 {
+	return murmur64(key + seed)
+}
+
+func fingerprint(h uint64) uint64 {
+	return h ^ (h >> 32)
+}
+
+// reduce maps x into [0, n) without division, see https://lemire.me/blog/2016/06/27/a-fast-alternative-to-the-modulo-reduction/
+func reduce(x, n uint32) uint32 {
+	return uint32((uint64(x) * uint64(n)) >> 32)
+}
@@ -0,0 +1,192 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bloomfilter
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/zeebo/xxh3"
+
+	"github.com/milvus-io/milvus/internal/json"
+)
+
+func TestXorFilter(t *testing.T) {
+	capacity := 100000
+	bf := NewBloomFilterWithType(uint(capacity), 0.001, XorBFName)
+	assert.Equal(t, XorBF, bf.Type())
+	assert.Equal(t, XorBFName, bf.Type().String())
+	assert.EqualValues(t, 1, bf.K())
+
+	for i := 0; i < capacity; i++ {
+		bf.Add([]byte(fmt.Sprintf("key%d", i)))
+	}
+	// duplicated keys
+	bf.AddString("key0")
+	bf.AddString("str")
+
+	for i := 0; i < capacity; i++ {
+		key := []byte(fmt.Sprintf("key%d", i))
+		assert.True(t, bf.Test(key))
+		assert.True(t, bf.TestLocations(Locations(key, bf.K(), XorBF)))
+	}
+	assert.True(t, bf.TestString("str"))
+	assert.True(t, bf.TestLocations([]uint64{1, 2}))
+	// 16-bit fingerprints for the fp lower than 1/256
+	assert.Greater(t, bf.Cap(), uint(capacity*16))
+	assert.Less(t, bf.Cap(), uint(capacity*20))
+	assert.Nil(t, bf.(*xorFilter).keys)
+
+	fp := 0
+	for i := 0; i < capacity; i++ {
+		if bf.Test([]byte(fmt.Sprintf("absent%d", i))) {
+			fp++
+		}
+	}
+	assert.Less(t, float64(fp)/float64(capacity), 0.001)
+
+	locs := [][]uint64{
+		{xxh3.HashString("key1")},
+		{xxh3.HashString("key2")},
+		{1, 2},
+	}
+	ret := bf.BatchTestLocations(locs, []bool{false, true, false})
+	assert.Equal(t, []bool{true, false, true}, ret)
+
+	// the filter is immutable once built
+	assert.Panics(t, func() { bf.AddString("late") })
+
+	data, err := bf.MarshalJSON()
+	assert.NoError(t, err)
+	bf2, err := UnmarshalJSON(data, XorBF)
+	assert.NoError(t, err)
+	assert.Equal(t, bf.Type(), bf2.Type())
+	assert.Equal(t, bf.Cap(), bf2.Cap())
+	for i := 0; i < capacity; i++ {
+		assert.True(t, bf2.Test([]byte(fmt.Sprintf("key%d", i))))
+	}
+	assert.Panics(t, func() { bf2.AddString("late") })
+
+	_, err = UnmarshalJSON([]byte("{"), XorBF)
+	assert.Error(t, err)
+	_, err = UnmarshalJSON([]byte(`{"seed":1,"block_length":2,"fingerprint_bits":16,"fingerprints":"AAAAAAAA"}`), XorBF)
+	assert.Error(t, err)
+	_, err = UnmarshalJSON([]byte(`{"seed":1,"block_length":2,"fingerprint_bits":4,"fingerprints":"AAAAAAAA"}`), XorBF)
+	assert.Error(t, err)
+}
+
+func TestXorFilter_8Bit(t *testing.T) {
+	capacity := 10000
+	bf := NewBloomFilterWithType(uint(capacity), 0.005, XorBFName)
+	for i := 0; i < capacity; i++ {
+		bf.Add([]byte(fmt.Sprintf("key%d", i)))
+	}
+	for i := 0; i < capacity; i++ {
+		assert.True(t, bf.Test([]byte(fmt.Sprintf("key%d", i))))
+	}
+	assert.Less(t, bf.Cap(), uint(capacity*10))
+
+	fp := 0
+	for i := 0; i < capacity; i++ {
+		if bf.Test([]byte(fmt.Sprintf("absent%d", i))) {
+			fp++
+		}
+	}
+	assert.Less(t, float64(fp)/float64(capacity), 0.01)
+
+	// the filters serialized without the fingerprint bits are 8-bit
+	data, err := json.Marshal(&xorFilterJSON{
+		Seed:         bf.(*xorFilter).seed,
+		BlockLength:  bf.(*xorFilter).blockLength,
+		Fingerprints: bf.(*xorFilter).fingerprints,
+	})
+	assert.NoError(t, err)
+	bf2, err := UnmarshalJSON(data, XorBF)
+	assert.NoError(t, err)
+	for i := 0; i < capacity; i++ {
+		assert.True(t, bf2.Test([]byte(fmt.Sprintf("key%d", i))))
+	}
+}
+
+func TestXorFilter_Concurrent(t *testing.T) {
+	bf := newXorFilter(1000, 0.001)
+	for i := 0; i < 1000; i++ {
+		bf.AddString(fmt.Sprintf("key%d", i))
+	}
+	wg := sync.WaitGroup{}
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 1000; j++ {
+				assert.True(t, bf.TestString(fmt.Sprintf("key%d", j)))
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestXorFilter_Empty(t *testing.T) {
+	bf := newXorFilter(0, 0.001)
+	assert.False(t, bf.TestString("key"))
+
+	data, err := bf.MarshalJSON()
+	assert.NoError(t, err)
+	bf2, err := UnmarshalJSON(data, XorBF)
+	assert.NoError(t, err)
+	assert.False(t, bf2.TestString("key"))
+}
+
+// BenchmarkFilterMemoryAndFPR compares the memory per key and the false positive rate of the
+// supported filter types, run with `go test -run=^$ -bench=BenchmarkFilterMemoryAndFPR`.
+func BenchmarkFilterMemoryAndFPR(b *testing.B) {
+	const (
+		keyNum   = 1000000
+		probeNum = 1000000
+		fp       = 0.001
+	)
+	keys := make([][]byte, keyNum)
+	for i := range keys {
+		keys[i] = []byte(fmt.Sprintf("key%d", i))
+	}
+
+	for _, bfType := range []string{BasicBFName, BlockBFName, XorBFName} {
+		b.Run(bfType, func(b *testing.B) {
+			var bf BloomFilterInterface
+			for i := 0; i < b.N; i++ {
+				bf = NewBloomFilterWithType(keyNum, fp, bfType)
+				for _, key := range keys {
+					bf.Add(key)
+				}
+				// the xor filter is built on the first test
+				bf.TestString("")
+			}
+			b.StopTimer()
+
+			falsePositive := 0
+			for i := 0; i < probeNum; i++ {
+				if bf.TestString(fmt.Sprintf("probe%d", i)) {
+					falsePositive++
+				}
+			}
+			b.ReportMetric(float64(bf.Cap())/keyNum, "bits/key")
+			b.ReportMetric(float64(falsePositive)/probeNum, "fpr")
+		})
+	}
+}
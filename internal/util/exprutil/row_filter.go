@@ -0,0 +1,418 @@
+package exprutil
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strings"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/schemapb"
+	"github.com/milvus-io/milvus/internal/proto/planpb"
+	"github.com/milvus-io/milvus/pkg/util/merr"
+)
+
+// RowFilter returns true if the row, which is keyed by field id, matches the expression.
+type RowFilter func(row map[int64]any) bool
+
+// NewRowFilter compiles the parsed filter expression into a RowFilter, which evaluates
+// the expression row by row without segcore. Only comparisons, term, range, arithmetic range
+// and logical expressions between a scalar field and constants are supported. Predicates on
+// json or array fields and comparisons between fields are rejected, since their semantics
+// could not be kept the same as segcore. Null values never match a predicate.
+func NewRowFilter(expr *planpb.Expr) (RowFilter, error) {
+	if expr == nil {
+		return func(map[int64]any) bool { return true }, nil
+	}
+	switch e := expr.GetExpr().(type) {
+	case *planpb.Expr_AlwaysTrueExpr:
+		return func(map[int64]any) bool { return true }, nil
+	case *planpb.Expr_BinaryExpr:
+		left, err := NewRowFilter(e.BinaryExpr.GetLeft())
+		if err != nil {
+			return nil, err
+		}
+		right, err := NewRowFilter(e.BinaryExpr.GetRight())
+		if err != nil {
+			return nil, err
+		}
+		switch e.BinaryExpr.GetOp() {
+		case planpb.BinaryExpr_LogicalAnd:
+			return func(row map[int64]any) bool { return left(row) && right(row) }, nil
+		case planpb.BinaryExpr_LogicalOr:
+			return func(row map[int64]any) bool { return left(row) || right(row) }, nil
+		}
+		return nil, unsupportedErr(e.BinaryExpr.GetOp().String())
+	case *planpb.Expr_UnaryExpr:
+		if e.UnaryExpr.GetOp() != planpb.UnaryExpr_Not {
+			return nil, unsupportedErr(e.UnaryExpr.GetOp().String())
+		}
+		child, err := NewRowFilter(e.UnaryExpr.GetChild())
+		if err != nil {
+			return nil, err
+		}
+		return func(row map[int64]any) bool { return !child(row) }, nil
+	case *planpb.Expr_UnaryRangeExpr:
+		return newUnaryRangeFilter(e.UnaryRangeExpr)
+	case *planpb.Expr_BinaryRangeExpr:
+		return newBinaryRangeFilter(e.BinaryRangeExpr)
+	case *planpb.Expr_TermExpr:
+		return newTermFilter(e.TermExpr)
+	case *planpb.Expr_CompareExpr:
+		return nil, unsupportedErr("comparison between fields")
+	case *planpb.Expr_BinaryArithOpEvalRangeExpr:
+		return newArithRangeFilter(e.BinaryArithOpEvalRangeExpr)
+	default:
+		return nil, unsupportedErr(fmt.Sprintf("%T", e))
+	}
+}
+
+func unsupportedErr(expr string) error {
+	return merr.WrapErrParameterInvalidMsg("expression %s is not supported by row filter", expr)
+}
+
+func newUnaryRangeFilter(expr *planpb.UnaryRangeExpr) (RowFilter, error) {
+	getter, err := newColumnGetter(expr.GetColumnInfo())
+	if err != nil {
+		return nil, err
+	}
+	value, err := genericValue(expr.GetValue())
+	if err != nil {
+		return nil, err
+	}
+	switch expr.GetOp() {
+	case planpb.OpType_PrefixMatch, planpb.OpType_PostfixMatch, planpb.OpType_Match:
+		pattern, ok := value.(string)
+		if !ok {
+			return nil, unsupportedErr(fmt.Sprintf("%s on non-string value", expr.GetOp().String()))
+		}
+		match, err := newStringMatcher(expr.GetOp(), pattern)
+		if err != nil {
+			return nil, err
+		}
+		return func(row map[int64]any) bool {
+			s, ok := getter(row).(string)
+			return ok && match(s)
+		}, nil
+	}
+	cmpOp, err := newCompareOp(expr.GetOp())
+	if err != nil {
+		return nil, err
+	}
+	return func(row map[int64]any) bool {
+		c, ok := compareValues(getter(row), value)
+		return ok && cmpOp(c)
+	}, nil
+}
+
+func newBinaryRangeFilter(expr *planpb.BinaryRangeExpr) (RowFilter, error) {
+	getter, err := newColumnGetter(expr.GetColumnInfo())
+	if err != nil {
+		return nil, err
+	}
+	lower, err := genericValue(expr.GetLowerValue())
+	if err != nil {
+		return nil, err
+	}
+	upper, err := genericValue(expr.GetUpperValue())
+	if err != nil {
+		return nil, err
+	}
+	lowerInclusive, upperInclusive := expr.GetLowerInclusive(), expr.GetUpperInclusive()
+	return func(row map[int64]any) bool {
+		v := getter(row)
+		c, ok := compareValues(v, lower)
+		if !ok || c < 0 || (c == 0 && !lowerInclusive) {
+			return false
+		}
+		c, ok = compareValues(v, upper)
+		if !ok || c > 0 || (c == 0 && !upperInclusive) {
+			return false
+		}
+		return true
+	}, nil
+}
+
+func newTermFilter(expr *planpb.TermExpr) (RowFilter, error) {
+	if expr.GetIsInField() {
+		return nil, unsupportedErr("term expression in field")
+	}
+	getter, err := newColumnGetter(expr.GetColumnInfo())
+	if err != nil {
+		return nil, err
+	}
+	values := make([]any, 0, len(expr.GetValues()))
+	for _, v := range expr.GetValues() {
+		value, err := genericValue(v)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, value)
+	}
+	return func(row map[int64]any) bool {
+		v := getter(row)
+		for _, value := range values {
+			if c, ok := compareValues(v, value); ok && c == 0 {
+				return true
+			}
+		}
+		return false
+	}, nil
+}
+
+func newArithRangeFilter(expr *planpb.BinaryArithOpEvalRangeExpr) (RowFilter, error) {
+	getter, err := newColumnGetter(expr.GetColumnInfo())
+	if err != nil {
+		return nil, err
+	}
+	operand, err := genericValue(expr.GetRightOperand())
+	if err != nil {
+		return nil, err
+	}
+	value, err := genericValue(expr.GetValue())
+	if err != nil {
+		return nil, err
+	}
+	cmpOp, err := newCompareOp(expr.GetOp())
+	if err != nil {
+		return nil, err
+	}
+	arithOp := expr.GetArithOp()
+	switch arithOp {
+	case planpb.ArithOpType_Add, planpb.ArithOpType_Sub, planpb.ArithOpType_Mul,
+		planpb.ArithOpType_Div, planpb.ArithOpType_Mod:
+	default:
+		return nil, unsupportedErr(arithOp.String())
+	}
+	return func(row map[int64]any) bool {
+		result, ok := evalArith(arithOp, getter(row), operand)
+		if !ok {
+			return false
+		}
+		c, ok := compareValues(result, value)
+		return ok && cmpOp(c)
+	}, nil
+}
+
+func evalArith(op planpb.ArithOpType, left, right any) (any, bool) {
+	li, lok := left.(int64)
+	ri, rok := right.(int64)
+	if lok && rok {
+		switch op {
+		case planpb.ArithOpType_Add:
+			return li + ri, true
+		case planpb.ArithOpType_Sub:
+			return li - ri, true
+		case planpb.ArithOpType_Mul:
+			return li * ri, true
+		case planpb.ArithOpType_Div:
+			if ri == 0 {
+				return nil, false
+			}
+			return li / ri, true
+		case planpb.ArithOpType_Mod:
+			if ri == 0 {
+				return nil, false
+			}
+			return li % ri, true
+		}
+		return nil, false
+	}
+	lf, lok := toFloat(left)
+	rf, rok := toFloat(right)
+	if !lok || !rok {
+		return nil, false
+	}
+	switch op {
+	case planpb.ArithOpType_Add:
+		return lf + rf, true
+	case planpb.ArithOpType_Sub:
+		return lf - rf, true
+	case planpb.ArithOpType_Mul:
+		return lf * rf, true
+	case planpb.ArithOpType_Div:
+		if rf == 0 {
+			return nil, false
+		}
+		return lf / rf, true
+	case planpb.ArithOpType_Mod:
+		if rf == 0 {
+			return nil, false
+		}
+		return math.Mod(lf, rf), true
+	}
+	return nil, false
+}
+
+func newCompareOp(op planpb.OpType) (func(c int) bool, error) {
+	switch op {
+	case planpb.OpType_GreaterThan:
+		return func(c int) bool { return c > 0 }, nil
+	case planpb.OpType_GreaterEqual:
+		return func(c int) bool { return c >= 0 }, nil
+	case planpb.OpType_LessThan:
+		return func(c int) bool { return c < 0 }, nil
+	case planpb.OpType_LessEqual:
+		return func(c int) bool { return c <= 0 }, nil
+	case planpb.OpType_Equal:
+		return func(c int) bool { return c == 0 }, nil
+	case planpb.OpType_NotEqual:
+		return func(c int) bool { return c != 0 }, nil
+	default:
+		return nil, unsupportedErr(op.String())
+	}
+}
+
+func newStringMatcher(op planpb.OpType, pattern string) (func(s string) bool, error) {
+	switch op {
+	case planpb.OpType_PrefixMatch:
+		return func(s string) bool { return strings.HasPrefix(s, pattern) }, nil
+	case planpb.OpType_PostfixMatch:
+		return func(s string) bool { return strings.HasSuffix(s, pattern) }, nil
+	default:
+		re, err := regexp.Compile(likeToRegexp(pattern))
+		if err != nil {
+			return nil, merr.WrapErrParameterInvalidMsg("invalid like pattern %s: %s", pattern, err.Error())
+		}
+		return re.MatchString, nil
+	}
+}
+
+// likeToRegexp translates the pattern of like, `%` matches any characters and `_` matches
+// a single character, both of them can be escaped by backslash.
+func likeToRegexp(pattern string) string {
+	var sb strings.Builder
+	sb.WriteString("^")
+	escaped := false
+	for _, r := range pattern {
+		switch {
+		case escaped:
+			sb.WriteString(regexp.QuoteMeta(string(r)))
+			escaped = false
+		case r == '\\':
+			escaped = true
+		case r == '%':
+			sb.WriteString("(?s:.*)")
+		case r == '_':
+			sb.WriteString("(?s:.)")
+		default:
+			sb.WriteString(regexp.QuoteMeta(string(r)))
+		}
+	}
+	sb.WriteString("$")
+	return sb.String()
+}
+
+type columnGetter func(row map[int64]any) any
+
+// newColumnGetter returns the normalized value of the column, integers are returned as int64,
+// floating numbers as float64, nil is returned if the value is null.
+func newColumnGetter(info *planpb.ColumnInfo) (columnGetter, error) {
+	fieldID := info.GetFieldId()
+	switch info.GetDataType() {
+	case schemapb.DataType_Bool, schemapb.DataType_Int8, schemapb.DataType_Int16, schemapb.DataType_Int32,
+		schemapb.DataType_Int64, schemapb.DataType_Float, schemapb.DataType_Double,
+		schemapb.DataType_VarChar, schemapb.DataType_String:
+		return func(row map[int64]any) any {
+			return normalize(row[fieldID])
+		}, nil
+	default:
+		return nil, unsupportedErr(fmt.Sprintf("predicate on %s field", info.GetDataType().String()))
+	}
+}
+
+func normalize(v any) any {
+	switch v := v.(type) {
+	case bool, string, int64, float64:
+		return v
+	case int8:
+		return int64(v)
+	case int16:
+		return int64(v)
+	case int32:
+		return int64(v)
+	case int:
+		return int64(v)
+	case float32:
+		return float64(v)
+	default:
+		return nil
+	}
+}
+
+func genericValue(v *planpb.GenericValue) (any, error) {
+	switch val := v.GetVal().(type) {
+	case *planpb.GenericValue_BoolVal:
+		return val.BoolVal, nil
+	case *planpb.GenericValue_Int64Val:
+		return val.Int64Val, nil
+	case *planpb.GenericValue_FloatVal:
+		return val.FloatVal, nil
+	case *planpb.GenericValue_StringVal:
+		return val.StringVal, nil
+	default:
+		return nil, unsupportedErr(fmt.Sprintf("%T value", val))
+	}
+}
+
+func toFloat(v any) (float64, bool) {
+	switch v := v.(type) {
+	case int64:
+		return float64(v), true
+	case float64:
+		return v, true
+	default:
+		return 0, false
+	}
+}
+
+// compareValues compares two normalized values, false is returned if they are not comparable.
+func compareValues(a, b any) (int, bool) {
+	if a == nil || b == nil {
+		return 0, false
+	}
+	switch av := a.(type) {
+	case bool:
+		bv, ok := b.(bool)
+		if !ok {
+			return 0, false
+		}
+		switch {
+		case av == bv:
+			return 0, true
+		case !av:
+			return -1, true
+		default:
+			return 1, true
+		}
+	case string:
+		bv, ok := b.(string)
+		if !ok {
+			return 0, false
+		}
+		return strings.Compare(av, bv), true
+	case int64:
+		if bv, ok := b.(int64); ok {
+			switch {
+			case av < bv:
+				return -1, true
+			case av > bv:
+				return 1, true
+			default:
+				return 0, true
+			}
+		}
+	}
+	af, aok := toFloat(a)
+	bf, bok := toFloat(b)
+	if !aok || !bok {
+		return 0, false
+	}
+	switch {
+	case af < bf:
+		return -1, true
+	case af > bf:
+		return 1, true
+	default:
+		return 0, true
+	}
+}
@@ -0,0 +1,101 @@
+package exprutil
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
+	"github.com/milvus-io/milvus-proto/go-api/v2/schemapb"
+	"github.com/milvus-io/milvus/internal/parser/planparserv2"
+	"github.com/milvus-io/milvus/internal/proto/planpb"
+	"github.com/milvus-io/milvus/pkg/util/typeutil"
+)
+
+func TestRowFilter(t *testing.T) {
+	schema := &schemapb.CollectionSchema{
+		Name:               "TestRowFilter",
+		EnableDynamicField: true,
+		Fields: []*schemapb.FieldSchema{
+			{FieldID: 100, Name: "pk", DataType: schemapb.DataType_Int64, IsPrimaryKey: true},
+			{FieldID: 101, Name: "age", DataType: schemapb.DataType_Int32},
+			{FieldID: 102, Name: "score", DataType: schemapb.DataType_Double},
+			{FieldID: 103, Name: "name", DataType: schemapb.DataType_VarChar, TypeParams: []*commonpb.KeyValuePair{{Key: "max_length", Value: "64"}}},
+			{FieldID: 104, Name: "valid", DataType: schemapb.DataType_Bool},
+			{FieldID: 105, Name: "extra", DataType: schemapb.DataType_JSON},
+			{FieldID: 106, Name: "vec", DataType: schemapb.DataType_FloatVector, TypeParams: []*commonpb.KeyValuePair{{Key: "dim", Value: "2"}}},
+			{FieldID: 107, Name: "$meta", DataType: schemapb.DataType_JSON, IsDynamic: true},
+			{FieldID: 108, Name: "limit", DataType: schemapb.DataType_Int64, Nullable: true},
+			{FieldID: 109, Name: "arr", DataType: schemapb.DataType_Array, ElementType: schemapb.DataType_Int64, TypeParams: []*commonpb.KeyValuePair{{Key: "max_capacity", Value: "4"}}},
+		},
+	}
+	schemaHelper, err := typeutil.CreateSchemaHelper(schema)
+	require.NoError(t, err)
+
+	rows := []map[int64]any{
+		{100: int64(1), 101: int32(18), 102: float64(60.5), 103: "alice", 104: true, 105: []byte(`{"city": "beijing", "tags": [1, 2]}`), 107: []byte(`{"level": 3}`), 108: int64(20)},
+		{100: int64(2), 101: int32(30), 102: float64(90), 103: "bob", 104: false, 105: []byte(`{"city": "shanghai", "tags": [3]}`), 107: []byte(`{}`), 108: nil},
+		{100: int64(3), 101: int32(45), 102: float64(75), 103: "carol_1", 104: true, 105: []byte(`{}`), 107: []byte(`{"level": 5}`), 108: int64(40)},
+	}
+
+	type testCase struct {
+		expr     string
+		expected []int64
+	}
+	cases := []testCase{
+		{"", []int64{1, 2, 3}},
+		{"pk > 1", []int64{2, 3}},
+		{"age >= 30 && score < 80", []int64{3}},
+		{"age < 20 || name == \"bob\"", []int64{1, 2}},
+		{"not (age < 20)", []int64{2, 3}},
+		{"20 < age <= 45", []int64{2, 3}},
+		{"pk in [1, 3]", []int64{1, 3}},
+		{"pk not in [1, 3]", []int64{2}},
+		{"name like \"al%\"", []int64{1}},
+		{"name like \"%o%\"", []int64{2, 3}},
+		{"name like \"b_b\"", []int64{2}},
+		{"valid == true", []int64{1, 3}},
+		{"age + 10 == 28", []int64{1}},
+		{"age % 15 == 0", []int64{2, 3}},
+		{"limit > 10", []int64{1, 3}},
+		{"score > 70.5", []int64{2, 3}},
+	}
+	for _, c := range cases {
+		t.Run(c.expr, func(t *testing.T) {
+			var expr *planpb.Expr
+			if c.expr != "" {
+				expr, err = planparserv2.ParseExpr(schemaHelper, c.expr, nil)
+				require.NoError(t, err)
+			}
+			filter, err := NewRowFilter(expr)
+			require.NoError(t, err)
+			matched := make([]int64, 0)
+			for _, row := range rows {
+				if filter(row) {
+					matched = append(matched, row[100].(int64))
+				}
+			}
+			assert.ElementsMatch(t, c.expected, matched)
+		})
+	}
+
+	t.Run("unsupported", func(t *testing.T) {
+		for _, exprStr := range []string{
+			"json_contains(extra[\"tags\"], 1)",
+			"exists extra[\"city\"]",
+			"extra[\"city\"] == \"shanghai\"",
+			"extra[\"tags\"][0] >= 2",
+			"level > 4",
+			"arr[0] > 1",
+			"array_length(arr) == 1",
+			"score > age",
+			"age < 20 || score > age",
+		} {
+			expr, err := planparserv2.ParseExpr(schemaHelper, exprStr, nil)
+			require.NoError(t, err)
+			_, err = NewRowFilter(expr)
+			assert.Error(t, err, exprStr)
+		}
+	})
+}
@@ -0,0 +1,96 @@
+/*
+ * # Licensed to the LF AI & Data foundation under one
+ * # or more contributor license agreements. See the NOTICE file
+ * # distributed with this work for additional information
+ * # regarding copyright ownership. The ASF licenses this file
+ * # to you under the Apache License, Version 2.0 (the
+ * # "License"); you may not use this file except in compliance
+ * # with the License. You may obtain a copy of the License at
+ * #
+ * #     http://www.apache.org/licenses/LICENSE-2.0
+ * #
+ * # Unless required by applicable law or agreed to in writing, software
+ * # distributed under the License is distributed on an "AS IS" BASIS,
+ * # WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * # See the License for the specific language governing permissions and
+ * # limitations under the License.
+ */
+
+package function
+
+import (
+	"context"
+	"sort"
+)
+
+const (
+	openAIProvider = "openai"
+	teiProvider    = "tei"
+)
+
+// embeddingProvider converts a batch of texts into dense vectors through an external service.
+type embeddingProvider interface {
+	Embed(ctx context.Context, texts []string) ([][]float32, error)
+}
+
+// openAIEmbeddingProvider calls an OpenAI-compatible `/v1/embeddings` endpoint.
+type openAIEmbeddingProvider struct {
+	client    *modelClient
+	modelName string
+	dim       int64
+}
+
+type openAIEmbeddingRequest struct {
+	Input      []string `json:"input"`
+	Model      string   `json:"model"`
+	Dimensions int64    `json:"dimensions,omitempty"`
+}
+
+type openAIEmbeddingResponse struct {
+	Data []struct {
+		Index     int       `json:"index"`
+		Embedding []float32 `json:"embedding"`
+	} `json:"data"`
+}
+
+func (p *openAIEmbeddingProvider) Embed(ctx context.Context, texts []string) ([][]float32, error) {
+	resp := &openAIEmbeddingResponse{}
+	if err := p.client.post(ctx, &openAIEmbeddingRequest{
+		Input:      texts,
+		Model:      p.modelName,
+		Dimensions: p.dim,
+	}, resp); err != nil {
+		return nil, err
+	}
+	// the embeddings may not be returned in input order.
+	sort.Slice(resp.Data, func(i, j int) bool {
+		return resp.Data[i].Index < resp.Data[j].Index
+	})
+	embeddings := make([][]float32, 0, len(resp.Data))
+	for _, item := range resp.Data {
+		embeddings = append(embeddings, item.Embedding)
+	}
+	return embeddings, nil
+}
+
+// teiEmbeddingProvider calls the `/embed` endpoint of a text-embeddings-inference server.
+type teiEmbeddingProvider struct {
+	client   *modelClient
+	truncate bool
+}
+
+type teiEmbeddingRequest struct {
+	Inputs   []string `json:"inputs"`
+	Truncate bool     `json:"truncate"`
+}
+
+func (p *teiEmbeddingProvider) Embed(ctx context.Context, texts []string) ([][]float32, error) {
+	var embeddings [][]float32
+	if err := p.client.post(ctx, &teiEmbeddingRequest{
+		Inputs:   texts,
+		Truncate: p.truncate,
+	}, &embeddings); err != nil {
+		return nil, err
+	}
+	return embeddings, nil
+}
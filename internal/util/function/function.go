@@ -35,6 +35,8 @@ func NewFunctionRunner(coll *schemapb.CollectionSchema, schema *schemapb.Functio
 	switch schema.GetType() {
 	case schemapb.FunctionType_BM25:
 		return NewBM25FunctionRunner(coll, schema)
+	case schemapb.FunctionType_TextEmbedding:
+		return NewTextEmbeddingFunctionRunner(coll, schema)
 	default:
 		return nil, fmt.Errorf("unknown functionRunner type %s", schema.GetType().String())
 	}
@@ -0,0 +1,158 @@
+/*
+ * # Licensed to the LF AI & Data foundation under one
+ * # or more contributor license agreements. See the NOTICE file
+ * # distributed with this work for additional information
+ * # regarding copyright ownership. The ASF licenses this file
+ * # to you under the Apache License, Version 2.0 (the
+ * # "License"); you may not use this file except in compliance
+ * # with the License. You may obtain a copy of the License at
+ * #
+ * #     http://www.apache.org/licenses/LICENSE-2.0
+ * #
+ * # Unless required by applicable law or agreed to in writing, software
+ * # distributed under the License is distributed on an "AS IS" BASIS,
+ * # WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * # See the License for the specific language governing permissions and
+ * # limitations under the License.
+ */
+
+package function
+
+import (
+	"context"
+	"fmt"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
+	"github.com/milvus-io/milvus-proto/go-api/v2/schemapb"
+	"github.com/milvus-io/milvus/internal/proto/internalpb"
+	"github.com/milvus-io/milvus/pkg/mq/msgstream"
+	"github.com/milvus-io/milvus/pkg/util/funcutil"
+)
+
+// HasNonBM25Functions returns true if the collection has functions which should be executed by proxy,
+// BM25 is executed inside datanode and querynode, so it's excluded.
+func HasNonBM25Functions(functions []*schemapb.FunctionSchema) bool {
+	for _, fn := range functions {
+		if fn.GetType() != schemapb.FunctionType_BM25 {
+			return true
+		}
+	}
+	return false
+}
+
+// FunctionExecutor executes the non-BM25 functions of a collection in proxy,
+// fills the output fields of insert requests and converts the text placeholders of search requests.
+type FunctionExecutor struct {
+	// output field id -> runner
+	runners map[int64]*TextEmbeddingFunctionRunner
+}
+
+func NewFunctionExecutor(schema *schemapb.CollectionSchema) (*FunctionExecutor, error) {
+	executor := &FunctionExecutor{
+		runners: make(map[int64]*TextEmbeddingFunctionRunner),
+	}
+	for _, fn := range schema.GetFunctions() {
+		if fn.GetType() != schemapb.FunctionType_TextEmbedding {
+			continue
+		}
+		runner, err := NewTextEmbeddingFunctionRunner(schema, fn)
+		if err != nil {
+			return nil, err
+		}
+		executor.runners[fn.GetOutputFieldIds()[0]] = runner
+	}
+	return executor, nil
+}
+
+// ProcessInsert runs the functions on the input columns of insert message and appends the output columns.
+func (executor *FunctionExecutor) ProcessInsert(ctx context.Context, msg *msgstream.InsertMsg) error {
+	for _, runner := range executor.runners {
+		inputFieldID := runner.GetSchema().GetInputFieldIds()[0]
+		var input *schemapb.FieldData
+		for _, fieldData := range msg.GetFieldsData() {
+			if fieldData.GetFieldId() == inputFieldID {
+				input = fieldData
+				break
+			}
+		}
+		if input == nil {
+			return fmt.Errorf("input field %s of function %s not found in insert data",
+				runner.GetSchema().GetInputFieldNames()[0], runner.GetSchema().GetName())
+		}
+
+		data, err := runner.Embed(ctx, input.GetScalars().GetStringData().GetData())
+		if err != nil {
+			return err
+		}
+		outputField := runner.GetOutputFields()[0]
+		msg.FieldsData = append(msg.FieldsData, &schemapb.FieldData{
+			Type:      outputField.GetDataType(),
+			FieldName: outputField.GetName(),
+			FieldId:   outputField.GetFieldID(),
+			Field: &schemapb.FieldData_Vectors{
+				Vectors: &schemapb.VectorField{
+					Dim: runner.dim,
+					Data: &schemapb.VectorField_FloatVector{
+						FloatVector: &schemapb.FloatArray{Data: data},
+					},
+				},
+			},
+		})
+	}
+	return nil
+}
+
+// ProcessSearch converts the text placeholders of the search request into float vectors,
+// if the searched field is the output of a text embedding function.
+func (executor *FunctionExecutor) ProcessSearch(ctx context.Context, req *internalpb.SearchRequest) error {
+	if !req.GetIsAdvanced() {
+		placeholderGroup, err := executor.processPlaceholderGroup(ctx, req.GetFieldId(), req.GetPlaceholderGroup())
+		if err != nil {
+			return err
+		}
+		req.PlaceholderGroup = placeholderGroup
+		return nil
+	}
+	for _, subReq := range req.GetSubReqs() {
+		placeholderGroup, err := executor.processPlaceholderGroup(ctx, subReq.GetFieldId(), subReq.GetPlaceholderGroup())
+		if err != nil {
+			return err
+		}
+		subReq.PlaceholderGroup = placeholderGroup
+	}
+	return nil
+}
+
+func (executor *FunctionExecutor) processPlaceholderGroup(ctx context.Context, fieldID int64, placeholderGroupBytes []byte) ([]byte, error) {
+	runner, ok := executor.runners[fieldID]
+	if !ok {
+		return placeholderGroupBytes, nil
+	}
+	placeholderGroup := &commonpb.PlaceholderGroup{}
+	if err := proto.Unmarshal(placeholderGroupBytes, placeholderGroup); err != nil {
+		return nil, err
+	}
+	if len(placeholderGroup.GetPlaceholders()) != 1 || placeholderGroup.GetPlaceholders()[0].GetType() != commonpb.PlaceholderType_VarChar {
+		// vectors are passed by user, search them directly.
+		return placeholderGroupBytes, nil
+	}
+
+	texts := funcutil.GetVarCharFromPlaceholder(placeholderGroup.GetPlaceholders()[0])
+	data, err := runner.Embed(ctx, texts)
+	if err != nil {
+		return nil, err
+	}
+	return funcutil.FieldDataToPlaceholderGroupBytes(&schemapb.FieldData{
+		Type: schemapb.DataType_FloatVector,
+		Field: &schemapb.FieldData_Vectors{
+			Vectors: &schemapb.VectorField{
+				Dim: runner.dim,
+				Data: &schemapb.VectorField_FloatVector{
+					FloatVector: &schemapb.FloatArray{Data: data},
+				},
+			},
+		},
+	})
+}
@@ -0,0 +1,89 @@
+/*
+ * # Licensed to the LF AI & Data foundation under one
+ * # or more contributor license agreements. See the NOTICE file
+ * # distributed with this work for additional information
+ * # regarding copyright ownership. The ASF licenses this file
+ * # to you under the Apache License, Version 2.0 (the
+ * # "License"); you may not use this file except in compliance
+ * # with the License. You may obtain a copy of the License at
+ * #
+ * #     http://www.apache.org/licenses/LICENSE-2.0
+ * #
+ * # Unless required by applicable law or agreed to in writing, software
+ * # distributed under the License is distributed on an "AS IS" BASIS,
+ * # WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * # See the License for the specific language governing permissions and
+ * # limitations under the License.
+ */
+
+package function
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/cockroachdb/errors"
+
+	"github.com/milvus-io/milvus/pkg/util/retry"
+)
+
+// modelClient is the shared http client of all model service providers,
+// it retries the request if the error is recoverable (network failure, 429 and 5xx).
+type modelClient struct {
+	url        string
+	apiKey     string
+	client     *http.Client
+	maxRetries uint
+}
+
+func newModelClient(url string, apiKey string, timeout time.Duration, maxRetries uint) *modelClient {
+	return &modelClient{
+		url:        url,
+		apiKey:     apiKey,
+		client:     &http.Client{Timeout: timeout},
+		maxRetries: maxRetries,
+	}
+}
+
+// post sends the request body as json and decodes the json response into resp.
+func (c *modelClient) post(ctx context.Context, req any, resp any) error {
+	body, err := json.Marshal(req)
+	if err != nil {
+		return err
+	}
+	return retry.Do(ctx, func() error {
+		httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, c.url, bytes.NewReader(body))
+		if err != nil {
+			return retry.Unrecoverable(err)
+		}
+		httpReq.Header.Set("Content-Type", "application/json")
+		if c.apiKey != "" {
+			httpReq.Header.Set("Authorization", "Bearer "+c.apiKey)
+		}
+		httpResp, err := c.client.Do(httpReq)
+		if err != nil {
+			return err
+		}
+		defer httpResp.Body.Close()
+		data, err := io.ReadAll(httpResp.Body)
+		if err != nil {
+			return err
+		}
+		if httpResp.StatusCode != http.StatusOK {
+			err := fmt.Errorf("model service %s returned status %d: %s", c.url, httpResp.StatusCode, string(data))
+			if httpResp.StatusCode == http.StatusTooManyRequests || httpResp.StatusCode >= http.StatusInternalServerError {
+				return err
+			}
+			return retry.Unrecoverable(err)
+		}
+		if err := json.Unmarshal(data, resp); err != nil {
+			return retry.Unrecoverable(errors.Wrapf(err, "failed to decode response of model service %s", c.url))
+		}
+		return nil
+	}, retry.Attempts(c.maxRetries+1), retry.Sleep(100*time.Millisecond), retry.MaxSleepTime(3*time.Second))
+}
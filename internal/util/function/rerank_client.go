@@ -0,0 +1,182 @@
+/*
+ * # Licensed to the LF AI & Data foundation under one
+ * # or more contributor license agreements. See the NOTICE file
+ * # distributed with this work for additional information
+ * # regarding copyright ownership. The ASF licenses this file
+ * # to you under the Apache License, Version 2.0 (the
+ * # "License"); you may not use this file except in compliance
+ * # with the License. You may obtain a copy of the License at
+ * #
+ * #     http://www.apache.org/licenses/LICENSE-2.0
+ * #
+ * # Unless required by applicable law or agreed to in writing, software
+ * # distributed under the License is distributed on an "AS IS" BASIS,
+ * # WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * # See the License for the specific language governing permissions and
+ * # limitations under the License.
+ */
+
+package function
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
+)
+
+const cohereProvider = "cohere"
+
+const defaultRerankMaxBatch = 64
+
+// RerankClient scores the relevance between a query and a batch of texts
+// through an external cross-encoder service.
+// It supports the `/rerank` endpoint of text-embeddings-inference
+// and the cohere compatible rerank api.
+type RerankClient struct {
+	provider  string
+	client    *modelClient
+	modelName string
+	maxBatch  int
+}
+
+func NewRerankClient(params []*commonpb.KeyValuePair) (*RerankClient, error) {
+	var (
+		provider, url, modelName, apiKey string
+		maxBatch                         = defaultRerankMaxBatch
+		timeout                          = defaultEmbeddingTimeout
+		maxRetries                       = uint(defaultEmbeddingMaxRetries)
+	)
+	for _, param := range params {
+		var err error
+		switch strings.ToLower(param.GetKey()) {
+		case providerParamKey:
+			provider = strings.ToLower(param.GetValue())
+		case urlParamKey:
+			url = param.GetValue()
+		case modelNameParamKey:
+			modelName = param.GetValue()
+		case apiKeyParamKey:
+			apiKey = param.GetValue()
+		case maxBatchParamKey:
+			maxBatch, err = strconv.Atoi(param.GetValue())
+			if err == nil && maxBatch <= 0 {
+				err = fmt.Errorf("must be positive")
+			}
+		case timeoutParamKey:
+			var ms int64
+			ms, err = strconv.ParseInt(param.GetValue(), 10, 64)
+			timeout = time.Duration(ms) * time.Millisecond
+		case maxRetriesParamKey:
+			var retries uint64
+			retries, err = strconv.ParseUint(param.GetValue(), 10, 32)
+			maxRetries = uint(retries)
+		default:
+			// other params belong to the caller.
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid rerank param %s=%s: %v", param.GetKey(), param.GetValue(), err)
+		}
+	}
+
+	if url == "" {
+		return nil, fmt.Errorf("rerank param %s is required", urlParamKey)
+	}
+	switch provider {
+	case teiProvider:
+	case cohereProvider:
+		if modelName == "" {
+			return nil, fmt.Errorf("rerank param %s is required by provider %s", modelNameParamKey, cohereProvider)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported rerank provider: %s, only %s and %s are supported", provider, teiProvider, cohereProvider)
+	}
+	return &RerankClient{
+		provider:  provider,
+		client:    newModelClient(url, apiKey, timeout, maxRetries),
+		modelName: modelName,
+		maxBatch:  maxBatch,
+	}, nil
+}
+
+type teiRerankRequest struct {
+	Query string   `json:"query"`
+	Texts []string `json:"texts"`
+}
+
+type teiRerankResponse []struct {
+	Index int     `json:"index"`
+	Score float32 `json:"score"`
+}
+
+type cohereRerankRequest struct {
+	Model     string   `json:"model"`
+	Query     string   `json:"query"`
+	Documents []string `json:"documents"`
+	TopN      int      `json:"top_n"`
+}
+
+type cohereRerankResponse struct {
+	Results []struct {
+		Index          int     `json:"index"`
+		RelevanceScore float32 `json:"relevance_score"`
+	} `json:"results"`
+}
+
+// Rerank returns the relevance score of every text to the query, in the order of texts.
+func (c *RerankClient) Rerank(ctx context.Context, query string, texts []string) ([]float32, error) {
+	scores := make([]float32, len(texts))
+	for start := 0; start < len(texts); start += c.maxBatch {
+		end := start + c.maxBatch
+		if end > len(texts) {
+			end = len(texts)
+		}
+		if err := c.rerank(ctx, query, texts[start:end], scores[start:end]); err != nil {
+			return nil, err
+		}
+	}
+	return scores, nil
+}
+
+func (c *RerankClient) rerank(ctx context.Context, query string, texts []string, dst []float32) error {
+	set := func(index int, score float32) error {
+		if index < 0 || index >= len(dst) {
+			return fmt.Errorf("rerank service returned out of range index %d for %d texts", index, len(dst))
+		}
+		dst[index] = score
+		return nil
+	}
+
+	switch c.provider {
+	case teiProvider:
+		resp := teiRerankResponse{}
+		if err := c.client.post(ctx, &teiRerankRequest{Query: query, Texts: texts}, &resp); err != nil {
+			return err
+		}
+		if len(resp) != len(texts) {
+			return fmt.Errorf("rerank service returned %d scores for %d texts", len(resp), len(texts))
+		}
+		for _, item := range resp {
+			if err := set(item.Index, item.Score); err != nil {
+				return err
+			}
+		}
+	case cohereProvider:
+		resp := &cohereRerankResponse{}
+		if err := c.client.post(ctx, &cohereRerankRequest{Model: c.modelName, Query: query, Documents: texts, TopN: len(texts)}, resp); err != nil {
+			return err
+		}
+		if len(resp.Results) != len(texts) {
+			return fmt.Errorf("rerank service returned %d scores for %d texts", len(resp.Results), len(texts))
+		}
+		for _, item := range resp.Results {
+			if err := set(item.Index, item.RelevanceScore); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
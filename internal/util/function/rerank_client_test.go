@@ -0,0 +1,74 @@
+/*
+ * # Licensed to the LF AI & Data foundation under one
+ * # or more contributor license agreements. See the NOTICE file
+ * # distributed with this work for additional information
+ * # regarding copyright ownership. The ASF licenses this file
+ * # to you under the Apache License, Version 2.0 (the
+ * # "License"); you may not use this file except in compliance
+ * # with the License. You may obtain a copy of the License at
+ * #
+ * #     http://www.apache.org/licenses/LICENSE-2.0
+ * #
+ * # Unless required by applicable law or agreed to in writing, software
+ * # distributed under the License is distributed on an "AS IS" BASIS,
+ * # WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * # See the License for the specific language governing permissions and
+ * # limitations under the License.
+ */
+
+package function
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
+)
+
+func TestRerankClient(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req := &cohereRerankRequest{}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(req))
+		assert.Equal(t, "rerank-model", req.Model)
+		assert.Equal(t, len(req.Documents), req.TopN)
+
+		resp := map[string]any{}
+		results := make([]map[string]any, 0, len(req.Documents))
+		// return the results in reversed order.
+		for i := len(req.Documents) - 1; i >= 0; i-- {
+			results = append(results, map[string]any{"index": i, "relevance_score": float32(len(req.Documents[i]))})
+		}
+		resp["results"] = results
+		assert.NoError(t, json.NewEncoder(w).Encode(resp))
+	}))
+	defer server.Close()
+
+	_, err := NewRerankClient([]*commonpb.KeyValuePair{{Key: providerParamKey, Value: cohereProvider}})
+	assert.Error(t, err)
+	_, err = NewRerankClient([]*commonpb.KeyValuePair{
+		{Key: providerParamKey, Value: cohereProvider},
+		{Key: urlParamKey, Value: server.URL},
+	})
+	assert.Error(t, err)
+	_, err = NewRerankClient([]*commonpb.KeyValuePair{
+		{Key: providerParamKey, Value: openAIProvider},
+		{Key: urlParamKey, Value: server.URL},
+	})
+	assert.Error(t, err)
+
+	client, err := NewRerankClient([]*commonpb.KeyValuePair{
+		{Key: providerParamKey, Value: cohereProvider},
+		{Key: urlParamKey, Value: server.URL},
+		{Key: modelNameParamKey, Value: "rerank-model"},
+		{Key: maxBatchParamKey, Value: "2"},
+	})
+	assert.NoError(t, err)
+	scores, err := client.Rerank(context.Background(), "query", []string{"a", "bb", "ccc"})
+	assert.NoError(t, err)
+	assert.Equal(t, []float32{1, 2, 3}, scores)
+}
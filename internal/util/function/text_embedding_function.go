@@ -0,0 +1,235 @@
+/*
+ * # Licensed to the LF AI & Data foundation under one
+ * # or more contributor license agreements. See the NOTICE file
+ * # distributed with this work for additional information
+ * # regarding copyright ownership. The ASF licenses this file
+ * # to you under the Apache License, Version 2.0 (the
+ * # "License"); you may not use this file except in compliance
+ * # with the License. You may obtain a copy of the License at
+ * #
+ * #     http://www.apache.org/licenses/LICENSE-2.0
+ * #
+ * # Unless required by applicable law or agreed to in writing, software
+ * # distributed under the License is distributed on an "AS IS" BASIS,
+ * # WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * # See the License for the specific language governing permissions and
+ * # limitations under the License.
+ */
+
+package function
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
+	"github.com/milvus-io/milvus-proto/go-api/v2/schemapb"
+	"github.com/milvus-io/milvus/pkg/util/typeutil"
+)
+
+const (
+	providerParamKey   = "provider"
+	urlParamKey        = "url"
+	modelNameParamKey  = "model_name"
+	apiKeyParamKey     = "api_key"
+	dimParamKey        = "dim"
+	maxBatchParamKey   = "max_batch"
+	timeoutParamKey    = "timeout_ms"
+	maxRetriesParamKey = "max_retries"
+	truncateParamKey   = "truncate"
+)
+
+const (
+	defaultEmbeddingMaxBatch   = 32
+	defaultEmbeddingTimeout    = 30 * time.Second
+	defaultEmbeddingMaxRetries = 3
+)
+
+// TextEmbedding Runner
+// Input: string
+// Output: float vector
+type TextEmbeddingFunctionRunner struct {
+	schema      *schemapb.FunctionSchema
+	outputField *schemapb.FieldSchema
+	provider    embeddingProvider
+	dim         int64
+	maxBatch    int
+}
+
+// ValidateTextEmbeddingParams checks the params of text embedding function without creating the runner.
+func ValidateTextEmbeddingParams(params []*commonpb.KeyValuePair) error {
+	_, err := parseTextEmbeddingParams(params)
+	return err
+}
+
+type textEmbeddingParams struct {
+	provider   string
+	url        string
+	modelName  string
+	apiKey     string
+	dim        int64
+	maxBatch   int
+	timeout    time.Duration
+	maxRetries uint
+	truncate   bool
+}
+
+func parseTextEmbeddingParams(params []*commonpb.KeyValuePair) (*textEmbeddingParams, error) {
+	p := &textEmbeddingParams{
+		maxBatch:   defaultEmbeddingMaxBatch,
+		timeout:    defaultEmbeddingTimeout,
+		maxRetries: defaultEmbeddingMaxRetries,
+	}
+	for _, param := range params {
+		var err error
+		switch strings.ToLower(param.GetKey()) {
+		case providerParamKey:
+			p.provider = strings.ToLower(param.GetValue())
+		case urlParamKey:
+			p.url = param.GetValue()
+		case modelNameParamKey:
+			p.modelName = param.GetValue()
+		case apiKeyParamKey:
+			p.apiKey = param.GetValue()
+		case dimParamKey:
+			p.dim, err = strconv.ParseInt(param.GetValue(), 10, 64)
+		case maxBatchParamKey:
+			p.maxBatch, err = strconv.Atoi(param.GetValue())
+			if err == nil && p.maxBatch <= 0 {
+				err = fmt.Errorf("must be positive")
+			}
+		case timeoutParamKey:
+			var ms int64
+			ms, err = strconv.ParseInt(param.GetValue(), 10, 64)
+			p.timeout = time.Duration(ms) * time.Millisecond
+		case maxRetriesParamKey:
+			var retries uint64
+			retries, err = strconv.ParseUint(param.GetValue(), 10, 32)
+			p.maxRetries = uint(retries)
+		case truncateParamKey:
+			p.truncate, err = strconv.ParseBool(param.GetValue())
+		default:
+			return nil, fmt.Errorf("unknown text embedding function param: %s", param.GetKey())
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid text embedding function param %s=%s: %v", param.GetKey(), param.GetValue(), err)
+		}
+	}
+
+	if p.url == "" {
+		return nil, fmt.Errorf("text embedding function param %s is required", urlParamKey)
+	}
+	switch p.provider {
+	case openAIProvider:
+		if p.modelName == "" {
+			return nil, fmt.Errorf("text embedding function param %s is required by provider %s", modelNameParamKey, openAIProvider)
+		}
+	case teiProvider:
+	default:
+		return nil, fmt.Errorf("unsupported text embedding provider: %s, only %s and %s are supported", p.provider, openAIProvider, teiProvider)
+	}
+	return p, nil
+}
+
+func NewTextEmbeddingFunctionRunner(coll *schemapb.CollectionSchema, schema *schemapb.FunctionSchema) (*TextEmbeddingFunctionRunner, error) {
+	if len(schema.GetInputFieldIds()) != 1 || len(schema.GetOutputFieldIds()) != 1 {
+		return nil, fmt.Errorf("text embedding function should have exactly one input and one output field, but now %d input and %d output",
+			len(schema.GetInputFieldIds()), len(schema.GetOutputFieldIds()))
+	}
+
+	params, err := parseTextEmbeddingParams(schema.GetParams())
+	if err != nil {
+		return nil, err
+	}
+
+	runner := &TextEmbeddingFunctionRunner{
+		schema:   schema,
+		maxBatch: params.maxBatch,
+	}
+	for _, field := range coll.GetFields() {
+		if field.GetFieldID() == schema.GetOutputFieldIds()[0] {
+			runner.outputField = field
+		}
+	}
+	if runner.outputField == nil {
+		return nil, fmt.Errorf("no output field")
+	}
+	if runner.outputField.GetDataType() != schemapb.DataType_FloatVector {
+		return nil, fmt.Errorf("text embedding function output field must be a FloatVector field, but got %s", runner.outputField.GetDataType().String())
+	}
+	runner.dim, err = typeutil.GetDim(runner.outputField)
+	if err != nil {
+		return nil, err
+	}
+	if params.dim != 0 && params.dim != runner.dim {
+		return nil, fmt.Errorf("text embedding function param dim %d mismatch the output field dim %d", params.dim, runner.dim)
+	}
+
+	client := newModelClient(params.url, params.apiKey, params.timeout, params.maxRetries)
+	switch params.provider {
+	case openAIProvider:
+		runner.provider = &openAIEmbeddingProvider{client: client, modelName: params.modelName, dim: params.dim}
+	case teiProvider:
+		runner.provider = &teiEmbeddingProvider{client: client, truncate: params.truncate}
+	}
+	return runner, nil
+}
+
+// Embed converts texts into a flattened float vector, the texts are sent to the provider in batches of maxBatch.
+func (v *TextEmbeddingFunctionRunner) Embed(ctx context.Context, texts []string) ([]float32, error) {
+	data := make([]float32, 0, int64(len(texts))*v.dim)
+	for start := 0; start < len(texts); start += v.maxBatch {
+		end := start + v.maxBatch
+		if end > len(texts) {
+			end = len(texts)
+		}
+		embeddings, err := v.provider.Embed(ctx, texts[start:end])
+		if err != nil {
+			return nil, err
+		}
+		if len(embeddings) != end-start {
+			return nil, fmt.Errorf("text embedding service returned %d embeddings for %d texts", len(embeddings), end-start)
+		}
+		for _, embedding := range embeddings {
+			if int64(len(embedding)) != v.dim {
+				return nil, fmt.Errorf("text embedding service returned embedding with dim %d, but output field %s dim is %d",
+					len(embedding), v.outputField.GetName(), v.dim)
+			}
+			data = append(data, embedding...)
+		}
+	}
+	return data, nil
+}
+
+func (v *TextEmbeddingFunctionRunner) BatchRun(inputs ...any) ([]any, error) {
+	if len(inputs) > 1 {
+		return nil, fmt.Errorf("text embedding function received more than one input column")
+	}
+
+	text, ok := inputs[0].([]string)
+	if !ok {
+		return nil, fmt.Errorf("text embedding function batch input not string list")
+	}
+
+	data, err := v.Embed(context.Background(), text)
+	if err != nil {
+		return nil, err
+	}
+	return []any{&schemapb.VectorField{
+		Dim: v.dim,
+		Data: &schemapb.VectorField_FloatVector{
+			FloatVector: &schemapb.FloatArray{Data: data},
+		},
+	}}, nil
+}
+
+func (v *TextEmbeddingFunctionRunner) GetSchema() *schemapb.FunctionSchema {
+	return v.schema
+}
+
+func (v *TextEmbeddingFunctionRunner) GetOutputFields() []*schemapb.FieldSchema {
+	return []*schemapb.FieldSchema{v.outputField}
+}
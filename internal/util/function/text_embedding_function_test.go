@@ -0,0 +1,262 @@
+/*
+ * # Licensed to the LF AI & Data foundation under one
+ * # or more contributor license agreements. See the NOTICE file
+ * # distributed with this work for additional information
+ * # regarding copyright ownership. The ASF licenses this file
+ * # to you under the Apache License, Version 2.0 (the
+ * # "License"); you may not use this file except in compliance
+ * # with the License. You may obtain a copy of the License at
+ * #
+ * #     http://www.apache.org/licenses/LICENSE-2.0
+ * #
+ * # Unless required by applicable law or agreed to in writing, software
+ * # distributed under the License is distributed on an "AS IS" BASIS,
+ * # WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * # See the License for the specific language governing permissions and
+ * # limitations under the License.
+ */
+
+package function
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/atomic"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
+	"github.com/milvus-io/milvus-proto/go-api/v2/schemapb"
+	"github.com/milvus-io/milvus/internal/proto/internalpb"
+	"github.com/milvus-io/milvus/pkg/mq/msgstream"
+	"github.com/milvus-io/milvus/pkg/util/funcutil"
+)
+
+func TestTextEmbeddingFunctionSuite(t *testing.T) {
+	suite.Run(t, new(TextEmbeddingFunctionSuite))
+}
+
+type TextEmbeddingFunctionSuite struct {
+	suite.Suite
+	schema   *schemapb.CollectionSchema
+	requests atomic.Int32
+	failures atomic.Int32
+}
+
+func (s *TextEmbeddingFunctionSuite) SetupTest() {
+	s.schema = &schemapb.CollectionSchema{
+		Name: "test",
+		Fields: []*schemapb.FieldSchema{
+			{FieldID: 100, Name: "int64", DataType: schemapb.DataType_Int64},
+			{FieldID: 101, Name: "text", DataType: schemapb.DataType_VarChar},
+			{
+				FieldID: 102, Name: "vector", DataType: schemapb.DataType_FloatVector,
+				TypeParams: []*commonpb.KeyValuePair{{Key: "dim", Value: "4"}},
+			},
+		},
+	}
+	s.requests.Store(0)
+	s.failures.Store(0)
+}
+
+// embedding returns a fake embedding of dim 4 for the text.
+func embedding(text string) []float32 {
+	return []float32{float32(len(text)), 1, 2, 3}
+}
+
+func (s *TextEmbeddingFunctionSuite) newOpenAIServer() *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.requests.Inc()
+		if s.failures.Load() > 0 {
+			s.failures.Dec()
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		req := &openAIEmbeddingRequest{}
+		s.NoError(json.NewDecoder(r.Body).Decode(req))
+		s.Equal("test-model", req.Model)
+		s.Equal("Bearer mock-key", r.Header.Get("Authorization"))
+
+		resp := map[string]any{}
+		data := make([]map[string]any, 0, len(req.Input))
+		// return the embeddings in reversed order.
+		for i := len(req.Input) - 1; i >= 0; i-- {
+			data = append(data, map[string]any{"index": i, "embedding": embedding(req.Input[i])})
+		}
+		resp["data"] = data
+		s.NoError(json.NewEncoder(w).Encode(resp))
+	}))
+}
+
+func (s *TextEmbeddingFunctionSuite) newTEIServer() *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.requests.Inc()
+		req := &teiEmbeddingRequest{}
+		s.NoError(json.NewDecoder(r.Body).Decode(req))
+		resp := make([][]float32, 0, len(req.Inputs))
+		for _, text := range req.Inputs {
+			resp = append(resp, embedding(text))
+		}
+		s.NoError(json.NewEncoder(w).Encode(resp))
+	}))
+}
+
+func (s *TextEmbeddingFunctionSuite) functionSchema(params ...*commonpb.KeyValuePair) *schemapb.FunctionSchema {
+	return &schemapb.FunctionSchema{
+		Name:             "test",
+		Type:             schemapb.FunctionType_TextEmbedding,
+		InputFieldIds:    []int64{101},
+		InputFieldNames:  []string{"text"},
+		OutputFieldIds:   []int64{102},
+		OutputFieldNames: []string{"vector"},
+		Params:           params,
+	}
+}
+
+func (s *TextEmbeddingFunctionSuite) TestInvalidParams() {
+	_, err := NewFunctionRunner(s.schema, s.functionSchema())
+	s.Error(err)
+
+	_, err = NewFunctionRunner(s.schema, s.functionSchema(
+		&commonpb.KeyValuePair{Key: providerParamKey, Value: "unknown"},
+		&commonpb.KeyValuePair{Key: urlParamKey, Value: "http://localhost"},
+	))
+	s.Error(err)
+
+	_, err = NewFunctionRunner(s.schema, s.functionSchema(
+		&commonpb.KeyValuePair{Key: providerParamKey, Value: openAIProvider},
+		&commonpb.KeyValuePair{Key: urlParamKey, Value: "http://localhost"},
+	))
+	s.Error(err)
+
+	_, err = NewFunctionRunner(s.schema, s.functionSchema(
+		&commonpb.KeyValuePair{Key: providerParamKey, Value: teiProvider},
+		&commonpb.KeyValuePair{Key: urlParamKey, Value: "http://localhost"},
+		&commonpb.KeyValuePair{Key: dimParamKey, Value: "8"},
+	))
+	s.Error(err)
+
+	_, err = NewFunctionRunner(s.schema, s.functionSchema(
+		&commonpb.KeyValuePair{Key: providerParamKey, Value: teiProvider},
+		&commonpb.KeyValuePair{Key: urlParamKey, Value: "http://localhost"},
+		&commonpb.KeyValuePair{Key: maxBatchParamKey, Value: "0"},
+	))
+	s.Error(err)
+
+	s.NoError(ValidateTextEmbeddingParams([]*commonpb.KeyValuePair{
+		{Key: providerParamKey, Value: teiProvider},
+		{Key: urlParamKey, Value: "http://localhost"},
+	}))
+}
+
+func (s *TextEmbeddingFunctionSuite) TestOpenAI() {
+	server := s.newOpenAIServer()
+	defer server.Close()
+
+	runner, err := NewFunctionRunner(s.schema, s.functionSchema(
+		&commonpb.KeyValuePair{Key: providerParamKey, Value: openAIProvider},
+		&commonpb.KeyValuePair{Key: urlParamKey, Value: server.URL},
+		&commonpb.KeyValuePair{Key: modelNameParamKey, Value: "test-model"},
+		&commonpb.KeyValuePair{Key: apiKeyParamKey, Value: "mock-key"},
+		&commonpb.KeyValuePair{Key: maxBatchParamKey, Value: "2"},
+	))
+	s.NoError(err)
+
+	// one failure should be retried.
+	s.failures.Store(1)
+	output, err := runner.BatchRun([]string{"a", "bb", "ccc"})
+	s.NoError(err)
+	s.Equal(int32(3), s.requests.Load())
+
+	s.Equal(1, len(output))
+	result, ok := output[0].(*schemapb.VectorField)
+	s.True(ok)
+	s.Equal(int64(4), result.GetDim())
+	s.Equal([]float32{1, 1, 2, 3, 2, 1, 2, 3, 3, 1, 2, 3}, result.GetFloatVector().GetData())
+
+	// return error because receive more than one field input
+	_, err = runner.BatchRun([]string{}, []string{})
+	s.Error(err)
+
+	// return error because field not string
+	_, err = runner.BatchRun([]int64{})
+	s.Error(err)
+}
+
+func (s *TextEmbeddingFunctionSuite) TestTEI() {
+	server := s.newTEIServer()
+	defer server.Close()
+
+	runner, err := NewFunctionRunner(s.schema, s.functionSchema(
+		&commonpb.KeyValuePair{Key: providerParamKey, Value: teiProvider},
+		&commonpb.KeyValuePair{Key: urlParamKey, Value: server.URL},
+	))
+	s.NoError(err)
+
+	output, err := runner.BatchRun([]string{"a", "bb"})
+	s.NoError(err)
+	result := output[0].(*schemapb.VectorField)
+	s.Equal([]float32{1, 1, 2, 3, 2, 1, 2, 3}, result.GetFloatVector().GetData())
+
+	// 4xx should not be retried.
+	notFound := httptest.NewServer(http.NotFoundHandler())
+	defer notFound.Close()
+	runner, err = NewFunctionRunner(s.schema, s.functionSchema(
+		&commonpb.KeyValuePair{Key: providerParamKey, Value: teiProvider},
+		&commonpb.KeyValuePair{Key: urlParamKey, Value: notFound.URL},
+	))
+	s.NoError(err)
+	_, err = runner.BatchRun([]string{"a"})
+	s.Error(err)
+}
+
+func (s *TextEmbeddingFunctionSuite) TestExecutor() {
+	server := s.newTEIServer()
+	defer server.Close()
+
+	s.schema.Functions = []*schemapb.FunctionSchema{s.functionSchema(
+		&commonpb.KeyValuePair{Key: providerParamKey, Value: teiProvider},
+		&commonpb.KeyValuePair{Key: urlParamKey, Value: server.URL},
+	)}
+	s.True(HasNonBM25Functions(s.schema.GetFunctions()))
+	exec, err := NewFunctionExecutor(s.schema)
+	s.NoError(err)
+
+	// insert
+	msg := &msgstream.InsertMsg{}
+	msg.FieldsData = []*schemapb.FieldData{{
+		Type:      schemapb.DataType_VarChar,
+		FieldName: "text",
+		FieldId:   101,
+		Field: &schemapb.FieldData_Scalars{Scalars: &schemapb.ScalarField{
+			Data: &schemapb.ScalarField_StringData{StringData: &schemapb.StringArray{Data: []string{"a", "bb"}}},
+		}},
+	}}
+	s.NoError(exec.ProcessInsert(context.Background(), msg))
+	s.Equal(2, len(msg.FieldsData))
+	s.Equal(int64(102), msg.FieldsData[1].GetFieldId())
+	s.Equal([]float32{1, 1, 2, 3, 2, 1, 2, 3}, msg.FieldsData[1].GetVectors().GetFloatVector().GetData())
+
+	// search
+	placeholder, err := funcutil.FieldDataToPlaceholderGroupBytes(msg.FieldsData[0])
+	s.NoError(err)
+	req := &internalpb.SearchRequest{FieldId: 102, PlaceholderGroup: placeholder}
+	s.NoError(exec.ProcessSearch(context.Background(), req))
+	group := &commonpb.PlaceholderGroup{}
+	s.NoError(proto.Unmarshal(req.GetPlaceholderGroup(), group))
+	s.Equal(commonpb.PlaceholderType_FloatVector, group.GetPlaceholders()[0].GetType())
+	s.Equal(2, len(group.GetPlaceholders()[0].GetValues()))
+
+	// vector placeholder is kept as it is.
+	vectorPlaceholder := req.GetPlaceholderGroup()
+	req = &internalpb.SearchRequest{
+		IsAdvanced: true,
+		SubReqs:    []*internalpb.SubSearchRequest{{FieldId: 102, PlaceholderGroup: vectorPlaceholder}},
+	}
+	s.NoError(exec.ProcessSearch(context.Background(), req))
+	s.Equal(vectorPlaceholder, req.GetSubReqs()[0].GetPlaceholderGroup())
+}
@@ -26,6 +26,7 @@ import (
 	"github.com/milvus-io/milvus-proto/go-api/v2/schemapb"
 	"github.com/milvus-io/milvus/internal/json"
 	"github.com/milvus-io/milvus/internal/util/importutilv2/common"
+	"github.com/milvus-io/milvus/pkg/util/geo"
 	"github.com/milvus-io/milvus/pkg/util/merr"
 	"github.com/milvus-io/milvus/pkg/util/parameterutil"
 	"github.com/milvus-io/milvus/pkg/util/typeutil"
@@ -273,6 +274,11 @@ func (r *rowParser) parseEntity(field *schemapb.FieldSchema, obj string) (any, e
 			return nil, err
 		}
 		return []byte(obj), nil
+	case schemapb.DataType_Geometry:
+		if nullable && obj == r.nullkey {
+			return nil, nil
+		}
+		return geo.WKTToWKB(obj)
 	case schemapb.DataType_FloatVector:
 		if nullable && obj == r.nullkey {
 			return nil, merr.WrapErrParameterInvalidMsg("not support nullable in vector")
@@ -26,6 +26,7 @@ import (
 	"github.com/milvus-io/milvus/internal/json"
 	"github.com/milvus-io/milvus/internal/util/importutilv2/common"
 	"github.com/milvus-io/milvus/internal/util/nullutil"
+	"github.com/milvus-io/milvus/pkg/util/geo"
 	"github.com/milvus-io/milvus/pkg/util/merr"
 	"github.com/milvus-io/milvus/pkg/util/parameterutil"
 	"github.com/milvus-io/milvus/pkg/util/typeutil"
@@ -397,6 +398,13 @@ func (r *rowParser) parseEntity(fieldID int64, obj any) (any, error) {
 		} else {
 			return nil, r.wrapTypeError(obj, fieldID)
 		}
+	case schemapb.DataType_Geometry:
+		// geometry is written as wkt, e.g. {"FieldGeo": "POINT(116.4 39.9)"}
+		value, ok := obj.(string)
+		if !ok {
+			return nil, r.wrapTypeError(obj, fieldID)
+		}
+		return geo.WKTToWKB(value)
 	case schemapb.DataType_Array:
 		arr, ok := obj.([]interface{})
 		if !ok {
@@ -542,6 +550,16 @@ func (r *rowParser) parseNullableEntity(fieldID int64, obj any) (any, error) {
 		} else {
 			return nil, r.wrapTypeError(obj, fieldID)
 		}
+	case schemapb.DataType_Geometry:
+		if obj == nil {
+			return nil, nil
+		}
+		// geometry is written as wkt, e.g. {"FieldGeo": "POINT(116.4 39.9)"}
+		value, ok := obj.(string)
+		if !ok {
+			return nil, r.wrapTypeError(obj, fieldID)
+		}
+		return geo.WKTToWKB(value)
 	case schemapb.DataType_Array:
 		if obj == nil {
 			return nil, nil
@@ -31,6 +31,7 @@ import (
 	"github.com/milvus-io/milvus/internal/storage"
 	"github.com/milvus-io/milvus/internal/util/importutilv2/common"
 	"github.com/milvus-io/milvus/internal/util/nullutil"
+	"github.com/milvus-io/milvus/pkg/util/geo"
 	"github.com/milvus-io/milvus/pkg/util/merr"
 	"github.com/milvus-io/milvus/pkg/util/parameterutil"
 	"github.com/milvus-io/milvus/pkg/util/typeutil"
@@ -150,6 +151,12 @@ func (c *FieldReader) Next(count int64) (any, any, error) {
 		}
 		data, err := ReadJSONData(c, count)
 		return data, nil, err
+	case schemapb.DataType_Geometry:
+		if c.field.GetNullable() {
+			return ReadNullableGeometryData(c, count)
+		}
+		data, err := ReadGeometryData(c, count)
+		return data, nil, err
 	case schemapb.DataType_BinaryVector, schemapb.DataType_Float16Vector, schemapb.DataType_BFloat16Vector:
 		// vector not support default_value
 		if c.field.GetNullable() {
@@ -620,6 +627,50 @@ func ReadNullableJSONData(pcr *FieldReader, count int64) (any, []bool, error) {
 	return byteArr, validData, nil
 }
 
+func ReadGeometryData(pcr *FieldReader, count int64) (any, error) {
+	// Geometry field read data from wkt string array Parquet
+	data, err := ReadStringData(pcr, count)
+	if err != nil {
+		return nil, err
+	}
+	if data == nil {
+		return nil, nil
+	}
+	byteArr := make([][]byte, 0)
+	for _, str := range data.([]string) {
+		wkb, err := geo.WKTToWKB(str)
+		if err != nil {
+			return nil, merr.WrapErrImportFailed(err.Error())
+		}
+		byteArr = append(byteArr, wkb)
+	}
+	return byteArr, nil
+}
+
+func ReadNullableGeometryData(pcr *FieldReader, count int64) (any, []bool, error) {
+	// Geometry field read data from wkt string array Parquet
+	data, validData, err := ReadNullableStringData(pcr, count)
+	if err != nil {
+		return nil, nil, err
+	}
+	if data == nil {
+		return nil, nil, nil
+	}
+	byteArr := make([][]byte, 0)
+	for i, str := range data.([]string) {
+		if !validData[i] {
+			byteArr = append(byteArr, []byte(nil))
+			continue
+		}
+		wkb, err := geo.WKTToWKB(str)
+		if err != nil {
+			return nil, nil, merr.WrapErrImportFailed(err.Error())
+		}
+		byteArr = append(byteArr, wkb)
+	}
+	return byteArr, validData, nil
+}
+
 func ReadBinaryData(pcr *FieldReader, count int64) (any, error) {
 	dataType := pcr.field.GetDataType()
 	chunked, err := pcr.columnReader.NextBatch(count)
@@ -173,7 +173,7 @@ func convertToArrowDataType(field *schemapb.FieldSchema, isArray bool) (arrow.Da
 		return &arrow.Float64Type{}, nil
 	case schemapb.DataType_VarChar, schemapb.DataType_String:
 		return &arrow.StringType{}, nil
-	case schemapb.DataType_JSON:
+	case schemapb.DataType_JSON, schemapb.DataType_Geometry:
 		return &arrow.StringType{}, nil
 	case schemapb.DataType_Array:
 		elemType, err := convertToArrowDataType(field, true)
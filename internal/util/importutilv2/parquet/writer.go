@@ -0,0 +1,286 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parquet
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/apache/arrow/go/v12/arrow"
+	"github.com/apache/arrow/go/v12/arrow/array"
+	"github.com/apache/arrow/go/v12/arrow/memory"
+	"github.com/apache/arrow/go/v12/parquet"
+	"github.com/apache/arrow/go/v12/parquet/compress"
+	"github.com/apache/arrow/go/v12/parquet/pqarrow"
+	"github.com/samber/lo"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/schemapb"
+	"github.com/milvus-io/milvus/internal/json"
+	"github.com/milvus-io/milvus/internal/storage"
+	"github.com/milvus-io/milvus/pkg/common"
+	"github.com/milvus-io/milvus/pkg/util/geo"
+	"github.com/milvus-io/milvus/pkg/util/merr"
+	"github.com/milvus-io/milvus/pkg/util/typeutil"
+)
+
+// Writer writes insert data into a parquet file in the same layout the parquet reader accepts,
+// so that the exported files could be imported again. The file is buffered in memory and
+// uploaded through chunk manager on Close, each Write produces a row group.
+type Writer struct {
+	ctx    context.Context
+	cm     storage.ChunkManager
+	path   string
+	fields []*schemapb.FieldSchema
+
+	arrSchema *arrow.Schema
+	buf       *bytes.Buffer
+	fw        *pqarrow.FileWriter
+	rows      int64
+}
+
+// ExportFields returns the fields written by Writer: user fields including the primary key,
+// system fields and function output fields are excluded.
+func ExportFields(schema *schemapb.CollectionSchema) []*schemapb.FieldSchema {
+	return lo.Filter(schema.GetFields(), func(field *schemapb.FieldSchema, _ int) bool {
+		return field.GetFieldID() >= common.StartOfUserFieldID && !field.GetIsFunctionOutput()
+	})
+}
+
+func NewWriter(ctx context.Context, cm storage.ChunkManager, schema *schemapb.CollectionSchema, path string) (*Writer, error) {
+	fields := ExportFields(schema)
+	arrFields := make([]arrow.Field, 0, len(fields))
+	for _, field := range fields {
+		arrDataType, err := convertToArrowDataType(field, false)
+		if err != nil {
+			return nil, err
+		}
+		arrFields = append(arrFields, arrow.Field{
+			Name:     field.GetName(),
+			Type:     arrDataType,
+			Nullable: field.GetNullable(),
+			Metadata: arrow.Metadata{},
+		})
+	}
+	arrSchema := arrow.NewSchema(arrFields, nil)
+	buf := &bytes.Buffer{}
+	fw, err := pqarrow.NewFileWriter(arrSchema, buf,
+		parquet.NewWriterProperties(
+			parquet.WithCompression(compress.Codecs.Zstd),
+			parquet.WithCompressionLevel(3),
+		),
+		pqarrow.DefaultWriterProps())
+	if err != nil {
+		return nil, err
+	}
+	return &Writer{
+		ctx:       ctx,
+		cm:        cm,
+		path:      path,
+		fields:    fields,
+		arrSchema: arrSchema,
+		buf:       buf,
+		fw:        fw,
+	}, nil
+}
+
+// Write appends the insert data as a row group.
+func (w *Writer) Write(data *storage.InsertData) error {
+	rows := data.GetRowNum()
+	if rows == 0 {
+		return nil
+	}
+	mem := memory.NewGoAllocator()
+	columns := make([]arrow.Array, 0, len(w.fields))
+	defer func() {
+		for _, column := range columns {
+			column.Release()
+		}
+	}()
+	for _, field := range w.fields {
+		fieldData, ok := data.Data[field.GetFieldID()]
+		if !ok {
+			return merr.WrapErrFieldNotFound(field.GetName())
+		}
+		column, err := buildArrowArray(mem, field, fieldData)
+		if err != nil {
+			return err
+		}
+		columns = append(columns, column)
+	}
+	record := array.NewRecord(w.arrSchema, columns, int64(rows))
+	defer record.Release()
+	if err := w.fw.Write(record); err != nil {
+		return err
+	}
+	w.rows += int64(rows)
+	return nil
+}
+
+// Size returns the size of written data, the row group in progress is not included.
+func (w *Writer) Size() int {
+	return w.buf.Len()
+}
+
+func (w *Writer) Rows() int64 {
+	return w.rows
+}
+
+func (w *Writer) Path() string {
+	return w.path
+}
+
+// Close finishes the parquet file and uploads it.
+func (w *Writer) Close() error {
+	if err := w.fw.Close(); err != nil {
+		return err
+	}
+	return w.cm.Write(w.ctx, w.path, w.buf.Bytes())
+}
+
+func buildArrowArray(mem memory.Allocator, field *schemapb.FieldSchema, fieldData storage.FieldData) (arrow.Array, error) {
+	switch data := fieldData.(type) {
+	case *storage.BoolFieldData:
+		builder := array.NewBooleanBuilder(mem)
+		builder.AppendValues(data.Data, data.ValidData)
+		return builder.NewArray(), nil
+	case *storage.Int8FieldData:
+		builder := array.NewInt8Builder(mem)
+		builder.AppendValues(data.Data, data.ValidData)
+		return builder.NewArray(), nil
+	case *storage.Int16FieldData:
+		builder := array.NewInt16Builder(mem)
+		builder.AppendValues(data.Data, data.ValidData)
+		return builder.NewArray(), nil
+	case *storage.Int32FieldData:
+		builder := array.NewInt32Builder(mem)
+		builder.AppendValues(data.Data, data.ValidData)
+		return builder.NewArray(), nil
+	case *storage.Int64FieldData:
+		builder := array.NewInt64Builder(mem)
+		builder.AppendValues(data.Data, data.ValidData)
+		return builder.NewArray(), nil
+	case *storage.FloatFieldData:
+		builder := array.NewFloat32Builder(mem)
+		builder.AppendValues(data.Data, data.ValidData)
+		return builder.NewArray(), nil
+	case *storage.DoubleFieldData:
+		builder := array.NewFloat64Builder(mem)
+		builder.AppendValues(data.Data, data.ValidData)
+		return builder.NewArray(), nil
+	case *storage.StringFieldData:
+		builder := array.NewStringBuilder(mem)
+		builder.AppendValues(data.Data, data.ValidData)
+		return builder.NewArray(), nil
+	case *storage.JSONFieldData:
+		builder := array.NewStringBuilder(mem)
+		builder.AppendValues(lo.Map(data.Data, func(bs []byte, _ int) string {
+			return string(bs)
+		}), data.ValidData)
+		return builder.NewArray(), nil
+	case *storage.GeometryFieldData:
+		builder := array.NewStringBuilder(mem)
+		for i, wkb := range data.Data {
+			if len(data.ValidData) > 0 && !data.ValidData[i] {
+				builder.AppendNull()
+				continue
+			}
+			wkt, err := geo.WKBToWKT(wkb)
+			if err != nil {
+				return nil, err
+			}
+			builder.Append(wkt)
+		}
+		return builder.NewArray(), nil
+	case *storage.FloatVectorFieldData:
+		builder := array.NewListBuilder(mem, &arrow.Float32Type{})
+		valueBuilder := builder.ValueBuilder().(*array.Float32Builder)
+		for i := 0; i < len(data.Data)/data.Dim; i++ {
+			builder.Append(true)
+			valueBuilder.AppendValues(data.Data[i*data.Dim:(i+1)*data.Dim], nil)
+		}
+		return builder.NewArray(), nil
+	case *storage.BinaryVectorFieldData:
+		return buildBytesVectorArray(mem, data.Data, data.Dim/8), nil
+	case *storage.Float16VectorFieldData:
+		return buildBytesVectorArray(mem, data.Data, data.Dim*2), nil
+	case *storage.BFloat16VectorFieldData:
+		return buildBytesVectorArray(mem, data.Data, data.Dim*2), nil
+	case *storage.SparseFloatVectorFieldData:
+		builder := array.NewStringBuilder(mem)
+		for _, content := range data.GetContents() {
+			bs, err := json.Marshal(typeutil.SparseFloatBytesToMap(content))
+			if err != nil {
+				return nil, err
+			}
+			builder.Append(string(bs))
+		}
+		return builder.NewArray(), nil
+	case *storage.ArrayFieldData:
+		return buildListArray(mem, field, data)
+	default:
+		return nil, merr.WrapErrParameterInvalidMsg("unsupported data type %s of field %s",
+			field.GetDataType().String(), field.GetName())
+	}
+}
+
+func buildBytesVectorArray(mem memory.Allocator, data []byte, rowBytes int) arrow.Array {
+	builder := array.NewListBuilder(mem, &arrow.Uint8Type{})
+	valueBuilder := builder.ValueBuilder().(*array.Uint8Builder)
+	for i := 0; i < len(data)/rowBytes; i++ {
+		builder.Append(true)
+		valueBuilder.AppendValues(data[i*rowBytes:(i+1)*rowBytes], nil)
+	}
+	return builder.NewArray()
+}
+
+func buildListArray(mem memory.Allocator, field *schemapb.FieldSchema, data *storage.ArrayFieldData) (arrow.Array, error) {
+	elemType, err := convertToArrowDataType(field, true)
+	if err != nil {
+		return nil, err
+	}
+	builder := array.NewListBuilder(mem, elemType)
+	valueBuilder := builder.ValueBuilder()
+	for i, scalar := range data.Data {
+		if len(data.ValidData) > 0 && !data.ValidData[i] {
+			builder.AppendNull()
+			continue
+		}
+		builder.Append(true)
+		switch vb := valueBuilder.(type) {
+		case *array.BooleanBuilder:
+			vb.AppendValues(scalar.GetBoolData().GetData(), nil)
+		case *array.Int8Builder:
+			vb.AppendValues(lo.Map(scalar.GetIntData().GetData(), func(v int32, _ int) int8 { return int8(v) }), nil)
+		case *array.Int16Builder:
+			vb.AppendValues(lo.Map(scalar.GetIntData().GetData(), func(v int32, _ int) int16 { return int16(v) }), nil)
+		case *array.Int32Builder:
+			vb.AppendValues(scalar.GetIntData().GetData(), nil)
+		case *array.Int64Builder:
+			vb.AppendValues(scalar.GetLongData().GetData(), nil)
+		case *array.Float32Builder:
+			vb.AppendValues(scalar.GetFloatData().GetData(), nil)
+		case *array.Float64Builder:
+			vb.AppendValues(scalar.GetDoubleData().GetData(), nil)
+		case *array.StringBuilder:
+			vb.AppendValues(scalar.GetStringData().GetData(), nil)
+		default:
+			return nil, merr.WrapErrParameterInvalidMsg("unsupported element type %s of field %s",
+				field.GetElementType().String(), field.GetName())
+		}
+	}
+	return builder.NewArray(), nil
+}
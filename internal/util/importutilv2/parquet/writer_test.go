@@ -0,0 +1,99 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parquet
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
+	"github.com/milvus-io/milvus-proto/go-api/v2/schemapb"
+	"github.com/milvus-io/milvus/internal/storage"
+	"github.com/milvus-io/milvus/internal/util/testutil"
+	"github.com/milvus-io/milvus/pkg/common"
+	"github.com/milvus-io/milvus/pkg/util/paramtable"
+)
+
+func TestWriter(t *testing.T) {
+	paramtable.Init()
+	schema := &schemapb.CollectionSchema{
+		Fields: []*schemapb.FieldSchema{
+			{FieldID: common.RowIDField, Name: common.RowIDFieldName, DataType: schemapb.DataType_Int64},
+			{FieldID: common.TimeStampField, Name: common.TimeStampFieldName, DataType: schemapb.DataType_Int64},
+			{FieldID: 100, Name: "pk", DataType: schemapb.DataType_Int64, IsPrimaryKey: true},
+			{FieldID: 101, Name: "vec", DataType: schemapb.DataType_FloatVector, TypeParams: []*commonpb.KeyValuePair{{Key: common.DimKey, Value: "8"}}},
+			{FieldID: 102, Name: "bin", DataType: schemapb.DataType_BinaryVector, TypeParams: []*commonpb.KeyValuePair{{Key: common.DimKey, Value: "16"}}},
+			{FieldID: 103, Name: "sparse", DataType: schemapb.DataType_SparseFloatVector},
+			{FieldID: 104, Name: "str", DataType: schemapb.DataType_VarChar, TypeParams: []*commonpb.KeyValuePair{{Key: common.MaxLengthKey, Value: "256"}}},
+			{FieldID: 105, Name: "json", DataType: schemapb.DataType_JSON},
+			{FieldID: 106, Name: "arr", DataType: schemapb.DataType_Array, ElementType: schemapb.DataType_Int32, TypeParams: []*commonpb.KeyValuePair{{Key: common.MaxCapacityKey, Value: "64"}}},
+			{FieldID: 107, Name: "nullable", DataType: schemapb.DataType_Double, Nullable: true},
+		},
+	}
+	userSchema := &schemapb.CollectionSchema{Fields: ExportFields(schema)}
+	assert.Len(t, userSchema.GetFields(), 8)
+
+	ctx := context.Background()
+	f := storage.NewChunkManagerFactory("local", storage.RootPath("/tmp/milvus_test/test_parquet_writer/"))
+	cm, err := f.NewPersistentStorageChunkManager(ctx)
+	require.NoError(t, err)
+	filePath := fmt.Sprintf("/tmp/milvus_test/test_parquet_writer/%d.parquet", rand.Int())
+	defer cm.Remove(ctx, filePath)
+
+	w, err := NewWriter(ctx, cm, schema, filePath)
+	require.NoError(t, err)
+	batches := make([]*storage.InsertData, 0, 2)
+	for i := 0; i < 2; i++ {
+		insertData, err := testutil.CreateInsertData(userSchema, 50, 30)
+		require.NoError(t, err)
+		require.NoError(t, w.Write(insertData))
+		batches = append(batches, insertData)
+	}
+	assert.EqualValues(t, 100, w.Rows())
+	assert.NoError(t, w.Write(&storage.InsertData{Data: map[int64]storage.FieldData{}}))
+	require.NoError(t, w.Close())
+
+	reader, err := NewReader(ctx, cm, userSchema, filePath, 64*1024*1024)
+	require.NoError(t, err)
+	defer reader.Close()
+	actual, err := reader.Read()
+	require.NoError(t, err)
+	assert.Equal(t, 100, actual.GetRowNum())
+	for fieldID, data := range actual.Data {
+		for i := 0; i < data.RowNum(); i++ {
+			expect := batches[i/50].Data[fieldID].GetRow(i % 50)
+			assert.Equal(t, expect, data.GetRow(i), "field %d row %d", fieldID, i)
+		}
+	}
+	_, err = reader.Read()
+	assert.ErrorIs(t, err, io.EOF)
+
+	t.Run("missing field", func(t *testing.T) {
+		w, err := NewWriter(ctx, cm, schema, filePath)
+		require.NoError(t, err)
+		err = w.Write(&storage.InsertData{Data: map[int64]storage.FieldData{
+			100: &storage.Int64FieldData{Data: []int64{1}},
+		}})
+		assert.Error(t, err)
+	})
+}
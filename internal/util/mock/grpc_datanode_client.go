@@ -105,6 +105,14 @@ func (m *GrpcDataNodeClient) DropImport(ctx context.Context, req *datapb.DropImp
 	return &commonpb.Status{}, m.Err
 }
 
+func (m *GrpcDataNodeClient) Export(ctx context.Context, req *datapb.ExportTaskRequest, opts ...grpc.CallOption) (*commonpb.Status, error) {
+	return &commonpb.Status{}, m.Err
+}
+
+func (m *GrpcDataNodeClient) QueryExport(ctx context.Context, req *datapb.QueryExportRequest, opts ...grpc.CallOption) (*datapb.QueryExportResponse, error) {
+	return &datapb.QueryExportResponse{}, m.Err
+}
+
 func (m *GrpcDataNodeClient) QuerySlot(ctx context.Context, req *datapb.QuerySlotRequest, opts ...grpc.CallOption) (*datapb.QuerySlotResponse, error) {
 	return &datapb.QuerySlotResponse{}, m.Err
 }
@@ -294,6 +294,22 @@ func (m *GrpcRootCoordClient) OperatePrivilegeGroup(ctx context.Context, in *mil
 	return &commonpb.Status{}, m.Err
 }
 
+func (m *GrpcRootCoordClient) OperateRowPolicy(ctx context.Context, in *rootcoordpb.OperateRowPolicyRequest, opts ...grpc.CallOption) (*commonpb.Status, error) {
+	return &commonpb.Status{}, m.Err
+}
+
+func (m *GrpcRootCoordClient) ListRecycleBin(ctx context.Context, in *proxypb.ListRecycleBinRequest, opts ...grpc.CallOption) (*proxypb.ListRecycleBinResponse, error) {
+	return &proxypb.ListRecycleBinResponse{}, m.Err
+}
+
+func (m *GrpcRootCoordClient) UndropCollection(ctx context.Context, in *proxypb.UndropCollectionRequest, opts ...grpc.CallOption) (*commonpb.Status, error) {
+	return &commonpb.Status{}, m.Err
+}
+
+func (m *GrpcRootCoordClient) UndropPartition(ctx context.Context, in *proxypb.UndropPartitionRequest, opts ...grpc.CallOption) (*commonpb.Status, error) {
+	return &commonpb.Status{}, m.Err
+}
+
 func (m *GrpcRootCoordClient) Close() error {
 	return nil
 }
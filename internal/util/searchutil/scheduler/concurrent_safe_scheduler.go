@@ -29,6 +29,7 @@ func newScheduler(policy schedulePolicy) Scheduler {
 		policy:           policy,
 		receiveChan:      make(chan addTaskReq, maxReceiveChanSize),
 		execChan:         make(chan Task),
+		finishChan:       make(chan struct{}, 1),
 		pool:             conc.NewPool[any](maxReadConcurrency, conc.WithPreAlloc(true)),
 		gpuPool:          conc.NewPool[any](paramtable.Get().QueryNodeCfg.MaxGpuReadConcurrency.GetAsInt(), conc.WithPreAlloc(true)),
 		schedulerCounter: schedulerCounter{},
@@ -46,8 +47,11 @@ type scheduler struct {
 	policy      schedulePolicy
 	receiveChan chan addTaskReq
 	execChan    chan Task
-	pool        *conc.Pool[any]
-	gpuPool     *conc.Pool[any]
+	// finishChan notifies the schedule worker that a task is finished,
+	// only used by finishAwarePolicy.
+	finishChan chan struct{}
+	pool       *conc.Pool[any]
+	gpuPool    *conc.Pool[any]
 
 	// wg is the waitgroup for internal worker goroutine
 	wg sync.WaitGroup
@@ -121,9 +125,15 @@ func (s *scheduler) schedule() {
 			if !ok {
 				log.Info("receiveChan closed, processing remaining request")
 				// drain policy maintained task
-				for task != nil {
-					execChan <- task
-					s.updateWaitingTaskCounter(-1, -nq)
+				for task != nil || s.policy.Len() > 0 {
+					if task == nil {
+						// policy holds the task back until some running task finished.
+						<-s.finishChan
+						task = s.policy.Pop()
+						continue
+					}
+					s.execChan <- task
+					s.updateWaitingTaskCounter(-1, -task.NQ())
 					task = s.produceExecChan()
 				}
 				log.Info("all task put into exeChan, schedule worker exit")
@@ -133,6 +143,8 @@ func (s *scheduler) schedule() {
 			// Receive add operation request and return the process result.
 			// And consume recv chan as much as possible.
 			s.consumeRecvChan(req, maxReceiveChanBatchConsumeNum)
+		case <-s.finishChan:
+			// Some task finished, the task held back by policy may be ready to run.
 		case execChan <- task:
 			// Task sent, drop the ownership of sent task.
 			// Update waiting task counter.
@@ -220,11 +232,13 @@ func (s *scheduler) exec() {
 		// Skip this task if task is canceled.
 		if err := t.Canceled(); err != nil {
 			log.Warn("task canceled before executing", zap.Error(err))
+			s.finish(t)
 			t.Done(err)
 			continue
 		}
 		if err := t.PreExecute(); err != nil {
 			log.Warn("failed to pre-execute task", zap.Error(err))
+			s.finish(t)
 			t.Done(err)
 			continue
 		}
@@ -241,12 +255,26 @@ func (s *scheduler) exec() {
 			collector.Counter.Dec(metricsinfo.ExecuteQueueType)
 
 			// Notify task done.
+			s.finish(t)
 			t.Done(err)
 			return nil, err
 		})
 	}
 }
 
+// finish notifies the finishAwarePolicy that the task is finished.
+func (s *scheduler) finish(t Task) {
+	policy, ok := s.policy.(finishAwarePolicy)
+	if !ok {
+		return
+	}
+	policy.Finish(t)
+	select {
+	case s.finishChan <- struct{}{}:
+	default:
+	}
+}
+
 func (s *scheduler) getPool(t Task) *conc.Pool[any] {
 	if t.IsGpuIndex() {
 		return s.gpuPool
@@ -24,6 +24,38 @@ func TestScheduler(t *testing.T) {
 	t.Run("fifo", func(t *testing.T) {
 		testScheduler(t, newFIFOPolicy())
 	})
+	t.Run("weighted-fair-queuing", func(t *testing.T) {
+		paramtable.Get().Save(paramtable.Get().QueryNodeCfg.SchedulePolicyMaxConcurrencyPerTenant.Key, "2")
+		defer paramtable.Get().Reset(paramtable.Get().QueryNodeCfg.SchedulePolicyMaxConcurrencyPerTenant.Key)
+		testScheduler(t, newWeightedFairQueuingPolicy())
+	})
+	t.Run("weighted-fair-queuing_stop", func(t *testing.T) {
+		paramtable.Get().Save(paramtable.Get().QueryNodeCfg.SchedulePolicyMaxConcurrencyPerTenant.Key, "1")
+		defer paramtable.Get().Reset(paramtable.Get().QueryNodeCfg.SchedulePolicyMaxConcurrencyPerTenant.Key)
+		scheduler := newScheduler(newWeightedFairQueuingPolicy())
+		scheduler.Start()
+
+		var cnt atomic.Int32
+		tasks := make([]Task, 0, 10)
+		for i := 0; i < 10; i++ {
+			task := newMockTask(mockTaskConfig{
+				dbName:      "db",
+				executeCost: 10 * time.Millisecond,
+				execution: func(ctx context.Context) error {
+					cnt.Inc()
+					return nil
+				},
+			})
+			assert.NoError(t, scheduler.Add(task))
+			tasks = append(tasks, task)
+		}
+		// tasks held back by policy are executed before stop.
+		scheduler.Stop()
+		for _, task := range tasks {
+			assert.NoError(t, task.Wait())
+		}
+		assert.Equal(t, int32(10), cnt.Load())
+	})
 	t.Run("scheduler_not_working", func(t *testing.T) {
 		scheduler := newScheduler(newFIFOPolicy())
 
@@ -61,6 +93,7 @@ func testScheduler(t *testing.T, policy schedulePolicy) {
 		username := fmt.Sprintf("user_%d", rand.Int31n(int32(userN)))
 		task := newMockTask(mockTaskConfig{
 			username:    username,
+			dbName:      username,
 			nq:          int64(i),
 			executeCost: 10 * time.Millisecond,
 			execution: func(ctx context.Context) error {
@@ -19,6 +19,8 @@ type mockTaskConfig struct {
 	mergeAble   bool
 	nq          int64
 	username    string
+	dbName      string
+	rgName      string
 	executeCost time.Duration
 	execution   func(ctx context.Context) error
 }
@@ -40,6 +42,8 @@ func newMockTask(c mockTaskConfig) Task {
 		mergeAble:   c.mergeAble,
 		nq:          c.nq,
 		username:    c.username,
+		dbName:      c.dbName,
+		rgName:      c.rgName,
 		execution:   c.execution,
 		tr:          timerecord.NewTimeRecorderWithTrace(c.ctx, "searchTask"),
 	}
@@ -52,6 +56,8 @@ type MockTask struct {
 	mergeAble   bool
 	nq          int64
 	username    string
+	dbName      string
+	rgName      string
 	execution   func(ctx context.Context) error
 	tr          *timerecord.TimeRecorder
 }
@@ -65,6 +71,14 @@ func (t *MockTask) Username() string {
 	return t.username
 }
 
+func (t *MockTask) DBName() string {
+	return t.dbName
+}
+
+func (t *MockTask) ResourceGroup() string {
+	return t.rgName
+}
+
 func (t *MockTask) IsGpuIndex() bool {
 	return false
 }
@@ -20,6 +20,78 @@ func TestFIFOPolicy(t *testing.T) {
 	testCommonPolicyOperation(t, newFIFOPolicy())
 }
 
+func TestWeightedFairQueuingPolicy(t *testing.T) {
+	paramtable.Init()
+	testCommonPolicyOperation(t, newWeightedFairQueuingPolicy())
+
+	t.Run("weight", func(t *testing.T) {
+		params := paramtable.Get()
+		params.Save(params.QueryNodeCfg.SchedulePolicyDatabaseWeights.Key, `{"online": 3, "invalid": "abc"}`)
+		defer params.Reset(params.QueryNodeCfg.SchedulePolicyDatabaseWeights.Key)
+		params.Save(params.QueryNodeCfg.SchedulePolicyResourceGroupWeights.Key, `{"rg1": 2}`)
+		defer params.Reset(params.QueryNodeCfg.SchedulePolicyResourceGroupWeights.Key)
+
+		policy := newWeightedFairQueuingPolicy()
+		assert.Equal(t, float64(3), policy.weight(wfqTenant{dbName: "online"}))
+		assert.Equal(t, float64(6), policy.weight(wfqTenant{dbName: "online", resourceGroup: "rg1"}))
+		assert.Equal(t, float64(1), policy.weight(wfqTenant{dbName: "invalid"}))
+		assert.Equal(t, float64(1), policy.weight(wfqTenant{dbName: "batch"}))
+
+		// batch tenant push all its tasks first.
+		n := 40
+		for i := 0; i < n; i++ {
+			policy.Push(newMockTask(mockTaskConfig{dbName: "batch", nq: 10}))
+		}
+		for i := 0; i < n; i++ {
+			policy.Push(newMockTask(mockTaskConfig{dbName: "online", nq: 10}))
+		}
+		// online tenant gets 3/4 of the first popped tasks.
+		counter := make(map[string]int)
+		for i := 0; i < n; i++ {
+			task := policy.Pop()
+			assert.NotNil(t, task)
+			counter[task.DBName()]++
+			policy.Finish(task)
+		}
+		assert.InDelta(t, 30, counter["online"], 1)
+		assert.InDelta(t, 10, counter["batch"], 1)
+		for policy.Len() > 0 {
+			policy.Finish(policy.Pop())
+		}
+	})
+
+	t.Run("max_concurrency", func(t *testing.T) {
+		params := paramtable.Get()
+		params.Save(params.QueryNodeCfg.SchedulePolicyMaxConcurrencyPerTenant.Key, "2")
+		defer params.Reset(params.QueryNodeCfg.SchedulePolicyMaxConcurrencyPerTenant.Key)
+
+		policy := newWeightedFairQueuingPolicy()
+		for i := 0; i < 4; i++ {
+			policy.Push(newMockTask(mockTaskConfig{dbName: "batch"}))
+		}
+		policy.Push(newMockTask(mockTaskConfig{dbName: "online"}))
+
+		running := make([]Task, 0)
+		for task := policy.Pop(); task != nil; task = policy.Pop() {
+			running = append(running, task)
+		}
+		// 2 tasks of batch and 1 task of online are running.
+		assert.Equal(t, 3, len(running))
+		assert.Equal(t, 2, policy.Len())
+
+		for _, task := range running {
+			policy.Finish(task)
+		}
+		assert.NotNil(t, policy.Pop())
+		assert.NotNil(t, policy.Pop())
+		assert.Nil(t, policy.Pop())
+		assert.Equal(t, 0, policy.Len())
+
+		// finish a task not popped is ignored.
+		policy.Finish(newMockTask(mockTaskConfig{dbName: "unknown"}))
+	})
+}
+
 func testCrossUserMerge(t *testing.T, policy schedulePolicy) {
 	userN := 10
 	maxNQ := paramtable.Get().QueryNodeCfg.MaxGroupNQ.GetAsInt64()
@@ -2,6 +2,7 @@ package scheduler
 
 import (
 	"container/ring"
+	"math"
 	"time"
 )
 
@@ -207,3 +208,150 @@ func (q *fairPollingTaskQueue) pop(queueExpire time.Duration) (task Task) {
 	q.checkpoint = checkpoint
 	return
 }
+
+// wfqTenant is the owner of tasks in weighted fair queuing.
+type wfqTenant struct {
+	dbName        string
+	resourceGroup string
+}
+
+// newWfqTenant returns the tenant of given task.
+func newWfqTenant(task Task) wfqTenant {
+	return wfqTenant{
+		dbName:        task.DBName(),
+		resourceGroup: task.ResourceGroup(),
+	}
+}
+
+// wfqTaskEntry is a task waiting in weighted fair queue.
+type wfqTaskEntry struct {
+	task        Task
+	startTag    float64
+	enqueueTime time.Time
+	tenant      wfqTenant
+}
+
+// wfqTenantQueue is the fifo task queue of a tenant.
+type wfqTenantQueue struct {
+	tenant  wfqTenant
+	entries []*wfqTaskEntry
+	// virtual finish time of the last task of tenant.
+	finishTag        float64
+	cleanupTimestamp time.Time
+}
+
+// newWeightedFairTaskQueue create a start-time fair queuing task queue.
+// The cost of a task is its nq, every tenant get the share of cost proportional to its weight.
+func newWeightedFairTaskQueue() *weightedFairTaskQueue {
+	return &weightedFairTaskQueue{
+		queues: make(map[wfqTenant]*wfqTenantQueue),
+	}
+}
+
+// weightedFairTaskQueue is a weighted fair queue, not concurrent safe.
+type weightedFairTaskQueue struct {
+	count int
+	// virtual time is the start tag of the last popped task.
+	virtualTime float64
+	queues      map[wfqTenant]*wfqTenantQueue
+}
+
+// len returns the item count in weightedFairTaskQueue.
+func (q *weightedFairTaskQueue) len() int {
+	return q.count
+}
+
+// groupLen returns the length of a tenant queue.
+func (q *weightedFairTaskQueue) groupLen(tenant wfqTenant) int {
+	if queue, ok := q.queues[tenant]; ok {
+		return len(queue.entries)
+	}
+	return 0
+}
+
+// tryMerge try to merge given task into exists tasks of the same tenant,
+// the merged cost is charged to the tenant.
+func (q *weightedFairTaskQueue) tryMerge(tenant wfqTenant, task MergeTask, maxNQ int64, weight float64) bool {
+	queue, ok := q.queues[tenant]
+	if !ok {
+		return false
+	}
+	nqRest := maxNQ - task.NQ()
+	if nqRest <= 0 {
+		return false
+	}
+	for i := len(queue.entries) - 1; i >= 0; i-- {
+		if taskInQueue := tryIntoMergeTask(queue.entries[i].task); taskInQueue != nil {
+			if taskInQueue.NQ() <= nqRest && taskInQueue.MergeWith(task) {
+				queue.finishTag += wfqCost(task) / weight
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// push add a new task into the queue of its tenant.
+func (q *weightedFairTaskQueue) push(tenant wfqTenant, task Task, weight float64) {
+	queue, ok := q.queues[tenant]
+	if !ok {
+		queue = &wfqTenantQueue{tenant: tenant}
+		q.queues[tenant] = queue
+	}
+	startTag := math.Max(q.virtualTime, queue.finishTag)
+	queue.finishTag = startTag + wfqCost(task)/weight
+	queue.entries = append(queue.entries, &wfqTaskEntry{
+		task:        task,
+		startTag:    startTag,
+		enqueueTime: time.Now(),
+		tenant:      tenant,
+	})
+	q.count++
+}
+
+// pop pops the task with the smallest start tag among the tenants allowed to run.
+// Returns nil if there's no task or all the tenants with task are not allowed to run.
+// The expired empty tenant queues are removed.
+func (q *weightedFairTaskQueue) pop(allowed func(tenant wfqTenant) bool, queueExpire time.Duration) *wfqTaskEntry {
+	var selected *wfqTenantQueue
+	for tenant, queue := range q.queues {
+		if len(queue.entries) == 0 {
+			if time.Since(queue.cleanupTimestamp) > queueExpire {
+				delete(q.queues, tenant)
+			}
+			continue
+		}
+		if !allowed(tenant) {
+			continue
+		}
+		if selected == nil || queue.entries[0].before(selected.entries[0]) {
+			selected = queue
+		}
+	}
+	if selected == nil {
+		return nil
+	}
+
+	entry := selected.entries[0]
+	selected.entries[0] = nil
+	selected.entries = selected.entries[1:]
+	if len(selected.entries) == 0 {
+		selected.cleanupTimestamp = time.Now()
+	}
+	q.count--
+	q.virtualTime = math.Max(q.virtualTime, entry.startTag)
+	return entry
+}
+
+// before returns whether the entry should be scheduled before the other one.
+func (e *wfqTaskEntry) before(other *wfqTaskEntry) bool {
+	if e.startTag != other.startTag {
+		return e.startTag < other.startTag
+	}
+	return e.enqueueTime.Before(other.enqueueTime)
+}
+
+// wfqCost returns the cost of task, which is at least 1.
+func wfqCost(task Task) float64 {
+	return math.Max(float64(task.NQ()), 1)
+}
@@ -161,3 +161,53 @@ func TestFairPollingTaskQueue(t *testing.T) {
 	assert.True(t, q.tryMergeWithOtherGroup(username, tryIntoMergeTask(task), int64(n/userN)+1))
 	assert.Equal(t, 0, q.groupLen(username))
 }
+
+func TestWeightedFairTaskQueue(t *testing.T) {
+	q := newWeightedFairTaskQueue()
+	allowAll := func(tenant wfqTenant) bool { return true }
+	assert.Equal(t, 0, q.len())
+	assert.Nil(t, q.pop(allowAll, time.Minute))
+
+	tenantA := wfqTenant{dbName: "a"}
+	tenantB := wfqTenant{dbName: "b", resourceGroup: "rg"}
+	for i := 0; i < 4; i++ {
+		q.push(tenantA, newMockTask(mockTaskConfig{dbName: "a", nq: 2}), 1)
+		q.push(tenantB, newMockTask(mockTaskConfig{dbName: "b", rgName: "rg", nq: 2}), 2)
+	}
+	assert.Equal(t, 8, q.len())
+	assert.Equal(t, 4, q.groupLen(tenantA))
+	assert.Equal(t, 4, q.groupLen(tenantB))
+	assert.Equal(t, 0, q.groupLen(wfqTenant{}))
+
+	// tenant b has double weight, the start tags are a: 0,2,4,6 and b: 0,1,2,3.
+	expected := []wfqTenant{tenantA, tenantB, tenantB, tenantA, tenantB, tenantB, tenantA, tenantA}
+	for i, tenant := range expected {
+		entry := q.pop(allowAll, time.Minute)
+		assert.NotNil(t, entry)
+		assert.Equal(t, tenant, entry.tenant, i)
+	}
+	assert.Equal(t, 0, q.len())
+
+	// new task starts from the virtual time, no credit for idle tenant.
+	q.push(tenantA, newMockTask(mockTaskConfig{dbName: "a"}), 1)
+	q.push(tenantB, newMockTask(mockTaskConfig{dbName: "b", rgName: "rg"}), 2)
+	assert.Nil(t, q.pop(func(tenant wfqTenant) bool { return false }, time.Minute))
+	entry := q.pop(func(tenant wfqTenant) bool { return tenant == tenantB }, time.Minute)
+	assert.Equal(t, tenantB, entry.tenant)
+	assert.Equal(t, tenantA, q.pop(allowAll, time.Minute).tenant)
+
+	// merge task and charge the cost to tenant.
+	q.push(tenantA, newMockTask(mockTaskConfig{dbName: "a", nq: 1, mergeAble: true}), 1)
+	finishTag := q.queues[tenantA].finishTag
+	assert.True(t, q.tryMerge(tenantA, newMockTask(mockTaskConfig{dbName: "a", nq: 1, mergeAble: true}).(MergeTask), 10, 1))
+	assert.Equal(t, finishTag+1, q.queues[tenantA].finishTag)
+	assert.False(t, q.tryMerge(tenantB, newMockTask(mockTaskConfig{dbName: "b", nq: 1, mergeAble: true}).(MergeTask), 10, 1))
+	assert.False(t, q.tryMerge(tenantA, newMockTask(mockTaskConfig{dbName: "a", nq: 10, mergeAble: true}).(MergeTask), 10, 1))
+	assert.Equal(t, 1, q.len())
+	assert.Equal(t, int64(2), q.pop(allowAll, time.Minute).task.NQ())
+
+	// expire empty tenant queue.
+	time.Sleep(10 * time.Millisecond)
+	assert.Nil(t, q.pop(allowAll, time.Millisecond))
+	assert.Equal(t, 0, len(q.queues))
+}
@@ -3,8 +3,9 @@ package scheduler
 import "github.com/milvus-io/milvus/internal/proto/internalpb"
 
 const (
-	schedulePolicyNameFIFO            = "fifo"
-	schedulePolicyNameUserTaskPolling = "user-task-polling"
+	schedulePolicyNameFIFO                = "fifo"
+	schedulePolicyNameUserTaskPolling     = "user-task-polling"
+	schedulePolicyNameWeightedFairQueuing = "weighted-fair-queuing"
 )
 
 // NewScheduler create a scheduler by policyName.
@@ -20,6 +21,10 @@ func NewScheduler(policyName string) Scheduler {
 		return newScheduler(
 			newUserTaskPollingPolicy(),
 		)
+	case schedulePolicyNameWeightedFairQueuing:
+		return newScheduler(
+			newWeightedFairQueuingPolicy(),
+		)
 	default:
 		panic("invalid schedule task policy")
 	}
@@ -69,6 +74,17 @@ type schedulePolicy interface {
 	Len() int
 }
 
+// finishAwarePolicy is a schedulePolicy which need to know when the popped task is finished,
+// e.g. to limit the running task count. Pop may return nil even if Len is not zero,
+// the scheduler will retry Pop after some task finished.
+type finishAwarePolicy interface {
+	schedulePolicy
+
+	// Finish notify the policy that a popped task is finished.
+	// Concurrent safe.
+	Finish(task Task)
+}
+
 // MergeTask is a Task which can be merged with other task
 type MergeTask interface {
 	Task
@@ -84,6 +100,12 @@ type Task interface {
 	// Return "" if the task do not contain any user info.
 	Username() string
 
+	// Return the database name which task is belong to.
+	DBName() string
+
+	// Return the resource group which task is running in.
+	ResourceGroup() string
+
 	// Return whether the task would be running on GPU.
 	IsGpuIndex() bool
 
@@ -0,0 +1,137 @@
+package scheduler
+
+import (
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/milvus-io/milvus/pkg/log"
+	"github.com/milvus-io/milvus/pkg/metrics"
+	"github.com/milvus-io/milvus/pkg/util/paramtable"
+)
+
+var _ finishAwarePolicy = &weightedFairQueuingPolicy{}
+
+// newWeightedFairQueuingPolicy create a new weighted fair queuing schedule policy.
+func newWeightedFairQueuingPolicy() *weightedFairQueuingPolicy {
+	return &weightedFairQueuingPolicy{
+		queue:   newWeightedFairTaskQueue(),
+		running: make(map[wfqTenant]int),
+	}
+}
+
+// weightedFairQueuingPolicy is a tenant based weighted fair queuing schedule policy.
+// The tenant of task is the database and resource group of its collection,
+// the weight of a tenant is the weight of its database times the weight of its resource group.
+// The running task count of each tenant can be limited to avoid one tenant occupying all the workers.
+type weightedFairQueuingPolicy struct {
+	queue *weightedFairTaskQueue
+
+	// running task count of tenants, updated by Pop and Finish concurrently.
+	mu      sync.Mutex
+	running map[wfqTenant]int
+}
+
+// Push add a new task into scheduler, an error will be returned if scheduler reaches some limit.
+func (p *weightedFairQueuingPolicy) Push(task Task) (int, error) {
+	pt := paramtable.Get()
+	tenant := newWfqTenant(task)
+	weight := p.weight(tenant)
+
+	// Try to merge task with the same tenant if task is mergeable.
+	if t := tryIntoMergeTask(task); t != nil {
+		maxNQ := pt.QueryNodeCfg.MaxGroupNQ.GetAsInt64()
+		if p.queue.tryMerge(tenant, t, maxNQ, weight) {
+			return 0, nil
+		}
+	}
+
+	p.queue.push(tenant, task, weight)
+	metrics.QueryNodeSchedulerTenantPendingTasks.WithLabelValues(
+		fmt.Sprint(paramtable.GetNodeID()),
+		tenant.dbName,
+		tenant.resourceGroup,
+	).Inc()
+	return 1, nil
+}
+
+// Pop get the task next ready to run.
+// Nil is returned if all tenants having task reach the max concurrency.
+func (p *weightedFairQueuingPolicy) Pop() Task {
+	pt := paramtable.Get()
+	maxConcurrency := pt.QueryNodeCfg.SchedulePolicyMaxConcurrencyPerTenant.GetAsInt()
+	expire := pt.QueryNodeCfg.SchedulePolicyTaskQueueExpire.GetAsDuration(time.Second)
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	entry := p.queue.pop(func(tenant wfqTenant) bool {
+		return maxConcurrency <= 0 || p.running[tenant] < maxConcurrency
+	}, expire)
+	if entry == nil {
+		return nil
+	}
+	p.running[entry.tenant]++
+
+	nodeID := fmt.Sprint(paramtable.GetNodeID())
+	waitDuration := time.Since(entry.enqueueTime)
+	metrics.QueryNodeSchedulerTenantPendingTasks.WithLabelValues(nodeID, entry.tenant.dbName, entry.tenant.resourceGroup).Dec()
+	metrics.QueryNodeSchedulerTenantRunningTasks.WithLabelValues(nodeID, entry.tenant.dbName, entry.tenant.resourceGroup).Inc()
+	metrics.QueryNodeSchedulerTenantQueueLatency.WithLabelValues(nodeID, entry.tenant.dbName, entry.tenant.resourceGroup).
+		Observe(float64(waitDuration.Milliseconds()))
+	if threshold := pt.QueryNodeCfg.SchedulePolicyStarvationThreshold.GetAsDuration(time.Second); threshold > 0 && waitDuration > threshold {
+		metrics.QueryNodeSchedulerTenantStarvedTaskCount.WithLabelValues(nodeID, entry.tenant.dbName, entry.tenant.resourceGroup).Inc()
+	}
+	return entry.task
+}
+
+// Finish notify the policy that a popped task is finished.
+func (p *weightedFairQueuingPolicy) Finish(task Task) {
+	tenant := newWfqTenant(task)
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.running[tenant] <= 0 {
+		log.Warn("finish a task not running in weighted fair queuing policy",
+			zap.String("dbName", tenant.dbName),
+			zap.String("resourceGroup", tenant.resourceGroup))
+		return
+	}
+	p.running[tenant]--
+	if p.running[tenant] == 0 {
+		delete(p.running, tenant)
+	}
+	metrics.QueryNodeSchedulerTenantRunningTasks.WithLabelValues(
+		fmt.Sprint(paramtable.GetNodeID()),
+		tenant.dbName,
+		tenant.resourceGroup,
+	).Dec()
+}
+
+// Len get ready task counts.
+func (p *weightedFairQueuingPolicy) Len() int {
+	return p.queue.len()
+}
+
+// weight returns the weight of tenant, which is the weight of its database times the weight of its resource group.
+func (p *weightedFairQueuingPolicy) weight(tenant wfqTenant) float64 {
+	pt := paramtable.Get()
+	return parseWeight(pt.QueryNodeCfg.SchedulePolicyDatabaseWeights.GetAsJSONMap(), tenant.dbName) *
+		parseWeight(pt.QueryNodeCfg.SchedulePolicyResourceGroupWeights.GetAsJSONMap(), tenant.resourceGroup)
+}
+
+// parseWeight returns the weight of name in weights, 1 is returned if not set or invalid.
+func parseWeight(weights map[string]string, name string) float64 {
+	value, ok := weights[name]
+	if !ok {
+		return 1
+	}
+	weight, err := strconv.ParseFloat(value, 64)
+	if err != nil || weight <= 0 {
+		log.RatedWarn(60, "invalid weight of weighted fair queuing policy, use 1 instead", zap.String("name", name), zap.String("weight", value))
+		return 1
+	}
+	return weight
+}
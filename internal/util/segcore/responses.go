@@ -16,6 +16,14 @@ type SearchResult struct {
 	cSearchResult C.CSearchResult
 }
 
+// DataCount returns the number of rows visible to the search,
+// and the number of them passing the filter.
+func (r *SearchResult) DataCount() (total int64, filtered int64) {
+	var cTotal, cFiltered C.int64_t
+	C.GetSearchResultDataCount(r.cSearchResult, &cTotal, &cFiltered)
+	return int64(cTotal), int64(cFiltered)
+}
+
 func (r *SearchResult) Release() {
 	C.DeleteSearchResult(r.cSearchResult)
 	r.cSearchResult = nil
@@ -50,6 +50,8 @@ const (
 	// DefaultIDKey default id key for Session
 	DefaultIDKey         = "id"
 	SupportedLabelPrefix = "MILVUS_SERVER_LABEL_"
+	// LabelZone is the label of the availability zone where the server is, set by env MILVUS_SERVER_LABEL_ZONE.
+	LabelZone = "ZONE"
 )
 
 // SessionEventType session event type
@@ -118,6 +120,11 @@ func (s *SessionRaw) GetServerLabel() map[string]string {
 	return s.ServerLabels
 }
 
+// GetZone returns the availability zone of the server, empty if it's unknown.
+func (s *SessionRaw) GetZone() string {
+	return s.ServerLabels[LabelZone]
+}
+
 func (s *SessionRaw) IsTriggerKill() bool {
 	return s.TriggerKill
 }
@@ -336,10 +343,15 @@ func (s *Session) getServerID() (int64, error) {
 	return nodeID, nil
 }
 
+// GetZoneFromEnv returns the availability zone of the server of the role, empty if it's unknown.
+func GetZoneFromEnv(role string) string {
+	return GetServerLabelsFromEnv(role)[LabelZone]
+}
+
 func GetServerLabelsFromEnv(role string) map[string]string {
 	ret := make(map[string]string)
 	switch role {
-	case "querynode":
+	case "querynode", "proxy":
 		for _, value := range os.Environ() {
 			rs := []rune(value)
 			in := strings.Index(value, "=")
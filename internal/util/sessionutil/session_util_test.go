@@ -1080,6 +1080,19 @@ func (s *SessionSuite) TestGetSessions() {
 	assert.Equal(s.T(), "value2", ret["key2"])
 }
 
+func (s *SessionSuite) TestGetZone() {
+	assert.Empty(s.T(), GetZoneFromEnv("proxy"))
+
+	os.Setenv("MILVUS_SERVER_LABEL_ZONE", "az-1")
+	defer os.Unsetenv("MILVUS_SERVER_LABEL_ZONE")
+	assert.Equal(s.T(), "az-1", GetZoneFromEnv("querynode"))
+	assert.Equal(s.T(), "az-1", GetZoneFromEnv("proxy"))
+	assert.Empty(s.T(), GetZoneFromEnv("datanode"))
+
+	session := &Session{SessionRaw: SessionRaw{ServerLabels: GetServerLabelsFromEnv("querynode")}}
+	assert.Equal(s.T(), "az-1", session.GetZone())
+}
+
 func TestSessionSuite(t *testing.T) {
 	suite.Run(t, new(SessionSuite))
 }
@@ -23,6 +23,7 @@ import (
 	clientv3 "go.etcd.io/etcd/client/v3"
 
 	etcdkv "github.com/milvus-io/milvus/internal/kv/etcd"
+	sqlitekv "github.com/milvus-io/milvus/internal/kv/sqlite"
 	"github.com/milvus-io/milvus/internal/kv/tikv"
 	"github.com/milvus-io/milvus/pkg/kv"
 )
@@ -36,3 +37,8 @@ func NewTSOKVBase(client *clientv3.Client, tsoRoot, subPath string) kv.TxnKV {
 func NewTSOTiKVBase(client *txnkv.Client, tsoRoot, subPath string) kv.TxnKV {
 	return tikv.NewTiKV(client, path.Join(tsoRoot, subPath))
 }
+
+// NewTSOSQLiteBase returns a kv.TxnKV object
+func NewTSOSQLiteBase(db *sqlitekv.DB, tsoRoot, subPath string) kv.TxnKV {
+	return sqlitekv.NewSQLiteKV(db, path.Join(tsoRoot, subPath))
+}
@@ -101,6 +101,9 @@ const (
 	// SegmentBm25LogPath storage path const for bm25 statistic
 	SegmentBm25LogPath = `bm25_stats`
 
+	// SegmentFieldStatsLogPath storage path const for the stats of fields with field_stats enabled
+	SegmentFieldStatsLogPath = `field_stats`
+
 	// PartitionStatsPath storage path const for partition stats files
 	PartitionStatsPath = `part_stats`
 
@@ -148,6 +151,23 @@ const (
 	AnalyzerParamKey  = `analyzer_params`
 )
 
+// Row expiration
+const (
+	// TTLFieldKey marks an Int64 field as the expiration time of each row, in unix seconds.
+	// Rows whose value is not positive never expire.
+	TTLFieldKey = "ttl_field"
+)
+
+// Segment pruning
+const (
+	// FieldStatsKey makes the datanodes write the min/max and a filter of a scalar field for every segment,
+	// so that the delegator can skip the segments which cannot match the filter on the field.
+	// The value is the filter type, FieldStatsBloom or FieldStatsXor.
+	FieldStatsKey   = "field_stats"
+	FieldStatsBloom = "bloom"
+	FieldStatsXor   = "xor"
+)
+
 //  Collection properties key
 
 const (
@@ -178,6 +198,8 @@ const (
 	DatabaseMaxCollectionsKey   = "database.max.collections"
 	DatabaseForceDenyWritingKey = "database.force.deny.writing"
 	DatabaseForceDenyReadingKey = "database.force.deny.reading"
+	// the seconds that the dropped collections and partitions are kept in the recycle bin.
+	DatabaseRecycleRetentionKey = "database.recycle.retention.seconds"
 
 	// collection level load properties
 	CollectionReplicaNumber  = "collection.replica.number"
@@ -329,6 +351,21 @@ func DatabaseLevelResourceGroups(kvs []*commonpb.KeyValuePair) ([]string, error)
 	return nil, fmt.Errorf("database property not found: %s", DatabaseResourceGroups)
 }
 
+func DatabaseLevelRecycleRetention(kvs []*commonpb.KeyValuePair) (int64, error) {
+	for _, kv := range kvs {
+		if kv.Key == DatabaseRecycleRetentionKey {
+			retention, err := strconv.ParseInt(kv.Value, 10, 64)
+			if err != nil || retention < 0 {
+				return 0, fmt.Errorf("invalid database property: [key=%s] [value=%s]", kv.Key, kv.Value)
+			}
+
+			return retention, nil
+		}
+	}
+
+	return 0, fmt.Errorf("database property not found: %s", DatabaseRecycleRetentionKey)
+}
+
 func CollectionLevelReplicaNumber(kvs []*commonpb.KeyValuePair) (int64, error) {
 	for _, kv := range kvs {
 		if kv.Key == CollectionReplicaNumber {
@@ -52,6 +52,10 @@ func TestDatabaseProperties(t *testing.T) {
 			Key:   DatabaseResourceGroups,
 			Value: strings.Join([]string{"rg1", "rg2"}, ","),
 		},
+		{
+			Key:   DatabaseRecycleRetentionKey,
+			Value: "3600",
+		},
 	}
 
 	replicaNum, err := DatabaseLevelReplicaNumber(props)
@@ -63,6 +67,10 @@ func TestDatabaseProperties(t *testing.T) {
 	assert.Contains(t, rgs, "rg1")
 	assert.Contains(t, rgs, "rg2")
 
+	retention, err := DatabaseLevelRecycleRetention(props)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3600), retention)
+
 	// test prop not found
 	_, err = DatabaseLevelReplicaNumber(nil)
 	assert.Error(t, err)
@@ -70,6 +78,9 @@ func TestDatabaseProperties(t *testing.T) {
 	_, err = DatabaseLevelResourceGroups(nil)
 	assert.Error(t, err)
 
+	_, err = DatabaseLevelRecycleRetention(nil)
+	assert.Error(t, err)
+
 	// test invalid prop value
 
 	props = []*commonpb.KeyValuePair{
@@ -81,12 +92,19 @@ func TestDatabaseProperties(t *testing.T) {
 			Key:   DatabaseResourceGroups,
 			Value: "",
 		},
+		{
+			Key:   DatabaseRecycleRetentionKey,
+			Value: "-1",
+		},
 	}
 	_, err = DatabaseLevelReplicaNumber(props)
 	assert.Error(t, err)
 
 	_, err = DatabaseLevelResourceGroups(props)
 	assert.Error(t, err)
+
+	_, err = DatabaseLevelRecycleRetention(props)
+	assert.Error(t, err)
 }
 
 func TestCommonPartitionKeyIsolation(t *testing.T) {
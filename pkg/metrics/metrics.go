@@ -32,6 +32,9 @@ const (
 	CancelLabel  = "cancel"
 	TotalLabel   = "total"
 
+	// PrimaryWonLabel is the status of the hedged shard requests whose primary returned the result first
+	PrimaryWonLabel = "primary_won"
+
 	HybridSearchLabel = "hybrid_search"
 
 	InsertLabel    = "insert"
@@ -417,6 +417,17 @@ var (
 			Name:      "recall_search_cnt",
 			Help:      "counter of recall search",
 		}, []string{nodeIDLabelName, queryTypeLabelName, collectionName})
+
+	// ProxyHedgedRequestCount records the shard requests hedged to another replica,
+	// the status is success if the hedged request returned the result first, primary_won if
+	// the primary request returned the result first, and fail if both of them failed.
+	ProxyHedgedRequestCount = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: milvusNamespace,
+			Subsystem: typeutil.ProxyRole,
+			Name:      "hedged_request_cnt",
+			Help:      "counter of shard requests hedged to another replica",
+		}, []string{nodeIDLabelName, statusLabelName})
 )
 
 // RegisterProxy registers Proxy metrics
@@ -478,6 +489,7 @@ func RegisterProxy(registry *prometheus.Registry) {
 	registry.MustRegister(ProxyRetrySearchCount)
 	registry.MustRegister(ProxyRetrySearchResultInsufficientCount)
 	registry.MustRegister(ProxyRecallSearchCount)
+	registry.MustRegister(ProxyHedgedRequestCount)
 
 	RegisterStreamingServiceClient(registry)
 }
@@ -218,6 +218,55 @@ var (
 		},
 	)
 
+	QueryNodeSchedulerTenantPendingTasks = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: milvusNamespace,
+			Subsystem: typeutil.QueryNodeRole,
+			Name:      "scheduler_tenant_pending_tasks",
+			Help:      "number of read tasks pending in scheduler per tenant",
+		}, []string{
+			nodeIDLabelName,
+			databaseLabelName,
+			resourceGroupLabelName,
+		})
+
+	QueryNodeSchedulerTenantRunningTasks = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: milvusNamespace,
+			Subsystem: typeutil.QueryNodeRole,
+			Name:      "scheduler_tenant_running_tasks",
+			Help:      "number of read tasks running per tenant",
+		}, []string{
+			nodeIDLabelName,
+			databaseLabelName,
+			resourceGroupLabelName,
+		})
+
+	QueryNodeSchedulerTenantQueueLatency = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: milvusNamespace,
+			Subsystem: typeutil.QueryNodeRole,
+			Name:      "scheduler_tenant_queue_latency",
+			Help:      "latency of read task waiting in scheduler per tenant",
+			Buckets:   buckets,
+		}, []string{
+			nodeIDLabelName,
+			databaseLabelName,
+			resourceGroupLabelName,
+		})
+
+	QueryNodeSchedulerTenantStarvedTaskCount = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: milvusNamespace,
+			Subsystem: typeutil.QueryNodeRole,
+			Name:      "scheduler_tenant_starved_task_count",
+			Help:      "count of read tasks waiting in scheduler longer than the starvation threshold per tenant",
+		}, []string{
+			nodeIDLabelName,
+			databaseLabelName,
+			resourceGroupLabelName,
+		})
+
 	QueryNodeSQSegmentLatency = prometheus.NewHistogramVec(
 		prometheus.HistogramOpts{
 			Namespace: milvusNamespace,
@@ -818,6 +867,10 @@ func RegisterQueryNode(registry *prometheus.Registry) {
 	registry.MustRegister(QueryNodeSQLatencyWaitTSafe)
 	registry.MustRegister(QueryNodeSQLatencyInQueue)
 	registry.MustRegister(QueryNodeSQPerUserLatencyInQueue)
+	registry.MustRegister(QueryNodeSchedulerTenantPendingTasks)
+	registry.MustRegister(QueryNodeSchedulerTenantRunningTasks)
+	registry.MustRegister(QueryNodeSchedulerTenantQueueLatency)
+	registry.MustRegister(QueryNodeSchedulerTenantStarvedTaskCount)
 	registry.MustRegister(QueryNodeSQSegmentLatency)
 	registry.MustRegister(QueryNodeSQSegmentLatencyInCore)
 	registry.MustRegister(QueryNodeReduceLatency)
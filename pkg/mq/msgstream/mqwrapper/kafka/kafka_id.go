@@ -11,6 +11,18 @@ type kafkaID struct {
 
 var _ mqcommon.MessageID = &kafkaID{}
 
+// NewKafkaID creates a new kafkaID
+func NewKafkaID(messageID int64) mqcommon.MessageID {
+	return &kafkaID{messageID: messageID}
+}
+
+// KafkaID returns the message id for conversion
+// Don't delete this function until conversion logic removed.
+// TODO: remove in future.
+func (kid *kafkaID) KafkaID() int64 {
+	return kid.messageID
+}
+
 func (kid *kafkaID) Serialize() []byte {
 	return SerializeKafkaID(kid.messageID)
 }
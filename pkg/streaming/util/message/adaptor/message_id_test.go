@@ -4,8 +4,10 @@ import (
 	"testing"
 
 	"github.com/apache/pulsar-client-go/pulsar"
+	"github.com/confluentinc/confluent-kafka-go/kafka"
 	"github.com/stretchr/testify/assert"
 
+	msgkafka "github.com/milvus-io/milvus/pkg/streaming/walimpls/impls/kafka"
 	msgpulsar "github.com/milvus-io/milvus/pkg/streaming/walimpls/impls/pulsar"
 	"github.com/milvus-io/milvus/pkg/streaming/walimpls/impls/rmq"
 )
@@ -17,4 +19,7 @@ func TestIDConvension(t *testing.T) {
 	msgID := pulsar.EarliestMessageID()
 	id = MustGetMessageIDFromMQWrapperID(MustGetMQWrapperIDFromMessage(msgpulsar.NewPulsarID(msgID)))
 	assert.True(t, id.EQ(msgpulsar.NewPulsarID(msgID)))
+
+	id = MustGetMessageIDFromMQWrapperID(MustGetMQWrapperIDFromMessage(msgkafka.NewKafkaID(kafka.Offset(1))))
+	assert.True(t, id.EQ(msgkafka.NewKafkaID(kafka.Offset(1))))
 }
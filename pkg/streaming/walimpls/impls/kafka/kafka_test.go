@@ -0,0 +1,40 @@
+package kafka
+
+import (
+	"testing"
+
+	"github.com/confluentinc/confluent-kafka-go/kafka"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/milvus-io/milvus/pkg/streaming/util/message"
+	"github.com/milvus-io/milvus/pkg/streaming/walimpls"
+	"github.com/milvus-io/milvus/pkg/streaming/walimpls/registry"
+	"github.com/milvus-io/milvus/pkg/util/paramtable"
+)
+
+func TestMain(m *testing.M) {
+	paramtable.Init()
+	m.Run()
+}
+
+func TestRegistry(t *testing.T) {
+	registeredB := registry.MustGetBuilder(walName)
+	assert.NotNil(t, registeredB)
+	assert.Equal(t, walName, registeredB.Name())
+
+	id, err := message.UnmarshalMessageID(walName,
+		kafkaID(123).Marshal())
+	assert.NoError(t, err)
+	assert.True(t, id.EQ(kafkaID(123)))
+}
+
+func TestKafka(t *testing.T) {
+	mockCluster, err := kafka.NewMockCluster(1)
+	assert.NoError(t, err)
+	defer mockCluster.Close()
+
+	paramtable.Get().Save(paramtable.Get().KafkaCfg.Address.Key, mockCluster.BootstrapServers())
+	defer paramtable.Get().Reset(paramtable.Get().KafkaCfg.Address.Key)
+
+	walimpls.NewWALImplsTestFramework(t, 100, &builderImpl{}).Run()
+}
@@ -0,0 +1,77 @@
+package kafka
+
+import (
+	"context"
+
+	"github.com/confluentinc/confluent-kafka-go/kafka"
+	"go.uber.org/zap"
+
+	"github.com/milvus-io/milvus/pkg/log"
+	"github.com/milvus-io/milvus/pkg/streaming/walimpls"
+	"github.com/milvus-io/milvus/pkg/streaming/walimpls/helper"
+	"github.com/milvus-io/milvus/pkg/util/syncutil"
+)
+
+var _ walimpls.OpenerImpls = (*openerImpl)(nil)
+
+// newOpenerImpl creates a new openerImpl instance.
+func newOpenerImpl(p *kafka.Producer, consumerConfig kafka.ConfigMap) *openerImpl {
+	o := &openerImpl{
+		n:              syncutil.NewAsyncTaskNotifier[struct{}](),
+		p:              p,
+		consumerConfig: consumerConfig,
+	}
+	go o.execute()
+	return o
+}
+
+// openerImpl is the opener implementation for kafka wal.
+// The producer is shared by all wal instances of the opener,
+// because the kafka producer is not bound to a topic.
+type openerImpl struct {
+	n              *syncutil.AsyncTaskNotifier[struct{}]
+	p              *kafka.Producer
+	consumerConfig kafka.ConfigMap
+}
+
+// Open opens a wal instance.
+func (o *openerImpl) Open(ctx context.Context, opt *walimpls.OpenOption) (walimpls.WALImpls, error) {
+	return &walImpl{
+		WALHelper:      helper.NewWALHelper(opt),
+		p:              o.p,
+		consumerConfig: o.consumerConfig,
+	}, nil
+}
+
+// execute handles the global events of the producer, the delivery reports are handled by the wal itself.
+func (o *openerImpl) execute() {
+	defer o.n.Finish(struct{}{})
+
+	for {
+		select {
+		case <-o.n.Context().Done():
+			return
+		case ev, ok := <-o.p.Events():
+			if !ok {
+				panic("kafka producer events channel should never be closed before the execute observer exit")
+			}
+			switch ev := ev.(type) {
+			case kafka.Error:
+				log.Error("kafka producer error", zap.Error(ev))
+				if ev.IsFatal() {
+					panic(ev)
+				}
+			default:
+				// ignore other events
+				log.Debug("kafka producer incoming non-message, non-error event", zap.String("event", ev.String()))
+			}
+		}
+	}
+}
+
+// Close closes the opener resources.
+func (o *openerImpl) Close() {
+	o.n.Cancel()
+	o.n.BlockUntilFinish()
+	o.p.Close()
+}
@@ -0,0 +1,119 @@
+package kafka
+
+import (
+	"context"
+
+	"github.com/cockroachdb/errors"
+	"github.com/confluentinc/confluent-kafka-go/kafka"
+	"go.uber.org/zap"
+
+	"github.com/milvus-io/milvus/pkg/streaming/proto/streamingpb"
+	"github.com/milvus-io/milvus/pkg/streaming/util/message"
+	"github.com/milvus-io/milvus/pkg/streaming/walimpls"
+	"github.com/milvus-io/milvus/pkg/streaming/walimpls/helper"
+)
+
+var _ walimpls.WALImpls = (*walImpl)(nil)
+
+// walImpl is the implementation of walimpls.WAL interface.
+// Every pchannel is mapped to a kafka topic with only one partition (partition 0).
+type walImpl struct {
+	*helper.WALHelper
+	p              *kafka.Producer
+	consumerConfig kafka.ConfigMap
+}
+
+func (w *walImpl) WALName() string {
+	return walName
+}
+
+// Append appends a message to the wal.
+func (w *walImpl) Append(ctx context.Context, msg message.MutableMessage) (message.MessageID, error) {
+	properties := msg.Properties().ToRawMap()
+	headers := make([]kafka.Header, 0, len(properties))
+	for key, value := range properties {
+		headers = append(headers, kafka.Header{Key: key, Value: []byte(value)})
+	}
+	ch := make(chan kafka.Event, 1)
+	topic := w.Channel().Name
+
+	if err := w.p.Produce(&kafka.Message{
+		TopicPartition: kafka.TopicPartition{Topic: &topic, Partition: 0},
+		Value:          msg.Payload(),
+		Headers:        headers,
+	}, ch); err != nil {
+		w.Log().RatedWarn(1, "send message to kafka failed", zap.Error(err))
+		return nil, err
+	}
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case event := <-ch:
+		relatedMsg := event.(*kafka.Message)
+		if relatedMsg.TopicPartition.Error != nil {
+			w.Log().RatedWarn(1, "send message to kafka failed", zap.Error(relatedMsg.TopicPartition.Error))
+			return nil, relatedMsg.TopicPartition.Error
+		}
+		return kafkaID(relatedMsg.TopicPartition.Offset), nil
+	}
+}
+
+// Read create a scanner to read the wal.
+func (w *walImpl) Read(ctx context.Context, opt walimpls.ReadOption) (s walimpls.ScannerImpls, err error) {
+	// The scanner is stateless, so we can create a scanner with an anonymous consumer group.
+	// and there's no commit operations.
+	consumerConfig := cloneKafkaConfig(w.consumerConfig)
+	consumerConfig.SetKey("group.id", opt.Name)
+	c, err := kafka.NewConsumer(&consumerConfig)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create kafka consumer")
+	}
+	defer func() {
+		if err != nil {
+			// release the consumer if following operation is failure.
+			// to avoid resource leak.
+			c.Close()
+		}
+	}()
+
+	topic := w.Channel().Name
+	seekPosition := kafka.TopicPartition{
+		Topic:     &topic,
+		Partition: 0,
+	}
+	var exclude *kafkaID
+	switch t := opt.DeliverPolicy.GetPolicy().(type) {
+	case *streamingpb.DeliverPolicy_All:
+		seekPosition.Offset = kafka.OffsetBeginning
+	case *streamingpb.DeliverPolicy_Latest:
+		seekPosition.Offset = kafka.OffsetEnd
+	case *streamingpb.DeliverPolicy_StartFrom:
+		id, err := unmarshalMessageID(t.StartFrom.GetId())
+		if err != nil {
+			return nil, err
+		}
+		seekPosition.Offset = kafka.Offset(id)
+	case *streamingpb.DeliverPolicy_StartAfter:
+		id, err := unmarshalMessageID(t.StartAfter.GetId())
+		if err != nil {
+			return nil, err
+		}
+		// Do a inclusive seek and filter the first message out in scanner.
+		seekPosition.Offset = kafka.Offset(id)
+		exclude = &id
+	default:
+		return nil, errors.Errorf("unknown deliver policy: %T", t)
+	}
+
+	if err := c.Assign([]kafka.TopicPartition{seekPosition}); err != nil {
+		return nil, errors.Wrap(err, "failed to assign kafka consumer")
+	}
+	return newScanner(opt.Name, exclude, c), nil
+}
+
+// Close closes the wal.
+func (w *walImpl) Close() {
+	// The producer is shared by all wal instances and owned by the opener,
+	// so it's not closed here.
+}
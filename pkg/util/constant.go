@@ -28,8 +28,9 @@ import (
 
 // Meta Prefix consts
 const (
-	MetaStoreTypeEtcd = "etcd"
-	MetaStoreTypeTiKV = "tikv"
+	MetaStoreTypeEtcd   = "etcd"
+	MetaStoreTypeTiKV   = "tikv"
+	MetaStoreTypeSQLite = "sqlite"
 
 	SegmentMetaPrefix    = "queryCoord-segmentMeta"
 	ChangeInfoMetaPrefix = "queryCoord-sealedSegmentChangeInfo"
@@ -64,10 +65,18 @@ const (
 	PrivilegeGroupWord = "PrivilegeGroup"
 	AnyWord            = "*"
 
+	// PrivilegeMaskField hides a field of the collection from the granted role, the object name
+	// of the grant is the collection name and the field name joined by FieldObjectNameSeparator.
+	// It is not defined in commonpb.ObjectPrivilege, so it is not part of any built-in privilege group.
+	PrivilegeMaskField       = "PrivilegeMaskField"
+	FieldObjectNameSeparator = "$"
+
 	IdentifierKey = "identifier"
 
 	HeaderUserAgent = "user-agent"
 	HeaderDBName    = "dbName"
+	// HeaderTxnID is the metadata carrying the id of the transaction which the dml request joins.
+	HeaderTxnID = "txn-id"
 
 	RoleConfigPrivileges = "privileges"
 	RoleConfigObjectType = "object_type"
@@ -121,6 +130,8 @@ var (
 			MetaStore2API(commonpb.ObjectPrivilege_PrivilegeDropPrivilegeGroup.String()),
 			MetaStore2API(commonpb.ObjectPrivilege_PrivilegeListPrivilegeGroups.String()),
 			MetaStore2API(commonpb.ObjectPrivilege_PrivilegeOperatePrivilegeGroup.String()),
+
+			MetaStore2API(PrivilegeMaskField),
 		},
 		commonpb.ObjectType_Global.String(): {
 			MetaStore2API(commonpb.ObjectPrivilege_PrivilegeAll.String()),
@@ -416,7 +427,7 @@ func MetaStore2API(name string) string {
 
 func PrivilegeNameForAPI(name string) string {
 	_, ok := commonpb.ObjectPrivilege_value[name]
-	if !ok {
+	if !ok && name != PrivilegeMaskField {
 		if strings.HasPrefix(name, PrivilegeGroupWord) {
 			return typeutil.After(name, PrivilegeGroupWord)
 		}
@@ -429,7 +440,7 @@ func PrivilegeNameForMetastore(name string) string {
 	// check if name is single privilege
 	dbPrivilege := PrivilegeWord + name
 	_, ok := commonpb.ObjectPrivilege_value[dbPrivilege]
-	if !ok {
+	if !ok && dbPrivilege != PrivilegeMaskField {
 		// check if name is privilege group
 		dbPrivilege := PrivilegeGroupWord + name
 		_, ok := commonpb.ObjectPrivilege_value[dbPrivilege]
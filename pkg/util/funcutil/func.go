@@ -379,6 +379,8 @@ func GetNumRowOfFieldDataWithSchema(fieldData *schemapb.FieldData, helper *typeu
 		fieldNumRows = getNumRowsOfScalarField(fieldData.GetScalars().GetArrayData().GetData())
 	case schemapb.DataType_JSON:
 		fieldNumRows = getNumRowsOfScalarField(fieldData.GetScalars().GetJsonData().GetData())
+	case schemapb.DataType_Geometry:
+		fieldNumRows = getNumRowsOfScalarField(fieldData.GetScalars().GetGeometryData().GetData())
 	case schemapb.DataType_FloatVector:
 		dim := fieldData.GetVectors().GetDim()
 		fieldNumRows, err = GetNumRowsOfFloatVectorField(fieldData.GetVectors().GetFloatVector().GetData(), dim)
@@ -436,6 +438,8 @@ func GetNumRowOfFieldData(fieldData *schemapb.FieldData) (uint64, error) {
 			fieldNumRows = getNumRowsOfScalarField(scalarField.GetArrayData().Data)
 		case *schemapb.ScalarField_JsonData:
 			fieldNumRows = getNumRowsOfScalarField(scalarField.GetJsonData().Data)
+		case *schemapb.ScalarField_GeometryData:
+			fieldNumRows = getNumRowsOfScalarField(scalarField.GetGeometryData().Data)
 		default:
 			return 0, fmt.Errorf("%s is not supported now", scalarType)
 		}
@@ -535,3 +539,17 @@ func DecodeUserRoleCache(cache string) (string, string, error) {
 	role := cache[index+1:]
 	return user, role, nil
 }
+
+// EncodeRowPolicyCache encodes the row policy into a cache op key, the expr is put at last
+// since it may contain the separator.
+func EncodeRowPolicyCache(role string, dbName string, collectionName string, expr string) string {
+	return fmt.Sprintf("%s/%s/%s/%s", role, dbName, collectionName, expr)
+}
+
+func DecodeRowPolicyCache(cache string) (string, string, string, string, error) {
+	parts := strings.SplitN(cache, "/", 4)
+	if len(parts) != 4 {
+		return "", "", "", "", fmt.Errorf("invalid param, cache: [%s]", cache)
+	}
+	return parts[0], parts[1], parts[2], parts[3], nil
+}
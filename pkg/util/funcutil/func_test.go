@@ -562,6 +562,19 @@ func TestUserRoleCache(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestRowPolicyCache(t *testing.T) {
+	cache := EncodeRowPolicyCache("tenant1", "default", "coll", "tenant == 1 and path like \"a/b%\"")
+	role, db, coll, expr, err := DecodeRowPolicyCache(cache)
+	assert.NoError(t, err)
+	assert.Equal(t, "tenant1", role)
+	assert.Equal(t, "default", db)
+	assert.Equal(t, "coll", coll)
+	assert.Equal(t, "tenant == 1 and path like \"a/b%\"", expr)
+
+	_, _, _, _, err = DecodeRowPolicyCache("tenant1/default")
+	assert.Error(t, err)
+}
+
 func TestMapToJSON(t *testing.T) {
 	s := `{"M": 30,"efConstruction": 360,"index_type": "HNSW", "metric_type": "IP"}`
 	m, err := JSONToMap(s)
@@ -144,6 +144,21 @@ func SplitObjectName(objectName string) (string, string) {
 	return names[0], names[1]
 }
 
+// CombineFieldObjectName returns the object name of the field privileges.
+func CombineFieldObjectName(collectionName string, fieldName string) string {
+	return collectionName + util.FieldObjectNameSeparator + fieldName
+}
+
+// SplitFieldObjectName splits the object name of the field privileges into the collection name and the field name,
+// field names never contain the separator while collection names may.
+func SplitFieldObjectName(objectName string) (string, string, bool) {
+	idx := strings.LastIndex(objectName, util.FieldObjectNameSeparator)
+	if idx <= 0 || idx == len(objectName)-1 {
+		return "", "", false
+	}
+	return objectName[:idx], objectName[idx+1:], true
+}
+
 func PolicyCheckerWithRole(policy, roleName string) bool {
 	return strings.Contains(policy, fmt.Sprintf(`"V0":"%s"`, roleName))
 }
@@ -79,3 +79,22 @@ func Test_PolicyCheckerWithRole(t *testing.T) {
 	assert.True(t, PolicyCheckerWithRole(a, "admin"))
 	assert.False(t, PolicyCheckerWithRole(b, "admin"))
 }
+
+func Test_FieldObjectName(t *testing.T) {
+	objectName := CombineFieldObjectName("col1", "email")
+	assert.Equal(t, "col1$email", objectName)
+	collectionName, fieldName, ok := SplitFieldObjectName(objectName)
+	assert.True(t, ok)
+	assert.Equal(t, "col1", collectionName)
+	assert.Equal(t, "email", fieldName)
+
+	collectionName, fieldName, ok = SplitFieldObjectName("col$1$email")
+	assert.True(t, ok)
+	assert.Equal(t, "col$1", collectionName)
+	assert.Equal(t, "email", fieldName)
+
+	for _, name := range []string{"col1", "$email", "col1$", ""} {
+		_, _, ok = SplitFieldObjectName(name)
+		assert.False(t, ok, name)
+	}
+}
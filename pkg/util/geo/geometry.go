@@ -0,0 +1,399 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package geo implements the geometries stored in the Geometry fields. Users read and write the geometries
+// as WKT, e.g. POINT(116.4 39.9), they are stored as WKB. The coordinates are longitude and latitude in degrees.
+package geo
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+)
+
+// Type is the geometry type, the values follow the WKB geometry type codes.
+type Type uint32
+
+const (
+	TypePoint      Type = 1
+	TypeLineString Type = 2
+	TypePolygon    Type = 3
+)
+
+var typeNames = map[Type]string{
+	TypePoint:      "POINT",
+	TypeLineString: "LINESTRING",
+	TypePolygon:    "POLYGON",
+}
+
+func (t Type) String() string {
+	if name, ok := typeNames[t]; ok {
+		return name
+	}
+	return fmt.Sprintf("UNKNOWN(%d)", uint32(t))
+}
+
+// Point is a coordinate, X is the longitude and Y is the latitude in degrees.
+type Point struct {
+	X float64
+	Y float64
+}
+
+// Geometry is a point, a line string or a polygon.
+type Geometry struct {
+	Type Type
+	// Rings holds a single point for a point, the points for a line string,
+	// or the exterior ring followed by the holes for a polygon.
+	Rings [][]Point
+}
+
+func NewPoint(x, y float64) *Geometry {
+	return &Geometry{Type: TypePoint, Rings: [][]Point{{{X: x, Y: y}}}}
+}
+
+// Validate checks the geometry is well-formed and the coordinates are valid longitudes and latitudes.
+func (g *Geometry) Validate() error {
+	switch g.Type {
+	case TypePoint:
+		if len(g.Rings) != 1 || len(g.Rings[0]) != 1 {
+			return errors.New("a point must have exactly one coordinate")
+		}
+	case TypeLineString:
+		if len(g.Rings) != 1 || len(g.Rings[0]) < 2 {
+			return errors.New("a line string must have at least 2 points")
+		}
+	case TypePolygon:
+		if len(g.Rings) == 0 {
+			return errors.New("a polygon must have an exterior ring")
+		}
+		for _, ring := range g.Rings {
+			if len(ring) < 4 {
+				return errors.New("a polygon ring must have at least 4 points")
+			}
+			if ring[0] != ring[len(ring)-1] {
+				return errors.New("a polygon ring must be closed")
+			}
+		}
+	default:
+		return fmt.Errorf("unsupported geometry type %s", g.Type)
+	}
+	for _, ring := range g.Rings {
+		for _, p := range ring {
+			if math.IsNaN(p.X) || math.IsNaN(p.Y) || p.X < -180 || p.X > 180 || p.Y < -90 || p.Y > 90 {
+				return fmt.Errorf("invalid coordinate (%v %v), longitude must be in [-180, 180] and latitude in [-90, 90]", p.X, p.Y)
+			}
+		}
+	}
+	return nil
+}
+
+// WKT returns the well-known text of the geometry.
+func (g *Geometry) WKT() string {
+	var sb strings.Builder
+	sb.WriteString(g.Type.String())
+	writeRing := func(ring []Point) {
+		sb.WriteByte('(')
+		for i, p := range ring {
+			if i > 0 {
+				sb.WriteString(", ")
+			}
+			sb.WriteString(strconv.FormatFloat(p.X, 'f', -1, 64))
+			sb.WriteByte(' ')
+			sb.WriteString(strconv.FormatFloat(p.Y, 'f', -1, 64))
+		}
+		sb.WriteByte(')')
+	}
+	if g.Type == TypePolygon {
+		sb.WriteByte('(')
+		for i, ring := range g.Rings {
+			if i > 0 {
+				sb.WriteString(", ")
+			}
+			writeRing(ring)
+		}
+		sb.WriteByte(')')
+	} else {
+		writeRing(g.Rings[0])
+	}
+	return sb.String()
+}
+
+// WKB returns the little endian well-known binary of the geometry.
+func (g *Geometry) WKB() []byte {
+	size := 5
+	if g.Type != TypePoint {
+		size += 4
+	}
+	for _, ring := range g.Rings {
+		if g.Type == TypePolygon {
+			size += 4
+		}
+		size += len(ring) * 16
+	}
+	buf := make([]byte, 0, size)
+	buf = append(buf, 1)
+	buf = binary.LittleEndian.AppendUint32(buf, uint32(g.Type))
+	if g.Type == TypePolygon {
+		buf = binary.LittleEndian.AppendUint32(buf, uint32(len(g.Rings)))
+	}
+	for _, ring := range g.Rings {
+		if g.Type != TypePoint {
+			buf = binary.LittleEndian.AppendUint32(buf, uint32(len(ring)))
+		}
+		for _, p := range ring {
+			buf = binary.LittleEndian.AppendUint64(buf, math.Float64bits(p.X))
+			buf = binary.LittleEndian.AppendUint64(buf, math.Float64bits(p.Y))
+		}
+	}
+	return buf
+}
+
+// ParseWKB parses and validates the well-known binary, both byte orders are accepted.
+func ParseWKB(data []byte) (*Geometry, error) {
+	if len(data) < 5 {
+		return nil, errors.New("invalid wkb, too short")
+	}
+	var order binary.ByteOrder
+	switch data[0] {
+	case 0:
+		order = binary.BigEndian
+	case 1:
+		order = binary.LittleEndian
+	default:
+		return nil, fmt.Errorf("invalid wkb byte order %d", data[0])
+	}
+	offset := 1
+	readUint32 := func() (uint32, error) {
+		if offset+4 > len(data) {
+			return 0, errors.New("invalid wkb, unexpected end of data")
+		}
+		v := order.Uint32(data[offset:])
+		offset += 4
+		return v, nil
+	}
+	readPoints := func(n uint32) ([]Point, error) {
+		if uint64(offset)+uint64(n)*16 > uint64(len(data)) {
+			return nil, errors.New("invalid wkb, unexpected end of data")
+		}
+		points := make([]Point, n)
+		for i := range points {
+			points[i].X = math.Float64frombits(order.Uint64(data[offset:]))
+			points[i].Y = math.Float64frombits(order.Uint64(data[offset+8:]))
+			offset += 16
+		}
+		return points, nil
+	}
+
+	geomType, err := readUint32()
+	if err != nil {
+		return nil, err
+	}
+	g := &Geometry{Type: Type(geomType)}
+	switch g.Type {
+	case TypePoint:
+		points, err := readPoints(1)
+		if err != nil {
+			return nil, err
+		}
+		g.Rings = [][]Point{points}
+	case TypeLineString:
+		n, err := readUint32()
+		if err != nil {
+			return nil, err
+		}
+		points, err := readPoints(n)
+		if err != nil {
+			return nil, err
+		}
+		g.Rings = [][]Point{points}
+	case TypePolygon:
+		numRings, err := readUint32()
+		if err != nil {
+			return nil, err
+		}
+		// each ring takes 4 bytes at least
+		if uint64(numRings)*4 > uint64(len(data)-offset) {
+			return nil, errors.New("invalid wkb, unexpected end of data")
+		}
+		g.Rings = make([][]Point, 0, numRings)
+		for i := uint32(0); i < numRings; i++ {
+			n, err := readUint32()
+			if err != nil {
+				return nil, err
+			}
+			points, err := readPoints(n)
+			if err != nil {
+				return nil, err
+			}
+			g.Rings = append(g.Rings, points)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported geometry type %d, only POINT, LINESTRING and POLYGON are supported", geomType)
+	}
+	if offset != len(data) {
+		return nil, errors.New("invalid wkb, trailing data")
+	}
+	if err := g.Validate(); err != nil {
+		return nil, err
+	}
+	return g, nil
+}
+
+// ParseWKT parses and validates the well-known text, e.g. POINT(1 2), LINESTRING(0 0, 1 1), POLYGON((0 0, 1 0, 1 1, 0 0)).
+func ParseWKT(wkt string) (*Geometry, error) {
+	p := &wktParser{input: wkt}
+	g, err := p.parse()
+	if err != nil {
+		return nil, fmt.Errorf("invalid wkt %q: %w", wkt, err)
+	}
+	if err := g.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid wkt %q: %w", wkt, err)
+	}
+	return g, nil
+}
+
+// WKTToWKB converts the well-known text to the well-known binary stored in the Geometry fields.
+func WKTToWKB(wkt string) ([]byte, error) {
+	g, err := ParseWKT(wkt)
+	if err != nil {
+		return nil, err
+	}
+	return g.WKB(), nil
+}
+
+// WKBToWKT converts the well-known binary stored in the Geometry fields to the well-known text.
+func WKBToWKT(wkb []byte) (string, error) {
+	g, err := ParseWKB(wkb)
+	if err != nil {
+		return "", err
+	}
+	return g.WKT(), nil
+}
+
+type wktParser struct {
+	input string
+	pos   int
+}
+
+func (p *wktParser) skipSpaces() {
+	for p.pos < len(p.input) && (p.input[p.pos] == ' ' || p.input[p.pos] == '\t' || p.input[p.pos] == '\n' || p.input[p.pos] == '\r') {
+		p.pos++
+	}
+}
+
+func (p *wktParser) expect(c byte) error {
+	p.skipSpaces()
+	if p.pos >= len(p.input) || p.input[p.pos] != c {
+		return fmt.Errorf("expect '%c' at %d", c, p.pos)
+	}
+	p.pos++
+	return nil
+}
+
+// peek returns whether the next non-space character is c.
+func (p *wktParser) peek(c byte) bool {
+	p.skipSpaces()
+	return p.pos < len(p.input) && p.input[p.pos] == c
+}
+
+func (p *wktParser) number() (float64, error) {
+	p.skipSpaces()
+	start := p.pos
+	for p.pos < len(p.input) && strings.IndexByte("+-.0123456789eE", p.input[p.pos]) >= 0 {
+		p.pos++
+	}
+	v, err := strconv.ParseFloat(p.input[start:p.pos], 64)
+	if err != nil {
+		return 0, fmt.Errorf("expect a number at %d", start)
+	}
+	return v, nil
+}
+
+// points parses "(x y, x y, ...)".
+func (p *wktParser) points() ([]Point, error) {
+	if err := p.expect('('); err != nil {
+		return nil, err
+	}
+	var points []Point
+	for {
+		x, err := p.number()
+		if err != nil {
+			return nil, err
+		}
+		y, err := p.number()
+		if err != nil {
+			return nil, err
+		}
+		points = append(points, Point{X: x, Y: y})
+		if !p.peek(',') {
+			break
+		}
+		p.pos++
+	}
+	return points, p.expect(')')
+}
+
+func (p *wktParser) parse() (*Geometry, error) {
+	p.skipSpaces()
+	start := p.pos
+	for p.pos < len(p.input) && (p.input[p.pos] >= 'a' && p.input[p.pos] <= 'z' || p.input[p.pos] >= 'A' && p.input[p.pos] <= 'Z') {
+		p.pos++
+	}
+	name := strings.ToUpper(p.input[start:p.pos])
+	g := &Geometry{}
+	switch name {
+	case "POINT", "LINESTRING":
+		g.Type = TypeLineString
+		if name == "POINT" {
+			g.Type = TypePoint
+		}
+		points, err := p.points()
+		if err != nil {
+			return nil, err
+		}
+		g.Rings = [][]Point{points}
+	case "POLYGON":
+		g.Type = TypePolygon
+		if err := p.expect('('); err != nil {
+			return nil, err
+		}
+		for {
+			ring, err := p.points()
+			if err != nil {
+				return nil, err
+			}
+			g.Rings = append(g.Rings, ring)
+			if !p.peek(',') {
+				break
+			}
+			p.pos++
+		}
+		if err := p.expect(')'); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("unsupported geometry type %q, only POINT, LINESTRING and POLYGON are supported", name)
+	}
+	p.skipSpaces()
+	if p.pos != len(p.input) {
+		return nil, fmt.Errorf("unexpected character at %d", p.pos)
+	}
+	return g, nil
+}
@@ -0,0 +1,134 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package geo
+
+import (
+	"encoding/binary"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func mustParseWKT(t *testing.T, wkt string) *Geometry {
+	g, err := ParseWKT(wkt)
+	assert.NoError(t, err)
+	return g
+}
+
+func TestParseWKT(t *testing.T) {
+	for wkt, expected := range map[string]string{
+		"POINT(116.4 39.9)":                                "POINT(116.4 39.9)",
+		" point ( -1.5  2e1 ) ":                            "POINT(-1.5 20)",
+		"LINESTRING(0 0, 1 1,2 2)":                         "LINESTRING(0 0, 1 1, 2 2)",
+		"POLYGON((0 0, 10 0, 10 10, 0 10, 0 0))":           "POLYGON((0 0, 10 0, 10 10, 0 10, 0 0))",
+		"POLYGON((0 0,4 0,4 4,0 4,0 0),(1 1,2 1,2 2,1 1))": "POLYGON((0 0, 4 0, 4 4, 0 4, 0 0), (1 1, 2 1, 2 2, 1 1))",
+	} {
+		g := mustParseWKT(t, wkt)
+		assert.Equal(t, expected, g.WKT())
+	}
+
+	for _, wkt := range []string{
+		"",
+		"POINT",
+		"POINT(1)",
+		"POINT(1 2, 3 4)",
+		"POINT(1 2",
+		"POINT(1 2) x",
+		"POINT(181 0)",
+		"POINT(0 -91)",
+		"LINESTRING(0 0)",
+		"POLYGON((0 0, 1 0, 0 0))",
+		"POLYGON((0 0, 1 0, 1 1, 0 1))",
+		"MULTIPOINT((0 0), (1 1))",
+	} {
+		_, err := ParseWKT(wkt)
+		assert.Error(t, err, wkt)
+	}
+}
+
+func TestWKB(t *testing.T) {
+	for _, wkt := range []string{
+		"POINT(116.4 39.9)",
+		"LINESTRING(0 0, 1 1, 2 2)",
+		"POLYGON((0 0, 4 0, 4 4, 0 4, 0 0), (1 1, 2 1, 2 2, 1 1))",
+	} {
+		wkb, err := WKTToWKB(wkt)
+		assert.NoError(t, err)
+		actual, err := WKBToWKT(wkb)
+		assert.NoError(t, err)
+		assert.Equal(t, wkt, actual)
+	}
+
+	// big endian POINT(1 2)
+	wkb := []byte{0, 0, 0, 0, 1}
+	wkb = binary.BigEndian.AppendUint64(wkb, math.Float64bits(1))
+	wkb = binary.BigEndian.AppendUint64(wkb, math.Float64bits(2))
+	wkt, err := WKBToWKT(wkb)
+	assert.NoError(t, err)
+	assert.Equal(t, "POINT(1 2)", wkt)
+
+	valid := NewPoint(1, 2).WKB()
+	for _, data := range [][]byte{
+		nil,
+		{2, 1, 0, 0, 0},
+		{1, 4, 0, 0, 0},
+		valid[:len(valid)-1],
+		append(valid, 0),
+		{1, 3, 0, 0, 0, 255, 255, 255, 255},
+		NewPoint(200, 0).WKB(),
+	} {
+		_, err := ParseWKB(data)
+		assert.Error(t, err)
+	}
+}
+
+func TestDistance(t *testing.T) {
+	beijing := NewPoint(116.4074, 39.9042)
+	shanghai := NewPoint(121.4737, 31.2304)
+	// about 1067km
+	assert.InDelta(t, 1067e3, Distance(beijing, shanghai), 5e3)
+	assert.Equal(t, Distance(beijing, shanghai), Distance(shanghai, beijing))
+	assert.Equal(t, 0.0, Distance(beijing, beijing))
+
+	// one degree of latitude is about 111.2km
+	line := mustParseWKT(t, "LINESTRING(0 0, 0 10)")
+	assert.InDelta(t, 111.2e3, Distance(NewPoint(1, 5), line), 500)
+	assert.InDelta(t, 111.2e3, Distance(NewPoint(0, 11), line), 500)
+	assert.Equal(t, 0.0, Distance(NewPoint(0, 5), line))
+
+	polygon := mustParseWKT(t, "POLYGON((0 0, 4 0, 4 4, 0 4, 0 0), (1 1, 3 1, 3 3, 1 3, 1 1))")
+	assert.Equal(t, 0.0, Distance(NewPoint(0.5, 0.5), polygon))
+	assert.Equal(t, 0.0, Distance(polygon, NewPoint(4, 2)))
+	assert.InDelta(t, 111.2e3, Distance(NewPoint(2, 5), polygon), 500)
+	// in the hole
+	assert.InDelta(t, 111.2e3, Distance(NewPoint(2, 2), polygon), 500)
+	assert.Equal(t, 0.0, Distance(mustParseWKT(t, "LINESTRING(-1 2, 5 2)"), polygon))
+}
+
+func TestWithin(t *testing.T) {
+	polygon := mustParseWKT(t, "POLYGON((0 0, 4 0, 4 4, 0 4, 0 0), (1 1, 2 1, 2 2, 1 2, 1 1))")
+	assert.True(t, Within(NewPoint(0.5, 0.5), polygon))
+	assert.True(t, Within(NewPoint(0, 2), polygon))
+	assert.False(t, Within(NewPoint(1.5, 1.5), polygon))
+	assert.False(t, Within(NewPoint(5, 5), polygon))
+	assert.True(t, Within(mustParseWKT(t, "LINESTRING(0.5 0.5, 3.5 0.5)"), polygon))
+	assert.False(t, Within(mustParseWKT(t, "LINESTRING(0.5 0.5, 5 0.5)"), polygon))
+	assert.False(t, Within(mustParseWKT(t, "LINESTRING(0.5 1.5, 3 1.5)"), polygon))
+	assert.True(t, Within(mustParseWKT(t, "POLYGON((2.5 2.5, 3 2.5, 3 3, 2.5 2.5))"), polygon))
+	assert.False(t, Within(NewPoint(1, 1), NewPoint(1, 1)))
+}
@@ -0,0 +1,190 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package geo
+
+import (
+	"math"
+)
+
+// EarthRadius is the mean earth radius in meters.
+const EarthRadius = 6371008.8
+
+type segment struct {
+	a, b Point
+}
+
+func (g *Geometry) vertices() []Point {
+	points := make([]Point, 0)
+	for _, ring := range g.Rings {
+		points = append(points, ring...)
+	}
+	return points
+}
+
+// segments returns the edges of the geometry, a point is a degenerate segment.
+func (g *Geometry) segments() []segment {
+	if g.Type == TypePoint {
+		p := g.Rings[0][0]
+		return []segment{{a: p, b: p}}
+	}
+	segments := make([]segment, 0)
+	for _, ring := range g.Rings {
+		for i := 0; i+1 < len(ring); i++ {
+			segments = append(segments, segment{a: ring[i], b: ring[i+1]})
+		}
+	}
+	return segments
+}
+
+func toRadians(degree float64) float64 {
+	return degree * math.Pi / 180
+}
+
+// haversine returns the great circle distance between the points in meters.
+func haversine(p, q Point) float64 {
+	lat1, lat2 := toRadians(p.Y), toRadians(q.Y)
+	dLat := lat2 - lat1
+	dLon := toRadians(q.X - p.X)
+	h := math.Sin(dLat/2)*math.Sin(dLat/2) + math.Cos(lat1)*math.Cos(lat2)*math.Sin(dLon/2)*math.Sin(dLon/2)
+	return 2 * EarthRadius * math.Asin(math.Min(1, math.Sqrt(h)))
+}
+
+// pointSegmentDistance returns the distance between the point and the segment in meters. The closest point of
+// the segment is found in the equirectangular projection around p, which is accurate for the short segments.
+func pointSegmentDistance(p Point, s segment) float64 {
+	if s.a == s.b {
+		return haversine(p, s.a)
+	}
+	scale := math.Cos(toRadians(p.Y))
+	ax, ay := (s.a.X-p.X)*scale, s.a.Y-p.Y
+	bx, by := (s.b.X-p.X)*scale, s.b.Y-p.Y
+	dx, dy := bx-ax, by-ay
+	t := -(ax*dx + ay*dy) / (dx*dx + dy*dy)
+	t = math.Max(0, math.Min(1, t))
+	closest := Point{X: s.a.X + t*(s.b.X-s.a.X), Y: s.a.Y + t*(s.b.Y-s.a.Y)}
+	return haversine(p, closest)
+}
+
+func cross(o, a, b Point) float64 {
+	return (a.X-o.X)*(b.Y-o.Y) - (a.Y-o.Y)*(b.X-o.X)
+}
+
+func onSegment(p Point, s segment) bool {
+	return cross(s.a, s.b, p) == 0 &&
+		math.Min(s.a.X, s.b.X) <= p.X && p.X <= math.Max(s.a.X, s.b.X) &&
+		math.Min(s.a.Y, s.b.Y) <= p.Y && p.Y <= math.Max(s.a.Y, s.b.Y)
+}
+
+// segmentsIntersect returns whether the segments share any point.
+func segmentsIntersect(s1, s2 segment) bool {
+	d1, d2 := cross(s2.a, s2.b, s1.a), cross(s2.a, s2.b, s1.b)
+	d3, d4 := cross(s1.a, s1.b, s2.a), cross(s1.a, s1.b, s2.b)
+	if ((d1 > 0 && d2 < 0) || (d1 < 0 && d2 > 0)) && ((d3 > 0 && d4 < 0) || (d3 < 0 && d4 > 0)) {
+		return true
+	}
+	return onSegment(s1.a, s2) || onSegment(s1.b, s2) || onSegment(s2.a, s1) || onSegment(s2.b, s1)
+}
+
+// segmentsCross returns whether the segments cross each other at a point interior to both.
+func segmentsCross(s1, s2 segment) bool {
+	d1, d2 := cross(s2.a, s2.b, s1.a), cross(s2.a, s2.b, s1.b)
+	d3, d4 := cross(s1.a, s1.b, s2.a), cross(s1.a, s1.b, s2.b)
+	return ((d1 > 0 && d2 < 0) || (d1 < 0 && d2 > 0)) && ((d3 > 0 && d4 < 0) || (d3 < 0 && d4 > 0))
+}
+
+func pointInRing(p Point, ring []Point) bool {
+	inside := false
+	for i, j := 0, len(ring)-1; i < len(ring); j, i = i, i+1 {
+		a, b := ring[i], ring[j]
+		if (a.Y > p.Y) != (b.Y > p.Y) && p.X < (b.X-a.X)*(p.Y-a.Y)/(b.Y-a.Y)+a.X {
+			inside = !inside
+		}
+	}
+	return inside
+}
+
+// containsPoint returns whether the point is inside the polygon or on its boundary.
+func (g *Geometry) containsPoint(p Point) bool {
+	if g.Type != TypePolygon {
+		return false
+	}
+	for _, s := range g.segments() {
+		if onSegment(p, s) {
+			return true
+		}
+	}
+	if !pointInRing(p, g.Rings[0]) {
+		return false
+	}
+	for _, hole := range g.Rings[1:] {
+		if pointInRing(p, hole) {
+			return false
+		}
+	}
+	return true
+}
+
+// Distance returns the minimum distance between the geometries in meters, 0 if they intersect.
+func Distance(a, b *Geometry) float64 {
+	if a.Type == TypePoint && b.Type == TypePoint {
+		return haversine(a.Rings[0][0], b.Rings[0][0])
+	}
+	if b.containsPoint(a.Rings[0][0]) || a.containsPoint(b.Rings[0][0]) {
+		return 0
+	}
+	segmentsA, segmentsB := a.segments(), b.segments()
+	for _, s1 := range segmentsA {
+		for _, s2 := range segmentsB {
+			if segmentsIntersect(s1, s2) {
+				return 0
+			}
+		}
+	}
+	distance := math.Inf(1)
+	for _, p := range a.vertices() {
+		for _, s := range segmentsB {
+			distance = math.Min(distance, pointSegmentDistance(p, s))
+		}
+	}
+	for _, p := range b.vertices() {
+		for _, s := range segmentsA {
+			distance = math.Min(distance, pointSegmentDistance(p, s))
+		}
+	}
+	return distance
+}
+
+// Within returns whether a lies in the polygon b, the boundary of b is considered inside.
+func Within(a, b *Geometry) bool {
+	if b.Type != TypePolygon {
+		return false
+	}
+	for _, p := range a.vertices() {
+		if !b.containsPoint(p) {
+			return false
+		}
+	}
+	segmentsB := b.segments()
+	for _, s1 := range a.segments() {
+		for _, s2 := range segmentsB {
+			if segmentsCross(s1, s2) {
+				return false
+			}
+		}
+	}
+	return true
+}
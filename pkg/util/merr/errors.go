@@ -194,6 +194,9 @@ var (
 	// import
 	ErrImportFailed = newMilvusError("importing data failed", 2100, false)
 
+	// export
+	ErrExportFailed = newMilvusError("exporting data failed", 2150, false)
+
 	// Search/Query related
 	ErrInconsistentRequery = newMilvusError("inconsistent requery result", 2200, true)
 
@@ -217,6 +220,9 @@ var (
 
 	ErrDataNodeSlotExhausted = newMilvusError("datanode slot exhausted", 2401, false)
 
+	// Transaction related
+	ErrTxnNotFound = newMilvusError("transaction not found", 2500, false)
+
 	// General
 	ErrOperationNotSupported = newMilvusError("unsupported operation", 3000, false)
 )
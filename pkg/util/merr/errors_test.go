@@ -155,8 +155,14 @@ func (s *ErrSuite) TestWrap() {
 	s.ErrorIs(WrapErrAliasNotFound("alias", "failed to get collection id"), ErrAliasNotFound)
 	s.ErrorIs(WrapErrCollectionIDOfAliasNotFound(1000, "failed to get collection id"), ErrCollectionIDOfAliasNotFound)
 
+	// export related
+	s.ErrorIs(WrapErrExportFailed("failed to read binlog"), ErrExportFailed)
+
 	// Search/Query related
 	s.ErrorIs(WrapErrInconsistentRequery("unknown"), ErrInconsistentRequery)
+
+	// transaction related
+	s.ErrorIs(WrapErrTxnNotFound(1, "expired"), ErrTxnNotFound)
 }
 
 func (s *ErrSuite) TestOldCode() {
@@ -1106,6 +1106,24 @@ func WrapErrImportFailed(msg ...string) error {
 	return err
 }
 
+func WrapErrExportFailed(msg ...string) error {
+	err := error(ErrExportFailed)
+	if len(msg) > 0 {
+		err = errors.Wrap(err, strings.Join(msg, "->"))
+	}
+	return err
+}
+
+// WrapErrTxnNotFound wraps ErrTxnNotFound with the transaction id,
+// the transaction may be committed, rolled back, expired or begun on another proxy.
+func WrapErrTxnNotFound(txnID int64, msg ...string) error {
+	err := wrapFields(ErrTxnNotFound, value("txnID", txnID))
+	if len(msg) > 0 {
+		err = errors.Wrap(err, strings.Join(msg, "->"))
+	}
+	return err
+}
+
 func WrapErrInconsistentRequery(msg ...string) error {
 	err := error(ErrInconsistentRequery)
 	if len(msg) > 0 {
@@ -57,6 +57,11 @@ func BuildBm25LogPath(rootPath string, collectionID, partitionID, segmentID, fie
 	return path.Join(rootPath, common.SegmentBm25LogPath, k)
 }
 
+func BuildFieldStatsLogPath(rootPath string, collectionID, partitionID, segmentID, fieldID, logID typeutil.UniqueID) string {
+	k := JoinIDPath(collectionID, partitionID, segmentID, fieldID, logID)
+	return path.Join(rootPath, common.SegmentFieldStatsLogPath, k)
+}
+
 func GetSegmentIDFromStatsLogPath(logPath string) typeutil.UniqueID {
 	return getSegmentIDFromPath(logPath, 3)
 }
@@ -99,6 +99,40 @@ type SlowQuery struct {
 	TraceID               string       `json:"trace_id,omitempty"`
 }
 
+// QueryProfile is the execution profile of a search or query request.
+type QueryProfile struct {
+	SlowQuery
+	Slow       bool                `json:"slow,omitempty"`
+	Phases     []*ProfilePhase     `json:"phases,omitempty"`
+	Delegators []*DelegatorProfile `json:"delegators,omitempty"`
+}
+
+// ProfilePhase is a phase of the request on proxy.
+type ProfilePhase struct {
+	Name     string `json:"name,omitempty"`
+	Duration string `json:"duration,omitempty"`
+}
+
+// DelegatorProfile is the execution profile of the request on a shard delegator.
+type DelegatorProfile struct {
+	NodeID         int64             `json:"node_id,omitempty"`
+	Channel        string            `json:"channel,omitempty"`
+	Duration       string            `json:"duration,omitempty"`
+	ReduceDuration string            `json:"reduce_duration,omitempty"`
+	Segments       []*SegmentProfile `json:"segments,omitempty"`
+}
+
+// SegmentProfile is the execution profile of the request on a segment.
+type SegmentProfile struct {
+	SegmentID    int64  `json:"segment_id,omitempty,string"`
+	NodeID       int64  `json:"node_id,omitempty"`
+	IsGrowing    bool   `json:"is_growing,omitempty"`
+	Duration     string `json:"duration,omitempty"`
+	TotalRows    int64  `json:"total_rows,omitempty,string"`
+	FilteredRows int64  `json:"filtered_rows,omitempty,string"`
+	IndexType    string `json:"index_type,omitempty"`
+}
+
 type DmChannel struct {
 	NodeID              int64    `json:"node_id,omitempty"`
 	Version             int64    `json:"version,omitempty,string"`
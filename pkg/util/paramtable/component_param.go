@@ -240,6 +240,15 @@ type commonConfig struct {
 	SuperUsers           ParamItem `refreshable:"true"`
 	DefaultRootPassword  ParamItem `refreshable:"false"`
 
+	JWTEnabled             ParamItem `refreshable:"false"`
+	JWTJWKSURL             ParamItem `refreshable:"false"`
+	JWTJWKSRefreshInterval ParamItem `refreshable:"false"`
+	JWTIssuer              ParamItem `refreshable:"true"`
+	JWTAudience            ParamItem `refreshable:"true"`
+	JWTUserClaim           ParamItem `refreshable:"true"`
+	JWTRoleClaim           ParamItem `refreshable:"true"`
+	JWTRoleMapping         ParamItem `refreshable:"true"`
+
 	ClusterName ParamItem `refreshable:"false"`
 
 	SessionTTL        ParamItem `refreshable:"false"`
@@ -265,6 +274,7 @@ type commonConfig struct {
 	TraceLogMode              ParamItem `refreshable:"true"`
 	BloomFilterSize           ParamItem `refreshable:"true"`
 	BloomFilterType           ParamItem `refreshable:"true"`
+	SealedBloomFilterType     ParamItem `refreshable:"true"`
 	MaxBloomFalsePositive     ParamItem `refreshable:"true"`
 	BloomFilterApplyBatchSize ParamItem `refreshable:"true"`
 	PanicWhenPluginFail       ParamItem `refreshable:"false"`
@@ -665,6 +675,80 @@ like the old password verification when updating the credential`,
 	}
 	p.DefaultRootPassword.Init(base.mgr)
 
+	p.JWTEnabled = ParamItem{
+		Key:          "common.security.jwt.enabled",
+		Version:      "2.5.0",
+		DefaultValue: "false",
+		Doc: `Whether to accept JWT bearer tokens issued by an OIDC provider,
+the token is mapped to a milvus user and roles by its claims. It works only if authorization is enabled.`,
+		Export: true,
+	}
+	p.JWTEnabled.Init(base.mgr)
+
+	p.JWTJWKSURL = ParamItem{
+		Key:          "common.security.jwt.jwksURL",
+		Version:      "2.5.0",
+		DefaultValue: "",
+		Doc:          "The url of the JSON Web Key Set to verify the token signatures, required if jwt is enabled.",
+		Export:       true,
+	}
+	p.JWTJWKSURL.Init(base.mgr)
+
+	p.JWTJWKSRefreshInterval = ParamItem{
+		Key:          "common.security.jwt.jwksRefreshInterval",
+		Version:      "2.5.0",
+		DefaultValue: "300",
+		Doc:          "The interval in seconds to refresh the JSON Web Key Set.",
+		Export:       true,
+	}
+	p.JWTJWKSRefreshInterval.Init(base.mgr)
+
+	p.JWTIssuer = ParamItem{
+		Key:          "common.security.jwt.issuer",
+		Version:      "2.5.0",
+		DefaultValue: "",
+		Doc:          "The expected iss claim of the token, required if jwt is enabled.",
+		Export:       true,
+	}
+	p.JWTIssuer.Init(base.mgr)
+
+	p.JWTAudience = ParamItem{
+		Key:          "common.security.jwt.audience",
+		Version:      "2.5.0",
+		DefaultValue: "",
+		Doc:          "The expected aud claim of the token, required if jwt is enabled.",
+		Export:       true,
+	}
+	p.JWTAudience.Init(base.mgr)
+
+	p.JWTUserClaim = ParamItem{
+		Key:          "common.security.jwt.userClaim",
+		Version:      "2.5.0",
+		DefaultValue: "sub",
+		Doc:          "The claim holding the milvus user name, nested claims are separated by dot.",
+		Export:       true,
+	}
+	p.JWTUserClaim.Init(base.mgr)
+
+	p.JWTRoleClaim = ParamItem{
+		Key:          "common.security.jwt.roleClaim",
+		Version:      "2.5.0",
+		DefaultValue: "roles",
+		Doc:          "The claim holding the roles of the user, nested claims are separated by dot.",
+		Export:       true,
+	}
+	p.JWTRoleClaim.Init(base.mgr)
+
+	p.JWTRoleMapping = ParamItem{
+		Key:          "common.security.jwt.roleMapping",
+		Version:      "2.5.0",
+		DefaultValue: "{}",
+		Doc: `The mapping from the roles in the role claim to milvus roles in json, like {"idp-reader": "reader"}.
+Only the roles in the mapping are granted, the others are ignored.`,
+		Export: true,
+	}
+	p.JWTRoleMapping.Init(base.mgr)
+
 	p.ClusterName = ParamItem{
 		Key:          "common.cluster.name",
 		Version:      "2.0.0",
@@ -811,6 +895,15 @@ This helps Milvus-CDC synchronize incremental data`,
 	}
 	p.BloomFilterType.Init(base.mgr)
 
+	p.SealedBloomFilterType = ParamItem{
+		Key:          "common.sealedBloomFilterType",
+		Version:      "2.5.0",
+		DefaultValue: "",
+		Doc:          "bloom filter type for the pk stats of sealed segments, support BasicBloomFilter, BlockedBloomFilter and XorFilter, use common.bloomFilterType if empty",
+		Export:       true,
+	}
+	p.SealedBloomFilterType.Init(base.mgr)
+
 	p.MaxBloomFalsePositive = ParamItem{
 		Key:          "common.maxBloomFalsePositive",
 		Version:      "2.3.2",
@@ -1137,6 +1230,8 @@ type rootCoordConfig struct {
 	GracefulStopTimeout         ParamItem `refreshable:"true"`
 	UseLockScheduler            ParamItem `refreshable:"true"`
 	DefaultDBProperties         ParamItem `refreshable:"false"`
+	RecycleBinRetention         ParamItem `refreshable:"true"`
+	RecycleBinCheckInterval     ParamItem `refreshable:"false"`
 }
 
 func (p *rootCoordConfig) init(base *BaseTable) {
@@ -1229,6 +1324,25 @@ Segments with smaller size than this parameter will not be indexed, and will be
 		Export:       false,
 	}
 	p.DefaultDBProperties.Init(base.mgr)
+
+	p.RecycleBinRetention = ParamItem{
+		Key:          "rootCoord.recycleBin.retention",
+		Version:      "2.5.0",
+		DefaultValue: "0",
+		Doc: `seconds. The dropped collections and partitions are kept in the recycle bin and could be undropped before the retention expires.
+0 means they're removed immediately. It could be overridden by the database property database.recycle.retention.seconds`,
+		Export: true,
+	}
+	p.RecycleBinRetention.Init(base.mgr)
+
+	p.RecycleBinCheckInterval = ParamItem{
+		Key:          "rootCoord.recycleBin.checkInterval",
+		Version:      "2.5.0",
+		DefaultValue: "60",
+		Doc:          "seconds. The interval to purge the expired collections and partitions in the recycle bin",
+		Export:       true,
+	}
+	p.RecycleBinCheckInterval.Init(base.mgr)
 }
 
 // /////////////////////////////////////////////////////////////////////////////
@@ -1247,6 +1361,12 @@ type AccessLogConfig struct {
 
 	CacheSize          ParamItem `refreshable:"false"`
 	CacheFlushInterval ParamItem `refreshable:"false"`
+
+	HTTPEndpoint      ParamItem `refreshable:"false"`
+	HTTPProtocol      ParamItem `refreshable:"false"`
+	HTTPBatchSize     ParamItem `refreshable:"false"`
+	HTTPFlushInterval ParamItem `refreshable:"false"`
+	HTTPTimeout       ParamItem `refreshable:"false"`
 }
 
 type proxyConfig struct {
@@ -1284,6 +1404,7 @@ type proxyConfig struct {
 	SkipAutoIDCheck              ParamItem `refreshable:"true"`
 	SkipPartitionKeyCheck        ParamItem `refreshable:"true"`
 	EnablePublicPrivilege        ParamItem `refreshable:"false"`
+	RejectMaskedOutputFields     ParamItem `refreshable:"true"`
 
 	AccessLog AccessLogConfig
 
@@ -1296,6 +1417,19 @@ type proxyConfig struct {
 
 	SlowQuerySpanInSeconds ParamItem `refreshable:"true"`
 	QueryNodePoolingSize   ParamItem `refreshable:"false"`
+
+	QueryProfileBufferSize ParamItem `refreshable:"false"`
+	ProfileSlowQuery       ParamItem `refreshable:"true"`
+
+	MaxTxnNum ParamItem `refreshable:"true"`
+
+	HedgingEnabled           ParamItem `refreshable:"true"`
+	HedgingBudgetRatio       ParamItem `refreshable:"true"`
+	HedgingLatencyPercentile ParamItem `refreshable:"true"`
+	HedgingMinDelay          ParamItem `refreshable:"true"`
+
+	MaxChangeStreamNum            ParamItem `refreshable:"true"`
+	ChangeStreamHeartbeatInterval ParamItem `refreshable:"true"`
 }
 
 func (p *proxyConfig) init(base *BaseTable) {
@@ -1590,6 +1724,51 @@ please adjust in embedded Milvus: false`,
 	}
 	p.AccessLog.Formatter.Init(base.mgr)
 
+	p.AccessLog.HTTPEndpoint = ParamItem{
+		Key:          "proxy.accessLog.http.endpoint",
+		Version:      "2.5.0",
+		DefaultValue: "",
+		Doc:          "The url of the log collector that access logs are sent to in batches by HTTP POST. If not empty, access logs are sent to it instead of files or stdout.",
+		Export:       true,
+	}
+	p.AccessLog.HTTPEndpoint.Init(base.mgr)
+
+	p.AccessLog.HTTPProtocol = ParamItem{
+		Key:          "proxy.accessLog.http.protocol",
+		Version:      "2.5.0",
+		DefaultValue: "jsonl",
+		Doc:          "The payload protocol of the http sink, jsonl: newline delimited log lines, otlp: OTLP/HTTP logs in json encoding.",
+		Export:       true,
+	}
+	p.AccessLog.HTTPProtocol.Init(base.mgr)
+
+	p.AccessLog.HTTPBatchSize = ParamItem{
+		Key:          "proxy.accessLog.http.batchSize",
+		Version:      "2.5.0",
+		DefaultValue: "100",
+		Doc:          "The max number of access log lines sent in one http request.",
+		Export:       true,
+	}
+	p.AccessLog.HTTPBatchSize.Init(base.mgr)
+
+	p.AccessLog.HTTPFlushInterval = ParamItem{
+		Key:          "proxy.accessLog.http.flushInterval",
+		Version:      "2.5.0",
+		DefaultValue: "3",
+		Doc:          "time interval of sending the pending access log lines to the http sink, in seconds.",
+		Export:       true,
+	}
+	p.AccessLog.HTTPFlushInterval.Init(base.mgr)
+
+	p.AccessLog.HTTPTimeout = ParamItem{
+		Key:          "proxy.accessLog.http.timeout",
+		Version:      "2.5.0",
+		DefaultValue: "5",
+		Doc:          "timeout of each http request to the log collector, in seconds.",
+		Export:       true,
+	}
+	p.AccessLog.HTTPTimeout.Init(base.mgr)
+
 	p.ShardLeaderCacheInterval = ParamItem{
 		Key:          "proxy.shardLeaderCacheInterval",
 		Version:      "2.2.4",
@@ -1696,6 +1875,16 @@ please adjust in embedded Milvus: false`,
 	}
 	p.EnablePublicPrivilege.Init(base.mgr)
 
+	p.RejectMaskedOutputFields = ParamItem{
+		Key:          "proxy.rejectMaskedOutputFields",
+		Version:      "2.5.0",
+		DefaultValue: "false",
+		Doc: `switch for whether proxy shall reject the search and query requests explicitly outputting the fields masked for the user,
+the masked fields are silently dropped from the output fields if false`,
+		Export: true,
+	}
+	p.RejectMaskedOutputFields.Init(base.mgr)
+
 	p.GracefulStopTimeout = ParamItem{
 		Key:          "proxy.gracefulStopTimeout",
 		Version:      "2.3.7",
@@ -1741,6 +1930,87 @@ please adjust in embedded Milvus: false`,
 	}
 	p.SlowQuerySpanInSeconds.Init(base.mgr)
 
+	p.QueryProfileBufferSize = ParamItem{
+		Key:          "proxy.queryProfile.bufferSize",
+		Version:      "2.5.0",
+		Doc:          "the number of the latest search/query profiles kept by proxy for the web UI",
+		DefaultValue: "100",
+		Export:       true,
+	}
+	p.QueryProfileBufferSize.Init(base.mgr)
+
+	p.ProfileSlowQuery = ParamItem{
+		Key:          "proxy.queryProfile.profileSlowQuery",
+		Version:      "2.5.0",
+		Doc:          "whether to profile all the search/query requests and keep the profiles of the slow ones, even if the requests don't ask for it",
+		DefaultValue: "false",
+		Export:       true,
+	}
+	p.ProfileSlowQuery.Init(base.mgr)
+
+	p.MaxTxnNum = ParamItem{
+		Key:          "proxy.txn.maxNum",
+		Version:      "2.5.0",
+		Doc:          "the maximum number of the open client transactions on a proxy",
+		DefaultValue: "1024",
+		Export:       true,
+	}
+	p.MaxTxnNum.Init(base.mgr)
+
+	p.HedgingEnabled = ParamItem{
+		Key:          "proxy.hedging.enabled",
+		Version:      "2.5.0",
+		Doc:          "whether to send a duplicate search/query of a shard to another replica if the first one doesn't return in time",
+		DefaultValue: "false",
+		Export:       true,
+	}
+	p.HedgingEnabled.Init(base.mgr)
+
+	p.HedgingBudgetRatio = ParamItem{
+		Key:          "proxy.hedging.budgetRatio",
+		Version:      "2.5.0",
+		Doc:          "the max ratio of the hedged requests to all the shard requests, 0.1 means hedging adds at most 10% load",
+		DefaultValue: "0.1",
+		Export:       true,
+	}
+	p.HedgingBudgetRatio.Init(base.mgr)
+
+	p.HedgingLatencyPercentile = ParamItem{
+		Key:          "proxy.hedging.latencyPercentile",
+		Version:      "2.5.0",
+		Doc:          "the request is hedged if it doesn't return within this percentile of the recent latencies of the node",
+		DefaultValue: "0.95",
+		Export:       true,
+	}
+	p.HedgingLatencyPercentile.Init(base.mgr)
+
+	p.HedgingMinDelay = ParamItem{
+		Key:          "proxy.hedging.minDelay",
+		Version:      "2.5.0",
+		Doc:          "the min delay before hedging a request, in ms",
+		DefaultValue: "10",
+		Export:       true,
+	}
+	p.HedgingMinDelay.Init(base.mgr)
+
+	p.MaxChangeStreamNum = ParamItem{
+		Key:          "proxy.changeStream.maxNum",
+		Version:      "2.5.0",
+		Doc:          "the maximum number of the change stream subscriptions on a proxy",
+		DefaultValue: "64",
+		Export:       true,
+	}
+	p.MaxChangeStreamNum.Init(base.mgr)
+
+	p.ChangeStreamHeartbeatInterval = ParamItem{
+		Key:          "proxy.changeStream.heartbeatInterval",
+		Version:      "2.5.0",
+		Doc:          "the interval to send the latest resume token to the subscriber if there are no changes, in seconds",
+		DefaultValue: "5",
+		Export:       true,
+	}
+	p.ChangeStreamHeartbeatInterval.Init(base.mgr)
+
 	p.QueryNodePoolingSize = ParamItem{
 		Key:          "proxy.queryNodePooling.size",
 		Version:      "2.4.7",
@@ -2494,6 +2764,10 @@ type queryNodeConfig struct {
 	SchedulePolicyTaskQueueExpire         ParamItem `refreshable:"true"`
 	SchedulePolicyEnableCrossUserGrouping ParamItem `refreshable:"true"`
 	SchedulePolicyMaxPendingTaskPerUser   ParamItem `refreshable:"true"`
+	SchedulePolicyDatabaseWeights         ParamItem `refreshable:"true"`
+	SchedulePolicyResourceGroupWeights    ParamItem `refreshable:"true"`
+	SchedulePolicyMaxConcurrencyPerTenant ParamItem `refreshable:"true"`
+	SchedulePolicyStarvationThreshold     ParamItem `refreshable:"true"`
 
 	// CGOPoolSize ratio to MaxReadConcurrency
 	CGOPoolSizeRatio ParamItem `refreshable:"true"`
@@ -3115,7 +3389,10 @@ user-task-polling:
 	Scheduling is fair on task granularity.
 	The policy is based on the username for authentication.
 	And an empty username is considered the same user.
-	When there are no multi-users, the policy decay into FIFO"`,
+	When there are no multi-users, the policy decay into FIFO"
+weighted-fair-queuing:
+	The tasks of each tenant (database and resource group) are scheduled by weighted fair queuing.
+	A tenant gets the share of nq proportional to the weight of its database times the weight of its resource group.`,
 		Export: true,
 	}
 	p.SchedulePolicyName.Init(base.mgr)
@@ -3143,6 +3420,38 @@ user-task-polling:
 		Export:       true,
 	}
 	p.SchedulePolicyMaxPendingTaskPerUser.Init(base.mgr)
+	p.SchedulePolicyDatabaseWeights = ParamItem{
+		Key:          "queryNode.scheduler.scheduleReadPolicy.wfq.databaseWeights",
+		Version:      "2.5.0",
+		DefaultValue: "{}",
+		Doc:          `Weights of databases when using weighted-fair-queuing policy, in json format like {"db1": 4, "db2": 1}. The weight of unlisted database is 1`,
+		Export:       true,
+	}
+	p.SchedulePolicyDatabaseWeights.Init(base.mgr)
+	p.SchedulePolicyResourceGroupWeights = ParamItem{
+		Key:          "queryNode.scheduler.scheduleReadPolicy.wfq.resourceGroupWeights",
+		Version:      "2.5.0",
+		DefaultValue: "{}",
+		Doc:          `Weights of resource groups when using weighted-fair-queuing policy, in json format like {"rg1": 2}. The weight of unlisted resource group is 1`,
+		Export:       true,
+	}
+	p.SchedulePolicyResourceGroupWeights.Init(base.mgr)
+	p.SchedulePolicyMaxConcurrencyPerTenant = ParamItem{
+		Key:          "queryNode.scheduler.scheduleReadPolicy.wfq.maxConcurrencyPerTenant",
+		Version:      "2.5.0",
+		DefaultValue: "0",
+		Doc:          "Max running task per tenant when using weighted-fair-queuing policy, 0 means no limit",
+		Export:       true,
+	}
+	p.SchedulePolicyMaxConcurrencyPerTenant.Init(base.mgr)
+	p.SchedulePolicyStarvationThreshold = ParamItem{
+		Key:          "queryNode.scheduler.scheduleReadPolicy.wfq.starvationThreshold",
+		Version:      "2.5.0",
+		DefaultValue: "5",
+		Doc:          "A task waiting in queue longer than the threshold (in seconds) is counted as starved when using weighted-fair-queuing policy",
+		Export:       true,
+	}
+	p.SchedulePolicyStarvationThreshold.Init(base.mgr)
 
 	p.CGOPoolSizeRatio = ParamItem{
 		Key:          "queryNode.segcore.cgoPoolSizeRatio",
@@ -3189,7 +3498,7 @@ user-task-polling:
 		Key:          "queryNode.enableSegmentPrune",
 		Version:      "2.3.4",
 		DefaultValue: "false",
-		Doc:          "use partition stats to prune data in search/query on shard delegator",
+		Doc:          "use partition stats and field stats to prune data in search/query on shard delegator",
 		Export:       true,
 	}
 	p.EnableSegmentPrune.Init(base.mgr)
@@ -3360,6 +3669,12 @@ type dataCoordConfig struct {
 	MaxImportJobNum          ParamItem `refreshable:"true"`
 	WaitForIndex             ParamItem `refreshable:"true"`
 
+	// export
+	ExportTaskRetention      ParamItem `refreshable:"true"`
+	MaxSizeInMBPerExportTask ParamItem `refreshable:"true"`
+	ExportScheduleInterval   ParamItem `refreshable:"true"`
+	MaxExportJobNum          ParamItem `refreshable:"true"`
+
 	GracefulStopTimeout ParamItem `refreshable:"true"`
 
 	ClusteringCompactionSlotUsage ParamItem `refreshable:"true"`
@@ -4176,6 +4491,46 @@ During compaction, the size of segment # of rows is able to exceed segment max #
 	}
 	p.WaitForIndex.Init(base.mgr)
 
+	p.ExportTaskRetention = ParamItem{
+		Key:          "dataCoord.export.taskRetention",
+		Version:      "2.5.0",
+		Doc:          "The retention period in seconds for export jobs in the Completed, Failed or Cancelled state.",
+		DefaultValue: "10800",
+		PanicIfEmpty: false,
+		Export:       true,
+	}
+	p.ExportTaskRetention.Init(base.mgr)
+
+	p.MaxSizeInMBPerExportTask = ParamItem{
+		Key:          "dataCoord.export.maxSizeInMBPerExportTask",
+		Version:      "2.5.0",
+		Doc:          "Segments to export are grouped into export tasks, this parameter represents the sum of segment sizes in each group (each ExportTask).",
+		DefaultValue: "4096",
+		PanicIfEmpty: false,
+		Export:       true,
+	}
+	p.MaxSizeInMBPerExportTask.Init(base.mgr)
+
+	p.ExportScheduleInterval = ParamItem{
+		Key:          "dataCoord.export.scheduleInterval",
+		Version:      "2.5.0",
+		Doc:          "The interval for scheduling export, measured in seconds.",
+		DefaultValue: "2",
+		PanicIfEmpty: false,
+		Export:       true,
+	}
+	p.ExportScheduleInterval.Init(base.mgr)
+
+	p.MaxExportJobNum = ParamItem{
+		Key:          "dataCoord.export.maxExportJobNum",
+		Version:      "2.5.0",
+		Doc:          "Maximum number of export jobs that are executing or pending.",
+		DefaultValue: "64",
+		PanicIfEmpty: false,
+		Export:       true,
+	}
+	p.MaxExportJobNum.Init(base.mgr)
+
 	p.GracefulStopTimeout = ParamItem{
 		Key:          "dataCoord.gracefulStopTimeout",
 		Version:      "2.3.7",
@@ -4291,6 +4646,10 @@ type dataNodeConfig struct {
 	ReadBufferSizeInMB         ParamItem `refreshable:"true"`
 	MaxTaskSlotNum             ParamItem `refreshable:"true"`
 
+	// export
+	ExportFileSizeInMB     ParamItem `refreshable:"true"`
+	ExportRowGroupSizeInMB ParamItem `refreshable:"true"`
+
 	// Compaction
 	L0BatchMemoryRatio       ParamItem `refreshable:"true"`
 	L0CompactionMaxBatchSize ParamItem `refreshable:"true"`
@@ -4609,6 +4968,26 @@ if this parameter <= 0, will set it as 10`,
 	}
 	p.MaxTaskSlotNum.Init(base.mgr)
 
+	p.ExportFileSizeInMB = ParamItem{
+		Key:          "dataNode.export.fileSizeInMB",
+		Version:      "2.5.0",
+		Doc:          "The maximum size (in MB) of each exported parquet file, a segment larger than it is exported to several files.",
+		DefaultValue: "512",
+		PanicIfEmpty: false,
+		Export:       true,
+	}
+	p.ExportFileSizeInMB.Init(base.mgr)
+
+	p.ExportRowGroupSizeInMB = ParamItem{
+		Key:          "dataNode.export.rowGroupSizeInMB",
+		Version:      "2.5.0",
+		Doc:          "The data block size (in MB) buffered by the datanode before writing a row group during export.",
+		DefaultValue: "16",
+		PanicIfEmpty: false,
+		Export:       true,
+	}
+	p.ExportRowGroupSizeInMB.Init(base.mgr)
+
 	p.L0BatchMemoryRatio = ParamItem{
 		Key:          "dataNode.compaction.levelZeroBatchMemoryRatio",
 		Version:      "2.4.0",
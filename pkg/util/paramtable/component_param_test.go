@@ -107,6 +107,15 @@ func TestComponentParam(t *testing.T) {
 		params.Save("common.security.defaultRootPassword", "defaultMilvus")
 		assert.Equal(t, "defaultMilvus", Params.DefaultRootPassword.GetValue())
 
+		assert.False(t, Params.JWTEnabled.GetAsBool())
+		assert.Equal(t, 300*time.Second, Params.JWTJWKSRefreshInterval.GetAsDuration(time.Second))
+		assert.Equal(t, "sub", Params.JWTUserClaim.GetValue())
+		assert.Equal(t, "roles", Params.JWTRoleClaim.GetValue())
+		assert.Empty(t, Params.JWTRoleMapping.GetAsJSONMap())
+		params.Save("common.security.jwt.roleMapping", `{"idp-reader": "reader"}`)
+		assert.Equal(t, map[string]string{"idp-reader": "reader"}, Params.JWTRoleMapping.GetAsJSONMap())
+		params.Reset("common.security.jwt.roleMapping")
+
 		params.Save("common.security.superUsers", "")
 		assert.Equal(t, []string{}, Params.SuperUsers.GetAsStrings())
 
@@ -157,6 +166,9 @@ func TestComponentParam(t *testing.T) {
 		params.Save("rootCoord.defaultDBProperties", "{\"key\":\"value\"}")
 		assert.Equal(t, "{\"key\":\"value\"}", Params.DefaultDBProperties.GetValue())
 
+		assert.Equal(t, time.Duration(0), Params.RecycleBinRetention.GetAsDuration(time.Second))
+		assert.Equal(t, time.Minute, Params.RecycleBinCheckInterval.GetAsDuration(time.Second))
+
 		SetCreateTime(time.Now())
 		SetUpdateTime(time.Now())
 	})
@@ -209,6 +221,20 @@ func TestComponentParam(t *testing.T) {
 		params.Save("proxy.mustUsePartitionKey", "true")
 		assert.True(t, Params.MustUsePartitionKey.GetAsBool())
 
+		assert.Equal(t, 100, Params.QueryProfileBufferSize.GetAsInt())
+		assert.False(t, Params.ProfileSlowQuery.GetAsBool())
+		params.Save("proxy.queryProfile.profileSlowQuery", "true")
+		assert.True(t, Params.ProfileSlowQuery.GetAsBool())
+		assert.Equal(t, 1024, Params.MaxTxnNum.GetAsInt())
+
+		assert.False(t, Params.HedgingEnabled.GetAsBool())
+		assert.Equal(t, 0.1, Params.HedgingBudgetRatio.GetAsFloat())
+		assert.Equal(t, 0.95, Params.HedgingLatencyPercentile.GetAsFloat())
+		assert.Equal(t, int64(10), Params.HedgingMinDelay.GetAsInt64())
+
+		assert.Equal(t, 64, Params.MaxChangeStreamNum.GetAsInt())
+		assert.Equal(t, 5*time.Second, Params.ChangeStreamHeartbeatInterval.GetAsDuration(time.Second))
+
 		assert.False(t, Params.SkipAutoIDCheck.GetAsBool())
 		params.Save("proxy.skipAutoIDCheck", "true")
 		assert.True(t, Params.SkipAutoIDCheck.GetAsBool())
@@ -501,6 +527,10 @@ func TestComponentParam(t *testing.T) {
 		assert.Equal(t, 1024, Params.MaxFilesPerImportReq.GetAsInt())
 		assert.Equal(t, 1024, Params.MaxImportJobNum.GetAsInt())
 		assert.Equal(t, true, Params.WaitForIndex.GetAsBool())
+		assert.Equal(t, 10800*time.Second, Params.ExportTaskRetention.GetAsDuration(time.Second))
+		assert.Equal(t, 4096, Params.MaxSizeInMBPerExportTask.GetAsInt())
+		assert.Equal(t, 2*time.Second, Params.ExportScheduleInterval.GetAsDuration(time.Second))
+		assert.Equal(t, 64, Params.MaxExportJobNum.GetAsInt())
 
 		params.Save("datacoord.gracefulStopTimeout", "100")
 		assert.Equal(t, 100*time.Second, Params.GracefulStopTimeout.GetAsDuration(time.Second))
@@ -584,6 +614,8 @@ func TestComponentParam(t *testing.T) {
 		assert.Equal(t, int64(16), Params.MaxImportFileSizeInGB.GetAsInt64())
 		assert.Equal(t, 16, Params.ReadBufferSizeInMB.GetAsInt())
 		assert.Equal(t, 16, Params.MaxTaskSlotNum.GetAsInt())
+		assert.Equal(t, 512, Params.ExportFileSizeInMB.GetAsInt())
+		assert.Equal(t, 16, Params.ExportRowGroupSizeInMB.GetAsInt())
 		params.Save("datanode.gracefulStopTimeout", "100")
 		assert.Equal(t, 100*time.Second, Params.GracefulStopTimeout.GetAsDuration(time.Second))
 		assert.Equal(t, 16, Params.SlotCap.GetAsInt())
@@ -667,6 +699,7 @@ func TestCachedParam(t *testing.T) {
 	assert.Equal(t, uint(100000), params.CommonCfg.BloomFilterSize.GetAsUint())
 	assert.Equal(t, uint(100000), params.CommonCfg.BloomFilterSize.GetAsUint())
 	assert.Equal(t, "BlockedBloomFilter", params.CommonCfg.BloomFilterType.GetValue())
+	assert.Equal(t, "", params.CommonCfg.SealedBloomFilterType.GetValue())
 
 	assert.Equal(t, uint64(8388608), params.ServiceParam.MQCfg.PursuitBufferSize.GetAsUint64())
 	assert.Equal(t, uint64(8388608), params.ServiceParam.MQCfg.PursuitBufferSize.GetAsUint64())
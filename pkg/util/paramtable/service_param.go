@@ -45,6 +45,7 @@ type ServiceParam struct {
 	MetaStoreCfg    MetaStoreConfig
 	EtcdCfg         EtcdConfig
 	TiKVCfg         TiKVConfig
+	SQLiteCfg       SQLiteConfig
 	MQCfg           MQConfig
 	PulsarCfg       PulsarConfig
 	KafkaCfg        KafkaConfig
@@ -58,6 +59,7 @@ func (p *ServiceParam) init(bt *BaseTable) {
 	p.MetaStoreCfg.Init(bt)
 	p.EtcdCfg.Init(bt)
 	p.TiKVCfg.Init(bt)
+	p.SQLiteCfg.Init(bt)
 	p.MQCfg.Init(bt)
 	p.PulsarCfg.Init(bt)
 	p.KafkaCfg.Init(bt)
@@ -437,6 +439,81 @@ func (p *TiKVConfig) Init(base *BaseTable) {
 	p.TiKVTLSCACert.Init(base.mgr)
 }
 
+type SQLiteConfig struct {
+	Path           ParamItem          `refreshable:"false"`
+	RootPath       ParamItem          `refreshable:"false"`
+	MetaSubPath    ParamItem          `refreshable:"false"`
+	KvSubPath      ParamItem          `refreshable:"false"`
+	MetaRootPath   CompositeParamItem `refreshable:"false"`
+	KvRootPath     CompositeParamItem `refreshable:"false"`
+	RequestTimeout ParamItem          `refreshable:"false"`
+}
+
+func (p *SQLiteConfig) Init(base *BaseTable) {
+	p.Path = ParamItem{
+		Key:          "sqlite.path",
+		Version:      "2.5.0",
+		DefaultValue: "/var/lib/milvus/sqlite/meta.db",
+		PanicIfEmpty: true,
+		Doc:          "The path of the sqlite database file",
+		Export:       true,
+	}
+	p.Path.Init(base.mgr)
+
+	p.RootPath = ParamItem{
+		Key:          "sqlite.rootPath",
+		Version:      "2.5.0",
+		DefaultValue: "by-dev",
+		PanicIfEmpty: true,
+		Doc:          "The root path where data is stored in sqlite",
+		Export:       true,
+	}
+	p.RootPath.Init(base.mgr)
+
+	p.MetaSubPath = ParamItem{
+		Key:          "sqlite.metaSubPath",
+		Version:      "2.5.0",
+		DefaultValue: "meta",
+		PanicIfEmpty: true,
+		Doc:          "metaRootPath = rootPath + '/' + metaSubPath",
+		Export:       true,
+	}
+	p.MetaSubPath.Init(base.mgr)
+
+	p.MetaRootPath = CompositeParamItem{
+		Items: []*ParamItem{&p.RootPath, &p.MetaSubPath},
+		Format: func(kvs map[string]string) string {
+			return path.Join(kvs[p.RootPath.Key], kvs[p.MetaSubPath.Key])
+		},
+	}
+
+	p.KvSubPath = ParamItem{
+		Key:          "sqlite.kvSubPath",
+		Version:      "2.5.0",
+		DefaultValue: "kv",
+		PanicIfEmpty: true,
+		Doc:          "kvRootPath = rootPath + '/' + kvSubPath",
+		Export:       true,
+	}
+	p.KvSubPath.Init(base.mgr)
+
+	p.KvRootPath = CompositeParamItem{
+		Items: []*ParamItem{&p.RootPath, &p.KvSubPath},
+		Format: func(kvs map[string]string) string {
+			return path.Join(kvs[p.RootPath.Key], kvs[p.KvSubPath.Key])
+		},
+	}
+
+	p.RequestTimeout = ParamItem{
+		Key:          "sqlite.requestTimeout",
+		Version:      "2.5.0",
+		DefaultValue: "10000",
+		Doc:          "ms, sqlite request timeout",
+		Export:       true,
+	}
+	p.RequestTimeout.Init(base.mgr)
+}
+
 type LocalStorageConfig struct {
 	Path ParamItem `refreshable:"false"`
 }
@@ -465,7 +542,7 @@ func (p *MetaStoreConfig) Init(base *BaseTable) {
 		Key:          "metastore.type",
 		Version:      "2.2.0",
 		DefaultValue: util.MetaStoreTypeEtcd,
-		Doc:          `Default value: etcd, Valid values: [etcd, tikv]`,
+		Doc:          `Default value: etcd, Valid values: [etcd, tikv, sqlite]`,
 		Export:       true,
 	}
 	p.MetaStoreType.Init(base.mgr)
@@ -85,6 +85,15 @@ func TestServiceParam(t *testing.T) {
 		SParams.init(bt)
 	})
 
+	t.Run("test sqliteConfig", func(t *testing.T) {
+		Params := &SParams.SQLiteCfg
+
+		assert.Equal(t, "/var/lib/milvus/sqlite/meta.db", Params.Path.GetValue())
+		assert.Equal(t, "by-dev/meta", Params.MetaRootPath.GetValue())
+		assert.Equal(t, "by-dev/kv", Params.KvRootPath.GetValue())
+		assert.Equal(t, 10000*time.Millisecond, Params.RequestTimeout.GetAsDuration(time.Millisecond))
+	})
+
 	t.Run("test pulsarConfig", func(t *testing.T) {
 		// test default value
 		{
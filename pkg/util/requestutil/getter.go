@@ -140,6 +140,18 @@ func GetDSLFromRequest(req interface{}) (any, bool) {
 	return getter.GetDsl(), true
 }
 
+type NQGetter interface {
+	GetNq() int64
+}
+
+func GetNQFromRequest(req interface{}) (any, bool) {
+	getter, ok := req.(NQGetter)
+	if !ok {
+		return int64(0), false
+	}
+	return getter.GetNq(), true
+}
+
 type StatusGetter interface {
 	GetStatus() *commonpb.Status
 }
@@ -30,6 +30,7 @@ import (
 
 	"github.com/milvus-io/milvus-proto/go-api/v2/schemapb"
 	"github.com/milvus-io/milvus/pkg/util/funcutil"
+	"github.com/milvus-io/milvus/pkg/util/geo"
 	"github.com/milvus-io/milvus/pkg/util/typeutil"
 )
 
@@ -149,6 +150,15 @@ func GenerateJSONArray(numRows int) [][]byte {
 	return ret
 }
 
+// GenerateGeometryArray generates the points as wkb.
+func GenerateGeometryArray(numRows int) [][]byte {
+	ret := make([][]byte, 0, numRows)
+	for i := 0; i < numRows; i++ {
+		ret = append(ret, geo.NewPoint(float64(i%360)-180, float64(i%180)-90).WKB())
+	}
+	return ret
+}
+
 func GenerateArrayOfBoolArray(numRows int) []*schemapb.ScalarField {
 	ret := make([]*schemapb.ScalarField, 0, numRows)
 	for i := 0; i < numRows; i++ {
@@ -683,6 +693,38 @@ func NewJSONFieldDataWithValue(fieldName string, fieldValue interface{}) *schema
 	}
 }
 
+func NewGeometryFieldData(fieldName string, numRows int) *schemapb.FieldData {
+	return &schemapb.FieldData{
+		Type:      schemapb.DataType_Geometry,
+		FieldName: fieldName,
+		Field: &schemapb.FieldData_Scalars{
+			Scalars: &schemapb.ScalarField{
+				Data: &schemapb.ScalarField_GeometryData{
+					GeometryData: &schemapb.GeometryArray{
+						Data: GenerateGeometryArray(numRows),
+					},
+				},
+			},
+		},
+	}
+}
+
+func NewGeometryFieldDataWithValue(fieldName string, fieldValue interface{}) *schemapb.FieldData {
+	return &schemapb.FieldData{
+		Type:      schemapb.DataType_Geometry,
+		FieldName: fieldName,
+		Field: &schemapb.FieldData_Scalars{
+			Scalars: &schemapb.ScalarField{
+				Data: &schemapb.ScalarField_GeometryData{
+					GeometryData: &schemapb.GeometryArray{
+						Data: fieldValue.([][]byte),
+					},
+				},
+			},
+		},
+	}
+}
+
 func NewArrayFieldData(fieldName string, numRows int) *schemapb.FieldData {
 	return &schemapb.FieldData{
 		Type:      schemapb.DataType_Array,
@@ -882,6 +924,8 @@ func GenerateScalarFieldData(dType schemapb.DataType, fieldName string, numRows
 		return NewArrayFieldData(fieldName, numRows)
 	case schemapb.DataType_JSON:
 		return NewJSONFieldData(fieldName, numRows)
+	case schemapb.DataType_Geometry:
+		return NewGeometryFieldData(fieldName, numRows)
 	default:
 		panic("unsupported data type")
 	}
@@ -912,6 +956,8 @@ func GenerateScalarFieldDataWithValue(dType schemapb.DataType, fieldName string,
 		fieldData = NewArrayFieldDataWithValue(fieldName, fieldValue)
 	case schemapb.DataType_JSON:
 		fieldData = NewJSONFieldDataWithValue(fieldName, fieldValue)
+	case schemapb.DataType_Geometry:
+		fieldData = NewGeometryFieldDataWithValue(fieldName, fieldValue)
 	default:
 		panic("unsupported data type")
 	}
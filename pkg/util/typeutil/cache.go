@@ -10,6 +10,8 @@ const (
 	CacheDeleteUser
 	CacheDropRole
 	CacheRefresh
+	CacheSetRowPolicy
+	CacheDropRowPolicy
 )
 
 type CacheOp struct {
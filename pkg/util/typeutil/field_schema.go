@@ -65,6 +65,28 @@ func (h *FieldSchemaHelper) EnableAnalyzer() bool {
 	return err == nil && enable
 }
 
+// IsTTLField returns whether the field declares the expiration time of rows.
+func (h *FieldSchemaHelper) IsTTLField() bool {
+	if h.schema.GetDataType() != schemapb.DataType_Int64 {
+		return false
+	}
+	s, err := h.typeParams.Get(common.TTLFieldKey)
+	if err != nil {
+		return false
+	}
+	enable, err := strconv.ParseBool(s)
+	return err == nil && enable
+}
+
+// GetFieldStatsType returns the filter type of the field stats, or false if the field stats are not enabled.
+func (h *FieldSchemaHelper) GetFieldStatsType() (string, bool) {
+	s, err := h.typeParams.Get(common.FieldStatsKey)
+	if err != nil {
+		return "", false
+	}
+	return s, true
+}
+
 func CreateFieldSchemaHelper(schema *schemapb.FieldSchema) *FieldSchemaHelper {
 	return &FieldSchemaHelper{
 		schema:      schema,
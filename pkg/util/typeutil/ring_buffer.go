@@ -0,0 +1,57 @@
+package typeutil
+
+import "sync"
+
+// NewRingBuffer creates a ring buffer keeping at most capacity elements.
+func NewRingBuffer[T any](capacity int) *RingBuffer[T] {
+	if capacity <= 0 {
+		capacity = 1
+	}
+	return &RingBuffer[T]{
+		elements: make([]T, capacity),
+	}
+}
+
+// RingBuffer is a bounded buffer that overwrites the oldest element when it's full.
+// It's safe for concurrent use.
+type RingBuffer[T any] struct {
+	mu       sync.RWMutex
+	elements []T
+	next     int
+	size     int
+}
+
+// Add appends the element, evicts the oldest one if the buffer is full.
+func (rb *RingBuffer[T]) Add(element T) {
+	rb.mu.Lock()
+	defer rb.mu.Unlock()
+	rb.elements[rb.next] = element
+	rb.next = (rb.next + 1) % len(rb.elements)
+	if rb.size < len(rb.elements) {
+		rb.size++
+	}
+}
+
+// Len returns the number of elements in the buffer.
+func (rb *RingBuffer[T]) Len() int {
+	rb.mu.RLock()
+	defer rb.mu.RUnlock()
+	return rb.size
+}
+
+// Cap returns the capacity of the buffer.
+func (rb *RingBuffer[T]) Cap() int {
+	return len(rb.elements)
+}
+
+// Values returns the elements from the oldest to the newest.
+func (rb *RingBuffer[T]) Values() []T {
+	rb.mu.RLock()
+	defer rb.mu.RUnlock()
+	values := make([]T, 0, rb.size)
+	start := (rb.next - rb.size + len(rb.elements)) % len(rb.elements)
+	for i := 0; i < rb.size; i++ {
+		values = append(values, rb.elements[(start+i)%len(rb.elements)])
+	}
+	return values
+}
@@ -0,0 +1,48 @@
+package typeutil
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRingBuffer(t *testing.T) {
+	rb := NewRingBuffer[int](3)
+	assert.Equal(t, 3, rb.Cap())
+	assert.Equal(t, 0, rb.Len())
+	assert.Empty(t, rb.Values())
+
+	rb.Add(1)
+	rb.Add(2)
+	assert.Equal(t, 2, rb.Len())
+	assert.Equal(t, []int{1, 2}, rb.Values())
+
+	rb.Add(3)
+	rb.Add(4)
+	rb.Add(5)
+	assert.Equal(t, 3, rb.Len())
+	assert.Equal(t, []int{3, 4, 5}, rb.Values())
+
+	rb = NewRingBuffer[int](0)
+	rb.Add(1)
+	rb.Add(2)
+	assert.Equal(t, []int{2}, rb.Values())
+}
+
+func TestRingBufferConcurrent(t *testing.T) {
+	rb := NewRingBuffer[int](10)
+	wg := sync.WaitGroup{}
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				rb.Add(j)
+				rb.Values()
+			}
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, 10, rb.Len())
+}
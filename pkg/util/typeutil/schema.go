@@ -82,7 +82,7 @@ func getVarFieldLength(fieldSchema *schemapb.FieldSchema, policy getVariableFiel
 		default:
 			return 0, fmt.Errorf("unrecognized getVariableFieldLengthPolicy %v", policy)
 		}
-	case schemapb.DataType_Array, schemapb.DataType_JSON:
+	case schemapb.DataType_Array, schemapb.DataType_JSON, schemapb.DataType_Geometry:
 		return DynamicFieldMaxLength, nil
 	default:
 		return 0, fmt.Errorf("field %s is not a variable-length type", fieldSchema.DataType.String())
@@ -114,7 +114,7 @@ func estimateSizeBy(schema *schemapb.CollectionSchema, policy getVariableFieldLe
 			res += 4
 		case schemapb.DataType_Int64, schemapb.DataType_Double:
 			res += 8
-		case schemapb.DataType_VarChar, schemapb.DataType_Array, schemapb.DataType_JSON:
+		case schemapb.DataType_VarChar, schemapb.DataType_Array, schemapb.DataType_JSON, schemapb.DataType_Geometry:
 			maxLengthPerRow, err := getVarFieldLength(fs, policy)
 			if err != nil {
 				return 0, err
@@ -196,6 +196,10 @@ func CalcColumnSize(column *schemapb.FieldData) int {
 		for _, str := range column.GetScalars().GetJsonData().GetData() {
 			res += len(str)
 		}
+	case schemapb.DataType_Geometry:
+		for _, wkb := range column.GetScalars().GetGeometryData().GetData() {
+			res += len(wkb)
+		}
 	default:
 		panic("Unknown data type:" + column.Type.String())
 	}
@@ -233,6 +237,11 @@ func EstimateEntitySize(fieldsData []*schemapb.FieldData, rowOffset int) (int, e
 				return 0, fmt.Errorf("offset out range of field datas")
 			}
 			res += len(fs.GetScalars().GetJsonData().GetData()[rowOffset])
+		case schemapb.DataType_Geometry:
+			if rowOffset >= len(fs.GetScalars().GetGeometryData().GetData()) {
+				return 0, fmt.Errorf("offset out range of field datas")
+			}
+			res += len(fs.GetScalars().GetGeometryData().GetData()[rowOffset])
 		case schemapb.DataType_BinaryVector:
 			res += int(fs.GetVectors().GetDim())
 		case schemapb.DataType_FloatVector:
@@ -525,6 +534,10 @@ func IsArrayType(dataType schemapb.DataType) bool {
 	return dataType == schemapb.DataType_Array
 }
 
+func IsGeometryType(dataType schemapb.DataType) bool {
+	return dataType == schemapb.DataType_Geometry
+}
+
 // IsFloatingType returns true if input is a floating type, otherwise false
 func IsFloatingType(dataType schemapb.DataType) bool {
 	switch dataType {
@@ -637,6 +650,12 @@ func PrepareResultFieldData(sample []*schemapb.FieldData, topK int64) []*schemap
 						Data: make([][]byte, 0, topK),
 					},
 				}
+			case *schemapb.ScalarField_GeometryData:
+				scalar.Scalars.Data = &schemapb.ScalarField_GeometryData{
+					GeometryData: &schemapb.GeometryArray{
+						Data: make([][]byte, 0, topK),
+					},
+				}
 			case *schemapb.ScalarField_ArrayData:
 				scalar.Scalars.Data = &schemapb.ScalarField_ArrayData{
 					ArrayData: &schemapb.ArrayArray{
@@ -808,6 +827,18 @@ func AppendFieldData(dst, src []*schemapb.FieldData, idx int64) (appendSize int6
 				}
 				/* #nosec G103 */
 				appendSize += int64(unsafe.Sizeof(srcScalar.JsonData.Data[idx]))
+			case *schemapb.ScalarField_GeometryData:
+				if dstScalar.GetGeometryData() == nil {
+					dstScalar.Data = &schemapb.ScalarField_GeometryData{
+						GeometryData: &schemapb.GeometryArray{
+							Data: [][]byte{srcScalar.GeometryData.Data[idx]},
+						},
+					}
+				} else {
+					dstScalar.GetGeometryData().Data = append(dstScalar.GetGeometryData().Data, srcScalar.GeometryData.Data[idx])
+				}
+				/* #nosec G103 */
+				appendSize += int64(unsafe.Sizeof(srcScalar.GeometryData.Data[idx]))
 			default:
 				log.Error("Not supported field type", zap.String("field type", fieldData.Type.String()))
 			}
@@ -926,6 +957,8 @@ func DeleteFieldData(dst []*schemapb.FieldData) {
 				dstScalar.GetStringData().Data = dstScalar.GetStringData().Data[:len(dstScalar.GetStringData().Data)-1]
 			case *schemapb.ScalarField_JsonData:
 				dstScalar.GetJsonData().Data = dstScalar.GetJsonData().Data[:len(dstScalar.GetJsonData().Data)-1]
+			case *schemapb.ScalarField_GeometryData:
+				dstScalar.GetGeometryData().Data = dstScalar.GetGeometryData().Data[:len(dstScalar.GetGeometryData().Data)-1]
 			default:
 				log.Error("wrong field type added", zap.String("field type", fieldData.Type.String()))
 			}
@@ -1063,6 +1096,16 @@ func MergeFieldData(dst []*schemapb.FieldData, src []*schemapb.FieldData) error
 				} else {
 					dstScalar.GetJsonData().Data = append(dstScalar.GetJsonData().Data, srcScalar.JsonData.Data...)
 				}
+			case *schemapb.ScalarField_GeometryData:
+				if dstScalar.GetGeometryData() == nil {
+					dstScalar.Data = &schemapb.ScalarField_GeometryData{
+						GeometryData: &schemapb.GeometryArray{
+							Data: srcScalar.GeometryData.Data,
+						},
+					}
+				} else {
+					dstScalar.GetGeometryData().Data = append(dstScalar.GetGeometryData().Data, srcScalar.GeometryData.Data...)
+				}
 			case *schemapb.ScalarField_BytesData:
 				if dstScalar.GetBytesData() == nil {
 					dstScalar.Data = &schemapb.ScalarField_BytesData{
@@ -1204,6 +1247,16 @@ func GetDynamicField(schema *schemapb.CollectionSchema) *schemapb.FieldSchema {
 	return nil
 }
 
+// GetTTLField returns the field declaring the expiration time of rows, nil if there is none.
+func GetTTLField(schema *schemapb.CollectionSchema) *schemapb.FieldSchema {
+	for _, fieldSchema := range schema.GetFields() {
+		if CreateFieldSchemaHelper(fieldSchema).IsTTLField() {
+			return fieldSchema
+		}
+	}
+	return nil
+}
+
 // HasPartitionKey check if a collection schema has PartitionKey field
 func HasPartitionKey(schema *schemapb.CollectionSchema) bool {
 	for _, fieldSchema := range schema.Fields {
@@ -1424,6 +1477,8 @@ func GetData(field *schemapb.FieldData, idx int) interface{} {
 		return field.GetScalars().GetDoubleData().GetData()[idx]
 	case schemapb.DataType_VarChar:
 		return field.GetScalars().GetStringData().GetData()[idx]
+	case schemapb.DataType_Geometry:
+		return field.GetScalars().GetGeometryData().GetData()[idx]
 	case schemapb.DataType_FloatVector:
 		dim := int(field.GetVectors().GetDim())
 		return field.GetVectors().GetFloatVector().GetData()[idx*dim : (idx+1)*dim]
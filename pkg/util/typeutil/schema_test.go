@@ -1317,6 +1317,51 @@ func TestGetClusterKeyFieldSchema(t *testing.T) {
 	assert.False(t, hasClusterKey2)
 }
 
+func TestGetTTLField(t *testing.T) {
+	int64Field := &schemapb.FieldSchema{
+		FieldID:  1,
+		Name:     "int64Field",
+		DataType: schemapb.DataType_Int64,
+	}
+	ttlField := &schemapb.FieldSchema{
+		FieldID:    2,
+		Name:       "expireAt",
+		DataType:   schemapb.DataType_Int64,
+		TypeParams: []*commonpb.KeyValuePair{{Key: common.TTLFieldKey, Value: "true"}},
+	}
+	floatField := &schemapb.FieldSchema{
+		FieldID:    3,
+		Name:       "floatField",
+		DataType:   schemapb.DataType_Float,
+		TypeParams: []*commonpb.KeyValuePair{{Key: common.TTLFieldKey, Value: "true"}},
+	}
+
+	schema := &schemapb.CollectionSchema{
+		Fields: []*schemapb.FieldSchema{int64Field, ttlField},
+	}
+	assert.Equal(t, ttlField, GetTTLField(schema))
+
+	schema = &schemapb.CollectionSchema{
+		Fields: []*schemapb.FieldSchema{int64Field, floatField},
+	}
+	assert.Nil(t, GetTTLField(schema))
+}
+
+func TestFieldSchemaHelper_GetFieldStatsType(t *testing.T) {
+	field := &schemapb.FieldSchema{
+		FieldID:  1,
+		Name:     "tenantID",
+		DataType: schemapb.DataType_VarChar,
+	}
+	_, ok := CreateFieldSchemaHelper(field).GetFieldStatsType()
+	assert.False(t, ok)
+
+	field.TypeParams = []*commonpb.KeyValuePair{{Key: common.FieldStatsKey, Value: common.FieldStatsXor}}
+	bfType, ok := CreateFieldSchemaHelper(field).GetFieldStatsType()
+	assert.True(t, ok)
+	assert.Equal(t, common.FieldStatsXor, bfType)
+}
+
 func TestGetPK(t *testing.T) {
 	type args struct {
 		data *schemapb.IDs